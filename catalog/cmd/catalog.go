@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -14,17 +15,30 @@ import (
 	"github.com/kubeflow/model-registry/catalog/internal/server/openapi"
 	"github.com/kubeflow/model-registry/internal/datastore"
 	"github.com/kubeflow/model-registry/internal/datastore/embedmd"
+	"github.com/kubeflow/model-registry/internal/db"
+	"github.com/kubeflow/model-registry/internal/leaderelection"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 )
 
 var catalogCfg = struct {
-	ListenAddress          string
-	ConfigPath             []string
-	PerformanceMetricsPath []string
+	ListenAddress           string
+	ConfigPath              []string
+	PerformanceMetricsPath  []string
+	LeaderElection          string
+	LeaderElectionID        string
+	LeaderElectionNamespace string
+	Enrichers               []string
+	EnrichmentWorkers       int
+	AccessPoliciesPath      string
 }{
 	ListenAddress:          "0.0.0.0:8080",
 	ConfigPath:             []string{"sources.yaml"},
 	PerformanceMetricsPath: []string{},
+	LeaderElection:         "none",
+	LeaderElectionID:       "model-catalog-sync",
+	Enrichers:              []string{},
+	EnrichmentWorkers:      catalog.DefaultEnrichmentWorkers,
 }
 
 var CatalogCmd = &cobra.Command{
@@ -42,6 +56,12 @@ func init() {
 	fs.StringVarP(&catalogCfg.ListenAddress, "listen", "l", catalogCfg.ListenAddress, "Address to listen on")
 	fs.StringSliceVar(&catalogCfg.ConfigPath, "catalogs-path", catalogCfg.ConfigPath, "Path to catalog source configuration file")
 	fs.StringSliceVar(&catalogCfg.PerformanceMetricsPath, "performance-metrics", catalogCfg.PerformanceMetricsPath, "Path to performance metrics data directory")
+	fs.StringVar(&catalogCfg.LeaderElection, "leader-election", catalogCfg.LeaderElection, "Leader election used to run the catalog sync on a single replica: none, lease or database")
+	fs.StringVar(&catalogCfg.LeaderElectionID, "leader-election-id", catalogCfg.LeaderElectionID, "Name of the lease or database lock used for leader election")
+	fs.StringVar(&catalogCfg.LeaderElectionNamespace, "leader-election-namespace", catalogCfg.LeaderElectionNamespace, "Namespace of the leader election lease, defaults to the pod namespace")
+	fs.StringSliceVar(&catalogCfg.Enrichers, "enrichers", catalogCfg.Enrichers, fmt.Sprintf("Enrichers to run on the synced models, in order: %s", strings.Join(catalog.RegisteredEnrichers(), ", ")))
+	fs.IntVar(&catalogCfg.EnrichmentWorkers, "enrichment-workers", catalogCfg.EnrichmentWorkers, "Number of models enriched concurrently")
+	fs.StringVar(&catalogCfg.AccessPoliciesPath, "access-policies-path", catalogCfg.AccessPoliciesPath, fmt.Sprintf("Path to the access policies restricting the sources and models visible to namespaces, identified by the %s request header", catalog.NamespaceHeader))
 }
 
 func runCatalogServer(cmd *cobra.Command, args []string) error {
@@ -81,21 +101,75 @@ func runCatalogServer(cmd *cobra.Command, args []string) error {
 		return nil
 	})
 
+	enrichment, err := catalog.NewEnrichmentPipeline(services.CatalogModelRepository, catalogCfg.Enrichers, catalogCfg.EnrichmentWorkers)
+	if err != nil {
+		return fmt.Errorf("error initializing catalog enrichment: %v", err)
+	}
+	enrichment.Start(context.Background())
+	loader.RegisterEventHandler(enrichment.Enqueue)
+
+	elector, err := newElector()
+	if err != nil {
+		return fmt.Errorf("error initializing leader election: %w", err)
+	}
+	if elector != nil {
+		loader.UseLeaderElection()
+	}
+
 	err = loader.Start(context.Background())
 	if err != nil {
 		return fmt.Errorf("error loading catalog sources: %v", err)
 	}
 
+	if elector != nil {
+		go elector.Run(context.Background(), loader.Lead)
+	}
+
+	var policies *catalog.AccessPolicies
+	if catalogCfg.AccessPoliciesPath != "" {
+		policies, err = catalog.LoadAccessPolicies(catalogCfg.AccessPoliciesPath)
+		if err != nil {
+			return fmt.Errorf("error loading access policies: %w", err)
+		}
+	}
+
+	provider := catalog.NewDBCatalog(services, loader.Sources)
 	svc := openapi.NewModelCatalogServiceAPIService(
-		catalog.NewDBCatalog(services, loader.Sources),
+		provider,
 		loader.Sources,
 		loader.Labels,
 		services.CatalogSourceRepository,
+		policies,
 	)
 	ctrl := openapi.NewModelCatalogServiceAPIController(svc)
+	facetsCtrl := openapi.NewModelCatalogFacetsAPIController(provider, loader.Sources)
+	enrichersCtrl := openapi.NewModelCatalogEnrichersAPIController(enrichment)
+	sourceConnectionCtrl := openapi.NewModelCatalogSourceConnectionAPIController(loader.Sources)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/", catalog.NamespaceMiddleware(openapi.NewRouter(ctrl, facetsCtrl, enrichersCtrl, sourceConnectionCtrl)))
 
 	glog.Infof("Catalog API server listening on %s", catalogCfg.ListenAddress)
-	return http.ListenAndServe(catalogCfg.ListenAddress, openapi.NewRouter(ctrl))
+	return http.ListenAndServe(catalogCfg.ListenAddress, mux)
+}
+
+// newElector returns the configured leader election, or nil if every replica should sync the catalog.
+func newElector() (leaderelection.Elector, error) {
+	switch catalogCfg.LeaderElection {
+	case "", "none":
+		return nil, nil
+	case "lease":
+		return leaderelection.NewLeaseElector(catalogCfg.LeaderElectionID, catalogCfg.LeaderElectionNamespace)
+	case "database":
+		connector, ok := db.GetConnector()
+		if !ok {
+			return nil, fmt.Errorf("database connector not initialized")
+		}
+		return leaderelection.NewDatabaseElector(connector.DB(), catalogCfg.LeaderElectionID)
+	default:
+		return nil, fmt.Errorf("unsupported leader election type %q, must be one of none, lease or database", catalogCfg.LeaderElection)
+	}
 }
 
 func getRepo[T any](repoSet datastore.RepoSet) T {
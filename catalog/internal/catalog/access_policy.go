@@ -0,0 +1,176 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	model "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// NamespaceHeader is the header of the catalog requests carrying the namespace of the caller, set by the gateway in
+// front of the catalog.
+const NamespaceHeader = "Kubeflow-Namespace"
+
+// AccessPolicy restricts the sources and models it selects to the namespaces allowed to use them.
+type AccessPolicy struct {
+	// Name of the policy, used in errors.
+	Name string `json:"name"`
+
+	// Namespaces allowed to see the selected sources and models, '*' matches any characters.
+	Namespaces []string `json:"namespaces"`
+
+	// Sources selects the sources with these ids.
+	Sources []string `json:"sources,omitempty"`
+
+	// SourceLabels selects the sources with any of these labels.
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+
+	// Licenses selects the models with these licenses, in the selected sources if Sources or SourceLabels is set. The
+	// sources themselves remain visible to every namespace.
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// accessPolicyConfig is the structure of the access policies YAML file.
+type accessPolicyConfig struct {
+	Policies []AccessPolicy `json:"policies"`
+}
+
+type accessRule struct {
+	policy     AccessPolicy
+	namespaces []*compiledPattern
+}
+
+// AccessPolicies decides which sources and models of the catalog a namespace can see. A source or model selected by
+// several policies is visible to the namespaces allowed by all of them, the ones no policy selects are visible to
+// every namespace. A nil AccessPolicies allows everything.
+type AccessPolicies struct {
+	rules []accessRule
+}
+
+// LoadAccessPolicies reads the access policies of the YAML file at path.
+func LoadAccessPolicies(path string) (*AccessPolicies, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading access policies: %w", err)
+	}
+
+	var config accessPolicyConfig
+	if err = yaml.UnmarshalStrict(bytes, &config); err != nil {
+		return nil, fmt.Errorf("error parsing access policies %s: %w", path, err)
+	}
+
+	return NewAccessPolicies(config.Policies)
+}
+
+// NewAccessPolicies validates policies and builds the AccessPolicies enforcing them.
+func NewAccessPolicies(policies []AccessPolicy) (*AccessPolicies, error) {
+	rules := make([]accessRule, 0, len(policies))
+	for i, policy := range policies {
+		if policy.Name == "" {
+			return nil, fmt.Errorf("policies[%d]: name is required", i)
+		}
+		if len(policy.Sources) == 0 && len(policy.SourceLabels) == 0 && len(policy.Licenses) == 0 {
+			return nil, fmt.Errorf("policy %s: at least one of sources, sourceLabels or licenses is required", policy.Name)
+		}
+		namespaces, err := compilePatterns("namespaces", policy.Namespaces)
+		if err != nil {
+			return nil, fmt.Errorf("policy %s: %w", policy.Name, err)
+		}
+		rules = append(rules, accessRule{policy: policy, namespaces: namespaces})
+	}
+
+	return &AccessPolicies{rules: rules}, nil
+}
+
+// AllowsSource returns true if the namespace can see the source. The models of a visible source may still be hidden
+// by their license, see AllowsModel.
+func (p *AccessPolicies) AllowsSource(namespace string, source model.CatalogSource) bool {
+	if p == nil {
+		return true
+	}
+
+	for _, rule := range p.rules {
+		if len(rule.policy.Licenses) == 0 && rule.selectsSource(source) && !rule.allows(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsModel returns true if the namespace can see a model of the source with the license.
+func (p *AccessPolicies) AllowsModel(namespace string, source model.CatalogSource, license string) bool {
+	if p == nil {
+		return true
+	}
+
+	for _, rule := range p.rules {
+		if rule.selectsSource(source) && rule.selectsLicense(license) && !rule.allows(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *accessRule) selectsSource(source model.CatalogSource) bool {
+	if len(r.policy.Sources) == 0 && len(r.policy.SourceLabels) == 0 {
+		return true
+	}
+	if slices.Contains(r.policy.Sources, source.Id) {
+		return true
+	}
+	for _, label := range source.Labels {
+		if slices.Contains(r.policy.SourceLabels, label) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *accessRule) selectsLicense(license string) bool {
+	if len(r.policy.Licenses) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.policy.Licenses, func(l string) bool {
+		return strings.EqualFold(l, license)
+	})
+}
+
+func (r *accessRule) allows(namespace string) bool {
+	if namespace == "" {
+		return false
+	}
+	for _, pattern := range r.namespaces {
+		if pattern.re.MatchString(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+type namespaceKey struct{}
+
+// WithNamespace returns a copy of ctx carrying the namespace of the caller.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// NamespaceFromContext returns the namespace of the caller carried by ctx, empty if unknown.
+func NamespaceFromContext(ctx context.Context) string {
+	namespace, _ := ctx.Value(namespaceKey{}).(string)
+	return namespace
+}
+
+// NamespaceMiddleware adds the namespace of the NamespaceHeader of the requests to their context.
+func NamespaceMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if namespace := strings.TrimSpace(r.Header.Get(NamespaceHeader)); namespace != "" {
+			r = r.WithContext(WithNamespace(r.Context(), namespace))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
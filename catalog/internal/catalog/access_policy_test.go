@@ -0,0 +1,72 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apimodels "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+policies:
+- name: internal-models
+  sourceLabels: [internal]
+  namespaces: [platform]
+- name: llama-license
+  licenses: [llama3.1]
+  namespaces: [research, "ml-*"]
+`), 0o600))
+	policies, err := LoadAccessPolicies(path)
+	require.NoError(t, err)
+
+	public := apimodels.CatalogSource{Id: "hf", Labels: []string{"public"}}
+	internal := apimodels.CatalogSource{Id: "models", Labels: []string{"internal"}}
+
+	assert.True(t, policies.AllowsSource("research", public))
+	assert.False(t, policies.AllowsSource("research", internal))
+	assert.True(t, policies.AllowsSource("platform", internal))
+	assert.False(t, policies.AllowsSource("", internal), "the restricted sources are hidden from unknown namespaces")
+
+	assert.True(t, policies.AllowsModel("", public, "apache-2.0"))
+	assert.True(t, policies.AllowsModel("ml-training", public, "Llama3.1"))
+	assert.False(t, policies.AllowsModel("platform", public, "llama3.1"))
+	assert.False(t, policies.AllowsModel("research", internal, "apache-2.0"))
+
+	var none *AccessPolicies
+	assert.True(t, none.AllowsModel("", internal, "llama3.1"))
+}
+
+func TestAccessPoliciesValidation(t *testing.T) {
+	_, err := NewAccessPolicies([]AccessPolicy{{Namespaces: []string{"team"}, Licenses: []string{"mit"}}})
+	assert.ErrorContains(t, err, "name is required")
+
+	_, err = NewAccessPolicies([]AccessPolicy{{Name: "everything", Namespaces: []string{"team"}}})
+	assert.ErrorContains(t, err, "at least one of sources, sourceLabels or licenses is required")
+
+	_, err = NewAccessPolicies([]AccessPolicy{{Name: "blank", Namespaces: []string{" "}, Sources: []string{"hf"}}})
+	assert.ErrorContains(t, err, "pattern cannot be empty")
+}
+
+func TestNamespaceMiddleware(t *testing.T) {
+	var namespace string
+	handler := NamespaceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace = NamespaceFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/model_catalog/v1alpha1/models", nil)
+	req.Header.Set(NamespaceHeader, "research")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "research", namespace)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/model_catalog/v1alpha1/models", nil))
+	assert.Empty(t, namespace)
+	assert.Empty(t, NamespaceFromContext(context.Background()))
+}
@@ -73,4 +73,9 @@ type APIProvider interface {
 	// GetFilterOptions returns all available filter options for models.
 	// This includes field names, data types, and available values or ranges.
 	GetFilterOptions(ctx context.Context) (*model.FilterOptionsList, error)
+
+	// GetModelFacets returns the number of models matching params for each
+	// provider, task, license and size bucket. The counts are cached and can
+	// lag behind catalog updates by up to DefaultFacetsTTL.
+	GetModelFacets(ctx context.Context, params ListModelFacetsParams) (*ModelFacets, error)
 }
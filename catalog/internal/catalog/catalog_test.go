@@ -733,6 +733,28 @@ func (m *MockCatalogModelRepository) GetDistinctSourceIDs() ([]string, error) {
 	return []string{}, nil
 }
 
+func (m *MockCatalogModelRepository) CountPropertyValues(listOptions dbmodels.CatalogModelListOptions, name string, isCustomProperty bool) (map[string]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// Mock implementation - list filters are ignored
+	counts := map[string]int64{}
+	for _, model := range m.SavedModels {
+		props := model.GetProperties()
+		if isCustomProperty {
+			props = model.GetCustomProperties()
+		}
+		if props == nil {
+			continue
+		}
+		for _, prop := range *props {
+			if prop.Name == name && prop.StringValue != nil {
+				counts[*prop.StringValue]++
+			}
+		}
+	}
+	return counts, nil
+}
+
 // GetSavedModels returns a copy of the saved models slice in a thread-safe manner.
 // This should be used by tests instead of directly accessing SavedModels field.
 func (m *MockCatalogModelRepository) GetSavedModels() []dbmodels.CatalogModel {
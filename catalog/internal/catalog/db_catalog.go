@@ -27,6 +27,7 @@ type dbCatalogImpl struct {
 	propertyOptionsRepository dbmodels.PropertyOptionsRepository
 	performanceService        *dbmodels.PerformanceArtifactService
 	sources                   *SourceCollection
+	facets                    *facetCache
 }
 
 func NewDBCatalog(services service.Services, sources *SourceCollection) APIProvider {
@@ -36,6 +37,7 @@ func NewDBCatalog(services service.Services, sources *SourceCollection) APIProvi
 		propertyOptionsRepository: services.PropertyOptionsRepository,
 		performanceService:        dbmodels.NewPerformanceArtifactService(services.CatalogArtifactRepository, services.CatalogModelRepository),
 		sources:                   sources,
+		facets:                    newFacetCache(DefaultFacetsTTL),
 	}
 }
 
@@ -80,7 +80,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			name:        "Custom property string filter",
 			filterQuery: `framework.string_value = "PyTorch"`,
 			expectedSQL: []string{
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				"prop_1",
 				`prop_1.context_id = "Context".id`,
@@ -88,13 +88,13 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 				`prop_1.string_value = $`,
 			},
 			expectedArgs: []any{"framework", "PyTorch"},
-			description:  "Custom properties should require JOIN with property table using PostgreSQL syntax",
+			description:  "Custom properties should use an EXISTS subquery on the property table using PostgreSQL syntax",
 		},
 		{
 			name:        "Custom property with type inference",
 			filterQuery: `accuracy > 0.95`,
 			expectedSQL: []string{
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				"prop_1",
 				`prop_1.context_id = "Context".id`,
@@ -126,7 +126,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			name:        "IN clause with multiple values",
 			filterQuery: `license IN ('MIT','Apache-2.0','GPL')`,
 			expectedSQL: []string{
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				"prop_1",
 				`prop_1.name = $`,
@@ -139,7 +139,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			name:        "Multiple filters with AND",
 			filterQuery: `provider.string_value = "HuggingFace" AND framework.string_value = "PyTorch"`,
 			expectedSQL: []string{
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				"prop_1",
 				`prop_1.name = $`,
@@ -149,7 +149,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 				`prop_2.string_value = $`,
 			},
 			expectedArgs: []any{"provider", "HuggingFace", "framework", "PyTorch"},
-			description:  "Multiple custom properties should create separate JOINs",
+			description:  "Multiple custom properties should create separate EXISTS subqueries",
 		},
 		{
 			name:        "OR condition with parentheses",
@@ -157,9 +157,10 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			expectedSQL: []string{
 				"EXISTS",
 				`"ContextProperty"`,
-				`"ContextProperty".context_id = "Context".id`,
-				`"ContextProperty".name = $`,
-				`"ContextProperty".string_value`,
+				`prop_1.context_id = "Context".id`,
+				`prop_1.name = $`,
+				`prop_1.string_value = $`,
+				`prop_2.string_value = $`,
 				"OR",
 			},
 			expectedArgs: []any{"framework", "PyTorch", "framework", "TensorFlow"},
@@ -171,9 +172,9 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			expectedSQL: []string{
 				"EXISTS",
 				`"ContextProperty"`,
-				`"ContextProperty".context_id = "Context".id`,
-				`"ContextProperty".name = $`,
-				`"ContextProperty".string_value LIKE`,
+				`prop_1.context_id = "Context".id`,
+				`prop_1.name = $`,
+				`prop_1.string_value LIKE`,
 				"OR",
 			},
 			expectedArgs: []any{"language", `%"en"%`, "language", `%"it"%`},
@@ -184,14 +185,14 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			filterQuery: `name = "test-model" AND accuracy.double_value > 0.9`,
 			expectedSQL: []string{
 				`"Context".name = $`,
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				"prop_1",
 				`prop_1.name = $`,
 				`prop_1.double_value > $`,
 			},
 			expectedArgs: []any{"test-model", "accuracy", 0.9},
-			description:  "Mixed property types should combine direct and JOIN conditions",
+			description:  "Mixed property types should combine direct and EXISTS conditions",
 		},
 		{
 			name:        "Numeric comparisons",
@@ -216,7 +217,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			name:        "String with quotes and special characters",
 			filterQuery: `description = "Model's \"best\" version (v1.0)"`,
 			expectedSQL: []string{
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 				`prop_1.string_value = $`,
 			},
@@ -261,7 +262,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 				"OR",
 				"EXISTS",
 				`"ContextProperty"`,
-				`"ContextProperty".string_value = $`,
+				`prop_2.string_value = $`,
 				`"Attribution"`,
 				`"Artifact"`,
 				`"ArtifactProperty"`,
@@ -277,7 +278,7 @@ func TestFilterQueryToSQLGeneration(t *testing.T) {
 			expectedSQL: []string{
 				"EXISTS",
 				`"ContextProperty"`,
-				`"ContextProperty".string_value LIKE`,
+				`prop_1.string_value LIKE`,
 				"OR",
 				`"Attribution"`,
 				`"Artifact"`,
@@ -545,17 +546,16 @@ func TestComplexFilterQueryGeneration(t *testing.T) {
 		description    string
 	}{
 		{
-			name:          "Multiple custom properties with AND",
-			filterQuery:   `framework.string_value = "PyTorch" AND license.string_value = "MIT" AND provider.string_value = "HuggingFace"`,
-			expectedJoins: 3,
-			description:   "Each custom property should create a separate JOIN",
+			name:           "Multiple custom properties with AND",
+			filterQuery:    `framework.string_value = "PyTorch" AND license.string_value = "MIT" AND provider.string_value = "HuggingFace"`,
+			expectedExists: 3,
+			description:    "Each custom property should create a separate EXISTS subquery",
 		},
 		{
 			name:           "Complex OR with parentheses",
 			filterQuery:    `(framework.string_value = "PyTorch" OR framework.string_value = "TensorFlow") AND license.string_value = "MIT"`,
-			expectedExists: 2, // OR condition uses 2 EXISTS (one for each OR branch)
-			expectedJoins:  1, // AND condition uses JOIN
-			description:    "OR conditions should use EXISTS, AND conditions use JOINs",
+			expectedExists: 3, // one EXISTS for each OR branch and one for the AND condition
+			description:    "OR and AND conditions should both use EXISTS subqueries",
 		},
 		{
 			name:        "Nested logical conditions",
@@ -1020,7 +1020,7 @@ func TestArtifactFilteringEdgeCases(t *testing.T) {
 			expectedSQL: []string{
 				"EXISTS",
 				`"ContextProperty"`,
-				`"ContextProperty".string_value = $`,
+				`prop_2.string_value = $`,
 				"OR",
 				"EXISTS",
 				`"Attribution"`,
@@ -1028,7 +1028,7 @@ func TestArtifactFilteringEdgeCases(t *testing.T) {
 				`"ArtifactProperty"`,
 				"artprop_",
 				".string_value = $",
-				"JOIN",
+				"EXISTS",
 				`"ContextProperty"`,
 			},
 			description: "Complex OR with custom and artifact properties should properly qualify all columns with aliases",
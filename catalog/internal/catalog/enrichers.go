@@ -0,0 +1,130 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// modelCardFields are the model card metadata fields copied to the custom
+// properties by the model card enricher, as model_card_<field>.
+var modelCardFields = []string{"base_model", "datasets", "library_name", "metrics", "pipeline_tag", "tags"}
+
+// restrictedLicensePrefixes are the licenses restricting the use of a model,
+// e.g. to non-commercial use or to an acceptable use policy.
+var restrictedLicensePrefixes = []string{"bigscience-", "creativeml-openrail", "gemma", "llama", "openrail", "other"}
+
+// enrichModelCard copies the metadata from the YAML front matter of the model README.
+func enrichModelCard(ctx context.Context, model dbmodels.CatalogModel) ([]mrmodels.Properties, error) {
+	readme, ok := stringProperty(model.GetProperties(), "readme")
+	if !ok {
+		return nil, nil
+	}
+
+	frontMatter, ok := readmeFrontMatter(readme)
+	if !ok {
+		return nil, nil
+	}
+
+	metadata := map[string]any{}
+	if err := yaml.Unmarshal([]byte(frontMatter), &metadata); err != nil {
+		return nil, fmt.Errorf("invalid model card metadata: %w", err)
+	}
+
+	var properties []mrmodels.Properties
+	for _, field := range modelCardFields {
+		var value string
+		switch v := metadata[field].(type) {
+		case string:
+			value = v
+		case []any:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid model card field %s: %w", field, err)
+			}
+			value = string(encoded)
+		default:
+			continue
+		}
+
+		if value != "" {
+			properties = append(properties, mrmodels.NewStringProperty("model_card_"+field, value, true))
+		}
+	}
+
+	return properties, nil
+}
+
+// readmeFrontMatter returns the YAML block delimited by --- lines at the start of a README.
+func readmeFrontMatter(readme string) (string, bool) {
+	readme = strings.TrimLeft(readme, "\ufeff\r\n")
+	if !strings.HasPrefix(readme, "---\n") && !strings.HasPrefix(readme, "---\r\n") {
+		return "", false
+	}
+
+	_, body, _ := strings.Cut(readme, "\n")
+	for offset := 0; offset < len(body); {
+		line, _, _ := strings.Cut(body[offset:], "\n")
+		if strings.TrimRight(line, "\r") == "---" {
+			return body[:offset], true
+		}
+		offset += len(line) + 1
+	}
+
+	return "", false
+}
+
+// enrichLicenseRestriction flags gated models and models under a restrictive license.
+func enrichLicenseRestriction(ctx context.Context, model dbmodels.CatalogModel) ([]mrmodels.Properties, error) {
+	restricted := false
+	if license, ok := stringProperty(model.GetProperties(), "license"); ok {
+		license = strings.ToLower(license)
+		for _, prefix := range restrictedLicensePrefixes {
+			if strings.HasPrefix(license, prefix) {
+				restricted = true
+				break
+			}
+		}
+		restricted = restricted || strings.Contains(license, "-nc")
+	}
+
+	// Hugging Face reports gated models as "auto" or "manual" approval
+	gated := false
+	if value, ok := stringProperty(model.GetCustomProperties(), "hf_gated"); ok {
+		gated = value != "" && value != "false"
+	}
+
+	return []mrmodels.Properties{
+		mrmodels.NewStringProperty("license_restricted", strconv.FormatBool(restricted), true),
+		mrmodels.NewStringProperty("gated", strconv.FormatBool(gated), true),
+	}, nil
+}
+
+func stringProperty(properties *[]mrmodels.Properties, name string) (string, bool) {
+	if properties == nil {
+		return "", false
+	}
+
+	for _, prop := range *properties {
+		if prop.Name == name && prop.StringValue != nil {
+			return *prop.StringValue, true
+		}
+	}
+
+	return "", false
+}
+
+func init() {
+	if err := RegisterEnricher("model-card", enrichModelCard); err != nil {
+		panic(err)
+	}
+	if err := RegisterEnricher("license-restriction", enrichLicenseRestriction); err != nil {
+		panic(err)
+	}
+}
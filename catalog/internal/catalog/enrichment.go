@@ -0,0 +1,294 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+)
+
+// Enrichment status constants, stored on every enriched model for each enricher
+const (
+	EnrichmentStatusSucceeded = "succeeded"
+	EnrichmentStatusFailed    = "failed"
+)
+
+// DefaultEnrichmentWorkers is the number of models enriched concurrently.
+const DefaultEnrichmentWorkers = 2
+
+// enrichmentQueueSize bounds the number of models waiting for enrichment, the
+// loader blocks once it is reached.
+const enrichmentQueueSize = 1024
+
+// EnricherFunc annotates a catalog model after it was synced from its source and
+// returns the custom properties to set on the model. Properties already set by
+// the source or by another enricher are replaced when they have the same name.
+type EnricherFunc func(ctx context.Context, model dbmodels.CatalogModel) ([]mrmodels.Properties, error)
+
+var registeredEnrichers = map[string]EnricherFunc{}
+
+func RegisterEnricher(name string, callback EnricherFunc) error {
+	if _, exists := registeredEnrichers[name]; exists {
+		return fmt.Errorf("enricher %s already exists", name)
+	}
+	registeredEnrichers[name] = callback
+	return nil
+}
+
+// RegisteredEnrichers returns the names of all the registered enrichers.
+func RegisteredEnrichers() []string {
+	names := make([]string, 0, len(registeredEnrichers))
+	for name := range registeredEnrichers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnrichmentStatusProperty is the custom property holding the status of an enricher on a model.
+func EnrichmentStatusProperty(enricher string) string {
+	return "enrichment." + enricher + ".status"
+}
+
+// EnrichmentErrorProperty is the custom property holding the last error of an enricher on a model.
+func EnrichmentErrorProperty(enricher string) string {
+	return "enrichment." + enricher + ".error"
+}
+
+// EnricherStatus counts the enrichment jobs of one enricher since the pipeline started.
+type EnricherStatus struct {
+	Name        string     `json:"name"`
+	Pending     int64      `json:"pending"`
+	Succeeded   int64      `json:"succeeded"`
+	Failed      int64      `json:"failed"`
+	LastError   string     `json:"lastError,omitempty"`
+	LastRunTime *time.Time `json:"lastRunTime,omitempty"`
+}
+
+type namedEnricher struct {
+	name string
+	fn   EnricherFunc
+}
+
+// EnrichmentPipeline runs the configured enrichers on the models processed by
+// the loader, in background workers so that enrichers calling slow external
+// services do not delay the sync.
+type EnrichmentPipeline struct {
+	modelRepo dbmodels.CatalogModelRepository
+	enrichers []namedEnricher
+	workers   int
+	queue     chan string
+
+	mu      sync.Mutex
+	pending map[string]struct{}
+	status  map[string]*EnricherStatus
+}
+
+// NewEnrichmentPipeline returns a pipeline running the named enrichers in the
+// given order, or nil if no enricher is configured.
+func NewEnrichmentPipeline(modelRepo dbmodels.CatalogModelRepository, names []string, workers int) (*EnrichmentPipeline, error) {
+	if len(names) == 0 {
+		glog.Info("No catalog enrichers configured, skipping model enrichment")
+		return nil, nil
+	}
+
+	if workers <= 0 {
+		workers = DefaultEnrichmentWorkers
+	}
+
+	p := &EnrichmentPipeline{
+		modelRepo: modelRepo,
+		workers:   workers,
+		queue:     make(chan string, enrichmentQueueSize),
+		pending:   map[string]struct{}{},
+		status:    map[string]*EnricherStatus{},
+	}
+
+	for _, name := range names {
+		fn, ok := registeredEnrichers[name]
+		if !ok {
+			return nil, fmt.Errorf("enricher %s not registered, must be one of %v", name, RegisteredEnrichers())
+		}
+		if _, exists := p.status[name]; exists {
+			return nil, fmt.Errorf("duplicate enricher %s", name)
+		}
+		p.enrichers = append(p.enrichers, namedEnricher{name: name, fn: fn})
+		p.status[name] = &EnricherStatus{Name: name}
+	}
+
+	return p, nil
+}
+
+// Start runs the enrichment workers until the context is canceled.
+func (p *EnrichmentPipeline) Start(ctx context.Context) {
+	if p == nil {
+		return
+	}
+
+	for range p.workers {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case name := <-p.queue:
+					p.enrich(ctx, name)
+				}
+			}
+		}()
+	}
+}
+
+// Enqueue schedules the enrichment of a loaded model, it is meant to be
+// registered as a LoaderEventHandler. A model already waiting for enrichment
+// is not scheduled twice.
+func (p *EnrichmentPipeline) Enqueue(ctx context.Context, record ModelProviderRecord) error {
+	if p == nil || record.Model == nil {
+		return nil
+	}
+
+	attrs := record.Model.GetAttributes()
+	if attrs == nil || attrs.Name == nil {
+		return nil
+	}
+	name := *attrs.Name
+
+	p.mu.Lock()
+	if _, exists := p.pending[name]; exists {
+		p.mu.Unlock()
+		return nil
+	}
+	p.pending[name] = struct{}{}
+	for _, status := range p.status {
+		status.Pending++
+	}
+	p.mu.Unlock()
+
+	select {
+	case p.queue <- name:
+		return nil
+	case <-ctx.Done():
+		p.done(name, nil)
+		return ctx.Err()
+	}
+}
+
+// Status returns the status of every configured enricher, in the order they run.
+func (p *EnrichmentPipeline) Status() []EnricherStatus {
+	if p == nil {
+		return []EnricherStatus{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	statuses := make([]EnricherStatus, 0, len(p.enrichers))
+	for _, enricher := range p.enrichers {
+		statuses = append(statuses, *p.status[enricher.name])
+	}
+	return statuses
+}
+
+// enrich runs every enricher on a model and saves their properties and status
+// on the model at once.
+func (p *EnrichmentPipeline) enrich(ctx context.Context, name string) {
+	model, err := p.modelRepo.GetByName(name)
+	if err != nil {
+		glog.Errorf("%s: unable to load model for enrichment: %v", name, err)
+		p.done(name, map[string]error{"": err})
+		return
+	}
+
+	properties := []mrmodels.Properties{}
+	if model.GetCustomProperties() != nil {
+		properties = append(properties, *model.GetCustomProperties()...)
+	}
+
+	results := make(map[string]error, len(p.enrichers))
+	for _, enricher := range p.enrichers {
+		enriched, err := enricher.fn(ctx, model)
+		results[enricher.name] = err
+
+		if err != nil {
+			glog.Warningf("%s: enricher %s failed: %v", name, enricher.name, err)
+			properties = setCustomProperty(properties, EnrichmentStatusProperty(enricher.name), EnrichmentStatusFailed)
+			properties = setCustomProperty(properties, EnrichmentErrorProperty(enricher.name), err.Error())
+			continue
+		}
+
+		for _, prop := range enriched {
+			prop.IsCustomProperty = true
+			properties = slices.DeleteFunc(properties, func(existing mrmodels.Properties) bool {
+				return existing.Name == prop.Name
+			})
+			properties = append(properties, prop)
+		}
+		properties = setCustomProperty(properties, EnrichmentStatusProperty(enricher.name), EnrichmentStatusSucceeded)
+		properties = slices.DeleteFunc(properties, func(existing mrmodels.Properties) bool {
+			return existing.Name == EnrichmentErrorProperty(enricher.name)
+		})
+	}
+
+	modelImpl, ok := model.(*dbmodels.CatalogModelImpl)
+	if !ok {
+		err := fmt.Errorf("unsupported model type %T", model)
+		glog.Errorf("%s: unable to enrich model: %v", name, err)
+		p.done(name, map[string]error{"": err})
+		return
+	}
+	modelImpl.CustomProperties = &properties
+
+	if _, err := p.modelRepo.Save(model); err != nil {
+		glog.Errorf("%s: unable to save enriched model: %v", name, err)
+		p.done(name, map[string]error{"": err})
+		return
+	}
+
+	glog.V(2).Infof("Enriched model %s with %d enricher(s)", name, len(p.enrichers))
+	p.done(name, results)
+}
+
+// done updates the enricher status once a model enrichment ended, results maps
+// the enricher names to their error. An error under the empty name applies to
+// every enricher, a nil map means the model was not enriched at all.
+func (p *EnrichmentPipeline) done(name string, results map[string]error) {
+	now := time.Now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.pending, name)
+	for enricher, status := range p.status {
+		status.Pending--
+		if results == nil {
+			continue
+		}
+
+		err, ok := results[enricher]
+		if !ok {
+			err = results[""]
+		}
+
+		status.LastRunTime = &now
+		if err != nil {
+			status.Failed++
+			status.LastError = fmt.Sprintf("%s: %v", name, err)
+		} else {
+			status.Succeeded++
+		}
+	}
+}
+
+// setCustomProperty sets a string custom property, replacing any property with the same name.
+func setCustomProperty(properties []mrmodels.Properties, name string, value string) []mrmodels.Properties {
+	properties = slices.DeleteFunc(properties, func(existing mrmodels.Properties) bool {
+		return existing.Name == name
+	})
+	return append(properties, mrmodels.NewStringProperty(name, value, true))
+}
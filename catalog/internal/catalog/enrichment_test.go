@@ -0,0 +1,156 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnrichmentPipeline(t *testing.T) {
+	t.Run("no enrichers", func(t *testing.T) {
+		pipeline, err := NewEnrichmentPipeline(&MockCatalogModelRepository{}, nil, 0)
+		require.NoError(t, err)
+		assert.Nil(t, pipeline)
+		assert.Empty(t, pipeline.Status())
+		assert.NoError(t, pipeline.Enqueue(context.Background(), ModelProviderRecord{}))
+	})
+
+	t.Run("unknown enricher", func(t *testing.T) {
+		_, err := NewEnrichmentPipeline(&MockCatalogModelRepository{}, []string{"missing"}, 0)
+		assert.ErrorContains(t, err, "enricher missing not registered")
+	})
+
+	t.Run("duplicate enricher", func(t *testing.T) {
+		_, err := NewEnrichmentPipeline(&MockCatalogModelRepository{}, []string{"model-card", "model-card"}, 0)
+		assert.ErrorContains(t, err, "duplicate enricher model-card")
+	})
+}
+
+func TestEnrichmentPipeline(t *testing.T) {
+	registeredEnrichers["test-failing"] = func(ctx context.Context, model dbmodels.CatalogModel) ([]mrmodels.Properties, error) {
+		return nil, errors.New("source unavailable")
+	}
+	defer delete(registeredEnrichers, "test-failing")
+
+	modelName := "granite-8b"
+	readme := "---\nbase_model: ibm-granite/granite-8b-base\ntags:\n  - granite\n  - code\n---\n# Granite\n"
+	modelRepo := &MockCatalogModelRepository{
+		SavedModels: []dbmodels.CatalogModel{
+			&dbmodels.CatalogModelImpl{
+				Attributes: &dbmodels.CatalogModelAttributes{Name: &modelName},
+				Properties: &[]mrmodels.Properties{
+					mrmodels.NewStringProperty("readme", readme, false),
+					mrmodels.NewStringProperty("license", "llama3.1", false),
+				},
+				CustomProperties: &[]mrmodels.Properties{
+					mrmodels.NewStringProperty("hf_gated", "manual", true),
+				},
+			},
+		},
+	}
+
+	pipeline, err := NewEnrichmentPipeline(modelRepo, []string{"model-card", "license-restriction", "test-failing"}, 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pipeline.Start(ctx)
+
+	require.NoError(t, pipeline.Enqueue(ctx, ModelProviderRecord{Model: modelRepo.GetSavedModels()[0]}))
+
+	require.Eventually(t, func() bool {
+		for _, status := range pipeline.Status() {
+			if status.Pending != 0 || status.LastRunTime == nil {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	statuses := pipeline.Status()
+	require.Len(t, statuses, 3)
+	assert.Equal(t, "model-card", statuses[0].Name)
+	assert.Equal(t, int64(1), statuses[0].Succeeded)
+	assert.Equal(t, int64(1), statuses[1].Succeeded)
+	assert.Equal(t, int64(1), statuses[2].Failed)
+	assert.Equal(t, "granite-8b: source unavailable", statuses[2].LastError)
+
+	saved := modelRepo.GetSavedModels()
+	customProperties := saved[len(saved)-1].GetCustomProperties()
+	expected := map[string]string{
+		"hf_gated":                              "manual",
+		"model_card_base_model":                 "ibm-granite/granite-8b-base",
+		"model_card_tags":                       `["granite","code"]`,
+		"license_restricted":                    "true",
+		"gated":                                 "true",
+		"enrichment.model-card.status":          EnrichmentStatusSucceeded,
+		"enrichment.test-failing.status":        EnrichmentStatusFailed,
+		"enrichment.test-failing.error":         "source unavailable",
+		"enrichment.license-restriction.status": EnrichmentStatusSucceeded,
+	}
+	for name, value := range expected {
+		actual, ok := stringProperty(customProperties, name)
+		if assert.True(t, ok, "missing custom property %s", name) {
+			assert.Equal(t, value, actual, name)
+		}
+	}
+}
+
+func TestReadmeFrontMatter(t *testing.T) {
+	tests := []struct {
+		name   string
+		readme string
+		want   string
+		found  bool
+	}{
+		{"front matter", "---\nlicense: mit\n---\n# Model\n", "license: mit\n", true},
+		{"windows line endings", "---\r\nlicense: mit\r\n---\r\n", "license: mit\r\n", true},
+		{"no front matter", "# Model\n---\n", "", false},
+		{"unterminated", "---\nlicense: mit\n", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := readmeFrontMatter(tt.readme)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEnrichLicenseRestriction(t *testing.T) {
+	tests := []struct {
+		license    string
+		restricted string
+	}{
+		{"apache-2.0", "false"},
+		{"mit", "false"},
+		{"cc-by-nc-4.0", "true"},
+		{"llama3.1", "true"},
+		{"other", "true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.license, func(t *testing.T) {
+			model := &dbmodels.CatalogModelImpl{
+				Properties: &[]mrmodels.Properties{
+					mrmodels.NewStringProperty("license", tt.license, false),
+				},
+			}
+
+			properties, err := enrichLicenseRestriction(context.Background(), model)
+			require.NoError(t, err)
+
+			restricted, _ := stringProperty(&properties, "license_restricted")
+			gated, _ := stringProperty(&properties, "gated")
+			assert.Equal(t, tt.restricted, restricted)
+			assert.Equal(t, "false", gated)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+)
+
+// DefaultFacetsTTL bounds how long the facet counts are served after the catalog changed.
+const DefaultFacetsTTL = time.Minute
+
+// Size buckets of the models, by parameter count.
+const (
+	SizeBucketUnder1B   = "<1B"
+	SizeBucket1To10B    = "1B-10B"
+	SizeBucket10To100B  = "10B-100B"
+	SizeBucketOver100B  = ">100B"
+	SizeBucketUndefined = "unknown"
+)
+
+type ListModelFacetsParams struct {
+	Query       string
+	FilterQuery string
+	SourceIDs   []string
+}
+
+// FacetValue is the number of models having a value.
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// ModelFacets are the number of models per provider, task, license and size bucket, ordered by decreasing count.
+type ModelFacets struct {
+	Provider   []FacetValue `json:"provider"`
+	Task       []FacetValue `json:"task"`
+	License    []FacetValue `json:"license"`
+	SizeBucket []FacetValue `json:"sizeBucket"`
+}
+
+func (d *dbCatalogImpl) GetModelFacets(ctx context.Context, params ListModelFacetsParams) (*ModelFacets, error) {
+	key, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.facets.get(string(key), func() (*ModelFacets, error) {
+		return d.countModelFacets(params)
+	})
+}
+
+func (d *dbCatalogImpl) countModelFacets(params ListModelFacetsParams) (*ModelFacets, error) {
+	var queryPtr *string
+	if params.Query != "" {
+		queryPtr = &params.Query
+	}
+
+	listOptions := dbmodels.CatalogModelListOptions{
+		SourceIDs: &params.SourceIDs,
+		Query:     queryPtr,
+		Pagination: mrmodels.Pagination{
+			FilterQuery: &params.FilterQuery,
+		},
+	}
+
+	providers, err := d.catalogModelRepository.CountPropertyValues(listOptions, "provider", false)
+	if err != nil {
+		return nil, err
+	}
+
+	licenses, err := d.catalogModelRepository.CountPropertyValues(listOptions, "license", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks are stored as JSON arrays, a model is counted once for each of its tasks
+	taskLists, err := d.catalogModelRepository.CountPropertyValues(listOptions, "tasks", false)
+	if err != nil {
+		return nil, err
+	}
+	tasks := map[string]int64{}
+	for taskList, count := range taskLists {
+		var values []string
+		if err := json.Unmarshal([]byte(taskList), &values); err != nil {
+			continue
+		}
+		for _, task := range slices.Compact(slices.Sorted(slices.Values(values))) {
+			tasks[task] += count
+		}
+	}
+
+	sizes, err := d.catalogModelRepository.CountPropertyValues(listOptions, "size", true)
+	if err != nil {
+		return nil, err
+	}
+	sizeBuckets := map[string]int64{}
+	for size, count := range sizes {
+		sizeBuckets[SizeBucket(size)] += count
+	}
+
+	return &ModelFacets{
+		Provider:   facetValues(providers),
+		Task:       facetValues(tasks),
+		License:    facetValues(licenses),
+		SizeBucket: facetValues(sizeBuckets),
+	}, nil
+}
+
+func facetValues(counts map[string]int64) []FacetValue {
+	values := make([]FacetValue, 0, len(counts))
+	for value, count := range counts {
+		if value == "" {
+			continue
+		}
+		values = append(values, FacetValue{Value: value, Count: count})
+	}
+
+	slices.SortFunc(values, func(a, b FacetValue) int {
+		if a.Count != b.Count {
+			if a.Count > b.Count {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Value, b.Value)
+	})
+
+	return values
+}
+
+var sizePattern = regexp.MustCompile(`(?i)^\s*([0-9]+(?:\.[0-9]+)?)\s*([kmbt])`)
+
+// SizeBucket returns the bucket of a model size, a parameter count such as "8B params" or "350M".
+func SizeBucket(size string) string {
+	match := sizePattern.FindStringSubmatch(size)
+	if match == nil {
+		return SizeBucketUndefined
+	}
+
+	n, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return SizeBucketUndefined
+	}
+
+	switch strings.ToUpper(match[2]) {
+	case "K":
+		n /= 1e6
+	case "M":
+		n /= 1e3
+	case "T":
+		n *= 1e3
+	}
+
+	switch {
+	case n < 1:
+		return SizeBucketUnder1B
+	case n < 10:
+		return SizeBucket1To10B
+	case n <= 100:
+		return SizeBucket10To100B
+	default:
+		return SizeBucketOver100B
+	}
+}
+
+type facetCacheEntry struct {
+	facets  *ModelFacets
+	expires time.Time
+}
+
+// facetCache keeps the facet counts per listing parameters for a TTL, the aggregates scan every model
+// matching the listing.
+type facetCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]facetCacheEntry
+}
+
+func newFacetCache(ttl time.Duration) *facetCache {
+	return &facetCache{
+		ttl:     ttl,
+		entries: map[string]facetCacheEntry{},
+	}
+}
+
+func (c *facetCache) get(key string, load func() (*ModelFacets, error)) (*ModelFacets, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.facets, nil
+	}
+
+	facets, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error counting model facets: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = facetCacheEntry{facets: facets, expires: now.Add(c.ttl)}
+
+	return facets, nil
+}
@@ -0,0 +1,89 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	"github.com/kubeflow/model-registry/catalog/internal/db/service"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeBucket(t *testing.T) {
+	tests := []struct {
+		size string
+		want string
+	}{
+		{"350M", SizeBucketUnder1B},
+		{"8B params", SizeBucket1To10B},
+		{"1.5b", SizeBucket1To10B},
+		{"70B", SizeBucket10To100B},
+		{"100B", SizeBucket10To100B},
+		{"405B params", SizeBucketOver100B},
+		{"1.2T", SizeBucketOver100B},
+		{"large", SizeBucketUndefined},
+		{"", SizeBucketUndefined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.size, func(t *testing.T) {
+			assert.Equal(t, tt.want, SizeBucket(tt.size))
+		})
+	}
+}
+
+func TestGetModelFacets(t *testing.T) {
+	newModel := func(provider, license, tasks, size string) dbmodels.CatalogModel {
+		return &dbmodels.CatalogModelImpl{
+			Properties: &[]mrmodels.Properties{
+				mrmodels.NewStringProperty("provider", provider, false),
+				mrmodels.NewStringProperty("license", license, false),
+				mrmodels.NewStringProperty("tasks", tasks, false),
+			},
+			CustomProperties: &[]mrmodels.Properties{
+				mrmodels.NewStringProperty("size", size, true),
+			},
+		}
+	}
+
+	modelRepo := &MockCatalogModelRepository{
+		SavedModels: []dbmodels.CatalogModel{
+			newModel("IBM", "apache-2.0", `["text-generation"]`, "8B params"),
+			newModel("IBM", "apache-2.0", `["text-generation", "code-generation"]`, "3B"),
+			newModel("Meta", "llama3", `["text-generation"]`, "70B params"),
+		},
+	}
+
+	dbCatalog := NewDBCatalog(service.NewServices(
+		modelRepo,
+		&MockCatalogArtifactRepository{},
+		&MockCatalogModelArtifactRepository{},
+		&MockCatalogMetricsArtifactRepository{},
+		&MockCatalogSourceRepository{},
+		&MockPropertyOptionsRepository{},
+	), nil)
+
+	facets, err := dbCatalog.GetModelFacets(context.Background(), ListModelFacetsParams{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []FacetValue{{Value: "IBM", Count: 2}, {Value: "Meta", Count: 1}}, facets.Provider)
+	assert.Equal(t, []FacetValue{{Value: "text-generation", Count: 3}, {Value: "code-generation", Count: 1}}, facets.Task)
+	assert.Equal(t, []FacetValue{{Value: "apache-2.0", Count: 2}, {Value: "llama3", Count: 1}}, facets.License)
+	assert.Equal(t, []FacetValue{{Value: SizeBucket1To10B, Count: 2}, {Value: SizeBucket10To100B, Count: 1}}, facets.SizeBucket)
+
+	t.Run("served from cache", func(t *testing.T) {
+		modelRepo.mu.Lock()
+		modelRepo.SavedModels = append(modelRepo.SavedModels, newModel("Mistral", "apache-2.0", `["text-generation"]`, "7B"))
+		modelRepo.mu.Unlock()
+
+		cached, err := dbCatalog.GetModelFacets(context.Background(), ListModelFacetsParams{})
+		require.NoError(t, err)
+		assert.Equal(t, facets, cached)
+
+		other, err := dbCatalog.GetModelFacets(context.Background(), ListModelFacetsParams{Query: "granite"})
+		require.NoError(t, err)
+		assert.Len(t, other.Provider, 3)
+	})
+}
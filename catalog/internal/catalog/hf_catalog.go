@@ -5,10 +5,12 @@ import (
 	_ "embed"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -520,7 +522,14 @@ func (p *hfModelProvider) getModelsFromHF(ctx context.Context) ([]ModelProviderR
 			continue
 		}
 
+		revision := hfRevision(modelInfo)
+		if stored, ok := StoredRevision(ctx, modelInfo.ID); ok && stored == revision {
+			records = append(records, UnchangedRecord(modelInfo.ID))
+			continue
+		}
+
 		record := p.convertHFModelToRecord(ctx, modelInfo, modelName)
+		SetRevision(record.Model, revision)
 
 		// Additional safety check: verify the final model name is not excluded
 		// (in case the model name changed during conversion, e.g., from hfInfo.ID)
@@ -586,7 +595,10 @@ func (p *hfModelProvider) fetchModelInfo(ctx context.Context, modelName string)
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Hugging Face API returned status %d for model %s: %s", resp.StatusCode, modelName, string(bodyBytes))
+		return nil, &hfAPIError{
+			StatusCode: resp.StatusCode,
+			message:    fmt.Sprintf("Hugging Face API returned status %d for model %s: %s", resp.StatusCode, modelName, string(bodyBytes)),
+		}
 	}
 
 	var modelInfo hfModelInfo
@@ -771,6 +783,23 @@ func convertHFModelProperties(catalogModel *apimodels.CatalogModel) ([]models.Pr
 	return properties, customProperties
 }
 
+// hfRevisionFormat is bumped when the conversion of the Hugging Face models
+// changes, so that every model is refreshed on the next sync.
+const hfRevisionFormat = "hf/1"
+
+// hfRevision identifies the content of a Hugging Face model: the commit of the
+// repository, with the access settings that change without a commit.
+func hfRevision(hfInfo *hfModelInfo) string {
+	commit := hfInfo.Sha
+	if commit == "" {
+		commit = hfInfo.UpdatedAt
+	}
+	if commit == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s:%s:%s:%t", hfRevisionFormat, commit, hfInfo.Gated, hfInfo.Private)
+}
+
 // parseHFTime parses Hugging Face timestamp format (ISO 8601)
 func parseHFTime(timeStr string) (int64, error) {
 	t, err := time.Parse(time.RFC3339, timeStr)
@@ -814,6 +843,16 @@ func (p *hfModelProvider) emitWithError(ctx context.Context, models []ModelProvi
 	}
 }
 
+// hfAPIError is returned when the Hugging Face API answers with an unexpected status.
+type hfAPIError struct {
+	StatusCode int
+	message    string
+}
+
+func (e *hfAPIError) Error() string {
+	return e.message
+}
+
 // validateCredentials checks if the Hugging Face API key credentials are valid
 func (p *hfModelProvider) validateCredentials(ctx context.Context) error {
 	glog.Infof("Validating Hugging Face API credentials")
@@ -849,14 +888,16 @@ func (p *hfModelProvider) validateCredentials(ctx context.Context) error {
 	return nil
 }
 
-func newHFModelProvider(ctx context.Context, source *Source, reldir string) (<-chan ModelProviderRecord, error) {
+// hfConnection returns a provider set up with the source connection properties, and the
+// name of the environment variable holding the API key.
+func hfConnection(source *Source) (*hfModelProvider, string, error) {
 	p := &hfModelProvider{}
 	p.client = &http.Client{Timeout: 30 * time.Second}
 
 	// Parse Source ID
 	sourceId := source.GetId()
 	if sourceId == "" {
-		return nil, fmt.Errorf("missing source ID for Hugging Face catalog")
+		return nil, "", fmt.Errorf("missing source ID for Hugging Face catalog")
 	}
 	p.sourceId = sourceId
 
@@ -866,11 +907,7 @@ func newHFModelProvider(ctx context.Context, source *Source, reldir string) (<-c
 	if envVar, ok := source.Properties[apiKeyEnvVarKey].(string); ok && envVar != "" {
 		apiKeyEnvVar = envVar
 	}
-	apiKey := os.Getenv(apiKeyEnvVar)
-	if apiKey == "" {
-		glog.Infof("No API key configured for Hugging Face. Only public models and limited data for gated models will be available.")
-	}
-	p.apiKey = apiKey
+	p.apiKey = os.Getenv(apiKeyEnvVar)
 
 	// Parse base URL (optional, defaults to huggingface.co)
 	// This allows tests to use mock servers by providing a custom URL
@@ -879,6 +916,18 @@ func newHFModelProvider(ctx context.Context, source *Source, reldir string) (<-c
 		p.baseURL = strings.TrimSuffix(url, "/")
 	}
 
+	return p, apiKeyEnvVar, nil
+}
+
+func newHFModelProvider(ctx context.Context, source *Source, reldir string) (<-chan ModelProviderRecord, error) {
+	p, _, err := hfConnection(source)
+	if err != nil {
+		return nil, err
+	}
+	if p.apiKey == "" {
+		glog.Infof("No API key configured for Hugging Face. Only public models and limited data for gated models will be available.")
+	}
+
 	allowedOrg, _ := source.Properties[allowedOrgKey].(string)
 	restrictToOrg(allowedOrg, &source.IncludedModels, &source.ExcludedModels)
 
@@ -929,10 +978,77 @@ func newHFModelProvider(ctx context.Context, source *Source, reldir string) (<-c
 	return p.Models(ctx)
 }
 
+// testHFConnection checks the API key, then the access to the first included model or organization.
+func testHFConnection(ctx context.Context, source *Source, reldir string) []SourceCheck {
+	p, apiKeyEnvVar, err := hfConnection(source)
+	if err != nil {
+		return []SourceCheck{failedCheck("configuration", "%v", err)}
+	}
+
+	checks := []SourceCheck{}
+	switch {
+	case p.apiKey == "":
+		checks = append(checks, passedCheck("credentials", "no API key in the %s environment variable, only public models are available", apiKeyEnvVar))
+	case !strings.HasPrefix(p.apiKey, "hf_"):
+		return append(checks, failedCheck("credentials", "the API key in the %s environment variable is ignored because it does not start with hf_, use a Hugging Face user access token", apiKeyEnvVar))
+	default:
+		if err := p.validateCredentials(ctx); err != nil {
+			return append(checks, failedCheck("credentials", "%v: check the token in the %s environment variable and that %s is reachable", err, apiKeyEnvVar, p.baseURL))
+		}
+		checks = append(checks, passedCheck("credentials", "the API key in the %s environment variable is valid", apiKeyEnvVar))
+	}
+
+	// Do not modify the source patterns, restrictToOrg updates them in place
+	included := slices.Clone(source.IncludedModels)
+	allowedOrg, _ := source.Properties[allowedOrgKey].(string)
+	restrictToOrg(allowedOrg, &included, nil)
+	if len(included) == 0 {
+		return append(checks, failedCheck("models", "includedModels cannot be empty for Hugging Face catalog"))
+	}
+
+	pattern := included[0]
+	patternType, org, prefix := parseModelPattern(pattern)
+	switch patternType {
+	case PatternInvalid:
+		return append(checks, failedCheck("models", "includedModels pattern %q is not supported, patterns must start with an organization, e.g. org/* or org/prefix*", pattern))
+	case PatternExact:
+		_, err = p.fetchModelInfo(ctx, pattern)
+	default:
+		p.maxModels = 1
+		var names []string
+		names, err = p.listModelsByAuthor(ctx, org, prefix)
+		if err == nil && len(names) == 0 {
+			return append(checks, failedCheck("models", "no models match the includedModels pattern %q, check the organization name", pattern))
+		}
+	}
+
+	if err != nil {
+		var apiErr *hfAPIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.StatusCode {
+			case http.StatusUnauthorized:
+				return append(checks, failedCheck("models", "%v: the API key in the %s environment variable is invalid or expired", err, apiKeyEnvVar))
+			case http.StatusForbidden:
+				return append(checks, failedCheck("models", "%v: %s is gated or private, request access with the account owning the API key in the %s environment variable", err, pattern, apiKeyEnvVar))
+			case http.StatusNotFound:
+				return append(checks, failedCheck("models", "%v: %s does not exist, or is private and requires an API key in the %s environment variable", err, pattern, apiKeyEnvVar))
+			case http.StatusTooManyRequests:
+				return append(checks, failedCheck("models", "%v: the Hugging Face API rate limit was reached, retry later or configure an API key in the %s environment variable", err, apiKeyEnvVar))
+			}
+		}
+		return append(checks, failedCheck("models", "%v", err))
+	}
+
+	return append(checks, passedCheck("models", "%s is accessible", pattern))
+}
+
 func init() {
 	if err := RegisterModelProvider("hf", newHFModelProvider); err != nil {
 		panic(err)
 	}
+	if err := RegisterConnectionTester("hf", testHFConnection); err != nil {
+		panic(err)
+	}
 }
 
 // NewHFPreviewProvider creates an hfModelProvider for preview use.
@@ -1109,7 +1225,10 @@ func (p *hfModelProvider) listModelsByAuthor(ctx context.Context, author string,
 		if resp.StatusCode != http.StatusOK {
 			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			return nil, fmt.Errorf("Hugging Face API returned status %d for author %s: %s", resp.StatusCode, author, string(bodyBytes))
+			return nil, &hfAPIError{
+				StatusCode: resp.StatusCode,
+				message:    fmt.Sprintf("Hugging Face API returned status %d for author %s: %s", resp.StatusCode, author, string(bodyBytes)),
+			}
 		}
 
 		var models []hfListModel
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/golang/glog"
@@ -49,6 +50,10 @@ type ModelProviderRecord struct {
 	Artifacts []dbmodels.CatalogArtifact
 	// Error can be set here to emit successfully loaded models before updating source status err.
 	Error error
+	// Unchanged is set when the model did not change upstream since it was
+	// stored, only the model name is set and the stored model is kept. See
+	// StoredRevision.
+	Unchanged bool
 }
 
 // ModelProviderFunc emits models and related data in the channel it returns. It is
@@ -115,6 +120,10 @@ type Loader struct {
 	closer        func() // cancels the current model loading goroutines
 	handlers      []LoaderEventHandler
 	loadedSources map[string]bool // tracks which source IDs have been loaded
+
+	// elected is set when database updates are restricted to the leader replica, see Lead.
+	elected bool
+	leading atomic.Bool
 }
 
 func NewLoader(services service.Services, paths []string) *Loader {
@@ -147,6 +156,37 @@ func (l *Loader) RegisterEventHandler(fn LoaderEventHandler) {
 	l.handlers = append(l.handlers, fn)
 }
 
+// UseLeaderElection restricts database updates to the periods when Lead is
+// running, so that multiple replicas can share the same database. Sources and
+// labels are still loaded from the configuration files by every replica. This
+// should be called before Start.
+func (l *Loader) UseLeaderElection() {
+	l.elected = true
+}
+
+// Lead loads the models from all sources into the database and keeps them
+// updated until the context is canceled. It is meant to be used as the
+// callback of a leaderelection.Elector.
+func (l *Loader) Lead(ctx context.Context) {
+	l.leading.Store(true)
+	l.reloadAll(ctx)
+
+	<-ctx.Done()
+
+	l.leading.Store(false)
+	l.closersMu.Lock()
+	if l.closer != nil {
+		l.closer()
+		l.closer = nil
+	}
+	l.closersMu.Unlock()
+}
+
+// canUpdateDatabase reports whether this replica is allowed to write to the database.
+func (l *Loader) canUpdateDatabase() bool {
+	return !l.elected || l.leading.Load()
+}
+
 // Start processes the sources YAML files. Background goroutines will be
 // stopped when the context is canceled.
 func (l *Loader) Start(ctx context.Context) error {
@@ -211,6 +251,10 @@ func (l *Loader) parseAndMerge(path string) error {
 
 // loadAllModels loads models from all merged sources.
 func (l *Loader) loadAllModels(ctx context.Context) error {
+	if !l.canUpdateDatabase() {
+		return nil
+	}
+
 	// Clear the loaded sources tracker for a fresh load
 	l.loadedSources = map[string]bool{}
 
@@ -338,6 +382,11 @@ func (l *Loader) updateDatabase(ctx context.Context) error {
 				continue
 			}
 
+			if record.Unchanged {
+				glog.V(2).Infof("Skipping unchanged model %s", *attr.Name)
+				continue
+			}
+
 			glog.Infof("Loading model %s with %d artifact(s)", *attr.Name, len(record.Artifacts))
 
 			model, err := l.services.CatalogModelRepository.Save(record.Model)
@@ -436,7 +485,7 @@ func (l *Loader) readProviderRecords(ctx context.Context) <-chan ModelProviderRe
 		// different configmaps) to use relative paths correctly.
 		sourceDir := filepath.Dir(source.Origin)
 
-		records, err := registerFunc(ctx, &source, sourceDir)
+		records, err := registerFunc(withRevisionLookup(ctx, l.storedRevisions(source.Id)), &source, sourceDir)
 		if err != nil {
 			glog.Errorf("error reading catalog type %s with id %s: %v", source.Type, source.Id, err)
 			l.saveSourceStatus(source.Id, SourceStatusError, err.Error())
@@ -448,11 +497,13 @@ func (l *Loader) readProviderRecords(ctx context.Context) <-chan ModelProviderRe
 			defer wg.Done()
 
 			modelNames := []string{}
+			skipped := 0
 			statusSaved := false
 
 			for r := range records {
 				if r.Model == nil {
-					glog.Infof("%s: loaded %d models", sourceID, len(modelNames))
+					glog.Infof("%s: loaded %d models, %d unchanged", sourceID, len(modelNames), skipped)
+					skipped = 0
 
 					// Copy the list of model names, then clear it.
 					modelNameSet := mapset.NewSet(modelNames...)
@@ -484,6 +535,13 @@ func (l *Loader) readProviderRecords(ctx context.Context) <-chan ModelProviderRe
 					modelNames = append(modelNames, *attr.Name)
 				}
 
+				if r.Unchanged {
+					skipped++
+					syncedModels.WithLabelValues(sourceID, SyncResultSkipped).Inc()
+				} else {
+					syncedModels.WithLabelValues(sourceID, SyncResultUpdated).Inc()
+				}
+
 				// Set source_id on every returned model.
 				l.setModelSourceID(r.Model, sourceID)
 
@@ -538,6 +596,10 @@ func (l *Loader) setModelSourceID(model dbmodels.CatalogModel, sourceID string)
 }
 
 func (l *Loader) removeModelsFromMissingSources() error {
+	if !l.canUpdateDatabase() {
+		return nil
+	}
+
 	enabledSourceIDs := mapset.NewSet[string]()
 	allSourceIDs := mapset.NewSet[string]()
 	for id, source := range l.Sources.AllSources() {
@@ -1,7 +1,9 @@
 package catalog
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/kubeflow/model-registry/catalog/internal/db/service"
@@ -159,6 +161,47 @@ func TestRemoveModelsFromMissingSources(t *testing.T) {
 	}
 }
 
+func TestLoaderLeaderElection(t *testing.T) {
+	mockModelRepo := &MockCatalogModelRepositoryWithSourceTracking{
+		ExistingSourceIDs: []string{"stale"},
+		DeletedSources:    []string{},
+	}
+
+	services := service.NewServices(
+		mockModelRepo,
+		&MockCatalogArtifactRepository{},
+		&MockCatalogModelArtifactRepository{},
+		&MockCatalogMetricsArtifactRepository{},
+		&MockCatalogSourceRepository{},
+		&MockPropertyOptionsRepository{},
+	)
+
+	loader := NewLoader(services, []string{})
+	loader.UseLeaderElection()
+
+	// Followers must not touch the database
+	err := loader.removeModelsFromMissingSources()
+	assert.NoError(t, err)
+	assert.Empty(t, mockModelRepo.DeletedSources)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		loader.Lead(ctx)
+	}()
+
+	assert.Eventually(t, func() bool {
+		return loader.canUpdateDatabase()
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	assert.False(t, loader.canUpdateDatabase())
+	assert.Equal(t, []string{"stale"}, mockModelRepo.DeletedSources)
+}
+
 // MockCatalogModelRepositoryWithSourceTracking extends the existing mock to add source tracking
 type MockCatalogModelRepositoryWithSourceTracking struct {
 	MockCatalogModelRepository
@@ -0,0 +1,98 @@
+package catalog
+
+import (
+	"context"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RevisionProperty is the model property holding the upstream revision the
+// model was last synced from, e.g. a commit hash or a content digest.
+const RevisionProperty = "upstream_revision"
+
+// Sync results of the models emitted by the providers.
+const (
+	SyncResultUpdated = "updated"
+	SyncResultSkipped = "skipped"
+)
+
+// syncedModels counts the models emitted by the providers, by source and
+// whether they were saved or skipped because they did not change upstream.
+var syncedModels = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "model_catalog_sync_models_total",
+	Help: "Number of catalog models processed by the sync, by source and result (updated or skipped).",
+}, []string{"source", "result"})
+
+func init() {
+	prometheus.MustRegister(syncedModels)
+}
+
+// revisionLookup returns the upstream revision of a stored model of the source being synced.
+type revisionLookup func(modelName string) (string, bool)
+
+type revisionLookupKey struct{}
+
+func withRevisionLookup(ctx context.Context, lookup revisionLookup) context.Context {
+	return context.WithValue(ctx, revisionLookupKey{}, lookup)
+}
+
+// StoredRevision returns the upstream revision of the stored model, if any.
+// Providers compare it with the current upstream revision to emit an
+// UnchangedRecord instead of fetching and converting the whole model.
+func StoredRevision(ctx context.Context, modelName string) (string, bool) {
+	lookup, ok := ctx.Value(revisionLookupKey{}).(revisionLookup)
+	if !ok || modelName == "" {
+		return "", false
+	}
+	return lookup(modelName)
+}
+
+// SetRevision records the upstream revision on a model emitted by a provider.
+func SetRevision(model dbmodels.CatalogModel, revision string) {
+	modelImpl, ok := model.(*dbmodels.CatalogModelImpl)
+	if !ok || revision == "" {
+		return
+	}
+
+	if modelImpl.Properties == nil {
+		modelImpl.Properties = &[]mrmodels.Properties{}
+	}
+	for i := range *modelImpl.Properties {
+		if (*modelImpl.Properties)[i].Name == RevisionProperty {
+			(*modelImpl.Properties)[i].StringValue = &revision
+			return
+		}
+	}
+	*modelImpl.Properties = append(*modelImpl.Properties, mrmodels.NewStringProperty(RevisionProperty, revision, false))
+}
+
+// UnchangedRecord returns the record emitted for a model whose upstream
+// revision matches the stored one. The loader keeps the stored model.
+func UnchangedRecord(modelName string) ModelProviderRecord {
+	return ModelProviderRecord{
+		Model: &dbmodels.CatalogModelImpl{
+			Attributes: &dbmodels.CatalogModelAttributes{Name: &modelName},
+		},
+		Unchanged: true,
+	}
+}
+
+// storedRevisions returns the lookup of the revisions of the models stored for
+// a source. Models stored for another source are reported as missing so that
+// they are always refreshed.
+func (l *Loader) storedRevisions(sourceID string) revisionLookup {
+	return func(modelName string) (string, bool) {
+		model, err := l.services.CatalogModelRepository.GetByName(modelName)
+		if err != nil || model == nil {
+			return "", false
+		}
+
+		if storedSource, _ := stringProperty(model.GetProperties(), "source_id"); storedSource != sourceID {
+			return "", false
+		}
+
+		return stringProperty(model.GetProperties(), RevisionProperty)
+	}
+}
@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
+	"github.com/kubeflow/model-registry/catalog/internal/db/service"
+	apimodels "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// staticRevisions is a revision lookup returning the given revisions
+func staticRevisions(revisions map[string]string) revisionLookup {
+	return func(modelName string) (string, bool) {
+		revision, ok := revisions[modelName]
+		return revision, ok
+	}
+}
+
+func recordRevision(record ModelProviderRecord) string {
+	revision, _ := stringProperty(record.Model.GetProperties(), RevisionProperty)
+	return revision
+}
+
+func TestStoredRevision(t *testing.T) {
+	_, ok := StoredRevision(context.Background(), "model")
+	assert.False(t, ok, "no revision without a lookup")
+
+	ctx := withRevisionLookup(context.Background(), staticRevisions(map[string]string{"model": "rev-1"}))
+	revision, ok := StoredRevision(ctx, "model")
+	assert.True(t, ok)
+	assert.Equal(t, "rev-1", revision)
+
+	_, ok = StoredRevision(ctx, "other")
+	assert.False(t, ok)
+}
+
+func TestYamlModelRevision(t *testing.T) {
+	newModel := func(uri string) *yamlModel {
+		return &yamlModel{
+			CatalogModel: apimodels.CatalogModel{Name: "model"},
+			Artifacts: []*yamlArtifact{
+				{CatalogArtifact: apimodels.CatalogModelArtifactAsCatalogArtifact(apimodels.NewCatalogModelArtifact("model-artifact", uri))},
+			},
+		}
+	}
+
+	revision := newModel("oci://registry/model:1").revision()
+	assert.True(t, strings.HasPrefix(revision, yamlRevisionFormat+":"))
+	assert.Equal(t, revision, newModel("oci://registry/model:1").revision())
+	assert.NotEqual(t, revision, newModel("oci://registry/model:2").revision(), "artifact changes are detected")
+}
+
+func TestYamlProviderSkipsUnchangedModels(t *testing.T) {
+	filter, err := NewModelFilter(nil, nil)
+	require.NoError(t, err)
+
+	unchanged := yamlModel{CatalogModel: apimodels.CatalogModel{Name: "unchanged"}}
+	changed := yamlModel{CatalogModel: apimodels.CatalogModel{Name: "changed"}}
+	added := yamlModel{CatalogModel: apimodels.CatalogModel{Name: "added"}}
+
+	ctx := withRevisionLookup(context.Background(), staticRevisions(map[string]string{
+		"unchanged": unchanged.revision(),
+		"changed":   yamlRevisionFormat + ":outdated",
+	}))
+
+	out := make(chan ModelProviderRecord, 4)
+	provider := &yamlModelProvider{filter: filter}
+	provider.emit(ctx, &yamlCatalog{Models: []yamlModel{unchanged, changed, added}}, out)
+	close(out)
+
+	records := map[string]ModelProviderRecord{}
+	for record := range out {
+		if record.Model != nil {
+			records[*record.Model.GetAttributes().Name] = record
+		}
+	}
+	require.Len(t, records, 3)
+
+	assert.True(t, records["unchanged"].Unchanged)
+	assert.False(t, records["changed"].Unchanged)
+	assert.Equal(t, changed.revision(), recordRevision(records["changed"]))
+	assert.False(t, records["added"].Unchanged)
+	assert.Equal(t, added.revision(), recordRevision(records["added"]))
+}
+
+func TestHFProviderSkipsUnchangedModels(t *testing.T) {
+	var readmeRequests atomic.Int32
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/models/test-org/"):
+			_ = json.NewEncoder(w).Encode(hfModelInfo{
+				ID:       strings.TrimPrefix(r.URL.Path, "/api/models/"),
+				Sha:      "abc123",
+				Siblings: []hfFile{{RFileName: "README.md"}},
+			})
+		case strings.HasSuffix(r.URL.Path, "/raw/main/README.md"):
+			readmeRequests.Add(1)
+			_, _ = w.Write([]byte("# Model"))
+		default:
+			http.Error(w, "Not found", http.StatusNotFound)
+		}
+	}))
+	defer mockServer.Close()
+
+	filter, err := NewModelFilter(nil, nil)
+	require.NoError(t, err)
+
+	provider := &hfModelProvider{
+		baseURL:        mockServer.URL,
+		includedModels: []string{"test-org/unchanged", "test-org/changed"},
+		filter:         filter,
+		client:         &http.Client{},
+	}
+
+	unchangedRevision := hfRevision(&hfModelInfo{Sha: "abc123"})
+	ctx := withRevisionLookup(context.Background(), staticRevisions(map[string]string{
+		"test-org/unchanged": unchangedRevision,
+		"test-org/changed":   hfRevision(&hfModelInfo{Sha: "0ld"}),
+	}))
+
+	records, err := provider.getModelsFromHF(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.True(t, records[0].Unchanged)
+	assert.Equal(t, "test-org/unchanged", *records[0].Model.GetAttributes().Name)
+	assert.False(t, records[1].Unchanged)
+	assert.Equal(t, unchangedRevision, recordRevision(records[1]))
+	assert.Equal(t, int32(1), readmeRequests.Load(), "the README of unchanged models is not fetched")
+}
+
+func TestLoaderStoredRevisions(t *testing.T) {
+	newModel := func(name, sourceID, revision string) dbmodels.CatalogModel {
+		return &dbmodels.CatalogModelImpl{
+			Attributes: &dbmodels.CatalogModelAttributes{Name: &name},
+			Properties: &[]mrmodels.Properties{
+				mrmodels.NewStringProperty("source_id", sourceID, false),
+				mrmodels.NewStringProperty(RevisionProperty, revision, false),
+			},
+		}
+	}
+
+	services := service.NewServices(
+		&MockCatalogModelRepository{SavedModels: []dbmodels.CatalogModel{
+			newModel("model", "source-1", "rev-1"),
+		}},
+		&MockCatalogArtifactRepository{},
+		&MockCatalogModelArtifactRepository{},
+		&MockCatalogMetricsArtifactRepository{},
+		&MockCatalogSourceRepository{},
+		&MockPropertyOptionsRepository{},
+	)
+	loader := NewLoader(services, []string{})
+
+	revision, ok := loader.storedRevisions("source-1")("model")
+	assert.True(t, ok)
+	assert.Equal(t, "rev-1", revision)
+
+	_, ok = loader.storedRevisions("source-2")("model")
+	assert.False(t, ok, "models stored for another source are refreshed")
+
+	_, ok = loader.storedRevisions("source-1")("missing")
+	assert.False(t, ok)
+}
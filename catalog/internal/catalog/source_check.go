@@ -0,0 +1,73 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// sourceCheckTimeout bounds the time spent testing the connection to an upstream.
+const sourceCheckTimeout = 30 * time.Second
+
+// SourceCheck is the outcome of one step of a source connection test.
+type SourceCheck struct {
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// SourceConnectionTest is the result of testing the connection of a source to its upstream.
+type SourceConnectionTest struct {
+	SourceId string        `json:"sourceId"`
+	Success  bool          `json:"success"`
+	Checks   []SourceCheck `json:"checks"`
+}
+
+// ConnectionTesterFunc checks that a source reaches its upstream with the
+// configured credentials, without loading any model. It returns the checks in
+// the order they ran, a failed check should say how to fix the configuration.
+type ConnectionTesterFunc func(ctx context.Context, source *Source, reldir string) []SourceCheck
+
+var registeredConnectionTesters = map[string]ConnectionTesterFunc{}
+
+func RegisterConnectionTester(name string, callback ConnectionTesterFunc) error {
+	if _, exists := registeredConnectionTesters[name]; exists {
+		return fmt.Errorf("connection tester for type %s already exists", name)
+	}
+	registeredConnectionTesters[name] = callback
+	return nil
+}
+
+// CheckSourceConnection tests the connection of a source with the connection
+// tester of its catalog type.
+func CheckSourceConnection(ctx context.Context, source Source) (*SourceConnectionTest, error) {
+	tester, ok := registeredConnectionTesters[source.Type]
+	if !ok {
+		return nil, fmt.Errorf("catalog type %q of source %s does not support connection tests: %w", source.Type, source.Id, api.ErrBadRequest)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sourceCheckTimeout)
+	defer cancel()
+
+	result := &SourceConnectionTest{
+		SourceId: source.Id,
+		Success:  true,
+		Checks:   tester(ctx, &source, filepath.Dir(source.Origin)),
+	}
+	for _, check := range result.Checks {
+		result.Success = result.Success && check.Success
+	}
+
+	return result, nil
+}
+
+func passedCheck(name string, format string, args ...any) SourceCheck {
+	return SourceCheck{Name: name, Success: true, Message: fmt.Sprintf(format, args...)}
+}
+
+func failedCheck(name string, format string, args ...any) SourceCheck {
+	return SourceCheck{Name: name, Success: false, Message: fmt.Sprintf(format, args...)}
+}
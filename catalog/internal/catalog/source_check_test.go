@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	apimodels "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSourceConnectionHF(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/whoami-v2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hf_valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"name": "test-user"})
+	})
+	mux.HandleFunc("/api/models/test-org/public-model", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "test-org/public-model"})
+	})
+	mux.HandleFunc("/api/models/test-org/gated-model", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/api/models", func(w http.ResponseWriter, r *http.Request) {
+		models := []map[string]any{}
+		if r.URL.Query().Get("author") == "test-org" {
+			models = append(models, map[string]any{"id": "test-org/public-model"})
+		}
+		_ = json.NewEncoder(w).Encode(models)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	newSource := func(includedModels ...string) Source {
+		return Source{
+			CatalogSource: apimodels.CatalogSource{
+				Id:             "hf-source",
+				IncludedModels: includedModels,
+			},
+			Type: "hf",
+			Properties: map[string]any{
+				"url":          server.URL,
+				"apiKeyEnvVar": "TEST_HF_API_KEY",
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		source   Source
+		success  bool
+		checks   []string
+		contains string
+	}{
+		{
+			name:    "public model without API key",
+			apiKey:  "",
+			source:  newSource("test-org/public-model"),
+			success: true,
+			checks:  []string{"credentials", "models"},
+		},
+		{
+			name:    "organization pattern with valid API key",
+			apiKey:  "hf_valid",
+			source:  newSource("test-org/*"),
+			success: true,
+			checks:  []string{"credentials", "models"},
+		},
+		{
+			name:     "API key without the hf_ prefix",
+			apiKey:   "invalid",
+			source:   newSource("test-org/public-model"),
+			checks:   []string{"credentials"},
+			contains: "does not start with hf_",
+		},
+		{
+			name:     "rejected API key",
+			apiKey:   "hf_expired",
+			source:   newSource("test-org/public-model"),
+			checks:   []string{"credentials"},
+			contains: "check the token in the TEST_HF_API_KEY environment variable",
+		},
+		{
+			name:     "gated model",
+			apiKey:   "hf_valid",
+			source:   newSource("test-org/gated-model"),
+			checks:   []string{"credentials", "models"},
+			contains: "test-org/gated-model is gated or private",
+		},
+		{
+			name:     "organization without models",
+			apiKey:   "",
+			source:   newSource("other-org/*"),
+			checks:   []string{"credentials", "models"},
+			contains: "no models match the includedModels pattern",
+		},
+		{
+			name:     "no included models",
+			apiKey:   "",
+			source:   newSource(),
+			checks:   []string{"credentials", "models"},
+			contains: "includedModels cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_HF_API_KEY", tt.apiKey)
+
+			result, err := CheckSourceConnection(context.Background(), tt.source)
+			require.NoError(t, err)
+
+			assert.Equal(t, "hf-source", result.SourceId)
+			assert.Equal(t, tt.success, result.Success)
+
+			names := []string{}
+			for _, check := range result.Checks {
+				names = append(names, check.Name)
+			}
+			assert.Equal(t, tt.checks, names)
+
+			last := result.Checks[len(result.Checks)-1]
+			assert.Equal(t, tt.success, last.Success)
+			assert.Contains(t, last.Message, tt.contains)
+		})
+	}
+}
+
+func TestCheckSourceConnectionYaml(t *testing.T) {
+	newSource := func(path string) Source {
+		return Source{
+			CatalogSource: apimodels.CatalogSource{Id: "yaml-source"},
+			Type:          "yaml",
+			Properties:    map[string]any{yamlCatalogPathKey: path},
+			Origin:        filepath.Join("testdata", "test-catalog-sources.yaml"),
+		}
+	}
+
+	result, err := CheckSourceConnection(context.Background(), newSource("test-yaml-catalog.yaml"))
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	result, err = CheckSourceConnection(context.Background(), newSource("missing.yaml"))
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	require.Len(t, result.Checks, 1)
+	assert.Contains(t, result.Checks[0].Message, "missing.yaml")
+}
+
+func TestCheckSourceConnectionUnsupportedType(t *testing.T) {
+	_, err := CheckSourceConnection(context.Background(), Source{
+		CatalogSource: apimodels.CatalogSource{Id: "source"},
+		Type:          "unknown",
+	})
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+}
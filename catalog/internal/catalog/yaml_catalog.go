@@ -2,6 +2,7 @@ package catalog
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -66,6 +67,29 @@ func init() {
 	if err := RegisterModelProvider("yaml", newYamlModelProvider); err != nil {
 		panic(err)
 	}
+	if err := RegisterConnectionTester("yaml", testYamlConnection); err != nil {
+		panic(err)
+	}
+}
+
+// testYamlConnection checks that the catalog file can be read and parsed.
+func testYamlConnection(ctx context.Context, source *Source, reldir string) []SourceCheck {
+	path, exists := source.Properties[yamlCatalogPathKey].(string)
+	if !exists || path == "" {
+		return []SourceCheck{failedCheck("configuration", "missing %s string property", yamlCatalogPathKey)}
+	}
+
+	p := &yamlModelProvider{path: path}
+	if !filepath.IsAbs(path) {
+		p.path = filepath.Join(reldir, path)
+	}
+
+	catalog, err := p.read()
+	if err != nil {
+		return []SourceCheck{failedCheck("catalog", "%s: %v", p.path, err)}
+	}
+
+	return []SourceCheck{passedCheck("catalog", "%s contains %d models", p.path, len(catalog.Models))}
 }
 
 type yamlModel struct {
@@ -77,6 +101,20 @@ type yamlArtifact struct {
 	apimodels.CatalogArtifact
 }
 
+// yamlRevisionFormat is bumped when the conversion of the YAML models changes,
+// so that every model is refreshed on the next sync.
+const yamlRevisionFormat = "yaml/1"
+
+// revision is the digest of the model definition, including its artifacts.
+func (ym *yamlModel) revision() string {
+	// The embedded CatalogModel marshaler would leave out the artifacts
+	content, err := json.Marshal([]any{ym.CatalogModel, ym.Artifacts})
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%x", yamlRevisionFormat, sha256.Sum256(content))
+}
+
 // convertModelAttributes converts basic model attributes and timestamps
 func (ym *yamlModel) convertModelAttributes() *dbmodels.CatalogModelAttributes {
 	attrs := &dbmodels.CatalogModelAttributes{
@@ -369,8 +407,15 @@ func (p *yamlModelProvider) emit(ctx context.Context, catalog *yamlCatalog, out
 			continue
 		}
 
+		record := UnchangedRecord(model.Name)
+		revision := model.revision()
+		if stored, ok := StoredRevision(ctx, model.Name); !ok || revision == "" || stored != revision {
+			record = model.ToModelProviderRecord()
+			SetRevision(record.Model, revision)
+		}
+
 		select {
-		case out <- model.ToModelProviderRecord():
+		case out <- record:
 		case <-done:
 			return
 		}
@@ -40,4 +40,7 @@ type CatalogModelRepository interface {
 	DeleteBySource(sourceID string) error
 	DeleteByID(id int32) error
 	GetDistinctSourceIDs() ([]string, error)
+	// CountPropertyValues returns the number of models matching listOptions for each string value of a property,
+	// models without the property are not counted.
+	CountPropertyValues(listOptions CatalogModelListOptions, name string, isCustomProperty bool) (map[string]int64, error)
 }
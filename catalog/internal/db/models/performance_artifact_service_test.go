@@ -59,6 +59,11 @@ func (m *MockCatalogModelRepository) GetDistinctSourceIDs() ([]string, error) {
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (m *MockCatalogModelRepository) CountPropertyValues(listOptions CatalogModelListOptions, name string, isCustomProperty bool) (map[string]int64, error) {
+	args := m.Called(listOptions, name, isCustomProperty)
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
 func (m *MockCatalogArtifactRepository) GetByID(id int32) (CatalogArtifact, error) {
 	args := m.Called(id)
 	return args.Get(0).(CatalogArtifact), args.Error(1)
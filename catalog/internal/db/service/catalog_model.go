@@ -256,6 +256,42 @@ func (r *CatalogModelRepositoryImpl) GetDistinctSourceIDs() ([]string, error) {
 	return sourceIDs, nil
 }
 
+func (r *CatalogModelRepositoryImpl) CountPropertyValues(listOptions models.CatalogModelListOptions, name string, isCustomProperty bool) (map[string]int64, error) {
+	config := r.GetConfig()
+
+	filtered, err := r.FilteredQuery(&listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	contextTable := utils.GetTableName(config.DB, &schema.Context{})
+	propertyTable := utils.GetTableName(config.DB, &schema.ContextProperty{})
+
+	var rows []struct {
+		Value string
+		Count int64
+	}
+
+	err = config.DB.Table(propertyTable+" fp").
+		Select("fp.string_value AS value, COUNT(DISTINCT fp.context_id) AS count").
+		Where("fp.context_id IN (?)", filtered.Select(contextTable+".id")).
+		Where("fp.name = ? AND fp.is_custom_property = ? AND fp.string_value IS NOT NULL", name, isCustomProperty).
+		Group("fp.string_value").
+		Scan(&rows).Error
+	if err != nil {
+		// Sanitize database errors to avoid exposing internal details to users
+		err = dbutil.SanitizeDatabaseError(err)
+		return nil, fmt.Errorf("error counting %s values of catalog models: %w", name, err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Value] = row.Count
+	}
+
+	return counts, nil
+}
+
 func applyCatalogModelListFilters(query *gorm.DB, listOptions *models.CatalogModelListOptions) *gorm.DB {
 	contextTable := utils.GetTableName(query.Statement.DB, &schema.Context{})
 
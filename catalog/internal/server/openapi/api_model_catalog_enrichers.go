@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/catalog/internal/catalog"
+)
+
+// EnricherStatusList is the status of every configured catalog enricher.
+type EnricherStatusList struct {
+	Items []catalog.EnricherStatus `json:"items"`
+	Size  int32                    `json:"size"`
+}
+
+// ModelCatalogEnrichersAPIController binds http requests for the catalog enrichment status
+// to the enrichment pipeline and writes the results to the http response
+type ModelCatalogEnrichersAPIController struct {
+	pipeline *catalog.EnrichmentPipeline
+}
+
+// NewModelCatalogEnrichersAPIController creates a default model catalog enrichers api controller,
+// the pipeline is nil when no enricher is configured
+func NewModelCatalogEnrichersAPIController(pipeline *catalog.EnrichmentPipeline) *ModelCatalogEnrichersAPIController {
+	return &ModelCatalogEnrichersAPIController{
+		pipeline: pipeline,
+	}
+}
+
+// Routes returns all the api routes for the ModelCatalogEnrichersAPIController
+func (c *ModelCatalogEnrichersAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelCatalogEnrichersAPIController
+func (c *ModelCatalogEnrichersAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"FindEnrichers",
+			strings.ToUpper("Get"),
+			"/api/model_catalog/v1alpha1/enrichers",
+			c.FindEnrichers,
+		},
+	}
+}
+
+// FindEnrichers - List the catalog enrichers with the number of pending, succeeded and failed enrichments.
+func (c *ModelCatalogEnrichersAPIController) FindEnrichers(w http.ResponseWriter, r *http.Request) {
+	statuses := c.pipeline.Status()
+
+	code := http.StatusOK
+	_ = EncodeJSONResponse(&EnricherStatusList{
+		Items: statuses,
+		Size:  int32(len(statuses)),
+	}, &code, w)
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/catalog/internal/catalog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelCatalogFacetsAPIController binds http requests for the facet counts of the models listing
+// to the catalog provider and writes the results to the http response
+type ModelCatalogFacetsAPIController struct {
+	provider     catalog.APIProvider
+	sources      *catalog.SourceCollection
+	errorHandler ErrorHandler
+}
+
+// NewModelCatalogFacetsAPIController creates a default model catalog facets api controller
+func NewModelCatalogFacetsAPIController(provider catalog.APIProvider, sources *catalog.SourceCollection) *ModelCatalogFacetsAPIController {
+	return &ModelCatalogFacetsAPIController{
+		provider:     provider,
+		sources:      sources,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ModelCatalogFacetsAPIController
+func (c *ModelCatalogFacetsAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelCatalogFacetsAPIController
+func (c *ModelCatalogFacetsAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"FindModelsFacets",
+			strings.ToUpper("Get"),
+			"/api/model_catalog/v1alpha1/models/facets",
+			c.FindModelsFacets,
+		},
+	}
+}
+
+// FindModelsFacets - Count the models matching the listing parameters per provider, task, license and size bucket.
+func (c *ModelCatalogFacetsAPIController) FindModelsFacets(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	params := catalog.ListModelFacetsParams{
+		Query:       query.Get("q"),
+		FilterQuery: query.Get("filterQuery"),
+	}
+	if query.Get("source") != "" {
+		params.SourceIDs = strings.Split(query.Get("source"), ",")
+	}
+
+	if query.Get("sourceLabel") != "" {
+		if len(params.SourceIDs) > 0 {
+			err := fmt.Errorf("source and sourceLabel cannot be used together")
+			result := ErrorResponse(http.StatusBadRequest, err)
+			c.errorHandler(w, r, err, &result)
+			return
+		}
+
+		sources := c.sources.ByLabel(strings.Split(query.Get("sourceLabel"), ","))
+		if len(sources) == 0 {
+			code := http.StatusOK
+			_ = EncodeJSONResponse(&catalog.ModelFacets{}, &code, w)
+			return
+		}
+		for _, source := range sources {
+			params.SourceIDs = append(params.SourceIDs, source.Id)
+		}
+	}
+
+	facets, err := c.provider.GetModelFacets(r.Context(), params)
+	if err != nil {
+		result := ErrorResponse(api.ErrToStatus(err), err)
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+
+	code := http.StatusOK
+	_ = EncodeJSONResponse(facets, &code, w)
+}
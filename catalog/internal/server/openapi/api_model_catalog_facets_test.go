@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/catalog/internal/catalog"
+	model "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// facetsProvider records the parameters of the facet requests
+type facetsProvider struct {
+	*mockModelProvider
+	params *catalog.ListModelFacetsParams
+}
+
+func (p *facetsProvider) GetModelFacets(ctx context.Context, params catalog.ListModelFacetsParams) (*catalog.ModelFacets, error) {
+	p.params = &params
+	return &catalog.ModelFacets{
+		Provider: []catalog.FacetValue{{Value: "IBM", Count: 2}},
+	}, nil
+}
+
+func TestFindModelsFacets(t *testing.T) {
+	trueValue := true
+
+	sources := catalog.NewSourceCollection()
+	require.NoError(t, sources.Merge("", map[string]catalog.Source{
+		"source1": {CatalogSource: model.CatalogSource{Id: "source1", Name: "Source 1", Enabled: &trueValue, Labels: []string{"featured"}}},
+		"source2": {CatalogSource: model.CatalogSource{Id: "source2", Name: "Source 2", Enabled: &trueValue}},
+	}))
+
+	testCases := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedParams *catalog.ListModelFacetsParams
+	}{
+		{
+			name:           "Listing parameters are passed to the provider",
+			url:            "/api/model_catalog/v1alpha1/models/facets?source=source1,source2&q=granite&filterQuery=license%3D%27apache-2.0%27",
+			expectedStatus: http.StatusOK,
+			expectedParams: &catalog.ListModelFacetsParams{
+				Query:       "granite",
+				FilterQuery: "license='apache-2.0'",
+				SourceIDs:   []string{"source1", "source2"},
+			},
+		},
+		{
+			name:           "Source labels are resolved to sources",
+			url:            "/api/model_catalog/v1alpha1/models/facets?sourceLabel=featured",
+			expectedStatus: http.StatusOK,
+			expectedParams: &catalog.ListModelFacetsParams{
+				SourceIDs: []string{"source1"},
+			},
+		},
+		{
+			name:           "Unknown source label returns no facets",
+			url:            "/api/model_catalog/v1alpha1/models/facets?sourceLabel=missing",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Source and source label cannot be used together",
+			url:            "/api/model_catalog/v1alpha1/models/facets?source=source1&sourceLabel=featured",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider := &facetsProvider{mockModelProvider: &mockModelProvider{}}
+			router := NewRouter(NewModelCatalogFacetsAPIController(provider, sources))
+
+			req := httptest.NewRequest("GET", tc.url, nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			assert.Equal(t, tc.expectedParams, provider.params)
+
+			if tc.expectedStatus == http.StatusOK {
+				var facets catalog.ModelFacets
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &facets))
+				if tc.expectedParams != nil {
+					assert.Equal(t, []catalog.FacetValue{{Value: "IBM", Count: 2}}, facets.Provider)
+				}
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ type ModelCatalogServiceAPIService struct {
 	sources          *catalog.SourceCollection
 	labels           *catalog.LabelCollection
 	sourceRepository models.CatalogSourceRepository
+	policies         *catalog.AccessPolicies
 }
 
 // GetAllModelArtifacts retrieves all model artifacts for a given model from the specified source.
@@ -54,6 +55,10 @@ func (m *ModelCatalogServiceAPIService) GetAllModelArtifacts(ctx context.Context
 		pageSizeInt = int32(parsed)
 	}
 
+	if !m.allowsModelByName(ctx, sourceID, modelName) {
+		return notFound(fmt.Sprintf("No model found '%s' in source '%s'", modelName, sourceID)), nil
+	}
+
 	// Handle multiple artifact types
 	var artifactTypesFilter []string
 
@@ -106,6 +111,10 @@ func (m *ModelCatalogServiceAPIService) GetAllModelPerformanceArtifacts(ctx cont
 		pageSizeInt = int32(parsed)
 	}
 
+	if !m.allowsModelByName(ctx, sourceID, modelName) {
+		return notFound(fmt.Sprintf("No model found '%s' in source '%s'", modelName, sourceID)), nil
+	}
+
 	// Call the provider's GetPerformanceArtifacts method
 	artifacts, err := m.provider.GetPerformanceArtifacts(ctx, modelName, sourceID, catalog.ListPerformanceArtifactsParams{
 		FilterQuery:           filterQuery,
@@ -264,6 +273,17 @@ func (m *ModelCatalogServiceAPIService) FindModels(ctx context.Context, recommen
 		}
 	}
 
+	// Only search the sources the caller can see
+	if m.policies != nil {
+		sourceIDs = m.visibleSourceIDs(ctx, sourceIDs)
+		if len(sourceIDs) == 0 {
+			return Response(http.StatusOK, model.CatalogModelList{
+				Items:    []model.CatalogModel{},
+				PageSize: pageSizeInt,
+			}), nil
+		}
+	}
+
 	// Handle recommended latency sorting
 	if recommended {
 		// Build Pareto filtering parameters with defaults
@@ -311,6 +331,7 @@ func (m *ModelCatalogServiceAPIService) FindModels(ctx context.Context, recommen
 		if err != nil {
 			return ErrorResponse(http.StatusInternalServerError, fmt.Errorf("failed to find models with recommended latency: %w", err)), err
 		}
+		m.removeHiddenModels(ctx, models)
 
 		return Response(http.StatusOK, *models), nil
 	}
@@ -334,6 +355,7 @@ func (m *ModelCatalogServiceAPIService) FindModels(ctx context.Context, recommen
 	if err != nil {
 		return ErrorResponse(http.StatusInternalServerError, err), err
 	}
+	m.removeHiddenModels(ctx, &models)
 
 	return Response(http.StatusOK, models), nil
 }
@@ -368,6 +390,10 @@ func (m *ModelCatalogServiceAPIService) GetModel(ctx context.Context, sourceID,
 		return notFound("Unknown model or version"), nil
 	}
 
+	if !m.policies.AllowsModel(catalog.NamespaceFromContext(ctx), m.sourceOf(sourceID), model.GetLicense()) {
+		return notFound(fmt.Sprintf("No model found '%s' in source '%s'", modelName, sourceID)), nil
+	}
+
 	return Response(http.StatusOK, model), nil
 }
 
@@ -402,6 +428,9 @@ func (m *ModelCatalogServiceAPIService) FindSources(ctx context.Context, name st
 		if !strings.Contains(strings.ToLower(v.Name), name) {
 			continue
 		}
+		if !m.policies.AllowsSource(catalog.NamespaceFromContext(ctx), v) {
+			continue
+		}
 
 		// Merge status from database if available
 		if statuses != nil {
@@ -651,15 +680,79 @@ func genLabelCmpFunc(orderByKey string, sortOrder model.SortOrder) func(sortable
 	}
 }
 
+// sourceOf returns the source with the id, or a source with only the id if it is not configured anymore.
+func (m *ModelCatalogServiceAPIService) sourceOf(id string) model.CatalogSource {
+	if source, ok := m.sources.Get(id); ok {
+		return source
+	}
+	return model.CatalogSource{Id: id}
+}
+
+// visibleSourceIDs returns the ids of sourceIDs, or of all the sources if empty, the caller can see.
+func (m *ModelCatalogServiceAPIService) visibleSourceIDs(ctx context.Context, sourceIDs []string) []string {
+	namespace := catalog.NamespaceFromContext(ctx)
+
+	if len(sourceIDs) == 0 {
+		for id := range m.sources.All() {
+			sourceIDs = append(sourceIDs, id)
+		}
+		slices.Sort(sourceIDs)
+	}
+
+	visible := make([]string, 0, len(sourceIDs))
+	for _, id := range sourceIDs {
+		if m.policies.AllowsSource(namespace, m.sourceOf(id)) {
+			visible = append(visible, id)
+		}
+	}
+	return visible
+}
+
+// removeHiddenModels removes the models the caller can't see from a page of models, which can be shorter than its
+// page size as a result.
+func (m *ModelCatalogServiceAPIService) removeHiddenModels(ctx context.Context, list *model.CatalogModelList) {
+	if m.policies == nil || list == nil {
+		return
+	}
+
+	namespace := catalog.NamespaceFromContext(ctx)
+	list.Items = slices.DeleteFunc(list.Items, func(item model.CatalogModel) bool {
+		return !m.policies.AllowsModel(namespace, m.sourceOf(item.GetSourceId()), item.GetLicense())
+	})
+	list.Size = int32(len(list.Items))
+}
+
+// allowsModelByName returns true if the caller can see the model. The errors looking the model up are left to the
+// caller to report.
+func (m *ModelCatalogServiceAPIService) allowsModelByName(ctx context.Context, sourceID string, modelName string) bool {
+	if m.policies == nil {
+		return true
+	}
+
+	namespace := catalog.NamespaceFromContext(ctx)
+	source := m.sourceOf(sourceID)
+	if !m.policies.AllowsSource(namespace, source) {
+		return false
+	}
+
+	catalogModel, err := m.provider.GetModel(ctx, modelName, sourceID)
+	if err != nil || catalogModel == nil {
+		return true
+	}
+	return m.policies.AllowsModel(namespace, source, catalogModel.GetLicense())
+}
+
 var _ ModelCatalogServiceAPIServicer = &ModelCatalogServiceAPIService{}
 
-// NewModelCatalogServiceAPIService creates a default api service
-func NewModelCatalogServiceAPIService(provider catalog.APIProvider, sources *catalog.SourceCollection, labels *catalog.LabelCollection, sourceRepository models.CatalogSourceRepository) ModelCatalogServiceAPIServicer {
+// NewModelCatalogServiceAPIService creates a default api service. policies restricts the sources and models visible
+// to the namespaces of the callers, nil allows everything.
+func NewModelCatalogServiceAPIService(provider catalog.APIProvider, sources *catalog.SourceCollection, labels *catalog.LabelCollection, sourceRepository models.CatalogSourceRepository, policies *catalog.AccessPolicies) ModelCatalogServiceAPIServicer {
 	return &ModelCatalogServiceAPIService{
 		provider:         provider,
 		sources:          sources,
 		labels:           labels,
 		sourceRepository: sourceRepository,
+		policies:         policies,
 	}
 }
 
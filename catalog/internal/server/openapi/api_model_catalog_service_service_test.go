@@ -13,6 +13,7 @@ import (
 	"github.com/kubeflow/model-registry/catalog/internal/catalog"
 	dbmodels "github.com/kubeflow/model-registry/catalog/internal/db/models"
 	model "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"github.com/kubeflow/model-registry/internal/apiutils"
 	mrmodels "github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,7 +306,7 @@ func TestFindModels(t *testing.T) {
 				models: tc.mockModels,
 			}
 
-			service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil, nil)
 
 			resp, err := service.FindModels(
 				context.Background(),
@@ -748,7 +749,7 @@ func TestFindSources(t *testing.T) {
 			sources := catalog.NewSourceCollection()
 			sources.Merge("", tc.catalogs)
 			sourceLabels := catalog.NewLabelCollection()
-			service := NewModelCatalogServiceAPIService(&mockModelProvider{}, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(&mockModelProvider{}, sources, sourceLabels, nil, nil)
 
 			// Call FindSources
 			resp, err := service.FindSources(
@@ -1043,7 +1044,7 @@ func TestFindLabels(t *testing.T) {
 			labelCollection := catalog.NewLabelCollection()
 			labelCollection.Merge("test-source", tc.labels)
 
-			service := NewModelCatalogServiceAPIService(&mockModelProvider{}, sources, labelCollection, nil)
+			service := NewModelCatalogServiceAPIService(&mockModelProvider{}, sources, labelCollection, nil, nil)
 
 			// Call FindLabels
 			resp, err := service.FindLabels(
@@ -1179,6 +1180,10 @@ func (m *mockProviderThatFailsOnRecommended) GetFilterOptions(ctx context.Contex
 	return m.mockModelProvider.GetFilterOptions(ctx)
 }
 
+func (m *mockProviderThatFailsOnRecommended) GetModelFacets(ctx context.Context, params catalog.ListModelFacetsParams) (*catalog.ModelFacets, error) {
+	return m.mockModelProvider.GetModelFacets(ctx, params)
+}
+
 func (m *mockProviderThatFailsOnRecommended) FindModelsWithRecommendedLatency(ctx context.Context, pagination mrmodels.Pagination, paretoParams dbmodels.ParetoFilteringParams, sourceIDs []string, query string) (*model.CatalogModelList, error) {
 	return nil, fmt.Errorf("recommended sorting not implemented")
 }
@@ -1272,6 +1277,16 @@ func (m *mockModelProvider) GetFilterOptions(ctx context.Context) (*model.Filter
 	return &model.FilterOptionsList{Filters: &emptyFilters}, nil
 }
 
+func (m *mockModelProvider) GetModelFacets(ctx context.Context, params catalog.ListModelFacetsParams) (*catalog.ModelFacets, error) {
+	facets := &catalog.ModelFacets{}
+	for _, mdl := range m.models {
+		if mdl.Provider != nil {
+			facets.Provider = append(facets.Provider, catalog.FacetValue{Value: *mdl.Provider, Count: 1})
+		}
+	}
+	return facets, nil
+}
+
 func (m *mockModelProvider) FindModelsWithRecommendedLatency(ctx context.Context, pagination mrmodels.Pagination, paretoParams dbmodels.ParetoFilteringParams, sourceIDs []string, query string) (*model.CatalogModelList, error) {
 	// Basic mock implementation - just return models sorted by name
 	var allModels []*model.CatalogModel
@@ -1424,7 +1439,7 @@ func TestGetModel(t *testing.T) {
 			sources := catalog.NewSourceCollection()
 			sources.Merge("", tc.sources)
 			sourceLabels := catalog.NewLabelCollection()
-			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil, nil)
 
 			// Call GetModel
 			resp, _ := service.GetModel(
@@ -1535,7 +1550,7 @@ func TestGetAllModelArtifacts(t *testing.T) {
 			sources := catalog.NewSourceCollection()
 			sources.Merge("", tc.sources)
 			sourceLabels := catalog.NewLabelCollection()
-			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil, nil)
 
 			// Call GetAllModelArtifacts
 			resp, _ := service.GetAllModelArtifacts(
@@ -1594,7 +1609,7 @@ func TestFindModelsFilterOptions(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			sources := catalog.NewSourceCollection()
 			sourceLabels := catalog.NewLabelCollection()
-			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil, nil)
 
 			resp, err := service.FindModelsFilterOptions(context.Background())
 
@@ -1754,7 +1769,7 @@ func TestGetAllModelPerformanceArtifacts(t *testing.T) {
 			})
 			sourceLabels := catalog.NewLabelCollection()
 
-			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil, nil)
 
 			resp, err := service.GetAllModelPerformanceArtifacts(
 				context.Background(),
@@ -1827,7 +1842,7 @@ func TestFindModelsRecommended(t *testing.T) {
 		},
 	}
 
-	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil)
+	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil, nil)
 
 	// Test recommended=true with default parameters
 	resp, err := service.FindModels(
@@ -1876,7 +1891,7 @@ func TestFindModelsRecommendedWithCustomParams(t *testing.T) {
 		},
 	}
 
-	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil)
+	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil, nil)
 
 	// Test with custom latency property and targetRPS
 	resp, err := service.FindModels(
@@ -1925,7 +1940,7 @@ func TestFindModelsRecommendedIgnoresOrderBy(t *testing.T) {
 		},
 	}
 
-	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil)
+	service := NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil, nil)
 
 	// Test that orderBy is ignored when recommended=true
 	resp, err := service.FindModels(
@@ -2033,7 +2048,7 @@ func TestGetAllModelPerformanceArtifactsWithConfigurableProperties(t *testing.T)
 			})
 			sourceLabels := catalog.NewLabelCollection()
 
-			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil)
+			service := NewModelCatalogServiceAPIService(tc.provider, sources, sourceLabels, nil, nil)
 
 			resp, err := service.GetAllModelPerformanceArtifacts(
 				context.Background(),
@@ -2056,3 +2071,63 @@ func TestGetAllModelPerformanceArtifactsWithConfigurableProperties(t *testing.T)
 		})
 	}
 }
+
+func TestAccessPolicies(t *testing.T) {
+	sources := catalog.NewSourceCollection()
+	sources.Merge("", map[string]catalog.Source{
+		"public":   {CatalogSource: model.CatalogSource{Id: "public", Name: "Public Catalog", Labels: []string{"public"}}},
+		"internal": {CatalogSource: model.CatalogSource{Id: "internal", Name: "Internal Catalog", Labels: []string{"internal"}}},
+	})
+	policies, err := catalog.NewAccessPolicies([]catalog.AccessPolicy{
+		{Name: "internal-models", SourceLabels: []string{"internal"}, Namespaces: []string{"platform"}},
+		{Name: "llama-license", Licenses: []string{"llama3.1"}, Namespaces: []string{"research", "platform"}},
+	})
+	require.NoError(t, err)
+
+	provider := &mockModelProvider{
+		models: map[string]*model.CatalogModel{
+			"granite": {Name: "granite", SourceId: apiutils.Of("public"), License: apiutils.Of("apache-2.0")},
+			"llama":   {Name: "llama", SourceId: apiutils.Of("public"), License: apiutils.Of("Llama3.1")},
+			"tuned":   {Name: "tuned", SourceId: apiutils.Of("internal"), License: apiutils.Of("apache-2.0")},
+		},
+	}
+	service := NewModelCatalogServiceAPIService(provider, sources, catalog.NewLabelCollection(), nil, policies)
+
+	modelNames := func(namespace string) []string {
+		resp, err := service.FindModels(catalog.WithNamespace(context.Background(), namespace), false, 0, "", "", "", "", nil, "", nil, "", "10", "", "", "")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.Code)
+		names := []string{}
+		for _, item := range resp.Body.(model.CatalogModelList).Items {
+			names = append(names, item.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"granite"}, modelNames(""))
+	assert.Equal(t, []string{"granite", "llama"}, modelNames("research"))
+	assert.Equal(t, []string{"granite", "llama", "tuned"}, modelNames("platform"))
+
+	sourceIDs := func(namespace string) []string {
+		resp, err := service.FindSources(catalog.WithNamespace(context.Background(), namespace), "", "10", model.ORDERBYFIELD_NAME, model.SORTORDER_ASC, "")
+		require.NoError(t, err)
+		ids := []string{}
+		for _, item := range resp.Body.(model.CatalogSourceList).Items {
+			ids = append(ids, item.Id)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"public"}, sourceIDs("research"), "the sources of the license policies remain visible")
+	assert.Equal(t, []string{"internal", "public"}, sourceIDs("platform"))
+
+	research := catalog.WithNamespace(context.Background(), "research")
+	resp, _ := service.GetModel(research, "public", "llama")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	resp, _ = service.GetModel(research, "internal", "tuned")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	resp, _ = service.GetModel(context.Background(), "public", "llama")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	resp, _ = service.GetAllModelArtifacts(context.Background(), "public", "llama", nil, nil, "", "10", "", model.SORTORDER_ASC, "")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	resp, _ = service.GetAllModelArtifacts(research, "public", "llama", nil, nil, "", "10", "", model.SORTORDER_ASC, "")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
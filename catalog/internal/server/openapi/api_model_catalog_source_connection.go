@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/catalog/internal/catalog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelCatalogSourceConnectionAPIController binds http requests testing the connection of a catalog source
+// to its upstream and writes the results to the http response
+type ModelCatalogSourceConnectionAPIController struct {
+	sources      *catalog.SourceCollection
+	errorHandler ErrorHandler
+}
+
+// NewModelCatalogSourceConnectionAPIController creates a default model catalog source connection api controller
+func NewModelCatalogSourceConnectionAPIController(sources *catalog.SourceCollection) *ModelCatalogSourceConnectionAPIController {
+	return &ModelCatalogSourceConnectionAPIController{
+		sources:      sources,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ModelCatalogSourceConnectionAPIController
+func (c *ModelCatalogSourceConnectionAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelCatalogSourceConnectionAPIController
+func (c *ModelCatalogSourceConnectionAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"TestSourceConnection",
+			strings.ToUpper("Post"),
+			"/api/model_catalog/v1alpha1/sources/{source_id}:testConnection",
+			c.TestSourceConnection,
+		},
+	}
+}
+
+// TestSourceConnection - Validate the configuration and credentials of a source against its upstream, without syncing it.
+func (c *ModelCatalogSourceConnectionAPIController) TestSourceConnection(w http.ResponseWriter, r *http.Request) {
+	sourceIdParam := chi.URLParam(r, "source_id")
+	if sourceIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"source_id"}, nil)
+		return
+	}
+
+	source, ok := c.sources.AllSources()[sourceIdParam]
+	if !ok {
+		err := fmt.Errorf("source %s not found: %w", sourceIdParam, api.ErrNotFound)
+		result := ErrorResponse(http.StatusNotFound, err)
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+
+	test, err := catalog.CheckSourceConnection(r.Context(), source)
+	if err != nil {
+		result := ErrorResponse(api.ErrToStatus(err), err)
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+
+	code := http.StatusOK
+	_ = EncodeJSONResponse(test, &code, w)
+}
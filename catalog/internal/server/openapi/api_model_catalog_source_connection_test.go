@@ -0,0 +1,69 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/catalog/internal/catalog"
+	model "github.com/kubeflow/model-registry/catalog/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestSourceConnection(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.yaml"), []byte("source: test\nmodels: []\n"), 0o600))
+
+	sources := catalog.NewSourceCollection()
+	require.NoError(t, sources.Merge("", map[string]catalog.Source{
+		"local": {
+			CatalogSource: model.CatalogSource{Id: "local", Name: "Local"},
+			Type:          "yaml",
+			Properties:    map[string]any{"yamlCatalogPath": filepath.Join(dir, "models.yaml")},
+		},
+		"broken": {
+			CatalogSource: model.CatalogSource{Id: "broken", Name: "Broken"},
+			Type:          "yaml",
+			Properties:    map[string]any{"yamlCatalogPath": filepath.Join(dir, "missing.yaml")},
+		},
+		"custom": {
+			CatalogSource: model.CatalogSource{Id: "custom", Name: "Custom"},
+			Type:          "custom",
+		},
+	}))
+
+	router := NewRouter(NewModelCatalogSourceConnectionAPIController(sources))
+
+	testCases := []struct {
+		name            string
+		sourceId        string
+		expectedStatus  int
+		expectedSuccess bool
+	}{
+		{"Reachable source", "local", http.StatusOK, true},
+		{"Misconfigured source", "broken", http.StatusOK, false},
+		{"Unsupported catalog type", "custom", http.StatusBadRequest, false},
+		{"Unknown source", "missing", http.StatusNotFound, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/model_catalog/v1alpha1/sources/"+tc.sourceId+":testConnection", nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.expectedStatus, resp.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var result catalog.SourceConnectionTest
+				require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &result))
+				assert.Equal(t, tc.sourceId, result.SourceId)
+				assert.Equal(t, tc.expectedSuccess, result.Success)
+				assert.NotEmpty(t, result.Checks)
+			}
+		})
+	}
+}
@@ -416,7 +416,7 @@ func setupTestServer(t *testing.T, provider catalog.APIProvider) (chi.Router, op
 	sourceLabels := catalog.NewLabelCollection()
 
 	// Create service and controller
-	service := openapi.NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil)
+	service := openapi.NewModelCatalogServiceAPIService(provider, sources, sourceLabels, nil, nil)
 	controller := openapi.NewModelCatalogServiceAPIController(service)
 
 	// Create router with proper routing
@@ -537,6 +537,10 @@ func (m *mockPerformanceProvider) GetFilterOptions(ctx context.Context) (*model.
 	return &model.FilterOptionsList{}, nil
 }
 
+func (m *mockPerformanceProvider) GetModelFacets(ctx context.Context, params catalog.ListModelFacetsParams) (*catalog.ModelFacets, error) {
+	return &catalog.ModelFacets{}, nil
+}
+
 func (m *mockPerformanceProvider) FindModelsWithRecommendedLatency(ctx context.Context, pagination mrmodels.Pagination, paretoParams dbmodels.ParetoFilteringParams, sourceIDs []string, query string) (*model.CatalogModelList, error) {
 	// Basic mock implementation - just return models sorted by name
 	var allModels []*model.CatalogModel
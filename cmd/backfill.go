@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/backfill"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillCfg            backfill.Config
+	backfillReachability   reachability.Config
+	backfillComputeDigests bool
+
+	// backfillCmd represents the backfill command
+	backfillCmd = &cobra.Command{
+		Use:   "backfill",
+		Short: "Sets the properties of existing entities of a model registry server",
+		Long: `This command sets the properties of the entities registered before the features computing them, through the
+REST api of a model registry server.`,
+	}
+
+	// backfillArtifactsCmd represents the backfill artifacts command
+	backfillArtifactsCmd = &cobra.Command{
+		Use:   "artifacts",
+		Short: "Sets the properties of existing model artifacts",
+		Long: `This command walks the model artifacts of a model registry server in the order of their ids.
+
+With --compute-digests, the content of the http(s)://, s3:// and oci:// uris of the artifacts without digest is read
+to save its sha256 and size in their custom properties "digest" and "size_bytes", used by artifact deduplication. The
+uris that can't be read are reported and skipped. The reads are limited to --rate artifacts per second, and an
+interrupted backfill with a --state-file resumes after the last artifact backfilled when run again.`,
+		RunE: runBackfillArtifacts,
+	}
+)
+
+func runBackfillArtifacts(cmd *cobra.Command, args []string) error {
+	if !backfillComputeDigests {
+		return errors.New("nothing to backfill, set --compute-digests")
+	}
+	if backfillCfg.Rate < 0 {
+		return fmt.Errorf("invalid rate %v, must not be negative", backfillCfg.Rate)
+	}
+
+	backfiller := backfill.NewBackfiller(backfillCfg, reachability.NewDigester(backfillReachability))
+	summary, err := backfiller.ComputeDigests(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("backfilled %s before failing: %w", summary, err)
+	}
+
+	glog.Infof("Backfilled %s", summary)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(backfillCmd)
+	backfillCmd.AddCommand(backfillArtifactsCmd)
+
+	backfillCmd.PersistentFlags().StringVar(&backfillCfg.URL, "url", "http://localhost:8080", "Base url of the model registry server")
+	backfillCmd.PersistentFlags().StringVar(&backfillCfg.Token, "token", "", "Bearer token sent to the model registry server")
+	backfillCmd.PersistentFlags().Float64Var(&backfillCfg.Rate, "rate", 10, "Maximum number of entities backfilled per second, unlimited when 0")
+	backfillCmd.PersistentFlags().StringVar(&backfillCfg.StateFile, "state-file", "", "File recording the progress of the backfill, to resume it when interrupted")
+	backfillCmd.PersistentFlags().IntVar(&backfillCfg.PageSize, "page-size", backfill.DefaultPageSize, "Number of entities listed at once")
+
+	backfillArtifactsCmd.Flags().BoolVar(&backfillComputeDigests, "compute-digests", false, "Compute the digest and size of the artifact uris")
+	backfillArtifactsCmd.Flags().StringVar(&backfillReachability.S3Endpoint, "s3-endpoint", "", "S3 compatible endpoint of the s3:// uris, defaults to AWS, the uris with another endpoint query parameter are not read")
+	backfillArtifactsCmd.Flags().StringVar(&backfillReachability.S3Region, "s3-region", "", "S3 region of the s3:// uris")
+	backfillArtifactsCmd.Flags().BoolVar(&backfillReachability.AllowPrivateNetworks, "allow-private-networks", false, "Read the http:// and oci:// uris of the loopback, private and link-local addresses, refused otherwise")
+}
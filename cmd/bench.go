@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/bench"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchCfg = bench.Config{
+		URL:               "http://localhost:8080",
+		Models:            100,
+		VersionsPerModel:  5,
+		Experiments:       10,
+		RunsPerExperiment: 20,
+		Concurrency:       8,
+		Duration:          30 * time.Second,
+	}
+	benchMix string
+
+	// benchCmd represents the bench command
+	benchCmd = &cobra.Command{
+		Use:   "bench",
+		Short: "Generates load against a model registry server and reports latency percentiles",
+		Long: `This command seeds a model registry server with registered models, model versions and experiment runs,
+then replays a weighted mix of read and write requests and reports the latency percentiles of each operation.
+
+The operations are get-model, list-models, filter-models (a filter query on the name and a custom property),
+list-versions, create-model, update-version and create-run. The seeded entities are prefixed with a per run
+prefix and are not deleted.`,
+		RunE: runBench,
+	}
+)
+
+func runBench(cmd *cobra.Command, args []string) error {
+	mix, err := bench.ParseMix(benchMix)
+	if err != nil {
+		return err
+	}
+	benchCfg.Mix = mix
+
+	if benchCfg.Prefix == "" {
+		benchCfg.Prefix = fmt.Sprintf("bench-%d", time.Now().Unix())
+	}
+
+	report, err := bench.Run(cmd.Context(), benchCfg)
+	if err != nil {
+		return err
+	}
+	return report.Print(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+
+	benchCmd.Flags().StringVar(&benchCfg.URL, "url", benchCfg.URL, "Base url of the model registry server")
+	benchCmd.Flags().StringVar(&benchCfg.Token, "token", "", "Bearer token sent to the model registry server")
+	benchCmd.Flags().StringVar(&benchCfg.Prefix, "prefix", "", "Prefix of the names of the seeded entities, defaults to bench-<unix time>")
+	benchCmd.Flags().IntVar(&benchCfg.Models, "models", benchCfg.Models, "Number of registered models seeded")
+	benchCmd.Flags().IntVar(&benchCfg.VersionsPerModel, "versions-per-model", benchCfg.VersionsPerModel, "Number of model versions seeded per registered model")
+	benchCmd.Flags().IntVar(&benchCfg.Experiments, "experiments", benchCfg.Experiments, "Number of experiments seeded")
+	benchCmd.Flags().IntVar(&benchCfg.RunsPerExperiment, "runs-per-experiment", benchCfg.RunsPerExperiment, "Number of experiment runs seeded per experiment")
+	benchCmd.Flags().StringVar(&benchMix, "mix", bench.DefaultMix, "Operation mix replayed, as <operation>=<weight>[,...]")
+	benchCmd.Flags().IntVar(&benchCfg.Concurrency, "concurrency", benchCfg.Concurrency, "Number of concurrent clients")
+	benchCmd.Flags().IntVar(&benchCfg.Requests, "requests", 0, "Number of requests replayed, 0 replays them for --duration")
+	benchCmd.Flags().DurationVar(&benchCfg.Duration, "duration", benchCfg.Duration, "How long requests are replayed when --requests is 0")
+}
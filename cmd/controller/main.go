@@ -146,6 +146,8 @@ func main() {
 			os.Exit(1)
 		}
 
+		inferenceServiceController.SetEventRecorder(mgr.GetEventRecorderFor("model-registry-inferenceservice-controller"))
+
 		if err = (&controllers.InferenceServiceReconciler{
 			Client:                     mgr.GetClient(),
 			Scheme:                     mgr.GetScheme(),
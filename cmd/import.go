@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/hfimport"
+	"github.com/kubeflow/model-registry/internal/mlflowimport"
+	"github.com/spf13/cobra"
+)
+
+// ImportConfig configures the model registry server the imports write to.
+type ImportConfig struct {
+	URL       string
+	Token     string
+	StateFile string
+	PageSize  int
+	// Interval repeats the import until the command is interrupted, the import runs once when zero
+	Interval time.Duration
+}
+
+var (
+	importCfg            ImportConfig
+	importMLflowCfg      mlflowimport.Config
+	importHuggingFaceCfg hfimport.Config
+
+	// importCmd represents the import command
+	importCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Imports the models of another registry into a model registry server",
+		Long: `This command imports the models of another registry into a model registry server, through its REST api.
+
+With an --interval, the import is repeated on that schedule until the command is interrupted, a failed import being
+retried on the next run.`,
+	}
+
+	// importMLflowCmd represents the import mlflow command
+	importMLflowCmd = &cobra.Command{
+		Use:   "mlflow",
+		Short: "Imports the model registry of an MLflow tracking server",
+		Long: `This command imports the registered models and model versions of an MLflow tracking server, in the order of
+their last update.
+
+The registered models and model versions are created, or updated when imported before, with the external ids
+"mlflow:<name>" and "mlflow:<name>/<version>". Their tags are imported as string custom properties, the stage of the
+model versions in the custom property "stage" and their run in "mlflow_run_id", and the source of the model versions
+as the uri of a model artifact named after the registered model.
+
+With a --state-file, the last update time of the entities imported is recorded as high-watermark, and the next import
+only reads those updated since. The entities deleted from MLflow are not deleted from the model registry.`,
+		RunE: runImportMLflow,
+	}
+
+	// importHuggingFaceCmd represents the import huggingface command
+	importHuggingFaceCmd = &cobra.Command{
+		Use:   "huggingface",
+		Short: "Imports the model repositories of a Hugging Face Hub organization",
+		Long: `This command registers the model repositories of a Hugging Face Hub organization or user.
+
+A registered model is created, or updated when imported before, for each repository with the external id
+"hf:<repo>" and the metadata of its model card: readme, license, languages, task and library. The latest revision of
+each repository is registered as a model version named after the abbreviated revision, with the external id
+"hf:<repo>@<revision>" and a model artifact whose uri is "hf://<repo>:<revision>" listing the files of the revision.
+The provenance of the entities is recorded in the hf_repo_id, hf_url, hf_revision and hf_last_modified custom
+properties.
+
+With a --state-file, the revisions imported are recorded, and the next import skips the repositories without a new
+revision. The repositories deleted from the hub are not deleted from the model registry.`,
+		RunE: runImportHuggingFace,
+	}
+)
+
+func runImportMLflow(cmd *cobra.Command, args []string) error {
+	if importMLflowCfg.MLflowURL == "" {
+		return errors.New("missing --mlflow-url")
+	}
+	importMLflowCfg.URL, importMLflowCfg.Token = importCfg.URL, importCfg.Token
+	importMLflowCfg.StateFile, importMLflowCfg.PageSize = importCfg.StateFile, importCfg.PageSize
+
+	importer := mlflowimport.NewImporter(importMLflowCfg)
+	return runImport(cmd.Context(), func(ctx context.Context) (fmt.Stringer, error) {
+		return importer.Import(ctx)
+	})
+}
+
+func runImportHuggingFace(cmd *cobra.Command, args []string) error {
+	if importHuggingFaceCfg.Author == "" {
+		return errors.New("missing --hf-author")
+	}
+	importHuggingFaceCfg.URL, importHuggingFaceCfg.Token = importCfg.URL, importCfg.Token
+	importHuggingFaceCfg.StateFile, importHuggingFaceCfg.PageSize = importCfg.StateFile, importCfg.PageSize
+
+	importer := hfimport.NewImporter(importHuggingFaceCfg)
+	return runImport(cmd.Context(), func(ctx context.Context) (fmt.Stringer, error) {
+		return importer.Import(ctx)
+	})
+}
+
+// runImport runs the import once, or every importCfg.Interval until the command is interrupted, logging the failed
+// runs.
+func runImport(ctx context.Context, run func(ctx context.Context) (fmt.Stringer, error)) error {
+	if importCfg.Interval <= 0 {
+		summary, err := run(ctx)
+		if err != nil {
+			return fmt.Errorf("imported %s before failing: %w", summary, err)
+		}
+		glog.Infof("Imported %s", summary)
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(importCfg.Interval)
+	defer ticker.Stop()
+	for {
+		summary, err := run(ctx)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			glog.Errorf("Imported %s before failing, retrying in %s: %v", summary, importCfg.Interval, err)
+		default:
+			glog.Infof("Imported %s, next import in %s", summary, importCfg.Interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.AddCommand(importMLflowCmd)
+	importCmd.AddCommand(importHuggingFaceCmd)
+
+	importCmd.PersistentFlags().StringVar(&importCfg.URL, "url", "http://localhost:8080", "Base url of the model registry server")
+	importCmd.PersistentFlags().StringVar(&importCfg.Token, "token", "", "Bearer token sent to the model registry server")
+	importCmd.PersistentFlags().StringVar(&importCfg.StateFile, "state-file", "", "File recording the progress of the import, to only import the entities updated since")
+	importCmd.PersistentFlags().IntVar(&importCfg.PageSize, "page-size", mlflowimport.DefaultPageSize, "Number of entities searched at once")
+	importCmd.PersistentFlags().DurationVar(&importCfg.Interval, "interval", 0, "Repeat the import on this schedule until interrupted, 0 imports once")
+
+	importMLflowCmd.Flags().StringVar(&importMLflowCfg.MLflowURL, "mlflow-url", "", "Base url of the MLflow tracking server")
+	importMLflowCmd.Flags().StringVar(&importMLflowCfg.MLflowToken, "mlflow-token", "", "Bearer token sent to the MLflow tracking server")
+
+	importHuggingFaceCmd.Flags().StringVar(&importHuggingFaceCfg.Author, "hf-author", "", "Organization or user whose model repositories are imported")
+	importHuggingFaceCmd.Flags().StringVar(&importHuggingFaceCfg.HubURL, "hf-url", hfimport.DefaultHubURL, "Base url of the Hugging Face Hub")
+	importHuggingFaceCmd.Flags().StringVar(&importHuggingFaceCfg.HubToken, "hf-token", "", "Access token sent to the Hugging Face Hub, required by the private and gated repositories")
+}
@@ -1,29 +1,151 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"net"
 	"net/http"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/accesslog"
+	"github.com/kubeflow/model-registry/internal/archive"
+	"github.com/kubeflow/model-registry/internal/authz"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/conversion"
 	"github.com/kubeflow/model-registry/internal/core"
 	"github.com/kubeflow/model-registry/internal/datastore"
 	"github.com/kubeflow/model-registry/internal/datastore/embedmd"
+	"github.com/kubeflow/model-registry/internal/db"
+	"github.com/kubeflow/model-registry/internal/db/budget"
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/deploygate"
+	"github.com/kubeflow/model-registry/internal/deployment"
+	"github.com/kubeflow/model-registry/internal/downloadurl"
+	"github.com/kubeflow/model-registry/internal/encryption"
+	"github.com/kubeflow/model-registry/internal/entityschema"
+	"github.com/kubeflow/model-registry/internal/features"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"github.com/kubeflow/model-registry/internal/lastupdate"
+	"github.com/kubeflow/model-registry/internal/leaderelection"
+	"github.com/kubeflow/model-registry/internal/legacyprops"
+	"github.com/kubeflow/model-registry/internal/metadatadefaults"
+	"github.com/kubeflow/model-registry/internal/metricexport"
+	"github.com/kubeflow/model-registry/internal/metrics"
+	"github.com/kubeflow/model-registry/internal/metricstore"
+	"github.com/kubeflow/model-registry/internal/naming"
+	"github.com/kubeflow/model-registry/internal/ociupload"
+	"github.com/kubeflow/model-registry/internal/promotion"
+	"github.com/kubeflow/model-registry/internal/propertylimits"
 	"github.com/kubeflow/model-registry/internal/proxy"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/internal/recyclebin"
+	"github.com/kubeflow/model-registry/internal/reporting"
+	"github.com/kubeflow/model-registry/internal/server/grpcapi"
 	"github.com/kubeflow/model-registry/internal/server/middleware"
-	"github.com/kubeflow/model-registry/internal/server/openapi"
+	"github.com/kubeflow/model-registry/internal/stale"
+	"github.com/kubeflow/model-registry/internal/telemetry"
 	"github.com/kubeflow/model-registry/internal/tls"
+	"github.com/kubeflow/model-registry/internal/tracing"
+	"github.com/kubeflow/model-registry/internal/webhooks"
 	"github.com/kubeflow/model-registry/pkg/api"
 	"github.com/spf13/cobra"
 )
 
 type ProxyConfig struct {
-	EmbedMD       embedmd.EmbedMDConfig
-	DatastoreType string
+	EmbedMD          embedmd.EmbedMDConfig
+	DatastoreType    string
+	MetricStore      metricstore.Config
+	Archive          archive.Config
+	MetricExport     metricexport.Config
+	CacheURL         string
+	CacheTTL         time.Duration
+	CacheWarm        cache.WarmConfig
+	ExternalIdPolicy api.ExternalIdPolicy
+	ConversionHooks  []string
+	// DeploymentHook is the url of the webhook applying the deployments of model versions to the serving platform
+	DeploymentHook   string
+	Reachability     ReachabilityConfig
+	OCIUploads       OCIUploadsConfig
+	DownloadURLs     DownloadURLsConfig
+	LegacyProperties legacyprops.Mode
+	AdminToken       string
+	FeatureFlagsFile string
+	FeatureFlags     []string
+	// Namespace is the namespace the registry serves, selecting the metadata defaults injected in new entities
+	Namespace            string
+	MetadataDefaultsFile string
+	LintRules            api.LintRules
+	NamingPoliciesFile   string
+	// PromotionRegistriesFile configures the remote registries the promotions read from and copy to
+	PromotionRegistriesFile string
+	PropertyLimitsFile      string
+	DeployGatesFile         string
+	// DeduplicateArtifacts links the new model and doc artifacts with the digest of an existing one to it
+	DeduplicateArtifacts bool
+	Reporting            ReportingConfig
+	// AccessLog.Tenant is set from Namespace
+	AccessLog accesslog.Config
+	Telemetry telemetry.Config
+	Stale     stale.Config
+	// RecycleBin.TTL purges the archived registered models and model versions, kept until deleted when zero
+	RecycleBin recyclebin.Config
+	// LastUpdate backfills the last update time of the contexts from their relationships
+	LastUpdate lastupdate.Config
+	Webhooks   webhooks.Config
+	// Encryption encrypts the values of custom properties with keys of the namespaces of their entities
+	Encryption encryption.Config
+	// APITokensFile sets the bearer tokens and scopes required by the api, the api is not authenticated when empty
+	APITokensFile string
+	// FieldAccessFile restricts fields of the entities to the roles of the api tokens, redacting them for the others
+	FieldAccessFile string
+	// TenantHeader is the header of the namespace the requests without a namespaced api token are restricted to,
+	// set by the authenticating proxy in front of the registry, the namespaces are only set by the tokens when empty
+	TenantHeader string
+	// AuthzPolicyFile and AuthzSubjectAccessReview check the permissions of the callers of the api, with a static
+	// policy or the Kubernetes RBAC, the api is not authorized when both are unset
+	AuthzPolicyFile          string
+	AuthzSubjectAccessReview bool
+	AuthzUserHeader          string
+	AuthzGroupsHeader        string
+	// AuditLog records the writes of the api requests in the audit_events table with their caller, the tokens or the
+	// users of AuthzUserHeader
+	AuditLog      bool
+	RequestLimits middleware.RequestLimits
+	// GRPCPort serves the registry api over gRPC next to the REST api, disabled when 0
+	GRPCPort int
+	// Tracing.Tenant is set from Namespace
+	Tracing tracing.Config
+}
+
+// ReportingConfig enables the reporting views of the stats and leaderboard endpoints.
+type ReportingConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// ReachabilityConfig enables the verification of the model artifact uris.
+type ReachabilityConfig struct {
+	Enabled bool
+	Workers int
+	reachability.Config
+}
+
+// OCIUploadsConfig enables the uploads of the model artifacts stored in OCI registries.
+type OCIUploadsConfig struct {
+	Enabled bool
+	ociupload.Config
+}
+
+// DownloadURLsConfig enables the pre-signed download URLs of the model artifacts stored in object stores.
+type DownloadURLsConfig struct {
+	Enabled bool
+	downloadurl.Config
 }
 
 const (
@@ -39,6 +161,33 @@ var (
 		},
 	}
 
+	// backgroundJobs schedules the background jobs of the server, managed with the /admin/jobs endpoints
+	backgroundJobs = jobs.NewScheduler()
+
+	// featureFlags gate the subsystems being rolled out, managed with the /admin/features endpoints
+	featureFlags *features.Set
+
+	// reporter serves the stats and leaderboard endpoints when the reporting views are enabled
+	reporter *reporting.Reporter
+
+	// staleDetector serves the stale report when the detection of stale entities is enabled
+	staleDetector *stale.Detector
+
+	// recycleBin serves the recycle bin endpoints once connected to the database
+	recycleBin *recyclebin.Bin
+
+	// entitySchemas serves the schema endpoints once connected to the database
+	entitySchemas *entityschema.Introspector
+
+	// telemetryRouter serves the telemetry preview once connected to the database
+	telemetryRouter = proxy.NewDynamicRouter()
+
+	// webhooksRouter serves the webhook subscription endpoints once connected to the database
+	webhooksRouter = proxy.NewDynamicRouter()
+
+	// encryptionRouter serves the encryption key endpoints once connected to the database
+	encryptionRouter = proxy.NewDynamicRouter()
+
 	// proxyCmd represents the proxy command
 	proxyCmd = &cobra.Command{
 		Use:   "proxy",
@@ -99,11 +248,25 @@ func runProxyServer(cmd *cobra.Command, args []string) error {
 
 	serviceHolder := &ModelRegistryServiceHolder{}
 
+	var err error
+	if featureFlags, err = newFeatureFlags(); err != nil {
+		return err
+	}
+
 	router := proxy.NewDynamicRouter()
 
 	router.SetRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, datastoreUnavailableMessage, http.StatusServiceUnavailable)
 	}))
+	telemetryRouter.SetRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, datastoreUnavailableMessage, http.StatusServiceUnavailable)
+	}))
+	webhooksRouter.SetRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, datastoreUnavailableMessage, http.StatusServiceUnavailable)
+	}))
+	encryptionRouter.SetRouter(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, datastoreUnavailableMessage, http.StatusServiceUnavailable)
+	}))
 
 	readyChecks := []proxy.HealthChecker{}
 	generalChecks := []proxy.HealthChecker{
@@ -119,6 +282,142 @@ func runProxyServer(cmd *cobra.Command, args []string) error {
 	generalReadinessHandler := proxy.GeneralReadinessHandler(generalChecks...)
 	readinessHandler := proxy.GeneralReadinessHandler(readyChecks...)
 
+	var apiTokens *middleware.APITokens
+	if proxyCfg.APITokensFile != "" {
+		config, err := middleware.LoadAPITokens(proxyCfg.APITokensFile)
+		if err != nil {
+			return err
+		}
+		if apiTokens, err = middleware.NewAPITokens(config); err != nil {
+			return err
+		}
+
+		glog.Infof("Requiring the scopes of the %d api tokens of %s", len(config.Tokens), proxyCfg.APITokensFile)
+	}
+
+	// the admin endpoints are served to any caller without tokens, refuse it when the api is restricted otherwise
+	if proxyCfg.AdminToken == "" && apiTokens == nil && (proxyCfg.AuthzPolicyFile != "" || proxyCfg.AuthzSubjectAccessReview || proxyCfg.TenantHeader != "") {
+		return fmt.Errorf("the /admin endpoints are not authenticated without --admin-token or --api-tokens-file, set one of them with the authorization or tenancy of the api")
+	}
+	jobsHandler := middleware.RequireAdmin(proxyCfg.AdminToken, apiTokens, jobs.NewHandler(backgroundJobs))
+	featuresHandler := middleware.RequireAdmin(proxyCfg.AdminToken, apiTokens, features.NewHandler(featureFlags))
+	telemetryHandler := middleware.RequireAdmin(proxyCfg.AdminToken, apiTokens, telemetryRouter)
+	// the webhook subscriptions of a tenant are notified of the events of its namespace only
+	webhooksHandler := middleware.RequireTenantAdmin(proxyCfg.AdminToken, apiTokens, middleware.Tenants(apiTokens, proxyCfg.TenantHeader, webhooksRouter))
+	// a tenant lists and rotates the encryption keys of its namespace only
+	encryptionHandler := middleware.RequireTenantAdmin(proxyCfg.AdminToken, apiTokens, middleware.Tenants(apiTokens, proxyCfg.TenantHeader, encryptionRouter))
+	apiHandler := features.RequestOverrides(middleware.IsAdmin(proxyCfg.AdminToken))(router)
+	apiHandler = middleware.LimitRequests(proxyCfg.RequestLimits, apiHandler)
+
+	if proxyCfg.FieldAccessFile != "" {
+		if proxyCfg.GRPCPort != 0 {
+			return fmt.Errorf("the fields of --field-access-file cannot be redacted from the gRPC api, unset --grpc-port")
+		}
+		config, err := middleware.LoadFieldAccess(proxyCfg.FieldAccessFile)
+		if err != nil {
+			return err
+		}
+		fieldAccess, err := middleware.NewFieldAccess(config)
+		if err != nil {
+			return err
+		}
+		apiHandler = middleware.RedactFields(fieldAccess, apiTokens, middleware.IsAdmin(proxyCfg.AdminToken), apiHandler)
+
+		glog.Infof("Restricting the %d fields of %s to the roles of the api tokens", len(config.Fields), proxyCfg.FieldAccessFile)
+	}
+
+	if proxyCfg.TenantHeader != "" && proxyCfg.GRPCPort != 0 {
+		return fmt.Errorf("the namespaces of --tenant-header are not read from the gRPC api, unset --grpc-port")
+	}
+	authorization := middleware.Authorization{
+		UserHeader:   proxyCfg.AuthzUserHeader,
+		GroupsHeader: proxyCfg.AuthzGroupsHeader,
+		Namespace:    proxyCfg.Namespace,
+	}
+	switch {
+	case proxyCfg.AuthzPolicyFile != "" && proxyCfg.AuthzSubjectAccessReview:
+		return fmt.Errorf("only one of --authz-policy-file and --authz-subject-access-review can be set")
+	case (proxyCfg.AuthzPolicyFile != "" || proxyCfg.AuthzSubjectAccessReview) && proxyCfg.GRPCPort != 0:
+		return fmt.Errorf("the permissions of the callers are not checked on the gRPC api, unset --grpc-port")
+	case proxyCfg.AuthzPolicyFile != "":
+		config, err := authz.LoadPolicy(proxyCfg.AuthzPolicyFile)
+		if err != nil {
+			return err
+		}
+		if authorization.Checker, err = authz.NewStaticPolicy(config); err != nil {
+			return err
+		}
+
+		glog.Infof("Authorizing the api requests with the %d rules of %s", len(config.Rules), proxyCfg.AuthzPolicyFile)
+	case proxyCfg.AuthzSubjectAccessReview:
+		reviewer, err := authz.NewInClusterSubjectAccessReviewer()
+		if err != nil {
+			return err
+		}
+		authorization.Checker = reviewer
+
+		glog.Infof("Authorizing the api requests with the Kubernetes RBAC rules of %s", authz.Group)
+	}
+	apiHandler = middleware.Authorize(authorization, apiTokens, middleware.IsAdmin(proxyCfg.AdminToken), apiHandler)
+
+	if proxyCfg.AuditLog {
+		if proxyCfg.GRPCPort != 0 {
+			return fmt.Errorf("the writes of the gRPC api are not recorded in the audit log, unset --grpc-port")
+		}
+		apiHandler = middleware.Audit(apiTokens, proxyCfg.AuthzUserHeader, middleware.IsAdmin(proxyCfg.AdminToken), apiHandler)
+
+		glog.Infof("Recording the writes of the api requests in the audit log")
+	}
+	apiHandler = middleware.Callers(apiTokens, proxyCfg.AuthzUserHeader, middleware.IsAdmin(proxyCfg.AdminToken), apiHandler)
+
+	apiHandler = middleware.Tenants(apiTokens, proxyCfg.TenantHeader, apiHandler)
+	if proxyCfg.TenantHeader != "" {
+		glog.Infof("Restricting the requests to the namespace of their %s header", proxyCfg.TenantHeader)
+	}
+
+	if apiTokens != nil {
+		apiHandler = middleware.RequireScopes(apiTokens, apiHandler)
+	}
+
+	if proxyCfg.AccessLog.Enabled() {
+		proxyCfg.AccessLog.Tenant = proxyCfg.Namespace
+		accessLogger, err := accesslog.New(proxyCfg.AccessLog)
+		if err != nil {
+			return fmt.Errorf("error creating access log: %w", err)
+		}
+		defer accessLogger.Close()
+
+		// health probes are not logged
+		jobsHandler = accessLogger.Middleware(jobsHandler)
+		featuresHandler = accessLogger.Middleware(featuresHandler)
+		telemetryHandler = accessLogger.Middleware(telemetryHandler)
+		webhooksHandler = accessLogger.Middleware(webhooksHandler)
+		encryptionHandler = accessLogger.Middleware(encryptionHandler)
+		apiHandler = accessLogger.Middleware(apiHandler)
+
+		glog.Infof("Writing %s access logs to %s", proxyCfg.AccessLog.Format, proxyCfg.AccessLog.Output)
+	}
+
+	apiHandler = metrics.Middleware(apiHandler)
+
+	if proxyCfg.Tracing.Enabled() {
+		proxyCfg.Tracing.Tenant = proxyCfg.Namespace
+		shutdown, err := tracing.Setup(cmd.Context(), proxyCfg.Tracing)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				glog.Warningf("Error flushing the traces: %v", err)
+			}
+		}()
+
+		apiHandler = tracing.Middleware(apiHandler)
+
+		glog.Infof("Exporting %v of the traces to %s", proxyCfg.Tracing.SampleRatio, proxyCfg.Tracing.Endpoint)
+	}
+	metricsHandler := metrics.Handler()
+
 	// route health endpoints appropriately
 	mainHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
@@ -132,11 +431,48 @@ func runProxyServer(cmd *cobra.Command, args []string) error {
 			return
 		}
 
-		router.ServeHTTP(w, r)
+		if r.URL.Path == metrics.Path {
+			metricsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, jobs.BasePath) {
+			jobsHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, features.BasePath) {
+			featuresHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, telemetry.BasePath) {
+			telemetryHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if proxyCfg.Webhooks.Enabled && strings.HasPrefix(r.URL.Path, webhooks.BasePath) {
+			webhooksHandler.ServeHTTP(w, r)
+			return
+		}
+
+		if proxyCfg.Encryption.Enabled() && strings.HasPrefix(r.URL.Path, encryption.BasePath) {
+			encryptionHandler.ServeHTTP(w, r)
+			return
+		}
+
+		apiHandler.ServeHTTP(w, r)
 	})
 
 	errChan := make(chan error, 1)
 
+	var grpcServer *grpcapi.Server
+	if proxyCfg.GRPCPort != 0 {
+		grpcServer = grpcapi.NewServer(serviceHolder.Get, apiTokens)
+		defer grpcServer.Stop()
+		wg.Add(1)
+	}
+
 	wg.Add(2)
 
 	go func() {
@@ -167,16 +503,71 @@ func runProxyServer(cmd *cobra.Command, args []string) error {
 			return
 		}
 
-		ModelRegistryServiceAPIService := openapi.NewModelRegistryServiceAPIService(conn)
-		ModelRegistryServiceAPIController := openapi.NewModelRegistryServiceAPIController(ModelRegistryServiceAPIService)
-
-		router.SetRouter(middleware.WrapWithValidation(ModelRegistryServiceAPIController))
+		var apiRouter http.Handler = middleware.NewModelRegistryHandler(conn)
+		if reporter != nil {
+			apiRouter = reporting.NewHandler(reporter, apiRouter)
+		}
+		if staleDetector != nil {
+			apiRouter = stale.NewHandler(staleDetector, apiRouter)
+		}
+		if recycleBin != nil {
+			apiRouter = recyclebin.NewHandler(recycleBin, apiRouter)
+		}
+		if entitySchemas != nil {
+			apiRouter = entityschema.NewHandler(entitySchemas, apiRouter)
+		}
+		if proxyCfg.MetricExport.Enabled() {
+			store, err := archive.NewBlobStore(proxyCfg.MetricExport.URL, proxyCfg.MetricExport.S3Endpoint, proxyCfg.MetricExport.S3Region)
+			if err != nil {
+				errChan <- fmt.Errorf("error creating metric export store: %w", err)
+				return
+			}
+			apiRouter = metricexport.NewHandler(metricexport.NewExporter(conn, store), apiRouter)
+		}
+		if proxyCfg.OCIUploads.Enabled {
+			uploader, err := ociupload.NewUploader(conn, proxyCfg.OCIUploads.Config)
+			if err != nil {
+				errChan <- fmt.Errorf("error creating model artifact uploader: %w", err)
+				return
+			}
+			apiRouter = ociupload.NewHandler(uploader, apiRouter)
+		}
+		if proxyCfg.DownloadURLs.Enabled {
+			signer, err := downloadurl.NewSigner(proxyCfg.DownloadURLs.Config)
+			if err != nil {
+				errChan <- fmt.Errorf("error creating download url signer: %w", err)
+				return
+			}
+			apiRouter = downloadurl.NewHandler(signer, conn, apiRouter)
+		}
+		router.SetRouter(apiRouter)
 
 		// Set the model registry service in the holder for health checks AFTER router is ready
 		// This ensures the readiness probe only passes when the router can serve actual requests
 		serviceHolder.Set(conn)
+		if grpcServer != nil {
+			grpcServer.SetServing()
+		}
 	}()
 
+	if grpcServer != nil {
+		go func() {
+			defer wg.Done()
+
+			lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.Hostname, proxyCfg.GRPCPort))
+			if err != nil {
+				errChan <- fmt.Errorf("error starting gRPC server: %w", err)
+				return
+			}
+
+			glog.Infof("gRPC server started at %s:%v", cfg.Hostname, proxyCfg.GRPCPort)
+
+			if err := grpcServer.Serve(lis); err != nil {
+				errChan <- fmt.Errorf("error serving gRPC api: %w", err)
+			}
+		}()
+	}
+
 	// Start the proxy server in a separate goroutine so that we can handle
 	// errors from both the proxy server and the connection to the Datastore server.
 	go func() {
@@ -201,6 +592,37 @@ func newModelRegistryService(ds datastore.Connector) (api.ModelRegistryApi, erro
 		return nil, err
 	}
 
+	if dbConnector, ok := db.GetConnector(); ok {
+		if err := budget.Register(dbConnector.DB()); err != nil {
+			return nil, err
+		}
+		if proxyCfg.Tracing.Enabled() {
+			if err := tracing.RegisterGorm(dbConnector.DB()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the writes outside of the cached registry invalidate the cache before any background job starts
+	var redisCache cache.Cache
+	if proxyCfg.CacheURL != "" {
+		if redisCache, err = cache.NewRedisCache(proxyCfg.CacheURL); err != nil {
+			return nil, fmt.Errorf("error creating cache: %w", err)
+		}
+		if dbConnector, ok := db.GetConnector(); ok {
+			if err := dbConnector.DB().Use(cache.NewInvalidator(redisCache, proxyCfg.CacheTTL)); err != nil {
+				return nil, fmt.Errorf("error registering cache invalidator: %w", err)
+			}
+		}
+	}
+
+	// the custom properties are encrypted before the repositories serve any write
+	if proxyCfg.Encryption.Enabled() {
+		if err := startEncryption(); err != nil {
+			return nil, err
+		}
+	}
+
 	modelRegistryService := core.NewModelRegistryService(
 		getRepo[models.ArtifactRepository](repoSet),
 		getRepo[models.ModelArtifactRepository](repoSet),
@@ -216,14 +638,619 @@ func newModelRegistryService(ds datastore.Connector) (api.ModelRegistryApi, erro
 		getRepo[models.MetricRepository](repoSet),
 		getRepo[models.ParameterRepository](repoSet),
 		getRepo[models.MetricHistoryRepository](repoSet),
+		getRepo[models.PromotionRepository](repoSet),
+		getRepo[models.PromotionRunRepository](repoSet),
+		getRepo[models.ConversionJobRepository](repoSet),
+		getRepo[models.DeploymentRepository](repoSet),
+		getRepo[models.ABTestRepository](repoSet),
+		getRepo[models.LineageRepository](repoSet),
+		getRepo[models.MetricsTableRepository](repoSet),
+		getRepo[models.ModelCardRepository](repoSet),
+		getRepo[models.StageTransitionRepository](repoSet),
+		getRepo[models.TagRepository](repoSet),
+		getRepo[models.AuditEventRepository](repoSet),
 		repoSet.TypeMap(),
 	)
 
+	if dbConnector, ok := db.GetConnector(); ok {
+		modelRegistryService.SetDB(dbConnector.DB())
+	}
+
+	if err := modelRegistryService.SetExternalIdPolicy(proxyCfg.ExternalIdPolicy); err != nil {
+		return nil, err
+	}
+
+	if err := modelRegistryService.SetLintRules(proxyCfg.LintRules); err != nil {
+		return nil, err
+	}
+
+	if proxyCfg.NamingPoliciesFile != "" {
+		namingPolicies, err := naming.LoadConfig(proxyCfg.NamingPoliciesFile)
+		if err != nil {
+			return nil, err
+		}
+		enforcer, err := naming.NewEnforcer(namingPolicies)
+		if err != nil {
+			return nil, err
+		}
+		modelRegistryService.SetNamingPolicies(enforcer)
+
+		glog.Infof("Enforcing the naming policies of %s", proxyCfg.NamingPoliciesFile)
+	}
+
+	if proxyCfg.DeployGatesFile != "" {
+		deployGates, err := deploygate.LoadConfig(proxyCfg.DeployGatesFile)
+		if err != nil {
+			return nil, err
+		}
+		gates, err := deploygate.NewGates(deployGates)
+		if err != nil {
+			return nil, err
+		}
+		modelRegistryService.SetDeployGates(gates)
+
+		glog.Infof("Evaluating the deployability of the model versions with the gates of %s", proxyCfg.DeployGatesFile)
+	}
+
+	if proxyCfg.PropertyLimitsFile != "" {
+		propertyLimits, err := propertylimits.LoadConfig(proxyCfg.PropertyLimitsFile)
+		if err != nil {
+			return nil, err
+		}
+		enforcer, err := propertylimits.NewEnforcer(propertyLimits)
+		if err != nil {
+			return nil, err
+		}
+		modelRegistryService.SetPropertyLimits(enforcer)
+
+		glog.Infof("Enforcing the custom property limits of %s", proxyCfg.PropertyLimitsFile)
+	}
+
+	if proxyCfg.PromotionRegistriesFile != "" {
+		promotionRegistries, err := promotion.LoadRegistries(proxyCfg.PromotionRegistriesFile)
+		if err != nil {
+			return nil, err
+		}
+		remotes, err := promotion.NewRemotes(promotionRegistries)
+		if err != nil {
+			return nil, err
+		}
+		modelRegistryService.SetPromotionRegistries(remotes)
+
+		glog.Infof("Promoting model versions to the %d remote registries of %s", len(remotes), proxyCfg.PromotionRegistriesFile)
+	}
+
+	modelRegistryService.SetArtifactDeduplication(proxyCfg.DeduplicateArtifacts)
+
+	conversionHooks, err := conversion.ParseHooks(proxyCfg.ConversionHooks)
+	if err != nil {
+		return nil, err
+	}
+	modelRegistryService.SetConversionHooks(conversionHooks)
+
+	if proxyCfg.DeploymentHook != "" {
+		deploymentHook, err := deployment.NewWebhook(proxyCfg.DeploymentHook)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deployment hook: %w", err)
+		}
+		modelRegistryService.SetDeploymentHook(deploymentHook)
+	}
+
+	if proxyCfg.MetadataDefaultsFile != "" {
+		metadataDefaults, err := metadatadefaults.LoadConfig(proxyCfg.MetadataDefaultsFile)
+		if err != nil {
+			return nil, err
+		}
+		injector, err := metadatadefaults.NewInjector(metadataDefaults, proxyCfg.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if injector != nil {
+			modelRegistryService.SetMetadataDefaults(injector)
+
+			glog.Infof("Injecting the metadata defaults of namespace %s into new entities", proxyCfg.Namespace)
+		} else {
+			glog.Warningf("No metadata defaults for namespace %q in %s", proxyCfg.Namespace, proxyCfg.MetadataDefaultsFile)
+		}
+	}
+
+	if proxyCfg.Reachability.Enabled {
+		verifier := reachability.NewVerifier(
+			reachability.NewChecker(proxyCfg.Reachability.Config),
+			modelRegistryService.RecordArtifactReachability,
+			proxyCfg.Reachability.Workers,
+		)
+		verifier.Start(context.Background())
+
+		modelRegistryService.SetArtifactVerifier(verifier)
+
+		glog.Infof("Verifying the reachability of model artifact uris with %d workers", proxyCfg.Reachability.Workers)
+	}
+
+	if proxyCfg.MetricStore.Enabled() {
+		metricStore, err := metricstore.New(proxyCfg.MetricStore)
+		if err != nil {
+			return nil, fmt.Errorf("error creating metric store: %w", err)
+		}
+
+		modelRegistryService.SetMetricStore(metricStore)
+
+		glog.Infof("Experiment run metric history stored in the %s metric store", proxyCfg.MetricStore.Driver)
+	}
+
+	if proxyCfg.Archive.Enabled() {
+		if err := startArchiver(modelRegistryService, repoSet.TypeMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	if proxyCfg.Reporting.Enabled {
+		if err := startReporting(repoSet.TypeMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	if proxyCfg.Stale.Enabled() {
+		if err := startStaleDetection(repoSet.TypeMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := startRecycleBin(repoSet.TypeMap()); err != nil {
+		return nil, err
+	}
+
+	if proxyCfg.LastUpdate.Enabled {
+		if err := startLastUpdateReindex(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := startTelemetry(repoSet.TypeMap()); err != nil {
+		return nil, err
+	}
+
+	if proxyCfg.Webhooks.Enabled {
+		if err := startWebhooks(repoSet.TypeMap()); err != nil {
+			return nil, err
+		}
+	}
+
+	if dbConnector, ok := db.GetConnector(); ok {
+		entitySchemas, err = entityschema.NewIntrospector(dbConnector.DB(), repoSet.TypeMap(), entityschema.DefaultTTL)
+		if err != nil {
+			return nil, fmt.Errorf("error creating entity schema introspector: %w", err)
+		}
+	}
+
+	if proxyCfg.LegacyProperties != legacyprops.ModeOff {
+		if err := migrateLegacyProperties(modelRegistryService); err != nil {
+			return nil, err
+		}
+	}
+
 	glog.Infof("EmbedMD service connected")
 
+	if redisCache != nil {
+		glog.Infof("Caching registered models, model versions and artifacts for %s", proxyCfg.CacheTTL)
+
+		cachedRegistry := cache.NewModelRegistry(modelRegistryService, redisCache, proxyCfg.CacheTTL)
+		if proxyCfg.CacheWarm.Enabled() {
+			if err := backgroundJobs.Register(cachedRegistry.Job(proxyCfg.CacheWarm)); err != nil {
+				return nil, err
+			}
+
+			// every replica adds the reads it counted to the read counts shared by the replicas
+			go backgroundJobs.Run(context.Background(), cache.WarmJobName)
+
+			glog.Infof("Warming the cache with the %d most read registered models and model versions", proxyCfg.CacheWarm.Top)
+		}
+
+		return cachedRegistry, nil
+	}
+
 	return modelRegistryService, nil
 }
 
+// startArchiver rehydrates archived entities on fetch and, if a policy is set, runs the archive job on the leader replica.
+func startArchiver(modelRegistryService *core.ModelRegistryService, typesMap map[string]int32) error {
+	if err := proxyCfg.Archive.Validate(); err != nil {
+		return err
+	}
+
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	store, err := archive.NewBlobStore(proxyCfg.Archive.URL, proxyCfg.Archive.S3Endpoint, proxyCfg.Archive.S3Region)
+	if err != nil {
+		return fmt.Errorf("error creating archive store: %w", err)
+	}
+
+	archiver, err := archive.NewArchiver(dbConnector.DB(), store, proxyCfg.Archive.Policy, typesMap)
+	if err != nil {
+		return fmt.Errorf("error creating archiver: %w", err)
+	}
+
+	modelRegistryService.SetRehydrator(archiver)
+
+	if proxyCfg.Archive.Policy.After > 0 {
+		elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-archiver")
+		if err != nil {
+			return fmt.Errorf("error creating archiver leader election: %w", err)
+		}
+
+		if err := backgroundJobs.Register(archiver.Job()); err != nil {
+			return err
+		}
+
+		go elector.Run(context.Background(), func(ctx context.Context) {
+			backgroundJobs.Run(ctx, archive.JobName)
+		})
+
+		glog.Infof("Archiving model versions and experiment runs not updated for %s", proxyCfg.Archive.Policy.After)
+	}
+
+	return nil
+}
+
+// startReporting creates the reporting views and refreshes them on the leader replica.
+func startReporting(typesMap map[string]int32) error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	r, err := reporting.NewReporter(dbConnector.DB(), proxyCfg.Reporting.Interval, typesMap)
+	if err != nil {
+		return fmt.Errorf("error creating reporter: %w", err)
+	}
+
+	if err := r.Setup(context.Background()); err != nil {
+		return err
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-reporting")
+	if err != nil {
+		return fmt.Errorf("error creating reporting leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(r.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, reporting.JobName)
+	})
+
+	reporter = r
+
+	glog.Infof("Refreshing the reporting views every %s", proxyCfg.Reporting.Interval)
+
+	return nil
+}
+
+// startStaleDetection flags the stale entities on the leader replica and serves the stale report.
+func startStaleDetection(typesMap map[string]int32) error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	detector, err := stale.NewDetector(dbConnector.DB(), proxyCfg.Stale, typesMap)
+	if err != nil {
+		return fmt.Errorf("error creating stale entity detector: %w", err)
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-stale-detection")
+	if err != nil {
+		return fmt.Errorf("error creating stale detection leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(detector.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, stale.JobName)
+	})
+
+	staleDetector = detector
+
+	glog.Infof("Flagging registered models and model versions inactive for %s as stale", proxyCfg.Stale.After)
+
+	return nil
+}
+
+// startLastUpdateReindex backfills the last update time of the contexts on the leader replica.
+func startLastUpdateReindex() error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	reindexer, err := lastupdate.NewReindexer(dbConnector.DB(), proxyCfg.LastUpdate)
+	if err != nil {
+		return fmt.Errorf("error creating last update reindexer: %w", err)
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-last-update-reindex")
+	if err != nil {
+		return fmt.Errorf("error creating last update reindex leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(reindexer.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, lastupdate.JobName)
+	})
+
+	glog.Infof("Reindexing the last update time of the contexts every %s", proxyCfg.LastUpdate.Interval)
+
+	return nil
+}
+
+// startRecycleBin serves the recycle bin and, if the TTL is set, purges the expired entities from the leader replica.
+func startRecycleBin(typesMap map[string]int32) error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	bin, err := recyclebin.NewBin(dbConnector.DB(), proxyCfg.RecycleBin, typesMap)
+	if err != nil {
+		return fmt.Errorf("error creating recycle bin: %w", err)
+	}
+	recycleBin = bin
+
+	if !proxyCfg.RecycleBin.Enabled() {
+		return nil
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-recycle-bin-purge")
+	if err != nil {
+		return fmt.Errorf("error creating recycle bin purge leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(bin.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, recyclebin.JobName)
+	})
+
+	glog.Infof("Purging the registered models and model versions archived for %s", proxyCfg.RecycleBin.TTL)
+
+	return nil
+}
+
+// startTelemetry serves the telemetry preview and, if telemetry is enabled, sends the reports from the leader replica.
+func startTelemetry(typesMap map[string]int32) error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	r, err := telemetry.NewReporter(dbConnector.DB(), proxyCfg.Telemetry, typesMap, usedFeatures)
+	if err != nil {
+		return err
+	}
+
+	telemetryRouter.SetRouter(telemetry.NewHandler(r))
+
+	if !proxyCfg.Telemetry.Enabled {
+		return nil
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-telemetry")
+	if err != nil {
+		return fmt.Errorf("error creating telemetry leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(r.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, telemetry.JobName)
+	})
+
+	glog.Infof("Sending anonymized usage telemetry to %s, preview the reports at %s/preview", proxyCfg.Telemetry.Endpoint, telemetry.BasePath)
+
+	return nil
+}
+
+// startWebhooks records the entity events in the outbox, delivers them from the leader replica and serves the
+// webhook subscription endpoints.
+func startWebhooks(typesMap map[string]int32) error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	notifier, err := webhooks.NewNotifier(dbConnector.DB(), proxyCfg.Webhooks, typesMap)
+	if err != nil {
+		return fmt.Errorf("error creating webhook notifier: %w", err)
+	}
+
+	if err := notifier.Register(); err != nil {
+		return err
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-webhooks")
+	if err != nil {
+		return fmt.Errorf("error creating webhooks leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(notifier.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, webhooks.JobName)
+	})
+
+	webhooksRouter.SetRouter(webhooks.NewHandler(notifier))
+
+	glog.Infof("Delivering entity events to the webhook subscriptions of %s", webhooks.BasePath)
+
+	return nil
+}
+
+// startEncryption registers the cipher of the custom properties with the database, re-encrypts them from the leader
+// replica and serves the encryption key endpoints.
+func startEncryption() error {
+	dbConnector, ok := db.GetConnector()
+	if !ok {
+		return fmt.Errorf("database connector not initialized")
+	}
+
+	masterKey, err := encryption.LoadLocalMasterKey(proxyCfg.Encryption.MasterKeyFile)
+	if err != nil {
+		return err
+	}
+	previousMasterKeys := make([]encryption.MasterKey, 0, len(proxyCfg.Encryption.PreviousMasterKeyFiles))
+	for _, file := range proxyCfg.Encryption.PreviousMasterKeyFiles {
+		previous, err := encryption.LoadLocalMasterKey(file)
+		if err != nil {
+			return err
+		}
+		previousMasterKeys = append(previousMasterKeys, previous)
+	}
+
+	cipher, err := encryption.NewCipher(dbConnector.DB(), proxyCfg.Encryption, masterKey, previousMasterKeys...)
+	if err != nil {
+		return fmt.Errorf("error creating custom property cipher: %w", err)
+	}
+
+	if err := cipher.Setup(context.Background()); err != nil {
+		return err
+	}
+
+	if err := dbConnector.DB().Use(cipher); err != nil {
+		return fmt.Errorf("error registering custom property cipher: %w", err)
+	}
+
+	elector, err := leaderelection.NewDatabaseElector(dbConnector.DB(), "model-registry-encryption")
+	if err != nil {
+		return fmt.Errorf("error creating encryption leader election: %w", err)
+	}
+
+	if err := backgroundJobs.Register(cipher.Job()); err != nil {
+		return err
+	}
+
+	go elector.Run(context.Background(), func(ctx context.Context) {
+		backgroundJobs.Run(ctx, encryption.JobName)
+	})
+
+	encryptionRouter.SetRouter(encryption.NewHandler(cipher))
+
+	glog.Infof("Encrypting the custom properties %s with master key %s, keys managed with %s", strings.Join(proxyCfg.Encryption.Properties, ", "), masterKey.ID(), encryption.BasePath)
+
+	return nil
+}
+
+// usedFeatures returns the names of the enabled feature flags and of the configured subsystems, for telemetry.
+func usedFeatures(ctx context.Context) []string {
+	used := []string{}
+	for _, flag := range featureFlags.List(ctx) {
+		if flag.Enabled {
+			used = append(used, string(flag.Name))
+		}
+	}
+
+	for name, enabled := range map[string]bool{
+		"archive":              proxyCfg.Archive.Enabled(),
+		"cache":                proxyCfg.CacheURL != "",
+		"cache-warming":        proxyCfg.CacheURL != "" && proxyCfg.CacheWarm.Enabled(),
+		"metric-export":        proxyCfg.MetricExport.Enabled(),
+		"conversion-hooks":     len(proxyCfg.ConversionHooks) > 0,
+		"deployment-hook":      proxyCfg.DeploymentHook != "",
+		"verify-artifact-uris": proxyCfg.Reachability.Enabled,
+		"oci-uploads":          proxyCfg.OCIUploads.Enabled,
+		"download-urls":        proxyCfg.DownloadURLs.Enabled,
+		"metadata-defaults":    proxyCfg.MetadataDefaultsFile != "",
+		"property-limits":      proxyCfg.PropertyLimitsFile != "",
+		"deploy-gates":         proxyCfg.DeployGatesFile != "",
+		"artifact-dedup":       proxyCfg.DeduplicateArtifacts,
+		"reporting-views":      proxyCfg.Reporting.Enabled,
+		"stale-detection":      proxyCfg.Stale.Enabled(),
+		"recycle-bin-purge":    proxyCfg.RecycleBin.Enabled(),
+		"last-update-reindex":  proxyCfg.LastUpdate.Enabled,
+		"webhooks":             proxyCfg.Webhooks.Enabled,
+		"encryption":           proxyCfg.Encryption.Enabled(),
+		"access-log":           proxyCfg.AccessLog.Enabled(),
+		"admin-token":          proxyCfg.AdminToken != "",
+		"api-tokens":           proxyCfg.APITokensFile != "",
+		"field-access":         proxyCfg.FieldAccessFile != "",
+		"tenant-header":        proxyCfg.TenantHeader != "",
+		"authz-policy":         proxyCfg.AuthzPolicyFile != "",
+		"authz-sar":            proxyCfg.AuthzSubjectAccessReview,
+		"audit-log":            proxyCfg.AuditLog,
+		"request-limits":       proxyCfg.RequestLimits.Enabled(),
+		"grpc":                 proxyCfg.GRPCPort != 0,
+		"tracing":              proxyCfg.Tracing.Enabled(),
+		"external-id-policy-" + string(proxyCfg.ExternalIdPolicy): true,
+		"metric-store-" + string(proxyCfg.MetricStore.Driver):     true,
+	} {
+		if enabled {
+			used = append(used, name)
+		}
+	}
+
+	return used
+}
+
+// newFeatureFlags returns the feature flags set in the feature flags file, and then on the command line.
+func newFeatureFlags() (*features.Set, error) {
+	values := map[string]bool{}
+	if proxyCfg.FeatureFlagsFile != "" {
+		fileValues, err := features.LoadConfig(proxyCfg.FeatureFlagsFile)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(values, fileValues)
+	}
+
+	flagValues, err := features.ParseValues(proxyCfg.FeatureFlags)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(values, flagValues)
+
+	return features.NewSet(values)
+}
+
+// migrateLegacyProperties converts the legacy custom properties to their fields, only reporting the conversions in
+// dry-run mode.
+func migrateLegacyProperties(modelRegistryService *core.ModelRegistryService) error {
+	if err := proxyCfg.LegacyProperties.Validate(); err != nil {
+		return err
+	}
+
+	dryRun := proxyCfg.LegacyProperties == legacyprops.ModeDryRun
+	report, err := legacyprops.Migrate(modelRegistryService, dryRun)
+	if err != nil {
+		return fmt.Errorf("error migrating legacy custom properties: %w", err)
+	}
+
+	for _, change := range report.Changes {
+		glog.Infof("Legacy custom property %s", change)
+	}
+	if dryRun {
+		glog.Infof("Legacy custom properties migration dry run: %s", report)
+	} else {
+		glog.Infof("Legacy custom properties migrated: %s", report)
+	}
+
+	return nil
+}
+
 func getRepo[T any](repoSet datastore.RepoSet) T {
 	repo, err := repoSet.Repository(reflect.TypeFor[T]())
 	if err != nil {
@@ -238,6 +1265,7 @@ func init() {
 
 	proxyCmd.Flags().StringVarP(&cfg.Hostname, "hostname", "n", cfg.Hostname, "Proxy server listen hostname")
 	proxyCmd.Flags().IntVarP(&cfg.Port, "port", "p", cfg.Port, "Proxy server listen port")
+	proxyCmd.Flags().IntVar(&proxyCfg.GRPCPort, "grpc-port", 0, "Port of the gRPC api served next to the REST api on the listen hostname, see api/grpc/model_registry.proto, 0 disables it. Incompatible with --field-access-file")
 
 	proxyCmd.Flags().StringVar(&proxyCfg.EmbedMD.DatabaseType, "embedmd-database-type", "mysql", "EmbedMD database type")
 	proxyCmd.Flags().StringVar(&proxyCfg.EmbedMD.DatabaseDSN, "embedmd-database-dsn", "", "EmbedMD database DSN")
@@ -248,5 +1276,104 @@ func init() {
 	proxyCmd.Flags().StringVar(&proxyCfg.EmbedMD.TLSConfig.Cipher, "embedmd-database-ssl-cipher", "", "Colon-separated list of allowed TLS ciphers for the EmbedMD database connection. Values are from the list at https://pkg.go.dev/crypto/tls#pkg-constants e.g. 'TLS_AES_128_GCM_SHA256:TLS_CHACHA20_POLY1305_SHA256'")
 	proxyCmd.Flags().BoolVar(&proxyCfg.EmbedMD.TLSConfig.VerifyServerCert, "embedmd-database-ssl-verify-server-cert", false, "EmbedMD SSL verify server cert")
 
+	proxyCmd.Flags().StringVar((*string)(&proxyCfg.EmbedMD.SchemaCheck), "embedmd-schema-check", string(db.SchemaCheckWarn), "EmbedMD database schema drift check on startup: off, warn or strict (refuse to start)")
+	proxyCmd.Flags().IntVar(&proxyCfg.EmbedMD.PropertyPartitions, "embedmd-property-partitions", 0, "Number of hash partitions for the EmbedMD artifact and context property tables, created by the migrations and checked on startup as they can't be changed, 0 disables partitioning (PostgreSQL only)")
+	proxyCmd.Flags().StringVar((*string)(&proxyCfg.MetricStore.Driver), "metric-store", string(metricstore.DriverProperty), "Storage for the experiment run metric history: property (database property tables), timescale, influx or prometheus (remote-write, write only)")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricStore.URL, "metric-store-url", "", "Metric store TimescaleDB DSN, InfluxDB base URL or Prometheus remote-write URL")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricStore.Token, "metric-store-token", "", "Metric store InfluxDB or Prometheus API token")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricStore.Org, "metric-store-org", "", "Metric store InfluxDB organization")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricStore.Bucket, "metric-store-bucket", "", "Metric store InfluxDB bucket")
+	proxyCmd.Flags().StringVar(&proxyCfg.Archive.URL, "archive-url", "", "Blob store for archived entities, s3://bucket/prefix or a directory, enables rehydration of archived entities")
+	proxyCmd.Flags().StringVar(&proxyCfg.Archive.S3Endpoint, "archive-s3-endpoint", "", "Archive S3 compatible endpoint, defaults to AWS")
+	proxyCmd.Flags().StringVar(&proxyCfg.Archive.S3Region, "archive-s3-region", "", "Archive S3 region")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Archive.Policy.After, "archive-after", 0, "Archive the custom properties of model versions and experiment runs not updated for this long, 0 disables archiving")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Archive.Policy.Interval, "archive-interval", archive.DefaultInterval, "How often cold entities are archived")
+	proxyCmd.Flags().IntVar(&proxyCfg.Archive.Policy.BatchSize, "archive-batch-size", archive.DefaultBatchSize, "Maximum number of entities archived per run")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricExport.URL, "metric-export-url", "", "Blob store for the exports of experiment metric histories, s3://bucket/prefix or a directory, enables the metric export endpoints")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricExport.S3Endpoint, "metric-export-s3-endpoint", "", "Metric export S3 compatible endpoint, defaults to AWS")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetricExport.S3Region, "metric-export-s3-region", "", "Metric export S3 region")
+	proxyCmd.Flags().StringVar(&proxyCfg.CacheURL, "cache-url", "", "Redis URL, redis://[user:password@]host:port[/db], caching registered models, model versions and artifacts reads")
+	proxyCmd.Flags().DurationVar(&proxyCfg.CacheTTL, "cache-ttl", cache.DefaultTTL, "Maximum time cached reads are served, bounds staleness for changes made outside of the API")
+	proxyCmd.Flags().IntVar(&proxyCfg.CacheWarm.Top, "cache-warm-top", 0, "Number of the most read registered models and model versions preloaded into the cache on startup and periodically, 0 disables the warming")
+	proxyCmd.Flags().DurationVar(&proxyCfg.CacheWarm.Interval, "cache-warm-interval", 0, "How often the cache is warmed, defaults to --cache-ttl so the warmed entities don't expire")
+	proxyCmd.Flags().StringVar((*string)(&proxyCfg.ExternalIdPolicy), "external-id-policy", string(api.ExternalIdUniquePerType), "Scope in which external ids must be unique: per-type (enforced by the database) or global (across all entity types)")
+	proxyCmd.Flags().StringArrayVar(&proxyCfg.ConversionHooks, "conversion-hook", nil, "Converter of model artifacts triggered by conversion jobs, as <converter>=<webhook url>, repeatable")
+	proxyCmd.Flags().StringVar(&proxyCfg.DeploymentHook, "deployment-hook", "", "Webhook url applying the deployments of model versions to the serving platform, e.g. patching the KServe InferenceService, deployments are rolled back when it fails")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Reachability.Enabled, "verify-artifact-uris", false, "Check in the background that the uris of saved model artifacts (http, s3 and oci) are reachable, recording their size")
+	proxyCmd.Flags().IntVar(&proxyCfg.Reachability.Workers, "verify-artifact-uris-workers", reachability.DefaultWorkers, "Number of model artifact uris verified concurrently")
+	proxyCmd.Flags().StringVar(&proxyCfg.Reachability.S3Endpoint, "verify-artifact-uris-s3-endpoint", "", "S3 compatible endpoint of the s3 uris, defaults to AWS, the uris with another endpoint query parameter are not reached")
+	proxyCmd.Flags().StringVar(&proxyCfg.Reachability.S3Region, "verify-artifact-uris-s3-region", "", "S3 region of s3 uris without a defaultRegion query parameter")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Reachability.AllowPrivateNetworks, "verify-artifact-uris-allow-private-networks", false, "Reach the http and oci uris of the loopback, private and link-local addresses, e.g. the services of the cluster, refused otherwise")
+	proxyCmd.Flags().BoolVar(&proxyCfg.OCIUploads.Enabled, "oci-uploads", false, "Serve POST /api/model_registry/v1alpha3/model_versions/{id}/artifacts:upload, creating the model artifacts of oci uris after verifying their manifest, with its digest and size")
+	proxyCmd.Flags().StringVar(&proxyCfg.OCIUploads.Repository, "oci-upload-repository", "", "Repository the model directories uploaded as tar archives are pushed to, as oci://registry/repository, only existing oci uris can be registered when empty")
+	proxyCmd.Flags().Int64Var(&proxyCfg.OCIUploads.MaxSize, "oci-upload-max-size", ociupload.DefaultMaxUploadSize, "Maximum size in bytes of the uploaded model directory archives")
+	proxyCmd.Flags().StringVar(&proxyCfg.OCIUploads.Username, "oci-username", "", "Username sent to the OCI registries of the uploads, the registries are accessed anonymously when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.OCIUploads.Password, "oci-password", "", "Password or token sent to the OCI registries of the uploads")
+	proxyCmd.Flags().BoolVar(&proxyCfg.OCIUploads.PlainHTTP, "oci-plain-http", false, "Access the OCI registries of the uploads over http instead of https")
+	proxyCmd.Flags().BoolVar(&proxyCfg.DownloadURLs.Enabled, "download-urls", false, "Serve GET /api/model_registry/v1alpha3/model_artifacts/{id}/download_url, returning pre-signed URLs of the model artifacts stored in S3, GCS and Azure Blob Storage")
+	proxyCmd.Flags().DurationVar(&proxyCfg.DownloadURLs.TTL, "download-url-ttl", downloadurl.DefaultTTL, "How long the download URLs are valid, the requests can only shorten it")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.S3Endpoint, "download-url-s3-endpoint", "", "S3 compatible endpoint of the s3 uris, defaults to AWS, signing with the credentials of the AWS environment; the uris with another endpoint query parameter are not signed")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.S3Region, "download-url-s3-region", "", "S3 region of s3 uris without a defaultRegion query parameter")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.DownloadURLs.S3Buckets, "download-url-s3-buckets", nil, "Buckets of the s3 uris the download URLs are signed for, comma separated, none when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.S3WebIdentityRoleARN, "download-url-s3-web-identity-role-arn", "", "IAM role assumed with the bearer token of the callers to sign the download URLs of s3 uris with their own credentials, so that the upstream entitlements apply; the other uris can't be downloaded when set")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.S3STSEndpoint, "download-url-s3-sts-endpoint", "", "STS compatible endpoint assuming the web identity role, defaults to AWS")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.GCSCredentialsFile, "download-url-gcs-credentials-file", "", "JSON key file of the service account signing the download URLs of gs:// uris, they can't be downloaded when empty")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.DownloadURLs.GCSBuckets, "download-url-gcs-buckets", nil, "Buckets of the gs:// uris the download URLs are signed for, comma separated, none when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.AzureAccountName, "download-url-azure-account-name", "", "Azure storage account of the https://<account>.blob.core.windows.net uris, they can't be downloaded when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.DownloadURLs.AzureAccountKey, "download-url-azure-account-key", "", "Shared key of the Azure storage account signing the download URLs")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.DownloadURLs.AzureContainers, "download-url-azure-containers", nil, "Containers of the Azure storage account the download URLs are signed for, comma separated, none when empty")
+	proxyCmd.Flags().StringVar((*string)(&proxyCfg.LegacyProperties), "migrate-legacy-properties", string(legacyprops.ModeOff), "Convert legacy custom properties (owner, description, tags, stage, ...) to their fields on startup: off, dry-run (report only) or apply")
+	proxyCmd.Flags().StringVar(&proxyCfg.AdminToken, "admin-token", "", "Bearer token required by the /admin endpoints and for the "+features.Header+" per-request feature flag overrides, the api tokens with the "+middleware.AdminScope+" scope, not granted by wildcards, are also served the /admin endpoints, the global ones only without a namespace, which are not authenticated without either")
+	proxyCmd.Flags().StringVar(&proxyCfg.APITokensFile, "api-tokens-file", "", "YAML file of the bearer tokens required by the api and their scopes, as tokens: [{name: <name>, sha256: <hex token hash>, scopes: [<models|versions|artifacts|experiments|serving|registry|*>:<read|write|promote|approve|admin|*>], namespace: <namespace>}], the api is not authenticated when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.TenantHeader, "tenant-header", "", "Header of the namespace the api requests are restricted to, set by the authenticating proxy in front of the registry, e.g. X-Forwarded-Namespace; the api tokens with a namespace are restricted to it regardless")
+	proxyCmd.Flags().StringVar(&proxyCfg.AuthzPolicyFile, "authz-policy-file", "", "YAML file of the permissions of the callers of the api, as rules: [{users: [<user>], groups: [<group>], namespaces: [<namespace|*>], entityTypes: [<registeredmodels|modelversions|artifacts|experiments|experimentruns|servingenvironments|inferenceservices|registry|*>], verbs: [<read|write|admin|*>]}], the callers are the api tokens by name or the users of --authz-user-header")
+	proxyCmd.Flags().BoolVar(&proxyCfg.AuthzSubjectAccessReview, "authz-subject-access-review", false, "Check the permissions of the callers of the api with Kubernetes SubjectAccessReviews on the resources of the "+authz.Group+" group, the read, write and admin verbs being get, update and delete")
+	proxyCmd.Flags().StringVar(&proxyCfg.AuthzUserHeader, "authz-user-header", "X-Remote-User", "Header of the user of the api requests without an api token, set by the authenticating proxy in front of the registry")
+	proxyCmd.Flags().StringVar(&proxyCfg.AuthzGroupsHeader, "authz-groups-header", "X-Remote-Group", "Header of the groups of the user of the api requests without an api token, comma separated or repeated")
+	proxyCmd.Flags().BoolVar(&proxyCfg.AuditLog, "audit-log", false, "Record the creations, updates, state transitions and deletions of the api requests with their caller and the changed fields in the audit log listed at /audit_events, the callers are the api tokens by name or the users of --authz-user-header")
+	proxyCmd.Flags().StringVar(&proxyCfg.FieldAccessFile, "field-access-file", "", "YAML file of the fields of the entities restricted to roles of the api tokens, as fields: [{field: <name|customProperties.<name>>, roles: [<role>]}], redacted from the api responses of the other callers, the admin token sees all the fields")
+	proxyCmd.Flags().DurationVar(&proxyCfg.RequestLimits.MaxTimeout, "max-request-timeout", 0, "Maximum time an api request runs, failing its queries with 504 past it, also capping the timeouts requested with the "+middleware.RequestTimeoutHeader+" header, 0 is unbounded")
+	proxyCmd.Flags().Int64Var(&proxyCfg.RequestLimits.Budget.Statements, "max-request-statements", 0, "Maximum number of SQL statements an api request runs before failing with 413, 0 is unlimited")
+	proxyCmd.Flags().Int64Var(&proxyCfg.RequestLimits.Budget.Rows, "max-request-rows", 0, "Maximum number of rows the queries of an api request read before failing with 413, 0 is unlimited")
+	proxyCmd.Flags().StringVar(&proxyCfg.FeatureFlagsFile, "feature-flags-file", "", "YAML file setting feature flags, as features: {<flag>: <bool>}")
+	proxyCmd.Flags().StringArrayVar(&proxyCfg.FeatureFlags, "feature-flag", nil, "Feature flag set as <flag>=<bool>, overriding the feature flags file, repeatable. Flags: "+strings.Join(features.Names(), ", "))
+	proxyCmd.Flags().StringVar(&proxyCfg.Namespace, "namespace", "", "Namespace the registry serves, set from the pod namespace when deployed, selecting the metadata defaults")
+	proxyCmd.Flags().StringVar(&proxyCfg.MetadataDefaultsFile, "metadata-defaults-file", "", "YAML file of the default custom properties and tags injected into the entities created in each namespace, as namespaces: {<namespace>: {customProperties: {<name>: <value>}, tags: [<tag>]}}")
+	proxyCmd.Flags().Int32Var(&proxyCfg.LintRules.MaxModelVersions, "lint-max-model-versions", api.DefaultLintRules.MaxModelVersions, "Number of versions of a registered model from which writes warn to archive the old ones, 0 disables the warning")
+	proxyCmd.Flags().IntVar(&proxyCfg.LintRules.MaxCustomProperties, "lint-max-custom-properties", api.DefaultLintRules.MaxCustomProperties, "Number of custom properties of an entity above which writes warn, 0 disables the warning")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.LintRules.DeprecatedURISchemes, "lint-deprecated-uri-schemes", nil, "Comma-separated artifact uri schemes writes warn are deprecated, e.g. 'http,gs'")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Reporting.Enabled, "reporting-views", false, "Maintain the reporting views (models per stage, latest evaluation per version, runs per experiment per week) and serve them on the "+reporting.BasePath+" endpoints")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Reporting.Interval, "reporting-refresh-interval", reporting.DefaultInterval, "How often the reporting views are refreshed, bounding the staleness of the stats endpoints")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Stale.After, "stale-after", 0, "Flag the registered models and model versions neither updated nor deployed for this long as stale and list them on "+stale.BasePath+"/stale, 0 disables the detection")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Stale.Interval, "stale-interval", stale.DefaultInterval, "How often stale entities are looked for")
+	proxyCmd.Flags().DurationVar(&proxyCfg.RecycleBin.TTL, "recycle-bin-ttl", 0, "Permanently delete the registered models and model versions archived for this long, listed on "+recyclebin.BasePath+", 0 keeps them until deleted")
+	proxyCmd.Flags().DurationVar(&proxyCfg.RecycleBin.Interval, "recycle-bin-purge-interval", recyclebin.DefaultInterval, "How often the expired entities of the recycle bin are purged")
+	proxyCmd.Flags().BoolVar(&proxyCfg.LastUpdate.Enabled, "last-update-reindex", false, "Backfill the last update time of the contexts whose artifacts, child contexts or executions were created after their last update")
+	proxyCmd.Flags().DurationVar(&proxyCfg.LastUpdate.Interval, "last-update-reindex-interval", lastupdate.DefaultInterval, "How often the last update times are reindexed")
+	proxyCmd.Flags().IntVar(&proxyCfg.LastUpdate.BatchSize, "last-update-reindex-batch-size", lastupdate.DefaultBatchSize, "Number of contexts reindexed at once")
+	proxyCmd.Flags().DurationVar(&proxyCfg.LastUpdate.Pause, "last-update-reindex-pause", lastupdate.DefaultPause, "Pause between two batches of the reindex, throttling its load on the database")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Webhooks.Enabled, "webhooks", false, "Notify the webhook subscriptions managed with the "+webhooks.BasePath+" endpoints of the creations, updates and deletions of the entities, recorded in an outbox table and delivered from the leader replica")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Webhooks.Interval, "webhooks-interval", webhooks.DefaultInterval, "How often the webhook events recorded in the outbox are delivered")
+	proxyCmd.Flags().IntVar(&proxyCfg.Webhooks.MaxAttempts, "webhooks-max-attempts", webhooks.DefaultMaxAttempts, "Number of delivery attempts of a webhook event before it is dropped, retried with an exponential backoff")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Webhooks.Timeout, "webhooks-timeout", webhooks.DefaultTimeout, "Maximum time a webhook subscription takes to respond to a notification")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Webhooks.AllowPrivateNetworks, "webhooks-allow-private-networks", false, "Deliver the webhook notifications to the loopback, private and link-local addresses, e.g. the services of the cluster, refused otherwise")
+	proxyCmd.Flags().StringVar(&proxyCfg.Encryption.MasterKeyFile, "encryption-master-key-file", "", "File of the base64 encoded 32 bytes master key wrapping the keys of the namespaces encrypting the --encrypted-custom-properties, e.g. a secret synced from the key management service, managed with the "+encryption.BasePath+" endpoints")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.Encryption.PreviousMasterKeyFiles, "encryption-previous-master-key-files", nil, "Comma-separated files of the master keys rotated out, unwrapping the keys until they are wrapped with the master key")
+	proxyCmd.Flags().StringSliceVar(&proxyCfg.Encryption.Properties, "encrypted-custom-properties", nil, "Comma-separated names of the custom properties whose string values are encrypted at rest, they can't filter, search or order the lists")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Encryption.Interval, "encryption-reencrypt-interval", encryption.DefaultInterval, "How often the encrypted custom properties are re-encrypted with the latest keys of their namespaces and master key")
+	proxyCmd.Flags().StringVar((*string)(&proxyCfg.AccessLog.Format), "access-log-format", string(accesslog.FormatOff), "Structured access logs, including the tenant and the entity touched: w3c (W3C extended log file) or otlp (OTLP/HTTP collector), disabled when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.AccessLog.Output, "access-log-output", "", "Access log file with the w3c format, or OTLP/HTTP logs endpoint with the otlp format, e.g. http://collector:4318/v1/logs")
+	proxyCmd.Flags().StringArrayVar(&proxyCfg.AccessLog.Headers, "access-log-otlp-header", nil, "Header of the OTLP access log exports as <name>=<value>, e.g. for authentication, repeatable")
+	proxyCmd.Flags().StringVar(&proxyCfg.Tracing.Endpoint, "tracing-endpoint", "", "OTLP/HTTP traces endpoint the spans of the api requests, repository operations and SQL statements are exported to, e.g. http://collector:4318/v1/traces, disabled when empty")
+	proxyCmd.Flags().StringArrayVar(&proxyCfg.Tracing.Headers, "tracing-otlp-header", nil, "Header of the OTLP trace exports as <name>=<value>, e.g. for authentication, repeatable")
+	proxyCmd.Flags().Float64Var(&proxyCfg.Tracing.SampleRatio, "tracing-sample-ratio", 1, "Ratio of the traces started by the registry that are exported, the requests of the traces sampled by the clients are always exported")
+	proxyCmd.Flags().BoolVar(&proxyCfg.Telemetry.Enabled, "telemetry", false, "Opt in to sending anonymized usage telemetry (entity counts by type, features in use, version) to the telemetry endpoint, preview the reports at "+telemetry.BasePath+"/preview")
+	proxyCmd.Flags().StringVar(&proxyCfg.Telemetry.Endpoint, "telemetry-endpoint", "", "URL the telemetry reports are posted to")
+	proxyCmd.Flags().DurationVar(&proxyCfg.Telemetry.Interval, "telemetry-interval", telemetry.DefaultInterval, "How often telemetry reports are sent")
+	proxyCmd.Flags().StringVar(&proxyCfg.DeployGatesFile, "deploy-gates-file", "", "YAML file of the gates of the model versions evaluated by their :deployable endpoint, as gates: {approval: {stages: [<stage>]}, signature: {property: <bool artifact custom property>}, scans: [{property: <custom property>, passValues: [<value>]}], evaluations: {propertyPrefix: <prefix of the evaluation scores>}}; without gates the model versions are deployable unless archived")
+	proxyCmd.Flags().StringVar(&proxyCfg.PromotionRegistriesFile, "promotion-registries-file", "", "YAML file of the remote registries the promotions copy the model versions to, as registries: [{name: <name>, url: <url>, tokenFile: <file of the bearer token>}], the model versions can't be promoted when empty")
+	proxyCmd.Flags().StringVar(&proxyCfg.NamingPoliciesFile, "naming-policies-file", "", "YAML file of the naming policies of the new entities of each type, as policies: {<RegisteredModel|ModelVersion|Artifact|...>: {pattern: <regex>, case: lower|upper, reservedPrefixes: [<prefix>], maxLength: <n>}}")
+	proxyCmd.Flags().StringVar(&proxyCfg.PropertyLimitsFile, "custom-property-limits-file", "", "YAML file of the custom property limits of the entities of each type, as limits: {<RegisteredModel|ModelVersion|Artifact|ExperimentRun|...>: {maxCount: <n>, maxValueSize: <bytes>, mode: reject|truncate}}")
+	proxyCmd.Flags().BoolVar(&proxyCfg.DeduplicateArtifacts, "deduplicate-artifacts", false, "Link the model and doc artifacts created with a uri and the digest custom property of an existing artifact of their type to their parent instead of duplicating it, returning the existing artifact")
 	proxyCmd.Flags().StringVar(&proxyCfg.DatastoreType, "datastore-type", proxyCfg.DatastoreType, "Datastore type")
 }
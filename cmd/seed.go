@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/seed"
+	"github.com/spf13/cobra"
+)
+
+var (
+	seedURL     string
+	seedToken   string
+	seedProfile string
+
+	// seedCmd represents the seed command
+	seedCmd = &cobra.Command{
+		Use:   "seed",
+		Short: "Populates a model registry server with a sample dataset",
+		Long: `This command populates a model registry server with a sample dataset, for demos and development.
+
+The demo profile creates registered models with labels and versions at different stages (custom property "stage"),
+their model and doc artifacts, and experiments with runs logging parameters and metric histories. The minimal profile
+creates one of each. Seeding fails if the entities of the profile already exist.`,
+		RunE: runSeed,
+	}
+)
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	profile, err := seed.GetProfile(seedProfile)
+	if err != nil {
+		return err
+	}
+
+	summary, err := seed.NewSeeder(seedURL, seedToken).Seed(cmd.Context(), profile)
+	if err != nil {
+		return fmt.Errorf("seeded %s before failing: %w", summary, err)
+	}
+
+	glog.Infof("Seeded the %s profile: %s", seedProfile, summary)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(seedCmd)
+
+	seedCmd.Flags().StringVar(&seedURL, "url", "http://localhost:8080", "Base url of the model registry server")
+	seedCmd.Flags().StringVar(&seedToken, "token", "", "Bearer token sent to the model registry server")
+	seedCmd.Flags().StringVar(&seedProfile, "profile", "demo", "Dataset seeded: "+strings.Join(seed.Profiles(), ", "))
+}
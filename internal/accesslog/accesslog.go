@@ -0,0 +1,324 @@
+// Package accesslog records the requests served by the registry as structured access logs, written to a file in the
+// W3C extended log file format or exported to an OpenTelemetry collector, for the forensic analysis of registry access.
+package accesslog
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Format is the format of the access logs.
+type Format string
+
+const (
+	// FormatOff disables the access logs.
+	FormatOff Format = ""
+	// FormatW3C appends the access logs to a file in the W3C extended log file format.
+	FormatW3C Format = "w3c"
+	// FormatOTLP exports the access logs to an OpenTelemetry collector with OTLP/HTTP.
+	FormatOTLP Format = "otlp"
+
+	// UserHeader is the header carrying the identity of the user, set by the Kubeflow gateway.
+	UserHeader = "kubeflow-userid"
+
+	bufferSize    = 1024
+	batchSize     = 100
+	flushInterval = time.Second
+	exportTimeout = 10 * time.Second
+)
+
+// Config configures the access logs.
+type Config struct {
+	Format Format
+	// Output is the log file with FormatW3C, and the OTLP/HTTP logs endpoint, e.g. http://collector:4318/v1/logs,
+	// with FormatOTLP.
+	Output string
+	// Headers are added to the OTLP export requests, e.g. for authentication, as <name>=<value>.
+	Headers []string
+	// Tenant is recorded in each entry, the namespace the registry serves.
+	Tenant string
+}
+
+// Enabled reports whether access logs are recorded.
+func (c *Config) Enabled() bool {
+	return c.Format != FormatOff
+}
+
+// Record is the access log entry of a request.
+type Record struct {
+	Time          time.Time
+	Duration      time.Duration
+	ClientIP      string
+	ForwardedFor  string
+	User          string
+	Method        string
+	Path          string
+	Query         string
+	Status        int
+	ResponseBytes int64
+	UserAgent     string
+	Tenant        string
+	// EntityType and EntityID are the entity touched by the request, EntityID is empty for lists and creations
+	EntityType string
+	EntityID   string
+}
+
+// sink writes batches of records.
+type sink interface {
+	write(ctx context.Context, records []Record) error
+	close() error
+}
+
+// Logger records the requests of its middleware in the background, so that slow outputs don't delay the responses.
+// Records are dropped when the output can't keep up.
+type Logger struct {
+	sink    sink
+	tenant  string
+	records chan Record
+	done    chan struct{}
+
+	mu      sync.Mutex
+	dropped int
+}
+
+// New returns a logger writing to the output of config, to be closed to flush the buffered records.
+func New(config Config) (*Logger, error) {
+	if config.Output == "" {
+		return nil, fmt.Errorf("access log output is required")
+	}
+
+	var (
+		s   sink
+		err error
+	)
+	switch config.Format {
+	case FormatW3C:
+		s, err = newW3CSink(config.Output)
+	case FormatOTLP:
+		s, err = newOTLPSink(config.Output, config.Headers, config.Tenant)
+	default:
+		return nil, fmt.Errorf("unsupported access log format %q, must be %s or %s", config.Format, FormatW3C, FormatOTLP)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Logger{
+		sink:    s,
+		tenant:  config.Tenant,
+		records: make(chan Record, bufferSize),
+		done:    make(chan struct{}),
+	}
+	go l.run()
+
+	return l, nil
+}
+
+// Middleware records the requests served by next.
+func (l *Logger) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		entityType, entityID := entityFromPath(r.URL.Path)
+		l.record(Record{
+			Time:          start,
+			Duration:      time.Since(start),
+			ClientIP:      clientIP(r),
+			ForwardedFor:  r.Header.Get("X-Forwarded-For"),
+			User:          r.Header.Get(UserHeader),
+			Method:        r.Method,
+			Path:          r.URL.Path,
+			Query:         r.URL.RawQuery,
+			Status:        rw.status,
+			ResponseBytes: rw.bytes,
+			UserAgent:     r.UserAgent(),
+			Tenant:        l.tenant,
+			EntityType:    entityType,
+			EntityID:      entityID,
+		})
+	})
+}
+
+func (l *Logger) record(record Record) {
+	select {
+	case l.records <- record:
+	default:
+		l.mu.Lock()
+		l.dropped++
+		l.mu.Unlock()
+	}
+}
+
+// run writes the records by batches, at least every flush interval.
+func (l *Logger) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, batchSize)
+	flush := func() {
+		l.mu.Lock()
+		dropped := l.dropped
+		l.dropped = 0
+		l.mu.Unlock()
+		if dropped > 0 {
+			glog.Warningf("Dropped %d access log records, the access log output can't keep up", dropped)
+		}
+
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		if err := l.sink.write(ctx, batch); err != nil {
+			glog.Errorf("Error writing %d access log records: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case record, ok := <-l.records:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, record)
+			if len(batch) == batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// Close writes the buffered records and closes the output, the middleware must not be serving requests anymore.
+func (l *Logger) Close() error {
+	close(l.records)
+	<-l.done
+	return l.sink.close()
+}
+
+// responseWriter captures the status and size of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// apiPath is the path prefix of the REST API.
+const apiPath = "/api/model_registry/v1alpha3/"
+
+// entityTypes maps the collections of the REST API paths to the type of their entities, singular paths find an
+// entity by name or external id.
+var entityTypes = map[string]string{
+	"registered_models":    "registered_model",
+	"registered_model":     "registered_model",
+	"model_versions":       "model_version",
+	"model_version":        "model_version",
+	"versions":             "model_version",
+	"artifacts":            "artifact",
+	"artifact":             "artifact",
+	"model_artifacts":      "model_artifact",
+	"model_artifact":       "model_artifact",
+	"experiments":          "experiment",
+	"experiment":           "experiment",
+	"experiment_runs":      "experiment_run",
+	"experiment_run":       "experiment_run",
+	"serving_environments": "serving_environment",
+	"serving_environment":  "serving_environment",
+	"inference_services":   "inference_service",
+	"inference_service":    "inference_service",
+	"serves":               "serve_model",
+	"conversion_jobs":      "conversion_job",
+	"promotions":           "promotion",
+	"promotion_runs":       "promotion_run",
+	"runs":                 "promotion_run",
+}
+
+// entityFromPath returns the deepest entity of a REST API path, e.g. the model version of
+// /registered_models/1/versions/2, or the listed type of a collection path.
+func entityFromPath(path string) (entityType, entityID string) {
+	rest, ok := strings.CutPrefix(path, apiPath)
+	if !ok {
+		return "", ""
+	}
+
+	segments := strings.Split(rest, "/")
+	for i := 0; i < len(segments); i++ {
+		// drop custom methods, e.g. registered_models:batchGet or model_versions/1:resolveArtifact
+		segment, _, _ := strings.Cut(segments[i], ":")
+		typ, ok := entityTypes[segment]
+		if !ok {
+			continue
+		}
+
+		entityType, entityID = typ, ""
+		if i+1 < len(segments) {
+			if id, _, _ := strings.Cut(segments[i+1], ":"); isID(id) {
+				entityID = id
+				i++
+			}
+		}
+	}
+
+	return entityType, entityID
+}
+
+func isID(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package accesslog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityFromPath(t *testing.T) {
+	for path, want := range map[string][2]string{
+		"/api/model_registry/v1alpha3/registered_models":                   {"registered_model", ""},
+		"/api/model_registry/v1alpha3/registered_models/1":                 {"registered_model", "1"},
+		"/api/model_registry/v1alpha3/registered_models/1/versions":        {"model_version", ""},
+		"/api/model_registry/v1alpha3/registered_models/1/versions:byName": {"model_version", ""},
+		"/api/model_registry/v1alpha3/model_versions/2:resolveArtifact":    {"model_version", "2"},
+		"/api/model_registry/v1alpha3/inference_services/3/model":          {"inference_service", "3"},
+		"/api/model_registry/v1alpha3/experiment_runs/4/metric_history":    {"experiment_run", "4"},
+		"/api/model_registry/v1alpha3/registered_model":                    {"registered_model", ""},
+		"/api/model_registry/v1alpha3/promotions/5/runs":                   {"promotion_run", ""},
+		"/api/model_registry/v1alpha3/entities:byExternalId":               {"", ""},
+		"/admin/jobs": {"", ""},
+	} {
+		entityType, entityID := entityFromPath(path)
+		assert.Equal(t, want, [2]string{entityType, entityID}, path)
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Format: FormatW3C})
+	assert.ErrorContains(t, err, "output is required")
+
+	_, err = New(Config{Format: "json", Output: "access.log"})
+	assert.ErrorContains(t, err, "unsupported access log format")
+
+	_, err = New(Config{Format: FormatOTLP, Output: "collector:4318"})
+	assert.ErrorContains(t, err, "invalid OTLP logs endpoint")
+
+	_, err = New(Config{Format: FormatOTLP, Output: "http://collector:4318/v1/logs", Headers: []string{"token"}})
+	assert.ErrorContains(t, err, "invalid OTLP header")
+}
+
+func serve(t *testing.T, logger *Logger, requests ...*http.Request) {
+	handler := logger.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = w.Write([]byte(`{"id":"1"}`))
+	}))
+	for _, r := range requests {
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	require.NoError(t, logger.Close())
+}
+
+func TestW3C(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "access.log")
+
+	logger, err := New(Config{Format: FormatW3C, Output: path, Tenant: "team-a"})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models/1?a=b", nil)
+	r.Header.Set(UserHeader, "alice@example.com")
+	r.Header.Set("User-Agent", "curl/8.0 (x86_64)")
+	serve(t, logger, r, httptest.NewRequest(http.MethodPost, "/api/model_registry/v1alpha3/registered_models", nil))
+
+	// records are appended to an existing log without repeating the directives
+	logger, err = New(Config{Format: FormatW3C, Output: path, Tenant: "team-a"})
+	require.NoError(t, err)
+	serve(t, logger, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/experiments", nil))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 7)
+	assert.Equal(t, "#Version: 1.0", lines[0])
+	assert.Equal(t, "#Fields: "+strings.Join(w3cFields, " "), lines[3])
+
+	fields := strings.Split(lines[4], " ")
+	require.Len(t, fields, len(w3cFields))
+	assert.Equal(t, []string{"192.0.2.1", "-", "alice@example.com", "GET", "/api/model_registry/v1alpha3/registered_models/1", "a=b", "200", "10"}, fields[2:10])
+	assert.Equal(t, []string{"curl/8.0+(x86_64)", "team-a", "registered_model", "1"}, fields[11:])
+
+	assert.Contains(t, lines[5], " POST /api/model_registry/v1alpha3/registered_models - 201 10 ")
+	assert.True(t, strings.HasSuffix(lines[5], " team-a registered_model -"))
+	assert.Contains(t, lines[6], " GET /api/model_registry/v1alpha3/experiments ")
+}
+
+func TestOTLP(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		payloads []otlpLogsData
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var payload otlpLogsData
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	t.Cleanup(collector.Close)
+
+	logger, err := New(Config{
+		Format:  FormatOTLP,
+		Output:  collector.URL + "/v1/logs",
+		Headers: []string{"Authorization=Bearer secret"},
+		Tenant:  "team-a",
+	})
+	require.NoError(t, err)
+
+	serve(t, logger, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/model_versions/2", nil))
+
+	require.Len(t, payloads, 1)
+	resourceLogs := payloads[0].ResourceLogs[0]
+	assert.Equal(t, "team-a", *resourceLogs.Resource.Attributes[1].Value.StringValue)
+
+	records := resourceLogs.ScopeLogs[0].LogRecords
+	require.Len(t, records, 1)
+	assert.Equal(t, "GET /api/model_registry/v1alpha3/model_versions/2 200", *records[0].Body.StringValue)
+
+	attributes := map[string]otlpAnyValue{}
+	for _, attribute := range records[0].Attributes {
+		attributes[attribute.Key] = attribute.Value
+	}
+	assert.Equal(t, "200", *attributes["http.response.status_code"].IntValue)
+	assert.Equal(t, "model_version", *attributes["model_registry.entity.type"].StringValue)
+	assert.Equal(t, "2", *attributes["model_registry.entity.id"].StringValue)
+	assert.NotContains(t, attributes, "user.id")
+}
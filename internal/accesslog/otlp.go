@@ -0,0 +1,187 @@
+package accesslog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	otlpScope = "github.com/kubeflow/model-registry/accesslog"
+	// otlpSeverityInfo is the INFO severity number of the OpenTelemetry log data model
+	otlpSeverityInfo = 9
+)
+
+// otlpSink exports the records to an OpenTelemetry collector with the OTLP/HTTP JSON encoding, the attributes follow
+// the OpenTelemetry HTTP semantic conventions.
+type otlpSink struct {
+	endpoint string
+	headers  http.Header
+	resource otlpResource
+	client   *http.Client
+}
+
+func newOTLPSink(endpoint string, headers []string, tenant string) (*otlpSink, error) {
+	if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP logs endpoint %q: must be an http or https url", endpoint)
+	}
+
+	s := &otlpSink{
+		endpoint: endpoint,
+		headers:  http.Header{},
+		resource: otlpResource{Attributes: []otlpAttribute{stringAttribute("service.name", "model-registry")}},
+		client:   &http.Client{},
+	}
+	if tenant != "" {
+		s.resource.Attributes = append(s.resource.Attributes, stringAttribute("k8s.namespace.name", tenant))
+	}
+
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q: must be <name>=<value>", header)
+		}
+		s.headers.Add(strings.TrimSpace(name), value)
+	}
+
+	return s, nil
+}
+
+type otlpLogsData struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpInstrumentationScope `json:"scope"`
+	LogRecords []otlpLogRecord          `json:"logRecords"`
+}
+
+type otlpInstrumentationScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber"`
+	SeverityText         string          `json:"severityText"`
+	Body                 otlpAnyValue    `json:"body"`
+	Attributes           []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds one of its values, 64 bits integers are strings in the OTLP JSON encoding.
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func stringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int64) otlpAttribute {
+	s := strconv.FormatInt(value, 10)
+	return otlpAttribute{Key: key, Value: otlpAnyValue{IntValue: &s}}
+}
+
+func (s *otlpSink) logRecord(record Record) otlpLogRecord {
+	body := fmt.Sprintf("%s %s %d", record.Method, record.Path, record.Status)
+	duration := record.Duration.Seconds()
+
+	attributes := []otlpAttribute{
+		stringAttribute("http.request.method", record.Method),
+		stringAttribute("url.path", record.Path),
+		intAttribute("http.response.status_code", int64(record.Status)),
+		intAttribute("http.response.body.size", record.ResponseBytes),
+		{Key: "http.server.request.duration", Value: otlpAnyValue{DoubleValue: &duration}},
+	}
+	for _, attribute := range [][2]string{
+		{"url.query", record.Query},
+		{"client.address", record.ClientIP},
+		{"http.request.header.x-forwarded-for", record.ForwardedFor},
+		{"user.id", record.User},
+		{"user_agent.original", record.UserAgent},
+		{"model_registry.tenant", record.Tenant},
+		{"model_registry.entity.type", record.EntityType},
+		{"model_registry.entity.id", record.EntityID},
+	} {
+		if attribute[1] != "" {
+			attributes = append(attributes, stringAttribute(attribute[0], attribute[1]))
+		}
+	}
+
+	timestamp := strconv.FormatInt(record.Time.UnixNano(), 10)
+	return otlpLogRecord{
+		TimeUnixNano:         timestamp,
+		ObservedTimeUnixNano: timestamp,
+		SeverityNumber:       otlpSeverityInfo,
+		SeverityText:         "INFO",
+		Body:                 otlpAnyValue{StringValue: &body},
+		Attributes:           attributes,
+	}
+}
+
+func (s *otlpSink) write(ctx context.Context, records []Record) error {
+	logRecords := make([]otlpLogRecord, 0, len(records))
+	for _, record := range records {
+		logRecords = append(logRecords, s.logRecord(record))
+	}
+
+	data, err := json.Marshal(otlpLogsData{ResourceLogs: []otlpResourceLogs{{
+		Resource: s.resource,
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpInstrumentationScope{Name: otlpScope},
+			LogRecords: logRecords,
+		}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("error encoding OTLP logs: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error exporting OTLP logs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error exporting OTLP logs: collector returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *otlpSink) close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,95 @@
+package accesslog
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// w3cFields are the fields of the W3C log, the x- prefixed ones are registry specific.
+var w3cFields = []string{
+	"date", "time", "c-ip", "cs(X-Forwarded-For)", "cs-username", "cs-method", "cs-uri-stem", "cs-uri-query",
+	"sc-status", "sc-bytes", "time-taken", "cs(User-Agent)", "x-tenant", "x-entity-type", "x-entity-id",
+}
+
+// w3cSink appends the records to a file in the W3C extended log file format, writing the directives when the file is
+// empty, so that it can be rotated by truncation.
+type w3cSink struct {
+	file *os.File
+	w    *bufio.Writer
+}
+
+func newW3CSink(path string) (*w3cSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("error opening access log file: %w", err)
+	}
+	return &w3cSink{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+func (s *w3cSink) write(_ context.Context, records []Record) error {
+	info, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		fmt.Fprintf(s.w, "#Version: 1.0\n#Software: model-registry\n#Date: %s\n#Fields: %s\n",
+			time.Now().UTC().Format(time.DateTime), strings.Join(w3cFields, " "))
+	}
+
+	for _, record := range records {
+		t := record.Time.UTC()
+		fields := []string{
+			t.Format(time.DateOnly),
+			t.Format("15:04:05.000"),
+			record.ClientIP,
+			record.ForwardedFor,
+			record.User,
+			record.Method,
+			record.Path,
+			record.Query,
+			strconv.Itoa(record.Status),
+			strconv.FormatInt(record.ResponseBytes, 10),
+			strconv.FormatFloat(record.Duration.Seconds(), 'f', 3, 64),
+			record.UserAgent,
+			record.Tenant,
+			record.EntityType,
+			record.EntityID,
+		}
+		for i, field := range fields {
+			fields[i] = w3cValue(field)
+		}
+		s.w.WriteString(strings.Join(fields, " "))
+		s.w.WriteByte('\n')
+	}
+
+	return s.w.Flush()
+}
+
+func (s *w3cSink) close() error {
+	if err := s.w.Flush(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// w3cValue escapes a field value, fields are separated by spaces and empty ones are written as a dash.
+func w3cValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '+'
+		case r < ' ' || r == 0x7f:
+			return '?'
+		}
+		return r
+	}, value)
+}
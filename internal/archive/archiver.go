@@ -0,0 +1,374 @@
+// Package archive moves the custom properties of cold model versions and experiment runs to a blob store, and
+// restores them when the entities are fetched again.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// archiveKeyProperty marks an archived entity, its value is the key of the bundle in the blob store.
+	archiveKeyProperty = "_archive_key"
+	// rehydratedAtProperty records when an entity was rehydrated, so that it is not archived again right away.
+	rehydratedAtProperty = "_archive_rehydrated_at"
+
+	bundleVersion = 1
+
+	// DefaultInterval is how often cold entities are looked for.
+	DefaultInterval = time.Hour
+	// DefaultBatchSize is the maximum number of entities archived per run.
+	DefaultBatchSize = 100
+)
+
+// archivedTypes are the context types that can be archived, with the blob store folder of their bundles.
+var archivedTypes = map[string]string{
+	defaults.ModelVersionTypeName:  "model_versions",
+	defaults.ExperimentRunTypeName: "experiment_runs",
+}
+
+// Policy selects the entities to archive.
+type Policy struct {
+	// After is how long an entity must not have been updated, or rehydrated, before it is archived.
+	After time.Duration
+	// Interval is how often cold entities are looked for, defaults to DefaultInterval.
+	Interval time.Duration
+	// BatchSize is the maximum number of entities archived per run, defaults to DefaultBatchSize.
+	BatchSize int
+}
+
+// Config configures the archive blob store and policy.
+type Config struct {
+	// URL is s3://bucket/prefix or a directory, empty disables archiving and rehydration.
+	URL        string
+	S3Endpoint string
+	S3Region   string
+	// Policy.After set to zero disables the archive job, archived entities are still rehydrated.
+	Policy Policy
+}
+
+// Validate checks that the blob store is configured when the archive job is enabled.
+func (c *Config) Validate() error {
+	if c.Policy.After < 0 {
+		return fmt.Errorf("invalid archive age threshold %s: must not be negative", c.Policy.After)
+	}
+	if c.Policy.After > 0 && c.URL == "" {
+		return fmt.Errorf("archive url is required to archive cold entities")
+	}
+
+	return nil
+}
+
+// Enabled reports whether archived entities can be rehydrated.
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// Bundle is the archived content of an entity.
+type Bundle struct {
+	Version    int                      `json:"version"`
+	EntityType string                   `json:"entity_type"`
+	EntityID   int32                    `json:"entity_id"`
+	ArchivedAt int64                    `json:"archived_at"`
+	Properties []schema.ContextProperty `json:"properties"`
+}
+
+// Rehydrator restores archived entities.
+type Rehydrator interface {
+	// Rehydrate restores the properties of the entity with the given id if it is archived, reporting whether it was.
+	Rehydrate(ctx context.Context, id int32) (bool, error)
+}
+
+// Archiver exports the custom properties of cold entities as compressed bundles and removes them from the database.
+type Archiver struct {
+	db      *gorm.DB
+	store   BlobStore
+	policy  Policy
+	folders map[int32]string
+}
+
+// NewArchiver returns an archiver for the model versions and experiment runs in db, typesMap maps type names to ids.
+// Without a policy age threshold it only rehydrates entities.
+func NewArchiver(db *gorm.DB, store BlobStore, policy Policy, typesMap map[string]int32) (*Archiver, error) {
+	if policy.Interval <= 0 {
+		policy.Interval = DefaultInterval
+	}
+	if policy.BatchSize <= 0 {
+		policy.BatchSize = DefaultBatchSize
+	}
+
+	folders := make(map[int32]string, len(archivedTypes))
+	for typeName, folder := range archivedTypes {
+		typeID, ok := typesMap[typeName]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in types map", typeName)
+		}
+		folders[typeID] = folder
+	}
+
+	return &Archiver{
+		db:      db,
+		store:   store,
+		policy:  policy,
+		folders: folders,
+	}, nil
+}
+
+// JobName is the name of the background job archiving cold entities.
+const JobName = "archive"
+
+// Job returns the background job archiving cold entities every policy interval.
+func (a *Archiver) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Archives the custom properties of cold model versions and experiment runs",
+		Interval:    a.policy.Interval,
+		Run: func(ctx context.Context) error {
+			archived, err := a.ArchiveCold(ctx)
+			if archived > 0 {
+				glog.Infof("Archived %d cold entities", archived)
+			}
+			return err
+		},
+	}
+}
+
+// ArchiveCold archives up to a batch of the entities not updated nor rehydrated within the policy threshold,
+// returning how many were archived.
+func (a *Archiver) ArchiveCold(ctx context.Context) (int, error) {
+	if a.policy.After <= 0 {
+		return 0, fmt.Errorf("invalid archive policy: the age threshold must be positive")
+	}
+
+	cutoff := time.Now().Add(-a.policy.After).UnixMilli()
+
+	db := a.db.WithContext(ctx)
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	propertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+
+	typeIDs := make([]int32, 0, len(a.folders))
+	for typeID := range a.folders {
+		typeIDs = append(typeIDs, typeID)
+	}
+
+	var candidates []schema.Context
+	err := db.Model(&schema.Context{}).
+		Where(contextTable+".type_id IN ? AND "+contextTable+".last_update_time_since_epoch < ?", typeIDs, cutoff).
+		Where("EXISTS (SELECT 1 FROM "+propertyTable+" p WHERE p.context_id = "+contextTable+".id AND p.is_custom_property = ?)", true).
+		Where("NOT EXISTS (SELECT 1 FROM "+propertyTable+" p WHERE p.context_id = "+contextTable+".id AND p.is_custom_property = ? AND (p.name = ? OR (p.name = ? AND p.double_value >= ?)))",
+			false, archiveKeyProperty, rehydratedAtProperty, cutoff).
+		Order(contextTable + ".id").
+		Limit(a.policy.BatchSize).
+		Find(&candidates).Error
+	if err != nil {
+		return 0, fmt.Errorf("error finding cold entities: %w", err)
+	}
+
+	archived := 0
+	for _, candidate := range candidates {
+		ok, err := a.archive(ctx, candidate.ID, cutoff)
+		if err != nil {
+			return archived, fmt.Errorf("error archiving entity %d: %w", candidate.ID, err)
+		}
+		if ok {
+			archived++
+		}
+	}
+
+	return archived, nil
+}
+
+// archive moves the custom properties of an entity to the blob store, unless it changed since it was selected.
+func (a *Archiver) archive(ctx context.Context, id int32, cutoff int64) (bool, error) {
+	var key string
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the entity so that concurrent updates or archivers wait for the archive to complete
+		var entity schema.Context
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&entity, id).Error; err != nil {
+			return err
+		}
+		if entity.LastUpdateTimeSinceEpoch >= cutoff {
+			return nil
+		}
+
+		var marker int64
+		if err := tx.Model(&schema.ContextProperty{}).
+			Where("context_id = ? AND name = ? AND is_custom_property = ?", id, archiveKeyProperty, false).
+			Count(&marker).Error; err != nil {
+			return err
+		}
+		if marker > 0 {
+			return nil
+		}
+
+		var properties []schema.ContextProperty
+		if err := tx.Where("context_id = ? AND is_custom_property = ?", id, true).Order("name").Find(&properties).Error; err != nil {
+			return err
+		}
+		if len(properties) == 0 {
+			return nil
+		}
+
+		now := time.Now().UnixMilli()
+		data, err := encodeBundle(&Bundle{
+			Version:    bundleVersion,
+			EntityType: a.folders[entity.TypeID],
+			EntityID:   id,
+			ArchivedAt: now,
+			Properties: properties,
+		})
+		if err != nil {
+			return err
+		}
+
+		key = fmt.Sprintf("%s/%d-%d.json.gz", a.folders[entity.TypeID], id, now)
+		if err := a.store.Put(ctx, key, data); err != nil {
+			return fmt.Errorf("error uploading archive bundle: %w", err)
+		}
+
+		if err := tx.Where("context_id = ? AND is_custom_property = ?", id, true).Delete(&schema.ContextProperty{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("context_id = ? AND name = ? AND is_custom_property = ?", id, rehydratedAtProperty, false).Delete(&schema.ContextProperty{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&schema.ContextProperty{
+			ContextID:        id,
+			Name:             archiveKeyProperty,
+			IsCustomProperty: false,
+			StringValue:      &key,
+		}).Error
+	})
+	if err != nil {
+		// The bundle is orphaned if the transaction failed after the upload
+		if key != "" {
+			if deleteErr := a.store.Delete(ctx, key); deleteErr != nil {
+				glog.Warningf("Unable to delete orphaned archive bundle %s: %v", key, deleteErr)
+			}
+		}
+		return false, err
+	}
+
+	return key != "", nil
+}
+
+// Rehydrate restores the properties of an archived entity and deletes its bundle.
+func (a *Archiver) Rehydrate(ctx context.Context, id int32) (bool, error) {
+	var key string
+
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var markers []schema.ContextProperty
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("context_id = ? AND name = ? AND is_custom_property = ?", id, archiveKeyProperty, false).
+			Find(&markers).Error; err != nil {
+			return err
+		}
+		if len(markers) == 0 || markers[0].StringValue == nil {
+			return nil
+		}
+
+		data, err := a.store.Get(ctx, *markers[0].StringValue)
+		if err != nil {
+			return fmt.Errorf("error downloading archive bundle: %w", err)
+		}
+
+		bundle, err := decodeBundle(data)
+		if err != nil {
+			return err
+		}
+		if bundle.EntityID != id {
+			return fmt.Errorf("archive bundle %s belongs to entity %d", *markers[0].StringValue, bundle.EntityID)
+		}
+
+		// Properties set while the entity was archived take precedence over the archived ones
+		if len(bundle.Properties) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&bundle.Properties).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("context_id = ? AND name = ? AND is_custom_property = ?", id, archiveKeyProperty, false).Delete(&schema.ContextProperty{}).Error; err != nil {
+			return err
+		}
+
+		now := float64(time.Now().UnixMilli())
+		if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&schema.ContextProperty{
+			ContextID:        id,
+			Name:             rehydratedAtProperty,
+			IsCustomProperty: false,
+			DoubleValue:      &now,
+		}).Error; err != nil {
+			return err
+		}
+
+		key = *markers[0].StringValue
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if key == "" {
+		return false, nil
+	}
+
+	cache.Invalidate(a.db.WithContext(ctx), cache.KindRegisteredModels, cache.KindModelVersions)
+
+	if err := a.store.Delete(ctx, key); err != nil {
+		glog.Warningf("Unable to delete rehydrated archive bundle %s: %v", key, err)
+	}
+
+	glog.Infof("Rehydrated archived entity %d", id)
+
+	return true, nil
+}
+
+func encodeBundle(bundle *Bundle) ([]byte, error) {
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(bundle); err != nil {
+		return nil, fmt.Errorf("error encoding archive bundle: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing archive bundle: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeBundle(data []byte) (*Bundle, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing archive bundle: %w", err)
+	}
+	defer r.Close()
+
+	bundle := &Bundle{}
+	if err := json.NewDecoder(io.LimitReader(r, 1<<30)).Decode(bundle); err != nil {
+		return nil, fmt.Errorf("error decoding archive bundle: %w", err)
+	}
+
+	if bundle.Version != bundleVersion {
+		return nil, errors.New("unsupported archive bundle version")
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,173 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ErrBlobNotFound is returned when reading a missing archive bundle.
+var ErrBlobNotFound = errors.New("archive bundle not found")
+
+// BlobStore stores the archive bundles.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore returns the store for url, either s3://bucket/prefix or a local directory, which can be a mounted
+// object storage volume.
+func NewBlobStore(url, endpoint, region string) (BlobStore, error) {
+	if bucket, found := strings.CutPrefix(url, "s3://"); found {
+		bucket, prefix, _ := strings.Cut(bucket, "/")
+		return NewS3BlobStore(bucket, prefix, endpoint, region)
+	}
+
+	return NewFileBlobStore(strings.TrimPrefix(url, "file://"))
+}
+
+type fileBlobStore struct {
+	dir string
+}
+
+// NewFileBlobStore returns a BlobStore keeping the bundles as files below dir.
+func NewFileBlobStore(dir string) (BlobStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("archive directory is required")
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating archive directory: %w", err)
+	}
+
+	return &fileBlobStore{dir: dir}, nil
+}
+
+func (s *fileBlobStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+func (s *fileBlobStore) Put(_ context.Context, key string, data []byte) error {
+	p := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that a partial bundle is never read back
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, p)
+}
+
+func (s *fileBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+	}
+
+	return data, err
+}
+
+func (s *fileBlobStore) Delete(_ context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+type s3BlobStore struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// NewS3BlobStore returns a BlobStore keeping the bundles in an S3 compatible bucket, the credentials are read from
+// the standard AWS environment variables and configuration files.
+func NewS3BlobStore(bucket, prefix, endpoint, region string) (BlobStore, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("archive bucket is required")
+	}
+
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating S3 session: %w", err)
+	}
+
+	return &s3BlobStore{
+		client: s3.New(sess),
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}, nil
+}
+
+func (s *s3BlobStore) key(key string) *string {
+	if s.prefix == "" {
+		return aws.String(key)
+	}
+
+	return aws.String(s.prefix + "/" + key)
+}
+
+func (s *s3BlobStore) Put(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+		Body:   bytes.NewReader(data),
+	})
+
+	return err
+}
+
+func (s *s3BlobStore) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+		}
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+func (s *s3BlobStore) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    s.key(key),
+	})
+
+	return err
+}
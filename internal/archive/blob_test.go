@@ -0,0 +1,61 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileBlobStore(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewBlobStore("file://"+t.TempDir(), "", "")
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "model_versions/1-1.json.gz", []byte("bundle")))
+
+	data, err := store.Get(ctx, "model_versions/1-1.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bundle"), data)
+
+	// Keys can not escape the archive directory
+	data, err = store.Get(ctx, "../../model_versions/1-1.json.gz")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("bundle"), data)
+
+	require.NoError(t, store.Delete(ctx, "model_versions/1-1.json.gz"))
+	require.NoError(t, store.Delete(ctx, "model_versions/1-1.json.gz"), "deleting a missing bundle is not an error")
+
+	_, err = store.Get(ctx, "model_versions/1-1.json.gz")
+	assert.True(t, errors.Is(err, ErrBlobNotFound))
+}
+
+func TestBundleEncoding(t *testing.T) {
+	value := "value"
+	bytesValue := []byte{0, 1, 2}
+
+	bundle := &Bundle{
+		Version:    bundleVersion,
+		EntityType: "model_versions",
+		EntityID:   1,
+		ArchivedAt: 1700000000000,
+		Properties: []schema.ContextProperty{
+			{ContextID: 1, Name: "string", IsCustomProperty: true, StringValue: &value},
+			{ContextID: 1, Name: "bytes", IsCustomProperty: true, ByteValue: &bytesValue},
+		},
+	}
+
+	data, err := encodeBundle(bundle)
+	require.NoError(t, err)
+
+	decoded, err := decodeBundle(data)
+	require.NoError(t, err)
+	assert.Equal(t, bundle, decoded)
+
+	_, err = decodeBundle([]byte("not a bundle"))
+	assert.Error(t, err)
+}
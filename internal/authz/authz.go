@@ -0,0 +1,68 @@
+// Package authz checks the permissions of the subjects of the api requests on the entity types of each namespace,
+// from a static policy or from the Kubernetes RBAC with SubjectAccessReviews.
+package authz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Verbs of the permissions, each verb grants the ones before it.
+const (
+	VerbRead  = "read"
+	VerbWrite = "write"
+	// VerbAdmin deletes the entities.
+	VerbAdmin = "admin"
+)
+
+var verbs = []string{VerbRead, VerbWrite, VerbAdmin}
+
+// Entity types of the permissions, the plural names of the resources of the entities.
+const (
+	EntityTypeRegisteredModels    = "registeredmodels"
+	EntityTypeModelVersions       = "modelversions"
+	EntityTypeArtifacts           = "artifacts"
+	EntityTypeExperiments         = "experiments"
+	EntityTypeExperimentRuns      = "experimentruns"
+	EntityTypeServingEnvironments = "servingenvironments"
+	EntityTypeInferenceServices   = "inferenceservices"
+	// EntityTypeRegistry covers the endpoints spanning entity types, e.g. watch, tags and resolve.
+	EntityTypeRegistry = "registry"
+)
+
+var entityTypes = []string{
+	EntityTypeRegisteredModels, EntityTypeModelVersions, EntityTypeArtifacts, EntityTypeExperiments,
+	EntityTypeExperimentRuns, EntityTypeServingEnvironments, EntityTypeInferenceServices, EntityTypeRegistry,
+}
+
+// Subject is the caller of a request.
+type Subject struct {
+	User   string
+	Groups []string
+}
+
+// Permission is a verb on the entities of a type in a namespace, the empty namespace is the one of the entities of
+// no tenant.
+type Permission struct {
+	Verb       string
+	EntityType string
+	Namespace  string
+}
+
+func (p Permission) String() string {
+	if p.Namespace == "" {
+		return fmt.Sprintf("%s %s", p.Verb, p.EntityType)
+	}
+	return fmt.Sprintf("%s %s in namespace %s", p.Verb, p.EntityType, p.Namespace)
+}
+
+// PermissionChecker tells whether subjects have permissions.
+type PermissionChecker interface {
+	Check(ctx context.Context, subject Subject, permission Permission) (bool, error)
+}
+
+// grants reports whether verb grants required.
+func grants(verb string, required string) bool {
+	return verb == "*" || slices.Index(verbs, verb) >= slices.Index(verbs, required) && slices.Contains(verbs, required)
+}
@@ -0,0 +1,121 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// Group is the api group of the entity types in the Kubernetes RBAC rules, e.g.
+	//
+	//	rules:
+	//	  - apiGroups: [modelregistry.kubeflow.org]
+	//	    resources: [registeredmodels, modelversions]
+	//	    verbs: [get, list, update]
+	Group = "modelregistry.kubeflow.org"
+
+	// DefaultDecisionTTL is how long the decisions of the Kubernetes api server are reused.
+	DefaultDecisionTTL = 10 * time.Second
+)
+
+// kubernetesVerbs maps the verbs of the permissions to the Kubernetes RBAC verbs.
+var kubernetesVerbs = map[string]string{
+	VerbRead:  "get",
+	VerbWrite: "update",
+	VerbAdmin: "delete",
+}
+
+// SubjectAccessReviewer checks the permissions against the Kubernetes RBAC rules of the entity types of Group, with
+// SubjectAccessReviews. The decisions are cached for the TTL to spare the api server a review per request.
+type SubjectAccessReviewer struct {
+	client kubernetes.Interface
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	decisions map[string]decision
+}
+
+type decision struct {
+	allowed bool
+	expires time.Time
+}
+
+// NewSubjectAccessReviewer returns a SubjectAccessReviewer reviewing with client, caching the decisions for ttl.
+func NewSubjectAccessReviewer(client kubernetes.Interface, ttl time.Duration) *SubjectAccessReviewer {
+	return &SubjectAccessReviewer{
+		client:    client,
+		ttl:       ttl,
+		decisions: map[string]decision{},
+	}
+}
+
+// NewInClusterSubjectAccessReviewer returns a SubjectAccessReviewer from the in-cluster configuration, its service
+// account must be allowed to create subjectaccessreviews.
+func NewInClusterSubjectAccessReviewer() (*SubjectAccessReviewer, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting in-cluster configuration: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+
+	return NewSubjectAccessReviewer(clientset, DefaultDecisionTTL), nil
+}
+
+func (s *SubjectAccessReviewer) Check(ctx context.Context, subject Subject, permission Permission) (bool, error) {
+	verb, ok := kubernetesVerbs[permission.Verb]
+	if !ok {
+		return false, fmt.Errorf("unknown verb %s", permission.Verb)
+	}
+
+	groups := slices.Sorted(slices.Values(subject.Groups))
+	key := strings.Join([]string{subject.User, strings.Join(groups, ","), verb, permission.EntityType, permission.Namespace}, "\x00")
+
+	now := time.Now()
+	s.mu.Lock()
+	cached, ok := s.decisions[key]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.allowed, nil
+	}
+
+	review, err := s.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   subject.User,
+			Groups: subject.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: permission.Namespace,
+				Verb:      verb,
+				Group:     Group,
+				Resource:  permission.EntityType,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("error reviewing the access of %s to %s: %w", subject.User, permission, err)
+	}
+
+	s.mu.Lock()
+	// expired decisions are dropped on write so the cache stays bounded by the subjects of the last TTL
+	for k, d := range s.decisions {
+		if !now.Before(d.expires) {
+			delete(s.decisions, k)
+		}
+	}
+	s.decisions[key] = decision{allowed: review.Status.Allowed, expires: now.Add(s.ttl)}
+	s.mu.Unlock()
+
+	return review.Status.Allowed, nil
+}
@@ -0,0 +1,59 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSubjectAccessReviewer(t *testing.T) {
+	var reviews []authorizationv1.SubjectAccessReviewSpec
+	client := fake.NewClientset()
+	client.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		reviews = append(reviews, review.Spec)
+		if review.Spec.User == "broken" {
+			return true, nil, errors.New("unavailable")
+		}
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" && attributes.Namespace == "team-a" && attributes.Verb != "delete"
+		return true, review, nil
+	})
+
+	reviewer := NewSubjectAccessReviewer(client, DefaultDecisionTTL)
+	ctx := context.Background()
+
+	allowed, err := reviewer.Check(ctx, Subject{User: "alice", Groups: []string{"team-a"}}, Permission{Verb: VerbWrite, EntityType: EntityTypeModelVersions, Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Namespace: "team-a", Verb: "update", Group: Group, Resource: EntityTypeModelVersions}, reviews[0].ResourceAttributes)
+	assert.Equal(t, []string{"team-a"}, reviews[0].Groups)
+
+	allowed, err = reviewer.Check(ctx, Subject{User: "alice", Groups: []string{"team-a"}}, Permission{Verb: VerbWrite, EntityType: EntityTypeModelVersions, Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Len(t, reviews, 1, "the decision is cached")
+
+	allowed, err = reviewer.Check(ctx, Subject{User: "alice"}, Permission{Verb: VerbAdmin, EntityType: EntityTypeModelVersions, Namespace: "team-a"})
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, "delete", reviews[1].ResourceAttributes.Verb)
+
+	allowed, err = reviewer.Check(ctx, Subject{User: "alice"}, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels, Namespace: "team-b"})
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	_, err = reviewer.Check(ctx, Subject{User: "broken"}, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels})
+	assert.ErrorContains(t, err, "unavailable")
+
+	_, err = reviewer.Check(ctx, Subject{User: "alice"}, Permission{Verb: "promote", EntityType: EntityTypeRegisteredModels})
+	assert.ErrorContains(t, err, "unknown verb")
+}
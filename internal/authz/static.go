@@ -0,0 +1,97 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// PolicyRule grants verbs on entity types in namespaces to users and groups, * matches all the namespaces, entity
+// types or verbs.
+type PolicyRule struct {
+	Users       []string `json:"users,omitempty"`
+	Groups      []string `json:"groups,omitempty"`
+	Namespaces  []string `json:"namespaces"`
+	EntityTypes []string `json:"entityTypes"`
+	Verbs       []string `json:"verbs"`
+}
+
+// PolicyConfig is the content of the static policy file, e.g.
+//
+//	rules:
+//	  - groups: [team-a]
+//	    namespaces: [team-a]
+//	    entityTypes: ["*"]
+//	    verbs: [write]
+//	  - users: [release-bot]
+//	    namespaces: ["*"]
+//	    entityTypes: [modelversions, inferenceservices]
+//	    verbs: [admin]
+type PolicyConfig struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// LoadPolicy reads a static policy file.
+func LoadPolicy(path string) (*PolicyConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading authorization policy file: %w", err)
+	}
+	var config PolicyConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing authorization policy file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// StaticPolicy checks the permissions against the rules of a policy file, the permissions no rule grants are denied.
+type StaticPolicy struct {
+	rules []PolicyRule
+}
+
+// NewStaticPolicy validates the rules of config.
+func NewStaticPolicy(config *PolicyConfig) (*StaticPolicy, error) {
+	for i, rule := range config.Rules {
+		switch {
+		case len(rule.Users) == 0 && len(rule.Groups) == 0:
+			return nil, fmt.Errorf("invalid authorization rule %d: at least one user or group is required", i)
+		case len(rule.Namespaces) == 0:
+			return nil, fmt.Errorf("invalid authorization rule %d: at least one namespace is required", i)
+		}
+		for _, entityType := range rule.EntityTypes {
+			if entityType != "*" && !slices.Contains(entityTypes, entityType) {
+				return nil, fmt.Errorf("invalid authorization rule %d: unknown entity type %s", i, entityType)
+			}
+		}
+		for _, verb := range rule.Verbs {
+			if verb != "*" && !slices.Contains(verbs, verb) {
+				return nil, fmt.Errorf("invalid authorization rule %d: unknown verb %s", i, verb)
+			}
+		}
+		if len(rule.EntityTypes) == 0 || len(rule.Verbs) == 0 {
+			return nil, fmt.Errorf("invalid authorization rule %d: at least one entity type and one verb are required", i)
+		}
+	}
+	return &StaticPolicy{rules: config.Rules}, nil
+}
+
+func (p *StaticPolicy) Check(_ context.Context, subject Subject, permission Permission) (bool, error) {
+	for _, rule := range p.rules {
+		if !slices.Contains(rule.Users, subject.User) && !slices.ContainsFunc(rule.Groups, func(group string) bool { return slices.Contains(subject.Groups, group) }) {
+			continue
+		}
+		if !slices.Contains(rule.Namespaces, "*") && !slices.Contains(rule.Namespaces, permission.Namespace) {
+			continue
+		}
+		if !slices.Contains(rule.EntityTypes, "*") && !slices.Contains(rule.EntityTypes, permission.EntityType) {
+			continue
+		}
+		if slices.ContainsFunc(rule.Verbs, func(verb string) bool { return grants(verb, permission.Verb) }) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
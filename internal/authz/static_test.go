@@ -0,0 +1,70 @@
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+rules:
+  - groups: [team-a]
+    namespaces: [team-a]
+    entityTypes: ["*"]
+    verbs: [write]
+  - users: [release-bot]
+    namespaces: ["*"]
+    entityTypes: [modelversions]
+    verbs: [admin]
+`), 0o600))
+
+	config, err := LoadPolicy(path)
+	require.NoError(t, err)
+	policy, err := NewStaticPolicy(config)
+	require.NoError(t, err)
+
+	check := func(subject Subject, permission Permission) bool {
+		allowed, err := policy.Check(context.Background(), subject, permission)
+		require.NoError(t, err)
+		return allowed
+	}
+
+	alice := Subject{User: "alice", Groups: []string{"team-a"}}
+	assert.True(t, check(alice, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels, Namespace: "team-a"}))
+	assert.True(t, check(alice, Permission{Verb: VerbWrite, EntityType: EntityTypeArtifacts, Namespace: "team-a"}))
+	assert.False(t, check(alice, Permission{Verb: VerbAdmin, EntityType: EntityTypeArtifacts, Namespace: "team-a"}), "write doesn't grant admin")
+	assert.False(t, check(alice, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels, Namespace: "team-b"}))
+	assert.False(t, check(alice, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels}), "the entities of no tenant aren't in team-a")
+
+	bot := Subject{User: "release-bot"}
+	assert.True(t, check(bot, Permission{Verb: VerbAdmin, EntityType: EntityTypeModelVersions, Namespace: "team-b"}))
+	assert.True(t, check(bot, Permission{Verb: VerbRead, EntityType: EntityTypeModelVersions}))
+	assert.False(t, check(bot, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels, Namespace: "team-b"}))
+
+	assert.False(t, check(Subject{User: "mallory", Groups: []string{"team-b"}}, Permission{Verb: VerbRead, EntityType: EntityTypeRegisteredModels, Namespace: "team-a"}))
+
+	t.Run("invalid rules", func(t *testing.T) {
+		for name, rule := range map[string]PolicyRule{
+			"no subject":     {Namespaces: []string{"*"}, EntityTypes: []string{"*"}, Verbs: []string{"*"}},
+			"no namespace":   {Users: []string{"alice"}, EntityTypes: []string{"*"}, Verbs: []string{"*"}},
+			"unknown type":   {Users: []string{"alice"}, Namespaces: []string{"*"}, EntityTypes: []string{"models"}, Verbs: []string{"*"}},
+			"unknown verb":   {Users: []string{"alice"}, Namespaces: []string{"*"}, EntityTypes: []string{"*"}, Verbs: []string{"delete"}},
+			"no entity type": {Users: []string{"alice"}, Namespaces: []string{"*"}, Verbs: []string{"*"}},
+		} {
+			_, err := NewStaticPolicy(&PolicyConfig{Rules: []PolicyRule{rule}})
+			assert.Error(t, err, name)
+		}
+	})
+
+	t.Run("unknown fields", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("rules:\n  - user: alice\n"), 0o600))
+		_, err := LoadPolicy(path)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,213 @@
+// Package backfill sets the properties of the artifacts registered before the features computing them, through the
+// REST api of a model registry server.
+package backfill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/clientutil"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultPageSize is the number of model artifacts listed at once.
+const DefaultPageSize = 100
+
+// Config configures a backfill.
+type Config struct {
+	URL   string
+	Token string
+	// Rate is the number of artifacts digested per second, unlimited when 0
+	Rate float64
+	// StateFile records the last artifact backfilled, so that an interrupted backfill resumes after it. Backfills
+	// without state file start from the first artifact.
+	StateFile string
+	PageSize  int
+}
+
+// Summary counts the artifacts backfilled.
+type Summary struct {
+	Artifacts int
+	Digested  int
+	Skipped   int
+	Failed    int
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d model artifacts: %d digested, %d skipped and %d failed", s.Artifacts, s.Digested, s.Skipped, s.Failed)
+}
+
+// state is the progress of a backfill saved in the state file.
+type state struct {
+	// LastArtifactId is the id of the last artifact backfilled, the backfill resumes after it
+	LastArtifactId string `json:"lastArtifactId"`
+}
+
+// Backfiller backfills the model registry server reached with its client.
+type Backfiller struct {
+	cfg      Config
+	client   *openapi.APIClient
+	digester reachability.Digester
+}
+
+// NewBackfiller returns a Backfiller of the model registry server at cfg.URL, reading the artifact uris with
+// digester.
+func NewBackfiller(cfg Config, digester reachability.Digester) *Backfiller {
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+
+	clientCfg := openapi.NewConfiguration()
+	clientCfg.Servers = openapi.ServerConfigurations{{URL: strings.TrimSuffix(cfg.URL, "/")}}
+	if cfg.Token != "" {
+		clientCfg.AddDefaultHeader("Authorization", "Bearer "+cfg.Token)
+	}
+	return &Backfiller{cfg: cfg, client: openapi.NewAPIClient(clientCfg), digester: digester}
+}
+
+// ComputeDigests walks the model artifacts in the order of their ids and saves the sha256 and size of the content
+// of their uri in their digest and size_bytes custom properties. The artifacts with a digest, without uri or with a
+// uri of an unsupported scheme are skipped, those whose uri can't be read are reported and skipped. The progress is
+// saved in the state file after each artifact.
+func (b *Backfiller) ComputeDigests(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	progress, err := b.loadState()
+	if err != nil {
+		return summary, err
+	}
+	if progress.LastArtifactId != "" {
+		glog.Infof("Resuming the backfill after model artifact %s", progress.LastArtifactId)
+	}
+
+	var tick <-chan time.Time
+	if b.cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / b.cfg.Rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// The pages are listed after the last artifact backfilled rather than with page tokens, as the backfill resumes
+	for {
+		req := b.client.ModelRegistryServiceAPI.GetModelArtifacts(ctx).
+			OrderBy(openapi.ORDERBYFIELD_ID).
+			SortOrder(openapi.SORTORDER_ASC).
+			PageSize(strconv.Itoa(b.cfg.PageSize))
+		if progress.LastArtifactId != "" {
+			req = req.FilterQuery("id > " + progress.LastArtifactId)
+		}
+
+		page, _, err := req.Execute()
+		if err != nil {
+			return summary, fmt.Errorf("error listing model artifacts: %w", clientutil.RestError(err))
+		}
+		if len(page.Items) == 0 {
+			return summary, nil
+		}
+
+		for _, artifact := range page.Items {
+			summary.Artifacts++
+			if uri := artifact.GetUri(); uri == "" || hasDigest(artifact) {
+				summary.Skipped++
+			} else {
+				if tick != nil {
+					select {
+					case <-ctx.Done():
+						return summary, ctx.Err()
+					case <-tick:
+					}
+				}
+				if err := b.computeDigest(ctx, artifact, &summary); err != nil {
+					return summary, err
+				}
+			}
+
+			progress.LastArtifactId = artifact.GetId()
+			if err := b.saveState(progress); err != nil {
+				return summary, err
+			}
+		}
+	}
+}
+
+// computeDigest saves the digest of the uri of a model artifact, only the failures to save it are returned.
+func (b *Backfiller) computeDigest(ctx context.Context, artifact openapi.ModelArtifact, summary *Summary) error {
+	digest, err := b.digester.Digest(ctx, artifact.GetUri())
+	if errors.Is(err, reachability.ErrUnsupportedScheme) {
+		glog.V(2).Infof("Skipping model artifact %s: %v", artifact.GetId(), err)
+		summary.Skipped++
+		return nil
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		glog.Warningf("Unable to digest model artifact %s uri %s: %v", artifact.GetId(), artifact.GetUri(), err)
+		summary.Failed++
+		return nil
+	}
+
+	// The custom properties of an update replace the existing ones, they are all sent
+	customProperties := map[string]openapi.MetadataValue{}
+	for name, value := range artifact.GetCustomProperties() {
+		customProperties[name] = value
+	}
+	customProperties[models.DigestCustomProperty] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(digest.Digest, "MetadataStringValue"))
+	if digest.SizeBytes != nil {
+		customProperties[models.SizeBytesCustomProperty] = openapi.MetadataIntValueAsMetadataValue(openapi.NewMetadataIntValue(strconv.FormatInt(*digest.SizeBytes, 10), "MetadataIntValue"))
+	}
+
+	_, _, err = b.client.ModelRegistryServiceAPI.UpdateModelArtifact(ctx, artifact.GetId()).
+		ModelArtifactUpdate(openapi.ModelArtifactUpdate{CustomProperties: customProperties}).
+		Execute()
+	if err != nil {
+		return fmt.Errorf("error saving the digest of model artifact %s: %w", artifact.GetId(), clientutil.RestError(err))
+	}
+
+	glog.V(2).Infof("Digested model artifact %s: %s", artifact.GetId(), digest.Digest)
+	summary.Digested++
+	return nil
+}
+
+func hasDigest(artifact openapi.ModelArtifact) bool {
+	value, ok := artifact.GetCustomProperties()[models.DigestCustomProperty]
+	return ok && value.MetadataStringValue != nil && value.MetadataStringValue.StringValue != ""
+}
+
+func (b *Backfiller) loadState() (state, error) {
+	progress := state{}
+	if b.cfg.StateFile == "" {
+		return progress, nil
+	}
+
+	data, err := os.ReadFile(b.cfg.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, fmt.Errorf("error reading backfill state: %w", err)
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf("invalid backfill state %s: %w", b.cfg.StateFile, err)
+	}
+	if _, err := strconv.ParseInt(progress.LastArtifactId, 10, 32); progress.LastArtifactId != "" && err != nil {
+		return progress, fmt.Errorf("invalid backfill state %s: artifact id %q", b.cfg.StateFile, progress.LastArtifactId)
+	}
+	return progress, nil
+}
+
+func (b *Backfiller) saveState(progress state) error {
+	if err := clientutil.SaveState(b.cfg.StateFile, progress); err != nil {
+		return fmt.Errorf("error saving backfill state: %w", err)
+	}
+	return nil
+}
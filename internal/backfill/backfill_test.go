@@ -0,0 +1,96 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeDigests(t *testing.T) {
+	blobs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "weights"+r.URL.Path)
+	}))
+	defer blobs.Close()
+
+	server, service := inmemory.NewServer(t)
+
+	create := func(name, uri string, customProperties map[string]openapi.MetadataValue) *openapi.ModelArtifact {
+		artifact := &openapi.ModelArtifact{Name: &name, CustomProperties: customProperties}
+		if uri != "" {
+			artifact.Uri = &uri
+		}
+		created, err := service.UpsertModelArtifact(artifact)
+		require.NoError(t, err)
+		return created
+	}
+	stage := map[string]openapi.MetadataValue{
+		"stage": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("production", "MetadataStringValue")),
+	}
+	digested := map[string]openapi.MetadataValue{
+		"digest": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("sha256:known", "MetadataStringValue")),
+	}
+
+	first := create("first", blobs.URL+"/first", stage)
+	create("pending", "", nil)
+	create("digested", blobs.URL+"/digested", digested)
+	create("missing", blobs.URL+"/missing", nil)
+	create("pvc", "pvc://models/granite", nil)
+	second := create("second", blobs.URL+"/second", nil)
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	backfiller := NewBackfiller(Config{URL: server.URL, StateFile: stateFile, PageSize: 2, Rate: 1000}, reachability.NewDigester(reachability.Config{AllowPrivateNetworks: true}))
+
+	summary, err := backfiller.ComputeDigests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Artifacts: 6, Digested: 2, Skipped: 3, Failed: 1}, summary)
+
+	artifact, err := service.GetModelArtifactById(*first.Id)
+	require.NoError(t, err)
+	digest, err := reachability.NewDigester(reachability.Config{AllowPrivateNetworks: true}).Digest(context.Background(), blobs.URL+"/first")
+	require.NoError(t, err)
+	props := artifact.GetCustomProperties()
+	assert.Equal(t, digest.Digest, props["digest"].MetadataStringValue.StringValue)
+	assert.Equal(t, fmt.Sprint(*digest.SizeBytes), props["size_bytes"].MetadataIntValue.IntValue)
+	assert.Equal(t, "production", props["stage"].MetadataStringValue.StringValue, "the existing custom properties are kept")
+
+	artifacts, err := service.GetModelArtifacts(api.ListOptions{}, nil)
+	require.NoError(t, err)
+	for _, artifact := range artifacts.Items {
+		if artifact.GetName() == "digested" {
+			assert.Equal(t, "sha256:known", artifact.GetCustomProperties()["digest"].MetadataStringValue.StringValue)
+		}
+	}
+
+	state, err := os.ReadFile(stateFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"lastArtifactId": %q}`, *second.Id), string(state))
+
+	// a rerun resumes after the last artifact backfilled
+	third := create("third", blobs.URL+"/third", nil)
+	summary, err = backfiller.ComputeDigests(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{Artifacts: 1, Digested: 1}, summary)
+
+	artifact, err = service.GetModelArtifactById(*third.Id)
+	require.NoError(t, err)
+	assert.Contains(t, artifact.GetCustomProperties(), "digest")
+
+	require.NoError(t, os.WriteFile(stateFile, []byte(`{"lastArtifactId": "1 OR 1"}`), 0o600))
+	_, err = backfiller.ComputeDigests(context.Background())
+	assert.ErrorContains(t, err, "invalid backfill state")
+}
@@ -0,0 +1,329 @@
+// Package bench generates load against a model registry server: it seeds registered models, model versions and
+// experiment runs, then replays a weighted mix of read and write operations and reports their latency.
+package bench
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+const (
+	// DefaultMix is the operation mix replayed when none is configured, mostly reads as for a registry serving
+	// deployments
+	DefaultMix = "get-model=30,list-models=20,filter-models=20,list-versions=15,create-model=5,update-version=5,create-run=5"
+
+	// pageSize is the page size of the list operations
+	pageSize = "20"
+	// teams is the number of distinct team custom properties of the seeded registered models, filter-models selects one
+	teams = 10
+)
+
+// Operation is a request to the model registry replayed by the benchmark.
+type Operation string
+
+const (
+	OpGetModel      Operation = "get-model"
+	OpListModels    Operation = "list-models"
+	OpFilterModels  Operation = "filter-models"
+	OpListVersions  Operation = "list-versions"
+	OpCreateModel   Operation = "create-model"
+	OpUpdateVersion Operation = "update-version"
+	OpCreateRun     Operation = "create-run"
+)
+
+var operations = []Operation{OpGetModel, OpListModels, OpFilterModels, OpListVersions, OpCreateModel, OpUpdateVersion, OpCreateRun}
+
+// Mix is the relative weight of each operation replayed.
+type Mix map[Operation]int
+
+// ParseMix parses a mix as a comma separated list of <operation>=<weight>.
+func ParseMix(s string) (Mix, error) {
+	mix := Mix{}
+	for part := range strings.SplitSeq(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid operation weight %q, expected <operation>=<weight>", part)
+		}
+		op := Operation(strings.TrimSpace(name))
+		if !slices.Contains(operations, op) {
+			return nil, fmt.Errorf("unknown operation %q, expected one of %v", op, operations)
+		}
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight of operation %s: %q", op, value)
+		}
+		mix[op] += weight
+	}
+
+	total := 0
+	for _, weight := range mix {
+		total += weight
+	}
+	if total == 0 {
+		return nil, errors.New("operation mix has no operation with a positive weight")
+	}
+	return mix, nil
+}
+
+// Config configures a benchmark run.
+type Config struct {
+	// URL is the base url of the model registry server
+	URL string
+	// Token is the bearer token sent to the server, if set
+	Token string
+	// Prefix of the names of the seeded entities, unique per run so that runs can target the same server
+	Prefix string
+
+	Models            int
+	VersionsPerModel  int
+	Experiments       int
+	RunsPerExperiment int
+
+	Mix         Mix
+	Concurrency int
+	// Requests is the number of requests replayed, if 0 they are replayed for Duration
+	Requests int
+	Duration time.Duration
+}
+
+// Validate checks the configuration is complete.
+func (c Config) Validate() error {
+	switch {
+	case c.URL == "":
+		return errors.New("missing model registry url")
+	case c.Models < 1 || c.VersionsPerModel < 1 || c.Experiments < 1 || c.RunsPerExperiment < 0:
+		return errors.New("at least one registered model, model version and experiment must be seeded")
+	case c.Concurrency < 1:
+		return errors.New("concurrency must be at least 1")
+	case c.Requests < 0 || c.Requests == 0 && c.Duration <= 0:
+		return errors.New("either a number of requests or a duration is required")
+	case len(c.Mix) == 0:
+		return errors.New("missing operation mix")
+	}
+	return nil
+}
+
+// seed holds the ids of the seeded entities the operations pick from.
+type seed struct {
+	models      []string
+	versions    []string
+	experiments []string
+}
+
+// Run seeds the server and replays the operation mix, returning the latency of the seeding and of each operation.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	clientCfg := openapi.NewConfiguration()
+	clientCfg.Servers = openapi.ServerConfigurations{{URL: strings.TrimSuffix(cfg.URL, "/")}}
+	clientCfg.HTTPClient = &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: cfg.Concurrency}}
+	if cfg.Token != "" {
+		clientCfg.AddDefaultHeader("Authorization", "Bearer "+cfg.Token)
+	}
+	b := &bench{cfg: cfg, client: openapi.NewAPIClient(clientCfg)}
+
+	report := &Report{}
+
+	start := time.Now()
+	seed, err := b.seed(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error seeding the model registry: %w", err)
+	}
+	report.SeedDuration = time.Since(start)
+
+	report.Stats, report.Duration = b.replay(ctx, seed)
+	return report, nil
+}
+
+type bench struct {
+	cfg    Config
+	client *openapi.APIClient
+	// created counts the entities created while replaying, to name them uniquely
+	created atomic.Int64
+}
+
+func (b *bench) seed(ctx context.Context) (*seed, error) {
+	api := b.client.ModelRegistryServiceAPI
+	s := &seed{}
+
+	for i := range b.cfg.Models {
+		model, err := b.createModel(ctx, fmt.Sprintf("%s-model-%d", b.cfg.Prefix, i), i%teams)
+		if err != nil {
+			return nil, err
+		}
+		s.models = append(s.models, *model.Id)
+
+		for j := range b.cfg.VersionsPerModel {
+			version, _, err := api.CreateModelVersion(ctx).ModelVersionCreate(openapi.ModelVersionCreate{
+				Name:              fmt.Sprintf("v%d", j),
+				RegisteredModelId: *model.Id,
+			}).Execute()
+			if err != nil {
+				return nil, fmt.Errorf("error creating model version: %w", err)
+			}
+			s.versions = append(s.versions, *version.Id)
+		}
+	}
+
+	for i := range b.cfg.Experiments {
+		experiment, _, err := api.CreateExperiment(ctx).ExperimentCreate(openapi.ExperimentCreate{
+			Name: fmt.Sprintf("%s-experiment-%d", b.cfg.Prefix, i),
+		}).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error creating experiment: %w", err)
+		}
+		s.experiments = append(s.experiments, *experiment.Id)
+
+		for j := range b.cfg.RunsPerExperiment {
+			if err := b.createRun(ctx, *experiment.Id, fmt.Sprintf("run-%d", j)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s, nil
+}
+
+func (b *bench) createModel(ctx context.Context, name string, team int) (*openapi.RegisteredModel, error) {
+	model, _, err := b.client.ModelRegistryServiceAPI.CreateRegisteredModel(ctx).RegisteredModelCreate(openapi.RegisteredModelCreate{
+		Name: name,
+		CustomProperties: map[string]openapi.MetadataValue{
+			"team": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(fmt.Sprintf("team-%d", team), "MetadataStringValue")),
+		},
+	}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("error creating registered model: %w", err)
+	}
+	return model, nil
+}
+
+func (b *bench) createRun(ctx context.Context, experimentID string, name string) error {
+	_, _, err := b.client.ModelRegistryServiceAPI.CreateExperimentRun(ctx).ExperimentRunCreate(openapi.ExperimentRunCreate{
+		Name:         &name,
+		ExperimentId: experimentID,
+	}).Execute()
+	if err != nil {
+		return fmt.Errorf("error creating experiment run: %w", err)
+	}
+	return nil
+}
+
+// execute runs an operation on entities picked at random from the seed.
+func (b *bench) execute(ctx context.Context, op Operation, s *seed, rng *rand.Rand) error {
+	api := b.client.ModelRegistryServiceAPI
+	pick := func(ids []string) string { return ids[rng.IntN(len(ids))] }
+
+	var err error
+	switch op {
+	case OpGetModel:
+		_, _, err = api.GetRegisteredModel(ctx, pick(s.models)).Execute()
+	case OpListModels:
+		_, _, err = api.GetRegisteredModels(ctx).PageSize(pageSize).Execute()
+	case OpFilterModels:
+		query := fmt.Sprintf("name LIKE '%s-model-%%' AND team = 'team-%d'", b.cfg.Prefix, rng.IntN(teams))
+		_, _, err = api.GetRegisteredModels(ctx).PageSize(pageSize).FilterQuery(query).Execute()
+	case OpListVersions:
+		_, _, err = api.GetRegisteredModelVersions(ctx, pick(s.models)).PageSize(pageSize).Execute()
+	case OpCreateModel:
+		_, err = b.createModel(ctx, fmt.Sprintf("%s-created-%d", b.cfg.Prefix, b.created.Add(1)), rng.IntN(teams))
+	case OpUpdateVersion:
+		description := fmt.Sprintf("updated %d", rng.Int64())
+		_, _, err = api.UpdateModelVersion(ctx, pick(s.versions)).ModelVersionUpdate(openapi.ModelVersionUpdate{Description: &description}).Execute()
+	case OpCreateRun:
+		err = b.createRun(ctx, pick(s.experiments), fmt.Sprintf("created-%d", b.created.Add(1)))
+	}
+	return err
+}
+
+// replay runs the operation mix with the configured concurrency, until the requests are done or the duration
+// elapsed.
+func (b *bench) replay(ctx context.Context, s *seed) ([]Stats, time.Duration) {
+	if b.cfg.Requests == 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.cfg.Duration)
+		defer cancel()
+	}
+
+	// weighted picks an operation from the mix
+	var weighted []Operation
+	for _, op := range operations {
+		for range b.cfg.Mix[op] {
+			weighted = append(weighted, op)
+		}
+	}
+
+	var (
+		mu        sync.Mutex
+		latencies = map[Operation][]time.Duration{}
+		errs      = map[Operation]int{}
+		remaining atomic.Int64
+		wg        sync.WaitGroup
+	)
+	remaining.Store(int64(b.cfg.Requests))
+
+	start := time.Now()
+	for range b.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+			local := map[Operation][]time.Duration{}
+			localErrs := map[Operation]int{}
+
+			for ctx.Err() == nil {
+				if b.cfg.Requests > 0 && remaining.Add(-1) < 0 {
+					break
+				}
+
+				op := weighted[rng.IntN(len(weighted))]
+				opStart := time.Now()
+				err := b.execute(ctx, op, s, rng)
+				if err != nil && ctx.Err() != nil {
+					// interrupted at the end of the duration
+					break
+				}
+				local[op] = append(local[op], time.Since(opStart))
+				if err != nil {
+					localErrs[op]++
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for op, durations := range local {
+				latencies[op] = append(latencies[op], durations...)
+			}
+			for op, count := range localErrs {
+				errs[op] += count
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	var stats []Stats
+	for _, op := range operations {
+		if len(latencies[op]) > 0 {
+			stats = append(stats, newStats(op, latencies[op], errs[op], elapsed))
+		}
+	}
+	return stats, elapsed
+}
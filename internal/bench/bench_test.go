@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMix(t *testing.T) {
+	mix, err := ParseMix(DefaultMix)
+	require.NoError(t, err)
+	assert.Equal(t, 30, mix[OpGetModel])
+	assert.Len(t, mix, 7)
+
+	mix, err = ParseMix(" get-model = 1, get-model=2 ,")
+	require.NoError(t, err)
+	assert.Equal(t, Mix{OpGetModel: 3}, mix)
+
+	for _, invalid := range []string{"", "get-model", "delete-model=1", "get-model=-1", "get-model=0"} {
+		_, err := ParseMix(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 90))
+}
+
+func TestRun(t *testing.T) {
+	server, _ := inmemory.NewServer(t)
+
+	mix, err := ParseMix(DefaultMix)
+	require.NoError(t, err)
+
+	report, err := Run(context.Background(), Config{
+		URL:               server.URL,
+		Prefix:            "test",
+		Models:            5,
+		VersionsPerModel:  2,
+		Experiments:       2,
+		RunsPerExperiment: 2,
+		Mix:               mix,
+		Concurrency:       4,
+		Requests:          200,
+	})
+	require.NoError(t, err)
+
+	requests := 0
+	for _, s := range report.Stats {
+		assert.Zero(t, s.Errors, s.Operation)
+		assert.LessOrEqual(t, s.P50, s.P99)
+		requests += s.Requests
+	}
+	assert.Equal(t, 200, requests)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Print(&out))
+	assert.Contains(t, out.String(), "filter-models")
+}
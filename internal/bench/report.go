@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Report is the result of a benchmark run.
+type Report struct {
+	SeedDuration time.Duration
+	// Duration of the replay of the operation mix
+	Duration time.Duration
+	Stats    []Stats
+}
+
+// Stats are the latency percentiles of an operation.
+type Stats struct {
+	Operation  Operation
+	Requests   int
+	Errors     int
+	Throughput float64
+	P50        time.Duration
+	P90        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+}
+
+func newStats(op Operation, latencies []time.Duration, errors int, elapsed time.Duration) Stats {
+	slices.Sort(latencies)
+	return Stats{
+		Operation:  op,
+		Requests:   len(latencies),
+		Errors:     errors,
+		Throughput: float64(len(latencies)) / elapsed.Seconds(),
+		P50:        percentile(latencies, 50),
+		P90:        percentile(latencies, 90),
+		P99:        percentile(latencies, 99),
+		Max:        latencies[len(latencies)-1],
+	}
+}
+
+// percentile returns the nearest-rank percentile p of sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// Print writes the report as a table.
+func (r *Report) Print(w io.Writer) error {
+	requests := 0
+	for _, s := range r.Stats {
+		requests += s.Requests
+	}
+	fmt.Fprintf(w, "seeded in %s, replayed %d requests in %s\n\n", r.SeedDuration.Round(time.Millisecond), requests, r.Duration.Round(time.Millisecond))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "operation\trequests\terrors\treq/s\tp50\tp90\tp99\tmax\t")
+	for _, s := range r.Stats {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n", s.Operation, s.Requests, s.Errors, s.Throughput,
+			round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+	}
+	return tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
@@ -0,0 +1,383 @@
+// Package cache serves repeated reads of registered models, model versions and artifacts from a shared cache,
+// for bursts of clients resolving the same entities.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultTTL bounds how long an entity changed outside of the registry API can be served stale.
+const DefaultTTL = 30 * time.Second
+
+// Cache stores the cached responses, it is shared by all the replicas.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Incr(ctx context.Context, key string) (int64, error)
+	Close() error
+}
+
+// Cached entity kinds, each has a generation that is incremented on writes to invalidate all of its cached reads.
+const (
+	KindRegisteredModels = "registered_models"
+	KindModelVersions    = "model_versions"
+	KindArtifacts        = "artifacts"
+)
+
+// ModelRegistry is a read-through cache in front of a model registry. Reads by id, by params and lists are cached
+// per kind, under keys hashing their arguments, filter and page token included. Writes through the registry
+// invalidate their kind on all replicas.
+type ModelRegistry struct {
+	api.ModelRegistryApi
+
+	cache       Cache
+	ttl         time.Duration
+	invalidator *Invalidator
+	// ctx is the context of the bound request, the versions of the cached entities are recorded with it
+	ctx context.Context
+	// namespace is the namespace of the tenant of the bound request, its reads are cached apart from the others
+	namespace string
+	// usage counts the reads by id of the registered models and model versions, for the warming of the cache
+	usage *usage
+}
+
+// NewModelRegistry returns registry with its hot reads served from cache.
+func NewModelRegistry(registry api.ModelRegistryApi, cache Cache, ttl time.Duration) *ModelRegistry {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &ModelRegistry{
+		ModelRegistryApi: registry,
+		cache:            cache,
+		ttl:              ttl,
+		invalidator:      NewInvalidator(cache, ttl),
+		usage:            newUsage(),
+	}
+}
+
+// WithContext binds the cached registry to ctx, cache misses are loaded with ctx.
+func (c *ModelRegistry) WithContext(ctx context.Context) api.ModelRegistryApi {
+	return &ModelRegistry{
+		ModelRegistryApi: api.WithContext(ctx, c.ModelRegistryApi),
+		cache:            c.cache,
+		ttl:              c.ttl,
+		invalidator:      c.invalidator,
+		ctx:              ctx,
+		namespace:        api.Namespace(ctx),
+		usage:            c.usage,
+	}
+}
+
+// generation returns the current generation of kind, cache errors are returned to skip the cache.
+func (c *ModelRegistry) generation(ctx context.Context, kind string) (string, error) {
+	value, ok, err := c.cache.Get(ctx, "mr:gen:"+kind)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "0", nil
+	}
+
+	return string(value), nil
+}
+
+// invalidate increments the generation of kind, so that no replica serves its cached reads anymore.
+func (c *ModelRegistry) invalidate(kind string) {
+	c.invalidator.Invalidate(context.Background(), kind)
+}
+
+// key returns the cache key of the result of op for args in the current generation of kind.
+func (c *ModelRegistry) key(ctx context.Context, kind, op string, args []any) (string, error) {
+	gen, err := c.generation(ctx, kind)
+	if err != nil {
+		return "", err
+	}
+
+	if c.namespace != "" {
+		args = append(args, "namespace="+c.namespace)
+	}
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(encodedArgs)
+	return fmt.Sprintf("mr:%s:%s:%s:%s", kind, gen, op, hex.EncodeToString(hash[:16])), nil
+}
+
+// entry is a cached result, with the versions of the entities read to load it: they are recorded again for the
+// request served from the cache, so that its response has the ETag of the entity.
+type entry struct {
+	Value    json.RawMessage        `json:"value"`
+	Versions []models.EntityVersion `json:"versions,omitempty"`
+}
+
+// cached returns the cached result of op for args, loading and caching it on misses. Cache failures fall back
+// to load, errors are never cached.
+func cached[T any](c *ModelRegistry, kind, op string, load func() (*T, error), args ...any) (*T, error) {
+	ctx := context.Background()
+
+	key, err := c.key(ctx, kind, op, args)
+	if err != nil {
+		glog.Warningf("Cache unavailable, reading %s from the database: %v", kind, err)
+		return load()
+	}
+
+	if value, ok, err := c.cache.Get(ctx, key); err != nil {
+		glog.Warningf("Cache unavailable, reading %s from the database: %v", kind, err)
+	} else if ok {
+		var cachedEntry entry
+		result := new(T)
+		if err := json.Unmarshal(value, &cachedEntry); err == nil && len(cachedEntry.Value) > 0 {
+			if err := json.Unmarshal(cachedEntry.Value, result); err == nil {
+				for _, version := range cachedEntry.Versions {
+					models.RecordEntityVersion(c.ctx, version)
+				}
+				return result, nil
+			}
+		}
+	}
+
+	result, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(ctx, kind, key, result)
+
+	return result, nil
+}
+
+// store caches the result under key, with the versions of the entities recorded while loading it.
+func (c *ModelRegistry) store(ctx context.Context, kind, key string, result any) {
+	value, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	if value, err = json.Marshal(entry{Value: value, Versions: models.EntityVersionsOf(c.ctx).All()}); err != nil {
+		return
+	}
+	if err := c.cache.Set(ctx, key, value, c.ttl); err != nil {
+		glog.Warningf("Unable to cache %s: %v", kind, err)
+	}
+}
+
+// invalidating invalidates kind once a write succeeded, passing its result through.
+func invalidating[T any](c *ModelRegistry, kind string, result *T, err error) (*T, error) {
+	if err == nil {
+		c.invalidate(kind)
+	}
+
+	return result, err
+}
+
+// REGISTERED MODEL
+
+func (c *ModelRegistry) UpsertRegisteredModel(registeredModel *openapi.RegisteredModel) (*openapi.RegisteredModel, error) {
+	result, err := c.ModelRegistryApi.UpsertRegisteredModel(registeredModel)
+	return invalidating(c, KindRegisteredModels, result, err)
+}
+
+func (c *ModelRegistry) GetRegisteredModelById(id string) (*openapi.RegisteredModel, error) {
+	c.usage.add(KindRegisteredModels, c.namespace, id)
+	return cached(c, KindRegisteredModels, "id", func() (*openapi.RegisteredModel, error) {
+		return c.ModelRegistryApi.GetRegisteredModelById(id)
+	}, id)
+}
+
+func (c *ModelRegistry) GetRegisteredModelByParams(name *string, externalId *string) (*openapi.RegisteredModel, error) {
+	return cached(c, KindRegisteredModels, "params", func() (*openapi.RegisteredModel, error) {
+		return c.ModelRegistryApi.GetRegisteredModelByParams(name, externalId)
+	}, name, externalId)
+}
+
+func (c *ModelRegistry) GetRegisteredModels(listOptions api.ListOptions) (*openapi.RegisteredModelList, error) {
+	return cached(c, KindRegisteredModels, "list", func() (*openapi.RegisteredModelList, error) {
+		return c.ModelRegistryApi.GetRegisteredModels(listOptions)
+	}, listOptions)
+}
+
+func (c *ModelRegistry) ArchiveRegisteredModel(id string) (*openapi.RegisteredModel, error) {
+	result, err := c.ModelRegistryApi.ArchiveRegisteredModel(id)
+	// the model versions are archived as well
+	c.invalidate(KindModelVersions)
+	return invalidating(c, KindRegisteredModels, result, err)
+}
+
+// MODEL VERSION
+
+func (c *ModelRegistry) UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error) {
+	result, err := c.ModelRegistryApi.UpsertModelVersion(modelVersion, registeredModelId)
+	return invalidating(c, KindModelVersions, result, err)
+}
+
+func (c *ModelRegistry) GetModelVersionById(id string) (*openapi.ModelVersion, error) {
+	c.usage.add(KindModelVersions, c.namespace, id)
+	return cached(c, KindModelVersions, "id", func() (*openapi.ModelVersion, error) {
+		return c.ModelRegistryApi.GetModelVersionById(id)
+	}, id)
+}
+
+func (c *ModelRegistry) GetModelVersionByParams(versionName *string, registeredModelId *string, externalId *string) (*openapi.ModelVersion, error) {
+	return cached(c, KindModelVersions, "params", func() (*openapi.ModelVersion, error) {
+		return c.ModelRegistryApi.GetModelVersionByParams(versionName, registeredModelId, externalId)
+	}, versionName, registeredModelId, externalId)
+}
+
+func (c *ModelRegistry) GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error) {
+	return cached(c, KindModelVersions, "list", func() (*openapi.ModelVersionList, error) {
+		return c.ModelRegistryApi.GetModelVersions(listOptions, registeredModelId)
+	}, listOptions, registeredModelId)
+}
+
+func (c *ModelRegistry) CreateModelVersions(registeredModelId string, batch *api.ModelVersionBatch) (*api.ModelVersionBatch, error) {
+	result, err := c.ModelRegistryApi.CreateModelVersions(registeredModelId, batch)
+	if err == nil {
+		c.invalidate(KindArtifacts)
+	}
+	return invalidating(c, KindModelVersions, result, err)
+}
+
+func (c *ModelRegistry) ArchiveModelVersion(id string) (*openapi.ModelVersion, error) {
+	result, err := c.ModelRegistryApi.ArchiveModelVersion(id)
+	return invalidating(c, KindModelVersions, result, err)
+}
+
+func (c *ModelRegistry) UpsertModelVersionPolicy(modelVersionId string, policy *api.ModelVersionPolicy) (*api.ModelVersionPolicy, error) {
+	result, err := c.ModelRegistryApi.UpsertModelVersionPolicy(modelVersionId, policy)
+	return invalidating(c, KindModelVersions, result, err)
+}
+
+func (c *ModelRegistry) UpdateModelVersionResourceFootprint(modelVersionId string, footprint *api.ResourceFootprint) (*api.ResourceFootprint, error) {
+	result, err := c.ModelRegistryApi.UpdateModelVersionResourceFootprint(modelVersionId, footprint)
+	return invalidating(c, KindModelVersions, result, err)
+}
+
+func (c *ModelRegistry) TransitionModelVersionStage(modelVersionId string, transition *api.StageTransition) (*api.StageTransition, error) {
+	result, err := c.ModelRegistryApi.TransitionModelVersionStage(modelVersionId, transition)
+	// the stage is moved even if archiving the other Production versions failed
+	c.invalidate(KindModelVersions)
+	return result, err
+}
+
+// ARTIFACT
+
+func (c *ModelRegistry) UpsertModelVersionArtifact(artifact *openapi.Artifact, modelVersionId string) (*openapi.Artifact, error) {
+	result, err := c.ModelRegistryApi.UpsertModelVersionArtifact(artifact, modelVersionId)
+	return invalidating(c, KindArtifacts, result, err)
+}
+
+func (c *ModelRegistry) UpsertArtifact(artifact *openapi.Artifact) (*openapi.Artifact, error) {
+	result, err := c.ModelRegistryApi.UpsertArtifact(artifact)
+	return invalidating(c, KindArtifacts, result, err)
+}
+
+func (c *ModelRegistry) UpsertExperimentRunArtifact(artifact *openapi.Artifact, experimentRunId string) (*openapi.Artifact, error) {
+	result, err := c.ModelRegistryApi.UpsertExperimentRunArtifact(artifact, experimentRunId)
+	return invalidating(c, KindArtifacts, result, err)
+}
+
+func (c *ModelRegistry) GetArtifactById(id string) (*openapi.Artifact, error) {
+	return cached(c, KindArtifacts, "id", func() (*openapi.Artifact, error) {
+		return c.ModelRegistryApi.GetArtifactById(id)
+	}, id)
+}
+
+func (c *ModelRegistry) GetArtifactByParams(artifactName *string, parentResourceId *string, externalId *string) (*openapi.Artifact, error) {
+	return cached(c, KindArtifacts, "params", func() (*openapi.Artifact, error) {
+		return c.ModelRegistryApi.GetArtifactByParams(artifactName, parentResourceId, externalId)
+	}, artifactName, parentResourceId, externalId)
+}
+
+func (c *ModelRegistry) GetArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions api.ListOptions, parentResourceId *string) (*openapi.ArtifactList, error) {
+	return cached(c, KindArtifacts, "list", func() (*openapi.ArtifactList, error) {
+		return c.ModelRegistryApi.GetArtifacts(artifactType, listOptions, parentResourceId)
+	}, artifactType, listOptions, parentResourceId)
+}
+
+// MODEL ARTIFACT
+
+func (c *ModelRegistry) UpsertModelArtifact(modelArtifact *openapi.ModelArtifact) (*openapi.ModelArtifact, error) {
+	result, err := c.ModelRegistryApi.UpsertModelArtifact(modelArtifact)
+	return invalidating(c, KindArtifacts, result, err)
+}
+
+func (c *ModelRegistry) GetModelArtifactById(id string) (*openapi.ModelArtifact, error) {
+	return cached(c, KindArtifacts, "model_artifact_id", func() (*openapi.ModelArtifact, error) {
+		return c.ModelRegistryApi.GetModelArtifactById(id)
+	}, id)
+}
+
+func (c *ModelRegistry) GetModelArtifactByParams(artifactName *string, parentResourceId *string, externalId *string) (*openapi.ModelArtifact, error) {
+	return cached(c, KindArtifacts, "model_artifact_params", func() (*openapi.ModelArtifact, error) {
+		return c.ModelRegistryApi.GetModelArtifactByParams(artifactName, parentResourceId, externalId)
+	}, artifactName, parentResourceId, externalId)
+}
+
+func (c *ModelRegistry) GetModelArtifacts(listOptions api.ListOptions, parentResourceId *string) (*openapi.ModelArtifactList, error) {
+	return cached(c, KindArtifacts, "model_artifact_list", func() (*openapi.ModelArtifactList, error) {
+		return c.ModelRegistryApi.GetModelArtifacts(listOptions, parentResourceId)
+	}, listOptions, parentResourceId)
+}
+
+func (c *ModelRegistry) UpdateModelArtifactVariant(artifactId string, variant *api.ArtifactVariant) (*api.ArtifactVariant, error) {
+	result, err := c.ModelRegistryApi.UpdateModelArtifactVariant(artifactId, variant)
+	return invalidating(c, KindArtifacts, result, err)
+}
+
+// MODEL CARD
+
+func (c *ModelRegistry) UpsertModelCard(registeredModelId string, modelCard *api.ModelCard) (*api.ModelCard, error) {
+	result, err := c.ModelRegistryApi.UpsertModelCard(registeredModelId, modelCard)
+	return invalidating(c, KindRegisteredModels, result, err)
+}
+
+// TAG
+
+func (c *ModelRegistry) UpsertTag(tag *api.Tag) (*api.Tag, error) {
+	result, err := c.ModelRegistryApi.UpsertTag(tag)
+	c.invalidateTagged(err)
+	return result, err
+}
+
+func (c *ModelRegistry) DeleteTag(name string) error {
+	err := c.ModelRegistryApi.DeleteTag(name)
+	c.invalidateTagged(err)
+	return err
+}
+
+func (c *ModelRegistry) SetEntityTags(entityType string, id string, tags []string) (*api.EntityTags, error) {
+	result, err := c.ModelRegistryApi.SetEntityTags(entityType, id, tags)
+	c.invalidateTagged(err)
+	return result, err
+}
+
+// invalidateTagged invalidates the registered models and model versions once a write of tags succeeded: the tags
+// filter their lists, and setting them touches the entities.
+func (c *ModelRegistry) invalidateTagged(err error) {
+	if err == nil {
+		c.invalidate(KindRegisteredModels)
+		c.invalidate(KindModelVersions)
+	}
+}
+
+// SNAPSHOT
+
+func (c *ModelRegistry) ImportSnapshot(records []api.SnapshotRecord) (*api.SnapshotImport, error) {
+	result, err := c.ModelRegistryApi.ImportSnapshot(records)
+	if err == nil {
+		c.invalidate(KindRegisteredModels)
+		c.invalidate(KindModelVersions)
+	}
+	return invalidating(c, KindArtifacts, result, err)
+}
@@ -0,0 +1,271 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	err  error
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return nil, false, m.err
+	}
+	value, ok := m.data[key]
+	return value, ok, nil
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.data[key] = value
+	return nil
+}
+
+func (m *memoryCache) Incr(_ context.Context, key string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return 0, m.err
+	}
+	n, _ := strconv.ParseInt(string(m.data[key]), 10, 64)
+	m.data[key] = []byte(strconv.FormatInt(n+1, 10))
+	return n + 1, nil
+}
+
+func (m *memoryCache) Close() error {
+	return nil
+}
+
+// countingRegistry serves model versions from memory, counting the reads.
+type countingRegistry struct {
+	api.ModelRegistryApi
+
+	versions map[string]openapi.ModelVersion
+	reads    int
+}
+
+func (r *countingRegistry) GetModelVersionById(id string) (*openapi.ModelVersion, error) {
+	r.reads++
+	version, ok := r.versions[id]
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return &version, nil
+}
+
+func (r *countingRegistry) GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error) {
+	r.reads++
+	list := &openapi.ModelVersionList{}
+	for _, version := range r.versions {
+		list.Items = append(list.Items, version)
+	}
+	return list, nil
+}
+
+func (r *countingRegistry) UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error) {
+	r.versions[*modelVersion.Id] = *modelVersion
+	return modelVersion, nil
+}
+
+func (r *countingRegistry) TransitionModelVersionStage(modelVersionId string, transition *api.StageTransition) (*api.StageTransition, error) {
+	version := r.versions[modelVersionId]
+	version.CustomProperties = map[string]openapi.MetadataValue{
+		"stage": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(string(transition.ToStage), "MetadataStringValue")),
+	}
+	r.versions[modelVersionId] = version
+	return transition, nil
+}
+
+func (r *countingRegistry) SetEntityTags(entityType string, id string, tags []string) (*api.EntityTags, error) {
+	version := r.versions[id]
+	version.Description = openapi.PtrString(strings.Join(tags, ","))
+	r.versions[id] = version
+	return &api.EntityTags{Tags: tags}, nil
+}
+
+func (r *countingRegistry) DeleteTag(name string) error {
+	return nil
+}
+
+func TestModelRegistryCache(t *testing.T) {
+	registry := &countingRegistry{versions: map[string]openapi.ModelVersion{
+		"1": {Id: openapi.PtrString("1"), Name: "v1"},
+	}}
+	memory := &memoryCache{data: map[string][]byte{}}
+	cached := NewModelRegistry(registry, memory, time.Minute)
+
+	t.Run("reads are served from the cache", func(t *testing.T) {
+		for range 3 {
+			version, err := cached.GetModelVersionById("1")
+			require.NoError(t, err)
+			assert.Equal(t, "v1", version.Name)
+		}
+		assert.Equal(t, 1, registry.reads)
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		registry.reads = 0
+		for range 2 {
+			_, err := cached.GetModelVersionById("2")
+			assert.True(t, errors.Is(err, api.ErrNotFound))
+		}
+		assert.Equal(t, 2, registry.reads)
+	})
+
+	t.Run("list options are part of the key", func(t *testing.T) {
+		registry.reads = 0
+		pageSize := int32(10)
+		_, err := cached.GetModelVersions(api.ListOptions{}, nil)
+		require.NoError(t, err)
+		_, err = cached.GetModelVersions(api.ListOptions{PageSize: &pageSize}, nil)
+		require.NoError(t, err)
+		_, err = cached.GetModelVersions(api.ListOptions{PageSize: &pageSize}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, registry.reads)
+	})
+
+	t.Run("writes invalidate cached reads", func(t *testing.T) {
+		_, err := cached.UpsertModelVersion(&openapi.ModelVersion{Id: openapi.PtrString("1"), Name: "v1-updated"}, nil)
+		require.NoError(t, err)
+
+		registry.reads = 0
+		version, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-updated", version.Name)
+		assert.Equal(t, 1, registry.reads)
+	})
+
+	t.Run("stage transitions invalidate cached reads", func(t *testing.T) {
+		_, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		_, err = cached.TransitionModelVersionStage("1", &api.StageTransition{ToStage: api.StageStaging})
+		require.NoError(t, err)
+
+		version, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		require.Contains(t, version.CustomProperties, "stage")
+		assert.Equal(t, string(api.StageStaging), version.CustomProperties["stage"].MetadataStringValue.StringValue)
+	})
+
+	t.Run("tag writes invalidate cached reads", func(t *testing.T) {
+		_, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		_, err = cached.SetEntityTags(api.TagEntityTypeModelVersion, "1", []string{"nlp", "bert"})
+		require.NoError(t, err)
+
+		version, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		assert.Equal(t, "nlp,bert", version.GetDescription())
+
+		registry.reads = 0
+		require.NoError(t, cached.DeleteTag("nlp"))
+		_, err = cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		assert.Equal(t, 1, registry.reads)
+	})
+
+	t.Run("reads are cached per tenant", func(t *testing.T) {
+		registry.reads = 0
+		for _, namespace := range []string{"team-a", "team-b", "team-a"} {
+			tenant := cached.WithContext(api.WithNamespace(context.Background(), namespace))
+			_, err := tenant.GetModelVersionById("1")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, registry.reads)
+	})
+
+	t.Run("writes outside of the registry invalidate cached reads", func(t *testing.T) {
+		conn, _, err := sqlmock.New()
+		require.NoError(t, err)
+		defer conn.Close()
+		db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+
+		// no cached reads to invalidate until the invalidator is registered
+		Invalidate(db, KindModelVersions)
+		require.NoError(t, db.Use(NewInvalidator(memory, time.Minute)))
+
+		_, err = cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		registry.versions["1"] = openapi.ModelVersion{Id: openapi.PtrString("1"), Name: "v1-reindexed"}
+		Invalidate(db, KindModelVersions)
+
+		version, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-reindexed", version.Name)
+	})
+
+	t.Run("cache failures fall back to the registry", func(t *testing.T) {
+		memory.err = errors.New("connection refused")
+		defer func() { memory.err = nil }()
+
+		registry.reads = 0
+		version, err := cached.GetModelVersionById("1")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-reindexed", version.Name)
+		assert.Equal(t, 1, registry.reads)
+	})
+}
+
+// versionedRegistry records the version of the model versions it reads, as the repositories do.
+type versionedRegistry struct {
+	*countingRegistry
+	ctx context.Context
+}
+
+func (r *versionedRegistry) WithContext(ctx context.Context) api.ModelRegistryApi {
+	return &versionedRegistry{countingRegistry: r.countingRegistry, ctx: ctx}
+}
+
+func (r *versionedRegistry) GetModelVersionById(id string) (*openapi.ModelVersion, error) {
+	version, err := r.countingRegistry.GetModelVersionById(id)
+	if err == nil {
+		parsed, _ := strconv.ParseInt(id, 10, 32)
+		models.RecordEntityVersion(r.ctx, models.EntityVersion{Table: models.VersionedContext, ID: int32(parsed), Version: 3})
+	}
+	return version, err
+}
+
+func TestModelRegistryCacheRecordsVersions(t *testing.T) {
+	registry := &countingRegistry{versions: map[string]openapi.ModelVersion{
+		"1": {Id: openapi.PtrString("1"), Name: "v1"},
+	}}
+	cached := NewModelRegistry(&versionedRegistry{countingRegistry: registry}, &memoryCache{data: map[string][]byte{}}, time.Minute)
+
+	// the versions of the reads served from the cache are recorded for the ETags of their responses
+	for range 2 {
+		ctx, versions := models.WithEntityVersions(context.Background())
+		_, err := cached.WithContext(ctx).GetModelVersionById("1")
+		require.NoError(t, err)
+
+		version, ok := versions.Get(models.VersionedContext, 1)
+		require.True(t, ok)
+		assert.Equal(t, int32(3), version)
+	}
+	assert.Equal(t, 1, registry.reads)
+}
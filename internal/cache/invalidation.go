@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+// PluginName is the name of the invalidator registered as a plugin of the database.
+const PluginName = "model-registry:cache-invalidation"
+
+// Invalidator invalidates the cached reads of the writes made to the database outside of the cached registry: by
+// the background jobs, the recycle bin and the verifications of the artifacts. They call Invalidate with their
+// database once their writes are committed.
+type Invalidator struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+// NewInvalidator returns the invalidator of the reads cached in cache for ttl.
+func NewInvalidator(cache Cache, ttl time.Duration) *Invalidator {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Invalidator{cache: cache, ttl: ttl}
+}
+
+// Name implements gorm.Plugin.
+func (i *Invalidator) Name() string {
+	return PluginName
+}
+
+// Initialize implements gorm.Plugin, the invalidator is looked up by the writers of the database with Invalidate.
+func (i *Invalidator) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// Invalidate increments the generations of kinds, so that no replica serves their cached reads anymore.
+func (i *Invalidator) Invalidate(ctx context.Context, kinds ...string) {
+	for _, kind := range kinds {
+		if _, err := i.cache.Incr(ctx, "mr:gen:"+kind); err != nil {
+			glog.Warningf("Unable to invalidate cached %s, they may be served stale for up to %s: %v", kind, i.ttl, err)
+		}
+	}
+}
+
+// Invalidate invalidates the cached reads of kinds with the invalidator registered as a plugin of db, it does nothing
+// if the reads are not cached.
+func Invalidate(db *gorm.DB, kinds ...string) {
+	if db == nil || db.Config == nil {
+		return
+	}
+	if i, ok := db.Config.Plugins[PluginName].(*Invalidator); ok {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		i.Invalidate(ctx, kinds...)
+	}
+}
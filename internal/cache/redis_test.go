@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis serves the redis commands used by the cache from memory.
+type fakeRedis struct {
+	mu       sync.Mutex
+	data     map[string]string
+	commands []string
+}
+
+func startFakeRedis(t *testing.T) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeRedis{data: map[string]string{}}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+
+	return f, listener.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	for {
+		reply, err := readReply(r)
+		if err != nil {
+			return
+		}
+
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+
+		var out string
+		switch args[0] {
+		case "AUTH":
+			out = "+OK\r\n"
+			if args[len(args)-1] != "secret" {
+				out = "-WRONGPASS invalid password\r\n"
+			}
+		case "SELECT":
+			out = "+OK\r\n"
+		case "GET":
+			if value, ok := f.data[args[1]]; ok {
+				out = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				out = "$-1\r\n"
+			}
+		case "SET":
+			f.data[args[1]] = args[2]
+			out = "+OK\r\n"
+		case "INCR":
+			n, _ := strconv.ParseInt(f.data[args[1]], 10, 64)
+			f.data[args[1]] = strconv.FormatInt(n+1, 10)
+			out = fmt.Sprintf(":%d\r\n", n+1)
+		default:
+			out = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := conn.Write([]byte(out)); err != nil {
+			return
+		}
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	f, addr := startFakeRedis(t)
+	ctx := context.Background()
+
+	c, err := NewRedisCache("redis://:secret@" + addr + "/2")
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, ok, err := c.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, c.Set(ctx, "key", []byte("line\r\nvalue"), 30*time.Second))
+
+	value, ok, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("line\r\nvalue"), value)
+
+	n, err := c.Incr(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assert.Equal(t, []string{"AUTH secret", "SELECT 2", "GET missing", "SET key line\r\nvalue PX 30000", "GET key", "INCR counter"}, f.commands,
+		"the connection is authenticated once and reused")
+}
+
+func TestRedisCacheErrors(t *testing.T) {
+	_, addr := startFakeRedis(t)
+	ctx := context.Background()
+
+	c, err := NewRedisCache("redis://:wrong@" + addr)
+	require.NoError(t, err)
+
+	_, _, err = c.Get(ctx, "key")
+	assert.ErrorContains(t, err, "WRONGPASS")
+
+	_, err = NewRedisCache("http://" + addr)
+	assert.Error(t, err)
+
+	c, err = NewRedisCache("redis://127.0.0.1:1")
+	require.NoError(t, err)
+
+	_, _, err = c.Get(ctx, "key")
+	assert.Error(t, err, "unreachable servers are reported")
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+const (
+	// WarmJobName is the name of the background job warming the cache.
+	WarmJobName = "cache-warming"
+
+	// usageKey is the key of the read counts shared by the replicas, kept for usageTTL after the last warming.
+	usageKey = "mr:usage"
+	usageTTL = 24 * time.Hour
+	// usageRetained is the number of entities whose read counts are kept per entity warmed, so that the entities
+	// read in bursts can climb into the warmed ones.
+	usageRetained = 10
+)
+
+// WarmConfig preloads the most read registered models and model versions into the cache.
+type WarmConfig struct {
+	// Top is the number of entities warmed, zero disables the warming.
+	Top int
+	// Interval is how often the cache is warmed, defaults to the TTL of the cache so the warmed entities don't expire.
+	Interval time.Duration
+}
+
+// Enabled reports whether the cache is warmed.
+func (c WarmConfig) Enabled() bool {
+	return c.Top > 0
+}
+
+// usageCount is the read count of an entity, its reads of the past warmings are halved at each warming so that the
+// popular entities are the ones read recently.
+type usageCount struct {
+	Kind      string  `json:"kind"`
+	Namespace string  `json:"namespace,omitempty"`
+	ID        string  `json:"id"`
+	Count     float64 `json:"count"`
+}
+
+type usageEntity struct {
+	kind      string
+	namespace string
+	id        string
+}
+
+// usage counts the reads by id of the entities on this replica between two warmings.
+type usage struct {
+	mu    sync.Mutex
+	reads map[usageEntity]int64
+}
+
+func newUsage() *usage {
+	return &usage{reads: map[usageEntity]int64{}}
+}
+
+func (u *usage) add(kind, namespace, id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.reads[usageEntity{kind: kind, namespace: namespace, id: id}]++
+}
+
+// take returns the reads counted since the last call.
+func (u *usage) take() map[usageEntity]int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	reads := u.reads
+	u.reads = map[usageEntity]int64{}
+	return reads
+}
+
+// Warm adds the reads counted by this replica to the read counts shared by the replicas, and reloads the top most
+// read registered models and model versions into the cache, returning how many were warmed. The counts of the
+// replicas warming at the same time can be lost, the popularity is approximate. The entities that can't be read
+// anymore are skipped.
+func (c *ModelRegistry) Warm(ctx context.Context, top int) (int, error) {
+	counts, err := c.mergeUsage(ctx, top)
+	if err != nil {
+		return 0, err
+	}
+
+	warmed := 0
+	for _, count := range counts[:min(top, len(counts))] {
+		// the versions of the entities are recorded for the ETags of the responses served from the cache
+		warmCtx, _ := models.WithEntityVersions(api.WithNamespace(ctx, count.Namespace))
+		registry := c.WithContext(warmCtx).(*ModelRegistry)
+		if err := registry.warm(ctx, count.Kind, count.ID); err != nil {
+			glog.V(2).Infof("Skipping the warming of %s %s: %v", count.Kind, count.ID, err)
+			continue
+		}
+		warmed++
+	}
+
+	return warmed, nil
+}
+
+// mergeUsage adds the reads of this replica to the shared read counts and returns them, most read first.
+func (c *ModelRegistry) mergeUsage(ctx context.Context, top int) ([]usageCount, error) {
+	counts := map[usageEntity]float64{}
+
+	value, ok, err := c.cache.Get(ctx, usageKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the read counts: %w", err)
+	}
+	if ok {
+		var stored []usageCount
+		if err := json.Unmarshal(value, &stored); err != nil {
+			glog.Warningf("Discarding the invalid read counts of the cache: %v", err)
+		}
+		for _, count := range stored {
+			counts[usageEntity{kind: count.Kind, namespace: count.Namespace, id: count.ID}] = count.Count / 2
+		}
+	}
+	for entity, reads := range c.usage.take() {
+		counts[entity] += float64(reads)
+	}
+
+	merged := make([]usageCount, 0, len(counts))
+	for entity, count := range counts {
+		merged = append(merged, usageCount{Kind: entity.kind, Namespace: entity.namespace, ID: entity.id, Count: count})
+	}
+	slices.SortFunc(merged, func(a, b usageCount) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.ID, b.ID))
+	})
+	merged = merged[:min(top*usageRetained, len(merged))]
+
+	value, err = json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.Set(ctx, usageKey, value, usageTTL); err != nil {
+		return nil, fmt.Errorf("error saving the read counts: %w", err)
+	}
+
+	return merged, nil
+}
+
+// warm reloads the entity of kind with id into the cache, as read by id.
+func (c *ModelRegistry) warm(ctx context.Context, kind, id string) error {
+	key, err := c.key(ctx, kind, "id", []any{id})
+	if err != nil {
+		return err
+	}
+
+	var result any
+	switch kind {
+	case KindRegisteredModels:
+		result, err = c.ModelRegistryApi.GetRegisteredModelById(id)
+	case KindModelVersions:
+		result, err = c.ModelRegistryApi.GetModelVersionById(id)
+	default:
+		return fmt.Errorf("%s are not warmed", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.store(ctx, kind, key, result)
+	return nil
+}
+
+// Job returns the background job warming the cache with the top most read entities, every interval.
+func (c *ModelRegistry) Job(config WarmConfig) jobs.Job {
+	interval := config.Interval
+	if interval <= 0 {
+		interval = c.ttl
+	}
+	return jobs.Job{
+		Name:        WarmJobName,
+		Description: "Preloads the most read registered models and model versions into the cache",
+		Interval:    interval,
+		Run: func(ctx context.Context) error {
+			warmed, err := c.Warm(ctx, config.Top)
+			if warmed > 0 {
+				glog.V(2).Infof("Warmed the cache with %d registered models and model versions", warmed)
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarm(t *testing.T) {
+	registry := &countingRegistry{versions: map[string]openapi.ModelVersion{
+		"1": {Id: openapi.PtrString("1"), Name: "v1"},
+		"2": {Id: openapi.PtrString("2"), Name: "v2"},
+		"3": {Id: openapi.PtrString("3"), Name: "v3"},
+	}}
+	memory := &memoryCache{data: map[string][]byte{}}
+	cached := NewModelRegistry(registry, memory, time.Minute)
+	ctx := context.Background()
+
+	for id, reads := range map[string]int{"1": 3, "2": 1, "3": 2, "9": 4} {
+		for range reads {
+			_, _ = cached.GetModelVersionById(id)
+		}
+	}
+
+	// the read counts are shared by the replicas, most read first
+	warmed, err := cached.Warm(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, warmed, "the model version 9 doesn't exist")
+
+	var counts []usageCount
+	require.NoError(t, json.Unmarshal(memory.data[usageKey], &counts))
+	require.Len(t, counts, 4)
+	assert.Equal(t, usageCount{Kind: KindModelVersions, ID: "9", Count: 4}, counts[0])
+	assert.Equal(t, usageCount{Kind: KindModelVersions, ID: "1", Count: 3}, counts[1])
+
+	// the warmed entities are reloaded once invalidated, and served from the cache
+	_, err = cached.UpsertModelVersion(&openapi.ModelVersion{Id: openapi.PtrString("1"), Name: "v1-updated"}, nil)
+	require.NoError(t, err)
+	_, err = cached.Warm(ctx, 3)
+	require.NoError(t, err)
+
+	registry.reads = 0
+	version, err := cached.GetModelVersionById("1")
+	require.NoError(t, err)
+	assert.Equal(t, "v1-updated", version.Name)
+	version, err = cached.GetModelVersionById("3")
+	require.NoError(t, err)
+	assert.Equal(t, "v3", version.Name)
+	assert.Equal(t, 0, registry.reads)
+
+	// the past reads count less than the recent ones
+	require.NoError(t, json.Unmarshal(memory.data[usageKey], &counts))
+	assert.Equal(t, []usageCount{
+		{Kind: KindModelVersions, ID: "9", Count: 2},
+		{Kind: KindModelVersions, ID: "1", Count: 1.5},
+	}, counts[:2])
+
+	// the reads of the tenants are warmed in their namespace
+	tenant := cached.WithContext(api.WithNamespace(ctx, "team-a"))
+	for range 10 {
+		_, err := tenant.GetModelVersionById("2")
+		require.NoError(t, err)
+	}
+	_, err = cached.Warm(ctx, 1)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(memory.data[usageKey], &counts))
+	assert.Equal(t, usageCount{Kind: KindModelVersions, Namespace: "team-a", ID: "2", Count: 10}, counts[0])
+}
@@ -0,0 +1,40 @@
+// Package clientutil holds the helpers of the tools calling a model registry server through the generated REST client:
+// the errors of the client, and the state file of the runs resumed where they stopped.
+package clientutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// RestError adds the body of the error responses to the errors of the client.
+func RestError(err error) error {
+	var apiErr *openapi.GenericOpenAPIError
+	if errors.As(err, &apiErr) && len(apiErr.Body()) > 0 {
+		err = fmt.Errorf("%w: %s", err, apiErr.Body())
+	}
+	return err
+}
+
+// SaveState writes state as JSON to file, nothing when file is empty.
+func SaveState(file string, state any) error {
+	if file == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that an interrupted write never loses the progress
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
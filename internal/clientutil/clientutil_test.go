@@ -0,0 +1,31 @@
+package clientutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestError(t *testing.T) {
+	assert.Nil(t, RestError(nil))
+
+	err := errors.New("404 Not Found")
+	assert.Same(t, err, RestError(err))
+}
+
+func TestSaveState(t *testing.T) {
+	require.NoError(t, SaveState("", map[string]string{"a": "b"}))
+
+	file := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, SaveState(file, map[string]string{"a": "b"}))
+	require.NoError(t, SaveState(file, map[string]string{"a": "c"}))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":"c"}`, string(data))
+	assert.NoFileExists(t, file+".tmp")
+}
@@ -0,0 +1,121 @@
+// Package conversion triggers the external jobs converting model artifacts, e.g. exporting them to ONNX or
+// quantizing them to INT8. Converters report completion back to the registry, which registers the converted
+// artifact as a variant of the model version.
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultTimeout bounds the time a converter takes to accept a job.
+const DefaultTimeout = 30 * time.Second
+
+var converterNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// Request is sent to the converter when a conversion job starts.
+type Request struct {
+	Job api.ConversionJob `json:"job"`
+	// SourceArtifact is the model artifact to convert.
+	SourceArtifact openapi.ModelArtifact `json:"sourceArtifact"`
+	// CompletePath is the path of the registry API the converter reports the outcome of the job to.
+	CompletePath string `json:"completePath"`
+}
+
+// Hook hands conversion jobs to an external converter, Trigger returns once the converter accepted the job.
+type Hook interface {
+	Trigger(ctx context.Context, request Request) error
+}
+
+// Hooks are the configured hooks by converter name.
+type Hooks map[string]Hook
+
+// CompletePath returns the path of the registry API completing the conversion job jobId.
+func CompletePath(jobId string) string {
+	return fmt.Sprintf("/api/model_registry/v1alpha3/conversion_jobs/%s:complete", jobId)
+}
+
+// ParseHooks returns the webhooks configured by specs of the form "<converter>=<url>".
+func ParseHooks(specs []string) (Hooks, error) {
+	hooks := Hooks{}
+
+	for _, spec := range specs {
+		name, hookURL, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid conversion hook %q, expected <converter>=<url>", spec)
+		}
+
+		if !converterNameRegexp.MatchString(name) {
+			return nil, fmt.Errorf("invalid converter name %q: must consist of lower case alphanumeric characters or '-'", name)
+		}
+
+		if _, ok := hooks[name]; ok {
+			return nil, fmt.Errorf("duplicate conversion hook for converter %s", name)
+		}
+
+		hook, err := NewWebhook(hookURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid conversion hook of converter %s: %w", name, err)
+		}
+		hooks[name] = hook
+	}
+
+	return hooks, nil
+}
+
+// webhook is a Hook posting the request as JSON to the url of the converter.
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns the Hook posting requests to hookURL, any 2xx response accepts the job.
+func NewWebhook(hookURL string) (Hook, error) {
+	parsed, err := url.Parse(hookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url %q, the scheme must be http or https", hookURL)
+	}
+
+	return &webhook{url: hookURL, client: &http.Client{Timeout: DefaultTimeout}}, nil
+}
+
+func (w *webhook) Trigger(ctx context.Context, request Request) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to encode conversion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("converter responded %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
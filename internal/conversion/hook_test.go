@@ -0,0 +1,71 @@
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHooks(t *testing.T) {
+	hooks, err := ParseHooks([]string{"onnx-export=http://converter/onnx", "int8=https://converter/int8"})
+	require.NoError(t, err)
+	assert.Len(t, hooks, 2)
+	assert.Contains(t, hooks, "onnx-export")
+	assert.Contains(t, hooks, "int8")
+
+	hooks, err = ParseHooks(nil)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	for _, specs := range [][]string{
+		{"onnx-export"},
+		{"ONNX=http://converter"},
+		{"onnx=ftp://converter"},
+		{"onnx=http://converter/a", "onnx=http://converter/b"},
+	} {
+		_, err := ParseHooks(specs)
+		assert.Error(t, err, "specs %v", specs)
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	var received Request
+	status := http.StatusAccepted
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("queue full\n"))
+	}))
+	defer server.Close()
+
+	hook, err := NewWebhook(server.URL)
+	require.NoError(t, err)
+
+	uri := "s3://models/granite/v1"
+	request := Request{
+		Job: api.ConversionJob{
+			Id:         "7",
+			Converter:  "int8",
+			Parameters: map[string]string{"calibration": "wikitext"},
+		},
+		SourceArtifact: openapi.ModelArtifact{Uri: &uri},
+		CompletePath:   CompletePath("7"),
+	}
+
+	require.NoError(t, hook.Trigger(context.Background(), request))
+	assert.Equal(t, request, received)
+	assert.Equal(t, "/api/model_registry/v1alpha3/conversion_jobs/7:complete", received.CompletePath)
+
+	status = http.StatusServiceUnavailable
+	err = hook.Trigger(context.Background(), request)
+	assert.ErrorContains(t, err, "converter responded 503 Service Unavailable: queue full")
+}
@@ -0,0 +1,55 @@
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// emptyOmittedFields are the object fields removed by OmitEmptyJSON when they are empty.
+var emptyOmittedFields = map[string]bool{
+	"properties":       true,
+	"customProperties": true,
+}
+
+// OmitEmptyJSON removes the null fields and the empty properties and customProperties objects from a JSON document,
+// at any depth. Numbers are kept as they are written, so that large ids and timestamps don't lose precision.
+func OmitEmptyJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(omitEmpty(document)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func omitEmpty(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if field == nil {
+				delete(value, key)
+				continue
+			}
+			field = omitEmpty(field)
+			if object, ok := field.(map[string]any); ok && len(object) == 0 && emptyOmittedFields[key] {
+				delete(value, key)
+				continue
+			}
+			value[key] = field
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = omitEmpty(item)
+		}
+	}
+	return value
+}
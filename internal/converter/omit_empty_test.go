@@ -0,0 +1,35 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOmitEmptyJSON(t *testing.T) {
+	compacted, err := OmitEmptyJSON([]byte(`{
+		"items": [
+			{"id": "1", "name": "a", "description": null, "customProperties": {}, "properties": {}},
+			{"id": "2", "customProperties": {"team": {"string_value": "risk", "metadataType": "MetadataStringValue"}}, "tags": {}}
+		],
+		"nextPageToken": "",
+		"size": 2,
+		"lastUpdateTimeSinceEpoch": 1792000977876543210
+	}`))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"items": [
+			{"id": "1", "name": "a"},
+			{"id": "2", "customProperties": {"team": {"string_value": "risk", "metadataType": "MetadataStringValue"}}, "tags": {}}
+		],
+		"nextPageToken": "",
+		"size": 2,
+		"lastUpdateTimeSinceEpoch": 1792000977876543210
+	}`, string(compacted))
+	assert.Contains(t, string(compacted), "1792000977876543210")
+
+	_, err = OmitEmptyJSON([]byte(`{"id": `))
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package converter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// CustomPropertyFieldPrefix prefixes the redacted custom properties, e.g. customProperties.cost.
+const CustomPropertyFieldPrefix = "customProperties."
+
+// RedactJSON removes the redacted fields from the objects of the JSON documents of data, e.g. a single document or
+// newline delimited changes, at any depth, so that the entities are redacted alike in single entities, lists and
+// nested documents. A field is the JSON name of a field of the entities, e.g. uri, or a custom property prefixed
+// with CustomPropertyFieldPrefix. Numbers are kept as they are written, as by OmitEmptyJSON.
+func RedactJSON(data []byte, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	redacted := map[string]bool{}
+	customProperties := map[string]bool{}
+	for _, field := range fields {
+		if name, ok := strings.CutPrefix(field, CustomPropertyFieldPrefix); ok {
+			customProperties[name] = true
+		} else {
+			redacted[field] = true
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for {
+		var document any
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return buf.Bytes(), nil
+		} else if err != nil {
+			return nil, err
+		}
+		if err := encoder.Encode(redact(document, redacted, customProperties)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func redact(value any, fields map[string]bool, customProperties map[string]bool) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, field := range value {
+			if fields[key] {
+				delete(value, key)
+				continue
+			}
+			if properties, ok := field.(map[string]any); ok && key == "customProperties" {
+				for name := range customProperties {
+					delete(properties, name)
+				}
+			}
+			value[key] = redact(field, fields, customProperties)
+		}
+	case []any:
+		for i, item := range value {
+			value[i] = redact(item, fields, customProperties)
+		}
+	}
+	return value
+}
+
+type redactedFieldsKey struct{}
+
+// WithRedactedFields returns a copy of ctx carrying the fields redacted from the responses of a request, for the
+// responses which are not JSON documents, e.g. the lineage exports.
+func WithRedactedFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, redactedFieldsKey{}, fields)
+}
+
+// RedactedFields returns the fields redacted from the responses of the request of ctx, if any.
+func RedactedFields(ctx context.Context) []string {
+	fields, _ := ctx.Value(redactedFieldsKey{}).([]string)
+	return fields
+}
@@ -0,0 +1,44 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactJSON(t *testing.T) {
+	redacted, err := RedactJSON([]byte(`{
+		"items": [
+			{"id": "1", "name": "a", "uri": "s3://internal/a", "customProperties": {"cost": {"double_value": 3.5}, "team": {"string_value": "risk"}}},
+			{"id": "2", "name": "b", "customProperties": {}}
+		],
+		"size": 2,
+		"lastUpdateTimeSinceEpoch": 1792000977876543210
+	}`), []string{"uri", "customProperties.cost"})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"items": [
+			{"id": "1", "name": "a", "customProperties": {"team": {"string_value": "risk"}}},
+			{"id": "2", "name": "b", "customProperties": {}}
+		],
+		"size": 2,
+		"lastUpdateTimeSinceEpoch": 1792000977876543210
+	}`, string(redacted))
+	assert.Contains(t, string(redacted), "1792000977876543210")
+
+	// newline delimited documents, e.g. watch changes, are redacted one by one
+	redacted, err = RedactJSON([]byte("{\"type\":\"ADDED\",\"entity\":{\"id\":\"1\",\"uri\":\"s3://a\"}}\n{\"type\":\"BOOKMARK\"}\n"), []string{"uri"})
+	require.NoError(t, err)
+	assert.Equal(t, "{\"entity\":{\"id\":\"1\"},\"type\":\"ADDED\"}\n{\"type\":\"BOOKMARK\"}\n", string(redacted))
+
+	_, err = RedactJSON([]byte(`{"id": `), []string{"uri"})
+	assert.Error(t, err)
+}
+
+func TestRedactedFields(t *testing.T) {
+	assert.Empty(t, RedactedFields(context.Background()))
+	assert.Equal(t, []string{"uri"}, RedactedFields(WithRedactedFields(context.Background(), []string{"uri"})))
+}
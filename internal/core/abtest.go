@@ -0,0 +1,349 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"gorm.io/gorm"
+)
+
+// ABTest properties
+const (
+	abTestRegisteredModelIdProperty    = "registered_model_id"
+	abTestDescriptionProperty          = "description"
+	abTestVariantsProperty             = "variants"
+	abTestStartTimeProperty            = "start_time_since_epoch"
+	abTestEndTimeProperty              = "end_time_since_epoch"
+	abTestStateProperty                = "state"
+	abTestWinnerModelVersionIdProperty = "winner_model_version_id"
+)
+
+// abTestMinVariants is the minimum number of variants of an A/B test.
+const abTestMinVariants = 2
+
+// CreateABTest creates a running A/B test of versions of the registered model, the names of the tests are
+// prefixed with the id of the registered model as those of the model versions.
+func (b *ModelRegistryService) CreateABTest(registeredModelId string, abTest *api.ABTest) (*api.ABTest, error) {
+	if abTest == nil {
+		return nil, fmt.Errorf("invalid ab test pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if abTest.Name == "" {
+		return nil, fmt.Errorf("missing ab test name: %w", api.ErrBadRequest)
+	}
+
+	registeredModelID, err := apiutils.ValidateIDAsInt32(registeredModelId, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.GetRegisteredModelById(registeredModelId); err != nil {
+		return nil, err
+	}
+
+	variants, err := b.validateABTestVariants(registeredModelId, abTest.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now().UnixMilli()
+	if abTest.StartTimeSinceEpoch != "" {
+		startTime, err = parseABTestTime(abTest.StartTimeSinceEpoch, "startTimeSinceEpoch")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	typeID, ok := b.typesMap[defaults.ABTestTypeName]
+	if !ok {
+		return nil, fmt.Errorf("ab test type not found in types map")
+	}
+
+	encodedVariants, err := json.Marshal(variants)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode ab test variants: %w", err)
+	}
+
+	props := []models.Properties{
+		models.NewIntProperty(abTestRegisteredModelIdProperty, registeredModelID, false),
+		models.NewStringProperty(abTestDescriptionProperty, abTest.Description, false),
+		models.NewStringProperty(abTestVariantsProperty, string(encodedVariants), false),
+		models.NewStringProperty(abTestStartTimeProperty, strconv.FormatInt(startTime, 10), false),
+		models.NewStringProperty(abTestStateProperty, string(api.ABTestRunning), false),
+	}
+	if abTest.EndTimeSinceEpoch != "" {
+		endTime, err := parseABTestTime(abTest.EndTimeSinceEpoch, "endTimeSinceEpoch")
+		if err != nil {
+			return nil, err
+		}
+		if endTime <= startTime {
+			return nil, fmt.Errorf("ab test endTimeSinceEpoch must be after startTimeSinceEpoch: %w", api.ErrBadRequest)
+		}
+		props = append(props, models.NewStringProperty(abTestEndTimeProperty, strconv.FormatInt(endTime, 10), false))
+	}
+
+	name := converter.PrefixWhenOwned(&registeredModelId, abTest.Name)
+	entity := &models.ABTestImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.ABTestAttributes{Name: &name},
+		Properties: &props,
+	}
+
+	saved, err := b.abTestRepository.Save(entity, &registeredModelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("ab test with name %s already exists: %w", abTest.Name, api.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return mapToABTest(saved)
+}
+
+func (b *ModelRegistryService) GetABTestById(id string) (*api.ABTest, error) {
+	entity, err := b.getABTestEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToABTest(entity)
+}
+
+func (b *ModelRegistryService) GetABTests(listOptions api.ListOptions, registeredModelId *string) (*api.ABTestList, error) {
+	var registeredModelID *int32
+
+	if registeredModelId != nil {
+		var err error
+		registeredModelID, err = apiutils.ValidateIDAsInt32Ptr(registeredModelId, "registered model")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	abTests, err := b.abTestRepository.List(models.ABTestListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		RegisteredModelID: registeredModelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	abTestList := &api.ABTestList{
+		Items: []api.ABTest{},
+	}
+
+	for _, entity := range abTests.Items {
+		mapped, err := mapToABTest(entity)
+		if err != nil {
+			return nil, err
+		}
+		abTestList.Items = append(abTestList.Items, *mapped)
+	}
+
+	abTestList.NextPageToken = abTests.NextPageToken
+	abTestList.PageSize = abTests.PageSize
+	abTestList.Size = abTests.Size
+
+	return abTestList, nil
+}
+
+// RecordABTestResult replaces the recorded values of the metrics of the variants by those of the result, and
+// completes the test when the result has a winner.
+func (b *ModelRegistryService) RecordABTestResult(id string, result *api.ABTestResult) (*api.ABTest, error) {
+	if result == nil {
+		return nil, fmt.Errorf("invalid ab test result pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if len(result.Variants) == 0 && result.WinnerModelVersionId == "" {
+		return nil, fmt.Errorf("ab test result must record metrics or a winner: %w", api.ErrBadRequest)
+	}
+
+	// Serialize the results so that concurrent results of a test do not drop each other's metrics
+	b.abTestMu.Lock()
+	defer b.abTestMu.Unlock()
+
+	entity, err := b.getABTestEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	abTest, err := mapToABTest(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if abTest.State != api.ABTestRunning {
+		return nil, fmt.Errorf("ab test %s is %s, only running tests record results: %w", id, abTest.State, api.ErrBadRequest)
+	}
+
+	variants := map[string]*api.ABTestVariant{}
+	for i := range abTest.Variants {
+		variants[abTest.Variants[i].ModelVersionId] = &abTest.Variants[i]
+	}
+
+	for _, recorded := range result.Variants {
+		variant, ok := variants[recorded.ModelVersionId]
+		if !ok {
+			return nil, fmt.Errorf("model version %s is not a variant of ab test %s: %w", recorded.ModelVersionId, id, api.ErrBadRequest)
+		}
+		if variant.Metrics == nil {
+			variant.Metrics = map[string]float64{}
+		}
+		for name, value := range recorded.Metrics {
+			variant.Metrics[name] = value
+		}
+	}
+
+	encodedVariants, err := json.Marshal(abTest.Variants)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode ab test variants: %w", err)
+	}
+
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(abTestVariantsProperty, string(encodedVariants), false))
+
+	if result.WinnerModelVersionId != "" {
+		if _, ok := variants[result.WinnerModelVersionId]; !ok {
+			return nil, fmt.Errorf("winner model version %s is not a variant of ab test %s: %w", result.WinnerModelVersionId, id, api.ErrBadRequest)
+		}
+		winnerID, err := apiutils.ValidateIDAsInt32(result.WinnerModelVersionId, "model version")
+		if err != nil {
+			return nil, err
+		}
+
+		setProperty(props, models.NewStringProperty(abTestStateProperty, string(api.ABTestCompleted), false))
+		setProperty(props, models.NewIntProperty(abTestWinnerModelVersionIdProperty, winnerID, false))
+		if abTest.EndTimeSinceEpoch == "" {
+			setProperty(props, models.NewStringProperty(abTestEndTimeProperty, strconv.FormatInt(time.Now().UnixMilli(), 10), false))
+		}
+
+		glog.Infof("AB test %s completed, model version %s won", id, result.WinnerModelVersionId)
+	}
+
+	saved, err := b.abTestRepository.Save(entity, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToABTest(saved)
+}
+
+// validateABTestVariants checks that the variants are distinct live versions of the registered model splitting the
+// whole traffic, returning them without metrics.
+func (b *ModelRegistryService) validateABTestVariants(registeredModelId string, variants []api.ABTestVariant) ([]api.ABTestVariant, error) {
+	if len(variants) < abTestMinVariants {
+		return nil, fmt.Errorf("ab test must have at least %d variants: %w", abTestMinVariants, api.ErrBadRequest)
+	}
+
+	validated := make([]api.ABTestVariant, 0, len(variants))
+	seen := map[string]bool{}
+	traffic := int32(0)
+
+	for _, variant := range variants {
+		if seen[variant.ModelVersionId] {
+			return nil, fmt.Errorf("model version %s is more than one variant of the ab test: %w", variant.ModelVersionId, api.ErrBadRequest)
+		}
+		seen[variant.ModelVersionId] = true
+
+		if variant.TrafficPercent < 0 || variant.TrafficPercent > 100 {
+			return nil, fmt.Errorf("invalid traffic percent %d of model version %s, must be between 0 and 100: %w", variant.TrafficPercent, variant.ModelVersionId, api.ErrBadRequest)
+		}
+		traffic += variant.TrafficPercent
+
+		modelVersion, err := b.GetModelVersionById(variant.ModelVersionId)
+		if err != nil {
+			return nil, err
+		}
+
+		if modelVersion.RegisteredModelId != registeredModelId {
+			return nil, fmt.Errorf("model version %s is not a version of registered model %s: %w", variant.ModelVersionId, registeredModelId, api.ErrBadRequest)
+		}
+
+		if modelVersion.State != nil && *modelVersion.State == openapi.MODELVERSIONSTATE_ARCHIVED {
+			return nil, fmt.Errorf("model version %s is archived and can not be tested: %w", variant.ModelVersionId, api.ErrBadRequest)
+		}
+
+		validated = append(validated, api.ABTestVariant{
+			ModelVersionId: variant.ModelVersionId,
+			TrafficPercent: variant.TrafficPercent,
+		})
+	}
+
+	if traffic != 100 {
+		return nil, fmt.Errorf("traffic percents of the ab test variants sum to %d, must sum to 100: %w", traffic, api.ErrBadRequest)
+	}
+
+	return validated, nil
+}
+
+// getABTestEntity loads the data layer ABTest, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getABTestEntity(id string) (models.ABTest, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "ab test")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.abTestRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no ab test found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return entity, nil
+}
+
+func parseABTestTime(value string, field string) (int64, error) {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid ab test %s %q, must be milliseconds since epoch: %w", field, value, api.ErrBadRequest)
+	}
+	return parsed, nil
+}
+
+func mapToABTest(entity models.ABTest) (*api.ABTest, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.ABTest{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		Name:                     apiutils.ZeroIfNil(converter.MapNameFromOwned(attrs.Name)),
+		Description:              stringPropertyValue(props, abTestDescriptionProperty),
+		Variants:                 []api.ABTestVariant{},
+		StartTimeSinceEpoch:      stringPropertyValue(props, abTestStartTimeProperty),
+		EndTimeSinceEpoch:        stringPropertyValue(props, abTestEndTimeProperty),
+		State:                    api.ABTestState(stringPropertyValue(props, abTestStateProperty)),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	for name, id := range map[string]*string{
+		abTestRegisteredModelIdProperty:    &mapped.RegisteredModelId,
+		abTestWinnerModelVersionIdProperty: &mapped.WinnerModelVersionId,
+	} {
+		if prop := findProperty(props, name); prop != nil && prop.IntValue != nil {
+			*id = strconv.FormatInt(int64(*prop.IntValue), 10)
+		}
+	}
+
+	if variants := stringPropertyValue(props, abTestVariantsProperty); variants != "" {
+		if err := json.Unmarshal([]byte(variants), &mapped.Variants); err != nil {
+			return nil, fmt.Errorf("unable to decode variants of ab test %s: %w", mapped.Id, err)
+		}
+	}
+
+	return mapped, nil
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/archive"
+)
+
+// SetRehydrator restores archived model versions and experiment runs when they are fetched.
+func (b *ModelRegistryService) SetRehydrator(rehydrator archive.Rehydrator) {
+	b.rehydrator = rehydrator
+}
+
+// rehydrate restores the archived properties of an entity, reporting whether it was archived
+func (b *ModelRegistryService) rehydrate(id int32) (bool, error) {
+	if b.rehydrator == nil {
+		return false, nil
+	}
+
+	rehydrated, err := b.rehydrator.Rehydrate(context.Background(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to rehydrate archived entity %d: %w", id, err)
+	}
+
+	return rehydrated, nil
+}
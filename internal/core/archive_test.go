@@ -0,0 +1,86 @@
+package core_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/archive"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveAndRehydrateModelVersion(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	_service := createModelRegistryService(t, db)
+
+	store, err := archive.NewFileBlobStore(t.TempDir())
+	require.NoError(t, err)
+
+	archiver, err := archive.NewArchiver(db, store, archive.Policy{After: time.Hour}, getTypeIDs(t, db))
+	require.NoError(t, err)
+
+	_service.SetRehydrator(archiver)
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "archive-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	customProperties := map[string]openapi.MetadataValue{
+		"training_config": {
+			MetadataStringValue: &openapi.MetadataStringValue{
+				StringValue:  "a very large training configuration",
+				MetadataType: "MetadataStringValue",
+			},
+		},
+	}
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "archive-test-version",
+		RegisteredModelId: *registeredModel.Id,
+		Author:            apiutils.Of("author"),
+		CustomProperties:  customProperties,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	id, err := apiutils.ValidateIDAsInt32(*modelVersion.Id, "model version")
+	require.NoError(t, err)
+
+	// Entities updated within the threshold are not archived
+	archived, err := archiver.ArchiveCold(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+
+	coldTime := time.Now().Add(-2 * time.Hour).UnixMilli()
+	require.NoError(t, db.Model(&schema.Context{}).Where("id = ?", id).Update("last_update_time_since_epoch", coldTime).Error)
+
+	archived, err = archiver.ArchiveCold(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	var count int64
+	require.NoError(t, db.Model(&schema.ContextProperty{}).Where("context_id = ? AND is_custom_property = ?", id, true).Count(&count).Error)
+	assert.Equal(t, int64(0), count, "custom properties are removed from the database")
+
+	fetched, err := _service.GetModelVersionById(*modelVersion.Id)
+	require.NoError(t, err)
+	assert.Equal(t, customProperties, fetched.CustomProperties)
+	assert.Equal(t, "author", *fetched.Author)
+	assert.Equal(t, strconv.FormatInt(coldTime, 10), *fetched.LastUpdateTimeSinceEpoch, "rehydration does not update the entity")
+
+	// Recently rehydrated entities are not archived again
+	archived, err = archiver.ArchiveCold(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, archived)
+
+	rehydrated, err := archiver.Rehydrate(context.Background(), id)
+	require.NoError(t, err)
+	assert.False(t, rehydrated)
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/converter"
@@ -49,9 +50,25 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		return nil, fmt.Errorf("invalid artifact pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkArtifactExternalIdAvailable(artifact); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyArtifactNamingPolicy(artifact); err != nil {
+		return nil, err
+	}
+
 	// Ensure artifact has a name if it's being created
 	ensureArtifactName(artifact)
 
+	if err := b.injectArtifactMetadataDefaults(artifact); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyArtifactPropertyLimits(artifact); err != nil {
+		return nil, err
+	}
+
 	// Only convert parentResourceId to int32 if it's provided
 	if parentResourceId != nil {
 		var err error
@@ -70,6 +87,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 	}
 
 	if ma := artifact.ModelArtifact; ma != nil {
+		var removed []models.Properties
 		if ma.Id != nil {
 			existing, err := b.getArtifact(*ma.Id)
 			if err != nil {
@@ -91,6 +109,13 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 				withNotEditable.CustomProperties = existing.ModelArtifact.CustomProperties
 			}
 
+			withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing.ModelArtifact, withNotEditable, func(a *openapi.ModelArtifact) (models.ModelArtifact, error) {
+				return b.mapper.MapFromModelArtifact(a, parentResourceId)
+			})
+			if err != nil {
+				return nil, err
+			}
+
 			ma = &withNotEditable
 		}
 
@@ -98,6 +123,21 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 		}
+		removeProperties(modelArtifact.GetProperties(), removed)
+		if err := setUriProperties(modelArtifact.GetProperties(), ma.Uri); err != nil {
+			return nil, err
+		}
+
+		if ma.Id == nil {
+			canonical, found, err := canonicalArtifact(b, b.modelArtifactRepository, ma.Uri, ma.CustomProperties)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				// The canonical artifact is linked to the parent instead of creating a duplicate of it
+				modelArtifact = canonical
+			}
+		}
 
 		modelArtifact, err = b.modelArtifactRepository.Save(modelArtifact, parentResourceIDPtr)
 		if err != nil {
@@ -107,6 +147,8 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 			return nil, err
 		}
 
+		b.verifyModelArtifact(modelArtifact)
+
 		toReturn, err := b.mapper.MapToModelArtifact(modelArtifact)
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
@@ -115,6 +157,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		artToReturn.ModelArtifact = toReturn
 		return artToReturn, nil
 	} else if da := artifact.DocArtifact; da != nil {
+		var removed []models.Properties
 		if da.Id != nil {
 			existing, err := b.getArtifact(*da.Id)
 			if err != nil {
@@ -136,6 +179,13 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 				withNotEditable.CustomProperties = existing.DocArtifact.CustomProperties
 			}
 
+			withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing.DocArtifact, withNotEditable, func(a *openapi.DocArtifact) (models.DocArtifact, error) {
+				return b.mapper.MapFromDocArtifact(a, parentResourceId)
+			})
+			if err != nil {
+				return nil, err
+			}
+
 			da = &withNotEditable
 		}
 
@@ -143,6 +193,21 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 		}
+		removeProperties(docArtifact.GetProperties(), removed)
+		if err := setUriProperties(docArtifact.GetProperties(), da.Uri); err != nil {
+			return nil, err
+		}
+
+		if da.Id == nil {
+			canonical, found, err := canonicalArtifact(b, b.docArtifactRepository, da.Uri, da.CustomProperties)
+			if err != nil {
+				return nil, err
+			}
+			if found {
+				// The canonical artifact is linked to the parent instead of creating a duplicate of it
+				docArtifact = canonical
+			}
+		}
 
 		docArtifact, err = b.docArtifactRepository.Save(docArtifact, parentResourceIDPtr)
 		if err != nil {
@@ -161,6 +226,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		return artToReturn, nil
 	} else if ds := artifact.DataSet; ds != nil {
 		// Handle DataSet artifacts using embedmd converters
+		var removed []models.Properties
 		if ds.Id != nil {
 			existing, err := b.getArtifact(*ds.Id)
 			if err != nil {
@@ -182,6 +248,11 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 				withNotEditable.CustomProperties = existing.DataSet.CustomProperties
 			}
 
+			withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing.DataSet, withNotEditable, b.mapper.MapFromDataSet)
+			if err != nil {
+				return nil, err
+			}
+
 			ds = &withNotEditable
 		}
 
@@ -189,6 +260,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 		}
+		removeProperties(dataSetEntity.GetProperties(), removed)
 
 		dataSetEntity, err = b.dataSetRepository.Save(dataSetEntity, parentResourceIDPtr)
 		if err != nil {
@@ -212,6 +284,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 			return nil, fmt.Errorf("metric value is required: %w", api.ErrBadRequest)
 		}
 
+		var removed []models.Properties
 		if me.Id != nil {
 			existing, err := b.getArtifact(*me.Id)
 			if err != nil {
@@ -233,6 +306,13 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 				withNotEditable.CustomProperties = existing.Metric.CustomProperties
 			}
 
+			withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing.Metric, withNotEditable, func(a *openapi.Metric) (models.Metric, error) {
+				return b.mapper.MapFromMetric(a, parentResourceId)
+			})
+			if err != nil {
+				return nil, err
+			}
+
 			me = &withNotEditable
 		} else {
 			// For new metrics (no ID), check if a metric with the same name already exists
@@ -265,6 +345,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 		}
+		removeProperties(metricEntity.GetProperties(), removed)
 
 		metricEntity, err = b.metricRepository.Save(metricEntity, parentResourceIDPtr)
 		if err != nil {
@@ -283,6 +364,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		return artToReturn, nil
 	} else if pa := artifact.Parameter; pa != nil {
 		// ADD PARAMETER SUPPORT using embedmd converters
+		var removed []models.Properties
 		if pa.Id != nil {
 			existing, err := b.getArtifact(*pa.Id)
 			if err != nil {
@@ -304,6 +386,13 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 				withNotEditable.CustomProperties = existing.Parameter.CustomProperties
 			}
 
+			withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing.Parameter, withNotEditable, func(a *openapi.Parameter) (models.Parameter, error) {
+				return b.mapper.MapFromParameter(a, parentResourceId)
+			})
+			if err != nil {
+				return nil, err
+			}
+
 			pa = &withNotEditable
 		} else {
 			// For new parameters (no ID), check if a parameter with the same name already exists
@@ -336,6 +425,7 @@ func (b *ModelRegistryService) upsertArtifact(artifact *openapi.Artifact, parent
 		if err != nil {
 			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 		}
+		removeProperties(parameterEntity.GetProperties(), removed)
 
 		parameterEntity, err = b.parameterRepository.Save(parameterEntity, parentResourceIDPtr)
 		if err != nil {
@@ -469,6 +559,31 @@ func (b *ModelRegistryService) GetArtifactByParams(artifactName *string, parentR
 	return b.getArtifactByParams(artifactName, parentResourceId, externalId, "")
 }
 
+// artifactRoleTypes are the artifact types playing each role for their model version or experiment run.
+var artifactRoleTypes = map[string]openapi.ArtifactTypeQueryParam{
+	api.ArtifactRoleModel:      openapi.ARTIFACTTYPEQUERYPARAM_MODEL_ARTIFACT,
+	api.ArtifactRoleData:       openapi.ARTIFACTTYPEQUERYPARAM_DATASET_ARTIFACT,
+	api.ArtifactRoleMetrics:    openapi.ARTIFACTTYPEQUERYPARAM_METRIC,
+	api.ArtifactRoleParameters: openapi.ARTIFACTTYPEQUERYPARAM_PARAMETER,
+	api.ArtifactRoleDoc:        openapi.ARTIFACTTYPEQUERYPARAM_DOC_ARTIFACT,
+}
+
+// artifactTypesOfRoles returns the artifact types of the roles, for the repository to filter the artifacts by type.
+func artifactTypesOfRoles(roles []string) ([]string, error) {
+	var artifactTypes []string
+	for _, role := range roles {
+		artifactType, ok := artifactRoleTypes[role]
+		if !ok {
+			return nil, fmt.Errorf("invalid artifact role %q, must be one of %s, %s, %s, %s or %s: %w", role,
+				api.ArtifactRoleModel, api.ArtifactRoleData, api.ArtifactRoleMetrics, api.ArtifactRoleParameters, api.ArtifactRoleDoc, api.ErrBadRequest)
+		}
+		if !slices.Contains(artifactTypes, string(artifactType)) {
+			artifactTypes = append(artifactTypes, string(artifactType))
+		}
+	}
+	return artifactTypes, nil
+}
+
 func (b *ModelRegistryService) GetArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions api.ListOptions, parentResourceId *string) (*openapi.ArtifactList, error) {
 	var parentResourceIDPtr *int32
 
@@ -486,6 +601,11 @@ func (b *ModelRegistryService) GetArtifacts(artifactType openapi.ArtifactTypeQue
 		artifactTypeStr = (*string)(&artifactType)
 	}
 
+	artifactTypes, err := artifactTypesOfRoles(listOptions.Roles)
+	if err != nil {
+		return nil, err
+	}
+
 	artifacts, err := b.artifactRepository.List(models.ArtifactListOptions{
 		Pagination: models.Pagination{
 			PageSize:      listOptions.PageSize,
@@ -493,9 +613,11 @@ func (b *ModelRegistryService) GetArtifacts(artifactType openapi.ArtifactTypeQue
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
 			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
 		},
 		ParentResourceID: parentResourceIDPtr,
 		ArtifactType:     artifactTypeStr,
+		ArtifactTypes:    artifactTypes,
 	})
 	if err != nil {
 		return nil, err
@@ -600,6 +722,7 @@ func (b *ModelRegistryService) GetModelArtifacts(listOptions api.ListOptions, pa
 			OrderBy:       listOptions.OrderBy,
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
 		},
 		ParentResourceID: parentResourceIDPtr,
 	})
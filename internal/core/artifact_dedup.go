@@ -0,0 +1,34 @@
+package core
+
+import (
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetArtifactDeduplication links the model and doc artifacts created with a uri and the digest custom property of an
+// existing artifact of their type to their parent instead of duplicating it, the existing artifact being returned.
+func (b *ModelRegistryService) SetArtifactDeduplication(enabled bool) {
+	b.deduplicateArtifacts = enabled
+}
+
+// canonicalArtifact returns the canonical artifact of the digest of a new artifact with a uri when the artifacts are
+// deduplicated, false if there is none.
+func canonicalArtifact[E any](b *ModelRegistryService, finder models.DigestFinder[E], uri *string, customProperties map[string]openapi.MetadataValue) (E, bool, error) {
+	var zero E
+	if !b.deduplicateArtifacts || uri == nil || *uri == "" {
+		return zero, false, nil
+	}
+	digest, ok := customProperties[models.DigestCustomProperty]
+	if !ok || digest.MetadataStringValue == nil || digest.MetadataStringValue.StringValue == "" {
+		return zero, false, nil
+	}
+
+	canonical, err := finder.FindByDigest(digest.MetadataStringValue.StringValue)
+	if err != nil {
+		return zero, false, api.IgnoreNotFound(err)
+	}
+	glog.V(2).Infof("Linking the canonical artifact of digest %s instead of creating %s", digest.MetadataStringValue.StringValue, *uri)
+	return canonical, true, nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelArtifact properties holding the last verification of the uri
+const (
+	uriReachableProperty           = "uri_reachable"
+	uriSizeBytesProperty           = "uri_size_bytes"
+	uriVerifiedAtProperty          = "uri_verified_at"
+	uriVerificationMessageProperty = "uri_verification_message"
+)
+
+// SetArtifactVerifier verifies the uri of the model artifacts in the background each time they are saved.
+func (b *ModelRegistryService) SetArtifactVerifier(verifier *reachability.Verifier) {
+	b.artifactVerifier = verifier
+}
+
+// verifyModelArtifact schedules the verification of the uri of a saved model artifact, if enabled.
+func (b *ModelRegistryService) verifyModelArtifact(modelArtifact models.ModelArtifact) {
+	if b.artifactVerifier == nil || modelArtifact.GetID() == nil {
+		return
+	}
+
+	uri := apiutils.ZeroIfNil(modelArtifact.GetAttributes().URI)
+	if uri == "" {
+		return
+	}
+
+	b.artifactVerifier.Enqueue(strconv.FormatInt(int64(*modelArtifact.GetID()), 10), uri)
+}
+
+// RecordArtifactReachability saves the verification of the uri of a model artifact, it is a reachability.RecordFunc.
+// Verifications of a previous uri of the artifact are discarded.
+func (b *ModelRegistryService) RecordArtifactReachability(artifactId string, uri string, result reachability.Result, verifiedAt time.Time) error {
+	modelArtifact, err := b.getModelArtifactEntity(artifactId)
+	if err != nil {
+		return err
+	}
+
+	if current := apiutils.ZeroIfNil(modelArtifact.GetAttributes().URI); current != uri {
+		glog.V(4).Infof("Discarding verification of artifact %s, its uri changed", artifactId)
+		return nil
+	}
+
+	size := ""
+	if result.SizeBytes != nil {
+		size = strconv.FormatInt(*result.SizeBytes, 10)
+	}
+
+	props := modelArtifact.GetProperties()
+	setProperty(props, models.NewBoolProperty(uriReachableProperty, result.Reachable, false))
+	setProperty(props, models.NewStringProperty(uriSizeBytesProperty, size, false))
+	setProperty(props, models.NewStringProperty(uriVerifiedAtProperty, strconv.FormatInt(verifiedAt.UnixMilli(), 10), false))
+	setProperty(props, models.NewStringProperty(uriVerificationMessageProperty, result.Message, false))
+
+	if _, err := b.modelArtifactRepository.Save(modelArtifact, nil); err != nil {
+		return err
+	}
+
+	// the verifications are saved by the verifier, not through the cached registry
+	cache.Invalidate(b.db, cache.KindArtifacts)
+	return nil
+}
+
+func (b *ModelRegistryService) GetModelArtifactReachability(artifactId string) (*api.ArtifactReachability, error) {
+	modelArtifact, err := b.getModelArtifactEntity(artifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapArtifactReachability(modelArtifact), nil
+}
+
+// GetUnreachableModelArtifacts reports the broken links, it lists all the model artifacts.
+func (b *ModelRegistryService) GetUnreachableModelArtifacts() (*api.ArtifactReachabilityList, error) {
+	modelArtifacts, err := b.listModelArtifacts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &api.ArtifactReachabilityList{
+		Items: []api.ArtifactReachability{},
+	}
+
+	for _, modelArtifact := range modelArtifacts {
+		verification := mapArtifactReachability(modelArtifact)
+		if verification.Reachable != nil && !*verification.Reachable {
+			report.Items = append(report.Items, *verification)
+		}
+	}
+
+	report.Size = int32(len(report.Items))
+
+	return report, nil
+}
+
+func mapArtifactReachability(modelArtifact models.ModelArtifact) *api.ArtifactReachability {
+	attrs := modelArtifact.GetAttributes()
+	props := modelArtifact.GetProperties()
+
+	verification := &api.ArtifactReachability{
+		ArtifactId:                 strconv.FormatInt(int64(*modelArtifact.GetID()), 10),
+		Name:                       apiutils.ZeroIfNil(attrs.Name),
+		Uri:                        apiutils.ZeroIfNil(attrs.URI),
+		SizeBytes:                  stringPropertyValue(props, uriSizeBytesProperty),
+		LastVerifiedTimeSinceEpoch: stringPropertyValue(props, uriVerifiedAtProperty),
+		Message:                    stringPropertyValue(props, uriVerificationMessageProperty),
+	}
+
+	if prop := findProperty(props, uriReachableProperty); prop != nil {
+		verification.Reachable = prop.BoolValue
+	}
+
+	return verification
+}
@@ -0,0 +1,113 @@
+package core_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactReachability(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model.onnx" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Length", "1024")
+	}))
+	defer storage.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	verifier := reachability.NewVerifier(reachability.NewChecker(reachability.Config{AllowPrivateNetworks: true}), _service.RecordArtifactReachability, 1)
+	verifier.Start(ctx)
+	_service.SetArtifactVerifier(verifier)
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "reachability-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "reachability-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	register := func(name string, uri string) string {
+		artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+			ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of(name), Uri: apiutils.Of(uri)},
+		}, *modelVersion.Id)
+		require.NoError(t, err)
+		return *artifact.ModelArtifact.Id
+	}
+
+	verified := func(artifactId string) *api.ArtifactReachability {
+		var verification *api.ArtifactReachability
+		require.Eventually(t, func() bool {
+			var err error
+			verification, err = _service.GetModelArtifactReachability(artifactId)
+			return err == nil && verification.Reachable != nil
+		}, 5*time.Second, 20*time.Millisecond)
+		return verification
+	}
+
+	reachable := register("reachable", storage.URL+"/model.onnx")
+	broken := register("broken", storage.URL+"/missing.onnx")
+	unsupported := register("unsupported", "pvc://models/granite")
+
+	t.Run("reachable uri", func(t *testing.T) {
+		verification := verified(reachable)
+		assert.True(t, *verification.Reachable)
+		assert.Equal(t, "1024", verification.SizeBytes)
+		assert.NotEmpty(t, verification.LastVerifiedTimeSinceEpoch)
+		assert.Empty(t, verification.Message)
+	})
+
+	t.Run("broken uri", func(t *testing.T) {
+		verification := verified(broken)
+		assert.False(t, *verification.Reachable)
+		assert.Equal(t, "server responded 404 Not Found", verification.Message)
+
+		report, err := _service.GetUnreachableModelArtifacts()
+		require.NoError(t, err)
+		require.Equal(t, int32(1), report.Size)
+		assert.Equal(t, broken, report.Items[0].ArtifactId)
+		assert.Equal(t, "broken", report.Items[0].Name)
+	})
+
+	t.Run("fixed uri", func(t *testing.T) {
+		_, err := _service.UpsertModelArtifact(&openapi.ModelArtifact{
+			Id:  &broken,
+			Uri: apiutils.Of(storage.URL + "/model.onnx"),
+		})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			verification, err := _service.GetModelArtifactReachability(broken)
+			return err == nil && *verification.Reachable
+		}, 5*time.Second, 20*time.Millisecond)
+
+		report, err := _service.GetUnreachableModelArtifacts()
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), report.Size)
+	})
+
+	t.Run("unsupported uri", func(t *testing.T) {
+		verification, err := _service.GetModelArtifactReachability(unsupported)
+		require.NoError(t, err)
+		assert.Nil(t, verification.Reachable, "unsupported schemes are not verified")
+	})
+}
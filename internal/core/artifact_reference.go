@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// referencePageSize is the page size used to list all the entities referencing an artifact
+var referencePageSize = int32(100)
+
+// GetModelArtifactReferences returns the entities referencing the blob at the uri of a model artifact, through any
+// of the model artifacts registered with the same uri. Inference services are matched on their model version, or on
+// their registered model when they serve its latest version.
+func (b *ModelRegistryService) GetModelArtifactReferences(artifactId string) (*api.ArtifactReferences, error) {
+	modelArtifact, err := b.getModelArtifactEntity(artifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := apiutils.ZeroIfNil(modelArtifact.GetAttributes().URI)
+
+	sharing := []models.ModelArtifact{modelArtifact}
+	if uri != "" {
+		sharing, err = b.listModelArtifactsByUri(uri)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	references := &api.ArtifactReferences{
+		ArtifactId:  artifactId,
+		Uri:         uri,
+		ArtifactIds: []string{},
+		References:  []api.ArtifactReference{},
+	}
+
+	// model version id and registered model id to the id of the model artifact of the version
+	versionArtifacts := map[string]string{}
+	registeredModelArtifacts := map[string]string{}
+
+	for _, artifact := range sharing {
+		id := strconv.FormatInt(int64(*artifact.GetID()), 10)
+		references.ArtifactIds = append(references.ArtifactIds, id)
+
+		versions, err := b.listArtifactModelVersions(*artifact.GetID())
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range versions {
+			state := string(apiutils.ZeroIfNil(version.State))
+			references.References = append(references.References, api.ArtifactReference{
+				Kind:       api.ArtifactReferenceModelVersion,
+				Id:         *version.Id,
+				Ref:        api.NewEntityRef(api.EntityTypeModelVersion, *version.Id),
+				Name:       version.Name,
+				ArtifactId: id,
+				State:      state,
+				Active:     state != string(openapi.MODELVERSIONSTATE_ARCHIVED),
+			})
+			versionArtifacts[*version.Id] = id
+			registeredModelArtifacts[version.RegisteredModelId] = id
+		}
+
+		runs, err := b.listArtifactExperimentRuns(*artifact.GetID())
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			state := string(apiutils.ZeroIfNil(run.State))
+			references.References = append(references.References, api.ArtifactReference{
+				Kind:       api.ArtifactReferenceExperimentRun,
+				Id:         *run.Id,
+				Ref:        api.NewEntityRef(api.EntityTypeExperimentRun, *run.Id),
+				Name:       apiutils.ZeroIfNil(run.Name),
+				ArtifactId: id,
+				State:      state,
+				Active:     state != string(openapi.EXPERIMENTRUNSTATE_ARCHIVED),
+			})
+		}
+	}
+
+	if len(versionArtifacts) > 0 {
+		inferenceServices, err := b.listAllInferenceServices()
+		if err != nil {
+			return nil, err
+		}
+		for _, inferenceService := range inferenceServices {
+			id, ok := "", false
+			if inferenceService.ModelVersionId != nil {
+				id, ok = versionArtifacts[*inferenceService.ModelVersionId]
+			} else {
+				id, ok = registeredModelArtifacts[inferenceService.RegisteredModelId]
+			}
+			if !ok {
+				continue
+			}
+
+			state := string(apiutils.ZeroIfNil(inferenceService.DesiredState))
+			references.References = append(references.References, api.ArtifactReference{
+				Kind:       api.ArtifactReferenceInferenceService,
+				Id:         *inferenceService.Id,
+				Ref:        api.NewEntityRef(api.EntityTypeInferenceService, *inferenceService.Id),
+				Name:       apiutils.ZeroIfNil(inferenceService.Name),
+				ArtifactId: id,
+				State:      state,
+				Active:     state != string(openapi.INFERENCESERVICESTATE_UNDEPLOYED),
+			})
+		}
+	}
+
+	for _, reference := range references.References {
+		if reference.Active {
+			references.ActiveReferences++
+		}
+	}
+	references.Deletable = references.ActiveReferences == 0
+
+	return references, nil
+}
+
+// listModelArtifactsByUri returns all the model artifacts registered with the uri.
+func (b *ModelRegistryService) listModelArtifactsByUri(uri string) ([]models.ModelArtifact, error) {
+	modelArtifacts := []models.ModelArtifact{}
+
+	filterQuery := fmt.Sprintf(`uri = "%s"`, strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(uri))
+	listOptions := models.ModelArtifactListOptions{
+		Pagination: models.Pagination{PageSize: &referencePageSize, FilterQuery: &filterQuery},
+	}
+	for {
+		page, err := b.modelArtifactRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		modelArtifacts = append(modelArtifacts, page.Items...)
+
+		if page.NextPageToken == "" {
+			return modelArtifacts, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// listArtifactModelVersions returns the model versions the artifact is attributed to.
+func (b *ModelRegistryService) listArtifactModelVersions(artifactId int32) ([]openapi.ModelVersion, error) {
+	modelVersions := []openapi.ModelVersion{}
+
+	listOptions := models.ModelVersionListOptions{
+		Pagination: models.Pagination{PageSize: &referencePageSize},
+		ArtifactID: &artifactId,
+	}
+	for {
+		page, err := b.modelVersionRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			modelVersion, err := b.mapper.MapToModelVersion(item)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+			}
+			modelVersions = append(modelVersions, *modelVersion)
+		}
+
+		if page.NextPageToken == "" {
+			return modelVersions, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// listArtifactExperimentRuns returns the experiment runs the artifact is attributed to.
+func (b *ModelRegistryService) listArtifactExperimentRuns(artifactId int32) ([]openapi.ExperimentRun, error) {
+	experimentRuns := []openapi.ExperimentRun{}
+
+	listOptions := models.ExperimentRunListOptions{
+		Pagination: models.Pagination{PageSize: &referencePageSize},
+		ArtifactID: &artifactId,
+	}
+	for {
+		page, err := b.experimentRunRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			experimentRun, err := b.mapper.MapToExperimentRun(item)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+			}
+			experimentRuns = append(experimentRuns, *experimentRun)
+		}
+
+		if page.NextPageToken == "" {
+			return experimentRuns, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// listAllInferenceServices returns the inference services of all the serving environments.
+func (b *ModelRegistryService) listAllInferenceServices() ([]openapi.InferenceService, error) {
+	inferenceServices := []openapi.InferenceService{}
+
+	listOptions := models.InferenceServiceListOptions{
+		Pagination: models.Pagination{PageSize: &referencePageSize},
+	}
+	for {
+		page, err := b.inferenceServiceRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			inferenceService, err := b.mapper.MapToInferenceService(item)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+			}
+			inferenceServices = append(inferenceServices, *inferenceService)
+		}
+
+		if page.NextPageToken == "" {
+			return inferenceServices, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
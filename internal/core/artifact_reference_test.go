@@ -0,0 +1,130 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetModelArtifactReferences(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	const uri = "s3://models/granite/model.safetensors"
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "references-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "references-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	versionArtifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of("model"), Uri: apiutils.Of(uri)},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+	versionArtifactId := *versionArtifact.ModelArtifact.Id
+
+	experiment, err := _service.UpsertExperiment(&openapi.Experiment{Name: "references-test-experiment"})
+	require.NoError(t, err)
+
+	experimentRun, err := _service.UpsertExperimentRun(&openapi.ExperimentRun{
+		Name: apiutils.Of("references-test-run"),
+	}, experiment.Id)
+	require.NoError(t, err)
+
+	runArtifact, err := _service.UpsertExperimentRunArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of("checkpoint"), Uri: apiutils.Of(uri)},
+	}, *experimentRun.Id)
+	require.NoError(t, err)
+	runArtifactId := *runArtifact.ModelArtifact.Id
+
+	unused, err := _service.UpsertModelArtifact(&openapi.ModelArtifact{
+		Name: apiutils.Of("references-test-unused"),
+		Uri:  apiutils.Of("s3://models/unused"),
+	})
+	require.NoError(t, err)
+
+	t.Run("shared uri", func(t *testing.T) {
+		references, err := _service.GetModelArtifactReferences(versionArtifactId)
+		require.NoError(t, err)
+
+		assert.Equal(t, uri, references.Uri)
+		assert.ElementsMatch(t, []string{versionArtifactId, runArtifactId}, references.ArtifactIds)
+		assert.ElementsMatch(t, []api.ArtifactReference{
+			{Kind: api.ArtifactReferenceModelVersion, Id: *modelVersion.Id, Ref: api.NewEntityRef(api.EntityTypeModelVersion, *modelVersion.Id), Name: "references-test-version", ArtifactId: versionArtifactId, State: "LIVE", Active: true},
+			{Kind: api.ArtifactReferenceExperimentRun, Id: *experimentRun.Id, Ref: api.NewEntityRef(api.EntityTypeExperimentRun, *experimentRun.Id), Name: "references-test-run", ArtifactId: runArtifactId, State: "LIVE", Active: true},
+		}, references.References)
+		assert.Equal(t, int32(2), references.ActiveReferences)
+		assert.False(t, references.Deletable)
+	})
+
+	t.Run("deployed version", func(t *testing.T) {
+		servingEnv, err := _service.UpsertServingEnvironment(&openapi.ServingEnvironment{
+			Name: "references-test-serving-env",
+		})
+		require.NoError(t, err)
+
+		inferenceService, err := _service.UpsertInferenceService(&openapi.InferenceService{
+			Name:                 apiutils.Of("references-test-inference-service"),
+			ServingEnvironmentId: *servingEnv.Id,
+			RegisteredModelId:    *registeredModel.Id,
+			ModelVersionId:       modelVersion.Id,
+		})
+		require.NoError(t, err)
+
+		references, err := _service.GetModelArtifactReferences(runArtifactId)
+		require.NoError(t, err)
+		assert.Contains(t, references.References, api.ArtifactReference{
+			Kind:       api.ArtifactReferenceInferenceService,
+			Id:         *inferenceService.Id,
+			Ref:        api.NewEntityRef(api.EntityTypeInferenceService, *inferenceService.Id),
+			Name:       "references-test-inference-service",
+			ArtifactId: versionArtifactId,
+			State:      "DEPLOYED",
+			Active:     true,
+		})
+		assert.Equal(t, int32(3), references.ActiveReferences)
+
+		inferenceService.DesiredState = apiutils.Of(openapi.INFERENCESERVICESTATE_UNDEPLOYED)
+		_, err = _service.UpsertInferenceService(inferenceService)
+		require.NoError(t, err)
+	})
+
+	t.Run("archived references", func(t *testing.T) {
+		modelVersion.State = apiutils.Of(openapi.MODELVERSIONSTATE_ARCHIVED)
+		_, err := _service.UpsertModelVersion(modelVersion, registeredModel.Id)
+		require.NoError(t, err)
+
+		experimentRun.State = apiutils.Of(openapi.EXPERIMENTRUNSTATE_ARCHIVED)
+		_, err = _service.UpsertExperimentRun(experimentRun, experiment.Id)
+		require.NoError(t, err)
+
+		references, err := _service.GetModelArtifactReferences(versionArtifactId)
+		require.NoError(t, err)
+		assert.Len(t, references.References, 3, "inactive references are still reported")
+		assert.Equal(t, int32(0), references.ActiveReferences)
+		assert.True(t, references.Deletable)
+	})
+
+	t.Run("unreferenced artifact", func(t *testing.T) {
+		references, err := _service.GetModelArtifactReferences(*unused.Id)
+		require.NoError(t, err)
+		assert.Equal(t, []string{*unused.Id}, references.ArtifactIds)
+		assert.Empty(t, references.References)
+		assert.True(t, references.Deletable)
+	})
+
+	t.Run("unknown artifact", func(t *testing.T) {
+		_, err := _service.GetModelArtifactReferences("999999")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
@@ -0,0 +1,48 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/storageuri"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelArtifact and DocArtifact properties holding the components of their uri, filtered on as uriScheme, uriBucket,
+// uriPath and uriRegion
+const (
+	uriSchemeProperty = "uri_scheme"
+	uriBucketProperty = "uri_bucket"
+	uriPathProperty   = "uri_path"
+	uriRegionProperty = "uri_region"
+)
+
+// setUriProperties validates the uri of an artifact and sets its components in the properties of the artifact. The
+// components of a previous uri missing from the new one are removed.
+func setUriProperties(props *[]models.Properties, uri *string) error {
+	if props == nil || uri == nil {
+		return nil
+	}
+
+	parsed, err := storageuri.Parse(*uri)
+	if err != nil {
+		return fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	components := []struct{ name, value string }{
+		{uriSchemeProperty, parsed.Scheme},
+		{uriBucketProperty, parsed.Bucket},
+		{uriPathProperty, parsed.Path},
+		{uriRegionProperty, parsed.Region},
+	}
+	for _, component := range components {
+		if component.value == "" {
+			// well-known properties without a value are removed when saved
+			setProperty(props, models.Properties{Name: component.name})
+			continue
+		}
+		setProperty(props, models.NewStringProperty(component.name, component.value, false))
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// ModelArtifact properties holding the variant the artifact is built for
+const (
+	variantArchitectureProperty = "variant_architecture"
+	variantAcceleratorProperty  = "variant_accelerator"
+	variantPrecisionProperty    = "variant_precision"
+	variantEngineProperty       = "variant_engine"
+)
+
+var variantValueRegexp = regexp.MustCompile(`^[a-z0-9]([-_.a-z0-9]*[a-z0-9])?$`)
+
+// modelArtifactPageSize is the page size used to list all the model artifacts
+var modelArtifactPageSize = int32(100)
+
+func (b *ModelRegistryService) GetModelArtifactVariant(artifactId string) (*api.ArtifactVariant, error) {
+	glog.Infof("Getting variant for ModelArtifact id %s", artifactId)
+
+	modelArtifact, err := b.getModelArtifactEntity(artifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapArtifactVariant(artifactId, modelArtifact.GetProperties()), nil
+}
+
+func (b *ModelRegistryService) UpdateModelArtifactVariant(artifactId string, variant *api.ArtifactVariant) (*api.ArtifactVariant, error) {
+	if variant == nil {
+		return nil, fmt.Errorf("invalid artifact variant pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := validateArtifactVariant(variant); err != nil {
+		return nil, err
+	}
+
+	modelArtifact, err := b.getModelArtifactEntity(artifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	props := modelArtifact.GetProperties()
+	if variant.Architecture != nil {
+		setProperty(props, models.NewStringProperty(variantArchitectureProperty, *variant.Architecture, false))
+	}
+	if variant.Accelerator != nil {
+		setProperty(props, models.NewStringProperty(variantAcceleratorProperty, *variant.Accelerator, false))
+	}
+	if variant.Precision != nil {
+		setProperty(props, models.NewStringProperty(variantPrecisionProperty, *variant.Precision, false))
+	}
+	if variant.Engine != nil {
+		setProperty(props, models.NewStringProperty(variantEngineProperty, *variant.Engine, false))
+	}
+
+	saved, err := b.modelArtifactRepository.Save(modelArtifact, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapArtifactVariant(artifactId, saved.GetProperties()), nil
+}
+
+// ResolveModelVersionArtifact returns the model artifact of the model version matching the most fields of query.
+// Variants leaving a field unset are generic and match any value, artifacts being deleted are never resolved.
+// Ties go to the most specific variant, then to the most recently updated artifact.
+func (b *ModelRegistryService) ResolveModelVersionArtifact(modelVersionId string, query api.ArtifactVariantQuery) (*openapi.Artifact, error) {
+	glog.Infof("Resolving artifact for ModelVersion id %s", modelVersionId)
+
+	modelVersionIdInt, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.getModelVersionEntity(modelVersionId); err != nil {
+		return nil, err
+	}
+
+	query = api.ArtifactVariantQuery{
+		Architecture: strings.ToLower(query.Architecture),
+		Accelerator:  strings.ToLower(query.Accelerator),
+		Precision:    strings.ToLower(query.Precision),
+		Engine:       strings.ToLower(query.Engine),
+	}
+
+	modelArtifacts, err := b.listModelArtifacts(&modelVersionIdInt)
+	if err != nil {
+		return nil, err
+	}
+
+	var best models.ModelArtifact
+	bestScore := variantScore{}
+
+	for _, modelArtifact := range modelArtifacts {
+		if !resolvableArtifactState(modelArtifact.GetAttributes().State) {
+			continue
+		}
+
+		score, ok := matchArtifactVariant(mapArtifactVariant("", modelArtifact.GetProperties()), query)
+		if !ok {
+			continue
+		}
+
+		if best == nil || bestScore.less(score, best, modelArtifact) {
+			best, bestScore = modelArtifact, score
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no artifact of model version %s matches %s: %w", modelVersionId, describeVariantQuery(query), api.ErrNotFound)
+	}
+
+	toReturn, err := b.mapper.MapToModelArtifact(best)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	return &openapi.Artifact{ModelArtifact: toReturn}, nil
+}
+
+// listModelArtifacts returns all the model artifacts, of a model version if parentResourceId is set.
+func (b *ModelRegistryService) listModelArtifacts(parentResourceId *int32) ([]models.ModelArtifact, error) {
+	modelArtifacts := []models.ModelArtifact{}
+
+	listOptions := models.ModelArtifactListOptions{
+		Pagination:       models.Pagination{PageSize: &modelArtifactPageSize},
+		ParentResourceID: parentResourceId,
+	}
+	for {
+		page, err := b.modelArtifactRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		modelArtifacts = append(modelArtifacts, page.Items...)
+
+		if page.NextPageToken == "" {
+			return modelArtifacts, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+func (b *ModelRegistryService) getModelArtifactEntity(artifactId string) (models.ModelArtifact, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(artifactId, "artifact")
+	if err != nil {
+		return nil, err
+	}
+
+	modelArtifact, err := b.modelArtifactRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no model artifact found for id %s: %w", artifactId, api.ErrNotFound)
+	}
+
+	return modelArtifact, nil
+}
+
+// variantScore ranks the variants matching a query.
+type variantScore struct {
+	// matched is the number of query fields the variant sets to the requested value
+	matched int
+	// specific is the number of fields the variant sets
+	specific int
+}
+
+// less reports whether the current best artifact, scored s, ranks below candidate scored other.
+func (s variantScore) less(other variantScore, best models.ModelArtifact, candidate models.ModelArtifact) bool {
+	if s.matched != other.matched {
+		return s.matched < other.matched
+	}
+	if s.specific != other.specific {
+		return s.specific < other.specific
+	}
+
+	bestUpdate := apiutils.ZeroIfNil(best.GetAttributes().LastUpdateTimeSinceEpoch)
+	candidateUpdate := apiutils.ZeroIfNil(candidate.GetAttributes().LastUpdateTimeSinceEpoch)
+	if bestUpdate != candidateUpdate {
+		return bestUpdate < candidateUpdate
+	}
+	return apiutils.ZeroIfNil(best.GetID()) < apiutils.ZeroIfNil(candidate.GetID())
+}
+
+// matchArtifactVariant scores variant against query, ok is false when a field set in both differs.
+func matchArtifactVariant(variant *api.ArtifactVariant, query api.ArtifactVariantQuery) (score variantScore, ok bool) {
+	fields := []struct {
+		value     *string
+		requested string
+	}{
+		{variant.Architecture, query.Architecture},
+		{variant.Accelerator, query.Accelerator},
+		{variant.Precision, query.Precision},
+		{variant.Engine, query.Engine},
+	}
+
+	for _, field := range fields {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+		score.specific++
+
+		if field.requested == "" {
+			continue
+		}
+		if *field.value != field.requested {
+			return variantScore{}, false
+		}
+		score.matched++
+	}
+
+	return score, true
+}
+
+func resolvableArtifactState(state *string) bool {
+	if state == nil {
+		return true
+	}
+
+	switch openapi.ArtifactState(*state) {
+	case openapi.ARTIFACTSTATE_MARKED_FOR_DELETION, openapi.ARTIFACTSTATE_DELETED, openapi.ARTIFACTSTATE_ABANDONED:
+		return false
+	}
+
+	return true
+}
+
+func describeVariantQuery(query api.ArtifactVariantQuery) string {
+	fields := []string{}
+	for _, field := range []struct{ name, value string }{
+		{"architecture", query.Architecture},
+		{"accelerator", query.Accelerator},
+		{"precision", query.Precision},
+		{"engine", query.Engine},
+	} {
+		if field.value != "" {
+			fields = append(fields, field.name+"="+field.value)
+		}
+	}
+
+	if len(fields) == 0 {
+		return "any variant"
+	}
+	return strings.Join(fields, ", ")
+}
+
+func mapArtifactVariant(artifactId string, props *[]models.Properties) *api.ArtifactVariant {
+	variant := &api.ArtifactVariant{
+		ArtifactId: artifactId,
+	}
+
+	if prop := findProperty(props, variantArchitectureProperty); prop != nil && apiutils.ZeroIfNil(prop.StringValue) != "" {
+		variant.Architecture = prop.StringValue
+	}
+	if prop := findProperty(props, variantAcceleratorProperty); prop != nil && apiutils.ZeroIfNil(prop.StringValue) != "" {
+		variant.Accelerator = prop.StringValue
+	}
+	if prop := findProperty(props, variantPrecisionProperty); prop != nil && apiutils.ZeroIfNil(prop.StringValue) != "" {
+		variant.Precision = prop.StringValue
+	}
+	if prop := findProperty(props, variantEngineProperty); prop != nil && apiutils.ZeroIfNil(prop.StringValue) != "" {
+		variant.Engine = prop.StringValue
+	}
+
+	return variant
+}
+
+func validateArtifactVariant(variant *api.ArtifactVariant) error {
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"architecture", variant.Architecture},
+		{"accelerator", variant.Accelerator},
+		{"precision", variant.Precision},
+		{"engine", variant.Engine},
+	} {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+		if !variantValueRegexp.MatchString(*field.value) {
+			return fmt.Errorf("invalid %s %q, must be lower case alphanumeric, '-', '_' or '.': %w", field.name, *field.value, api.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactVariant(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "variant-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "variant-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	addArtifact := func(name string, variant *api.ArtifactVariant) string {
+		artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+			ModelArtifact: &openapi.ModelArtifact{
+				Name: apiutils.Of(name),
+				Uri:  apiutils.Of("oci://registry/variant-test:" + name),
+			},
+		}, *modelVersion.Id)
+		require.NoError(t, err)
+
+		if variant != nil {
+			_, err = _service.UpdateModelArtifactVariant(*artifact.ModelArtifact.Id, variant)
+			require.NoError(t, err)
+		}
+		return *artifact.ModelArtifact.Id
+	}
+
+	generic := addArtifact("generic", nil)
+	fp16 := addArtifact("a100-fp16", &api.ArtifactVariant{Accelerator: apiutils.Of("a100"), Precision: apiutils.Of("fp16")})
+	int8 := addArtifact("a100-int8", &api.ArtifactVariant{Accelerator: apiutils.Of("a100"), Precision: apiutils.Of("int8")})
+	tensorrt := addArtifact("a100-int8-tensorrt", &api.ArtifactVariant{
+		Accelerator: apiutils.Of("a100"),
+		Precision:   apiutils.Of("int8"),
+		Engine:      apiutils.Of("tensorrt"),
+	})
+	arm := addArtifact("arm64", &api.ArtifactVariant{Architecture: apiutils.Of("arm64")})
+
+	t.Run("get and update variant", func(t *testing.T) {
+		variant, err := _service.GetModelArtifactVariant(generic)
+		require.NoError(t, err)
+		assert.Equal(t, generic, variant.ArtifactId)
+		assert.Nil(t, variant.Accelerator)
+
+		variant, err = _service.UpdateModelArtifactVariant(fp16, &api.ArtifactVariant{Architecture: apiutils.Of("amd64")})
+		require.NoError(t, err)
+		assert.Equal(t, "amd64", *variant.Architecture)
+		assert.Equal(t, "a100", *variant.Accelerator, "unset fields are left untouched")
+		assert.Equal(t, "fp16", *variant.Precision)
+
+		variant, err = _service.UpdateModelArtifactVariant(fp16, &api.ArtifactVariant{Architecture: apiutils.Of("")})
+		require.NoError(t, err)
+		assert.Nil(t, variant.Architecture, "empty strings clear the field")
+
+		_, err = _service.UpsertModelArtifact(&openapi.ModelArtifact{
+			Id:          &fp16,
+			Description: apiutils.Of("updated"),
+		})
+		require.NoError(t, err)
+
+		variant, err = _service.GetModelArtifactVariant(fp16)
+		require.NoError(t, err)
+		assert.Equal(t, "fp16", *variant.Precision, "artifact updates keep the variant")
+	})
+
+	t.Run("invalid variants", func(t *testing.T) {
+		_, err := _service.UpdateModelArtifactVariant(fp16, &api.ArtifactVariant{Precision: apiutils.Of("FP 16")})
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		_, err = _service.UpdateModelArtifactVariant(fp16, nil)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		_, err = _service.GetModelArtifactVariant("999999")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+
+	t.Run("resolve artifact", func(t *testing.T) {
+		resolve := func(query api.ArtifactVariantQuery) string {
+			artifact, err := _service.ResolveModelVersionArtifact(*modelVersion.Id, query)
+			require.NoError(t, err)
+			return *artifact.ModelArtifact.Id
+		}
+
+		assert.Equal(t, fp16, resolve(api.ArtifactVariantQuery{Accelerator: "a100", Precision: "fp16"}))
+		assert.Equal(t, fp16, resolve(api.ArtifactVariantQuery{Accelerator: "A100", Precision: "FP16"}), "values are case insensitive")
+		assert.Equal(t, tensorrt, resolve(api.ArtifactVariantQuery{Accelerator: "a100", Precision: "int8"}), "the most specific variant wins ties")
+		assert.Equal(t, int8, resolve(api.ArtifactVariantQuery{Accelerator: "a100", Precision: "int8", Engine: "onnx"}))
+		assert.Equal(t, arm, resolve(api.ArtifactVariantQuery{Architecture: "arm64", Accelerator: "h100"}))
+		assert.Equal(t, generic, resolve(api.ArtifactVariantQuery{Accelerator: "h100"}), "generic artifacts match any variant")
+
+		_, err := _service.ResolveModelVersionArtifact(*modelVersion.Id, api.ArtifactVariantQuery{Architecture: "ppc64le", Accelerator: "h100"})
+		assert.NoError(t, err, "the generic artifact matches")
+
+		_, err = _service.ResolveModelVersionArtifact("999999", api.ArtifactVariantQuery{})
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+
+	t.Run("deleted artifacts are not resolved", func(t *testing.T) {
+		_, err := _service.UpsertModelArtifact(&openapi.ModelArtifact{
+			Id:    &generic,
+			State: apiutils.Of(openapi.ARTIFACTSTATE_MARKED_FOR_DELETION),
+		})
+		require.NoError(t, err)
+
+		_, err = _service.ResolveModelVersionArtifact(*modelVersion.Id, api.ArtifactVariantQuery{Accelerator: "h100"})
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// auditTypePrefix is the prefix of the names of the model registry types, left out of the entity types of the audit
+// events.
+const auditTypePrefix = "kf."
+
+func (b *ModelRegistryService) GetAuditEvents(listOptions api.ListOptions, filter api.AuditEventFilter) (*api.AuditEventList, error) {
+	if filter.Action != nil && !slices.Contains(api.AuditActions, *filter.Action) {
+		return nil, fmt.Errorf("invalid action %q, must be one of %v: %w", *filter.Action, api.AuditActions, api.ErrBadRequest)
+	}
+	if filter.Since != nil && filter.Until != nil && *filter.Since > *filter.Until {
+		return nil, fmt.Errorf("invalid time range, since %d is after until %d: %w", *filter.Since, *filter.Until, api.ErrBadRequest)
+	}
+
+	entityID, err := apiutils.ValidateIDAsInt32Ptr(filter.EntityId, "entity")
+	if err != nil {
+		return nil, err
+	}
+
+	repoListOptions := models.AuditEventListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		Actor:    filter.Actor,
+		Action:   filter.Action,
+		EntityID: entityID,
+		Since:    filter.Since,
+		Until:    filter.Until,
+	}
+	if filter.EntityType != nil {
+		switch entityType := *filter.EntityType; entityType {
+		case api.EntityTypeArtifact:
+			repoListOptions.EntityKind = apiutils.Of(models.AuditEntityKindArtifact)
+		case api.AuditEntityTypeTag:
+			repoListOptions.EntityKind = apiutils.Of(models.AuditEntityKindTag)
+		default:
+			typeID, ok := b.typesMap[auditTypePrefix+entityType]
+			if !ok {
+				return nil, fmt.Errorf("invalid entity type %q: %w", entityType, api.ErrBadRequest)
+			}
+			repoListOptions.TypeID = &typeID
+		}
+	}
+
+	events, err := b.auditEventRepository.List(repoListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	typeNames := make(map[int32]string, len(b.typesMap))
+	for name, id := range b.typesMap {
+		typeNames[id] = strings.TrimPrefix(name, auditTypePrefix)
+	}
+
+	list := &api.AuditEventList{
+		Items:         []api.AuditEvent{},
+		NextPageToken: events.NextPageToken,
+		PageSize:      events.PageSize,
+		Size:          events.Size,
+	}
+	for _, event := range events.Items {
+		list.Items = append(list.Items, mapToAuditEvent(event, typeNames))
+	}
+
+	return list, nil
+}
+
+// mapToAuditEvent returns the api audit event of event, with the entity type of its type id in typeNames.
+func mapToAuditEvent(event models.AuditEvent, typeNames map[int32]string) api.AuditEvent {
+	entityID := strconv.FormatInt(int64(event.EntityID), 10)
+
+	entityType := typeNames[event.TypeID]
+	var ref *api.EntityRef
+	switch {
+	case event.EntityKind == models.AuditEntityKindTag:
+		entityType = api.AuditEntityTypeTag
+	case event.EntityKind == models.AuditEntityKindArtifact:
+		ref = apiutils.Of(api.NewEntityRef(api.EntityTypeArtifact, entityID))
+	case slices.Contains(api.WatchEntityTypes, entityType):
+		// the watched entity types are the ones with references
+		ref = apiutils.Of(api.NewEntityRef(entityType, entityID))
+	}
+
+	changes := make([]api.FieldChange, 0, len(event.Changes))
+	for _, change := range event.Changes {
+		changes = append(changes, api.FieldChange{Field: change.Field, Before: change.Before, After: change.After})
+	}
+
+	return api.AuditEvent{
+		Id:                   strconv.FormatInt(int64(*event.ID), 10),
+		Action:               event.Action,
+		Actor:                event.Actor,
+		EntityType:           entityType,
+		EntityId:             entityID,
+		Ref:                  ref,
+		Namespace:            event.Namespace,
+		Changes:              changes,
+		CreateTimeSinceEpoch: strconv.FormatInt(*event.CreateTimeSinceEpoch, 10),
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"gorm.io/gorm"
+)
+
+// CreateModelVersions creates the model versions of the batch and their model artifacts in a single transaction.
+func (b *ModelRegistryService) CreateModelVersions(registeredModelId string, batch *api.ModelVersionBatch) (*api.ModelVersionBatch, error) {
+	if batch == nil || len(batch.Items) == 0 {
+		return nil, fmt.Errorf("at least one model version is required: %w", api.ErrBadRequest)
+	}
+
+	if len(batch.Items) > api.MaxBatchCreateModelVersions {
+		return nil, fmt.Errorf("too many model versions, at most %d can be created at once: %w", api.MaxBatchCreateModelVersions, api.ErrBadRequest)
+	}
+
+	if _, err := b.GetRegisteredModelById(registeredModelId); err != nil {
+		return nil, err
+	}
+
+	modelVersions := make([]models.ModelVersion, 0, len(batch.Items))
+	artifacts := make([][]openapi.ModelArtifact, 0, len(batch.Items))
+
+	for i := range batch.Items {
+		item := batch.Items[i]
+		modelVersion := &item.ModelVersion
+
+		if modelVersion.Id != nil {
+			return nil, fmt.Errorf("model version %s cannot be created with an id: %w", modelVersion.Name, api.ErrBadRequest)
+		}
+
+		if err := b.checkExternalIdAvailable(modelVersion.ExternalId, api.EntityTypeModelVersion, nil); err != nil {
+			return nil, err
+		}
+
+		if err := b.applyNamingPolicy(api.EntityTypeModelVersion, nil, &modelVersion.Name); err != nil {
+			return nil, err
+		}
+
+		if err := b.injectMetadataDefaults("model version", nil, &modelVersion.CustomProperties); err != nil {
+			return nil, err
+		}
+
+		if err := b.applyPropertyLimits(api.EntityTypeModelVersion, &modelVersion.CustomProperties); err != nil {
+			return nil, err
+		}
+
+		modelVersion.RegisteredModelId = registeredModelId
+
+		model, err := b.mapper.MapFromModelVersion(modelVersion, &registeredModelId)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		modelVersions = append(modelVersions, model)
+
+		for j := range item.Artifacts {
+			if item.Artifacts[j].Id != nil {
+				return nil, fmt.Errorf("model artifact of model version %s cannot be created with an id: %w", modelVersion.Name, api.ErrBadRequest)
+			}
+
+			artifact := &openapi.Artifact{ModelArtifact: &item.Artifacts[j]}
+			ensureArtifactName(artifact)
+
+			if err := b.injectArtifactMetadataDefaults(artifact); err != nil {
+				return nil, err
+			}
+
+			if err := b.applyArtifactPropertyLimits(artifact); err != nil {
+				return nil, err
+			}
+		}
+		artifacts = append(artifacts, item.Artifacts)
+	}
+
+	// the names of the model artifacts are prefixed with the id of their model version, known once it is saved
+	mapArtifacts := func(index int, modelVersionID int32) ([]models.ModelArtifact, error) {
+		modelVersionId := strconv.FormatInt(int64(modelVersionID), 10)
+
+		mapped := make([]models.ModelArtifact, 0, len(artifacts[index]))
+		for j := range artifacts[index] {
+			modelArtifact, err := b.mapper.MapFromModelArtifact(&artifacts[index][j], &modelVersionId)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+			}
+			mapped = append(mapped, modelArtifact)
+		}
+
+		return mapped, nil
+	}
+
+	savedVersions, savedArtifacts, err := b.modelVersionRepository.SaveBatch(modelVersions, mapArtifacts)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("model version or model artifact name already exists: %w", api.ErrConflict)
+		}
+
+		return nil, err
+	}
+
+	result := &api.ModelVersionBatch{Items: make([]api.ModelVersionBatchItem, 0, len(savedVersions))}
+
+	for i, savedVersion := range savedVersions {
+		modelVersion, err := b.mapper.MapToModelVersion(savedVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+
+		item := api.ModelVersionBatchItem{ModelVersion: *modelVersion}
+		for _, savedArtifact := range savedArtifacts[i] {
+			b.verifyModelArtifact(savedArtifact)
+
+			modelArtifact, err := b.mapper.MapToModelArtifact(savedArtifact)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+			}
+			item.Artifacts = append(item.Artifacts, *modelArtifact)
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
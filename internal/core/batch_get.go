@@ -0,0 +1,175 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// validateBatchIds converts the requested ids, dropping duplicates while preserving their order
+func validateBatchIds(ids []string, entityName string) ([]int32, error) {
+	return validateIds(ids, entityName, api.MaxBatchGetIds)
+}
+
+// validateIds converts at most maxIds requested ids, dropping duplicates while preserving their order
+func validateIds(ids []string, entityName string, maxIds int) ([]int32, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one %s id is required: %w", entityName, api.ErrBadRequest)
+	}
+
+	if len(ids) > maxIds {
+		return nil, fmt.Errorf("too many %s ids, at most %d can be requested at once: %w", entityName, maxIds, api.ErrBadRequest)
+	}
+
+	convertedIds := make([]int32, 0, len(ids))
+	seen := make(map[int32]struct{}, len(ids))
+
+	for _, id := range ids {
+		convertedId, err := apiutils.ValidateIDAsInt32(id, entityName)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := seen[convertedId]; ok {
+			continue
+		}
+
+		seen[convertedId] = struct{}{}
+		convertedIds = append(convertedIds, convertedId)
+	}
+
+	return convertedIds, nil
+}
+
+// inRequestOrder reorders the entities loaded by id to follow the requested ids
+func inRequestOrder[T any](ids []int32, entities []T, getID func(T) *int32) []T {
+	byID := make(map[int32]T, len(entities))
+	for _, entity := range entities {
+		if id := getID(entity); id != nil {
+			byID[*id] = entity
+		}
+	}
+
+	ordered := make([]T, 0, len(entities))
+	for _, id := range ids {
+		if entity, ok := byID[id]; ok {
+			ordered = append(ordered, entity)
+		}
+	}
+
+	return ordered
+}
+
+func (b *ModelRegistryService) GetRegisteredModelsByIds(ids []string) (*openapi.RegisteredModelList, error) {
+	convertedIds, err := validateBatchIds(ids, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	registeredModels, err := b.registeredModelRepository.GetByIDs(convertedIds)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &openapi.RegisteredModelList{
+		Items: []openapi.RegisteredModel{},
+	}
+
+	for _, model := range inRequestOrder(convertedIds, registeredModels, models.RegisteredModel.GetID) {
+		registeredModel, err := b.mapper.MapToRegisteredModel(model)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		list.Items = append(list.Items, *registeredModel)
+	}
+
+	list.PageSize = int32(len(list.Items))
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
+
+func (b *ModelRegistryService) GetModelVersionsByIds(ids []string) (*openapi.ModelVersionList, error) {
+	convertedIds, err := validateBatchIds(ids, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range convertedIds {
+		if _, err := b.rehydrate(id); err != nil {
+			return nil, err
+		}
+	}
+
+	modelVersions, err := b.modelVersionRepository.GetByIDs(convertedIds)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &openapi.ModelVersionList{
+		Items: []openapi.ModelVersion{},
+	}
+
+	for _, model := range inRequestOrder(convertedIds, modelVersions, models.ModelVersion.GetID) {
+		modelVersion, err := b.mapper.MapToModelVersion(model)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		list.Items = append(list.Items, *modelVersion)
+	}
+
+	list.PageSize = int32(len(list.Items))
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
+
+func (b *ModelRegistryService) GetArtifactsByIds(ids []string) (*openapi.ArtifactList, error) {
+	convertedIds, err := validateBatchIds(ids, "artifact")
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := b.artifactRepository.GetByIDs(convertedIds)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &openapi.ArtifactList{
+		Items: []openapi.Artifact{},
+	}
+
+	for _, artifact := range inRequestOrder(convertedIds, artifacts, artifactID) {
+		mappedArtifact, err := b.mapper.MapToArtifact(artifact)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		list.Items = append(list.Items, *mappedArtifact)
+	}
+
+	list.PageSize = int32(len(list.Items))
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
+
+// artifactID returns the id of the loaded artifact, whatever its type
+func artifactID(artifact models.Artifact) *int32 {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return (*artifact.ModelArtifact).GetID()
+	case artifact.DocArtifact != nil:
+		return (*artifact.DocArtifact).GetID()
+	case artifact.DataSet != nil:
+		return (*artifact.DataSet).GetID()
+	case artifact.Metric != nil:
+		return (*artifact.Metric).GetID()
+	case artifact.Parameter != nil:
+		return (*artifact.Parameter).GetID()
+	}
+
+	return nil
+}
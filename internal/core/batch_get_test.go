@@ -0,0 +1,88 @@
+package core_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchGet(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModelIds := []string{}
+	for i := range 3 {
+		registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+			Name:        "batch-get-model-" + strconv.Itoa(i),
+			Description: apiutils.Of("batch get model " + strconv.Itoa(i)),
+		})
+		require.NoError(t, err)
+		registeredModelIds = append(registeredModelIds, *registeredModel.Id)
+	}
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "batch-get-version",
+		RegisteredModelId: registeredModelIds[0],
+	}, &registeredModelIds[0])
+	require.NoError(t, err)
+
+	artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{
+			Name: apiutils.Of("batch-get-artifact"),
+			Uri:  apiutils.Of("s3://bucket/batch-get"),
+		},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+
+	t.Run("registered models in request order", func(t *testing.T) {
+		ids := []string{registeredModelIds[2], registeredModelIds[0], "99999", registeredModelIds[2]}
+
+		list, err := _service.GetRegisteredModelsByIds(ids)
+		require.NoError(t, err)
+
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, int32(2), list.Size)
+		assert.Equal(t, registeredModelIds[2], *list.Items[0].Id)
+		assert.Equal(t, registeredModelIds[0], *list.Items[1].Id)
+		assert.Equal(t, "batch get model 2", *list.Items[0].Description)
+	})
+
+	t.Run("model versions", func(t *testing.T) {
+		// Registered model ids are not model versions
+		list, err := _service.GetModelVersionsByIds([]string{*modelVersion.Id, registeredModelIds[1]})
+		require.NoError(t, err)
+
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, *modelVersion.Id, *list.Items[0].Id)
+	})
+
+	t.Run("artifacts", func(t *testing.T) {
+		list, err := _service.GetArtifactsByIds([]string{*artifact.ModelArtifact.Id})
+		require.NoError(t, err)
+
+		require.Len(t, list.Items, 1)
+		require.NotNil(t, list.Items[0].ModelArtifact)
+		assert.Equal(t, "s3://bucket/batch-get", *list.Items[0].ModelArtifact.Uri)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := _service.GetRegisteredModelsByIds(nil)
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+
+		_, err = _service.GetModelVersionsByIds([]string{"not-an-id"})
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+
+		tooMany := make([]string, api.MaxBatchGetIds+1)
+		for i := range tooMany {
+			tooMany[i] = strconv.Itoa(i + 1)
+		}
+		_, err = _service.GetArtifactsByIds(tooMany)
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+	})
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// withoutClearedFields returns the update of existing without the fields cleared by the merge patch of the request,
+// and the well-known properties of existing storing them, without a value for the repositories to remove them.
+// mapFrom maps the openapi entities to the entities of the repository.
+func withoutClearedFields[T any, E interface{ GetProperties() *[]models.Properties }](cleared []string, existing *T, update T, mapFrom func(*T) (E, error)) (T, []models.Properties, error) {
+	if len(cleared) == 0 {
+		return update, nil, nil
+	}
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return update, nil, err
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return update, nil, err
+	}
+	for _, field := range cleared {
+		delete(fields, field)
+	}
+	if data, err = json.Marshal(fields); err != nil {
+		return update, nil, err
+	}
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return update, nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	before, err := mapFrom(existing)
+	if err != nil {
+		return update, nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+	after, err := mapFrom(&result)
+	if err != nil {
+		return update, nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	var removed []models.Properties
+	if before.GetProperties() != nil {
+		for _, property := range *before.GetProperties() {
+			if property.IsCustomProperty || findProperty(after.GetProperties(), property.Name) != nil {
+				continue
+			}
+			if !slices.ContainsFunc(removed, func(p models.Properties) bool { return p.Name == property.Name }) {
+				removed = append(removed, models.Properties{Name: property.Name})
+			}
+		}
+	}
+	return result, removed, nil
+}
+
+// removeProperties adds the removed properties, without a value, to the properties saved by an update.
+func removeProperties(properties *[]models.Properties, removed []models.Properties) {
+	if properties == nil {
+		return
+	}
+	*properties = append(*properties, removed...)
+}
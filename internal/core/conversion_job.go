@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/conversion"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// ConversionJob properties
+const (
+	conversionJobModelVersionIdProperty   = "model_version_id"
+	conversionJobConverterProperty        = "converter"
+	conversionJobSourceArtifactIdProperty = "source_artifact_id"
+	conversionJobParametersProperty       = "parameters"
+	conversionJobVariantProperty          = "variant"
+	conversionJobStateProperty            = "state"
+	conversionJobMessageProperty          = "message"
+	conversionJobArtifactIdProperty       = "artifact_id"
+)
+
+// Custom properties recording the provenance of the converted artifacts
+const (
+	convertedByJobProperty          = "conversion.job_id"
+	convertedByConverterProperty    = "conversion.converter"
+	convertedFromArtifactIdProperty = "conversion.source_artifact_id"
+)
+
+// SetConversionHooks configures the converters conversion jobs can be started with.
+func (b *ModelRegistryService) SetConversionHooks(hooks conversion.Hooks) {
+	b.conversionHooks = hooks
+}
+
+// StartConversionJob records the job and hands it to the hook of its converter. A job the converter does not
+// accept is returned FAILED, otherwise it stays RUNNING until the converter calls CompleteConversionJob.
+func (b *ModelRegistryService) StartConversionJob(modelVersionId string, job *api.ConversionJob) (*api.ConversionJob, error) {
+	if job == nil {
+		return nil, fmt.Errorf("invalid conversion job pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	hook, ok := b.conversionHooks[job.Converter]
+	if !ok {
+		return nil, fmt.Errorf("unknown converter %q, configured converters: [%s]: %w", job.Converter, strings.Join(b.converterNames(), ", "), api.ErrBadRequest)
+	}
+
+	if err := validateArtifactVariant(&job.Variant); err != nil {
+		return nil, err
+	}
+
+	modelVersionID, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.getModelVersionEntity(modelVersionId); err != nil {
+		return nil, err
+	}
+
+	source, err := b.getModelVersionArtifact(modelVersionID, job.SourceArtifactId)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceArtifact, err := b.mapper.MapToModelArtifact(source)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	typeID, ok := b.typesMap[defaults.ConversionJobTypeName]
+	if !ok {
+		return nil, fmt.Errorf("conversion job type not found in types map")
+	}
+
+	parameters, err := json.Marshal(job.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode conversion job parameters: %w", err)
+	}
+
+	variant := job.Variant
+	variant.ArtifactId = ""
+	encodedVariant, err := json.Marshal(variant)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode conversion job variant: %w", err)
+	}
+
+	name := fmt.Sprintf("%s:%s", modelVersionId, uuid.NewString())
+	entity := &models.ConversionJobImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.ConversionJobAttributes{Name: &name},
+		Properties: &[]models.Properties{
+			models.NewIntProperty(conversionJobModelVersionIdProperty, modelVersionID, false),
+			models.NewStringProperty(conversionJobConverterProperty, job.Converter, false),
+			models.NewIntProperty(conversionJobSourceArtifactIdProperty, *source.GetID(), false),
+			models.NewStringProperty(conversionJobParametersProperty, string(parameters), false),
+			models.NewStringProperty(conversionJobVariantProperty, string(encodedVariant), false),
+			models.NewStringProperty(conversionJobStateProperty, string(api.ConversionJobRunning), false),
+		},
+	}
+
+	saved, err := b.conversionJobRepository.Save(entity, &modelVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	started, err := mapToConversionJob(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), conversion.DefaultTimeout)
+	defer cancel()
+
+	err = hook.Trigger(ctx, conversion.Request{
+		Job:            *started,
+		SourceArtifact: *sourceArtifact,
+		CompletePath:   conversion.CompletePath(started.Id),
+	})
+	if err != nil {
+		glog.Warningf("Converter %s did not accept conversion job %s: %v", job.Converter, started.Id, err)
+
+		return b.saveConversionJobOutcome(saved, api.ConversionJobFailed, fmt.Sprintf("unable to trigger converter %s: %v", job.Converter, err), nil)
+	}
+
+	glog.Infof("Started conversion job %s of model version %s with converter %s", started.Id, modelVersionId, job.Converter)
+
+	return started, nil
+}
+
+func (b *ModelRegistryService) GetConversionJobById(id string) (*api.ConversionJob, error) {
+	entity, err := b.getConversionJobEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToConversionJob(entity)
+}
+
+func (b *ModelRegistryService) GetConversionJobs(listOptions api.ListOptions, modelVersionId *string) (*api.ConversionJobList, error) {
+	var modelVersionID *int32
+
+	if modelVersionId != nil {
+		var err error
+		modelVersionID, err = apiutils.ValidateIDAsInt32Ptr(modelVersionId, "model version")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	jobs, err := b.conversionJobRepository.List(models.ConversionJobListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		ModelVersionID: modelVersionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobList := &api.ConversionJobList{
+		Items: []api.ConversionJob{},
+	}
+
+	for _, entity := range jobs.Items {
+		mapped, err := mapToConversionJob(entity)
+		if err != nil {
+			return nil, err
+		}
+		jobList.Items = append(jobList.Items, *mapped)
+	}
+
+	jobList.NextPageToken = jobs.NextPageToken
+	jobList.PageSize = jobs.PageSize
+	jobList.Size = jobs.Size
+
+	return jobList, nil
+}
+
+// CompleteConversionJob registers the converted artifact of a succeeded job as a variant of the model version.
+// The job stays RUNNING if the artifact can not be registered, so that the converter can retry.
+func (b *ModelRegistryService) CompleteConversionJob(id string, completion *api.ConversionJobCompletion) (*api.ConversionJob, error) {
+	if completion == nil {
+		return nil, fmt.Errorf("invalid conversion job completion pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	switch completion.State {
+	case api.ConversionJobSucceeded:
+		if completion.Artifact == nil || completion.Artifact.Uri == nil || *completion.Artifact.Uri == "" {
+			return nil, fmt.Errorf("missing converted artifact uri of conversion job %s: %w", id, api.ErrBadRequest)
+		}
+	case api.ConversionJobFailed:
+	default:
+		return nil, fmt.Errorf("invalid conversion job state %q, must be %s or %s: %w", completion.State, api.ConversionJobSucceeded, api.ConversionJobFailed, api.ErrBadRequest)
+	}
+
+	// Serialize the completions so that a job registers at most one artifact
+	b.conversionJobMu.Lock()
+	defer b.conversionJobMu.Unlock()
+
+	entity, err := b.getConversionJobEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := mapToConversionJob(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.State != api.ConversionJobRunning {
+		return nil, fmt.Errorf("conversion job %s is %s, only running jobs can be completed: %w", id, job.State, api.ErrBadRequest)
+	}
+
+	if completion.State == api.ConversionJobFailed {
+		glog.Infof("Conversion job %s failed: %s", id, completion.Message)
+
+		return b.saveConversionJobOutcome(entity, api.ConversionJobFailed, completion.Message, nil)
+	}
+
+	converted, err := b.registerConvertedArtifact(job, *completion.Artifact)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Conversion job %s registered artifact %s", id, *converted.GetID())
+
+	return b.saveConversionJobOutcome(entity, api.ConversionJobSucceeded, completion.Message, converted.GetID())
+}
+
+// registerConvertedArtifact creates the converted artifact under the model version of job, recording its
+// provenance and the variant of the job.
+func (b *ModelRegistryService) registerConvertedArtifact(job *api.ConversionJob, artifact openapi.ModelArtifact) (models.ModelArtifact, error) {
+	artifact.Id = nil
+
+	if artifact.Name == nil || *artifact.Name == "" {
+		source, err := b.getModelArtifactEntity(job.SourceArtifactId)
+		if err != nil {
+			return nil, err
+		}
+		artifact.Name = apiutils.Of(fmt.Sprintf("%s-%s", apiutils.ZeroIfNil(source.GetAttributes().Name), job.Converter))
+	}
+
+	customProperties := map[string]openapi.MetadataValue{}
+	for name, value := range artifact.CustomProperties {
+		customProperties[name] = value
+	}
+	for name, value := range map[string]string{
+		convertedByJobProperty:          job.Id,
+		convertedByConverterProperty:    job.Converter,
+		convertedFromArtifactIdProperty: job.SourceArtifactId,
+	} {
+		customProperties[name] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+	}
+	artifact.CustomProperties = customProperties
+
+	created, err := b.UpsertModelVersionArtifact(&openapi.Artifact{ModelArtifact: &artifact}, job.ModelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.UpdateModelArtifactVariant(*created.ModelArtifact.Id, &job.Variant); err != nil {
+		return nil, err
+	}
+
+	return b.getModelArtifactEntity(*created.ModelArtifact.Id)
+}
+
+func (b *ModelRegistryService) saveConversionJobOutcome(entity models.ConversionJob, state api.ConversionJobState, message string, artifactId *int32) (*api.ConversionJob, error) {
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(conversionJobStateProperty, string(state), false))
+	setProperty(props, models.NewStringProperty(conversionJobMessageProperty, message, false))
+	if artifactId != nil {
+		setProperty(props, models.NewIntProperty(conversionJobArtifactIdProperty, *artifactId, false))
+	}
+
+	saved, err := b.conversionJobRepository.Save(entity, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToConversionJob(saved)
+}
+
+// getModelVersionArtifact returns the model artifact artifactId, failing if it is not owned by the model version.
+func (b *ModelRegistryService) getModelVersionArtifact(modelVersionId int32, artifactId string) (models.ModelArtifact, error) {
+	if artifactId == "" {
+		return nil, fmt.Errorf("missing source artifact id: %w", api.ErrBadRequest)
+	}
+
+	modelArtifacts, err := b.listModelArtifacts(&modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, modelArtifact := range modelArtifacts {
+		if strconv.FormatInt(int64(*modelArtifact.GetID()), 10) == artifactId {
+			return modelArtifact, nil
+		}
+	}
+
+	return nil, fmt.Errorf("artifact %s is not a model artifact of model version %d: %w", artifactId, modelVersionId, api.ErrBadRequest)
+}
+
+// getConversionJobEntity loads the data layer ConversionJob, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getConversionJobEntity(id string) (models.ConversionJob, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "conversion job")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.conversionJobRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no conversion job found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return entity, nil
+}
+
+func (b *ModelRegistryService) converterNames() []string {
+	names := make([]string, 0, len(b.conversionHooks))
+	for name := range b.conversionHooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func mapToConversionJob(entity models.ConversionJob) (*api.ConversionJob, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.ConversionJob{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		Converter:                stringPropertyValue(props, conversionJobConverterProperty),
+		State:                    api.ConversionJobState(stringPropertyValue(props, conversionJobStateProperty)),
+		Message:                  stringPropertyValue(props, conversionJobMessageProperty),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	for name, id := range map[string]*string{
+		conversionJobModelVersionIdProperty:   &mapped.ModelVersionId,
+		conversionJobSourceArtifactIdProperty: &mapped.SourceArtifactId,
+		conversionJobArtifactIdProperty:       &mapped.ArtifactId,
+	} {
+		if prop := findProperty(props, name); prop != nil && prop.IntValue != nil {
+			*id = strconv.FormatInt(int64(*prop.IntValue), 10)
+		}
+	}
+
+	if parameters := stringPropertyValue(props, conversionJobParametersProperty); parameters != "" {
+		if err := json.Unmarshal([]byte(parameters), &mapped.Parameters); err != nil {
+			return nil, fmt.Errorf("unable to decode parameters of conversion job %s: %w", mapped.Id, err)
+		}
+	}
+	if variant := stringPropertyValue(props, conversionJobVariantProperty); variant != "" {
+		if err := json.Unmarshal([]byte(variant), &mapped.Variant); err != nil {
+			return nil, fmt.Errorf("unable to decode variant of conversion job %s: %w", mapped.Id, err)
+		}
+	}
+
+	return mapped, nil
+}
@@ -0,0 +1,145 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/conversion"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hookFunc is a conversion.Hook calling the function
+type hookFunc func(ctx context.Context, request conversion.Request) error
+
+func (f hookFunc) Trigger(ctx context.Context, request conversion.Request) error {
+	return f(ctx, request)
+}
+
+func TestConversionJob(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	var triggered []conversion.Request
+	_service.SetConversionHooks(conversion.Hooks{
+		"int8": hookFunc(func(ctx context.Context, request conversion.Request) error {
+			triggered = append(triggered, request)
+			return nil
+		}),
+		"broken": hookFunc(func(ctx context.Context, request conversion.Request) error {
+			return errors.New("connection refused")
+		}),
+	})
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "conversion-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "conversion-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	source, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{
+			Name: apiutils.Of("model"),
+			Uri:  apiutils.Of("s3://models/conversion-test/fp16"),
+		},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+	sourceId := *source.ModelArtifact.Id
+
+	start := func(converter string) *api.ConversionJob {
+		job, err := _service.StartConversionJob(*modelVersion.Id, &api.ConversionJob{
+			Converter:        converter,
+			SourceArtifactId: sourceId,
+			Parameters:       map[string]string{"calibration": "wikitext"},
+			Variant:          api.ArtifactVariant{Accelerator: apiutils.Of("a100"), Precision: apiutils.Of("int8")},
+		})
+		require.NoError(t, err)
+		return job
+	}
+
+	t.Run("completed jobs register the converted artifact as a variant", func(t *testing.T) {
+		job := start("int8")
+		assert.Equal(t, api.ConversionJobRunning, job.State)
+		assert.Equal(t, *modelVersion.Id, job.ModelVersionId)
+		assert.Equal(t, sourceId, job.SourceArtifactId)
+
+		require.Len(t, triggered, 1)
+		assert.Equal(t, *job, triggered[0].Job)
+		assert.Equal(t, "s3://models/conversion-test/fp16", *triggered[0].SourceArtifact.Uri)
+		assert.Equal(t, conversion.CompletePath(job.Id), triggered[0].CompletePath)
+
+		completed, err := _service.CompleteConversionJob(job.Id, &api.ConversionJobCompletion{
+			State:    api.ConversionJobSucceeded,
+			Artifact: &openapi.ModelArtifact{Uri: apiutils.Of("s3://models/conversion-test/int8")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, api.ConversionJobSucceeded, completed.State)
+		require.NotEmpty(t, completed.ArtifactId)
+
+		converted, err := _service.GetModelArtifactById(completed.ArtifactId)
+		require.NoError(t, err)
+		assert.Equal(t, "model-int8", *converted.Name)
+		assert.Equal(t, job.Id, converted.CustomProperties["conversion.job_id"].MetadataStringValue.StringValue)
+		assert.Equal(t, sourceId, converted.CustomProperties["conversion.source_artifact_id"].MetadataStringValue.StringValue)
+
+		resolved, err := _service.ResolveModelVersionArtifact(*modelVersion.Id, api.ArtifactVariantQuery{Accelerator: "a100", Precision: "int8"})
+		require.NoError(t, err)
+		assert.Equal(t, completed.ArtifactId, *resolved.ModelArtifact.Id)
+
+		_, err = _service.CompleteConversionJob(job.Id, &api.ConversionJobCompletion{State: api.ConversionJobFailed})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "jobs are completed once")
+	})
+
+	t.Run("failed jobs", func(t *testing.T) {
+		job := start("int8")
+		failed, err := _service.CompleteConversionJob(job.Id, &api.ConversionJobCompletion{
+			State:   api.ConversionJobFailed,
+			Message: "out of memory",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, api.ConversionJobFailed, failed.State)
+		assert.Equal(t, "out of memory", failed.Message)
+		assert.Empty(t, failed.ArtifactId)
+
+		untriggered := start("broken")
+		assert.Equal(t, api.ConversionJobFailed, untriggered.State)
+		assert.Contains(t, untriggered.Message, "connection refused")
+	})
+
+	t.Run("invalid jobs", func(t *testing.T) {
+		_, err := _service.StartConversionJob(*modelVersion.Id, &api.ConversionJob{Converter: "onnx", SourceArtifactId: sourceId})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "unknown converter")
+
+		_, err = _service.StartConversionJob(*modelVersion.Id, &api.ConversionJob{Converter: "int8"})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "missing source artifact")
+
+		_, err = _service.StartConversionJob("999999", &api.ConversionJob{Converter: "int8", SourceArtifactId: sourceId})
+		assert.ErrorIs(t, err, api.ErrNotFound)
+
+		job := start("int8")
+		_, err = _service.CompleteConversionJob(job.Id, &api.ConversionJobCompletion{State: api.ConversionJobSucceeded})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "the converted artifact is required")
+
+		_, err = _service.CompleteConversionJob(job.Id, &api.ConversionJobCompletion{State: api.ConversionJobRunning})
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+	})
+
+	t.Run("list jobs", func(t *testing.T) {
+		jobs, err := _service.GetConversionJobs(api.ListOptions{}, modelVersion.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), jobs.Size)
+
+		all, err := _service.GetConversionJobs(api.ListOptions{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), all.Size)
+	})
+}
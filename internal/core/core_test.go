@@ -51,6 +51,14 @@ func getTypeIDs(t *testing.T, db *gorm.DB) map[string]int32 {
 		defaults.MetricTypeName,
 		defaults.MetricHistoryTypeName,
 		defaults.ParameterTypeName,
+		defaults.PromotionTypeName,
+		defaults.PromotionRunTypeName,
+		defaults.ConversionJobTypeName,
+		defaults.DeploymentTypeName,
+		defaults.ABTestTypeName,
+		defaults.MetricsTableTypeName,
+		defaults.ModelCardTypeName,
+		defaults.StageTransitionTypeName,
 	}
 
 	for _, typeName := range typeNames {
@@ -76,6 +84,8 @@ func createModelRegistryService(t *testing.T, db *gorm.DB) *core.ModelRegistrySe
 		defaults.MetricTypeName:        typesMap[defaults.MetricTypeName],
 		defaults.ParameterTypeName:     typesMap[defaults.ParameterTypeName],
 		defaults.MetricHistoryTypeName: typesMap[defaults.MetricHistoryTypeName],
+		defaults.MetricsTableTypeName:  typesMap[defaults.MetricsTableTypeName],
+		defaults.ModelCardTypeName:     typesMap[defaults.ModelCardTypeName],
 	})
 	modelArtifactRepo := service.NewModelArtifactRepository(db, typesMap[defaults.ModelArtifactTypeName])
 	docArtifactRepo := service.NewDocArtifactRepository(db, typesMap[defaults.DocArtifactTypeName])
@@ -90,6 +100,17 @@ func createModelRegistryService(t *testing.T, db *gorm.DB) *core.ModelRegistrySe
 	metricRepo := service.NewMetricRepository(db, typesMap[defaults.MetricTypeName])
 	parameterRepo := service.NewParameterRepository(db, typesMap[defaults.ParameterTypeName])
 	metricHistoryRepo := service.NewMetricHistoryRepository(db, typesMap[defaults.MetricHistoryTypeName])
+	promotionRepo := service.NewPromotionRepository(db, typesMap[defaults.PromotionTypeName])
+	promotionRunRepo := service.NewPromotionRunRepository(db, typesMap[defaults.PromotionRunTypeName])
+	conversionJobRepo := service.NewConversionJobRepository(db, typesMap[defaults.ConversionJobTypeName])
+	deploymentRepo := service.NewDeploymentRepository(db, typesMap[defaults.DeploymentTypeName])
+	abTestRepo := service.NewABTestRepository(db, typesMap[defaults.ABTestTypeName])
+	lineageRepo := service.NewLineageRepository(db)
+	metricsTableRepo := service.NewMetricsTableRepository(db, typesMap[defaults.MetricsTableTypeName])
+	modelCardRepo := service.NewModelCardRepository(db, typesMap[defaults.ModelCardTypeName])
+	stageTransitionRepo := service.NewStageTransitionRepository(db, typesMap[defaults.StageTransitionTypeName])
+	tagRepo := service.NewTagRepository(db)
+	auditEventRepo := service.NewAuditEventRepository(db)
 
 	// Create the core service
 	return core.NewModelRegistryService(
@@ -107,6 +128,17 @@ func createModelRegistryService(t *testing.T, db *gorm.DB) *core.ModelRegistrySe
 		metricRepo,
 		parameterRepo,
 		metricHistoryRepo,
+		promotionRepo,
+		promotionRunRepo,
+		conversionJobRepo,
+		deploymentRepo,
+		abTestRepo,
+		lineageRepo,
+		metricsTableRepo,
+		modelCardRepo,
+		stageTransitionRepo,
+		tagRepo,
+		auditEventRepo,
 		typesMap,
 	)
 }
@@ -118,6 +150,7 @@ func SetupModelRegistryService(t *testing.T) (*core.ModelRegistryService, func()
 
 	// Create the core service
 	service := createModelRegistryService(t, db)
+	service.SetDB(db)
 
 	return service, cleanup
 }
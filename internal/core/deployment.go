@@ -0,0 +1,386 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/deployment"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// Deployment properties
+const (
+	deploymentModelVersionIdProperty         = "model_version_id"
+	deploymentInferenceServiceIdProperty     = "inference_service_id"
+	deploymentStageProperty                  = "stage"
+	deploymentPreviousStageProperty          = "previous_stage"
+	deploymentPreviousModelVersionIdProperty = "previous_model_version_id"
+	deploymentPreviousDesiredStateProperty   = "previous_desired_state"
+	deploymentStateProperty                  = "state"
+	deploymentMessageProperty                = "message"
+)
+
+// modelVersionStageProperty is the custom property holding the stage of the model versions, see reporting.StageProperty.
+const modelVersionStageProperty = "stage"
+
+// SetDeploymentHook configures the hook applying the deployments to the serving platform, e.g. patching the KServe
+// InferenceService. Without hook the deployments only update the registry entities.
+func (b *ModelRegistryService) SetDeploymentHook(hook deployment.Hook) {
+	b.deploymentHook = hook
+}
+
+// DeployModelVersion records the deployment and executes it in the background: the model version is moved to the
+// stage of the deployment, the inference service is updated to serve it and the deployment hook is called. If any
+// step fails the stage of the model version and the inference service are restored. Deployments interrupted by a
+// restart of the server stay RUNNING.
+func (b *ModelRegistryService) DeployModelVersion(modelVersionId string, toDeploy *api.Deployment) (*api.Deployment, error) {
+	if toDeploy == nil {
+		return nil, fmt.Errorf("invalid deployment pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	stage := toDeploy.Stage
+	if stage == "" {
+		stage = api.DefaultDeploymentStage
+	}
+
+	modelVersionID, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	inferenceServiceID, err := apiutils.ValidateIDAsInt32(toDeploy.InferenceServiceId, "inference service")
+	if err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := b.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if modelVersion.State != nil && *modelVersion.State == openapi.MODELVERSIONSTATE_ARCHIVED {
+		return nil, fmt.Errorf("model version %s is archived and can not be deployed: %w", modelVersionId, api.ErrBadRequest)
+	}
+
+	inferenceService, err := b.GetInferenceServiceById(toDeploy.InferenceServiceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if inferenceService.RegisteredModelId != modelVersion.RegisteredModelId {
+		return nil, fmt.Errorf("inference service %s serves registered model %s, not the registered model %s of model version %s: %w",
+			toDeploy.InferenceServiceId, inferenceService.RegisteredModelId, modelVersion.RegisteredModelId, modelVersionId, api.ErrBadRequest)
+	}
+
+	typeID, ok := b.typesMap[defaults.DeploymentTypeName]
+	if !ok {
+		return nil, fmt.Errorf("deployment type not found in types map")
+	}
+
+	// Deployments of an inference service are serialized on this server, so that each one restores the state
+	// left by the previous one when rolled back
+	if !b.acquireInferenceService(inferenceServiceID) {
+		return nil, fmt.Errorf("inference service %s is already being deployed: %w", toDeploy.InferenceServiceId, api.ErrConflict)
+	}
+
+	props := []models.Properties{
+		models.NewIntProperty(deploymentModelVersionIdProperty, modelVersionID, false),
+		models.NewIntProperty(deploymentInferenceServiceIdProperty, inferenceServiceID, false),
+		models.NewStringProperty(deploymentStageProperty, stage, false),
+		models.NewStringProperty(deploymentPreviousStageProperty, modelVersionStage(modelVersion), false),
+		models.NewStringProperty(deploymentStateProperty, string(api.DeploymentRunning), false),
+	}
+	if inferenceService.ModelVersionId != nil {
+		previousModelVersionID, err := apiutils.ValidateIDAsInt32(*inferenceService.ModelVersionId, "model version")
+		if err != nil {
+			b.releaseInferenceService(inferenceServiceID)
+			return nil, err
+		}
+		props = append(props, models.NewIntProperty(deploymentPreviousModelVersionIdProperty, previousModelVersionID, false))
+	}
+	if inferenceService.DesiredState != nil {
+		props = append(props, models.NewStringProperty(deploymentPreviousDesiredStateProperty, string(*inferenceService.DesiredState), false))
+	}
+
+	name := fmt.Sprintf("%s:%s", toDeploy.InferenceServiceId, uuid.NewString())
+	entity := &models.DeploymentImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.DeploymentAttributes{Name: &name},
+		Properties: &props,
+	}
+
+	saved, err := b.deploymentRepository.Save(entity, &inferenceServiceID)
+	if err != nil {
+		b.releaseInferenceService(inferenceServiceID)
+		return nil, err
+	}
+
+	started, err := mapToDeployment(saved)
+	if err != nil {
+		b.releaseInferenceService(inferenceServiceID)
+		return nil, err
+	}
+
+	glog.Infof("Started deployment %s of model version %s to inference service %s", started.Id, modelVersionId, toDeploy.InferenceServiceId)
+
+	go b.detached().executeDeployment(*started, inferenceServiceID)
+
+	return started, nil
+}
+
+func (b *ModelRegistryService) GetDeploymentById(id string) (*api.Deployment, error) {
+	entity, err := b.getDeploymentEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToDeployment(entity)
+}
+
+func (b *ModelRegistryService) GetDeployments(listOptions api.ListOptions, inferenceServiceId *string) (*api.DeploymentList, error) {
+	var inferenceServiceID *int32
+
+	if inferenceServiceId != nil {
+		var err error
+		inferenceServiceID, err = apiutils.ValidateIDAsInt32Ptr(inferenceServiceId, "inference service")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deployments, err := b.deploymentRepository.List(models.DeploymentListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		InferenceServiceID: inferenceServiceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentList := &api.DeploymentList{
+		Items: []api.Deployment{},
+	}
+
+	for _, entity := range deployments.Items {
+		mapped, err := mapToDeployment(entity)
+		if err != nil {
+			return nil, err
+		}
+		deploymentList.Items = append(deploymentList.Items, *mapped)
+	}
+
+	deploymentList.NextPageToken = deployments.NextPageToken
+	deploymentList.PageSize = deployments.PageSize
+	deploymentList.Size = deployments.Size
+
+	return deploymentList, nil
+}
+
+// executeDeployment deploys the model version and saves the outcome in the deployment, rolling it back on failure.
+func (b *ModelRegistryService) executeDeployment(toDeploy api.Deployment, inferenceServiceID int32) {
+	defer b.releaseInferenceService(inferenceServiceID)
+
+	state := api.DeploymentSucceeded
+	message := ""
+	if err := b.deploy(toDeploy); err != nil {
+		glog.Warningf("Deployment %s failed, rolling back: %v", toDeploy.Id, err)
+
+		state = api.DeploymentRolledBack
+		message = err.Error()
+		if rollbackErr := b.rollbackDeployment(toDeploy); rollbackErr != nil {
+			state = api.DeploymentFailed
+			message = fmt.Sprintf("%s, rollback failed: %v", message, rollbackErr)
+		}
+	}
+
+	if err := b.completeDeployment(toDeploy.Id, state, message); err != nil {
+		glog.Errorf("Unable to save the outcome of deployment %s: %v", toDeploy.Id, err)
+		return
+	}
+
+	glog.Infof("Deployment %s of model version %s to inference service %s %s", toDeploy.Id, toDeploy.ModelVersionId, toDeploy.InferenceServiceId, state)
+}
+
+func (b *ModelRegistryService) deploy(toDeploy api.Deployment) error {
+	modelVersion, err := b.setModelVersionStage(toDeploy.ModelVersionId, toDeploy.Stage)
+	if err != nil {
+		return fmt.Errorf("unable to move model version %s to stage %s: %w", toDeploy.ModelVersionId, toDeploy.Stage, err)
+	}
+
+	inferenceService, err := b.UpsertInferenceService(&openapi.InferenceService{
+		Id:             &toDeploy.InferenceServiceId,
+		ModelVersionId: &toDeploy.ModelVersionId,
+		DesiredState:   openapi.INFERENCESERVICESTATE_DEPLOYED.Ptr(),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update inference service %s: %w", toDeploy.InferenceServiceId, err)
+	}
+
+	if b.deploymentHook == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deployment.DefaultTimeout)
+	defer cancel()
+
+	err = b.deploymentHook.Apply(ctx, deployment.Request{
+		Deployment:       toDeploy,
+		ModelVersion:     *modelVersion,
+		InferenceService: *inferenceService,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to apply the deployment to the serving platform: %w", err)
+	}
+
+	return nil
+}
+
+// rollbackDeployment restores the stage of the model version and the inference service recorded when the
+// deployment started. The model version of inference services which served none can't be unset, they are
+// restored as undeployed.
+func (b *ModelRegistryService) rollbackDeployment(toDeploy api.Deployment) error {
+	if _, err := b.setModelVersionStage(toDeploy.ModelVersionId, toDeploy.PreviousStage); err != nil {
+		return fmt.Errorf("unable to restore the stage of model version %s: %w", toDeploy.ModelVersionId, err)
+	}
+
+	entity, err := b.getDeploymentEntity(toDeploy.Id)
+	if err != nil {
+		return err
+	}
+
+	desiredState := openapi.INFERENCESERVICESTATE_UNDEPLOYED
+	if previous := stringPropertyValue(entity.GetProperties(), deploymentPreviousDesiredStateProperty); previous != "" && toDeploy.PreviousModelVersionId != "" {
+		desiredState = openapi.InferenceServiceState(previous)
+	}
+
+	restored := &openapi.InferenceService{
+		Id:           &toDeploy.InferenceServiceId,
+		DesiredState: &desiredState,
+	}
+	if toDeploy.PreviousModelVersionId != "" {
+		restored.ModelVersionId = &toDeploy.PreviousModelVersionId
+	}
+
+	if _, err := b.UpsertInferenceService(restored); err != nil {
+		return fmt.Errorf("unable to restore inference service %s: %w", toDeploy.InferenceServiceId, err)
+	}
+
+	return nil
+}
+
+// setModelVersionStage sets the stage custom property of the model version, removing it when stage is empty.
+func (b *ModelRegistryService) setModelVersionStage(modelVersionId string, stage string) (*openapi.ModelVersion, error) {
+	modelVersion, err := b.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	customProperties := map[string]openapi.MetadataValue{}
+	for name, value := range modelVersion.CustomProperties {
+		customProperties[name] = value
+	}
+	if stage == "" {
+		delete(customProperties, modelVersionStageProperty)
+	} else {
+		customProperties[modelVersionStageProperty] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(stage, "MetadataStringValue"))
+	}
+
+	modelVersion.CustomProperties = customProperties
+
+	return b.UpsertModelVersion(modelVersion, nil)
+}
+
+func (b *ModelRegistryService) completeDeployment(id string, state api.DeploymentState, message string) error {
+	entity, err := b.getDeploymentEntity(id)
+	if err != nil {
+		return err
+	}
+
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(deploymentStateProperty, string(state), false))
+	setProperty(props, models.NewStringProperty(deploymentMessageProperty, message, false))
+
+	_, err = b.deploymentRepository.Save(entity, nil)
+	return err
+}
+
+// acquireInferenceService marks the inference service as being deployed, false if it already is.
+func (b *ModelRegistryService) acquireInferenceService(inferenceServiceID int32) bool {
+	b.deploymentMu.Lock()
+	defer b.deploymentMu.Unlock()
+
+	if b.deploying[inferenceServiceID] {
+		return false
+	}
+	b.deploying[inferenceServiceID] = true
+	return true
+}
+
+func (b *ModelRegistryService) releaseInferenceService(inferenceServiceID int32) {
+	b.deploymentMu.Lock()
+	defer b.deploymentMu.Unlock()
+
+	delete(b.deploying, inferenceServiceID)
+}
+
+// getDeploymentEntity loads the data layer Deployment, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getDeploymentEntity(id string) (models.Deployment, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "deployment")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.deploymentRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no deployment found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return entity, nil
+}
+
+// modelVersionStage returns the stage of the model version, empty if it has none.
+func modelVersionStage(modelVersion *openapi.ModelVersion) string {
+	stage, ok := modelVersion.CustomProperties[modelVersionStageProperty]
+	if !ok || stage.MetadataStringValue == nil {
+		return ""
+	}
+	return stage.MetadataStringValue.StringValue
+}
+
+func mapToDeployment(entity models.Deployment) (*api.Deployment, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.Deployment{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		Stage:                    stringPropertyValue(props, deploymentStageProperty),
+		PreviousStage:            stringPropertyValue(props, deploymentPreviousStageProperty),
+		State:                    api.DeploymentState(stringPropertyValue(props, deploymentStateProperty)),
+		Message:                  stringPropertyValue(props, deploymentMessageProperty),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	for name, id := range map[string]*string{
+		deploymentModelVersionIdProperty:         &mapped.ModelVersionId,
+		deploymentInferenceServiceIdProperty:     &mapped.InferenceServiceId,
+		deploymentPreviousModelVersionIdProperty: &mapped.PreviousModelVersionId,
+	} {
+		if prop := findProperty(props, name); prop != nil && prop.IntValue != nil {
+			*id = strconv.FormatInt(int64(*prop.IntValue), 10)
+		}
+	}
+
+	return mapped, nil
+}
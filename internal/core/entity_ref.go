@@ -0,0 +1,110 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+func (b *ModelRegistryService) ResolveEntityRefs(refs []api.EntityRef) (*api.ResolvedEntityList, error) {
+	if len(refs) > api.MaxResolveRefs {
+		return nil, fmt.Errorf("too many entity references: %d, at most %d can be resolved at once: %w", len(refs), api.MaxResolveRefs, api.ErrBadRequest)
+	}
+
+	list := &api.ResolvedEntityList{
+		Items: make([]api.ResolvedEntity, 0, len(refs)),
+	}
+
+	for _, ref := range refs {
+		object, err := b.resolveEntityRef(ref)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve %s: %w", ref, err)
+		}
+
+		instance := object
+		if artifact, ok := object.(*openapi.Artifact); ok {
+			instance = artifact.GetActualInstance()
+		}
+		entity, ok := instance.(interface{ GetId() string })
+		if !ok {
+			return nil, fmt.Errorf("unexpected %s %T", ref.EntityType, instance)
+		}
+
+		list.Items = append(list.Items, api.ResolvedEntity{
+			Ref:        ref,
+			EntityType: ref.EntityType,
+			Id:         entity.GetId(),
+			Object:     object,
+		})
+	}
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
+
+// resolveEntityRef returns the entity of the reference as returned by its get endpoint.
+func (b *ModelRegistryService) resolveEntityRef(ref api.EntityRef) (any, error) {
+	var name, externalId *string
+	if ref.Name != "" {
+		name = &ref.Name
+	}
+	if ref.ExternalId != "" {
+		externalId = &ref.ExternalId
+	}
+
+	switch ref.EntityType {
+	case api.EntityTypeRegisteredModel:
+		if ref.Id != "" {
+			return b.GetRegisteredModelById(ref.Id)
+		}
+		return b.GetRegisteredModelByParams(name, externalId)
+	case api.EntityTypeModelVersion:
+		if ref.Id != "" {
+			return b.GetModelVersionById(ref.Id)
+		}
+		return b.GetModelVersionByParams(nil, nil, externalId)
+	case api.EntityTypeArtifact:
+		if ref.Id != "" {
+			return b.GetArtifactById(ref.Id)
+		}
+		return b.GetArtifactByParams(nil, nil, externalId)
+	case api.EntityTypeServingEnvironment:
+		if ref.Id != "" {
+			return b.GetServingEnvironmentById(ref.Id)
+		}
+		return b.GetServingEnvironmentByParams(name, externalId)
+	case api.EntityTypeInferenceService:
+		if ref.Id != "" {
+			return b.GetInferenceServiceById(ref.Id)
+		}
+		return b.GetInferenceServiceByParams(nil, nil, externalId)
+	case api.EntityTypeServeModel:
+		if ref.Id != "" {
+			return b.GetServeModelById(ref.Id)
+		}
+		serveModels, err := b.serveModelRepository.List(models.ServeModelListOptions{ExternalID: externalId})
+		if err != nil {
+			return nil, err
+		}
+		if len(serveModels.Items) == 0 {
+			return nil, fmt.Errorf("no serve model found for externalId=%s: %w", ref.ExternalId, api.ErrNotFound)
+		}
+		return b.GetServeModelById(strconv.FormatInt(int64(apiutils.ZeroIfNil(serveModels.Items[0].GetID())), 10))
+	case api.EntityTypeExperiment:
+		if ref.Id != "" {
+			return b.GetExperimentById(ref.Id)
+		}
+		return b.GetExperimentByParams(name, externalId)
+	case api.EntityTypeExperimentRun:
+		if ref.Id != "" {
+			return b.GetExperimentRunById(ref.Id)
+		}
+		return b.GetExperimentRunByParams(nil, nil, externalId)
+	}
+
+	return nil, fmt.Errorf("invalid entity type %q: %w", ref.EntityType, api.ErrBadRequest)
+}
@@ -17,6 +17,23 @@ func (b *ModelRegistryService) UpsertExperiment(experiment *openapi.Experiment)
 		return nil, fmt.Errorf("invalid experiment pointer, can't upsert nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(experiment.ExternalId, api.EntityTypeExperiment, experiment.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeExperiment, experiment.Id, &experiment.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("experiment", experiment.Id, &experiment.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeExperiment, &experiment.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	var removed []models.Properties
 	if experiment.Id != nil {
 		existing, err := b.GetExperimentById(*experiment.Id)
 		if err != nil {
@@ -35,6 +52,11 @@ func (b *ModelRegistryService) UpsertExperiment(experiment *openapi.Experiment)
 			withNotEditable.CustomProperties = existing.CustomProperties
 		}
 
+		withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing, withNotEditable, b.mapper.MapFromExperiment)
+		if err != nil {
+			return nil, err
+		}
+
 		experiment = &withNotEditable
 	}
 
@@ -42,6 +64,7 @@ func (b *ModelRegistryService) UpsertExperiment(experiment *openapi.Experiment)
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 	}
+	removeProperties(experimentEntity.GetProperties(), removed)
 
 	experimentEntity, err = b.experimentRepository.Save(experimentEntity)
 	if err != nil {
@@ -116,6 +139,8 @@ func (b *ModelRegistryService) GetExperiments(listOptions api.ListOptions) (*ope
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
 			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
 		},
 	})
 	if err != nil {
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// experimentMetricSchemaProperty is the Experiment property holding the JSON encoded metric schema.
+const experimentMetricSchemaProperty = "metric_schema"
+
+func (b *ModelRegistryService) GetExperimentMetricSchema(experimentId string) (*api.ExperimentMetricSchema, error) {
+	experiment, err := b.getExperimentEntity(experimentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeExperimentMetricSchema(experimentId, findProperty(experiment.GetProperties(), experimentMetricSchemaProperty))
+}
+
+func (b *ModelRegistryService) UpsertExperimentMetricSchema(experimentId string, schema *api.ExperimentMetricSchema) (*api.ExperimentMetricSchema, error) {
+	if schema == nil {
+		return nil, fmt.Errorf("invalid experiment metric schema pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := validateExperimentMetricSchema(schema); err != nil {
+		return nil, err
+	}
+
+	experiment, err := b.getExperimentEntity(experimentId)
+	if err != nil {
+		return nil, err
+	}
+
+	toStore := api.ExperimentMetricSchema{Metrics: make([]api.MetricSpec, 0, len(schema.Metrics))}
+	for _, metric := range schema.Metrics {
+		metric.Type = cmp.Or(metric.Type, api.MetricValueDouble)
+		toStore.Metrics = append(toStore.Metrics, metric)
+	}
+
+	encoded, err := json.Marshal(toStore)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode experiment metric schema: %w", err)
+	}
+
+	setProperty(experiment.GetProperties(), models.NewStringProperty(experimentMetricSchemaProperty, string(encoded), false))
+
+	saved, err := b.experimentRepository.Save(experiment)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeExperimentMetricSchema(experimentId, findProperty(saved.GetProperties(), experimentMetricSchemaProperty))
+}
+
+func (b *ModelRegistryService) GetExperimentRunsLeaderboard(experimentId string, metric string) (*api.RunLeaderboard, error) {
+	if metric == "" {
+		return nil, fmt.Errorf("metric name is required: %w", api.ErrBadRequest)
+	}
+
+	schema, err := b.GetExperimentMetricSchema(experimentId)
+	if err != nil {
+		return nil, err
+	}
+	spec := findMetricSpec(schema, metric)
+	if spec == nil {
+		return nil, fmt.Errorf("metric %q is not declared in the metric schema of experiment %s: %w", metric, experimentId, api.ErrBadRequest)
+	}
+
+	aggregation := api.MetricAggregationMax
+	if spec.Direction == api.MetricLowerIsBetter {
+		aggregation = api.MetricAggregationMin
+	}
+	aggregates, err := b.GetExperimentRunsMetricAggregate(experimentId, metric, aggregation)
+	if err != nil {
+		return nil, err
+	}
+
+	// the aggregates are ordered by run id, the stable sort keeps the ties in that order
+	items := slices.Clone(aggregates.Items)
+	slices.SortStableFunc(items, func(a, b api.RunMetricAggregate) int {
+		if spec.Direction == api.MetricLowerIsBetter {
+			return cmp.Compare(a.Value, b.Value)
+		}
+		return cmp.Compare(b.Value, a.Value)
+	})
+
+	leaderboard := &api.RunLeaderboard{
+		Metric:    metric,
+		Direction: spec.Direction,
+		Items:     make([]api.RankedRunMetric, 0, len(items)),
+		Size:      int32(len(items)),
+	}
+	for i, item := range items {
+		leaderboard.Items = append(leaderboard.Items, api.RankedRunMetric{
+			Rank:            int32(i + 1),
+			ExperimentRunId: item.ExperimentRunId,
+			Value:           item.Value,
+			Count:           item.Count,
+		})
+	}
+
+	return leaderboard, nil
+}
+
+// validateRunMetric checks a metric logged by an experiment run against the metric schema of its experiment.
+func (b *ModelRegistryService) validateRunMetric(metric *openapi.Metric, experimentRunId string) error {
+	if metric.Name == nil {
+		return nil
+	}
+
+	experimentRun, err := b.GetExperimentRunById(experimentRunId)
+	if err != nil {
+		return err
+	}
+	schema, err := b.GetExperimentMetricSchema(experimentRun.ExperimentId)
+	if err != nil {
+		return err
+	}
+	if len(schema.Metrics) == 0 {
+		return nil
+	}
+
+	spec := findMetricSpec(schema, *metric.Name)
+	if spec == nil {
+		declared := make([]string, 0, len(schema.Metrics))
+		for _, declaredMetric := range schema.Metrics {
+			declared = append(declared, declaredMetric.Name)
+		}
+		return fmt.Errorf("metric %q is not declared in the metric schema of experiment %s, declared metrics are %s: %w",
+			*metric.Name, experimentRun.ExperimentId, strings.Join(declared, ", "), api.ErrBadRequest)
+	}
+	if spec.Type == api.MetricValueInt && metric.Value != nil && *metric.Value != math.Trunc(*metric.Value) {
+		return fmt.Errorf("metric %q of experiment %s has integer values, not %g: %w", *metric.Name, experimentRun.ExperimentId, *metric.Value, api.ErrBadRequest)
+	}
+
+	return nil
+}
+
+// getExperimentEntity loads the data layer Experiment, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getExperimentEntity(experimentId string) (models.Experiment, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(experimentId, "experiment")
+	if err != nil {
+		return nil, err
+	}
+
+	experiment, err := b.experimentRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no experiment found for id %s: %w", experimentId, api.ErrNotFound)
+	}
+
+	return experiment, nil
+}
+
+func findMetricSpec(schema *api.ExperimentMetricSchema, name string) *api.MetricSpec {
+	for i := range schema.Metrics {
+		if schema.Metrics[i].Name == name {
+			return &schema.Metrics[i]
+		}
+	}
+	return nil
+}
+
+func decodeExperimentMetricSchema(experimentId string, prop *models.Properties) (*api.ExperimentMetricSchema, error) {
+	schema := &api.ExperimentMetricSchema{}
+
+	if prop != nil && prop.StringValue != nil && *prop.StringValue != "" {
+		if err := json.Unmarshal([]byte(*prop.StringValue), schema); err != nil {
+			return nil, fmt.Errorf("unable to decode metric schema for experiment %s: %w", experimentId, err)
+		}
+	}
+
+	schema.ExperimentId = experimentId
+	if schema.Metrics == nil {
+		schema.Metrics = []api.MetricSpec{}
+	}
+
+	return schema, nil
+}
+
+func validateExperimentMetricSchema(schema *api.ExperimentMetricSchema) error {
+	names := map[string]struct{}{}
+	for i, metric := range schema.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metric at index %d is missing a name: %w", i, api.ErrBadRequest)
+		}
+		if _, ok := names[metric.Name]; ok {
+			return fmt.Errorf("duplicate metric %q: %w", metric.Name, api.ErrBadRequest)
+		}
+		switch metric.Type {
+		case "", api.MetricValueDouble, api.MetricValueInt:
+		default:
+			return fmt.Errorf("invalid type %q of metric %q, must be %s or %s: %w", metric.Type, metric.Name,
+				api.MetricValueDouble, api.MetricValueInt, api.ErrBadRequest)
+		}
+		switch metric.Direction {
+		case api.MetricHigherIsBetter, api.MetricLowerIsBetter:
+		default:
+			return fmt.Errorf("invalid direction %q of metric %q, must be %s or %s: %w", metric.Direction, metric.Name,
+				api.MetricHigherIsBetter, api.MetricLowerIsBetter, api.ErrBadRequest)
+		}
+		names[metric.Name] = struct{}{}
+	}
+
+	return nil
+}
@@ -22,6 +22,22 @@ func (b *ModelRegistryService) UpsertExperimentRun(experimentRun *openapi.Experi
 		return nil, fmt.Errorf("invalid experiment run pointer, can't upsert nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(experimentRun.ExternalId, api.EntityTypeExperimentRun, experimentRun.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeExperimentRun, experimentRun.Id, experimentRun.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("experiment run", experimentRun.Id, &experimentRun.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeExperimentRun, &experimentRun.CustomProperties); err != nil {
+		return nil, err
+	}
+
 	if experimentId == nil {
 		return nil, fmt.Errorf("experiment ID is required: %w", api.ErrBadRequest)
 	}
@@ -101,6 +117,10 @@ func (b *ModelRegistryService) GetExperimentRunById(id string) (*openapi.Experim
 		return nil, err
 	}
 
+	if _, err := b.rehydrate(convertedId); err != nil {
+		return nil, err
+	}
+
 	experimentRun, err := b.experimentRunRepository.GetByID(convertedId)
 	if err != nil {
 		return nil, fmt.Errorf("no experiment run found for id %s: %w", id, api.ErrNotFound)
@@ -145,6 +165,15 @@ func (b *ModelRegistryService) GetExperimentRunByParams(name *string, experiment
 		return nil, fmt.Errorf("multiple experiment runs found for name=%v, experimentId=%v, externalId=%v: %w", apiutils.ZeroIfNil(name), apiutils.ZeroIfNil(experimentId), apiutils.ZeroIfNil(externalId), api.ErrNotFound)
 	}
 
+	// Fetch again archived entities once rehydrated, to return their restored properties
+	rehydrated, err := b.rehydrate(*experimentRuns.Items[0].GetID())
+	if err != nil {
+		return nil, err
+	}
+	if rehydrated {
+		return b.GetExperimentRunById(strconv.Itoa(int(*experimentRuns.Items[0].GetID())))
+	}
+
 	toReturn, err := b.mapper.MapToExperimentRun(experimentRuns.Items[0])
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
@@ -154,31 +183,12 @@ func (b *ModelRegistryService) GetExperimentRunByParams(name *string, experiment
 }
 
 func (b *ModelRegistryService) GetExperimentRuns(listOptions api.ListOptions, experimentId *string) (*openapi.ExperimentRunList, error) {
-	var experimentIDPtr *int32
-	if experimentId != nil {
-		var err error
-		experimentIDPtr, err = apiutils.ValidateIDAsInt32Ptr(experimentId, "experiment")
-		if err != nil {
-			return nil, err
-		}
-
-		// Validate that the experiment exists
-		_, err = b.GetExperimentById(*experimentId)
-		if err != nil {
-			return nil, err
-		}
+	repoListOptions, err := b.experimentRunListOptions(listOptions, experimentId)
+	if err != nil {
+		return nil, err
 	}
 
-	experimentRuns, err := b.experimentRunRepository.List(models.ExperimentRunListOptions{
-		Pagination: models.Pagination{
-			PageSize:      listOptions.PageSize,
-			OrderBy:       listOptions.OrderBy,
-			SortOrder:     listOptions.SortOrder,
-			NextPageToken: listOptions.NextPageToken,
-			FilterQuery:   listOptions.FilterQuery,
-		},
-		ExperimentID: experimentIDPtr,
-	})
+	experimentRuns, err := b.experimentRunRepository.List(repoListOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +212,60 @@ func (b *ModelRegistryService) GetExperimentRuns(listOptions api.ListOptions, ex
 	return experimentRunList, nil
 }
 
+// StreamExperimentRuns calls yield with the experiment runs of the page of listOptions as they are read, and returns
+// the token of the next page.
+func (b *ModelRegistryService) StreamExperimentRuns(listOptions api.ListOptions, experimentId *string, yield func(*openapi.ExperimentRun) error) (string, error) {
+	repoListOptions, err := b.experimentRunListOptions(listOptions, experimentId)
+	if err != nil {
+		return "", err
+	}
+
+	return b.experimentRunRepository.ListStream(repoListOptions, func(run models.ExperimentRun) error {
+		experimentRun, err := b.mapper.MapToExperimentRun(run)
+		if err != nil {
+			return fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		return yield(experimentRun)
+	})
+}
+
+func (b *ModelRegistryService) experimentRunListOptions(listOptions api.ListOptions, experimentId *string) (models.ExperimentRunListOptions, error) {
+	var experimentIDPtr *int32
+	if experimentId != nil {
+		var err error
+		experimentIDPtr, err = apiutils.ValidateIDAsInt32Ptr(experimentId, "experiment")
+		if err != nil {
+			return models.ExperimentRunListOptions{}, err
+		}
+
+		// Validate that the experiment exists
+		_, err = b.GetExperimentById(*experimentId)
+		if err != nil {
+			return models.ExperimentRunListOptions{}, err
+		}
+	}
+
+	return models.ExperimentRunListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
+		},
+		ExperimentID: experimentIDPtr,
+	}, nil
+}
+
 func (b *ModelRegistryService) UpsertExperimentRunArtifact(artifact *openapi.Artifact, experimentRunId string) (*openapi.Artifact, error) {
+	if artifact != nil && artifact.Metric != nil {
+		if err := b.validateRunMetric(artifact.Metric, experimentRunId); err != nil {
+			return nil, err
+		}
+	}
+
 	result, err := b.upsertArtifact(artifact, &experimentRunId)
 	if err != nil {
 		return nil, err
@@ -265,6 +328,7 @@ func (b *ModelRegistryService) GetExperimentRunMetricHistory(name *string, stepI
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
 			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
 		},
 		ExperimentRunID: experimentRunIdInt32Ptr,
 	}
@@ -282,6 +346,10 @@ func (b *ModelRegistryService) GetExperimentRunMetricHistory(name *string, stepI
 		listOptsCopy.StepIds = stepIds
 	}
 
+	if b.metricStore != nil {
+		return b.getMetricHistoryFromStore(listOptsCopy)
+	}
+
 	// Query metric history repository
 	metricHistories, err := b.metricHistoryRepository.List(listOptsCopy)
 	if err != nil {
@@ -332,11 +400,15 @@ func (b *ModelRegistryService) InsertMetricHistory(metric *openapi.Metric, exper
 	}
 
 	// Validate that the experiment run exists
-	_, err := b.GetExperimentRunById(experimentRunId)
+	experimentRun, err := b.GetExperimentRunById(experimentRunId)
 	if err != nil {
 		return fmt.Errorf("experiment run not found: %w", err)
 	}
 
+	if b.metricStore != nil {
+		return b.insertMetricHistoryPoint(metric, experimentRun)
+	}
+
 	// Convert experiment run ID to int32
 	experimentRunIdInt32, err := apiutils.ValidateIDAsInt32(experimentRunId, "experiment run")
 	if err != nil {
@@ -361,7 +433,7 @@ func (b *ModelRegistryService) InsertMetricHistory(metric *openapi.Metric, exper
 
 	// Set experiment properties on the metric before converting
 	// This ensures the experiment context is available as custom properties for the converter
-	experimentRun, err := b.GetExperimentRunById(experimentRunId)
+	experimentRun, err = b.GetExperimentRunById(experimentRunId)
 	if err != nil {
 		return fmt.Errorf("failed to get experiment run: %w", err)
 	}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetExternalIdPolicy sets the scope in which external ids must be unique, the default is api.ExternalIdUniquePerType.
+func (b *ModelRegistryService) SetExternalIdPolicy(policy api.ExternalIdPolicy) error {
+	switch policy {
+	case api.ExternalIdUniquePerType, api.ExternalIdUniqueGlobal:
+		b.externalIdPolicy = policy
+		return nil
+	default:
+		return fmt.Errorf("invalid external id policy %q: must be %s or %s", policy, api.ExternalIdUniquePerType, api.ExternalIdUniqueGlobal)
+	}
+}
+
+func (b *ModelRegistryService) GetEntitiesByExternalId(externalId string) (*api.EntityReferenceList, error) {
+	if externalId == "" {
+		return nil, fmt.Errorf("invalid parameters call, supply externalId: %w", api.ErrBadRequest)
+	}
+
+	refs, err := b.findByExternalId(externalId)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no entities found for externalId=%s: %w", externalId, api.ErrNotFound)
+	}
+
+	return &api.EntityReferenceList{
+		Items: refs,
+		Size:  int32(len(refs)),
+	}, nil
+}
+
+// findByExternalId looks up the external id in every entity type, the external id is unique per type
+// so that each lookup returns at most one entity
+func (b *ModelRegistryService) findByExternalId(externalId string) ([]api.EntityReference, error) {
+	refs := []api.EntityReference{}
+
+	appendRefs := func(entityType string, ids []*int32) {
+		for _, id := range ids {
+			if id != nil {
+				refs = append(refs, newEntityReference(entityType, strconv.Itoa(int(*id))))
+			}
+		}
+	}
+
+	registeredModels, err := b.registeredModelRepository.List(models.RegisteredModelListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeRegisteredModel, entityIDs(registeredModels.Items))
+
+	modelVersions, err := b.modelVersionRepository.List(models.ModelVersionListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeModelVersion, entityIDs(modelVersions.Items))
+
+	servingEnvironments, err := b.servingEnvironmentRepository.List(models.ServingEnvironmentListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeServingEnvironment, entityIDs(servingEnvironments.Items))
+
+	inferenceServices, err := b.inferenceServiceRepository.List(models.InferenceServiceListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeInferenceService, entityIDs(inferenceServices.Items))
+
+	serveModels, err := b.serveModelRepository.List(models.ServeModelListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeServeModel, entityIDs(serveModels.Items))
+
+	experiments, err := b.experimentRepository.List(models.ExperimentListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeExperiment, entityIDs(experiments.Items))
+
+	experimentRuns, err := b.experimentRunRepository.List(models.ExperimentRunListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	appendRefs(api.EntityTypeExperimentRun, entityIDs(experimentRuns.Items))
+
+	artifacts, err := b.artifactRepository.List(models.ArtifactListOptions{ExternalID: &externalId})
+	if err != nil {
+		return nil, err
+	}
+	for _, artifact := range artifacts.Items {
+		if id := artifactID(artifact); id != nil {
+			ref := newEntityReference(api.EntityTypeArtifact, strconv.Itoa(int(*id)))
+			ref.ArtifactType = artifactType(artifact)
+			refs = append(refs, ref)
+		}
+	}
+
+	return refs, nil
+}
+
+// checkExternalIdAvailable rejects an external id already used by an entity of another type when external ids
+// are unique globally, uniqueness per type is enforced by the database
+func (b *ModelRegistryService) checkExternalIdAvailable(externalId *string, entityType string, id *string) error {
+	if b.externalIdPolicy != api.ExternalIdUniqueGlobal || externalId == nil || *externalId == "" {
+		return nil
+	}
+
+	refs, err := b.findByExternalId(*externalId)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if ref.EntityType == entityType && id != nil && ref.Id == *id {
+			continue
+		}
+
+		return fmt.Errorf("externalId %s is already used by %s %s: %w", *externalId, ref.EntityType, ref.Id, api.ErrConflict)
+	}
+
+	return nil
+}
+
+// checkArtifactExternalIdAvailable is checkExternalIdAvailable for any type of artifact
+func (b *ModelRegistryService) checkArtifactExternalIdAvailable(artifact *openapi.Artifact) error {
+	instance, ok := artifact.GetActualInstance().(interface {
+		GetExternalIdOk() (*string, bool)
+		GetIdOk() (*string, bool)
+	})
+	if !ok {
+		return nil
+	}
+
+	externalId, _ := instance.GetExternalIdOk()
+	id, _ := instance.GetIdOk()
+
+	return b.checkExternalIdAvailable(externalId, api.EntityTypeArtifact, id)
+}
+
+func newEntityReference(entityType string, id string) api.EntityReference {
+	return api.EntityReference{EntityType: entityType, Id: id, Ref: api.NewEntityRef(entityType, id)}
+}
+
+func entityIDs[T interface{ GetID() *int32 }](entities []T) []*int32 {
+	ids := make([]*int32, 0, len(entities))
+	for _, entity := range entities {
+		ids = append(ids, entity.GetID())
+	}
+
+	return ids
+}
+
+// artifactType returns the REST artifact type of the loaded artifact
+func artifactType(artifact models.Artifact) string {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return string(openapi.ARTIFACTTYPEQUERYPARAM_MODEL_ARTIFACT)
+	case artifact.DocArtifact != nil:
+		return string(openapi.ARTIFACTTYPEQUERYPARAM_DOC_ARTIFACT)
+	case artifact.DataSet != nil:
+		return string(openapi.ARTIFACTTYPEQUERYPARAM_DATASET_ARTIFACT)
+	case artifact.Metric != nil:
+		return string(openapi.ARTIFACTTYPEQUERYPARAM_METRIC)
+	case artifact.Parameter != nil:
+		return string(openapi.ARTIFACTTYPEQUERYPARAM_PARAMETER)
+	}
+
+	return ""
+}
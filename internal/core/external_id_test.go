@@ -0,0 +1,94 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEntitiesByExternalId(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name:       "external-id-model",
+		ExternalId: apiutils.Of("shared-external-id"),
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "external-id-version",
+		RegisteredModelId: *registeredModel.Id,
+		ExternalId:        apiutils.Of("version-external-id"),
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	// External ids are unique per type by default, an artifact can share the external id of a registered model
+	artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{
+			Name:       apiutils.Of("external-id-artifact"),
+			ExternalId: apiutils.Of("shared-external-id"),
+		},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+
+	t.Run("single entity", func(t *testing.T) {
+		refs, err := _service.GetEntitiesByExternalId("version-external-id")
+		require.NoError(t, err)
+
+		require.Len(t, refs.Items, 1)
+		assert.Equal(t, api.EntityReference{
+			EntityType: api.EntityTypeModelVersion,
+			Id:         *modelVersion.Id,
+			Ref:        api.NewEntityRef(api.EntityTypeModelVersion, *modelVersion.Id),
+		}, refs.Items[0])
+	})
+
+	t.Run("entities of different types", func(t *testing.T) {
+		refs, err := _service.GetEntitiesByExternalId("shared-external-id")
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []api.EntityReference{
+			{EntityType: api.EntityTypeRegisteredModel, Id: *registeredModel.Id, Ref: api.NewEntityRef(api.EntityTypeRegisteredModel, *registeredModel.Id)},
+			{EntityType: api.EntityTypeArtifact, ArtifactType: "model-artifact", Id: *artifact.ModelArtifact.Id, Ref: api.NewEntityRef(api.EntityTypeArtifact, *artifact.ModelArtifact.Id)},
+		}, refs.Items)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := _service.GetEntitiesByExternalId("unknown-external-id")
+		assert.True(t, errors.Is(err, api.ErrNotFound))
+
+		_, err = _service.GetEntitiesByExternalId("")
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+	})
+
+	t.Run("global uniqueness", func(t *testing.T) {
+		require.NoError(t, _service.SetExternalIdPolicy(api.ExternalIdUniqueGlobal))
+		defer func() {
+			require.NoError(t, _service.SetExternalIdPolicy(api.ExternalIdUniquePerType))
+		}()
+
+		_, err := _service.UpsertExperiment(&openapi.Experiment{
+			Name:       "external-id-experiment",
+			ExternalId: apiutils.Of("version-external-id"),
+		})
+		assert.True(t, errors.Is(err, api.ErrConflict))
+
+		// Updating an entity keeping its own external id is allowed
+		_, err = _service.UpsertModelVersion(&openapi.ModelVersion{
+			Id:                modelVersion.Id,
+			Name:              "external-id-version",
+			RegisteredModelId: *registeredModel.Id,
+			Description:       apiutils.Of("updated"),
+			ExternalId:        apiutils.Of("version-external-id"),
+		}, registeredModel.Id)
+		assert.NoError(t, err)
+
+		assert.Error(t, _service.SetExternalIdPolicy("unknown"))
+	})
+}
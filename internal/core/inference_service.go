@@ -18,6 +18,22 @@ func (b *ModelRegistryService) UpsertInferenceService(inferenceService *openapi.
 		return nil, fmt.Errorf("invalid inference service pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(inferenceService.ExternalId, api.EntityTypeInferenceService, inferenceService.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeInferenceService, inferenceService.Id, inferenceService.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("inference service", inferenceService.Id, &inferenceService.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeInferenceService, &inferenceService.CustomProperties); err != nil {
+		return nil, err
+	}
+
 	if inferenceService.Id != nil {
 		existing, err := b.GetInferenceServiceById(*inferenceService.Id)
 		if err != nil {
@@ -138,6 +154,9 @@ func (b *ModelRegistryService) GetInferenceServices(listOptions api.ListOptions,
 			OrderBy:       listOptions.OrderBy,
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
 		},
 		Runtime:          runtime,
 		ParentResourceID: parentResourceID,
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// lineageKey identifies an entity of a lineage, the ids of each kind have their own sequence.
+type lineageKey struct {
+	kind models.LineageKind
+	id   int32
+}
+
+// lineageUnownedTypes are the types whose names are not prefixed with the id of an owner.
+var lineageUnownedTypes = map[string]bool{
+	defaults.RegisteredModelTypeName:    true,
+	defaults.ExperimentTypeName:         true,
+	defaults.ServingEnvironmentTypeName: true,
+}
+
+// lineageEntityTypes are the entity types of the types of the lineage nodes with a get endpoint.
+var lineageEntityTypes = map[string]string{
+	defaults.RegisteredModelTypeName:    api.EntityTypeRegisteredModel,
+	defaults.ModelVersionTypeName:       api.EntityTypeModelVersion,
+	defaults.ServingEnvironmentTypeName: api.EntityTypeServingEnvironment,
+	defaults.InferenceServiceTypeName:   api.EntityTypeInferenceService,
+	defaults.ServeModelTypeName:         api.EntityTypeServeModel,
+	defaults.ExperimentTypeName:         api.EntityTypeExperiment,
+	defaults.ExperimentRunTypeName:      api.EntityTypeExperimentRun,
+	defaults.ModelArtifactTypeName:      api.EntityTypeArtifact,
+	defaults.DocArtifactTypeName:        api.EntityTypeArtifact,
+	defaults.DataSetTypeName:            api.EntityTypeArtifact,
+	defaults.MetricTypeName:             api.EntityTypeArtifact,
+	defaults.ParameterTypeName:          api.EntityTypeArtifact,
+}
+
+// GetRegisteredModelLineage walks the links of the registered model breadth first, up to depth links away.
+func (b *ModelRegistryService) GetRegisteredModelLineage(id string, depth int32) (*api.Lineage, error) {
+	registeredModelID, err := apiutils.ValidateIDAsInt32(id, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.GetRegisteredModelById(id); err != nil {
+		return nil, err
+	}
+
+	return b.getLineage(id, registeredModelID, depth)
+}
+
+// GetModelVersionLineage walks the links of the model version breadth first, up to depth links away.
+func (b *ModelRegistryService) GetModelVersionLineage(id string, depth int32) (*api.Lineage, error) {
+	modelVersionID, err := apiutils.ValidateIDAsInt32(id, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.GetModelVersionById(id); err != nil {
+		return nil, err
+	}
+
+	return b.getLineage(id, modelVersionID, depth)
+}
+
+// getLineage walks the links of the root context. The child contexts are only walked from the root, e.g. the
+// versions of a registered model, so that the lineage has the experiments of the runs which logged the artifacts but
+// not their other runs, nor the other versions of the registered model of a model version.
+func (b *ModelRegistryService) getLineage(id string, rootID int32, depth int32) (*api.Lineage, error) {
+	if depth < 1 || depth > api.MaxLineageDepth {
+		return nil, fmt.Errorf("invalid lineage depth %d, must be between 1 and %d: %w", depth, api.MaxLineageDepth, api.ErrBadRequest)
+	}
+
+	root := lineageKey{kind: models.LineageContext, id: rootID}
+	depths := map[lineageKey]int32{root: 0}
+	edges := map[models.LineageLink]bool{}
+
+	frontier := []lineageKey{root}
+	for distance := int32(1); distance <= depth && len(frontier) > 0; distance++ {
+		links, err := b.lineageRepository.GetLinks(lineageIDs(frontier))
+		if err != nil {
+			return nil, err
+		}
+
+		var next []lineageKey
+		reach := func(key lineageKey) {
+			if _, ok := depths[key]; !ok {
+				depths[key] = distance
+				next = append(next, key)
+			}
+		}
+
+		for _, link := range links {
+			parent := lineageKey{kind: models.LineageContext, id: link.ContextID}
+			child := lineageKey{kind: link.Kind, id: link.ID}
+
+			fromParent := slices.Contains(frontier, parent) && (link.Kind != models.LineageContext || parent == root)
+			fromChild := slices.Contains(frontier, child)
+			if !fromParent && !fromChild {
+				continue
+			}
+
+			edges[link] = true
+			if fromParent {
+				reach(child)
+			}
+			if fromChild {
+				reach(parent)
+			}
+		}
+
+		frontier = next
+	}
+
+	keys := make([]lineageKey, 0, len(depths))
+	for key := range depths {
+		keys = append(keys, key)
+	}
+	nodes, err := b.lineageRepository.GetNodes(lineageIDs(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	typeNames := make(map[int32]string, len(b.typesMap))
+	for name, typeID := range b.typesMap {
+		typeNames[typeID] = name
+	}
+
+	lineage := &api.Lineage{
+		RootId: id,
+		Depth:  depth,
+		Nodes:  make([]api.LineageNode, 0, len(nodes)),
+		Edges:  make([]api.LineageEdge, 0, len(edges)),
+	}
+
+	for _, node := range nodes {
+		typeName := typeNames[node.TypeID]
+		name := node.Name
+		if !lineageUnownedTypes[typeName] {
+			name = converter.MapNameFromOwned(name)
+		}
+		nodeID := strconv.FormatInt(int64(node.ID), 10)
+		var ref *api.EntityRef
+		if entityType, ok := lineageEntityTypes[typeName]; ok {
+			ref = apiutils.Of(api.NewEntityRef(entityType, nodeID))
+		}
+		lineage.Nodes = append(lineage.Nodes, api.LineageNode{
+			Id:    nodeID,
+			Kind:  string(node.Kind),
+			Type:  typeName,
+			Name:  apiutils.ZeroIfNil(name),
+			Ref:   ref,
+			Depth: depths[lineageKey{kind: node.Kind, id: node.ID}],
+		})
+	}
+	slices.SortFunc(lineage.Nodes, func(a, b api.LineageNode) int {
+		return cmp.Or(cmp.Compare(a.Depth, b.Depth), cmp.Compare(a.Kind, b.Kind), compareIDs(a.Id, b.Id))
+	})
+
+	for link := range edges {
+		lineage.Edges = append(lineage.Edges, api.LineageEdge{
+			ContextId: strconv.FormatInt(int64(link.ContextID), 10),
+			Kind:      string(link.Kind),
+			Id:        strconv.FormatInt(int64(link.ID), 10),
+		})
+	}
+	slices.SortFunc(lineage.Edges, func(a, b api.LineageEdge) int {
+		return cmp.Or(compareIDs(a.ContextId, b.ContextId), cmp.Compare(a.Kind, b.Kind), compareIDs(a.Id, b.Id))
+	})
+
+	return lineage, nil
+}
+
+// lineageIDs groups the ids of the entities by kind.
+func lineageIDs(keys []lineageKey) models.LineageIDs {
+	ids := models.LineageIDs{}
+	for _, key := range keys {
+		ids[key.kind] = append(ids[key.kind], key.id)
+	}
+	return ids
+}
+
+// compareIDs orders numeric ids by value.
+func compareIDs(a, b string) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), cmp.Compare(a, b))
+}
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetLintRules sets the soft limits reported as warnings of the writes, the default is api.DefaultLintRules.
+func (b *ModelRegistryService) SetLintRules(rules api.LintRules) error {
+	if rules.MaxModelVersions < 0 || rules.MaxCustomProperties < 0 {
+		return fmt.Errorf("invalid lint rules: limits cannot be negative")
+	}
+
+	schemes := make([]string, 0, len(rules.DeprecatedURISchemes))
+	for _, scheme := range rules.DeprecatedURISchemes {
+		scheme = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(scheme), "://"))
+		if scheme == "" {
+			return fmt.Errorf("invalid lint rules: deprecated uri schemes cannot be empty")
+		}
+		schemes = append(schemes, scheme)
+	}
+	rules.DeprecatedURISchemes = schemes
+
+	b.lintRules = rules
+	return nil
+}
+
+// customPropertiesEntity is implemented by all the entity types of the api.
+type customPropertiesEntity interface {
+	GetCustomProperties() map[string]openapi.MetadataValue
+}
+
+func (b *ModelRegistryService) LintWrite(entity any) []api.Warning {
+	if artifact, ok := entity.(*openapi.Artifact); ok {
+		if artifact == nil {
+			return nil
+		}
+		entity = artifact.GetActualInstance()
+	}
+
+	warnings := []api.Warning{}
+
+	if e, ok := entity.(customPropertiesEntity); ok && b.lintRules.MaxCustomProperties > 0 {
+		if count := len(e.GetCustomProperties()); count > b.lintRules.MaxCustomProperties {
+			warnings = append(warnings, api.Warning{
+				Rule:    api.LintRuleMaxCustomProperties,
+				Message: fmt.Sprintf("entity has %d custom properties, more than %d, consider moving bulky metadata to a doc artifact", count, b.lintRules.MaxCustomProperties),
+			})
+		}
+	}
+
+	if e, ok := entity.(customPropertiesEntity); ok {
+		warnings = append(warnings, b.propertyLimits.Warnings(propertyLimitsEntityType(entity), e.GetCustomProperties())...)
+	}
+
+	switch e := entity.(type) {
+	case *openapi.ModelVersion:
+		if warning := b.lintModelVersionCount(e.RegisteredModelId); warning != nil {
+			warnings = append(warnings, *warning)
+		}
+	case *openapi.ModelArtifact:
+		warnings = append(warnings, b.lintURIScheme(e.Uri)...)
+	case *openapi.DocArtifact:
+		warnings = append(warnings, b.lintURIScheme(e.Uri)...)
+	case *openapi.DataSet:
+		warnings = append(warnings, b.lintURIScheme(e.Uri)...)
+	}
+
+	if len(warnings) == 0 {
+		return nil
+	}
+	return warnings
+}
+
+// lintModelVersionCount suggests archiving old versions once a registered model has MaxModelVersions versions.
+func (b *ModelRegistryService) lintModelVersionCount(registeredModelId string) *api.Warning {
+	if b.lintRules.MaxModelVersions <= 0 {
+		return nil
+	}
+
+	parentID, err := apiutils.ValidateIDAsInt32(registeredModelId, "registered model")
+	if err != nil {
+		return nil
+	}
+
+	// counting stops at the limit, a full page means the limit is reached
+	versions, err := b.modelVersionRepository.List(models.ModelVersionListOptions{
+		Pagination:       models.Pagination{PageSize: apiutils.Of(b.lintRules.MaxModelVersions)},
+		ParentResourceID: &parentID,
+	})
+	if err != nil {
+		glog.Warningf("Failed to count the versions of registered model %s for lint rule %s: %v", registeredModelId, api.LintRuleMaxModelVersions, err)
+		return nil
+	}
+
+	if versions.Size < b.lintRules.MaxModelVersions {
+		return nil
+	}
+	return &api.Warning{
+		Rule:    api.LintRuleMaxModelVersions,
+		Message: fmt.Sprintf("registered model %s has %d+ versions, consider archiving the old ones", registeredModelId, b.lintRules.MaxModelVersions),
+	}
+}
+
+// lintURIScheme reports artifact uris using a deprecated scheme.
+func (b *ModelRegistryService) lintURIScheme(uri *string) []api.Warning {
+	if uri == nil || len(b.lintRules.DeprecatedURISchemes) == 0 {
+		return nil
+	}
+
+	parsed, err := url.Parse(*uri)
+	if err != nil || parsed.Scheme == "" {
+		return nil
+	}
+
+	if scheme := strings.ToLower(parsed.Scheme); slices.Contains(b.lintRules.DeprecatedURISchemes, scheme) {
+		return []api.Warning{{
+			Rule:    api.LintRuleDeprecatedURIScheme,
+			Message: fmt.Sprintf("artifact uri scheme %s is deprecated, consider migrating the artifact", scheme),
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintWrite(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	require.NoError(t, _service.SetLintRules(api.LintRules{
+		MaxModelVersions:     2,
+		MaxCustomProperties:  1,
+		DeprecatedURISchemes: []string{"HTTP"},
+	}))
+	assert.Error(t, _service.SetLintRules(api.LintRules{MaxModelVersions: -1}))
+	assert.Error(t, _service.SetLintRules(api.LintRules{DeprecatedURISchemes: []string{" "}}))
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "lint-test-registered-model"})
+	require.NoError(t, err)
+	assert.Empty(t, _service.LintWrite(registeredModel))
+
+	var modelVersion *openapi.ModelVersion
+	for i := range 2 {
+		modelVersion, err = _service.UpsertModelVersion(&openapi.ModelVersion{Name: fmt.Sprintf("v%d", i)}, registeredModel.Id)
+		require.NoError(t, err)
+	}
+	warnings := _service.LintWrite(modelVersion)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, api.LintRuleMaxModelVersions, warnings[0].Rule)
+	assert.Contains(t, warnings[0].Message, "2+ versions")
+
+	artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{
+			Name: apiutils.Of("model"),
+			Uri:  apiutils.Of("http://models.example.com/model.onnx"),
+			CustomProperties: map[string]openapi.MetadataValue{
+				"a": stringMetadataValue("1"),
+				"b": stringMetadataValue("2"),
+			},
+		},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+
+	warnings = _service.LintWrite(artifact)
+	rules := []string{}
+	for _, warning := range warnings {
+		rules = append(rules, warning.Rule)
+	}
+	assert.ElementsMatch(t, []string{api.LintRuleMaxCustomProperties, api.LintRuleDeprecatedURIScheme}, rules)
+
+	require.NoError(t, _service.SetLintRules(api.LintRules{}))
+	assert.Empty(t, _service.LintWrite(artifact), "zero limits disable the rules")
+}
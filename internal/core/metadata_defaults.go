@@ -0,0 +1,45 @@
+package core
+
+import (
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/metadatadefaults"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetMetadataDefaults injects the default custom properties and tags of the namespace of the registry into the
+// entities it creates.
+func (b *ModelRegistryService) SetMetadataDefaults(injector *metadatadefaults.Injector) {
+	b.metadataDefaults = injector
+}
+
+// injectMetadataDefaults sets the missing default custom properties of a new entity, entities being updated are
+// left unchanged.
+func (b *ModelRegistryService) injectMetadataDefaults(entityType string, id *string, customProperties *map[string]openapi.MetadataValue) error {
+	if b.metadataDefaults == nil || id != nil {
+		return nil
+	}
+
+	injected, err := b.metadataDefaults.Inject(customProperties)
+	if err != nil {
+		return err
+	}
+
+	if len(injected) > 0 {
+		glog.Infof("Injected default custom properties %v of namespace %s into new %s", injected, b.metadataDefaults.Namespace(), entityType)
+	}
+	return nil
+}
+
+// injectArtifactMetadataDefaults injects the default custom properties into new model artifacts, doc artifacts and
+// datasets, metrics and parameters are values logged for an experiment run and get none.
+func (b *ModelRegistryService) injectArtifactMetadataDefaults(artifact *openapi.Artifact) error {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return b.injectMetadataDefaults("model artifact", artifact.ModelArtifact.Id, &artifact.ModelArtifact.CustomProperties)
+	case artifact.DocArtifact != nil:
+		return b.injectMetadataDefaults("doc artifact", artifact.DocArtifact.Id, &artifact.DocArtifact.CustomProperties)
+	case artifact.DataSet != nil:
+		return b.injectMetadataDefaults("dataset", artifact.DataSet.Id, &artifact.DataSet.CustomProperties)
+	}
+	return nil
+}
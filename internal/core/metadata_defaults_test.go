@@ -0,0 +1,71 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/metadatadefaults"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringMetadataValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
+
+func TestMetadataDefaults(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	injector, err := metadatadefaults.NewInjector(&metadatadefaults.Config{Namespaces: map[string]metadatadefaults.Defaults{
+		"team-a": {
+			CustomProperties: map[string]string{"cost-center": "4711", "data-classification": "internal"},
+			Tags:             []string{"team-a"},
+		},
+	}}, "team-a")
+	require.NoError(t, err)
+	_service.SetMetadataDefaults(injector)
+
+	// values set by the client are kept
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "defaults-test-registered-model",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"data-classification": stringMetadataValue("confidential"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "4711", registeredModel.CustomProperties["cost-center"].MetadataStringValue.StringValue)
+	assert.Equal(t, "confidential", registeredModel.CustomProperties["data-classification"].MetadataStringValue.StringValue)
+	assert.Equal(t, "", registeredModel.CustomProperties["team-a"].MetadataStringValue.StringValue)
+
+	var audit metadatadefaults.Audit
+	require.NoError(t, json.Unmarshal([]byte(registeredModel.CustomProperties[metadatadefaults.AuditProperty].MetadataStringValue.StringValue), &audit))
+	assert.Equal(t, metadatadefaults.Audit{
+		Namespace: "team-a",
+		Values:    map[string]string{"cost-center": "4711", "team-a": ""},
+	}, audit)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, registeredModel.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "internal", modelVersion.CustomProperties["data-classification"].MetadataStringValue.StringValue)
+
+	artifact, err := _service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of("model"), Uri: apiutils.Of("s3://bucket/model")},
+	}, *modelVersion.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "4711", artifact.ModelArtifact.CustomProperties["cost-center"].MetadataStringValue.StringValue)
+
+	// updates don't inject the defaults removed from an entity
+	updated, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Id: modelVersion.Id,
+		CustomProperties: map[string]openapi.MetadataValue{
+			"data-classification": stringMetadataValue("internal"),
+		},
+	}, registeredModel.Id)
+	require.NoError(t, err)
+	assert.NotContains(t, updated.CustomProperties, "cost-center")
+	assert.NotContains(t, updated.CustomProperties, metadatadefaults.AuditProperty)
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+func (b *ModelRegistryService) GetExperimentRunsMetricAggregate(experimentId string, metric string, aggregation string) (*api.RunMetricAggregateList, error) {
+	if metric == "" {
+		return nil, fmt.Errorf("metric name is required: %w", api.ErrBadRequest)
+	}
+
+	switch aggregation {
+	case api.MetricAggregationMax, api.MetricAggregationMin, api.MetricAggregationAvg:
+	default:
+		return nil, fmt.Errorf("invalid aggregation %q, must be one of %s, %s or %s: %w", aggregation,
+			api.MetricAggregationMax, api.MetricAggregationMin, api.MetricAggregationAvg, api.ErrBadRequest)
+	}
+
+	if b.metricStore != nil {
+		return nil, fmt.Errorf("aggregating metric history stored in a metric store is not supported: %w", api.ErrBadRequest)
+	}
+
+	if _, err := b.GetExperimentById(experimentId); err != nil {
+		return nil, err
+	}
+
+	experimentID, err := apiutils.ValidateIDAsInt32(experimentId, "experiment")
+	if err != nil {
+		return nil, err
+	}
+
+	aggregates, err := b.metricHistoryRepository.Aggregate(models.MetricHistoryAggregateOptions{
+		ExperimentID: experimentID,
+		Name:         metric,
+		Aggregation:  aggregation,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.RunMetricAggregateList{
+		Metric:      metric,
+		Aggregation: aggregation,
+		Items:       []api.RunMetricAggregate{},
+	}
+
+	// the aggregation over all the runs is computed from the ones of each run, the averages weighted by their count
+	var value float64
+	var count int32
+	for i, aggregate := range aggregates {
+		result.Items = append(result.Items, api.RunMetricAggregate{
+			ExperimentRunId: strconv.FormatInt(int64(aggregate.ExperimentRunID), 10),
+			Value:           aggregate.Value,
+			Count:           aggregate.Count,
+		})
+
+		switch aggregation {
+		case api.MetricAggregationMax:
+			if i == 0 || aggregate.Value > value {
+				value = aggregate.Value
+			}
+		case api.MetricAggregationMin:
+			if i == 0 || aggregate.Value < value {
+				value = aggregate.Value
+			}
+		case api.MetricAggregationAvg:
+			value += aggregate.Value * float64(aggregate.Count)
+		}
+		count += aggregate.Count
+	}
+
+	if count > 0 {
+		if aggregation == api.MetricAggregationAvg {
+			value /= float64(count)
+		}
+		result.Value = &value
+	}
+	result.Size = int32(len(result.Items))
+
+	return result, nil
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/metricstore"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// insertMetricHistoryPoint writes a metric value to the metric store instead of the property tables
+func (b *ModelRegistryService) insertMetricHistoryPoint(metric *openapi.Metric, experimentRun *openapi.ExperimentRun) error {
+	if metric.Name == nil || *metric.Name == "" {
+		return fmt.Errorf("metric name is required: %w", api.ErrBadRequest)
+	}
+
+	if metric.Value == nil {
+		return fmt.Errorf("metric value is required: %w", api.ErrBadRequest)
+	}
+
+	// Prefer the time the value was logged, falling back to the metric update time
+	timestamp := time.Now().UnixMilli()
+	for _, t := range []*string{metric.Timestamp, metric.LastUpdateTimeSinceEpoch} {
+		if t == nil {
+			continue
+		}
+		if parsed, err := strconv.ParseInt(*t, 10, 64); err == nil {
+			timestamp = parsed
+			break
+		}
+	}
+
+	point := metricstore.Point{
+		ExperimentID:    experimentRun.ExperimentId,
+		ExperimentRunID: *experimentRun.Id,
+		Name:            *metric.Name,
+		Value:           *metric.Value,
+		Timestamp:       timestamp,
+	}
+	if metric.Step != nil {
+		point.Step = *metric.Step
+	}
+
+	if err := b.metricStore.Write(context.Background(), point); err != nil {
+		return fmt.Errorf("failed to insert metric history: %w", err)
+	}
+
+	glog.Infof("Successfully inserted metric history for metric %s in experiment run %s", *metric.Name, *experimentRun.Id)
+	return nil
+}
+
+// getMetricHistoryFromStore reads the metric history from the metric store instead of the property tables
+func (b *ModelRegistryService) getMetricHistoryFromStore(listOptions models.MetricHistoryListOptions) (*openapi.MetricList, error) {
+	if listOptions.FilterQuery != nil && *listOptions.FilterQuery != "" {
+		return nil, fmt.Errorf("filterQuery is not supported on metric history stored in a metric store: %w", api.ErrBadRequest)
+	}
+
+	if listOptions.Query != nil && *listOptions.Query != "" {
+		return nil, fmt.Errorf("q is not supported on metric history stored in a metric store: %w", api.ErrBadRequest)
+	}
+
+	query := metricstore.Query{
+		Name: listOptions.Name,
+	}
+
+	if listOptions.ExperimentRunID != nil {
+		query.ExperimentRunID = apiutils.Of(strconv.FormatInt(int64(*listOptions.ExperimentRunID), 10))
+	}
+
+	if listOptions.PageSize != nil {
+		query.PageSize = *listOptions.PageSize
+	}
+
+	if listOptions.NextPageToken != nil {
+		query.NextPageToken = *listOptions.NextPageToken
+	}
+
+	if listOptions.StepIds != nil {
+		for _, part := range strings.Split(*listOptions.StepIds, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			step, err := strconv.ParseInt(part, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid step ID '%s': must be a valid integer: %w", part, api.ErrBadRequest)
+			}
+			query.Steps = append(query.Steps, step)
+		}
+	}
+
+	page, err := b.metricStore.Query(context.Background(), query)
+	if err != nil {
+		if errors.Is(err, metricstore.ErrQueryNotSupported) {
+			return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		return nil, err
+	}
+
+	results := make([]openapi.Metric, 0, len(page.Items))
+	for _, point := range page.Items {
+		timestamp := strconv.FormatInt(point.Timestamp, 10)
+
+		results = append(results, openapi.Metric{
+			Name:                     apiutils.Of(point.Name),
+			ExperimentId:             apiutils.Of(point.ExperimentID),
+			ExperimentRunId:          apiutils.Of(point.ExperimentRunID),
+			ArtifactType:             apiutils.Of(models.MetricHistoryType),
+			Value:                    apiutils.Of(point.Value),
+			Step:                     apiutils.Of(point.Step),
+			Timestamp:                apiutils.Of(timestamp),
+			CreateTimeSinceEpoch:     apiutils.Of(timestamp),
+			LastUpdateTimeSinceEpoch: apiutils.Of(timestamp),
+		})
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = metricstore.DefaultPageSize
+	}
+
+	return &openapi.MetricList{
+		NextPageToken: page.NextPageToken,
+		PageSize:      pageSize,
+		Size:          int32(len(results)),
+		Items:         results,
+	}, nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/metricstable"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// MetricsTable properties
+const (
+	metricsTableDescriptionProperty    = "description"
+	metricsTableModelVersionIdProperty = "model_version_id"
+	metricsTableDataProperty           = "data"
+)
+
+// CreateMetricsTable stores a metrics table of the model version, its columns encoded by metricstable. The names of
+// the tables are prefixed with the id of the model version as those of its artifacts.
+func (b *ModelRegistryService) CreateMetricsTable(modelVersionId string, metricsTable *api.MetricsTable) (*api.MetricsTable, error) {
+	if metricsTable == nil {
+		return nil, fmt.Errorf("invalid metrics table pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if metricsTable.Name == "" {
+		return nil, fmt.Errorf("missing metrics table name: %w", api.ErrBadRequest)
+	}
+
+	modelVersionID, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.getModelVersionEntity(modelVersionId); err != nil {
+		return nil, err
+	}
+
+	data, err := metricstable.Encode(metricsTable.Columns)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	typeID, ok := b.typesMap[defaults.MetricsTableTypeName]
+	if !ok {
+		return nil, fmt.Errorf("metrics table type not found in types map")
+	}
+
+	props := []models.Properties{
+		models.NewIntProperty(metricsTableModelVersionIdProperty, modelVersionID, false),
+		models.NewByteProperty(metricsTableDataProperty, data, false),
+	}
+	if metricsTable.Description != "" {
+		props = append(props, models.NewStringProperty(metricsTableDescriptionProperty, metricsTable.Description, false))
+	}
+
+	name := converter.PrefixWhenOwned(&modelVersionId, metricsTable.Name)
+	entity := &models.MetricsTableImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.MetricsTableAttributes{Name: &name},
+		Properties: &props,
+	}
+
+	saved, err := b.metricsTableRepository.Save(entity, &modelVersionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("metrics table with name %s already exists: %w", metricsTable.Name, api.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return mapToMetricsTable(saved, nil, true)
+}
+
+// GetMetricsTableById returns the metrics table with the selected columns, in the order of the selection, or all its
+// columns without selection.
+func (b *ModelRegistryService) GetMetricsTableById(id string, columns []string) (*api.MetricsTable, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "metrics table")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.metricsTableRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no metrics table found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return mapToMetricsTable(entity, columns, true)
+}
+
+// GetMetricsTables lists the metrics tables, with the names and types of their columns but not their values.
+func (b *ModelRegistryService) GetMetricsTables(listOptions api.ListOptions, modelVersionId *string) (*api.MetricsTableList, error) {
+	var modelVersionID *int32
+
+	if modelVersionId != nil {
+		var err error
+		modelVersionID, err = apiutils.ValidateIDAsInt32Ptr(modelVersionId, "model version")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tables, err := b.metricsTableRepository.List(models.MetricsTableListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		ModelVersionID: modelVersionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tableList := &api.MetricsTableList{
+		Items: []api.MetricsTable{},
+	}
+
+	for _, entity := range tables.Items {
+		mapped, err := mapToMetricsTable(entity, nil, false)
+		if err != nil {
+			return nil, err
+		}
+		tableList.Items = append(tableList.Items, *mapped)
+	}
+
+	tableList.NextPageToken = tables.NextPageToken
+	tableList.PageSize = tables.PageSize
+	tableList.Size = tables.Size
+
+	return tableList, nil
+}
+
+// mapToMetricsTable maps a data layer metrics table, decoding the values of the selected columns when withValues.
+func mapToMetricsTable(entity models.MetricsTable, columns []string, withValues bool) (*api.MetricsTable, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.MetricsTable{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		Name:                     apiutils.ZeroIfNil(converter.MapNameFromOwned(attrs.Name)),
+		Description:              stringPropertyValue(props, metricsTableDescriptionProperty),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	if prop := findProperty(props, metricsTableModelVersionIdProperty); prop != nil && prop.IntValue != nil {
+		mapped.ModelVersionId = strconv.FormatInt(int64(*prop.IntValue), 10)
+	}
+
+	prop := findProperty(props, metricsTableDataProperty)
+	if prop == nil || prop.ByteValue == nil {
+		return nil, fmt.Errorf("metrics table %s has no data", mapped.Id)
+	}
+
+	header, err := metricstable.DecodeHeader(*prop.ByteValue)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode metrics table %s: %w", mapped.Id, err)
+	}
+	mapped.RowCount = int32(header.Rows)
+	mapped.Columns = header.Columns
+
+	if withValues {
+		mapped.Columns, err = metricstable.Decode(*prop.ByteValue, columns)
+		if errors.Is(err, metricstable.ErrUnknownColumn) {
+			return nil, fmt.Errorf("metrics table %s: %v: %w", mapped.Id, err, api.ErrBadRequest)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode metrics table %s: %w", mapped.Id, err)
+		}
+	}
+
+	return mapped, nil
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// ModelCard properties, the lists and the training data are stored as JSON
+const (
+	modelCardRegisteredModelIdProperty     = "registered_model_id"
+	modelCardIntendedUseProperty           = "intended_use"
+	modelCardOutOfScopeUsesProperty        = "out_of_scope_uses"
+	modelCardLimitationsProperty           = "limitations"
+	modelCardEthicalConsiderationsProperty = "ethical_considerations"
+	modelCardTrainingDataProperty          = "training_data"
+)
+
+// modelCardName is the name of the cards, prefixed with the id of their registered model as those of owned entities.
+const modelCardName = "model-card"
+
+// UpsertModelCard creates the card of the registered model, or replaces all its sections if it has one.
+func (b *ModelRegistryService) UpsertModelCard(registeredModelId string, modelCard *api.ModelCard) (*api.ModelCard, error) {
+	if modelCard == nil {
+		return nil, fmt.Errorf("invalid model card pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := validateModelCard(modelCard); err != nil {
+		return nil, err
+	}
+
+	registeredModelID, err := apiutils.ValidateIDAsInt32(registeredModelId, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := b.registeredModelRepository.GetByID(registeredModelID); err != nil {
+		return nil, fmt.Errorf("no registered model found for id %s: %w", registeredModelId, api.ErrNotFound)
+	}
+
+	typeID, ok := b.typesMap[defaults.ModelCardTypeName]
+	if !ok {
+		return nil, fmt.Errorf("model card type not found in types map")
+	}
+
+	existing, err := b.getModelCardEntity(registeredModelID)
+	if err != nil {
+		return nil, err
+	}
+
+	// all the sections are written so that the ones left out of a replacement are cleared
+	props := []models.Properties{
+		models.NewIntProperty(modelCardRegisteredModelIdProperty, registeredModelID, false),
+		models.NewStringProperty(modelCardIntendedUseProperty, modelCard.IntendedUse, false),
+	}
+	for name, section := range map[string]any{
+		modelCardOutOfScopeUsesProperty:        modelCard.OutOfScopeUses,
+		modelCardLimitationsProperty:           modelCard.Limitations,
+		modelCardEthicalConsiderationsProperty: modelCard.EthicalConsiderations,
+		modelCardTrainingDataProperty:          modelCard.TrainingData,
+	} {
+		encoded, err := json.Marshal(section)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode model card %s: %w", name, err)
+		}
+		props = append(props, models.NewStringProperty(name, string(encoded), false))
+	}
+
+	name := converter.PrefixWhenOwned(&registeredModelId, modelCardName)
+	entity := &models.ModelCardImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.ModelCardAttributes{Name: &name},
+		Properties: &props,
+	}
+	if existing != nil {
+		entity.ID = existing.GetID()
+		entity.Attributes.CreateTimeSinceEpoch = existing.GetAttributes().CreateTimeSinceEpoch
+	}
+
+	saved, err := b.modelCardRepository.Save(entity, &registeredModelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("model card of registered model %s already exists: %w", registeredModelId, api.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return mapToModelCard(saved)
+}
+
+// GetModelCard returns the card of the registered model.
+func (b *ModelRegistryService) GetModelCard(registeredModelId string) (*api.ModelCard, error) {
+	registeredModelID, err := apiutils.ValidateIDAsInt32(registeredModelId, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.getModelCardEntity(registeredModelID)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, fmt.Errorf("no model card found for registered model %s: %w", registeredModelId, api.ErrNotFound)
+	}
+
+	return mapToModelCard(entity)
+}
+
+// getModelCardEntity returns the card of the registered model, nil if it has none.
+func (b *ModelRegistryService) getModelCardEntity(registeredModelID int32) (models.ModelCard, error) {
+	cards, err := b.modelCardRepository.List(models.ModelCardListOptions{
+		Pagination:        models.Pagination{PageSize: apiutils.Of(int32(1))},
+		RegisteredModelID: &registeredModelID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(cards.Items) == 0 {
+		return nil, nil
+	}
+	return cards.Items[0], nil
+}
+
+// validateModelCard checks the sections of the card are set, the training data being optional.
+func validateModelCard(modelCard *api.ModelCard) error {
+	if strings.TrimSpace(modelCard.IntendedUse) == "" {
+		return fmt.Errorf("missing model card intended use: %w", api.ErrBadRequest)
+	}
+
+	for section, items := range map[string][]string{
+		"out of scope uses":      modelCard.OutOfScopeUses,
+		"limitations":            modelCard.Limitations,
+		"ethical considerations": modelCard.EthicalConsiderations,
+	} {
+		for i, item := range items {
+			if strings.TrimSpace(item) == "" {
+				return fmt.Errorf("empty model card %s at index %d: %w", section, i, api.ErrBadRequest)
+			}
+		}
+	}
+
+	if data := modelCard.TrainingData; data != nil {
+		if strings.TrimSpace(data.Summary) == "" {
+			return fmt.Errorf("missing model card training data summary: %w", api.ErrBadRequest)
+		}
+		for i, dataset := range data.Datasets {
+			if strings.TrimSpace(dataset) == "" {
+				return fmt.Errorf("empty model card training dataset at index %d: %w", i, api.ErrBadRequest)
+			}
+		}
+	}
+
+	return nil
+}
+
+// mapToModelCard maps a data layer model card.
+func mapToModelCard(entity models.ModelCard) (*api.ModelCard, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.ModelCard{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		IntendedUse:              stringPropertyValue(props, modelCardIntendedUseProperty),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	if prop := findProperty(props, modelCardRegisteredModelIdProperty); prop != nil && prop.IntValue != nil {
+		mapped.RegisteredModelId = strconv.FormatInt(int64(*prop.IntValue), 10)
+	}
+
+	for name, section := range map[string]any{
+		modelCardOutOfScopeUsesProperty:        &mapped.OutOfScopeUses,
+		modelCardLimitationsProperty:           &mapped.Limitations,
+		modelCardEthicalConsiderationsProperty: &mapped.EthicalConsiderations,
+		modelCardTrainingDataProperty:          &mapped.TrainingData,
+	} {
+		if value := stringPropertyValue(props, name); value != "" {
+			if err := json.Unmarshal([]byte(value), section); err != nil {
+				return nil, fmt.Errorf("unable to decode %s of model card %s: %w", name, mapped.Id, err)
+			}
+		}
+	}
+
+	return mapped, nil
+}
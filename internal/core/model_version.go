@@ -19,6 +19,23 @@ func (b *ModelRegistryService) UpsertModelVersion(modelVersion *openapi.ModelVer
 		return nil, fmt.Errorf("invalid model version pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(modelVersion.ExternalId, api.EntityTypeModelVersion, modelVersion.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeModelVersion, modelVersion.Id, &modelVersion.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("model version", modelVersion.Id, &modelVersion.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeModelVersion, &modelVersion.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	var removed []models.Properties
 	if modelVersion.Id != nil {
 		existing, err := b.GetModelVersionById(*modelVersion.Id)
 		if err != nil {
@@ -36,6 +53,13 @@ func (b *ModelRegistryService) UpsertModelVersion(modelVersion *openapi.ModelVer
 			withNotEditable.CustomProperties = existing.CustomProperties
 		}
 
+		withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing, withNotEditable, func(modelVersion *openapi.ModelVersion) (models.ModelVersion, error) {
+			return b.mapper.MapFromModelVersion(modelVersion, nil)
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		modelVersion = &withNotEditable
 	}
 
@@ -47,6 +71,7 @@ func (b *ModelRegistryService) UpsertModelVersion(modelVersion *openapi.ModelVer
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 	}
+	removeProperties(model.GetProperties(), removed)
 
 	savedModel, err := b.modelVersionRepository.Save(model)
 	if err != nil {
@@ -73,6 +98,10 @@ func (b *ModelRegistryService) GetModelVersionById(id string) (*openapi.ModelVer
 		return nil, err
 	}
 
+	if _, err := b.rehydrate(convertedId); err != nil {
+		return nil, err
+	}
+
 	model, err := b.modelVersionRepository.GetByID(convertedId)
 	if err != nil {
 		return nil, fmt.Errorf("no model version found for id %s: %w", id, api.ErrNotFound)
@@ -125,9 +154,7 @@ func (b *ModelRegistryService) GetModelVersionByInferenceService(inferenceServic
 		}
 	}
 	// modelVersionId: ID of the ModelVersion to serve. If it's unspecified, then the latest ModelVersion by creation order will be served.
-	orderByCreateTime := "CREATE_TIME"
-	sortOrderDesc := "DESC"
-	versions, err := b.GetModelVersions(api.ListOptions{OrderBy: &orderByCreateTime, SortOrder: &sortOrderDesc}, &registeredModelID)
+	versions, err := b.GetModelVersions(api.NewModelVersionListOptions().OrderByCreateTime(openapi.SORTORDER_DESC).Build(), &registeredModelID)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +197,15 @@ func (b *ModelRegistryService) GetModelVersionByParams(name *string, parentResou
 		return nil, fmt.Errorf("no model versions found for name=%v, parentResourceId=%v, externalId=%v: %w", apiutils.ZeroIfNil(name), apiutils.ZeroIfNil(parentResourceId), apiutils.ZeroIfNil(externalId), api.ErrNotFound)
 	}
 
+	// Fetch again archived entities once rehydrated, to return their restored properties
+	rehydrated, err := b.rehydrate(*versionsList.Items[0].GetID())
+	if err != nil {
+		return nil, err
+	}
+	if rehydrated {
+		return b.GetModelVersionById(strconv.Itoa(int(*versionsList.Items[0].GetID())))
+	}
+
 	toReturn, err := b.mapper.MapToModelVersion(versionsList.Items[0])
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
@@ -179,26 +215,12 @@ func (b *ModelRegistryService) GetModelVersionByParams(name *string, parentResou
 }
 
 func (b *ModelRegistryService) GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error) {
-	var parentResourceID *int32
-
-	if registeredModelId != nil {
-		var err error
-		parentResourceID, err = apiutils.ValidateIDAsInt32Ptr(registeredModelId, "registered model")
-		if err != nil {
-			return nil, err
-		}
+	repoListOptions, err := modelVersionListOptions(listOptions, registeredModelId)
+	if err != nil {
+		return nil, err
 	}
 
-	versionsList, err := b.modelVersionRepository.List(models.ModelVersionListOptions{
-		Pagination: models.Pagination{
-			PageSize:      listOptions.PageSize,
-			OrderBy:       listOptions.OrderBy,
-			SortOrder:     listOptions.SortOrder,
-			NextPageToken: listOptions.NextPageToken,
-			FilterQuery:   listOptions.FilterQuery,
-		},
-		ParentResourceID: parentResourceID,
-	})
+	versionsList, err := b.modelVersionRepository.List(repoListOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -221,3 +243,51 @@ func (b *ModelRegistryService) GetModelVersions(listOptions api.ListOptions, reg
 
 	return modelVersionList, nil
 }
+
+// StreamModelVersions calls yield with the model versions of the page of listOptions as they are read, and returns
+// the token of the next page.
+func (b *ModelRegistryService) StreamModelVersions(listOptions api.ListOptions, registeredModelId *string, yield func(*openapi.ModelVersion) error) (string, error) {
+	repoListOptions, err := modelVersionListOptions(listOptions, registeredModelId)
+	if err != nil {
+		return "", err
+	}
+
+	return b.modelVersionRepository.ListStream(repoListOptions, func(model models.ModelVersion) error {
+		modelVersion, err := b.mapper.MapToModelVersion(model)
+		if err != nil {
+			return fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		return yield(modelVersion)
+	})
+}
+
+func modelVersionListOptions(listOptions api.ListOptions, registeredModelId *string) (models.ModelVersionListOptions, error) {
+	var parentResourceID *int32
+
+	if registeredModelId != nil {
+		var err error
+		parentResourceID, err = apiutils.ValidateIDAsInt32Ptr(registeredModelId, "registered model")
+		if err != nil {
+			return models.ModelVersionListOptions{}, err
+		}
+	}
+
+	state, err := listState(listOptions)
+	if err != nil {
+		return models.ModelVersionListOptions{}, err
+	}
+
+	return models.ModelVersionListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
+		},
+		ParentResourceID: parentResourceID,
+		State:            state,
+	}, nil
+}
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetDeployGates configures the gates evaluated by IsModelVersionDeployable, validated by deploygate.NewGates.
+// Without gates the model versions are deployable unless archived.
+func (b *ModelRegistryService) SetDeployGates(gates *api.DeployGates) {
+	b.deployGates = gates
+}
+
+// IsModelVersionDeployable evaluates the deploy gates for the model version, reporting the reasons of the failed
+// ones. The gates are evaluated on the current state of the registry, a model version can stop being deployable.
+func (b *ModelRegistryService) IsModelVersionDeployable(modelVersionId string) (*api.Deployability, error) {
+	modelVersion, err := b.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	gates := b.deployGates
+	if gates == nil {
+		gates = &api.DeployGates{}
+	}
+
+	results := []api.GateResult{stateGate(modelVersion)}
+	if gates.Approval != nil {
+		results = append(results, approvalGate(gates.Approval, modelVersion))
+	}
+	if gates.Signature != nil {
+		result, err := b.signatureGate(gates.Signature, modelVersionId)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if len(gates.Scans) > 0 {
+		results = append(results, scansGate(gates.Scans, modelVersion))
+	}
+	if gates.Evaluations != nil {
+		policy, err := b.GetModelVersionPolicy(modelVersionId)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, evaluationsGate(gates.Evaluations, policy, modelVersion))
+	}
+
+	deployability := &api.Deployability{
+		ModelVersionId: modelVersionId,
+		Deployable:     true,
+		Gates:          results,
+		Reasons:        []string{},
+	}
+	for _, result := range results {
+		if !result.Passed {
+			deployability.Deployable = false
+			deployability.Reasons = append(deployability.Reasons, result.Reasons...)
+		}
+	}
+
+	return deployability, nil
+}
+
+// newGateResult returns the result of gate, passed without reasons.
+func newGateResult(gate string, reasons []string) api.GateResult {
+	return api.GateResult{Gate: gate, Passed: len(reasons) == 0, Reasons: reasons}
+}
+
+func stateGate(modelVersion *openapi.ModelVersion) api.GateResult {
+	var reasons []string
+	if modelVersion.State != nil && *modelVersion.State == openapi.MODELVERSIONSTATE_ARCHIVED {
+		reasons = append(reasons, fmt.Sprintf("model version %s is archived", *modelVersion.Id))
+	}
+	return newGateResult(api.DeployGateState, reasons)
+}
+
+func approvalGate(gate *api.ApprovalGate, modelVersion *openapi.ModelVersion) api.GateResult {
+	var reasons []string
+	stage, _ := parseModelVersionStage(modelVersionStage(modelVersion))
+	if !slices.Contains(gate.Stages, stage) {
+		reasons = append(reasons, fmt.Sprintf("model version %s is in stage %s, not approved into %s", *modelVersion.Id, stage, joinStages(gate.Stages)))
+	}
+	return newGateResult(api.DeployGateApproval, reasons)
+}
+
+// signatureGate checks the signature property of the model artifacts of the model version which can be served,
+// failing if there are none.
+func (b *ModelRegistryService) signatureGate(gate *api.SignatureGate, modelVersionId string) (api.GateResult, error) {
+	modelVersionID, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return api.GateResult{}, err
+	}
+	modelArtifacts, err := b.listModelArtifacts(&modelVersionID)
+	if err != nil {
+		return api.GateResult{}, err
+	}
+
+	var reasons []string
+	signed := 0
+	for _, modelArtifact := range modelArtifacts {
+		if !resolvableArtifactState(modelArtifact.GetAttributes().State) {
+			continue
+		}
+		signed++
+		if verified := findCustomProperty(modelArtifact.GetCustomProperties(), gate.Property); verified == nil || !propertyIsTrue(*verified) {
+			reasons = append(reasons, fmt.Sprintf("model artifact %d has no verified signature in %s", *modelArtifact.GetID(), gate.Property))
+		}
+	}
+	if signed == 0 {
+		reasons = append(reasons, fmt.Sprintf("model version %s has no model artifact with a verified signature", modelVersionId))
+	}
+	return newGateResult(api.DeployGateSignature, reasons), nil
+}
+
+func scansGate(gates []api.ScanGate, modelVersion *openapi.ModelVersion) api.GateResult {
+	var reasons []string
+	for _, gate := range gates {
+		value, ok := modelVersion.CustomProperties[gate.Property]
+		switch {
+		case !ok || value.MetadataStringValue == nil:
+			reasons = append(reasons, fmt.Sprintf("model version %s has no %s result", *modelVersion.Id, gate.Property))
+		case !slices.ContainsFunc(gate.PassValues, func(pass string) bool { return strings.EqualFold(pass, value.MetadataStringValue.StringValue) }):
+			reasons = append(reasons, fmt.Sprintf("model version %s did not pass %s: %s", *modelVersion.Id, gate.Property, value.MetadataStringValue.StringValue))
+		}
+	}
+	return newGateResult(api.DeployGateScans, reasons)
+}
+
+func evaluationsGate(gate *api.EvaluationsGate, policy *api.ModelVersionPolicy, modelVersion *openapi.ModelVersion) api.GateResult {
+	var reasons []string
+	for _, evaluation := range policy.RequiredEvaluations {
+		property := gate.PropertyPrefix + evaluation.Suite
+		score, ok := metadataScore(modelVersion.CustomProperties[property])
+		switch {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("model version %s has no score for evaluation %s in %s", *modelVersion.Id, evaluation.Suite, property))
+		case evaluation.MinScore != nil && score < *evaluation.MinScore:
+			reasons = append(reasons, fmt.Sprintf("model version %s scored %g on evaluation %s, below the minimum score %g", *modelVersion.Id, score, evaluation.Suite, *evaluation.MinScore))
+		}
+	}
+	return newGateResult(api.DeployGateEvaluations, reasons)
+}
+
+// findCustomProperty returns the custom property with the name, nil if there is none.
+func findCustomProperty(props *[]models.Properties, name string) *models.Properties {
+	if props == nil {
+		return nil
+	}
+	for i := range *props {
+		if (*props)[i].Name == name {
+			return &(*props)[i]
+		}
+	}
+	return nil
+}
+
+// propertyIsTrue reports whether the property is the boolean true, or the string true.
+func propertyIsTrue(prop models.Properties) bool {
+	if prop.BoolValue != nil {
+		return *prop.BoolValue
+	}
+	if prop.StringValue != nil {
+		verified, _ := strconv.ParseBool(*prop.StringValue)
+		return verified
+	}
+	return false
+}
+
+// metadataScore returns the number of a double or int custom property, false for the other values.
+func metadataScore(value openapi.MetadataValue) (float64, bool) {
+	switch {
+	case value.MetadataDoubleValue != nil:
+		return value.MetadataDoubleValue.DoubleValue, true
+	case value.MetadataIntValue != nil:
+		score, err := strconv.ParseInt(value.MetadataIntValue.IntValue, 10, 64)
+		return float64(score), err == nil
+	}
+	return 0, false
+}
+
+// joinStages returns the stages separated by commas.
+func joinStages(stages []api.ModelVersionStage) string {
+	names := make([]string, 0, len(stages))
+	for _, stage := range stages {
+		names = append(names, string(stage))
+	}
+	return strings.Join(names, ", ")
+}
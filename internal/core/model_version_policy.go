@@ -0,0 +1,133 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// modelVersionPolicyProperty is the ModelVersion property holding the JSON encoded policy.
+const modelVersionPolicyProperty = "policy"
+
+func (b *ModelRegistryService) GetModelVersionPolicy(modelVersionId string) (*api.ModelVersionPolicy, error) {
+	glog.Infof("Getting policy for ModelVersion id %s", modelVersionId)
+
+	modelVersion, err := b.getModelVersionEntity(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeModelVersionPolicy(modelVersionId, findProperty(modelVersion.GetProperties(), modelVersionPolicyProperty))
+}
+
+func (b *ModelRegistryService) UpsertModelVersionPolicy(modelVersionId string, policy *api.ModelVersionPolicy) (*api.ModelVersionPolicy, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("invalid model version policy pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := validateModelVersionPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := b.getModelVersionEntity(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	toStore := api.ModelVersionPolicy{
+		Guardrails:          policy.Guardrails,
+		RequiredEvaluations: policy.RequiredEvaluations,
+	}
+
+	encoded, err := json.Marshal(toStore)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode model version policy: %w", err)
+	}
+
+	setProperty(modelVersion.GetProperties(), models.NewStringProperty(modelVersionPolicyProperty, string(encoded), false))
+
+	saved, err := b.modelVersionRepository.Save(modelVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeModelVersionPolicy(modelVersionId, findProperty(saved.GetProperties(), modelVersionPolicyProperty))
+}
+
+func (b *ModelRegistryService) GetInferenceServicePolicy(inferenceServiceId string) (*api.ModelVersionPolicy, error) {
+	modelVersion, err := b.GetModelVersionByInferenceService(inferenceServiceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.GetModelVersionPolicy(*modelVersion.Id)
+}
+
+// getModelVersionEntity loads the data layer ModelVersion, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getModelVersionEntity(modelVersionId string) (models.ModelVersion, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := b.modelVersionRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no model version found for id %s: %w", modelVersionId, api.ErrNotFound)
+	}
+
+	return modelVersion, nil
+}
+
+func decodeModelVersionPolicy(modelVersionId string, prop *models.Properties) (*api.ModelVersionPolicy, error) {
+	policy := &api.ModelVersionPolicy{}
+
+	if prop != nil && prop.StringValue != nil && *prop.StringValue != "" {
+		if err := json.Unmarshal([]byte(*prop.StringValue), policy); err != nil {
+			return nil, fmt.Errorf("unable to decode policy for model version %s: %w", modelVersionId, err)
+		}
+	}
+
+	policy.ModelVersionId = modelVersionId
+	if policy.Guardrails == nil {
+		policy.Guardrails = []api.GuardrailConfig{}
+	}
+	if policy.RequiredEvaluations == nil {
+		policy.RequiredEvaluations = []api.EvaluationRequirement{}
+	}
+
+	return policy, nil
+}
+
+func validateModelVersionPolicy(policy *api.ModelVersionPolicy) error {
+	guardrails := map[string]struct{}{}
+	for i, guardrail := range policy.Guardrails {
+		if guardrail.Name == "" {
+			return fmt.Errorf("guardrail at index %d is missing a name: %w", i, api.ErrBadRequest)
+		}
+		if _, ok := guardrails[guardrail.Name]; ok {
+			return fmt.Errorf("duplicate guardrail %q: %w", guardrail.Name, api.ErrBadRequest)
+		}
+		guardrails[guardrail.Name] = struct{}{}
+	}
+
+	suites := map[string]struct{}{}
+	for i, evaluation := range policy.RequiredEvaluations {
+		if evaluation.Suite == "" {
+			return fmt.Errorf("required evaluation at index %d is missing a suite: %w", i, api.ErrBadRequest)
+		}
+		if _, ok := suites[evaluation.Suite]; ok {
+			return fmt.Errorf("duplicate required evaluation suite %q: %w", evaluation.Suite, api.ErrBadRequest)
+		}
+		if evaluation.MinScore != nil && (math.IsNaN(*evaluation.MinScore) || math.IsInf(*evaluation.MinScore, 0)) {
+			return fmt.Errorf("invalid minScore for evaluation suite %q: %w", evaluation.Suite, api.ErrBadRequest)
+		}
+		suites[evaluation.Suite] = struct{}{}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelVersionPolicy(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "policy-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "policy-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	t.Run("empty policy by default", func(t *testing.T) {
+		policy, err := _service.GetModelVersionPolicy(*modelVersion.Id)
+		require.NoError(t, err)
+
+		assert.Equal(t, *modelVersion.Id, policy.ModelVersionId)
+		assert.Empty(t, policy.Guardrails)
+		assert.Empty(t, policy.RequiredEvaluations)
+	})
+
+	t.Run("upsert and get policy", func(t *testing.T) {
+		policy, err := _service.UpsertModelVersionPolicy(*modelVersion.Id, &api.ModelVersionPolicy{
+			Guardrails: []api.GuardrailConfig{
+				{Name: "pii-filter", Provider: "trustyai", Config: map[string]string{"mode": "redact"}},
+			},
+			RequiredEvaluations: []api.EvaluationRequirement{
+				{Suite: "toxicity", MinScore: apiutils.Of(0.95)},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, policy.Guardrails, 1)
+		assert.Equal(t, "pii-filter", policy.Guardrails[0].Name)
+
+		fetched, err := _service.GetModelVersionPolicy(*modelVersion.Id)
+		require.NoError(t, err)
+		assert.Equal(t, policy, fetched)
+		require.Len(t, fetched.RequiredEvaluations, 1)
+		assert.Equal(t, 0.95, *fetched.RequiredEvaluations[0].MinScore)
+	})
+
+	t.Run("model version updates preserve policy", func(t *testing.T) {
+		_, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+			Id:          modelVersion.Id,
+			Name:        modelVersion.Name,
+			Description: apiutils.Of("updated description"),
+		}, nil)
+		require.NoError(t, err)
+
+		fetched, err := _service.GetModelVersionPolicy(*modelVersion.Id)
+		require.NoError(t, err)
+		assert.Len(t, fetched.Guardrails, 1)
+	})
+
+	t.Run("policy of served model version", func(t *testing.T) {
+		servingEnv, err := _service.UpsertServingEnvironment(&openapi.ServingEnvironment{
+			Name: "policy-test-env",
+		})
+		require.NoError(t, err)
+
+		inferenceService, err := _service.UpsertInferenceService(&openapi.InferenceService{
+			Name:                 apiutils.Of("policy-test-inference-service"),
+			ServingEnvironmentId: *servingEnv.Id,
+			RegisteredModelId:    *registeredModel.Id,
+			ModelVersionId:       modelVersion.Id,
+		})
+		require.NoError(t, err)
+
+		policy, err := _service.GetInferenceServicePolicy(*inferenceService.Id)
+		require.NoError(t, err)
+		assert.Equal(t, *modelVersion.Id, policy.ModelVersionId)
+		assert.Len(t, policy.Guardrails, 1)
+	})
+
+	t.Run("invalid policies are rejected", func(t *testing.T) {
+		_, err := _service.UpsertModelVersionPolicy(*modelVersion.Id, &api.ModelVersionPolicy{
+			Guardrails: []api.GuardrailConfig{{Name: "dup"}, {Name: "dup"}},
+		})
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		_, err = _service.UpsertModelVersionPolicy(*modelVersion.Id, &api.ModelVersionPolicy{
+			RequiredEvaluations: []api.EvaluationRequirement{{Suite: ""}},
+		})
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		_, err = _service.UpsertModelVersionPolicy(*modelVersion.Id, nil)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+	})
+
+	t.Run("unknown model version", func(t *testing.T) {
+		_, err := _service.GetModelVersionPolicy("99999")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ModelVersion properties holding the serving resource footprint
+const (
+	cpuRequestProperty          = "cpu_request"
+	memoryRequestProperty       = "memory_request"
+	acceleratorTypeProperty     = "accelerator_type"
+	acceleratorCountProperty    = "accelerator_count"
+	costPer1kInferencesProperty = "cost_per_1k_inferences"
+	costCurrencyProperty        = "cost_currency"
+)
+
+var currencyCodeRegexp = regexp.MustCompile(`^[A-Z]{3}$`)
+
+func (b *ModelRegistryService) GetModelVersionResourceFootprint(modelVersionId string) (*api.ResourceFootprint, error) {
+	glog.Infof("Getting resource footprint for ModelVersion id %s", modelVersionId)
+
+	modelVersion, err := b.getModelVersionEntity(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapResourceFootprint(modelVersionId, modelVersion.GetProperties()), nil
+}
+
+func (b *ModelRegistryService) UpdateModelVersionResourceFootprint(modelVersionId string, footprint *api.ResourceFootprint) (*api.ResourceFootprint, error) {
+	if footprint == nil {
+		return nil, fmt.Errorf("invalid resource footprint pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := validateResourceFootprint(footprint); err != nil {
+		return nil, err
+	}
+
+	modelVersion, err := b.getModelVersionEntity(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	props := modelVersion.GetProperties()
+	if footprint.CpuRequest != nil {
+		setProperty(props, models.NewStringProperty(cpuRequestProperty, *footprint.CpuRequest, false))
+	}
+	if footprint.MemoryRequest != nil {
+		setProperty(props, models.NewStringProperty(memoryRequestProperty, *footprint.MemoryRequest, false))
+	}
+	if footprint.AcceleratorType != nil {
+		setProperty(props, models.NewStringProperty(acceleratorTypeProperty, *footprint.AcceleratorType, false))
+	}
+	if footprint.AcceleratorCount != nil {
+		setProperty(props, models.NewIntProperty(acceleratorCountProperty, *footprint.AcceleratorCount, false))
+	}
+	if footprint.CostPer1kInferences != nil {
+		setProperty(props, models.NewDoubleProperty(costPer1kInferencesProperty, *footprint.CostPer1kInferences, false))
+	}
+	if footprint.CostCurrency != nil {
+		setProperty(props, models.NewStringProperty(costCurrencyProperty, *footprint.CostCurrency, false))
+	}
+
+	saved, err := b.modelVersionRepository.Save(modelVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapResourceFootprint(modelVersionId, saved.GetProperties()), nil
+}
+
+func mapResourceFootprint(modelVersionId string, props *[]models.Properties) *api.ResourceFootprint {
+	footprint := &api.ResourceFootprint{
+		ModelVersionId: modelVersionId,
+	}
+
+	if prop := findProperty(props, cpuRequestProperty); prop != nil {
+		footprint.CpuRequest = prop.StringValue
+	}
+	if prop := findProperty(props, memoryRequestProperty); prop != nil {
+		footprint.MemoryRequest = prop.StringValue
+	}
+	if prop := findProperty(props, acceleratorTypeProperty); prop != nil {
+		footprint.AcceleratorType = prop.StringValue
+	}
+	if prop := findProperty(props, acceleratorCountProperty); prop != nil {
+		footprint.AcceleratorCount = prop.IntValue
+	}
+	if prop := findProperty(props, costPer1kInferencesProperty); prop != nil {
+		footprint.CostPer1kInferences = prop.DoubleValue
+	}
+	if prop := findProperty(props, costCurrencyProperty); prop != nil {
+		footprint.CostCurrency = prop.StringValue
+	}
+
+	return footprint
+}
+
+func validateResourceFootprint(footprint *api.ResourceFootprint) error {
+	if err := validateQuantity("cpuRequest", footprint.CpuRequest); err != nil {
+		return err
+	}
+
+	if err := validateQuantity("memoryRequest", footprint.MemoryRequest); err != nil {
+		return err
+	}
+
+	if footprint.AcceleratorCount != nil && *footprint.AcceleratorCount < 0 {
+		return fmt.Errorf("invalid acceleratorCount %d, must not be negative: %w", *footprint.AcceleratorCount, api.ErrBadRequest)
+	}
+
+	if cost := footprint.CostPer1kInferences; cost != nil && (*cost < 0 || math.IsNaN(*cost) || math.IsInf(*cost, 0)) {
+		return fmt.Errorf("invalid costPer1kInferences %v, must be a non negative number: %w", *cost, api.ErrBadRequest)
+	}
+
+	if footprint.CostCurrency != nil && !currencyCodeRegexp.MatchString(*footprint.CostCurrency) {
+		return fmt.Errorf("invalid costCurrency %q, must be an ISO 4217 code: %w", *footprint.CostCurrency, api.ErrBadRequest)
+	}
+
+	return nil
+}
+
+func validateQuantity(name string, quantity *string) error {
+	if quantity == nil {
+		return nil
+	}
+
+	if _, err := resource.ParseQuantity(*quantity); err != nil {
+		return fmt.Errorf("invalid %s %q: %v: %w", name, *quantity, err, api.ErrBadRequest)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package core_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelVersionResourceFootprint(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "resources-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	modelVersion, err := _service.UpsertModelVersion(&openapi.ModelVersion{
+		Name:              "resources-test-version",
+		RegisteredModelId: *registeredModel.Id,
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	t.Run("empty footprint by default", func(t *testing.T) {
+		footprint, err := _service.GetModelVersionResourceFootprint(*modelVersion.Id)
+		require.NoError(t, err)
+
+		assert.Equal(t, *modelVersion.Id, footprint.ModelVersionId)
+		assert.Nil(t, footprint.CpuRequest)
+		assert.Nil(t, footprint.CostPer1kInferences)
+	})
+
+	t.Run("partial updates", func(t *testing.T) {
+		_, err := _service.UpdateModelVersionResourceFootprint(*modelVersion.Id, &api.ResourceFootprint{
+			CpuRequest:       apiutils.Of("2"),
+			MemoryRequest:    apiutils.Of("16Gi"),
+			AcceleratorType:  apiutils.Of("nvidia.com/gpu"),
+			AcceleratorCount: apiutils.Of(int32(1)),
+		})
+		require.NoError(t, err)
+
+		footprint, err := _service.UpdateModelVersionResourceFootprint(*modelVersion.Id, &api.ResourceFootprint{
+			CostPer1kInferences: apiutils.Of(0.42),
+			CostCurrency:        apiutils.Of("USD"),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "2", *footprint.CpuRequest)
+		assert.Equal(t, "16Gi", *footprint.MemoryRequest)
+		assert.Equal(t, "nvidia.com/gpu", *footprint.AcceleratorType)
+		assert.Equal(t, int32(1), *footprint.AcceleratorCount)
+		assert.Equal(t, 0.42, *footprint.CostPer1kInferences)
+		assert.Equal(t, "USD", *footprint.CostCurrency)
+
+		fetched, err := _service.GetModelVersionResourceFootprint(*modelVersion.Id)
+		require.NoError(t, err)
+		assert.Equal(t, footprint, fetched)
+	})
+
+	t.Run("filter model versions by cost", func(t *testing.T) {
+		versions, err := _service.GetModelVersions(api.ListOptions{
+			FilterQuery: apiutils.Of("costPer1kInferences < 1.0 AND acceleratorType = 'nvidia.com/gpu'"),
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, versions.Items, 1)
+		assert.Equal(t, *modelVersion.Id, *versions.Items[0].Id)
+
+		versions, err = _service.GetModelVersions(api.ListOptions{
+			FilterQuery: apiutils.Of("costPer1kInferences > 1.0"),
+		}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, versions.Items)
+	})
+
+	t.Run("invalid footprint", func(t *testing.T) {
+		_, err := _service.UpdateModelVersionResourceFootprint(*modelVersion.Id, &api.ResourceFootprint{
+			MemoryRequest: apiutils.Of("lots"),
+		})
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+
+		_, err = _service.UpdateModelVersionResourceFootprint(*modelVersion.Id, &api.ResourceFootprint{
+			CostPer1kInferences: apiutils.Of(-1.0),
+		})
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+
+		_, err = _service.UpdateModelVersionResourceFootprint(*modelVersion.Id, &api.ResourceFootprint{
+			CostCurrency: apiutils.Of("dollars"),
+		})
+		assert.True(t, errors.Is(err, api.ErrBadRequest))
+	})
+
+	t.Run("unknown model version", func(t *testing.T) {
+		_, err := _service.GetModelVersionResourceFootprint("9999")
+		assert.True(t, errors.Is(err, api.ErrNotFound))
+	})
+}
@@ -1,9 +1,22 @@
 package core
 
 import (
+	"context"
+	"sync"
+
+	"github.com/kubeflow/model-registry/internal/archive"
+	"github.com/kubeflow/model-registry/internal/conversion"
 	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/deployment"
 	"github.com/kubeflow/model-registry/internal/mapper"
+	"github.com/kubeflow/model-registry/internal/metadatadefaults"
+	"github.com/kubeflow/model-registry/internal/metricstore"
+	"github.com/kubeflow/model-registry/internal/naming"
+	"github.com/kubeflow/model-registry/internal/promotion"
+	"github.com/kubeflow/model-registry/internal/propertylimits"
+	"github.com/kubeflow/model-registry/internal/reachability"
 	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
 )
 
 // Compile-time assertion to ensure ModelRegistryService implements ModelRegistryApi
@@ -24,8 +37,42 @@ type ModelRegistryService struct {
 	metricRepository             models.MetricRepository
 	parameterRepository          models.ParameterRepository
 	metricHistoryRepository      models.MetricHistoryRepository
+	promotionRepository          models.PromotionRepository
+	promotionRunRepository       models.PromotionRunRepository
+	conversionJobRepository      models.ConversionJobRepository
+	deploymentRepository         models.DeploymentRepository
+	abTestRepository             models.ABTestRepository
+	lineageRepository            models.LineageRepository
+	metricsTableRepository       models.MetricsTableRepository
+	modelCardRepository          models.ModelCardRepository
+	stageTransitionRepository    models.StageTransitionRepository
+	tagRepository                models.TagRepository
+	auditEventRepository         models.AuditEventRepository
 	mapper                       mapper.EmbedMDMapper
 	typesMap                     map[string]int32
+	metricStore                  metricstore.Store
+	db                           *gorm.DB
+	rehydrator                   archive.Rehydrator
+	externalIdPolicy             api.ExternalIdPolicy
+	promotionReviewMu            *sync.Mutex
+	promotionRegistries          promotion.Remotes
+	conversionHooks              conversion.Hooks
+	conversionJobMu              *sync.Mutex
+	deploymentHook               deployment.Hook
+	deploymentMu                 *sync.Mutex
+	deploying                    map[int32]bool
+	abTestMu                     *sync.Mutex
+	artifactVerifier             *reachability.Verifier
+	metadataDefaults             *metadatadefaults.Injector
+	naming                       *naming.Enforcer
+	deployGates                  *api.DeployGates
+	propertyLimits               *propertylimits.Enforcer
+	deduplicateArtifacts         bool
+	lintRules                    api.LintRules
+	clearedFields                []string
+	caller                       string
+	// ctx is the context the repositories are bound to, nil when they aren't
+	ctx context.Context
 }
 
 func NewModelRegistryService(
@@ -43,6 +90,17 @@ func NewModelRegistryService(
 	metricRepository models.MetricRepository,
 	parameterRepository models.ParameterRepository,
 	metricHistoryRepository models.MetricHistoryRepository,
+	promotionRepository models.PromotionRepository,
+	promotionRunRepository models.PromotionRunRepository,
+	conversionJobRepository models.ConversionJobRepository,
+	deploymentRepository models.DeploymentRepository,
+	abTestRepository models.ABTestRepository,
+	lineageRepository models.LineageRepository,
+	metricsTableRepository models.MetricsTableRepository,
+	modelCardRepository models.ModelCardRepository,
+	stageTransitionRepository models.StageTransitionRepository,
+	tagRepository models.TagRepository,
+	auditEventRepository models.AuditEventRepository,
 	typesMap map[string]int32) *ModelRegistryService {
 	return &ModelRegistryService{
 		artifactRepository:           artifactRepository,
@@ -59,7 +117,92 @@ func NewModelRegistryService(
 		metricRepository:             metricRepository,
 		parameterRepository:          parameterRepository,
 		metricHistoryRepository:      metricHistoryRepository,
+		promotionRepository:          promotionRepository,
+		promotionRunRepository:       promotionRunRepository,
+		conversionJobRepository:      conversionJobRepository,
+		deploymentRepository:         deploymentRepository,
+		abTestRepository:             abTestRepository,
+		lineageRepository:            lineageRepository,
+		metricsTableRepository:       metricsTableRepository,
+		modelCardRepository:          modelCardRepository,
+		stageTransitionRepository:    stageTransitionRepository,
+		tagRepository:                tagRepository,
+		auditEventRepository:         auditEventRepository,
 		mapper:                       *mapper.NewEmbedMDMapper(typesMap),
 		typesMap:                     typesMap,
+		externalIdPolicy:             api.ExternalIdUniquePerType,
+		promotionReviewMu:            &sync.Mutex{},
+		conversionJobMu:              &sync.Mutex{},
+		deploymentMu:                 &sync.Mutex{},
+		deploying:                    map[int32]bool{},
+		abTestMu:                     &sync.Mutex{},
+		lintRules:                    api.DefaultLintRules,
+	}
+}
+
+// WithContext returns a copy of the service running its queries with ctx, the copies share their settings and locks.
+func (b *ModelRegistryService) WithContext(ctx context.Context) api.ModelRegistryApi {
+	bound := *b
+	bound.artifactRepository = withContext(ctx, b.artifactRepository)
+	bound.modelArtifactRepository = withContext(ctx, b.modelArtifactRepository)
+	bound.docArtifactRepository = withContext(ctx, b.docArtifactRepository)
+	bound.registeredModelRepository = withContext(ctx, b.registeredModelRepository)
+	bound.modelVersionRepository = withContext(ctx, b.modelVersionRepository)
+	bound.servingEnvironmentRepository = withContext(ctx, b.servingEnvironmentRepository)
+	bound.inferenceServiceRepository = withContext(ctx, b.inferenceServiceRepository)
+	bound.serveModelRepository = withContext(ctx, b.serveModelRepository)
+	bound.experimentRepository = withContext(ctx, b.experimentRepository)
+	bound.experimentRunRepository = withContext(ctx, b.experimentRunRepository)
+	bound.dataSetRepository = withContext(ctx, b.dataSetRepository)
+	bound.metricRepository = withContext(ctx, b.metricRepository)
+	bound.parameterRepository = withContext(ctx, b.parameterRepository)
+	bound.metricHistoryRepository = withContext(ctx, b.metricHistoryRepository)
+	bound.promotionRepository = withContext(ctx, b.promotionRepository)
+	bound.promotionRunRepository = withContext(ctx, b.promotionRunRepository)
+	bound.conversionJobRepository = withContext(ctx, b.conversionJobRepository)
+	bound.deploymentRepository = withContext(ctx, b.deploymentRepository)
+	bound.abTestRepository = withContext(ctx, b.abTestRepository)
+	bound.lineageRepository = withContext(ctx, b.lineageRepository)
+	bound.metricsTableRepository = withContext(ctx, b.metricsTableRepository)
+	bound.modelCardRepository = withContext(ctx, b.modelCardRepository)
+	bound.stageTransitionRepository = withContext(ctx, b.stageTransitionRepository)
+	bound.tagRepository = withContext(ctx, b.tagRepository)
+	bound.auditEventRepository = withContext(ctx, b.auditEventRepository)
+	bound.clearedFields = api.ClearedFields(ctx)
+	bound.caller = api.Caller(ctx)
+	bound.ctx = ctx
+	return &bound
+}
+
+// boundContext returns the context the repositories are bound to, the background context when they aren't.
+func (b *ModelRegistryService) boundContext() context.Context {
+	if b.ctx == nil {
+		return context.Background()
 	}
+	return b.ctx
+}
+
+// detached returns a copy of the service running its queries without the context of the request, for the
+// background work outliving it.
+func (b *ModelRegistryService) detached() *ModelRegistryService {
+	return b.WithContext(context.Background()).(*ModelRegistryService)
+}
+
+// withContext returns repository bound to ctx, or repository itself if it can't be bound, e.g. in memory.
+func withContext[T any](ctx context.Context, repository T) T {
+	if binder, ok := any(repository).(interface{ WithContext(context.Context) T }); ok {
+		return binder.WithContext(ctx)
+	}
+	return repository
+}
+
+// SetDB sets the database of the repositories, to run the snapshot exports and imports in transactions. Without it,
+// e.g. in memory, they run query by query.
+func (b *ModelRegistryService) SetDB(db *gorm.DB) {
+	b.db = db
+}
+
+// SetMetricStore offloads the experiment run metric history to a time series store instead of the property tables.
+func (b *ModelRegistryService) SetMetricStore(store metricstore.Store) {
+	b.metricStore = store
 }
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"github.com/kubeflow/model-registry/internal/naming"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetNamingPolicies normalizes and validates the names of the entities the registry creates with the policies of
+// their types.
+func (b *ModelRegistryService) SetNamingPolicies(enforcer *naming.Enforcer) {
+	b.naming = enforcer
+}
+
+// applyNamingPolicy normalizes the name of a new entity, returning an *api.NamingViolation wrapping api.ErrBadRequest
+// if it breaks the policy of its type, entities being updated keep their names.
+func (b *ModelRegistryService) applyNamingPolicy(entityType string, id *string, name *string) error {
+	if b.naming == nil || id != nil || name == nil || *name == "" {
+		return nil
+	}
+
+	normalized, err := b.naming.Apply(entityType, *name)
+	if err != nil {
+		return err
+	}
+	*name = normalized
+	return nil
+}
+
+// applyArtifactNamingPolicy applies the artifact naming policy to new model artifacts, doc artifacts and datasets,
+// the names of metrics and parameters are the keys logged by experiment runs.
+func (b *ModelRegistryService) applyArtifactNamingPolicy(artifact *openapi.Artifact) error {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return b.applyNamingPolicy(api.EntityTypeArtifact, artifact.ModelArtifact.Id, artifact.ModelArtifact.Name)
+	case artifact.DocArtifact != nil:
+		return b.applyNamingPolicy(api.EntityTypeArtifact, artifact.DocArtifact.Id, artifact.DocArtifact.Name)
+	case artifact.DataSet != nil:
+		return b.applyNamingPolicy(api.EntityTypeArtifact, artifact.DataSet.Id, artifact.DataSet.Name)
+	}
+	return nil
+}
@@ -0,0 +1,541 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/promotion"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// Promotion and PromotionRun properties
+const (
+	promotionDescriptionProperty     = "description"
+	promotionSourceProperty          = "source"
+	promotionTargetProperty          = "target"
+	promotionRequireApprovalProperty = "require_approval"
+
+	promotionRunPromotionIdProperty   = "promotion_id"
+	promotionRunStateProperty         = "state"
+	promotionRunReviewerProperty      = "reviewer"
+	promotionRunReviewCommentProperty = "review_comment"
+	promotionRunMessageProperty       = "message"
+	promotionRunModelVersionsProperty = "model_versions"
+)
+
+// registryNameRegexp restricts the registry names, used in the custom properties recording the provenance.
+var registryNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// SetPromotionRegistries configures the remote registries the promotions read from and copy to.
+func (b *ModelRegistryService) SetPromotionRegistries(remotes promotion.Remotes) {
+	b.promotionRegistries = remotes
+}
+
+func (b *ModelRegistryService) UpsertPromotion(toUpsert *api.Promotion) (*api.Promotion, error) {
+	if toUpsert == nil {
+		return nil, fmt.Errorf("invalid promotion pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	if err := b.validatePromotion(toUpsert); err != nil {
+		return nil, err
+	}
+
+	var entity models.Promotion
+	if toUpsert.Id != "" {
+		existing, err := b.getPromotionEntity(toUpsert.Id)
+		if err != nil {
+			return nil, err
+		}
+		existing.GetAttributes().Name = &toUpsert.Name
+		entity = existing
+	} else {
+		typeID, ok := b.typesMap[defaults.PromotionTypeName]
+		if !ok {
+			return nil, fmt.Errorf("promotion type not found in types map")
+		}
+		entity = &models.PromotionImpl{
+			TypeID:     apiutils.Of(typeID),
+			Attributes: &models.PromotionAttributes{Name: &toUpsert.Name},
+			Properties: &[]models.Properties{},
+		}
+	}
+
+	source, err := json.Marshal(toUpsert.Source)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode promotion source: %w", err)
+	}
+	target, err := json.Marshal(toUpsert.Target)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode promotion target: %w", err)
+	}
+
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(promotionDescriptionProperty, toUpsert.Description, false))
+	setProperty(props, models.NewStringProperty(promotionSourceProperty, string(source), false))
+	setProperty(props, models.NewStringProperty(promotionTargetProperty, string(target), false))
+	setProperty(props, models.NewBoolProperty(promotionRequireApprovalProperty, toUpsert.RequireApproval, false))
+
+	saved, err := b.promotionRepository.Save(entity)
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, fmt.Errorf("promotion with name %s already exists: %w", toUpsert.Name, api.ErrConflict)
+		}
+		return nil, err
+	}
+
+	return mapToPromotion(saved)
+}
+
+func (b *ModelRegistryService) GetPromotionById(id string) (*api.Promotion, error) {
+	entity, err := b.getPromotionEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToPromotion(entity)
+}
+
+func (b *ModelRegistryService) GetPromotions(listOptions api.ListOptions) (*api.PromotionList, error) {
+	promotions, err := b.promotionRepository.List(models.PromotionListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	promotionList := &api.PromotionList{
+		Items: []api.Promotion{},
+	}
+
+	for _, entity := range promotions.Items {
+		mapped, err := mapToPromotion(entity)
+		if err != nil {
+			return nil, err
+		}
+		promotionList.Items = append(promotionList.Items, *mapped)
+	}
+
+	promotionList.NextPageToken = promotions.NextPageToken
+	promotionList.PageSize = promotions.PageSize
+	promotionList.Size = promotions.Size
+
+	return promotionList, nil
+}
+
+// StartPromotionRun creates a run of the promotion. Runs of promotions that do not require approval are
+// executed right away in the background, the run is saved once complete: runs interrupted by a restart
+// of the server stay RUNNING and have to be started again.
+func (b *ModelRegistryService) StartPromotionRun(promotionId string) (*api.PromotionRun, error) {
+	toRun, err := b.GetPromotionById(promotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	promotionID, err := apiutils.ValidateIDAsInt32(promotionId, "promotion")
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, ok := b.typesMap[defaults.PromotionRunTypeName]
+	if !ok {
+		return nil, fmt.Errorf("promotion run type not found in types map")
+	}
+
+	state := api.PromotionRunRunning
+	if toRun.RequireApproval {
+		state = api.PromotionRunPendingApproval
+	}
+
+	name := fmt.Sprintf("%s:%s", promotionId, uuid.NewString())
+	entity := &models.PromotionRunImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.PromotionRunAttributes{Name: &name},
+		Properties: &[]models.Properties{
+			models.NewIntProperty(promotionRunPromotionIdProperty, promotionID, false),
+			models.NewStringProperty(promotionRunStateProperty, string(state), false),
+		},
+	}
+
+	saved, err := b.promotionRunRepository.Save(entity, &promotionID)
+	if err != nil {
+		return nil, err
+	}
+
+	run, err := mapToPromotionRun(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Started run %s of promotion %s in state %s", run.Id, promotionId, run.State)
+
+	if state == api.PromotionRunRunning {
+		go b.detached().executePromotionRun(toRun, run.Id, "")
+	}
+
+	return run, nil
+}
+
+// PlanPromotionRun returns the changes a run of the promotion would make if approved now, without creating the run.
+func (b *ModelRegistryService) PlanPromotionRun(promotionId string) (*api.Plan, error) {
+	toRun, err := b.GetPromotionById(promotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := b.promotionRegistry(toRun.Source.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.promotionRegistry(toRun.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := promotion.FindModelVersions(source, toRun.Source)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the model versions to promote in registry %s: %w", toRun.Source.Registry.Name, err)
+	}
+
+	promoter := promotion.NewPromoter(source, target, promotion.Run{
+		PromotionId: toRun.Id,
+		Source:      toRun.Source.Registry.Name,
+		Target:      toRun.Target.Name,
+	})
+
+	plan := api.NewPlan()
+	for _, version := range versions {
+		if err := promoter.Plan(version, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+func (b *ModelRegistryService) GetPromotionRunById(id string) (*api.PromotionRun, error) {
+	entity, err := b.getPromotionRunEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToPromotionRun(entity)
+}
+
+func (b *ModelRegistryService) GetPromotionRuns(listOptions api.ListOptions, promotionId string) (*api.PromotionRunList, error) {
+	promotionID, err := apiutils.ValidateIDAsInt32(promotionId, "promotion")
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := b.promotionRunRepository.List(models.PromotionRunListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		PromotionID: &promotionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runList := &api.PromotionRunList{
+		Items: []api.PromotionRun{},
+	}
+
+	for _, entity := range runs.Items {
+		mapped, err := mapToPromotionRun(entity)
+		if err != nil {
+			return nil, err
+		}
+		runList.Items = append(runList.Items, *mapped)
+	}
+
+	runList.NextPageToken = runs.NextPageToken
+	runList.PageSize = runs.PageSize
+	runList.Size = runs.Size
+
+	return runList, nil
+}
+
+func (b *ModelRegistryService) ApprovePromotionRun(id string, review *api.PromotionReview) (*api.PromotionRun, error) {
+	run, err := b.reviewPromotionRun(id, review, api.PromotionRunRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	toRun, err := b.GetPromotionById(run.PromotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	go b.detached().executePromotionRun(toRun, run.Id, run.Reviewer)
+
+	return run, nil
+}
+
+func (b *ModelRegistryService) RejectPromotionRun(id string, review *api.PromotionReview) (*api.PromotionRun, error) {
+	return b.reviewPromotionRun(id, review, api.PromotionRunRejected)
+}
+
+// reviewPromotionRun moves a run pending approval to the given state, recording the review on behalf of the caller.
+func (b *ModelRegistryService) reviewPromotionRun(id string, review *api.PromotionReview, state api.PromotionRunState) (*api.PromotionRun, error) {
+	if review == nil {
+		return nil, fmt.Errorf("invalid promotion review pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+	if b.caller == "" {
+		return nil, fmt.Errorf("promotion run %s can only be reviewed by an authenticated caller: %w", id, api.ErrBadRequest)
+	}
+
+	// Serialize the reviews so that a run is approved at most once by this server
+	b.promotionReviewMu.Lock()
+	defer b.promotionReviewMu.Unlock()
+
+	entity, err := b.getPromotionRunEntity(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if current := stringPropertyValue(entity.GetProperties(), promotionRunStateProperty); current != string(api.PromotionRunPendingApproval) {
+		return nil, fmt.Errorf("promotion run %s is %s, only runs pending approval can be reviewed: %w", id, current, api.ErrBadRequest)
+	}
+
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(promotionRunStateProperty, string(state), false))
+	setProperty(props, models.NewStringProperty(promotionRunReviewerProperty, b.caller, false))
+	setProperty(props, models.NewStringProperty(promotionRunReviewCommentProperty, review.Comment, false))
+
+	saved, err := b.promotionRunRepository.Save(entity, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Promotion run %s reviewed by %s: %s", id, b.caller, state)
+
+	return mapToPromotionRun(saved)
+}
+
+// executePromotionRun promotes the model versions matching the promotion source and saves the outcome in the run.
+func (b *ModelRegistryService) executePromotionRun(toRun *api.Promotion, runId string, reviewer string) {
+	results, err := b.promote(toRun, runId, reviewer)
+
+	state := api.PromotionRunSucceeded
+	message := ""
+	if err != nil {
+		state = api.PromotionRunFailed
+		message = err.Error()
+	} else {
+		failed := 0
+		for _, result := range results {
+			if result.Result == api.PromotionResultFailed {
+				failed++
+			}
+		}
+		if failed > 0 {
+			state = api.PromotionRunFailed
+			message = fmt.Sprintf("%d of %d model versions could not be promoted", failed, len(results))
+		}
+	}
+
+	if err := b.completePromotionRun(runId, state, message, results); err != nil {
+		glog.Errorf("Unable to save the outcome of promotion run %s: %v", runId, err)
+		return
+	}
+
+	glog.Infof("Promotion run %s of promotion %s %s: %d model versions", runId, toRun.Id, state, len(results))
+}
+
+func (b *ModelRegistryService) promote(toRun *api.Promotion, runId string, reviewer string) ([]api.PromotedModelVersion, error) {
+	source, err := b.promotionRegistry(toRun.Source.Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := b.promotionRegistry(toRun.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := promotion.FindModelVersions(source, toRun.Source)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the model versions to promote in registry %s: %w", toRun.Source.Registry.Name, err)
+	}
+
+	promoter := promotion.NewPromoter(source, target, promotion.Run{
+		PromotionId: toRun.Id,
+		RunId:       runId,
+		Source:      toRun.Source.Registry.Name,
+		Target:      toRun.Target.Name,
+		Reviewer:    reviewer,
+	})
+
+	results := make([]api.PromotedModelVersion, 0, len(versions))
+	for _, version := range versions {
+		results = append(results, promoter.Promote(version))
+	}
+
+	return results, nil
+}
+
+// promotionRegistry returns the remote registry configured with the name of registry, this registry otherwise.
+func (b *ModelRegistryService) promotionRegistry(registry api.PromotionRegistry) (promotion.Registry, error) {
+	if remote, ok := b.promotionRegistries[registry.Name]; ok {
+		return remote, nil
+	}
+
+	return b, nil
+}
+
+func (b *ModelRegistryService) completePromotionRun(runId string, state api.PromotionRunState, message string, results []api.PromotedModelVersion) error {
+	entity, err := b.getPromotionRunEntity(runId)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("unable to encode promotion run results: %w", err)
+	}
+
+	props := entity.GetProperties()
+	setProperty(props, models.NewStringProperty(promotionRunStateProperty, string(state), false))
+	setProperty(props, models.NewStringProperty(promotionRunMessageProperty, message, false))
+	setProperty(props, models.NewStringProperty(promotionRunModelVersionsProperty, string(encoded), false))
+
+	_, err = b.promotionRunRepository.Save(entity, nil)
+	return err
+}
+
+// getPromotionEntity loads the data layer Promotion, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getPromotionEntity(id string) (models.Promotion, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "promotion")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.promotionRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no promotion found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return entity, nil
+}
+
+// getPromotionRunEntity loads the data layer PromotionRun, mapping lookup failures to api errors.
+func (b *ModelRegistryService) getPromotionRunEntity(id string) (models.PromotionRun, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "promotion run")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := b.promotionRunRepository.GetByID(convertedId)
+	if err != nil {
+		return nil, fmt.Errorf("no promotion run found for id %s: %w", id, api.ErrNotFound)
+	}
+
+	return entity, nil
+}
+
+func (b *ModelRegistryService) validatePromotion(p *api.Promotion) error {
+	if p.Name == "" {
+		return fmt.Errorf("missing promotion name: %w", api.ErrBadRequest)
+	}
+
+	for _, registry := range []api.PromotionRegistry{p.Source.Registry, p.Target} {
+		if !registryNameRegexp.MatchString(registry.Name) {
+			return fmt.Errorf("invalid registry name %q: must consist of lower case alphanumeric characters or '-': %w", registry.Name, api.ErrBadRequest)
+		}
+	}
+
+	if p.Source.Registry.Name == p.Target.Name {
+		return fmt.Errorf("source and target registries must have different names: %w", api.ErrBadRequest)
+	}
+
+	if _, ok := b.promotionRegistries[p.Target.Name]; !ok {
+		return fmt.Errorf("unknown target registry %s, the target must be one of the remote registries configured on the server: %w", p.Target.Name, api.ErrBadRequest)
+	}
+
+	return nil
+}
+
+func mapToPromotion(entity models.Promotion) (*api.Promotion, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.Promotion{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		Name:                     *attrs.Name,
+		Description:              stringPropertyValue(props, promotionDescriptionProperty),
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	if source := stringPropertyValue(props, promotionSourceProperty); source != "" {
+		if err := json.Unmarshal([]byte(source), &mapped.Source); err != nil {
+			return nil, fmt.Errorf("unable to decode source of promotion %s: %w", mapped.Id, err)
+		}
+	}
+	if target := stringPropertyValue(props, promotionTargetProperty); target != "" {
+		if err := json.Unmarshal([]byte(target), &mapped.Target); err != nil {
+			return nil, fmt.Errorf("unable to decode target of promotion %s: %w", mapped.Id, err)
+		}
+	}
+	if prop := findProperty(props, promotionRequireApprovalProperty); prop != nil && prop.BoolValue != nil {
+		mapped.RequireApproval = *prop.BoolValue
+	}
+
+	return mapped, nil
+}
+
+func mapToPromotionRun(entity models.PromotionRun) (*api.PromotionRun, error) {
+	attrs := entity.GetAttributes()
+	props := entity.GetProperties()
+
+	mapped := &api.PromotionRun{
+		Id:                       strconv.FormatInt(int64(*entity.GetID()), 10),
+		State:                    api.PromotionRunState(stringPropertyValue(props, promotionRunStateProperty)),
+		Reviewer:                 stringPropertyValue(props, promotionRunReviewerProperty),
+		ReviewComment:            stringPropertyValue(props, promotionRunReviewCommentProperty),
+		Message:                  stringPropertyValue(props, promotionRunMessageProperty),
+		ModelVersions:            []api.PromotedModelVersion{},
+		CreateTimeSinceEpoch:     strconv.FormatInt(*attrs.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*attrs.LastUpdateTimeSinceEpoch, 10),
+	}
+
+	if prop := findProperty(props, promotionRunPromotionIdProperty); prop != nil && prop.IntValue != nil {
+		mapped.PromotionId = strconv.FormatInt(int64(*prop.IntValue), 10)
+	}
+
+	if results := stringPropertyValue(props, promotionRunModelVersionsProperty); results != "" {
+		if err := json.Unmarshal([]byte(results), &mapped.ModelVersions); err != nil {
+			return nil, fmt.Errorf("unable to decode results of promotion run %s: %w", mapped.Id, err)
+		}
+	}
+
+	return mapped, nil
+}
+
+// stringPropertyValue returns the value of the (non custom) string property, empty if not set.
+func stringPropertyValue(props *[]models.Properties, name string) string {
+	prop := findProperty(props, name)
+	if prop == nil || prop.StringValue == nil {
+		return ""
+	}
+	return *prop.StringValue
+}
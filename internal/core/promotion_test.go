@@ -0,0 +1,132 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/promotion"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotion(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+	_service.SetPromotionRegistries(promotion.Remotes{"prod": nil})
+	reviewers := func(caller string) api.ModelRegistryApi {
+		return _service.WithContext(api.WithCaller(context.Background(), caller))
+	}
+
+	_, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "promotion-test-registered-model",
+	})
+	require.NoError(t, err)
+
+	newPromotion := func(name string, requireApproval bool) *api.Promotion {
+		return &api.Promotion{
+			Name: name,
+			Source: api.PromotionSource{
+				Registry:            api.PromotionRegistry{Name: "dev"},
+				RegisteredModelName: "promotion-test-registered-model",
+				FilterQuery:         "state='LIVE'",
+			},
+			Target:          api.PromotionRegistry{Name: "prod"},
+			RequireApproval: requireApproval,
+		}
+	}
+
+	t.Run("create, get and update promotion", func(t *testing.T) {
+		created, err := _service.UpsertPromotion(newPromotion("dev-to-prod", true))
+		require.NoError(t, err)
+		require.NotEmpty(t, created.Id)
+		assert.Equal(t, "dev-to-prod", created.Name)
+		assert.Equal(t, "promotion-test-registered-model", created.Source.RegisteredModelName)
+		assert.Equal(t, "prod", created.Target.Name)
+		assert.True(t, created.RequireApproval)
+
+		fetched, err := _service.GetPromotionById(created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, created, fetched)
+
+		update := newPromotion("dev-to-prod", false)
+		update.Id = created.Id
+		update.Description = "no approval"
+		updated, err := _service.UpsertPromotion(update)
+		require.NoError(t, err)
+		assert.Equal(t, created.Id, updated.Id)
+		assert.Equal(t, "no approval", updated.Description)
+		assert.False(t, updated.RequireApproval)
+
+		list, err := _service.GetPromotions(api.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), list.Size)
+	})
+
+	t.Run("invalid promotions", func(t *testing.T) {
+		sameNames := newPromotion("same-names", false)
+		sameNames.Target.Name = "dev"
+		_, err := _service.UpsertPromotion(sameNames)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		unknownTarget := newPromotion("unknown-target", false)
+		unknownTarget.Target.Name = "staging"
+		_, err = _service.UpsertPromotion(unknownTarget)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+		assert.ErrorContains(t, err, "unknown target registry staging")
+
+		invalidName := newPromotion("invalid-name", false)
+		invalidName.Target.Name = "Prod Registry"
+		_, err = _service.UpsertPromotion(invalidName)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		_, err = _service.UpsertPromotion(newPromotion("dev-to-prod", false))
+		assert.ErrorIs(t, err, api.ErrConflict)
+	})
+
+	t.Run("runs wait for approval", func(t *testing.T) {
+		gated, err := _service.UpsertPromotion(newPromotion("gated", true))
+		require.NoError(t, err)
+
+		run, err := _service.StartPromotionRun(gated.Id)
+		require.NoError(t, err)
+		assert.Equal(t, api.PromotionRunPendingApproval, run.State)
+		assert.Equal(t, gated.Id, run.PromotionId)
+
+		_, err = _service.ApprovePromotionRun(run.Id, &api.PromotionReview{})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "an authenticated reviewer is required")
+
+		approved, err := reviewers("alice").ApprovePromotionRun(run.Id, &api.PromotionReview{Comment: "lgtm"})
+		require.NoError(t, err)
+		assert.Equal(t, api.PromotionRunRunning, approved.State)
+		assert.Equal(t, "alice", approved.Reviewer)
+
+		_, err = reviewers("bob").RejectPromotionRun(run.Id, &api.PromotionReview{})
+		assert.ErrorIs(t, err, api.ErrBadRequest, "runs are reviewed once")
+
+		// No model version matches, the run completes without reaching the target registry
+		require.Eventually(t, func() bool {
+			completed, err := _service.GetPromotionRunById(run.Id)
+			return err == nil && completed.State == api.PromotionRunSucceeded
+		}, 5*time.Second, 50*time.Millisecond)
+
+		rejected, err := _service.StartPromotionRun(gated.Id)
+		require.NoError(t, err)
+		rejected, err = reviewers("bob").RejectPromotionRun(rejected.Id, &api.PromotionReview{Comment: "not yet"})
+		require.NoError(t, err)
+		assert.Equal(t, api.PromotionRunRejected, rejected.State)
+		assert.Equal(t, "bob", rejected.Reviewer)
+		assert.Equal(t, "not yet", rejected.ReviewComment)
+
+		runs, err := _service.GetPromotionRuns(api.ListOptions{}, gated.Id)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), runs.Size)
+	})
+
+	t.Run("promotion not found", func(t *testing.T) {
+		_, err := _service.StartPromotionRun("999999")
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+}
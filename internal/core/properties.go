@@ -0,0 +1,30 @@
+package core
+
+import "github.com/kubeflow/model-registry/internal/db/models"
+
+// findProperty returns the (non custom) property with the given name, or nil if it is not set.
+func findProperty(props *[]models.Properties, name string) *models.Properties {
+	if props == nil {
+		return nil
+	}
+
+	for i := range *props {
+		if (*props)[i].Name == name && !(*props)[i].IsCustomProperty {
+			return &(*props)[i]
+		}
+	}
+
+	return nil
+}
+
+// setProperty replaces the property with the same name and kind in props, or appends it.
+func setProperty(props *[]models.Properties, prop models.Properties) {
+	for i := range *props {
+		if (*props)[i].Name == prop.Name && (*props)[i].IsCustomProperty == prop.IsCustomProperty {
+			(*props)[i] = prop
+			return
+		}
+	}
+
+	*props = append(*props, prop)
+}
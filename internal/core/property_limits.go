@@ -0,0 +1,64 @@
+package core
+
+import (
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/propertylimits"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// SetPropertyLimits caps the number of custom properties of the entities the registry writes and the size of their
+// values with the limits of their types.
+func (b *ModelRegistryService) SetPropertyLimits(enforcer *propertylimits.Enforcer) {
+	b.propertyLimits = enforcer
+}
+
+// applyPropertyLimits rejects or truncates the custom properties written to an entity breaking the limits of its
+// type, the rejections wrap api.ErrBadRequest.
+func (b *ModelRegistryService) applyPropertyLimits(entityType string, customProperties *map[string]openapi.MetadataValue) error {
+	truncated, err := b.propertyLimits.Apply(entityType, customProperties)
+	if err != nil {
+		return err
+	}
+	if len(truncated) > 0 {
+		glog.Warningf("Truncated custom properties %v of %s breaking the custom property limits", truncated, entityType)
+	}
+	return nil
+}
+
+// applyArtifactPropertyLimits applies the artifact custom property limits to model artifacts, doc artifacts and
+// datasets.
+func (b *ModelRegistryService) applyArtifactPropertyLimits(artifact *openapi.Artifact) error {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return b.applyPropertyLimits(api.EntityTypeArtifact, &artifact.ModelArtifact.CustomProperties)
+	case artifact.DocArtifact != nil:
+		return b.applyPropertyLimits(api.EntityTypeArtifact, &artifact.DocArtifact.CustomProperties)
+	case artifact.DataSet != nil:
+		return b.applyPropertyLimits(api.EntityTypeArtifact, &artifact.DataSet.CustomProperties)
+	}
+	return nil
+}
+
+// propertyLimitsEntityType returns the entity type of the custom property limits of an entity of the api.
+func propertyLimitsEntityType(entity any) string {
+	switch entity.(type) {
+	case *openapi.RegisteredModel:
+		return api.EntityTypeRegisteredModel
+	case *openapi.ModelVersion:
+		return api.EntityTypeModelVersion
+	case *openapi.ModelArtifact, *openapi.DocArtifact, *openapi.DataSet:
+		return api.EntityTypeArtifact
+	case *openapi.ServingEnvironment:
+		return api.EntityTypeServingEnvironment
+	case *openapi.InferenceService:
+		return api.EntityTypeInferenceService
+	case *openapi.ServeModel:
+		return api.EntityTypeServeModel
+	case *openapi.Experiment:
+		return api.EntityTypeExperiment
+	case *openapi.ExperimentRun:
+		return api.EntityTypeExperimentRun
+	}
+	return ""
+}
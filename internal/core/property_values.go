@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+func (b *ModelRegistryService) GetCustomPropertyValues(entityType string, key string, ids []string) (*api.PropertyValueList, error) {
+	if key == "" {
+		return nil, fmt.Errorf("property key is required: %w", api.ErrBadRequest)
+	}
+
+	var reader models.CustomPropertyReader
+	archivable := false
+	switch entityType {
+	case api.PropertyEntityTypeRegisteredModel:
+		reader = b.registeredModelRepository
+	case api.PropertyEntityTypeModelVersion:
+		reader, archivable = b.modelVersionRepository, true
+	case api.PropertyEntityTypeModelArtifact:
+		reader = b.modelArtifactRepository
+	case api.PropertyEntityTypeExperiment:
+		reader = b.experimentRepository
+	case api.PropertyEntityTypeExperimentRun:
+		reader, archivable = b.experimentRunRepository, true
+	default:
+		return nil, fmt.Errorf("invalid entity type %q, must be one of %s, %s, %s, %s or %s: %w", entityType,
+			api.PropertyEntityTypeRegisteredModel, api.PropertyEntityTypeModelVersion, api.PropertyEntityTypeModelArtifact,
+			api.PropertyEntityTypeExperiment, api.PropertyEntityTypeExperimentRun, api.ErrBadRequest)
+	}
+
+	convertedIds, err := validateIds(ids, "entity", api.MaxPropertyValuesIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// the custom properties of archived entities are only in the archive until rehydrated
+	if archivable {
+		for _, id := range convertedIds {
+			if _, err := b.rehydrate(id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	properties, err := reader.GetCustomPropertyByIDs(convertedIds, key)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &api.PropertyValueList{
+		EntityType: entityType,
+		Key:        key,
+		Items:      []api.PropertyValue{},
+	}
+
+	for _, id := range convertedIds {
+		property, ok := properties[id]
+		if !ok {
+			continue
+		}
+
+		values, err := converter.MapEmbedMDCustomProperties([]models.Properties{property})
+		if err != nil {
+			return nil, fmt.Errorf("failed to map custom property %s of entity %d: %w", key, id, err)
+		}
+
+		list.Items = append(list.Items, api.PropertyValue{
+			Id:    strconv.FormatInt(int64(id), 10),
+			Value: values[key],
+		})
+	}
+
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
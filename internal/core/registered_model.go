@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/converter"
@@ -17,6 +18,23 @@ func (b *ModelRegistryService) UpsertRegisteredModel(registeredModel *openapi.Re
 		return nil, fmt.Errorf("invalid registered model pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(registeredModel.ExternalId, api.EntityTypeRegisteredModel, registeredModel.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeRegisteredModel, registeredModel.Id, &registeredModel.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("registered model", registeredModel.Id, &registeredModel.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeRegisteredModel, &registeredModel.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	var removed []models.Properties
 	if registeredModel.Id != nil {
 		existing, err := b.GetRegisteredModelById(*registeredModel.Id)
 		if err != nil {
@@ -34,6 +52,11 @@ func (b *ModelRegistryService) UpsertRegisteredModel(registeredModel *openapi.Re
 			withNotEditable.CustomProperties = existing.CustomProperties
 		}
 
+		withNotEditable, removed, err = withoutClearedFields(b.clearedFields, existing, withNotEditable, b.mapper.MapFromRegisteredModel)
+		if err != nil {
+			return nil, err
+		}
+
 		registeredModel = &withNotEditable
 	}
 
@@ -41,6 +64,7 @@ func (b *ModelRegistryService) UpsertRegisteredModel(registeredModel *openapi.Re
 	if err != nil {
 		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
 	}
+	removeProperties(model.GetProperties(), removed)
 
 	savedModel, err := b.registeredModelRepository.Save(model)
 	if err != nil {
@@ -68,6 +92,23 @@ func (b *ModelRegistryService) GetRegisteredModelById(id string) (*openapi.Regis
 	return b.mapper.MapToRegisteredModel(model)
 }
 
+func (b *ModelRegistryService) GetRegisteredModelAsOf(id string, asOf time.Time) (*openapi.RegisteredModel, error) {
+	convertedId, err := apiutils.ValidateIDAsInt32(id, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := b.registeredModelRepository.GetByIDAsOf(convertedId, asOf.UnixMilli())
+	if err != nil {
+		if errors.Is(err, api.ErrBadRequest) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no registered model found for id %s at %s: %w", id, asOf.Format(time.RFC3339), api.ErrNotFound)
+	}
+
+	return b.mapper.MapToRegisteredModel(model)
+}
+
 func (b *ModelRegistryService) GetRegisteredModelByInferenceService(inferenceServiceId string) (*openapi.RegisteredModel, error) {
 	convertedId, err := apiutils.ValidateIDAsInt32(inferenceServiceId, "inference service")
 	if err != nil {
@@ -136,15 +177,12 @@ func (b *ModelRegistryService) GetRegisteredModelByParams(name *string, external
 }
 
 func (b *ModelRegistryService) GetRegisteredModels(listOptions api.ListOptions) (*openapi.RegisteredModelList, error) {
-	modelsList, err := b.registeredModelRepository.List(models.RegisteredModelListOptions{
-		Pagination: models.Pagination{
-			PageSize:      listOptions.PageSize,
-			OrderBy:       listOptions.OrderBy,
-			SortOrder:     listOptions.SortOrder,
-			NextPageToken: listOptions.NextPageToken,
-			FilterQuery:   listOptions.FilterQuery,
-		},
-	})
+	repoListOptions, err := registeredModelListOptions(listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	modelsList, err := b.registeredModelRepository.List(repoListOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -167,3 +205,40 @@ func (b *ModelRegistryService) GetRegisteredModels(listOptions api.ListOptions)
 
 	return registeredModelList, nil
 }
+
+// StreamRegisteredModels calls yield with the registered models of the page of listOptions as they are read, and
+// returns the token of the next page.
+func (b *ModelRegistryService) StreamRegisteredModels(listOptions api.ListOptions, yield func(*openapi.RegisteredModel) error) (string, error) {
+	repoListOptions, err := registeredModelListOptions(listOptions)
+	if err != nil {
+		return "", err
+	}
+
+	return b.registeredModelRepository.ListStream(repoListOptions, func(model models.RegisteredModel) error {
+		registeredModel, err := b.mapper.MapToRegisteredModel(model)
+		if err != nil {
+			return fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+		return yield(registeredModel)
+	})
+}
+
+func registeredModelListOptions(listOptions api.ListOptions) (models.RegisteredModelListOptions, error) {
+	state, err := listState(listOptions)
+	if err != nil {
+		return models.RegisteredModelListOptions{}, err
+	}
+
+	return models.RegisteredModelListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
+		},
+		State: state,
+	}, nil
+}
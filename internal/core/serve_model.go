@@ -18,6 +18,22 @@ func (b *ModelRegistryService) UpsertServeModel(serveModel *openapi.ServeModel,
 		return nil, fmt.Errorf("invalid serve model pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(serveModel.ExternalId, api.EntityTypeServeModel, serveModel.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeServeModel, serveModel.Id, serveModel.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("serve model", serveModel.Id, &serveModel.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeServeModel, &serveModel.CustomProperties); err != nil {
+		return nil, err
+	}
+
 	if serveModel.Id != nil {
 		existing, err := b.GetServeModelById(*serveModel.Id)
 		if err != nil {
@@ -114,6 +130,8 @@ func (b *ModelRegistryService) GetServeModels(listOptions api.ListOptions, infer
 			OrderBy:       listOptions.OrderBy,
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
 		},
 		InferenceServiceID: inferenceServiceID,
 	})
@@ -17,6 +17,22 @@ func (b *ModelRegistryService) UpsertServingEnvironment(servingEnvironment *open
 		return nil, fmt.Errorf("invalid serving environment pointer, cannot be nil: %w", api.ErrBadRequest)
 	}
 
+	if err := b.checkExternalIdAvailable(servingEnvironment.ExternalId, api.EntityTypeServingEnvironment, servingEnvironment.Id); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyNamingPolicy(api.EntityTypeServingEnvironment, servingEnvironment.Id, &servingEnvironment.Name); err != nil {
+		return nil, err
+	}
+
+	if err := b.injectMetadataDefaults("serving environment", servingEnvironment.Id, &servingEnvironment.CustomProperties); err != nil {
+		return nil, err
+	}
+
+	if err := b.applyPropertyLimits(api.EntityTypeServingEnvironment, &servingEnvironment.CustomProperties); err != nil {
+		return nil, err
+	}
+
 	if servingEnvironment.Id != nil {
 		existing, err := b.GetServingEnvironmentById(*servingEnvironment.Id)
 		if err != nil {
@@ -107,6 +123,9 @@ func (b *ModelRegistryService) GetServingEnvironments(listOptions api.ListOption
 			OrderBy:       listOptions.OrderBy,
 			SortOrder:     listOptions.SortOrder,
 			NextPageToken: listOptions.NextPageToken,
+			FilterQuery:   listOptions.FilterQuery,
+			Query:         listOptions.Query,
+			Tags:          listOptions.Tags,
 		},
 	})
 	if err != nil {
@@ -0,0 +1,400 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"gorm.io/gorm"
+)
+
+// snapshotPageSize is the number of model versions or artifacts read at once by the exports.
+var snapshotPageSize int32 = 100
+
+// ExportSnapshot reads the registered models in a single snapshot of the database, so that a concurrent write can't
+// leave a model version or an artifact referencing an entity read before it.
+func (b *ModelRegistryService) ExportSnapshot(request api.SnapshotExportRequest) ([]api.SnapshotRecord, error) {
+	if len(request.RegisteredModelIds) == 0 {
+		return nil, fmt.Errorf("at least one registered model id is required: %w", api.ErrBadRequest)
+	}
+
+	if b.db == nil {
+		return b.exportSnapshot(request)
+	}
+	var records []api.SnapshotRecord
+	ctx := b.boundContext()
+	err := db.Snapshot(b.db.WithContext(ctx), func(tx *gorm.DB) error {
+		var err error
+		records, err = b.WithContext(dbutil.WithTransaction(ctx, tx)).(*ModelRegistryService).exportSnapshot(request)
+		return err
+	})
+	return records, err
+}
+
+func (b *ModelRegistryService) exportSnapshot(request api.SnapshotExportRequest) ([]api.SnapshotRecord, error) {
+	records := []api.SnapshotRecord{{
+		Kind:                 api.SnapshotRecordHeader,
+		Version:              api.SnapshotVersion,
+		ExportTimeSinceEpoch: strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}}
+
+	exported := map[string]bool{}
+	for _, registeredModelId := range request.RegisteredModelIds {
+		if exported[registeredModelId] {
+			continue
+		}
+		exported[registeredModelId] = true
+
+		registeredModel, err := b.GetRegisteredModelById(registeredModelId)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, api.SnapshotRecord{Kind: api.SnapshotRecordRegisteredModel, RegisteredModel: registeredModel})
+
+		err = listAllPages(func(listOptions api.ListOptions) (string, error) {
+			page, err := b.GetModelVersions(listOptions, &registeredModelId)
+			if err != nil {
+				return "", err
+			}
+			for i := range page.Items {
+				modelVersion := &page.Items[i]
+				records = append(records, api.SnapshotRecord{Kind: api.SnapshotRecordModelVersion, ModelVersion: modelVersion})
+
+				err := listAllPages(func(listOptions api.ListOptions) (string, error) {
+					artifacts, err := b.GetArtifacts("", listOptions, modelVersion.Id)
+					if err != nil {
+						return "", err
+					}
+					for j := range artifacts.Items {
+						records = append(records, api.SnapshotRecord{
+							Kind:           api.SnapshotRecordArtifact,
+							ModelVersionId: *modelVersion.Id,
+							Artifact:       &artifacts.Items[j],
+						})
+					}
+					return artifacts.NextPageToken, nil
+				})
+				if err != nil {
+					return "", err
+				}
+			}
+			return page.NextPageToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// ImportSnapshot validates and imports the records in a single transaction, so that a failed import leaves none of
+// its entities behind.
+func (b *ModelRegistryService) ImportSnapshot(records []api.SnapshotRecord) (*api.SnapshotImport, error) {
+	if b.db == nil {
+		return b.importSnapshot(records)
+	}
+	var result *api.SnapshotImport
+	ctx := b.boundContext()
+	err := b.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		result, err = b.WithContext(dbutil.WithTransaction(ctx, tx)).(*ModelRegistryService).importSnapshot(records)
+		return err
+	})
+	return result, err
+}
+
+func (b *ModelRegistryService) importSnapshot(records []api.SnapshotRecord) (*api.SnapshotImport, error) {
+	if err := b.validateSnapshot(records, nil); err != nil {
+		return nil, err
+	}
+
+	result := &api.SnapshotImport{
+		RegisteredModels: []api.SnapshotIdMapping{},
+		ModelVersions:    []api.SnapshotIdMapping{},
+		Artifacts:        []api.SnapshotIdMapping{},
+	}
+	registeredModelIds := map[string]string{}
+	modelVersionIds := map[string]string{}
+	artifactIds := map[string]string{}
+
+	for i, record := range records[1:] {
+		switch record.Kind {
+		case api.SnapshotRecordRegisteredModel:
+			registeredModel := *record.RegisteredModel
+			sourceId := registeredModel.GetId()
+			registeredModel.Id, registeredModel.CreateTimeSinceEpoch, registeredModel.LastUpdateTimeSinceEpoch = nil, nil, nil
+
+			imported, err := b.UpsertRegisteredModel(&registeredModel)
+			if err != nil {
+				return nil, fmt.Errorf("error importing registered model %s of line %d: %w", registeredModel.Name, i+2, err)
+			}
+			registeredModelIds[sourceId] = *imported.Id
+			result.RegisteredModels = append(result.RegisteredModels, api.SnapshotIdMapping{SourceId: sourceId, Id: *imported.Id})
+
+		case api.SnapshotRecordModelVersion:
+			modelVersion := *record.ModelVersion
+			sourceId := modelVersion.GetId()
+			registeredModelId := registeredModelIds[modelVersion.RegisteredModelId]
+			modelVersion.Id, modelVersion.CreateTimeSinceEpoch, modelVersion.LastUpdateTimeSinceEpoch = nil, nil, nil
+			modelVersion.RegisteredModelId = registeredModelId
+
+			imported, err := b.UpsertModelVersion(&modelVersion, &registeredModelId)
+			if err != nil {
+				return nil, fmt.Errorf("error importing model version %s of line %d: %w", modelVersion.Name, i+2, err)
+			}
+			modelVersionIds[sourceId] = *imported.Id
+			result.ModelVersions = append(result.ModelVersions, api.SnapshotIdMapping{SourceId: sourceId, Id: *imported.Id})
+
+		case api.SnapshotRecordArtifact:
+			artifact := clearSnapshotArtifact(*record.Artifact)
+			sourceId := apiutils.ZeroIfNil(artifactId(record.Artifact))
+
+			// an artifact of several model versions is imported once, then attributed to the other model versions
+			importedId, shared := artifactIds[sourceId]
+			if shared {
+				setArtifactId(&artifact, importedId)
+			}
+			imported, err := b.UpsertModelVersionArtifact(&artifact, modelVersionIds[record.ModelVersionId])
+			if err != nil {
+				return nil, fmt.Errorf("error importing artifact %s of line %d: %w", sourceId, i+2, err)
+			}
+			if !shared {
+				importedId = apiutils.ZeroIfNil(artifactId(imported))
+				artifactIds[sourceId] = importedId
+				result.Artifacts = append(result.Artifacts, api.SnapshotIdMapping{SourceId: sourceId, Id: importedId})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// PlanSnapshotImport returns the changes the import of the records would make without importing them: the
+// registered models, model versions and artifacts created, the artifacts shared by several model versions skipped
+// once imported, and the conflicts with the existing registered models and external ids.
+func (b *ModelRegistryService) PlanSnapshotImport(records []api.SnapshotRecord) (*api.Plan, error) {
+	plan := api.NewPlan()
+	if err := b.validateSnapshot(records, plan); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// validateSnapshot checks the records of a snapshot before any is imported: the header, the kinds of the records,
+// the references to the entities of the previous records, and the names of the registered models and the external
+// ids of the registered models and model versions, which must not exist yet. The records are numbered by their line
+// in the archive. With a plan, the existing names and external ids are added to it as conflicts instead of failing,
+// along with the entities the import would create and skip.
+func (b *ModelRegistryService) validateSnapshot(records []api.SnapshotRecord, plan *api.Plan) error {
+	if len(records) == 0 || records[0].Kind != api.SnapshotRecordHeader {
+		return fmt.Errorf("a snapshot starts with its %s record: %w", api.SnapshotRecordHeader, api.ErrBadRequest)
+	}
+	if records[0].Version != api.SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, only version %d is imported: %w", records[0].Version, api.SnapshotVersion, api.ErrBadRequest)
+	}
+
+	// the names of the registered models and model versions by id, for the parents of the plan items
+	registeredModelNames := map[string]string{}
+	modelVersionNames := map[string]string{}
+	artifactVersions := map[string]string{}
+	for i, record := range records[1:] {
+		line := i + 2
+		switch record.Kind {
+		case api.SnapshotRecordRegisteredModel:
+			if record.RegisteredModel == nil || record.RegisteredModel.Id == nil {
+				return fmt.Errorf("line %d is missing its registered model or its id: %w", line, api.ErrBadRequest)
+			}
+			if _, ok := registeredModelNames[*record.RegisteredModel.Id]; ok {
+				return fmt.Errorf("duplicate registered model %s in line %d: %w", *record.RegisteredModel.Id, line, api.ErrBadRequest)
+			}
+			registeredModelNames[*record.RegisteredModel.Id] = record.RegisteredModel.Name
+			item := api.PlanItem{
+				Action:     api.PlanActionCreate,
+				EntityType: api.EntityTypeRegisteredModel,
+				Name:       record.RegisteredModel.Name,
+				SourceId:   *record.RegisteredModel.Id,
+			}
+
+			existing, err := b.GetRegisteredModelByParams(&record.RegisteredModel.Name, nil)
+			if err == nil {
+				err = fmt.Errorf("registered model %s of line %d already exists: %w", record.RegisteredModel.Name, line, api.ErrConflict)
+				item.Id = apiutils.ZeroIfNil(existing.Id)
+			} else if errors.Is(err, api.ErrNotFound) {
+				err = b.checkExternalIdAvailable(record.RegisteredModel.ExternalId, api.EntityTypeRegisteredModel, nil)
+				if err != nil {
+					err = fmt.Errorf("registered model %s of line %d: %w", record.RegisteredModel.Name, line, err)
+				}
+			}
+			if err := addSnapshotPlanItem(plan, item, err); err != nil {
+				return err
+			}
+
+		case api.SnapshotRecordModelVersion:
+			if record.ModelVersion == nil || record.ModelVersion.Id == nil {
+				return fmt.Errorf("line %d is missing its model version or its id: %w", line, api.ErrBadRequest)
+			}
+			registeredModelName, ok := registeredModelNames[record.ModelVersion.RegisteredModelId]
+			if !ok {
+				return fmt.Errorf("model version %s of line %d follows no registered model %s: %w", *record.ModelVersion.Id, line, record.ModelVersion.RegisteredModelId, api.ErrBadRequest)
+			}
+			if _, ok := modelVersionNames[*record.ModelVersion.Id]; ok {
+				return fmt.Errorf("duplicate model version %s in line %d: %w", *record.ModelVersion.Id, line, api.ErrBadRequest)
+			}
+			modelVersionNames[*record.ModelVersion.Id] = record.ModelVersion.Name
+
+			err := b.checkExternalIdAvailable(record.ModelVersion.ExternalId, api.EntityTypeModelVersion, nil)
+			if err != nil {
+				err = fmt.Errorf("model version %s of line %d: %w", *record.ModelVersion.Id, line, err)
+			}
+			if err := addSnapshotPlanItem(plan, api.PlanItem{
+				Action:     api.PlanActionCreate,
+				EntityType: api.EntityTypeModelVersion,
+				Name:       record.ModelVersion.Name,
+				ParentName: registeredModelName,
+				SourceId:   *record.ModelVersion.Id,
+			}, err); err != nil {
+				return err
+			}
+
+		case api.SnapshotRecordArtifact:
+			if record.Artifact == nil || artifactId(record.Artifact) == nil {
+				return fmt.Errorf("line %d is missing its artifact or its id: %w", line, api.ErrBadRequest)
+			}
+			modelVersionName, ok := modelVersionNames[record.ModelVersionId]
+			if !ok {
+				return fmt.Errorf("artifact %s of line %d follows no model version %s: %w", *artifactId(record.Artifact), line, record.ModelVersionId, api.ErrBadRequest)
+			}
+
+			sourceId := *artifactId(record.Artifact)
+			item := api.PlanItem{
+				Action:     api.PlanActionCreate,
+				EntityType: api.EntityTypeArtifact,
+				Name:       artifactName(record.Artifact),
+				ParentName: modelVersionName,
+				SourceId:   sourceId,
+			}
+			if importedWith, shared := artifactVersions[sourceId]; shared {
+				item.Action = api.PlanActionSkip
+				item.Message = fmt.Sprintf("imported with model version %s, then attributed to model version %s", importedWith, modelVersionName)
+			} else {
+				artifactVersions[sourceId] = modelVersionName
+			}
+			if plan != nil {
+				plan.Add(item)
+			}
+
+		default:
+			return fmt.Errorf("unknown kind %q of line %d: %w", record.Kind, line, api.ErrBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// addSnapshotPlanItem adds item to plan, as a conflict if err is one. Without a plan, it returns err.
+func addSnapshotPlanItem(plan *api.Plan, item api.PlanItem, err error) error {
+	if plan == nil || (err != nil && !errors.Is(err, api.ErrConflict)) {
+		return err
+	}
+	if err != nil {
+		item.Action = api.PlanActionConflict
+		item.Message = err.Error()
+	}
+	plan.Add(item)
+	return nil
+}
+
+// clearSnapshotArtifact returns a copy of an exported artifact without the fields of the registry it was exported
+// from: its id, times and the experiment run which logged it, which is not exported.
+func clearSnapshotArtifact(artifact openapi.Artifact) openapi.Artifact {
+	switch {
+	case artifact.ModelArtifact != nil:
+		a := *artifact.ModelArtifact
+		a.Id, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch, a.ExperimentId, a.ExperimentRunId = nil, nil, nil, nil, nil
+		return openapi.Artifact{ModelArtifact: &a}
+	case artifact.DocArtifact != nil:
+		a := *artifact.DocArtifact
+		a.Id, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch, a.ExperimentId, a.ExperimentRunId = nil, nil, nil, nil, nil
+		return openapi.Artifact{DocArtifact: &a}
+	case artifact.DataSet != nil:
+		a := *artifact.DataSet
+		a.Id, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch, a.ExperimentId, a.ExperimentRunId = nil, nil, nil, nil, nil
+		return openapi.Artifact{DataSet: &a}
+	case artifact.Metric != nil:
+		a := *artifact.Metric
+		a.Id, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch, a.ExperimentId, a.ExperimentRunId = nil, nil, nil, nil, nil
+		return openapi.Artifact{Metric: &a}
+	case artifact.Parameter != nil:
+		a := *artifact.Parameter
+		a.Id, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch, a.ExperimentId, a.ExperimentRunId = nil, nil, nil, nil, nil
+		return openapi.Artifact{Parameter: &a}
+	}
+	return artifact
+}
+
+// artifactId returns the id of whichever artifact type is set.
+func artifactId(artifact *openapi.Artifact) *string {
+	switch {
+	case artifact.ModelArtifact != nil:
+		return artifact.ModelArtifact.Id
+	case artifact.DocArtifact != nil:
+		return artifact.DocArtifact.Id
+	case artifact.DataSet != nil:
+		return artifact.DataSet.Id
+	case artifact.Metric != nil:
+		return artifact.Metric.Id
+	case artifact.Parameter != nil:
+		return artifact.Parameter.Id
+	}
+	return nil
+}
+
+// artifactName returns the name of whichever artifact type is set.
+func artifactName(artifact *openapi.Artifact) string {
+	if named, ok := artifact.GetActualInstance().(interface{ GetName() string }); ok {
+		return named.GetName()
+	}
+	return ""
+}
+
+// setArtifactId sets the id of whichever artifact type is set.
+func setArtifactId(artifact *openapi.Artifact, id string) {
+	switch {
+	case artifact.ModelArtifact != nil:
+		artifact.ModelArtifact.Id = &id
+	case artifact.DocArtifact != nil:
+		artifact.DocArtifact.Id = &id
+	case artifact.DataSet != nil:
+		artifact.DataSet.Id = &id
+	case artifact.Metric != nil:
+		artifact.Metric.Id = &id
+	case artifact.Parameter != nil:
+		artifact.Parameter.Id = &id
+	}
+}
+
+// listAllPages calls list with the list options of each page, ordered by id, until it returns no next page token.
+func listAllPages(list func(listOptions api.ListOptions) (string, error)) error {
+	listOptions := api.ListOptions{
+		PageSize:  &snapshotPageSize,
+		OrderBy:   apiutils.Of(string(openapi.ORDERBYFIELD_ID)),
+		SortOrder: apiutils.Of(string(openapi.SORTORDER_ASC)),
+	}
+	for {
+		nextPageToken, err := list(listOptions)
+		if err != nil {
+			return err
+		}
+		if nextPageToken == "" {
+			return nil
+		}
+		listOptions.NextPageToken = &nextPageToken
+	}
+}
@@ -0,0 +1,56 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "snapshotXmodel"})
+	require.NoError(t, err)
+	version, err := _service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, registeredModel.Id)
+	require.NoError(t, err)
+	_, err = _service.UpsertModelVersionArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{
+		Name:       openapi.PtrString("weights"),
+		Uri:        openapi.PtrString("s3://bucket/weights"),
+		ExternalId: openapi.PtrString("snapshot-weights"),
+	}}, *version.Id)
+	require.NoError(t, err)
+
+	records, err := _service.ExportSnapshot(api.SnapshotExportRequest{RegisteredModelIds: []string{*registeredModel.Id}})
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	// the name is not a pattern matching the exported registered model
+	records[1].RegisteredModel.Name = "snapshot_model"
+
+	t.Run("a failed import creates none of its entities", func(t *testing.T) {
+		// the external id of the artifact is taken by the exported artifact, its creation fails last
+		_, err := _service.ImportSnapshot(records)
+		require.Error(t, err)
+
+		_, err = _service.GetRegisteredModelByParams(openapi.PtrString("snapshot_model"), nil)
+		assert.ErrorIs(t, err, api.ErrNotFound)
+	})
+
+	t.Run("an import creates all its entities", func(t *testing.T) {
+		records[3].Artifact.ModelArtifact.ExternalId = nil
+
+		imported, err := _service.ImportSnapshot(records)
+		require.NoError(t, err)
+		require.Len(t, imported.RegisteredModels, 1)
+		require.Len(t, imported.ModelVersions, 1)
+		require.Len(t, imported.Artifacts, 1)
+
+		model, err := _service.GetRegisteredModelByParams(openapi.PtrString("snapshot_model"), nil)
+		require.NoError(t, err)
+		assert.Equal(t, imported.RegisteredModels[0].Id, *model.Id)
+	})
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+var archivePageSize = int32(100)
+
+// ArchiveRegisteredModel archives the live versions of the registered model, then the registered model: if archiving
+// a version fails the registered model stays live, so that archiving it again archives the remaining versions.
+func (b *ModelRegistryService) ArchiveRegisteredModel(id string) (*openapi.RegisteredModel, error) {
+	registeredModel, err := b.GetRegisteredModelById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := apiutils.ValidateIDAsInt32(id, "registered model")
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := b.listLiveModelVersions(parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if _, err := b.ArchiveModelVersion(strconv.FormatInt(int64(*version.GetID()), 10)); err != nil {
+			return nil, fmt.Errorf("unable to archive the versions of registered model %s: %w", id, err)
+		}
+	}
+
+	if registeredModel.State != nil && *registeredModel.State == openapi.REGISTEREDMODELSTATE_ARCHIVED {
+		return registeredModel, nil
+	}
+
+	registeredModel.State = openapi.REGISTEREDMODELSTATE_ARCHIVED.Ptr()
+	archived, err := b.UpsertRegisteredModel(registeredModel)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Archived registered model %s and its %d live versions", id, len(versions))
+
+	return archived, nil
+}
+
+// ArchiveModelVersion archives the model version, archived versions are returned as is.
+func (b *ModelRegistryService) ArchiveModelVersion(id string) (*openapi.ModelVersion, error) {
+	modelVersion, err := b.GetModelVersionById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if modelVersion.State != nil && *modelVersion.State == openapi.MODELVERSIONSTATE_ARCHIVED {
+		return modelVersion, nil
+	}
+
+	modelVersion.State = openapi.MODELVERSIONSTATE_ARCHIVED.Ptr()
+
+	return b.UpsertModelVersion(modelVersion, nil)
+}
+
+// listLiveModelVersions returns the versions of the registered model which are not archived.
+func (b *ModelRegistryService) listLiveModelVersions(registeredModelId int32) ([]models.ModelVersion, error) {
+	modelVersions := []models.ModelVersion{}
+
+	listOptions := models.ModelVersionListOptions{
+		Pagination:       models.Pagination{PageSize: &archivePageSize},
+		ParentResourceID: &registeredModelId,
+		State:            apiutils.Of(api.ListStateLive),
+	}
+	for {
+		page, err := b.modelVersionRepository.List(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		modelVersions = append(modelVersions, page.Items...)
+
+		if page.NextPageToken == "" {
+			return modelVersions, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// listState returns the state the list options restrict the entities to, nil for all the states.
+func listState(listOptions api.ListOptions) (*string, error) {
+	if listOptions.State == nil || *listOptions.State == api.ListStateAll {
+		return nil, nil
+	}
+
+	switch *listOptions.State {
+	case api.ListStateLive, api.ListStateArchived:
+		return listOptions.State, nil
+	}
+
+	return nil, fmt.Errorf("invalid state %q, must be %s, %s or %s: %w", *listOptions.State, api.ListStateLive, api.ListStateArchived, api.ListStateAll, api.ErrBadRequest)
+}
@@ -0,0 +1,229 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// StageTransition properties
+const (
+	stageTransitionModelVersionIdProperty = "model_version_id"
+	stageTransitionFromStageProperty      = "from_stage"
+	stageTransitionToStageProperty        = "to_stage"
+	stageTransitionCommentProperty        = "comment"
+)
+
+// TransitionModelVersionStage moves the model version to the stage of the transition if its current stage allows
+// it, see api.StageTransitions, and records the transition. The stages are matched ignoring case, and a stage
+// outside of the lifecycle, e.g. set before the lifecycle existed, is taken as None. The stage is only moved if the
+// model version wasn't updated since it was validated, on any replica, the concurrent transitions are rejected with
+// a conflict.
+func (b *ModelRegistryService) TransitionModelVersionStage(modelVersionId string, transition *api.StageTransition) (*api.StageTransition, error) {
+	if transition == nil {
+		return nil, fmt.Errorf("invalid stage transition pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+
+	toStage, ok := parseModelVersionStage(string(transition.ToStage))
+	if !ok {
+		return nil, fmt.Errorf("invalid stage %q, must be one of %s, %s, %s or %s: %w",
+			transition.ToStage, api.StageNone, api.StageStaging, api.StageProduction, api.StageArchived, api.ErrBadRequest)
+	}
+
+	// The version of the model version read is recorded, for the update of its stage to only apply to it
+	ctx, versions := models.WithEntityVersions(b.boundContext())
+	bound := b.WithContext(ctx).(*ModelRegistryService)
+
+	modelVersion, err := bound.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	if modelVersion.State != nil && *modelVersion.State == openapi.MODELVERSIONSTATE_ARCHIVED && toStage != api.StageArchived {
+		return nil, fmt.Errorf("model version %s is archived and can only be moved to stage %s: %w", modelVersionId, api.StageArchived, api.ErrConflict)
+	}
+
+	fromStage, _ := parseModelVersionStage(modelVersionStage(modelVersion))
+	if fromStage == toStage {
+		return nil, fmt.Errorf("model version %s is already in stage %s: %w", modelVersionId, toStage, api.ErrConflict)
+	}
+	if !slices.Contains(api.StageTransitions[fromStage], toStage) {
+		return nil, fmt.Errorf("model version %s can not move from stage %s to stage %s: %w", modelVersionId, fromStage, toStage, api.ErrConflict)
+	}
+
+	if modelVersionID, err := apiutils.ValidateIDAsInt32(modelVersionId, "model version"); err == nil {
+		if version, ok := versions.Get(models.VersionedContext, modelVersionID); ok {
+			bound = b.WithContext(models.WithIfMatch(ctx, models.EntityVersion{Table: models.VersionedContext, ID: modelVersionID, Version: version})).(*ModelRegistryService)
+		}
+	}
+
+	recorded, err := bound.moveModelVersionStage(modelVersion, fromStage, toStage, transition.Comment)
+	if errors.Is(err, api.ErrPreconditionFailed) {
+		return nil, fmt.Errorf("model version %s was updated during its transition to stage %s, retry it: %w", modelVersionId, toStage, api.ErrConflict)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if toStage == api.StageProduction && transition.ArchiveExisting {
+		if err := b.archiveProductionVersions(modelVersion); err != nil {
+			return nil, err
+		}
+	}
+
+	return recorded, nil
+}
+
+func (b *ModelRegistryService) GetStageTransitions(listOptions api.ListOptions, modelVersionId *string) (*api.StageTransitionList, error) {
+	var modelVersionID *int32
+
+	if modelVersionId != nil {
+		var err error
+		modelVersionID, err = apiutils.ValidateIDAsInt32Ptr(modelVersionId, "model version")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transitions, err := b.stageTransitionRepository.List(models.StageTransitionListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+		ModelVersionID: modelVersionID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transitionList := &api.StageTransitionList{
+		Items: []api.StageTransition{},
+	}
+
+	for _, entity := range transitions.Items {
+		transitionList.Items = append(transitionList.Items, mapToStageTransition(entity))
+	}
+
+	transitionList.NextPageToken = transitions.NextPageToken
+	transitionList.PageSize = transitions.PageSize
+	transitionList.Size = transitions.Size
+
+	return transitionList, nil
+}
+
+// moveModelVersionStage sets the stage custom property of the model version, removed for None, and records the
+// transition.
+func (b *ModelRegistryService) moveModelVersionStage(modelVersion *openapi.ModelVersion, fromStage, toStage api.ModelVersionStage, comment string) (*api.StageTransition, error) {
+	modelVersionID, err := apiutils.ValidateIDAsInt32(*modelVersion.Id, "model version")
+	if err != nil {
+		return nil, err
+	}
+
+	typeID, ok := b.typesMap[defaults.StageTransitionTypeName]
+	if !ok {
+		return nil, fmt.Errorf("stage transition type not found in types map")
+	}
+
+	stage := string(toStage)
+	if toStage == api.StageNone {
+		stage = ""
+	}
+	if _, err := b.setModelVersionStage(*modelVersion.Id, stage); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%s:%s", *modelVersion.Id, uuid.NewString())
+	entity := &models.StageTransitionImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.StageTransitionAttributes{Name: &name},
+		Properties: &[]models.Properties{
+			models.NewIntProperty(stageTransitionModelVersionIdProperty, modelVersionID, false),
+			models.NewStringProperty(stageTransitionFromStageProperty, string(fromStage), false),
+			models.NewStringProperty(stageTransitionToStageProperty, string(toStage), false),
+			models.NewStringProperty(stageTransitionCommentProperty, comment, false),
+		},
+	}
+
+	saved, err := b.stageTransitionRepository.Save(entity, &modelVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.Infof("Moved model version %s from stage %s to stage %s", *modelVersion.Id, fromStage, toStage)
+
+	recorded := mapToStageTransition(saved)
+	return &recorded, nil
+}
+
+// archiveProductionVersions moves the other Production versions of the registered model of modelVersion to Archived.
+func (b *ModelRegistryService) archiveProductionVersions(modelVersion *openapi.ModelVersion) error {
+	listOptions := api.ListOptions{}
+	for {
+		versions, err := b.GetModelVersions(listOptions, &modelVersion.RegisteredModelId)
+		if err != nil {
+			return err
+		}
+
+		for i := range versions.Items {
+			other := &versions.Items[i]
+			if *other.Id == *modelVersion.Id {
+				continue
+			}
+			if stage, _ := parseModelVersionStage(modelVersionStage(other)); stage != api.StageProduction {
+				continue
+			}
+			comment := fmt.Sprintf("Replaced in %s by model version %s", api.StageProduction, *modelVersion.Id)
+			if _, err := b.moveModelVersionStage(other, api.StageProduction, api.StageArchived, comment); err != nil {
+				return fmt.Errorf("unable to archive model version %s: %w", *other.Id, err)
+			}
+		}
+
+		if versions.NextPageToken == "" {
+			return nil
+		}
+		listOptions.NextPageToken = apiutils.Of(versions.NextPageToken)
+	}
+}
+
+// parseModelVersionStage returns the stage of the lifecycle matching stage ignoring case, None for an empty stage,
+// and reports whether stage is one of the lifecycle.
+func parseModelVersionStage(stage string) (api.ModelVersionStage, bool) {
+	if stage == "" {
+		return api.StageNone, true
+	}
+	for known := range api.StageTransitions {
+		if strings.EqualFold(stage, string(known)) {
+			return known, true
+		}
+	}
+	return api.StageNone, false
+}
+
+func mapToStageTransition(entity models.StageTransition) api.StageTransition {
+	props := entity.GetProperties()
+
+	mapped := api.StageTransition{
+		Id:                   strconv.FormatInt(int64(*entity.GetID()), 10),
+		FromStage:            api.ModelVersionStage(stringPropertyValue(props, stageTransitionFromStageProperty)),
+		ToStage:              api.ModelVersionStage(stringPropertyValue(props, stageTransitionToStageProperty)),
+		Comment:              stringPropertyValue(props, stageTransitionCommentProperty),
+		CreateTimeSinceEpoch: strconv.FormatInt(*entity.GetAttributes().CreateTimeSinceEpoch, 10),
+	}
+	if prop := findProperty(props, stageTransitionModelVersionIdProperty); prop != nil && prop.IntValue != nil {
+		mapped.ModelVersionId = strconv.FormatInt(int64(*prop.IntValue), 10)
+	}
+
+	return mapped
+}
@@ -0,0 +1,48 @@
+package core_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionModelVersionStageConcurrently(t *testing.T) {
+	_service, cleanup := SetupModelRegistryService(t)
+	defer cleanup()
+
+	registeredModel, err := _service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "stage-transition-test-registered-model"})
+	require.NoError(t, err)
+	version, err := _service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, registeredModel.Id)
+	require.NoError(t, err)
+
+	// only one of the transitions validated against the None stage moves the model version
+	const transitions = 5
+	errs := make([]error, transitions)
+	var wg sync.WaitGroup
+	for i := range transitions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = _service.TransitionModelVersionStage(*version.Id, &api.StageTransition{ToStage: api.StageStaging})
+		}()
+	}
+	wg.Wait()
+
+	moved := 0
+	for _, err := range errs {
+		if err == nil {
+			moved++
+			continue
+		}
+		assert.ErrorIs(t, err, api.ErrConflict)
+	}
+	assert.Equal(t, 1, moved)
+
+	transitionList, err := _service.GetStageTransitions(api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	assert.Len(t, transitionList.Items, 1)
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// tagNameRegexp matches the names of the tags, the comma separating the tags of the tags list option is excluded.
+var tagNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][-_.:A-Za-z0-9]*$`)
+
+func (b *ModelRegistryService) UpsertTag(tag *api.Tag) (*api.Tag, error) {
+	if tag == nil {
+		return nil, fmt.Errorf("invalid tag pointer, cannot be nil: %w", api.ErrBadRequest)
+	}
+	if err := validateTagName(tag.Name); err != nil {
+		return nil, err
+	}
+
+	saved, err := b.tagRepository.Save(models.Tag{
+		Name:        tag.Name,
+		Color:       tag.Color,
+		Description: tag.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToTag(saved), nil
+}
+
+func (b *ModelRegistryService) GetTagByName(name string) (*api.Tag, error) {
+	tag, err := b.tagRepository.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToTag(tag), nil
+}
+
+func (b *ModelRegistryService) GetTags(listOptions api.ListOptions) (*api.TagList, error) {
+	tags, err := b.tagRepository.List(models.TagListOptions{
+		Pagination: models.Pagination{
+			PageSize:      listOptions.PageSize,
+			OrderBy:       listOptions.OrderBy,
+			SortOrder:     listOptions.SortOrder,
+			NextPageToken: listOptions.NextPageToken,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tagList := &api.TagList{
+		Items: []api.Tag{},
+	}
+
+	for _, tag := range tags.Items {
+		tagList.Items = append(tagList.Items, *mapToTag(tag))
+	}
+
+	tagList.NextPageToken = tags.NextPageToken
+	tagList.PageSize = tags.PageSize
+	tagList.Size = tags.Size
+
+	return tagList, nil
+}
+
+func (b *ModelRegistryService) DeleteTag(name string) error {
+	return b.tagRepository.Delete(name)
+}
+
+func (b *ModelRegistryService) GetEntityTags(entityType string, id string) (*api.EntityTags, error) {
+	contextID, err := b.taggedEntityID(entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := b.tagRepository.GetContextTags([]int32{contextID})
+	if err != nil {
+		return nil, err
+	}
+
+	names := tags[contextID]
+	if names == nil {
+		names = []string{}
+	}
+
+	return &api.EntityTags{Tags: names}, nil
+}
+
+func (b *ModelRegistryService) SetEntityTags(entityType string, id string, tags []string) (*api.EntityTags, error) {
+	for _, tag := range tags {
+		if err := validateTagName(tag); err != nil {
+			return nil, err
+		}
+	}
+
+	contextID, err := b.taggedEntityID(entityType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := b.tagRepository.SetContextTags(contextID, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.EntityTags{Tags: names}, nil
+}
+
+// taggedEntityID returns the context id of the entity of entityType with the id, checking that it exists.
+func (b *ModelRegistryService) taggedEntityID(entityType string, id string) (int32, error) {
+	var err error
+	switch entityType {
+	case api.TagEntityTypeRegisteredModel:
+		_, err = b.GetRegisteredModelById(id)
+	case api.TagEntityTypeModelVersion:
+		_, err = b.GetModelVersionById(id)
+	case api.TagEntityTypeExperiment:
+		_, err = b.GetExperimentById(id)
+	case api.TagEntityTypeExperimentRun:
+		_, err = b.GetExperimentRunById(id)
+	case api.TagEntityTypeInferenceService:
+		_, err = b.GetInferenceServiceById(id)
+	case api.TagEntityTypeServingEnvironment:
+		_, err = b.GetServingEnvironmentById(id)
+	default:
+		return 0, fmt.Errorf("invalid entity type %q, must be one of %s, %s, %s, %s, %s or %s: %w", entityType,
+			api.TagEntityTypeRegisteredModel, api.TagEntityTypeModelVersion, api.TagEntityTypeExperiment,
+			api.TagEntityTypeExperimentRun, api.TagEntityTypeInferenceService, api.TagEntityTypeServingEnvironment,
+			api.ErrBadRequest)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return apiutils.ValidateIDAsInt32(id, entityType)
+}
+
+func validateTagName(name string) error {
+	if len(name) > api.MaxTagNameLength || !tagNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid tag name %q, must be at most %d letters, digits and the characters '-', '_', '.' or ':', starting with a letter or digit: %w",
+			name, api.MaxTagNameLength, api.ErrBadRequest)
+	}
+	return nil
+}
+
+func mapToTag(tag models.Tag) *api.Tag {
+	return &api.Tag{
+		Id:                       strconv.FormatInt(int64(*tag.ID), 10),
+		Name:                     tag.Name,
+		Color:                    tag.Color,
+		Description:              tag.Description,
+		Count:                    tag.Count,
+		CreateTimeSinceEpoch:     strconv.FormatInt(*tag.CreateTimeSinceEpoch, 10),
+		LastUpdateTimeSinceEpoch: strconv.FormatInt(*tag.LastUpdateTimeSinceEpoch, 10),
+	}
+}
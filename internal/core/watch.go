@@ -0,0 +1,205 @@
+package core
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// watchedEntity is implemented by all the watched entities, the changes are computed from their update times.
+type watchedEntity interface {
+	GetId() string
+	GetCreateTimeSinceEpoch() string
+	GetLastUpdateTimeSinceEpoch() string
+}
+
+// timedChange is a change with its resource version as a number, for sorting.
+type timedChange struct {
+	api.Change
+	time int64
+}
+
+// GetChanges returns the changes of the entities of entityTypes, or of all the WatchEntityTypes, updated after the
+// resourceVersion and at most at until, both in milliseconds since epoch. Changes are returned in update time order and
+// at most MaxChanges at once, the resource version of the list is the one to get the next changes from.
+func (b *ModelRegistryService) GetChanges(entityTypes []string, resourceVersion int64, until int64) (*api.ChangeList, error) {
+	if len(entityTypes) == 0 {
+		entityTypes = api.WatchEntityTypes
+	}
+	for _, entityType := range entityTypes {
+		if !slices.Contains(api.WatchEntityTypes, entityType) {
+			return nil, fmt.Errorf("invalid entity type %q, must be one of %v: %w", entityType, api.WatchEntityTypes, api.ErrBadRequest)
+		}
+	}
+
+	list := &api.ChangeList{
+		Items:           []api.Change{},
+		ResourceVersion: strconv.FormatInt(max(resourceVersion, until), 10),
+	}
+	if until <= resourceVersion {
+		return list, nil
+	}
+
+	// each type returns one more change than the maximum to tell if changes are left out
+	listOptions := api.ListOptions{
+		PageSize:    apiutils.Of(int32(api.MaxChanges + 1)),
+		OrderBy:     apiutils.Of("LAST_UPDATE_TIME"),
+		SortOrder:   apiutils.Of("ASC"),
+		FilterQuery: apiutils.Of(fmt.Sprintf("lastUpdateTimeSinceEpoch > %d AND lastUpdateTimeSinceEpoch <= %d", resourceVersion, until)),
+	}
+
+	changes := []timedChange{}
+	for _, entityType := range entityTypes {
+		objects, err := b.listChanged(entityType, listOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range objects {
+			change, err := newChange(entityType, object)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	// the changes of each type are already in order
+	slices.SortStableFunc(changes, func(a, b timedChange) int {
+		return cmp.Compare(a.time, b.time)
+	})
+
+	if len(changes) > api.MaxChanges {
+		// the resource version cannot point in between changes at the same time, so the ones at the time of the first
+		// change left out are returned next
+		end := api.MaxChanges
+		for end > 0 && changes[end-1].time == changes[api.MaxChanges].time {
+			end--
+		}
+		if end == 0 {
+			glog.Warningf("More than %d changes at %d, the ones left out are skipped", api.MaxChanges, changes[0].time)
+			end = api.MaxChanges
+		}
+		changes = changes[:end]
+		list.ResourceVersion = changes[end-1].ResourceVersion
+	}
+
+	for _, change := range changes {
+		list.Items = append(list.Items, change.Change)
+	}
+	list.Size = int32(len(list.Items))
+
+	return list, nil
+}
+
+// listChanged returns the entities of entityType matching listOptions.
+func (b *ModelRegistryService) listChanged(entityType string, listOptions api.ListOptions) ([]any, error) {
+	var objects []any
+	switch entityType {
+	case api.EntityTypeRegisteredModel:
+		list, err := b.GetRegisteredModels(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeModelVersion:
+		list, err := b.GetModelVersions(listOptions, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeArtifact:
+		list, err := b.GetArtifacts("", listOptions, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeServingEnvironment:
+		list, err := b.GetServingEnvironments(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeInferenceService:
+		list, err := b.GetInferenceServices(listOptions, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeServeModel:
+		list, err := b.GetServeModels(listOptions, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeExperiment:
+		list, err := b.GetExperiments(listOptions)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	case api.EntityTypeExperimentRun:
+		list, err := b.GetExperimentRuns(listOptions, nil)
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+	return objects, nil
+}
+
+func newChange(entityType string, object any) (timedChange, error) {
+	instance := object
+	if artifact, ok := object.(*openapi.Artifact); ok {
+		instance = artifact.GetActualInstance()
+	}
+	entity, ok := instance.(watchedEntity)
+	if !ok {
+		return timedChange{}, fmt.Errorf("unexpected %s %T", entityType, instance)
+	}
+
+	lastUpdate := entity.GetLastUpdateTimeSinceEpoch()
+	time, err := strconv.ParseInt(lastUpdate, 10, 64)
+	if err != nil {
+		return timedChange{}, fmt.Errorf("invalid last update time %q of %s %s: %w", lastUpdate, entityType, entity.GetId(), err)
+	}
+
+	changeType := api.ChangeModified
+	if entity.GetCreateTimeSinceEpoch() == lastUpdate {
+		changeType = api.ChangeAdded
+	}
+
+	return timedChange{
+		Change: api.Change{
+			Type:            changeType,
+			EntityType:      entityType,
+			Id:              entity.GetId(),
+			Ref:             apiutils.Of(api.NewEntityRef(entityType, entity.GetId())),
+			ResourceVersion: lastUpdate,
+			Object:          object,
+		},
+		time: time,
+	}, nil
+}
@@ -2,7 +2,9 @@ package mysql
 
 import (
 	"embed"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
@@ -17,6 +19,49 @@ const (
 	MigrationDir = "migrations"
 )
 
+// RequiredIndexes lists, by table, the indexes created by the migrations that queries rely on.
+var RequiredIndexes = map[string][]string{
+	"Artifact":          {"idx_artifact_create_time_since_epoch", "idx_artifact_last_update_time_since_epoch", "idx_artifact_external_id", "idx_artifact_name_fulltext", "idx_artifact_namespace"},
+	"ArtifactDigest":    {"idx_artifact_digest_artifact_id"},
+	"ArtifactProperty":  {"idx_artifact_property_int", "idx_artifact_property_double", "idx_artifact_property_string_value_fulltext"},
+	"Context":           {"idx_context_create_time_since_epoch", "idx_context_last_update_time_since_epoch", "idx_context_external_id", "idx_context_name_fulltext", "idx_context_namespace"},
+	"ContextRevision":   {"idx_context_revision_last_update_time_since_epoch"},
+	"ContextProperty":   {"idx_context_property_int", "idx_context_property_double", "idx_context_property_string_value_fulltext"},
+	"ContextTag":        {"idx_context_tag_tag_id"},
+	"Event":             {"idx_event_execution_id"},
+	"Execution":         {"idx_execution_create_time_since_epoch", "idx_execution_last_update_time_since_epoch", "idx_execution_external_id", "idx_execution_name_fulltext"},
+	"ExecutionProperty": {"idx_execution_property_int", "idx_execution_property_double", "idx_execution_property_string_value_fulltext"},
+	"ParentContext":     {"idx_parentcontext_parent_context_id"},
+	"Tag":               {"idx_tag_name"},
+	"Type":              {"idx_type_name"},
+	"audit_events":      {"idx_audit_events_create_time_since_epoch", "idx_audit_events_entity", "idx_audit_events_actor"},
+}
+
+// LatestVersion returns the version of the last embedded migration, which is the expected schema version.
+func LatestVersion() (uint, error) {
+	source, err := iofs.New(migrations, MigrationDir)
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
 type MySQLMigrator struct {
 	migrator *migrate.Migrate
 }
@@ -0,0 +1,8 @@
+-- Remove full-text indexes added in 000022_add_full_text_indexes.up.sql
+
+DROP INDEX idx_artifact_name_fulltext ON `Artifact`;
+DROP INDEX idx_artifact_property_string_value_fulltext ON `ArtifactProperty`;
+DROP INDEX idx_context_name_fulltext ON `Context`;
+DROP INDEX idx_context_property_string_value_fulltext ON `ContextProperty`;
+DROP INDEX idx_execution_name_fulltext ON `Execution`;
+DROP INDEX idx_execution_property_string_value_fulltext ON `ExecutionProperty`;
@@ -0,0 +1,9 @@
+-- Add full-text indexes for the free-text search of the list endpoints
+-- The search matches the entity name and the string value of the description and custom properties
+
+CREATE FULLTEXT INDEX idx_artifact_name_fulltext ON `Artifact` (`name`);
+CREATE FULLTEXT INDEX idx_artifact_property_string_value_fulltext ON `ArtifactProperty` (`string_value`);
+CREATE FULLTEXT INDEX idx_context_name_fulltext ON `Context` (`name`);
+CREATE FULLTEXT INDEX idx_context_property_string_value_fulltext ON `ContextProperty` (`string_value`);
+CREATE FULLTEXT INDEX idx_execution_name_fulltext ON `Execution` (`name`);
+CREATE FULLTEXT INDEX idx_execution_property_string_value_fulltext ON `ExecutionProperty` (`string_value`);
@@ -0,0 +1,4 @@
+-- Remove the versions added in 000023_add_entity_versions.up.sql
+
+ALTER TABLE `Context` DROP COLUMN `version`;
+ALTER TABLE `Artifact` DROP COLUMN `version`;
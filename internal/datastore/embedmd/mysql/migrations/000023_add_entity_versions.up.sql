@@ -0,0 +1,5 @@
+-- Add the versions of the contexts and artifacts for the optimistic concurrency control of their updates
+-- Each update increments the version, the updates with an If-Match header only apply to the version it names.
+
+ALTER TABLE `Context` ADD COLUMN `version` INT NOT NULL DEFAULT 1;
+ALTER TABLE `Artifact` ADD COLUMN `version` INT NOT NULL DEFAULT 1;
@@ -0,0 +1,15 @@
+-- Create the ContextRevision table, the state of the contexts recording revisions after each of their saves
+-- The properties are the ContextProperty rows of the revision as a JSON array, for the reads as of a past time.
+
+CREATE TABLE IF NOT EXISTS `ContextRevision` (
+  `context_id` int NOT NULL,
+  `version` int NOT NULL,
+  `type_id` int NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `external_id` varchar(255) DEFAULT NULL,
+  `create_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  `last_update_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  `properties` longtext NOT NULL,
+  PRIMARY KEY (`context_id`, `version`),
+  KEY `idx_context_revision_last_update_time_since_epoch` (`context_id`, `last_update_time_since_epoch`)
+);
@@ -0,0 +1,10 @@
+-- Create the ArtifactDigest table, the canonical artifact of each content digest of an artifact type
+-- The first artifact saved with a digest is its canonical artifact, the later ones are deduplicated to it.
+
+CREATE TABLE IF NOT EXISTS `ArtifactDigest` (
+  `type_id` int NOT NULL,
+  `digest` varchar(255) NOT NULL,
+  `artifact_id` int NOT NULL,
+  PRIMARY KEY (`type_id`, `digest`),
+  KEY `idx_artifact_digest_artifact_id` (`artifact_id`)
+);
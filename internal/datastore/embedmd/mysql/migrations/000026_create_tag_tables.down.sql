@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `ContextTag`;
+DROP TABLE IF EXISTS `Tag`;
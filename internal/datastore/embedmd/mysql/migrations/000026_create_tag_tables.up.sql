@@ -0,0 +1,20 @@
+-- Create the Tag table, the key-only labels of the contexts, and the ContextTag table relating the contexts to their tags
+-- Tags are simpler than custom properties: they have no value, and can be listed with the number of contexts using them.
+
+CREATE TABLE IF NOT EXISTS `Tag` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL,
+  `color` varchar(32) DEFAULT NULL,
+  `description` text DEFAULT NULL,
+  `create_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  `last_update_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_tag_name` (`name`)
+);
+
+CREATE TABLE IF NOT EXISTS `ContextTag` (
+  `context_id` int NOT NULL,
+  `tag_id` int NOT NULL,
+  PRIMARY KEY (`context_id`, `tag_id`),
+  KEY `idx_context_tag_tag_id` (`tag_id`)
+);
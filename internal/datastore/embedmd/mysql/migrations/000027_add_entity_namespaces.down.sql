@@ -0,0 +1,8 @@
+-- Remove the namespaces added in 000027_add_entity_namespaces.up.sql
+
+ALTER TABLE `Artifact` DROP INDEX `idx_artifact_namespace`;
+ALTER TABLE `Artifact` DROP INDEX `idx_artifact_type_id_namespace_name`, ADD UNIQUE KEY `UniqueArtifactTypeName` (`type_id`, `name`);
+ALTER TABLE `Artifact` DROP COLUMN `namespace`;
+ALTER TABLE `Context` DROP INDEX `idx_context_namespace`;
+ALTER TABLE `Context` DROP INDEX `idx_context_type_id_namespace_name`, ADD UNIQUE KEY `type_id` (`type_id`, `name`);
+ALTER TABLE `Context` DROP COLUMN `namespace`;
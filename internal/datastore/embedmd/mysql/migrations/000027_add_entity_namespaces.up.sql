@@ -0,0 +1,10 @@
+-- Add the namespaces of the contexts and artifacts, for the teams sharing a registry as tenants
+-- The requests of a tenant only see the entities of its namespace, the names are unique per namespace.
+-- The existing entities, and the ones created by the requests of no tenant, are in the empty namespace.
+
+ALTER TABLE `Context` ADD COLUMN `namespace` VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE `Context` DROP INDEX `type_id`, ADD UNIQUE KEY `idx_context_type_id_namespace_name` (`type_id`, `namespace`, `name`);
+ALTER TABLE `Context` ADD INDEX `idx_context_namespace` (`namespace`);
+ALTER TABLE `Artifact` ADD COLUMN `namespace` VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE `Artifact` DROP INDEX `UniqueArtifactTypeName`, ADD UNIQUE KEY `idx_artifact_type_id_namespace_name` (`type_id`, `namespace`, `name`);
+ALTER TABLE `Artifact` ADD INDEX `idx_artifact_namespace` (`namespace`);
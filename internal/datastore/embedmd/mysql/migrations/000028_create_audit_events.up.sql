@@ -0,0 +1,19 @@
+-- Create the audit_events table, the audit log of the writes of the entities made through the api: who created,
+-- updated, deleted or transitioned which entity and when, with the fields it changed before and after the write.
+-- entity_kind is the table of the entity (Context, Artifact, Execution or Tag) and changes a JSON array.
+
+CREATE TABLE IF NOT EXISTS `audit_events` (
+  `id` int NOT NULL AUTO_INCREMENT,
+  `action` varchar(16) NOT NULL,
+  `actor` varchar(255) NOT NULL DEFAULT '',
+  `entity_kind` varchar(16) NOT NULL,
+  `type_id` int NOT NULL DEFAULT '0',
+  `entity_id` int NOT NULL,
+  `namespace` varchar(255) NOT NULL DEFAULT '',
+  `changes` mediumtext NOT NULL,
+  `create_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `idx_audit_events_create_time_since_epoch` (`create_time_since_epoch`),
+  KEY `idx_audit_events_entity` (`entity_kind`, `entity_id`),
+  KEY `idx_audit_events_actor` (`actor`)
+);
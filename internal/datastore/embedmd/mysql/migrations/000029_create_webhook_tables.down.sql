@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `mr_webhook_outbox`;
+DROP TABLE IF EXISTS `mr_webhook_subscriptions`;
@@ -0,0 +1,27 @@
+-- Create the tables of the webhooks: the subscriptions to the events of the entities, and the outbox of the events
+-- written in the transactions of the writes of the entities, delivered to the subscriptions by the webhooks job.
+-- The subscriptions and events of a namespace are those of the tenant of the namespace.
+
+CREATE TABLE IF NOT EXISTS `mr_webhook_subscriptions` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `url` varchar(2048) NOT NULL,
+  `entity_types` varchar(1024) NOT NULL DEFAULT '',
+  `events` varchar(255) NOT NULL DEFAULT '',
+  `secret` varchar(255) NOT NULL DEFAULT '',
+  `namespace` varchar(255) NOT NULL DEFAULT '',
+  `create_time_since_epoch` bigint NOT NULL,
+  PRIMARY KEY (`id`)
+);
+
+CREATE TABLE IF NOT EXISTS `mr_webhook_outbox` (
+  `id` bigint NOT NULL AUTO_INCREMENT,
+  `event` varchar(16) NOT NULL,
+  `entity_type` varchar(255) NOT NULL,
+  `entity_id` int NOT NULL,
+  `namespace` varchar(255) NOT NULL DEFAULT '',
+  `create_time_since_epoch` bigint NOT NULL,
+  `attempts` int NOT NULL DEFAULT '0',
+  `next_attempt_time_since_epoch` bigint NOT NULL DEFAULT '0',
+  `last_error` text,
+  PRIMARY KEY (`id`)
+);
@@ -0,0 +1,8 @@
+-- Remove full-text indexes added in 000026_add_full_text_indexes.up.sql
+
+DROP INDEX IF EXISTS idx_artifact_name_fulltext;
+DROP INDEX IF EXISTS idx_artifact_property_string_value_fulltext;
+DROP INDEX IF EXISTS idx_context_name_fulltext;
+DROP INDEX IF EXISTS idx_context_property_string_value_fulltext;
+DROP INDEX IF EXISTS idx_execution_name_fulltext;
+DROP INDEX IF EXISTS idx_execution_property_string_value_fulltext;
@@ -0,0 +1,10 @@
+-- Add full-text indexes for the free-text search of the list endpoints
+-- The search matches the entity name and the string value of the description and custom properties,
+-- the expressions must match the ones of filter.FullTextScope for the indexes to be used
+
+CREATE INDEX IF NOT EXISTS idx_artifact_name_fulltext ON "Artifact" USING GIN (to_tsvector('simple', coalesce(name, '')));
+CREATE INDEX IF NOT EXISTS idx_artifact_property_string_value_fulltext ON "ArtifactProperty" USING GIN (to_tsvector('simple', coalesce(string_value, '')));
+CREATE INDEX IF NOT EXISTS idx_context_name_fulltext ON "Context" USING GIN (to_tsvector('simple', coalesce(name, '')));
+CREATE INDEX IF NOT EXISTS idx_context_property_string_value_fulltext ON "ContextProperty" USING GIN (to_tsvector('simple', coalesce(string_value, '')));
+CREATE INDEX IF NOT EXISTS idx_execution_name_fulltext ON "Execution" USING GIN (to_tsvector('simple', coalesce(name, '')));
+CREATE INDEX IF NOT EXISTS idx_execution_property_string_value_fulltext ON "ExecutionProperty" USING GIN (to_tsvector('simple', coalesce(string_value, '')));
@@ -0,0 +1,11 @@
+-- Remove the property value indexes added in 000027_add_property_value_indexes.up.sql
+
+DROP INDEX IF EXISTS idx_artifact_property_int_value;
+DROP INDEX IF EXISTS idx_artifact_property_double_value;
+DROP INDEX IF EXISTS idx_artifact_property_string_value;
+DROP INDEX IF EXISTS idx_context_property_int_value;
+DROP INDEX IF EXISTS idx_context_property_double_value;
+DROP INDEX IF EXISTS idx_context_property_string_value;
+DROP INDEX IF EXISTS idx_execution_property_int_value;
+DROP INDEX IF EXISTS idx_execution_property_double_value;
+DROP INDEX IF EXISTS idx_execution_property_string_value;
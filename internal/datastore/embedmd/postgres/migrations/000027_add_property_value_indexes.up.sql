@@ -0,0 +1,15 @@
+-- Add partial covering indexes on the typed value columns of the property tables for the property filters
+-- The predicates must match the ones of the EXISTS subqueries of the filter planner (filter/planner.go) for the
+-- indexes to be used: a filter on a double property only scans the index entries of the double values of the
+-- property and reads the entity ids from the index without visiting the table.
+-- The string values are unbounded TEXT, a hash index serves their equality without the size limit of btree entries.
+
+CREATE INDEX IF NOT EXISTS idx_artifact_property_int_value ON "ArtifactProperty" (name, int_value) INCLUDE (artifact_id) WHERE int_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_artifact_property_double_value ON "ArtifactProperty" (name, double_value) INCLUDE (artifact_id) WHERE double_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_artifact_property_string_value ON "ArtifactProperty" USING HASH (string_value) WHERE string_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_context_property_int_value ON "ContextProperty" (name, int_value) INCLUDE (context_id) WHERE int_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_context_property_double_value ON "ContextProperty" (name, double_value) INCLUDE (context_id) WHERE double_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_context_property_string_value ON "ContextProperty" USING HASH (string_value) WHERE string_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_execution_property_int_value ON "ExecutionProperty" (name, int_value) INCLUDE (execution_id) WHERE int_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_execution_property_double_value ON "ExecutionProperty" (name, double_value) INCLUDE (execution_id) WHERE double_value IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_execution_property_string_value ON "ExecutionProperty" USING HASH (string_value) WHERE string_value IS NOT NULL;
@@ -0,0 +1,4 @@
+-- Remove the versions added in 000028_add_entity_versions.up.sql
+
+ALTER TABLE "Context" DROP COLUMN IF EXISTS version;
+ALTER TABLE "Artifact" DROP COLUMN IF EXISTS version;
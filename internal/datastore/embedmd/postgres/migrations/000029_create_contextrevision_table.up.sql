@@ -0,0 +1,15 @@
+-- Create the ContextRevision table, the state of the contexts recording revisions after each of their saves
+-- The properties are the ContextProperty rows of the revision as a JSON array, for the reads as of a past time.
+CREATE TABLE IF NOT EXISTS "ContextRevision" (
+    context_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    type_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    external_id VARCHAR(255) DEFAULT NULL,
+    create_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    last_update_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    properties TEXT NOT NULL,
+    PRIMARY KEY (context_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_context_revision_last_update_time_since_epoch ON "ContextRevision" (context_id, last_update_time_since_epoch);
@@ -0,0 +1,10 @@
+-- Create the ArtifactDigest table, the canonical artifact of each content digest of an artifact type
+-- The first artifact saved with a digest is its canonical artifact, the later ones are deduplicated to it.
+CREATE TABLE IF NOT EXISTS "ArtifactDigest" (
+    type_id INTEGER NOT NULL,
+    digest VARCHAR(255) NOT NULL,
+    artifact_id INTEGER NOT NULL,
+    PRIMARY KEY (type_id, digest)
+);
+
+CREATE INDEX IF NOT EXISTS idx_artifact_digest_artifact_id ON "ArtifactDigest" (artifact_id);
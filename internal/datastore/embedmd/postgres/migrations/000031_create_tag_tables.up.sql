@@ -0,0 +1,21 @@
+-- Create the Tag table, the key-only labels of the contexts, and the ContextTag table relating the contexts to their tags
+-- Tags are simpler than custom properties: they have no value, and can be listed with the number of contexts using them.
+CREATE TABLE IF NOT EXISTS "Tag" (
+    id INTEGER GENERATED ALWAYS AS IDENTITY,
+    name VARCHAR(255) NOT NULL,
+    color VARCHAR(32) DEFAULT NULL,
+    description TEXT DEFAULT NULL,
+    create_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    last_update_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tag_name ON "Tag" (name);
+
+CREATE TABLE IF NOT EXISTS "ContextTag" (
+    context_id INTEGER NOT NULL,
+    tag_id INTEGER NOT NULL,
+    PRIMARY KEY (context_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_context_tag_tag_id ON "ContextTag" (tag_id);
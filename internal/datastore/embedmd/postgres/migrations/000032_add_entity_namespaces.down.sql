@@ -0,0 +1,10 @@
+-- Remove the namespaces added in 000032_add_entity_namespaces.up.sql
+
+DROP INDEX IF EXISTS idx_artifact_namespace;
+DROP INDEX IF EXISTS idx_artifact_type_id_namespace_name;
+ALTER TABLE "Artifact" ADD CONSTRAINT "Artifact_type_id_name_key" UNIQUE (type_id, name);
+ALTER TABLE "Artifact" DROP COLUMN IF EXISTS namespace;
+DROP INDEX IF EXISTS idx_context_namespace;
+DROP INDEX IF EXISTS idx_context_type_id_namespace_name;
+ALTER TABLE "Context" ADD CONSTRAINT "Context_type_id_name_key" UNIQUE (type_id, name);
+ALTER TABLE "Context" DROP COLUMN IF EXISTS namespace;
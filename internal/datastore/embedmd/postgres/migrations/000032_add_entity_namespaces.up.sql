@@ -0,0 +1,12 @@
+-- Add the namespaces of the contexts and artifacts, for the teams sharing a registry as tenants
+-- The requests of a tenant only see the entities of its namespace, the names are unique per namespace.
+-- The existing entities, and the ones created by the requests of no tenant, are in the empty namespace.
+
+ALTER TABLE "Context" ADD COLUMN IF NOT EXISTS namespace VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE "Context" DROP CONSTRAINT IF EXISTS "Context_type_id_name_key";
+CREATE UNIQUE INDEX IF NOT EXISTS idx_context_type_id_namespace_name ON "Context" (type_id, namespace, name);
+CREATE INDEX IF NOT EXISTS idx_context_namespace ON "Context" (namespace);
+ALTER TABLE "Artifact" ADD COLUMN IF NOT EXISTS namespace VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE "Artifact" DROP CONSTRAINT IF EXISTS "Artifact_type_id_name_key";
+CREATE UNIQUE INDEX IF NOT EXISTS idx_artifact_type_id_namespace_name ON "Artifact" (type_id, namespace, name);
+CREATE INDEX IF NOT EXISTS idx_artifact_namespace ON "Artifact" (namespace);
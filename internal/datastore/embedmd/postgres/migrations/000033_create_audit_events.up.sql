@@ -0,0 +1,19 @@
+-- Create the audit_events table, the audit log of the writes of the entities made through the api: who created,
+-- updated, deleted or transitioned which entity and when, with the fields it changed before and after the write.
+-- entity_kind is the table of the entity (Context, Artifact, Execution or Tag) and changes a JSON array.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INTEGER GENERATED ALWAYS AS IDENTITY,
+    action VARCHAR(16) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    entity_kind VARCHAR(16) NOT NULL,
+    type_id INTEGER NOT NULL DEFAULT '0',
+    entity_id INTEGER NOT NULL,
+    namespace VARCHAR(255) NOT NULL DEFAULT '',
+    changes TEXT NOT NULL,
+    create_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_create_time_since_epoch ON audit_events (create_time_since_epoch);
+CREATE INDEX IF NOT EXISTS idx_audit_events_entity ON audit_events (entity_kind, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_events_actor ON audit_events (actor);
@@ -0,0 +1,26 @@
+-- Create the tables of the webhooks: the subscriptions to the events of the entities, and the outbox of the events
+-- written in the transactions of the writes of the entities, delivered to the subscriptions by the webhooks job.
+-- The subscriptions and events of a namespace are those of the tenant of the namespace.
+CREATE TABLE IF NOT EXISTS mr_webhook_subscriptions (
+    id BIGINT GENERATED ALWAYS AS IDENTITY,
+    url VARCHAR(2048) NOT NULL,
+    entity_types VARCHAR(1024) NOT NULL DEFAULT '',
+    events VARCHAR(255) NOT NULL DEFAULT '',
+    secret VARCHAR(255) NOT NULL DEFAULT '',
+    namespace VARCHAR(255) NOT NULL DEFAULT '',
+    create_time_since_epoch BIGINT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS mr_webhook_outbox (
+    id BIGINT GENERATED ALWAYS AS IDENTITY,
+    event VARCHAR(16) NOT NULL,
+    entity_type VARCHAR(255) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    namespace VARCHAR(255) NOT NULL DEFAULT '',
+    create_time_since_epoch BIGINT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT '0',
+    next_attempt_time_since_epoch BIGINT NOT NULL DEFAULT '0',
+    last_error TEXT,
+    PRIMARY KEY (id)
+);
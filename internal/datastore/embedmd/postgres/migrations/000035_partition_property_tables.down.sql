@@ -0,0 +1,3 @@
+-- The partitioned property tables are left as they are, they are read and written like the unpartitioned ones.
+DROP FUNCTION IF EXISTS mr_partition_property_tables(integer);
+DROP FUNCTION IF EXISTS mr_partition_property_table(text, text, integer);
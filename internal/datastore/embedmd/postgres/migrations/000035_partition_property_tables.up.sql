@@ -0,0 +1,81 @@
+-- Hash partition the artifact and context property tables on the id of their entity, so that the lookups by entity
+-- id only scan a single partition. The tables are partitioned when the migrations run with the number of partitions
+-- in the model_registry.property_partitions setting of their session, set from --embedmd-property-partitions, and
+-- left as they are otherwise. The tables of a database migrated without it are partitioned with
+-- SELECT mr_partition_property_tables(<partitions>).
+
+-- Converts a property table to a table hash partitioned on key, unless it is partitioned. Its constraints, indexes
+-- and dependent materialized views, dropped along with the unpartitioned table, are recreated from their definitions.
+CREATE OR REPLACE FUNCTION mr_partition_property_table(tbl text, key text, partitions integer) RETURNS void AS $$
+DECLARE
+    old text := tbl || '_unpartitioned';
+    def record;
+    view_index record;
+    statements text[] := '{}';
+    view_statements text[] := '{}';
+    view_index_statements text[] := '{}';
+    statement text;
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_partitioned_table pt JOIN pg_class c ON c.oid = pt.partrelid
+        WHERE c.relname = tbl AND c.relnamespace = current_schema()::regnamespace) THEN
+        RETURN;
+    END IF;
+
+    FOR def IN SELECT conname AS name, pg_get_constraintdef(oid) AS definition FROM pg_constraint
+        WHERE conrelid = quote_ident(tbl)::regclass AND contype IN ('p', 'f', 'u') ORDER BY contype DESC, conname LOOP
+        statements := statements || format('ALTER TABLE %I ADD CONSTRAINT %I %s', tbl, def.name, def.definition);
+    END LOOP;
+
+    FOR def IN SELECT i.relname AS name, pg_get_indexdef(i.oid) AS definition FROM pg_index x
+        JOIN pg_class i ON i.oid = x.indexrelid
+        WHERE x.indrelid = quote_ident(tbl)::regclass AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = x.indexrelid)
+        ORDER BY i.relname LOOP
+        statements := statements || def.definition;
+    END LOOP;
+
+    FOR def IN SELECT DISTINCT v.relname AS name, pg_get_viewdef(v.oid) AS definition FROM pg_depend d
+        JOIN pg_rewrite r ON r.oid = d.objid
+        JOIN pg_class v ON v.oid = r.ev_class
+        WHERE d.refobjid = quote_ident(tbl)::regclass AND v.relkind = 'm' AND v.oid <> d.refobjid
+        ORDER BY v.relname LOOP
+        view_statements := view_statements || format('CREATE MATERIALIZED VIEW %I AS %s', def.name, rtrim(rtrim(def.definition), ';'));
+        FOR view_index IN SELECT indexdef FROM pg_indexes
+            WHERE schemaname = current_schema() AND tablename = def.name ORDER BY indexname LOOP
+            view_index_statements := view_index_statements || view_index.indexdef;
+        END LOOP;
+    END LOOP;
+
+    EXECUTE format('ALTER TABLE %I RENAME TO %I', tbl, old);
+    EXECUTE format('CREATE TABLE %I (LIKE %I INCLUDING DEFAULTS) PARTITION BY HASH (%I)', tbl, old, key);
+    FOR i IN 0..partitions - 1 LOOP
+        EXECUTE format('CREATE TABLE %I PARTITION OF %I FOR VALUES WITH (MODULUS %s, REMAINDER %s)', tbl || '_p' || i, tbl, partitions, i);
+    END LOOP;
+    EXECUTE format('INSERT INTO %I SELECT * FROM %I', tbl, old);
+    EXECUTE format('DROP TABLE %I CASCADE', old);
+
+    FOREACH statement IN ARRAY statements || view_statements || view_index_statements LOOP
+        EXECUTE statement;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Partitions the artifact and context property tables, serializing the replicas partitioning them concurrently.
+CREATE OR REPLACE FUNCTION mr_partition_property_tables(partitions integer) RETURNS void AS $$
+BEGIN
+    IF partitions < 2 OR partitions > 1024 THEN
+        RAISE EXCEPTION 'invalid number of property partitions %: must be between 2 and 1024', partitions;
+    END IF;
+
+    PERFORM pg_advisory_xact_lock(7886470833755026537);
+    PERFORM mr_partition_property_table('ArtifactProperty', 'artifact_id', partitions);
+    PERFORM mr_partition_property_table('ContextProperty', 'context_id', partitions);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+BEGIN
+    IF coalesce(current_setting('model_registry.property_partitions', true), '') NOT IN ('', '0') THEN
+        PERFORM mr_partition_property_tables(current_setting('model_registry.property_partitions')::integer);
+    END IF;
+END;
+$$;
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxPropertyPartitions is the maximum number of hash partitions per property table.
+	MaxPropertyPartitions = 1024
+
+	// PropertyPartitionsSetting is the setting of the session of the migrations holding the number of hash
+	// partitions of the property tables, the migration partitioning them leaves them as they are without it.
+	PropertyPartitionsSetting = "model_registry.property_partitions"
+)
+
+// partitionedPropertyTables are the property tables hash partitioned on the id of their entity by the migrations.
+var partitionedPropertyTables = []string{"ArtifactProperty", "ContextProperty"}
+
+// PartitionPropertyTables converts the artifact and context property tables of a database migrated without
+// partitions to tables hash partitioned on the owning entity id, with the function created by the migrations. Tables
+// that are already partitioned are left untouched. Constraints, indexes and dependent materialized views are
+// recreated with their original definitions.
+func PartitionPropertyTables(db *gorm.DB, partitions int) error {
+	if partitions < 2 || partitions > MaxPropertyPartitions {
+		return fmt.Errorf("invalid number of property partitions %d: must be between 2 and %d", partitions, MaxPropertyPartitions)
+	}
+
+	if err := db.Exec("SELECT mr_partition_property_tables(?)", partitions).Error; err != nil {
+		return fmt.Errorf("error partitioning property tables: %w", err)
+	}
+	return nil
+}
+
+// CheckPropertyPartitions fails unless the property tables have the configured number of partitions, 0 for
+// unpartitioned tables: the partitions are created once by the migrations and never changed afterwards. Partitioned
+// tables are only reported when no partitions are configured, as they are read and written like the others.
+func CheckPropertyPartitions(db *gorm.DB, partitions int) error {
+	for _, table := range partitionedPropertyTables {
+		var count int
+		if err := db.Raw(`SELECT COUNT(*) FROM pg_inherits i JOIN pg_class p ON p.oid = i.inhparent
+			WHERE p.relname = ? AND p.relnamespace = current_schema()::regnamespace`, table).Scan(&count).Error; err != nil {
+			return fmt.Errorf("error counting the partitions of %s: %w", table, err)
+		}
+
+		switch {
+		case count == partitions:
+		case partitions == 0:
+			glog.Warningf("Property table %s has %d partitions, none configured", table, count)
+		case count == 0:
+			return fmt.Errorf("property table %s is not partitioned but %d partitions are configured: the tables of a database migrated without partitions are partitioned with SELECT mr_partition_property_tables(%d)",
+				table, partitions, partitions)
+		default:
+			return fmt.Errorf("property table %s has %d partitions but %d are configured: the partitions of the property tables can't be changed",
+				table, count, partitions)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/datastore/embedmd/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionPropertyTables(t *testing.T) {
+	cleanupTestData(t, sharedDB)
+
+	migrator, err := postgres.NewPostgresMigrator(sharedDB)
+	require.NoError(t, err)
+
+	err = migrator.Migrate()
+	require.NoError(t, err)
+
+	// Invalid partition counts are rejected
+	assert.Error(t, postgres.PartitionPropertyTables(sharedDB, 1))
+	assert.Error(t, postgres.PartitionPropertyTables(sharedDB, postgres.MaxPropertyPartitions+1))
+
+	var typeID int64
+	err = sharedDB.Raw(`SELECT id FROM "Type" LIMIT 1`).Scan(&typeID).Error
+	require.NoError(t, err)
+
+	err = sharedDB.Exec(`INSERT INTO "Artifact" (id, type_id, name, create_time_since_epoch, last_update_time_since_epoch) VALUES (1, ?, 'partitioned', 0, 0)`, typeID).Error
+	require.NoError(t, err)
+	err = sharedDB.Exec(`INSERT INTO "ArtifactProperty" (artifact_id, name, is_custom_property, int_value) VALUES (1, 'step', false, 42)`).Error
+	require.NoError(t, err)
+
+	// The tables migrated without partitions are not partitioned
+	require.NoError(t, postgres.CheckPropertyPartitions(sharedDB, 0))
+	assert.Error(t, postgres.CheckPropertyPartitions(sharedDB, 4))
+
+	err = postgres.PartitionPropertyTables(sharedDB, 4)
+	require.NoError(t, err)
+
+	// Calling it again is a no-op
+	err = postgres.PartitionPropertyTables(sharedDB, 4)
+	require.NoError(t, err)
+
+	// The number of partitions can't be changed
+	require.NoError(t, postgres.CheckPropertyPartitions(sharedDB, 4))
+	assert.Error(t, postgres.CheckPropertyPartitions(sharedDB, 8))
+
+	for _, table := range []string{"ArtifactProperty", "ContextProperty"} {
+		var count int64
+		err = sharedDB.Raw(`SELECT COUNT(*) FROM pg_inherits i JOIN pg_class p ON p.oid = i.inhparent WHERE p.relname = ?`, table).Scan(&count).Error
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count, "partitions of %s", table)
+	}
+
+	// Data, constraints, indexes and materialized views are preserved
+	var intValue int64
+	err = sharedDB.Raw(`SELECT int_value FROM "ArtifactProperty" WHERE artifact_id = 1 AND name = 'step'`).Scan(&intValue).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), intValue)
+
+	var fkCount int64
+	err = sharedDB.Raw(`SELECT COUNT(*) FROM pg_constraint WHERE conname = 'ArtifactProperty_artifact_id_fkey'`).Scan(&fkCount).Error
+	require.NoError(t, err)
+	assert.Positive(t, fkCount)
+
+	assert.True(t, sharedDB.Migrator().HasIndex("ArtifactProperty", "idx_artifact_property_int"))
+
+	for _, view := range []string{"artifact_property_options", "context_property_options"} {
+		var exists bool
+		err = sharedDB.Raw(`SELECT EXISTS (SELECT 1 FROM pg_matviews WHERE matviewname = ?)`, view).Scan(&exists).Error
+		require.NoError(t, err)
+		assert.True(t, exists, "materialized view %s", view)
+	}
+
+	// Deleting the artifact still cascades to its properties
+	err = sharedDB.Exec(`DELETE FROM "Artifact" WHERE id = 1`).Error
+	require.NoError(t, err)
+
+	var propCount int64
+	err = sharedDB.Raw(`SELECT COUNT(*) FROM "ArtifactProperty" WHERE artifact_id = 1`).Scan(&propCount).Error
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), propCount)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/datastore"
+	"github.com/kubeflow/model-registry/internal/datastore/embedmd/postgres"
 	"github.com/kubeflow/model-registry/internal/db"
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/service"
@@ -43,9 +44,34 @@ type EmbedMDConfig struct {
 	// DB is an already connected database instance that, if provided, will
 	// be used instead of making a new connection.
 	DB *gorm.DB
+
+	// SchemaCheck configures the schema drift check run after migrations,
+	// defaults to db.SchemaCheckWarn.
+	SchemaCheck db.SchemaCheckMode
+
+	// PropertyPartitions, if set, hash partitions the artifact and context
+	// property tables in that many partitions when they are migrated, the
+	// service fails to connect to tables with another number of partitions.
+	// PostgreSQL only.
+	PropertyPartitions int
 }
 
 func (c *EmbedMDConfig) Validate() error {
+	if c.PropertyPartitions != 0 {
+		if c.DB == nil && c.DatabaseType != types.DatabaseTypePostgres {
+			return fmt.Errorf("property partitioning is only supported with database type %s", types.DatabaseTypePostgres)
+		}
+		if c.PropertyPartitions < 2 || c.PropertyPartitions > postgres.MaxPropertyPartitions {
+			return fmt.Errorf("invalid number of property partitions %d: must be between 2 and %d", c.PropertyPartitions, postgres.MaxPropertyPartitions)
+		}
+	}
+
+	switch c.SchemaCheck {
+	case "", db.SchemaCheckOff, db.SchemaCheckWarn, db.SchemaCheckStrict:
+	default:
+		return fmt.Errorf("unsupported schema check mode: %s. Supported modes: %s, %s, %s", c.SchemaCheck, db.SchemaCheckOff, db.SchemaCheckWarn, db.SchemaCheckStrict)
+	}
+
 	if c.DB == nil {
 		if c.DatabaseType != types.DatabaseTypeMySQL && c.DatabaseType != types.DatabaseTypePostgres {
 			return fmt.Errorf("unsupported database type: %s. Supported types: %s, %s", c.DatabaseType, types.DatabaseTypeMySQL, types.DatabaseTypePostgres)
@@ -97,7 +123,9 @@ func (c *EmbedMDConfig) Validate() error {
 }
 
 type EmbedMDService struct {
-	dbConnector db.Connector
+	dbConnector        db.Connector
+	schemaCheck        db.SchemaCheckMode
+	propertyPartitions int
 }
 
 func NewEmbedMDService(cfg *EmbedMDConfig) (*EmbedMDService, error) {
@@ -115,8 +143,15 @@ func NewEmbedMDService(cfg *EmbedMDConfig) (*EmbedMDService, error) {
 		return nil, fmt.Errorf("database connector not initialized")
 	}
 
+	schemaCheck := cfg.SchemaCheck
+	if schemaCheck == "" {
+		schemaCheck = db.SchemaCheckWarn
+	}
+
 	return &EmbedMDService{
-		dbConnector: dbConnector,
+		dbConnector:        dbConnector,
+		schemaCheck:        schemaCheck,
+		propertyPartitions: cfg.PropertyPartitions,
 	}, nil
 }
 
@@ -130,7 +165,15 @@ func (s *EmbedMDService) Connect(spec *datastore.Spec) (datastore.RepoSet, error
 
 	glog.Infof("Connected to EmbedMD service")
 
-	migrator, err := db.NewDBMigrator(connectedDB)
+	var migrator db.DBMigrator
+	if s.propertyPartitions > 0 {
+		if connectedDB.Name() != types.DatabaseTypePostgres {
+			return nil, fmt.Errorf("property partitioning is only supported with database type %s", types.DatabaseTypePostgres)
+		}
+		migrator, err = postgres.NewPartitioningPostgresMigrator(connectedDB, s.propertyPartitions)
+	} else {
+		migrator, err = db.NewDBMigrator(connectedDB)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +187,16 @@ func (s *EmbedMDService) Connect(spec *datastore.Spec) (datastore.RepoSet, error
 
 	glog.Infof("Migrations completed")
 
+	if connectedDB.Name() == types.DatabaseTypePostgres {
+		if err = postgres.CheckPropertyPartitions(connectedDB, s.propertyPartitions); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.checkSchema(connectedDB); err != nil {
+		return nil, err
+	}
+
 	glog.Infof("Syncing types...")
 	err = s.syncTypes(connectedDB, spec)
 	if err != nil {
@@ -154,6 +207,35 @@ func (s *EmbedMDService) Connect(spec *datastore.Spec) (datastore.RepoSet, error
 	return newRepoSet(connectedDB, spec)
 }
 
+// checkSchema detects drift between the live schema and the migrations, refusing to continue in strict mode.
+func (s *EmbedMDService) checkSchema(conn *gorm.DB) error {
+	if s.schemaCheck == db.SchemaCheckOff {
+		return nil
+	}
+
+	glog.Infof("Checking database schema...")
+
+	result, err := db.CheckSchema(conn, s.schemaCheck)
+	if err != nil {
+		return fmt.Errorf("error checking database schema: %w", err)
+	}
+
+	db.SetSchemaCheckResult(result)
+
+	if result.Drifted() {
+		if s.schemaCheck == db.SchemaCheckStrict {
+			return fmt.Errorf("database schema drift detected: %s", result.Problems())
+		}
+
+		glog.Warningf("Database schema drift detected: %s", result.Problems())
+		return nil
+	}
+
+	glog.Infof("Database schema matches version %d", result.ExpectedVersion)
+
+	return nil
+}
+
 func (s EmbedMDService) Type() string {
 	return connectorType
 }
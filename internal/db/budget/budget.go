@@ -0,0 +1,121 @@
+// Package budget bounds the SQL statements run and the rows read by a request, so that expensive requests fail fast
+// instead of piling up slow queries on the database.
+package budget
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// Limits bounds the queries of a request, zero limits are unlimited.
+type Limits struct {
+	// Statements is the maximum number of SQL statements run by a request.
+	Statements int64
+	// Rows is the maximum number of rows read by the queries of a request.
+	Rows int64
+}
+
+// Enabled reports whether any limit is set.
+func (l Limits) Enabled() bool {
+	return l.Statements > 0 || l.Rows > 0
+}
+
+// Budget accounts the queries run with a context, it is safe for concurrent use.
+type Budget struct {
+	limits     Limits
+	statements atomic.Int64
+	rows       atomic.Int64
+}
+
+// New returns an unused budget bounded by limits.
+func New(limits Limits) *Budget {
+	return &Budget{limits: limits}
+}
+
+// Used returns the SQL statements run and the rows read so far.
+func (b *Budget) Used() (statements int64, rows int64) {
+	return b.statements.Load(), b.rows.Load()
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx accounting its queries to budget.
+func NewContext(ctx context.Context, budget *Budget) context.Context {
+	return context.WithValue(ctx, contextKey{}, budget)
+}
+
+// FromContext returns the budget of ctx, if any.
+func FromContext(ctx context.Context) (*Budget, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	budget, ok := ctx.Value(contextKey{}).(*Budget)
+	return budget, ok
+}
+
+// Renew returns a copy of ctx with a fresh budget of the same limits as its budget, for requests running rounds of
+// queries, e.g. the polls of a watch. Contexts without a budget are returned as is.
+func Renew(ctx context.Context) context.Context {
+	budget, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return NewContext(ctx, New(budget.limits))
+}
+
+// Register installs the callbacks enforcing the budgets and the deadlines of the statement contexts on db. Statements
+// aren't run once their context is done or their budget is spent, queries reading rows beyond the budget fail after
+// reading them. Registering them again is a no-op.
+func Register(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if callbacks.Query().Get("budget:before_query") != nil {
+		return nil
+	}
+	for name, err := range map[string]error{
+		"create": callbacks.Create().Before("gorm:create").Register("budget:before_create", beforeStatement),
+		"query":  callbacks.Query().Before("gorm:query").Register("budget:before_query", beforeStatement),
+		"update": callbacks.Update().Before("gorm:update").Register("budget:before_update", beforeStatement),
+		"delete": callbacks.Delete().Before("gorm:delete").Register("budget:before_delete", beforeStatement),
+		"row":    callbacks.Row().Before("gorm:row").Register("budget:before_row", beforeStatement),
+		"raw":    callbacks.Raw().Before("gorm:raw").Register("budget:before_raw", beforeStatement),
+		"rows":   callbacks.Query().After("gorm:query").Register("budget:after_query", afterQuery),
+	} {
+		if err != nil {
+			return fmt.Errorf("error registering query budget %s callback: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func beforeStatement(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil || db.Error != nil {
+		return
+	}
+	if err := ctx.Err(); err != nil {
+		_ = db.AddError(err)
+		return
+	}
+
+	budget, ok := FromContext(ctx)
+	if !ok {
+		return
+	}
+	if statements := budget.statements.Add(1); budget.limits.Statements > 0 && statements > budget.limits.Statements {
+		_ = db.AddError(fmt.Errorf("%w: more than %d sql statements", api.ErrQueryBudgetExceeded, budget.limits.Statements))
+	}
+}
+
+func afterQuery(db *gorm.DB) {
+	budget, ok := FromContext(db.Statement.Context)
+	if !ok || db.Error != nil {
+		return
+	}
+	if rows := budget.rows.Add(db.Statement.RowsAffected); budget.limits.Rows > 0 && rows > budget.limits.Rows {
+		_ = db.AddError(fmt.Errorf("%w: more than %d rows read", api.ErrQueryBudgetExceeded, budget.limits.Rows))
+	}
+}
@@ -0,0 +1,92 @@
+package budget
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, Register(db))
+	// registering again does not count the statements twice
+	require.NoError(t, Register(db))
+	return db, mock
+}
+
+func expectContexts(mock sqlmock.Sqlmock, ids ...int) {
+	rows := sqlmock.NewRows([]string{"id"})
+	for _, id := range ids {
+		rows.AddRow(id)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Context"`)).WillReturnRows(rows)
+}
+
+func TestStatementsBudget(t *testing.T) {
+	db, mock := newMockDB(t)
+	budget := New(Limits{Statements: 2})
+	ctx := NewContext(context.Background(), budget)
+
+	expectContexts(mock, 1)
+	expectContexts(mock, 2)
+	var contexts []schema.Context
+	require.NoError(t, db.WithContext(ctx).Find(&contexts).Error)
+	require.NoError(t, db.WithContext(ctx).Find(&contexts).Error)
+
+	// the third statement isn't run
+	err := db.WithContext(ctx).Find(&contexts).Error
+	assert.ErrorIs(t, err, api.ErrQueryBudgetExceeded)
+	assert.Equal(t, 413, api.ErrToStatus(err))
+
+	// renewed budgets start over
+	expectContexts(mock, 3)
+	require.NoError(t, db.WithContext(Renew(ctx)).Find(&contexts).Error)
+
+	statements, rows := budget.Used()
+	assert.Equal(t, int64(3), statements)
+	assert.Equal(t, int64(2), rows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRowsBudget(t *testing.T) {
+	db, mock := newMockDB(t)
+	ctx := NewContext(context.Background(), New(Limits{Rows: 2}))
+
+	expectContexts(mock, 1, 2, 3)
+	var contexts []schema.Context
+	assert.ErrorIs(t, db.WithContext(ctx).Find(&contexts).Error, api.ErrQueryBudgetExceeded)
+
+	// without a budget the queries are unlimited
+	expectContexts(mock, 1, 2, 3)
+	require.NoError(t, db.Find(&contexts).Error)
+	assert.Len(t, contexts, 3)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeadline(t *testing.T) {
+	db, mock := newMockDB(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+
+	var contexts []schema.Context
+	err := db.WithContext(ctx).Find(&contexts).Error
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 504, api.ErrToStatus(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package dbutil
+
+import (
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/glog"
+)
+
+// MySQL error numbers of the transactions rolled back by InnoDB that succeed when retried
+const (
+	mysqlLockWaitTimeout = 1205
+	mysqlDeadlock        = 1213
+)
+
+// SQLSTATE codes of the PostgreSQL transactions aborted that succeed when retried
+const (
+	postgresSerializationFailure = "40001"
+	postgresDeadlockDetected     = "40P01"
+)
+
+// RetryPolicy configures how transactions failing with a retryable error are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, doubled for each following retry
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of the repositories writes.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    500 * time.Millisecond,
+}
+
+// sqlStateError is implemented by the PostgreSQL driver errors
+type sqlStateError interface {
+	SQLState() string
+}
+
+// IsRetryableError checks if a database error is a deadlock or serialization failure, the database rolled back
+// the transaction which can be retried as is.
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock || mysqlErr.Number == mysqlLockWaitTimeout
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		state := stateErr.SQLState()
+		return state == postgresSerializationFailure || state == postgresDeadlockDetected
+	}
+
+	return false
+}
+
+// Retry calls fn until it succeeds, fails with an error that is not retryable or the attempts of the policy are
+// exhausted, waiting with an exponential backoff and jitter between attempts. fn must be safe to call again, e.g.
+// a whole transaction.
+func (p RetryPolicy) Retry(fn func() error) error {
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= p.MaxAttempts || !IsRetryableError(err) {
+			return err
+		}
+
+		glog.Warningf("Retrying transaction after attempt %d/%d failed: %v", attempt, p.MaxAttempts, err)
+
+		// jitter the delay by ±50% so that the transactions which conflicted don't retry in lockstep
+		if delay > 0 {
+			time.Sleep(rand.N(delay) + delay/2)
+		}
+		delay = min(delay*2, p.MaxDelay)
+	}
+}
@@ -0,0 +1,128 @@
+package dbutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+// pgError mimics the SQLSTATE carrying errors of the PostgreSQL driver
+type pgError struct {
+	code string
+}
+
+func (e *pgError) Error() string    { return "ERROR: (SQLSTATE " + e.code + ")" }
+func (e *pgError) SQLState() string { return e.code }
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "nil error",
+			err:      nil,
+			expected: false,
+		},
+		{
+			name:     "MySQL deadlock",
+			err:      &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"},
+			expected: true,
+		},
+		{
+			name:     "MySQL lock wait timeout",
+			err:      &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded; try restarting transaction"},
+			expected: true,
+		},
+		{
+			name:     "wrapped MySQL deadlock",
+			err:      fmt.Errorf("error saving registered model: %w", &mysql.MySQLError{Number: 1213}),
+			expected: true,
+		},
+		{
+			name:     "MySQL duplicate entry",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'x' for key 'type_id'"},
+			expected: false,
+		},
+		{
+			name:     "PostgreSQL serialization failure",
+			err:      &pgError{code: "40001"},
+			expected: true,
+		},
+		{
+			name:     "wrapped PostgreSQL deadlock",
+			err:      fmt.Errorf("error saving properties: %w", &pgError{code: "40P01"}),
+			expected: true,
+		},
+		{
+			name:     "PostgreSQL unique violation",
+			err:      &pgError{code: "23505"},
+			expected: false,
+		},
+		{
+			name:     "deadlock message without driver error",
+			err:      errors.New("Deadlock found when trying to get lock"),
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsRetryableError(tt.err))
+		})
+	}
+}
+
+func TestRetryPolicyRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+	deadlock := fmt.Errorf("error saving: %w", &mysql.MySQLError{Number: 1213})
+
+	t.Run("succeeds after retryable errors", func(t *testing.T) {
+		attempts := 0
+		err := policy.Retry(func() error {
+			attempts++
+			if attempts < 3 {
+				return deadlock
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		attempts := 0
+		err := policy.Retry(func() error {
+			attempts++
+			return deadlock
+		})
+		assert.ErrorIs(t, err, deadlock)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		other := errors.New("duplicate key")
+		err := policy.Retry(func() error {
+			attempts++
+			return other
+		})
+		assert.ErrorIs(t, err, other)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("waits between attempts", func(t *testing.T) {
+		attempts := 0
+		err := RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}.Retry(func() error {
+			attempts++
+			return &pgError{code: "40001"}
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 4, attempts)
+	})
+}
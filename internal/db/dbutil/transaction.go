@@ -0,0 +1,23 @@
+package dbutil
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionKey struct{}
+
+// WithTransaction returns a copy of ctx carrying tx. The repositories bound to it run their queries in tx, so that
+// the reads of several repositories see a single point in time, and their writes are committed together.
+func WithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, transactionKey{}, tx)
+}
+
+// Bind returns db running its queries with ctx, in the transaction ctx carries if any.
+func Bind(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(transactionKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"gorm.io/gorm"
+)
+
+// DescriptionProperty is the property searched by FullTextScope along with the string custom properties.
+const DescriptionProperty = "description"
+
+// FullTextTerms splits a free-text search in its terms, the runs of letters and digits. The search operators of the
+// databases are never terms, so they are not interpreted.
+func FullTextTerms(search string) []string {
+	return strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// FullTextScope restricts a query on the entity table, Context, Artifact or Execution, to the entities matching all
+// the terms of the free-text search, each in their name, description or a string custom property: the terms may match
+// different fields, as "bert" in the name and "sentiment" in the description. MySQL matches the FULLTEXT indexes in
+// boolean mode and Postgres the tsvector indexes of the simple configuration, both matching whole words, other dialects
+// fall back to a case insensitive substring match of each term, which also matches the words containing it. A search
+// without terms leaves the query unchanged. The custom properties named excluded, e.g. the encrypted ones, are not
+// searched.
+func FullTextScope(entityTable string, search string, excluded ...string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		terms := FullTextTerms(search)
+		if len(terms) == 0 {
+			return db
+		}
+
+		table := dbutil.QuoteTableName(db, entityTable)
+		propertyTable := dbutil.QuoteTableName(db, entityTable+"Property")
+		idColumn := strings.ToLower(entityTable) + "_id"
+
+		searched := fmt.Sprintf("(%s.is_custom_property = ? OR %s.name = ?)", propertyTable, propertyTable)
+		searchedValues := []any{true, DescriptionProperty}
+		if len(excluded) > 0 {
+			searched = fmt.Sprintf("((%s.is_custom_property = ? AND %s.name NOT IN ?) OR (%s.is_custom_property = ? AND %s.name = ?))",
+				propertyTable, propertyTable, propertyTable, propertyTable)
+			searchedValues = []any{true, excluded, false, DescriptionProperty}
+		}
+
+		conditions := make([]string, 0, len(terms))
+		values := make([]any, 0, (len(searchedValues)+2)*len(terms))
+		for _, term := range terms {
+			nameMatch, nameValue := fullTextMatch(db.Name(), table+".name", term)
+			valueMatch, valueValue := fullTextMatch(db.Name(), propertyTable+".string_value", term)
+
+			conditions = append(conditions, fmt.Sprintf("(%s OR %s.id IN (SELECT %s.%s FROM %s WHERE %s AND %s))",
+				nameMatch, table, propertyTable, idColumn, propertyTable, searched, valueMatch))
+			values = append(values, nameValue)
+			values = append(values, searchedValues...)
+			values = append(values, valueValue)
+		}
+
+		return db.Where("("+strings.Join(conditions, " AND ")+")", values...)
+	}
+}
+
+// fullTextMatch returns the condition matching a term in the column by dialect, and its bound value.
+func fullTextMatch(dialect string, column string, term string) (string, any) {
+	switch dialect {
+	case "mysql":
+		return fmt.Sprintf("MATCH(%s) AGAINST (? IN BOOLEAN MODE)", column), "+" + term
+	case "postgres":
+		// the expression of the indexes created by the migrations
+		return fmt.Sprintf("to_tsvector('simple', coalesce(%s, '')) @@ plainto_tsquery('simple', ?)", column), term
+	default:
+		return fmt.Sprintf("LOWER(%s) LIKE ?", column), "%" + term + "%"
+	}
+}
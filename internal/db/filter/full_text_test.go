@@ -0,0 +1,76 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFullTextTerms(t *testing.T) {
+	assert.Equal(t, []string{"fraud", "detection", "v2"}, FullTextTerms(" Fraud-detection +v2* "))
+	assert.Empty(t, FullTextTerms(`+-"()~*`))
+}
+
+func TestFullTextScope(t *testing.T) {
+	tests := []struct {
+		dialect string
+		match   string
+		values  []any
+	}{
+		{dialect: "mysql", match: "MATCH(`Context`.name) AGAINST (? IN BOOLEAN MODE)", values: []any{"+fraud", "+detection"}},
+		{dialect: "postgres", match: `to_tsvector('simple', coalesce("Context".name, '')) @@ plainto_tsquery('simple', $1)`, values: []any{"fraud", "detection"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dialect, func(t *testing.T) {
+			db := dryRunDB(t, tt.dialect)
+
+			stmt := db.Session(&gorm.Session{NewDB: true}).Scopes(FullTextScope("Context", "Fraud detection!")).Find(&[]schema.Context{}).Statement
+			sql := stmt.SQL.String()
+
+			assert.Contains(t, sql, tt.match)
+			assert.Contains(t, sql, "ContextProperty")
+			// each term is matched on its own, in the name or a property, so that the terms can match different fields
+			require.Len(t, stmt.Vars, 8)
+			assert.Equal(t, []any{tt.values[0], true, DescriptionProperty, tt.values[0], tt.values[1], true, DescriptionProperty, tt.values[1]}, stmt.Vars)
+		})
+	}
+}
+
+func TestFullTextScopeExcludingProperties(t *testing.T) {
+	db := dryRunDB(t, "postgres")
+
+	stmt := db.Session(&gorm.Session{NewDB: true}).Scopes(FullTextScope("Context", "jane", "owner_email")).Find(&[]schema.Context{}).Statement
+
+	// the excluded custom properties are not searched, the description still is
+	assert.Contains(t, stmt.SQL.String(), `"ContextProperty".name NOT IN ($3)`)
+	assert.Equal(t, []any{"jane", true, "owner_email", false, DescriptionProperty, "jane"}, stmt.Vars)
+}
+
+func TestFullTextMatch(t *testing.T) {
+	// MySQL and Postgres match the term as a whole word
+	match, value := fullTextMatch("mysql", "`Context`.name", "bert")
+	assert.Equal(t, "MATCH(`Context`.name) AGAINST (? IN BOOLEAN MODE)", match)
+	assert.Equal(t, "+bert", value)
+
+	match, value = fullTextMatch("postgres", `"Context".name`, "bert")
+	assert.Equal(t, `to_tsvector('simple', coalesce("Context".name, '')) @@ plainto_tsquery('simple', ?)`, match)
+	assert.Equal(t, "bert", value)
+
+	// the other dialects match it as a substring, "bert" also matching "roberta"
+	match, value = fullTextMatch("sqlite", `"Context".name`, "bert")
+	assert.Equal(t, `LOWER("Context".name) LIKE ?`, match)
+	assert.Equal(t, "%bert%", value)
+}
+
+func TestFullTextScopeWithoutTerms(t *testing.T) {
+	db := dryRunDB(t, "postgres")
+
+	stmt := db.Session(&gorm.Session{NewDB: true}).Scopes(FullTextScope("Artifact", " -- ")).Find(&[]schema.Artifact{}).Statement
+
+	assert.NotContains(t, stmt.SQL.String(), "WHERE")
+	assert.Empty(t, stmt.Vars)
+}
@@ -0,0 +1,182 @@
+package filter
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fuzzSeeds are valid and hostile filter queries the fuzz targets start from
+var fuzzSeeds = []string{
+	`name = "test-model"`,
+	`name LIKE "%test%" AND state = "LIVE"`,
+	`name ILIKE "%Test%" OR owner <> 'me'`,
+	`accuracy > 0.95 AND framework = "pytorch"`,
+	`budget.double_value >= 100 AND replicas.INT_VALUE < 3`,
+	`id IN (1, 2, 3) AND tags IN ("a", "b")`,
+	`(name = "a" OR name = "b") AND (active = true OR retired = FALSE)`,
+	"`mlflow.source.type` = \"NOTEBOOK\"",
+	"`x\\.y` = 1",
+	`name = "x'; DROP TABLE Context; --"`,
+	`name = 'x\' OR 1=1 --'`,
+	"`name; DROP TABLE Context` = 1",
+	"`a' OR '1'='1` = \"b\"",
+	`language = "en" AND tasks != "x"`,
+	`experimentId = "exp-123" AND experimentRunId = "run-456"`,
+	`uri = "s3://bucket/model.pkl" -- comment`,
+}
+
+// fuzzRestEntities cover the three MLMD entity types
+var fuzzRestEntities = []RestEntityType{
+	RestEntityRegisteredModel,
+	RestEntityModelVersion,
+	RestEntityExperimentRun,
+	RestEntityModelArtifact,
+	RestEntityServeModel,
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		expr, err := Parse(input)
+		if err != nil || expr == nil {
+			return
+		}
+		checkFilterExpression(t, expr)
+	})
+}
+
+func FuzzQueryBuilder(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	dbs := map[string]*gorm.DB{
+		"mysql":    dryRunDB(f, "mysql"),
+		"postgres": dryRunDB(f, "postgres"),
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		expr, err := Parse(input)
+		if err != nil || expr == nil {
+			return
+		}
+
+		for dialect, db := range dbs {
+			for _, restEntityType := range fuzzRestEntities {
+				sql, vars, err := buildDryRunSQL(db, restEntityType, expr)
+				if err != nil {
+					if !errors.Is(err, ErrUnsafeFilter) {
+						t.Fatalf("%s %s: unexpected error for %q: %v", dialect, restEntityType, input, err)
+					}
+					continue
+				}
+				checkSQL(t, dialect, sql, vars)
+			}
+		}
+	})
+}
+
+// checkFilterExpression verifies the invariants of parsed expressions.
+func checkFilterExpression(t *testing.T, expr *FilterExpression) {
+	t.Helper()
+
+	if !expr.IsLeaf {
+		if expr.Operator != "AND" && expr.Operator != "OR" {
+			t.Fatalf("unexpected logical operator %q", expr.Operator)
+		}
+		if expr.Left == nil || expr.Right == nil {
+			t.Fatalf("logical expression %q without operands", expr.Operator)
+		}
+		checkFilterExpression(t, expr.Left)
+		checkFilterExpression(t, expr.Right)
+		return
+	}
+
+	if !filterOperators[expr.Operator] {
+		t.Fatalf("operator %q is not canonical", expr.Operator)
+	}
+}
+
+// unquotedSQLPattern matches the SQL built from a filter with all the user input bound: no string literal,
+// statement separator or comment.
+var unquotedSQLPattern = regexp.MustCompile(`'|;|--|/\*`)
+
+// checkSQL verifies no filter text was written in the SQL instead of being bound.
+func checkSQL(t *testing.T, dialect string, sql string, vars []any) {
+	t.Helper()
+
+	if unquotedSQLPattern.MatchString(sql) {
+		t.Fatalf("%s: filter text in the SQL: %s", dialect, sql)
+	}
+
+	// MySQL quotes identifiers with backticks and binds every value with ?, Postgres JSON operators bind gorm
+	// expressions so only MySQL placeholders are counted
+	if dialect == "mysql" {
+		if strings.Contains(sql, `"`) {
+			t.Fatalf("%s: filter text in the SQL: %s", dialect, sql)
+		}
+		if placeholders := strings.Count(sql, "?"); placeholders != len(vars) {
+			t.Fatalf("%s: %d placeholders for %d bound values in %s", dialect, placeholders, len(vars), sql)
+		}
+	}
+}
+
+// dryRunDB returns a gorm DB building the SQL of a dialect without a database.
+func dryRunDB(tb testing.TB, dialect string) *gorm.DB {
+	conn, _, err := sqlmock.New()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { _ = conn.Close() })
+
+	var dialector gorm.Dialector
+	switch dialect {
+	case "mysql":
+		dialector = mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+	default:
+		dialector = postgres.New(postgres.Config{Conn: conn, PreferSimpleProtocol: true})
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:               logger.Default.LogMode(logger.Silent),
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+// buildDryRunSQL returns the SQL listing the entities matching a filter.
+func buildDryRunSQL(db *gorm.DB, restEntityType RestEntityType, expr *FilterExpression) (string, []any, error) {
+	var model any
+	switch GetMLMDEntityType(restEntityType) {
+	case EntityTypeArtifact:
+		model = &[]schema.Artifact{}
+	case EntityTypeExecution:
+		model = &[]schema.Execution{}
+	default:
+		model = &[]schema.Context{}
+	}
+
+	query := NewQueryBuilderForRestEntity(restEntityType, nil).BuildQuery(db.Session(&gorm.Session{NewDB: true}), expr)
+	if query.Error != nil {
+		return "", nil, query.Error
+	}
+
+	stmt := query.Find(model).Statement
+	return stmt.SQL.String(), stmt.Vars, query.Error
+}
@@ -0,0 +1,173 @@
+// Command listoptionsgen generates the ListOptions builders of the entities of pkg/api from the properties their
+// filter queries accept, as defined by filter.RestEntityPropertyMap: each builder restricts the entities with a typed
+// method per well-known property, and with the State and Tags of the ListOptions for the entities supporting them.
+//
+//	go run ./internal/db/filter/listoptionsgen -o pkg/api/list_options.gen.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/kubeflow/model-registry/internal/db/filter"
+)
+
+// entity is an entity with a ListOptions builder.
+type entity struct {
+	Type filter.RestEntityType
+	// Plural is the name of the entities in the doc comments
+	Plural string
+	// State is true for the entities restricted by the State of the ListOptions instead of a filter on their state
+	State bool
+	// Tags is true for the entities restricted by the Tags of the ListOptions
+	Tags bool
+}
+
+var entities = []entity{
+	{Type: filter.RestEntityRegisteredModel, Plural: "registered models", State: true, Tags: true},
+	{Type: filter.RestEntityModelVersion, Plural: "model versions", State: true, Tags: true},
+	{Type: filter.RestEntityInferenceService, Plural: "inference services", Tags: true},
+	{Type: filter.RestEntityServingEnvironment, Plural: "serving environments", Tags: true},
+	{Type: filter.RestEntityExperiment, Plural: "experiments", Tags: true},
+	{Type: filter.RestEntityExperimentRun, Plural: "experiment runs", Tags: true},
+	{Type: filter.RestEntityModelArtifact, Plural: "model artifacts"},
+	{Type: filter.RestEntityDocArtifact, Plural: "doc artifacts"},
+	{Type: filter.RestEntityDataSet, Plural: "data sets"},
+	{Type: filter.RestEntityMetric, Plural: "metrics"},
+	{Type: filter.RestEntityParameter, Plural: "parameters"},
+	{Type: filter.RestEntityServeModel, Plural: "serve models"},
+}
+
+// property is a well-known property of an entity with its builder method.
+type property struct {
+	Name string
+	// Method is the name of the builder method, With followed by the capitalized name
+	Method string
+	// GoType is the type of the argument of the method and Format the expression of its filter query literal
+	GoType string
+	Format string
+}
+
+// properties returns the well-known properties of the entity, but its state when the ListOptions restrict it.
+func properties(e entity) ([]property, error) {
+	names := make([]string, 0, len(filter.RestEntityPropertyMap[e.Type]))
+	for name := range filter.RestEntityPropertyMap[e.Type] {
+		if e.State && name == "state" {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	result := make([]property, 0, len(names))
+	for _, name := range names {
+		p := property{Name: name, Method: "With" + strings.ToUpper(name[:1]) + name[1:]}
+		valueType := filter.GetPropertyDefinitionForRestEntity(e.Type, name).ValueType
+		// the states of the artifacts and executions are filtered by their names, as for the contexts
+		if name == "state" {
+			valueType = filter.StringValueType
+		}
+		switch valueType {
+		case filter.StringValueType:
+			p.GoType, p.Format = "string", "quoteFilterString(%s)"
+		case filter.IntValueType:
+			p.GoType, p.Format = "int64", "strconv.FormatInt(%s, 10)"
+		case filter.DoubleValueType:
+			p.GoType, p.Format = "float64", "strconv.FormatFloat(%s, 'f', -1, 64)"
+		case filter.BoolValueType:
+			p.GoType, p.Format = "bool", "strconv.FormatBool(%s)"
+		default:
+			return nil, fmt.Errorf("unsupported value type %s of property %s of %s", valueType, name, e.Type)
+		}
+		p.Format = fmt.Sprintf(p.Format, name)
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+var source = template.Must(template.New("list_options").Parse(`// Code generated by listoptionsgen from filter.RestEntityPropertyMap; DO NOT EDIT.
+
+package api
+
+import "strconv"
+{{range .}}{{$builder := printf "%sListOptions" .Type}}
+// {{$builder}} builds the ListOptions of the {{.Plural}}.
+type {{$builder}} struct {
+	listOptions[{{$builder}}]
+}
+
+// New{{$builder}} returns a builder of the ListOptions of the {{.Plural}}.
+func New{{$builder}}() *{{$builder}} {
+	b := &{{$builder}}{}
+	b.self = b
+	return b
+}
+{{- if .State}}
+
+// WithState restricts the {{.Plural}} to a state: ListStateLive, ListStateArchived or ListStateAll.
+func (b *{{$builder}}) WithState(state string) *{{$builder}} {
+	return b.withState(state)
+}
+{{- end}}
+{{- if .Tags}}
+
+// WithTags restricts the {{.Plural}} to the ones with all the tags.
+func (b *{{$builder}}) WithTags(tags ...string) *{{$builder}} {
+	return b.withTags(tags)
+}
+{{- end}}
+{{- $plural := .Plural}}
+{{- range .Properties}}
+
+// {{.Method}} restricts the {{$plural}} to the ones with this {{.Name}}.
+func (b *{{$builder}}) {{.Method}}({{.Name}} {{.GoType}}) *{{$builder}} {
+	return b.where("{{.Name}}", "=", {{.Format}})
+}
+{{- end}}
+{{end}}`))
+
+// generate returns the source of the builders.
+func generate() ([]byte, error) {
+	type data struct {
+		entity
+		Properties []property
+	}
+	all := make([]data, 0, len(entities))
+	for _, e := range entities {
+		props, err := properties(e)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, data{entity: e, Properties: props})
+	}
+
+	var buf bytes.Buffer
+	if err := source.Execute(&buf, all); err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+func main() {
+	output := flag.String("o", "list_options.gen.go", "File the builders are written to")
+	flag.Parse()
+
+	generated, err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, generated, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratedUpToDate(t *testing.T) {
+	generated, err := generate()
+	require.NoError(t, err)
+
+	current, err := os.ReadFile("../../../../pkg/api/list_options.gen.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(current), "pkg/api/list_options.gen.go is outdated, run go generate ./pkg/api")
+}
+
+func TestEntities(t *testing.T) {
+	covered := map[filter.RestEntityType]bool{}
+	for _, e := range entities {
+		covered[e.Type] = true
+	}
+	for entityType := range filter.RestEntityPropertyMap {
+		assert.True(t, covered[entityType], "no ListOptions builder for %s", entityType)
+	}
+}
+
+func TestFilterQueriesParse(t *testing.T) {
+	for _, options := range []api.ListOptions{
+		api.NewModelVersionListOptions().WithName(`it's a \ name`).WithRegisteredModelId(1).WithCostPer1kInferences(0.25).Build(),
+		api.NewModelArtifactListOptions().WithState("LIVE").WithUriScheme("s3").UpdatedSince(time.Now()).Build(),
+		api.NewServeModelListOptions().WithLastKnownState(1).WithFilter("name = 'a' OR name = 'b'").Build(),
+		api.NewRegisteredModelListOptions().WithOwner("team").OrderByCreateTime(openapi.SORTORDER_DESC).Build(),
+	} {
+		_, err := filter.Parse(*options.FilterQuery)
+		assert.NoError(t, err, *options.FilterQuery)
+	}
+}
@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"container/list"
+	"sync"
+)
+
+const (
+	// parseCacheSize bounds the number of filter queries whose parsing is cached.
+	parseCacheSize = 1024
+	// maxCachedQueryLength bounds the length of the cached filter queries, longer ones are parsed each time.
+	maxCachedQueryLength = 4096
+)
+
+// parsedQueries caches the parsing of the filter queries, which dashboards issue again and again.
+var parsedQueries = newParseCache(parseCacheSize)
+
+// parseCache is a least recently used cache of the parsing of filter queries, errors included as parsing is
+// deterministic.
+type parseCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type parseCacheEntry struct {
+	input string
+	expr  *FilterExpression
+	err   error
+}
+
+func newParseCache(size int) *parseCache {
+	return &parseCache{
+		size:    size,
+		entries: make(map[string]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+func (c *parseCache) get(input string) (*parseCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[input]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*parseCacheEntry), true
+}
+
+func (c *parseCache) add(input string, expr *FilterExpression, err error) {
+	if len(input) > maxCachedQueryLength {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[input]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[input] = c.order.PushFront(&parseCacheEntry{input: input, expr: expr, err: err})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*parseCacheEntry).input)
+	}
+}
@@ -0,0 +1,66 @@
+package filter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCachesExpressions(t *testing.T) {
+	query := "name = 'cached' AND owner.string_value = 'team-a'"
+
+	first, err := Parse(query)
+	require.NoError(t, err)
+	second, err := Parse(query)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, firstErr := Parse("name = = 'invalid'")
+	_, secondErr := Parse("name = = 'invalid'")
+	require.Error(t, firstErr)
+	assert.Same(t, firstErr, secondErr)
+}
+
+func TestParseCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newParseCache(2)
+
+	cache.add("a", &FilterExpression{Property: "a"}, nil)
+	cache.add("b", &FilterExpression{Property: "b"}, nil)
+	_, ok := cache.get("a")
+	require.True(t, ok)
+	cache.add("c", &FilterExpression{Property: "c"}, nil)
+
+	_, ok = cache.get("b")
+	assert.False(t, ok, "b is the least recently used")
+	for _, input := range []string{"a", "c"} {
+		entry, ok := cache.get(input)
+		require.True(t, ok, input)
+		assert.Equal(t, input, entry.expr.Property)
+	}
+}
+
+func TestParseCacheSkipsLongQueries(t *testing.T) {
+	cache := newParseCache(2)
+
+	long := fmt.Sprintf("name = '%0*d'", maxCachedQueryLength, 0)
+	cache.add(long, &FilterExpression{}, nil)
+
+	_, ok := cache.get(long)
+	assert.False(t, ok)
+}
+
+func BenchmarkParse(b *testing.B) {
+	query := "name LIKE 'fraud%' AND (state = 'LIVE' OR customProperties.team.string_value IN ('a', 'b')) AND accuracy.double_value > 0.9"
+	b.Run("cached", func(b *testing.B) {
+		for range b.N {
+			_, _ = Parse(query)
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		for range b.N {
+			_, _ = parse(query)
+		}
+	})
+}
@@ -142,11 +142,23 @@ type PropertyReference struct {
 
 // Parse parses a filter query string and returns the root expression
 // This function is thread-safe and reuses a singleton parser instance
+// The expressions of the most recent queries are cached and shared by the callers, they must not be modified
 func Parse(input string) (*FilterExpression, error) {
 	if strings.TrimSpace(input) == "" {
 		return nil, nil
 	}
 
+	if cached, ok := parsedQueries.get(input); ok {
+		return cached.expr, cached.err
+	}
+
+	expr, err := parse(input)
+	parsedQueries.add(input, expr, err)
+	return expr, err
+}
+
+// parse parses a non empty filter query string
+func parse(input string) (*FilterExpression, error) {
 	parser := getParser()
 	whereClause, err := parser.ParseString("", input)
 	if err != nil {
@@ -215,7 +227,7 @@ func convertComparison(comp *Comparison) *FilterExpression {
 
 	return &FilterExpression{
 		Property: propertyName,
-		Operator: comp.Operator,
+		Operator: canonicalOperator(comp.Operator),
 		Value:    value,
 		IsLeaf:   true,
 	}
@@ -0,0 +1,87 @@
+package filter
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// indexedValueTypes are the value columns of the property tables with partial indexes on PostgreSQL, see the
+// 000027_add_property_value_indexes migration.
+var indexedValueTypes = map[string]bool{
+	StringValueType: true,
+	IntValueType:    true,
+	DoubleValueType: true,
+}
+
+// plansPropertyExists tells whether the property table conditions are planned as EXISTS subqueries rather than joins.
+// On PostgreSQL the subqueries are semi-joins served by the partial covering indexes on the typed value columns,
+// while the joins are planned before the property predicates and scan the whole property table.
+func (qb *QueryBuilder) plansPropertyExists() bool {
+	return qb.db != nil && qb.db.Name() == "postgres"
+}
+
+// propertyTable returns the quoted property table of the entity type and its column referencing the entities.
+func (qb *QueryBuilder) propertyTable() (table string, entityColumn string) {
+	switch qb.entityType {
+	case EntityTypeContext:
+		return qb.quoteTableName("ContextProperty"), "context_id"
+	case EntityTypeArtifact:
+		return qb.quoteTableName("ArtifactProperty"), "artifact_id"
+	case EntityTypeExecution:
+		return qb.quoteTableName("ExecutionProperty"), "execution_id"
+	}
+	return "", ""
+}
+
+// buildPropertyExistsCondition builds the EXISTS subquery of a property table condition, checking the typed value
+// column of the property. Integer literals on custom properties match either the int or the double values, with a
+// subquery per column so that each one is served by the index of its column.
+func (qb *QueryBuilder) buildPropertyExistsCondition(propRef *PropertyReference, operator string, value any) conditionResult {
+	valueType, inferredAsInt := qb.determinePropertyValueType(propRef, value)
+
+	if inferredAsInt {
+		intExists := qb.buildTypedPropertyExists(propRef.Name, IntValueType, operator, value)
+		doubleExists := qb.buildTypedPropertyExists(propRef.Name, DoubleValueType, operator, value)
+		return conditionResult{
+			condition: fmt.Sprintf("(%s OR %s)", intExists.condition, doubleExists.condition),
+			args:      append(intExists.args, doubleExists.args...),
+		}
+	}
+	return qb.buildTypedPropertyExists(propRef.Name, valueType, operator, value)
+}
+
+// buildTypedPropertyExists builds the EXISTS subquery matching the value of the property in the column of valueType.
+func (qb *QueryBuilder) buildTypedPropertyExists(name string, valueType string, operator string, value any) conditionResult {
+	qb.joinCounter++
+	alias := fmt.Sprintf("prop_%d", qb.joinCounter)
+	table, entityColumn := qb.propertyTable()
+
+	var condition conditionResult
+	switch {
+	case valueType == ArrayValueType:
+		condition = qb.buildJSONOperatorCondition(qb.valueColumnRef(alias, StringValueType), operator, value)
+	case operator == "ILIKE":
+		condition = conditionResult{condition: fmt.Sprintf("%s ILIKE ?", qb.valueColumnRef(alias, valueType)), args: []any{value}}
+	default:
+		column := qb.valueColumnRef(alias, valueType)
+		condition = qb.buildOperatorCondition(column, operator, value)
+		if indexedValueTypes[valueType] {
+			// implies the predicate of the partial index of the column
+			condition.condition = fmt.Sprintf("%s IS NOT NULL AND %s", column, condition.condition)
+		}
+	}
+
+	subquery := fmt.Sprintf("EXISTS (SELECT 1 FROM %s %s WHERE %s.%s = %s.id AND %s.name = ? AND %s)",
+		table, alias, alias, entityColumn, qb.tablePrefix, alias, condition.condition)
+
+	args := []any{name}
+	args = append(args, condition.args...)
+	return conditionResult{condition: subquery, args: args}
+}
+
+// buildPlannedPropertyTableCondition adds the EXISTS subquery of a property table condition to db.
+func (qb *QueryBuilder) buildPlannedPropertyTableCondition(db *gorm.DB, propRef *PropertyReference, operator string, value any) *gorm.DB {
+	condition := qb.buildPropertyExistsCondition(propRef, operator, value)
+	return db.Where(condition.condition, condition.args...)
+}
@@ -0,0 +1,94 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestPlanPropertyExists(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		sql    []string
+		absent []string
+		vars   []any
+	}{
+		{
+			name:  "double property",
+			query: `accuracy > 0.95`,
+			sql: []string{
+				`EXISTS (SELECT 1 FROM "ContextProperty" prop_1 WHERE prop_1.context_id = "Context".id AND prop_1.name = $1 AND prop_1.double_value IS NOT NULL AND prop_1.double_value > $2)`,
+			},
+			absent: []string{"JOIN"},
+			vars:   []any{"accuracy", 0.95},
+		},
+		{
+			name:  "integer literal",
+			query: `replicas >= 3`,
+			sql: []string{
+				`(EXISTS (SELECT 1 FROM "ContextProperty" prop_1 WHERE prop_1.context_id = "Context".id AND prop_1.name = $1 AND prop_1.int_value IS NOT NULL AND prop_1.int_value >= $2) OR ` +
+					`EXISTS (SELECT 1 FROM "ContextProperty" prop_2 WHERE prop_2.context_id = "Context".id AND prop_2.name = $3 AND prop_2.double_value IS NOT NULL AND prop_2.double_value >= $4))`,
+			},
+			vars: []any{"replicas", int64(3), "replicas", int64(3)},
+		},
+		{
+			name:  "string properties in AND and OR",
+			query: `framework = "pytorch" AND (license = "MIT" OR license = "Apache-2.0")`,
+			sql: []string{
+				`prop_1.string_value IS NOT NULL AND prop_1.string_value = $2`,
+				`(EXISTS (SELECT 1 FROM "ContextProperty" prop_2`,
+				`prop_2.string_value = $4) OR EXISTS (SELECT 1 FROM "ContextProperty" prop_3`,
+			},
+			absent: []string{"JOIN"},
+			vars:   []any{"framework", "pytorch", "license", "MIT", "license", "Apache-2.0"},
+		},
+		{
+			name:  "case insensitive match",
+			query: `framework ILIKE "%torch%"`,
+			sql:   []string{`prop_1.string_value ILIKE $2`},
+			vars:  []any{"framework", "%torch%"},
+		},
+		{
+			name:   "bool property",
+			query:  `validated.bool_value = true`,
+			sql:    []string{`prop_1.name = $1 AND prop_1.bool_value = $2)`},
+			absent: []string{"bool_value IS NOT NULL"},
+			vars:   []any{"validated", true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			require.NoError(t, err)
+
+			db := dryRunDB(t, "postgres").Session(&gorm.Session{NewDB: true}).Model(&schema.Context{})
+			stmt := NewQueryBuilderForRestEntity(RestEntityRegisteredModel, nil).BuildQuery(db, expr).Find(&[]schema.Context{}).Statement
+			require.NoError(t, stmt.Error)
+
+			for _, fragment := range tt.sql {
+				assert.Contains(t, stmt.SQL.String(), fragment)
+			}
+			for _, fragment := range tt.absent {
+				assert.NotContains(t, stmt.SQL.String(), fragment)
+			}
+			assert.Equal(t, tt.vars, stmt.Vars)
+		})
+	}
+}
+
+func TestPlanPropertyJoinsOnMySQL(t *testing.T) {
+	expr, err := Parse(`accuracy > 0.95`)
+	require.NoError(t, err)
+
+	db := dryRunDB(t, "mysql").Session(&gorm.Session{NewDB: true}).Model(&schema.Context{})
+	stmt := NewQueryBuilderForRestEntity(RestEntityRegisteredModel, nil).BuildQuery(db, expr).Find(&[]schema.Context{}).Statement
+	require.NoError(t, stmt.Error)
+
+	assert.Contains(t, stmt.SQL.String(), "JOIN `ContextProperty` prop_1 ON prop_1.context_id = `Context`.id")
+	assert.NotContains(t, stmt.SQL.String(), "EXISTS")
+}
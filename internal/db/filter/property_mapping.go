@@ -88,6 +88,14 @@ var contextPropertyMap = EntityPropertyMap{
 	"status":               {Location: PropertyTable, ValueType: StringValueType, Column: "status"},
 	"endTimeSinceEpoch":    {Location: PropertyTable, ValueType: StringValueType, Column: "end_time_since_epoch"},
 	"startTimeSinceEpoch":  {Location: PropertyTable, ValueType: StringValueType, Column: "start_time_since_epoch"},
+
+	// Serving resource footprint and cost of ModelVersions
+	"cpuRequest":          {Location: PropertyTable, ValueType: StringValueType, Column: "cpu_request"},
+	"memoryRequest":       {Location: PropertyTable, ValueType: StringValueType, Column: "memory_request"},
+	"acceleratorType":     {Location: PropertyTable, ValueType: StringValueType, Column: "accelerator_type"},
+	"acceleratorCount":    {Location: PropertyTable, ValueType: IntValueType, Column: "accelerator_count"},
+	"costPer1kInferences": {Location: PropertyTable, ValueType: DoubleValueType, Column: "cost_per_1k_inferences"},
+	"costCurrency":        {Location: PropertyTable, ValueType: StringValueType, Column: "cost_currency"},
 }
 var artifactPropertyMap = EntityPropertyMap{
 	// Entity table columns (Artifact table)
@@ -121,6 +129,10 @@ var artifactPropertyMap = EntityPropertyMap{
 	"profile":            {Location: PropertyTable, ValueType: StringValueType, Column: "profile"},        // For datasets
 	"experimentId":       {Location: PropertyTable, ValueType: IntValueType, Column: "experiment_id"},     // For all artifacts
 	"experimentRunId":    {Location: PropertyTable, ValueType: IntValueType, Column: "experiment_run_id"}, // For all artifacts
+	"uriScheme":          {Location: PropertyTable, ValueType: StringValueType, Column: "uri_scheme"},     // For model and doc artifacts
+	"uriBucket":          {Location: PropertyTable, ValueType: StringValueType, Column: "uri_bucket"},     // For model and doc artifacts
+	"uriPath":            {Location: PropertyTable, ValueType: StringValueType, Column: "uri_path"},       // For model and doc artifacts
+	"uriRegion":          {Location: PropertyTable, ValueType: StringValueType, Column: "uri_region"},     // For model and doc artifacts
 }
 
 // executionPropertyMap defines properties for Execution entities
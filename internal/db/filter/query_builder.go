@@ -41,6 +41,7 @@ type QueryBuilder struct {
 	joinCounter    int
 	db             *gorm.DB               // Added to access naming strategy
 	mappingFuncs   EntityMappingFunctions // Entity mapping functions
+	err            error                  // First error of the query being built, see sanitize.go
 }
 
 // NewQueryBuilderForRestEntity creates a new query builder for the specified REST entity type
@@ -83,9 +84,35 @@ func (qb *QueryBuilder) BuildQuery(db *gorm.DB, expr *FilterExpression) *gorm.DB
 
 	// Store db reference for table name quoting
 	qb.db = db
+	qb.err = nil
 	qb.applyDatabaseQuoting()
 
-	return qb.buildExpression(db, expr)
+	query := qb.buildExpression(db, expr)
+	if qb.err != nil {
+		_ = query.AddError(qb.err)
+	}
+	return query
+}
+
+// CustomPropertyNames returns the names of the custom properties compared by a filter expression, of the entities
+// or of their related entities, e.g. the custom properties of the artifacts of a model version.
+func (qb *QueryBuilder) CustomPropertyNames(expr *FilterExpression) []string {
+	if expr == nil {
+		return nil
+	}
+	if !expr.IsLeaf {
+		return append(qb.CustomPropertyNames(expr.Left), qb.CustomPropertyNames(expr.Right)...)
+	}
+
+	propRef := qb.buildPropertyReference(expr)
+	switch {
+	case propRef.IsCustom:
+		return []string{propRef.Name}
+	case propRef.PropertyDef.Location == RelatedEntity && propRef.PropertyDef.RelatedProperty != "":
+		return []string{propRef.PropertyDef.RelatedProperty}
+	default:
+		return nil
+	}
 }
 
 // applyDatabaseQuoting updates tablePrefix with proper quoting based on database dialect
@@ -399,12 +426,14 @@ func (qb *QueryBuilder) buildPropertyReference(expr *FilterExpression) *Property
 
 // buildLeafExpression builds a GORM query for a leaf expression (property comparison)
 func (qb *QueryBuilder) buildLeafExpression(db *gorm.DB, expr *FilterExpression) *gorm.DB {
+	qb.checkOperator(expr.Operator)
 	propRef := qb.buildPropertyReference(expr)
 	return qb.buildPropertyCondition(db, propRef, expr.Operator, expr.Value)
 }
 
 // buildLeafConditionString builds a condition string for a leaf expression
 func (qb *QueryBuilder) buildLeafConditionString(expr *FilterExpression) conditionResult {
+	qb.checkOperator(expr.Operator)
 	propRef := qb.buildPropertyReference(expr)
 	return qb.buildPropertyConditionString(propRef, expr.Operator, expr.Value)
 }
@@ -491,7 +520,7 @@ func (qb *QueryBuilder) ConvertStateValue(propertyName string, value any) any {
 // buildEntityTablePropertyCondition builds a condition for properties stored in the entity table
 func (qb *QueryBuilder) buildEntityTablePropertyCondition(db *gorm.DB, propRef *PropertyReference, operator string, value any) *gorm.DB {
 	propDef := GetPropertyDefinition(qb.entityType, propRef.Name)
-	column := fmt.Sprintf("%s.%s", qb.tablePrefix, propDef.Column)
+	column := qb.columnRef(qb.tablePrefix, propDef.Column)
 
 	// Convert state string values to integers based on entity type
 	value = qb.ConvertStateValue(propRef.Name, value)
@@ -528,7 +557,7 @@ func (qb *QueryBuilder) buildEntityTablePropertyCondition(db *gorm.DB, propRef *
 // buildEntityTablePropertyConditionString builds a condition string for properties stored in the entity table
 func (qb *QueryBuilder) buildEntityTablePropertyConditionString(propRef *PropertyReference, operator string, value any) conditionResult {
 	propDef := GetPropertyDefinition(qb.entityType, propRef.Name)
-	column := fmt.Sprintf("%s.%s", qb.tablePrefix, propDef.Column)
+	column := qb.columnRef(qb.tablePrefix, propDef.Column)
 
 	// Convert state string values to integers based on entity type
 	value = qb.ConvertStateValue(propRef.Name, value)
@@ -558,6 +587,10 @@ func (qb *QueryBuilder) buildEntityTablePropertyConditionString(propRef *Propert
 
 // buildPropertyTableCondition builds a condition for properties stored in the property table (requires join)
 func (qb *QueryBuilder) buildPropertyTableCondition(db *gorm.DB, propRef *PropertyReference, operator string, value any) *gorm.DB {
+	if qb.plansPropertyExists() {
+		return qb.buildPlannedPropertyTableCondition(db, propRef, operator, value)
+	}
+
 	qb.joinCounter++
 	alias := fmt.Sprintf("prop_%d", qb.joinCounter)
 
@@ -586,7 +619,7 @@ func (qb *QueryBuilder) buildPropertyTableCondition(db *gorm.DB, propRef *Proper
 
 	// Use cross-database case-insensitive LIKE for ILIKE operator
 	if operator == "ILIKE" {
-		valueColumn := fmt.Sprintf("%s.%s", alias, propRef.ValueType)
+		valueColumn := qb.valueColumnRef(alias, propRef.ValueType)
 		return qb.buildCaseInsensitiveLikeCondition(db, valueColumn, value)
 	}
 
@@ -603,16 +636,11 @@ func (qb *QueryBuilder) buildPropertyTableCondition(db *gorm.DB, propRef *Proper
 		condition = qb.buildDualColumnCondition(intColumn, doubleColumn, operator, value)
 	} else if valueType == ArrayValueType && db.Name() == "postgres" {
 		// Special handling for array types in PostgreSQL
-		valueColumn := fmt.Sprintf("%s.%s", alias, StringValueType)
+		valueColumn := qb.valueColumnRef(alias, StringValueType)
 		condition = qb.buildJSONOperatorCondition(valueColumn, operator, value)
 	} else {
-		// For explicit types or non-integer types, use the specified column
-		var valueColumn string
-		if valueType == ArrayValueType {
-			valueColumn = fmt.Sprintf("%s.%s", alias, StringValueType)
-		} else {
-			valueColumn = fmt.Sprintf("%s.%s", alias, valueType)
-		}
+		// For explicit types or non-integer types, use the specified column, arrays are stored as strings
+		valueColumn := qb.valueColumnRef(alias, valueType)
 		condition = qb.buildOperatorCondition(valueColumn, operator, value)
 	}
 
@@ -621,6 +649,10 @@ func (qb *QueryBuilder) buildPropertyTableCondition(db *gorm.DB, propRef *Proper
 
 // buildPropertyTableConditionString builds a condition string for properties stored in the property table
 func (qb *QueryBuilder) buildPropertyTableConditionString(propRef *PropertyReference, operator string, value any) conditionResult {
+	if qb.plansPropertyExists() {
+		return qb.buildPropertyExistsCondition(propRef, operator, value)
+	}
+
 	// This is more complex for OR conditions - we need to handle joins differently
 	// For now, we'll create a subquery-based approach
 
@@ -651,13 +683,8 @@ func (qb *QueryBuilder) buildPropertyTableConditionString(propRef *PropertyRefer
 		doubleColumn := fmt.Sprintf("%s.double_value", propertyTable)
 		condition = qb.buildDualColumnCondition(intColumn, doubleColumn, operator, value)
 	} else {
-		// For explicit types or non-integer types, use the specified column
-		var valueColumn string
-		if valueType == ArrayValueType {
-			valueColumn = fmt.Sprintf("%s.%s", propertyTable, StringValueType)
-		} else {
-			valueColumn = fmt.Sprintf("%s.%s", propertyTable, valueType)
-		}
+		// For explicit types or non-integer types, use the specified column, arrays are stored as strings
+		valueColumn := qb.valueColumnRef(propertyTable, valueType)
 		condition = qb.buildOperatorCondition(valueColumn, operator, value)
 	}
 
@@ -796,7 +823,7 @@ func (qb *QueryBuilder) buildValueCondition(propertyAlias string, explicitType s
 	}
 
 	// For explicit types or non-integer types, use the specified column
-	valueColumn := fmt.Sprintf("%s.%s", propertyAlias, valueType)
+	valueColumn := qb.valueColumnRef(propertyAlias, valueType)
 	return qb.buildOperatorCondition(valueColumn, operator, value)
 }
 
@@ -493,3 +493,15 @@ func findFirstLeafExpression(expr *FilterExpression) *FilterExpression {
 	}
 	return nil
 }
+
+func TestQueryBuilderCustomPropertyNames(t *testing.T) {
+	expr, err := Parse(`name = "fraud" AND (owner_email.string_value = "jane@example.com" OR accuracy > 0.95) AND state = "LIVE"`)
+	if err != nil {
+		t.Fatalf("Failed to parse query: %v", err)
+	}
+
+	names := NewQueryBuilderForRestEntity(RestEntityRegisteredModel, nil).CustomPropertyNames(expr)
+	if fmt.Sprint(names) != "[owner_email accuracy]" {
+		t.Errorf("Expected the custom properties owner_email and accuracy, got %v", names)
+	}
+}
@@ -53,6 +53,9 @@ var RestEntityPropertyMap = map[RestEntityType]map[string]bool{
 		"createTimeSinceEpoch": true, "lastUpdateTimeSinceEpoch": true,
 		// ModelVersion-specific properties
 		"registeredModelId": true, "state": true, "author": true,
+		// ModelVersion resource footprint and cost properties
+		"cpuRequest": true, "memoryRequest": true, "acceleratorType": true, "acceleratorCount": true,
+		"costPer1kInferences": true, "costCurrency": true,
 		// No experiment or serving-specific properties allowed
 	},
 
@@ -104,6 +107,8 @@ var RestEntityPropertyMap = map[RestEntityType]map[string]bool{
 		"storageKey": true, "storagePath": true, "serviceAccountName": true,
 		"modelSourceKind": true, "modelSourceClass": true, "modelSourceGroup": true,
 		"modelSourceId": true, "modelSourceName": true,
+		// Components of the uri
+		"uriScheme": true, "uriBucket": true, "uriPath": true, "uriRegion": true,
 		// Experiment properties (available on all artifacts)
 		"experimentId": true, "experimentRunId": true,
 		// No metric/parameter/dataset-specific properties allowed
@@ -114,6 +119,8 @@ var RestEntityPropertyMap = map[RestEntityType]map[string]bool{
 		"id": true, "name": true, "externalId": true,
 		"createTimeSinceEpoch": true, "lastUpdateTimeSinceEpoch": true,
 		"uri": true, "state": true,
+		// Components of the uri
+		"uriScheme": true, "uriBucket": true, "uriPath": true, "uriRegion": true,
 		// Experiment properties (available on all artifacts)
 		"experimentId": true, "experimentRunId": true,
 		// DocArtifact has minimal additional properties
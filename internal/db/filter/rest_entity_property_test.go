@@ -46,6 +46,22 @@ func TestRestEntityPropertyTypeDistinction(t *testing.T) {
 			expectedValueType: IntValueType,
 			description:       "Metric step should be PropertyTable/int_value",
 		},
+		{
+			name:              "ModelVersion well-known cost property",
+			restEntityType:    RestEntityModelVersion,
+			propertyName:      "costPer1kInferences",
+			expectedLocation:  PropertyTable,
+			expectedValueType: DoubleValueType,
+			description:       "ModelVersion costPer1kInferences should be PropertyTable/double_value",
+		},
+		{
+			name:              "ModelVersion well-known accelerator count property",
+			restEntityType:    RestEntityModelVersion,
+			propertyName:      "acceleratorCount",
+			expectedLocation:  PropertyTable,
+			expectedValueType: IntValueType,
+			description:       "ModelVersion acceleratorCount should be PropertyTable/int_value",
+		},
 
 		// Custom properties should always be Custom/string_value (default)
 		{
@@ -0,0 +1,77 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The filter language is user input turned into SQL. The only text from a filter query that may end up in the SQL
+// itself is validated here: columns must be identifiers from the property mappings, value columns one of the
+// property value types and operators one of the filter operators. Property names and values are always passed as
+// bound parameters.
+
+// ErrUnsafeFilter is reported when a filter query would put text that is not a known identifier in the SQL.
+var ErrUnsafeFilter = errors.New("unsafe filter query")
+
+// sqlIdentifierPattern matches the unquoted column names allowed in the queries.
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// valueColumns are the columns of the property tables holding the property values.
+var valueColumns = map[string]bool{
+	StringValueType: true,
+	IntValueType:    true,
+	DoubleValueType: true,
+	BoolValueType:   true,
+}
+
+// filterOperators are the comparison operators of the filter language, in their canonical form.
+var filterOperators = map[string]bool{
+	"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
+	"LIKE": true, "ILIKE": true, "IN": true,
+}
+
+// canonicalOperator returns the canonical form of a comparison operator, keywords are upper case and <> is !=.
+func canonicalOperator(operator string) string {
+	operator = strings.ToUpper(operator)
+	if operator == "<>" {
+		return "!="
+	}
+	return operator
+}
+
+// fail records the first error of the query being built, BuildQuery adds it to the returned query.
+func (qb *QueryBuilder) fail(err error) {
+	if qb.err == nil {
+		qb.err = err
+	}
+}
+
+// columnRef returns table.column, failing the query unless column is a plain identifier.
+func (qb *QueryBuilder) columnRef(table string, column string) string {
+	if !sqlIdentifierPattern.MatchString(column) {
+		qb.fail(fmt.Errorf("%w: invalid column %q", ErrUnsafeFilter, column))
+		return "NULL"
+	}
+	return fmt.Sprintf("%s.%s", table, column)
+}
+
+// valueColumnRef returns the column of a property table holding values of valueType, arrays are stored as strings.
+func (qb *QueryBuilder) valueColumnRef(table string, valueType string) string {
+	if valueType == ArrayValueType {
+		valueType = StringValueType
+	}
+	if !valueColumns[valueType] {
+		qb.fail(fmt.Errorf("%w: invalid value type %q", ErrUnsafeFilter, valueType))
+		return "NULL"
+	}
+	return fmt.Sprintf("%s.%s", table, valueType)
+}
+
+// checkOperator fails the query unless operator is a filter operator.
+func (qb *QueryBuilder) checkOperator(operator string) {
+	if !filterOperators[operator] {
+		qb.fail(fmt.Errorf("%w: invalid operator %q", ErrUnsafeFilter, operator))
+	}
+}
@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"errors"
+	"testing"
+)
+
+// unsafeEntityMappings maps every property to the definition, as a faulty mapping could
+type unsafeEntityMappings struct {
+	defaultEntityMappings
+	definition func(propertyName string) PropertyDefinition
+}
+
+func (u *unsafeEntityMappings) GetPropertyDefinitionForRestEntity(_ RestEntityType, propertyName string) PropertyDefinition {
+	return u.definition(propertyName)
+}
+
+func TestQueryBuilderRejectsUnsafeIdentifiers(t *testing.T) {
+	db := dryRunDB(t, "mysql")
+
+	tests := []struct {
+		name       string
+		query      string
+		definition func(propertyName string) PropertyDefinition
+		unsafe     bool
+	}{
+		{
+			name:  "entity table column from the property name",
+			query: "`id; DROP TABLE Context` = 1",
+			definition: func(propertyName string) PropertyDefinition {
+				return PropertyDefinition{Location: EntityTable, ValueType: IntValueType, Column: propertyName}
+			},
+			unsafe: true,
+		},
+		{
+			name:  "entity table column identifier",
+			query: "`id` = 1",
+			definition: func(propertyName string) PropertyDefinition {
+				return PropertyDefinition{Location: EntityTable, ValueType: IntValueType, Column: propertyName}
+			},
+		},
+		{
+			name:  "property table value type",
+			query: `owner = "me"`,
+			definition: func(propertyName string) PropertyDefinition {
+				return PropertyDefinition{Location: PropertyTable, ValueType: "string_value OR 1=1", Column: propertyName}
+			},
+			unsafe: true,
+		},
+		{
+			name:  "property table name with quotes",
+			query: "`own'er` = \"me\"",
+			definition: func(propertyName string) PropertyDefinition {
+				return PropertyDefinition{Location: PropertyTable, ValueType: StringValueType, Column: propertyName}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to parse query: %v", err)
+			}
+
+			mappings := &unsafeEntityMappings{definition: tt.definition}
+			query := NewQueryBuilderForRestEntity(RestEntityRegisteredModel, mappings).BuildQuery(db, expr)
+
+			if tt.unsafe {
+				if !errors.Is(query.Error, ErrUnsafeFilter) {
+					t.Fatalf("Expected ErrUnsafeFilter, got %v", query.Error)
+				}
+				return
+			}
+			if query.Error != nil {
+				t.Fatalf("Unexpected error: %v", query.Error)
+			}
+
+			stmt := query.Find(&[]struct{}{}).Statement
+			checkSQL(t, "mysql", stmt.SQL.String(), stmt.Vars)
+		})
+	}
+}
+
+func TestParseCanonicalOperators(t *testing.T) {
+	for query, expected := range map[string]string{
+		`owner <> "me"`:             "!=",
+		`owner != "me"`:             "!=",
+		`name LIKE "a%"`:            "LIKE",
+		`budget.double_value > 1.5`: ">",
+	} {
+		expr, err := Parse(query)
+		if err != nil {
+			t.Fatalf("Failed to parse %q: %v", query, err)
+		}
+		if expr.Operator != expected {
+			t.Errorf("Expected operator %s for %q, got %s", expected, query, expr.Operator)
+		}
+	}
+}
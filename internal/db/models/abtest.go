@@ -0,0 +1,25 @@
+package models
+
+type ABTestListOptions struct {
+	Pagination
+	RegisteredModelID *int32
+}
+
+type ABTestAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type ABTest interface {
+	Entity[ABTestAttributes]
+}
+
+type ABTestImpl = BaseEntity[ABTestAttributes]
+
+type ABTestRepository interface {
+	GetByID(id int32) (ABTest, error)
+	List(listOptions ABTestListOptions) (*ListWrapper[ABTest], error)
+	Save(abTest ABTest, registeredModelID *int32) (ABTest, error)
+}
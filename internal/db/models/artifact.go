@@ -17,14 +17,20 @@ type ArtifactListOptions struct {
 	ExternalID       *string
 	ParentResourceID *int32
 	ArtifactType     *string
+	// ArtifactTypes restricts the artifacts to any of the types, in addition to ArtifactType
+	ArtifactTypes []string
 }
 
 // GetRestEntityType implements the FilterApplier interface
 // This enables advanced filtering support for artifacts
 func (a *ArtifactListOptions) GetRestEntityType() filter.RestEntityType {
 	// Determine the appropriate REST entity type based on artifact type
-	if a.ArtifactType != nil {
-		switch *a.ArtifactType {
+	artifactType := a.ArtifactType
+	if artifactType == nil && len(a.ArtifactTypes) == 1 {
+		artifactType = &a.ArtifactTypes[0]
+	}
+	if artifactType != nil {
+		switch *artifactType {
 		case "model-artifact":
 			return filter.RestEntityModelArtifact
 		case "doc-artifact":
@@ -52,5 +58,6 @@ type Artifact struct {
 
 type ArtifactRepository interface {
 	GetByID(id int32) (Artifact, error)
+	GetByIDs(ids []int32) ([]Artifact, error)
 	List(listOptions ArtifactListOptions) (*ListWrapper[Artifact], error)
 }
@@ -0,0 +1,57 @@
+package models
+
+// Actions of the audit events.
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+	// AuditActionTransition is an update changing the state or the stage of the entity.
+	AuditActionTransition = "TRANSITION"
+)
+
+// Kinds of the entities of the audit events, the tables they are stored in.
+const (
+	AuditEntityKindContext   = "Context"
+	AuditEntityKindArtifact  = "Artifact"
+	AuditEntityKindExecution = "Execution"
+	AuditEntityKindTag       = "Tag"
+)
+
+// AuditChange is the change of a field of an entity, Before is nil for the fields set by the write and After for the
+// fields it removed.
+type AuditChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// AuditEvent is a write of an entity, recorded in the audit log.
+type AuditEvent struct {
+	ID         *int32
+	Action     string
+	Actor      string
+	EntityKind string
+	// TypeID is the type of the contexts, artifacts and executions, 0 for the tags.
+	TypeID               int32
+	EntityID             int32
+	Namespace            string
+	Changes              []AuditChange
+	CreateTimeSinceEpoch *int64
+}
+
+type AuditEventListOptions struct {
+	Pagination
+	Actor      *string
+	Action     *string
+	EntityKind *string
+	TypeID     *int32
+	EntityID   *int32
+	// Since and Until bound the create time of the events in milliseconds since epoch, inclusively.
+	Since *int64
+	Until *int64
+}
+
+type AuditEventRepository interface {
+	// List returns a page of the audit events matching the options, ordered by id or create time.
+	List(listOptions AuditEventListOptions) (*ListWrapper[AuditEvent], error)
+}
@@ -0,0 +1,25 @@
+package models
+
+type ConversionJobListOptions struct {
+	Pagination
+	ModelVersionID *int32
+}
+
+type ConversionJobAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type ConversionJob interface {
+	Entity[ConversionJobAttributes]
+}
+
+type ConversionJobImpl = BaseEntity[ConversionJobAttributes]
+
+type ConversionJobRepository interface {
+	GetByID(id int32) (ConversionJob, error)
+	List(listOptions ConversionJobListOptions) (*ListWrapper[ConversionJob], error)
+	Save(conversionJob ConversionJob, modelVersionID *int32) (ConversionJob, error)
+}
@@ -0,0 +1,25 @@
+package models
+
+type DeploymentListOptions struct {
+	Pagination
+	InferenceServiceID *int32
+}
+
+type DeploymentAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type Deployment interface {
+	Entity[DeploymentAttributes]
+}
+
+type DeploymentImpl = BaseEntity[DeploymentAttributes]
+
+type DeploymentRepository interface {
+	GetByID(id int32) (Deployment, error)
+	List(listOptions DeploymentListOptions) (*ListWrapper[Deployment], error)
+	Save(deployment Deployment, inferenceServiceID *int32) (Deployment, error)
+}
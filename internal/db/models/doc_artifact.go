@@ -26,6 +26,7 @@ type DocArtifact interface {
 type DocArtifactImpl = BaseEntity[DocArtifactAttributes]
 
 type DocArtifactRepository interface {
+	DigestFinder[DocArtifact]
 	GetByID(id int32) (DocArtifact, error)
 	List(listOptions DocArtifactListOptions) (*ListWrapper[DocArtifact], error)
 	Save(docArtifact DocArtifact, parentResourceID *int32) (DocArtifact, error)
@@ -45,3 +45,31 @@ func (r *BaseEntity[T]) GetProperties() *[]Properties {
 func (r *BaseEntity[T]) GetCustomProperties() *[]Properties {
 	return r.CustomProperties
 }
+
+// CustomPropertyReader reads one custom property of many entities of a type in a single query.
+type CustomPropertyReader interface {
+	// GetCustomPropertyByIDs returns the custom property named name of the entities with the given ids, keyed by
+	// entity id, ids not found or without the property are skipped.
+	GetCustomPropertyByIDs(ids []int32, name string) (map[int32]Properties, error)
+}
+
+// ListStreamer streams the lists of entities of a type.
+type ListStreamer[E any, O any] interface {
+	// ListStream calls yield with the entities of the page of listOptions in order, as they are read from the
+	// database instead of holding the whole page, and returns the token of the next page. It stops at the first error
+	// of yield.
+	ListStream(listOptions O, yield func(E) error) (string, error)
+}
+
+// DigestCustomProperty is the custom property holding the content digest of the artifacts deduplicated by digest.
+const DigestCustomProperty = "digest"
+
+// SizeBytesCustomProperty is the custom property holding the size of the content of the artifacts, next to their
+// digest.
+const SizeBytesCustomProperty = "size_bytes"
+
+// DigestFinder finds the artifacts of a type by their content digest.
+type DigestFinder[E any] interface {
+	// FindByDigest returns the canonical artifact of digest, the first artifact of the type saved with it.
+	FindByDigest(digest string) (E, error)
+}
@@ -27,6 +27,7 @@ type Experiment interface {
 type ExperimentImpl = BaseEntity[ExperimentAttributes]
 
 type ExperimentRepository interface {
+	CustomPropertyReader
 	GetByID(id int32) (Experiment, error)
 	List(listOptions ExperimentListOptions) (*ListWrapper[Experiment], error)
 	Save(experiment Experiment) (Experiment, error)
@@ -7,6 +7,8 @@ type ExperimentRunListOptions struct {
 	Name         *string
 	ExternalID   *string
 	ExperimentID *int32
+	// ArtifactID filters the experiment runs attributing the artifact
+	ArtifactID *int32
 }
 
 // GetRestEntityType implements the FilterApplier interface
@@ -28,6 +30,8 @@ type ExperimentRun interface {
 type ExperimentRunImpl = BaseEntity[ExperimentRunAttributes]
 
 type ExperimentRunRepository interface {
+	CustomPropertyReader
+	ListStreamer[ExperimentRun, ExperimentRunListOptions]
 	GetByID(id int32) (ExperimentRun, error)
 	List(listOptions ExperimentRunListOptions) (*ListWrapper[ExperimentRun], error)
 	Save(experimentRun ExperimentRun, experimentID *int32) (ExperimentRun, error)
@@ -1,5 +1,7 @@
 package models
 
+import "github.com/kubeflow/model-registry/internal/db/filter"
+
 type InferenceServiceListOptions struct {
 	Pagination
 	Name             *string
@@ -8,6 +10,11 @@ type InferenceServiceListOptions struct {
 	Runtime          *string
 }
 
+// GetRestEntityType implements the FilterApplier interface
+func (i *InferenceServiceListOptions) GetRestEntityType() filter.RestEntityType {
+	return filter.RestEntityInferenceService
+}
+
 type InferenceServiceAttributes struct {
 	Name                     *string
 	ExternalID               *string
@@ -0,0 +1,36 @@
+package models
+
+// LineageKind is the MLMD kind of an entity of a lineage graph.
+type LineageKind string
+
+const (
+	LineageContext   LineageKind = "CONTEXT"
+	LineageArtifact  LineageKind = "ARTIFACT"
+	LineageExecution LineageKind = "EXECUTION"
+)
+
+// LineageLink relates a context to a child context, an attributed artifact or an associated execution.
+type LineageLink struct {
+	ContextID int32
+	Kind      LineageKind
+	ID        int32
+}
+
+// LineageNode is an entity of a lineage graph.
+type LineageNode struct {
+	Kind   LineageKind
+	ID     int32
+	TypeID int32
+	Name   *string
+}
+
+// LineageIDs are the ids of the entities of each kind of a step of a lineage walk.
+type LineageIDs map[LineageKind][]int32
+
+type LineageRepository interface {
+	// GetLinks returns the links of the entities: the parent and child contexts and the attributions and
+	// associations of the contexts, the attributions of the artifacts and the associations of the executions.
+	GetLinks(ids LineageIDs) ([]LineageLink, error)
+	// GetNodes returns the entities with the ids, ids not found are skipped.
+	GetNodes(ids LineageIDs) ([]LineageNode, error)
+}
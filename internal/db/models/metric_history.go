@@ -33,8 +33,33 @@ type MetricHistory interface {
 
 type MetricHistoryImpl = BaseEntity[MetricHistoryAttributes]
 
+// Aggregations of the values of the metric history of the runs of an experiment.
+const (
+	MetricAggregationMax = "max"
+	MetricAggregationMin = "min"
+	MetricAggregationAvg = "avg"
+)
+
+type MetricHistoryAggregateOptions struct {
+	ExperimentID int32
+	// Name of the metric, without the run id prefix and the timestamp suffix of the metric history records
+	Name        string
+	Aggregation string
+}
+
+// MetricHistoryAggregate is the aggregation of the values of the metric history of an experiment run, over Count
+// records.
+type MetricHistoryAggregate struct {
+	ExperimentRunID int32
+	Value           float64
+	Count           int32
+}
+
 type MetricHistoryRepository interface {
 	GetByID(id int32) (MetricHistory, error)
 	List(listOptions MetricHistoryListOptions) (*ListWrapper[MetricHistory], error)
 	Save(metricHistory MetricHistory, experimentRunID *int32) (MetricHistory, error)
+	// Aggregate returns the aggregations of the metric history of the runs of an experiment, ordered by run id.
+	// Runs without history of the metric are omitted.
+	Aggregate(options MetricHistoryAggregateOptions) ([]MetricHistoryAggregate, error)
 }
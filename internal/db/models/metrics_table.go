@@ -0,0 +1,25 @@
+package models
+
+type MetricsTableListOptions struct {
+	Pagination
+	ModelVersionID *int32
+}
+
+type MetricsTableAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type MetricsTable interface {
+	Entity[MetricsTableAttributes]
+}
+
+type MetricsTableImpl = BaseEntity[MetricsTableAttributes]
+
+type MetricsTableRepository interface {
+	GetByID(id int32) (MetricsTable, error)
+	List(listOptions MetricsTableListOptions) (*ListWrapper[MetricsTable], error)
+	Save(metricsTable MetricsTable, modelVersionID *int32) (MetricsTable, error)
+}
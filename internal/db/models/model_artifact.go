@@ -33,6 +33,8 @@ type ModelArtifact interface {
 type ModelArtifactImpl = BaseEntity[ModelArtifactAttributes]
 
 type ModelArtifactRepository interface {
+	CustomPropertyReader
+	DigestFinder[ModelArtifact]
 	GetByID(id int32) (ModelArtifact, error)
 	List(listOptions ModelArtifactListOptions) (*ListWrapper[ModelArtifact], error)
 	Save(modelArtifact ModelArtifact, parentResourceID *int32) (ModelArtifact, error)
@@ -0,0 +1,25 @@
+package models
+
+type ModelCardListOptions struct {
+	Pagination
+	RegisteredModelID *int32
+}
+
+type ModelCardAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type ModelCard interface {
+	Entity[ModelCardAttributes]
+}
+
+type ModelCardImpl = BaseEntity[ModelCardAttributes]
+
+type ModelCardRepository interface {
+	GetByID(id int32) (ModelCard, error)
+	List(listOptions ModelCardListOptions) (*ListWrapper[ModelCard], error)
+	Save(modelCard ModelCard, registeredModelID *int32) (ModelCard, error)
+}
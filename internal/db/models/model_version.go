@@ -7,6 +7,10 @@ type ModelVersionListOptions struct {
 	Name             *string
 	ExternalID       *string
 	ParentResourceID *int32
+	// ArtifactID filters the model versions attributing the artifact
+	ArtifactID *int32
+	// State restricts the model versions to LIVE or ARCHIVED ones, model versions without state are live
+	State *string
 }
 
 // GetRestEntityType implements the FilterApplier interface
@@ -27,8 +31,18 @@ type ModelVersion interface {
 
 type ModelVersionImpl = BaseEntity[ModelVersionAttributes]
 
+// ModelArtifactsFunc returns the model artifacts to save with the model version at index of a batch, once the model
+// version is saved with id modelVersionID.
+type ModelArtifactsFunc func(index int, modelVersionID int32) ([]ModelArtifact, error)
+
 type ModelVersionRepository interface {
+	CustomPropertyReader
+	ListStreamer[ModelVersion, ModelVersionListOptions]
 	GetByID(id int32) (ModelVersion, error)
+	GetByIDs(ids []int32) ([]ModelVersion, error)
 	List(listOptions ModelVersionListOptions) (*ListWrapper[ModelVersion], error)
 	Save(model ModelVersion) (ModelVersion, error)
+	// SaveBatch saves the model versions and their model artifacts in a single transaction, none is saved if any
+	// fails. The saved model artifacts are returned by model version.
+	SaveBatch(modelVersions []ModelVersion, modelArtifacts ModelArtifactsFunc) ([]ModelVersion, [][]ModelArtifact, error)
 }
@@ -13,11 +13,13 @@ var (
 )
 
 type Pagination struct {
-	PageSize      *int32  `json:"pageSize,omitempty"`
-	OrderBy       *string `json:"orderBy,omitempty"`
-	SortOrder     *string `json:"sortOrder,omitempty"`
-	NextPageToken *string `json:"nextPageToken,omitempty"`
-	FilterQuery   *string `json:"filterQuery,omitempty"`
+	PageSize      *int32   `json:"pageSize,omitempty"`
+	OrderBy       *string  `json:"orderBy,omitempty"`
+	SortOrder     *string  `json:"sortOrder,omitempty"`
+	NextPageToken *string  `json:"nextPageToken,omitempty"`
+	FilterQuery   *string  `json:"filterQuery,omitempty"`
+	Query         *string  `json:"q,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
 }
 
 func (p *Pagination) GetNextPageToken() string {
@@ -60,6 +62,19 @@ func (p *Pagination) GetFilterQuery() string {
 	return *p.FilterQuery
 }
 
+func (p *Pagination) GetQuery() string {
+	if p.Query == nil {
+		return ""
+	}
+
+	return *p.Query
+}
+
+// GetTags returns the names of the tags the listed contexts must all have.
+func (p *Pagination) GetTags() []string {
+	return p.Tags
+}
+
 func (p *Pagination) SetNextPageToken(token *string) {
 	p.NextPageToken = token
 }
@@ -0,0 +1,49 @@
+package models
+
+type PromotionListOptions struct {
+	Pagination
+	Name *string
+}
+
+type PromotionAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type Promotion interface {
+	Entity[PromotionAttributes]
+}
+
+type PromotionImpl = BaseEntity[PromotionAttributes]
+
+type PromotionRepository interface {
+	GetByID(id int32) (Promotion, error)
+	List(listOptions PromotionListOptions) (*ListWrapper[Promotion], error)
+	Save(promotion Promotion) (Promotion, error)
+}
+
+type PromotionRunListOptions struct {
+	Pagination
+	PromotionID *int32
+}
+
+type PromotionRunAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type PromotionRun interface {
+	Entity[PromotionRunAttributes]
+}
+
+type PromotionRunImpl = BaseEntity[PromotionRunAttributes]
+
+type PromotionRunRepository interface {
+	GetByID(id int32) (PromotionRun, error)
+	List(listOptions PromotionRunListOptions) (*ListWrapper[PromotionRun], error)
+	Save(promotionRun PromotionRun, promotionID *int32) (PromotionRun, error)
+}
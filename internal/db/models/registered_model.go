@@ -10,6 +10,8 @@ type RegisteredModelListOptions struct {
 	Pagination
 	Name       *string
 	ExternalID *string
+	// State restricts the registered models to LIVE or ARCHIVED ones, registered models without state are live
+	State *string
 }
 
 // GetRestEntityType implements the FilterApplier interface
@@ -17,6 +19,8 @@ func (r *RegisteredModelListOptions) GetRestEntityType() filter.RestEntityType {
 	return filter.RestEntityRegisteredModel
 }
 
+// Properties are the values of the properties of an entity. A well-known property saved without a value, which the
+// mappers never produce, is removed from the entity, as a field cleared by a merge patch.
 type Properties struct {
 	Name             string
 	IsCustomProperty bool
@@ -28,6 +32,11 @@ type Properties struct {
 	ProtoValue       *[]byte
 }
 
+// HasValue reports whether any of the values of the property is set.
+func (p *Properties) HasValue() bool {
+	return p.IntValue != nil || p.DoubleValue != nil || p.StringValue != nil || p.BoolValue != nil || p.ByteValue != nil || p.ProtoValue != nil
+}
+
 func (p *Properties) SetInt64Value(n int64) {
 	if n >= math.MinInt32 && n <= math.MaxInt32 {
 		n32 := int32(n)
@@ -98,7 +107,12 @@ type RegisteredModel interface {
 type RegisteredModelImpl = BaseEntity[RegisteredModelAttributes]
 
 type RegisteredModelRepository interface {
+	CustomPropertyReader
+	ListStreamer[RegisteredModel, RegisteredModelListOptions]
 	GetByID(id int32) (RegisteredModel, error)
+	GetByIDs(ids []int32) ([]RegisteredModel, error)
+	// GetByIDAsOf returns the model as it was at asOf, in milliseconds since epoch.
+	GetByIDAsOf(id int32, asOf int64) (RegisteredModel, error)
 	List(listOptions RegisteredModelListOptions) (*ListWrapper[RegisteredModel], error)
 	Save(model RegisteredModel) (RegisteredModel, error)
 }
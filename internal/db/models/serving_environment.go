@@ -1,11 +1,18 @@
 package models
 
+import "github.com/kubeflow/model-registry/internal/db/filter"
+
 type ServingEnvironmentListOptions struct {
 	Pagination
 	Name       *string
 	ExternalID *string
 }
 
+// GetRestEntityType implements the FilterApplier interface
+func (s *ServingEnvironmentListOptions) GetRestEntityType() filter.RestEntityType {
+	return filter.RestEntityServingEnvironment
+}
+
 type ServingEnvironmentAttributes struct {
 	Name                     *string
 	ExternalID               *string
@@ -0,0 +1,25 @@
+package models
+
+type StageTransitionListOptions struct {
+	Pagination
+	ModelVersionID *int32
+}
+
+type StageTransitionAttributes struct {
+	Name                     *string
+	ExternalID               *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+}
+
+type StageTransition interface {
+	Entity[StageTransitionAttributes]
+}
+
+type StageTransitionImpl = BaseEntity[StageTransitionAttributes]
+
+type StageTransitionRepository interface {
+	GetByID(id int32) (StageTransition, error)
+	List(listOptions StageTransitionListOptions) (*ListWrapper[StageTransition], error)
+	Save(stageTransition StageTransition, modelVersionID *int32) (StageTransition, error)
+}
@@ -0,0 +1,32 @@
+package models
+
+// Tag is a key-only label of the contexts, with an optional color and description.
+type Tag struct {
+	ID                       *int32
+	Name                     string
+	Color                    *string
+	Description              *string
+	CreateTimeSinceEpoch     *int64
+	LastUpdateTimeSinceEpoch *int64
+	// Count is the number of contexts with the tag. Output only.
+	Count int32
+}
+
+type TagListOptions struct {
+	Pagination
+}
+
+type TagRepository interface {
+	// GetByName returns the tag with the name.
+	GetByName(name string) (Tag, error)
+	// List returns a page of the tags, ordered by id, create or last update time, with their counts.
+	List(listOptions TagListOptions) (*ListWrapper[Tag], error)
+	// Save creates the tag, or updates the color and description of the tag with its name.
+	Save(tag Tag) (Tag, error)
+	// Delete deletes the tag with the name, removing it from its contexts.
+	Delete(name string) error
+	// GetContextTags returns the names of the tags of the contexts with the ids, sorted.
+	GetContextTags(contextIDs []int32) (map[int32][]string, error)
+	// SetContextTags replaces the tags of the context by the tags with the names, creating the missing ones.
+	SetContextTags(contextID int32, names []string) ([]string, error)
+}
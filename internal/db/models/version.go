@@ -0,0 +1,89 @@
+package models
+
+import (
+	"context"
+	"sync"
+)
+
+// Tables of the versioned entities, the ids of the contexts and the artifacts are only unique in their table.
+const (
+	VersionedContext  = "Context"
+	VersionedArtifact = "Artifact"
+)
+
+// EntityVersion is the version of the context or artifact with ID, incremented by each of its updates.
+type EntityVersion struct {
+	Table   string
+	ID      int32
+	Version int32
+}
+
+type ifMatchKey struct{}
+
+// WithIfMatch returns ctx for an update that only applies to the version of the entity, as read by the editor.
+func WithIfMatch(ctx context.Context, expected EntityVersion) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, expected)
+}
+
+// IfMatch returns the version the update of ctx applies to, if any.
+func IfMatch(ctx context.Context) (EntityVersion, bool) {
+	expected, ok := ctx.Value(ifMatchKey{}).(EntityVersion)
+	return expected, ok
+}
+
+// EntityVersions are the versions of the entities read or saved by a request, for its responses to surface them.
+type EntityVersions struct {
+	mu       sync.Mutex
+	versions map[EntityVersion]int32
+}
+
+type entityVersionsKey struct{}
+
+// WithEntityVersions returns ctx recording the versions of the entities read or saved with it.
+func WithEntityVersions(ctx context.Context) (context.Context, *EntityVersions) {
+	versions := &EntityVersions{versions: map[EntityVersion]int32{}}
+	return context.WithValue(ctx, entityVersionsKey{}, versions), versions
+}
+
+// RecordEntityVersion records the version of the entity read or saved with ctx, if ctx records them.
+func RecordEntityVersion(ctx context.Context, version EntityVersion) {
+	if ctx == nil {
+		return
+	}
+	if versions, ok := ctx.Value(entityVersionsKey{}).(*EntityVersions); ok {
+		versions.mu.Lock()
+		defer versions.mu.Unlock()
+		versions.versions[EntityVersion{Table: version.Table, ID: version.ID}] = version.Version
+	}
+}
+
+// EntityVersionsOf returns the versions recorded by ctx, nil if ctx doesn't record them.
+func EntityVersionsOf(ctx context.Context) *EntityVersions {
+	if ctx == nil {
+		return nil
+	}
+	versions, _ := ctx.Value(entityVersionsKey{}).(*EntityVersions)
+	return versions
+}
+
+// All returns the last versions recorded for the entities, none for nil versions.
+func (v *EntityVersions) All() []EntityVersion {
+	if v == nil {
+		return nil
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	all := make([]EntityVersion, 0, len(v.versions))
+	for entity, version := range v.versions {
+		all = append(all, EntityVersion{Table: entity.Table, ID: entity.ID, Version: version})
+	}
+	return all
+}
+
+// Get returns the last version recorded for the entity with id in table.
+func (v *EntityVersions) Get(table string, id int32) (int32, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	version, ok := v.versions[EntityVersion{Table: table, ID: id}]
+	return version, ok
+}
@@ -16,6 +16,8 @@ type Artifact struct {
 	ExternalID               *string `gorm:"column:external_id" json:"external_id"`
 	CreateTimeSinceEpoch     int64   `gorm:"column:create_time_since_epoch;not null" json:"create_time_since_epoch"`
 	LastUpdateTimeSinceEpoch int64   `gorm:"column:last_update_time_since_epoch;not null" json:"last_update_time_since_epoch"`
+	Version                  int32   `gorm:"column:version;not null;default:1" json:"version"`
+	Namespace                string  `gorm:"column:namespace;not null" json:"namespace"`
 }
 
 // TableName Artifact's table name
@@ -0,0 +1,156 @@
+package db
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kubeflow/model-registry/internal/datastore/embedmd/mysql"
+	"github.com/kubeflow/model-registry/internal/datastore/embedmd/postgres"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/types"
+	"gorm.io/gorm"
+)
+
+// SchemaCheckMode configures what happens on startup when the live database schema drifts from the expected one.
+type SchemaCheckMode string
+
+const (
+	// SchemaCheckOff skips the schema check.
+	SchemaCheckOff SchemaCheckMode = "off"
+	// SchemaCheckWarn logs a warning and reports the drift on readiness checks.
+	SchemaCheckWarn SchemaCheckMode = "warn"
+	// SchemaCheckStrict refuses to start and fails readiness checks on drift.
+	SchemaCheckStrict SchemaCheckMode = "strict"
+)
+
+// requiredTables are the tables every schema version must contain.
+var requiredTables = []string{
+	schema.TableNameType,
+	schema.TableNameTypeProperty,
+	schema.TableNameParentType,
+	schema.TableNameArtifact,
+	schema.TableNameArtifactProperty,
+	schema.TableNameContext,
+	schema.TableNameContextProperty,
+	schema.TableNameParentContext,
+	schema.TableNameExecution,
+	schema.TableNameExecutionProperty,
+	schema.TableNameAttribution,
+	schema.TableNameAssociation,
+	schema.TableNameEvent,
+	schema.TableNameEventPath,
+}
+
+// SchemaCheckResult is the outcome of comparing the live database schema with the embedded migrations.
+type SchemaCheckResult struct {
+	Mode            SchemaCheckMode `json:"mode"`
+	ExpectedVersion uint            `json:"expected_version"`
+	Version         uint            `json:"version"`
+	Dirty           bool            `json:"dirty"`
+	MissingTables   []string        `json:"missing_tables,omitempty"`
+	MissingIndexes  []string        `json:"missing_indexes,omitempty"`
+}
+
+// Drifted reports whether the live schema differs from the expected one.
+func (r *SchemaCheckResult) Drifted() bool {
+	return r.Dirty || r.Version != r.ExpectedVersion || len(r.MissingTables) > 0 || len(r.MissingIndexes) > 0
+}
+
+// Problems describes every detected difference, it is empty when the schema did not drift.
+func (r *SchemaCheckResult) Problems() string {
+	problems := []string{}
+
+	if r.Dirty {
+		problems = append(problems, fmt.Sprintf("schema version %d is dirty", r.Version))
+	}
+	if r.Version != r.ExpectedVersion {
+		problems = append(problems, fmt.Sprintf("schema version %d does not match expected version %d", r.Version, r.ExpectedVersion))
+	}
+	if len(r.MissingTables) > 0 {
+		problems = append(problems, fmt.Sprintf("missing tables: %s", strings.Join(r.MissingTables, ", ")))
+	}
+	if len(r.MissingIndexes) > 0 {
+		problems = append(problems, fmt.Sprintf("missing indexes: %s", strings.Join(r.MissingIndexes, ", ")))
+	}
+
+	return strings.Join(problems, "; ")
+}
+
+var (
+	_schemaCheckResult *SchemaCheckResult
+	schemaCheckMutex   sync.RWMutex
+)
+
+// SetSchemaCheckResult stores the result of the startup schema check so that it can be reported on readiness checks.
+func SetSchemaCheckResult(result *SchemaCheckResult) {
+	schemaCheckMutex.Lock()
+	defer schemaCheckMutex.Unlock()
+	_schemaCheckResult = result
+}
+
+// GetSchemaCheckResult returns the result of the startup schema check, if it ran.
+func GetSchemaCheckResult() (*SchemaCheckResult, bool) {
+	schemaCheckMutex.RLock()
+	defer schemaCheckMutex.RUnlock()
+	return _schemaCheckResult, _schemaCheckResult != nil
+}
+
+// CheckSchema compares the schema version, tables and indexes of the connected database with the embedded migrations.
+func CheckSchema(db *gorm.DB, mode SchemaCheckMode) (*SchemaCheckResult, error) {
+	var (
+		expectedVersion uint
+		requiredIndexes map[string][]string
+		err             error
+	)
+
+	switch db.Name() {
+	case types.DatabaseTypeMySQL:
+		expectedVersion, err = mysql.LatestVersion()
+		requiredIndexes = mysql.RequiredIndexes
+	case types.DatabaseTypePostgres:
+		expectedVersion, err = postgres.LatestVersion()
+		requiredIndexes = postgres.RequiredIndexes
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s. Supported types: %s, %s", db.Name(), types.DatabaseTypeMySQL, types.DatabaseTypePostgres)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read expected schema version: %w", err)
+	}
+
+	result := &SchemaCheckResult{
+		Mode:            mode,
+		ExpectedVersion: expectedVersion,
+	}
+
+	var migration struct {
+		Version uint
+		Dirty   bool
+	}
+	if err := db.Raw("SELECT version, dirty FROM schema_migrations ORDER BY version DESC LIMIT 1").Scan(&migration).Error; err != nil {
+		return nil, fmt.Errorf("unable to read schema version: %w", err)
+	}
+
+	result.Version = migration.Version
+	result.Dirty = migration.Dirty
+
+	migrator := db.Migrator()
+
+	for _, table := range requiredTables {
+		if !migrator.HasTable(table) {
+			result.MissingTables = append(result.MissingTables, table)
+		}
+	}
+
+	for _, table := range slices.Sorted(maps.Keys(requiredIndexes)) {
+		for _, index := range requiredIndexes[table] {
+			if !migrator.HasIndex(table, index) {
+				result.MissingIndexes = append(result.MissingIndexes, fmt.Sprintf("%s.%s", table, index))
+			}
+		}
+	}
+
+	return result, nil
+}
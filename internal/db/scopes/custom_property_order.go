@@ -0,0 +1,186 @@
+package scopes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"gorm.io/gorm"
+)
+
+// CustomPropertyOrderPrefix prefixes the orderBy of the custom properties: customProperties.accuracy orders by the
+// numeric value, double or int, of the accuracy custom property, and customProperties.framework.string_value by the
+// string value of framework. The entities without the property are listed last.
+const CustomPropertyOrderPrefix = "customProperties."
+
+// Value types of the custom properties that can be ordered by, numeric is the double value or else the int value.
+const (
+	orderNumericValue = ""
+	orderDoubleValue  = "double_value"
+	orderIntValue     = "int_value"
+	orderStringValue  = "string_value"
+)
+
+// sortValueMarker prefixes the cursor values of the entities with the custom property, so that the entities without
+// it are told apart from those with an empty string.
+const sortValueMarker = "="
+
+// CustomPropertyOrder is the ordering by the value of a custom property.
+type CustomPropertyOrder struct {
+	Name      string
+	valueType string
+}
+
+// ParseCustomPropertyOrder returns the custom property ordering of orderBy, false if it doesn't order by a custom
+// property.
+func ParseCustomPropertyOrder(orderBy string) (CustomPropertyOrder, bool) {
+	name, ok := strings.CutPrefix(orderBy, CustomPropertyOrderPrefix)
+	if !ok {
+		return CustomPropertyOrder{}, false
+	}
+
+	order := CustomPropertyOrder{Name: name}
+	for _, valueType := range []string{orderDoubleValue, orderIntValue, orderStringValue} {
+		if trimmed, ok := strings.CutSuffix(name, "."+valueType); ok {
+			order = CustomPropertyOrder{Name: trimmed, valueType: valueType}
+			break
+		}
+	}
+
+	return order, order.Name != ""
+}
+
+// SortValue returns the cursor value of an entity with the custom properties, which marks the entities without the
+// ordered property.
+func (o CustomPropertyOrder) SortValue(customProperties []models.Properties) string {
+	for _, prop := range customProperties {
+		if prop.Name != o.Name {
+			continue
+		}
+		switch o.valueType {
+		case orderStringValue:
+			if prop.StringValue != nil {
+				return sortValueMarker + *prop.StringValue
+			}
+		case orderIntValue:
+			if prop.IntValue != nil {
+				return sortValueMarker + strconv.FormatInt(int64(*prop.IntValue), 10)
+			}
+		default:
+			if prop.DoubleValue != nil {
+				return sortValueMarker + strconv.FormatFloat(*prop.DoubleValue, 'g', -1, 64)
+			}
+			if prop.IntValue != nil && o.valueType == orderNumericValue {
+				return sortValueMarker + strconv.FormatInt(int64(*prop.IntValue), 10)
+			}
+		}
+	}
+	return ""
+}
+
+// Compare orders the sort values of two entities, as the database does: numerically unless ordering by a string
+// value, the entities without the property last.
+func (o CustomPropertyOrder) Compare(a string, b string) int {
+	aValue, aOK := strings.CutPrefix(a, sortValueMarker)
+	bValue, bOK := strings.CutPrefix(b, sortValueMarker)
+	switch {
+	case !aOK || !bOK:
+		// false sorts before true, as IS NULL in the database
+		return compareBool(!aOK, !bOK)
+	case o.valueType == orderStringValue:
+		return strings.Compare(aValue, bValue)
+	default:
+		aNumber, _ := strconv.ParseFloat(aValue, 64)
+		bNumber, _ := strconv.ParseFloat(bValue, 64)
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	}
+}
+
+func compareBool(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// column returns the expression of the ordered value in the property table.
+func (o CustomPropertyOrder) column() string {
+	if o.valueType == orderNumericValue {
+		return "COALESCE(double_value, int_value)"
+	}
+	return o.valueType
+}
+
+// cursorValue returns the bound value of the cursor, false for the entities without the property.
+func (o CustomPropertyOrder) cursorValue(cursor *Cursor) (any, bool) {
+	value, ok := strings.CutPrefix(cursor.Value, sortValueMarker)
+	if !ok {
+		return nil, false
+	}
+	if o.valueType == orderStringValue {
+		return value, true
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, false
+	}
+	return number, true
+}
+
+// entityTables are the tables of the entities with custom properties, by the table prefix of the pagination.
+var entityTables = map[string]bool{
+	"Context":   true,
+	"Artifact":  true,
+	"Execution": true,
+}
+
+// paginateByCustomProperty orders the rows of the entity table by the value of the custom property, joined from the
+// property table, the rows without the property last and then by id. The join only has the entity id and value
+// columns, so that the unqualified conditions on the entity columns, e.g. name, aren't ambiguous.
+func paginateByCustomProperty(db *gorm.DB, order CustomPropertyOrder, tablePrefix string, sortOrder string, nextPageToken string) *gorm.DB {
+	table := dbutil.QuoteTableName(db, tablePrefix)
+	propertyTable := dbutil.QuoteTableName(db, tablePrefix+"Property")
+	idColumn := table + ".id"
+
+	if len(db.Statement.Selects) == 0 {
+		db = db.Select(table + ".*")
+	}
+	db = db.Joins(fmt.Sprintf("LEFT JOIN (SELECT %s_id AS sort_entity_id, %s AS sort_value FROM %s WHERE name = ? AND is_custom_property = ?) sort_property ON sort_property.sort_entity_id = %s",
+		strings.ToLower(tablePrefix), order.column(), propertyTable, idColumn), order.Name, true)
+
+	direction := models.SortOrderAsc
+	comparison := ">"
+	if sortOrder == models.SortOrderDesc {
+		direction = models.SortOrderDesc
+		comparison = "<"
+	}
+
+	value := "sort_property.sort_value"
+	db = db.Order(value + " IS NULL").
+		Order(value + " " + direction).
+		Order(idColumn + " " + direction)
+
+	if nextPageToken != "" {
+		if cursor, err := DecodeCursor(nextPageToken); err == nil {
+			if cursorValue, ok := order.cursorValue(cursor); ok {
+				db = db.Where("("+value+" IS NULL OR "+value+" "+comparison+" ? OR ("+value+" = ? AND "+idColumn+" "+comparison+" ?))",
+					cursorValue, cursorValue, cursor.ID)
+			} else {
+				db = db.Where(value+" IS NULL AND "+idColumn+" "+comparison+" ?", cursor.ID)
+			}
+		}
+	}
+
+	return db
+}
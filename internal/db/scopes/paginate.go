@@ -44,7 +44,12 @@ func PaginateWithTablePrefix(value any, pagination *models.Pagination, db *gorm.
 	return PaginateWithOptions(value, pagination, db, tablePrefix, nil)
 }
 
-// PaginateWithOptions provides full control over pagination with custom allowed columns
+// PaginateWithOptions provides full control over pagination with custom allowed columns.
+// The custom properties are ordered by with an orderBy prefixed with CustomPropertyOrderPrefix, if the table prefix is
+// the entity table.
+// The rows are always ordered by id after the orderBy column, so that rows sharing the same orderBy value are
+// returned in a deterministic order and the (value, id) cursor of the next page token skips exactly the rows of
+// the previous pages.
 func PaginateWithOptions(value any, pagination *models.Pagination, db *gorm.DB, tablePrefix string, customAllowedColumns map[string]string) func(db *gorm.DB) *gorm.DB {
 	pageSize := pagination.GetPageSize()
 	orderBy := pagination.GetOrderBy()
@@ -62,34 +67,22 @@ func PaginateWithOptions(value any, pagination *models.Pagination, db *gorm.DB,
 			db = db.Limit(int(pageSize) + 1)
 		}
 
-		if orderBy != "" && sortOrder != "" {
-			// Validate and sanitize orderBy
-			sanitizedOrderBy, ok := columnsMap[orderBy]
-			if !ok {
-				sanitizedOrderBy = models.DefaultOrderBy
-			}
-
-			sanitizedSortOrder := models.DefaultSortOrder
-
-			// Validate and sanitize so
-			if so, ok := allowedSortOrders[sortOrder]; ok {
-				sanitizedSortOrder = so
-			}
+		if propertyOrder, ok := ParseCustomPropertyOrder(orderBy); ok && entityTables[tablePrefix] {
+			return paginateByCustomProperty(db, propertyOrder, tablePrefix, allowedSortOrders[sortOrder], nextPageToken)
+		}
 
-			// Apply table prefix to ORDER BY column for consistency with WHERE clauses
-			orderByColumn := sanitizedOrderBy
-			if tablePrefix != "" {
-				quotedPrefix := dbutil.QuoteTableName(db, tablePrefix)
-				orderByColumn = quotedPrefix + "." + sanitizedOrderBy
-			}
+		order := resolveOrder(db, orderBy, sortOrder, tablePrefix, columnsMap)
 
-			db = db.Order(fmt.Sprintf("%s %s", orderByColumn, sanitizedSortOrder))
+		db = db.Order(fmt.Sprintf("%s %s", order.column, order.sortOrder))
+		if order.column != order.idColumn {
+			// Unique tiebreaker for rows with the same orderBy value
+			db = db.Order(fmt.Sprintf("%s %s", order.idColumn, order.sortOrder))
 		}
 
 		if nextPageToken != "" {
 			decodedCursor, err := DecodeCursor(nextPageToken)
 			if err == nil {
-				db = buildWhereClause(db, decodedCursor, orderBy, sortOrder, tablePrefix)
+				db = buildWhereClause(db, decodedCursor, order)
 			}
 		}
 
@@ -97,8 +90,21 @@ func PaginateWithOptions(value any, pagination *models.Pagination, db *gorm.DB,
 	}
 }
 
-// buildWhereClause now returns a *gorm.DB with properly parameterized queries instead of raw SQL strings
-func buildWhereClause(db *gorm.DB, cursor *Cursor, orderBy string, sortOrder string, tablePrefix string) *gorm.DB {
+// pageOrder is the validated ordering of a page.
+type pageOrder struct {
+	// column is the quoted orderBy column
+	column string
+	// idColumn is the quoted id column breaking ties
+	idColumn string
+	// sortOrder is ASC or DESC
+	sortOrder string
+	// byID is true when the rows are only ordered by id and cursors hold no value
+	byID bool
+}
+
+// resolveOrder validates orderBy against the allowed columns and sortOrder, unknown values fall back to the
+// defaults, and qualifies the columns with the table prefix.
+func resolveOrder(db *gorm.DB, orderBy string, sortOrder string, tablePrefix string, columnsMap map[string]string) pageOrder {
 	// Validate table prefix to prevent SQL injection
 	if !isValidTablePrefix(tablePrefix) {
 		// If invalid table prefix, ignore it and use no prefix
@@ -106,53 +112,53 @@ func buildWhereClause(db *gorm.DB, cursor *Cursor, orderBy string, sortOrder str
 	}
 
 	// Apply database-specific quoting to table prefix
-	if tablePrefix != "" {
-		tablePrefix = dbutil.QuoteTableName(db, tablePrefix)
-	}
-
-	// Build column names with proper validation
-	idColumn := "id"
-	if tablePrefix != "" {
-		idColumn = tablePrefix + ".id"
+	qualify := func(column string) string {
+		if tablePrefix == "" {
+			return column
+		}
+		return dbutil.QuoteTableName(db, tablePrefix) + "." + column
 	}
 
-	// Validate and get the actual column name for orderBy
-	orderByColumn, ok := allowedOrderByColumns[orderBy]
+	sanitizedOrderBy, ok := columnsMap[orderBy]
 	if !ok {
-		orderByColumn = models.DefaultOrderBy
-	}
-
-	if tablePrefix != "" && orderByColumn != "" {
-		orderByColumn = tablePrefix + "." + orderByColumn
+		sanitizedOrderBy = models.DefaultOrderBy
 	}
-
-	// Validate sort order
 	sanitizedSortOrder := models.DefaultSortOrder
 	if so, ok := allowedSortOrders[sortOrder]; ok {
 		sanitizedSortOrder = so
 	}
 
-	if orderBy == "" {
-		if sanitizedSortOrder == "ASC" {
-			return db.Where(idColumn+" > ?", cursor.ID)
-		}
-		return db.Where(idColumn+" < ?", cursor.ID)
+	return pageOrder{
+		column:    qualify(sanitizedOrderBy),
+		idColumn:  qualify("id"),
+		sortOrder: sanitizedSortOrder,
+		byID:      orderBy == "" || sanitizedOrderBy == "id",
+	}
+}
+
+// buildWhereClause returns a *gorm.DB with properly parameterized queries selecting the rows after the cursor
+func buildWhereClause(db *gorm.DB, cursor *Cursor, order pageOrder) *gorm.DB {
+	comparison := ">"
+	if order.sortOrder == models.SortOrderDesc {
+		comparison = "<"
 	}
 
-	if sanitizedSortOrder == "ASC" {
-		return db.Where("("+orderByColumn+" > ? OR ("+orderByColumn+" = ? AND "+idColumn+" > ?))",
-			cursor.Value, cursor.Value, cursor.ID)
+	if order.byID {
+		return db.Where(order.idColumn+" "+comparison+" ?", cursor.ID)
 	}
-	return db.Where("("+orderByColumn+" < ? OR ("+orderByColumn+" = ? AND "+idColumn+" < ?))",
+
+	return db.Where("("+order.column+" "+comparison+" ? OR ("+order.column+" = ? AND "+order.idColumn+" "+comparison+" ?))",
 		cursor.Value, cursor.Value, cursor.ID)
 }
 
+// Cursor is the keyset of the last row of a page: the rows of the next page sort after its orderBy value, then its id.
 type Cursor struct {
 	ID    int32
 	Value string
 }
 
-// DecodeCursor parses a next page token.
+// DecodeCursor parses a next page token. The value is everything after the id, so that values with colons, such as
+// names, round-trip.
 func DecodeCursor(token string) (*Cursor, error) {
 	// Sanity check the token size
 	if len(token) > 1024 {
@@ -164,7 +170,7 @@ func DecodeCursor(token string) (*Cursor, error) {
 		return nil, err
 	}
 
-	parts := strings.Split(string(decoded), ":")
+	parts := strings.SplitN(string(decoded), ":", 2)
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid cursor format")
 	}
@@ -180,6 +186,7 @@ func DecodeCursor(token string) (*Cursor, error) {
 	}, nil
 }
 
+// CreateNextPageToken returns the opaque next page token of the cursor of the row with the id and the orderBy value.
 func CreateNextPageToken(id int32, value any) string {
 	var valueString string
 
@@ -4,8 +4,15 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // TestInputValidation ensures input validation works correctly
@@ -251,6 +258,15 @@ func TestCursorDecoding(t *testing.T) {
 	}
 }
 
+// TestCursorRoundTrip ensures the values of the tokens, including colons, decode as they were encoded
+func TestCursorRoundTrip(t *testing.T) {
+	for _, value := range []string{"", "1700000000000", "granite:3b:instruct", "org/model:v1"} {
+		cursor, err := DecodeCursor(CreateNextPageToken(42, value))
+		require.NoError(t, err)
+		assert.Equal(t, &Cursor{ID: 42, Value: value}, cursor)
+	}
+}
+
 // createValidCursor creates a valid cursor for testing
 func createValidCursor() string {
 	cursor := "123:test_value"
@@ -263,3 +279,178 @@ func createMaliciousCursor() string {
 	cursor := "1:" + maliciousValue
 	return base64.StdEncoding.EncodeToString([]byte(cursor))
 }
+
+// TestPaginateOrdering ensures every page is ordered by a unique tiebreaker and the cursor matches the ordering
+func TestPaginateOrdering(t *testing.T) {
+	conn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		orderBy       string
+		sortOrder     string
+		token         string
+		columns       map[string]string
+		expectedOrder string
+		expectedWhere string
+		expectedVars  []any
+	}{
+		{
+			name:          "Order by create time",
+			orderBy:       "CREATE_TIME",
+			sortOrder:     "ASC",
+			token:         CreateNextPageToken(7, "1000"),
+			expectedOrder: "ORDER BY `Context`.create_time_since_epoch ASC,`Context`.id ASC",
+			expectedWhere: "WHERE (`Context`.create_time_since_epoch > ? OR (`Context`.create_time_since_epoch = ? AND `Context`.id > ?))",
+			expectedVars:  []any{"1000", "1000", int32(7)},
+		},
+		{
+			name:          "Order by last update time descending",
+			orderBy:       "LAST_UPDATE_TIME",
+			sortOrder:     "DESC",
+			token:         CreateNextPageToken(7, "1000"),
+			expectedOrder: "ORDER BY `Context`.last_update_time_since_epoch DESC,`Context`.id DESC",
+			expectedWhere: "WHERE (`Context`.last_update_time_since_epoch < ? OR (`Context`.last_update_time_since_epoch = ? AND `Context`.id < ?))",
+			expectedVars:  []any{"1000", "1000", int32(7)},
+		},
+		{
+			name:          "Order by id",
+			orderBy:       "ID",
+			sortOrder:     "DESC",
+			token:         CreateNextPageToken(7, "7"),
+			expectedOrder: "ORDER BY `Context`.id DESC",
+			expectedWhere: "WHERE `Context`.id < ?",
+			expectedVars:  []any{int32(7)},
+		},
+		{
+			name:          "No order defaults to id",
+			token:         CreateNextPageToken(7, ""),
+			expectedOrder: "ORDER BY `Context`.id ASC",
+			expectedWhere: "WHERE `Context`.id > ?",
+			expectedVars:  []any{int32(7)},
+		},
+		{
+			name:          "Invalid order defaults to id",
+			orderBy:       "'; DROP TABLE users; --",
+			sortOrder:     "ASC; DROP TABLE users; --",
+			token:         CreateNextPageToken(7, "7"),
+			expectedOrder: "ORDER BY `Context`.id ASC",
+			expectedWhere: "WHERE `Context`.id > ?",
+			expectedVars:  []any{int32(7)},
+		},
+		{
+			name:          "Custom columns",
+			orderBy:       "NAME",
+			sortOrder:     "ASC",
+			token:         CreateNextPageToken(7, "granite"),
+			columns:       map[string]string{"ID": "id", "NAME": "name"},
+			expectedOrder: "ORDER BY `Context`.name ASC,`Context`.id ASC",
+			expectedWhere: "WHERE (`Context`.name > ? OR (`Context`.name = ? AND `Context`.id > ?))",
+			expectedVars:  []any{"granite", "granite", int32(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination := &models.Pagination{
+				PageSize:      apiutils.Of(int32(10)),
+				OrderBy:       &tt.orderBy,
+				SortOrder:     &tt.sortOrder,
+				NextPageToken: &tt.token,
+			}
+
+			var contexts []schema.Context
+			stmt := db.Model(&schema.Context{}).
+				Scopes(PaginateWithOptions(&contexts, pagination, db, "Context", tt.columns)).
+				Find(&contexts).Statement
+
+			sql := stmt.SQL.String()
+			assert.Contains(t, sql, tt.expectedOrder)
+			assert.Contains(t, sql, tt.expectedWhere)
+			assert.Equal(t, tt.expectedVars, stmt.Vars[:len(tt.expectedVars)])
+		})
+	}
+}
+
+func TestPaginateByCustomProperty(t *testing.T) {
+	conn, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+		DryRun: true,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		orderBy       string
+		sortOrder     string
+		token         string
+		expectedValue string
+		expectedOrder string
+		expectedWhere string
+		expectedVars  []any
+	}{
+		{
+			name:          "Numeric value",
+			orderBy:       "customProperties.accuracy",
+			sortOrder:     "DESC",
+			token:         CreateNextPageToken(7, "=0.9"),
+			expectedValue: "COALESCE(double_value, int_value)",
+			expectedOrder: "ORDER BY sort_property.sort_value IS NULL,sort_property.sort_value DESC,`Context`.id DESC",
+			expectedWhere: "WHERE (sort_property.sort_value IS NULL OR sort_property.sort_value < ? OR (sort_property.sort_value = ? AND `Context`.id < ?))",
+			expectedVars:  []any{"accuracy", true, 0.9, 0.9, int32(7)},
+		},
+		{
+			name:          "String value",
+			orderBy:       "customProperties.framework.string_value",
+			sortOrder:     "ASC",
+			token:         CreateNextPageToken(7, "=onnx:1"),
+			expectedValue: "string_value",
+			expectedOrder: "ORDER BY sort_property.sort_value IS NULL,sort_property.sort_value ASC,`Context`.id ASC",
+			expectedWhere: "WHERE (sort_property.sort_value IS NULL OR sort_property.sort_value > ? OR (sort_property.sort_value = ? AND `Context`.id > ?))",
+			expectedVars:  []any{"framework", true, "onnx:1", "onnx:1", int32(7)},
+		},
+		{
+			name:          "After the entities with the property",
+			orderBy:       "customProperties.accuracy",
+			sortOrder:     "ASC",
+			token:         CreateNextPageToken(7, ""),
+			expectedValue: "COALESCE(double_value, int_value)",
+			expectedOrder: "`Context`.id ASC",
+			expectedWhere: "WHERE sort_property.sort_value IS NULL AND `Context`.id > ?",
+			expectedVars:  []any{"accuracy", true, int32(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagination := &models.Pagination{
+				PageSize:      apiutils.Of(int32(10)),
+				OrderBy:       &tt.orderBy,
+				SortOrder:     &tt.sortOrder,
+				NextPageToken: &tt.token,
+			}
+
+			var contexts []schema.Context
+			stmt := db.Model(&schema.Context{}).
+				Scopes(PaginateWithTablePrefix(&contexts, pagination, db, "Context")).
+				Find(&contexts).Statement
+
+			sql := stmt.SQL.String()
+			assert.Contains(t, sql, "SELECT `Context`.* FROM `Context` LEFT JOIN (SELECT context_id AS sort_entity_id, "+tt.expectedValue+" AS sort_value FROM `ContextProperty` WHERE name = ? AND is_custom_property = ?) sort_property ON sort_property.sort_entity_id = `Context`.id")
+			assert.Contains(t, sql, tt.expectedOrder)
+			assert.Contains(t, sql, tt.expectedWhere)
+			assert.Equal(t, tt.expectedVars, stmt.Vars[:len(tt.expectedVars)])
+		})
+	}
+}
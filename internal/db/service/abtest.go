@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrABTestNotFound = errors.New("ab test by id not found")
+
+type ABTestRepositoryImpl struct {
+	*GenericRepository[models.ABTest, schema.Execution, schema.ExecutionProperty, *models.ABTestListOptions]
+}
+
+func NewABTestRepository(db *gorm.DB, typeID int32) models.ABTestRepository {
+	config := GenericRepositoryConfig[models.ABTest, schema.Execution, schema.ExecutionProperty, *models.ABTestListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapABTestToExecution,
+		SchemaToEntity:      mapDataLayerToABTest,
+		EntityToProperties:  mapABTestToExecutionProperties,
+		NotFoundError:       ErrABTestNotFound,
+		EntityName:          "ab test",
+		PropertyFieldName:   "execution_id",
+		ApplyListFilters:    applyABTestListFilters,
+		IsNewEntity:         func(entity models.ABTest) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.ABTest) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &ABTestRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ABTestRepositoryImpl) WithContext(ctx context.Context) models.ABTestRepository {
+	return &ABTestRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *ABTestRepositoryImpl) Save(abTest models.ABTest, registeredModelID *int32) (models.ABTest, error) {
+	return r.GenericRepository.Save(abTest, registeredModelID)
+}
+
+func (r *ABTestRepositoryImpl) List(listOptions models.ABTestListOptions) (*models.ListWrapper[models.ABTest], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyABTestListFilters(query *gorm.DB, listOptions *models.ABTestListOptions) *gorm.DB {
+	if listOptions.RegisteredModelID != nil {
+		query = query.Joins(utils.BuildAssociationJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Association{}, "context_id")+" = ?", listOptions.RegisteredModelID)
+	}
+
+	return query
+}
+
+func mapABTestToExecution(abTest models.ABTest) schema.Execution {
+	attrs := abTest.GetAttributes()
+	execution := schema.Execution{
+		TypeID: *abTest.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if abTest.GetID() != nil {
+		execution.ID = *abTest.GetID()
+	}
+
+	if attrs != nil {
+		execution.Name = attrs.Name
+		execution.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			execution.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			execution.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return execution
+}
+
+func mapABTestToExecutionProperties(abTest models.ABTest, executionID int32) []schema.ExecutionProperty {
+	var properties []schema.ExecutionProperty
+
+	if abTest.GetProperties() != nil {
+		for _, prop := range *abTest.GetProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, false))
+		}
+	}
+
+	if abTest.GetCustomProperties() != nil {
+		for _, prop := range *abTest.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToABTest(abTest schema.Execution, properties []schema.ExecutionProperty) models.ABTest {
+	abTestModel := &models.BaseEntity[models.ABTestAttributes]{
+		ID:     &abTest.ID,
+		TypeID: &abTest.TypeID,
+		Attributes: &models.ABTestAttributes{
+			Name:                     abTest.Name,
+			ExternalID:               abTest.ExternalID,
+			CreateTimeSinceEpoch:     &abTest.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &abTest.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	abTestProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapExecutionPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			abTestProperties = append(abTestProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	abTestModel.Properties = &abTestProperties
+	abTestModel.CustomProperties = &customProperties
+
+	return abTestModel
+}
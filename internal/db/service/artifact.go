@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/datastore"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/schema"
 	"github.com/kubeflow/model-registry/internal/db/scopes"
@@ -36,11 +38,20 @@ func NewArtifactRepository(db *gorm.DB, artifactTypes datastore.ArtifactTypeMap)
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ArtifactRepositoryImpl) WithContext(ctx context.Context) models.ArtifactRepository {
+	return &ArtifactRepositoryImpl{
+		db:       dbutil.Bind(ctx, r.db),
+		nameToID: r.nameToID,
+		idToName: r.idToName,
+	}
+}
+
 func (r *ArtifactRepositoryImpl) GetByID(id int32) (models.Artifact, error) {
 	artifact := &schema.Artifact{}
 	properties := []schema.ArtifactProperty{}
 
-	if err := r.db.Where("id = ?", id).First(artifact).Error; err != nil {
+	if err := whereNamespace(r.db, "Artifact").Where("id = ?", id).First(artifact).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return models.Artifact{}, fmt.Errorf("%w: %v", ErrArtifactNotFound, err)
 		}
@@ -51,6 +62,11 @@ func (r *ArtifactRepositoryImpl) GetByID(id int32) (models.Artifact, error) {
 	if err := r.db.Where("artifact_id = ?", artifact.ID).Find(&properties).Error; err != nil {
 		return models.Artifact{}, fmt.Errorf("error getting properties by artifact id: %w", err)
 	}
+	if err := decryptProperties(r.db, properties); err != nil {
+		return models.Artifact{}, err
+	}
+
+	models.RecordEntityVersion(r.db.Statement.Context, models.EntityVersion{Table: models.VersionedArtifact, ID: artifact.ID, Version: artifact.Version})
 
 	// Use the same logic as mapDataLayerToArtifact to handle all artifact types
 	mappedArtifact, err := r.mapDataLayerToArtifact(*artifact, properties)
@@ -61,6 +77,40 @@ func (r *ArtifactRepositoryImpl) GetByID(id int32) (models.Artifact, error) {
 	return mappedArtifact, nil
 }
 
+// GetByIDs returns the artifacts with the given ids ordered by id, ids not found and the artifacts of the types not
+// listed are skipped.
+func (r *ArtifactRepositoryImpl) GetByIDs(ids []int32) ([]models.Artifact, error) {
+	artifacts := []models.Artifact{}
+	if len(ids) == 0 {
+		return artifacts, nil
+	}
+
+	query := r.excludeUnlistedTypes(whereNamespace(r.db, "Artifact").Where("id IN ?", ids))
+
+	artifactsArt := []schema.Artifact{}
+	if err := query.Order("id").Find(&artifactsArt).Error; err != nil {
+		return nil, fmt.Errorf("error getting artifacts by ids: %w", err)
+	}
+	if len(artifactsArt) == 0 {
+		return artifacts, nil
+	}
+
+	propertiesByArtifact, err := r.getPropertiesByArtifacts(artifactsArt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, artifactArt := range artifactsArt {
+		artifact, err := r.mapDataLayerToArtifact(artifactArt, propertiesByArtifact[artifactArt.ID])
+		if err != nil {
+			return nil, fmt.Errorf("error mapping artifact: %w", err)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
+
 func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*models.ListWrapper[models.Artifact], error) {
 	list := models.ListWrapper[models.Artifact]{
 		PageSize: listOptions.GetPageSize(),
@@ -69,17 +119,14 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 	artifacts := []models.Artifact{}
 	artifactsArt := []schema.Artifact{}
 
-	query := r.db.Model(&schema.Artifact{})
+	query := whereNamespace(r.db.Model(&schema.Artifact{}), "Artifact")
 
-	// Exclude metric history records - they should only be returned via metric history endpoints
-	if metricHistoryTypeID, ok := r.nameToID[defaults.MetricHistoryTypeName]; ok {
-		query = query.Where("type_id != ?", metricHistoryTypeID)
-	}
+	query = r.excludeUnlistedTypes(query)
 
 	if listOptions.Name != nil {
 		// Name is not prefixed with the parent resource id to allow for filtering by name only
 		// Parent resource Id is used later to filter by Attribution.context_id
-		query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
@@ -97,10 +144,26 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 		query = query.Where(utils.GetTableName(r.db, &schema.Artifact{})+".type_id = ?", typeID)
 	}
 
+	if len(listOptions.ArtifactTypes) > 0 {
+		typeIDs := make([]int32, 0, len(listOptions.ArtifactTypes))
+		for _, artifactType := range listOptions.ArtifactTypes {
+			typeID, err := r.getTypeIDFromArtifactType(artifactType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid artifact type %s: %w", artifactType, api.ErrBadRequest)
+			}
+			typeIDs = append(typeIDs, typeID)
+		}
+		query = query.Where(utils.GetTableName(r.db, &schema.Artifact{})+".type_id IN ?", typeIDs)
+	}
+
+	if err := rejectEncryptedOrder(r.db, &listOptions); err != nil {
+		return nil, err
+	}
 	query, err := applyFilterQuery(query, &listOptions, nil)
 	if err != nil {
 		return nil, err
 	}
+	query = applyFullTextSearch(query, &listOptions, "Artifact")
 
 	if listOptions.ParentResourceID != nil {
 		// Proper GORM JOIN: Use helper that respects naming strategy
@@ -110,7 +173,7 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 		// Use table-prefixed pagination to avoid column ambiguity
 		query = query.Scopes(scopes.PaginateWithTablePrefix(artifacts, &listOptions.Pagination, r.db, "Artifact"))
 	} else {
-		query = query.Scopes(scopes.Paginate(artifacts, &listOptions.Pagination, r.db))
+		query = query.Scopes(scopes.PaginateWithTablePrefix(artifacts, &listOptions.Pagination, r.db, "Artifact"))
 	}
 
 	if err := query.Find(&artifactsArt).Error; err != nil {
@@ -126,13 +189,14 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 		}
 	}
 
-	for _, artifactArt := range artifactsArt {
-		properties := []schema.ArtifactProperty{}
-		if err := r.db.Where("artifact_id = ?", artifactArt.ID).Find(&properties).Error; err != nil {
-			return nil, fmt.Errorf("error getting properties by artifact id: %w", err)
-		}
+	// Load the properties of the whole page in one query
+	propertiesByArtifact, err := r.getPropertiesByArtifacts(artifactsArt)
+	if err != nil {
+		return nil, err
+	}
 
-		artifact, err := r.mapDataLayerToArtifact(artifactArt, properties)
+	for _, artifactArt := range artifactsArt {
+		artifact, err := r.mapDataLayerToArtifact(artifactArt, propertiesByArtifact[artifactArt.ID])
 		if err != nil {
 			return nil, fmt.Errorf("error mapping artifact: %w", err)
 		}
@@ -143,7 +207,15 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 		lastArtifact := artifactsArt[len(artifactsArt)-1]
 		orderBy := listOptions.GetOrderBy()
 		value := ""
-		if orderBy != "" {
+		if propertyOrder, ok := scopes.ParseCustomPropertyOrder(orderBy); ok {
+			customProperties := []models.Properties{}
+			for _, prop := range propertiesByArtifact[lastArtifact.ID] {
+				if prop.IsCustomProperty {
+					customProperties = append(customProperties, MapArtifactPropertyToProperties(prop))
+				}
+			}
+			value = propertyOrder.SortValue(customProperties)
+		} else if orderBy != "" {
 			switch orderBy {
 			case "ID":
 				value = fmt.Sprintf("%d", lastArtifact.ID)
@@ -169,6 +241,48 @@ func (r *ArtifactRepositoryImpl) List(listOptions models.ArtifactListOptions) (*
 	return &list, nil
 }
 
+// excludeUnlistedTypes excludes the metric history records, metrics tables and model cards from the query, they are
+// only returned by their own endpoints.
+func (r *ArtifactRepositoryImpl) excludeUnlistedTypes(query *gorm.DB) *gorm.DB {
+	typeIDs := []int32{}
+	for _, typeName := range []string{defaults.MetricHistoryTypeName, defaults.MetricsTableTypeName, defaults.ModelCardTypeName} {
+		if typeID, ok := r.nameToID[typeName]; ok {
+			typeIDs = append(typeIDs, typeID)
+		}
+	}
+	if len(typeIDs) == 0 {
+		return query
+	}
+	return query.Where(utils.GetTableName(query, &schema.Artifact{})+".type_id NOT IN ?", typeIDs)
+}
+
+// getPropertiesByArtifacts returns the properties of artifacts by artifact id, loaded in a single query.
+func (r *ArtifactRepositoryImpl) getPropertiesByArtifacts(artifacts []schema.Artifact) (map[int32][]schema.ArtifactProperty, error) {
+	propertiesByArtifact := make(map[int32][]schema.ArtifactProperty, len(artifacts))
+	if len(artifacts) == 0 {
+		return propertiesByArtifact, nil
+	}
+
+	artifactIDs := make([]int32, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		artifactIDs = append(artifactIDs, artifact.ID)
+	}
+
+	properties := []schema.ArtifactProperty{}
+	if err := r.db.Where("artifact_id IN ?", artifactIDs).Find(&properties).Error; err != nil {
+		return nil, fmt.Errorf("error getting properties by artifact id: %w", err)
+	}
+	if err := decryptProperties(r.db, properties); err != nil {
+		return nil, err
+	}
+
+	for _, property := range properties {
+		propertiesByArtifact[property.ArtifactID] = append(propertiesByArtifact[property.ArtifactID], property)
+	}
+
+	return propertiesByArtifact, nil
+}
+
 // getTypeIDFromArtifactType maps artifact type strings to their corresponding type IDs
 func (r *ArtifactRepositoryImpl) getTypeIDFromArtifactType(artifactType string) (int32, error) {
 	switch openapi.ArtifactTypeQueryParam(artifactType) {
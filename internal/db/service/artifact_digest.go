@@ -0,0 +1,66 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxDigestLength is the size of the digest column of ArtifactDigest, longer digests are not indexed
+const maxDigestLength = 255
+
+// artifactDigest returns the digest custom property of the artifact properties, if any.
+func artifactDigest[TProp PropertyEntity](properties []TProp) (string, bool) {
+	for _, prop := range properties {
+		p, ok := any(prop).(schema.ArtifactProperty)
+		if ok && p.IsCustomProperty && p.Name == models.DigestCustomProperty && p.StringValue != nil && *p.StringValue != "" {
+			return *p.StringValue, len(*p.StringValue) <= maxDigestLength
+		}
+	}
+	return "", false
+}
+
+// indexDigest maps the digest of the saved artifact to it in ArtifactDigest, unless an artifact of the type was saved
+// with the digest before: the first one stays the canonical artifact of the digest. The mapping of a previous digest of
+// the artifact is removed.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) indexDigest(tx *gorm.DB, entityID int32, properties []TProp) error {
+	digest, ok := artifactDigest(properties)
+
+	stale := tx.Where("artifact_id = ?", entityID)
+	if ok {
+		stale = stale.Where("digest <> ?", digest)
+	}
+	if err := stale.Delete(&schema.ArtifactDigest{}).Error; err != nil {
+		return fmt.Errorf("error indexing %s digest: %w", r.config.EntityName, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	mapping := schema.ArtifactDigest{TypeID: r.config.TypeID, Digest: digest, ArtifactID: entityID}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mapping).Error; err != nil {
+		return fmt.Errorf("error indexing %s digest: %w", r.config.EntityName, err)
+	}
+	return nil
+}
+
+// FindByDigest returns the canonical artifact of digest, the first artifact of the type saved with it. The error of a
+// digest without artifact wraps both the not found error of the repository and api.ErrNotFound.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) FindByDigest(digest string) (TEntity, error) {
+	var zeroEntity TEntity
+
+	var mapping schema.ArtifactDigest
+	if err := r.config.DB.Where("type_id = ? AND digest = ?", r.config.TypeID, digest).First(&mapping).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return zeroEntity, fmt.Errorf("%w: no %s with digest %s: %w", r.config.NotFoundError, r.config.EntityName, digest, api.ErrNotFound)
+		}
+		return zeroEntity, fmt.Errorf("error getting %s by digest: %w", r.config.EntityName, err)
+	}
+
+	return r.GetByID(mapping.ArtifactID)
+}
@@ -1,11 +1,13 @@
 package service_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/budget"
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/service"
 	"github.com/kubeflow/model-registry/internal/defaults"
@@ -504,4 +506,35 @@ func TestArtifactRepository(t *testing.T) {
 		assert.False(t, foundStandaloneModel, "Should NOT find standalone model artifact when filtering by model version")
 		assert.False(t, foundStandaloneDoc, "Should NOT find standalone doc artifact when filtering by model version")
 	})
+	t.Run("TestListLoadsPropertiesInOneQuery", func(t *testing.T) {
+		modelArtifactRepo := service.NewModelArtifactRepository(sharedDB, modelArtifactTypeID)
+		for i := range 5 {
+			_, err := modelArtifactRepo.Save(&models.ModelArtifactImpl{
+				TypeID: apiutils.Of(modelArtifactTypeID),
+				Attributes: &models.ModelArtifactAttributes{
+					Name:         apiutils.Of(fmt.Sprintf("batched-model-artifact-%d", i)),
+					URI:          apiutils.Of(fmt.Sprintf("s3://bucket/batched-%d.pkl", i)),
+					ArtifactType: apiutils.Of("model-artifact"),
+				},
+				Properties: &[]models.Properties{
+					{Name: "model_format_name", StringValue: apiutils.Of("onnx")},
+				},
+			}, nil)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, budget.Register(sharedDB))
+		queryBudget := budget.New(budget.Limits{})
+		ctx := budget.NewContext(context.Background(), queryBudget)
+
+		listOptions := models.ArtifactListOptions{}
+		listOptions.PageSize = apiutils.Of(int32(100))
+		result, err := repo.(*service.ArtifactRepositoryImpl).WithContext(ctx).List(listOptions)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(result.Items), 5)
+
+		// one query for the page of artifacts and one for all their properties
+		statements, _ := queryBudget.Used()
+		assert.Equal(t, int64(2), statements)
+	})
 }
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// auditEventOrderByColumns are the orderBy columns of the audit events, which are never updated
+var auditEventOrderByColumns = map[string]string{
+	"ID":          "id",
+	"CREATE_TIME": "create_time_since_epoch",
+	"id":          "id",
+}
+
+// auditTransitionFields are the fields whose changes make an update a transition
+var auditTransitionFields = []string{"state", "desired_state", "stage", "customProperties.stage"}
+
+// auditIgnoredFields are the columns of the entities left out of the changes, set by every write or never changed
+var auditIgnoredFields = []string{"id", "type_id", "version", "namespace", "create_time_since_epoch", "last_update_time_since_epoch"}
+
+type AuditEventRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditEventRepository(db *gorm.DB) models.AuditEventRepository {
+	return &AuditEventRepositoryImpl{db: db}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *AuditEventRepositoryImpl) WithContext(ctx context.Context) models.AuditEventRepository {
+	return &AuditEventRepositoryImpl{db: dbutil.Bind(ctx, r.db)}
+}
+
+func (r *AuditEventRepositoryImpl) List(listOptions models.AuditEventListOptions) (*models.ListWrapper[models.AuditEvent], error) {
+	list := models.ListWrapper[models.AuditEvent]{
+		PageSize: listOptions.GetPageSize(),
+		Items:    []models.AuditEvent{},
+	}
+
+	var events []schema.AuditEvent
+	query := whereNamespace(r.db.Model(&schema.AuditEvent{}), schema.TableNameAuditEvent)
+	if listOptions.Actor != nil {
+		query = query.Where("actor = ?", *listOptions.Actor)
+	}
+	if listOptions.Action != nil {
+		query = query.Where("action = ?", *listOptions.Action)
+	}
+	if listOptions.EntityKind != nil {
+		query = query.Where("entity_kind = ?", *listOptions.EntityKind)
+	}
+	if listOptions.TypeID != nil {
+		query = query.Where("type_id = ?", *listOptions.TypeID)
+	}
+	if listOptions.EntityID != nil {
+		query = query.Where("entity_id = ?", *listOptions.EntityID)
+	}
+	if listOptions.Since != nil {
+		query = query.Where("create_time_since_epoch >= ?", *listOptions.Since)
+	}
+	if listOptions.Until != nil {
+		query = query.Where("create_time_since_epoch <= ?", *listOptions.Until)
+	}
+
+	query = query.Scopes(scopes.PaginateWithOptions(&events, &listOptions.Pagination, r.db, schema.TableNameAuditEvent, auditEventOrderByColumns))
+	if err := query.Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	hasMore := false
+	if pageSize := listOptions.GetPageSize(); pageSize > 0 && len(events) > int(pageSize) {
+		hasMore = true
+		events = events[:pageSize]
+	}
+
+	for _, event := range events {
+		mapped, err := mapDataLayerToAuditEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, mapped)
+	}
+
+	if hasMore {
+		last := events[len(events)-1]
+		value := fmt.Sprintf("%d", last.ID)
+		if listOptions.GetOrderBy() == "CREATE_TIME" {
+			value = fmt.Sprintf("%d", last.CreateTimeSinceEpoch)
+		}
+		list.NextPageToken = scopes.CreateNextPageToken(last.ID, value)
+	}
+	list.Size = int32(len(list.Items))
+
+	return &list, nil
+}
+
+func mapDataLayerToAuditEvent(event schema.AuditEvent) (models.AuditEvent, error) {
+	var changes []models.AuditChange
+	if err := json.Unmarshal([]byte(event.Changes), &changes); err != nil {
+		return models.AuditEvent{}, fmt.Errorf("error reading the changes of audit event %d: %w", event.ID, err)
+	}
+	return models.AuditEvent{
+		ID:                   &event.ID,
+		Action:               event.Action,
+		Actor:                event.Actor,
+		EntityKind:           event.EntityKind,
+		TypeID:               event.TypeID,
+		EntityID:             event.EntityID,
+		Namespace:            event.Namespace,
+		Changes:              changes,
+		CreateTimeSinceEpoch: &event.CreateTimeSinceEpoch,
+	}, nil
+}
+
+// recordAuditEvent records the write of an entity in the audit log, in the transaction of the write, if the context
+// of tx has an actor. The action is the one of the write, or a transition for the updates of the state or stage; the
+// updates changing nothing are not recorded.
+func recordAuditEvent(tx *gorm.DB, action string, entityKind string, typeID, entityID int32, namespace string, before, after map[string]any) error {
+	actor, ok := api.Actor(tx.Statement.Context)
+	if !ok {
+		return nil
+	}
+
+	changes := diffAuditFields(before, after)
+	if action == models.AuditActionUpdate {
+		if len(changes) == 0 {
+			return nil
+		}
+		if slices.ContainsFunc(changes, func(change models.AuditChange) bool { return slices.Contains(auditTransitionFields, change.Field) }) {
+			action = models.AuditActionTransition
+		}
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+	event := schema.AuditEvent{
+		Action:               action,
+		Actor:                actor,
+		EntityKind:           entityKind,
+		TypeID:               typeID,
+		EntityID:             entityID,
+		Namespace:            namespace,
+		Changes:              string(data),
+		CreateTimeSinceEpoch: time.Now().UnixMilli(),
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return fmt.Errorf("error recording audit event: %w", err)
+	}
+	return nil
+}
+
+// RecordContextDeletions records the deletions of contexts outside of the repositories in the audit log, such as the
+// purges of the recycle bin. It runs in the transaction of the deletions, before the contexts and their properties
+// are deleted.
+func RecordContextDeletions(tx *gorm.DB, contexts []schema.Context) error {
+	if _, ok := api.Actor(tx.Statement.Context); !ok {
+		return nil
+	}
+
+	for _, context := range contexts {
+		var properties []schema.ContextProperty
+		if err := tx.Where("context_id = ?", context.ID).Find(&properties).Error; err != nil {
+			return fmt.Errorf("error reading context properties for its audit event: %w", err)
+		}
+		values := make(map[string]any, len(properties))
+		for _, p := range properties {
+			values[auditPropertyName(p.Name, p.IsCustomProperty)] = propertyValue(p.IntValue, p.DoubleValue, p.StringValue, p.BoolValue, p.ByteValue, p.ProtoValue)
+		}
+
+		before, err := auditFields(context, values)
+		if err != nil {
+			return fmt.Errorf("error reading context for its audit event: %w", err)
+		}
+		if err := recordAuditEvent(tx, models.AuditActionDelete, models.AuditEntityKindContext, context.TypeID, context.ID, context.Namespace, before, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordArtifactDeletions records the deletions of artifacts outside of the repositories in the audit log, like
+// RecordContextDeletions.
+func RecordArtifactDeletions(tx *gorm.DB, artifacts []schema.Artifact) error {
+	if _, ok := api.Actor(tx.Statement.Context); !ok {
+		return nil
+	}
+
+	for _, artifact := range artifacts {
+		var properties []schema.ArtifactProperty
+		if err := tx.Where("artifact_id = ?", artifact.ID).Find(&properties).Error; err != nil {
+			return fmt.Errorf("error reading artifact properties for its audit event: %w", err)
+		}
+		values := make(map[string]any, len(properties))
+		for _, p := range properties {
+			values[auditPropertyName(p.Name, p.IsCustomProperty)] = propertyValue(p.IntValue, p.DoubleValue, p.StringValue, p.BoolValue, p.ByteValue, p.ProtoValue)
+		}
+
+		before, err := auditFields(artifact, values)
+		if err != nil {
+			return fmt.Errorf("error reading artifact for its audit event: %w", err)
+		}
+		if err := recordAuditEvent(tx, models.AuditActionDelete, models.AuditEntityKindArtifact, artifact.TypeID, artifact.ID, artifact.Namespace, before, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditPropertyName returns the field of a property in the audit events, the custom properties are prefixed.
+func auditPropertyName(name string, custom bool) string {
+	if custom {
+		return "customProperties." + name
+	}
+	return name
+}
+
+// auditFields returns the fields of entity, the columns of its JSON encoding but the auditIgnoredFields, with values
+// normalized by a JSON round trip so that they compare equal once read back.
+func auditFields(entity any, properties map[string]any) (map[string]any, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, field := range auditIgnoredFields {
+		delete(fields, field)
+	}
+	maps.Copy(fields, properties)
+
+	if data, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	normalized := map[string]any{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// diffAuditFields returns the changes between the fields before and after a write, sorted by field, the null fields
+// are the same as the missing ones.
+func diffAuditFields(before, after map[string]any) []models.AuditChange {
+	changes := []models.AuditChange{}
+	for field, value := range before {
+		if _, ok := after[field]; !ok && value != nil {
+			changes = append(changes, models.AuditChange{Field: field, Before: value})
+		}
+	}
+	for field, value := range after {
+		if !reflect.DeepEqual(before[field], value) {
+			changes = append(changes, models.AuditChange{Field: field, Before: before[field], After: value})
+		}
+	}
+	slices.SortFunc(changes, func(a, b models.AuditChange) int { return cmp.Compare(a.Field, b.Field) })
+	return changes
+}
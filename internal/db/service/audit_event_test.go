@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEventRepository(t *testing.T) {
+	sharedDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	typeID := getRegisteredModelTypeID(t, sharedDB)
+	ctx := api.WithActor(context.Background(), "alice")
+	repo := service.NewRegisteredModelRepository(sharedDB.WithContext(ctx), typeID)
+	auditRepo := service.NewAuditEventRepository(sharedDB)
+
+	registeredModel := &models.RegisteredModelImpl{
+		TypeID: apiutils.Of(typeID),
+		Attributes: &models.RegisteredModelAttributes{
+			Name: apiutils.Of("audited-model"),
+		},
+		Properties: &[]models.Properties{
+			{Name: "state", StringValue: apiutils.Of("LIVE")},
+		},
+		CustomProperties: &[]models.Properties{
+			{Name: "team", StringValue: apiutils.Of("fraud")},
+		},
+	}
+	saved, err := repo.Save(registeredModel)
+	require.NoError(t, err)
+
+	update := func(state string, team string) {
+		registeredModel.ID = saved.GetID()
+		registeredModel.GetAttributes().CreateTimeSinceEpoch = saved.GetAttributes().CreateTimeSinceEpoch
+		registeredModel.Properties = &[]models.Properties{{Name: "state", StringValue: apiutils.Of(state)}}
+		registeredModel.CustomProperties = &[]models.Properties{{Name: "team", StringValue: apiutils.Of(team)}}
+		_, err := repo.Save(registeredModel)
+		require.NoError(t, err)
+	}
+	update("LIVE", "risk")
+	update("LIVE", "risk")
+	update("ARCHIVED", "risk")
+
+	// the writes without an actor are not audited
+	_, err = service.NewRegisteredModelRepository(sharedDB, typeID).Save(&models.RegisteredModelImpl{
+		TypeID:     apiutils.Of(typeID),
+		Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("unaudited-model")},
+	})
+	require.NoError(t, err)
+
+	events, err := auditRepo.List(models.AuditEventListOptions{})
+	require.NoError(t, err)
+	require.Len(t, events.Items, 3, "the update changing nothing is not recorded")
+
+	created := events.Items[0]
+	assert.Equal(t, models.AuditActionCreate, created.Action)
+	assert.Equal(t, "alice", created.Actor)
+	assert.Equal(t, models.AuditEntityKindContext, created.EntityKind)
+	assert.Equal(t, typeID, created.TypeID)
+	assert.Equal(t, *saved.GetID(), created.EntityID)
+	assert.Contains(t, created.Changes, models.AuditChange{Field: "name", After: "audited-model"})
+	assert.Contains(t, created.Changes, models.AuditChange{Field: "customProperties.team", After: "fraud"})
+
+	assert.Equal(t, models.AuditActionUpdate, events.Items[1].Action)
+	assert.Equal(t, []models.AuditChange{{Field: "customProperties.team", Before: "fraud", After: "risk"}}, events.Items[1].Changes)
+
+	assert.Equal(t, models.AuditActionTransition, events.Items[2].Action)
+	assert.Equal(t, []models.AuditChange{{Field: "state", Before: "LIVE", After: "ARCHIVED"}}, events.Items[2].Changes)
+
+	t.Run("Filters", func(t *testing.T) {
+		events, err := auditRepo.List(models.AuditEventListOptions{Action: apiutils.Of(models.AuditActionTransition)})
+		require.NoError(t, err)
+		assert.Len(t, events.Items, 1)
+
+		events, err = auditRepo.List(models.AuditEventListOptions{Actor: apiutils.Of("bob")})
+		require.NoError(t, err)
+		assert.Empty(t, events.Items)
+
+		events, err = auditRepo.List(models.AuditEventListOptions{TypeID: apiutils.Of(typeID), EntityID: saved.GetID()})
+		require.NoError(t, err)
+		assert.Len(t, events.Items, 3)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		page, err := auditRepo.List(models.AuditEventListOptions{Pagination: models.Pagination{PageSize: apiutils.Of(int32(2))}})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 2)
+		require.NotEmpty(t, page.NextPageToken)
+
+		next, err := auditRepo.List(models.AuditEventListOptions{Pagination: models.Pagination{
+			PageSize:      apiutils.Of(int32(2)),
+			NextPageToken: apiutils.Of(page.NextPageToken),
+		}})
+		require.NoError(t, err)
+		require.Len(t, next.Items, 1)
+		assert.Equal(t, models.AuditActionTransition, next.Items[0].Action)
+		assert.Empty(t, next.NextPageToken)
+	})
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBatchedPropertyWrites saves the same changes of properties with both write paths, which must agree.
+func TestBatchedPropertyWrites(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	repo := service.NewMetricRepository(db, getMetricTypeID(t, db))
+
+	for _, batched := range []bool{false, true} {
+		t.Run(fmt.Sprintf("batched=%t", batched), func(t *testing.T) {
+			defer service.SetBatchPropertyWrites(batched)()
+
+			saved, err := repo.Save(&models.MetricImpl{
+				TypeID:     apiutils.Of(getMetricTypeID(t, db)),
+				Attributes: &models.MetricAttributes{Name: apiutils.Of(fmt.Sprintf("loss-%t", batched))},
+				Properties: &[]models.Properties{
+					{Name: "description", StringValue: apiutils.Of("training loss")},
+					{Name: "value", DoubleValue: apiutils.Of(0.5)},
+				},
+				CustomProperties: &[]models.Properties{
+					{Name: "epoch", IntValue: apiutils.Of(int32(1)), IsCustomProperty: true},
+					{Name: "optimizer", StringValue: apiutils.Of("adam"), IsCustomProperty: true},
+				},
+			}, nil)
+			require.NoError(t, err)
+
+			updated, err := repo.Save(&models.MetricImpl{
+				ID:         saved.GetID(),
+				TypeID:     saved.GetTypeID(),
+				Attributes: saved.GetAttributes(),
+				Properties: &[]models.Properties{
+					// cleared by a merge patch
+					{Name: "description"},
+					{Name: "value", DoubleValue: apiutils.Of(0.25)},
+				},
+				CustomProperties: &[]models.Properties{
+					{Name: "epoch", StringValue: apiutils.Of("last"), IsCustomProperty: true},
+					{Name: "batch", IntValue: apiutils.Of(int32(64)), IsCustomProperty: true},
+				},
+			}, nil)
+			require.NoError(t, err)
+
+			properties := map[string]models.Properties{}
+			for _, prop := range *updated.GetProperties() {
+				properties[prop.Name] = prop
+			}
+			assert.NotContains(t, properties, "description")
+			assert.Equal(t, 0.25, *properties["value"].DoubleValue)
+
+			customProperties := map[string]models.Properties{}
+			for _, prop := range *updated.GetCustomProperties() {
+				customProperties[prop.Name] = prop
+			}
+			assert.Len(t, customProperties, 2, "optimizer is removed")
+			assert.Equal(t, "last", *customProperties["epoch"].StringValue)
+			assert.Equal(t, int32(64), *customProperties["batch"].IntValue)
+		})
+	}
+}
+
+// BenchmarkSaveProperties compares the batched property writes of MySQL with the writes of a property at a time, for
+// metric-heavy artifacts updating many custom properties.
+func BenchmarkSaveProperties(b *testing.B) {
+	// Setup test database - convert to testing.T for compatibility
+	t := &testing.T{}
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	typeID := getMetricTypeID(t, db)
+	repo := service.NewMetricRepository(db, typeID)
+
+	for _, count := range []int{10, 100, 1000} {
+		for _, batched := range []bool{false, true} {
+			b.Run(fmt.Sprintf("properties=%d/batched=%t", count, batched), func(b *testing.B) {
+				defer service.SetBatchPropertyWrites(batched)()
+
+				metric := &models.MetricImpl{
+					TypeID:     apiutils.Of(typeID),
+					Attributes: &models.MetricAttributes{Name: apiutils.Of(fmt.Sprintf("metrics-%d-%t", count, batched))},
+				}
+				step := 0
+				for b.Loop() {
+					customProperties := make([]models.Properties, 0, count)
+					for i := range count {
+						customProperties = append(customProperties, models.Properties{
+							Name:             fmt.Sprintf("step-%d", i),
+							IsCustomProperty: true,
+							DoubleValue:      apiutils.Of(float64(step * i)),
+						})
+					}
+					metric.CustomProperties = &customProperties
+					step++
+
+					saved, err := repo.Save(metric, nil)
+					require.NoError(b, err)
+					metric.ID = saved.GetID()
+				}
+			})
+		}
+	}
+}
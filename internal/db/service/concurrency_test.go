@@ -0,0 +1,222 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrentWriters is the number of goroutines saving the same context at once
+const concurrentWriters = 16
+
+// TestConcurrentSaves saves the same contexts from many goroutines, as bulk importers do: deadlocks between the
+// transactions must be retried and never reach the caller, and the saved properties must not be duplicated.
+func TestConcurrentSaves(t *testing.T) {
+	sharedDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	registeredModelTypeID := getRegisteredModelTypeID(t, sharedDB)
+	registeredModelRepo := service.NewRegisteredModelRepository(sharedDB, registeredModelTypeID)
+	modelVersionTypeID := getModelVersionTypeID(t, sharedDB)
+	modelVersionRepo := service.NewModelVersionRepository(sharedDB, modelVersionTypeID)
+
+	propertyNames := []string{"owner", "team", "stage", "framework", "license", "source"}
+	properties := func(writer int) []models.Properties {
+		props := make([]models.Properties, 0, len(propertyNames))
+		for _, name := range propertyNames {
+			props = append(props, models.Properties{
+				Name:             name,
+				IsCustomProperty: true,
+				StringValue:      apiutils.Of(fmt.Sprintf("writer-%d", writer)),
+			})
+		}
+		// each writer updates the property rows in a different order, a classic deadlock
+		rand.Shuffle(len(props), func(i, j int) { props[i], props[j] = props[j], props[i] })
+		return props
+	}
+
+	// run calls save from concurrentWriters goroutines at once and returns their errors.
+	run := func(save func(writer int) error) []error {
+		var (
+			wg    sync.WaitGroup
+			start = make(chan struct{})
+			errs  = make([]error, concurrentWriters)
+		)
+		for writer := range concurrentWriters {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				errs[writer] = save(writer)
+			}()
+		}
+		close(start)
+		wg.Wait()
+		return errs
+	}
+
+	t.Run("updates of the same registered model", func(t *testing.T) {
+		created, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+			TypeID:           apiutils.Of(registeredModelTypeID),
+			Attributes:       &models.RegisteredModelAttributes{Name: apiutils.Of("concurrent-model")},
+			CustomProperties: apiutils.Of(properties(-1)),
+		})
+		require.NoError(t, err)
+
+		errs := run(func(writer int) error {
+			_, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+				ID:     created.GetID(),
+				TypeID: apiutils.Of(registeredModelTypeID),
+				Attributes: &models.RegisteredModelAttributes{
+					Name:                 apiutils.Of("concurrent-model"),
+					CreateTimeSinceEpoch: created.GetAttributes().CreateTimeSinceEpoch,
+				},
+				CustomProperties: apiutils.Of(properties(writer)),
+			})
+			return err
+		})
+		for writer, err := range errs {
+			assert.NoError(t, err, "writer %d", writer)
+		}
+
+		saved, err := registeredModelRepo.GetByID(*created.GetID())
+		require.NoError(t, err)
+		require.Len(t, *saved.GetCustomProperties(), len(propertyNames))
+		for _, prop := range *saved.GetCustomProperties() {
+			assert.Regexp(t, `^writer-\d+$`, *prop.StringValue, "property %s", prop.Name)
+		}
+	})
+
+	t.Run("versions of the same registered model", func(t *testing.T) {
+		parent, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+			TypeID:     apiutils.Of(registeredModelTypeID),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("concurrent-parent")},
+		})
+		require.NoError(t, err)
+
+		errs := run(func(writer int) error {
+			_, err := modelVersionRepo.Save(&models.ModelVersionImpl{
+				TypeID: apiutils.Of(modelVersionTypeID),
+				Attributes: &models.ModelVersionAttributes{
+					Name: apiutils.Of(fmt.Sprintf("%d:v%d", *parent.GetID(), writer)),
+				},
+				Properties: &[]models.Properties{
+					{Name: "registered_model_id", IntValue: parent.GetID()},
+				},
+				CustomProperties: apiutils.Of(properties(writer)),
+			})
+			return err
+		})
+		for writer, err := range errs {
+			assert.NoError(t, err, "writer %d", writer)
+		}
+
+		pageSize := int32(concurrentWriters * 2)
+		versions, err := modelVersionRepo.List(models.ModelVersionListOptions{
+			Pagination:       models.Pagination{PageSize: &pageSize},
+			ParentResourceID: parent.GetID(),
+		})
+		require.NoError(t, err)
+		require.Len(t, versions.Items, concurrentWriters)
+		for _, version := range versions.Items {
+			assert.Len(t, *version.GetCustomProperties(), len(propertyNames), "version %s", *version.GetAttributes().Name)
+		}
+	})
+}
+
+// TestOptimisticConcurrency updates a registered model with the version it was read at, as editors do with the
+// If-Match header: the updates of an outdated version must be rejected and leave the model unchanged.
+func TestOptimisticConcurrency(t *testing.T) {
+	sharedDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	registeredModelTypeID := getRegisteredModelTypeID(t, sharedDB)
+	registeredModelRepo := service.NewRegisteredModelRepository(sharedDB, registeredModelTypeID)
+	bind := func(ctx context.Context) models.RegisteredModelRepository {
+		return registeredModelRepo.(interface {
+			WithContext(context.Context) models.RegisteredModelRepository
+		}).WithContext(ctx)
+	}
+
+	created, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+		TypeID:     apiutils.Of(registeredModelTypeID),
+		Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("edited-model")},
+	})
+	require.NoError(t, err)
+	id := *created.GetID()
+
+	// update saves the owner of the model, with the version it was read at if any, and returns its new version
+	update := func(owner string, readAt *int32) (int32, error) {
+		ctx, versions := models.WithEntityVersions(context.Background())
+		if readAt != nil {
+			ctx = models.WithIfMatch(ctx, models.EntityVersion{Table: models.VersionedContext, ID: id, Version: *readAt})
+		}
+		_, err := bind(ctx).Save(&models.RegisteredModelImpl{
+			ID:     &id,
+			TypeID: apiutils.Of(registeredModelTypeID),
+			Attributes: &models.RegisteredModelAttributes{
+				Name:                 apiutils.Of("edited-model"),
+				CreateTimeSinceEpoch: created.GetAttributes().CreateTimeSinceEpoch,
+			},
+			CustomProperties: &[]models.Properties{{Name: "owner", IsCustomProperty: true, StringValue: &owner}},
+		})
+		version, _ := versions.Get(models.VersionedContext, id)
+		return version, err
+	}
+
+	ctx, versions := models.WithEntityVersions(context.Background())
+	_, err = bind(ctx).GetByID(id)
+	require.NoError(t, err)
+	readAt, ok := versions.Get(models.VersionedContext, id)
+	require.True(t, ok)
+	assert.Equal(t, int32(1), readAt)
+
+	version, err := update("alice", &readAt)
+	require.NoError(t, err)
+	assert.Equal(t, readAt+1, version)
+
+	// the other editor read the model before the first update
+	_, err = update("bob", &readAt)
+	require.ErrorIs(t, err, api.ErrPreconditionFailed)
+	saved, err := registeredModelRepo.GetByID(id)
+	require.NoError(t, err)
+	require.Len(t, *saved.GetCustomProperties(), 1)
+	assert.Equal(t, "alice", *(*saved.GetCustomProperties())[0].StringValue)
+
+	// the updates without a version always apply
+	version, err = update("carol", nil)
+	require.NoError(t, err)
+	assert.Equal(t, readAt+2, version)
+
+	// only one of the editors having read the same version updates the model
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for writer := range concurrentWriters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := update(fmt.Sprintf("writer-%d", writer), &version)
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+				return
+			}
+			assert.ErrorIs(t, err, api.ErrPreconditionFailed, "writer %d", writer)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, succeeded)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrConversionJobNotFound = errors.New("conversion job by id not found")
+
+type ConversionJobRepositoryImpl struct {
+	*GenericRepository[models.ConversionJob, schema.Execution, schema.ExecutionProperty, *models.ConversionJobListOptions]
+}
+
+func NewConversionJobRepository(db *gorm.DB, typeID int32) models.ConversionJobRepository {
+	config := GenericRepositoryConfig[models.ConversionJob, schema.Execution, schema.ExecutionProperty, *models.ConversionJobListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapConversionJobToExecution,
+		SchemaToEntity:      mapDataLayerToConversionJob,
+		EntityToProperties:  mapConversionJobToExecutionProperties,
+		NotFoundError:       ErrConversionJobNotFound,
+		EntityName:          "conversion job",
+		PropertyFieldName:   "execution_id",
+		ApplyListFilters:    applyConversionJobListFilters,
+		IsNewEntity:         func(entity models.ConversionJob) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.ConversionJob) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &ConversionJobRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ConversionJobRepositoryImpl) WithContext(ctx context.Context) models.ConversionJobRepository {
+	return &ConversionJobRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *ConversionJobRepositoryImpl) Save(conversionJob models.ConversionJob, modelVersionID *int32) (models.ConversionJob, error) {
+	return r.GenericRepository.Save(conversionJob, modelVersionID)
+}
+
+func (r *ConversionJobRepositoryImpl) List(listOptions models.ConversionJobListOptions) (*models.ListWrapper[models.ConversionJob], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyConversionJobListFilters(query *gorm.DB, listOptions *models.ConversionJobListOptions) *gorm.DB {
+	if listOptions.ModelVersionID != nil {
+		query = query.Joins(utils.BuildAssociationJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Association{}, "context_id")+" = ?", listOptions.ModelVersionID)
+	}
+
+	return query
+}
+
+func mapConversionJobToExecution(conversionJob models.ConversionJob) schema.Execution {
+	attrs := conversionJob.GetAttributes()
+	execution := schema.Execution{
+		TypeID: *conversionJob.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if conversionJob.GetID() != nil {
+		execution.ID = *conversionJob.GetID()
+	}
+
+	if attrs != nil {
+		execution.Name = attrs.Name
+		execution.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			execution.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			execution.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return execution
+}
+
+func mapConversionJobToExecutionProperties(conversionJob models.ConversionJob, executionID int32) []schema.ExecutionProperty {
+	var properties []schema.ExecutionProperty
+
+	if conversionJob.GetProperties() != nil {
+		for _, prop := range *conversionJob.GetProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, false))
+		}
+	}
+
+	if conversionJob.GetCustomProperties() != nil {
+		for _, prop := range *conversionJob.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToConversionJob(conversionJob schema.Execution, properties []schema.ExecutionProperty) models.ConversionJob {
+	conversionJobModel := &models.BaseEntity[models.ConversionJobAttributes]{
+		ID:     &conversionJob.ID,
+		TypeID: &conversionJob.TypeID,
+		Attributes: &models.ConversionJobAttributes{
+			Name:                     conversionJob.Name,
+			ExternalID:               conversionJob.ExternalID,
+			CreateTimeSinceEpoch:     &conversionJob.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &conversionJob.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	jobProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapExecutionPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			jobProperties = append(jobProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	conversionJobModel.Properties = &jobProperties
+	conversionJobModel.CustomProperties = &customProperties
+
+	return conversionJobModel
+}
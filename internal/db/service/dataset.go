@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -37,6 +37,13 @@ func NewDataSetRepository(db *gorm.DB, typeID int32) models.DataSetRepository {
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *DataSetRepositoryImpl) WithContext(ctx context.Context) models.DataSetRepository {
+	return &DataSetRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 // List adapts the generic repository List method to match the interface contract
 func (r *DataSetRepositoryImpl) List(listOptions models.DataSetListOptions) (*models.ListWrapper[models.DataSet], error) {
 	return r.GenericRepository.List(&listOptions)
@@ -44,7 +51,7 @@ func (r *DataSetRepositoryImpl) List(listOptions models.DataSetListOptions) (*mo
 
 func applyDataSetListFilters(query *gorm.DB, listOptions *models.DataSetListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrDeploymentNotFound = errors.New("deployment by id not found")
+
+type DeploymentRepositoryImpl struct {
+	*GenericRepository[models.Deployment, schema.Execution, schema.ExecutionProperty, *models.DeploymentListOptions]
+}
+
+func NewDeploymentRepository(db *gorm.DB, typeID int32) models.DeploymentRepository {
+	config := GenericRepositoryConfig[models.Deployment, schema.Execution, schema.ExecutionProperty, *models.DeploymentListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapDeploymentToExecution,
+		SchemaToEntity:      mapDataLayerToDeployment,
+		EntityToProperties:  mapDeploymentToExecutionProperties,
+		NotFoundError:       ErrDeploymentNotFound,
+		EntityName:          "deployment",
+		PropertyFieldName:   "execution_id",
+		ApplyListFilters:    applyDeploymentListFilters,
+		IsNewEntity:         func(entity models.Deployment) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.Deployment) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &DeploymentRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *DeploymentRepositoryImpl) WithContext(ctx context.Context) models.DeploymentRepository {
+	return &DeploymentRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *DeploymentRepositoryImpl) Save(deployment models.Deployment, inferenceServiceID *int32) (models.Deployment, error) {
+	return r.GenericRepository.Save(deployment, inferenceServiceID)
+}
+
+func (r *DeploymentRepositoryImpl) List(listOptions models.DeploymentListOptions) (*models.ListWrapper[models.Deployment], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyDeploymentListFilters(query *gorm.DB, listOptions *models.DeploymentListOptions) *gorm.DB {
+	if listOptions.InferenceServiceID != nil {
+		query = query.Joins(utils.BuildAssociationJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Association{}, "context_id")+" = ?", listOptions.InferenceServiceID)
+	}
+
+	return query
+}
+
+func mapDeploymentToExecution(deployment models.Deployment) schema.Execution {
+	attrs := deployment.GetAttributes()
+	execution := schema.Execution{
+		TypeID: *deployment.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if deployment.GetID() != nil {
+		execution.ID = *deployment.GetID()
+	}
+
+	if attrs != nil {
+		execution.Name = attrs.Name
+		execution.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			execution.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			execution.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return execution
+}
+
+func mapDeploymentToExecutionProperties(deployment models.Deployment, executionID int32) []schema.ExecutionProperty {
+	var properties []schema.ExecutionProperty
+
+	if deployment.GetProperties() != nil {
+		for _, prop := range *deployment.GetProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, false))
+		}
+	}
+
+	if deployment.GetCustomProperties() != nil {
+		for _, prop := range *deployment.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToDeployment(deployment schema.Execution, properties []schema.ExecutionProperty) models.Deployment {
+	deploymentModel := &models.BaseEntity[models.DeploymentAttributes]{
+		ID:     &deployment.ID,
+		TypeID: &deployment.TypeID,
+		Attributes: &models.DeploymentAttributes{
+			Name:                     deployment.Name,
+			ExternalID:               deployment.ExternalID,
+			CreateTimeSinceEpoch:     &deployment.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &deployment.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	deploymentProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapExecutionPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			deploymentProperties = append(deploymentProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	deploymentModel.Properties = &deploymentProperties
+	deploymentModel.CustomProperties = &customProperties
+
+	return deploymentModel
+}
@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -30,6 +30,7 @@ func NewDocArtifactRepository(db *gorm.DB, typeID int32) models.DocArtifactRepos
 		ApplyListFilters:    applyDocArtifactListFilters,
 		IsNewEntity:         func(entity models.DocArtifact) bool { return entity.GetID() == nil },
 		HasCustomProperties: func(entity models.DocArtifact) bool { return entity.GetCustomProperties() != nil },
+		IndexDigests:        true,
 	}
 
 	return &DocArtifactRepositoryImpl{
@@ -37,13 +38,20 @@ func NewDocArtifactRepository(db *gorm.DB, typeID int32) models.DocArtifactRepos
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *DocArtifactRepositoryImpl) WithContext(ctx context.Context) models.DocArtifactRepository {
+	return &DocArtifactRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *DocArtifactRepositoryImpl) List(listOptions models.DocArtifactListOptions) (*models.ListWrapper[models.DocArtifact], error) {
 	return r.GenericRepository.List(&listOptions)
 }
 
 func applyDocArtifactListFilters(query *gorm.DB, listOptions *models.DocArtifactListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
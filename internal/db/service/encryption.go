@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/internal/encryption"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// propertyString returns the name of the property, whether it is a custom property and its string value.
+func propertyString(prop any) (string, bool, **string) {
+	switch p := prop.(type) {
+	case *schema.ArtifactProperty:
+		return p.Name, p.IsCustomProperty, &p.StringValue
+	case *schema.ContextProperty:
+		return p.Name, p.IsCustomProperty, &p.StringValue
+	case *schema.ExecutionProperty:
+		return p.Name, p.IsCustomProperty, &p.StringValue
+	default:
+		panic(fmt.Sprintf("unsupported property type: %T", prop))
+	}
+}
+
+// encryptProperties encrypts the string values of the encrypted custom properties of the saved entity, with the
+// data key of its namespace.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) encryptProperties(tx *gorm.DB, entityID int32, properties []TProp) error {
+	cipher := encryption.FromDB(tx)
+	if cipher == nil {
+		return nil
+	}
+
+	namespace, read := "", false
+	for i := range properties {
+		name, custom, value := propertyString(&properties[i])
+		if !custom || *value == nil || !cipher.Encrypts(name) {
+			continue
+		}
+
+		// The namespace of an updated entity is not set by the update, it is read once
+		if !read {
+			var saved TSchema
+			if err := tx.Where("id = ?", entityID).First(&saved).Error; err != nil {
+				return fmt.Errorf("error reading %s namespace: %w", r.config.EntityName, err)
+			}
+			namespace, read = r.getNamespace(saved), true
+		}
+
+		ciphertext, err := cipher.Encrypt(tx.Statement.Context, namespace, name, **value)
+		if err != nil {
+			return fmt.Errorf("error encrypting custom property %s: %w", name, err)
+		}
+		*value = &ciphertext
+	}
+	return nil
+}
+
+// decryptProperties decrypts the string values of the encrypted custom properties read with db.
+func decryptProperties[TProp any](db *gorm.DB, properties []TProp) error {
+	cipher := encryption.FromDB(db)
+	if cipher == nil {
+		return nil
+	}
+
+	for i := range properties {
+		name, custom, value := propertyString(&properties[i])
+		if !custom || *value == nil || !cipher.Encrypts(name) {
+			continue
+		}
+
+		plaintext, err := cipher.Decrypt(db.Statement.Context, name, **value)
+		if err != nil {
+			return fmt.Errorf("error decrypting custom property %s: %w", name, err)
+		}
+		*value = &plaintext
+	}
+	return nil
+}
+
+// rejectEncryptedProperties fails with api.ErrBadRequest if one of the custom properties named names, which filter or
+// order a list, is encrypted: the database would compare their ciphertexts.
+func rejectEncryptedProperties(db *gorm.DB, usage string, names ...string) error {
+	cipher := encryption.FromDB(db)
+	for _, name := range names {
+		if cipher.Encrypts(name) {
+			return fmt.Errorf("custom property %s is encrypted, it can't %s: %w", name, usage, api.ErrBadRequest)
+		}
+	}
+	return nil
+}
+
+// rejectEncryptedOrder fails with api.ErrBadRequest if the list options order by an encrypted custom property.
+func rejectEncryptedOrder(db *gorm.DB, listOptions any) error {
+	if orderByGetter, ok := listOptions.(interface{ GetOrderBy() string }); ok {
+		if propertyOrder, ok := scopes.ParseCustomPropertyOrder(orderByGetter.GetOrderBy()); ok {
+			return rejectEncryptedProperties(db, "order the list", propertyOrder.Name)
+		}
+	}
+	return nil
+}
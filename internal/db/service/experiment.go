@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -34,6 +35,13 @@ func NewExperimentRepository(db *gorm.DB, typeID int32) models.ExperimentReposit
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ExperimentRepositoryImpl) WithContext(ctx context.Context) models.ExperimentRepository {
+	return &ExperimentRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *ExperimentRepositoryImpl) Save(experiment models.Experiment) (models.Experiment, error) {
 	return r.GenericRepository.Save(experiment, nil)
 }
@@ -44,7 +52,7 @@ func (r *ExperimentRepositoryImpl) List(listOptions models.ExperimentListOptions
 
 func applyExperimentListFilters(query *gorm.DB, listOptions *models.ExperimentListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", listOptions.Name)
+		query = query.Where("name = ?", listOptions.Name)
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
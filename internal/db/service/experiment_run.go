@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -36,6 +37,13 @@ func NewExperimentRunRepository(db *gorm.DB, typeID int32) models.ExperimentRunR
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ExperimentRunRepositoryImpl) WithContext(ctx context.Context) models.ExperimentRunRepository {
+	return &ExperimentRunRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *ExperimentRunRepositoryImpl) Save(experimentRun models.ExperimentRun, experimentID *int32) (models.ExperimentRun, error) {
 	return r.GenericRepository.Save(experimentRun, experimentID)
 }
@@ -44,12 +52,17 @@ func (r *ExperimentRunRepositoryImpl) List(listOptions models.ExperimentRunListO
 	return r.GenericRepository.List(&listOptions)
 }
 
+// ListStream adapts the generic repository ListStream method to match the interface contract
+func (r *ExperimentRunRepositoryImpl) ListStream(listOptions models.ExperimentRunListOptions, yield func(models.ExperimentRun) error) (string, error) {
+	return r.GenericRepository.ListStream(&listOptions, yield)
+}
+
 func applyExperimentRunListFilters(query *gorm.DB, listOptions *models.ExperimentRunListOptions) *gorm.DB {
 	if listOptions.Name != nil {
 		if listOptions.ExperimentID != nil {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%d:%s", *listOptions.ExperimentID, *listOptions.Name))
+			query = query.Where("name = ?", fmt.Sprintf("%d:%s", *listOptions.ExperimentID, *listOptions.Name))
 		} else {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+			query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 		}
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
@@ -61,6 +74,11 @@ func applyExperimentRunListFilters(query *gorm.DB, listOptions *models.Experimen
 			Where(utils.GetColumnRef(query, &schema.ParentContext{}, "parent_context_id")+" = ?", listOptions.ExperimentID)
 	}
 
+	if listOptions.ArtifactID != nil {
+		query = query.Joins(utils.BuildContextAttributionJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Attribution{}, "artifact_id")+" = ?", listOptions.ArtifactID)
+	}
+
 	return query
 }
 
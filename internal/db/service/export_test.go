@@ -0,0 +1,9 @@
+package service
+
+// SetBatchPropertyWrites enables or disables the batched property writes of MySQL and returns the function restoring
+// them, for the benchmarks comparing them with the writes of a property at a time.
+func SetBatchPropertyWrites(enabled bool) (restore func()) {
+	previous := batchPropertyWrites
+	batchPropertyWrites = enabled
+	return func() { batchPropertyWrites = previous }
+}
@@ -1,8 +1,11 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/kubeflow/model-registry/internal/db/dbutil"
@@ -10,10 +13,18 @@ import (
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/schema"
 	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/encryption"
+	"github.com/kubeflow/model-registry/internal/metrics"
+	"github.com/kubeflow/model-registry/internal/tracing"
 	"github.com/kubeflow/model-registry/pkg/api"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 )
 
+// listStreamChunkSize is the number of entities of a streamed list whose properties are loaded at once
+const listStreamChunkSize = 100
+
 // Generic constraints for different entity types
 type SchemaEntity interface {
 	schema.Artifact | schema.Context | schema.Execution
@@ -58,7 +69,13 @@ func ApplyFilterQuery(query *gorm.DB, listOptions any, mappingFuncs filter.Entit
 
 				if filterExpr != nil {
 					queryBuilder := filter.NewQueryBuilderForRestEntity(filterApplier.GetRestEntityType(), mappingFuncs)
+					if err := rejectEncryptedProperties(query, "filter the list", queryBuilder.CustomPropertyNames(filterExpr)...); err != nil {
+						return nil, err
+					}
 					query = queryBuilder.BuildQuery(query, filterExpr)
+					if errors.Is(query.Error, filter.ErrUnsafeFilter) {
+						return nil, fmt.Errorf("%v: %w", query.Error, api.ErrBadRequest)
+					}
 				}
 			}
 		}
@@ -66,6 +83,35 @@ func ApplyFilterQuery(query *gorm.DB, listOptions any, mappingFuncs filter.Entit
 	return query, nil
 }
 
+// applyFullTextSearch restricts a query on entityTable to the entities matching the free-text search of listOptions,
+// if any, which doesn't search the encrypted custom properties
+func applyFullTextSearch(query *gorm.DB, listOptions any, entityTable string) *gorm.DB {
+	if queryGetter, ok := listOptions.(interface{ GetQuery() string }); ok {
+		if search := queryGetter.GetQuery(); search != "" {
+			query = query.Scopes(filter.FullTextScope(entityTable, search, encryption.FromDB(query).Properties()...))
+		}
+	}
+	return query
+}
+
+// applyTagFilter restricts a query on the Context table to the contexts with all the tags of listOptions, if any
+func applyTagFilter(query *gorm.DB, listOptions any, entityTable string) *gorm.DB {
+	tagsGetter, ok := listOptions.(interface{ GetTags() []string })
+	if !ok || entityTable != "Context" {
+		return query
+	}
+	tags := slices.Compact(slices.Sorted(slices.Values(tagsGetter.GetTags())))
+	if len(tags) == 0 {
+		return query
+	}
+
+	contextTag := dbutil.QuoteTableName(query, schema.TableNameContextTag)
+	tag := dbutil.QuoteTableName(query, schema.TableNameTag)
+	return query.Where(dbutil.QuoteTableName(query, entityTable)+".id IN (SELECT "+contextTag+".context_id FROM "+contextTag+
+		" JOIN "+tag+" ON "+tag+".id = "+contextTag+".tag_id WHERE "+tag+".name IN ? GROUP BY "+contextTag+".context_id"+
+		" HAVING COUNT(DISTINCT "+tag+".id) = ?)", tags, len(tags))
+}
+
 // applyFilterQuery is a legacy alias for backward compatibility
 func applyFilterQuery(query *gorm.DB, listOptions any, mappingFuncs filter.EntityMappingFunctions) (*gorm.DB, error) {
 	return ApplyFilterQuery(query, listOptions, mappingFuncs)
@@ -88,6 +134,8 @@ type GenericRepositoryConfig[TEntity any, TSchema SchemaEntity, TProp PropertyEn
 	HasCustomProperties     func(TEntity) bool
 	EntityMappingFuncs      filter.EntityMappingFunctions // Optional - custom entity mappings for filtering
 	PreserveHistoricalTimes bool                          // Optional - when true, preserves timestamps from source data (e.g. YAML catalog loading). Default false (Model Registry behavior - always auto-generate timestamps)
+	RecordRevisions         bool                          // Optional - when true, each saved version of the contexts is recorded for the reads of their past state with GetByIDAsOf
+	IndexDigests            bool                          // Optional - when true, the digest custom property of the saved artifacts is indexed for FindByDigest
 }
 
 // Generic repository implementation
@@ -103,13 +151,29 @@ func NewGenericRepository[TEntity any, TSchema SchemaEntity, TProp PropertyEntit
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx, in the transaction it carries if any.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) WithContext(ctx context.Context) *GenericRepository[TEntity, TSchema, TProp, TListOpts] {
+	config := r.config
+	config.DB = dbutil.Bind(ctx, config.DB)
+	return &GenericRepository[TEntity, TSchema, TProp, TListOpts]{
+		config: config,
+	}
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByID(id int32) (TEntity, error) {
+	repo, done := r.instrument(metrics.OperationGetByID)
+	entity, err := repo.getByID(id)
+	done(1, err)
+	return entity, err
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getByID(id int32) (TEntity, error) {
 	var entity TSchema
 	var properties []TProp
 	var zeroEntity TEntity
 
 	// Query main entity
-	if err := r.config.DB.Where("id = ? AND type_id = ?", id, r.config.TypeID).First(&entity).Error; err != nil {
+	if err := r.namespaced(r.config.DB).Where("id = ? AND type_id = ?", id, r.config.TypeID).First(&entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return zeroEntity, fmt.Errorf("%w: %v", r.config.NotFoundError, err)
 		}
@@ -121,18 +185,130 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByID(id int32
 	if err := r.config.DB.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&properties).Error; err != nil {
 		return zeroEntity, fmt.Errorf("error getting properties by %s id: %w", r.config.EntityName, err)
 	}
+	if err := decryptProperties(r.config.DB, properties); err != nil {
+		return zeroEntity, err
+	}
+
+	if version, ok := r.entityVersion(entity); ok {
+		models.RecordEntityVersion(r.config.DB.Statement.Context, version)
+	}
 
 	// Map to domain model
 	return r.config.SchemaToEntity(entity, properties), nil
 }
 
+// GetByIDAsOf returns the entity with id as it was at asOf, in milliseconds since epoch. The entities not updated since
+// asOf are read as they are, the others are rebuilt from their last revision saved by then.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByIDAsOf(id int32, asOf int64) (TEntity, error) {
+	var zeroEntity TEntity
+
+	var entity TSchema
+	if err := r.namespaced(r.config.DB).Where("id = ? AND type_id = ?", id, r.config.TypeID).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return zeroEntity, fmt.Errorf("%w: %v", r.config.NotFoundError, err)
+		}
+		return zeroEntity, fmt.Errorf("error getting %s by id: %w", r.config.EntityName, err)
+	}
+	if r.getLastUpdateTime(entity) <= asOf {
+		return r.GetByID(id)
+	}
+	if !r.config.RecordRevisions {
+		return zeroEntity, fmt.Errorf("the revisions of %s are not recorded: %w", r.config.EntityName, api.ErrBadRequest)
+	}
+
+	var revision schema.ContextRevision
+	if err := r.config.DB.Where("context_id = ? AND last_update_time_since_epoch <= ?", id, asOf).Order("version DESC").First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return zeroEntity, fmt.Errorf("%w: no revision of %s %d at %d", r.config.NotFoundError, r.config.EntityName, id, asOf)
+		}
+		return zeroEntity, fmt.Errorf("error getting %s revision: %w", r.config.EntityName, err)
+	}
+
+	var properties []TProp
+	if err := json.Unmarshal([]byte(revision.Properties), &properties); err != nil {
+		return zeroEntity, fmt.Errorf("error reading %s revision properties: %w", r.config.EntityName, err)
+	}
+	if err := decryptProperties(r.config.DB, properties); err != nil {
+		return zeroEntity, err
+	}
+	past, ok := any(schema.Context{
+		ID:                       revision.ContextID,
+		TypeID:                   revision.TypeID,
+		Name:                     revision.Name,
+		ExternalID:               revision.ExternalID,
+		CreateTimeSinceEpoch:     revision.CreateTimeSinceEpoch,
+		LastUpdateTimeSinceEpoch: revision.LastUpdateTimeSinceEpoch,
+		Version:                  revision.Version,
+	}).(TSchema)
+	if !ok {
+		return zeroEntity, fmt.Errorf("the revisions of %s are not recorded: %w", r.config.EntityName, api.ErrBadRequest)
+	}
+	return r.config.SchemaToEntity(past, properties), nil
+}
+
+// GetByIDs returns the entities with the given ids ordered by id, ids not found are skipped.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByIDs(ids []int32) ([]TEntity, error) {
+	entities := []TEntity{}
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	var schemaEntities []TSchema
+	if err := r.namespaced(r.config.DB).Where("id IN ? AND type_id = ?", ids, r.config.TypeID).Order("id").Find(&schemaEntities).Error; err != nil {
+		return nil, fmt.Errorf("error getting %s by ids: %w", r.config.EntityName, err)
+	}
+
+	propertiesByEntity, err := r.getPropertiesByEntityIDs(schemaEntities)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schemaEntity := range schemaEntities {
+		entities = append(entities, r.config.SchemaToEntity(schemaEntity, propertiesByEntity[r.getEntityID(schemaEntity)]))
+	}
+
+	return entities, nil
+}
+
+// GetCustomPropertyByIDs returns the custom property named name of the entities with the given ids, keyed by entity
+// id, in a single query on the primary key of the property table. Ids not found or without the property are skipped.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetCustomPropertyByIDs(ids []int32, name string) (map[int32]models.Properties, error) {
+	propertiesByEntity := make(map[int32]models.Properties, len(ids))
+	if len(ids) == 0 {
+		return propertiesByEntity, nil
+	}
+
+	var schemaEntity TSchema
+	var property TProp
+	entityTable := utils.GetTableName(r.config.DB, &schemaEntity)
+	propertyTable := utils.GetTableName(r.config.DB, &property)
+
+	var properties []TProp
+	if err := r.namespaced(r.config.DB.Model(&property)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.id = %s.%s", entityTable, entityTable, propertyTable, r.config.PropertyFieldName)).
+		Where(fmt.Sprintf("%s.%s IN ? AND %s.name = ? AND %s.is_custom_property = ? AND %s.type_id = ?",
+			propertyTable, r.config.PropertyFieldName, propertyTable, propertyTable, entityTable), ids, name, true, r.config.TypeID).
+		Find(&properties).Error; err != nil {
+		return nil, fmt.Errorf("error getting custom property %s by %s ids: %w", name, r.config.EntityName, err)
+	}
+	if err := decryptProperties(r.config.DB, properties); err != nil {
+		return nil, err
+	}
+
+	for _, prop := range properties {
+		propertiesByEntity[r.getPropertyEntityID(prop)] = mapPropertyToProperties(prop)
+	}
+
+	return propertiesByEntity, nil
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByName(name string) (TEntity, error) {
 	var entity TSchema
 	var properties []TProp
 	var zeroEntity TEntity
 
 	// Query main entity
-	if err := r.config.DB.Where("name = ? AND type_id = ?", name, r.config.TypeID).First(&entity).Error; err != nil {
+	if err := r.namespaced(r.config.DB).Where("name = ? AND type_id = ?", name, r.config.TypeID).First(&entity).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return zeroEntity, fmt.Errorf("%w: %v", r.config.NotFoundError, err)
 		}
@@ -144,12 +320,30 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) GetByName(name s
 	if err := r.config.DB.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&properties).Error; err != nil {
 		return zeroEntity, fmt.Errorf("error getting properties by %s id: %w", r.config.EntityName, err)
 	}
+	if err := decryptProperties(r.config.DB, properties); err != nil {
+		return zeroEntity, err
+	}
+
+	if version, ok := r.entityVersion(entity); ok {
+		models.RecordEntityVersion(r.config.DB.Statement.Context, version)
+	}
 
 	// Map to domain model
 	return r.config.SchemaToEntity(entity, properties), nil
 }
 
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) List(listOptions TListOpts) (*models.ListWrapper[TEntity], error) {
+	repo, done := r.instrument(metrics.OperationList)
+	list, err := repo.list(listOptions)
+	rows := 0
+	if list != nil {
+		rows = len(list.Items)
+	}
+	done(rows, err)
+	return list, err
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) list(listOptions TListOpts) (*models.ListWrapper[TEntity], error) {
 	pageSize := listOptions.GetPageSize()
 
 	list := models.ListWrapper[TEntity]{
@@ -159,16 +353,7 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) List(listOptions
 	var entities []TEntity
 	var schemaEntities []TSchema
 
-	// Build base query
-	query := r.buildBaseQuery()
-
-	// Apply type-specific filters
-	if r.config.ApplyListFilters != nil {
-		query = r.config.ApplyListFilters(query, listOptions)
-	}
-
-	// Apply advanced filter query if supported
-	query, err := applyFilterQuery(query, listOptions, r.config.EntityMappingFuncs)
+	query, err := r.FilteredQuery(listOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -198,27 +383,22 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) List(listOptions
 		}
 	}
 
-	// Load properties and map to domain models
-	for _, schemaEntity := range schemaEntities {
-		var properties []TProp
-		entityID := r.getEntityID(schemaEntity)
-		if err := r.config.DB.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&properties).Error; err != nil {
-			return nil, fmt.Errorf("error getting properties by %s id: %w", r.config.EntityName, err)
-		}
+	// Load the properties of the whole page in one query, filtering on the
+	// entity id keeps it partition-pruned on partitioned property tables
+	propertiesByEntity, err := r.getPropertiesByEntityIDs(schemaEntities)
+	if err != nil {
+		return nil, err
+	}
 
-		entity := r.config.SchemaToEntity(schemaEntity, properties)
+	for _, schemaEntity := range schemaEntities {
+		entity := r.config.SchemaToEntity(schemaEntity, propertiesByEntity[r.getEntityID(schemaEntity)])
 		entities = append(entities, entity)
 	}
 
 	// Set pagination token
 	if hasMore && len(schemaEntities) > 0 {
 		lastEntity := schemaEntities[len(schemaEntities)-1]
-		var nextToken string
-		if r.config.CreatePaginationToken != nil {
-			nextToken = r.config.CreatePaginationToken(lastEntity, listOptions)
-		} else {
-			nextToken = r.CreateDefaultPaginationToken(lastEntity, listOptions)
-		}
+		nextToken := r.nextPageToken(lastEntity, propertiesByEntity[r.getEntityID(lastEntity)], listOptions)
 		listOptions.SetNextPageToken(&nextToken)
 	} else {
 		listOptions.SetNextPageToken(nil)
@@ -232,7 +412,232 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) List(listOptions
 	return &list, nil
 }
 
+// ListStream calls yield with the entities of the page of listOptions in order, as they are read from the rows of
+// the database cursor instead of holding the whole page: the properties are loaded for listStreamChunkSize entities
+// at a time. It returns the token of the next page, and stops at the first error of yield.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) ListStream(listOptions TListOpts, yield func(TEntity) error) (string, error) {
+	pageSize := listOptions.GetPageSize()
+
+	var entities []TEntity
+
+	query, err := r.FilteredQuery(listOptions)
+	if err != nil {
+		return "", err
+	}
+
+	if r.config.ApplyCustomOrdering != nil {
+		query = r.config.ApplyCustomOrdering(query, listOptions)
+	} else {
+		query = r.ApplyStandardPagination(query, listOptions, entities)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		err = dbutil.SanitizeDatabaseError(err)
+		return "", fmt.Errorf("error listing %ss: %w", r.config.EntityName, err)
+	}
+	defer rows.Close()
+
+	var (
+		chunk          []TSchema
+		count          int32
+		hasMore        bool
+		lastEntity     TSchema
+		lastProperties []TProp
+	)
+	yieldChunk := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		propertiesByEntity, err := r.getPropertiesByEntityIDs(chunk)
+		if err != nil {
+			return err
+		}
+		for _, schemaEntity := range chunk {
+			properties := propertiesByEntity[r.getEntityID(schemaEntity)]
+			if err := yield(r.config.SchemaToEntity(schemaEntity, properties)); err != nil {
+				return err
+			}
+			lastEntity, lastProperties = schemaEntity, properties
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for rows.Next() {
+		if pageSize > 0 && count == pageSize {
+			// the row after the page
+			hasMore = true
+			break
+		}
+		var schemaEntity TSchema
+		if err := query.ScanRows(rows, &schemaEntity); err != nil {
+			err = dbutil.SanitizeDatabaseError(err)
+			return "", fmt.Errorf("error reading %ss: %w", r.config.EntityName, err)
+		}
+		chunk = append(chunk, schemaEntity)
+		count++
+		if len(chunk) == listStreamChunkSize {
+			if err := yieldChunk(); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		err = dbutil.SanitizeDatabaseError(err)
+		return "", fmt.Errorf("error listing %ss: %w", r.config.EntityName, err)
+	}
+	if err := yieldChunk(); err != nil {
+		return "", err
+	}
+
+	if !hasMore || count == 0 {
+		return "", nil
+	}
+	return r.nextPageToken(lastEntity, lastProperties, listOptions), nil
+}
+
+// nextPageToken returns the token of the page after lastEntity, the last entity of a page.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) nextPageToken(lastEntity TSchema, properties []TProp, listOptions TListOpts) string {
+	if r.config.CreatePaginationToken != nil {
+		return r.config.CreatePaginationToken(lastEntity, listOptions)
+	}
+	if propertyOrder, ok := scopes.ParseCustomPropertyOrder(listOptions.GetOrderBy()); ok {
+		return r.customPropertyPaginationToken(lastEntity, propertyOrder, properties)
+	}
+	return r.CreateDefaultPaginationToken(lastEntity, listOptions)
+}
+
+// FilteredQuery returns the query selecting the entities matching the list filters and filter query
+// of listOptions, without ordering nor pagination, for aggregates over a listing.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) FilteredQuery(listOptions TListOpts) (*gorm.DB, error) {
+	if err := rejectEncryptedOrder(r.config.DB, listOptions); err != nil {
+		return nil, err
+	}
+
+	// Build base query
+	query := r.buildBaseQuery()
+
+	// Apply type-specific filters
+	if r.config.ApplyListFilters != nil {
+		query = r.config.ApplyListFilters(query, listOptions)
+	}
+
+	// Apply advanced filter query if supported
+	query, err := applyFilterQuery(query, listOptions, r.config.EntityMappingFuncs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = applyTagFilter(query, listOptions, r.entityTableName())
+
+	return applyFullTextSearch(query, listOptions, r.entityTableName()), nil
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getPropertiesByEntityIDs(schemaEntities []TSchema) (map[int32][]TProp, error) {
+	propertiesByEntity := make(map[int32][]TProp, len(schemaEntities))
+	if len(schemaEntities) == 0 {
+		return propertiesByEntity, nil
+	}
+
+	entityIDs := make([]int32, 0, len(schemaEntities))
+	for _, schemaEntity := range schemaEntities {
+		entityID := r.getEntityID(schemaEntity)
+		entityIDs = append(entityIDs, entityID)
+		propertiesByEntity[entityID] = []TProp{}
+	}
+
+	var properties []TProp
+	if err := r.config.DB.Where(r.config.PropertyFieldName+" IN ?", entityIDs).Find(&properties).Error; err != nil {
+		return nil, fmt.Errorf("error getting properties by %s id: %w", r.config.EntityName, err)
+	}
+	if err := decryptProperties(r.config.DB, properties); err != nil {
+		return nil, err
+	}
+
+	for _, prop := range properties {
+		entityID := r.getPropertyEntityID(prop)
+		propertiesByEntity[entityID] = append(propertiesByEntity[entityID], prop)
+	}
+
+	return propertiesByEntity, nil
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) Save(entity TEntity, parentResourceID *int32) (TEntity, error) {
+	repo, done := r.instrument(metrics.OperationSave)
+	saved, err := repo.save(entity, parentResourceID)
+	done(1, err)
+	return saved, err
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) save(entity TEntity, parentResourceID *int32) (TEntity, error) {
+	var saved TEntity
+
+	// Deadlocks and serialization failures roll the whole transaction back, each attempt starts again from the
+	// entity to save so that an id assigned by a rolled back insert is not reused
+	err := dbutil.DefaultRetryPolicy.Retry(func() error {
+		return r.config.DB.Transaction(func(tx *gorm.DB) error {
+			var err error
+			saved, err = r.saveInTransaction(tx, entity, parentResourceID)
+			return err
+		})
+	})
+	if err != nil {
+		var zeroEntity TEntity
+		return zeroEntity, err
+	}
+
+	return saved, nil
+}
+
+// SaveBatch saves the entities, each with the parent resource at the same index of parentResourceIDs if any, in a
+// single transaction retried as a whole like Save. then, if set, is called in the transaction with the saved
+// entities to save their children: none of the entities is saved if any of them or then fails.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) SaveBatch(entities []TEntity, parentResourceIDs []*int32, then func(tx *gorm.DB, saved []TEntity) error) ([]TEntity, error) {
+	repo, done := r.instrument(metrics.OperationSave)
+	saved, err := repo.saveBatch(entities, parentResourceIDs, then)
+	done(len(saved), err)
+	return saved, err
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) saveBatch(entities []TEntity, parentResourceIDs []*int32, then func(tx *gorm.DB, saved []TEntity) error) ([]TEntity, error) {
+	if len(parentResourceIDs) != 0 && len(parentResourceIDs) != len(entities) {
+		return nil, fmt.Errorf("error saving %s batch: %d parent resources for %d entities", r.config.EntityName, len(parentResourceIDs), len(entities))
+	}
+
+	var saved []TEntity
+
+	err := dbutil.DefaultRetryPolicy.Retry(func() error {
+		return r.config.DB.Transaction(func(tx *gorm.DB) error {
+			saved = make([]TEntity, 0, len(entities))
+			for i, entity := range entities {
+				var parentResourceID *int32
+				if len(parentResourceIDs) != 0 {
+					parentResourceID = parentResourceIDs[i]
+				}
+
+				savedEntity, err := r.saveInTransaction(tx, entity, parentResourceID)
+				if err != nil {
+					return err
+				}
+				saved = append(saved, savedEntity)
+			}
+
+			if then != nil {
+				return then(tx, saved)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// saveInTransaction saves the entity, its parent relationship and properties with tx.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) saveInTransaction(tx *gorm.DB, entity TEntity, parentResourceID *int32) (TEntity, error) {
 	now := time.Now().UnixMilli()
 	var zeroEntity TEntity
 
@@ -285,45 +690,106 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) Save(entity TEnt
 
 	hasCustomProperties := r.config.HasCustomProperties != nil && r.config.HasCustomProperties(entity)
 
-	err := r.config.DB.Transaction(func(tx *gorm.DB) error {
-		// Save main entity with smart field handling
-		if isNewEntity {
-			// For new entities, save all fields
-			if err := tx.Save(&schemaEntity).Error; err != nil {
-				return fmt.Errorf("error saving %s: %w", r.config.EntityName, err)
-			}
-		} else {
-			// For updates, use Updates() to only update changed fields
-			// Updates() automatically handles zero values correctly and respects omitted fields
-			omitFields := r.getNonUpdatableFields(schemaEntity)
-			if err := tx.Model(&schemaEntity).Omit(omitFields...).Updates(&schemaEntity).Error; err != nil {
-				return fmt.Errorf("error saving %s: %w", r.config.EntityName, err)
+	// The fields of the updated entity are read before the update for the changes of its audit event
+	_, audited := api.Actor(tx.Statement.Context)
+	var before map[string]any
+
+	// Save main entity with smart field handling
+	if isNewEntity {
+		// For new entities, save all fields, in the namespace of the tenant of the request
+		r.setNamespace(&schemaEntity, api.Namespace(tx.Statement.Context))
+		if err := tx.Save(&schemaEntity).Error; err != nil {
+			return zeroEntity, fmt.Errorf("error saving %s: %w", r.config.EntityName, err)
+		}
+	} else {
+		if err := r.checkNamespace(tx, schemaEntity); err != nil {
+			return zeroEntity, err
+		}
+
+		if audited {
+			var err error
+			if _, before, err = r.auditSnapshot(tx, r.getEntityID(schemaEntity), nil); err != nil {
+				return zeroEntity, err
 			}
 		}
 
-		// Handle parent relationship if applicable
-		if parentResourceID != nil {
-			if err := r.handleParentRelationship(tx, schemaEntity, parentResourceID); err != nil {
-				return err
+		// The version is incremented first, so that the update of an outdated version is rejected before any write
+		if err := r.incrementVersion(tx, schemaEntity); err != nil {
+			return zeroEntity, err
+		}
+
+		// For updates, use Updates() to only update changed fields
+		// Updates() automatically handles zero values correctly and respects omitted fields
+		omitFields := r.getNonUpdatableFields(schemaEntity)
+		if err := tx.Model(&schemaEntity).Omit(omitFields...).Updates(&schemaEntity).Error; err != nil {
+			return zeroEntity, fmt.Errorf("error saving %s: %w", r.config.EntityName, err)
+		}
+	}
+
+	// Handle parent relationship if applicable, a new child being a change of its parent
+	if parentResourceID != nil {
+		linked, err := r.handleParentRelationship(tx, schemaEntity, parentResourceID)
+		if err != nil {
+			return zeroEntity, err
+		}
+		if linked && !r.config.PreserveHistoricalTimes {
+			if err := touchContexts(tx, []int32{*parentResourceID}, now); err != nil {
+				return zeroEntity, err
 			}
 		}
+	}
 
-		// Handle properties
-		entityID := r.getEntityID(schemaEntity)
-		properties := r.config.EntityToProperties(entity, entityID)
+	// Handle properties
+	entityID := r.getEntityID(schemaEntity)
+	properties := r.config.EntityToProperties(entity, entityID)
+	if err := r.encryptProperties(tx, entityID, properties); err != nil {
+		return zeroEntity, err
+	}
 
-		if err := r.handleProperties(tx, entityID, properties, hasCustomProperties); err != nil {
-			return err
+	if err := r.handleProperties(tx, entityID, properties, hasCustomProperties); err != nil {
+		return zeroEntity, err
+	}
+
+	// Get final properties for return object
+	if err := tx.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&finalProperties).Error; err != nil {
+		return zeroEntity, fmt.Errorf("error getting final properties by %s id: %w", r.config.EntityName, err)
+	}
+
+	if version, ok := r.entityVersion(schemaEntity); ok {
+		if err := tx.Table(dbutil.QuoteTableName(tx, version.Table)).Select("version").Where("id = ?", entityID).Scan(&version.Version).Error; err != nil {
+			return zeroEntity, fmt.Errorf("error getting %s version: %w", r.config.EntityName, err)
+		}
+		models.RecordEntityVersion(tx.Statement.Context, version)
+	}
+
+	if audited {
+		saved, after, err := r.auditSnapshot(tx, entityID, finalProperties)
+		if err != nil {
+			return zeroEntity, err
+		}
+		action := models.AuditActionUpdate
+		if isNewEntity {
+			action = models.AuditActionCreate
 		}
+		if err := recordAuditEvent(tx, action, r.entityTableName(), r.config.TypeID, entityID, r.getNamespace(saved), before, after); err != nil {
+			return zeroEntity, err
+		}
+	}
 
-		// Get final properties for return object
-		if err := tx.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&finalProperties).Error; err != nil {
-			return fmt.Errorf("error getting final properties by %s id: %w", r.config.EntityName, err)
+	if r.config.RecordRevisions {
+		if err := r.recordRevision(tx, entityID, finalProperties); err != nil {
+			return zeroEntity, err
 		}
+	}
 
-		return nil
-	})
-	if err != nil {
+	if r.config.IndexDigests {
+		if err := r.indexDigest(tx, entityID, finalProperties); err != nil {
+			return zeroEntity, err
+		}
+	}
+
+	// The revision, audit event and digest keep the encrypted values, the updated entity is returned decrypted
+	if err := decryptProperties(tx, finalProperties); err != nil {
 		return zeroEntity, err
 	}
 
@@ -333,6 +799,48 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) Save(entity TEnt
 
 // Helper methods
 
+// entityTableName returns the unquoted name of the table of the schema entities
+// instrument starts an operation of the repository, returning the repository to run it with and the function
+// recording it in the repository metrics and its span once done. The SQL statements of the operation are children of
+// its span when tracing is on, the entities not found are not counted as errors and the rows are only counted for the
+// operations that succeeded.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) instrument(operation string) (*GenericRepository[TEntity, TSchema, TProp, TListOpts], func(rows int, err error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(r.config.DB.Statement.Context, r.config.EntityName+" "+operation,
+		attribute.String("model_registry.entity", r.config.EntityName),
+		attribute.String("model_registry.operation", operation),
+	)
+
+	repo := r
+	if span.IsRecording() {
+		repo = r.WithContext(ctx)
+	}
+
+	return repo, func(rows int, err error) {
+		if err != nil {
+			rows = 0
+		}
+		if errors.Is(err, r.config.NotFoundError) {
+			err = nil
+		}
+		metrics.ObserveRepository(r.config.EntityName, operation, start, rows, err != nil)
+		span.SetAttributes(attribute.Int("model_registry.rows", rows))
+		tracing.End(span, err)
+	}
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) entityTableName() string {
+	var schemaEntity TSchema
+	switch any(schemaEntity).(type) {
+	case schema.Artifact:
+		return "Artifact"
+	case schema.Context:
+		return "Context"
+	default:
+		return "Execution"
+	}
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) buildBaseQuery() *gorm.DB {
 	var schemaEntity TSchema
 	var tableName string
@@ -357,7 +865,55 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) buildBaseQuery()
 	tableNameQuoted := dbutil.QuoteTableName(r.config.DB, tableName)
 	whereClause := fmt.Sprintf("%s.type_id = ?", tableNameQuoted)
 
-	return r.config.DB.Model(model).Where(whereClause, r.config.TypeID)
+	return r.namespaced(r.config.DB.Model(model).Where(whereClause, r.config.TypeID))
+}
+
+// namespaced restricts query to the namespace of the tenant of the request, for the artifacts and contexts which are
+// the entities having one.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) namespaced(query *gorm.DB) *gorm.DB {
+	table := r.entityTableName()
+	if table == "Execution" {
+		return query
+	}
+	return whereNamespace(query, table)
+}
+
+// checkNamespace checks that the entity updated by the request of a tenant is in its namespace, the entities of the
+// other namespaces are not found.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) checkNamespace(tx *gorm.DB, entity TSchema) error {
+	if api.Namespace(tx.Statement.Context) == "" || r.entityTableName() == "Execution" {
+		return nil
+	}
+
+	entityID := r.getEntityID(entity)
+	var count int64
+	if err := r.namespaced(tx.Table(dbutil.QuoteTableName(tx, r.entityTableName()))).Where("id = ?", entityID).Count(&count).Error; err != nil {
+		return fmt.Errorf("error getting %s namespace: %w", r.config.EntityName, err)
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s %d not found", r.config.NotFoundError, r.config.EntityName, entityID)
+	}
+	return nil
+}
+
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) setNamespace(entity *TSchema, namespace string) {
+	switch e := any(entity).(type) {
+	case *schema.Artifact:
+		e.Namespace = namespace
+	case *schema.Context:
+		e.Namespace = namespace
+	}
+}
+
+// getNamespace returns the namespace of the entity, empty for the executions which have none.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getNamespace(entity TSchema) string {
+	switch e := any(entity).(type) {
+	case schema.Artifact:
+		return e.Namespace
+	case schema.Context:
+		return e.Namespace
+	}
+	return ""
 }
 
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getEntityID(entity TSchema) int32 {
@@ -373,6 +929,114 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getEntityID(enti
 	}
 }
 
+// entityVersion returns the version of the entity, false for the executions which are not versioned.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) entityVersion(entity TSchema) (models.EntityVersion, bool) {
+	switch e := any(entity).(type) {
+	case schema.Artifact:
+		return models.EntityVersion{Table: models.VersionedArtifact, ID: e.ID, Version: e.Version}, true
+	case schema.Context:
+		return models.EntityVersion{Table: models.VersionedContext, ID: e.ID, Version: e.Version}, true
+	}
+	return models.EntityVersion{}, false
+}
+
+// recordRevision records the saved version of the context with its properties, for the reads of its past state.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) recordRevision(tx *gorm.DB, entityID int32, properties []TProp) error {
+	var saved schema.Context
+	if err := tx.Where("id = ?", entityID).First(&saved).Error; err != nil {
+		return fmt.Errorf("error getting %s revision: %w", r.config.EntityName, err)
+	}
+	if properties == nil {
+		properties = []TProp{}
+	}
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return fmt.Errorf("error saving %s revision: %w", r.config.EntityName, err)
+	}
+	revision := schema.ContextRevision{
+		ContextID:                saved.ID,
+		Version:                  saved.Version,
+		TypeID:                   saved.TypeID,
+		Name:                     saved.Name,
+		ExternalID:               saved.ExternalID,
+		CreateTimeSinceEpoch:     saved.CreateTimeSinceEpoch,
+		LastUpdateTimeSinceEpoch: saved.LastUpdateTimeSinceEpoch,
+		Properties:               string(data),
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return fmt.Errorf("error saving %s revision: %w", r.config.EntityName, err)
+	}
+	return nil
+}
+
+// auditSnapshot reads the saved entity with the id and returns it with its fields for its audit events, its
+// properties are read unless given.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) auditSnapshot(tx *gorm.DB, entityID int32, properties []TProp) (TSchema, map[string]any, error) {
+	var entity TSchema
+	if err := tx.Where("id = ?", entityID).First(&entity).Error; err != nil {
+		return entity, nil, fmt.Errorf("error reading %s for its audit event: %w", r.config.EntityName, err)
+	}
+	if properties == nil {
+		if err := tx.Where(r.config.PropertyFieldName+" = ?", entityID).Find(&properties).Error; err != nil {
+			return entity, nil, fmt.Errorf("error reading %s properties for its audit event: %w", r.config.EntityName, err)
+		}
+	}
+
+	values := make(map[string]any, len(properties))
+	for _, property := range properties {
+		values[auditPropertyName(r.getPropertyName(property), r.getPropertyIsCustom(property))] = r.getPropertyValue(property)
+	}
+
+	fields, err := auditFields(entity, values)
+	if err != nil {
+		return entity, nil, fmt.Errorf("error reading %s for its audit event: %w", r.config.EntityName, err)
+	}
+	return entity, fields, nil
+}
+
+// incrementVersion increments the version of the updated entity, checking first it is the version the update applies
+// to if it has one, so that concurrent editors can't clobber each other's changes. The table is updated without the
+// entity model as the version is not a change of the entity for the callbacks of its updates, such as the webhooks.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) incrementVersion(tx *gorm.DB, entity TSchema) error {
+	current, ok := r.entityVersion(entity)
+	if !ok {
+		return nil
+	}
+
+	query := tx.Table(dbutil.QuoteTableName(tx, current.Table)).Where("id = ?", current.ID)
+	expected, checked := models.IfMatch(tx.Statement.Context)
+	checked = checked && expected.Table == current.Table && expected.ID == current.ID
+	if checked {
+		query = query.Where("version = ?", expected.Version)
+	}
+
+	result := query.UpdateColumn("version", gorm.Expr("version + 1"))
+	if result.Error != nil {
+		return fmt.Errorf("error saving %s version: %w", r.config.EntityName, result.Error)
+	}
+	if checked && result.RowsAffected == 0 {
+		return fmt.Errorf("%s %d was updated since version %d: %w", r.config.EntityName, current.ID, expected.Version, api.ErrPreconditionFailed)
+	}
+	return nil
+}
+
+// touchContexts sets the last update time of the contexts to now, for the changes of their relationships and tags
+// which are not saves of the contexts, so that the reads of the contexts updated since a time see them. Their version
+// is kept: the changes don't write the contexts, and the updates of the clients holding their ETag must not fail. As
+// for the versions, the table is updated without the entity model.
+func touchContexts(tx *gorm.DB, ids []int32, now int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := tx.Table(dbutil.QuoteTableName(tx, models.VersionedContext)).
+		Where("id IN ? AND last_update_time_since_epoch <= ?", ids, now).
+		UpdateColumn("last_update_time_since_epoch", now).Error
+	if err != nil {
+		return fmt.Errorf("error updating the last update time of contexts %v: %w", ids, err)
+	}
+	return nil
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) setLastUpdateTime(entity *TSchema, timestamp int64) {
 	switch e := any(entity).(type) {
 	case *schema.Artifact:
@@ -395,7 +1059,8 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) setCreateTime(en
 	}
 }
 
-func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleParentRelationship(tx *gorm.DB, entity TSchema, parentResourceID *int32) error {
+// handleParentRelationship links the entity to its parent context, reporting whether the relationship is new.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleParentRelationship(tx *gorm.DB, entity TSchema, parentResourceID *int32) (bool, error) {
 	// Handle Attribution for artifacts, ParentContext for contexts, or Association for executions
 	entityID := r.getEntityID(entity)
 
@@ -412,10 +1077,11 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleParentRela
 				ContextID:  *parentResourceID,
 			}
 			if err := tx.Create(&attribution).Error; err != nil {
-				return fmt.Errorf("error creating attribution: %w", err)
+				return false, fmt.Errorf("error creating attribution: %w", err)
 			}
+			return true, nil
 		} else if result.Error != nil {
-			return fmt.Errorf("error checking existing attribution: %w", result.Error)
+			return false, fmt.Errorf("error checking existing attribution: %w", result.Error)
 		}
 		// If attribution already exists, do nothing
 
@@ -431,10 +1097,11 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleParentRela
 				ParentContextID: *parentResourceID,
 			}
 			if err := tx.Create(&parentContext).Error; err != nil {
-				return fmt.Errorf("error creating parent context: %w", err)
+				return false, fmt.Errorf("error creating parent context: %w", err)
 			}
+			return true, nil
 		} else if result.Error != nil {
-			return fmt.Errorf("error checking existing parent context: %w", result.Error)
+			return false, fmt.Errorf("error checking existing parent context: %w", result.Error)
 		}
 		// If parent context already exists, do nothing
 
@@ -450,18 +1117,21 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleParentRela
 				ContextID:   *parentResourceID,
 			}
 			if err := tx.Create(&association).Error; err != nil {
-				return fmt.Errorf("error creating association: %w", err)
+				return false, fmt.Errorf("error creating association: %w", err)
 			}
+			return true, nil
 		} else if result.Error != nil {
-			return fmt.Errorf("error checking existing association: %w", result.Error)
+			return false, fmt.Errorf("error checking existing association: %w", result.Error)
 		}
 		// If association already exists, do nothing
 	}
 
-	return nil
+	return false, nil
 }
 
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleProperties(tx *gorm.DB, entityID int32, properties []TProp, hasCustomProperties bool) error {
+	batched := batchesPropertyWrites(tx)
+
 	// Get existing custom properties if we have custom properties
 	if hasCustomProperties {
 		var existingCustomProperties []TProp
@@ -470,6 +1140,7 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleProperties
 		}
 
 		// Delete removed custom properties
+		var removed []string
 		for _, existingProp := range existingCustomProperties {
 			found := false
 			for _, prop := range properties {
@@ -479,28 +1150,50 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) handleProperties
 				}
 			}
 
-			if !found {
-				if err := tx.Delete(&existingProp).Error; err != nil {
-					return fmt.Errorf("error deleting property: %w", err)
-				}
+			if found {
+				continue
 			}
+			if batched {
+				removed = append(removed, r.getPropertyName(existingProp))
+			} else if err := tx.Delete(&existingProp).Error; err != nil {
+				return fmt.Errorf("error deleting property: %w", err)
+			}
+		}
+		if err := r.deleteProperties(tx, entityID, true, removed); err != nil {
+			return err
 		}
 	}
 
-	// Upsert properties
+	if batched {
+		return r.upsertPropertiesBatched(tx, entityID, properties)
+	}
+	return r.upsertProperties(tx, entityID, properties)
+}
+
+// upsertProperties writes the properties of an entity one statement at a time.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) upsertProperties(tx *gorm.DB, entityID int32, properties []TProp) error {
 	for _, prop := range properties {
+		// Well-known properties without a value are removed, as the fields cleared by a merge patch
+		removed := !r.getPropertyIsCustom(prop) && !r.propertyHasValue(prop)
+
 		var existingProp TProp
 		result := tx.Where(r.config.PropertyFieldName+" = ? AND name = ? AND is_custom_property = ?",
 			entityID, r.getPropertyName(prop), r.getPropertyIsCustom(prop)).First(&existingProp)
 
-		switch result.Error {
-		case nil:
+		switch {
+		case result.Error == nil && removed:
+			if err := tx.Delete(&existingProp).Error; err != nil {
+				return fmt.Errorf("error deleting property %s: %w", r.getPropertyName(prop), err)
+			}
+		case errors.Is(result.Error, gorm.ErrRecordNotFound) && removed:
+			// Nothing to remove
+		case result.Error == nil:
 			// Update existing property
 			r.copyPropertyValues(&prop, &existingProp)
 			if err := tx.Model(&existingProp).Updates(prop).Error; err != nil {
 				return fmt.Errorf("error updating property %s: %w", r.getPropertyName(prop), err)
 			}
-		case gorm.ErrRecordNotFound:
+		case errors.Is(result.Error, gorm.ErrRecordNotFound):
 			// Create new property
 			if err := tx.Create(&prop).Error; err != nil {
 				return fmt.Errorf("error creating property %s: %w", r.getPropertyName(prop), err)
@@ -545,6 +1238,52 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getPropertyIsCus
 	}
 }
 
+// propertyHasValue reports whether any of the values of the property is set.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) propertyHasValue(prop TProp) bool {
+	switch p := any(prop).(type) {
+	case schema.ArtifactProperty:
+		return p.IntValue != nil || p.DoubleValue != nil || p.StringValue != nil || p.BoolValue != nil || p.ByteValue != nil || p.ProtoValue != nil
+	case schema.ContextProperty:
+		return p.IntValue != nil || p.DoubleValue != nil || p.StringValue != nil || p.BoolValue != nil || p.ByteValue != nil || p.ProtoValue != nil
+	case schema.ExecutionProperty:
+		return p.IntValue != nil || p.DoubleValue != nil || p.StringValue != nil || p.BoolValue != nil || p.ByteValue != nil || p.ProtoValue != nil
+	default:
+		panic(fmt.Sprintf("unsupported property type: %T", prop))
+	}
+}
+
+// getPropertyValue returns the value of the property, nil if it has none.
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getPropertyValue(prop TProp) any {
+	switch p := any(prop).(type) {
+	case schema.ArtifactProperty:
+		return propertyValue(p.IntValue, p.DoubleValue, p.StringValue, p.BoolValue, p.ByteValue, p.ProtoValue)
+	case schema.ContextProperty:
+		return propertyValue(p.IntValue, p.DoubleValue, p.StringValue, p.BoolValue, p.ByteValue, p.ProtoValue)
+	case schema.ExecutionProperty:
+		return propertyValue(p.IntValue, p.DoubleValue, p.StringValue, p.BoolValue, p.ByteValue, p.ProtoValue)
+	default:
+		panic(fmt.Sprintf("unsupported property type: %T", prop))
+	}
+}
+
+func propertyValue(intValue *int32, doubleValue *float64, stringValue *string, boolValue *bool, byteValue *[]byte, protoValue *[]byte) any {
+	switch {
+	case intValue != nil:
+		return *intValue
+	case doubleValue != nil:
+		return *doubleValue
+	case stringValue != nil:
+		return *stringValue
+	case boolValue != nil:
+		return *boolValue
+	case byteValue != nil:
+		return *byteValue
+	case protoValue != nil:
+		return *protoValue
+	}
+	return nil
+}
+
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getPropertyEntityID(prop TProp) int32 {
 	switch p := any(prop).(type) {
 	case schema.ArtifactProperty:
@@ -610,6 +1349,17 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) CreateDefaultPag
 	return scopes.CreateNextPageToken(entityID, value)
 }
 
+// customPropertyPaginationToken returns the token of the page after entity, listed by the value of a custom property
+func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) customPropertyPaginationToken(entity TSchema, order scopes.CustomPropertyOrder, properties []TProp) string {
+	customProperties := []models.Properties{}
+	for _, prop := range properties {
+		if r.getPropertyIsCustom(prop) {
+			customProperties = append(customProperties, mapPropertyToProperties(prop))
+		}
+	}
+	return scopes.CreateNextPageToken(r.getEntityID(entity), order.SortValue(customProperties))
+}
+
 // getCreateTime extracts CreateTimeSinceEpoch from any schema entity
 func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getCreateTime(entity TSchema) int64 {
 	switch e := any(entity).(type) {
@@ -644,11 +1394,11 @@ func (r *GenericRepository[TEntity, TSchema, TProp, TListOpts]) getNonUpdatableF
 
 	switch any(entity).(type) {
 	case schema.Artifact:
-		// Non-updatable fields for artifacts: id, name, type_id, create_time_since_epoch
-		omitFields = []string{"id", "name", "type_id", "create_time_since_epoch"}
+		// Non-updatable fields for artifacts: id, name, type_id, create_time_since_epoch, version, namespace
+		omitFields = []string{"id", "name", "type_id", "create_time_since_epoch", "version", "namespace"}
 	case schema.Context:
-		// Non-updatable fields for contexts: id, name, type_id, create_time_since_epoch
-		omitFields = []string{"id", "name", "type_id", "create_time_since_epoch"}
+		// Non-updatable fields for contexts: id, name, type_id, create_time_since_epoch, version, namespace
+		omitFields = []string{"id", "name", "type_id", "create_time_since_epoch", "version", "namespace"}
 	case schema.Execution:
 		// Non-updatable fields for executions: id, name, type_id, create_time_since_epoch
 		omitFields = []string{"id", "name", "type_id", "create_time_since_epoch"}
@@ -742,6 +1492,20 @@ func MapPropertiesToExecutionProperty(prop models.Properties, executionID int32,
 	}
 }
 
+// mapPropertyToProperties converts a property of any property table to models.Properties
+func mapPropertyToProperties[TProp PropertyEntity](prop TProp) models.Properties {
+	switch p := any(prop).(type) {
+	case schema.ArtifactProperty:
+		return MapArtifactPropertyToProperties(p)
+	case schema.ContextProperty:
+		return MapContextPropertyToProperties(p)
+	case schema.ExecutionProperty:
+		return MapExecutionPropertyToProperties(p)
+	default:
+		panic(fmt.Sprintf("unsupported property type: %T", prop))
+	}
+}
+
 // MapArtifactPropertyToProperties converts schema.ArtifactProperty to models.Properties
 func MapArtifactPropertyToProperties(artProperty schema.ArtifactProperty) models.Properties {
 	return models.Properties{
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -36,6 +37,13 @@ func NewInferenceServiceRepository(db *gorm.DB, typeID int32) models.InferenceSe
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *InferenceServiceRepositoryImpl) WithContext(ctx context.Context) models.InferenceServiceRepository {
+	return &InferenceServiceRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *InferenceServiceRepositoryImpl) Save(inferenceService models.InferenceService) (models.InferenceService, error) {
 	// Extract serving_environment_id from properties for parent relationship
 	var servingEnvironmentID *int32
@@ -57,9 +65,9 @@ func (r *InferenceServiceRepositoryImpl) List(listOptions models.InferenceServic
 func applyInferenceServiceListFilters(query *gorm.DB, listOptions *models.InferenceServiceListOptions) *gorm.DB {
 	if listOptions.Name != nil {
 		if listOptions.ParentResourceID != nil {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%d:%s", *listOptions.ParentResourceID, *listOptions.Name))
+			query = query.Where("name = ?", fmt.Sprintf("%d:%s", *listOptions.ParentResourceID, *listOptions.Name))
 		} else {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+			query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 		}
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+type LineageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLineageRepository(db *gorm.DB) models.LineageRepository {
+	return &LineageRepositoryImpl{db: db}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *LineageRepositoryImpl) WithContext(ctx context.Context) models.LineageRepository {
+	return &LineageRepositoryImpl{db: dbutil.Bind(ctx, r.db)}
+}
+
+// GetLinks returns the links of the entities, the ones between the entities of its namespace for a tenant so that its
+// lineage doesn't walk through the entities of other namespaces.
+func (r *LineageRepositoryImpl) GetLinks(ids models.LineageIDs) ([]models.LineageLink, error) {
+	contextIDs := ids[models.LineageContext]
+	artifactIDs := ids[models.LineageArtifact]
+	executionIDs := ids[models.LineageExecution]
+
+	links := []models.LineageLink{}
+
+	if len(contextIDs) > 0 {
+		var parents []schema.ParentContext
+		query := r.db.Where("context_id IN ? OR parent_context_id IN ?", contextIDs, contextIDs)
+		query = r.inNamespace(query, "context_id", "Context")
+		query = r.inNamespace(query, "parent_context_id", "Context")
+		if err := query.Find(&parents).Error; err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			links = append(links, models.LineageLink{ContextID: parent.ParentContextID, Kind: models.LineageContext, ID: parent.ContextID})
+		}
+	}
+
+	if len(contextIDs) > 0 || len(artifactIDs) > 0 {
+		var attributions []schema.Attribution
+		query := r.db.Where(anyIn(r.db, "context_id", contextIDs, "artifact_id", artifactIDs))
+		query = r.inNamespace(query, "context_id", "Context")
+		query = r.inNamespace(query, "artifact_id", "Artifact")
+		if err := query.Find(&attributions).Error; err != nil {
+			return nil, err
+		}
+		for _, attribution := range attributions {
+			links = append(links, models.LineageLink{ContextID: attribution.ContextID, Kind: models.LineageArtifact, ID: attribution.ArtifactID})
+		}
+	}
+
+	if len(contextIDs) > 0 || len(executionIDs) > 0 {
+		var associations []schema.Association
+		query := r.db.Where(anyIn(r.db, "context_id", contextIDs, "execution_id", executionIDs))
+		query = r.inNamespace(query, "context_id", "Context")
+		if err := query.Find(&associations).Error; err != nil {
+			return nil, err
+		}
+		for _, association := range associations {
+			links = append(links, models.LineageLink{ContextID: association.ContextID, Kind: models.LineageExecution, ID: association.ExecutionID})
+		}
+	}
+
+	return links, nil
+}
+
+// GetNodes returns the entities with the ids, the contexts and artifacts of other namespaces are not found for a tenant.
+func (r *LineageRepositoryImpl) GetNodes(ids models.LineageIDs) ([]models.LineageNode, error) {
+	nodes := []models.LineageNode{}
+
+	appendNodes := func(kind models.LineageKind, table any, tableName string) error {
+		if len(ids[kind]) == 0 {
+			return nil
+		}
+		var rows []struct {
+			ID     int32
+			TypeID int32
+			Name   *string
+		}
+		query := r.db.Model(table)
+		if tableName != "Execution" {
+			query = whereNamespace(query, tableName)
+		}
+		if err := query.Select("id", "type_id", "name").Where("id IN ?", ids[kind]).Order("id").Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			nodes = append(nodes, models.LineageNode{Kind: kind, ID: row.ID, TypeID: row.TypeID, Name: row.Name})
+		}
+		return nil
+	}
+
+	if err := appendNodes(models.LineageContext, &schema.Context{}, "Context"); err != nil {
+		return nil, err
+	}
+	if err := appendNodes(models.LineageArtifact, &schema.Artifact{}, "Artifact"); err != nil {
+		return nil, err
+	}
+	if err := appendNodes(models.LineageExecution, &schema.Execution{}, "Execution"); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// inNamespace restricts query to the rows whose column references an entity of table in the namespace of the tenant
+// of the request, the queries of no tenant are not restricted.
+func (r *LineageRepositoryImpl) inNamespace(query *gorm.DB, column string, table string) *gorm.DB {
+	if api.Namespace(r.db.Statement.Context) == "" {
+		return query
+	}
+	entities := r.db.Session(&gorm.Session{NewDB: true})
+	entities = entities.Table(dbutil.QuoteTableName(entities, table)).Select("id")
+	return query.Where(column+" IN (?)", whereNamespace(entities, table))
+}
+
+// anyIn returns the condition matching the rows with column in ids or otherColumn in otherIDs, skipping the empty
+// lists which IN can't take.
+func anyIn(db *gorm.DB, column string, ids []int32, otherColumn string, otherIDs []int32) *gorm.DB {
+	condition := db.Session(&gorm.Session{NewDB: true})
+	if len(ids) > 0 {
+		condition = condition.Or(column+" IN ?", ids)
+	}
+	if len(otherIDs) > 0 {
+		condition = condition.Or(otherColumn+" IN ?", otherIDs)
+	}
+	return condition
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLineageRepositoryNamespaces(t *testing.T) {
+	sharedDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	modelTypeID := getRegisteredModelTypeID(t, sharedDB)
+	versionTypeID := getModelVersionTypeID(t, sharedDB)
+	artifactTypeID := getModelArtifactTypeID(t, sharedDB)
+
+	// the version of team-a is linked to a registered model and an artifact of team-b
+	modelA := &schema.Context{TypeID: modelTypeID, Name: "lineage-model-a", Namespace: "team-a"}
+	modelB := &schema.Context{TypeID: modelTypeID, Name: "lineage-model-b", Namespace: "team-b"}
+	require.NoError(t, sharedDB.Create(modelA).Error)
+	require.NoError(t, sharedDB.Create(modelB).Error)
+	version := &schema.Context{TypeID: versionTypeID, Name: "v1", Namespace: "team-a"}
+	require.NoError(t, sharedDB.Create(version).Error)
+	artifactA := &schema.Artifact{TypeID: artifactTypeID, Name: apiutils.Of("lineage-artifact-a"), Namespace: "team-a"}
+	artifactB := &schema.Artifact{TypeID: artifactTypeID, Name: apiutils.Of("lineage-artifact-b"), Namespace: "team-b"}
+	require.NoError(t, sharedDB.Create(artifactA).Error)
+	require.NoError(t, sharedDB.Create(artifactB).Error)
+	require.NoError(t, sharedDB.Create(&[]schema.ParentContext{
+		{ContextID: version.ID, ParentContextID: modelA.ID},
+		{ContextID: version.ID, ParentContextID: modelB.ID},
+	}).Error)
+	require.NoError(t, sharedDB.Create(&[]schema.Attribution{
+		{ContextID: version.ID, ArtifactID: artifactA.ID},
+		{ContextID: version.ID, ArtifactID: artifactB.ID},
+	}).Error)
+
+	ids := models.LineageIDs{models.LineageContext: {version.ID}}
+	all := models.LineageIDs{
+		models.LineageContext:  {modelA.ID, modelB.ID, version.ID},
+		models.LineageArtifact: {artifactA.ID, artifactB.ID},
+	}
+
+	t.Run("tenant", func(t *testing.T) {
+		repo := service.NewLineageRepository(sharedDB.WithContext(api.WithNamespace(context.Background(), "team-a")))
+
+		links, err := repo.GetLinks(ids)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []models.LineageLink{
+			{ContextID: modelA.ID, Kind: models.LineageContext, ID: version.ID},
+			{ContextID: version.ID, Kind: models.LineageArtifact, ID: artifactA.ID},
+		}, links, "the links to the entities of other namespaces are not found")
+
+		nodes, err := repo.GetNodes(all)
+		require.NoError(t, err)
+		var found []int32
+		for _, node := range nodes {
+			found = append(found, node.ID)
+		}
+		assert.ElementsMatch(t, []int32{modelA.ID, version.ID, artifactA.ID}, found)
+	})
+
+	t.Run("no tenant", func(t *testing.T) {
+		repo := service.NewLineageRepository(sharedDB)
+
+		links, err := repo.GetLinks(ids)
+		require.NoError(t, err)
+		assert.Len(t, links, 4)
+
+		nodes, err := repo.GetNodes(all)
+		require.NoError(t, err)
+		assert.Len(t, nodes, 5)
+	})
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -67,13 +68,90 @@ func NewMetricHistoryRepository(db *gorm.DB, typeID int32) models.MetricHistoryR
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *MetricHistoryRepositoryImpl) WithContext(ctx context.Context) models.MetricHistoryRepository {
+	return &MetricHistoryRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *MetricHistoryRepositoryImpl) List(listOptions models.MetricHistoryListOptions) (*models.ListWrapper[models.MetricHistory], error) {
 	return r.GenericRepository.List(&listOptions)
 }
 
+func (r *MetricHistoryRepositoryImpl) Aggregate(options models.MetricHistoryAggregateOptions) ([]models.MetricHistoryAggregate, error) {
+	query, err := buildMetricHistoryAggregateQuery(r.config.DB, r.config.TypeID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ExperimentRunID int32
+		Value           float64
+		Count           int32
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error aggregating metric history: %w", err)
+	}
+
+	aggregates := make([]models.MetricHistoryAggregate, 0, len(rows))
+	for _, row := range rows {
+		aggregates = append(aggregates, models.MetricHistoryAggregate{
+			ExperimentRunID: row.ExperimentRunID,
+			Value:           row.Value,
+			Count:           row.Count,
+		})
+	}
+	return aggregates, nil
+}
+
+// metricAggregateFunctions are the SQL aggregate functions of the metric aggregations.
+var metricAggregateFunctions = map[string]string{
+	models.MetricAggregationMax: "MAX",
+	models.MetricAggregationMin: "MIN",
+	models.MetricAggregationAvg: "AVG",
+}
+
+// buildMetricHistoryAggregateQuery builds the query aggregating the value property of the metric history records of
+// the metric, grouped by the experiment runs of the experiment they are attributed to. The records of a run are named
+// "<run id>:<metric name>__<timestamp>", the name is matched on the prefix of the run of each record, excluding the
+// metrics which names start with the metric name followed by "__".
+func buildMetricHistoryAggregateQuery(db *gorm.DB, typeID int32, options models.MetricHistoryAggregateOptions) (*gorm.DB, error) {
+	function, ok := metricAggregateFunctions[options.Aggregation]
+	if !ok {
+		return nil, fmt.Errorf("unsupported metric aggregation %q", options.Aggregation)
+	}
+
+	artifactTable := utils.GetTableName(db, &schema.Artifact{})
+	attributionTable := utils.GetTableName(db, &schema.Attribution{})
+	parentContextTable := utils.GetTableName(db, &schema.ParentContext{})
+	propertyTable := utils.GetTableName(db, &schema.ArtifactProperty{})
+	runID := attributionTable + ".context_id"
+
+	prefix := ":" + escapeLikePattern(options.Name) + `\_\_`
+
+	return db.Table(artifactTable).
+		Select(fmt.Sprintf("%s AS experiment_run_id, %s(value_props.double_value) AS value, COUNT(*) AS count", runID, function)).
+		Joins(utils.BuildAttributionJoin(db)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.context_id = %s", parentContextTable, parentContextTable, runID)).
+		Joins(fmt.Sprintf("JOIN %s AS value_props ON value_props.artifact_id = %s.id AND value_props.name = ? AND value_props.is_custom_property = ?", propertyTable, artifactTable), "value", false).
+		Where(artifactTable+".type_id = ?", typeID).
+		Where(parentContextTable+".parent_context_id = ?", options.ExperimentID).
+		Where(fmt.Sprintf("%s.name LIKE CONCAT(%s, ?)", artifactTable, runID), prefix+"%").
+		Where(fmt.Sprintf("%s.name NOT LIKE CONCAT(%s, ?)", artifactTable, runID), prefix+`%\_\_%`).
+		Where("value_props.double_value IS NOT NULL").
+		Group(runID).
+		Order(runID), nil
+}
+
+// escapeLikePattern escapes the wildcards of a LIKE pattern with the default escape character.
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 func applyMetricHistoryListFilters(query *gorm.DB, listOptions *models.MetricHistoryListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where(utils.GetTableName(query, &schema.Artifact{})+".name LIKE ?", fmt.Sprintf("%%%s%%", *listOptions.Name))
+		query = query.Where(utils.GetTableName(query, &schema.Artifact{})+".name LIKE ? ESCAPE '!'", "%"+escapeLike(*listOptions.Name)+"%")
 	} else if listOptions.ExternalID != nil {
 		query = query.Where(utils.GetTableName(query, &schema.Artifact{})+".external_id = ?", listOptions.ExternalID)
 	}
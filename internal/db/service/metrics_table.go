@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrMetricsTableNotFound = errors.New("metrics table by id not found")
+
+type MetricsTableRepositoryImpl struct {
+	*GenericRepository[models.MetricsTable, schema.Artifact, schema.ArtifactProperty, *models.MetricsTableListOptions]
+}
+
+func NewMetricsTableRepository(db *gorm.DB, typeID int32) models.MetricsTableRepository {
+	config := GenericRepositoryConfig[models.MetricsTable, schema.Artifact, schema.ArtifactProperty, *models.MetricsTableListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapMetricsTableToArtifact,
+		SchemaToEntity:      mapDataLayerToMetricsTable,
+		EntityToProperties:  mapMetricsTableToArtifactProperties,
+		NotFoundError:       ErrMetricsTableNotFound,
+		EntityName:          "metrics table",
+		PropertyFieldName:   "artifact_id",
+		ApplyListFilters:    applyMetricsTableListFilters,
+		IsNewEntity:         func(entity models.MetricsTable) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.MetricsTable) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &MetricsTableRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *MetricsTableRepositoryImpl) WithContext(ctx context.Context) models.MetricsTableRepository {
+	return &MetricsTableRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *MetricsTableRepositoryImpl) Save(metricsTable models.MetricsTable, modelVersionID *int32) (models.MetricsTable, error) {
+	return r.GenericRepository.Save(metricsTable, modelVersionID)
+}
+
+func (r *MetricsTableRepositoryImpl) List(listOptions models.MetricsTableListOptions) (*models.ListWrapper[models.MetricsTable], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyMetricsTableListFilters(query *gorm.DB, listOptions *models.MetricsTableListOptions) *gorm.DB {
+	if listOptions.ModelVersionID != nil {
+		query = query.Joins(utils.BuildAttributionJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Attribution{}, "context_id")+" = ?", listOptions.ModelVersionID)
+	}
+
+	return query
+}
+
+func mapMetricsTableToArtifact(metricsTable models.MetricsTable) schema.Artifact {
+	attrs := metricsTable.GetAttributes()
+	artifact := schema.Artifact{
+		TypeID: *metricsTable.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if metricsTable.GetID() != nil {
+		artifact.ID = *metricsTable.GetID()
+	}
+
+	if attrs != nil {
+		artifact.Name = attrs.Name
+		artifact.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			artifact.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			artifact.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return artifact
+}
+
+func mapMetricsTableToArtifactProperties(metricsTable models.MetricsTable, artifactID int32) []schema.ArtifactProperty {
+	var properties []schema.ArtifactProperty
+
+	if metricsTable.GetProperties() != nil {
+		for _, prop := range *metricsTable.GetProperties() {
+			properties = append(properties, MapPropertiesToArtifactProperty(prop, artifactID, false))
+		}
+	}
+
+	if metricsTable.GetCustomProperties() != nil {
+		for _, prop := range *metricsTable.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToArtifactProperty(prop, artifactID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToMetricsTable(metricsTable schema.Artifact, properties []schema.ArtifactProperty) models.MetricsTable {
+	metricsTableModel := &models.BaseEntity[models.MetricsTableAttributes]{
+		ID:     &metricsTable.ID,
+		TypeID: &metricsTable.TypeID,
+		Attributes: &models.MetricsTableAttributes{
+			Name:                     metricsTable.Name,
+			ExternalID:               metricsTable.ExternalID,
+			CreateTimeSinceEpoch:     &metricsTable.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &metricsTable.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	tableProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapArtifactPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			tableProperties = append(tableProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	metricsTableModel.Properties = &tableProperties
+	metricsTableModel.CustomProperties = &customProperties
+
+	return metricsTableModel
+}
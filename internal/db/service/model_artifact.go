@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -30,6 +30,7 @@ func NewModelArtifactRepository(db *gorm.DB, typeID int32) models.ModelArtifactR
 		ApplyListFilters:    applyModelArtifactListFilters,
 		IsNewEntity:         func(entity models.ModelArtifact) bool { return entity.GetID() == nil },
 		HasCustomProperties: func(entity models.ModelArtifact) bool { return entity.GetCustomProperties() != nil },
+		IndexDigests:        true,
 	}
 
 	return &ModelArtifactRepositoryImpl{
@@ -37,6 +38,13 @@ func NewModelArtifactRepository(db *gorm.DB, typeID int32) models.ModelArtifactR
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ModelArtifactRepositoryImpl) WithContext(ctx context.Context) models.ModelArtifactRepository {
+	return &ModelArtifactRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 // List adapts the generic repository List method to match the interface contract
 func (r *ModelArtifactRepositoryImpl) List(listOptions models.ModelArtifactListOptions) (*models.ListWrapper[models.ModelArtifact], error) {
 	return r.GenericRepository.List(&listOptions)
@@ -44,7 +52,7 @@ func (r *ModelArtifactRepositoryImpl) List(listOptions models.ModelArtifactListO
 
 func applyModelArtifactListFilters(query *gorm.DB, listOptions *models.ModelArtifactListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
@@ -511,4 +511,81 @@ func TestModelArtifactRepository(t *testing.T) {
 		assert.True(t, foundModelFormat, "Should find model_format property")
 		assert.True(t, foundSizeBytes, "Should find size_bytes property")
 	})
+
+	t.Run("TestFindByDigest", func(t *testing.T) {
+		save := func(name, digest string) models.ModelArtifact {
+			saved, err := repo.Save(&models.ModelArtifactImpl{
+				TypeID: apiutils.Of(int32(typeID)),
+				Attributes: &models.ModelArtifactAttributes{
+					Name: apiutils.Of(name),
+					URI:  apiutils.Of("s3://bucket/" + name),
+				},
+				CustomProperties: &[]models.Properties{
+					{Name: models.DigestCustomProperty, StringValue: apiutils.Of(digest), IsCustomProperty: true},
+				},
+			}, nil)
+			require.NoError(t, err)
+			return saved
+		}
+
+		first := save("digest-first", "sha256:aaa")
+		save("digest-second", "sha256:aaa")
+
+		found, err := repo.FindByDigest("sha256:aaa")
+		require.NoError(t, err)
+		assert.Equal(t, *first.GetID(), *found.GetID(), "the first artifact saved with the digest is canonical")
+
+		_, err = repo.FindByDigest("sha256:bbb")
+		assert.ErrorIs(t, err, service.ErrModelArtifactNotFound)
+
+		// changing the digest of the canonical artifact moves its mapping
+		_, err = repo.Save(&models.ModelArtifactImpl{
+			ID:     first.GetID(),
+			TypeID: apiutils.Of(int32(typeID)),
+			CustomProperties: &[]models.Properties{
+				{Name: models.DigestCustomProperty, StringValue: apiutils.Of("sha256:bbb"), IsCustomProperty: true},
+			},
+		}, nil)
+		require.NoError(t, err)
+
+		found, err = repo.FindByDigest("sha256:bbb")
+		require.NoError(t, err)
+		assert.Equal(t, *first.GetID(), *found.GetID())
+	})
+
+	t.Run("TestSaveUpdatesModelVersion", func(t *testing.T) {
+		savedRegisteredModel, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+			TypeID:     apiutils.Of(int32(registeredModelTypeID)),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("test-registered-model-for-last-update")},
+		})
+		require.NoError(t, err)
+		savedModelVersion, err := modelVersionRepo.Save(&models.ModelVersionImpl{
+			TypeID:     apiutils.Of(int32(modelVersionTypeID)),
+			Attributes: &models.ModelVersionAttributes{Name: apiutils.Of("test-model-version-for-last-update")},
+			Properties: &[]models.Properties{{Name: "registered_model_id", IntValue: savedRegisteredModel.GetID()}},
+		})
+		require.NoError(t, err)
+		time.Sleep(2 * time.Millisecond)
+
+		// A new artifact updates its model version, an update of the artifact doesn't
+		saved, err := repo.Save(&models.ModelArtifactImpl{
+			TypeID: apiutils.Of(int32(typeID)),
+			Attributes: &models.ModelArtifactAttributes{
+				Name: apiutils.Of(fmt.Sprintf("%d:last-update-model-artifact", *savedModelVersion.GetID())),
+				URI:  apiutils.Of("s3://bucket/last-update.pkl"),
+			},
+		}, savedModelVersion.GetID())
+		require.NoError(t, err)
+
+		updatedModelVersion, err := modelVersionRepo.GetByID(*savedModelVersion.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, *saved.GetAttributes().LastUpdateTimeSinceEpoch, *updatedModelVersion.GetAttributes().LastUpdateTimeSinceEpoch)
+		time.Sleep(2 * time.Millisecond)
+
+		_, err = repo.Save(saved, savedModelVersion.GetID())
+		require.NoError(t, err)
+		unchangedModelVersion, err := modelVersionRepo.GetByID(*savedModelVersion.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, *updatedModelVersion.GetAttributes().LastUpdateTimeSinceEpoch, *unchangedModelVersion.GetAttributes().LastUpdateTimeSinceEpoch)
+	})
 }
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrModelCardNotFound = errors.New("model card by id not found")
+
+type ModelCardRepositoryImpl struct {
+	*GenericRepository[models.ModelCard, schema.Artifact, schema.ArtifactProperty, *models.ModelCardListOptions]
+}
+
+func NewModelCardRepository(db *gorm.DB, typeID int32) models.ModelCardRepository {
+	config := GenericRepositoryConfig[models.ModelCard, schema.Artifact, schema.ArtifactProperty, *models.ModelCardListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapModelCardToArtifact,
+		SchemaToEntity:      mapDataLayerToModelCard,
+		EntityToProperties:  mapModelCardToArtifactProperties,
+		NotFoundError:       ErrModelCardNotFound,
+		EntityName:          "model card",
+		PropertyFieldName:   "artifact_id",
+		ApplyListFilters:    applyModelCardListFilters,
+		IsNewEntity:         func(entity models.ModelCard) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.ModelCard) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &ModelCardRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ModelCardRepositoryImpl) WithContext(ctx context.Context) models.ModelCardRepository {
+	return &ModelCardRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *ModelCardRepositoryImpl) Save(modelCard models.ModelCard, registeredModelID *int32) (models.ModelCard, error) {
+	return r.GenericRepository.Save(modelCard, registeredModelID)
+}
+
+func (r *ModelCardRepositoryImpl) List(listOptions models.ModelCardListOptions) (*models.ListWrapper[models.ModelCard], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyModelCardListFilters(query *gorm.DB, listOptions *models.ModelCardListOptions) *gorm.DB {
+	if listOptions.RegisteredModelID != nil {
+		query = query.Joins(utils.BuildAttributionJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Attribution{}, "context_id")+" = ?", listOptions.RegisteredModelID)
+	}
+
+	return query
+}
+
+func mapModelCardToArtifact(modelCard models.ModelCard) schema.Artifact {
+	attrs := modelCard.GetAttributes()
+	artifact := schema.Artifact{
+		TypeID: *modelCard.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if modelCard.GetID() != nil {
+		artifact.ID = *modelCard.GetID()
+	}
+
+	if attrs != nil {
+		artifact.Name = attrs.Name
+		artifact.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			artifact.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			artifact.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return artifact
+}
+
+func mapModelCardToArtifactProperties(modelCard models.ModelCard, artifactID int32) []schema.ArtifactProperty {
+	var properties []schema.ArtifactProperty
+
+	if modelCard.GetProperties() != nil {
+		for _, prop := range *modelCard.GetProperties() {
+			properties = append(properties, MapPropertiesToArtifactProperty(prop, artifactID, false))
+		}
+	}
+
+	if modelCard.GetCustomProperties() != nil {
+		for _, prop := range *modelCard.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToArtifactProperty(prop, artifactID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToModelCard(modelCard schema.Artifact, properties []schema.ArtifactProperty) models.ModelCard {
+	modelCardModel := &models.BaseEntity[models.ModelCardAttributes]{
+		ID:     &modelCard.ID,
+		TypeID: &modelCard.TypeID,
+		Attributes: &models.ModelCardAttributes{
+			Name:                     modelCard.Name,
+			ExternalID:               modelCard.ExternalID,
+			CreateTimeSinceEpoch:     &modelCard.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &modelCard.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	cardProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapArtifactPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			cardProperties = append(cardProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	modelCardModel.Properties = &cardProperties
+	modelCardModel.CustomProperties = &customProperties
+
+	return modelCardModel
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -36,30 +37,87 @@ func NewModelVersionRepository(db *gorm.DB, typeID int32) models.ModelVersionRep
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ModelVersionRepositoryImpl) WithContext(ctx context.Context) models.ModelVersionRepository {
+	return &ModelVersionRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *ModelVersionRepositoryImpl) Save(modelVersion models.ModelVersion) (models.ModelVersion, error) {
-	// Extract registered_model_id from properties for parent relationship
-	var registeredModelID *int32
+	return r.GenericRepository.Save(modelVersion, registeredModelIDOf(modelVersion))
+}
+
+func (r *ModelVersionRepositoryImpl) SaveBatch(modelVersions []models.ModelVersion, modelArtifacts models.ModelArtifactsFunc) ([]models.ModelVersion, [][]models.ModelArtifact, error) {
+	registeredModelIDs := make([]*int32, len(modelVersions))
+	for i, modelVersion := range modelVersions {
+		registeredModelIDs[i] = registeredModelIDOf(modelVersion)
+	}
+
+	var savedArtifacts [][]models.ModelArtifact
+
+	saved, err := r.GenericRepository.SaveBatch(modelVersions, registeredModelIDs, func(tx *gorm.DB, saved []models.ModelVersion) error {
+		savedArtifacts = make([][]models.ModelArtifact, len(saved))
+		if modelArtifacts == nil {
+			return nil
+		}
+
+		for i, modelVersion := range saved {
+			artifacts, err := modelArtifacts(i, *modelVersion.GetID())
+			if err != nil {
+				return err
+			}
+
+			for _, artifact := range artifacts {
+				if artifact.GetTypeID() == nil {
+					return fmt.Errorf("error saving model artifact: missing type id")
+				}
+				// the model artifact repository is bound to the transaction and only used to save
+				repository := NewModelArtifactRepository(tx, *artifact.GetTypeID()).(*ModelArtifactRepositoryImpl)
+				savedArtifact, err := repository.saveInTransaction(tx, artifact, modelVersion.GetID())
+				if err != nil {
+					return err
+				}
+				savedArtifacts[i] = append(savedArtifacts[i], savedArtifact)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return saved, savedArtifacts, nil
+}
+
+// registeredModelIDOf extracts the registered_model_id property of the model version, its parent relationship.
+func registeredModelIDOf(modelVersion models.ModelVersion) *int32 {
 	if modelVersion.GetProperties() != nil {
 		for _, prop := range *modelVersion.GetProperties() {
 			if prop.Name == "registered_model_id" && prop.IntValue != nil {
-				registeredModelID = prop.IntValue
-				break
+				return prop.IntValue
 			}
 		}
 	}
-	return r.GenericRepository.Save(modelVersion, registeredModelID)
+	return nil
 }
 
 func (r *ModelVersionRepositoryImpl) List(listOptions models.ModelVersionListOptions) (*models.ListWrapper[models.ModelVersion], error) {
 	return r.GenericRepository.List(&listOptions)
 }
 
+// ListStream adapts the generic repository ListStream method to match the interface contract
+func (r *ModelVersionRepositoryImpl) ListStream(listOptions models.ModelVersionListOptions, yield func(models.ModelVersion) error) (string, error) {
+	return r.GenericRepository.ListStream(&listOptions, yield)
+}
+
 func applyModelVersionListFilters(query *gorm.DB, listOptions *models.ModelVersionListOptions) *gorm.DB {
 	if listOptions.Name != nil {
 		if listOptions.ParentResourceID != nil {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%d:%s", *listOptions.ParentResourceID, *listOptions.Name))
+			query = query.Where("name = ?", fmt.Sprintf("%d:%s", *listOptions.ParentResourceID, *listOptions.Name))
 		} else {
-			query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+			query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 		}
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
@@ -71,7 +129,12 @@ func applyModelVersionListFilters(query *gorm.DB, listOptions *models.ModelVersi
 			Where(utils.GetColumnRef(query, &schema.ParentContext{}, "parent_context_id")+" = ?", listOptions.ParentResourceID)
 	}
 
-	return query
+	if listOptions.ArtifactID != nil {
+		query = query.Joins(utils.BuildContextAttributionJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Attribution{}, "artifact_id")+" = ?", listOptions.ArtifactID)
+	}
+
+	return applyContextStateFilter(query, listOptions.State)
 }
 
 func mapModelVersionToContext(modelVersion models.ModelVersion) schema.Context {
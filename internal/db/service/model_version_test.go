@@ -388,6 +388,79 @@ func TestModelVersionRepository(t *testing.T) {
 		assert.NotNil(t, retrieved.GetCustomProperties())
 		assert.Len(t, *retrieved.GetCustomProperties(), 2)
 	})
+
+	t.Run("TestGetCustomPropertyByIDs", func(t *testing.T) {
+		parentModel := &models.RegisteredModelImpl{
+			TypeID: apiutils.Of(int32(registeredModelTypeID)),
+			Attributes: &models.RegisteredModelAttributes{
+				Name: apiutils.Of("parent-model-for-property-values"),
+			},
+			CustomProperties: &[]models.Properties{
+				{Name: "accuracy", IsCustomProperty: true, DoubleValue: apiutils.Of(0.5)},
+			},
+		}
+		savedParent, err := registeredModelRepo.Save(parentModel)
+		require.NoError(t, err)
+
+		ids := []int32{*savedParent.GetID()}
+		for i := range 3 {
+			customProperties := []models.Properties{}
+			if i != 1 {
+				customProperties = append(customProperties, models.Properties{Name: "accuracy", IsCustomProperty: true, DoubleValue: apiutils.Of(0.9 + float64(i)/100)})
+			}
+			saved, err := repo.Save(&models.ModelVersionImpl{
+				TypeID: apiutils.Of(int32(typeID)),
+				Attributes: &models.ModelVersionAttributes{
+					Name: apiutils.Of(fmt.Sprintf("%d:property-values-v%d", *savedParent.GetID(), i)),
+				},
+				Properties: &[]models.Properties{
+					// a property with the same name is not a custom property
+					{Name: "accuracy", DoubleValue: apiutils.Of(0.1)},
+					{Name: "registered_model_id", IntValue: savedParent.GetID()},
+				},
+				CustomProperties: &customProperties,
+			})
+			require.NoError(t, err)
+			ids = append(ids, *saved.GetID())
+		}
+
+		properties, err := repo.GetCustomPropertyByIDs(append(ids, 99999), "accuracy")
+		require.NoError(t, err)
+
+		// the registered model, the version without the property and the unknown id are skipped
+		require.Len(t, properties, 2)
+		assert.InDelta(t, 0.9, *properties[ids[1]].DoubleValue, 1e-9)
+		assert.InDelta(t, 0.92, *properties[ids[3]].DoubleValue, 1e-9)
+		assert.True(t, properties[ids[1]].IsCustomProperty)
+	})
+
+	t.Run("TestListNameIsLiteral", func(t *testing.T) {
+		savedParent, err := registeredModelRepo.Save(&models.RegisteredModelImpl{
+			TypeID:     apiutils.Of(int32(registeredModelTypeID)),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("parent-model-for-literal-names")},
+		})
+		require.NoError(t, err)
+		for _, name := range []string{"v_1", "vX1", "v1%"} {
+			_, err := repo.Save(&models.ModelVersionImpl{
+				TypeID:     apiutils.Of(int32(typeID)),
+				Attributes: &models.ModelVersionAttributes{Name: apiutils.Of(fmt.Sprintf("%d:%s", *savedParent.GetID(), name))},
+				Properties: &[]models.Properties{{Name: "registered_model_id", IntValue: savedParent.GetID()}},
+			})
+			require.NoError(t, err)
+		}
+
+		// the wildcards of the names are matched literally, with and without the parent
+		for _, name := range []string{"v_1", "v1%"} {
+			list, err := repo.List(models.ModelVersionListOptions{Name: apiutils.Of(name), ParentResourceID: savedParent.GetID()})
+			require.NoError(t, err)
+			require.Len(t, list.Items, 1)
+			assert.Equal(t, fmt.Sprintf("%d:%s", *savedParent.GetID(), name), *list.Items[0].GetAttributes().Name)
+
+			list, err = repo.List(models.ModelVersionListOptions{Name: apiutils.Of(name)})
+			require.NoError(t, err)
+			require.Len(t, list.Items, 1)
+		}
+	})
 }
 
 func TestModelVersionRepository_FilterQuery(t *testing.T) {
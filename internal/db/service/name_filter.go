@@ -0,0 +1,14 @@
+package service
+
+import "strings"
+
+// likeEscape is the escape character of the name patterns. Unlike the backslash, it has no special meaning in the
+// string literals of MySQL and PostgreSQL.
+const likeEscape = "!"
+
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// escapeLike escapes the wildcards of s, so that a name LIKE ? ESCAPE '!' pattern matches it literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
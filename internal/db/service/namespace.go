@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// whereNamespace restricts the query of table, Context, Artifact or audit_events, to the namespace of the tenant of its context.
+// The queries of no tenant are not restricted.
+func whereNamespace(query *gorm.DB, table string) *gorm.DB {
+	namespace := api.Namespace(query.Statement.Context)
+	if namespace == "" {
+		return query
+	}
+	return query.Where(dbutil.QuoteTableName(query, table)+".namespace = ?", namespace)
+}
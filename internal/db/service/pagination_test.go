@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPaginationProperties pages through random datasets with many equal orderBy values while other models are
+// inserted concurrently: every model existing before the first page must be listed exactly once, and the pages
+// must follow the requested order.
+func TestPaginationProperties(t *testing.T) {
+	sharedDB, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	typeID := getRegisteredModelTypeID(t, sharedDB)
+	repo := service.NewRegisteredModelRepository(sharedDB, typeID)
+
+	orderBys := []string{"ID", "CREATE_TIME", "LAST_UPDATE_TIME"}
+	sortOrders := []string{"ASC", "DESC"}
+
+	for seed := uint64(1); seed <= 20; seed++ {
+		rng := rand.New(rand.NewPCG(seed, seed))
+
+		orderBy := orderBys[rng.IntN(len(orderBys))]
+		sortOrder := sortOrders[rng.IntN(len(sortOrders))]
+		pageSize := int32(rng.IntN(10) + 1)
+
+		t.Run(fmt.Sprintf("seed %d %s %s page size %d", seed, orderBy, sortOrder, pageSize), func(t *testing.T) {
+			testutils.CleanupTestData(t, sharedDB)
+
+			// few distinct times, so that most models share their orderBy value with others
+			times := rng.IntN(4) + 1
+			save := func(name string) (int32, error) {
+				saved, err := repo.Save(&models.RegisteredModelImpl{
+					TypeID:     apiutils.Of(typeID),
+					Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of(name)},
+				})
+				if err != nil {
+					return 0, err
+				}
+				return *saved.GetID(), nil
+			}
+
+			existing := map[int32]bool{}
+			for i := range rng.IntN(40) + 1 {
+				id, err := save(fmt.Sprintf("model-%d", i))
+				require.NoError(t, err)
+				err = sharedDB.Model(&schema.Context{}).Where("id = ?", id).Updates(map[string]any{
+					"create_time_since_epoch":      int64(rng.IntN(times)),
+					"last_update_time_since_epoch": int64(rng.IntN(times)),
+				}).Error
+				require.NoError(t, err)
+				existing[id] = true
+			}
+
+			// insert models concurrently with the paging, saved in the same milliseconds their times are often equal
+			inserts := rng.IntN(20)
+			var (
+				wg        sync.WaitGroup
+				insertErr error
+			)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range inserts {
+					if _, insertErr = save(fmt.Sprintf("inserted-%d", i)); insertErr != nil {
+						return
+					}
+				}
+			}()
+
+			listed := []models.RegisteredModel{}
+			listOptions := models.RegisteredModelListOptions{
+				Pagination: models.Pagination{
+					PageSize:  &pageSize,
+					OrderBy:   &orderBy,
+					SortOrder: &sortOrder,
+				},
+			}
+			for {
+				page, err := repo.List(listOptions)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(page.Items), int(pageSize))
+				listed = append(listed, page.Items...)
+
+				if page.NextPageToken == "" {
+					break
+				}
+				listOptions.NextPageToken = apiutils.Of(page.NextPageToken)
+			}
+			wg.Wait()
+			require.NoError(t, insertErr)
+
+			seen := map[int32]int{}
+			for _, model := range listed {
+				seen[*model.GetID()]++
+			}
+			for id, count := range seen {
+				assert.Equal(t, 1, count, "model %d listed more than once", id)
+			}
+			for id := range existing {
+				assert.Contains(t, seen, id, "model %d skipped", id)
+			}
+
+			assert.True(t, slices.IsSortedFunc(listed, func(a, b models.RegisteredModel) int {
+				compare := paginationKey(a, orderBy).compare(paginationKey(b, orderBy))
+				if sortOrder == "DESC" {
+					return -compare
+				}
+				return compare
+			}), "models not listed in %s %s order", orderBy, sortOrder)
+		})
+	}
+}
+
+// paginationOrder is the (orderBy value, id) key of a model in a page.
+type paginationOrder struct {
+	value int64
+	id    int32
+}
+
+func (p paginationOrder) compare(other paginationOrder) int {
+	if p.value != other.value {
+		return cmp.Compare(p.value, other.value)
+	}
+	return cmp.Compare(p.id, other.id)
+}
+
+func paginationKey(model models.RegisteredModel, orderBy string) paginationOrder {
+	key := paginationOrder{id: *model.GetID()}
+	switch orderBy {
+	case "CREATE_TIME":
+		key.value = *model.GetAttributes().CreateTimeSinceEpoch
+	case "LAST_UPDATE_TIME":
+		key.value = *model.GetAttributes().LastUpdateTimeSinceEpoch
+	default:
+		key.value = int64(key.id)
+	}
+	return key
+}
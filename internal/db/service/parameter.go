@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -37,6 +37,13 @@ func NewParameterRepository(db *gorm.DB, typeID int32) models.ParameterRepositor
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ParameterRepositoryImpl) WithContext(ctx context.Context) models.ParameterRepository {
+	return &ParameterRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 // List adapts the generic repository List method to match the interface contract
 func (r *ParameterRepositoryImpl) List(listOptions models.ParameterListOptions) (*models.ListWrapper[models.Parameter], error) {
 	return r.GenericRepository.List(&listOptions)
@@ -44,7 +51,7 @@ func (r *ParameterRepositoryImpl) List(listOptions models.ParameterListOptions)
 
 func applyParameterListFilters(query *gorm.DB, listOptions *models.ParameterListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where("name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
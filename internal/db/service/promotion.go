@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"gorm.io/gorm"
+)
+
+var ErrPromotionNotFound = errors.New("promotion by id not found")
+
+type PromotionRepositoryImpl struct {
+	*GenericRepository[models.Promotion, schema.Context, schema.ContextProperty, *models.PromotionListOptions]
+}
+
+func NewPromotionRepository(db *gorm.DB, typeID int32) models.PromotionRepository {
+	config := GenericRepositoryConfig[models.Promotion, schema.Context, schema.ContextProperty, *models.PromotionListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapPromotionToContext,
+		SchemaToEntity:      mapDataLayerToPromotion,
+		EntityToProperties:  mapPromotionToContextProperties,
+		NotFoundError:       ErrPromotionNotFound,
+		EntityName:          "promotion",
+		PropertyFieldName:   "context_id",
+		ApplyListFilters:    applyPromotionListFilters,
+		IsNewEntity:         func(entity models.Promotion) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.Promotion) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &PromotionRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *PromotionRepositoryImpl) WithContext(ctx context.Context) models.PromotionRepository {
+	return &PromotionRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *PromotionRepositoryImpl) Save(promotion models.Promotion) (models.Promotion, error) {
+	return r.GenericRepository.Save(promotion, nil)
+}
+
+func (r *PromotionRepositoryImpl) List(listOptions models.PromotionListOptions) (*models.ListWrapper[models.Promotion], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyPromotionListFilters(query *gorm.DB, listOptions *models.PromotionListOptions) *gorm.DB {
+	if listOptions.Name != nil {
+		query = query.Where("name = ?", listOptions.Name)
+	}
+	return query
+}
+
+func mapPromotionToContext(promotion models.Promotion) schema.Context {
+	attrs := promotion.GetAttributes()
+	context := schema.Context{
+		TypeID: *promotion.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if promotion.GetID() != nil {
+		context.ID = *promotion.GetID()
+	}
+
+	if attrs != nil {
+		if attrs.Name != nil {
+			context.Name = *attrs.Name
+		}
+		context.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			context.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			context.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return context
+}
+
+func mapPromotionToContextProperties(promotion models.Promotion, contextID int32) []schema.ContextProperty {
+	var properties []schema.ContextProperty
+
+	if promotion.GetProperties() != nil {
+		for _, prop := range *promotion.GetProperties() {
+			properties = append(properties, MapPropertiesToContextProperty(prop, contextID, false))
+		}
+	}
+
+	if promotion.GetCustomProperties() != nil {
+		for _, prop := range *promotion.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToContextProperty(prop, contextID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToPromotion(promotionCtx schema.Context, propertiesCtx []schema.ContextProperty) models.Promotion {
+	promotionModel := &models.BaseEntity[models.PromotionAttributes]{
+		ID:     &promotionCtx.ID,
+		TypeID: &promotionCtx.TypeID,
+		Attributes: &models.PromotionAttributes{
+			Name:                     &promotionCtx.Name,
+			ExternalID:               promotionCtx.ExternalID,
+			CreateTimeSinceEpoch:     &promotionCtx.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &promotionCtx.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	properties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range propertiesCtx {
+		mappedProperty := MapContextPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			properties = append(properties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	promotionModel.Properties = &properties
+	promotionModel.CustomProperties = &customProperties
+
+	return promotionModel
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrPromotionRunNotFound = errors.New("promotion run by id not found")
+
+type PromotionRunRepositoryImpl struct {
+	*GenericRepository[models.PromotionRun, schema.Execution, schema.ExecutionProperty, *models.PromotionRunListOptions]
+}
+
+func NewPromotionRunRepository(db *gorm.DB, typeID int32) models.PromotionRunRepository {
+	config := GenericRepositoryConfig[models.PromotionRun, schema.Execution, schema.ExecutionProperty, *models.PromotionRunListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapPromotionRunToExecution,
+		SchemaToEntity:      mapDataLayerToPromotionRun,
+		EntityToProperties:  mapPromotionRunToExecutionProperties,
+		NotFoundError:       ErrPromotionRunNotFound,
+		EntityName:          "promotion run",
+		PropertyFieldName:   "execution_id",
+		ApplyListFilters:    applyPromotionRunListFilters,
+		IsNewEntity:         func(entity models.PromotionRun) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.PromotionRun) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &PromotionRunRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *PromotionRunRepositoryImpl) WithContext(ctx context.Context) models.PromotionRunRepository {
+	return &PromotionRunRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *PromotionRunRepositoryImpl) Save(promotionRun models.PromotionRun, promotionID *int32) (models.PromotionRun, error) {
+	return r.GenericRepository.Save(promotionRun, promotionID)
+}
+
+func (r *PromotionRunRepositoryImpl) List(listOptions models.PromotionRunListOptions) (*models.ListWrapper[models.PromotionRun], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyPromotionRunListFilters(query *gorm.DB, listOptions *models.PromotionRunListOptions) *gorm.DB {
+	if listOptions.PromotionID != nil {
+		query = query.Joins(utils.BuildAssociationJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Association{}, "context_id")+" = ?", listOptions.PromotionID)
+	}
+
+	return query
+}
+
+func mapPromotionRunToExecution(promotionRun models.PromotionRun) schema.Execution {
+	attrs := promotionRun.GetAttributes()
+	execution := schema.Execution{
+		TypeID: *promotionRun.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if promotionRun.GetID() != nil {
+		execution.ID = *promotionRun.GetID()
+	}
+
+	if attrs != nil {
+		execution.Name = attrs.Name
+		execution.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			execution.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			execution.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return execution
+}
+
+func mapPromotionRunToExecutionProperties(promotionRun models.PromotionRun, executionID int32) []schema.ExecutionProperty {
+	var properties []schema.ExecutionProperty
+
+	if promotionRun.GetProperties() != nil {
+		for _, prop := range *promotionRun.GetProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, false))
+		}
+	}
+
+	if promotionRun.GetCustomProperties() != nil {
+		for _, prop := range *promotionRun.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToPromotionRun(promotionRun schema.Execution, properties []schema.ExecutionProperty) models.PromotionRun {
+	promotionRunModel := &models.BaseEntity[models.PromotionRunAttributes]{
+		ID:     &promotionRun.ID,
+		TypeID: &promotionRun.TypeID,
+		Attributes: &models.PromotionRunAttributes{
+			Name:                     promotionRun.Name,
+			ExternalID:               promotionRun.ExternalID,
+			CreateTimeSinceEpoch:     &promotionRun.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &promotionRun.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	runProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapExecutionPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			runProperties = append(runProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	promotionRunModel.Properties = &runProperties
+	promotionRunModel.CustomProperties = &customProperties
+
+	return promotionRunModel
+}
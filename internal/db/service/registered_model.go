@@ -1,10 +1,12 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
 	"gorm.io/gorm"
 )
 
@@ -27,6 +29,7 @@ func NewRegisteredModelRepository(db *gorm.DB, typeID int32) models.RegisteredMo
 		ApplyListFilters:    applyRegisteredModelListFilters,
 		IsNewEntity:         func(entity models.RegisteredModel) bool { return entity.GetID() == nil },
 		HasCustomProperties: func(entity models.RegisteredModel) bool { return entity.GetCustomProperties() != nil },
+		RecordRevisions:     true,
 	}
 
 	return &RegisteredModelRepositoryImpl{
@@ -34,6 +37,13 @@ func NewRegisteredModelRepository(db *gorm.DB, typeID int32) models.RegisteredMo
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *RegisteredModelRepositoryImpl) WithContext(ctx context.Context) models.RegisteredModelRepository {
+	return &RegisteredModelRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *RegisteredModelRepositoryImpl) Save(model models.RegisteredModel) (models.RegisteredModel, error) {
 	return r.GenericRepository.Save(model, nil)
 }
@@ -42,13 +52,37 @@ func (r *RegisteredModelRepositoryImpl) List(listOptions models.RegisteredModelL
 	return r.GenericRepository.List(&listOptions)
 }
 
+// ListStream adapts the generic repository ListStream method to match the interface contract
+func (r *RegisteredModelRepositoryImpl) ListStream(listOptions models.RegisteredModelListOptions, yield func(models.RegisteredModel) error) (string, error) {
+	return r.GenericRepository.ListStream(&listOptions, yield)
+}
+
 func applyRegisteredModelListFilters(query *gorm.DB, listOptions *models.RegisteredModelListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", listOptions.Name)
+		query = query.Where("name = ?", listOptions.Name)
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
-	return query
+	return applyContextStateFilter(query, listOptions.State)
+}
+
+// applyContextStateFilter restricts the contexts to the LIVE or ARCHIVED state, stored in their state property.
+// Contexts without state are live.
+func applyContextStateFilter(query *gorm.DB, state *string) *gorm.DB {
+	if state == nil {
+		return query
+	}
+
+	archived := query.Session(&gorm.Session{NewDB: true}).
+		Model(&schema.ContextProperty{}).
+		Select("context_id").
+		Where("name = ? AND is_custom_property = ? AND string_value = ?", "state", false, "ARCHIVED")
+
+	column := utils.GetColumnRef(query, &schema.Context{}, "id")
+	if *state == "ARCHIVED" {
+		return query.Where(column+" IN (?)", archived)
+	}
+	return query.Where(column+" NOT IN (?)", archived)
 }
 
 func mapRegisteredModelToContext(model models.RegisteredModel) schema.Context {
@@ -1,13 +1,20 @@
 package service_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/budget"
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/encryption"
 	"github.com/kubeflow/model-registry/internal/testutils"
+	"github.com/kubeflow/model-registry/pkg/api"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -89,6 +96,40 @@ func TestRegisteredModelRepository(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("TestGetByIDs", func(t *testing.T) {
+		var ids []int32
+		for _, name := range []string{"get-ids-model-1", "get-ids-model-2"} {
+			saved, err := repo.Save(&models.RegisteredModelImpl{
+				TypeID: apiutils.Of(int32(typeID)),
+				Attributes: &models.RegisteredModelAttributes{
+					Name: apiutils.Of(name),
+				},
+				Properties: &[]models.Properties{
+					{
+						Name:        "description",
+						StringValue: apiutils.Of(name + " description"),
+					},
+				},
+			})
+			require.NoError(t, err)
+			ids = append(ids, *saved.GetID())
+		}
+
+		// Unknown ids are skipped and results are ordered by id
+		retrieved, err := repo.GetByIDs([]int32{ids[1], 99999, ids[0]})
+		require.NoError(t, err)
+		require.Len(t, retrieved, 2)
+		assert.Equal(t, ids[0], *retrieved[0].GetID())
+		assert.Equal(t, ids[1], *retrieved[1].GetID())
+		require.NotNil(t, retrieved[1].GetProperties())
+		require.Len(t, *retrieved[1].GetProperties(), 1)
+		assert.Equal(t, "get-ids-model-2 description", *(*retrieved[1].GetProperties())[0].StringValue)
+
+		retrieved, err = repo.GetByIDs(nil)
+		require.NoError(t, err)
+		assert.Empty(t, retrieved)
+	})
+
 	t.Run("TestList", func(t *testing.T) {
 		// Create multiple models for listing
 		testModels := []*models.RegisteredModelImpl{
@@ -279,4 +320,161 @@ func TestRegisteredModelRepository(t *testing.T) {
 		assert.NotNil(t, retrieved.GetCustomProperties())
 		assert.Len(t, *retrieved.GetCustomProperties(), 2)
 	})
+
+	t.Run("TestListLoadsPropertiesInOneQuery", func(t *testing.T) {
+		for i := range 5 {
+			_, err := repo.Save(&models.RegisteredModelImpl{
+				TypeID: apiutils.Of(int32(typeID)),
+				Attributes: &models.RegisteredModelAttributes{
+					Name: apiutils.Of(fmt.Sprintf("batched-model-%d", i)),
+				},
+				Properties: &[]models.Properties{
+					{Name: "owner", StringValue: apiutils.Of("owner")},
+				},
+			})
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, budget.Register(sharedDB))
+		queryBudget := budget.New(budget.Limits{})
+		ctx := budget.NewContext(context.Background(), queryBudget)
+
+		listOptions := models.RegisteredModelListOptions{}
+		listOptions.PageSize = apiutils.Of(int32(100))
+		result, err := service.NewRegisteredModelRepository(sharedDB.WithContext(ctx), typeID).List(listOptions)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, len(result.Items), 5)
+
+		// one query for the page of models and one for all their properties
+		statements, _ := queryBudget.Used()
+		assert.Equal(t, int64(2), statements)
+	})
+	t.Run("TestListStream", func(t *testing.T) {
+		for i := range 5 {
+			_, err := repo.Save(&models.RegisteredModelImpl{
+				TypeID: apiutils.Of(int32(typeID)),
+				Attributes: &models.RegisteredModelAttributes{
+					Name: apiutils.Of(fmt.Sprintf("streamed-model-%d", i)),
+				},
+				Properties: &[]models.Properties{
+					{Name: "owner", StringValue: apiutils.Of(fmt.Sprintf("owner-%d", i))},
+				},
+			})
+			require.NoError(t, err)
+		}
+
+		listOptions := models.RegisteredModelListOptions{Name: apiutils.Of("streamed-model-%")}
+		listOptions.PageSize = apiutils.Of(int32(3))
+		listOptions.OrderBy = apiutils.Of("ID")
+
+		// the streamed pages are the listed pages
+		page, err := repo.List(listOptions)
+		require.NoError(t, err)
+
+		var streamed []models.RegisteredModel
+		nextPageToken, err := repo.ListStream(listOptions, func(model models.RegisteredModel) error {
+			streamed = append(streamed, model)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, page.Items, streamed)
+		assert.Equal(t, page.NextPageToken, nextPageToken)
+
+		listOptions.NextPageToken = &nextPageToken
+		streamed = nil
+		nextPageToken, err = repo.ListStream(listOptions, func(model models.RegisteredModel) error {
+			streamed = append(streamed, model)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, streamed, 2)
+		assert.Contains(t, *streamed[1].GetProperties(), models.Properties{Name: "owner", StringValue: apiutils.Of("owner-4")})
+		assert.Empty(t, nextPageToken)
+
+		// the stream stops at the first error of yield
+		stop := errors.New("stop")
+		count := 0
+		_, err = repo.ListStream(listOptions, func(model models.RegisteredModel) error {
+			count++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, count)
+	})
+	t.Run("TestListNameIsLiteral", func(t *testing.T) {
+		for _, name := range []string{"literal_model", "literalXmodel", "literal%"} {
+			_, err := repo.Save(&models.RegisteredModelImpl{
+				TypeID:     apiutils.Of(int32(typeID)),
+				Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of(name)},
+			})
+			require.NoError(t, err)
+		}
+
+		// the wildcards of the names are matched literally
+		for _, name := range []string{"literal_model", "literal%"} {
+			list, err := repo.List(models.RegisteredModelListOptions{Name: apiutils.Of(name)})
+			require.NoError(t, err)
+			require.Len(t, list.Items, 1)
+			assert.Equal(t, name, *list.Items[0].GetAttributes().Name)
+		}
+	})
+	t.Run("TestNamespaces", func(t *testing.T) {
+		teamA := service.NewRegisteredModelRepository(sharedDB.WithContext(api.WithNamespace(context.Background(), "team-a")), typeID)
+		teamB := service.NewRegisteredModelRepository(sharedDB.WithContext(api.WithNamespace(context.Background(), "team-b")), typeID)
+
+		// the names are unique per namespace
+		modelA, err := teamA.Save(&models.RegisteredModelImpl{
+			TypeID:     apiutils.Of(int32(typeID)),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("tenant-model")},
+		})
+		require.NoError(t, err)
+		modelB, err := teamB.Save(&models.RegisteredModelImpl{
+			TypeID:     apiutils.Of(int32(typeID)),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("tenant-model")},
+		})
+		require.NoError(t, err)
+
+		// the tenants only see the entities of their namespace
+		list, err := teamA.List(models.RegisteredModelListOptions{Name: apiutils.Of("tenant-model")})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, *modelA.GetID(), *list.Items[0].GetID())
+
+		_, err = teamA.GetByID(*modelB.GetID())
+		assert.ErrorIs(t, err, service.ErrRegisteredModelNotFound)
+		_, err = teamA.Save(&models.RegisteredModelImpl{
+			ID:         modelB.GetID(),
+			TypeID:     apiutils.Of(int32(typeID)),
+			Attributes: &models.RegisteredModelAttributes{Name: apiutils.Of("tenant-model")},
+		})
+		assert.ErrorIs(t, err, service.ErrRegisteredModelNotFound)
+
+		// the requests of no tenant see all the entities
+		list, err = repo.List(models.RegisteredModelListOptions{Name: apiutils.Of("tenant-model")})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+	})
+	t.Run("TestListRejectsEncryptedProperties", func(t *testing.T) {
+		masterKey, err := encryption.NewLocalMasterKey([]byte(strings.Repeat("k", 32)))
+		require.NoError(t, err)
+		cipher, err := encryption.NewCipher(sharedDB, encryption.Config{Properties: []string{"owner_email"}}, masterKey)
+		require.NoError(t, err)
+		require.NoError(t, sharedDB.Use(cipher))
+
+		// the encrypted values would be compared encrypted
+		filtered := models.RegisteredModelListOptions{}
+		filtered.FilterQuery = apiutils.Of(`owner_email = "jane@example.com"`)
+		_, err = repo.List(filtered)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		ordered := models.RegisteredModelListOptions{}
+		ordered.OrderBy = apiutils.Of("customProperties.owner_email.string_value")
+		_, err = repo.List(ordered)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+
+		// the other custom properties still filter the lists
+		filtered.FilterQuery = apiutils.Of(`owner = "owner"`)
+		_, err = repo.List(filtered)
+		assert.NoError(t, err)
+	})
 }
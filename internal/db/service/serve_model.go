@@ -1,8 +1,8 @@
 package service
 
 import (
+	"context"
 	"errors"
-	"fmt"
 
 	"github.com/kubeflow/model-registry/internal/db/models"
 	"github.com/kubeflow/model-registry/internal/db/schema"
@@ -36,6 +36,13 @@ func NewServeModelRepository(db *gorm.DB, typeID int32) models.ServeModelReposit
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ServeModelRepositoryImpl) WithContext(ctx context.Context) models.ServeModelRepository {
+	return &ServeModelRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *ServeModelRepositoryImpl) Save(serveModel models.ServeModel, inferenceServiceID *int32) (models.ServeModel, error) {
 	return r.GenericRepository.Save(serveModel, inferenceServiceID)
 }
@@ -46,7 +53,7 @@ func (r *ServeModelRepositoryImpl) List(listOptions models.ServeModelListOptions
 
 func applyServeModelListFilters(query *gorm.DB, listOptions *models.ServeModelListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where(utils.GetTableName(query, &schema.Execution{})+".name LIKE ?", fmt.Sprintf("%%:%s", *listOptions.Name))
+		query = query.Where(utils.GetTableName(query, &schema.Execution{})+".name LIKE ? ESCAPE '!'", "%:"+escapeLike(*listOptions.Name))
 	} else if listOptions.ExternalID != nil {
 		query = query.Where(utils.GetTableName(query, &schema.Execution{})+".external_id = ?", listOptions.ExternalID)
 	}
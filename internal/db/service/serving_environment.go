@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 
 	"github.com/kubeflow/model-registry/internal/db/models"
@@ -34,6 +35,13 @@ func NewServingEnvironmentRepository(db *gorm.DB, typeID int32) models.ServingEn
 	}
 }
 
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *ServingEnvironmentRepositoryImpl) WithContext(ctx context.Context) models.ServingEnvironmentRepository {
+	return &ServingEnvironmentRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
 func (r *ServingEnvironmentRepositoryImpl) Save(servEnv models.ServingEnvironment) (models.ServingEnvironment, error) {
 	return r.GenericRepository.Save(servEnv, nil)
 }
@@ -44,7 +52,7 @@ func (r *ServingEnvironmentRepositoryImpl) List(listOptions models.ServingEnviro
 
 func applyServingEnvironmentListFilters(query *gorm.DB, listOptions *models.ServingEnvironmentListOptions) *gorm.DB {
 	if listOptions.Name != nil {
-		query = query.Where("name LIKE ?", listOptions.Name)
+		query = query.Where("name = ?", listOptions.Name)
 	} else if listOptions.ExternalID != nil {
 		query = query.Where("external_id = ?", listOptions.ExternalID)
 	}
@@ -13,10 +13,26 @@ func DatastoreSpec() *datastore.Spec {
 			AddString("model_format_version").
 			AddString("service_account_name").
 			AddString("storage_key").
-			AddString("storage_path"),
+			AddString("storage_path").
+			AddString("variant_architecture").
+			AddString("variant_accelerator").
+			AddString("variant_precision").
+			AddString("variant_engine").
+			AddBoolean("uri_reachable").
+			AddString("uri_size_bytes").
+			AddString("uri_verified_at").
+			AddString("uri_verification_message").
+			AddString("uri_scheme").
+			AddString("uri_bucket").
+			AddString("uri_path").
+			AddString("uri_region"),
 		).
 		AddArtifact(defaults.DocArtifactTypeName, datastore.NewSpecType(NewDocArtifactRepository).
-			AddString("description"),
+			AddString("description").
+			AddString("uri_scheme").
+			AddString("uri_bucket").
+			AddString("uri_path").
+			AddString("uri_region"),
 		).
 		AddArtifact(defaults.DataSetTypeName, datastore.NewSpecType(NewDataSetRepository).
 			AddString("description").
@@ -43,6 +59,19 @@ func DatastoreSpec() *datastore.Spec {
 			AddString("timestamp").
 			AddInt("step"),
 		).
+		AddArtifact(defaults.MetricsTableTypeName, datastore.NewSpecType(NewMetricsTableRepository).
+			AddString("description").
+			AddInt("model_version_id").
+			AddStruct("data"),
+		).
+		AddArtifact(defaults.ModelCardTypeName, datastore.NewSpecType(NewModelCardRepository).
+			AddInt("registered_model_id").
+			AddString("intended_use").
+			AddString("out_of_scope_uses").
+			AddString("limitations").
+			AddString("ethical_considerations").
+			AddString("training_data"),
+		).
 		AddContext(defaults.RegisteredModelTypeName, datastore.NewSpecType(NewRegisteredModelRepository).
 			AddString("description").
 			AddString("owner").
@@ -61,8 +90,15 @@ func DatastoreSpec() *datastore.Spec {
 			AddString("author").
 			AddString("description").
 			AddString("model_name").
+			AddString("policy").
 			AddString("state").
-			AddString("version"),
+			AddString("version").
+			AddString("cpu_request").
+			AddString("memory_request").
+			AddString("accelerator_type").
+			AddInt("accelerator_count").
+			AddDouble("cost_per_1k_inferences").
+			AddString("cost_currency"),
 		).
 		AddContext(defaults.ServingEnvironmentTypeName, datastore.NewSpecType(NewServingEnvironmentRepository).
 			AddString("description"),
@@ -77,6 +113,7 @@ func DatastoreSpec() *datastore.Spec {
 		).
 		AddContext(defaults.ExperimentTypeName, datastore.NewSpecType(NewExperimentRepository).
 			AddString("description").
+			AddString("metric_schema").
 			AddString("owner").
 			AddString("state"),
 		).
@@ -89,9 +126,61 @@ func DatastoreSpec() *datastore.Spec {
 			AddInt("end_time_since_epoch").
 			AddInt("experiment_id"),
 		).
+		AddContext(defaults.PromotionTypeName, datastore.NewSpecType(NewPromotionRepository).
+			AddString("description").
+			AddString("source").
+			AddString("target").
+			AddBoolean("require_approval"),
+		).
+		AddExecution(defaults.PromotionRunTypeName, datastore.NewSpecType(NewPromotionRunRepository).
+			AddInt("promotion_id").
+			AddString("state").
+			AddString("reviewer").
+			AddString("review_comment").
+			AddString("message").
+			AddString("model_versions"),
+		).
+		AddExecution(defaults.ConversionJobTypeName, datastore.NewSpecType(NewConversionJobRepository).
+			AddInt("model_version_id").
+			AddString("converter").
+			AddInt("source_artifact_id").
+			AddString("parameters").
+			AddString("variant").
+			AddString("state").
+			AddString("message").
+			AddInt("artifact_id"),
+		).
+		AddExecution(defaults.DeploymentTypeName, datastore.NewSpecType(NewDeploymentRepository).
+			AddInt("model_version_id").
+			AddInt("inference_service_id").
+			AddString("stage").
+			AddString("previous_stage").
+			AddInt("previous_model_version_id").
+			AddString("previous_desired_state").
+			AddString("state").
+			AddString("message"),
+		).
+		AddExecution(defaults.ABTestTypeName, datastore.NewSpecType(NewABTestRepository).
+			AddInt("registered_model_id").
+			AddString("description").
+			AddString("variants").
+			AddString("start_time_since_epoch").
+			AddString("end_time_since_epoch").
+			AddString("state").
+			AddInt("winner_model_version_id"),
+		).
+		AddExecution(defaults.StageTransitionTypeName, datastore.NewSpecType(NewStageTransitionRepository).
+			AddInt("model_version_id").
+			AddString("from_stage").
+			AddString("to_stage").
+			AddString("comment"),
+		).
 		AddExecution(defaults.ServeModelTypeName, datastore.NewSpecType(NewServeModelRepository).
 			AddString("description").
 			AddInt("model_version_id"),
 		).
-		AddOther(NewArtifactRepository)
+		AddOther(NewArtifactRepository).
+		AddOther(NewLineageRepository).
+		AddOther(NewTagRepository).
+		AddOther(NewAuditEventRepository)
 }
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"gorm.io/gorm"
+)
+
+var ErrStageTransitionNotFound = errors.New("stage transition by id not found")
+
+type StageTransitionRepositoryImpl struct {
+	*GenericRepository[models.StageTransition, schema.Execution, schema.ExecutionProperty, *models.StageTransitionListOptions]
+}
+
+func NewStageTransitionRepository(db *gorm.DB, typeID int32) models.StageTransitionRepository {
+	config := GenericRepositoryConfig[models.StageTransition, schema.Execution, schema.ExecutionProperty, *models.StageTransitionListOptions]{
+		DB:                  db,
+		TypeID:              typeID,
+		EntityToSchema:      mapStageTransitionToExecution,
+		SchemaToEntity:      mapDataLayerToStageTransition,
+		EntityToProperties:  mapStageTransitionToExecutionProperties,
+		NotFoundError:       ErrStageTransitionNotFound,
+		EntityName:          "stage transition",
+		PropertyFieldName:   "execution_id",
+		ApplyListFilters:    applyStageTransitionListFilters,
+		IsNewEntity:         func(entity models.StageTransition) bool { return entity.GetID() == nil },
+		HasCustomProperties: func(entity models.StageTransition) bool { return entity.GetCustomProperties() != nil },
+	}
+
+	return &StageTransitionRepositoryImpl{
+		GenericRepository: NewGenericRepository(config),
+	}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *StageTransitionRepositoryImpl) WithContext(ctx context.Context) models.StageTransitionRepository {
+	return &StageTransitionRepositoryImpl{
+		GenericRepository: r.GenericRepository.WithContext(ctx),
+	}
+}
+
+func (r *StageTransitionRepositoryImpl) Save(stageTransition models.StageTransition, modelVersionID *int32) (models.StageTransition, error) {
+	return r.GenericRepository.Save(stageTransition, modelVersionID)
+}
+
+func (r *StageTransitionRepositoryImpl) List(listOptions models.StageTransitionListOptions) (*models.ListWrapper[models.StageTransition], error) {
+	return r.GenericRepository.List(&listOptions)
+}
+
+func applyStageTransitionListFilters(query *gorm.DB, listOptions *models.StageTransitionListOptions) *gorm.DB {
+	if listOptions.ModelVersionID != nil {
+		query = query.Joins(utils.BuildAssociationJoin(query)).
+			Where(utils.GetColumnRef(query, &schema.Association{}, "context_id")+" = ?", listOptions.ModelVersionID)
+	}
+
+	return query
+}
+
+func mapStageTransitionToExecution(stageTransition models.StageTransition) schema.Execution {
+	attrs := stageTransition.GetAttributes()
+	execution := schema.Execution{
+		TypeID: *stageTransition.GetTypeID(),
+	}
+
+	// Only set ID if it's not nil (for existing entities)
+	if stageTransition.GetID() != nil {
+		execution.ID = *stageTransition.GetID()
+	}
+
+	if attrs != nil {
+		execution.Name = attrs.Name
+		execution.ExternalID = attrs.ExternalID
+		if attrs.CreateTimeSinceEpoch != nil {
+			execution.CreateTimeSinceEpoch = *attrs.CreateTimeSinceEpoch
+		}
+		if attrs.LastUpdateTimeSinceEpoch != nil {
+			execution.LastUpdateTimeSinceEpoch = *attrs.LastUpdateTimeSinceEpoch
+		}
+	}
+
+	return execution
+}
+
+func mapStageTransitionToExecutionProperties(stageTransition models.StageTransition, executionID int32) []schema.ExecutionProperty {
+	var properties []schema.ExecutionProperty
+
+	if stageTransition.GetProperties() != nil {
+		for _, prop := range *stageTransition.GetProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, false))
+		}
+	}
+
+	if stageTransition.GetCustomProperties() != nil {
+		for _, prop := range *stageTransition.GetCustomProperties() {
+			properties = append(properties, MapPropertiesToExecutionProperty(prop, executionID, true))
+		}
+	}
+
+	return properties
+}
+
+func mapDataLayerToStageTransition(stageTransition schema.Execution, properties []schema.ExecutionProperty) models.StageTransition {
+	stageTransitionModel := &models.BaseEntity[models.StageTransitionAttributes]{
+		ID:     &stageTransition.ID,
+		TypeID: &stageTransition.TypeID,
+		Attributes: &models.StageTransitionAttributes{
+			Name:                     stageTransition.Name,
+			ExternalID:               stageTransition.ExternalID,
+			CreateTimeSinceEpoch:     &stageTransition.CreateTimeSinceEpoch,
+			LastUpdateTimeSinceEpoch: &stageTransition.LastUpdateTimeSinceEpoch,
+		},
+	}
+
+	stageTransitionProperties := []models.Properties{}
+	customProperties := []models.Properties{}
+
+	for _, prop := range properties {
+		mappedProperty := MapExecutionPropertyToProperties(prop)
+
+		if prop.IsCustomProperty {
+			customProperties = append(customProperties, mappedProperty)
+		} else {
+			stageTransitionProperties = append(stageTransitionProperties, mappedProperty)
+		}
+	}
+
+	// Always set Properties and CustomProperties, even if empty
+	stageTransitionModel.Properties = &stageTransitionProperties
+	stageTransitionModel.CustomProperties = &customProperties
+
+	return stageTransitionModel
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrTagNotFound = errors.New("tag by name not found")
+
+type TagRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) models.TagRepository {
+	return &TagRepositoryImpl{db: db}
+}
+
+// WithContext returns a copy of the repository running its queries with ctx.
+func (r *TagRepositoryImpl) WithContext(ctx context.Context) models.TagRepository {
+	return &TagRepositoryImpl{db: dbutil.Bind(ctx, r.db)}
+}
+
+func (r *TagRepositoryImpl) GetByName(name string) (models.Tag, error) {
+	var tag schema.Tag
+	if err := r.db.Where("name = ?", name).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Tag{}, fmt.Errorf("%w: %s: %w", ErrTagNotFound, name, api.ErrNotFound)
+		}
+		return models.Tag{}, fmt.Errorf("error getting tag by name: %w", err)
+	}
+
+	counts, err := r.countContexts([]schema.Tag{tag})
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return mapDataLayerToTag(tag, counts[tag.ID]), nil
+}
+
+func (r *TagRepositoryImpl) List(listOptions models.TagListOptions) (*models.ListWrapper[models.Tag], error) {
+	list := models.ListWrapper[models.Tag]{
+		PageSize: listOptions.GetPageSize(),
+		Items:    []models.Tag{},
+	}
+
+	var tags []schema.Tag
+	query := r.db.Model(&schema.Tag{}).Scopes(scopes.PaginateWithTablePrefix(&tags, &listOptions.Pagination, r.db, schema.TableNameTag))
+	if err := query.Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("error listing tags: %w", err)
+	}
+
+	hasMore := false
+	if pageSize := listOptions.GetPageSize(); pageSize > 0 && len(tags) > int(pageSize) {
+		hasMore = true
+		tags = tags[:pageSize]
+	}
+
+	counts, err := r.countContexts(tags)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		list.Items = append(list.Items, mapDataLayerToTag(tag, counts[tag.ID]))
+	}
+
+	if hasMore {
+		last := tags[len(tags)-1]
+		value := fmt.Sprintf("%d", last.ID)
+		switch listOptions.GetOrderBy() {
+		case "CREATE_TIME":
+			value = fmt.Sprintf("%d", last.CreateTimeSinceEpoch)
+		case "LAST_UPDATE_TIME":
+			value = fmt.Sprintf("%d", last.LastUpdateTimeSinceEpoch)
+		}
+		list.NextPageToken = scopes.CreateNextPageToken(last.ID, value)
+	}
+	list.Size = int32(len(list.Items))
+
+	return &list, nil
+}
+
+func (r *TagRepositoryImpl) Save(tag models.Tag) (models.Tag, error) {
+	var saved schema.Tag
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+
+		err := tx.Where("name = ?", tag.Name).First(&saved).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			saved = schema.Tag{
+				Name:                     tag.Name,
+				Color:                    tag.Color,
+				Description:              tag.Description,
+				CreateTimeSinceEpoch:     now,
+				LastUpdateTimeSinceEpoch: now,
+			}
+			if err := tx.Create(&saved).Error; err != nil {
+				return err
+			}
+			return recordTagAuditEvent(tx, models.AuditActionCreate, schema.Tag{}, saved)
+		}
+		if err != nil {
+			return err
+		}
+
+		before := saved
+		saved.Color = tag.Color
+		saved.Description = tag.Description
+		saved.LastUpdateTimeSinceEpoch = now
+		if err := tx.Save(&saved).Error; err != nil {
+			return err
+		}
+		return recordTagAuditEvent(tx, models.AuditActionUpdate, before, saved)
+	})
+	if err != nil {
+		return models.Tag{}, fmt.Errorf("error saving tag: %w", err)
+	}
+
+	counts, err := r.countContexts([]schema.Tag{saved})
+	if err != nil {
+		return models.Tag{}, err
+	}
+
+	return mapDataLayerToTag(saved, counts[saved.ID]), nil
+}
+
+func (r *TagRepositoryImpl) Delete(name string) error {
+	tag, err := r.GetByName(name)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// The contexts losing the tag are updated
+		var contextIDs []int32
+		if err := tx.Model(&schema.ContextTag{}).Where("tag_id = ?", *tag.ID).Pluck("context_id", &contextIDs).Error; err != nil {
+			return fmt.Errorf("error deleting tag: %w", err)
+		}
+		if err := touchContexts(tx, contextIDs, time.Now().UnixMilli()); err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", *tag.ID).Delete(&schema.ContextTag{}).Error; err != nil {
+			return fmt.Errorf("error deleting tag: %w", err)
+		}
+		if err := tx.Delete(&schema.Tag{}, *tag.ID).Error; err != nil {
+			return fmt.Errorf("error deleting tag: %w", err)
+		}
+		deleted := schema.Tag{ID: *tag.ID, Name: tag.Name, Color: tag.Color, Description: tag.Description}
+		return recordTagAuditEvent(tx, models.AuditActionDelete, deleted, schema.Tag{})
+	})
+}
+
+func (r *TagRepositoryImpl) GetContextTags(contextIDs []int32) (map[int32][]string, error) {
+	tagsByContext := make(map[int32][]string, len(contextIDs))
+	if len(contextIDs) == 0 {
+		return tagsByContext, nil
+	}
+
+	var rows []struct {
+		ContextID int32
+		Name      string
+	}
+	contextTag := dbutil.QuoteTableName(r.db, schema.TableNameContextTag)
+	tag := dbutil.QuoteTableName(r.db, schema.TableNameTag)
+	query := r.db.Model(&schema.ContextTag{}).
+		Select(contextTag+".context_id AS context_id, "+tag+".name AS name").
+		Joins("JOIN "+tag+" ON "+tag+".id = "+contextTag+".tag_id").
+		Where(contextTag+".context_id IN ?", contextIDs).
+		Order(tag + ".name")
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error getting context tags: %w", err)
+	}
+
+	for _, row := range rows {
+		tagsByContext[row.ContextID] = append(tagsByContext[row.ContextID], row.Name)
+	}
+	return tagsByContext, nil
+}
+
+func (r *TagRepositoryImpl) SetContextTags(contextID int32, names []string) ([]string, error) {
+	names = slices.Compact(slices.Sorted(slices.Values(names)))
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if _, audited := api.Actor(tx.Statement.Context); audited {
+			if err := r.recordContextTagsAuditEvent(tx, contextID, names); err != nil {
+				return err
+			}
+		}
+
+		// The context is updated when its tags change
+		now := time.Now().UnixMilli()
+		previous, err := (&TagRepositoryImpl{db: tx}).GetContextTags([]int32{contextID})
+		if err != nil {
+			return err
+		}
+		// The names are compared as sets, the database may sort them in another collation
+		if !slices.Equal(slices.Sorted(slices.Values(previous[contextID])), names) {
+			if err := touchContexts(tx, []int32{contextID}, now); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("context_id = ?", contextID).Delete(&schema.ContextTag{}).Error; err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return nil
+		}
+
+		missing := make([]schema.Tag, 0, len(names))
+		for _, name := range names {
+			missing = append(missing, schema.Tag{Name: name, CreateTimeSinceEpoch: now, LastUpdateTimeSinceEpoch: now})
+		}
+		// The existing tags are kept as they are, with their color and description
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&missing).Error; err != nil {
+			return err
+		}
+
+		var tags []schema.Tag
+		if err := tx.Where("name IN ?", names).Find(&tags).Error; err != nil {
+			return err
+		}
+		contextTags := make([]schema.ContextTag, 0, len(tags))
+		for _, tag := range tags {
+			contextTags = append(contextTags, schema.ContextTag{ContextID: contextID, TagID: tag.ID})
+		}
+		return tx.Create(&contextTags).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error setting context tags: %w", err)
+	}
+
+	return names, nil
+}
+
+// recordTagAuditEvent records the write of a tag in the audit log, the zero tag is the one before a creation or
+// after a deletion.
+func recordTagAuditEvent(tx *gorm.DB, action string, before, after schema.Tag) error {
+	var beforeFields, afterFields map[string]any
+	var err error
+	if before.Name != "" {
+		if beforeFields, err = auditFields(before, nil); err != nil {
+			return err
+		}
+	}
+	if after.Name != "" {
+		if afterFields, err = auditFields(after, nil); err != nil {
+			return err
+		}
+	}
+	id := cmp.Or(after.ID, before.ID)
+	return recordAuditEvent(tx, action, models.AuditEntityKindTag, 0, id, api.Namespace(tx.Statement.Context), beforeFields, afterFields)
+}
+
+// recordContextTagsAuditEvent records the replacement of the tags of the context by names as an update of the
+// context, reading its tags before they are replaced.
+func (r *TagRepositoryImpl) recordContextTagsAuditEvent(tx *gorm.DB, contextID int32, names []string) error {
+	var context schema.Context
+	if err := tx.Where("id = ?", contextID).First(&context).Error; err != nil {
+		return fmt.Errorf("error reading context for its audit event: %w", err)
+	}
+	previous, err := (&TagRepositoryImpl{db: tx}).GetContextTags([]int32{contextID})
+	if err != nil {
+		return err
+	}
+
+	before, err := auditFields(map[string]any{"tags": previous[contextID]}, nil)
+	if err != nil {
+		return err
+	}
+	after, err := auditFields(map[string]any{"tags": names}, nil)
+	if err != nil {
+		return err
+	}
+	return recordAuditEvent(tx, models.AuditActionUpdate, models.AuditEntityKindContext, context.TypeID, contextID, context.Namespace, before, after)
+}
+
+// countContexts returns the number of contexts with each of the tags, by tag id.
+func (r *TagRepositoryImpl) countContexts(tags []schema.Tag) (map[int32]int32, error) {
+	counts := make(map[int32]int32, len(tags))
+	if len(tags) == 0 {
+		return counts, nil
+	}
+
+	ids := make([]int32, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+
+	var rows []struct {
+		TagID int32
+		Count int32
+	}
+	query := r.db.Model(&schema.ContextTag{}).
+		Select("tag_id, COUNT(*) AS count").
+		Where("tag_id IN ?", ids).
+		Group("tag_id")
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error counting tagged contexts: %w", err)
+	}
+
+	for _, row := range rows {
+		counts[row.TagID] = row.Count
+	}
+	return counts, nil
+}
+
+func mapDataLayerToTag(tag schema.Tag, count int32) models.Tag {
+	return models.Tag{
+		ID:                       &tag.ID,
+		Name:                     tag.Name,
+		Color:                    tag.Color,
+		Description:              tag.Description,
+		CreateTimeSinceEpoch:     &tag.CreateTimeSinceEpoch,
+		LastUpdateTimeSinceEpoch: &tag.LastUpdateTimeSinceEpoch,
+		Count:                    count,
+	}
+}
@@ -0,0 +1,17 @@
+package db
+
+import (
+	"database/sql"
+
+	"gorm.io/gorm"
+)
+
+// Snapshot runs read in a read-only repeatable read transaction, so that everything it reads reflects a single
+// point in time even while writes continue. Readers exporting related entities, such as backups, must use it to
+// avoid dangling references between entities read before and after a concurrent write.
+func Snapshot(db *gorm.DB, read func(tx *gorm.DB) error) error {
+	return db.Transaction(read, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+}
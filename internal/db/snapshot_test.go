@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSnapshot(t *testing.T) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	count := func(db *gorm.DB) (int64, error) {
+		var n int64
+		err := db.Table("Context").Count(&n).Error
+		return n, err
+	}
+
+	t.Run("the readers bound to the context read in the snapshot", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "Context"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "Context"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectCommit()
+
+		err := Snapshot(db, func(tx *gorm.DB) error {
+			ctx := dbutil.WithTransaction(context.Background(), tx)
+			for range 2 {
+				n, err := count(dbutil.Bind(ctx, db))
+				if err != nil {
+					return err
+				}
+				assert.Equal(t, int64(2), n)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("the snapshot is rolled back on errors", func(t *testing.T) {
+		failed := errors.New("failed")
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+
+		err := Snapshot(db, func(tx *gorm.DB) error {
+			return failed
+		})
+		assert.ErrorIs(t, err, failed)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("the readers of other contexts don't read in the snapshot", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "Context"`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		n, err := count(dbutil.Bind(context.Background(), db))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
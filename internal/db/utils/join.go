@@ -53,6 +53,14 @@ func BuildAttributionJoin(db *gorm.DB) string {
 		attributionTable, attributionTable, artifactTable)
 }
 
+// BuildContextAttributionJoin creates a JOIN clause for Attribution relationships, from the context side
+func BuildContextAttributionJoin(db *gorm.DB) string {
+	attributionTable := getTableName(db, &schema.Attribution{})
+	contextTable := getTableName(db, &schema.Context{})
+	return fmt.Sprintf("JOIN %s ON %s.context_id = %s.id",
+		attributionTable, attributionTable, contextTable)
+}
+
 // BuildAssociationJoin creates a JOIN clause for Association relationships
 func BuildAssociationJoin(db *gorm.DB) string {
 	associationTable := getTableName(db, &schema.Association{})
@@ -15,4 +15,12 @@ const (
 	MetricTypeName             = "kf.Metric"
 	MetricHistoryTypeName      = "kf.MetricHistory"
 	ParameterTypeName          = "kf.Parameter"
+	PromotionTypeName          = "kf.Promotion"
+	PromotionRunTypeName       = "kf.PromotionRun"
+	ConversionJobTypeName      = "kf.ConversionJob"
+	DeploymentTypeName         = "kf.Deployment"
+	ABTestTypeName             = "kf.ABTest"
+	MetricsTableTypeName       = "kf.MetricsTable"
+	ModelCardTypeName          = "kf.ModelCard"
+	StageTransitionTypeName    = "kf.StageTransition"
 )
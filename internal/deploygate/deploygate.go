@@ -0,0 +1,96 @@
+// Package deploygate loads the gates operators configure for the deployment of the model versions, e.g. an approval
+// into Production, verified signatures, passed scans and evaluation scores, which CD pipelines check with the
+// deployable endpoint of the model versions before deploying them.
+package deploygate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Config is the content of the deploy gates file, e.g.
+//
+//	gates:
+//	  approval:
+//	    stages: [Production]
+//	  signature:
+//	    property: signature_verified
+//	  scans:
+//	    - property: security_scan
+//	    - property: license_scan
+//	      passValues: [passed, waived]
+//	  evaluations:
+//	    propertyPrefix: eval.
+type Config struct {
+	Gates api.DeployGates `json:"gates"`
+}
+
+// LoadConfig reads a deploy gates file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading deploy gates file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing deploy gates file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// NewGates validates the gates of config and returns them with their defaults and the stages of the lifecycle as
+// spelled by api.StageTransitions.
+func NewGates(config *Config) (*api.DeployGates, error) {
+	gates := config.Gates
+
+	if gates.Approval != nil {
+		if len(gates.Approval.Stages) == 0 {
+			return nil, fmt.Errorf("invalid approval gate: at least one stage is required")
+		}
+		stages := make([]api.ModelVersionStage, 0, len(gates.Approval.Stages))
+		for _, stage := range gates.Approval.Stages {
+			known, ok := lifecycleStage(stage)
+			if !ok || known == api.StageNone {
+				return nil, fmt.Errorf("invalid approval gate: unknown stage %s", stage)
+			}
+			stages = append(stages, known)
+		}
+		gates.Approval = &api.ApprovalGate{Stages: stages}
+	}
+
+	if gates.Signature != nil && gates.Signature.Property == "" {
+		return nil, fmt.Errorf("invalid signature gate: property is required")
+	}
+
+	scans := make([]api.ScanGate, 0, len(gates.Scans))
+	for i, scan := range gates.Scans {
+		if scan.Property == "" {
+			return nil, fmt.Errorf("invalid scan gate %d: property is required", i)
+		}
+		if len(scan.PassValues) == 0 {
+			scan.PassValues = api.DefaultScanPassValues
+		}
+		scans = append(scans, scan)
+	}
+	gates.Scans = scans
+
+	if gates.Evaluations != nil && gates.Evaluations.PropertyPrefix == "" {
+		gates.Evaluations = &api.EvaluationsGate{PropertyPrefix: api.DefaultEvaluationPropertyPrefix}
+	}
+
+	return &gates, nil
+}
+
+// lifecycleStage returns the stage of the lifecycle matching stage ignoring case.
+func lifecycleStage(stage api.ModelVersionStage) (api.ModelVersionStage, bool) {
+	for known := range api.StageTransitions {
+		if strings.EqualFold(string(stage), string(known)) {
+			return known, true
+		}
+	}
+	return "", false
+}
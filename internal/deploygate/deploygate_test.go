@@ -0,0 +1,61 @@
+package deploygate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gates.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+gates:
+  approval:
+    stages: [production]
+  signature:
+    property: signature_verified
+  scans:
+    - property: security_scan
+    - property: license_scan
+      passValues: [passed, waived]
+  evaluations: {}
+`), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	gates, err := NewGates(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, []api.ModelVersionStage{api.StageProduction}, gates.Approval.Stages, "stages are matched ignoring case")
+	assert.Equal(t, "signature_verified", gates.Signature.Property)
+	assert.Equal(t, []api.ScanGate{
+		{Property: "security_scan", PassValues: api.DefaultScanPassValues},
+		{Property: "license_scan", PassValues: []string{"passed", "waived"}},
+	}, gates.Scans)
+	assert.Equal(t, api.DefaultEvaluationPropertyPrefix, gates.Evaluations.PropertyPrefix)
+
+	require.NoError(t, os.WriteFile(path, []byte("gates:\n  signed: {}\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestNewGates(t *testing.T) {
+	gates, err := NewGates(&Config{})
+	require.NoError(t, err)
+	assert.Equal(t, &api.DeployGates{Scans: []api.ScanGate{}}, gates, "no gates")
+
+	for message, gates := range map[string]api.DeployGates{
+		"at least one stage":   {Approval: &api.ApprovalGate{}},
+		"unknown stage QA":     {Approval: &api.ApprovalGate{Stages: []api.ModelVersionStage{"QA"}}},
+		"unknown stage None":   {Approval: &api.ApprovalGate{Stages: []api.ModelVersionStage{api.StageNone}}},
+		"signature gate":       {Signature: &api.SignatureGate{}},
+		"invalid scan gate 0:": {Scans: []api.ScanGate{{PassValues: []string{"passed"}}}},
+	} {
+		_, err := NewGates(&Config{Gates: gates})
+		assert.ErrorContains(t, err, message)
+	}
+}
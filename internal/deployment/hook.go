@@ -0,0 +1,82 @@
+// Package deployment applies the deployments of model versions to the serving platform, e.g. patching the KServe
+// InferenceService of the registry inference service, once the registry entities are updated.
+package deployment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultTimeout bounds the time the serving platform takes to apply a deployment.
+const DefaultTimeout = 2 * time.Minute
+
+// Request is sent to the hook once the model version is staged and the inference service updated.
+type Request struct {
+	Deployment api.Deployment `json:"deployment"`
+	// ModelVersion is the deployed model version.
+	ModelVersion openapi.ModelVersion `json:"modelVersion"`
+	// InferenceService is the updated inference service, serving the model version.
+	InferenceService openapi.InferenceService `json:"inferenceService"`
+}
+
+// Hook applies deployments to the serving platform, Apply returns once the deployment is applied: the deployment
+// is rolled back when it fails.
+type Hook interface {
+	Apply(ctx context.Context, request Request) error
+}
+
+// webhook is a Hook posting the request as JSON to the url of the serving platform.
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook returns the Hook posting requests to hookURL, any 2xx response applies the deployment.
+func NewWebhook(hookURL string) (Hook, error) {
+	parsed, err := url.Parse(hookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url %q, the scheme must be http or https", hookURL)
+	}
+
+	return &webhook{url: hookURL, client: &http.Client{Timeout: DefaultTimeout}}, nil
+}
+
+func (w *webhook) Apply(ctx context.Context, request Request) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("unable to encode deployment request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("deployment hook responded %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package deployment
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook(t *testing.T) {
+	_, err := NewWebhook("https://serving/deployments")
+	assert.NoError(t, err)
+
+	_, err = NewWebhook("ftp://serving/deployments")
+	assert.ErrorContains(t, err, "the scheme must be http or https")
+}
+
+func TestWebhook(t *testing.T) {
+	var received Request
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("admission webhook denied the request\n"))
+	}))
+	defer server.Close()
+
+	hook, err := NewWebhook(server.URL)
+	require.NoError(t, err)
+
+	modelVersionId := "3"
+	request := Request{
+		Deployment: api.Deployment{
+			Id:                 "7",
+			ModelVersionId:     modelVersionId,
+			InferenceServiceId: "5",
+			Stage:              api.DefaultDeploymentStage,
+			State:              api.DeploymentRunning,
+		},
+		ModelVersion:     openapi.ModelVersion{Id: &modelVersionId, Name: "v1"},
+		InferenceService: openapi.InferenceService{ModelVersionId: &modelVersionId, DesiredState: openapi.INFERENCESERVICESTATE_DEPLOYED.Ptr()},
+	}
+
+	require.NoError(t, hook.Apply(context.Background(), request))
+	assert.Equal(t, request, received)
+
+	status = http.StatusUnprocessableEntity
+	err = hook.Apply(context.Background(), request)
+	assert.ErrorContains(t, err, "deployment hook responded 422 Unprocessable Entity: admission webhook denied the request")
+}
@@ -0,0 +1,47 @@
+package downloadurl
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+)
+
+// azureHostSuffix is the suffix of the hosts of the blob endpoints of the Azure storage accounts.
+const azureHostSuffix = ".blob.core.windows.net"
+
+// azureSigner signs the https://<account>.blob.core.windows.net/<container>/<blob> uris of a storage account with
+// its shared key, as read-only blob SAS.
+type azureSigner struct {
+	credential *azblob.SharedKeyCredential
+}
+
+func newAzureSigner(account string, key string) (*azureSigner, error) {
+	credential, err := azblob.NewSharedKeyCredential(account, key)
+	if err != nil {
+		return nil, err
+	}
+	return &azureSigner{credential: credential}, nil
+}
+
+// host is the host of the blob endpoint of the storage account.
+func (s *azureSigner) host() string {
+	return strings.ToLower(s.credential.AccountName()) + azureHostSuffix
+}
+
+func (s *azureSigner) sign(container string, blob string, expires time.Time) (string, error) {
+	query, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		ExpiryTime:    expires.UTC(),
+		Permissions:   (&sas.BlobPermissions{Read: true}).String(),
+		ContainerName: container,
+		BlobName:      blob,
+	}.SignWithSharedKey(s.credential)
+	if err != nil {
+		return "", err
+	}
+	signed := url.URL{Scheme: "https", Host: s.host(), Path: "/" + container + "/" + blob, RawQuery: query.Encode()}
+	return signed.String(), nil
+}
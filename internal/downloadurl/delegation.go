@@ -0,0 +1,92 @@
+package downloadurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/kubeflow/model-registry/internal/storageuri"
+)
+
+// ErrForbidden is returned when the upstream object store refuses to delegate credentials to the caller.
+var ErrForbidden = errors.New("download forbidden")
+
+// webIdentitySessionName is the session name of the roles assumed for the callers.
+const webIdentitySessionName = "model-registry-download"
+
+// minWebIdentityDuration is the shortest duration of the credentials of the roles assumed.
+const minWebIdentityDuration = 15 * time.Minute
+
+// Credentials are the temporary credentials of the caller of a download URL for the object store of the artifact
+// uri, signing the URL in place of the credentials of the registry.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	// Expiration is when the credentials expire, the URLs signed with them expire at the latest then.
+	Expiration time.Time
+}
+
+// TokenExchanger exchanges the bearer token of the caller of a download URL for credentials of the object store of
+// the artifact uri, so that the entitlements of the caller upstream decide which files it downloads rather than the
+// credentials of the registry. It returns ErrForbidden when the caller is not entitled.
+type TokenExchanger interface {
+	Exchange(ctx context.Context, token string, uri storageuri.URI) (*Credentials, error)
+}
+
+// webIdentityExchanger exchanges the tokens of the callers for temporary credentials of an IAM role with
+// AssumeRoleWithWebIdentity, the trust policy of the role deciding which callers are entitled and the claims of
+// their token which objects they read.
+type webIdentityExchanger struct {
+	roleARN  string
+	endpoint string
+	region   string
+	duration time.Duration
+}
+
+func (e *webIdentityExchanger) Exchange(ctx context.Context, token string, uri storageuri.URI) (*Credentials, error) {
+	cfg := aws.NewConfig()
+	if region := uri.Region; region != "" {
+		cfg = cfg.WithRegion(region)
+	} else if e.region != "" {
+		cfg = cfg.WithRegion(e.region)
+	}
+	if e.endpoint != "" {
+		cfg = cfg.WithEndpoint(e.endpoint)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating STS session: %w", err)
+	}
+
+	// the requests are not signed, the token of the caller authenticates them
+	out, err := sts.New(sess).AssumeRoleWithWebIdentityWithContext(ctx, &sts.AssumeRoleWithWebIdentityInput{
+		RoleArn:          aws.String(e.roleARN),
+		RoleSessionName:  aws.String(webIdentitySessionName),
+		WebIdentityToken: aws.String(token),
+		DurationSeconds:  aws.Int64(int64(max(e.duration, minWebIdentityDuration).Seconds())),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) {
+			switch awsErr.Code() {
+			case "AccessDenied", sts.ErrCodeInvalidIdentityTokenException, sts.ErrCodeExpiredTokenException, sts.ErrCodeIDPRejectedClaimException:
+				return nil, fmt.Errorf("%w: role %s can't be assumed by the caller: %s", ErrForbidden, e.roleARN, awsErr.Message())
+			}
+		}
+		return nil, fmt.Errorf("error assuming role %s for the caller: %w", e.roleARN, err)
+	}
+
+	return &Credentials{
+		AccessKeyID:     aws.StringValue(out.Credentials.AccessKeyId),
+		SecretAccessKey: aws.StringValue(out.Credentials.SecretAccessKey),
+		SessionToken:    aws.StringValue(out.Credentials.SessionToken),
+		Expiration:      aws.TimeValue(out.Credentials.Expiration),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package downloadurl
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// gcsSigner signs the gs:// uris with the key of a service account.
+type gcsSigner struct {
+	email      string
+	privateKey []byte
+}
+
+// newGCSSigner returns the signer of the JSON key of a service account.
+func newGCSSigner(key []byte) (*gcsSigner, error) {
+	var credentials struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	if err := json.Unmarshal(key, &credentials); err != nil {
+		return nil, err
+	}
+	if credentials.Type != "service_account" || credentials.ClientEmail == "" || credentials.PrivateKey == "" {
+		return nil, errors.New("expected the JSON key of a service account")
+	}
+	return &gcsSigner{email: credentials.ClientEmail, privateKey: []byte(credentials.PrivateKey)}, nil
+}
+
+func (s *gcsSigner) sign(bucket string, object string, expires time.Time) (string, error) {
+	return storage.SignedURL(bucket, object, &storage.SignedURLOptions{
+		GoogleAccessID: s.email,
+		PrivateKey:     s.privateKey,
+		Method:         "GET",
+		Expires:        expires,
+		Scheme:         storage.SigningSchemeV4,
+	})
+}
@@ -0,0 +1,105 @@
+package downloadurl
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// DownloadURLPath is the path of the download URLs of the model artifacts.
+const DownloadURLPath = "/api/model_registry/v1alpha3/model_artifacts/{modelartifactId}/download_url"
+
+// DownloadURL is a pre-signed URL downloading a model artifact file.
+type DownloadURL struct {
+	Url string `json:"url"`
+	// ExpirationTimeSinceEpoch is when the URL expires, in milliseconds since epoch.
+	ExpirationTimeSinceEpoch string `json:"expirationTimeSinceEpoch"`
+}
+
+// NewHandler returns the handler of the download URLs of the model artifacts of registry, passing the other requests
+// to next:
+//
+//	GET /api/model_registry/v1alpha3/model_artifacts/{modelartifactId}/download_url  returns a DownloadURL of the model artifact
+//
+// The path query parameter selects a file below the uri of the model directories, the ttl query parameter shortens
+// the validity of the URL, e.g. 5m. The callers the uri is redacted for are forbidden. When the credentials are
+// delegated to the callers, the bearer token of the request is exchanged for them, the anonymous callers are
+// unauthorized and the callers the object store refuses are forbidden.
+func NewHandler(signer *Signer, registry api.ModelRegistryApi, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+DownloadURLPath, func(w http.ResponseWriter, r *http.Request) {
+		var ttl time.Duration
+		if value := r.URL.Query().Get("ttl"); value != "" {
+			var err error
+			if ttl, err = time.ParseDuration(value); err != nil || ttl <= 0 {
+				writeError(w, http.StatusBadRequest, "invalid ttl "+value+", expected a positive duration, e.g. 5m")
+				return
+			}
+		}
+
+		// the uri is not redacted by the middleware from the signed URL
+		if slices.Contains(converter.RedactedFields(r.Context()), "uri") {
+			writeError(w, http.StatusForbidden, "the uri of the model artifacts is restricted, their download urls can't be signed")
+			return
+		}
+
+		bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if signer.Delegated() && bearer == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="model-registry"`)
+			writeError(w, http.StatusUnauthorized, "the download urls are signed with the credentials of the caller, a bearer token is required")
+			return
+		}
+
+		artifact, err := api.WithContext(r.Context(), registry).GetModelArtifactById(r.PathValue("modelartifactId"))
+		if err != nil {
+			writeError(w, api.ErrToStatus(err), err.Error())
+			return
+		}
+		if artifact.Uri == nil || *artifact.Uri == "" {
+			writeError(w, http.StatusBadRequest, "model artifact "+*artifact.Id+" has no uri")
+			return
+		}
+
+		url, expires, err := signer.Sign(r.Context(), bearer, *artifact.Uri, r.URL.Query().Get("path"), ttl)
+		if err != nil {
+			if errors.Is(err, ErrUnsupported) {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if errors.Is(err, ErrForbidden) {
+				writeError(w, http.StatusForbidden, err.Error())
+				return
+			}
+			glog.Errorf("Error signing download url of model artifact %s: %v", *artifact.Id, err)
+			writeError(w, http.StatusInternalServerError, "error signing download url")
+			return
+		}
+
+		writeJSON(w, http.StatusOK, DownloadURL{Url: url, ExpirationTimeSinceEpoch: strconv.FormatInt(expires.UnixMilli(), 10)})
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing download url response: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package downloadurl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/storageuri"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenExchanger delegates fixed credentials to the callers with a token.
+type tokenExchanger map[string]Credentials
+
+func (e tokenExchanger) Exchange(ctx context.Context, token string, uri storageuri.URI) (*Credentials, error) {
+	credentials, ok := e[token]
+	if !ok {
+		return nil, ErrForbidden
+	}
+	return &credentials, nil
+}
+
+func TestHandler(t *testing.T) {
+	setAWSCredentials(t)
+	signer, err := NewSigner(Config{S3Endpoint: "http://minio:9000", S3Region: "us-east-1", S3Buckets: []string{"models"}})
+	require.NoError(t, err)
+
+	service := inmemory.NewModelRegistryService(inmemory.NewStore())
+	server := httptest.NewServer(NewHandler(signer, service, http.NotFoundHandler()))
+	t.Cleanup(server.Close)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "granite"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	artifact := func(uri string) string {
+		created, err := service.UpsertModelVersionArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{Name: openapi.PtrString(uri), Uri: openapi.PtrString(uri)}}, *version.Id)
+		require.NoError(t, err)
+		return *created.ModelArtifact.Id
+	}
+	get := func(id string, query string) (int, map[string]string) {
+		resp, err := http.Get(server.URL + strings.Replace(DownloadURLPath, "{modelartifactId}", id, 1) + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		result := map[string]string{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	s3Artifact := artifact("s3://models/granite")
+	code, result := get(s3Artifact, "?path=weights/model.safetensors&ttl=5m")
+	require.Equal(t, http.StatusOK, code, result)
+	signed, err := url.Parse(result["url"])
+	require.NoError(t, err)
+	assert.Equal(t, "minio:9000", signed.Host)
+	assert.Equal(t, "/models/granite/weights/model.safetensors", signed.Path)
+	assert.Equal(t, "300", signed.Query().Get("X-Amz-Expires"))
+	expiration, err := strconv.ParseInt(result["expirationTimeSinceEpoch"], 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), time.UnixMilli(expiration), time.Minute)
+
+	code, result = get(artifact("oci://quay.io/org/granite:v1"), "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, result["message"], "only the s3, gs and Azure Blob Storage uris can be downloaded")
+
+	code, result = get(artifact("gs://models/granite"), "?path=config.json")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, result["message"], "no credentials configured for its object store")
+
+	code, result = get(s3Artifact, "?ttl=soon")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, result["message"], "invalid ttl soon")
+
+	code, _ = get("999", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// the callers the uri is redacted for can't download it
+	redacted := NewHandler(signer, service, http.NotFoundHandler())
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, strings.Replace(DownloadURLPath, "{modelartifactId}", s3Artifact, 1), nil)
+	redacted.ServeHTTP(rec, req.WithContext(converter.WithRedactedFields(req.Context(), []string{"uri"})))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// the callers sign the urls with their own credentials
+	signer.SetTokenExchanger(tokenExchanger{"alice-token": {AccessKeyID: "ASIAALICE", SecretAccessKey: "secret", SessionToken: "session"}})
+	getAs := func(token string) (int, map[string]string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+strings.Replace(DownloadURLPath, "{modelartifactId}", s3Artifact, 1), nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		result := map[string]string{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+	code, result = getAs("alice-token")
+	require.Equal(t, http.StatusOK, code, result)
+	signed, err = url.Parse(result["url"])
+	require.NoError(t, err)
+	assert.Contains(t, signed.Query().Get("X-Amz-Credential"), "ASIAALICE/")
+	assert.Equal(t, "session", signed.Query().Get("X-Amz-Security-Token"))
+
+	code, _ = getAs("")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = getAs("bob-token")
+	assert.Equal(t, http.StatusForbidden, code)
+}
@@ -0,0 +1,52 @@
+package downloadurl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/kubeflow/model-registry/internal/storageuri"
+)
+
+// s3Signer signs the s3 uris at the configured endpoint, the region of the uris overrides the configured region. The
+// URLs are signed with the credentials of the AWS SDK environment, or the ones delegated to the caller.
+type s3Signer struct {
+	endpoint string
+	region   string
+}
+
+func (s *s3Signer) sign(uri storageuri.URI, key string, ttl time.Duration, delegated *Credentials) (string, error) {
+	endpoint := s.endpoint
+	region := s.region
+	if uri.Region != "" {
+		region = uri.Region
+	}
+
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+	if delegated != nil {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(delegated.AccessKeyID, delegated.SecretAccessKey, delegated.SessionToken))
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating S3 session: %w", err)
+	}
+
+	req, _ := s3.New(sess).GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(uri.Bucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(ttl)
+}
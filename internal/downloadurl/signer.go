@@ -0,0 +1,202 @@
+// Package downloadurl generates the time-limited pre-signed URLs of the files of the model artifacts stored in S3,
+// GCS and Azure Blob Storage, so that the users of the UI can download them without credentials of the object
+// stores. The URLs are signed with the credentials configured in the registry, only for the buckets and containers
+// allowed by the configuration: the artifact uris are written by the users of the api, they must not reach the other
+// objects the credentials can read. With a TokenExchanger, the s3 uris are signed with the credentials delegated to
+// the caller instead, so that the entitlements of the caller upstream apply too.
+package downloadurl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/storageuri"
+)
+
+// DefaultTTL is how long the download URLs are valid.
+const DefaultTTL = 15 * time.Minute
+
+// ErrUnsupported is returned for the URIs which can't be signed: the URIs of other schemes, and the ones of the object
+// stores, endpoints, buckets and containers not allowed by the configuration.
+var ErrUnsupported = errors.New("unsupported artifact uri")
+
+// Config configures the credentials of the object stores signing the download URLs.
+type Config struct {
+	// TTL is how long the download URLs are valid, and the maximum validity requested, DefaultTTL if 0.
+	TTL time.Duration
+	// S3Endpoint and S3Region are the endpoint and region of the s3 uris, the credentials are the ones of the AWS SDK
+	// environment. The uris with another endpoint query parameter are not signed.
+	S3Endpoint string
+	S3Region   string
+	// S3Buckets are the buckets of the s3 uris signed, none when empty.
+	S3Buckets []string
+	// S3WebIdentityRoleARN delegates the signing of the s3 uris to the callers: their bearer token is exchanged for
+	// temporary credentials of the role with AssumeRoleWithWebIdentity. The other uris can't be signed then.
+	S3WebIdentityRoleARN string
+	// S3STSEndpoint is the endpoint of the AssumeRoleWithWebIdentity requests, defaults to AWS.
+	S3STSEndpoint string
+	// GCSCredentialsFile is the JSON key file of the service account signing the gs:// uris, they can't be signed
+	// when empty.
+	GCSCredentialsFile string
+	// GCSBuckets are the buckets of the gs:// uris signed, none when empty.
+	GCSBuckets []string
+	// AzureAccountName and AzureAccountKey are the shared key of the storage account of the
+	// https://<account>.blob.core.windows.net uris, they can't be signed when empty.
+	AzureAccountName string
+	AzureAccountKey  string
+	// AzureContainers are the containers of the storage account signed, none when empty.
+	AzureContainers []string
+}
+
+// Signer signs the download URLs of the artifact uris.
+type Signer struct {
+	ttl   time.Duration
+	s3    *s3Signer
+	gcs   *gcsSigner
+	azure *azureSigner
+	// exchanger delegates the credentials signing the URLs to the callers, none when nil
+	exchanger TokenExchanger
+
+	s3Buckets       []string
+	gcsBuckets      []string
+	azureContainers []string
+}
+
+// NewSigner returns the Signer of the object stores of config, reading the GCS credentials file.
+func NewSigner(config Config) (*Signer, error) {
+	s := &Signer{
+		ttl:             config.TTL,
+		s3:              &s3Signer{endpoint: config.S3Endpoint, region: config.S3Region},
+		s3Buckets:       config.S3Buckets,
+		gcsBuckets:      config.GCSBuckets,
+		azureContainers: config.AzureContainers,
+	}
+	if s.ttl <= 0 {
+		s.ttl = DefaultTTL
+	}
+
+	if config.S3WebIdentityRoleARN != "" {
+		s.exchanger = &webIdentityExchanger{
+			roleARN:  config.S3WebIdentityRoleARN,
+			endpoint: config.S3STSEndpoint,
+			region:   config.S3Region,
+			duration: s.ttl,
+		}
+	}
+
+	if config.GCSCredentialsFile != "" {
+		key, err := os.ReadFile(config.GCSCredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading GCS credentials: %w", err)
+		}
+		if s.gcs, err = newGCSSigner(key); err != nil {
+			return nil, fmt.Errorf("invalid GCS credentials %s: %w", config.GCSCredentialsFile, err)
+		}
+	}
+
+	if config.AzureAccountName != "" {
+		var err error
+		if s.azure, err = newAzureSigner(config.AzureAccountName, config.AzureAccountKey); err != nil {
+			return nil, fmt.Errorf("invalid Azure storage account key: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// TTL is the validity of the download URLs, and the maximum validity requested.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// SetTokenExchanger delegates the credentials signing the URLs to the callers with exchanger, replacing the
+// exchanger of the configuration. Only the s3 uris can be signed with delegated credentials.
+func (s *Signer) SetTokenExchanger(exchanger TokenExchanger) {
+	s.exchanger = exchanger
+}
+
+// Delegated reports whether the URLs are signed with the credentials delegated to the callers.
+func (s *Signer) Delegated() bool {
+	return s.exchanger != nil
+}
+
+// Sign returns the URL downloading the file of uri for ttl, bounded by the TTL of s. The file is the object of uri
+// itself, or the object at the relative file path below uri for the model directories. The bearer token of the
+// caller is exchanged for the credentials signing the URL when they are delegated, the URL expires with them.
+func (s *Signer) Sign(ctx context.Context, token string, uri string, file string, ttl time.Duration) (string, time.Time, error) {
+	if ttl <= 0 || ttl > s.ttl {
+		ttl = s.ttl
+	}
+	if file != "" && !fs.ValidPath(file) {
+		return "", time.Time{}, fmt.Errorf("%w: invalid file path %q, expected a relative path below the artifact uri", ErrUnsupported, file)
+	}
+
+	parsed, err := storageuri.Parse(uri)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	isAzure := parsed.Scheme == "https" && strings.HasSuffix(parsed.Bucket, azureHostSuffix)
+	if parsed.Scheme != "s3" && parsed.Scheme != "gs" && !isAzure {
+		return "", time.Time{}, fmt.Errorf("%w %q: only the s3, gs and Azure Blob Storage uris can be downloaded", ErrUnsupported, uri)
+	}
+	key := path.Join(parsed.Path, file)
+	if key == "" {
+		return "", time.Time{}, fmt.Errorf("%w %q: missing object key", ErrUnsupported, uri)
+	}
+	expires := time.Now().Add(ttl)
+
+	var signed string
+	switch {
+	case parsed.Scheme == "s3":
+		if raw, err := url.Parse(uri); err == nil {
+			if endpoint := raw.Query().Get("endpoint"); endpoint != "" && strings.TrimSuffix(endpoint, "/") != strings.TrimSuffix(s.s3.endpoint, "/") {
+				return "", time.Time{}, fmt.Errorf("%w %q: endpoint %s is not allowed", ErrUnsupported, uri, endpoint)
+			}
+		}
+		if !slices.Contains(s.s3Buckets, parsed.Bucket) {
+			return "", time.Time{}, fmt.Errorf("%w %q: bucket %s is not allowed", ErrUnsupported, uri, parsed.Bucket)
+		}
+		var delegated *Credentials
+		if s.exchanger != nil {
+			if delegated, err = s.exchanger.Exchange(ctx, token, parsed); err != nil {
+				return "", time.Time{}, err
+			}
+			if !delegated.Expiration.IsZero() && delegated.Expiration.Before(expires) {
+				expires = delegated.Expiration
+				ttl = time.Until(expires)
+			}
+		}
+		signed, err = s.s3.sign(parsed, key, ttl, delegated)
+	case s.exchanger != nil:
+		return "", time.Time{}, fmt.Errorf("%w %q: only the s3 uris can be signed with the credentials of the caller", ErrUnsupported, uri)
+	case parsed.Scheme == "gs" && s.gcs != nil:
+		if !slices.Contains(s.gcsBuckets, parsed.Bucket) {
+			return "", time.Time{}, fmt.Errorf("%w %q: bucket %s is not allowed", ErrUnsupported, uri, parsed.Bucket)
+		}
+		signed, err = s.gcs.sign(parsed.Bucket, key, expires)
+	case isAzure && s.azure != nil && parsed.Bucket == s.azure.host():
+		container, blob, found := strings.Cut(key, "/")
+		if !found {
+			return "", time.Time{}, fmt.Errorf("%w %q: expected https://<account>.blob.core.windows.net/<container>/<blob>", ErrUnsupported, uri)
+		}
+		if !slices.Contains(s.azureContainers, container) {
+			return "", time.Time{}, fmt.Errorf("%w %q: container %s is not allowed", ErrUnsupported, uri, container)
+		}
+		signed, err = s.azure.sign(container, blob, expires)
+	default:
+		return "", time.Time{}, fmt.Errorf("%w %q: no credentials configured for its object store", ErrUnsupported, uri)
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("error signing download url of %s: %w", uri, err)
+	}
+
+	return signed, expires, nil
+}
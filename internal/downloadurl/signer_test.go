@@ -0,0 +1,204 @@
+package downloadurl
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setAWSCredentials sets the static credentials of the AWS SDK environment, ignoring the shared config files.
+func setAWSCredentials(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+}
+
+// gcsCredentialsFile writes the JSON key of a service account with a generated private key.
+func gcsCredentialsFile(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "model-registry@project.iam.gserviceaccount.com",
+		"private_key":  string(privateKey),
+	})
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(file, credentials, 0o600))
+	return file
+}
+
+func TestSign(t *testing.T) {
+	setAWSCredentials(t)
+	signer, err := NewSigner(Config{
+		TTL:                time.Hour,
+		S3Endpoint:         "http://minio:9000",
+		S3Region:           "us-east-1",
+		S3Buckets:          []string{"models"},
+		GCSCredentialsFile: gcsCredentialsFile(t),
+		GCSBuckets:         []string{"models"},
+		AzureAccountName:   "models",
+		AzureAccountKey:    base64.StdEncoding.EncodeToString([]byte("key")),
+		AzureContainers:    []string{"granite"},
+	})
+	require.NoError(t, err)
+
+	sign := func(uri string, file string, ttl time.Duration) *url.URL {
+		signed, expires, err := signer.Sign(context.Background(), "", uri, file, ttl)
+		require.NoError(t, err, uri)
+		assert.WithinDuration(t, time.Now().Add(min(ttl, time.Hour)), expires, time.Minute, uri)
+		parsed, err := url.Parse(signed)
+		require.NoError(t, err, uri)
+		return parsed
+	}
+
+	s3URL := sign("s3://models/granite/model.safetensors?endpoint=http://minio:9000&defaultRegion=eu-west-1", "", 5*time.Minute)
+	assert.Equal(t, "minio:9000", s3URL.Host)
+	assert.Equal(t, "/models/granite/model.safetensors", s3URL.Path)
+	assert.Equal(t, "300", s3URL.Query().Get("X-Amz-Expires"))
+	assert.Contains(t, s3URL.Query().Get("X-Amz-Credential"), "AKIDEXAMPLE/")
+	assert.Contains(t, s3URL.Query().Get("X-Amz-Credential"), "/eu-west-1/s3/")
+	assert.NotEmpty(t, s3URL.Query().Get("X-Amz-Signature"))
+
+	// the validity is bounded by the ttl of the signer, the files are below the uris of the model directories
+	s3URL = sign("https://models.s3.eu-central-1.amazonaws.com/granite", "weights/model.safetensors", 24*time.Hour)
+	assert.Equal(t, "minio:9000", s3URL.Host)
+	assert.Equal(t, "/models/granite/weights/model.safetensors", s3URL.Path)
+	assert.Contains(t, s3URL.Query().Get("X-Amz-Credential"), "/eu-central-1/s3/")
+	assert.Equal(t, "3600", s3URL.Query().Get("X-Amz-Expires"))
+
+	gcsURL := sign("gs://models/granite", "config.json", time.Hour)
+	assert.Equal(t, "storage.googleapis.com", gcsURL.Host)
+	assert.Equal(t, "/models/granite/config.json", gcsURL.Path)
+	assert.Equal(t, "GOOG4-RSA-SHA256", gcsURL.Query().Get("X-Goog-Algorithm"))
+	assert.Contains(t, gcsURL.Query().Get("X-Goog-Credential"), "model-registry@project.iam.gserviceaccount.com/")
+	assert.NotEmpty(t, gcsURL.Query().Get("X-Goog-Signature"))
+
+	azureURL := sign("https://models.blob.core.windows.net/granite/v1/model 1.bin", "", time.Hour)
+	assert.Equal(t, "https", azureURL.Scheme)
+	assert.Equal(t, "models.blob.core.windows.net", azureURL.Host)
+	assert.Equal(t, "/granite/v1/model 1.bin", azureURL.Path)
+	assert.Equal(t, "r", azureURL.Query().Get("sp"))
+	assert.Equal(t, "b", azureURL.Query().Get("sr"))
+	assert.NotEmpty(t, azureURL.Query().Get("sig"))
+
+	for uri, message := range map[string]string{
+		"oci://quay.io/org/model:v1":                                    "only the s3, gs and Azure Blob Storage uris can be downloaded",
+		"/mnt/models/granite":                                           "only the s3, gs and Azure Blob Storage uris can be downloaded",
+		"https://other.blob.core.windows.net/granite/v1":                "no credentials configured for its object store",
+		"https://models.blob.core.windows.net/granite":                  "expected https://<account>.blob.core.windows.net/<container>/<blob>",
+		"s3://models":                                                   "missing object key",
+		"s3://secrets/credentials.json":                                 "bucket secrets is not allowed",
+		"s3://models/granite?endpoint=http://attacker":                  "endpoint http://attacker is not allowed",
+		"gs://secrets/credentials.json":                                 "bucket secrets is not allowed",
+		"https://models.blob.core.windows.net/secrets/credentials.json": "container secrets is not allowed",
+	} {
+		_, _, err := signer.Sign(context.Background(), "", uri, "", 0)
+		assert.ErrorIs(t, err, ErrUnsupported, uri)
+		assert.ErrorContains(t, err, message, uri)
+	}
+
+	_, _, err = signer.Sign(context.Background(), "", "s3://models/granite", "../other/model.bin", 0)
+	assert.ErrorContains(t, err, "invalid file path")
+
+	// the gs:// uris can't be signed without the key of a service account
+	signer, err = NewSigner(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTTL, signer.TTL())
+	_, _, err = signer.Sign(context.Background(), "", "gs://models/granite", "config.json", 0)
+	assert.ErrorContains(t, err, "no credentials configured for its object store")
+}
+
+func TestSignDelegated(t *testing.T) {
+	setAWSCredentials(t)
+	expiration := time.Now().Add(10 * time.Minute).UTC()
+	var tokens []string
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/downloads", r.Form.Get("RoleArn"))
+		assert.Empty(t, r.Header.Get("Authorization"), "the requests are not signed with the credentials of the registry")
+		tokens = append(tokens, r.Form.Get("WebIdentityToken"))
+
+		w.Header().Set("Content-Type", "text/xml")
+		if r.Form.Get("WebIdentityToken") != "alice-token" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<ErrorResponse><Error><Type>Sender</Type><Code>AccessDenied</Code><Message>Not authorized to perform sts:AssumeRoleWithWebIdentity</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>`+
+			`<AccessKeyId>ASIAALICE</AccessKeyId><SecretAccessKey>alice-secret</SecretAccessKey><SessionToken>alice-session</SessionToken>`+
+			`<Expiration>%s</Expiration></Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`, expiration.Format(time.RFC3339))
+	}))
+	t.Cleanup(sts.Close)
+
+	signer, err := NewSigner(Config{
+		TTL:                  time.Hour,
+		S3Endpoint:           "http://minio:9000",
+		S3Region:             "us-east-1",
+		S3Buckets:            []string{"models"},
+		S3WebIdentityRoleARN: "arn:aws:iam::123456789012:role/downloads",
+		S3STSEndpoint:        sts.URL,
+		GCSCredentialsFile:   gcsCredentialsFile(t),
+		GCSBuckets:           []string{"models"},
+	})
+	require.NoError(t, err)
+	assert.True(t, signer.Delegated())
+
+	// the url is signed with the credentials of the caller, and expires with them
+	signed, expires, err := signer.Sign(context.Background(), "alice-token", "s3://models/granite", "model.safetensors", time.Hour)
+	require.NoError(t, err)
+	assert.WithinDuration(t, expiration, expires, time.Second)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Contains(t, parsed.Query().Get("X-Amz-Credential"), "ASIAALICE/")
+	assert.Equal(t, "alice-session", parsed.Query().Get("X-Amz-Security-Token"))
+	validity, err := strconv.Atoi(parsed.Query().Get("X-Amz-Expires"))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, validity, 600)
+
+	_, _, err = signer.Sign(context.Background(), "bob-token", "s3://models/granite", "model.safetensors", 0)
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.ErrorContains(t, err, "Not authorized")
+
+	// the buckets are still checked before exchanging the tokens, the other uris can't be signed for the caller
+	_, _, err = signer.Sign(context.Background(), "alice-token", "s3://secrets/credentials.json", "", 0)
+	assert.ErrorIs(t, err, ErrUnsupported)
+	_, _, err = signer.Sign(context.Background(), "alice-token", "gs://models/granite", "config.json", 0)
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.ErrorContains(t, err, "only the s3 uris can be signed with the credentials of the caller")
+	assert.Equal(t, []string{"alice-token", "bob-token"}, tokens)
+}
+
+func TestNewSignerValidatesCredentials(t *testing.T) {
+	invalid := filepath.Join(t.TempDir(), "credentials.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"type": "authorized_user"}`), 0o600))
+	_, err := NewSigner(Config{GCSCredentialsFile: invalid})
+	assert.ErrorContains(t, err, "expected the JSON key of a service account")
+
+	_, err = NewSigner(Config{GCSCredentialsFile: filepath.Join(t.TempDir(), "missing.json")})
+	assert.ErrorContains(t, err, "error reading GCS credentials")
+
+	_, err = NewSigner(Config{AzureAccountName: "models", AzureAccountKey: "not base64"})
+	assert.ErrorContains(t, err, "invalid Azure storage account key")
+}
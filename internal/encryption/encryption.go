@@ -0,0 +1,564 @@
+// Package encryption encrypts at rest the string values of the custom properties holding sensitive metadata, e.g.
+// credentials or personal data attached to models, with a data key of the namespace of their entity.
+//
+// The data keys are AES-256 keys stored in the database, wrapped by a master key held outside of it, so that a dump
+// of the database alone reveals none of the encrypted values. Each namespace, the tenant of its entities, has its own
+// data keys: rotating the key of a namespace re-encrypts its values only. The values are stored as an envelope naming
+// the data key, so that values encrypted with older keys are still read while they are re-encrypted with the latest
+// key of their namespace by a background job. The data keys are never deleted, as the revisions of the entities and
+// their archives keep the values encrypted with them.
+//
+// The encrypted values are opaque to the database: the filter queries and orderings of the lists on the encrypted
+// properties are rejected as bad requests, the free-text searches don't match their values, and they are returned
+// encrypted by the reporting views and exports reading the tables directly.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/types"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// PluginName is the name of the cipher registered as a plugin of the database.
+	PluginName = "model-registry:encryption"
+	// JobName is the name of the background job re-encrypting the values.
+	JobName = "encryption-reencrypt"
+	// DefaultInterval is how often the values are re-encrypted with the latest keys.
+	DefaultInterval = time.Hour
+
+	// envelopePrefix prefixes the encrypted values, followed by the id of their data key and their ciphertext
+	envelopePrefix = "enc:v1:"
+	// keySize is the size of the data and master keys, for AES-256
+	keySize = 32
+	// activeKeyTTL bounds the time a replica encrypts with a data key after it was rotated by another replica
+	activeKeyTTL = time.Minute
+
+	keysTable = "mr_encryption_keys"
+)
+
+var (
+	ErrInvalidKey        = errors.New("invalid encryption key")
+	ErrKeyNotFound       = errors.New("encryption key not found")
+	ErrInvalidCiphertext = errors.New("invalid encrypted value")
+)
+
+// Config enables the encryption of custom properties.
+type Config struct {
+	// MasterKeyFile is the file of the base64 encoded 32 bytes master key wrapping the data keys, the encryption is
+	// disabled when empty.
+	MasterKeyFile string
+	// PreviousMasterKeyFiles are the files of the master keys rotated out, still unwrapping the data keys until they
+	// are wrapped with the master key by the re-encryption job.
+	PreviousMasterKeyFiles []string
+	// Properties are the names of the custom properties encrypted.
+	Properties []string
+	// Interval is how often the values are re-encrypted with the latest keys, defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// Enabled reports whether the custom properties are encrypted.
+func (c Config) Enabled() bool {
+	return c.MasterKeyFile != ""
+}
+
+// MasterKey wraps the data keys, it is implemented by the key management services holding the master key.
+type MasterKey interface {
+	// ID identifies the master key, it is stored with the data keys it wraps.
+	ID() string
+	// Wrap encrypts a data key.
+	Wrap(ctx context.Context, key []byte) ([]byte, error)
+	// Unwrap decrypts a data key wrapped by Wrap.
+	Unwrap(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// localMasterKey is a master key read from a file, e.g. a Kubernetes secret synced from the key management service.
+type localMasterKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewLocalMasterKey returns the master key of 32 bytes, its id is derived from it.
+func NewLocalMasterKey(key []byte) (MasterKey, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("%w: master key must be %d bytes, got %d", ErrInvalidKey, keySize, len(key))
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &localMasterKey{id: "local:" + hex.EncodeToString(sum[:8]), aead: aead}, nil
+}
+
+// LoadLocalMasterKey reads the base64 encoded master key of file.
+func LoadLocalMasterKey(file string) (MasterKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error reading master key file %s: %w", file, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: master key file %s is not base64 encoded: %v", ErrInvalidKey, file, err)
+	}
+	masterKey, err := NewLocalMasterKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("error loading master key file %s: %w", file, err)
+	}
+	return masterKey, nil
+}
+
+func (k *localMasterKey) ID() string {
+	return k.id
+}
+
+func (k *localMasterKey) Wrap(_ context.Context, key []byte) ([]byte, error) {
+	return seal(k.aead, key, []byte(k.id))
+}
+
+func (k *localMasterKey) Unwrap(_ context.Context, wrapped []byte) ([]byte, error) {
+	return open(k.aead, wrapped, []byte(k.id))
+}
+
+// Key is a data key of a namespace, without its key material.
+type Key struct {
+	// Id of the key, named by the values it encrypts. Output only.
+	Id string `json:"id"`
+	// Namespace of the entities whose values the key encrypts, empty for the entities of no namespace.
+	Namespace string `json:"namespace"`
+	// Version of the key in its namespace, the values are encrypted with the latest version.
+	Version int32 `json:"version"`
+	// MasterKeyId is the id of the master key wrapping the key.
+	MasterKeyId string `json:"masterKeyId"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch"`
+}
+
+// KeyList is the list of the keys returned by GET /admin/encryption/keys.
+type KeyList struct {
+	Items []Key `json:"items"`
+	Size  int   `json:"size"`
+}
+
+// keyRecord is a row of the keys table, the wrapped key is base64 encoded.
+type keyRecord struct {
+	ID                   int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Namespace            string `gorm:"column:namespace"`
+	Version              int32  `gorm:"column:version"`
+	WrappedKey           string `gorm:"column:wrapped_key"`
+	MasterKeyID          string `gorm:"column:master_key_id"`
+	CreateTimeSinceEpoch int64  `gorm:"column:create_time_since_epoch"`
+}
+
+func (r *keyRecord) toKey() Key {
+	return Key{
+		Id:                   strconv.FormatInt(r.ID, 10),
+		Namespace:            r.Namespace,
+		Version:              r.Version,
+		MasterKeyId:          r.MasterKeyID,
+		CreateTimeSinceEpoch: strconv.FormatInt(r.CreateTimeSinceEpoch, 10),
+	}
+}
+
+// activeKey is the data key encrypting the values of a namespace.
+type activeKey struct {
+	id       int64
+	aead     cipher.AEAD
+	loadedAt time.Time
+}
+
+// Cipher encrypts and decrypts the values of the encrypted custom properties, it is safe for concurrent use.
+type Cipher struct {
+	db         *gorm.DB
+	config     Config
+	masterKey  MasterKey
+	properties map[string]bool
+	// masterKeys are the current and previous master keys by id
+	masterKeys map[string]MasterKey
+
+	mu sync.Mutex
+	// aeads are the unwrapped data keys by id
+	aeads map[int64]cipher.AEAD
+	// active are the data keys encrypting the values of each namespace
+	active map[string]activeKey
+}
+
+// NewCipher returns the cipher of the registry in db, encrypting the data keys with masterKey and unwrapping them
+// with masterKey or previousMasterKeys. Setup must be called before it is registered.
+func NewCipher(db *gorm.DB, config Config, masterKey MasterKey, previousMasterKeys ...MasterKey) (*Cipher, error) {
+	switch db.Name() {
+	case types.DatabaseTypeMySQL, types.DatabaseTypePostgres:
+	default:
+		return nil, fmt.Errorf("encryption is not supported on %s databases", db.Name())
+	}
+
+	if len(config.Properties) == 0 {
+		return nil, fmt.Errorf("no custom properties to encrypt")
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	properties := make(map[string]bool, len(config.Properties))
+	for _, name := range config.Properties {
+		properties[name] = true
+	}
+
+	masterKeys := map[string]MasterKey{masterKey.ID(): masterKey}
+	for _, previous := range previousMasterKeys {
+		if _, ok := masterKeys[previous.ID()]; !ok {
+			masterKeys[previous.ID()] = previous
+		}
+	}
+
+	return &Cipher{
+		db:         db,
+		config:     config,
+		masterKey:  masterKey,
+		properties: properties,
+		masterKeys: masterKeys,
+		aeads:      map[int64]cipher.AEAD{},
+		active:     map[string]activeKey{},
+	}, nil
+}
+
+// Setup creates the missing keys table.
+func (c *Cipher) Setup(ctx context.Context) error {
+	db := c.db.WithContext(ctx)
+
+	id := "BIGSERIAL PRIMARY KEY"
+	if db.Name() == types.DatabaseTypeMySQL {
+		id = "BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY"
+	}
+
+	if err := db.Exec("CREATE TABLE IF NOT EXISTS " + dbutil.QuoteTableName(db, keysTable) + ` (
+	id ` + id + `,
+	namespace VARCHAR(255) NOT NULL DEFAULT '',
+	version INTEGER NOT NULL,
+	wrapped_key VARCHAR(255) NOT NULL,
+	master_key_id VARCHAR(255) NOT NULL,
+	create_time_since_epoch BIGINT NOT NULL,
+	UNIQUE (namespace, version)
+)`).Error; err != nil {
+		return fmt.Errorf("error creating encryption table %s: %w", keysTable, err)
+	}
+
+	return nil
+}
+
+// Name implements gorm.Plugin.
+func (c *Cipher) Name() string {
+	return PluginName
+}
+
+// Initialize implements gorm.Plugin, the cipher is looked up by the repositories of the database with FromDB.
+func (c *Cipher) Initialize(*gorm.DB) error {
+	return nil
+}
+
+// FromDB returns the cipher registered as a plugin of db, nil if the custom properties are not encrypted.
+func FromDB(db *gorm.DB) *Cipher {
+	if db == nil || db.Config == nil {
+		return nil
+	}
+	c, _ := db.Config.Plugins[PluginName].(*Cipher)
+	return c
+}
+
+// Encrypts reports whether the custom property named name is encrypted, false for a nil cipher.
+func (c *Cipher) Encrypts(name string) bool {
+	return c != nil && c.properties[name]
+}
+
+// Properties returns the names of the encrypted custom properties, sorted, none for a nil cipher.
+func (c *Cipher) Properties() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.properties))
+	for name := range c.properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Encrypt returns the value of the custom property named name of an entity of namespace, encrypted with the latest
+// data key of the namespace, created if it has none.
+func (c *Cipher) Encrypt(ctx context.Context, namespace string, name string, value string) (string, error) {
+	key, err := c.activeKey(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := seal(key.aead, []byte(value), []byte(name))
+	if err != nil {
+		return "", err
+	}
+	return envelopePrefix + strconv.FormatInt(key.id, 10) + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt returns the plaintext of the value of the custom property named name. The values stored before the
+// property was encrypted are returned as they are.
+func (c *Cipher) Decrypt(ctx context.Context, name string, value string) (string, error) {
+	keyID, ciphertext, ok, err := parseEnvelope(value)
+	if err != nil || !ok {
+		return value, err
+	}
+	aead, err := c.key(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("%w: custom property %s: %v", ErrInvalidCiphertext, name, err)
+	}
+	return string(plaintext), nil
+}
+
+// RotateKey creates the next data key of namespace, the namespace of the tenant of ctx if any, encrypting its values
+// from then on. The values encrypted with its previous keys are re-encrypted by the background job.
+func (c *Cipher) RotateKey(ctx context.Context, namespace string) (Key, error) {
+	if tenant := api.Namespace(ctx); tenant != "" {
+		if namespace != "" && namespace != tenant {
+			return Key{}, fmt.Errorf("%w: namespace %s is not the namespace %s of the tenant", ErrInvalidKey, namespace, tenant)
+		}
+		namespace = tenant
+	}
+	if namespace != "" {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return Key{}, fmt.Errorf("%w: invalid namespace %q: %s", ErrInvalidKey, namespace, strings.Join(errs, ", "))
+		}
+	}
+
+	record, err := c.createKey(ctx, namespace)
+	if err != nil {
+		return Key{}, err
+	}
+	c.mu.Lock()
+	delete(c.active, namespace)
+	c.mu.Unlock()
+	return record.toKey(), nil
+}
+
+// ListKeys returns the data keys by namespace and version, the ones of its namespace for a tenant.
+func (c *Cipher) ListKeys(ctx context.Context) ([]Key, error) {
+	db := c.db.WithContext(ctx)
+	query := db.Table(dbutil.QuoteTableName(db, keysTable))
+	if namespace := api.Namespace(ctx); namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+
+	var records []keyRecord
+	if err := query.Order("namespace").Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("error listing encryption keys: %w", err)
+	}
+
+	keys := make([]Key, 0, len(records))
+	for _, record := range records {
+		keys = append(keys, record.toKey())
+	}
+	return keys, nil
+}
+
+// activeKey returns the latest data key of namespace, creating the first one. The keys are read with the database of
+// the cipher, out of the transaction of ctx, so that a key created by a rolled back write is still used.
+func (c *Cipher) activeKey(ctx context.Context, namespace string) (activeKey, error) {
+	c.mu.Lock()
+	key, ok := c.active[namespace]
+	c.mu.Unlock()
+	if ok && time.Since(key.loadedAt) < activeKeyTTL {
+		return key, nil
+	}
+
+	record, err := c.latestKey(ctx, namespace)
+	if errors.Is(err, ErrKeyNotFound) {
+		record, err = c.createKey(ctx, namespace)
+	}
+	if err != nil {
+		return activeKey{}, err
+	}
+	aead, err := c.unwrap(ctx, record)
+	if err != nil {
+		return activeKey{}, err
+	}
+
+	key = activeKey{id: record.ID, aead: aead, loadedAt: time.Now()}
+	c.mu.Lock()
+	c.active[namespace] = key
+	c.mu.Unlock()
+	return key, nil
+}
+
+// latestKey returns the record of the latest data key of namespace.
+func (c *Cipher) latestKey(ctx context.Context, namespace string) (keyRecord, error) {
+	db := c.db.WithContext(ctx)
+	var records []keyRecord
+	if err := db.Table(dbutil.QuoteTableName(db, keysTable)).Where("namespace = ?", namespace).
+		Order("version DESC").Limit(1).Find(&records).Error; err != nil {
+		return keyRecord{}, fmt.Errorf("error reading encryption key of namespace %q: %w", namespace, err)
+	}
+	if len(records) == 0 {
+		return keyRecord{}, fmt.Errorf("%w: namespace %q has no key", ErrKeyNotFound, namespace)
+	}
+	return records[0], nil
+}
+
+// createKey creates the next data key of namespace, wrapped with the master key. The key created concurrently by
+// another replica is returned instead of failing the version taken.
+func (c *Cipher) createKey(ctx context.Context, namespace string) (keyRecord, error) {
+	db := c.db.WithContext(ctx)
+
+	var version int32
+	latest, err := c.latestKey(ctx, namespace)
+	switch {
+	case err == nil:
+		version = latest.Version
+	case !errors.Is(err, ErrKeyNotFound):
+		return keyRecord{}, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return keyRecord{}, fmt.Errorf("error generating encryption key: %w", err)
+	}
+	wrapped, err := c.masterKey.Wrap(ctx, key)
+	if err != nil {
+		return keyRecord{}, fmt.Errorf("error wrapping encryption key: %w", err)
+	}
+
+	record := keyRecord{
+		Namespace:            namespace,
+		Version:              version + 1,
+		WrappedKey:           base64.StdEncoding.EncodeToString(wrapped),
+		MasterKeyID:          c.masterKey.ID(),
+		CreateTimeSinceEpoch: time.Now().UnixMilli(),
+	}
+	result := db.Table(dbutil.QuoteTableName(db, keysTable)).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return keyRecord{}, fmt.Errorf("error creating encryption key of namespace %q: %w", namespace, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return c.latestKey(ctx, namespace)
+	}
+	return record, nil
+}
+
+// key returns the data key with id, unwrapped once.
+func (c *Cipher) key(ctx context.Context, id int64) (cipher.AEAD, error) {
+	c.mu.Lock()
+	aead, ok := c.aeads[id]
+	c.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	db := c.db.WithContext(ctx)
+	var records []keyRecord
+	if err := db.Table(dbutil.QuoteTableName(db, keysTable)).Where("id = ?", id).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("error reading encryption key %d: %w", id, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrKeyNotFound, id)
+	}
+	return c.unwrap(ctx, records[0])
+}
+
+// unwrap returns the data key of record, with the master key that wrapped it.
+func (c *Cipher) unwrap(ctx context.Context, record keyRecord) (cipher.AEAD, error) {
+	c.mu.Lock()
+	aead, ok := c.aeads[record.ID]
+	c.mu.Unlock()
+	if ok {
+		return aead, nil
+	}
+
+	masterKey, ok := c.masterKeys[record.MasterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: master key %s of encryption key %d is not configured", ErrKeyNotFound, record.MasterKeyID, record.ID)
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(record.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encryption key %d: %v", ErrInvalidKey, record.ID, err)
+	}
+	key, err := masterKey.Unwrap(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping encryption key %d: %w", record.ID, err)
+	}
+	if aead, err = newAEAD(key); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.aeads[record.ID] = aead
+	c.mu.Unlock()
+	return aead, nil
+}
+
+// parseEnvelope returns the data key id and the ciphertext of value, false if value is not encrypted.
+func parseEnvelope(value string) (int64, []byte, bool, error) {
+	rest, ok := strings.CutPrefix(value, envelopePrefix)
+	if !ok {
+		return 0, nil, false, nil
+	}
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return 0, nil, false, fmt.Errorf("%w: missing key id", ErrInvalidCiphertext)
+	}
+	keyID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("%w: invalid key id %q", ErrInvalidCiphertext, id)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, nil, false, fmt.Errorf("%w: %v", ErrInvalidCiphertext, err)
+	}
+	return keyID, ciphertext, true, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("%w: key must be %d bytes, got %d", ErrInvalidKey, keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext authenticated with data, the random nonce prefixes the ciphertext.
+func seal(aead cipher.AEAD, plaintext []byte, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, data), nil
+}
+
+// open decrypts the ciphertext of seal.
+func open(aead cipher.AEAD, ciphertext []byte, data []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidCiphertext)
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, data)
+}
@@ -0,0 +1,214 @@
+package encryption
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	selectLatestKey = "SELECT * FROM `mr_encryption_keys` WHERE namespace = ? ORDER BY version DESC LIMIT ?"
+	insertKey       = "INSERT INTO `mr_encryption_keys` (`namespace`,`version`,`wrapped_key`,`master_key_id`,`create_time_since_epoch`) VALUES (?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`"
+)
+
+var keyColumns = []string{"id", "namespace", "version", "wrapped_key", "master_key_id", "create_time_since_epoch"}
+
+func newMasterKey(t *testing.T, b byte) MasterKey {
+	masterKey, err := NewLocalMasterKey([]byte(strings.Repeat(string(b), keySize)))
+	require.NoError(t, err)
+	return masterKey
+}
+
+func newMockCipher(t *testing.T, masterKey MasterKey, previousMasterKeys ...MasterKey) (*Cipher, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	c, err := NewCipher(db, Config{Properties: []string{"owner_email"}}, masterKey, previousMasterKeys...)
+	require.NoError(t, err)
+	return c, mock
+}
+
+// wrappedKey returns the row of a data key wrapped by masterKey.
+func wrappedKey(t *testing.T, masterKey MasterKey, id int64, namespace string, version int32, key []byte) []driver.Value {
+	wrapped, err := masterKey.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	return []driver.Value{id, namespace, version, base64.StdEncoding.EncodeToString(wrapped), masterKey.ID(), 1000}
+}
+
+func TestLocalMasterKey(t *testing.T) {
+	masterKey := newMasterKey(t, 'a')
+	assert.True(t, strings.HasPrefix(masterKey.ID(), "local:"))
+
+	wrapped, err := masterKey.Wrap(context.Background(), []byte("data key"))
+	require.NoError(t, err)
+	key, err := masterKey.Unwrap(context.Background(), wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, "data key", string(key))
+
+	_, err = newMasterKey(t, 'b').Unwrap(context.Background(), wrapped)
+	assert.Error(t, err, "another master key can't unwrap the key")
+
+	_, err = NewLocalMasterKey([]byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	c, mock := newMockCipher(t, newMasterKey(t, 'a'))
+	ctx := context.Background()
+
+	// the first value of a namespace creates its key
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestKey)).WithArgs("team-a", 1).WillReturnRows(sqlmock.NewRows(keyColumns))
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestKey)).WithArgs("team-a", 1).WillReturnRows(sqlmock.NewRows(keyColumns))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(insertKey)).
+		WithArgs("team-a", 1, sqlmock.AnyArg(), c.masterKey.ID(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(7, 1))
+	mock.ExpectCommit()
+
+	encrypted, err := c.Encrypt(ctx, "team-a", "owner_email", "jane@example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:7:"))
+	assert.NotContains(t, encrypted, "jane")
+
+	// the key is cached
+	again, err := c.Encrypt(ctx, "team-a", "owner_email", "jane@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "the nonces are random")
+
+	decrypted, err := c.Decrypt(ctx, "owner_email", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", decrypted)
+
+	// the values stored before the property was encrypted are read as they are
+	plaintext, err := c.Decrypt(ctx, "owner_email", "john@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "john@example.com", plaintext)
+
+	// the value of another property can't be swapped in
+	_, err = c.Decrypt(ctx, "other", encrypted)
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	// the keys not unwrapped yet are read from the database
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_encryption_keys` WHERE id = ?")).WithArgs(8).
+		WillReturnRows(sqlmock.NewRows(keyColumns))
+	_, err = c.Decrypt(ctx, "owner_email", "enc:v1:8:"+base64.StdEncoding.EncodeToString([]byte("ciphertext")))
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+
+	_, err = c.Decrypt(ctx, "owner_email", "enc:v1:x:y")
+	assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDecryptWithPreviousMasterKey(t *testing.T) {
+	previous := newMasterKey(t, 'a')
+	c, mock := newMockCipher(t, newMasterKey(t, 'b'), previous)
+	ctx := context.Background()
+
+	key := []byte(strings.Repeat("k", keySize))
+	aead, err := newAEAD(key)
+	require.NoError(t, err)
+	ciphertext, err := seal(aead, []byte("jane@example.com"), []byte("owner_email"))
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_encryption_keys` WHERE id = ?")).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(wrappedKey(t, previous, 3, "team-a", 1, key)...))
+	decrypted, err := c.Decrypt(ctx, "owner_email", "enc:v1:3:"+base64.StdEncoding.EncodeToString(ciphertext))
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", decrypted)
+
+	// the key is wrapped with the master key by the re-encryption job
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_encryption_keys` WHERE master_key_id <> ? ORDER BY id")).WithArgs(c.masterKey.ID()).
+		WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(wrappedKey(t, previous, 3, "team-a", 1, key)...))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `mr_encryption_keys` SET `master_key_id`=?,`wrapped_key`=? WHERE id = ? AND master_key_id = ?")).
+		WithArgs(c.masterKey.ID(), sqlmock.AnyArg(), 3, previous.ID()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	require.NoError(t, c.rewrapKeys(ctx))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReencryptTable(t *testing.T) {
+	c, mock := newMockCipher(t, newMasterKey(t, 'a'))
+	ctx := context.Background()
+
+	key := []byte(strings.Repeat("k", keySize))
+	aead, err := newAEAD(key)
+	require.NoError(t, err)
+	current, err := seal(aead, []byte("jane@example.com"), []byte("owner_email"))
+	require.NoError(t, err)
+	upToDate := "enc:v1:2:" + base64.StdEncoding.EncodeToString(current)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `ArtifactProperty`.artifact_id AS entity_id, `ArtifactProperty`.name AS name, `ArtifactProperty`.string_value AS string_value, `Artifact`.namespace AS namespace FROM `ArtifactProperty` JOIN `Artifact` ON `Artifact`.id = `ArtifactProperty`.artifact_id WHERE `ArtifactProperty`.is_custom_property = ? AND `ArtifactProperty`.name IN (?) AND `ArtifactProperty`.string_value IS NOT NULL ORDER BY `ArtifactProperty`.artifact_id,`ArtifactProperty`.name LIMIT ?")).
+		WithArgs(true, "owner_email", batchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"entity_id", "name", "string_value", "namespace"}).
+			AddRow(1, "owner_email", "john@example.com", "team-a").
+			AddRow(2, "owner_email", upToDate, "team-a"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestKey)).WithArgs("team-a", 1).
+		WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(wrappedKey(t, c.masterKey, 2, "team-a", 1, key)...))
+	// the value stored before the property was encrypted is encrypted, unless updated meanwhile
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `ArtifactProperty` SET string_value = ? WHERE artifact_id = ? AND name = ? AND is_custom_property = ? AND string_value = ?")).
+		WithArgs(sqlmock.AnyArg(), 1, "owner_email", true, "john@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	count, err := c.reencryptTable(ctx, propertyTables[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRotateKey(t *testing.T) {
+	c, mock := newMockCipher(t, newMasterKey(t, 'a'))
+	handler := NewHandler(c)
+
+	// a tenant rotates the key of its namespace only
+	tenant := api.WithNamespace(context.Background(), "team-a")
+	_, err := c.RotateKey(tenant, "team-b")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	mock.ExpectQuery(regexp.QuoteMeta(selectLatestKey)).WithArgs("team-a", 1).
+		WillReturnRows(sqlmock.NewRows(keyColumns).AddRow(wrappedKey(t, c.masterKey, 2, "team-a", 1, []byte(strings.Repeat("k", keySize)))...))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(insertKey)).
+		WithArgs("team-a", 2, sqlmock.AnyArg(), c.masterKey.ID(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(5, 1))
+	mock.ExpectCommit()
+
+	req := httptest.NewRequest(http.MethodPost, BasePath+"/keys", strings.NewReader(`{}`)).WithContext(tenant)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var key Key
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &key))
+	assert.Equal(t, "5", key.Id)
+	assert.Equal(t, "team-a", key.Namespace)
+	assert.Equal(t, int32(2), key.Version)
+	assert.Equal(t, c.masterKey.ID(), key.MasterKeyId)
+
+	req = httptest.NewRequest(http.MethodPost, BasePath+"/keys", strings.NewReader(`{"namespace":"Team_A"}`))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,79 @@
+package encryption
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// BasePath is the path of the encryption endpoints.
+	BasePath = "/admin/encryption"
+
+	// maxBodySize bounds the size of the rotation requests
+	maxBodySize = 4 << 10
+)
+
+// RotateRequest is the body of POST /admin/encryption/keys.
+type RotateRequest struct {
+	// Namespace whose key is rotated, the namespace of the tenant for a tenant, the entities of no namespace when
+	// empty otherwise.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// NewHandler returns the handler of the encryption endpoints:
+//
+//	GET  /admin/encryption/keys    lists the data keys
+//	POST /admin/encryption/keys    rotates the data key of a namespace
+func NewHandler(c *Cipher) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/keys", func(w http.ResponseWriter, r *http.Request) {
+		keys, err := c.ListKeys(r.Context())
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, KeyList{Items: keys, Size: len(keys)})
+	})
+	mux.HandleFunc("POST "+BasePath+"/keys", func(w http.ResponseWriter, r *http.Request) {
+		var request RotateRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+			writeError(w, http.StatusBadRequest, "invalid key rotation request: "+err.Error())
+			return
+		}
+		key, err := c.RotateKey(r.Context(), request.Namespace)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, key)
+	})
+
+	return mux
+}
+
+func writeFailure(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidKey):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		glog.Errorf("Error handling encryption key request: %v", err)
+		writeError(w, http.StatusInternalServerError, "error handling encryption key request")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing encryption response: %v", err)
+	}
+}
@@ -0,0 +1,195 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"gorm.io/gorm"
+)
+
+// batchSize is the number of property values read per query by the re-encryption job
+const batchSize = 500
+
+// propertyTable is a table of properties whose custom properties are encrypted.
+type propertyTable struct {
+	name string
+	// entityColumn is the column of the id of the entity of the properties
+	entityColumn string
+	// entityTable is the table of the entities, whose namespace column keys the values, none for the executions
+	entityTable string
+	// cached are the kinds of the cached reads of the entities, none for the executions
+	cached []string
+}
+
+var propertyTables = []propertyTable{
+	{name: "ArtifactProperty", entityColumn: "artifact_id", entityTable: "Artifact", cached: []string{cache.KindArtifacts}},
+	{name: "ContextProperty", entityColumn: "context_id", entityTable: "Context", cached: []string{cache.KindRegisteredModels, cache.KindModelVersions}},
+	{name: "ExecutionProperty", entityColumn: "execution_id"},
+}
+
+// propertyValue is the value of an encrypted custom property.
+type propertyValue struct {
+	EntityID    int32  `gorm:"column:entity_id"`
+	Name        string `gorm:"column:name"`
+	StringValue string `gorm:"column:string_value"`
+	Namespace   string `gorm:"column:namespace"`
+}
+
+// Job returns the background job re-encrypting the values.
+func (c *Cipher) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Re-encrypts the encrypted custom properties with the latest key of their namespace, and the keys with the master key",
+		Interval:    c.config.Interval,
+		Run:         c.Reencrypt,
+	}
+}
+
+// Reencrypt wraps the data keys wrapped by a previous master key with the master key, then encrypts the values of the
+// encrypted custom properties stored before they were encrypted or encrypted with a key rotated since. The values are
+// updated unless they changed meanwhile, so that the writes of the api are never overwritten.
+func (c *Cipher) Reencrypt(ctx context.Context) error {
+	if err := c.rewrapKeys(ctx); err != nil {
+		return err
+	}
+
+	for _, table := range propertyTables {
+		count, err := c.reencryptTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			cache.Invalidate(c.db.WithContext(ctx), table.cached...)
+			glog.Infof("Re-encrypted %d values of the custom properties of %s", count, table.name)
+		}
+	}
+	return nil
+}
+
+// rewrapKeys wraps the data keys wrapped by a previous master key with the master key.
+func (c *Cipher) rewrapKeys(ctx context.Context) error {
+	db := c.db.WithContext(ctx)
+	table := dbutil.QuoteTableName(db, keysTable)
+
+	var records []keyRecord
+	if err := db.Table(table).Where("master_key_id <> ?", c.masterKey.ID()).Order("id").Find(&records).Error; err != nil {
+		return fmt.Errorf("error listing encryption keys to rewrap: %w", err)
+	}
+
+	for _, record := range records {
+		masterKey, ok := c.masterKeys[record.MasterKeyID]
+		if !ok {
+			return fmt.Errorf("%w: master key %s of encryption key %d is not configured", ErrKeyNotFound, record.MasterKeyID, record.ID)
+		}
+		wrapped, err := base64.StdEncoding.DecodeString(record.WrappedKey)
+		if err != nil {
+			return fmt.Errorf("%w: encryption key %d: %v", ErrInvalidKey, record.ID, err)
+		}
+		key, err := masterKey.Unwrap(ctx, wrapped)
+		if err != nil {
+			return fmt.Errorf("error unwrapping encryption key %d: %w", record.ID, err)
+		}
+		if wrapped, err = c.masterKey.Wrap(ctx, key); err != nil {
+			return fmt.Errorf("error wrapping encryption key %d: %w", record.ID, err)
+		}
+
+		if err := db.Table(table).Where("id = ? AND master_key_id = ?", record.ID, record.MasterKeyID).Updates(map[string]any{
+			"wrapped_key":   base64.StdEncoding.EncodeToString(wrapped),
+			"master_key_id": c.masterKey.ID(),
+		}).Error; err != nil {
+			return fmt.Errorf("error rewrapping encryption key %d: %w", record.ID, err)
+		}
+	}
+
+	if len(records) > 0 {
+		glog.Infof("Wrapped %d encryption keys with master key %s", len(records), c.masterKey.ID())
+	}
+	return nil
+}
+
+// reencryptTable re-encrypts the values of table not encrypted with the latest key of their namespace, in batches
+// ordered by entity id and name. It returns the number of values re-encrypted.
+func (c *Cipher) reencryptTable(ctx context.Context, table propertyTable) (int, error) {
+	db := c.db.WithContext(ctx)
+	properties := dbutil.QuoteTableName(db, table.name)
+
+	namespace := "''"
+	query := db.Table(properties)
+	if table.entityTable != "" {
+		entities := dbutil.QuoteTableName(db, table.entityTable)
+		namespace = entities + ".namespace"
+		query = query.Joins(fmt.Sprintf("JOIN %s ON %s.id = %s.%s", entities, entities, properties, table.entityColumn))
+	}
+	query = query.Select(fmt.Sprintf("%s.%s AS entity_id, %s.name AS name, %s.string_value AS string_value, %s AS namespace",
+		properties, table.entityColumn, properties, properties, namespace)).
+		Where(properties+".is_custom_property = ? AND "+properties+".name IN ? AND "+properties+".string_value IS NOT NULL", true, c.Properties())
+
+	count := 0
+	var last *propertyValue
+	for {
+		batch := query.Session(&gorm.Session{})
+		if last != nil {
+			batch = batch.Where(fmt.Sprintf("(%s.%s > ? OR (%s.%s = ? AND %s.name > ?))", properties, table.entityColumn, properties, table.entityColumn, properties),
+				last.EntityID, last.EntityID, last.Name)
+		}
+
+		var values []propertyValue
+		if err := batch.Order(properties + "." + table.entityColumn).Order(properties + ".name").Limit(batchSize).Find(&values).Error; err != nil {
+			return count, fmt.Errorf("error reading encrypted custom properties of %s: %w", table.name, err)
+		}
+
+		for _, value := range values {
+			updated, err := c.reencrypt(ctx, table, value)
+			if err != nil {
+				return count, err
+			}
+			if updated {
+				count++
+			}
+		}
+
+		if len(values) < batchSize {
+			return count, nil
+		}
+		last = &values[len(values)-1]
+	}
+}
+
+// reencrypt encrypts value with the latest key of its namespace unless it is encrypted with it, it reports whether
+// value was updated.
+func (c *Cipher) reencrypt(ctx context.Context, table propertyTable, value propertyValue) (bool, error) {
+	key, err := c.activeKey(ctx, value.Namespace)
+	if err != nil {
+		return false, err
+	}
+	keyID, _, encrypted, err := parseEnvelope(value.StringValue)
+	if err != nil {
+		return false, fmt.Errorf("custom property %s of %s %d: %w", value.Name, table.name, value.EntityID, err)
+	}
+	if encrypted && keyID == key.id {
+		return false, nil
+	}
+
+	plaintext, err := c.Decrypt(ctx, value.Name, value.StringValue)
+	if err != nil {
+		return false, fmt.Errorf("error decrypting custom property %s of %s %d: %w", value.Name, table.name, value.EntityID, err)
+	}
+	ciphertext, err := c.Encrypt(ctx, value.Namespace, value.Name, plaintext)
+	if err != nil {
+		return false, fmt.Errorf("error encrypting custom property %s of %s %d: %w", value.Name, table.name, value.EntityID, err)
+	}
+
+	// Raw statement, so that the re-encryption is not a change of the entity for the callbacks of its updates
+	db := c.db.WithContext(ctx)
+	result := db.Exec(fmt.Sprintf("UPDATE %s SET string_value = ? WHERE %s = ? AND name = ? AND is_custom_property = ? AND string_value = ?",
+		dbutil.QuoteTableName(db, table.name), table.entityColumn), ciphertext, value.EntityID, value.Name, true, value.StringValue)
+	if result.Error != nil {
+		return false, fmt.Errorf("error re-encrypting custom property %s of %s %d: %w", value.Name, table.name, value.EntityID, result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
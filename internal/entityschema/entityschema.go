@@ -0,0 +1,407 @@
+// Package entityschema describes the entity types of the registry: their attributes, the properties declared by their
+// types or found on the stored entities, and the fields their lists can be filtered by, so that generic clients and
+// UIs can build forms and query builders without hardcoding them.
+package entityschema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/datastore"
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"gorm.io/gorm"
+)
+
+// DefaultTTL is how long the properties found on the stored entities are cached.
+const DefaultTTL = time.Minute
+
+// Metadata types of the properties, as the metadataType of the custom property values.
+const (
+	MetadataIntValue    = "MetadataIntValue"
+	MetadataDoubleValue = "MetadataDoubleValue"
+	MetadataStringValue = "MetadataStringValue"
+	MetadataStructValue = "MetadataStructValue"
+	MetadataProtoValue  = "MetadataProtoValue"
+	MetadataBoolValue   = "MetadataBoolValue"
+)
+
+// Entity describes an entity type.
+type Entity struct {
+	// EntityType is the REST entity type, e.g. ModelVersion or ModelArtifact.
+	EntityType string      `json:"entityType"`
+	Attributes []Attribute `json:"attributes"`
+	// Properties are the properties declared by the type, then the custom properties found on its entities.
+	Properties []Property `json:"properties"`
+	// FilterableFields are the fields of the filterQuery of the lists of the entity type.
+	FilterableFields []Field `json:"filterableFields"`
+}
+
+// Attribute is a field of the REST representation of an entity.
+type Attribute struct {
+	Name string `json:"name"`
+	// Type is the JSON type of the attribute: string, integer, number, boolean, array or object.
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Enum lists the allowed values of enumerated attributes.
+	Enum []string `json:"enum,omitempty"`
+}
+
+// Property is a property of the entities of a type.
+type Property struct {
+	Name string `json:"name"`
+	// Type is one of the Metadata*Value metadata types.
+	Type string `json:"type"`
+	// Custom is set for the custom properties, which are found on the stored entities rather than declared.
+	Custom bool `json:"custom"`
+	// Count is the number of entities with the custom property of this type.
+	Count int64 `json:"count,omitempty"`
+}
+
+// Field is a field of a filterQuery.
+type Field struct {
+	// Name is the field as written in a filterQuery, custom properties are suffixed by their value type,
+	// e.g. accuracy.double_value.
+	Name string `json:"name"`
+	// Type is the type of the values the field is compared to: string, integer, double or boolean.
+	Type   string `json:"type"`
+	Custom bool   `json:"custom,omitempty"`
+}
+
+// entityTypes are the described entity types, with their type and their REST representation.
+var entityTypes = []struct {
+	restType filter.RestEntityType
+	typeName string
+	model    any
+}{
+	{filter.RestEntityRegisteredModel, defaults.RegisteredModelTypeName, openapi.RegisteredModel{}},
+	{filter.RestEntityModelVersion, defaults.ModelVersionTypeName, openapi.ModelVersion{}},
+	{filter.RestEntityModelArtifact, defaults.ModelArtifactTypeName, openapi.ModelArtifact{}},
+	{filter.RestEntityDocArtifact, defaults.DocArtifactTypeName, openapi.DocArtifact{}},
+	{filter.RestEntityDataSet, defaults.DataSetTypeName, openapi.DataSet{}},
+	{filter.RestEntityMetric, defaults.MetricTypeName, openapi.Metric{}},
+	{filter.RestEntityParameter, defaults.ParameterTypeName, openapi.Parameter{}},
+	{filter.RestEntityExperiment, defaults.ExperimentTypeName, openapi.Experiment{}},
+	{filter.RestEntityExperimentRun, defaults.ExperimentRunTypeName, openapi.ExperimentRun{}},
+	{filter.RestEntityServingEnvironment, defaults.ServingEnvironmentTypeName, openapi.ServingEnvironment{}},
+	{filter.RestEntityInferenceService, defaults.InferenceServiceTypeName, openapi.InferenceService{}},
+	{filter.RestEntityServeModel, defaults.ServeModelTypeName, openapi.ServeModel{}},
+}
+
+// enums are the allowed values of the enumerated attribute types.
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[openapi.ArtifactState]():         enumValues(openapi.AllowedArtifactStateEnumValues),
+	reflect.TypeFor[openapi.ExecutionState]():        enumValues(openapi.AllowedExecutionStateEnumValues),
+	reflect.TypeFor[openapi.ExperimentRunState]():    enumValues(openapi.AllowedExperimentRunStateEnumValues),
+	reflect.TypeFor[openapi.ExperimentRunStatus]():   enumValues(openapi.AllowedExperimentRunStatusEnumValues),
+	reflect.TypeFor[openapi.ExperimentState]():       enumValues(openapi.AllowedExperimentStateEnumValues),
+	reflect.TypeFor[openapi.InferenceServiceState](): enumValues(openapi.AllowedInferenceServiceStateEnumValues),
+	reflect.TypeFor[openapi.ModelVersionState]():     enumValues(openapi.AllowedModelVersionStateEnumValues),
+	reflect.TypeFor[openapi.ParameterType]():         enumValues(openapi.AllowedParameterTypeEnumValues),
+	reflect.TypeFor[openapi.RegisteredModelState]():  enumValues(openapi.AllowedRegisteredModelStateEnumValues),
+}
+
+func enumValues[T ~string](values []T) []string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = string(value)
+	}
+	return strs
+}
+
+// metadataTypes maps the data types of the declared properties to metadata types.
+var metadataTypes = map[datastore.PropertyType]string{
+	datastore.PropertyTypeInt:     MetadataIntValue,
+	datastore.PropertyTypeDouble:  MetadataDoubleValue,
+	datastore.PropertyTypeString:  MetadataStringValue,
+	datastore.PropertyTypeStruct:  MetadataStructValue,
+	datastore.PropertyTypeProto:   MetadataProtoValue,
+	datastore.PropertyTypeBoolean: MetadataBoolValue,
+}
+
+// filterValueTypes maps the metadata types of the custom properties to the value types of the filterQuery.
+var filterValueTypes = map[string]string{
+	MetadataIntValue:    filter.IntValueType,
+	MetadataDoubleValue: filter.DoubleValueType,
+	MetadataStringValue: filter.StringValueType,
+	MetadataBoolValue:   filter.BoolValueType,
+}
+
+// fieldTypes names the value types of the filterQuery.
+var fieldTypes = map[string]string{
+	filter.IntValueType:    "integer",
+	filter.DoubleValueType: "double",
+	filter.StringValueType: "string",
+	filter.BoolValueType:   "boolean",
+}
+
+// identifier matches the property names that don't need to be escaped in a filterQuery.
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Introspector describes the entity types of a registry.
+type Introspector struct {
+	db       *gorm.DB
+	typesMap map[string]int32
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	described []Entity
+	expiry    time.Time
+}
+
+// NewIntrospector returns an introspector of the entity types in db, typesMap maps type names to ids. The
+// descriptions are cached for ttl, DefaultTTL if not positive.
+func NewIntrospector(db *gorm.DB, typesMap map[string]int32, ttl time.Duration) (*Introspector, error) {
+	for _, entityType := range entityTypes {
+		if _, ok := typesMap[entityType.typeName]; !ok {
+			return nil, fmt.Errorf("type %s not found in types map", entityType.typeName)
+		}
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Introspector{db: db, typesMap: typesMap, ttl: ttl}, nil
+}
+
+// Describe returns the descriptions of all the entity types.
+func (i *Introspector) Describe(ctx context.Context) ([]Entity, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.described != nil && time.Now().Before(i.expiry) {
+		return i.described, nil
+	}
+
+	described, err := i.describe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i.described, i.expiry = described, time.Now().Add(i.ttl)
+
+	return described, nil
+}
+
+// DescribeEntity returns the description of entityType.
+func (i *Introspector) DescribeEntity(ctx context.Context, entityType string) (*Entity, error) {
+	described, err := i.Describe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for j := range described {
+		if described[j].EntityType == entityType {
+			return &described[j], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown entity type %q: %w", entityType, api.ErrNotFound)
+}
+
+func (i *Introspector) describe(ctx context.Context) ([]Entity, error) {
+	db := i.db.WithContext(ctx)
+
+	declared, err := i.declaredProperties(db)
+	if err != nil {
+		return nil, err
+	}
+	custom, err := i.customProperties(db)
+	if err != nil {
+		return nil, err
+	}
+
+	described := make([]Entity, 0, len(entityTypes))
+	for _, entityType := range entityTypes {
+		typeID := i.typesMap[entityType.typeName]
+		entity := Entity{
+			EntityType:       string(entityType.restType),
+			Attributes:       attributes(reflect.TypeOf(entityType.model)),
+			Properties:       slices.Concat(declared[typeID], custom[typeID]),
+			FilterableFields: filterableFields(entityType.restType, custom[typeID]),
+		}
+		if entity.Properties == nil {
+			entity.Properties = []Property{}
+		}
+		described = append(described, entity)
+	}
+
+	return described, nil
+}
+
+// declaredProperties returns the properties declared by the types, by type id.
+func (i *Introspector) declaredProperties(db *gorm.DB) (map[int32][]Property, error) {
+	var typeProperties []schema.TypeProperty
+	if err := db.Where("type_id IN ?", i.typeIDs()).Order("type_id, name").Find(&typeProperties).Error; err != nil {
+		return nil, fmt.Errorf("error reading the declared properties: %w", err)
+	}
+
+	properties := map[int32][]Property{}
+	for _, typeProperty := range typeProperties {
+		metadataType := MetadataStringValue
+		if typeProperty.DataType != nil {
+			if t, ok := metadataTypes[datastore.PropertyType(*typeProperty.DataType)]; ok {
+				metadataType = t
+			}
+		}
+		properties[typeProperty.TypeID] = append(properties[typeProperty.TypeID], Property{
+			Name: typeProperty.Name,
+			Type: metadataType,
+		})
+	}
+
+	return properties, nil
+}
+
+// customPropertyRow is a custom property name and metadata type found on the entities of a type.
+type customPropertyRow struct {
+	TypeID       int32
+	Name         string
+	MetadataType string
+	Count        int64
+}
+
+// metadataTypeColumn derives the metadata type of a custom property from its value column, as the REST mapping does.
+const metadataTypeColumn = `CASE
+	WHEN p.int_value IS NOT NULL THEN '` + MetadataIntValue + `'
+	WHEN p.string_value LIKE 'mlmd-struct::%' THEN '` + MetadataStructValue + `'
+	WHEN p.string_value IS NOT NULL THEN '` + MetadataStringValue + `'
+	WHEN p.bool_value IS NOT NULL THEN '` + MetadataBoolValue + `'
+	WHEN p.double_value IS NOT NULL THEN '` + MetadataDoubleValue + `'
+	WHEN p.byte_value IS NOT NULL THEN '` + MetadataStructValue + `'
+	ELSE '' END`
+
+// customProperties returns the custom properties found on the stored entities, by type id, most used first.
+func (i *Introspector) customProperties(db *gorm.DB) (map[int32][]Property, error) {
+	var rows []customPropertyRow
+	for _, tables := range []struct {
+		entity     any
+		property   any
+		foreignKey string
+	}{
+		{&schema.Context{}, &schema.ContextProperty{}, "context_id"},
+		{&schema.Artifact{}, &schema.ArtifactProperty{}, "artifact_id"},
+		{&schema.Execution{}, &schema.ExecutionProperty{}, "execution_id"},
+	} {
+		var tableRows []customPropertyRow
+		if err := db.Table(utils.GetTableName(db, tables.property)+" p").
+			Select("e.type_id, p.name, "+metadataTypeColumn+" AS metadata_type, COUNT(*) AS count").
+			Joins("JOIN "+utils.GetTableName(db, tables.entity)+" e ON e.id = p."+tables.foreignKey).
+			Where("e.type_id IN ? AND p.is_custom_property = ?", i.typeIDs(), true).
+			Group("e.type_id, p.name, metadata_type").
+			Scan(&tableRows).Error; err != nil {
+			return nil, fmt.Errorf("error reading the custom properties: %w", err)
+		}
+		rows = append(rows, tableRows...)
+	}
+
+	sort.SliceStable(rows, func(a, b int) bool {
+		if rows[a].Count != rows[b].Count {
+			return rows[a].Count > rows[b].Count
+		}
+		return rows[a].Name < rows[b].Name
+	})
+
+	properties := map[int32][]Property{}
+	for _, row := range rows {
+		// the experiment properties of the artifacts are attributes, and proto values are not mapped
+		if row.MetadataType == "" || row.Name == "experiment_id" || row.Name == "experiment_run_id" {
+			continue
+		}
+		properties[row.TypeID] = append(properties[row.TypeID], Property{
+			Name:   row.Name,
+			Type:   row.MetadataType,
+			Custom: true,
+			Count:  row.Count,
+		})
+	}
+
+	return properties, nil
+}
+
+func (i *Introspector) typeIDs() []int32 {
+	ids := make([]int32, 0, len(entityTypes))
+	for _, entityType := range entityTypes {
+		ids = append(ids, i.typesMap[entityType.typeName])
+	}
+	return ids
+}
+
+// attributes returns the attributes of a REST model, but its custom properties described as properties.
+func attributes(model reflect.Type) []Attribute {
+	attrs := []Attribute{}
+	for j := range model.NumField() {
+		field := model.Field(j)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" || name == "customProperties" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		attrs = append(attrs, Attribute{
+			Name:     name,
+			Type:     jsonType(fieldType),
+			Required: field.Type.Kind() != reflect.Pointer && !slices.Contains(strings.Split(options, ","), "omitempty"),
+			Enum:     enums[fieldType],
+		})
+	}
+	return attrs
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// filterableFields returns the well-known fields of restType, then its custom properties of a filterable type.
+func filterableFields(restType filter.RestEntityType, custom []Property) []Field {
+	names := make([]string, 0, len(filter.RestEntityPropertyMap[restType]))
+	for name := range filter.RestEntityPropertyMap[restType] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]Field, 0, len(names)+len(custom))
+	for _, name := range names {
+		definition := filter.GetPropertyDefinitionForRestEntity(restType, name)
+		fields = append(fields, Field{Name: name, Type: fieldTypes[definition.ValueType]})
+	}
+
+	for _, property := range custom {
+		valueType, ok := filterValueTypes[property.Type]
+		if !ok {
+			continue
+		}
+		name := property.Name
+		if !identifier.MatchString(name) {
+			name = "`" + strings.ReplaceAll(strings.ReplaceAll(name, `\`, `\\`), ".", `\.`) + "`"
+		}
+		fields = append(fields, Field{Name: name + "." + valueType, Type: fieldTypes[valueType], Custom: true})
+	}
+
+	return fields
+}
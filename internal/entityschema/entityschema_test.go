@@ -0,0 +1,127 @@
+package entityschema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockIntrospector(t *testing.T) (*Introspector, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	typesMap := map[string]int32{}
+	for i, entityType := range entityTypes {
+		typesMap[entityType.typeName] = int32(i + 1)
+	}
+	introspector, err := NewIntrospector(db, typesMap, 0)
+	require.NoError(t, err)
+	return introspector, mock
+}
+
+// expectDescribe expects the queries of a description, the model versions, type 2, declare an author and their
+// entities have an accuracy custom property.
+func expectDescribe(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "TypeProperty" WHERE type_id IN ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) ORDER BY type_id, name`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "name", "data_type"}).AddRow(2, "author", int32(datastore.PropertyTypeString)))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "ContextProperty" p JOIN "Context" e ON e.id = p.context_id WHERE e.type_id IN`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "name", "metadata_type", "count"}).
+			AddRow(2, "accuracy", MetadataDoubleValue, 3).
+			AddRow(2, "training.config", MetadataStructValue, 1).
+			AddRow(2, "data.set", MetadataStringValue, 5))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "ArtifactProperty" p JOIN "Artifact" e ON e.id = p.artifact_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "name", "metadata_type", "count"}).
+			AddRow(3, "experiment_id", MetadataIntValue, 9))
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "ExecutionProperty" p JOIN "Execution" e ON e.id = p.execution_id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "name", "metadata_type", "count"}))
+}
+
+func TestDescribe(t *testing.T) {
+	introspector, mock := newMockIntrospector(t)
+	expectDescribe(mock)
+
+	entity, err := introspector.DescribeEntity(context.Background(), "ModelVersion")
+	require.NoError(t, err)
+
+	assert.Contains(t, entity.Attributes, Attribute{Name: "name", Type: "string", Required: true})
+	assert.Contains(t, entity.Attributes, Attribute{Name: "state", Type: "string", Enum: []string{"LIVE", "ARCHIVED"}})
+	for _, attribute := range entity.Attributes {
+		assert.NotEqual(t, "customProperties", attribute.Name)
+	}
+
+	assert.Equal(t, []Property{
+		{Name: "author", Type: MetadataStringValue},
+		{Name: "data.set", Type: MetadataStringValue, Custom: true, Count: 5},
+		{Name: "accuracy", Type: MetadataDoubleValue, Custom: true, Count: 3},
+		{Name: "training.config", Type: MetadataStructValue, Custom: true, Count: 1},
+	}, entity.Properties)
+
+	assert.Contains(t, entity.FilterableFields, Field{Name: "registeredModelId", Type: "integer"})
+	assert.Contains(t, entity.FilterableFields, Field{Name: "costPer1kInferences", Type: "double"})
+	assert.Contains(t, entity.FilterableFields, Field{Name: "accuracy.double_value", Type: "double", Custom: true})
+	assert.Contains(t, entity.FilterableFields, Field{Name: "`data\\.set`.string_value", Type: "string", Custom: true})
+	for _, field := range entity.FilterableFields {
+		assert.NotContains(t, field.Name, "training")
+	}
+
+	// the experiment properties of the artifacts are attributes
+	artifact, err := introspector.DescribeEntity(context.Background(), "ModelArtifact")
+	require.NoError(t, err)
+	assert.Empty(t, artifact.Properties)
+
+	_, err = introspector.DescribeEntity(context.Background(), "Artifact")
+	assert.ErrorContains(t, err, "unknown entity type")
+
+	// the descriptions are cached
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler(t *testing.T) {
+	introspector, mock := newMockIntrospector(t)
+	expectDescribe(mock)
+
+	handler := NewHandler(introspector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/entities", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var list List
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Equal(t, len(entityTypes), list.Size)
+	assert.Equal(t, "RegisteredModel", list.Items[0].EntityType)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/entities/ServeModel", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var entity Entity
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&entity))
+	assert.Equal(t, "ServeModel", entity.EntityType)
+	assert.NotNil(t, entity.Properties)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/entities/Unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package entityschema
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BasePath is the path of the schema endpoints.
+const BasePath = "/api/model_registry/v1alpha3/schema"
+
+// List is the list of the entity types returned by the schema endpoint.
+type List struct {
+	Items []Entity `json:"items"`
+	Size  int      `json:"size"`
+}
+
+// NewHandler returns the handler of the schema endpoints, passing the other requests to next:
+//
+//	GET /api/model_registry/v1alpha3/schema/entities               describes all the entity types
+//	GET /api/model_registry/v1alpha3/schema/entities/{entityType}  describes one entity type, e.g. ModelVersion
+//
+// The custom properties found on the stored entities lag behind the registry by up to the cache ttl.
+func NewHandler(introspector *Introspector, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/entities", func(w http.ResponseWriter, r *http.Request) {
+		entities, err := introspector.Describe(r.Context())
+		if err != nil {
+			glog.Errorf("Error describing the entity types: %v", err)
+			writeError(w, api.ErrToStatus(err), "error describing the entity types")
+			return
+		}
+		writeJSON(w, http.StatusOK, List{Items: entities, Size: len(entities)})
+	})
+
+	mux.HandleFunc("GET "+BasePath+"/entities/{entityType}", func(w http.ResponseWriter, r *http.Request) {
+		entity, err := introspector.DescribeEntity(r.Context(), r.PathValue("entityType"))
+		if errors.Is(err, api.ErrNotFound) {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			glog.Errorf("Error describing the entity types: %v", err)
+			writeError(w, api.ErrToStatus(err), "error describing the entity types")
+			return
+		}
+		writeJSON(w, http.StatusOK, entity)
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing schema response: %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+// Package features gates the subsystems that are rolled out gradually behind feature flags.
+//
+// A flag is enabled by its default, unless set in the configuration file, unless overridden with the admin API, and
+// admins can override flags for a single request with the Header header, e.g. "semantic-search=true,federation=false".
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Flag is the name of a feature flag.
+type Flag string
+
+const (
+	SemanticSearch Flag = "semantic-search"
+	Federation     Flag = "federation"
+	MLflowShim     Flag = "mlflow-shim"
+)
+
+var ErrUnknownFlag = errors.New("unknown feature flag")
+
+// Header is the header admins override flags for a request with.
+const Header = "X-Model-Registry-Features"
+
+type definition struct {
+	description string
+	enabled     bool
+}
+
+var definitions = map[Flag]definition{
+	SemanticSearch: {description: "Semantic search of registered models and model versions"},
+	Federation:     {description: "Federation of the registered models of remote registries"},
+	MLflowShim:     {description: "MLflow Model Registry compatible REST API"},
+}
+
+// Source is where the value of a flag comes from.
+type Source string
+
+const (
+	SourceDefault  Source = "default"
+	SourceConfig   Source = "config"
+	SourceOverride Source = "override"
+	SourceRequest  Source = "request"
+)
+
+// Status is the value of a flag.
+type Status struct {
+	Name        Flag   `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Source      Source `json:"source"`
+}
+
+// Set holds the values of the flags, it is safe for concurrent use.
+type Set struct {
+	mu        sync.RWMutex
+	config    map[Flag]bool
+	overrides map[Flag]bool
+}
+
+// NewSet returns the flags with the configured values.
+func NewSet(config map[string]bool) (*Set, error) {
+	values, err := parseValues(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Set{config: values, overrides: map[Flag]bool{}}, nil
+}
+
+// Config is the content of the feature flags configuration file.
+type Config struct {
+	Features map[string]bool `json:"features"`
+}
+
+// LoadConfig reads the flag values of a configuration file, e.g.
+//
+//	features:
+//	  semantic-search: true
+func LoadConfig(path string) (map[string]bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading feature flags file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing feature flags file %s: %w", path, err)
+	}
+	return config.Features, nil
+}
+
+// ParseValues parses flag values given as <flag>=<bool>, for the comma separated values of the Header header and
+// the command line.
+func ParseValues(values []string) (map[string]bool, error) {
+	parsed := map[string]bool{}
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			name, enabled, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid feature flag %q, expected <flag>=<true|false>", part)
+			}
+			b, err := strconv.ParseBool(strings.TrimSpace(enabled))
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of feature flag %s: %q", name, enabled)
+			}
+			parsed[strings.TrimSpace(name)] = b
+		}
+	}
+	return parsed, nil
+}
+
+func parseValues(values map[string]bool) (map[Flag]bool, error) {
+	parsed := make(map[Flag]bool, len(values))
+	for name, enabled := range values {
+		flag := Flag(name)
+		if _, ok := definitions[flag]; !ok {
+			return nil, fmt.Errorf("unknown feature flag %q, expected one of %s", name, strings.Join(Names(), ", "))
+		}
+		parsed[flag] = enabled
+	}
+	return parsed, nil
+}
+
+// Names returns the names of the flags.
+func Names() []string {
+	names := make([]string, 0, len(definitions))
+	for flag := range definitions {
+		names = append(names, string(flag))
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Enabled reports whether a flag is enabled for the request of ctx.
+func (s *Set) Enabled(ctx context.Context, flag Flag) bool {
+	return s.status(ctx, flag).Enabled
+}
+
+// List returns the value of the flags, for the request of ctx.
+func (s *Set) List(ctx context.Context) []Status {
+	statuses := make([]Status, 0, len(definitions))
+	for _, name := range Names() {
+		statuses = append(statuses, s.status(ctx, Flag(name)))
+	}
+	return statuses
+}
+
+func (s *Set) status(ctx context.Context, flag Flag) Status {
+	def := definitions[flag]
+	status := Status{Name: flag, Description: def.description, Enabled: def.enabled, Source: SourceDefault}
+
+	if enabled, ok := requestOverrides(ctx)[flag]; ok {
+		status.Enabled, status.Source = enabled, SourceRequest
+		return status
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if enabled, ok := s.overrides[flag]; ok {
+		status.Enabled, status.Source = enabled, SourceOverride
+	} else if enabled, ok := s.config[flag]; ok {
+		status.Enabled, status.Source = enabled, SourceConfig
+	}
+	return status
+}
+
+// Override sets the value of a flag until it is cleared or the server restarts.
+func (s *Set) Override(flag Flag, enabled bool) error {
+	if _, ok := definitions[flag]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[flag] = enabled
+	return nil
+}
+
+// ClearOverride restores the configured value of a flag.
+func (s *Set) ClearOverride(flag Flag) error {
+	if _, ok := definitions[flag]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownFlag, flag)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides, flag)
+	return nil
+}
+
+type overridesKey struct{}
+
+// WithRequestOverrides returns a context overriding the flag values for a request.
+func WithRequestOverrides(ctx context.Context, overrides map[Flag]bool) context.Context {
+	merged := maps.Clone(requestOverrides(ctx))
+	if merged == nil {
+		merged = map[Flag]bool{}
+	}
+	maps.Copy(merged, overrides)
+	return context.WithValue(ctx, overridesKey{}, merged)
+}
+
+func requestOverrides(ctx context.Context) map[Flag]bool {
+	if ctx == nil {
+		return nil
+	}
+	overrides, _ := ctx.Value(overridesKey{}).(map[Flag]bool)
+	return overrides
+}
@@ -0,0 +1,136 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecedence(t *testing.T) {
+	set, err := NewSet(map[string]bool{string(SemanticSearch): true, string(Federation): true})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Equal(t, Status{Name: MLflowShim, Description: definitions[MLflowShim].description, Enabled: false, Source: SourceDefault}, set.status(ctx, MLflowShim))
+	assert.True(t, set.Enabled(ctx, SemanticSearch))
+	assert.Equal(t, SourceConfig, set.status(ctx, SemanticSearch).Source)
+
+	require.NoError(t, set.Override(SemanticSearch, false))
+	assert.False(t, set.Enabled(ctx, SemanticSearch))
+	assert.Equal(t, SourceOverride, set.status(ctx, SemanticSearch).Source)
+
+	requestCtx := WithRequestOverrides(ctx, map[Flag]bool{SemanticSearch: true})
+	assert.True(t, set.Enabled(requestCtx, SemanticSearch))
+	assert.Equal(t, SourceRequest, set.status(requestCtx, SemanticSearch).Source)
+	assert.True(t, set.Enabled(requestCtx, Federation), "flags not overridden by the request keep their value")
+
+	require.NoError(t, set.ClearOverride(SemanticSearch))
+	assert.True(t, set.Enabled(ctx, SemanticSearch))
+	assert.Equal(t, SourceConfig, set.status(ctx, SemanticSearch).Source)
+
+	assert.ErrorIs(t, set.Override("unknown", true), ErrUnknownFlag)
+	assert.ErrorIs(t, set.ClearOverride("unknown"), ErrUnknownFlag)
+}
+
+func TestNewSetUnknownFlag(t *testing.T) {
+	_, err := NewSet(map[string]bool{"unknown": true})
+	assert.ErrorContains(t, err, `unknown feature flag "unknown"`)
+}
+
+func TestParseValues(t *testing.T) {
+	values, err := ParseValues([]string{"semantic-search=true, federation = false", "mlflow-shim=1"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"semantic-search": true, "federation": false, "mlflow-shim": true}, values)
+
+	_, err = ParseValues([]string{"semantic-search"})
+	assert.ErrorContains(t, err, "expected <flag>=<true|false>")
+
+	_, err = ParseValues([]string{"semantic-search=maybe"})
+	assert.ErrorContains(t, err, "invalid value of feature flag semantic-search")
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "features.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("features:\n  semantic-search: true\n  federation: false\n"), 0o600))
+
+	values, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"semantic-search": true, "federation": false}, values)
+
+	require.NoError(t, os.WriteFile(path, []byte("flags:\n  semantic-search: true\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.ErrorContains(t, err, "error parsing feature flags file")
+}
+
+func TestHandler(t *testing.T) {
+	set, err := NewSet(nil)
+	require.NoError(t, err)
+	handler := NewHandler(set)
+
+	serve := func(method string, path string, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodGet, BasePath, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list StatusList
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&list))
+	assert.Equal(t, len(definitions), list.Size)
+	assert.Equal(t, Federation, list.Items[0].Name)
+
+	rec = serve(http.MethodPut, BasePath+"/semantic-search", `{"enabled": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status Status
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, Status{Name: SemanticSearch, Description: definitions[SemanticSearch].description, Enabled: true, Source: SourceOverride}, status)
+	assert.True(t, set.Enabled(context.Background(), SemanticSearch))
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, BasePath+"/semantic-search", `{}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, BasePath+"/unknown", `{"enabled": true}`).Code)
+
+	rec = serve(http.MethodDelete, BasePath+"/semantic-search", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, set.Enabled(context.Background(), SemanticSearch))
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, BasePath+"/unknown", "").Code)
+}
+
+func TestRequestOverrides(t *testing.T) {
+	set, err := NewSet(nil)
+	require.NoError(t, err)
+
+	isAdmin := func(r *http.Request) bool { return r.Header.Get("Authorization") == "Bearer admin" }
+	handler := RequestOverrides(isAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if set.Enabled(r.Context(), SemanticSearch) {
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+
+	serve := func(token string, header string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if header != "" {
+			req.Header.Set(Header, header)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve("", ""))
+	assert.Equal(t, http.StatusOK, serve("user", "semantic-search=true"), "the header of non admins is ignored")
+	assert.Equal(t, http.StatusAccepted, serve("admin", "semantic-search=true"))
+	assert.Equal(t, http.StatusBadRequest, serve("admin", "unknown=true"))
+	assert.Equal(t, http.StatusBadRequest, serve("admin", "semantic-search"))
+}
@@ -0,0 +1,102 @@
+package features
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+// BasePath is the path of the feature flag endpoints.
+const BasePath = "/admin/features"
+
+// StatusList is the list of the flags returned by GET /admin/features.
+type StatusList struct {
+	Items []Status `json:"items"`
+	Size  int      `json:"size"`
+}
+
+// OverrideRequest is the body of PUT /admin/features/{name}.
+type OverrideRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// NewHandler returns the handler of the feature flag endpoints:
+//
+//	GET    /admin/features         lists the flags
+//	PUT    /admin/features/{name}  overrides a flag, {"enabled": true}
+//	DELETE /admin/features/{name}  clears the override of a flag
+func NewHandler(set *Set) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath, func(w http.ResponseWriter, r *http.Request) {
+		statuses := set.List(r.Context())
+		writeJSON(w, http.StatusOK, StatusList{Items: statuses, Size: len(statuses)})
+	})
+
+	mux.HandleFunc("PUT "+BasePath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		var body OverrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Enabled == nil {
+			writeError(w, http.StatusBadRequest, `invalid body, expected {"enabled": <bool>}`)
+			return
+		}
+		flag := Flag(r.PathValue("name"))
+		if err := set.Override(flag, *body.Enabled); err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		glog.Infof("Feature flag %s overridden to %t", flag, *body.Enabled)
+		writeJSON(w, http.StatusOK, set.status(r.Context(), flag))
+	})
+
+	mux.HandleFunc("DELETE "+BasePath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		flag := Flag(r.PathValue("name"))
+		if err := set.ClearOverride(flag); err != nil {
+			writeError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		glog.Infof("Feature flag %s override cleared", flag)
+		writeJSON(w, http.StatusOK, set.status(r.Context(), flag))
+	})
+
+	return mux
+}
+
+// RequestOverrides returns a middleware applying the flag values of the Header header to the requests isAdmin
+// accepts, the header of other requests is ignored.
+func RequestOverrides(isAdmin func(r *http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Values(Header)
+			if len(header) == 0 || !isAdmin(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			values, err := ParseValues(header)
+			if err == nil {
+				var overrides map[Flag]bool
+				if overrides, err = parseValues(values); err == nil {
+					r = r.WithContext(WithRequestOverrides(r.Context(), overrides))
+				}
+			}
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing feature flags response: %v", err)
+	}
+}
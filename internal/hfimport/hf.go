@@ -0,0 +1,148 @@
+package hfimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// model is a model repository of the Hugging Face Hub api.
+type model struct {
+	Id           string `json:"id"`
+	Author       string `json:"author"`
+	Sha          string `json:"sha"`
+	LastModified string `json:"lastModified"`
+	PipelineTag  string `json:"pipeline_tag"`
+	LibraryName  string `json:"library_name"`
+	// CardData is the metadata of the model card, the YAML header of its README.md.
+	CardData map[string]any `json:"cardData"`
+	Siblings []file         `json:"siblings"`
+}
+
+// file is a file of a model repository.
+type file struct {
+	RFilename string `json:"rfilename"`
+}
+
+// errNoReadme is returned for the model repositories without model card.
+var errNoReadme = errors.New("no README.md")
+
+// hubClient reads the model repositories of a Hugging Face Hub.
+type hubClient struct {
+	url   string
+	token string
+}
+
+// listModels returns a page of the ids of the model repositories of author, with the cursor of the next page.
+func (c *hubClient) listModels(ctx context.Context, author string, limit int, cursor string) ([]string, string, error) {
+	query := url.Values{"author": {author}, "limit": {strconv.Itoa(limit)}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var models []model
+	resp, err := c.get(ctx, "/api/models", query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&models); err != nil {
+		return nil, "", fmt.Errorf("invalid response of the hub to /api/models: %w", err)
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.Id)
+	}
+	return ids, nextCursor(resp.Header.Get("Link")), nil
+}
+
+// getModel returns the model repository with id at its latest revision.
+func (c *hubClient) getModel(ctx context.Context, id string) (model, error) {
+	var found model
+	resp, err := c.get(ctx, "/api/models/"+id, nil)
+	if err != nil {
+		return found, err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(&found); err != nil {
+		return found, fmt.Errorf("invalid response of the hub to /api/models/%s: %w", id, err)
+	}
+	return found, nil
+}
+
+// getReadme returns the model card of the model repository with id at revision, without its metadata header.
+func (c *hubClient) getReadme(ctx context.Context, id string, revision string) (string, error) {
+	resp, err := c.get(ctx, "/"+id+"/raw/"+revision+"/README.md", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading the model card of %s: %w", id, err)
+	}
+	return stripMetadata(string(body)), nil
+}
+
+func (c *hubClient) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	target := strings.TrimSuffix(c.url, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s from the hub: %w", path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound && strings.HasSuffix(path, "/README.md") {
+			return nil, errNoReadme
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("error reading %s from the hub: %s: %s", path, resp.Status, body)
+	}
+	return resp, nil
+}
+
+// nextCursor returns the cursor of the next page of a Link header, <url>; rel="next".
+func nextCursor(link string) string {
+	for entry := range strings.SplitSeq(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(entry), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		next, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+		return next.Query().Get("cursor")
+	}
+	return ""
+}
+
+// stripMetadata removes the YAML metadata header, between --- lines, of a model card.
+func stripMetadata(readme string) string {
+	rest, ok := strings.CutPrefix(readme, "---\n")
+	if !ok {
+		return readme
+	}
+	if _, body, ok := strings.Cut(rest, "\n---\n"); ok {
+		return strings.TrimLeft(body, "\n")
+	}
+	return readme
+}
@@ -0,0 +1,362 @@
+// Package hfimport registers the model repositories of a Hugging Face Hub organization in a model registry server,
+// through their REST apis, a model version for each revision of a repository.
+package hfimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/clientutil"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+const (
+	// DefaultHubURL is the url of the public Hugging Face Hub.
+	DefaultHubURL = "https://huggingface.co"
+	// DefaultPageSize is the number of model repositories listed at once.
+	DefaultPageSize = 100
+)
+
+const (
+	// externalIdPrefix prefixes the external ids of the imported entities, after which they are found on later runs.
+	externalIdPrefix = "hf:"
+	// sourceKind is the model source kind of the imported model artifacts.
+	sourceKind = "huggingface"
+	// revisionNameLength is the length of the abbreviated revisions naming the model versions.
+	revisionNameLength = 12
+)
+
+// Custom properties recording the provenance of the imported entities.
+const (
+	repoIdProperty       = "hf_repo_id"
+	urlProperty          = "hf_url"
+	revisionProperty     = "hf_revision"
+	lastModifiedProperty = "hf_last_modified"
+	baseModelProperty    = "hf_base_model"
+	datasetsProperty     = "hf_datasets"
+	filesProperty        = "hf_files"
+)
+
+// Config configures an import.
+type Config struct {
+	HubURL   string
+	HubToken string
+	// Author is the organization or user whose model repositories are imported.
+	Author string
+	URL    string
+	Token  string
+	// StateFile records the revisions of the model repositories imported, so that the next import skips those without
+	// a new revision. Imports without state file update all the registered models.
+	StateFile string
+	PageSize  int
+}
+
+// Summary counts the entities imported.
+type Summary struct {
+	RegisteredModels int
+	ModelVersions    int
+	// Skipped counts the model repositories without a new revision since the previous import.
+	Skipped int
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d registered models and %d model versions, %d skipped as unchanged", s.RegisteredModels, s.ModelVersions, s.Skipped)
+}
+
+// state is the progress of the imports saved in the state file.
+type state struct {
+	// Revisions maps the ids of the model repositories imported to their revision when imported.
+	Revisions map[string]string `json:"revisions"`
+}
+
+// Importer imports the model repositories of a Hugging Face Hub organization into the model registry server reached
+// with its client.
+type Importer struct {
+	cfg    Config
+	hub    *hubClient
+	client *openapi.APIClient
+}
+
+// NewImporter returns an Importer from the Hugging Face Hub at cfg.HubURL into the model registry server at cfg.URL.
+func NewImporter(cfg Config) *Importer {
+	if cfg.HubURL == "" {
+		cfg.HubURL = DefaultHubURL
+	}
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+
+	clientCfg := openapi.NewConfiguration()
+	clientCfg.Servers = openapi.ServerConfigurations{{URL: strings.TrimSuffix(cfg.URL, "/")}}
+	if cfg.Token != "" {
+		clientCfg.AddDefaultHeader("Authorization", "Bearer "+cfg.Token)
+	}
+	return &Importer{
+		cfg:    cfg,
+		hub:    &hubClient{url: cfg.HubURL, token: cfg.HubToken},
+		client: openapi.NewAPIClient(clientCfg),
+	}
+}
+
+// Import creates or updates a registered model for each model repository of the author with the metadata of its
+// model card, then a model version named after the abbreviated revision of the repository if it has none yet, with a
+// model artifact whose uri is hf://<repo>:<revision>. The imported entities are found by their external id
+// "hf:<repo>" and "hf:<repo>@<revision>" on later imports, their provenance is recorded in hf_* custom properties.
+// The revisions pushed between two imports are not registered, only the latest one. The repositories whose revision
+// is the one of the state file are skipped, the state is saved after each repository.
+func (i *Importer) Import(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	progress, err := i.loadState()
+	if err != nil {
+		return summary, err
+	}
+
+	cursor := ""
+	for {
+		ids, next, err := i.hub.listModels(ctx, i.cfg.Author, i.cfg.PageSize, cursor)
+		if err != nil {
+			return summary, err
+		}
+		for _, id := range ids {
+			repo, err := i.hub.getModel(ctx, id)
+			if err != nil {
+				return summary, err
+			}
+			if repo.Sha == "" || progress.Revisions[id] == repo.Sha {
+				summary.Skipped++
+				continue
+			}
+
+			registeredModel, err := i.importRegisteredModel(ctx, repo)
+			if err != nil {
+				return summary, err
+			}
+			summary.RegisteredModels++
+
+			created, err := i.importModelVersion(ctx, registeredModel, repo)
+			if err != nil {
+				return summary, err
+			}
+			if created {
+				summary.ModelVersions++
+			}
+
+			progress.Revisions[id] = repo.Sha
+			if err := i.saveState(progress); err != nil {
+				return summary, err
+			}
+		}
+		if next == "" {
+			return summary, nil
+		}
+		cursor = next
+	}
+}
+
+// importRegisteredModel creates or updates the registered model of a model repository from its model card.
+func (i *Importer) importRegisteredModel(ctx context.Context, repo model) (*openapi.RegisteredModel, error) {
+	readme, err := i.hub.getReadme(ctx, repo.Id, repo.Sha)
+	if err != nil && !errors.Is(err, errNoReadme) {
+		return nil, err
+	}
+
+	properties := map[string]openapi.MetadataValue{
+		repoIdProperty:   stringValue(repo.Id),
+		urlProperty:      stringValue(strings.TrimSuffix(i.cfg.HubURL, "/") + "/" + repo.Id),
+		revisionProperty: stringValue(repo.Sha),
+	}
+	if repo.LastModified != "" {
+		properties[lastModifiedProperty] = stringValue(repo.LastModified)
+	}
+	if baseModels := cardStrings(repo.CardData, "base_model"); len(baseModels) > 0 {
+		properties[baseModelProperty] = stringValue(strings.Join(baseModels, ","))
+	}
+	if datasets := cardStrings(repo.CardData, "datasets"); len(datasets) > 0 {
+		properties[datasetsProperty] = stringValue(strings.Join(datasets, ","))
+	}
+
+	var tasks []string
+	if repo.PipelineTag != "" {
+		tasks = []string{repo.PipelineTag}
+	}
+	libraryName := repo.LibraryName
+	if libraryName == "" {
+		libraryName = cardString(repo.CardData, "library_name")
+	}
+
+	externalId := externalIdPrefix + repo.Id
+	existing, resp, err := i.client.ModelRegistryServiceAPI.FindRegisteredModel(ctx).ExternalId(externalId).Execute()
+	if err != nil && !isNotFound(resp) {
+		return nil, fmt.Errorf("error finding registered model %s: %w", repo.Id, clientutil.RestError(err))
+	}
+
+	if existing == nil {
+		created, _, err := i.client.ModelRegistryServiceAPI.CreateRegisteredModel(ctx).RegisteredModelCreate(openapi.RegisteredModelCreate{
+			Name:             repo.Id,
+			ExternalId:       &externalId,
+			Readme:           optional(readme),
+			Language:         cardStrings(repo.CardData, "language"),
+			Tasks:            tasks,
+			Provider:         optional(repo.Author),
+			License:          optional(cardString(repo.CardData, "license")),
+			LicenseLink:      optional(cardString(repo.CardData, "license_link")),
+			LibraryName:      optional(libraryName),
+			CustomProperties: properties,
+		}).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error creating registered model %s: %w", repo.Id, clientutil.RestError(err))
+		}
+		glog.V(2).Infof("Imported model repository %s as registered model %s", repo.Id, created.GetId())
+		return created, nil
+	}
+
+	for name, value := range existing.GetCustomProperties() {
+		if _, ok := properties[name]; !ok {
+			properties[name] = value
+		}
+	}
+	updated, _, err := i.client.ModelRegistryServiceAPI.UpdateRegisteredModel(ctx, existing.GetId()).RegisteredModelUpdate(openapi.RegisteredModelUpdate{
+		Readme:           optional(readme),
+		Language:         cardStrings(repo.CardData, "language"),
+		Tasks:            tasks,
+		Provider:         optional(repo.Author),
+		License:          optional(cardString(repo.CardData, "license")),
+		LicenseLink:      optional(cardString(repo.CardData, "license_link")),
+		LibraryName:      optional(libraryName),
+		CustomProperties: properties,
+	}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("error updating registered model %s: %w", repo.Id, clientutil.RestError(err))
+	}
+	glog.V(2).Infof("Updated registered model %s", updated.GetId())
+	return updated, nil
+}
+
+// importModelVersion creates the model version of the revision of a model repository and its model artifact,
+// reporting whether it was created: the model versions of the revisions imported before are left as is.
+func (i *Importer) importModelVersion(ctx context.Context, registeredModel *openapi.RegisteredModel, repo model) (bool, error) {
+	externalId := externalIdPrefix + repo.Id + "@" + repo.Sha
+	_, resp, err := i.client.ModelRegistryServiceAPI.FindModelVersion(ctx).ExternalId(externalId).Execute()
+	if err == nil {
+		return false, nil
+	}
+	if !isNotFound(resp) {
+		return false, fmt.Errorf("error finding model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	properties := map[string]openapi.MetadataValue{
+		repoIdProperty:   stringValue(repo.Id),
+		revisionProperty: stringValue(repo.Sha),
+	}
+	if repo.LastModified != "" {
+		properties[lastModifiedProperty] = stringValue(repo.LastModified)
+	}
+	version, _, err := i.client.ModelRegistryServiceAPI.CreateModelVersion(ctx).ModelVersionCreate(openapi.ModelVersionCreate{
+		Name:              repo.Sha[:min(revisionNameLength, len(repo.Sha))],
+		RegisteredModelId: registeredModel.GetId(),
+		ExternalId:        &externalId,
+		CustomProperties:  properties,
+	}).Execute()
+	if err != nil {
+		return false, fmt.Errorf("error creating model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	files := make([]string, 0, len(repo.Siblings))
+	for _, f := range repo.Siblings {
+		files = append(files, f.RFilename)
+	}
+	artifact := openapi.ModelArtifact{
+		ArtifactType:     apiutils.Of("model-artifact"),
+		Name:             &repo.Id,
+		Uri:              apiutils.Of("hf://" + repo.Id + ":" + repo.Sha),
+		ModelSourceKind:  apiutils.Of(sourceKind),
+		ModelSourceClass: apiutils.Of("model"),
+		ModelSourceGroup: optional(repo.Author),
+		ModelSourceId:    &repo.Sha,
+		ModelSourceName:  &repo.Id,
+	}
+	if len(files) > 0 {
+		artifact.CustomProperties = map[string]openapi.MetadataValue{filesProperty: stringValue(strings.Join(files, ","))}
+	}
+	if _, _, err := i.client.ModelRegistryServiceAPI.UpsertModelVersionArtifact(ctx, version.GetId()).Artifact(openapi.Artifact{ModelArtifact: &artifact}).Execute(); err != nil {
+		return false, fmt.Errorf("error importing model artifact of model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	glog.V(2).Infof("Imported revision %s of model repository %s as model version %s", repo.Sha, repo.Id, version.GetId())
+	return true, nil
+}
+
+// cardString returns the string metadata of a model card.
+func cardString(card map[string]any, key string) string {
+	value, _ := card[key].(string)
+	return value
+}
+
+// cardStrings returns the metadata of a model card which is a string or a list of strings.
+func cardStrings(card map[string]any, key string) []string {
+	switch value := card[key].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func stringValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func isNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func (i *Importer) loadState() (state, error) {
+	progress := state{Revisions: map[string]string{}}
+	if i.cfg.StateFile == "" {
+		return progress, nil
+	}
+
+	data, err := os.ReadFile(i.cfg.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, fmt.Errorf("error reading import state: %w", err)
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf("invalid import state %s: %w", i.cfg.StateFile, err)
+	}
+	if progress.Revisions == nil {
+		progress.Revisions = map[string]string{}
+	}
+	return progress, nil
+}
+
+func (i *Importer) saveState(progress state) error {
+	if err := clientutil.SaveState(i.cfg.StateFile, progress); err != nil {
+		return fmt.Errorf("error saving import state: %w", err)
+	}
+	return nil
+}
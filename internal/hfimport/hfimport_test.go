@@ -0,0 +1,158 @@
+package hfimport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHub serves the model endpoints of a Hugging Face Hub, with the offset of the next page as cursor.
+type fakeHub struct {
+	models  []model
+	readmes map[string]string
+}
+
+func (f *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/models" {
+		query := r.URL.Query()
+		var authored []model
+		for _, m := range f.models {
+			if m.Author == query.Get("author") {
+				authored = append(authored, m)
+			}
+		}
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		offset, _ := strconv.Atoi(query.Get("cursor"))
+		end := min(offset+limit, len(authored))
+		if end < len(authored) {
+			w.Header().Set("Link", `<http://`+r.Host+`/api/models?author=`+query.Get("author")+`&cursor=`+strconv.Itoa(end)+`>; rel="next"`)
+		}
+		_ = json.NewEncoder(w).Encode(authored[offset:end])
+		return
+	}
+
+	if id, ok := strings.CutPrefix(r.URL.Path, "/api/models/"); ok {
+		for _, m := range f.models {
+			if m.Id == id {
+				_ = json.NewEncoder(w).Encode(m)
+				return
+			}
+		}
+	}
+	for _, m := range f.models {
+		if r.URL.Path == "/"+m.Id+"/raw/"+m.Sha+"/README.md" && f.readmes[m.Id] != "" {
+			_, _ = w.Write([]byte(f.readmes[m.Id]))
+			return
+		}
+	}
+	http.Error(w, `{"error": "Repository not found"}`, http.StatusNotFound)
+}
+
+func TestImport(t *testing.T) {
+	hub := &fakeHub{
+		models: []model{
+			{
+				Id: "acme/churn-7b", Author: "acme", Sha: "0123456789abcdef0123", LastModified: "2026-01-02T03:04:05.000Z",
+				PipelineTag: "text-generation", LibraryName: "transformers",
+				CardData: map[string]any{"license": "apache-2.0", "language": []any{"en", "fr"}, "base_model": "acme/base-7b"},
+				Siblings: []file{{RFilename: "config.json"}, {RFilename: "model.safetensors"}},
+			},
+			{Id: "acme/fraud", Author: "acme", Sha: "fedcba9876543210fedc"},
+			{Id: "acme/empty", Author: "acme"},
+			{Id: "other/model", Author: "other", Sha: "aaaaaaaaaaaaaaaaaaaa"},
+		},
+		readmes: map[string]string{"acme/churn-7b": "---\nlicense: apache-2.0\n---\n\n# Churn 7B\n"},
+	}
+	hubServer := httptest.NewServer(hub)
+	defer hubServer.Close()
+
+	server, service := inmemory.NewServer(t)
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	importer := NewImporter(Config{HubURL: hubServer.URL, Author: "acme", URL: server.URL, StateFile: stateFile, PageSize: 2})
+
+	summary, err := importer.Import(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{RegisteredModels: 2, ModelVersions: 2, Skipped: 1}, summary)
+
+	churn, err := service.GetRegisteredModelByParams(nil, apiutils.Of("hf:acme/churn-7b"))
+	require.NoError(t, err)
+	assert.Equal(t, "acme/churn-7b", churn.Name)
+	assert.Equal(t, "# Churn 7B\n", churn.GetReadme())
+	assert.Equal(t, "apache-2.0", churn.GetLicense())
+	assert.Equal(t, []string{"en", "fr"}, churn.Language)
+	assert.Equal(t, []string{"text-generation"}, churn.Tasks)
+	assert.Equal(t, "acme", churn.GetProvider())
+	assert.Equal(t, "transformers", churn.GetLibraryName())
+	assert.Equal(t, hubServer.URL+"/acme/churn-7b", churn.CustomProperties["hf_url"].MetadataStringValue.StringValue)
+	assert.Equal(t, "acme/base-7b", churn.CustomProperties["hf_base_model"].MetadataStringValue.StringValue)
+
+	version, err := service.GetModelVersionByParams(nil, nil, apiutils.Of("hf:acme/churn-7b@0123456789abcdef0123"))
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789ab", version.Name)
+	assert.Equal(t, "0123456789abcdef0123", version.CustomProperties["hf_revision"].MetadataStringValue.StringValue)
+
+	artifacts, err := service.GetModelArtifacts(api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	require.Len(t, artifacts.Items, 1)
+	assert.Equal(t, "hf://acme/churn-7b:0123456789abcdef0123", artifacts.Items[0].GetUri())
+	assert.Equal(t, "huggingface", artifacts.Items[0].GetModelSourceKind())
+	assert.Equal(t, "acme", artifacts.Items[0].GetModelSourceGroup())
+	assert.Equal(t, "config.json,model.safetensors", artifacts.Items[0].CustomProperties["hf_files"].MetadataStringValue.StringValue)
+
+	_, err = service.GetRegisteredModelByParams(nil, apiutils.Of("hf:other/model"))
+	assert.ErrorIs(t, err, api.ErrNotFound)
+
+	state, err := os.ReadFile(stateFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"revisions": {"acme/churn-7b": "0123456789abcdef0123", "acme/fraud": "fedcba9876543210fedc"}}`, string(state))
+
+	// a rerun registers the new revisions as model versions, keeping the custom properties set in the registry
+	churn.CustomProperties["owner"] = stringValue("ml-platform")
+	_, err = service.UpsertRegisteredModel(churn)
+	require.NoError(t, err)
+
+	hub.models[0].Sha = "1111111111111111aaaa"
+	hub.models[0].CardData["license"] = "mit"
+
+	summary, err = importer.Import(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{RegisteredModels: 1, ModelVersions: 1, Skipped: 2}, summary)
+
+	churn, err = service.GetRegisteredModelByParams(nil, apiutils.Of("hf:acme/churn-7b"))
+	require.NoError(t, err)
+	assert.Equal(t, "mit", churn.GetLicense())
+	assert.Equal(t, "ml-platform", churn.CustomProperties["owner"].MetadataStringValue.StringValue)
+	assert.Equal(t, "# Churn 7B\n", churn.GetReadme(), "the readme is kept when the new revision has no model card")
+
+	versions, err := service.GetModelVersions(api.ListOptions{}, churn.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), versions.Size)
+
+	// without state file, the repositories are updated but their revisions are registered once
+	summary, err = NewImporter(Config{HubURL: hubServer.URL, Author: "acme", URL: server.URL}).Import(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{RegisteredModels: 2, Skipped: 1}, summary)
+
+	require.NoError(t, os.WriteFile(stateFile, []byte(`{"revisions": ["acme/churn-7b"]}`), 0o600))
+	_, err = importer.Import(context.Background())
+	assert.ErrorContains(t, err, "invalid import state")
+}
+
+func TestNextCursor(t *testing.T) {
+	assert.Equal(t, "abc", nextCursor(`<https://huggingface.co/api/models?author=acme&cursor=abc&limit=100>; rel="next"`))
+	assert.Equal(t, "", nextCursor(`<https://huggingface.co/api/models?cursor=abc>; rel="prev"`))
+	assert.Equal(t, "", nextCursor(""))
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+// BasePath is the path of the job endpoints.
+const BasePath = "/admin/jobs"
+
+// StatusList is the list of the jobs returned by GET /admin/jobs.
+type StatusList struct {
+	Items []Status `json:"items"`
+	Size  int      `json:"size"`
+}
+
+// NewHandler returns the handler of the job endpoints:
+//
+//	GET  /admin/jobs                  lists the jobs
+//	GET  /admin/jobs/{name}           returns a job
+//	POST /admin/jobs/{name}/pause     pauses the scheduled runs of a job
+//	POST /admin/jobs/{name}/resume    resumes the scheduled runs of a job
+//	POST /admin/jobs/{name}/trigger   runs a job now
+func NewHandler(scheduler *Scheduler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath, func(w http.ResponseWriter, r *http.Request) {
+		statuses := scheduler.List()
+		writeJSON(w, http.StatusOK, StatusList{Items: statuses, Size: len(statuses)})
+	})
+	mux.HandleFunc("GET "+BasePath+"/{name}", func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, http.StatusOK)(scheduler.Get(r.PathValue("name")))
+	})
+
+	actions := map[string]func(name string) (Status, error){
+		"pause":   scheduler.Pause,
+		"resume":  scheduler.Resume,
+		"trigger": scheduler.Trigger,
+	}
+	mux.HandleFunc("POST "+BasePath+"/{name}/{action}", func(w http.ResponseWriter, r *http.Request) {
+		action, ok := actions[r.PathValue("action")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "unknown job action "+r.PathValue("action"))
+			return
+		}
+		writeStatus(w, http.StatusAccepted)(action(r.PathValue("name")))
+	})
+
+	return mux
+}
+
+func writeStatus(w http.ResponseWriter, code int) func(Status, error) {
+	return func(status Status, err error) {
+		switch {
+		case errors.Is(err, ErrJobNotFound):
+			writeError(w, http.StatusNotFound, err.Error())
+		case errors.Is(err, ErrJobNotRunning):
+			writeError(w, http.StatusConflict, err.Error())
+		case err != nil:
+			writeError(w, http.StatusInternalServerError, err.Error())
+		default:
+			writeJSON(w, code, status)
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing job response: %v", err)
+	}
+}
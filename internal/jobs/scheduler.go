@@ -0,0 +1,254 @@
+// Package jobs schedules the background jobs of the server and reports their status, so that operators can pause,
+// resume and trigger them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobNotRunning is returned when triggering a job not scheduled on this replica, e.g. as it is not the leader.
+	ErrJobNotRunning = errors.New("job not running on this replica")
+)
+
+// Job is a background job run every interval.
+type Job struct {
+	Name        string
+	Description string
+	Interval    time.Duration
+	Run         func(ctx context.Context) error
+}
+
+// Status is the status of a job on this replica.
+type Status struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Interval    string `json:"interval"`
+	// Scheduled is false when the job doesn't run on this replica, e.g. it is not the leader
+	Scheduled bool `json:"scheduled"`
+	Paused    bool `json:"paused"`
+	Running   bool `json:"running"`
+	Runs      int  `json:"runs"`
+	// LastRun is the start time of the last run, in milliseconds since epoch
+	LastRun *int64 `json:"lastRun,omitempty"`
+	// LastDuration is the duration of the last run, in milliseconds
+	LastDuration *int64  `json:"lastDuration,omitempty"`
+	LastError    *string `json:"lastError,omitempty"`
+	// NextRun is when the job runs next unless triggered, in milliseconds since epoch
+	NextRun *int64 `json:"nextRun,omitempty"`
+}
+
+// Scheduler runs the registered jobs. Pausing a job only applies to this replica.
+type Scheduler struct {
+	mu   sync.Mutex
+	jobs map[string]*entry
+}
+
+type entry struct {
+	job     Job
+	status  Status
+	trigger chan struct{}
+	// resume is closed when a paused job is resumed
+	resume chan struct{}
+	// next is when the next scheduled run is due
+	next time.Time
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{jobs: map[string]*entry{}}
+}
+
+// Register adds a job, which runs once Run is called for it.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil || job.Interval <= 0 {
+		return fmt.Errorf("invalid job %q: a name, an interval and a run function are required", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("job %s already registered", job.Name)
+	}
+	s.jobs[job.Name] = &entry{
+		job:     job,
+		status:  Status{Name: job.Name, Description: job.Description, Interval: job.Interval.String()},
+		trigger: make(chan struct{}, 1),
+	}
+	return nil
+}
+
+// Run runs the job right away and then every interval, or when triggered, until ctx is canceled. It can be used as
+// the lead function of a leader election.
+func (s *Scheduler) Run(ctx context.Context, name string) {
+	s.mu.Lock()
+	e, ok := s.jobs[name]
+	if !ok {
+		s.mu.Unlock()
+		glog.Errorf("Background job %s not registered", name)
+		return
+	}
+	e.status.Scheduled = true
+	e.next = time.Now()
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		e.status.Scheduled = false
+		e.status.NextRun = nil
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		resume := e.resume
+		s.mu.Unlock()
+
+		if resume != nil {
+			// paused, only run when triggered or resumed
+			select {
+			case <-ctx.Done():
+				return
+			case <-e.trigger:
+			case <-resume:
+				continue
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return
+			case <-e.trigger:
+			case <-timer.C:
+			}
+		}
+
+		s.run(ctx, e)
+		timer.Reset(e.job.Interval)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e *entry) {
+	start := time.Now()
+
+	s.mu.Lock()
+	e.status.Running = true
+	e.status.NextRun = nil
+	s.mu.Unlock()
+
+	err := e.job.Run(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e.status.Running = false
+	e.status.Runs++
+	e.status.LastRun = millis(start)
+	e.status.LastDuration = apiutils.Of(time.Since(start).Milliseconds())
+	e.status.LastError = nil
+	e.next = time.Now().Add(e.job.Interval)
+	if e.resume == nil {
+		e.status.NextRun = millis(e.next)
+	}
+	if err != nil {
+		glog.Errorf("Background job %s failed: %v", e.job.Name, err)
+		e.status.LastError = apiutils.Of(err.Error())
+	}
+}
+
+// List returns the status of the jobs ordered by name.
+func (s *Scheduler) List() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]Status, 0, len(s.jobs))
+	for _, e := range s.jobs {
+		statuses = append(statuses, e.status)
+	}
+	slices.SortFunc(statuses, func(a, b Status) int { return strings.Compare(a.Name, b.Name) })
+	return statuses
+}
+
+// Get returns the status of a job.
+func (s *Scheduler) Get(name string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return e.status, nil
+}
+
+// Pause stops the scheduled runs of a job, the run in progress if any completes.
+func (s *Scheduler) Pause(name string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if e.resume == nil {
+		e.resume = make(chan struct{})
+	}
+	e.status.Paused = true
+	e.status.NextRun = nil
+	return e.status, nil
+}
+
+// Resume restarts the scheduled runs of a paused job, it runs right away if a run was due while it was paused.
+func (s *Scheduler) Resume(name string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if e.resume != nil {
+		close(e.resume)
+		e.resume = nil
+	}
+	e.status.Paused = false
+	if e.status.Scheduled && !e.status.Running {
+		e.status.NextRun = millis(e.next)
+	}
+	return e.status, nil
+}
+
+// Trigger runs a job now, paused or not, unless it is not scheduled on this replica.
+func (s *Scheduler) Trigger(name string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.jobs[name]
+	if !ok {
+		return Status{}, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if !e.status.Scheduled {
+		return e.status, fmt.Errorf("%w: %s", ErrJobNotRunning, name)
+	}
+	select {
+	case e.trigger <- struct{}{}:
+	default:
+		// already triggered
+	}
+	return e.status, nil
+}
+
+func millis(t time.Time) *int64 {
+	return apiutils.Of(t.UnixMilli())
+}
@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	var runs atomic.Int32
+	scheduler := NewScheduler()
+	require.NoError(t, scheduler.Register(Job{
+		Name:     "gc",
+		Interval: time.Hour,
+		Run: func(ctx context.Context) error {
+			if runs.Add(1) == 2 {
+				return errors.New("boom")
+			}
+			return nil
+		},
+	}))
+	assert.Error(t, scheduler.Register(Job{Name: "gc", Interval: time.Hour, Run: func(context.Context) error { return nil }}))
+	assert.Error(t, scheduler.Register(Job{Name: "invalid"}))
+
+	_, err := scheduler.Trigger("gc")
+	assert.ErrorIs(t, err, ErrJobNotRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx, "gc")
+	}()
+
+	// runs right away, then waits for the interval
+	waitForRuns(t, scheduler, 1)
+	status, err := scheduler.Get("gc")
+	require.NoError(t, err)
+	assert.True(t, status.Scheduled)
+	assert.Nil(t, status.LastError)
+	require.NotNil(t, status.NextRun)
+	assert.Greater(t, *status.NextRun, time.Now().Add(59*time.Minute).UnixMilli())
+
+	_, err = scheduler.Trigger("gc")
+	require.NoError(t, err)
+	waitForRuns(t, scheduler, 2)
+	status, _ = scheduler.Get("gc")
+	require.NotNil(t, status.LastError)
+	assert.Equal(t, "boom", *status.LastError)
+
+	status, err = scheduler.Pause("gc")
+	require.NoError(t, err)
+	assert.True(t, status.Paused)
+	assert.Nil(t, status.NextRun)
+
+	// paused jobs still run when triggered
+	_, err = scheduler.Trigger("gc")
+	require.NoError(t, err)
+	waitForRuns(t, scheduler, 3)
+
+	status, err = scheduler.Resume("gc")
+	require.NoError(t, err)
+	assert.False(t, status.Paused)
+	assert.NotNil(t, status.NextRun)
+
+	cancel()
+	<-done
+	status, _ = scheduler.Get("gc")
+	assert.False(t, status.Scheduled)
+
+	_, err = scheduler.Pause("unknown")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestHandler(t *testing.T) {
+	scheduler := NewScheduler()
+	require.NoError(t, scheduler.Register(Job{Name: "b", Interval: time.Minute, Run: func(context.Context) error { return nil }}))
+	require.NoError(t, scheduler.Register(Job{Name: "a", Interval: time.Minute, Run: func(context.Context) error { return nil }}))
+	server := httptest.NewServer(NewHandler(scheduler))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + BasePath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var list StatusList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Equal(t, 2, list.Size)
+	assert.Equal(t, "a", list.Items[0].Name)
+	assert.Equal(t, "1m0s", list.Items[0].Interval)
+
+	for path, code := range map[string]int{
+		"/a/pause":     http.StatusAccepted,
+		"/a/resume":    http.StatusAccepted,
+		"/a/trigger":   http.StatusConflict,
+		"/a/restart":   http.StatusNotFound,
+		"/none/pause":  http.StatusNotFound,
+		"/none/resume": http.StatusNotFound,
+	} {
+		resp, err := http.Post(server.URL+BasePath+path, "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, code, resp.StatusCode, path)
+	}
+
+	resp, err = http.Get(server.URL + BasePath + "/none")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func waitForRuns(t *testing.T, scheduler *Scheduler, runs int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		status, err := scheduler.Get("gc")
+		return err == nil && status.Runs >= runs && !status.Running
+	}, 5*time.Second, time.Millisecond)
+}
@@ -0,0 +1,207 @@
+// Package lastupdate backfills the last update time of the contexts whose relationships changed after their last
+// save, before those changes updated the contexts: the artifacts attributed to them, their child contexts and the
+// executions associated with them. The contexts are updated to the creation time of their newest relationship, so
+// that the syncs reading the contexts updated since a time see the historical changes as they see the new ones. Their
+// version is kept, as for the new changes, so that the backfill doesn't fail the updates of the clients holding their
+// ETag.
+//
+// The contexts are reindexed in batches with a pause between them, so that a backfill of a large registry doesn't
+// compete with the api for the database. The tags of the contexts record no time and are not backfilled.
+package lastupdate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"gorm.io/gorm"
+)
+
+const (
+	// JobName is the name of the background job reindexing the last update times.
+	JobName = "last-update-reindex"
+	// DefaultInterval is how often the last update times are reindexed.
+	DefaultInterval = 24 * time.Hour
+	// DefaultBatchSize is the number of contexts reindexed at once.
+	DefaultBatchSize = 500
+	// DefaultPause is the pause between two batches.
+	DefaultPause = 100 * time.Millisecond
+)
+
+// Config enables the reindex of the last update times.
+type Config struct {
+	// Enabled runs the reindex as a background job of the leader replica.
+	Enabled bool
+	// Interval is how often the last update times are reindexed, defaults to DefaultInterval.
+	Interval time.Duration
+	// BatchSize is the number of contexts reindexed at once, defaults to DefaultBatchSize.
+	BatchSize int
+	// Pause is the pause between two batches, throttling the reindex, none when zero.
+	Pause time.Duration
+}
+
+// Reindexer updates the last update time of the contexts to the time of their newest relationship.
+type Reindexer struct {
+	db     *gorm.DB
+	config Config
+}
+
+// NewReindexer returns a reindexer of the contexts in db.
+func NewReindexer(db *gorm.DB, config Config) (*Reindexer, error) {
+	if config.BatchSize < 0 {
+		return nil, fmt.Errorf("invalid last update reindex batch size %d: must be positive", config.BatchSize)
+	}
+	if config.Pause < 0 {
+		return nil, fmt.Errorf("invalid last update reindex pause %s: must not be negative", config.Pause)
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	return &Reindexer{db: db, config: config}, nil
+}
+
+// Job returns the background job reindexing the last update times every interval.
+func (r *Reindexer) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Updates the last update time of the contexts to the time of their newest artifact, child context or execution",
+		Interval:    r.config.Interval,
+		Run: func(ctx context.Context) error {
+			updated, err := r.Reindex(ctx)
+			if updated > 0 {
+				glog.Infof("Reindexed the last update time of %d contexts", updated)
+			}
+			return err
+		},
+	}
+}
+
+// Reindex updates the contexts last updated before their newest relationship was created, returning how many were
+// updated.
+func (r *Reindexer) Reindex(ctx context.Context) (int, error) {
+	db := r.db.WithContext(ctx)
+
+	updated := 0
+	var after int32
+	for {
+		var contexts []schema.Context
+		if err := db.Select("id", "last_update_time_since_epoch").
+			Where("id > ?", after).
+			Order("id").
+			Limit(r.config.BatchSize).
+			Find(&contexts).Error; err != nil {
+			return updated, fmt.Errorf("error reading contexts: %w", err)
+		}
+		if len(contexts) == 0 {
+			return updated, nil
+		}
+		after = contexts[len(contexts)-1].ID
+
+		n, err := r.reindexBatch(db, contexts)
+		updated += n
+		if n > 0 {
+			cache.Invalidate(db, cache.KindRegisteredModels, cache.KindModelVersions)
+		}
+		if err != nil {
+			return updated, err
+		}
+		if len(contexts) < r.config.BatchSize {
+			return updated, nil
+		}
+
+		if r.config.Pause > 0 {
+			timer := time.NewTimer(r.config.Pause)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return updated, ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// reindexBatch updates the contexts of a batch last updated before their newest relationship.
+func (r *Reindexer) reindexBatch(db *gorm.DB, contexts []schema.Context) (int, error) {
+	ids := make([]int32, 0, len(contexts))
+	for _, c := range contexts {
+		ids = append(ids, c.ID)
+	}
+
+	latest, err := r.latestRelationships(db, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, c := range contexts {
+		newest, ok := latest[c.ID]
+		if !ok || newest <= c.LastUpdateTimeSinceEpoch {
+			continue
+		}
+		// the context may have been updated since it was read
+		result := db.Model(&schema.Context{}).
+			Where("id = ? AND last_update_time_since_epoch < ?", c.ID, newest).
+			UpdateColumn("last_update_time_since_epoch", newest)
+		if result.Error != nil {
+			return updated, fmt.Errorf("error updating the last update time of context %d: %w", c.ID, result.Error)
+		}
+		updated += int(result.RowsAffected)
+	}
+	return updated, nil
+}
+
+// latestRelationships returns the creation time of the newest artifact, child context or execution of the contexts
+// with ids, by context id.
+func (r *Reindexer) latestRelationships(db *gorm.DB, ids []int32) (map[int32]int64, error) {
+	attributionTable := utils.GetTableName(db, &schema.Attribution{})
+	artifactTable := utils.GetTableName(db, &schema.Artifact{})
+	parentTable := utils.GetTableName(db, &schema.ParentContext{})
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	associationTable := utils.GetTableName(db, &schema.Association{})
+	executionTable := utils.GetTableName(db, &schema.Execution{})
+
+	relationships := []struct {
+		name  string
+		query *gorm.DB
+	}{
+		{"artifacts", db.Table(attributionTable+" r").
+			Select("r.context_id AS context_id, MAX(c.create_time_since_epoch) AS latest").
+			Joins("JOIN "+artifactTable+" c ON c.id = r.artifact_id").
+			Where("r.context_id IN ?", ids).
+			Group("r.context_id")},
+		{"child contexts", db.Table(parentTable+" r").
+			Select("r.parent_context_id AS context_id, MAX(c.create_time_since_epoch) AS latest").
+			Joins("JOIN "+contextTable+" c ON c.id = r.context_id").
+			Where("r.parent_context_id IN ?", ids).
+			Group("r.parent_context_id")},
+		{"executions", db.Table(associationTable+" r").
+			Select("r.context_id AS context_id, MAX(c.create_time_since_epoch) AS latest").
+			Joins("JOIN "+executionTable+" c ON c.id = r.execution_id").
+			Where("r.context_id IN ?", ids).
+			Group("r.context_id")},
+	}
+
+	latest := map[int32]int64{}
+	for _, relationship := range relationships {
+		var rows []struct {
+			ContextID int32
+			Latest    int64
+		}
+		if err := relationship.query.Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("error reading the %s of contexts: %w", relationship.name, err)
+		}
+		for _, row := range rows {
+			latest[row.ContextID] = max(latest[row.ContextID], row.Latest)
+		}
+	}
+	return latest, nil
+}
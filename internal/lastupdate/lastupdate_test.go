@@ -0,0 +1,103 @@
+package lastupdate
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockReindexer(t *testing.T, config Config) (*Reindexer, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	reindexer, err := NewReindexer(db, config)
+	require.NoError(t, err)
+	return reindexer, mock
+}
+
+func TestNewReindexer(t *testing.T) {
+	reindexer, _ := newMockReindexer(t, Config{Enabled: true})
+	assert.Equal(t, DefaultInterval, reindexer.Job().Interval)
+	assert.Equal(t, DefaultBatchSize, reindexer.config.BatchSize)
+
+	_, err := NewReindexer(reindexer.db, Config{BatchSize: -1})
+	assert.ErrorContains(t, err, "must be positive")
+
+	_, err = NewReindexer(reindexer.db, Config{Pause: -time.Second})
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestReindex(t *testing.T) {
+	reindexer, mock := newMockReindexer(t, Config{BatchSize: 2, Pause: time.Millisecond})
+
+	expectRelationships := func(artifacts, children, executions *sqlmock.Rows) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.context_id AS context_id, MAX(c.create_time_since_epoch) AS latest FROM "Attribution" r JOIN "Artifact" c ON c.id = r.artifact_id WHERE r.context_id IN`)).
+			WillReturnRows(artifacts)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.parent_context_id AS context_id, MAX(c.create_time_since_epoch) AS latest FROM "ParentContext" r JOIN "Context" c ON c.id = r.context_id WHERE r.parent_context_id IN`)).
+			WillReturnRows(children)
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT r.context_id AS context_id, MAX(c.create_time_since_epoch) AS latest FROM "Association" r JOIN "Execution" c ON c.id = r.execution_id WHERE r.context_id IN`)).
+			WillReturnRows(executions)
+	}
+	expectUpdate := func(id int32, latest int64) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE "Context" SET "last_update_time_since_epoch"=$1 WHERE id = $2 AND last_update_time_since_epoch < $3`)).
+			WithArgs(latest, id, latest).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	relationshipRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"context_id", "latest"})
+	}
+
+	// context 1 got an artifact and a child context after its last update, context 2 is up to date
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","last_update_time_since_epoch" FROM "Context" WHERE id > $1 ORDER BY id`)).
+		WithArgs(int32(0), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "last_update_time_since_epoch"}).AddRow(1, 100).AddRow(2, 500))
+	expectRelationships(
+		relationshipRows().AddRow(1, 300),
+		relationshipRows().AddRow(1, 200).AddRow(2, 400),
+		relationshipRows(),
+	)
+	expectUpdate(1, 300)
+
+	// context 3 got an execution after its last update, and is the last one
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","last_update_time_since_epoch" FROM "Context" WHERE id > $1 ORDER BY id`)).
+		WithArgs(int32(2), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "last_update_time_since_epoch"}).AddRow(3, 100))
+	expectRelationships(relationshipRows(), relationshipRows(), relationshipRows().AddRow(3, 150))
+	expectUpdate(3, 150)
+
+	updated, err := reindexer.Reindex(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReindexCanceled(t *testing.T) {
+	reindexer, mock := newMockReindexer(t, Config{BatchSize: 1, Pause: time.Hour})
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","last_update_time_since_epoch" FROM "Context" WHERE id > $1 ORDER BY id`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "last_update_time_since_epoch"}).AddRow(1, 100))
+	for range 3 {
+		mock.ExpectQuery(`SELECT r\.`).WillReturnRows(sqlmock.NewRows([]string{"context_id", "latest"}))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	updated, err := reindexer.Reindex(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, updated)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,152 @@
+package leaderelection
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/types"
+	"gorm.io/gorm"
+)
+
+// databaseElector uses a session level advisory lock to elect the leader. The lock is held by a dedicated
+// connection, and released by the database server if that connection is lost.
+type databaseElector struct {
+	db          *sql.DB
+	name        string
+	lockQuery   string
+	unlockQuery string
+	lockArg     any
+	retryPeriod time.Duration
+}
+
+// NewDatabaseElector returns an Elector using a MySQL named lock or a PostgreSQL advisory lock identified by name.
+func NewDatabaseElector(db *gorm.DB, name string) (Elector, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("error getting database connection pool: %w", err)
+	}
+
+	e := &databaseElector{
+		db:          sqlDB,
+		name:        name,
+		retryPeriod: DefaultRetryPeriod,
+	}
+
+	switch db.Name() {
+	case types.DatabaseTypeMySQL:
+		// MySQL lock names are limited to 64 characters
+		if len(name) > 64 {
+			return nil, fmt.Errorf("invalid leader election name %q: must be at most 64 characters", name)
+		}
+		e.lockQuery = "SELECT GET_LOCK(?, 0)"
+		e.unlockQuery = "SELECT RELEASE_LOCK(?)"
+		e.lockArg = name
+	case types.DatabaseTypePostgres:
+		h := fnv.New64a()
+		h.Write([]byte(name))
+		e.lockQuery = "SELECT CASE WHEN pg_try_advisory_lock($1) THEN 1 ELSE 0 END"
+		e.unlockQuery = "SELECT pg_advisory_unlock($1)"
+		e.lockArg = int64(h.Sum64())
+	default:
+		return nil, fmt.Errorf("unsupported database type for leader election: %s", db.Name())
+	}
+
+	return e, nil
+}
+
+func (e *databaseElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	for {
+		conn := e.acquire(ctx)
+		if conn == nil {
+			return
+		}
+
+		glog.Infof("Acquired leadership for %s", e.name)
+
+		leadCtx, stop := runLeading(ctx, lead)
+		e.hold(leadCtx, conn)
+		stop()
+		e.release(conn)
+
+		glog.Infof("Released leadership for %s", e.name)
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// acquire retries getting the lock until it succeeds or ctx is canceled, in which case it returns nil.
+func (e *databaseElector) acquire(ctx context.Context) *sql.Conn {
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+
+	for {
+		conn, err := e.tryLock(ctx)
+		if err != nil && ctx.Err() == nil {
+			glog.Warningf("Unable to acquire leadership lock for %s: %v", e.name, err)
+		}
+		if conn != nil {
+			return conn
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *databaseElector) tryLock(ctx context.Context) (*sql.Conn, error) {
+	conn, err := e.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, e.lockQuery, e.lockArg).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if acquired.Int64 != 1 {
+		conn.Close()
+		return nil, nil
+	}
+
+	return conn, nil
+}
+
+// hold checks the lock connection is still alive until ctx is canceled or the connection fails.
+func (e *databaseElector) hold(ctx context.Context, conn *sql.Conn) {
+	ticker := time.NewTicker(e.retryPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := conn.PingContext(ctx); err != nil && ctx.Err() == nil {
+				glog.Errorf("Lost leadership lock connection for %s: %v", e.name, err)
+				return
+			}
+		}
+	}
+}
+
+func (e *databaseElector) release(conn *sql.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultRenewDeadline)
+	defer cancel()
+
+	if _, err := conn.ExecContext(ctx, e.unlockQuery, e.lockArg); err != nil {
+		glog.Warningf("Unable to release leadership lock for %s: %v", e.name, err)
+	}
+}
@@ -0,0 +1,149 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	getLock     = "SELECT GET_LOCK(?, 0)"
+	releaseLock = "SELECT RELEASE_LOCK(?)"
+)
+
+func newMockDatabaseElector(t *testing.T, dialect string, name string) (*databaseElector, sqlmock.Sqlmock, error) {
+	conn, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	dialector := postgres.New(postgres.Config{Conn: conn})
+	if dialect == "mysql" {
+		dialector = mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent), DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	elector, err := NewDatabaseElector(db, name)
+	if err != nil {
+		return nil, mock, err
+	}
+	elector.(*databaseElector).retryPeriod = 20 * time.Millisecond
+	return elector.(*databaseElector), mock, nil
+}
+
+// lockResult returns the rows of a lock query, 1 when the lock is acquired.
+func lockResult(acquired int) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"acquired"}).AddRow(acquired)
+}
+
+// runElector runs e until ctx is canceled, it returns a channel closed when Run returns.
+func runElector(ctx context.Context, e Elector, lead func(ctx context.Context)) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.Run(ctx, lead)
+	}()
+	return done
+}
+
+func TestNewDatabaseElector(t *testing.T) {
+	mysqlElector, _, err := newMockDatabaseElector(t, "mysql", "jobs")
+	require.NoError(t, err)
+	assert.Equal(t, getLock, mysqlElector.lockQuery)
+	assert.Equal(t, "jobs", mysqlElector.lockArg)
+
+	postgresElector, _, err := newMockDatabaseElector(t, "postgres", "jobs")
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT pg_advisory_unlock($1)", postgresElector.unlockQuery)
+	assert.IsType(t, int64(0), postgresElector.lockArg)
+
+	_, _, err = newMockDatabaseElector(t, "mysql", strings.Repeat("x", 65))
+	assert.ErrorContains(t, err, "must be at most 64 characters")
+}
+
+func TestDatabaseElectorAcquireAndRelease(t *testing.T) {
+	e, mock, err := newMockDatabaseElector(t, "mysql", "jobs")
+	require.NoError(t, err)
+
+	// another replica holds the lock at first
+	mock.ExpectQuery(regexp.QuoteMeta(getLock)).WithArgs("jobs").WillReturnRows(lockResult(0))
+	mock.ExpectQuery(regexp.QuoteMeta(getLock)).WithArgs("jobs").WillReturnRows(lockResult(1))
+	mock.ExpectExec(regexp.QuoteMeta(releaseLock)).WithArgs("jobs").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan context.Context, 1)
+	done := runElector(ctx, e, func(leadCtx context.Context) {
+		leading <- leadCtx
+		<-leadCtx.Done()
+	})
+
+	var leadCtx context.Context
+	select {
+	case leadCtx = <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired")
+	}
+
+	// shutting down releases the lock
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector not stopped")
+	}
+	assert.Error(t, leadCtx.Err())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseElectorLoseLeadership(t *testing.T) {
+	e, mock, err := newMockDatabaseElector(t, "mysql", "jobs")
+	require.NoError(t, err)
+
+	// the lock connection is lost, and the lock acquired again
+	mock.ExpectQuery(regexp.QuoteMeta(getLock)).WithArgs("jobs").WillReturnRows(lockResult(1))
+	mock.ExpectPing().WillReturnError(errors.New("connection lost"))
+	mock.ExpectExec(regexp.QuoteMeta(releaseLock)).WithArgs("jobs").WillReturnError(errors.New("connection lost"))
+	mock.ExpectQuery(regexp.QuoteMeta(getLock)).WithArgs("jobs").WillReturnRows(lockResult(1))
+	mock.ExpectExec(regexp.QuoteMeta(releaseLock)).WithArgs("jobs").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan context.Context, 2)
+	done := runElector(ctx, e, func(leadCtx context.Context) {
+		leading <- leadCtx
+		<-leadCtx.Done()
+	})
+
+	var first context.Context
+	select {
+	case first = <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired")
+	}
+	select {
+	case <-first.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not lost")
+	}
+
+	select {
+	case <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired again")
+	}
+	cancel()
+	<-done
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,52 @@
+// Package leaderelection picks a single replica to run singleton background jobs, such as the catalog sync,
+// so that the servers can be scaled horizontally.
+package leaderelection
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultLeaseDuration is how long a lease is valid before other replicas can take over.
+	DefaultLeaseDuration = 15 * time.Second
+	// DefaultRenewDeadline is how long the leader retries renewing its lease before giving up leadership.
+	DefaultRenewDeadline = 10 * time.Second
+	// DefaultRetryPeriod is how often replicas try to acquire or check their leadership.
+	DefaultRetryPeriod = 2 * time.Second
+)
+
+// Elector decides which replica runs the singleton background jobs.
+type Elector interface {
+	// Run blocks until ctx is canceled. It calls lead every time leadership is acquired, with a context
+	// canceled when leadership is lost, and waits for lead to return before campaigning again.
+	Run(ctx context.Context, lead func(ctx context.Context))
+}
+
+// AlwaysLeader returns an Elector for single replica deployments, where the current replica always leads.
+func AlwaysLeader() Elector {
+	return alwaysLeader{}
+}
+
+type alwaysLeader struct{}
+
+func (alwaysLeader) Run(ctx context.Context, lead func(ctx context.Context)) {
+	lead(ctx)
+	<-ctx.Done()
+}
+
+// runLeading calls lead in a goroutine and returns a function canceling its context and waiting for it to return.
+func runLeading(ctx context.Context, lead func(ctx context.Context)) (leadCtx context.Context, stop func()) {
+	leadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		lead(leadCtx)
+	}()
+
+	return leadCtx, func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,109 @@
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// leaseElector uses a Kubernetes coordination.k8s.io Lease to elect the leader.
+type leaseElector struct {
+	config leaderelection.LeaderElectionConfig
+}
+
+// NewLeaseElector returns an Elector using the Lease name in namespace, from the in-cluster configuration.
+// If namespace is empty the namespace of the pod service account is used.
+func NewLeaseElector(name, namespace string) (Elector, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error getting in-cluster configuration: %w", err)
+	}
+
+	if namespace == "" {
+		ns, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading pod namespace, set it explicitly: %w", err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+
+	identity, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("error getting leader election identity: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes client: %w", err)
+	}
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		namespace,
+		name,
+		clientset.CoreV1(),
+		clientset.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating lease lock: %w", err)
+	}
+
+	return newLeaseElector(lock, name), nil
+}
+
+// newLeaseElector returns the leaseElector of lock, with the default durations.
+func newLeaseElector(lock resourcelock.Interface, name string) *leaseElector {
+	return &leaseElector{
+		config: leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   DefaultLeaseDuration,
+			RenewDeadline:   DefaultRenewDeadline,
+			RetryPeriod:     DefaultRetryPeriod,
+			ReleaseOnCancel: true,
+			Name:            name,
+		},
+	}
+}
+
+func (e *leaseElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	for ctx.Err() == nil {
+		var (
+			mu   sync.Mutex
+			stop func()
+		)
+
+		config := e.config
+		config.Callbacks = leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leadCtx context.Context) {
+				glog.Infof("Acquired leadership for %s", config.Name)
+
+				mu.Lock()
+				defer mu.Unlock()
+				_, stop = runLeading(leadCtx, lead)
+			},
+			OnStoppedLeading: func() {
+				glog.Infof("Released leadership for %s", config.Name)
+			},
+		}
+
+		// RunOrDie returns once leadership is lost or ctx is canceled
+		leaderelection.RunOrDie(ctx, config)
+
+		mu.Lock()
+		if stop != nil {
+			stop()
+		}
+		mu.Unlock()
+	}
+}
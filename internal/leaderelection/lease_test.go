@@ -0,0 +1,128 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+const (
+	leaseName      = "model-registry-jobs"
+	leaseNamespace = "kubeflow"
+)
+
+func newFakeLeaseElector(t *testing.T) (*leaseElector, *fake.Clientset) {
+	clientset := fake.NewClientset()
+	e := newLeaseElector(&resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: leaseName, Namespace: leaseNamespace},
+		Client:     clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: "replica-1"},
+	}, leaseName)
+	e.config.LeaseDuration = time.Second
+	e.config.RenewDeadline = 500 * time.Millisecond
+	e.config.RetryPeriod = 100 * time.Millisecond
+	return e, clientset
+}
+
+func getLease(t *testing.T, clientset *fake.Clientset) *coordinationv1.Lease {
+	lease, err := clientset.CoordinationV1().Leases(leaseNamespace).Get(context.Background(), leaseName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return lease
+}
+
+func TestLeaseElectorAcquireAndRelease(t *testing.T) {
+	e, clientset := newFakeLeaseElector(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan context.Context, 1)
+	done := runElector(ctx, e, func(leadCtx context.Context) {
+		leading <- leadCtx
+		<-leadCtx.Done()
+	})
+
+	var leadCtx context.Context
+	select {
+	case leadCtx = <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired")
+	}
+	assert.Equal(t, "replica-1", apiutils.ZeroIfNil(getLease(t, clientset).Spec.HolderIdentity))
+
+	// shutting down releases the lease, so that another replica takes over without waiting for it to expire
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector not stopped")
+	}
+	assert.Error(t, leadCtx.Err())
+	assert.Empty(t, apiutils.ZeroIfNil(getLease(t, clientset).Spec.HolderIdentity))
+}
+
+func TestLeaseElectorLoseLeadership(t *testing.T) {
+	e, clientset := newFakeLeaseElector(t)
+
+	// the updates of the lease by the leader conflict once another replica took it over
+	var takenOver atomic.Bool
+	clientset.PrependReactor("update", "leases", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		lease := action.(k8stesting.UpdateAction).GetObject().(*coordinationv1.Lease)
+		if takenOver.Load() && apiutils.ZeroIfNil(lease.Spec.HolderIdentity) == "replica-1" {
+			return true, nil, errors.New("the lease was modified")
+		}
+		return false, nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	leading := make(chan context.Context, 2)
+	done := runElector(ctx, e, func(leadCtx context.Context) {
+		leading <- leadCtx
+		<-leadCtx.Done()
+	})
+
+	var leadCtx context.Context
+	select {
+	case leadCtx = <-leading:
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not acquired")
+	}
+
+	// another replica takes the lease over, the leader fails to renew it
+	takenOver.Store(true)
+	lease := getLease(t, clientset)
+	lease.Spec.HolderIdentity = apiutils.Of("replica-2")
+	lease.Spec.LeaseDurationSeconds = apiutils.Of(int32(60))
+	lease.Spec.RenewTime = &metav1.MicroTime{Time: time.Now()}
+	_, err := clientset.CoordinationV1().Leases(leaseNamespace).Update(context.Background(), lease, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case <-leadCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("leadership not lost")
+	}
+
+	// the elector campaigns again without taking the lease of the other replica
+	time.Sleep(3 * e.config.RetryPeriod)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("elector not stopped")
+	}
+	assert.Empty(t, leading)
+	assert.Equal(t, "replica-2", apiutils.ZeroIfNil(getLease(t, clientset).Spec.HolderIdentity))
+}
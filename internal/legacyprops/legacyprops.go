@@ -0,0 +1,355 @@
+// Package legacyprops migrates the custom properties that clients used before the registry had first class fields
+// for them, e.g. an "owner" custom property of registered models, to these fields.
+//
+// The well-known properties are converted only when they are string values and the field is not set to another
+// value, otherwise they are reported as conflicts and left unchanged. Converted properties are removed:
+//
+//   - owner, description, license, provider and readme set the fields of the same name of registered models,
+//     language and tasks set their lists from comma separated values;
+//   - author, or owner, and description set the fields of model versions, owner and description those of experiments;
+//   - tags, comma separated, are converted to labels, i.e. custom properties with an empty string value;
+//   - stage sets the state to ARCHIVED for the archived, deprecated and retired stages, and to LIVE for live and
+//     active, other stages have no field and are skipped.
+package legacyprops
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// Mode configures the migration run on startup.
+type Mode string
+
+const (
+	ModeOff Mode = "off"
+	// ModeDryRun reports the conversions without saving them
+	ModeDryRun Mode = "dry-run"
+	ModeApply  Mode = "apply"
+)
+
+// Validate checks the mode is supported.
+func (m Mode) Validate() error {
+	switch m {
+	case ModeOff, ModeDryRun, ModeApply:
+		return nil
+	}
+	return fmt.Errorf("unsupported legacy properties migration mode: %s. Supported modes: %s, %s, %s", m, ModeOff, ModeDryRun, ModeApply)
+}
+
+// Outcome is the result of the conversion of a property.
+type Outcome string
+
+const (
+	Converted Outcome = "converted"
+	Conflict  Outcome = "conflict"
+	Skipped   Outcome = "skipped"
+)
+
+// Change is the conversion of a legacy custom property of an entity.
+type Change struct {
+	EntityType string
+	ID         string
+	Name       string
+	Property   string
+	Field      string
+	Value      string
+	Outcome    Outcome
+	// Reason of conflicts and skipped properties
+	Reason string
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s (%s): %s %q", c.EntityType, c.ID, c.Name, c.Property, c.Value)
+	if c.Outcome == Converted {
+		return fmt.Sprintf("%s converted to %s", s, c.Field)
+	}
+	return fmt.Sprintf("%s %s: %s", s, c.Outcome, c.Reason)
+}
+
+// Report lists the changes of a migration.
+type Report struct {
+	DryRun  bool
+	Changes []Change
+	// Updated is the number of entities with converted properties, saved unless DryRun
+	Updated int
+}
+
+func (r *Report) String() string {
+	counts := map[Outcome]int{}
+	for _, change := range r.Changes {
+		counts[change.Outcome]++
+	}
+	verb := "updated"
+	if r.DryRun {
+		verb = "to update"
+	}
+	return fmt.Sprintf("%d entities %s, %d properties converted, %d conflicts, %d skipped", r.Updated, verb, counts[Converted], counts[Conflict], counts[Skipped])
+}
+
+// Registry is the part of the model registry api the migration reads and updates entities with.
+type Registry interface {
+	GetRegisteredModels(listOptions api.ListOptions) (*openapi.RegisteredModelList, error)
+	UpsertRegisteredModel(registeredModel *openapi.RegisteredModel) (*openapi.RegisteredModel, error)
+	GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error)
+	UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error)
+	GetExperiments(listOptions api.ListOptions) (*openapi.ExperimentList, error)
+	UpsertExperiment(experiment *openapi.Experiment) (*openapi.Experiment, error)
+}
+
+// pageSize is the number of entities read at once
+const pageSize = 100
+
+// Migrate converts the legacy custom properties of all the registered models, model versions and experiments,
+// saving the entities unless dryRun.
+func Migrate(registry Registry, dryRun bool) (*Report, error) {
+	report := &Report{DryRun: dryRun}
+
+	err := forEachPage(func(listOptions api.ListOptions) ([]openapi.RegisteredModel, string, error) {
+		list, err := registry.GetRegisteredModels(listOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, list.NextPageToken, nil
+	}, func(model openapi.RegisteredModel) error {
+		m := migration{entityType: "registered model", id: *model.Id, name: model.Name, customProperties: &model.CustomProperties}
+		m.stringField("owner", "owner", &model.Owner)
+		m.stringField("description", "description", &model.Description)
+		m.stringField("license", "license", &model.License)
+		m.stringField("provider", "provider", &model.Provider)
+		m.stringField("readme", "readme", &model.Readme)
+		m.listField("language", "language", &model.Language)
+		m.listField("tasks", "tasks", &model.Tasks)
+		m.labels()
+		m.state(func(archived bool) bool {
+			return convertState(&model.State, archived, openapi.REGISTEREDMODELSTATE_ARCHIVED, openapi.REGISTEREDMODELSTATE_LIVE)
+		})
+		return m.save(report, func() error {
+			_, err := registry.UpsertRegisteredModel(&model)
+			return err
+		})
+	})
+	if err != nil {
+		return report, fmt.Errorf("error migrating registered models: %w", err)
+	}
+
+	err = forEachPage(func(listOptions api.ListOptions) ([]openapi.ModelVersion, string, error) {
+		list, err := registry.GetModelVersions(listOptions, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, list.NextPageToken, nil
+	}, func(version openapi.ModelVersion) error {
+		m := migration{entityType: "model version", id: *version.Id, name: version.Name, customProperties: &version.CustomProperties}
+		m.stringField("author", "author", &version.Author)
+		m.stringField("owner", "author", &version.Author)
+		m.stringField("description", "description", &version.Description)
+		m.labels()
+		m.state(func(archived bool) bool {
+			return convertState(&version.State, archived, openapi.MODELVERSIONSTATE_ARCHIVED, openapi.MODELVERSIONSTATE_LIVE)
+		})
+		return m.save(report, func() error {
+			_, err := registry.UpsertModelVersion(&version, &version.RegisteredModelId)
+			return err
+		})
+	})
+	if err != nil {
+		return report, fmt.Errorf("error migrating model versions: %w", err)
+	}
+
+	err = forEachPage(func(listOptions api.ListOptions) ([]openapi.Experiment, string, error) {
+		list, err := registry.GetExperiments(listOptions)
+		if err != nil {
+			return nil, "", err
+		}
+		return list.Items, list.NextPageToken, nil
+	}, func(experiment openapi.Experiment) error {
+		m := migration{entityType: "experiment", id: *experiment.Id, name: experiment.Name, customProperties: &experiment.CustomProperties}
+		m.stringField("owner", "owner", &experiment.Owner)
+		m.stringField("description", "description", &experiment.Description)
+		m.labels()
+		m.state(func(archived bool) bool {
+			return convertState(&experiment.State, archived, openapi.EXPERIMENTSTATE_ARCHIVED, openapi.EXPERIMENTSTATE_LIVE)
+		})
+		return m.save(report, func() error {
+			_, err := registry.UpsertExperiment(&experiment)
+			return err
+		})
+	})
+	if err != nil {
+		return report, fmt.Errorf("error migrating experiments: %w", err)
+	}
+
+	return report, nil
+}
+
+// forEachPage calls fn for each entity of all the pages listed by list.
+func forEachPage[T any](list func(api.ListOptions) ([]T, string, error), fn func(T) error) error {
+	listOptions := api.ListOptions{PageSize: apiutils.Of(int32(pageSize))}
+	for {
+		items, nextPageToken, err := list(listOptions)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if nextPageToken == "" {
+			return nil
+		}
+		listOptions.NextPageToken = &nextPageToken
+	}
+}
+
+// migration converts the legacy properties of an entity, changing the entity in place.
+type migration struct {
+	entityType       string
+	id               string
+	name             string
+	customProperties *map[string]openapi.MetadataValue
+	changes          []Change
+	converted        bool
+}
+
+// value returns the string value of a custom property, false if it is not set or not a string.
+func (m *migration) value(property string) (string, bool) {
+	value, ok := (*m.customProperties)[property]
+	if !ok || value.MetadataStringValue == nil {
+		return "", false
+	}
+	return value.MetadataStringValue.StringValue, true
+}
+
+func (m *migration) record(property string, field string, value string, outcome Outcome, reason string) {
+	m.changes = append(m.changes, Change{
+		EntityType: m.entityType,
+		ID:         m.id,
+		Name:       m.name,
+		Property:   property,
+		Field:      field,
+		Value:      value,
+		Outcome:    outcome,
+		Reason:     reason,
+	})
+	if outcome == Converted {
+		m.converted = true
+		delete(*m.customProperties, property)
+	}
+}
+
+func (m *migration) stringField(property string, fieldName string, field **string) {
+	value, ok := m.value(property)
+	if !ok {
+		return
+	}
+	if *field != nil && **field != "" && **field != value {
+		m.record(property, fieldName, value, Conflict, fmt.Sprintf("%s is already set to %q", fieldName, **field))
+		return
+	}
+	*field = &value
+	m.record(property, fieldName, value, Converted, "")
+}
+
+func (m *migration) listField(property string, fieldName string, field *[]string) {
+	value, ok := m.value(property)
+	if !ok {
+		return
+	}
+	values := splitList(value)
+	if len(*field) > 0 && !slices.Equal(*field, values) {
+		m.record(property, fieldName, value, Conflict, fmt.Sprintf("%s is already set to %v", fieldName, *field))
+		return
+	}
+	*field = values
+	m.record(property, fieldName, value, Converted, "")
+}
+
+func (m *migration) labels() {
+	value, ok := m.value("tags")
+	if !ok {
+		return
+	}
+	labels := splitList(value)
+	for _, label := range labels {
+		existing, ok := (*m.customProperties)[label]
+		if label == "tags" || ok && (existing.MetadataStringValue == nil || existing.MetadataStringValue.StringValue != "") {
+			m.record("tags", "labels", value, Conflict, fmt.Sprintf("custom property %s is not a label", label))
+			return
+		}
+	}
+	for _, label := range labels {
+		(*m.customProperties)[label] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("", "MetadataStringValue"))
+	}
+	m.record("tags", "labels", value, Converted, "")
+}
+
+// state converts the stage with convert, which sets the state of the entity unless it conflicts with the stage.
+func (m *migration) state(convert func(archived bool) (conflict bool)) {
+	value, ok := m.value("stage")
+	if !ok {
+		return
+	}
+
+	var archived bool
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "archived", "deprecated", "retired":
+		archived = true
+	case "live", "active":
+	default:
+		m.record("stage", "state", value, Skipped, "no state for this stage")
+		return
+	}
+
+	if convert(archived) {
+		m.record("stage", "state", value, Conflict, "the state is archived")
+		return
+	}
+	m.record("stage", "state", value, Converted, "")
+}
+
+// save records the changes in the report and saves the entity with save, if properties were converted and the
+// report is not a dry run.
+func (m *migration) save(report *Report, save func() error) error {
+	report.Changes = append(report.Changes, m.changes...)
+	if !m.converted {
+		return nil
+	}
+	report.Updated++
+	if report.DryRun {
+		return nil
+	}
+	if err := save(); err != nil {
+		return fmt.Errorf("error saving %s %s: %w", m.entityType, m.id, err)
+	}
+	return nil
+}
+
+// convertState sets the state to archived or live, returning true if a live stage conflicts with an archived state.
+func convertState[S comparable](state **S, archived bool, archivedState S, liveState S) bool {
+	if archived {
+		*state = &archivedState
+		return false
+	}
+	if *state != nil && **state == archivedState {
+		return true
+	}
+	*state = &liveState
+	return false
+}
+
+// splitList splits comma separated values, ignoring blank ones.
+func splitList(value string) []string {
+	values := []string{}
+	for part := range strings.SplitSeq(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,99 @@
+package legacyprops
+
+import (
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
+
+func TestMigrate(t *testing.T) {
+	service := inmemory.NewModelRegistryService(inmemory.NewStore())
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name:    "model",
+		License: apiutils.Of("mit"),
+		CustomProperties: map[string]openapi.MetadataValue{
+			"owner":    stringValue("alice"),
+			"license":  stringValue("apache-2.0"),
+			"tasks":    stringValue("classification, ,detection"),
+			"tags":     stringValue("vision,edge"),
+			"accuracy": stringValue("0.9"),
+		},
+	})
+	require.NoError(t, err)
+
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{
+		Name: "v1",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"owner": stringValue("bob"),
+			"stage": stringValue("Deprecated"),
+		},
+	}, model.Id)
+	require.NoError(t, err)
+
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{
+		Name: "experiment",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"stage": stringValue("production"),
+		},
+	})
+	require.NoError(t, err)
+
+	report, err := Migrate(service, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Updated)
+	assert.Equal(t, "2 entities to update, 5 properties converted, 1 conflicts, 1 skipped", report.String())
+
+	unchanged, err := service.GetRegisteredModelById(*model.Id)
+	require.NoError(t, err)
+	assert.Nil(t, unchanged.Owner)
+	assert.Contains(t, unchanged.CustomProperties, "owner")
+
+	report, err = Migrate(service, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Updated)
+
+	migrated, err := service.GetRegisteredModelById(*model.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", *migrated.Owner)
+	assert.Equal(t, "mit", *migrated.License)
+	assert.Equal(t, []string{"classification", "detection"}, migrated.Tasks)
+	assert.Equal(t, stringValue(""), migrated.CustomProperties["vision"])
+	assert.Equal(t, stringValue(""), migrated.CustomProperties["edge"])
+	assert.ElementsMatch(t, []string{"license", "accuracy", "vision", "edge"}, keys(migrated.CustomProperties))
+
+	migratedVersion, err := service.GetModelVersionById(*version.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", *migratedVersion.Author)
+	assert.Equal(t, openapi.MODELVERSIONSTATE_ARCHIVED, *migratedVersion.State)
+	assert.Empty(t, migratedVersion.CustomProperties)
+
+	skipped, err := service.GetExperimentById(*experiment.Id)
+	require.NoError(t, err)
+	assert.Contains(t, skipped.CustomProperties, "stage")
+
+	report, err = Migrate(service, false)
+	require.NoError(t, err)
+	assert.Zero(t, report.Updated)
+}
+
+func TestModeValidate(t *testing.T) {
+	assert.NoError(t, ModeDryRun.Validate())
+	assert.Error(t, Mode("on").Validate())
+}
+
+func keys(properties map[string]openapi.MetadataValue) []string {
+	var names []string
+	for name := range properties {
+		names = append(names, name)
+	}
+	return names
+}
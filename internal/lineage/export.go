@@ -0,0 +1,173 @@
+// Package lineage renders the lineage graphs of the model registry as graph files, to embed provenance diagrams in
+// audits and documentation.
+package lineage
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// Formats of the exported graphs.
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+)
+
+// contentTypes are the media types of the formats.
+var contentTypes = map[string]string{
+	FormatDOT:     "text/vnd.graphviz",
+	FormatGraphML: "application/graphml+xml",
+}
+
+// shapes are the DOT shapes of the kinds of nodes.
+var shapes = map[string]string{
+	api.LineageContext:   "box",
+	api.LineageArtifact:  "ellipse",
+	api.LineageExecution: "hexagon",
+}
+
+// ContentType returns the media type of the format, false for unknown formats.
+func ContentType(format string) (string, bool) {
+	contentType, ok := contentTypes[format]
+	return contentType, ok
+}
+
+// Export writes the lineage as a graph in the format, the edges are directed from the contexts to the entities
+// linked to them. The ids of the nodes are prefixed with their kind, the ids of each kind having their own sequence.
+func Export(w io.Writer, lineage *api.Lineage, format string) error {
+	switch format {
+	case FormatDOT:
+		return exportDOT(w, lineage)
+	case FormatGraphML:
+		return exportGraphML(w, lineage)
+	default:
+		return fmt.Errorf("unsupported lineage format %q, must be %s or %s", format, FormatDOT, FormatGraphML)
+	}
+}
+
+// nodeID returns the id of the node of the entity in an exported graph.
+func nodeID(kind string, id string) string {
+	return strings.ToLower(kind) + "/" + id
+}
+
+func exportDOT(w io.Writer, lineage *api.Lineage) error {
+	var b strings.Builder
+	b.WriteString("digraph lineage {\n")
+	b.WriteString("  rankdir=LR;\n")
+	for _, node := range lineage.Nodes {
+		label := node.Type
+		if node.Name != "" {
+			label += "\n" + node.Name
+		}
+		ref := ""
+		if node.Ref != nil {
+			ref = ", ref=" + quoteDOT(node.Ref.String())
+		}
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s%s];\n", quoteDOT(nodeID(node.Kind, node.Id)), quoteDOT(label), shapes[node.Kind], ref)
+	}
+	for _, edge := range lineage.Edges {
+		fmt.Fprintf(&b, "  %s -> %s;\n", quoteDOT(nodeID(api.LineageContext, edge.ContextId)), quoteDOT(nodeID(edge.Kind, edge.Id)))
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// quoteDOT quotes a DOT identifier, escaping quotes and backslashes and writing new lines as \n.
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// graphML is the GraphML document of a lineage, with the attributes of the nodes as data keys.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+func exportGraphML(w io.Writer, lineage *api.Lineage) error {
+	document := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "kind", For: "node", AttrName: "kind", AttrType: "string"},
+			{ID: "type", For: "node", AttrName: "type", AttrType: "string"},
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "depth", For: "node", AttrName: "depth", AttrType: "int"},
+			{ID: "ref", For: "node", AttrName: "ref", AttrType: "string"},
+		},
+		Graph: graphMLGraph{
+			ID:          "lineage",
+			EdgeDefault: "directed",
+			Nodes:       make([]graphMLNode, 0, len(lineage.Nodes)),
+			Edges:       make([]graphMLEdge, 0, len(lineage.Edges)),
+		},
+	}
+
+	for _, node := range lineage.Nodes {
+		data := []graphMLData{
+			{Key: "kind", Value: node.Kind},
+			{Key: "type", Value: node.Type},
+			{Key: "name", Value: node.Name},
+			{Key: "depth", Value: fmt.Sprint(node.Depth)},
+		}
+		if node.Ref != nil {
+			data = append(data, graphMLData{Key: "ref", Value: node.Ref.String()})
+		}
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{
+			ID:   nodeID(node.Kind, node.Id),
+			Data: data,
+		})
+	}
+	for _, edge := range lineage.Edges {
+		document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{
+			Source: nodeID(api.LineageContext, edge.ContextId),
+			Target: nodeID(edge.Kind, edge.Id),
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
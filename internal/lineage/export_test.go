@@ -0,0 +1,54 @@
+package lineage
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLineage = &api.Lineage{
+	RootId: "2",
+	Depth:  2,
+	Nodes: []api.LineageNode{
+		{Id: "2", Kind: api.LineageContext, Type: "kf.ModelVersion", Name: `v1 "beta"`, Ref: &api.EntityRef{EntityType: api.EntityTypeModelVersion, Id: "2"}},
+		{Id: "2", Kind: api.LineageArtifact, Type: "kf.ModelArtifact", Name: "model", Depth: 1},
+	},
+	Edges: []api.LineageEdge{{ContextId: "2", Kind: api.LineageArtifact, Id: "2"}},
+}
+
+func TestExportDOT(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Export(&out, testLineage, FormatDOT))
+
+	assert.Equal(t, `digraph lineage {
+  rankdir=LR;
+  "context/2" [label="kf.ModelVersion\nv1 \"beta\"", shape=box, ref="model_version/2"];
+  "artifact/2" [label="kf.ModelArtifact\nmodel", shape=ellipse];
+  "context/2" -> "artifact/2";
+}
+`, out.String())
+}
+
+func TestExportGraphML(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Export(&out, testLineage, FormatGraphML))
+
+	var document graphML
+	require.NoError(t, xml.Unmarshal(out.Bytes(), &document))
+	assert.Equal(t, "directed", document.Graph.EdgeDefault)
+	require.Len(t, document.Graph.Nodes, 2)
+	assert.Equal(t, "context/2", document.Graph.Nodes[0].ID)
+	assert.Contains(t, document.Graph.Nodes[0].Data, graphMLData{Key: "name", Value: `v1 "beta"`})
+	assert.Contains(t, document.Graph.Nodes[0].Data, graphMLData{Key: "ref", Value: "model_version/2"})
+	assert.Equal(t, []graphMLEdge{{Source: "context/2", Target: "artifact/2"}}, document.Graph.Edges)
+}
+
+func TestExportUnsupportedFormat(t *testing.T) {
+	assert.Error(t, Export(&bytes.Buffer{}, testLineage, "svg"))
+	_, ok := ContentType("svg")
+	assert.False(t, ok)
+}
@@ -0,0 +1,163 @@
+// Package metadatadefaults injects the default custom properties and tags configured for a namespace into the
+// entities created in it, e.g. a cost-center or a data-classification every registered model must carry.
+//
+// Defaults never replace the values set by the client, and the values injected in an entity are recorded in its
+// AuditProperty custom property.
+package metadatadefaults
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// AuditProperty is the custom property recording the values injected in an entity, as an Audit JSON document.
+const AuditProperty = "_injected_defaults"
+
+// Config is the content of the metadata defaults file, e.g.
+//
+//	namespaces:
+//	  team-a:
+//	    customProperties:
+//	      cost-center: "4711"
+//	      data-classification: internal
+//	    tags: [team-a]
+type Config struct {
+	Namespaces map[string]Defaults `json:"namespaces"`
+}
+
+// Defaults are the metadata injected in the entities created in a namespace. Tags are custom properties with an
+// empty string value, as the labels of the UI.
+type Defaults struct {
+	CustomProperties map[string]string `json:"customProperties,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+}
+
+// Audit records the defaults injected in an entity.
+type Audit struct {
+	Namespace string            `json:"namespace"`
+	Values    map[string]string `json:"values"`
+}
+
+// LoadConfig reads and validates a metadata defaults file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading metadata defaults file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing metadata defaults file %s: %w", path, err)
+	}
+	for namespace, defaults := range config.Namespaces {
+		if err := defaults.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid metadata defaults of namespace %s: %w", namespace, err)
+		}
+	}
+	return &config, nil
+}
+
+// Validate checks that the defaults don't set reserved or conflicting custom properties.
+func (d Defaults) Validate() error {
+	for name := range d.values() {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("custom property and tag names cannot be empty")
+		}
+		if strings.HasPrefix(name, "_") {
+			return fmt.Errorf("custom property %s is reserved, names starting with _ are internal", name)
+		}
+	}
+	for _, tag := range d.Tags {
+		if _, ok := d.CustomProperties[tag]; ok {
+			return fmt.Errorf("tag %s is also set as a custom property", tag)
+		}
+	}
+	return nil
+}
+
+// values returns the custom property values of the defaults, tags included.
+func (d Defaults) values() map[string]string {
+	values := maps.Clone(d.CustomProperties)
+	if values == nil {
+		values = map[string]string{}
+	}
+	for _, tag := range d.Tags {
+		values[tag] = ""
+	}
+	return values
+}
+
+// Injector injects the defaults of the namespace the registry serves.
+type Injector struct {
+	namespace string
+	values    map[string]string
+}
+
+// NewInjector returns the injector of the defaults of namespace in config, nil if there are none so that nothing
+// is injected.
+func NewInjector(config *Config, namespace string) (*Injector, error) {
+	if config == nil {
+		return nil, nil
+	}
+	defaults, ok := config.Namespaces[namespace]
+	if !ok {
+		return nil, nil
+	}
+	if err := defaults.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid metadata defaults of namespace %s: %w", namespace, err)
+	}
+	values := defaults.values()
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return &Injector{namespace: namespace, values: values}, nil
+}
+
+// Namespace returns the namespace of the injected defaults.
+func (i *Injector) Namespace() string {
+	return i.namespace
+}
+
+// Inject sets the defaults missing from the custom properties of a new entity, recording them in AuditProperty,
+// and returns the names of the injected custom properties. Nothing is injected by a nil injector.
+func (i *Injector) Inject(customProperties *map[string]openapi.MetadataValue) ([]string, error) {
+	if i == nil {
+		return nil, nil
+	}
+
+	injected := map[string]string{}
+	for name, value := range i.values {
+		if _, ok := (*customProperties)[name]; ok {
+			continue
+		}
+		injected[name] = value
+	}
+	if len(injected) == 0 {
+		return nil, nil
+	}
+
+	audit, err := json.Marshal(Audit{Namespace: i.namespace, Values: injected})
+	if err != nil {
+		return nil, fmt.Errorf("error encoding injected metadata defaults: %w", err)
+	}
+
+	if *customProperties == nil {
+		*customProperties = map[string]openapi.MetadataValue{}
+	}
+	for name, value := range injected {
+		(*customProperties)[name] = stringValue(value)
+	}
+	(*customProperties)[AuditProperty] = stringValue(string(audit))
+
+	return slices.Sorted(maps.Keys(injected)), nil
+}
+
+func stringValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
@@ -0,0 +1,93 @@
+package metadatadefaults
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "defaults.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`namespaces:
+  team-a:
+    customProperties:
+      cost-center: "4711"
+    tags: [pii]
+`), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, Defaults{CustomProperties: map[string]string{"cost-center": "4711"}, Tags: []string{"pii"}}, config.Namespaces["team-a"])
+
+	for _, content := range []string{
+		"defaults: {}\n",
+		"namespaces:\n  team-a:\n    customProperties:\n      _archive_key: x\n",
+		"namespaces:\n  team-a:\n    customProperties:\n      pii: x\n    tags: [pii]\n",
+		"namespaces:\n  team-a:\n    tags: ['']\n",
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		_, err := LoadConfig(path)
+		assert.Error(t, err, "content %q", content)
+	}
+}
+
+func TestNewInjector(t *testing.T) {
+	config := &Config{Namespaces: map[string]Defaults{"team-a": {Tags: []string{"pii"}}, "team-b": {}}}
+
+	injector, err := NewInjector(config, "team-a")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", injector.Namespace())
+
+	for _, namespace := range []string{"team-b", "team-c"} {
+		injector, err := NewInjector(config, namespace)
+		require.NoError(t, err)
+		assert.Nil(t, injector, "namespace %s", namespace)
+	}
+
+	injector, err = NewInjector(nil, "team-a")
+	require.NoError(t, err)
+	assert.Nil(t, injector)
+}
+
+func TestInject(t *testing.T) {
+	injector, err := NewInjector(&Config{Namespaces: map[string]Defaults{
+		"team-a": {CustomProperties: map[string]string{"cost-center": "4711", "owner-team": "a"}, Tags: []string{"pii"}},
+	}}, "team-a")
+	require.NoError(t, err)
+
+	customProperties := map[string]openapi.MetadataValue{"owner-team": stringValue("b")}
+	injected, err := injector.Inject(&customProperties)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cost-center", "pii"}, injected)
+
+	assert.Equal(t, "4711", customProperties["cost-center"].MetadataStringValue.StringValue)
+	assert.Equal(t, "b", customProperties["owner-team"].MetadataStringValue.StringValue)
+	assert.Equal(t, "", customProperties["pii"].MetadataStringValue.StringValue)
+
+	var audit Audit
+	require.NoError(t, json.Unmarshal([]byte(customProperties[AuditProperty].MetadataStringValue.StringValue), &audit))
+	assert.Equal(t, Audit{Namespace: "team-a", Values: map[string]string{"cost-center": "4711", "pii": ""}}, audit)
+
+	// nothing is missing anymore
+	before := len(customProperties)
+	injected, err = injector.Inject(&customProperties)
+	require.NoError(t, err)
+	assert.Empty(t, injected)
+	assert.Len(t, customProperties, before)
+
+	var none map[string]openapi.MetadataValue
+	injected, err = injector.Inject(&none)
+	require.NoError(t, err)
+	assert.Len(t, injected, 3)
+	assert.Len(t, none, 4)
+
+	var nilInjector *Injector
+	injected, err = nilInjector.Inject(&none)
+	require.NoError(t, err)
+	assert.Empty(t, injected)
+}
@@ -0,0 +1,306 @@
+// Package metricexport exports the metric histories of the runs of an experiment to a blob store in the background,
+// for the sweeps to be analyzed offline from a single download instead of paging through the metric history endpoints.
+package metricexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/google/uuid"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/archive"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+const (
+	// keyPrefix is the blob store folder of the exports.
+	keyPrefix = "metric_exports/"
+
+	// DefaultPageSize is the number of runs and metric history points read per page.
+	DefaultPageSize = 1000
+)
+
+var (
+	// ErrExportNotFound is returned for an unknown export.
+	ErrExportNotFound = errors.New("metric export not found")
+	// ErrExportNotReady is returned when reading the content of an export which hasn't succeeded.
+	ErrExportNotReady = errors.New("metric export not ready")
+)
+
+// Format is the file format of an export.
+type Format string
+
+// FormatCSV writes a row per metric history point, with a header row.
+const FormatCSV Format = "csv"
+
+// State is the state of an export.
+type State string
+
+const (
+	StateRunning   State = "RUNNING"
+	StateSucceeded State = "SUCCEEDED"
+	StateFailed    State = "FAILED"
+)
+
+// csvHeader are the columns of the CSV exports.
+var csvHeader = []string{"experiment_id", "experiment_run_id", "experiment_run_name", "metric", "step", "timestamp", "value"}
+
+// Config configures the blob store of the exports.
+type Config struct {
+	// URL is s3://bucket/prefix or a directory, empty disables the exports.
+	URL        string
+	S3Endpoint string
+	S3Region   string
+}
+
+// Enabled reports whether metric histories can be exported.
+func (c *Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// Request selects the runs of an export.
+type Request struct {
+	// FilterQuery restricts the exported runs of the experiment, as the filterQuery of the experiment run lists.
+	FilterQuery string `json:"filterQuery,omitempty"`
+	// Format defaults to csv.
+	Format Format `json:"format,omitempty"`
+}
+
+// Export is an export of the metric histories of an experiment, the manifest saved next to its content.
+type Export struct {
+	Id           string `json:"id"`
+	ExperimentId string `json:"experimentId"`
+	FilterQuery  string `json:"filterQuery,omitempty"`
+	Format       Format `json:"format"`
+	State        State  `json:"state"`
+	// Message is the error of a FAILED export.
+	Message string `json:"message,omitempty"`
+	// Runs and Points are the number of runs and metric history points exported.
+	Runs                 int    `json:"runs"`
+	Points               int    `json:"points"`
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch"`
+	// CompleteTimeSinceEpoch is set once the export has succeeded or failed.
+	CompleteTimeSinceEpoch string `json:"completeTimeSinceEpoch,omitempty"`
+	// DownloadUrl is the path of the content of a SUCCEEDED export.
+	DownloadUrl string `json:"downloadUrl,omitempty"`
+}
+
+// Exporter runs the exports, keeping their manifest and content in a blob store shared by the replicas.
+type Exporter struct {
+	registry api.ModelRegistryApi
+	store    archive.BlobStore
+	pageSize int32
+}
+
+// NewExporter returns an Exporter reading the metric histories from registry.
+func NewExporter(registry api.ModelRegistryApi, store archive.BlobStore) *Exporter {
+	return &Exporter{
+		registry: registry,
+		store:    store,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// Start validates the request and exports the metric histories of the experiment in the background, the returned
+// export is RUNNING until its content is saved.
+func (e *Exporter) Start(ctx context.Context, experimentId string, request Request) (Export, error) {
+	if request.Format == "" {
+		request.Format = FormatCSV
+	}
+	if request.Format != FormatCSV {
+		return Export{}, fmt.Errorf("unsupported metric export format %q, supported formats: [%s]: %w", request.Format, FormatCSV, api.ErrBadRequest)
+	}
+
+	registry := api.WithContext(ctx, e.registry)
+	if _, err := registry.GetExperimentById(experimentId); err != nil {
+		return Export{}, err
+	}
+	// Fail the invalid filters right away rather than in the background
+	if _, err := registry.GetExperimentRuns(e.runsListOptions(request.FilterQuery, nil, 1), &experimentId); err != nil {
+		return Export{}, err
+	}
+
+	export := Export{
+		Id:                   uuid.NewString(),
+		ExperimentId:         experimentId,
+		FilterQuery:          request.FilterQuery,
+		Format:               request.Format,
+		State:                StateRunning,
+		CreateTimeSinceEpoch: epochMillis(time.Now()),
+	}
+	if err := e.saveManifest(ctx, export); err != nil {
+		return Export{}, err
+	}
+
+	go e.run(export)
+
+	return export, nil
+}
+
+// Get returns the export with id.
+func (e *Exporter) Get(ctx context.Context, id string) (Export, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return Export{}, fmt.Errorf("%w: %s", ErrExportNotFound, id)
+	}
+
+	data, err := e.store.Get(ctx, manifestKey(id))
+	if errors.Is(err, archive.ErrBlobNotFound) {
+		return Export{}, fmt.Errorf("%w: %s", ErrExportNotFound, id)
+	}
+	if err != nil {
+		return Export{}, fmt.Errorf("error reading metric export %s: %w", id, err)
+	}
+
+	var export Export
+	if err := json.Unmarshal(data, &export); err != nil {
+		return Export{}, fmt.Errorf("error decoding metric export %s: %w", id, err)
+	}
+	return export, nil
+}
+
+// Content returns the content of the export with id, once it has succeeded.
+func (e *Exporter) Content(ctx context.Context, id string) (Export, []byte, error) {
+	export, err := e.Get(ctx, id)
+	if err != nil {
+		return export, nil, err
+	}
+	if export.State != StateSucceeded {
+		return export, nil, fmt.Errorf("%w: %s is %s", ErrExportNotReady, id, export.State)
+	}
+
+	data, err := e.store.Get(ctx, contentKey(export))
+	if err != nil {
+		return export, nil, fmt.Errorf("error reading metric export %s: %w", id, err)
+	}
+	return export, data, nil
+}
+
+// run writes the content of export and records its outcome in its manifest.
+func (e *Exporter) run(export Export) {
+	ctx := context.Background()
+
+	content, err := e.write(&export)
+	if err == nil {
+		err = e.store.Put(ctx, contentKey(export), content)
+	}
+
+	export.CompleteTimeSinceEpoch = epochMillis(time.Now())
+	if err != nil {
+		glog.Errorf("Error exporting the metric histories of experiment %s: %v", export.ExperimentId, err)
+		export.State = StateFailed
+		export.Message = err.Error()
+	} else {
+		export.State = StateSucceeded
+		export.DownloadUrl = BasePath + "/metric_exports/" + export.Id + "/content"
+	}
+
+	if err := e.saveManifest(ctx, export); err != nil {
+		glog.Errorf("Error saving metric export %s: %v", export.Id, err)
+	}
+}
+
+// write returns the metric history points of the runs of export as CSV, counting the runs and points in export.
+func (e *Exporter) write(export *Export) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	var runsToken *string
+	for {
+		runs, err := e.registry.GetExperimentRuns(e.runsListOptions(export.FilterQuery, runsToken, e.pageSize), &export.ExperimentId)
+		if err != nil {
+			return nil, fmt.Errorf("error listing experiment runs: %w", err)
+		}
+
+		for _, run := range runs.Items {
+			runId := run.GetId()
+			export.Runs++
+
+			var pointsToken *string
+			for {
+				points, err := e.registry.GetExperimentRunMetricHistory(nil, nil, api.ListOptions{
+					PageSize:      apiutils.Of(e.pageSize),
+					NextPageToken: pointsToken,
+				}, &runId)
+				if err != nil {
+					return nil, fmt.Errorf("error reading the metric history of experiment run %s: %w", runId, err)
+				}
+
+				for _, point := range points.Items {
+					record := []string{
+						export.ExperimentId,
+						runId,
+						run.GetName(),
+						point.GetName(),
+						"",
+						point.GetTimestamp(),
+						strconv.FormatFloat(point.GetValue(), 'g', -1, 64),
+					}
+					if point.Step != nil {
+						record[4] = strconv.FormatInt(*point.Step, 10)
+					}
+					if err := w.Write(record); err != nil {
+						return nil, err
+					}
+					export.Points++
+				}
+
+				if points.NextPageToken == "" {
+					break
+				}
+				pointsToken = apiutils.Of(points.NextPageToken)
+			}
+		}
+
+		if runs.NextPageToken == "" {
+			break
+		}
+		runsToken = apiutils.Of(runs.NextPageToken)
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (e *Exporter) runsListOptions(filterQuery string, nextPageToken *string, pageSize int32) api.ListOptions {
+	options := api.ListOptions{
+		PageSize:      apiutils.Of(pageSize),
+		NextPageToken: nextPageToken,
+	}
+	if filterQuery != "" {
+		options.FilterQuery = apiutils.Of(filterQuery)
+	}
+	return options
+}
+
+func (e *Exporter) saveManifest(ctx context.Context, export Export) error {
+	data, err := json.Marshal(export)
+	if err != nil {
+		return err
+	}
+	if err := e.store.Put(ctx, manifestKey(export.Id), data); err != nil {
+		return fmt.Errorf("error saving metric export %s: %w", export.Id, err)
+	}
+	return nil
+}
+
+func manifestKey(id string) string {
+	return keyPrefix + id + ".json"
+}
+
+func contentKey(export Export) string {
+	return keyPrefix + export.Id + "." + string(export.Format)
+}
+
+func epochMillis(t time.Time) string {
+	return strconv.FormatInt(t.UnixMilli(), 10)
+}
@@ -0,0 +1,112 @@
+package metricexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/archive"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	service := inmemory.NewModelRegistryService(inmemory.NewStore())
+	store, err := archive.NewFileBlobStore(t.TempDir())
+	require.NoError(t, err)
+
+	exporter := NewExporter(service, store)
+	// Page through the runs and metric histories
+	exporter.pageSize = 1
+	server := httptest.NewServer(NewHandler(exporter, http.NotFoundHandler()))
+	t.Cleanup(server.Close)
+
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "sweep"})
+	require.NoError(t, err)
+	for _, name := range []string{"lr-0.1", "lr-0.01"} {
+		run, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: apiutils.Of(name)}, experiment.Id)
+		require.NoError(t, err)
+		for step, loss := range []float64{0.5, 0.25} {
+			_, err := service.UpsertExperimentRunArtifact(&openapi.Artifact{Metric: &openapi.Metric{
+				Name:      apiutils.Of("loss"),
+				Value:     apiutils.Of(loss),
+				Step:      apiutils.Of(int64(step)),
+				Timestamp: apiutils.Of(strconv.Itoa(1700000000000 + step)),
+			}}, *run.Id)
+			require.NoError(t, err)
+			// The history points of a metric are told apart by their update time
+			time.Sleep(2 * time.Millisecond)
+		}
+	}
+
+	start := func(body string) (*http.Response, Export) {
+		resp, err := http.Post(server.URL+BasePath+"/experiments/"+*experiment.Id+"/metric_exports", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var export Export
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+		return resp, export
+	}
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	wait := func(id string) Export {
+		var export Export
+		require.Eventually(t, func() bool {
+			resp := get(BasePath + "/metric_exports/" + id)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+			return export.State != StateRunning
+		}, 5*time.Second, 10*time.Millisecond)
+		return export
+	}
+
+	resp, started := start("")
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, BasePath+"/metric_exports/"+started.Id, resp.Header.Get("Location"))
+	assert.Equal(t, FormatCSV, started.Format)
+
+	export := wait(started.Id)
+	require.Equal(t, StateSucceeded, export.State, export.Message)
+	assert.Equal(t, 2, export.Runs)
+	assert.Equal(t, 4, export.Points)
+
+	content := get(export.DownloadUrl)
+	require.Equal(t, http.StatusOK, content.StatusCode)
+	assert.Equal(t, "text/csv; charset=UTF-8", content.Header.Get("Content-Type"))
+	records, err := csv.NewReader(content.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, []string{*experiment.Id, records[1][1], "lr-0.1", "loss", "0", "1700000000000", "0.5"}, records[1])
+
+	// Only the runs matching the filter are exported
+	_, started = start(`{"filterQuery": "name = 'lr-0.01'"}`)
+	export = wait(started.Id)
+	require.Equal(t, StateSucceeded, export.State, export.Message)
+	assert.Equal(t, 1, export.Runs)
+	assert.Equal(t, 2, export.Points)
+
+	resp, _ = start(`{"format": "parquet"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+BasePath+"/experiments/999/metric_exports", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	assert.Equal(t, http.StatusNotFound, get(BasePath+"/metric_exports/unknown").StatusCode)
+}
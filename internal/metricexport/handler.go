@@ -0,0 +1,98 @@
+package metricexport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BasePath is the path the metric export endpoints are below.
+const BasePath = "/api/model_registry/v1alpha3"
+
+// NewHandler returns the handler of the metric export endpoints, passing the other requests to next:
+//
+//	POST /api/model_registry/v1alpha3/experiments/{experimentId}/metric_exports  starts an export of an experiment
+//	GET  /api/model_registry/v1alpha3/metric_exports/{id}                        returns an export
+//	GET  /api/model_registry/v1alpha3/metric_exports/{id}/content                downloads a SUCCEEDED export
+//
+// The body of the POST is a Request, which can be empty to export all the runs of the experiment as CSV.
+func NewHandler(exporter *Exporter, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+BasePath+"/experiments/{experimentId}/metric_exports", func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if r.ContentLength != 0 {
+			d := json.NewDecoder(r.Body)
+			d.DisallowUnknownFields()
+			if err := d.Decode(&request); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid metric export request: "+err.Error())
+				return
+			}
+		}
+
+		export, err := exporter.Start(r.Context(), r.PathValue("experimentId"), request)
+		if err != nil {
+			writeExportError(w, err)
+			return
+		}
+		w.Header().Set("Location", BasePath+"/metric_exports/"+export.Id)
+		writeJSON(w, http.StatusAccepted, export)
+	})
+
+	mux.HandleFunc("GET "+BasePath+"/metric_exports/{id}", func(w http.ResponseWriter, r *http.Request) {
+		export, err := exporter.Get(r.Context(), r.PathValue("id"))
+		if err != nil {
+			writeExportError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, export)
+	})
+
+	mux.HandleFunc("GET "+BasePath+"/metric_exports/{id}/content", func(w http.ResponseWriter, r *http.Request) {
+		export, content, err := exporter.Content(r.Context(), r.PathValue("id"))
+		if err != nil {
+			writeExportError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv; charset=UTF-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="experiment-%s-metrics.%s"`, export.ExperimentId, export.Format))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(content); err != nil {
+			glog.Errorf("Error writing metric export %s: %v", export.Id, err)
+		}
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func writeExportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrExportNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrExportNotReady):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, api.ErrBadRequest), errors.Is(err, api.ErrNotFound):
+		writeError(w, api.ErrToStatus(err), err.Error())
+	default:
+		glog.Errorf("Error exporting metric histories: %v", err)
+		writeError(w, http.StatusInternalServerError, "error exporting metric histories")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing metric export response: %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+// Package metrics exposes the Prometheus metrics of the registry: the durations, row counts and errors of the
+// repository operations, and the durations and statuses of the REST api requests.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is the path of the metrics endpoint.
+const Path = "/metrics"
+
+// Repository operations, the values of the operation label.
+const (
+	OperationGetByID = "get_by_id"
+	OperationList    = "list"
+	OperationSave    = "save"
+)
+
+// apiPath is the prefix of the REST api paths, the other paths are reported as otherRoute.
+const apiPath = "/api/model_registry/"
+
+const otherRoute = "other"
+
+var (
+	repositoryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "model_registry_repository_operation_duration_seconds",
+		Help:    "Duration of the repository operations, by entity and operation (get_by_id, list or save).",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"entity", "operation"})
+
+	repositoryRows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "model_registry_repository_rows_total",
+		Help: "Number of entities read or saved by the repository operations, by entity and operation.",
+	}, []string{"entity", "operation"})
+
+	repositoryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "model_registry_repository_errors_total",
+		Help: "Number of failed repository operations, by entity and operation. Entities not found are not counted.",
+	}, []string{"entity", "operation"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "model_registry_http_request_duration_seconds",
+		Help:    "Duration of the REST api requests, by method, route and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "code"})
+)
+
+func init() {
+	prometheus.MustRegister(repositoryDuration, repositoryRows, repositoryErrors, httpDuration)
+}
+
+// Handler returns the handler of the metrics endpoint, in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// ObserveRepository records a repository operation on an entity started at start, which read or saved rows
+// entities. failed tells whether the operation failed, a lookup of a missing entity is not a failure.
+func ObserveRepository(entity string, operation string, start time.Time, rows int, failed bool) {
+	entity = strings.ReplaceAll(entity, " ", "_")
+	repositoryDuration.WithLabelValues(entity, operation).Observe(time.Since(start).Seconds())
+	if rows > 0 {
+		repositoryRows.WithLabelValues(entity, operation).Add(float64(rows))
+	}
+	if failed {
+		repositoryErrors.WithLabelValues(entity, operation).Inc()
+	}
+}
+
+// Middleware records the duration and status of the requests served by next.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rw, r)
+
+		httpDuration.WithLabelValues(r.Method, Route(r.URL.Path), strconv.Itoa(rw.status)).Observe(time.Since(start).Seconds())
+	})
+}
+
+// Route returns the path of a REST api request with its ids replaced by {id}, keeping the number of routes
+// reported bounded, e.g. /api/model_registry/v1alpha3/registered_models/{id}/versions.
+func Route(path string) string {
+	if !strings.HasPrefix(path, apiPath) {
+		return otherRoute
+	}
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		// keep custom methods, e.g. model_versions/1:resolveArtifact
+		id, method, hasMethod := strings.Cut(segment, ":")
+		if !isID(id) {
+			continue
+		}
+		segments[i] = "{id}"
+		if hasMethod {
+			segments[i] += ":" + method
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func isID(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// responseWriter captures the status of a response.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
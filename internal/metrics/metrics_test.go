@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute(t *testing.T) {
+	tests := map[string]string{
+		"/api/model_registry/v1alpha3/registered_models":                  "/api/model_registry/v1alpha3/registered_models",
+		"/api/model_registry/v1alpha3/registered_models/12/versions":      "/api/model_registry/v1alpha3/registered_models/{id}/versions",
+		"/api/model_registry/v1alpha3/model_versions/3:resolveArtifact":   "/api/model_registry/v1alpha3/model_versions/{id}:resolveArtifact",
+		"/api/model_registry/v1alpha3/registered_models:batchGet":         "/api/model_registry/v1alpha3/registered_models:batchGet",
+		"/api/model_registry/v1alpha3/experiment_runs/4/metric_history/5": "/api/model_registry/v1alpha3/experiment_runs/{id}/metric_history/{id}",
+		"/readyz/health": "other",
+	}
+	for path, expected := range tests {
+		assert.Equal(t, expected, Route(path), path)
+	}
+}
+
+func TestObserveRepository(t *testing.T) {
+	ObserveRepository("model artifact", OperationList, time.Now(), 3, false)
+	ObserveRepository("model artifact", OperationList, time.Now(), 0, true)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(repositoryRows.WithLabelValues("model_artifact", OperationList)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(repositoryErrors.WithLabelValues("model_artifact", OperationList)))
+	assert.Equal(t, 1, testutil.CollectAndCount(repositoryDuration), "the durations are observed in one series")
+}
+
+func TestMiddleware(t *testing.T) {
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte("{}"))
+		w.(http.Flusher).Flush()
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	for _, path := range []string{"/api/model_registry/v1alpha3/registered_models/1", "/api/model_registry/v1alpha3/registered_models/404"} {
+		resp, err := http.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	exposed := httptest.NewRecorder()
+	Handler().ServeHTTP(exposed, httptest.NewRequest(http.MethodGet, Path, nil))
+	body := exposed.Body.String()
+	for _, code := range []int{http.StatusOK, http.StatusNotFound} {
+		assert.Contains(t, body, fmt.Sprintf(`model_registry_http_request_duration_seconds_count{code="%d",method="GET",route="/api/model_registry/v1alpha3/registered_models/{id}"} 1`, code))
+	}
+}
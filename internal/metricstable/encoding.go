@@ -0,0 +1,351 @@
+// Package metricstable encodes the columns of metrics tables in a compact columnar format, decoded with a selection
+// of columns.
+//
+// A table is encoded as a header, listing the name, type and encoded size of each column, followed by the columns,
+// each compressed on its own so that decoding skips the columns not selected. Doubles are stored as their IEEE 754
+// bits and strings with a dictionary, as the label columns of metrics tables repeat few distinct values.
+package metricstable
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// magic starts the tables of the first version of the encoding.
+const magic = "MRT1"
+
+// MaxColumns, MaxRows and MaxStringLength bound the size of a table.
+const (
+	MaxColumns      = 256
+	MaxRows         = 100_000
+	MaxStringLength = 64 << 10
+)
+
+const (
+	doubleColumn byte = iota
+	stringColumn
+)
+
+// ErrUnknownColumn is returned when decoding a selected column the table doesn't have.
+var ErrUnknownColumn = errors.New("unknown column")
+
+// Header describes the columns of an encoded table, without their values.
+type Header struct {
+	Rows    int
+	Columns []api.MetricsTableColumn
+	sizes   []int
+}
+
+// Validate checks that the columns are a valid table, with distinct names, known types and values for every row, and
+// returns its number of rows.
+func Validate(columns []api.MetricsTableColumn) (int, error) {
+	if len(columns) == 0 {
+		return 0, errors.New("a metrics table must have at least one column")
+	}
+	if len(columns) > MaxColumns {
+		return 0, fmt.Errorf("a metrics table can't have more than %d columns", MaxColumns)
+	}
+
+	rows := -1
+	names := map[string]bool{}
+	for _, column := range columns {
+		if column.Name == "" {
+			return 0, errors.New("missing metrics table column name")
+		}
+		if len(column.Name) > MaxStringLength {
+			return 0, fmt.Errorf("metrics table column names can't be longer than %d bytes", MaxStringLength)
+		}
+		if names[column.Name] {
+			return 0, fmt.Errorf("duplicate metrics table column %q", column.Name)
+		}
+		names[column.Name] = true
+
+		var length int
+		switch column.Type {
+		case api.MetricsTableDouble:
+			if len(column.StringValues) > 0 {
+				return 0, fmt.Errorf("DOUBLE metrics table column %q can't have string values", column.Name)
+			}
+			for _, value := range column.DoubleValues {
+				if math.IsNaN(value) || math.IsInf(value, 0) {
+					return 0, fmt.Errorf("metrics table column %q has a value which is not a finite number", column.Name)
+				}
+			}
+			length = len(column.DoubleValues)
+		case api.MetricsTableString:
+			if len(column.DoubleValues) > 0 {
+				return 0, fmt.Errorf("STRING metrics table column %q can't have double values", column.Name)
+			}
+			for _, value := range column.StringValues {
+				if len(value) > MaxStringLength {
+					return 0, fmt.Errorf("metrics table column %q has a value longer than %d bytes", column.Name, MaxStringLength)
+				}
+			}
+			length = len(column.StringValues)
+		default:
+			return 0, fmt.Errorf("invalid type %q of metrics table column %q, must be %s or %s", column.Type, column.Name, api.MetricsTableDouble, api.MetricsTableString)
+		}
+
+		if rows == -1 {
+			rows = length
+		} else if length != rows {
+			return 0, fmt.Errorf("metrics table column %q has %d values, expected %d as the first column", column.Name, length, rows)
+		}
+	}
+
+	if rows > MaxRows {
+		return 0, fmt.Errorf("a metrics table can't have more than %d rows", MaxRows)
+	}
+	return rows, nil
+}
+
+// Encode encodes the columns of a table, they must be valid.
+func Encode(columns []api.MetricsTableColumn) ([]byte, error) {
+	rows, err := Validate(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([][]byte, 0, len(columns))
+	for _, column := range columns {
+		block, err := encodeColumn(column)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding metrics table column %q: %w", column.Name, err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(magic)
+	buf.Write(binary.AppendUvarint(nil, uint64(rows)))
+	buf.Write(binary.AppendUvarint(nil, uint64(len(columns))))
+	for i, column := range columns {
+		writeString(&buf, column.Name)
+		if column.Type == api.MetricsTableDouble {
+			buf.WriteByte(doubleColumn)
+		} else {
+			buf.WriteByte(stringColumn)
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(blocks[i]))))
+	}
+	for _, block := range blocks {
+		buf.Write(block)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeHeader returns the rows and columns of an encoded table, the columns without their values.
+func DecodeHeader(data []byte) (*Header, error) {
+	header, _, err := decodeHeader(data)
+	return header, err
+}
+
+// Decode returns the columns of an encoded table selected by name in the order of the selection, or all the columns
+// in the order of the table without selection.
+func Decode(data []byte, selection []string) ([]api.MetricsTableColumn, error) {
+	header, offset, err := decodeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// offsets of the blocks of the columns by name
+	indexes := make(map[string]int, len(header.Columns))
+	offsets := make([]int, len(header.Columns))
+	for i, column := range header.Columns {
+		indexes[column.Name] = i
+		offsets[i] = offset
+		offset += header.sizes[i]
+	}
+	if offset != len(data) {
+		return nil, errors.New("invalid metrics table encoding: size mismatch")
+	}
+
+	if selection == nil {
+		selection = make([]string, 0, len(header.Columns))
+		for _, column := range header.Columns {
+			selection = append(selection, column.Name)
+		}
+	}
+
+	columns := make([]api.MetricsTableColumn, 0, len(selection))
+	for _, name := range selection {
+		i, ok := indexes[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownColumn, name)
+		}
+		column, err := decodeColumn(header.Columns[i], header.Rows, data[offsets[i]:offsets[i]+header.sizes[i]])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding metrics table column %q: %w", name, err)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func decodeHeader(data []byte) (*Header, int, error) {
+	invalid := errors.New("invalid metrics table encoding")
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return nil, 0, invalid
+	}
+	reader := bytes.NewReader(data[len(magic):])
+
+	rows, err := binary.ReadUvarint(reader)
+	if err != nil || rows > MaxRows {
+		return nil, 0, invalid
+	}
+	count, err := binary.ReadUvarint(reader)
+	if err != nil || count > MaxColumns {
+		return nil, 0, invalid
+	}
+
+	header := &Header{Rows: int(rows)}
+	for range count {
+		name, err := readString(reader)
+		if err != nil {
+			return nil, 0, invalid
+		}
+		columnType, err := reader.ReadByte()
+		if err != nil {
+			return nil, 0, invalid
+		}
+		size, err := binary.ReadUvarint(reader)
+		if err != nil || size > uint64(len(data)) {
+			return nil, 0, invalid
+		}
+
+		column := api.MetricsTableColumn{Name: name}
+		switch columnType {
+		case doubleColumn:
+			column.Type = api.MetricsTableDouble
+		case stringColumn:
+			column.Type = api.MetricsTableString
+		default:
+			return nil, 0, invalid
+		}
+		header.Columns = append(header.Columns, column)
+		header.sizes = append(header.sizes, int(size))
+	}
+
+	return header, len(data) - reader.Len(), nil
+}
+
+func encodeColumn(column api.MetricsTableColumn) ([]byte, error) {
+	var raw bytes.Buffer
+	if column.Type == api.MetricsTableDouble {
+		for _, value := range column.DoubleValues {
+			raw.Write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(value)))
+		}
+	} else {
+		dictionary := map[string]uint64{}
+		var values []string
+		indexes := make([]uint64, 0, len(column.StringValues))
+		for _, value := range column.StringValues {
+			index, ok := dictionary[value]
+			if !ok {
+				index = uint64(len(values))
+				dictionary[value] = index
+				values = append(values, value)
+			}
+			indexes = append(indexes, index)
+		}
+
+		raw.Write(binary.AppendUvarint(nil, uint64(len(values))))
+		for _, value := range values {
+			writeString(&raw, value)
+		}
+		for _, index := range indexes {
+			raw.Write(binary.AppendUvarint(nil, index))
+		}
+	}
+
+	var compressed bytes.Buffer
+	writer, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return compressed.Bytes(), nil
+}
+
+func decodeColumn(column api.MetricsTableColumn, rows int, block []byte) (api.MetricsTableColumn, error) {
+	reader := bufio.NewReader(flate.NewReader(bytes.NewReader(block)))
+
+	if column.Type == api.MetricsTableDouble {
+		column.DoubleValues = make([]float64, rows)
+		var bits [8]byte
+		for i := range rows {
+			if _, err := io.ReadFull(reader, bits[:]); err != nil {
+				return column, err
+			}
+			column.DoubleValues[i] = math.Float64frombits(binary.LittleEndian.Uint64(bits[:]))
+		}
+		return column, nil
+	}
+
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return column, err
+	}
+	if count > uint64(rows) {
+		return column, errors.New("dictionary larger than the column")
+	}
+	values := make([]string, count)
+	for i := range values {
+		if values[i], err = readString(reader); err != nil {
+			return column, err
+		}
+	}
+
+	column.StringValues = make([]string, rows)
+	for i := range rows {
+		index, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return column, err
+		}
+		if index >= count {
+			return column, errors.New("dictionary index out of range")
+		}
+		column.StringValues[i] = values[index]
+	}
+	return column, nil
+}
+
+func writeString(buf *bytes.Buffer, value string) {
+	buf.Write(binary.AppendUvarint(nil, uint64(len(value))))
+	buf.WriteString(value)
+}
+
+// stringReader reads the strings of the encoding.
+type stringReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+func readString(reader stringReader) (string, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return "", err
+	}
+	if length > MaxStringLength {
+		return "", errors.New("string too long")
+	}
+	value := make([]byte, length)
+	if _, err := io.ReadFull(reader, value); err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
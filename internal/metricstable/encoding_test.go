@@ -0,0 +1,91 @@
+package metricstable
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	columns := []api.MetricsTableColumn{
+		{Name: "slice", Type: api.MetricsTableString, StringValues: []string{"age<30", "age>=30", "age<30", ""}},
+		{Name: "auc", Type: api.MetricsTableDouble, DoubleValues: []float64{0.91, -1.5e-12, 0, 1e300}},
+	}
+
+	data, err := Encode(columns)
+	require.NoError(t, err)
+
+	decoded, err := Decode(data, nil)
+	require.NoError(t, err)
+	assert.Equal(t, columns, decoded)
+
+	selected, err := Decode(data, []string{"auc"})
+	require.NoError(t, err)
+	assert.Equal(t, columns[1:], selected)
+
+	_, err = Decode(data, []string{"f1"})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	header, err := DecodeHeader(data)
+	require.NoError(t, err)
+	assert.Equal(t, 4, header.Rows)
+	assert.Equal(t, []api.MetricsTableColumn{{Name: "slice", Type: api.MetricsTableString}, {Name: "auc", Type: api.MetricsTableDouble}}, header.Columns)
+}
+
+func TestEncodeIsCompact(t *testing.T) {
+	rows := 10_000
+	classes := make([]string, rows)
+	scores := make([]float64, rows)
+	for i := range rows {
+		classes[i] = fmt.Sprintf("class-%d", i%10)
+		scores[i] = float64(i%100) / 100
+	}
+	columns := []api.MetricsTableColumn{
+		{Name: "class", Type: api.MetricsTableString, StringValues: classes},
+		{Name: "score", Type: api.MetricsTableDouble, DoubleValues: scores},
+	}
+
+	data, err := Encode(columns)
+	require.NoError(t, err)
+	encodedJSON, err := json.Marshal(columns)
+	require.NoError(t, err)
+	assert.Less(t, len(data), len(encodedJSON)/10)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns []api.MetricsTableColumn
+	}{
+		{name: "no columns"},
+		{name: "unnamed column", columns: []api.MetricsTableColumn{{Type: api.MetricsTableDouble}}},
+		{name: "duplicate column", columns: []api.MetricsTableColumn{{Name: "a", Type: api.MetricsTableDouble}, {Name: "a", Type: api.MetricsTableDouble}}},
+		{name: "unknown type", columns: []api.MetricsTableColumn{{Name: "a", Type: "INT"}}},
+		{name: "mismatched values", columns: []api.MetricsTableColumn{{Name: "a", Type: api.MetricsTableDouble, StringValues: []string{"x"}}}},
+		{name: "ragged columns", columns: []api.MetricsTableColumn{
+			{Name: "a", Type: api.MetricsTableDouble, DoubleValues: []float64{1, 2}},
+			{Name: "b", Type: api.MetricsTableString, StringValues: []string{"x"}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Validate(tt.columns)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	data, err := Encode([]api.MetricsTableColumn{{Name: "a", Type: api.MetricsTableDouble, DoubleValues: []float64{1, 2}}})
+	require.NoError(t, err)
+
+	for _, invalid := range [][]byte{nil, []byte("MRT0"), data[:len(data)-1], append(data, 0)} {
+		_, err := Decode(invalid, nil)
+		assert.Error(t, err)
+	}
+}
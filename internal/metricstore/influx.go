@@ -0,0 +1,245 @@
+package metricstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// influxMeasurement is the measurement of the metric history points, tagged by experiment, run and metric name.
+const influxMeasurement = "experiment_run_metric"
+
+type influxStore struct {
+	url    string
+	org    string
+	bucket string
+	token  string
+	client *http.Client
+}
+
+// NewInfluxStore returns a Store writing and querying the metric history in an InfluxDB v2 bucket. Points of the
+// same metric logged at the same millisecond overwrite each other.
+func NewInfluxStore(baseURL, org, bucket, token string) Store {
+	return &influxStore{
+		url:    strings.TrimSuffix(baseURL, "/"),
+		org:    org,
+		bucket: bucket,
+		token:  token,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (s *influxStore) Write(ctx context.Context, points ...Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	var body bytes.Buffer
+	for _, p := range points {
+		// Influx drops empty tags, which would make the point unreadable
+		if p.ExperimentID == "" || p.ExperimentRunID == "" || p.Name == "" {
+			return fmt.Errorf("experiment id, experiment run id and name are required to write metric history to influx")
+		}
+
+		fmt.Fprintf(&body, "%s,experiment_id=%s,experiment_run_id=%s,name=%s value=%s,step=%di %d\n",
+			influxMeasurement,
+			escapeInfluxTag(p.ExperimentID),
+			escapeInfluxTag(p.ExperimentRunID),
+			escapeInfluxTag(p.Name),
+			strconv.FormatFloat(p.Value, 'g', -1, 64),
+			p.Step,
+			p.Timestamp,
+		)
+	}
+
+	params := url.Values{"org": {s.org}, "bucket": {s.bucket}, "precision": {"ms"}}
+
+	_, err := s.do(ctx, "/api/v2/write?"+params.Encode(), "text/plain; charset=utf-8", &body)
+	if err != nil {
+		return fmt.Errorf("error writing metric history to influx: %w", err)
+	}
+
+	return nil
+}
+
+func (s *influxStore) Query(ctx context.Context, query Query) (*Page, error) {
+	limit, offset, err := pageBounds(query)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query": s.flux(query, limit+1, offset),
+		"type":  "flux",
+		"dialect": map[string]any{
+			"header":      true,
+			"annotations": []string{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"org": {s.org}}
+
+	response, err := s.do(ctx, "/api/v2/query?"+params.Encode(), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error reading metric history from influx: %w", err)
+	}
+
+	points, err := parseInfluxCSV(response)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metric history from influx: %w", err)
+	}
+
+	return nextPage(points, limit, offset), nil
+}
+
+func (s *influxStore) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// flux builds the query returning one row per point with the step and value fields pivoted into columns.
+func (s *influxStore) flux(query Query, limit, offset int) string {
+	filters := []string{fmt.Sprintf("r._measurement == %s", fluxString(influxMeasurement))}
+	if query.ExperimentRunID != nil {
+		filters = append(filters, fmt.Sprintf("r.experiment_run_id == %s", fluxString(*query.ExperimentRunID)))
+	}
+	if query.Name != nil && *query.Name != "" {
+		filters = append(filters, fmt.Sprintf("r.name == %s", fluxString(*query.Name)))
+	}
+
+	var flux strings.Builder
+	fmt.Fprintf(&flux, "from(bucket: %s)\n", fluxString(s.bucket))
+	flux.WriteString("  |> range(start: 0)\n")
+	fmt.Fprintf(&flux, "  |> filter(fn: (r) => %s)\n", strings.Join(filters, " and "))
+	flux.WriteString("  |> pivot(rowKey: [\"_time\"], columnKey: [\"_field\"], valueColumn: \"_value\")\n")
+	flux.WriteString("  |> group()\n")
+	if len(query.Steps) > 0 {
+		steps := make([]string, 0, len(query.Steps))
+		for _, step := range query.Steps {
+			steps = append(steps, strconv.FormatInt(step, 10))
+		}
+		fmt.Fprintf(&flux, "  |> filter(fn: (r) => contains(value: r.step, set: [%s]))\n", strings.Join(steps, ", "))
+	}
+	flux.WriteString("  |> sort(columns: [\"experiment_run_id\", \"name\", \"step\", \"_time\"])\n")
+	fmt.Fprintf(&flux, "  |> limit(n: %d, offset: %d)\n", limit, offset)
+	flux.WriteString("  |> keep(columns: [\"_time\", \"experiment_id\", \"experiment_run_id\", \"name\", \"step\", \"value\"])\n")
+
+	return flux.String()
+}
+
+func (s *influxStore) do(ctx context.Context, path, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if s.token != "" {
+		req.Header.Set("Authorization", "Token "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(response)))
+	}
+
+	return response, nil
+}
+
+// parseInfluxCSV reads the points from the CSV response of the flux query.
+func parseInfluxCSV(response []byte) ([]Point, error) {
+	reader := csv.NewReader(bytes.NewReader(response))
+	reader.FieldsPerRecord = -1
+
+	points := []Point{}
+	var columns map[string]int
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Every table repeats the header, the empty lines between tables are skipped by the reader
+		if columns == nil || (columns["_time"] < len(record) && record[columns["_time"]] == "_time") {
+			columns = make(map[string]int, len(record))
+			for i, name := range record {
+				columns[name] = i
+			}
+			for _, name := range []string{"_time", "experiment_id", "experiment_run_id", "name", "step", "value"} {
+				if _, ok := columns[name]; !ok {
+					return nil, fmt.Errorf("missing column %s", name)
+				}
+			}
+			continue
+		}
+
+		field := func(name string) string {
+			if i := columns[name]; i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		timestamp, err := time.Parse(time.RFC3339Nano, field("_time"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q: %w", field("_time"), err)
+		}
+		step, err := strconv.ParseInt(field("step"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid step %q: %w", field("step"), err)
+		}
+		value, err := strconv.ParseFloat(field("value"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", field("value"), err)
+		}
+
+		points = append(points, Point{
+			ExperimentID:    field("experiment_id"),
+			ExperimentRunID: field("experiment_run_id"),
+			Name:            field("name"),
+			Value:           value,
+			Step:            step,
+			Timestamp:       timestamp.UnixMilli(),
+		})
+	}
+
+	return points, nil
+}
+
+var influxTagEscaper = strings.NewReplacer(`\`, `\\`, ",", `\,`, "=", `\=`, " ", `\ `, "\n", `\n`)
+
+func escapeInfluxTag(value string) string {
+	return influxTagEscaper.Replace(value)
+}
+
+var fluxStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`, "\n", `\n`)
+
+func fluxString(value string) string {
+	return `"` + fluxStringEscaper.Replace(value) + `"`
+}
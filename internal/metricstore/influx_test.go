@@ -0,0 +1,83 @@
+package metricstore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfluxStoreWrite(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/write", r.URL.Path)
+		assert.Equal(t, "my-org", r.URL.Query().Get("org"))
+		assert.Equal(t, "metrics", r.URL.Query().Get("bucket"))
+		assert.Equal(t, "ms", r.URL.Query().Get("precision"))
+		assert.Equal(t, "Token secret", r.Header.Get("Authorization"))
+
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := NewInfluxStore(server.URL+"/", "my-org", "metrics", "secret")
+
+	err := store.Write(context.Background(),
+		Point{ExperimentID: "1", ExperimentRunID: "2", Name: "train loss", Value: 0.25, Step: 3, Timestamp: 1700000000000},
+		Point{ExperimentID: "1", ExperimentRunID: "2", Name: "acc,top=1", Value: 1, Step: 4, Timestamp: 1700000000001},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		"experiment_run_metric,experiment_id=1,experiment_run_id=2,name=train\\ loss value=0.25,step=3i 1700000000000\n"+
+			"experiment_run_metric,experiment_id=1,experiment_run_id=2,name=acc\\,top\\=1 value=1,step=4i 1700000000001\n",
+		body)
+
+	err = store.Write(context.Background(), Point{ExperimentRunID: "2", Name: "loss"})
+	assert.Error(t, err, "points without an experiment id are rejected")
+}
+
+func TestInfluxStoreQuery(t *testing.T) {
+	var request struct {
+		Query string `json:"query"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/query", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		_, _ = io.WriteString(w, ",result,table,_time,experiment_id,experiment_run_id,name,step,value\r\n"+
+			",_result,0,2023-11-14T22:13:20Z,1,2,loss,1,0.5\r\n"+
+			",_result,0,2023-11-14T22:13:20.001Z,1,2,loss,2,0.25\r\n"+
+			"\r\n"+
+			",result,table,_time,experiment_id,experiment_run_id,name,step,value\r\n"+
+			",_result,1,2023-11-14T22:13:20.002Z,1,2,loss,3,0.125\r\n")
+	}))
+	defer server.Close()
+
+	store := NewInfluxStore(server.URL, "my-org", "metrics", "")
+
+	runID := `2"`
+	page, err := store.Query(context.Background(), Query{ExperimentRunID: &runID, Steps: []int64{1, 2, 3}, PageSize: 2})
+	require.NoError(t, err)
+
+	assert.Contains(t, request.Query, `r.experiment_run_id == "2\""`)
+	assert.Contains(t, request.Query, "contains(value: r.step, set: [1, 2, 3])")
+	assert.Contains(t, request.Query, "limit(n: 3, offset: 0)")
+
+	require.Len(t, page.Items, 2)
+	assert.Equal(t, Point{ExperimentID: "1", ExperimentRunID: "2", Name: "loss", Value: 0.5, Step: 1, Timestamp: 1700000000000}, page.Items[0])
+	assert.Equal(t, int64(1700000000001), page.Items[1].Timestamp)
+	assert.NotEmpty(t, page.NextPageToken)
+
+	page, err = store.Query(context.Background(), Query{PageSize: 2, NextPageToken: page.NextPageToken})
+	require.NoError(t, err)
+	assert.Contains(t, request.Query, "limit(n: 3, offset: 2)")
+	assert.NotContains(t, request.Query, "experiment_run_id ==")
+}
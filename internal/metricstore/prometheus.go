@@ -0,0 +1,154 @@
+package metricstore
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// prometheusValueMetric holds the logged values, labeled by experiment, run and metric name.
+	prometheusValueMetric = "model_registry_experiment_run_metric"
+	// prometheusStepMetric holds the step of each logged value, a step label would create a series per step.
+	prometheusStepMetric = "model_registry_experiment_run_metric_step"
+)
+
+type prometheusStore struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewPrometheusStore returns a write only Store sending the metric history to a Prometheus remote-write endpoint.
+func NewPrometheusStore(remoteWriteURL, token string) Store {
+	return &prometheusStore{
+		url:    remoteWriteURL,
+		token:  token,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type prometheusSample struct {
+	value     float64
+	timestamp int64
+}
+
+type prometheusSeries struct {
+	labels  [][2]string
+	samples []prometheusSample
+}
+
+func (s *prometheusStore) Write(ctx context.Context, points ...Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(s2.EncodeSnappy(nil, encodeWriteRequest(points))))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error writing metric history to prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("error writing metric history to prometheus: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func (s *prometheusStore) Query(ctx context.Context, query Query) (*Page, error) {
+	return nil, fmt.Errorf("%w: the %s metric store is write only", ErrQueryNotSupported, DriverPrometheus)
+}
+
+func (s *prometheusStore) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// encodeWriteRequest encodes the points as a remote-write protobuf WriteRequest, with a value and a step series
+// per metric, samples ordered by timestamp.
+func encodeWriteRequest(points []Point) []byte {
+	index := map[string]*prometheusSeries{}
+	series := []*prometheusSeries{}
+
+	add := func(name string, p Point, value float64) {
+		labels := [][2]string{
+			{"__name__", name},
+			{"experiment_id", p.ExperimentID},
+			{"experiment_run_id", p.ExperimentRunID},
+			{"metric", p.Name},
+		}
+
+		key := fmt.Sprint(labels)
+		ts, ok := index[key]
+		if !ok {
+			ts = &prometheusSeries{labels: labels}
+			index[key] = ts
+			series = append(series, ts)
+		}
+
+		ts.samples = append(ts.samples, prometheusSample{value: value, timestamp: p.Timestamp})
+	}
+
+	for _, p := range points {
+		add(prometheusValueMetric, p, p.Value)
+		add(prometheusStepMetric, p, float64(p.Step))
+	}
+
+	var request []byte
+	for _, ts := range series {
+		slices.SortStableFunc(ts.samples, func(a, b prometheusSample) int {
+			return cmp.Compare(a.timestamp, b.timestamp)
+		})
+
+		var encoded []byte
+		for _, label := range ts.labels {
+			var l []byte
+			l = protowire.AppendTag(l, 1, protowire.BytesType)
+			l = protowire.AppendString(l, label[0])
+			l = protowire.AppendTag(l, 2, protowire.BytesType)
+			l = protowire.AppendString(l, label[1])
+
+			encoded = protowire.AppendTag(encoded, 1, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, l)
+		}
+		for _, sample := range ts.samples {
+			var s []byte
+			s = protowire.AppendTag(s, 1, protowire.Fixed64Type)
+			s = protowire.AppendFixed64(s, math.Float64bits(sample.value))
+			s = protowire.AppendTag(s, 2, protowire.VarintType)
+			s = protowire.AppendVarint(s, uint64(sample.timestamp))
+
+			encoded = protowire.AppendTag(encoded, 2, protowire.BytesType)
+			encoded = protowire.AppendBytes(encoded, s)
+		}
+
+		request = protowire.AppendTag(request, 1, protowire.BytesType)
+		request = protowire.AppendBytes(request, encoded)
+	}
+
+	return request
+}
@@ -0,0 +1,126 @@
+package metricstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type decodedSeries struct {
+	labels  map[string]string
+	samples []prometheusSample
+}
+
+// decodeWriteRequest decodes the fields of a remote-write WriteRequest used by the store.
+func decodeWriteRequest(t *testing.T, b []byte) []decodedSeries {
+	fields := func(b []byte, visit func(num protowire.Number, typ protowire.Type, value []byte, scalar uint64)) {
+		for len(b) > 0 {
+			num, typ, n := protowire.ConsumeTag(b)
+			require.GreaterOrEqual(t, n, 0)
+			b = b[n:]
+
+			switch typ {
+			case protowire.BytesType:
+				v, n := protowire.ConsumeBytes(b)
+				require.GreaterOrEqual(t, n, 0)
+				visit(num, typ, v, 0)
+				b = b[n:]
+			case protowire.Fixed64Type:
+				v, n := protowire.ConsumeFixed64(b)
+				require.GreaterOrEqual(t, n, 0)
+				visit(num, typ, nil, v)
+				b = b[n:]
+			case protowire.VarintType:
+				v, n := protowire.ConsumeVarint(b)
+				require.GreaterOrEqual(t, n, 0)
+				visit(num, typ, nil, v)
+				b = b[n:]
+			default:
+				t.Fatalf("unexpected wire type %v", typ)
+			}
+		}
+	}
+
+	var series []decodedSeries
+	fields(b, func(_ protowire.Number, _ protowire.Type, ts []byte, _ uint64) {
+		s := decodedSeries{labels: map[string]string{}}
+		fields(ts, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) {
+			switch num {
+			case 1:
+				var name, value string
+				fields(v, func(num protowire.Number, _ protowire.Type, v []byte, _ uint64) {
+					if num == 1 {
+						name = string(v)
+					} else {
+						value = string(v)
+					}
+				})
+				s.labels[name] = value
+			case 2:
+				var sample prometheusSample
+				fields(v, func(num protowire.Number, _ protowire.Type, _ []byte, scalar uint64) {
+					if num == 1 {
+						sample.value = math.Float64frombits(scalar)
+					} else {
+						sample.timestamp = int64(scalar)
+					}
+				})
+				s.samples = append(s.samples, sample)
+			}
+		})
+		series = append(series, s)
+	})
+
+	return series
+}
+
+func TestPrometheusStoreWrite(t *testing.T) {
+	var series []decodedSeries
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+		compressed, _ := io.ReadAll(r.Body)
+		body, err := s2.Decode(nil, compressed)
+		require.NoError(t, err)
+		series = decodeWriteRequest(t, body)
+	}))
+	defer server.Close()
+
+	store := NewPrometheusStore(server.URL+"/api/v1/write", "secret")
+
+	err := store.Write(context.Background(),
+		Point{ExperimentID: "1", ExperimentRunID: "2", Name: "loss", Value: 0.25, Step: 2, Timestamp: 2000},
+		Point{ExperimentID: "1", ExperimentRunID: "2", Name: "loss", Value: 0.5, Step: 1, Timestamp: 1000},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, series, 2)
+	assert.Equal(t, map[string]string{
+		"__name__":          prometheusValueMetric,
+		"experiment_id":     "1",
+		"experiment_run_id": "2",
+		"metric":            "loss",
+	}, series[0].labels)
+	assert.Equal(t, []prometheusSample{{value: 0.5, timestamp: 1000}, {value: 0.25, timestamp: 2000}}, series[0].samples)
+
+	assert.Equal(t, prometheusStepMetric, series[1].labels["__name__"])
+	assert.Equal(t, []prometheusSample{{value: 1, timestamp: 1000}, {value: 2, timestamp: 2000}}, series[1].samples)
+}
+
+func TestPrometheusStoreQuery(t *testing.T) {
+	store := NewPrometheusStore("http://localhost/api/v1/write", "")
+
+	_, err := store.Query(context.Background(), Query{})
+	assert.True(t, errors.Is(err, ErrQueryNotSupported))
+}
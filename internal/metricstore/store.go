@@ -0,0 +1,156 @@
+// Package metricstore offloads step based experiment run metrics to a time series database, so that the metric
+// history of huge training runs does not grow the relational property tables.
+package metricstore
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Driver names the time series backend used to store the metric history.
+type Driver string
+
+const (
+	// DriverProperty keeps the metric history in the relational property tables, it is the default.
+	DriverProperty Driver = "property"
+	// DriverTimescale stores the metric history in a TimescaleDB hypertable.
+	DriverTimescale Driver = "timescale"
+	// DriverInflux stores the metric history in an InfluxDB v2 bucket.
+	DriverInflux Driver = "influx"
+	// DriverPrometheus sends the metric history to a Prometheus remote-write endpoint, it can not be read back.
+	DriverPrometheus Driver = "prometheus"
+)
+
+// DefaultPageSize is the number of points returned by a query without a page size.
+const DefaultPageSize = 100
+
+// ErrQueryNotSupported is returned by write only stores when reading the metric history.
+var ErrQueryNotSupported = errors.New("metric store does not support queries")
+
+// Config configures the metric store.
+type Config struct {
+	// Driver selects the backend, empty or DriverProperty disables the offload.
+	Driver Driver
+	// URL is the DSN of the TimescaleDB database, or the base or remote-write URL of InfluxDB and Prometheus.
+	URL string
+	// Token authenticates the requests to InfluxDB and Prometheus.
+	Token string
+	// Org and Bucket locate the InfluxDB bucket.
+	Org    string
+	Bucket string
+}
+
+// Validate checks that the options required by the configured driver are set.
+func (c *Config) Validate() error {
+	switch c.Driver {
+	case "", DriverProperty:
+		return nil
+	case DriverTimescale, DriverPrometheus:
+		if c.URL == "" {
+			return fmt.Errorf("metric store url is required for the %s driver", c.Driver)
+		}
+	case DriverInflux:
+		if c.URL == "" || c.Org == "" || c.Bucket == "" {
+			return fmt.Errorf("metric store url, org and bucket are required for the %s driver", c.Driver)
+		}
+	default:
+		return fmt.Errorf("unsupported metric store driver: %s. Supported drivers: %s, %s, %s, %s", c.Driver, DriverProperty, DriverTimescale, DriverInflux, DriverPrometheus)
+	}
+
+	return nil
+}
+
+// Enabled reports whether the metric history is offloaded from the property tables.
+func (c *Config) Enabled() bool {
+	return c.Driver != "" && c.Driver != DriverProperty
+}
+
+// Point is a single logged value of an experiment run metric.
+type Point struct {
+	ExperimentID    string
+	ExperimentRunID string
+	Name            string
+	Value           float64
+	Step            int64
+	// Timestamp is the time the value was logged, in milliseconds since epoch.
+	Timestamp int64
+}
+
+// Query selects the points to read, nil or empty fields match everything.
+type Query struct {
+	ExperimentRunID *string
+	Name            *string
+	Steps           []int64
+	PageSize        int32
+	NextPageToken   string
+}
+
+// Page is a page of points ordered by experiment run, name, step and timestamp.
+type Page struct {
+	Items []Point
+	// NextPageToken is empty on the last page.
+	NextPageToken string
+}
+
+// Store persists the metric history of experiment runs.
+type Store interface {
+	// Write appends points to the metric history.
+	Write(ctx context.Context, points ...Point) error
+	// Query reads a page of the metric history, write only stores return ErrQueryNotSupported.
+	Query(ctx context.Context, query Query) (*Page, error)
+	// Close releases the connections held by the store.
+	Close() error
+}
+
+// New returns the store configured by cfg, or nil when the metric history stays in the property tables.
+func New(cfg Config) (Store, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch cfg.Driver {
+	case DriverTimescale:
+		return NewTimescaleStore(cfg.URL)
+	case DriverInflux:
+		return NewInfluxStore(cfg.URL, cfg.Org, cfg.Bucket, cfg.Token), nil
+	case DriverPrometheus:
+		return NewPrometheusStore(cfg.URL, cfg.Token), nil
+	}
+
+	return nil, nil
+}
+
+// pageBounds returns the page size and offset encoded in the query page token.
+func pageBounds(query Query) (limit int, offset int, err error) {
+	limit = int(query.PageSize)
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	if query.NextPageToken != "" {
+		decoded, err := base64.URLEncoding.DecodeString(query.NextPageToken)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid next page token: %w", err)
+		}
+		offset, err = strconv.Atoi(string(decoded))
+		if err != nil || offset < 0 {
+			return 0, 0, fmt.Errorf("invalid next page token: %s", query.NextPageToken)
+		}
+	}
+
+	return limit, offset, nil
+}
+
+// nextPage trims the extra point fetched past the page size and returns the page with its next page token.
+func nextPage(points []Point, limit, offset int) *Page {
+	page := &Page{Items: points}
+	if len(points) > limit {
+		page.Items = points[:limit]
+		page.NextPageToken = base64.URLEncoding.EncodeToString([]byte(strconv.Itoa(offset + limit)))
+	}
+
+	return page
+}
@@ -0,0 +1,63 @@
+package metricstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		enabled bool
+		wantErr bool
+	}{
+		{name: "default", config: Config{}},
+		{name: "property", config: Config{Driver: DriverProperty}},
+		{name: "timescale", config: Config{Driver: DriverTimescale, URL: "postgres://localhost/metrics"}, enabled: true},
+		{name: "timescale without url", config: Config{Driver: DriverTimescale}, enabled: true, wantErr: true},
+		{name: "influx", config: Config{Driver: DriverInflux, URL: "http://localhost:8086", Org: "org", Bucket: "metrics"}, enabled: true},
+		{name: "influx without bucket", config: Config{Driver: DriverInflux, URL: "http://localhost:8086", Org: "org"}, enabled: true, wantErr: true},
+		{name: "prometheus", config: Config{Driver: DriverPrometheus, URL: "http://localhost:9090/api/v1/write"}, enabled: true},
+		{name: "unknown", config: Config{Driver: "graphite"}, enabled: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.enabled, tt.config.Enabled())
+		})
+	}
+}
+
+func TestPagination(t *testing.T) {
+	limit, offset, err := pageBounds(Query{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPageSize, limit)
+	assert.Equal(t, 0, offset)
+
+	points := []Point{{Step: 1}, {Step: 2}, {Step: 3}}
+
+	page := nextPage(points, 2, 0)
+	assert.Len(t, page.Items, 2)
+	require.NotEmpty(t, page.NextPageToken)
+
+	limit, offset, err = pageBounds(Query{PageSize: 2, NextPageToken: page.NextPageToken})
+	require.NoError(t, err)
+	assert.Equal(t, 2, limit)
+	assert.Equal(t, 2, offset)
+
+	page = nextPage(points[2:], 2, 2)
+	assert.Len(t, page.Items, 1)
+	assert.Empty(t, page.NextPageToken)
+
+	_, _, err = pageBounds(Query{NextPageToken: "not a token"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package metricstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const timescaleTable = "metric_history_points"
+
+// timescalePoint is a row of the metric history hypertable.
+type timescalePoint struct {
+	Time            time.Time `gorm:"column:time"`
+	ExperimentID    string    `gorm:"column:experiment_id"`
+	ExperimentRunID string    `gorm:"column:experiment_run_id"`
+	Name            string    `gorm:"column:name"`
+	Step            int64     `gorm:"column:step"`
+	Value           float64   `gorm:"column:value"`
+}
+
+func (timescalePoint) TableName() string {
+	return timescaleTable
+}
+
+type timescaleStore struct {
+	db *gorm.DB
+}
+
+// NewTimescaleStore connects to the TimescaleDB database at dsn and creates the metric history hypertable if needed.
+func NewTimescaleStore(dsn string) (Store, error) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to timescale: %w", err)
+	}
+
+	return newTimescaleStore(db)
+}
+
+func newTimescaleStore(db *gorm.DB) (*timescaleStore, error) {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS ` + timescaleTable + ` (
+			time TIMESTAMPTZ NOT NULL,
+			experiment_id TEXT NOT NULL,
+			experiment_run_id TEXT NOT NULL,
+			name TEXT NOT NULL,
+			step BIGINT NOT NULL,
+			value DOUBLE PRECISION NOT NULL
+		)`,
+		`SELECT create_hypertable('` + timescaleTable + `', 'time', if_not_exists => TRUE)`,
+		`CREATE INDEX IF NOT EXISTS idx_` + timescaleTable + `_run ON ` + timescaleTable + ` (experiment_run_id, name, step, time)`,
+	}
+
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return nil, fmt.Errorf("error creating timescale metric history hypertable: %w", err)
+		}
+	}
+
+	return &timescaleStore{db: db}, nil
+}
+
+func (s *timescaleStore) Write(ctx context.Context, points ...Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	rows := make([]timescalePoint, 0, len(points))
+	for _, p := range points {
+		rows = append(rows, timescalePoint{
+			Time:            time.UnixMilli(p.Timestamp).UTC(),
+			ExperimentID:    p.ExperimentID,
+			ExperimentRunID: p.ExperimentRunID,
+			Name:            p.Name,
+			Step:            p.Step,
+			Value:           p.Value,
+		})
+	}
+
+	if err := s.db.WithContext(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("error writing metric history to timescale: %w", err)
+	}
+
+	return nil
+}
+
+func (s *timescaleStore) Query(ctx context.Context, query Query) (*Page, error) {
+	limit, offset, err := pageBounds(query)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.db.WithContext(ctx).Model(&timescalePoint{})
+	if query.ExperimentRunID != nil {
+		tx = tx.Where("experiment_run_id = ?", *query.ExperimentRunID)
+	}
+	if query.Name != nil && *query.Name != "" {
+		tx = tx.Where("name = ?", *query.Name)
+	}
+	if len(query.Steps) > 0 {
+		tx = tx.Where("step IN ?", query.Steps)
+	}
+
+	// Fetch one extra row to find out if there is a next page
+	var rows []timescalePoint
+	if err := tx.Order("experiment_run_id, name, step, time").Limit(limit + 1).Offset(offset).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error reading metric history from timescale: %w", err)
+	}
+
+	points := make([]Point, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, Point{
+			ExperimentID:    row.ExperimentID,
+			ExperimentRunID: row.ExperimentRunID,
+			Name:            row.Name,
+			Value:           row.Value,
+			Step:            row.Step,
+			Timestamp:       row.Time.UnixMilli(),
+		})
+	}
+
+	return nextPage(points, limit, offset), nil
+}
+
+func (s *timescaleStore) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
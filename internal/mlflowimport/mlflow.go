@@ -0,0 +1,109 @@
+package mlflowimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// registeredModel is a registered model of the MLflow model registry REST api.
+type registeredModel struct {
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	LastUpdatedTimestamp int64  `json:"last_updated_timestamp"`
+	Tags                 []tag  `json:"tags"`
+}
+
+// modelVersion is a model version of the MLflow model registry REST api.
+type modelVersion struct {
+	Name                 string `json:"name"`
+	Version              string `json:"version"`
+	Description          string `json:"description"`
+	UserId               string `json:"user_id"`
+	Source               string `json:"source"`
+	RunId                string `json:"run_id"`
+	CurrentStage         string `json:"current_stage"`
+	LastUpdatedTimestamp int64  `json:"last_updated_timestamp"`
+	Tags                 []tag  `json:"tags"`
+}
+
+type tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// mlflowClient reads the model registry of an MLflow tracking server.
+type mlflowClient struct {
+	url   string
+	token string
+}
+
+// searchRegisteredModels returns a page of the registered models in the order of their last update.
+func (c *mlflowClient) searchRegisteredModels(ctx context.Context, pageSize int, pageToken string) ([]registeredModel, string, error) {
+	var page struct {
+		RegisteredModels []registeredModel `json:"registered_models"`
+		NextPageToken    string            `json:"next_page_token"`
+	}
+	err := c.get(ctx, "/api/2.0/mlflow/registered-models/search", searchQuery(pageSize, pageToken), &page)
+	return page.RegisteredModels, page.NextPageToken, err
+}
+
+// searchModelVersions returns a page of the model versions of all the registered models in the order of their last
+// update.
+func (c *mlflowClient) searchModelVersions(ctx context.Context, pageSize int, pageToken string) ([]modelVersion, string, error) {
+	var page struct {
+		ModelVersions []modelVersion `json:"model_versions"`
+		NextPageToken string         `json:"next_page_token"`
+	}
+	err := c.get(ctx, "/api/2.0/mlflow/model-versions/search", searchQuery(pageSize, pageToken), &page)
+	return page.ModelVersions, page.NextPageToken, err
+}
+
+func (c *mlflowClient) getRegisteredModel(ctx context.Context, name string) (registeredModel, error) {
+	var found struct {
+		RegisteredModel registeredModel `json:"registered_model"`
+	}
+	err := c.get(ctx, "/api/2.0/mlflow/registered-models/get", url.Values{"name": {name}}, &found)
+	return found.RegisteredModel, err
+}
+
+func searchQuery(pageSize int, pageToken string) url.Values {
+	query := url.Values{
+		"max_results": {strconv.Itoa(pageSize)},
+		"order_by":    {"last_updated_timestamp ASC"},
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+	return query
+}
+
+func (c *mlflowClient) get(ctx context.Context, path string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.url, "/")+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error reading %s from mlflow: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("error reading %s from mlflow: %s: %s", path, resp.Status, body)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response of mlflow to %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+// Package mlflowimport imports the registered models and model versions of an MLflow tracking server into a model
+// registry server, through their REST apis.
+package mlflowimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/clientutil"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultPageSize is the number of MLflow entities searched at once.
+const DefaultPageSize = 100
+
+const (
+	// externalIdPrefix prefixes the external ids of the imported entities, after which they are found on later runs.
+	externalIdPrefix = "mlflow:"
+	// stageProperty is the custom property holding the stage of the model versions.
+	stageProperty = "stage"
+	// runIdProperty is the custom property holding the MLflow run which logged the model of a model version.
+	runIdProperty = "mlflow_run_id"
+)
+
+// Config configures an import.
+type Config struct {
+	MLflowURL   string
+	MLflowToken string
+	URL         string
+	Token       string
+	// StateFile records the high-watermarks of the import, the last update time of the MLflow entities imported, so
+	// that the next import only reads those updated since. Imports without state file import all the entities.
+	StateFile string
+	PageSize  int
+}
+
+// Summary counts the entities imported.
+type Summary struct {
+	RegisteredModels int
+	ModelVersions    int
+	// Skipped counts the MLflow entities not updated since the previous import.
+	Skipped int
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d registered models and %d model versions, %d skipped as unchanged", s.RegisteredModels, s.ModelVersions, s.Skipped)
+}
+
+// state is the progress of the imports saved in the state file.
+type state struct {
+	// RegisteredModels is the last update time of the last MLflow registered model imported, in milliseconds
+	RegisteredModels int64 `json:"registeredModels"`
+	// ModelVersions is the last update time of the last MLflow model version imported, in milliseconds
+	ModelVersions int64 `json:"modelVersions"`
+}
+
+// Importer imports the model registry of an MLflow tracking server into the model registry server reached with its
+// client.
+type Importer struct {
+	cfg    Config
+	mlflow *mlflowClient
+	client *openapi.APIClient
+}
+
+// NewImporter returns an Importer from the MLflow tracking server at cfg.MLflowURL into the model registry server at
+// cfg.URL.
+func NewImporter(cfg Config) *Importer {
+	if cfg.PageSize <= 0 {
+		cfg.PageSize = DefaultPageSize
+	}
+
+	clientCfg := openapi.NewConfiguration()
+	clientCfg.Servers = openapi.ServerConfigurations{{URL: strings.TrimSuffix(cfg.URL, "/")}}
+	if cfg.Token != "" {
+		clientCfg.AddDefaultHeader("Authorization", "Bearer "+cfg.Token)
+	}
+	return &Importer{
+		cfg:    cfg,
+		mlflow: &mlflowClient{url: cfg.MLflowURL, token: cfg.MLflowToken},
+		client: openapi.NewAPIClient(clientCfg),
+	}
+}
+
+// Import creates or updates a registered model for each MLflow registered model, then a model version and its model
+// artifact for each MLflow model version, in the order of their last update. The imported entities are found by
+// their external id "mlflow:<name>" and "mlflow:<name>/<version>" on later imports, the tags are imported as string
+// custom properties, merged with the existing ones. The entities updated before the high-watermarks of the state
+// file are skipped, the high-watermarks are saved after each entity. The MLflow entities deleted since a previous
+// import are not deleted from the model registry.
+func (i *Importer) Import(ctx context.Context) (Summary, error) {
+	var summary Summary
+
+	progress, err := i.loadState()
+	if err != nil {
+		return summary, err
+	}
+	if progress.RegisteredModels > 0 || progress.ModelVersions > 0 {
+		glog.Infof("Importing the MLflow registered models updated since %d and model versions since %d", progress.RegisteredModels, progress.ModelVersions)
+	}
+
+	// The entities updated at the high-watermark are imported again, as others may have been updated at the same time
+	pageToken := ""
+	for {
+		models, next, err := i.mlflow.searchRegisteredModels(ctx, i.cfg.PageSize, pageToken)
+		if err != nil {
+			return summary, err
+		}
+		for _, model := range models {
+			if model.LastUpdatedTimestamp < progress.RegisteredModels {
+				summary.Skipped++
+				continue
+			}
+			if _, err := i.importRegisteredModel(ctx, model); err != nil {
+				return summary, err
+			}
+			summary.RegisteredModels++
+
+			progress.RegisteredModels = model.LastUpdatedTimestamp
+			if err := i.saveState(progress); err != nil {
+				return summary, err
+			}
+		}
+		if next == "" {
+			break
+		}
+		pageToken = next
+	}
+
+	pageToken = ""
+	for {
+		versions, next, err := i.mlflow.searchModelVersions(ctx, i.cfg.PageSize, pageToken)
+		if err != nil {
+			return summary, err
+		}
+		for _, version := range versions {
+			if version.LastUpdatedTimestamp < progress.ModelVersions {
+				summary.Skipped++
+				continue
+			}
+			if err := i.importModelVersion(ctx, version); err != nil {
+				return summary, err
+			}
+			summary.ModelVersions++
+
+			progress.ModelVersions = version.LastUpdatedTimestamp
+			if err := i.saveState(progress); err != nil {
+				return summary, err
+			}
+		}
+		if next == "" {
+			return summary, nil
+		}
+		pageToken = next
+	}
+}
+
+// importRegisteredModel creates or updates the registered model of an MLflow registered model.
+func (i *Importer) importRegisteredModel(ctx context.Context, model registeredModel) (*openapi.RegisteredModel, error) {
+	externalId := externalIdPrefix + model.Name
+	existing, resp, err := i.client.ModelRegistryServiceAPI.FindRegisteredModel(ctx).ExternalId(externalId).Execute()
+	if err != nil && !isNotFound(resp) {
+		return nil, fmt.Errorf("error finding registered model %s: %w", model.Name, clientutil.RestError(err))
+	}
+
+	if existing == nil {
+		created, _, err := i.client.ModelRegistryServiceAPI.CreateRegisteredModel(ctx).RegisteredModelCreate(openapi.RegisteredModelCreate{
+			Name:             model.Name,
+			ExternalId:       &externalId,
+			Description:      optional(model.Description),
+			CustomProperties: customProperties(nil, model.Tags),
+		}).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("error creating registered model %s: %w", model.Name, clientutil.RestError(err))
+		}
+		glog.V(2).Infof("Imported registered model %s as %s", model.Name, created.GetId())
+		return created, nil
+	}
+
+	updated, _, err := i.client.ModelRegistryServiceAPI.UpdateRegisteredModel(ctx, existing.GetId()).RegisteredModelUpdate(openapi.RegisteredModelUpdate{
+		Description:      optional(model.Description),
+		CustomProperties: customProperties(existing.GetCustomProperties(), model.Tags),
+	}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("error updating registered model %s: %w", model.Name, clientutil.RestError(err))
+	}
+	glog.V(2).Infof("Updated registered model %s", updated.GetId())
+	return updated, nil
+}
+
+// importModelVersion creates or updates the model version of an MLflow model version and its model artifact, the
+// registered model is imported first if it isn't yet.
+func (i *Importer) importModelVersion(ctx context.Context, version modelVersion) error {
+	registeredModel, resp, err := i.client.ModelRegistryServiceAPI.FindRegisteredModel(ctx).ExternalId(externalIdPrefix + version.Name).Execute()
+	switch {
+	case isNotFound(resp):
+		// The registered model was created after the registered models were searched
+		model, err := i.mlflow.getRegisteredModel(ctx, version.Name)
+		if err != nil {
+			return err
+		}
+		if registeredModel, err = i.importRegisteredModel(ctx, model); err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("error finding registered model %s: %w", version.Name, clientutil.RestError(err))
+	}
+
+	externalId := externalIdPrefix + version.Name + "/" + version.Version
+	properties := map[string]openapi.MetadataValue{}
+	if version.CurrentStage != "" && version.CurrentStage != string(api.StageNone) {
+		properties[stageProperty] = stringValue(version.CurrentStage)
+	}
+	if version.RunId != "" {
+		properties[runIdProperty] = stringValue(version.RunId)
+	}
+
+	existing, resp, err := i.client.ModelRegistryServiceAPI.FindModelVersion(ctx).ExternalId(externalId).Execute()
+	if err != nil && !isNotFound(resp) {
+		return fmt.Errorf("error finding model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	var imported *openapi.ModelVersion
+	if existing == nil {
+		imported, _, err = i.client.ModelRegistryServiceAPI.CreateModelVersion(ctx).ModelVersionCreate(openapi.ModelVersionCreate{
+			Name:              version.Version,
+			RegisteredModelId: registeredModel.GetId(),
+			ExternalId:        &externalId,
+			Description:       optional(version.Description),
+			Author:            optional(version.UserId),
+			CustomProperties:  customProperties(properties, version.Tags),
+		}).Execute()
+	} else {
+		for name, value := range existing.GetCustomProperties() {
+			if _, ok := properties[name]; !ok && name != stageProperty {
+				properties[name] = value
+			}
+		}
+		imported, _, err = i.client.ModelRegistryServiceAPI.UpdateModelVersion(ctx, existing.GetId()).ModelVersionUpdate(openapi.ModelVersionUpdate{
+			Description:      optional(version.Description),
+			Author:           optional(version.UserId),
+			CustomProperties: customProperties(properties, version.Tags),
+		}).Execute()
+	}
+	if err != nil {
+		return fmt.Errorf("error importing model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	if version.Source == "" {
+		return nil
+	}
+
+	// The model artifact is named after the registered model, as the artifacts of the model versions are found by name
+	artifacts, _, err := i.client.ModelRegistryServiceAPI.GetModelVersionArtifacts(ctx, imported.GetId()).Name(version.Name).Execute()
+	if err != nil {
+		return fmt.Errorf("error listing artifacts of model version %s: %w", externalId, clientutil.RestError(err))
+	}
+	artifact := openapi.ModelArtifact{
+		ArtifactType: apiutils.Of("model-artifact"),
+		Name:         &version.Name,
+		Uri:          &version.Source,
+	}
+	for _, existing := range artifacts.Items {
+		if existing.ModelArtifact != nil {
+			artifact.Id = existing.ModelArtifact.Id
+			artifact.CustomProperties = existing.ModelArtifact.CustomProperties
+		}
+	}
+	if _, _, err := i.client.ModelRegistryServiceAPI.UpsertModelVersionArtifact(ctx, imported.GetId()).Artifact(openapi.Artifact{ModelArtifact: &artifact}).Execute(); err != nil {
+		return fmt.Errorf("error importing model artifact of model version %s: %w", externalId, clientutil.RestError(err))
+	}
+
+	glog.V(2).Infof("Imported model version %s as %s", externalId, imported.GetId())
+	return nil
+}
+
+// customProperties returns the existing custom properties with the tags, the custom properties of an update replace
+// the existing ones.
+func customProperties(existing map[string]openapi.MetadataValue, tags []tag) map[string]openapi.MetadataValue {
+	properties := make(map[string]openapi.MetadataValue, len(existing)+len(tags))
+	for name, value := range existing {
+		properties[name] = value
+	}
+	for _, tag := range tags {
+		properties[tag.Key] = stringValue(tag.Value)
+	}
+	return properties
+}
+
+func stringValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func isNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func (i *Importer) loadState() (state, error) {
+	progress := state{}
+	if i.cfg.StateFile == "" {
+		return progress, nil
+	}
+
+	data, err := os.ReadFile(i.cfg.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return progress, nil
+	}
+	if err != nil {
+		return progress, fmt.Errorf("error reading import state: %w", err)
+	}
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf("invalid import state %s: %w", i.cfg.StateFile, err)
+	}
+	return progress, nil
+}
+
+func (i *Importer) saveState(progress state) error {
+	if err := clientutil.SaveState(i.cfg.StateFile, progress); err != nil {
+		return fmt.Errorf("error saving import state: %w", err)
+	}
+	return nil
+}
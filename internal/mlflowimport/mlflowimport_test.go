@@ -0,0 +1,155 @@
+package mlflowimport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMLflow serves the model registry search endpoints of an MLflow tracking server, with the offset of the next
+// page as page token.
+type fakeMLflow struct {
+	models   []registeredModel
+	versions []modelVersion
+}
+
+func (f *fakeMLflow) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if r.URL.Path == "/api/2.0/mlflow/registered-models/get" {
+		for _, model := range f.models {
+			if model.Name == query.Get("name") {
+				_ = json.NewEncoder(w).Encode(map[string]any{"registered_model": model})
+				return
+			}
+		}
+		http.Error(w, `{"error_code": "RESOURCE_DOES_NOT_EXIST"}`, http.StatusNotFound)
+		return
+	}
+
+	if query.Get("order_by") != "last_updated_timestamp ASC" {
+		http.Error(w, "unexpected order_by", http.StatusBadRequest)
+		return
+	}
+	pageSize, _ := strconv.Atoi(query.Get("max_results"))
+	offset, _ := strconv.Atoi(query.Get("page_token"))
+	page := func(total int) (int, string) {
+		end := min(offset+pageSize, total)
+		if end == total {
+			return end, ""
+		}
+		return end, strconv.Itoa(end)
+	}
+
+	switch r.URL.Path {
+	case "/api/2.0/mlflow/registered-models/search":
+		end, next := page(len(f.models))
+		_ = json.NewEncoder(w).Encode(map[string]any{"registered_models": f.models[offset:end], "next_page_token": next})
+	case "/api/2.0/mlflow/model-versions/search":
+		end, next := page(len(f.versions))
+		_ = json.NewEncoder(w).Encode(map[string]any{"model_versions": f.versions[offset:end], "next_page_token": next})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestImport(t *testing.T) {
+	mlflow := &fakeMLflow{
+		models: []registeredModel{
+			{Name: "churn", Description: "Churn classifier", LastUpdatedTimestamp: 100, Tags: []tag{{Key: "team", Value: "growth"}}},
+			{Name: "fraud", LastUpdatedTimestamp: 200},
+		},
+		versions: []modelVersion{
+			{Name: "churn", Version: "1", Source: "s3://mlflow/churn/1", RunId: "run1", CurrentStage: "Production", UserId: "alice", LastUpdatedTimestamp: 110},
+			{Name: "churn", Version: "2", Source: "s3://mlflow/churn/2", CurrentStage: "None", LastUpdatedTimestamp: 120, Tags: []tag{{Key: "framework", Value: "sklearn"}}},
+			{Name: "fraud", Version: "1", Source: "s3://mlflow/fraud/1", CurrentStage: "Staging", LastUpdatedTimestamp: 210},
+		},
+	}
+	mlflowServer := httptest.NewServer(mlflow)
+	defer mlflowServer.Close()
+
+	server, service := inmemory.NewServer(t)
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	importer := NewImporter(Config{MLflowURL: mlflowServer.URL, URL: server.URL, StateFile: stateFile, PageSize: 2})
+
+	summary, err := importer.Import(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{RegisteredModels: 2, ModelVersions: 3}, summary)
+
+	churn, err := service.GetRegisteredModelByParams(nil, apiutils.Of("mlflow:churn"))
+	require.NoError(t, err)
+	assert.Equal(t, "churn", churn.Name)
+	assert.Equal(t, "Churn classifier", churn.GetDescription())
+	assert.Equal(t, "growth", churn.CustomProperties["team"].MetadataStringValue.StringValue)
+
+	version, err := service.GetModelVersionByParams(nil, nil, apiutils.Of("mlflow:churn/1"))
+	require.NoError(t, err)
+	assert.Equal(t, "1", version.Name)
+	assert.Equal(t, "alice", version.GetAuthor())
+	assert.Equal(t, "Production", version.CustomProperties["stage"].MetadataStringValue.StringValue)
+	assert.Equal(t, "run1", version.CustomProperties["mlflow_run_id"].MetadataStringValue.StringValue)
+
+	artifacts, err := service.GetModelArtifacts(api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	require.Len(t, artifacts.Items, 1)
+	assert.Equal(t, "s3://mlflow/churn/1", artifacts.Items[0].GetUri())
+
+	version, err = service.GetModelVersionByParams(nil, nil, apiutils.Of("mlflow:churn/2"))
+	require.NoError(t, err)
+	assert.NotContains(t, version.CustomProperties, "stage")
+	assert.Equal(t, "sklearn", version.CustomProperties["framework"].MetadataStringValue.StringValue)
+
+	state, err := os.ReadFile(stateFile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"registeredModels": 200, "modelVersions": 210}`, string(state))
+
+	// a rerun imports the entities updated since the high-watermarks, updating those imported before
+	version, err = service.GetModelVersionByParams(nil, nil, apiutils.Of("mlflow:churn/1"))
+	require.NoError(t, err)
+	version.CustomProperties["owner"] = stringValue("ml-platform")
+	_, err = service.UpsertModelVersion(version, churn.Id)
+	require.NoError(t, err)
+
+	mlflow.versions[0].CurrentStage = "Archived"
+	mlflow.versions[0].Source = "s3://mlflow/churn/1-fixed"
+	mlflow.versions[0].LastUpdatedTimestamp = 300
+	mlflow.versions = append(mlflow.versions[1:], mlflow.versions[0],
+		modelVersion{Name: "sentiment", Version: "1", Source: "s3://mlflow/sentiment/1", LastUpdatedTimestamp: 310})
+	mlflow.models = append(mlflow.models, registeredModel{Name: "sentiment", LastUpdatedTimestamp: 305})
+
+	summary, err = importer.Import(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Summary{RegisteredModels: 2, ModelVersions: 3, Skipped: 2}, summary, "the entities at the high-watermarks are imported again")
+
+	version, err = service.GetModelVersionByParams(nil, nil, apiutils.Of("mlflow:churn/1"))
+	require.NoError(t, err)
+	assert.Equal(t, "Archived", version.CustomProperties["stage"].MetadataStringValue.StringValue)
+	assert.Equal(t, "ml-platform", version.CustomProperties["owner"].MetadataStringValue.StringValue, "the existing custom properties are kept")
+
+	artifacts, err = service.GetModelArtifacts(api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	require.Len(t, artifacts.Items, 1)
+	assert.Equal(t, "s3://mlflow/churn/1-fixed", artifacts.Items[0].GetUri())
+
+	_, err = service.GetModelVersionByParams(nil, nil, apiutils.Of("mlflow:sentiment/1"))
+	require.NoError(t, err)
+
+	models, err := service.GetRegisteredModels(api.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), models.Size)
+
+	require.NoError(t, os.WriteFile(stateFile, []byte(`{"registeredModels": "yesterday"}`), 0o600))
+	_, err = importer.Import(context.Background())
+	assert.ErrorContains(t, err, "invalid import state")
+}
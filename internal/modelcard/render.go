@@ -0,0 +1,84 @@
+// Package modelcard renders the model cards of the registered models as documents, to publish them along with the
+// models, e.g. as the README of a model repository.
+package modelcard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// Formats of the rendered cards.
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "markdown"
+)
+
+// contentTypes are the media types of the formats.
+var contentTypes = map[string]string{
+	FormatJSON:     "application/json; charset=UTF-8",
+	FormatMarkdown: "text/markdown; charset=UTF-8",
+}
+
+// ContentType returns the media type of the format, false for unknown formats.
+func ContentType(format string) (string, bool) {
+	contentType, ok := contentTypes[format]
+	return contentType, ok
+}
+
+// Render writes the card of the named model in the format.
+func Render(w io.Writer, modelName string, card *api.ModelCard, format string) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(card)
+	case FormatMarkdown:
+		return renderMarkdown(w, modelName, card)
+	default:
+		return fmt.Errorf("unsupported model card format %q, must be %s or %s", format, FormatJSON, FormatMarkdown)
+	}
+}
+
+// renderMarkdown writes a section per set field of the card, the lists as bullet lists. The text of the fields is
+// written as is, so that it can hold Markdown itself.
+func renderMarkdown(w io.Writer, modelName string, card *api.ModelCard) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Model card: %s\n", modelName)
+
+	section := func(title string, text string) {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", title, strings.TrimSpace(text))
+	}
+	list := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		writeItems(&b, items)
+	}
+
+	if card.IntendedUse != "" {
+		section("Intended use", card.IntendedUse)
+	}
+	list("Out-of-scope uses", card.OutOfScopeUses)
+	list("Limitations", card.Limitations)
+	list("Ethical considerations", card.EthicalConsiderations)
+	if data := card.TrainingData; data != nil {
+		section("Training data", data.Summary)
+		if len(data.Datasets) > 0 {
+			b.WriteString("\nDatasets:\n\n")
+			writeItems(&b, data.Datasets)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeItems writes a bullet list, indenting the next lines of the items to keep them in their item.
+func writeItems(b *strings.Builder, items []string) {
+	for _, item := range items {
+		fmt.Fprintf(b, "- %s\n", strings.ReplaceAll(strings.TrimSpace(item), "\n", "\n  "))
+	}
+}
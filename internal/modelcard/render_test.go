@@ -0,0 +1,80 @@
+package modelcard
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCard = &api.ModelCard{
+	Id:                    "3",
+	RegisteredModelId:     "1",
+	IntendedUse:           "Flagging fraudulent card transactions for **manual review**.",
+	OutOfScopeUses:        []string{"Automated account closures"},
+	Limitations:           []string{"Trained on EU transactions only", "Degrades on merchants\nunseen in training"},
+	EthicalConsiderations: []string{"False positives delay payments of legitimate customers"},
+	TrainingData: &api.ModelCardTrainingData{
+		Summary:  "12 months of labelled transactions.",
+		Datasets: []string{"s3://datasets/transactions-2025"},
+	},
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Render(&out, "fraud-detector", testCard, FormatMarkdown))
+
+	assert.Equal(t, `# Model card: fraud-detector
+
+## Intended use
+
+Flagging fraudulent card transactions for **manual review**.
+
+## Out-of-scope uses
+
+- Automated account closures
+
+## Limitations
+
+- Trained on EU transactions only
+- Degrades on merchants
+  unseen in training
+
+## Ethical considerations
+
+- False positives delay payments of legitimate customers
+
+## Training data
+
+12 months of labelled transactions.
+
+Datasets:
+
+- s3://datasets/transactions-2025
+`, out.String())
+}
+
+func TestRenderMarkdownWithoutOptionalSections(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Render(&out, "fraud-detector", &api.ModelCard{IntendedUse: "Fraud screening."}, FormatMarkdown))
+
+	assert.Equal(t, "# Model card: fraud-detector\n\n## Intended use\n\nFraud screening.\n", out.String())
+}
+
+func TestRenderJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, Render(&out, "fraud-detector", testCard, FormatJSON))
+
+	var card api.ModelCard
+	require.NoError(t, json.Unmarshal(out.Bytes(), &card))
+	assert.Equal(t, *testCard, card)
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	assert.Error(t, Render(&bytes.Buffer{}, "fraud-detector", testCard, "pdf"))
+	_, ok := ContentType("pdf")
+	assert.False(t, ok)
+}
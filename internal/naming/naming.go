@@ -0,0 +1,203 @@
+// Package naming enforces the naming policies operators configure per entity type, e.g. lower case registered model
+// names of at most 63 characters that don't start with a reserved system prefix.
+//
+// Policies apply to the names of new entities, existing entities keep their names.
+package naming
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// entityTypes are the entity types naming policies can be set for, the Artifact policy applies to model artifacts,
+// doc artifacts and datasets.
+var entityTypes = map[string]bool{
+	api.EntityTypeRegisteredModel:    true,
+	api.EntityTypeModelVersion:       true,
+	api.EntityTypeArtifact:           true,
+	api.EntityTypeServingEnvironment: true,
+	api.EntityTypeInferenceService:   true,
+	api.EntityTypeServeModel:         true,
+	api.EntityTypeExperiment:         true,
+	api.EntityTypeExperimentRun:      true,
+}
+
+// Config is the content of the naming policies file, e.g.
+//
+//	policies:
+//	  RegisteredModel:
+//	    pattern: "[a-z0-9]([-a-z0-9]*[a-z0-9])?"
+//	    case: lower
+//	    reservedPrefixes: [kf-, system-]
+//	    maxLength: 63
+type Config struct {
+	Policies map[string]api.NamingPolicy `json:"policies"`
+}
+
+// LoadConfig reads a naming policies file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading naming policies file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing naming policies file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+type policy struct {
+	api.NamingPolicy
+	pattern *regexp.Regexp
+}
+
+// Enforcer normalizes and validates the names of new entities.
+type Enforcer struct {
+	policies map[string]*policy
+}
+
+// NewEnforcer validates the policies of config, it returns nil when there are none.
+func NewEnforcer(config *Config) (*Enforcer, error) {
+	if config == nil || len(config.Policies) == 0 {
+		return nil, nil
+	}
+
+	policies := make(map[string]*policy, len(config.Policies))
+	for entityType, p := range config.Policies {
+		if !entityTypes[entityType] {
+			return nil, fmt.Errorf("invalid naming policy: unknown entity type %s", entityType)
+		}
+
+		compiled, err := compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming policy of %s: %w", entityType, err)
+		}
+		policies[entityType] = compiled
+	}
+
+	return &Enforcer{policies: policies}, nil
+}
+
+func compile(p api.NamingPolicy) (*policy, error) {
+	switch p.Case {
+	case api.NameCasePreserve, api.NameCaseLower, api.NameCaseUpper:
+	default:
+		return nil, fmt.Errorf("case must be %s or %s", api.NameCaseLower, api.NameCaseUpper)
+	}
+
+	if p.MaxLength < 0 {
+		return nil, fmt.Errorf("max length cannot be negative")
+	}
+
+	for _, prefix := range p.ReservedPrefixes {
+		if prefix == "" {
+			return nil, fmt.Errorf("reserved prefixes cannot be empty")
+		}
+	}
+
+	compiled := &policy{NamingPolicy: p}
+	if p.Pattern != "" {
+		var err error
+		// the whole name must match
+		if compiled.pattern, err = regexp.Compile(`^(?:` + p.Pattern + `)$`); err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+
+	return compiled, nil
+}
+
+// Apply returns the name of a new entity normalized by the policy of its type, or an *api.NamingViolation if the
+// name breaks it. Enforcers are nil-safe.
+func (e *Enforcer) Apply(entityType string, name string) (string, error) {
+	if e == nil {
+		return name, nil
+	}
+	p, ok := e.policies[entityType]
+	if !ok {
+		return name, nil
+	}
+
+	name = p.normalize(name)
+
+	rule, reason := p.check(name)
+	if rule == "" {
+		return name, nil
+	}
+
+	violation := &api.NamingViolation{
+		EntityType: entityType,
+		Name:       name,
+		Rule:       rule,
+		Reason:     reason,
+	}
+	if suggestion := p.suggest(name); suggestion != name {
+		if rule, _ := p.check(suggestion); rule == "" {
+			violation.Suggestion = suggestion
+		}
+	}
+	return "", violation
+}
+
+func (p *policy) normalize(name string) string {
+	switch p.Case {
+	case api.NameCaseLower:
+		return strings.ToLower(name)
+	case api.NameCaseUpper:
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// check returns the first rule the name breaks with the reason, or an empty rule.
+func (p *policy) check(name string) (rule string, reason string) {
+	if p.MaxLength > 0 {
+		if length := utf8.RuneCountInString(name); length > p.MaxLength {
+			return api.NamingRuleMaxLength, fmt.Sprintf("%d characters, the maximum is %d", length, p.MaxLength)
+		}
+	}
+
+	for _, prefix := range p.ReservedPrefixes {
+		if strings.HasPrefix(name, p.normalize(prefix)) {
+			return api.NamingRuleReservedPrefix, fmt.Sprintf("prefix %q is reserved", prefix)
+		}
+	}
+
+	if p.pattern != nil && !p.pattern.MatchString(name) {
+		return api.NamingRulePattern, fmt.Sprintf("must match %s", p.Pattern)
+	}
+
+	return "", ""
+}
+
+// nonSlugChars are replaced by dashes in the suggestions of names not matching the pattern.
+var nonSlugChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// suggest returns a name close to a name breaking the policy, the result must still be checked.
+func (p *policy) suggest(name string) string {
+	for stripped := true; stripped; {
+		stripped = false
+		for _, prefix := range p.ReservedPrefixes {
+			if rest, ok := strings.CutPrefix(name, p.normalize(prefix)); ok {
+				name, stripped = rest, true
+			}
+		}
+	}
+
+	if p.pattern != nil && !p.pattern.MatchString(name) {
+		name = strings.Trim(nonSlugChars.ReplaceAllString(name, "-"), "-")
+	}
+
+	if p.MaxLength > 0 && utf8.RuneCountInString(name) > p.MaxLength {
+		name = strings.TrimRight(string([]rune(name)[:p.MaxLength]), "-")
+	}
+
+	return name
+}
@@ -0,0 +1,87 @@
+package naming
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEnforcer(t *testing.T) {
+	enforcer, err := NewEnforcer(&Config{})
+	require.NoError(t, err)
+	assert.Nil(t, enforcer, "no policies")
+
+	for message, policies := range map[string]map[string]api.NamingPolicy{
+		"unknown entity type": {"Model": {}},
+		"case must be":        {api.EntityTypeRegisteredModel: {Case: "title"}},
+		"cannot be negative":  {api.EntityTypeRegisteredModel: {MaxLength: -1}},
+		"cannot be empty":     {api.EntityTypeRegisteredModel: {ReservedPrefixes: []string{""}}},
+		"invalid pattern":     {api.EntityTypeRegisteredModel: {Pattern: "[a-z"}},
+	} {
+		_, err := NewEnforcer(&Config{Policies: policies})
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestApply(t *testing.T) {
+	enforcer, err := NewEnforcer(&Config{Policies: map[string]api.NamingPolicy{
+		api.EntityTypeRegisteredModel: {
+			Pattern:          "[a-z0-9]([-a-z0-9]*[a-z0-9])?",
+			Case:             api.NameCaseLower,
+			ReservedPrefixes: []string{"KF-", "system-"},
+			MaxLength:        12,
+		},
+	}})
+	require.NoError(t, err)
+
+	name, err := enforcer.Apply(api.EntityTypeRegisteredModel, "Fraud-Model")
+	require.NoError(t, err)
+	assert.Equal(t, "fraud-model", name, "names are normalized")
+
+	name, err = enforcer.Apply(api.EntityTypeModelVersion, "V1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "V1.0", name, "types without a policy are unchanged")
+
+	for name, want := range map[string]api.NamingViolation{
+		"kf-fraud":          {Rule: api.NamingRuleReservedPrefix, Suggestion: "fraud"},
+		"fraud job":         {Rule: api.NamingRulePattern, Suggestion: "fraud-job"},
+		"fraud-detection-2": {Rule: api.NamingRuleMaxLength, Suggestion: "fraud-detect"},
+		"---":               {Rule: api.NamingRulePattern},
+	} {
+		_, err := enforcer.Apply(api.EntityTypeRegisteredModel, name)
+
+		var violation *api.NamingViolation
+		require.True(t, errors.As(err, &violation), name)
+		assert.ErrorIs(t, err, api.ErrBadRequest)
+		assert.Equal(t, want.Rule, violation.Rule, name)
+		assert.Equal(t, want.Suggestion, violation.Suggestion, name)
+	}
+
+	var nilEnforcer *Enforcer
+	name, err = nilEnforcer.Apply(api.EntityTypeRegisteredModel, "Any Name")
+	require.NoError(t, err)
+	assert.Equal(t, "Any Name", name)
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "naming.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+policies:
+  Experiment:
+    case: upper
+    maxLength: 20
+`), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, api.NamingPolicy{Case: api.NameCaseUpper, MaxLength: 20}, config.Policies[api.EntityTypeExperiment])
+
+	require.NoError(t, os.WriteFile(path, []byte("policies:\n  Experiment:\n    minLength: 1\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err, "unknown fields are rejected")
+}
@@ -0,0 +1,304 @@
+// Package oci is a client of the distribution API of OCI registries: it resolves the manifests of the oci:// model
+// artifact URIs, and pushes model directories as OCI artifacts with a layer per file, as ORAS does, so that the
+// pushed models can be pulled with the usual OCI tooling.
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+const (
+	// ManifestMediaType is the media type of the manifests pushed by the client.
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ArtifactType is the artifact type of the pushed model directories.
+	ArtifactType = "application/vnd.kubeflow.model-registry.model.v1"
+	// LayerMediaType is the media type of the layers holding the files of the pushed model directories.
+	LayerMediaType = "application/octet-stream"
+	// TitleAnnotation holds the path of the file of a layer in its directory.
+	TitleAnnotation = "org.opencontainers.image.title"
+
+	// emptyMediaType is the media type of the empty config of the artifacts, whose content is emptyConfig
+	emptyMediaType = "application/vnd.oci.empty.v1+json"
+	emptyConfig    = "{}"
+)
+
+// maxManifestSize bounds the manifests and token responses read from registries.
+const maxManifestSize = 4 << 20
+
+// ErrNotFound is returned when the registry has no manifest for a reference.
+var ErrNotFound = errors.New("manifest not found")
+
+// manifestMediaTypes are the manifests accepted from registries.
+var manifestMediaTypes = strings.Join([]string{
+	ManifestMediaType,
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}, ", ")
+
+// Descriptor describes a blob or a manifest of a registry.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an image manifest, the manifest of the pushed artifacts.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	ArtifactType  string       `json:"artifactType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// ContentSize sums the sizes of the config and layers of an image manifest. Image indexes do not carry the size of
+// their images, their size is unknown.
+func ContentSize(manifest []byte) (int64, bool) {
+	parsed := Manifest{}
+	if json.Unmarshal(manifest, &parsed) != nil || len(parsed.Layers) == 0 {
+		return 0, false
+	}
+
+	size := parsed.Config.Size
+	for _, layer := range parsed.Layers {
+		size += layer.Size
+	}
+	return size, true
+}
+
+// Config configures the access to the registries.
+type Config struct {
+	// Username and Password are sent to the registries requiring authentication, the registries are accessed
+	// anonymously when they are empty. Password can be a token.
+	Username string
+	Password string
+	// PlainHTTP reaches the registries over http instead of https, for local registries.
+	PlainHTTP bool
+}
+
+// Client reads and pushes manifests and blobs, authenticating with the Basic or Bearer challenges of the registries.
+type Client struct {
+	client   *http.Client
+	scheme   string
+	username string
+	password string
+
+	mu sync.Mutex
+	// authorizations are the last Authorization headers accepted by the repositories, by registry/repository
+	authorizations map[string]string
+}
+
+// NewClient returns a Client sending its requests with client.
+func NewClient(client *http.Client, cfg Config) *Client {
+	scheme := "https"
+	if cfg.PlainHTTP {
+		scheme = "http"
+	}
+
+	return &Client{
+		client:         client,
+		scheme:         scheme,
+		username:       cfg.Username,
+		password:       cfg.Password,
+		authorizations: map[string]string{},
+	}
+}
+
+// FetchManifest returns the descriptor and the content of the manifest of ref. The digest of the descriptor is the
+// one reported by the registry, or the digest of the content when it reports none.
+func (c *Client) FetchManifest(ctx context.Context, ref Reference) (Descriptor, []byte, error) {
+	manifestURL := c.url(ref, "manifests", ref.reference())
+
+	resp, err := c.do(ctx, ref, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, manifestURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", manifestMediaTypes)
+		return req, nil
+	})
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("registry %s responded %s for %s:%s", ref.Registry, resp.Status, ref.Repository, ref.reference())
+		if resp.StatusCode == http.StatusNotFound {
+			err = fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return Descriptor{}, nil, err
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+
+	descriptor := Descriptor{
+		MediaType: resp.Header.Get("Content-Type"),
+		Digest:    resp.Header.Get("Docker-Content-Digest"),
+		Size:      int64(len(body)),
+	}
+	if !strings.HasPrefix(descriptor.Digest, "sha256:") {
+		descriptor.Digest = digestOf(body)
+	}
+
+	return descriptor, body, nil
+}
+
+// url returns the url of an endpoint of the repository of ref.
+func (c *Client) url(ref Reference, endpoint string, name string) string {
+	return fmt.Sprintf("%s://%s/v2/%s/%s/%s", c.scheme, ref.Registry, ref.Repository, endpoint, name)
+}
+
+// do sends the request built by newRequest to the repository of ref. When the registry responds 401 the request is
+// built and sent again, authorized with the challenge of the response.
+func (c *Client) do(ctx context.Context, ref Reference, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	repository := ref.Registry + "/" + ref.Repository
+
+	send := func(authorization string) (*http.Response, error) {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return c.client.Do(req)
+	}
+
+	c.mu.Lock()
+	authorization := c.authorizations[repository]
+	c.mu.Unlock()
+
+	resp, err := send(authorization)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+
+	authorization, err = c.authorize(ctx, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("registry %s requires authentication: %w", ref.Registry, err)
+	}
+
+	c.mu.Lock()
+	c.authorizations[repository] = authorization
+	c.mu.Unlock()
+
+	return send(authorization)
+}
+
+// authorize returns the Authorization header answering a challenge: the credentials for Basic challenges, a token
+// requested from the realm for Bearer challenges, anonymously without credentials as for public repositories.
+func (c *Client) authorize(ctx context.Context, challenge string) (string, error) {
+	if strings.HasPrefix(challenge, "Basic ") {
+		if c.username == "" && c.password == "" {
+			return "", fmt.Errorf("no credentials for challenge %q", challenge)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)), nil
+	}
+
+	params, ok := parseBearerChallenge(challenge)
+	if !ok || params["realm"] == "" {
+		return "", fmt.Errorf("unsupported challenge %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil {
+		return "", err
+	}
+	query := realm.Query()
+	for _, name := range []string{"service", "scope"} {
+		if value := params[name]; value != "" {
+			query.Set(name, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint responded %s", resp.Status)
+	}
+
+	tokens := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&tokens); err != nil {
+		return "", fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if tokens.Token != "" {
+		return "Bearer " + tokens.Token, nil
+	}
+	if tokens.AccessToken != "" {
+		return "Bearer " + tokens.AccessToken, nil
+	}
+	return "", fmt.Errorf("token endpoint returned no token")
+}
+
+// parseBearerChallenge parses the parameters of a `Bearer realm="...",service="...",scope="..."` challenge.
+func parseBearerChallenge(challenge string) (map[string]string, bool) {
+	rest, ok := strings.CutPrefix(challenge, "Bearer ")
+	if !ok {
+		return nil, false
+	}
+
+	params := map[string]string{}
+	for rest != "" {
+		name, value, ok := strings.Cut(strings.TrimLeft(rest, ", "), "=")
+		if !ok {
+			break
+		}
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				return nil, false
+			}
+			params[strings.ToLower(name)] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(value, ",")
+			params[strings.ToLower(name)] = value
+		}
+	}
+
+	return params, true
+}
+
+// digestOf returns the sha256 digest of content, as sha256:<hex>.
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,186 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry serves the manifests and blobs endpoints of the distribution API, requiring the bearer token of
+// the credentials of its token endpoint.
+type fakeRegistry struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	uploads   int
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	f := &fakeRegistry{manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	f.server = httptest.NewServer(f)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// host is the registry of the oci:// URIs of the fake registry.
+func (f *fakeRegistry) host() string {
+	return strings.TrimPrefix(f.server.URL, "http://")
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.URL.Path == "/token" {
+		if username, password, _ := r.BasicAuth(); username != "robot" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "pushable"})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer pushable" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:models:pull,push"`, f.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	repository, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v2/"), "/")
+	switch {
+	case r.Method == http.MethodGet && strings.HasPrefix(rest, "manifests/"):
+		manifest, ok := f.manifests[repository+":"+strings.TrimPrefix(rest, "manifests/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", ManifestMediaType)
+		_, _ = w.Write(manifest)
+
+	case r.Method == http.MethodPut && strings.HasPrefix(rest, "manifests/"):
+		manifest, _ := io.ReadAll(r.Body)
+		f.manifests[repository+":"+strings.TrimPrefix(rest, "manifests/")] = manifest
+		f.manifests[repository+":"+digestOf(manifest)] = manifest
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodHead && strings.HasPrefix(rest, "blobs/"):
+		if _, ok := f.blobs[strings.TrimPrefix(rest, "blobs/")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+		}
+
+	case r.Method == http.MethodPost && rest == "blobs/uploads/":
+		w.Header().Set("Location", "/v2/"+repository+"/blobs/uploads/1?state=started")
+		w.WriteHeader(http.StatusAccepted)
+
+	case r.Method == http.MethodPut && rest == "blobs/uploads/1":
+		blob, _ := io.ReadAll(r.Body)
+		digest := r.URL.Query().Get("digest")
+		if r.URL.Query().Get("state") != "started" || digest != digestOf(blob) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[digest] = blob
+		f.uploads++
+		w.WriteHeader(http.StatusCreated)
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestFetchManifest(t *testing.T) {
+	registry := newFakeRegistry(t)
+	registry.manifests["models:v1"] = []byte(`{"config":{"size":10},"layers":[{"size":100},{"size":1000}]}`)
+	client := NewClient(&http.Client{}, Config{Username: "robot", Password: "secret", PlainHTTP: true})
+
+	ref, err := ParseReference("oci://" + registry.host() + "/models:v1")
+	require.NoError(t, err)
+	descriptor, manifest, err := client.FetchManifest(context.Background(), ref)
+	require.NoError(t, err)
+	assert.Equal(t, digestOf(registry.manifests["models:v1"]), descriptor.Digest)
+	assert.Equal(t, ManifestMediaType, descriptor.MediaType)
+	size, ok := ContentSize(manifest)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1110), size)
+
+	_, _, err = client.FetchManifest(context.Background(), ref.WithTag("v2"))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "404 Not Found for models:v2")
+
+	anonymous := NewClient(&http.Client{}, Config{PlainHTTP: true})
+	_, _, err = anonymous.FetchManifest(context.Background(), ref)
+	assert.ErrorContains(t, err, "requires authentication: token endpoint responded 401 Unauthorized")
+}
+
+func TestPush(t *testing.T) {
+	registry := newFakeRegistry(t)
+	client := NewClient(&http.Client{}, Config{Username: "robot", Password: "secret", PlainHTTP: true})
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"architectures": ["granite"]}`), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "weights"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "weights", "model.safetensors"), []byte("weights"), 0o600))
+
+	ref, err := ParseReference("oci://" + registry.host() + "/models:granite")
+	require.NoError(t, err)
+	descriptor, manifest, err := client.Push(context.Background(), ref, dir)
+	require.NoError(t, err)
+	assert.Equal(t, digestOf(manifest), descriptor.Digest)
+	assert.Equal(t, manifest, registry.manifests["models:granite"])
+	assert.Equal(t, 3, registry.uploads, "the files and the config are uploaded")
+
+	pushed := Manifest{}
+	require.NoError(t, json.Unmarshal(manifest, &pushed))
+	assert.Equal(t, ArtifactType, pushed.ArtifactType)
+	assert.Equal(t, emptyMediaType, pushed.Config.MediaType)
+	require.Len(t, pushed.Layers, 2)
+	assert.Equal(t, "config.json", pushed.Layers[0].Annotations[TitleAnnotation])
+	assert.Equal(t, "weights/model.safetensors", pushed.Layers[1].Annotations[TitleAnnotation])
+	assert.Equal(t, []byte("weights"), registry.blobs[pushed.Layers[1].Digest])
+
+	// the blobs in the repository are not uploaded again, the artifact is pushed by digest without tag
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Granite"), 0o600))
+	descriptor, _, err = client.Push(context.Background(), ref.WithTag(""), dir)
+	require.NoError(t, err)
+	assert.Equal(t, 4, registry.uploads)
+	assert.Contains(t, registry.manifests, "models:"+descriptor.Digest)
+
+	_, _, err = client.Push(context.Background(), ref, t.TempDir())
+	assert.ErrorContains(t, err, "no files to push")
+}
+
+func TestParseReference(t *testing.T) {
+	for uri, expected := range map[string]Reference{
+		"oci://quay.io/org/model:v1":          {Registry: "quay.io", Repository: "org/model", Tag: "v1"},
+		"oci://quay.io/org/model":             {Registry: "quay.io", Repository: "org/model"},
+		"oci://localhost:5000/model@sha256:1": {Registry: "localhost:5000", Repository: "model", Digest: "sha256:1"},
+		"oci://docker.io/busybox":             {Registry: "registry-1.docker.io", Repository: "library/busybox"},
+	} {
+		ref, err := ParseReference(uri)
+		require.NoError(t, err, uri)
+		assert.Equal(t, expected, ref, uri)
+	}
+
+	ref, err := ParseReference("oci://quay.io/org/model:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "oci://quay.io/org/model@sha256:1", ref.WithDigest("sha256:1").String())
+	assert.Equal(t, "latest", ref.WithTag("").reference())
+
+	for _, uri := range []string{"oci://quay.io", "s3://bucket/model", "quay.io/org/model"} {
+		_, err := ParseReference(uri)
+		assert.Error(t, err, uri)
+	}
+}
@@ -0,0 +1,172 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// Push pushes the regular files of dir as the layers of an artifact tagged with the tag of ref, or pushed by digest
+// when ref has none, and returns the descriptor and the content of its manifest. The blobs already in the repository
+// are not sent again.
+func (c *Client) Push(ctx context.Context, ref Reference, dir string) (Descriptor, []byte, error) {
+	var layers []Descriptor
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		layer, err := fileDescriptor(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		layer.Annotations = map[string]string{TitleAnnotation: filepath.ToSlash(rel)}
+
+		if err := c.pushBlob(ctx, ref, layer, func() (io.ReadCloser, error) { return os.Open(path) }); err != nil {
+			return fmt.Errorf("error pushing %s: %w", layer.Annotations[TitleAnnotation], err)
+		}
+		layers = append(layers, layer)
+		return nil
+	})
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+	if len(layers) == 0 {
+		return Descriptor{}, nil, fmt.Errorf("no files to push in %s", dir)
+	}
+
+	config := Descriptor{MediaType: emptyMediaType, Digest: digestOf([]byte(emptyConfig)), Size: int64(len(emptyConfig))}
+	if err := c.pushBlob(ctx, ref, config, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte(emptyConfig))), nil
+	}); err != nil {
+		return Descriptor{}, nil, fmt.Errorf("error pushing config: %w", err)
+	}
+
+	manifest, err := json.Marshal(Manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		ArtifactType:  ArtifactType,
+		Config:        config,
+		Layers:        layers,
+	})
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+	descriptor := Descriptor{MediaType: ManifestMediaType, Digest: digestOf(manifest), Size: int64(len(manifest))}
+
+	reference := ref.Tag
+	if reference == "" {
+		reference = descriptor.Digest
+	}
+	resp, err := c.do(ctx, ref, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url(ref, "manifests", reference), bytes.NewReader(manifest))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", ManifestMediaType)
+		return req, nil
+	})
+	if err != nil {
+		return Descriptor{}, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return Descriptor{}, nil, fmt.Errorf("registry %s responded %s to the push of the manifest of %s", ref.Registry, resp.Status, ref.Repository)
+	}
+
+	return descriptor, manifest, nil
+}
+
+// pushBlob uploads the blob of descriptor read from open, unless the repository has it, with a monolithic upload.
+func (c *Client) pushBlob(ctx context.Context, ref Reference, descriptor Descriptor, open func() (io.ReadCloser, error)) error {
+	resp, err := c.do(ctx, ref, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodHead, c.url(ref, "blobs", descriptor.Digest), nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = c.do(ctx, ref, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodPost, c.url(ref, "blobs", "uploads/"), nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("registry %s responded %s to the start of the upload", ref.Registry, resp.Status)
+	}
+
+	// the location of the upload may be relative to the request
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || resp.Header.Get("Location") == "" {
+		return fmt.Errorf("registry %s returned an invalid upload location %q", ref.Registry, resp.Header.Get("Location"))
+	}
+	query := location.Query()
+	query.Set("digest", descriptor.Digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref, func() (*http.Request, error) {
+		return newUploadRequest(ctx, location, descriptor, open)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("registry %s responded %s to the upload", ref.Registry, resp.Status)
+	}
+
+	return nil
+}
+
+func newUploadRequest(ctx context.Context, location *url.URL, descriptor Descriptor, open func() (io.ReadCloser, error)) (*http.Request, error) {
+	body, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, location.String(), body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.ContentLength = descriptor.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	return req, nil
+}
+
+// fileDescriptor returns the layer descriptor of a file, reading it to compute its digest.
+func fileDescriptor(path string) (Descriptor, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, file)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return Descriptor{MediaType: LayerMediaType, Digest: "sha256:" + hex.EncodeToString(h.Sum(nil)), Size: size}, nil
+}
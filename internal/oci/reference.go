@@ -0,0 +1,77 @@
+package oci
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Reference is a manifest of a repository, by tag or digest.
+type Reference struct {
+	// Registry is the host of the distribution API of the registry, registry-1.docker.io for docker.io.
+	Registry   string
+	Repository string
+	// Tag is empty for the references by digest, and for latest.
+	Tag string
+	// Digest is set for the references by digest, as sha256:<hex>.
+	Digest string
+}
+
+// ParseReference parses oci://registry/repository[:tag|@digest] URIs.
+func ParseReference(uri string) (Reference, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "oci" || parsed.Host == "" || strings.Trim(parsed.Path, "/") == "" {
+		return Reference{}, fmt.Errorf("invalid oci uri %q, expected oci://registry/repository[:tag|@digest]", uri)
+	}
+
+	ref := Reference{Registry: parsed.Host, Repository: strings.TrimPrefix(parsed.Path, "/")}
+	if name, digest, ok := strings.Cut(ref.Repository, "@"); ok {
+		ref.Repository, ref.Digest = name, digest
+	} else if i := strings.LastIndex(ref.Repository, ":"); i > strings.LastIndex(ref.Repository, "/") {
+		ref.Repository, ref.Tag = ref.Repository[:i], ref.Repository[i+1:]
+	}
+
+	if ref.Registry == "docker.io" {
+		ref.Registry = "registry-1.docker.io"
+		if !strings.Contains(ref.Repository, "/") {
+			ref.Repository = "library/" + ref.Repository
+		}
+	}
+
+	return ref, nil
+}
+
+// WithTag returns the reference of the tag in the repository of ref.
+func (r Reference) WithTag(tag string) Reference {
+	r.Tag, r.Digest = tag, ""
+	return r
+}
+
+// WithDigest returns the reference of the digest in the repository of ref, pinning its manifest.
+func (r Reference) WithDigest(digest string) Reference {
+	r.Tag, r.Digest = "", digest
+	return r
+}
+
+// String returns the oci:// URI of the reference.
+func (r Reference) String() string {
+	uri := "oci://" + r.Registry + "/" + r.Repository
+	switch {
+	case r.Digest != "":
+		return uri + "@" + r.Digest
+	case r.Tag != "":
+		return uri + ":" + r.Tag
+	}
+	return uri
+}
+
+// reference returns the tag or digest of the manifest in the distribution API.
+func (r Reference) reference() string {
+	switch {
+	case r.Digest != "":
+		return r.Digest
+	case r.Tag != "":
+		return r.Tag
+	}
+	return "latest"
+}
@@ -0,0 +1,87 @@
+package ociupload
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// UploadPath is the path of the uploads of the model artifacts of a model version.
+const UploadPath = "/api/model_registry/v1alpha3/model_versions/{modelversionId}/artifacts:upload"
+
+// NewHandler returns the handler of the uploads of the model artifacts, passing the other requests to next:
+//
+//	POST /api/model_registry/v1alpha3/model_versions/{modelversionId}/artifacts:upload  creates a model artifact stored in an OCI registry
+//
+// A JSON body is a RegisterRequest registering an artifact already in a registry, a tar archive body, gzipped or not,
+// is the model directory pushed to the upload repository with the model artifact name of the name query parameter.
+// The created model artifact is returned.
+func NewHandler(uploader *Uploader, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST "+UploadPath, func(w http.ResponseWriter, r *http.Request) {
+		modelVersionId := r.PathValue("modelversionId")
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		switch mediaType {
+		case "application/json":
+			var request RegisterRequest
+			d := json.NewDecoder(r.Body)
+			d.DisallowUnknownFields()
+			if err := d.Decode(&request); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid model artifact upload request: "+err.Error())
+				return
+			}
+
+			artifact, err := uploader.Register(r.Context(), modelVersionId, request)
+			if err != nil {
+				writeUploadError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusCreated, artifact)
+
+		case "application/x-tar", "application/gzip":
+			artifact, err := uploader.Upload(r.Context(), modelVersionId, r.URL.Query().Get("name"), r.Body)
+			if err != nil {
+				writeUploadError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusCreated, artifact)
+
+		default:
+			writeError(w, http.StatusUnsupportedMediaType, "unsupported content type "+r.Header.Get("Content-Type")+", expected application/json, application/x-tar or application/gzip")
+		}
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrTooLarge):
+		writeError(w, http.StatusRequestEntityTooLarge, err.Error())
+	case errors.Is(err, api.ErrBadRequest), errors.Is(err, api.ErrNotFound), errors.Is(err, api.ErrConflict):
+		writeError(w, api.ErrToStatus(err), err.Error())
+	default:
+		glog.Errorf("Error uploading model artifact: %v", err)
+		writeError(w, http.StatusInternalServerError, "error uploading model artifact")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing model artifact upload response: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package ociupload
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/oci"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry serves the manifests and blob uploads of the distribution API to anonymous clients.
+type fakeRegistry struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	manifests map[string][]byte
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	f := &fakeRegistry{manifests: map[string][]byte{}}
+	f.server = httptest.NewServer(f)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// host is the registry of the oci:// URIs of the fake registry.
+func (f *fakeRegistry) host() string {
+	return strings.TrimPrefix(f.server.URL, "http://")
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	repository, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v2/"), "/")
+	reference, isManifest := strings.CutPrefix(rest, "manifests/")
+	switch {
+	case r.Method == http.MethodGet && isManifest:
+		manifest, ok := f.manifests[repository+":"+reference]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(manifest)
+	case r.Method == http.MethodPut && isManifest:
+		manifest, _ := io.ReadAll(r.Body)
+		f.manifests[repository+":"+reference] = manifest
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodHead:
+		w.WriteHeader(http.StatusNotFound)
+	case r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/"+repository+"/blobs/uploads/1")
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == http.MethodPut:
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func digestOf(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// tarArchive returns the gzipped tar archive of the files, by path.
+func tarArchive(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for path, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: path, Typeflag: tar.TypeReg, Mode: 0o600, Size: int64(len(content))}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestHandler(t *testing.T) {
+	registry := newFakeRegistry(t)
+	registry.manifests["models:v1"] = []byte(`{"config":{"size":10},"layers":[{"size":100},{"size":1000}]}`)
+
+	service := inmemory.NewModelRegistryService(inmemory.NewStore())
+	uploader, err := NewUploader(service, Config{Config: oci.Config{PlainHTTP: true}, Repository: "oci://" + registry.host() + "/models", MaxSize: 1 << 10})
+	require.NoError(t, err)
+	server := httptest.NewServer(NewHandler(uploader, http.NotFoundHandler()))
+	t.Cleanup(server.Close)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "granite"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	uploadURL := server.URL + strings.Replace(UploadPath, "{modelversionId}", *version.Id, 1)
+
+	post := func(url string, contentType string, body []byte) (int, map[string]any) {
+		resp, err := http.Post(url, contentType, bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		result := map[string]any{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+	customProperty := func(artifact map[string]any, name string) any {
+		return artifact["customProperties"].(map[string]any)[name]
+	}
+
+	// the archives are bounded once gzipped
+	weights := make([]byte, 4<<10)
+	rand.New(rand.NewSource(1)).Read(weights)
+
+	// an artifact in a registry is registered with the digest and size of its manifest
+	uri := "oci://" + registry.host() + "/models:v1"
+	code, artifact := post(uploadURL, "application/json", []byte(`{"uri": "`+uri+`", "name": "pushed"}`))
+	require.Equal(t, http.StatusCreated, code, artifact)
+	assert.Equal(t, "pushed", artifact["name"])
+	assert.Equal(t, uri, artifact["uri"])
+	assert.Equal(t, map[string]any{"metadataType": "MetadataStringValue", "string_value": digestOf(registry.manifests["models:v1"])}, customProperty(artifact, "digest"))
+	assert.Equal(t, map[string]any{"metadataType": "MetadataIntValue", "int_value": "1110"}, customProperty(artifact, "size_bytes"))
+
+	// a model directory is pushed to the repository, the artifact uri is pinned to its manifest
+	code, artifact = post(uploadURL, "application/gzip", tarArchive(t, map[string]string{"config.json": "{}", "weights/model.bin": "weights"}))
+	require.Equal(t, http.StatusCreated, code, artifact)
+	assert.Equal(t, "v1", artifact["name"], "the name defaults to the name of the model version")
+	manifest := registry.manifests["models:model-version-"+*version.Id]
+	require.NotNil(t, manifest)
+	assert.Equal(t, "oci://"+registry.host()+"/models@"+digestOf(manifest), artifact["uri"])
+	size, _ := oci.ContentSize(manifest)
+	assert.Equal(t, strconv.FormatInt(size, 10), customProperty(artifact, "size_bytes").(map[string]any)["int_value"])
+
+	artifacts, err := service.GetModelArtifacts(api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), artifacts.Size)
+
+	for name, request := range map[string]struct {
+		contentType string
+		body        []byte
+		code        int
+		message     string
+	}{
+		"invalid uri":       {"application/json", []byte(`{"uri": "s3://bucket/model"}`), http.StatusBadRequest, "invalid oci uri"},
+		"missing manifest":  {"application/json", []byte(`{"uri": "oci://` + registry.host() + `/models:v2"}`), http.StatusBadRequest, "manifest not found"},
+		"unknown field":     {"application/json", []byte(`{"url": "oci://quay.io/org/model"}`), http.StatusBadRequest, "unknown field"},
+		"escaping entry":    {"application/x-tar", tarArchive(t, map[string]string{"../model.bin": "weights"}), http.StatusBadRequest, "outside of the directory"},
+		"large archive":     {"application/gzip", tarArchive(t, map[string]string{"model.bin": string(weights)}), http.StatusRequestEntityTooLarge, "larger than 1024 bytes"},
+		"unsupported media": {"text/plain", []byte("weights"), http.StatusUnsupportedMediaType, "unsupported content type"},
+	} {
+		code, body := post(uploadURL, request.contentType, request.body)
+		assert.Equal(t, request.code, code, name)
+		assert.Contains(t, body["message"], request.message, name)
+	}
+
+	code, _ = post(server.URL+strings.Replace(UploadPath, "{modelversionId}", "404", 1), "application/json", []byte(`{"uri": "`+uri+`"}`))
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// without upload repository, only the artifacts in a registry are registered
+	uploader, err = NewUploader(service, Config{})
+	require.NoError(t, err)
+	_, err = uploader.Upload(t.Context(), *version.Id, "", bytes.NewReader(nil))
+	assert.ErrorContains(t, err, "no repository configured")
+
+	_, err = NewUploader(service, Config{Repository: "oci://quay.io/org/models:v1"})
+	assert.ErrorContains(t, err, "must have no tag or digest")
+}
@@ -0,0 +1,229 @@
+// Package ociupload creates the model artifacts of the model versions stored in OCI registries: it registers the
+// artifacts already pushed to a registry after verifying that their manifest exists, and pushes the model directories
+// uploaded as tar archives to a configured repository. The model artifacts record the digest of their manifest and the
+// size of their content, as the backfill of the digests does.
+package ociupload
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/oci"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// DefaultMaxUploadSize bounds the size of the archives of the uploaded model directories.
+const DefaultMaxUploadSize = 10 << 30
+
+// ErrTooLarge is returned for the archives of model directories larger than the maximum upload size.
+var ErrTooLarge = errors.New("model directory archive too large")
+
+// Config configures the uploads of the model artifacts.
+type Config struct {
+	oci.Config
+	// Repository is the oci://registry/repository the uploaded model directories are pushed to, tagged with the id of
+	// their model version. The directories can't be uploaded when it is empty, only the artifacts already in a
+	// registry can be registered.
+	Repository string
+	// MaxSize bounds the size of the archives of the model directories, defaults to DefaultMaxUploadSize.
+	MaxSize int64
+}
+
+// RegisterRequest registers an artifact already pushed to a registry as a model artifact.
+type RegisterRequest struct {
+	// Uri is the oci://registry/repository[:tag|@digest] of the artifact.
+	Uri string `json:"uri"`
+	// Name of the model artifact, defaults to the name of the model version.
+	Name string `json:"name,omitempty"`
+}
+
+// Uploader creates the model artifacts of the model versions stored in OCI registries, with the digest and the size
+// of their manifest.
+type Uploader struct {
+	registry   api.ModelRegistryApi
+	client     *oci.Client
+	repository *oci.Reference
+	maxSize    int64
+}
+
+// NewUploader returns an Uploader creating the model artifacts in registry.
+func NewUploader(registry api.ModelRegistryApi, config Config) (*Uploader, error) {
+	u := &Uploader{registry: registry, client: oci.NewClient(&http.Client{}, config.Config), maxSize: config.MaxSize}
+	if config.Repository != "" {
+		repository, err := oci.ParseReference(config.Repository)
+		if err != nil {
+			return nil, fmt.Errorf("invalid upload repository: %w", err)
+		}
+		if repository.Tag != "" || repository.Digest != "" {
+			return nil, fmt.Errorf("invalid upload repository %q: it must have no tag or digest", config.Repository)
+		}
+		u.repository = &repository
+	}
+	if u.maxSize <= 0 {
+		u.maxSize = DefaultMaxUploadSize
+	}
+	return u, nil
+}
+
+// Register verifies that the manifest of the uri of request exists and creates the model artifact of the model
+// version with it.
+func (u *Uploader) Register(ctx context.Context, modelVersionId string, request RegisterRequest) (*openapi.ModelArtifact, error) {
+	ref, err := oci.ParseReference(request.Uri)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+
+	registry := api.WithContext(ctx, u.registry)
+	version, err := registry.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, manifest, err := u.client.FetchManifest(ctx, ref)
+	if errors.Is(err, oci.ErrNotFound) {
+		return nil, fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching manifest of %s: %w", request.Uri, err)
+	}
+
+	size, ok := oci.ContentSize(manifest)
+	return u.createModelArtifact(registry, version, request.Name, request.Uri, descriptor.Digest, size, ok)
+}
+
+// Upload pushes the model directory of a tar archive, gzipped or not, to the repository and creates the model artifact
+// of the model version with the uri of its manifest, pinned by digest.
+func (u *Uploader) Upload(ctx context.Context, modelVersionId string, name string, archive io.Reader) (*openapi.ModelArtifact, error) {
+	if u.repository == nil {
+		return nil, fmt.Errorf("no repository configured for the uploads of model directories, only oci uris can be registered: %w", api.ErrBadRequest)
+	}
+
+	registry := api.WithContext(ctx, u.registry)
+	version, err := registry.GetModelVersionById(modelVersionId)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "model-upload-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := extractArchive(archive, dir, u.maxSize); err != nil {
+		return nil, err
+	}
+
+	ref := u.repository.WithTag("model-version-" + version.GetId())
+	descriptor, manifest, err := u.client.Push(ctx, ref, dir)
+	if err != nil {
+		return nil, fmt.Errorf("error pushing model directory to %s: %w", ref, err)
+	}
+
+	size, ok := oci.ContentSize(manifest)
+	return u.createModelArtifact(registry, version, name, ref.WithDigest(descriptor.Digest).String(), descriptor.Digest, size, ok)
+}
+
+// createModelArtifact creates the model artifact of the model version with uri, recording the digest of its manifest
+// and the size of its content when it is known.
+func (u *Uploader) createModelArtifact(registry api.ModelRegistryApi, version *openapi.ModelVersion, name string, uri string, digest string, size int64, sizeKnown bool) (*openapi.ModelArtifact, error) {
+	if name == "" {
+		name = version.Name
+	}
+
+	customProperties := map[string]openapi.MetadataValue{
+		models.DigestCustomProperty: openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(digest, "MetadataStringValue")),
+	}
+	if sizeKnown {
+		customProperties[models.SizeBytesCustomProperty] = openapi.MetadataIntValueAsMetadataValue(openapi.NewMetadataIntValue(strconv.FormatInt(size, 10), "MetadataIntValue"))
+	}
+
+	artifact, err := registry.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{
+			Name:             &name,
+			Uri:              &uri,
+			CustomProperties: customProperties,
+		},
+	}, version.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if artifact.ModelArtifact == nil {
+		return nil, fmt.Errorf("artifact %s of model version %s is not a model artifact", name, version.GetId())
+	}
+	return artifact.ModelArtifact, nil
+}
+
+// extractArchive extracts the regular files and directories of a tar archive, gzipped or not, to dir. The other
+// entries are skipped, the archives larger than maxSize and the entries outside of dir are rejected.
+func extractArchive(archive io.Reader, dir string, maxSize int64) error {
+	limited := &io.LimitedReader{R: archive, N: maxSize + 1}
+	buffered := bufio.NewReader(limited)
+
+	var reader io.Reader = buffered
+	if magic, _ := buffered.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return fmt.Errorf("invalid model directory archive: %v: %w", err, api.ErrBadRequest)
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	// the archives larger than maxSize are truncated, failing the reads
+	archiveError := func(err error) error {
+		if limited.N <= 0 {
+			return fmt.Errorf("%w: the archive is larger than %d bytes", ErrTooLarge, maxSize)
+		}
+		return fmt.Errorf("invalid model directory archive: %v: %w", err, api.ErrBadRequest)
+	}
+
+	archiveReader := tar.NewReader(reader)
+	for {
+		header, err := archiveReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return archiveError(err)
+		}
+
+		if !filepath.IsLocal(header.Name) {
+			return fmt.Errorf("invalid model directory archive: entry %q is outside of the directory: %w", header.Name, api.ErrBadRequest)
+		}
+		path := filepath.Join(dir, header.Name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, 0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+				return err
+			}
+			file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, archiveReader)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return archiveError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,393 @@
+// Package promotion copies model versions with their artifacts from a source to a target model registry,
+// recording the provenance of the copies on both sides.
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// Custom properties recording the provenance of the model versions promoted to a target registry.
+const (
+	SourceRegistryProperty       = "promotion.source_registry"
+	SourceModelVersionIdProperty = "promotion.source_model_version_id"
+	PromotionIdProperty          = "promotion.promotion_id"
+	RunIdProperty                = "promotion.run_id"
+	ReviewerProperty             = "promotion.reviewer"
+	PromotedAtProperty           = "promotion.promoted_at"
+)
+
+// pageSize is the page size used to list the model versions and artifacts to promote.
+const pageSize = int32(100)
+
+// TargetProperty returns the custom property recording on a source model version the given
+// field of its promotion to the target registry, e.g. "promotion.prod.model_version_id".
+func TargetProperty(target string, field string) string {
+	return "promotion." + target + "." + field
+}
+
+// Run identifies the promotion run recorded in the provenance of the promoted model versions.
+type Run struct {
+	PromotionId string
+	RunId       string
+	Source      string
+	Target      string
+	Reviewer    string
+}
+
+// Promoter copies model versions from a source to a target registry on behalf of a promotion run.
+type Promoter struct {
+	source Registry
+	target Registry
+	run    Run
+	now    func() time.Time
+	// planned are the names of the registered models the plan creates in the target registry
+	planned map[string]bool
+}
+
+func NewPromoter(source Registry, target Registry, run Run) *Promoter {
+	return &Promoter{
+		source: source,
+		target: target,
+		run:    run,
+		now:    time.Now,
+	}
+}
+
+// FindModelVersions returns all the model versions of source matching the promotion source filter.
+func FindModelVersions(source Registry, filter api.PromotionSource) ([]openapi.ModelVersion, error) {
+	var registeredModelId *string
+	if filter.RegisteredModelName != "" {
+		registeredModel, err := source.GetRegisteredModelByParams(&filter.RegisteredModelName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find registered model %s: %w", filter.RegisteredModelName, err)
+		}
+		registeredModelId = registeredModel.Id
+	}
+
+	listOptions := api.ListOptions{PageSize: ptr(pageSize)}
+	if filter.FilterQuery != "" {
+		listOptions.FilterQuery = &filter.FilterQuery
+	}
+
+	versions := []openapi.ModelVersion{}
+	for {
+		page, err := source.GetModelVersions(listOptions, registeredModelId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list model versions: %w", err)
+		}
+		versions = append(versions, page.Items...)
+
+		if page.NextPageToken == "" || len(page.Items) == 0 {
+			return versions, nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// Promote copies a model version with its artifacts to the target registry, creating its registered
+// model there if needed. The provenance is recorded on the target version once all its artifacts are
+// copied, and then on the source version. A version already promoted by the same source is skipped.
+func (p *Promoter) Promote(version openapi.ModelVersion) api.PromotedModelVersion {
+	result := api.PromotedModelVersion{
+		VersionName:          version.Name,
+		SourceModelVersionId: deref(version.Id),
+	}
+
+	failed := func(format string, args ...any) api.PromotedModelVersion {
+		result.Result = api.PromotionResultFailed
+		result.Message = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	registeredModel, err := p.source.GetRegisteredModelById(version.RegisteredModelId)
+	if err != nil {
+		return failed("unable to get registered model %s: %v", version.RegisteredModelId, err)
+	}
+	result.RegisteredModelName = registeredModel.Name
+
+	targetModel, err := p.targetRegisteredModel(*registeredModel)
+	if err != nil {
+		return failed("unable to create registered model %s in registry %s: %v", registeredModel.Name, p.run.Target, err)
+	}
+
+	existing, err := p.target.GetModelVersionByParams(&version.Name, targetModel.Id, nil)
+	switch {
+	case err == nil:
+		result.TargetModelVersionId = deref(existing.Id)
+		if stringValue(existing.CustomProperties, SourceRegistryProperty) != p.run.Source ||
+			stringValue(existing.CustomProperties, SourceModelVersionIdProperty) != result.SourceModelVersionId {
+			return failed("model version %s of %s already exists in registry %s and was not promoted from this model version", version.Name, registeredModel.Name, p.run.Target)
+		}
+		result.Result = api.PromotionResultSkipped
+		result.Message = fmt.Sprintf("already promoted by run %s", stringValue(existing.CustomProperties, RunIdProperty))
+		return result
+	case !errors.Is(err, api.ErrNotFound):
+		return failed("unable to get model version %s of %s in registry %s: %v", version.Name, registeredModel.Name, p.run.Target, err)
+	}
+
+	copied := version
+	copied.Id = nil
+	copied.ExternalId = nil
+	copied.CreateTimeSinceEpoch = nil
+	copied.LastUpdateTimeSinceEpoch = nil
+	copied.RegisteredModelId = deref(targetModel.Id)
+	copied.CustomProperties = maps.Clone(version.CustomProperties)
+
+	created, err := p.target.UpsertModelVersion(&copied, targetModel.Id)
+	if err != nil {
+		return failed("unable to create model version in registry %s: %v", p.run.Target, err)
+	}
+	result.TargetModelVersionId = deref(created.Id)
+
+	if err := p.copyArtifacts(result.SourceModelVersionId, result.TargetModelVersionId); err != nil {
+		return failed("created model version %s in registry %s but %v", result.TargetModelVersionId, p.run.Target, err)
+	}
+
+	promotedAt := strconv.FormatInt(p.now().UnixMilli(), 10)
+
+	created.CustomProperties = withStringValues(created.CustomProperties, map[string]string{
+		SourceRegistryProperty:       p.run.Source,
+		SourceModelVersionIdProperty: result.SourceModelVersionId,
+		PromotionIdProperty:          p.run.PromotionId,
+		RunIdProperty:                p.run.RunId,
+		ReviewerProperty:             p.run.Reviewer,
+		PromotedAtProperty:           promotedAt,
+	})
+	if _, err := p.target.UpsertModelVersion(created, nil); err != nil {
+		return failed("copied model version %s to registry %s but unable to record its provenance: %v", result.TargetModelVersionId, p.run.Target, err)
+	}
+
+	version.CustomProperties = withStringValues(version.CustomProperties, map[string]string{
+		TargetProperty(p.run.Target, "model_version_id"): result.TargetModelVersionId,
+		TargetProperty(p.run.Target, "run_id"):           p.run.RunId,
+		TargetProperty(p.run.Target, "promoted_at"):      promotedAt,
+	})
+	if _, err := p.source.UpsertModelVersion(&version, nil); err != nil {
+		return failed("promoted model version %s to registry %s but unable to record it on the source model version: %v", result.TargetModelVersionId, p.run.Target, err)
+	}
+
+	result.Result = api.PromotionResultPromoted
+	return result
+}
+
+// Plan adds to plan the changes Promote would make for a model version, without making any: the creation of its
+// registered model in the target registry if missing, of the model version and its artifacts there, and the update
+// of the provenance on the source model version. A version already promoted by the same source is skipped, and one
+// existing in the target registry but promoted from elsewhere conflicts.
+func (p *Promoter) Plan(version openapi.ModelVersion, plan *api.Plan) error {
+	registeredModel, err := p.source.GetRegisteredModelById(version.RegisteredModelId)
+	if err != nil {
+		return fmt.Errorf("unable to get registered model %s: %w", version.RegisteredModelId, err)
+	}
+
+	item := api.PlanItem{
+		EntityType: api.EntityTypeModelVersion,
+		Name:       version.Name,
+		ParentName: registeredModel.Name,
+		SourceId:   deref(version.Id),
+	}
+
+	targetModel, err := p.target.GetRegisteredModelByParams(&registeredModel.Name, nil)
+	switch {
+	case err == nil:
+		existing, err := p.target.GetModelVersionByParams(&version.Name, targetModel.Id, nil)
+		if err == nil {
+			item.Id = deref(existing.Id)
+			if stringValue(existing.CustomProperties, SourceRegistryProperty) != p.run.Source ||
+				stringValue(existing.CustomProperties, SourceModelVersionIdProperty) != item.SourceId {
+				item.Action = api.PlanActionConflict
+				item.Message = fmt.Sprintf("model version %s of %s already exists in registry %s and was not promoted from this model version", version.Name, registeredModel.Name, p.run.Target)
+			} else {
+				item.Action = api.PlanActionSkip
+				item.Message = fmt.Sprintf("already promoted by run %s", stringValue(existing.CustomProperties, RunIdProperty))
+			}
+			plan.Add(item)
+			return nil
+		}
+		if !errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("unable to get model version %s of %s in registry %s: %w", version.Name, registeredModel.Name, p.run.Target, err)
+		}
+	case errors.Is(err, api.ErrNotFound):
+		if p.planned == nil {
+			p.planned = map[string]bool{}
+		}
+		if !p.planned[registeredModel.Name] {
+			p.planned[registeredModel.Name] = true
+			plan.Add(api.PlanItem{
+				Action:     api.PlanActionCreate,
+				EntityType: api.EntityTypeRegisteredModel,
+				Name:       registeredModel.Name,
+				SourceId:   deref(registeredModel.Id),
+			})
+		}
+	default:
+		return fmt.Errorf("unable to get registered model %s in registry %s: %w", registeredModel.Name, p.run.Target, err)
+	}
+
+	item.Action = api.PlanActionCreate
+	plan.Add(item)
+
+	listOptions := api.ListOptions{PageSize: ptr(pageSize)}
+	for {
+		page, err := p.source.GetArtifacts("", listOptions, version.Id)
+		if err != nil {
+			return fmt.Errorf("unable to list the artifacts of model version %s: %w", item.SourceId, err)
+		}
+		for _, artifact := range page.Items {
+			sourceId := artifactSourceId(&artifact)
+			plan.Add(api.PlanItem{
+				Action:     api.PlanActionCreate,
+				EntityType: api.EntityTypeArtifact,
+				Name:       clearArtifactIdentity(&artifact),
+				ParentName: version.Name,
+				SourceId:   sourceId,
+			})
+		}
+		if page.NextPageToken == "" || len(page.Items) == 0 {
+			break
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+
+	update := api.PlanItem{
+		Action:     api.PlanActionUpdate,
+		EntityType: api.EntityTypeModelVersion,
+		Name:       version.Name,
+		ParentName: registeredModel.Name,
+		Id:         item.SourceId,
+	}
+	for _, field := range []string{"model_version_id", "run_id", "promoted_at"} {
+		name := TargetProperty(p.run.Target, field)
+		update.Changes = append(update.Changes, api.PlanFieldChange{
+			Field: "customProperties." + name,
+			From:  stringValue(version.CustomProperties, name),
+		})
+	}
+	plan.Add(update)
+
+	return nil
+}
+
+// targetRegisteredModel returns the registered model with the same name in the target registry, created
+// from the source one if missing.
+func (p *Promoter) targetRegisteredModel(registeredModel openapi.RegisteredModel) (*openapi.RegisteredModel, error) {
+	existing, err := p.target.GetRegisteredModelByParams(&registeredModel.Name, nil)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, api.ErrNotFound) {
+		return nil, err
+	}
+
+	registeredModel.Id = nil
+	registeredModel.ExternalId = nil
+	registeredModel.CreateTimeSinceEpoch = nil
+	registeredModel.LastUpdateTimeSinceEpoch = nil
+
+	return p.target.UpsertRegisteredModel(&registeredModel)
+}
+
+// copyArtifacts copies all the artifacts of the source model version to the target model version.
+func (p *Promoter) copyArtifacts(sourceVersionId string, targetVersionId string) error {
+	listOptions := api.ListOptions{PageSize: ptr(pageSize)}
+
+	for {
+		page, err := p.source.GetArtifacts("", listOptions, &sourceVersionId)
+		if err != nil {
+			return fmt.Errorf("unable to list the artifacts to copy: %w", err)
+		}
+
+		for _, artifact := range page.Items {
+			name := clearArtifactIdentity(&artifact)
+			if _, err := p.target.UpsertModelVersionArtifact(&artifact, targetVersionId); err != nil {
+				return fmt.Errorf("unable to copy artifact %s: %w", name, err)
+			}
+		}
+
+		if page.NextPageToken == "" || len(page.Items) == 0 {
+			return nil
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+}
+
+// clearArtifactIdentity unsets the fields identifying the artifact in its registry, and returns its name.
+func clearArtifactIdentity(artifact *openapi.Artifact) string {
+	switch {
+	case artifact.ModelArtifact != nil:
+		a := *artifact.ModelArtifact
+		a.Id, a.ExternalId, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch = nil, nil, nil, nil
+		artifact.ModelArtifact = &a
+		return deref(a.Name)
+	case artifact.DocArtifact != nil:
+		a := *artifact.DocArtifact
+		a.Id, a.ExternalId, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch = nil, nil, nil, nil
+		artifact.DocArtifact = &a
+		return deref(a.Name)
+	case artifact.DataSet != nil:
+		a := *artifact.DataSet
+		a.Id, a.ExternalId, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch = nil, nil, nil, nil
+		artifact.DataSet = &a
+		return deref(a.Name)
+	case artifact.Metric != nil:
+		a := *artifact.Metric
+		a.Id, a.ExternalId, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch = nil, nil, nil, nil
+		artifact.Metric = &a
+		return deref(a.Name)
+	case artifact.Parameter != nil:
+		a := *artifact.Parameter
+		a.Id, a.ExternalId, a.CreateTimeSinceEpoch, a.LastUpdateTimeSinceEpoch = nil, nil, nil, nil
+		artifact.Parameter = &a
+		return deref(a.Name)
+	}
+	return ""
+}
+
+// artifactSourceId returns the id of whichever artifact type is set.
+func artifactSourceId(artifact *openapi.Artifact) string {
+	if identified, ok := artifact.GetActualInstance().(interface{ GetId() string }); ok {
+		return identified.GetId()
+	}
+	return ""
+}
+
+// withStringValues returns a copy of the custom properties with the given string values set.
+func withStringValues(props map[string]openapi.MetadataValue, values map[string]string) map[string]openapi.MetadataValue {
+	updated := maps.Clone(props)
+	if updated == nil {
+		updated = map[string]openapi.MetadataValue{}
+	}
+	for name, value := range values {
+		if value == "" {
+			continue
+		}
+		updated[name] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+	}
+	return updated
+}
+
+func stringValue(props map[string]openapi.MetadataValue, name string) string {
+	value, ok := props[name]
+	if !ok || value.MetadataStringValue == nil {
+		return ""
+	}
+	return value.MetadataStringValue.StringValue
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
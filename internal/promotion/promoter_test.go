@@ -0,0 +1,313 @@
+package promotion
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memRegistry is an in memory Registry, the filter query only supports "state='<state>'".
+type memRegistry struct {
+	nextId           int
+	registeredModels map[string]*openapi.RegisteredModel
+	modelVersions    map[string]*openapi.ModelVersion
+	artifacts        map[string][]openapi.Artifact
+}
+
+func newMemRegistry() *memRegistry {
+	return &memRegistry{
+		registeredModels: map[string]*openapi.RegisteredModel{},
+		modelVersions:    map[string]*openapi.ModelVersion{},
+		artifacts:        map[string][]openapi.Artifact{},
+	}
+}
+
+func (m *memRegistry) id() *string {
+	m.nextId++
+	id := strconv.Itoa(m.nextId)
+	return &id
+}
+
+func (m *memRegistry) GetRegisteredModelById(id string) (*openapi.RegisteredModel, error) {
+	if registeredModel, ok := m.registeredModels[id]; ok {
+		return registeredModel, nil
+	}
+	return nil, api.ErrNotFound
+}
+
+func (m *memRegistry) GetRegisteredModelByParams(name *string, externalId *string) (*openapi.RegisteredModel, error) {
+	for _, registeredModel := range m.registeredModels {
+		if registeredModel.Name == *name {
+			return registeredModel, nil
+		}
+	}
+	return nil, api.ErrNotFound
+}
+
+func (m *memRegistry) UpsertRegisteredModel(registeredModel *openapi.RegisteredModel) (*openapi.RegisteredModel, error) {
+	created := *registeredModel
+	created.Id = m.id()
+	m.registeredModels[*created.Id] = &created
+	return &created, nil
+}
+
+func (m *memRegistry) GetModelVersionByParams(versionName *string, registeredModelId *string, externalId *string) (*openapi.ModelVersion, error) {
+	for _, modelVersion := range m.modelVersions {
+		if modelVersion.Name == *versionName && modelVersion.RegisteredModelId == *registeredModelId {
+			return modelVersion, nil
+		}
+	}
+	return nil, api.ErrNotFound
+}
+
+func (m *memRegistry) GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error) {
+	ids := []int{}
+	for id, modelVersion := range m.modelVersions {
+		if registeredModelId != nil && modelVersion.RegisteredModelId != *registeredModelId {
+			continue
+		}
+		if listOptions.FilterQuery != nil && fmt.Sprintf("state='%s'", *modelVersion.State) != *listOptions.FilterQuery {
+			continue
+		}
+		i, _ := strconv.Atoi(id)
+		ids = append(ids, i)
+	}
+
+	sort.Ints(ids)
+
+	start := 0
+	if listOptions.NextPageToken != nil {
+		start, _ = strconv.Atoi(*listOptions.NextPageToken)
+	}
+
+	list := &openapi.ModelVersionList{Items: []openapi.ModelVersion{}}
+	for i := start; i < len(ids) && i < start+int(*listOptions.PageSize); i++ {
+		list.Items = append(list.Items, *m.modelVersions[strconv.Itoa(ids[i])])
+	}
+	if end := start + int(*listOptions.PageSize); end < len(ids) {
+		list.NextPageToken = strconv.Itoa(end)
+	}
+	list.Size = int32(len(list.Items))
+	return list, nil
+}
+
+func (m *memRegistry) UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error) {
+	upserted := *modelVersion
+	if upserted.Id == nil {
+		upserted.Id = m.id()
+	}
+	if registeredModelId != nil {
+		upserted.RegisteredModelId = *registeredModelId
+	}
+	m.modelVersions[*upserted.Id] = &upserted
+	return &upserted, nil
+}
+
+func (m *memRegistry) GetArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions api.ListOptions, parentResourceId *string) (*openapi.ArtifactList, error) {
+	items := m.artifacts[*parentResourceId]
+	return &openapi.ArtifactList{Items: items, Size: int32(len(items))}, nil
+}
+
+func (m *memRegistry) UpsertModelVersionArtifact(artifact *openapi.Artifact, modelVersionId string) (*openapi.Artifact, error) {
+	if artifact.ModelArtifact != nil && artifact.ModelArtifact.Id != nil {
+		return nil, fmt.Errorf("artifact id %s of another registry: %w", *artifact.ModelArtifact.Id, api.ErrBadRequest)
+	}
+	m.artifacts[modelVersionId] = append(m.artifacts[modelVersionId], *artifact)
+	return artifact, nil
+}
+
+func (m *memRegistry) addModelVersion(t *testing.T, modelName string, versionName string, state openapi.ModelVersionState) *openapi.ModelVersion {
+	registeredModel, err := m.GetRegisteredModelByParams(&modelName, nil)
+	if err != nil {
+		registeredModel, err = m.UpsertRegisteredModel(&openapi.RegisteredModel{Name: modelName, Owner: &modelName})
+		require.NoError(t, err)
+	}
+
+	modelVersion, err := m.UpsertModelVersion(&openapi.ModelVersion{
+		Name:  versionName,
+		State: &state,
+		CustomProperties: map[string]openapi.MetadataValue{
+			"accuracy": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("0.9", "MetadataStringValue")),
+		},
+	}, registeredModel.Id)
+	require.NoError(t, err)
+
+	uri := "oci://registry/" + modelName + ":" + versionName
+	m.artifacts[*modelVersion.Id] = []openapi.Artifact{
+		openapi.ModelArtifactAsArtifact(&openapi.ModelArtifact{Id: m.id(), Name: &versionName, Uri: &uri}),
+	}
+
+	return modelVersion
+}
+
+func TestFindModelVersions(t *testing.T) {
+	source := newMemRegistry()
+	for i := range 150 {
+		source.addModelVersion(t, "granite", fmt.Sprintf("v%d", i), openapi.MODELVERSIONSTATE_LIVE)
+	}
+	source.addModelVersion(t, "granite", "archived", openapi.MODELVERSIONSTATE_ARCHIVED)
+	source.addModelVersion(t, "llama", "v1", openapi.MODELVERSIONSTATE_LIVE)
+
+	versions, err := FindModelVersions(source, api.PromotionSource{RegisteredModelName: "granite", FilterQuery: "state='LIVE'"})
+	require.NoError(t, err)
+	assert.Len(t, versions, 150, "all the pages are listed")
+
+	versions, err = FindModelVersions(source, api.PromotionSource{})
+	require.NoError(t, err)
+	assert.Len(t, versions, 152)
+
+	_, err = FindModelVersions(source, api.PromotionSource{RegisteredModelName: "missing"})
+	assert.ErrorIs(t, err, api.ErrNotFound)
+}
+
+func TestPromote(t *testing.T) {
+	source := newMemRegistry()
+	target := newMemRegistry()
+	version := source.addModelVersion(t, "granite", "v1", openapi.MODELVERSIONSTATE_LIVE)
+
+	promoter := NewPromoter(source, target, Run{PromotionId: "1", RunId: "7", Source: "dev", Target: "prod", Reviewer: "alice"})
+	promoter.now = func() time.Time { return time.UnixMilli(1700000000000) }
+
+	result := promoter.Promote(*version)
+	require.Equal(t, api.PromotionResultPromoted, result.Result, result.Message)
+	assert.Equal(t, "granite", result.RegisteredModelName)
+	assert.Equal(t, "v1", result.VersionName)
+	assert.Equal(t, *version.Id, result.SourceModelVersionId)
+
+	promoted := target.modelVersions[result.TargetModelVersionId]
+	require.NotNil(t, promoted)
+	targetModel := target.registeredModels[promoted.RegisteredModelId]
+	require.NotNil(t, targetModel)
+	assert.Equal(t, "granite", targetModel.Name)
+	assert.Equal(t, "granite", *targetModel.Owner)
+
+	assert.Equal(t, "0.9", stringValue(promoted.CustomProperties, "accuracy"))
+	assert.Equal(t, "dev", stringValue(promoted.CustomProperties, SourceRegistryProperty))
+	assert.Equal(t, *version.Id, stringValue(promoted.CustomProperties, SourceModelVersionIdProperty))
+	assert.Equal(t, "1", stringValue(promoted.CustomProperties, PromotionIdProperty))
+	assert.Equal(t, "7", stringValue(promoted.CustomProperties, RunIdProperty))
+	assert.Equal(t, "alice", stringValue(promoted.CustomProperties, ReviewerProperty))
+	assert.Equal(t, "1700000000000", stringValue(promoted.CustomProperties, PromotedAtProperty))
+
+	artifacts := target.artifacts[result.TargetModelVersionId]
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "oci://registry/granite:v1", *artifacts[0].ModelArtifact.Uri)
+	assert.Nil(t, artifacts[0].ModelArtifact.Id)
+	assert.NotNil(t, source.artifacts[*version.Id][0].ModelArtifact.Id, "the source artifact is left untouched")
+
+	updatedSource := source.modelVersions[*version.Id]
+	assert.Equal(t, result.TargetModelVersionId, stringValue(updatedSource.CustomProperties, "promotion.prod.model_version_id"))
+	assert.Equal(t, "7", stringValue(updatedSource.CustomProperties, "promotion.prod.run_id"))
+	assert.Equal(t, "1700000000000", stringValue(updatedSource.CustomProperties, "promotion.prod.promoted_at"))
+	assert.Empty(t, stringValue(version.CustomProperties, "promotion.prod.run_id"), "the listed model version is not modified")
+
+	t.Run("already promoted", func(t *testing.T) {
+		again := NewPromoter(source, target, Run{PromotionId: "1", RunId: "8", Source: "dev", Target: "prod"}).Promote(*updatedSource)
+		assert.Equal(t, api.PromotionResultSkipped, again.Result)
+		assert.Equal(t, result.TargetModelVersionId, again.TargetModelVersionId)
+		assert.Equal(t, "already promoted by run 7", again.Message)
+		assert.Len(t, target.modelVersions, 1)
+	})
+
+	t.Run("existing version not promoted", func(t *testing.T) {
+		target.addModelVersion(t, "llama", "v1", openapi.MODELVERSIONSTATE_LIVE)
+		other := source.addModelVersion(t, "llama", "v1", openapi.MODELVERSIONSTATE_LIVE)
+
+		conflict := promoter.Promote(*other)
+		assert.Equal(t, api.PromotionResultFailed, conflict.Result)
+		assert.Contains(t, conflict.Message, "already exists in registry prod")
+	})
+}
+
+func TestPlan(t *testing.T) {
+	source := newMemRegistry()
+	target := newMemRegistry()
+	v1 := source.addModelVersion(t, "granite", "v1", openapi.MODELVERSIONSTATE_LIVE)
+	v2 := source.addModelVersion(t, "granite", "v2", openapi.MODELVERSIONSTATE_LIVE)
+
+	plan := api.NewPlan()
+	promoter := NewPromoter(source, target, Run{PromotionId: "1", Source: "dev", Target: "prod"})
+	require.NoError(t, promoter.Plan(*v1, plan))
+	require.NoError(t, promoter.Plan(*v2, plan))
+	assert.Empty(t, target.registeredModels, "nothing is copied")
+	assert.Empty(t, source.modelVersions[*v1.Id].CustomProperties["promotion.prod.run_id"], "nothing is recorded on the source")
+
+	require.Len(t, plan.Items, 7)
+	assert.Equal(t, api.PlanItem{Action: api.PlanActionCreate, EntityType: api.EntityTypeRegisteredModel, Name: "granite", SourceId: v1.RegisteredModelId}, plan.Items[0],
+		"the registered model is created once")
+	assert.Equal(t, api.PlanItem{Action: api.PlanActionCreate, EntityType: api.EntityTypeModelVersion, Name: "v1", ParentName: "granite", SourceId: *v1.Id}, plan.Items[1])
+	assert.Equal(t, api.PlanActionCreate, plan.Items[2].Action)
+	assert.Equal(t, api.EntityTypeArtifact, plan.Items[2].EntityType)
+	assert.Equal(t, "v1", plan.Items[2].Name)
+	assert.Equal(t, "v1", plan.Items[2].ParentName)
+	update := plan.Items[3]
+	assert.Equal(t, api.PlanActionUpdate, update.Action)
+	assert.Equal(t, *v1.Id, update.Id)
+	assert.Equal(t, []api.PlanFieldChange{
+		{Field: "customProperties.promotion.prod.model_version_id"},
+		{Field: "customProperties.promotion.prod.run_id"},
+		{Field: "customProperties.promotion.prod.promoted_at"},
+	}, update.Changes)
+	assert.Equal(t, int32(5), plan.Creates)
+	assert.Equal(t, int32(2), plan.Updates)
+
+	result := NewPromoter(source, target, Run{PromotionId: "1", RunId: "7", Source: "dev", Target: "prod"}).Promote(*v1)
+	require.Equal(t, api.PromotionResultPromoted, result.Result, result.Message)
+	target.addModelVersion(t, "granite", "v2", openapi.MODELVERSIONSTATE_LIVE)
+
+	plan = api.NewPlan()
+	promoter = NewPromoter(source, target, Run{PromotionId: "1", Source: "dev", Target: "prod"})
+	require.NoError(t, promoter.Plan(*source.modelVersions[*v1.Id], plan))
+	require.NoError(t, promoter.Plan(*v2, plan))
+	require.Len(t, plan.Items, 2)
+	assert.Equal(t, api.PlanActionSkip, plan.Items[0].Action)
+	assert.Equal(t, result.TargetModelVersionId, plan.Items[0].Id)
+	assert.Equal(t, "already promoted by run 7", plan.Items[0].Message)
+	assert.Equal(t, api.PlanActionConflict, plan.Items[1].Action)
+	assert.Contains(t, plan.Items[1].Message, "already exists in registry prod")
+	assert.Equal(t, int32(1), plan.Skips)
+	assert.Equal(t, int32(1), plan.Conflicts)
+}
+
+func TestNewRemotes(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+	registriesFile := filepath.Join(t.TempDir(), "registries.yaml")
+	require.NoError(t, os.WriteFile(registriesFile, []byte(`
+registries:
+  - name: prod
+    url: http://prod:8080
+    tokenFile: `+tokenFile+`
+  - name: staging
+    url: https://staging
+`), 0o600))
+
+	config, err := LoadRegistries(registriesFile)
+	require.NoError(t, err)
+	remotes, err := NewRemotes(config)
+	require.NoError(t, err)
+	assert.Len(t, remotes, 2)
+
+	for message, registries := range map[string][]RemoteRegistry{
+		"name is required": {{URL: "http://prod"}},
+		"invalid promotion registry prod: duplicate name": {{Name: "prod", URL: "http://prod"}, {Name: "prod", URL: "http://other"}},
+		`invalid url "file:///etc/passwd"`:                {{Name: "prod", URL: "file:///etc/passwd"}},
+		"error reading token":                             {{Name: "prod", URL: "http://prod", TokenFile: filepath.Join(t.TempDir(), "missing")}},
+	} {
+		_, err := NewRemotes(&RegistriesConfig{Registries: registries})
+		assert.ErrorContains(t, err, message)
+	}
+
+	require.NoError(t, os.WriteFile(registriesFile, []byte(`registries: [{name: prod, url: http://prod, tokenEnvVar: DB_PASSWORD}]`), 0o600))
+	_, err = LoadRegistries(registriesFile)
+	assert.ErrorContains(t, err, "error parsing promotion registries file")
+}
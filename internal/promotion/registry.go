@@ -0,0 +1,268 @@
+package promotion
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/clientutil"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Registry is the part of the model registry api a promotion reads the model versions from and copies
+// them to. api.ModelRegistryApi implements it for this registry, NewRESTRegistry for a remote one.
+type Registry interface {
+	GetRegisteredModelById(id string) (*openapi.RegisteredModel, error)
+	GetRegisteredModelByParams(name *string, externalId *string) (*openapi.RegisteredModel, error)
+	UpsertRegisteredModel(registeredModel *openapi.RegisteredModel) (*openapi.RegisteredModel, error)
+	GetModelVersionByParams(versionName *string, registeredModelId *string, externalId *string) (*openapi.ModelVersion, error)
+	GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error)
+	UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error)
+	GetArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions api.ListOptions, parentResourceId *string) (*openapi.ArtifactList, error)
+	UpsertModelVersionArtifact(artifact *openapi.Artifact, modelVersionId string) (*openapi.Artifact, error)
+}
+
+// RemoteRegistry is a remote model registry the promotions read the model versions from or copy them to, configured
+// on the server with its credentials.
+type RemoteRegistry struct {
+	// Name identifies the registry in the promotions, e.g. "prod".
+	Name string `json:"name"`
+	// URL is the base URL of the registry REST API.
+	URL string `json:"url"`
+	// TokenFile is the file of the bearer token sent to the registry, e.g. a mounted secret, the requests are not
+	// authenticated when empty.
+	TokenFile string `json:"tokenFile,omitempty"`
+}
+
+// RegistriesConfig is the content of the promotion registries file, e.g.
+//
+//	registries:
+//	  - name: prod
+//	    url: https://model-registry.prod.example.com
+//	    tokenFile: /var/run/secrets/prod-registry/token
+type RegistriesConfig struct {
+	Registries []RemoteRegistry `json:"registries"`
+}
+
+// LoadRegistries reads a promotion registries file.
+func LoadRegistries(path string) (*RegistriesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading promotion registries file: %w", err)
+	}
+	var config RegistriesConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing promotion registries file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Remotes are the remote registries of the promotions by name.
+type Remotes map[string]Registry
+
+// NewRemotes returns the remote registries of config, reading their tokens.
+func NewRemotes(config *RegistriesConfig) (Remotes, error) {
+	remotes := make(Remotes, len(config.Registries))
+	for _, remote := range config.Registries {
+		if remote.Name == "" {
+			return nil, errors.New("invalid promotion registry: name is required")
+		}
+		if _, ok := remotes[remote.Name]; ok {
+			return nil, fmt.Errorf("invalid promotion registry %s: duplicate name", remote.Name)
+		}
+		registry, err := NewRESTRegistry(remote)
+		if err != nil {
+			return nil, fmt.Errorf("invalid promotion registry %s: %w", remote.Name, err)
+		}
+		remotes[remote.Name] = registry
+	}
+	return remotes, nil
+}
+
+// restRegistry is a Registry calling the REST API of a remote model registry.
+type restRegistry struct {
+	client *openapi.APIClient
+}
+
+// NewRESTRegistry returns the Registry reached at the url of remote, authenticated with the bearer token of its
+// TokenFile if set.
+func NewRESTRegistry(remote RemoteRegistry) (Registry, error) {
+	u, err := url.Parse(remote.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q, expected http(s)://host[:port]", remote.URL)
+	}
+
+	cfg := openapi.NewConfiguration()
+	cfg.Servers = openapi.ServerConfigurations{{URL: remote.URL}}
+
+	if remote.TokenFile != "" {
+		token, err := os.ReadFile(remote.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading token: %w", err)
+		}
+		if len(bytes.TrimSpace(token)) == 0 {
+			return nil, fmt.Errorf("empty token file %s", remote.TokenFile)
+		}
+		cfg.AddDefaultHeader("Authorization", "Bearer "+string(bytes.TrimSpace(token)))
+	}
+
+	return &restRegistry{client: openapi.NewAPIClient(cfg)}, nil
+}
+
+func (r *restRegistry) GetRegisteredModelById(id string) (*openapi.RegisteredModel, error) {
+	registeredModel, resp, err := r.client.ModelRegistryServiceAPI.GetRegisteredModel(context.Background(), id).Execute()
+	return registeredModel, restError(resp, err)
+}
+
+func (r *restRegistry) GetRegisteredModelByParams(name *string, externalId *string) (*openapi.RegisteredModel, error) {
+	req := r.client.ModelRegistryServiceAPI.FindRegisteredModel(context.Background())
+	if name != nil {
+		req = req.Name(*name)
+	}
+	if externalId != nil {
+		req = req.ExternalId(*externalId)
+	}
+	registeredModel, resp, err := req.Execute()
+	return registeredModel, restError(resp, err)
+}
+
+func (r *restRegistry) UpsertRegisteredModel(registeredModel *openapi.RegisteredModel) (*openapi.RegisteredModel, error) {
+	if registeredModel.Id != nil {
+		return nil, fmt.Errorf("updating registered models is not supported: %w", api.ErrBadRequest)
+	}
+
+	create := openapi.RegisteredModelCreate{}
+	if err := convert(registeredModel, &create); err != nil {
+		return nil, err
+	}
+	created, resp, err := r.client.ModelRegistryServiceAPI.CreateRegisteredModel(context.Background()).RegisteredModelCreate(create).Execute()
+	return created, restError(resp, err)
+}
+
+func (r *restRegistry) GetModelVersionByParams(versionName *string, registeredModelId *string, externalId *string) (*openapi.ModelVersion, error) {
+	req := r.client.ModelRegistryServiceAPI.FindModelVersion(context.Background())
+	if versionName != nil {
+		req = req.Name(*versionName)
+	}
+	if registeredModelId != nil {
+		req = req.ParentResourceId(*registeredModelId)
+	}
+	if externalId != nil {
+		req = req.ExternalId(*externalId)
+	}
+	modelVersion, resp, err := req.Execute()
+	return modelVersion, restError(resp, err)
+}
+
+func (r *restRegistry) GetModelVersions(listOptions api.ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error) {
+	if registeredModelId != nil {
+		req := r.client.ModelRegistryServiceAPI.GetRegisteredModelVersions(context.Background(), *registeredModelId)
+		if listOptions.FilterQuery != nil {
+			req = req.FilterQuery(*listOptions.FilterQuery)
+		}
+		if listOptions.PageSize != nil {
+			req = req.PageSize(strconv.Itoa(int(*listOptions.PageSize)))
+		}
+		if listOptions.NextPageToken != nil {
+			req = req.NextPageToken(*listOptions.NextPageToken)
+		}
+		list, resp, err := req.Execute()
+		return list, restError(resp, err)
+	}
+
+	req := r.client.ModelRegistryServiceAPI.GetModelVersions(context.Background())
+	if listOptions.FilterQuery != nil {
+		req = req.FilterQuery(*listOptions.FilterQuery)
+	}
+	if listOptions.PageSize != nil {
+		req = req.PageSize(strconv.Itoa(int(*listOptions.PageSize)))
+	}
+	if listOptions.NextPageToken != nil {
+		req = req.NextPageToken(*listOptions.NextPageToken)
+	}
+	list, resp, err := req.Execute()
+	return list, restError(resp, err)
+}
+
+func (r *restRegistry) UpsertModelVersion(modelVersion *openapi.ModelVersion, registeredModelId *string) (*openapi.ModelVersion, error) {
+	if modelVersion.Id != nil {
+		update := openapi.ModelVersionUpdate{}
+		if err := convert(modelVersion, &update); err != nil {
+			return nil, err
+		}
+		updated, resp, err := r.client.ModelRegistryServiceAPI.UpdateModelVersion(context.Background(), *modelVersion.Id).ModelVersionUpdate(update).Execute()
+		return updated, restError(resp, err)
+	}
+
+	create := openapi.ModelVersionCreate{}
+	if err := convert(modelVersion, &create); err != nil {
+		return nil, err
+	}
+	if registeredModelId != nil {
+		create.RegisteredModelId = *registeredModelId
+	}
+	created, resp, err := r.client.ModelRegistryServiceAPI.CreateModelVersion(context.Background()).ModelVersionCreate(create).Execute()
+	return created, restError(resp, err)
+}
+
+func (r *restRegistry) GetArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions api.ListOptions, parentResourceId *string) (*openapi.ArtifactList, error) {
+	if parentResourceId == nil {
+		return nil, fmt.Errorf("listing artifacts requires a model version id: %w", api.ErrBadRequest)
+	}
+
+	req := r.client.ModelRegistryServiceAPI.GetModelVersionArtifacts(context.Background(), *parentResourceId)
+	if artifactType != "" {
+		req = req.ArtifactType(artifactType)
+	}
+	if listOptions.PageSize != nil {
+		req = req.PageSize(strconv.Itoa(int(*listOptions.PageSize)))
+	}
+	if listOptions.NextPageToken != nil {
+		req = req.NextPageToken(*listOptions.NextPageToken)
+	}
+	list, resp, err := req.Execute()
+	return list, restError(resp, err)
+}
+
+func (r *restRegistry) UpsertModelVersionArtifact(artifact *openapi.Artifact, modelVersionId string) (*openapi.Artifact, error) {
+	upserted, resp, err := r.client.ModelRegistryServiceAPI.UpsertModelVersionArtifact(context.Background(), modelVersionId).Artifact(*artifact).Execute()
+	return upserted, restError(resp, err)
+}
+
+// convert copies the fields of an entity to its create or update request by name.
+func convert(from any, to any) error {
+	encoded, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, to)
+}
+
+// restError maps the http status of a failed call to the api errors.
+func restError(resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	err = clientutil.RestError(err)
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%v: %w", err, api.ErrNotFound)
+		case http.StatusConflict:
+			return fmt.Errorf("%v: %w", err, api.ErrConflict)
+		case http.StatusBadRequest:
+			return fmt.Errorf("%v: %w", err, api.ErrBadRequest)
+		}
+	}
+
+	return err
+}
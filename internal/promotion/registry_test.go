@@ -0,0 +1,50 @@
+package promotion
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTRegistry(t *testing.T) {
+	var created openapi.ModelVersionCreate
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/model_registry/v1alpha3/registered_model", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"404","message":"no registered models found"}`))
+	})
+	mux.HandleFunc("POST /api/model_registry/v1alpha3/model_versions", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(openapi.ModelVersion{Id: ptr("2"), Name: created.Name, RegisteredModelId: created.RegisteredModelId})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("secret"), 0o600))
+	registry, err := NewRESTRegistry(RemoteRegistry{Name: "prod", URL: server.URL, TokenFile: tokenFile})
+	require.NoError(t, err)
+
+	_, err = registry.GetRegisteredModelByParams(ptr("granite"), nil)
+	assert.ErrorIs(t, err, api.ErrNotFound)
+	assert.ErrorContains(t, err, "no registered models found")
+
+	version, err := registry.UpsertModelVersion(&openapi.ModelVersion{Name: "v1", Author: ptr("alice")}, ptr("1"))
+	require.NoError(t, err)
+	assert.Equal(t, "2", *version.Id)
+	assert.Equal(t, "1", created.RegisteredModelId)
+	assert.Equal(t, "alice", *created.Author)
+}
@@ -0,0 +1,223 @@
+// Package propertylimits caps the number of custom properties of the entities of each type and the size of their
+// values, e.g. experiment runs logging thousands of keys by mistake, to protect the property tables and the
+// performance of the lists.
+//
+// Writes breaking a limit are rejected, or truncated for the types configured to: the custom properties above the
+// count are dropped and the values above the size are shortened.
+package propertylimits
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Mode is what writes breaking a limit do.
+type Mode string
+
+const (
+	// ModeReject fails the writes breaking a limit with api.ErrBadRequest, the default.
+	ModeReject Mode = "reject"
+	// ModeTruncate drops the custom properties above the count, in key order, and shortens the string values above
+	// the size. The struct and proto values above the size can't be shortened and are dropped.
+	ModeTruncate Mode = "truncate"
+)
+
+// entityTypes are the entity types limits can be set for, the Artifact limits apply to model artifacts, doc artifacts
+// and datasets.
+var entityTypes = map[string]bool{
+	api.EntityTypeRegisteredModel:    true,
+	api.EntityTypeModelVersion:       true,
+	api.EntityTypeArtifact:           true,
+	api.EntityTypeServingEnvironment: true,
+	api.EntityTypeInferenceService:   true,
+	api.EntityTypeServeModel:         true,
+	api.EntityTypeExperiment:         true,
+	api.EntityTypeExperimentRun:      true,
+}
+
+// Limit caps the custom properties of the entities of a type, a zero limit is unlimited.
+type Limit struct {
+	// MaxCount is the number of custom properties of an entity.
+	MaxCount int `json:"maxCount"`
+	// MaxValueSize is the size in bytes of the value of a custom property.
+	MaxValueSize int  `json:"maxValueSize"`
+	Mode         Mode `json:"mode"`
+}
+
+// Config is the content of the custom property limits file, e.g.
+//
+//	limits:
+//	  ExperimentRun:
+//	    maxCount: 200
+//	    maxValueSize: 4096
+//	    mode: truncate
+type Config struct {
+	Limits map[string]Limit `json:"limits"`
+}
+
+// LoadConfig reads a custom property limits file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom property limits file: %w", err)
+	}
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing custom property limits file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// Enforcer applies the limits of each entity type to the custom properties of the writes.
+type Enforcer struct {
+	limits map[string]Limit
+}
+
+// NewEnforcer validates the limits of config, it returns nil when there are none.
+func NewEnforcer(config *Config) (*Enforcer, error) {
+	if config == nil || len(config.Limits) == 0 {
+		return nil, nil
+	}
+
+	limits := make(map[string]Limit, len(config.Limits))
+	for entityType, limit := range config.Limits {
+		if !entityTypes[entityType] {
+			return nil, fmt.Errorf("invalid custom property limits: unknown entity type %s", entityType)
+		}
+		if limit.MaxCount < 0 || limit.MaxValueSize < 0 {
+			return nil, fmt.Errorf("invalid custom property limits of %s: limits cannot be negative", entityType)
+		}
+		switch limit.Mode {
+		case "":
+			limit.Mode = ModeReject
+		case ModeReject, ModeTruncate:
+		default:
+			return nil, fmt.Errorf("invalid custom property limits of %s: mode must be %s or %s", entityType, ModeReject, ModeTruncate)
+		}
+		limits[entityType] = limit
+	}
+
+	return &Enforcer{limits: limits}, nil
+}
+
+// Apply checks the custom properties written to an entity of entityType against the limits of its type. It returns
+// an error wrapping api.ErrBadRequest for a type rejecting the writes breaking them, otherwise it truncates
+// customProperties and returns the keys of the custom properties it dropped or shortened. Enforcers are nil-safe.
+func (e *Enforcer) Apply(entityType string, customProperties *map[string]openapi.MetadataValue) ([]string, error) {
+	if e == nil || customProperties == nil || len(*customProperties) == 0 {
+		return nil, nil
+	}
+	limit, ok := e.limits[entityType]
+	if !ok {
+		return nil, nil
+	}
+	properties := *customProperties
+
+	if limit.Mode == ModeReject {
+		if limit.MaxCount > 0 && len(properties) > limit.MaxCount {
+			return nil, fmt.Errorf("%s has %d custom properties, the maximum is %d: %w", entityType, len(properties), limit.MaxCount, api.ErrBadRequest)
+		}
+		if limit.MaxValueSize > 0 {
+			for _, key := range sortedKeys(properties) {
+				if size := valueSize(properties[key]); size > limit.MaxValueSize {
+					return nil, fmt.Errorf("%s custom property %s has a value of %d bytes, the maximum is %d: %w", entityType, key, size, limit.MaxValueSize, api.ErrBadRequest)
+				}
+			}
+		}
+		return nil, nil
+	}
+
+	var truncated []string
+	for i, key := range sortedKeys(properties) {
+		if limit.MaxCount > 0 && i >= limit.MaxCount {
+			delete(properties, key)
+			truncated = append(truncated, key)
+			continue
+		}
+		if limit.MaxValueSize > 0 && valueSize(properties[key]) > limit.MaxValueSize {
+			if shortened, ok := shorten(properties[key], limit.MaxValueSize); ok {
+				properties[key] = shortened
+			} else {
+				delete(properties, key)
+			}
+			truncated = append(truncated, key)
+		}
+	}
+	return truncated, nil
+}
+
+// Warnings reports the custom properties of an entity of entityType at the limits of a type truncating the writes,
+// as its later writes may be truncated. Enforcers are nil-safe.
+func (e *Enforcer) Warnings(entityType string, customProperties map[string]openapi.MetadataValue) []api.Warning {
+	if e == nil {
+		return nil
+	}
+	limit, ok := e.limits[entityType]
+	if !ok || limit.Mode != ModeTruncate {
+		return nil
+	}
+
+	var warnings []api.Warning
+	if limit.MaxCount > 0 && len(customProperties) >= limit.MaxCount {
+		warnings = append(warnings, api.Warning{
+			Rule:    api.LintRuleCustomPropertyLimits,
+			Message: fmt.Sprintf("entity has %d custom properties, the maximum of %s, the custom properties beyond it are dropped", len(customProperties), entityType),
+		})
+	}
+	if limit.MaxValueSize > 0 {
+		for _, key := range sortedKeys(customProperties) {
+			// shortened values end up to a rune short of the limit
+			if valueSize(customProperties[key]) > limit.MaxValueSize-utf8.UTFMax {
+				warnings = append(warnings, api.Warning{
+					Rule:    api.LintRuleCustomPropertyLimits,
+					Message: fmt.Sprintf("custom property %s has a value at the maximum size of %d bytes of %s, longer values are truncated", key, limit.MaxValueSize, entityType),
+				})
+			}
+		}
+	}
+	return warnings
+}
+
+// valueSize returns the size of the variable length content of a value.
+func valueSize(value openapi.MetadataValue) int {
+	switch {
+	case value.MetadataStringValue != nil:
+		return len(value.MetadataStringValue.StringValue)
+	case value.MetadataStructValue != nil:
+		return len(value.MetadataStructValue.StructValue)
+	case value.MetadataProtoValue != nil:
+		return len(value.MetadataProtoValue.ProtoValue)
+	case value.MetadataIntValue != nil:
+		return len(value.MetadataIntValue.IntValue)
+	}
+	return 0
+}
+
+// shorten returns a string value cut to at most size bytes, without splitting a character.
+func shorten(value openapi.MetadataValue, size int) (openapi.MetadataValue, bool) {
+	if value.MetadataStringValue == nil {
+		return value, false
+	}
+	s := value.MetadataStringValue.StringValue
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+	shortened := *value.MetadataStringValue
+	shortened.StringValue = s[:size]
+	return openapi.MetadataValue{MetadataStringValue: &shortened}, true
+}
+
+func sortedKeys(properties map[string]openapi.MetadataValue) []string {
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
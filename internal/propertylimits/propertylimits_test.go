@@ -0,0 +1,114 @@
+package propertylimits
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringValue(s string) openapi.MetadataValue {
+	return openapi.MetadataValue{MetadataStringValue: openapi.NewMetadataStringValue(s, "MetadataStringValue")}
+}
+
+func TestNewEnforcer(t *testing.T) {
+	enforcer, err := NewEnforcer(&Config{})
+	require.NoError(t, err)
+	assert.Nil(t, enforcer, "no limits")
+
+	for message, limits := range map[string]map[string]Limit{
+		"unknown entity type": {"Run": {MaxCount: 1}},
+		"cannot be negative":  {api.EntityTypeExperimentRun: {MaxValueSize: -1}},
+		"mode must be":        {api.EntityTypeExperimentRun: {Mode: "warn"}},
+	} {
+		_, err := NewEnforcer(&Config{Limits: limits})
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestApply(t *testing.T) {
+	enforcer, err := NewEnforcer(&Config{Limits: map[string]Limit{
+		api.EntityTypeModelVersion:  {MaxCount: 2, MaxValueSize: 8},
+		api.EntityTypeExperimentRun: {MaxCount: 2, MaxValueSize: 8, Mode: ModeTruncate},
+	}})
+	require.NoError(t, err)
+
+	properties := map[string]openapi.MetadataValue{"a": stringValue("short"), "b": stringValue("tiny")}
+	truncated, err := enforcer.Apply(api.EntityTypeModelVersion, &properties)
+	require.NoError(t, err)
+	assert.Empty(t, truncated)
+
+	properties["c"] = stringValue("x")
+	_, err = enforcer.Apply(api.EntityTypeModelVersion, &properties)
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+	assert.ErrorContains(t, err, "3 custom properties")
+
+	properties = map[string]openapi.MetadataValue{"a": stringValue("much too long")}
+	_, err = enforcer.Apply(api.EntityTypeModelVersion, &properties)
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+	assert.ErrorContains(t, err, "custom property a has a value of 13 bytes")
+
+	// the properties above the count are dropped in key order, the long strings are shortened without splitting
+	// characters and the long structs are dropped
+	properties = map[string]openapi.MetadataValue{
+		"a": stringValue("héhéhé-long"),
+		"b": {MetadataStructValue: openapi.NewMetadataStructValue(strings.Repeat("e30=", 4), "MetadataStructValue")},
+		"c": stringValue("dropped"),
+	}
+	truncated, err = enforcer.Apply(api.EntityTypeExperimentRun, &properties)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, truncated)
+	assert.Equal(t, map[string]openapi.MetadataValue{"a": stringValue("héhéh")}, properties)
+
+	properties = map[string]openapi.MetadataValue{"a": stringValue("much too long")}
+	truncated, err = enforcer.Apply(api.EntityTypeExperiment, &properties)
+	require.NoError(t, err)
+	assert.Empty(t, truncated, "types without limits are unchanged")
+
+	var nilEnforcer *Enforcer
+	truncated, err = nilEnforcer.Apply(api.EntityTypeModelVersion, &properties)
+	require.NoError(t, err)
+	assert.Empty(t, truncated)
+}
+
+func TestWarnings(t *testing.T) {
+	enforcer, err := NewEnforcer(&Config{Limits: map[string]Limit{
+		api.EntityTypeModelVersion:  {MaxCount: 1},
+		api.EntityTypeExperimentRun: {MaxCount: 2, MaxValueSize: 8, Mode: ModeTruncate},
+	}})
+	require.NoError(t, err)
+
+	properties := map[string]openapi.MetadataValue{"a": stringValue("12345678"), "b": stringValue("x")}
+	warnings := enforcer.Warnings(api.EntityTypeExperimentRun, properties)
+	require.Len(t, warnings, 2)
+	assert.Equal(t, api.LintRuleCustomPropertyLimits, warnings[0].Rule)
+	assert.Contains(t, warnings[0].Message, "2 custom properties")
+	assert.Contains(t, warnings[1].Message, "custom property a")
+
+	assert.Empty(t, enforcer.Warnings(api.EntityTypeExperimentRun, map[string]openapi.MetadataValue{"b": stringValue("x")}))
+	assert.Empty(t, enforcer.Warnings(api.EntityTypeModelVersion, properties), "rejecting types don't warn")
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+limits:
+  ExperimentRun:
+    maxCount: 200
+    maxValueSize: 4096
+    mode: truncate
+`), 0o600))
+
+	config, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, Limit{MaxCount: 200, MaxValueSize: 4096, Mode: ModeTruncate}, config.Limits[api.EntityTypeExperimentRun])
+
+	require.NoError(t, os.WriteFile(path, []byte("limits:\n  ExperimentRun:\n    maxKeys: 1\n"), 0o600))
+	_, err = LoadConfig(path)
+	assert.Error(t, err, "unknown fields are rejected")
+}
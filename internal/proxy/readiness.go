@@ -31,6 +31,8 @@ const (
 	detailDatastoreType                 = "datastore_type"
 	detailSchemaVersion                 = "schema_version"
 	detailSchemaDirty                   = "schema_dirty"
+	detailSchemaExpectedVersion         = "schema_expected_version"
+	detailSchemaCheck                   = "schema_check"
 	detailRegisteredModelsAccessible    = "registered_models_accessible"
 	detailRegisteredModelsCount         = "registered_models_count"
 	detailArtifactsAccessible           = "artifacts_accessible"
@@ -121,6 +123,18 @@ func (d *DatabaseHealthChecker) Check() HealthCheck {
 		return check
 	}
 
+	// Report the result of the startup schema drift check, in strict mode the live version must still match
+	if schemaCheck, ok := db.GetSchemaCheckResult(); ok {
+		check.Details[detailSchemaExpectedVersion] = schemaCheck.ExpectedVersion
+		check.Details[detailSchemaCheck] = schemaCheck
+
+		if schemaCheck.Mode == db.SchemaCheckStrict && uint(result.Version) != schemaCheck.ExpectedVersion {
+			check.Status = StatusFail
+			check.Message = fmt.Sprintf("database schema version %d does not match expected version %d", result.Version, schemaCheck.ExpectedVersion)
+			return check
+		}
+	}
+
 	check.Status = StatusPass
 	check.Message = "database is healthy"
 	return check
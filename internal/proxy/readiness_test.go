@@ -56,6 +56,12 @@ func getTypeIDs(sharedDB *gorm.DB) map[string]int32 {
 		defaults.MetricTypeName,
 		defaults.ParameterTypeName,
 		defaults.MetricHistoryTypeName,
+		defaults.PromotionTypeName,
+		defaults.PromotionRunTypeName,
+		defaults.ConversionJobTypeName,
+		defaults.DeploymentTypeName,
+		defaults.ABTestTypeName,
+		defaults.StageTransitionTypeName,
 	}
 
 	for _, typeName := range typeNames {
@@ -97,6 +103,17 @@ func setupModelRegistryService(sharedDB *gorm.DB) api.ModelRegistryApi {
 	metricRepo := service.NewMetricRepository(sharedDB, typesMap[defaults.MetricTypeName])
 	parameterRepo := service.NewParameterRepository(sharedDB, typesMap[defaults.ParameterTypeName])
 	metricHistoryRepo := service.NewMetricHistoryRepository(sharedDB, typesMap[defaults.MetricHistoryTypeName])
+	promotionRepo := service.NewPromotionRepository(sharedDB, typesMap[defaults.PromotionTypeName])
+	promotionRunRepo := service.NewPromotionRunRepository(sharedDB, typesMap[defaults.PromotionRunTypeName])
+	conversionJobRepo := service.NewConversionJobRepository(sharedDB, typesMap[defaults.ConversionJobTypeName])
+	deploymentRepo := service.NewDeploymentRepository(sharedDB, typesMap[defaults.DeploymentTypeName])
+	abTestRepo := service.NewABTestRepository(sharedDB, typesMap[defaults.ABTestTypeName])
+	lineageRepo := service.NewLineageRepository(sharedDB)
+	metricsTableRepo := service.NewMetricsTableRepository(sharedDB, typesMap[defaults.MetricsTableTypeName])
+	modelCardRepo := service.NewModelCardRepository(sharedDB, typesMap[defaults.ModelCardTypeName])
+	stageTransitionRepo := service.NewStageTransitionRepository(sharedDB, typesMap[defaults.StageTransitionTypeName])
+	tagRepo := service.NewTagRepository(sharedDB)
+	auditEventRepo := service.NewAuditEventRepository(sharedDB)
 
 	// Create the core service
 	service := core.NewModelRegistryService(
@@ -114,6 +131,17 @@ func setupModelRegistryService(sharedDB *gorm.DB) api.ModelRegistryApi {
 		metricRepo,
 		parameterRepo,
 		metricHistoryRepo,
+		promotionRepo,
+		promotionRunRepo,
+		conversionJobRepo,
+		deploymentRepo,
+		abTestRepo,
+		lineageRepo,
+		metricsTableRepo,
+		modelCardRepo,
+		stageTransitionRepo,
+		tagRepo,
+		auditEventRepo,
 		typesMap,
 	)
 
@@ -196,6 +224,40 @@ func TestReadinessHandler_EmbedMD_Dirty(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), "database schema is in dirty state")
 }
 
+func TestReadinessHandler_EmbedMD_SchemaCheck(t *testing.T) {
+	sharedDB, _, _, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	cleanupSchemaState(t, sharedDB)
+
+	result, err := db.CheckSchema(sharedDB, db.SchemaCheckStrict)
+	require.NoError(t, err)
+	assert.False(t, result.Drifted(), result.Problems())
+
+	db.SetSchemaCheckResult(result)
+	defer db.SetSchemaCheckResult(nil)
+
+	handler := GeneralReadinessHandler(NewDatabaseHealthChecker())
+	req, err := http.NewRequest("GET", "/readyz/isDirty", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	// A newer expected version means the live schema is missing migrations
+	db.SetSchemaCheckResult(&db.SchemaCheckResult{
+		Mode:            db.SchemaCheckStrict,
+		ExpectedVersion: result.ExpectedVersion + 1,
+		Version:         result.Version,
+	})
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.Contains(t, rr.Body.String(), "does not match expected version")
+}
+
 func TestGeneralReadinessHandler_WithModelRegistry_Success(t *testing.T) {
 	// Ensure clean state before test
 	sharedDB, _, sharedModelRegistryService, cleanup := setupTestDB(t)
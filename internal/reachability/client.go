@@ -0,0 +1,51 @@
+package reachability
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errPrivateAddress is returned when a url resolves to an address of the network of the registry.
+var errPrivateAddress = errors.New("address not allowed")
+
+// NewHTTPClient returns the client of the urls written by the users of the api, the http(s) and oci uris of the
+// artifacts or the urls of the webhooks, refusing to connect to the loopback, private and link-local addresses unless
+// allowPrivateNetworks: they must not probe the services of the network of the registry, e.g. the cloud metadata
+// endpoints. The addresses are checked when connecting, after the names are resolved and on each redirect. The urls
+// are reached without the proxy of the environment, which would connect to any address on their behalf.
+func NewHTTPClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if !allowPrivateNetworks {
+		dialer.Control = refusePrivateAddresses
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
+
+// refusePrivateAddresses is the dialer control refusing the connections to the addresses which are not public.
+func refusePrivateAddresses(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fmt.Errorf("%w: %s is not a public address", errPrivateAddress, ip)
+	}
+	return nil
+}
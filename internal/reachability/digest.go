@@ -0,0 +1,192 @@
+package reachability
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// ErrUnreachable is returned for URIs whose blob can not be read to compute its digest.
+var ErrUnreachable = errors.New("uri not reachable")
+
+// Digest is the content digest of the blob of a URI.
+type Digest struct {
+	// Digest is the sha256 of the content, as sha256:<hex>.
+	Digest string
+	// SizeBytes is the size of the blob, unset if it is unknown.
+	SizeBytes *int64
+}
+
+// Digester computes the content digest of an artifact URI, reading the whole blob.
+type Digester interface {
+	Digest(ctx context.Context, uri string) (Digest, error)
+}
+
+// schemeDigester dispatches the digests to the digester of the URI scheme.
+type schemeDigester struct {
+	client    *http.Client
+	s3        *s3Checker
+	oci       *ociChecker
+	timeout   time.Duration
+	digesters map[string]func(ctx context.Context, uri *url.URL) (Digest, error)
+}
+
+// NewDigester returns the Digester of http(s)://, s3:// and oci:// URIs, reaching them as the Checker does. The
+// digest of a URI reads its blobs for DefaultDigestTimeout at most.
+func NewDigester(cfg Config) Digester {
+	client := NewHTTPClient(DefaultDigestTimeout, cfg.AllowPrivateNetworks)
+
+	d := &schemeDigester{
+		client:  client,
+		s3:      &s3Checker{endpoint: cfg.S3Endpoint, region: cfg.S3Region},
+		oci:     &ociChecker{client: client, scheme: "https"},
+		timeout: DefaultDigestTimeout,
+	}
+	d.digesters = map[string]func(ctx context.Context, uri *url.URL) (Digest, error){
+		"http":  d.digestHTTP,
+		"https": d.digestHTTP,
+		"s3":    d.s3.digest,
+		"oci":   d.oci.digest,
+	}
+
+	return d
+}
+
+func (d *schemeDigester) Digest(ctx context.Context, uri string) (Digest, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: invalid uri: %v", ErrUnreachable, err)
+	}
+
+	digest, ok := d.digesters[parsed.Scheme]
+	if !ok {
+		return Digest{}, fmt.Errorf("%w: %q", ErrUnsupportedScheme, parsed.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	return digest(ctx, parsed)
+}
+
+// digestHTTP reads the body of a GET of the URI.
+func (d *schemeDigester) digestHTTP(ctx context.Context, uri *url.URL) (Digest, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
+	if err != nil {
+		return Digest{}, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Digest{}, fmt.Errorf("%w: server responded %s", ErrUnreachable, resp.Status)
+	}
+
+	return digestOf(resp.Body)
+}
+
+// digest reads the object at the key of the URI or, for the models uploaded as a directory, the objects below it.
+// The digest of a directory is the sha256 of the `<sha256 hex>  <relative key>` lines of its objects in the order of
+// their keys, as sha256sum prints them.
+func (c *s3Checker) digest(ctx context.Context, uri *url.URL) (Digest, error) {
+	bucket := uri.Host
+	key := strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" {
+		return Digest{}, fmt.Errorf("%w: missing bucket", ErrUnreachable)
+	}
+
+	client, err := c.client(uri.Query())
+	if errors.Is(err, errEndpointNotAllowed) {
+		return Digest{}, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	if err != nil {
+		return Digest{}, err
+	}
+
+	if key != "" {
+		digest, err := c.digestObject(ctx, client, bucket, key)
+		if err == nil || !isNotFound(err) {
+			return digest, err
+		}
+	}
+
+	prefix := key
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	var keys []string
+	err = client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %s", ErrUnreachable, s3Message(err))
+	}
+	if len(keys) == 0 {
+		return Digest{}, fmt.Errorf("%w: no object found at s3://%s/%s", ErrUnreachable, bucket, key)
+	}
+
+	listing := sha256.New()
+	var size int64
+	for _, objectKey := range keys {
+		object, err := c.digestObject(ctx, client, bucket, objectKey)
+		if err != nil {
+			return Digest{}, err
+		}
+		fmt.Fprintf(listing, "%s  %s\n", strings.TrimPrefix(object.Digest, "sha256:"), strings.TrimPrefix(objectKey, prefix))
+		size += *object.SizeBytes
+	}
+
+	return Digest{Digest: sha256Digest(listing), SizeBytes: &size}, nil
+}
+
+func (c *s3Checker) digestObject(ctx context.Context, client *s3.S3, bucket string, key string) (Digest, error) {
+	object, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return Digest{}, err
+		}
+		return Digest{}, fmt.Errorf("%w: %s", ErrUnreachable, s3Message(err))
+	}
+	defer object.Body.Close()
+
+	return digestOf(object.Body)
+}
+
+// digestOf reads a blob to its end.
+func digestOf(blob io.Reader) (Digest, error) {
+	h := sha256.New()
+	size, err := io.Copy(h, blob)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+	return Digest{Digest: sha256Digest(h), SizeBytes: &size}, nil
+}
+
+func sha256Digest(h hash.Hash) string {
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
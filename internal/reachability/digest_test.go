@@ -0,0 +1,128 @@
+package reachability
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDigestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model.onnx" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "weights")
+	}))
+	defer server.Close()
+
+	digester := NewDigester(Config{AllowPrivateNetworks: true})
+
+	digest, err := digester.Digest(context.Background(), server.URL+"/model.onnx")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+sha256Hex("weights"), digest.Digest)
+	assert.Equal(t, int64(7), *digest.SizeBytes)
+
+	_, err = digester.Digest(context.Background(), server.URL+"/missing")
+	assert.ErrorIs(t, err, ErrUnreachable)
+	assert.ErrorContains(t, err, "404 Not Found")
+
+	_, err = digester.Digest(context.Background(), "pvc://models/granite")
+	assert.ErrorIs(t, err, ErrUnsupportedScheme)
+}
+
+func TestDigestS3(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	objects := map[string]string{
+		"/models/granite/config.json":       "{}",
+		"/models/granite/model.safetensors": "weights",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if content, ok := objects[r.URL.Path]; ok {
+			fmt.Fprint(w, content)
+			return
+		}
+		if r.URL.Path == "/models" && r.URL.Query().Get("prefix") == "granite/" {
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>granite/config.json</Key><Size>2</Size></Contents>`+
+				`<Contents><Key>granite/model.safetensors</Key><Size>7</Size></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`)
+			return
+		}
+		if r.URL.Path == "/models" {
+			fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated></ListBucketResult>`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<Error><Code>NoSuchKey</Code><Message>not found</Message></Error>`)
+	}))
+	defer server.Close()
+
+	digester := NewDigester(Config{S3Endpoint: server.URL, S3Region: "us-east-1"})
+
+	digest, err := digester.Digest(context.Background(), "s3://models/granite/model.safetensors")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+sha256Hex("weights"), digest.Digest)
+
+	digest, err = digester.Digest(context.Background(), "s3://models/granite")
+	require.NoError(t, err)
+	listing := sha256Hex("{}") + "  config.json\n" + sha256Hex("weights") + "  model.safetensors\n"
+	assert.Equal(t, "sha256:"+sha256Hex(listing), digest.Digest, "directories are digested as their sha256sum listing")
+	assert.Equal(t, int64(9), *digest.SizeBytes)
+
+	_, err = digester.Digest(context.Background(), "s3://models/missing")
+	assert.ErrorIs(t, err, ErrUnreachable)
+
+	_, err = digester.Digest(context.Background(), "s3://models/granite?endpoint="+url.QueryEscape("http://169.254.169.254"))
+	assert.ErrorIs(t, err, ErrUnreachable)
+	assert.ErrorContains(t, err, "endpoint not allowed")
+
+	_, err = NewDigester(Config{}).Digest(context.Background(), server.URL+"/models/granite/model.safetensors")
+	assert.ErrorIs(t, err, ErrUnreachable, "the private addresses are refused")
+}
+
+func TestDigestOCI(t *testing.T) {
+	manifest := `{"config":{"size":10},"layers":[{"size":100},{"size":1000}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/org/granite/manifests/v1":
+			fmt.Fprint(w, manifest)
+		case "/v2/org/granite/manifests/v2":
+			w.Header().Set("Docker-Content-Digest", "sha256:reported")
+			fmt.Fprint(w, manifest)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	digester := NewDigester(Config{AllowPrivateNetworks: true}).(*schemeDigester)
+	digester.oci.scheme = "http"
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	digest, err := digester.Digest(context.Background(), "oci://"+registry+"/org/granite:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+sha256Hex(manifest), digest.Digest)
+	assert.Equal(t, int64(1110), *digest.SizeBytes)
+
+	digest, err = digester.Digest(context.Background(), "oci://"+registry+"/org/granite:v2")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:reported", digest.Digest, "the digest reported by the registry is kept")
+
+	_, err = digester.Digest(context.Background(), "oci://"+registry+"/org/granite:v3")
+	assert.ErrorIs(t, err, ErrUnreachable)
+}
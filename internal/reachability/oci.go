@@ -0,0 +1,57 @@
+package reachability
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kubeflow/model-registry/internal/oci"
+)
+
+// ociChecker checks oci://registry/repository[:tag|@digest] URIs by fetching the manifest with the distribution
+// API, using anonymous bearer tokens when the registry requires them.
+type ociChecker struct {
+	client *http.Client
+	// scheme of the registry API, https outside of tests
+	scheme string
+}
+
+func (c *ociChecker) check(ctx context.Context, uri *url.URL) (Result, error) {
+	_, manifest, err := c.fetchManifest(ctx, uri)
+	if err != nil {
+		return Result{Message: err.Error()}, nil
+	}
+
+	result := Result{Reachable: true}
+	if size, ok := oci.ContentSize(manifest); ok {
+		result.SizeBytes = &size
+	}
+
+	return result, nil
+}
+
+// digest is the digest of the manifest of the URI, as the registry reports it or computed from the manifest, and
+// the size of its image.
+func (c *ociChecker) digest(ctx context.Context, uri *url.URL) (Digest, error) {
+	descriptor, manifest, err := c.fetchManifest(ctx, uri)
+	if err != nil {
+		return Digest{}, fmt.Errorf("%w: %v", ErrUnreachable, err)
+	}
+
+	digest := Digest{Digest: descriptor.Digest}
+	if size, ok := oci.ContentSize(manifest); ok {
+		digest.SizeBytes = &size
+	}
+
+	return digest, nil
+}
+
+func (c *ociChecker) fetchManifest(ctx context.Context, uri *url.URL) (oci.Descriptor, []byte, error) {
+	ref, err := oci.ParseReference(uri.String())
+	if err != nil {
+		return oci.Descriptor{}, nil, err
+	}
+
+	return oci.NewClient(c.client, oci.Config{PlainHTTP: c.scheme == "http"}).FetchManifest(ctx, ref)
+}
@@ -0,0 +1,147 @@
+// Package reachability checks that the URIs of registered artifacts point to existing blobs, so that broken
+// links are found when an artifact is registered instead of when it is deployed.
+package reachability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds the time spent checking a single URI.
+	DefaultTimeout = 30 * time.Second
+	// DefaultDigestTimeout bounds the time spent reading the blobs of a single URI to compute its digest.
+	DefaultDigestTimeout = time.Hour
+)
+
+// ErrUnsupportedScheme is returned for URIs whose scheme can not be checked, their reachability is not recorded.
+var ErrUnsupportedScheme = errors.New("unsupported uri scheme")
+
+// Result is the outcome of the check of a URI.
+type Result struct {
+	// Reachable is true if the blob exists and could be accessed.
+	Reachable bool
+	// SizeBytes is the size of the blob, unset if it is unknown.
+	SizeBytes *int64
+	// Message explains why the blob is not reachable.
+	Message string
+}
+
+// Checker checks the reachability of an artifact URI.
+type Checker interface {
+	Check(ctx context.Context, uri string) (Result, error)
+}
+
+// Config configures the checks of the schemes requiring one.
+type Config struct {
+	// S3Endpoint is the S3 compatible endpoint of the s3:// URIs, defaults to AWS. The URIs with another endpoint
+	// query parameter are not reached.
+	S3Endpoint string
+	// S3Region is the region used for s3:// URIs without a defaultRegion query parameter.
+	S3Region string
+	// AllowPrivateNetworks reaches the http(s):// and oci:// URIs of the loopback, private and link-local addresses,
+	// e.g. the services of the cluster of the registry, refused otherwise.
+	AllowPrivateNetworks bool
+}
+
+// schemeChecker dispatches the checks to the checker of the URI scheme.
+type schemeChecker struct {
+	client   *http.Client
+	s3       *s3Checker
+	oci      *ociChecker
+	timeout  time.Duration
+	checkers map[string]func(ctx context.Context, uri *url.URL) (Result, error)
+}
+
+// NewChecker returns the Checker of http(s)://, s3:// and oci:// URIs. The S3 credentials are read from the
+// standard AWS environment variables and configuration files, OCI registries are accessed anonymously.
+func NewChecker(cfg Config) Checker {
+	client := NewHTTPClient(DefaultTimeout, cfg.AllowPrivateNetworks)
+
+	c := &schemeChecker{
+		client:  client,
+		s3:      &s3Checker{endpoint: cfg.S3Endpoint, region: cfg.S3Region},
+		oci:     &ociChecker{client: client, scheme: "https"},
+		timeout: DefaultTimeout,
+	}
+	c.checkers = map[string]func(ctx context.Context, uri *url.URL) (Result, error){
+		"http":  c.checkHTTP,
+		"https": c.checkHTTP,
+		"s3":    c.s3.check,
+		"oci":   c.oci.check,
+	}
+
+	return c
+}
+
+func (c *schemeChecker) Check(ctx context.Context, uri string) (Result, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return Result{Message: fmt.Sprintf("invalid uri: %v", err)}, nil
+	}
+
+	check, ok := c.checkers[parsed.Scheme]
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %q", ErrUnsupportedScheme, parsed.Scheme)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return check(ctx, parsed)
+}
+
+// checkHTTP sends a HEAD request to the URI, falling back to a single byte GET for servers not supporting it.
+func (c *schemeChecker) checkHTTP(ctx context.Context, uri *url.URL) (Result, error) {
+	resp, err := c.do(ctx, http.MethodHead, uri.String(), nil)
+	if err != nil {
+		return Result{Message: err.Error()}, nil
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		resp, err = c.do(ctx, http.MethodGet, uri.String(), http.Header{"Range": {"bytes=0-0"}})
+		if err != nil {
+			return Result{Message: err.Error()}, nil
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return Result{Message: fmt.Sprintf("server responded %s", resp.Status)}, nil
+	}
+
+	result := Result{Reachable: true}
+	if size, ok := contentRangeSize(resp.Header.Get("Content-Range")); ok {
+		result.SizeBytes = &size
+	} else if resp.StatusCode != http.StatusPartialContent && resp.ContentLength >= 0 {
+		result.SizeBytes = &resp.ContentLength
+	}
+
+	return result, nil
+}
+
+func (c *schemeChecker) do(ctx context.Context, method string, uri string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	return c.client.Do(req)
+}
+
+// contentRangeSize returns the complete length of a "bytes 0-0/<size>" content range.
+func contentRangeSize(contentRange string) (int64, bool) {
+	var start, end, size int64
+	if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &size); err != nil {
+		return 0, false
+	}
+	return size, true
+}
@@ -0,0 +1,252 @@
+package reachability
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/model.onnx":
+			w.Header().Set("Content-Length", "1024")
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			assert.Equal(t, "bytes=0-0", r.Header.Get("Range"))
+			w.Header().Set("Content-Range", "bytes 0-0/4096")
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte("x"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewChecker(Config{AllowPrivateNetworks: true})
+
+	result, err := checker.Check(context.Background(), server.URL+"/model.onnx")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable)
+	require.NotNil(t, result.SizeBytes)
+	assert.Equal(t, int64(1024), *result.SizeBytes)
+
+	result, err = checker.Check(context.Background(), server.URL+"/no-head")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable)
+	require.NotNil(t, result.SizeBytes)
+	assert.Equal(t, int64(4096), *result.SizeBytes)
+
+	result, err = checker.Check(context.Background(), server.URL+"/missing")
+	require.NoError(t, err)
+	assert.False(t, result.Reachable)
+	assert.Equal(t, "server responded 404 Not Found", result.Message)
+
+	_, err = checker.Check(context.Background(), "pvc://models/granite")
+	assert.ErrorIs(t, err, ErrUnsupportedScheme)
+}
+
+func TestCheckS3(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "test")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "test")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/models/granite/model.safetensors":
+			w.Header().Set("Content-Length", "2048")
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.URL.Path == "/models" && r.URL.Query().Get("prefix") == "granite/":
+			fmt.Fprint(w, `<ListBucketResult><Contents><Key>granite/config.json</Key><Size>100</Size></Contents>`+
+				`<Contents><Key>granite/model.safetensors</Key><Size>2048</Size></Contents><IsTruncated>false</IsTruncated></ListBucketResult>`)
+		default:
+			fmt.Fprint(w, `<ListBucketResult><IsTruncated>false</IsTruncated></ListBucketResult>`)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewChecker(Config{S3Endpoint: server.URL, S3Region: "us-east-1"})
+
+	result, err := checker.Check(context.Background(), "s3://models/granite/model.safetensors")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable)
+	assert.Equal(t, int64(2048), *result.SizeBytes)
+
+	result, err = checker.Check(context.Background(), "s3://models/granite")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable, "directories are summed")
+	assert.Equal(t, int64(2148), *result.SizeBytes)
+
+	result, err = checker.Check(context.Background(), "s3://models/missing")
+	require.NoError(t, err)
+	assert.False(t, result.Reachable)
+	assert.Equal(t, "no object found at s3://models/missing", result.Message)
+
+	result, err = checker.Check(context.Background(), "s3://models/granite?endpoint="+url.QueryEscape(server.URL))
+	require.NoError(t, err)
+	assert.True(t, result.Reachable, "the configured endpoint is allowed")
+
+	result, err = checker.Check(context.Background(), "s3://models/granite?endpoint="+url.QueryEscape("http://169.254.169.254"))
+	require.NoError(t, err)
+	assert.False(t, result.Reachable)
+	assert.Equal(t, "endpoint not allowed: http://169.254.169.254", result.Message)
+}
+
+func TestCheckPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1024")
+	}))
+	defer server.Close()
+
+	checker := NewChecker(Config{})
+
+	for _, uri := range []string{server.URL + "/model.onnx", "http://localhost:1/model.onnx", "oci://" + strings.TrimPrefix(server.URL, "http://") + "/org/granite:v1"} {
+		result, err := checker.Check(context.Background(), uri)
+		require.NoError(t, err)
+		assert.False(t, result.Reachable, uri)
+		assert.Contains(t, result.Message, "is not a public address", uri)
+	}
+}
+
+func TestRefusePrivateAddresses(t *testing.T) {
+	for address, allowed := range map[string]bool{
+		"127.0.0.1:80":          false,
+		"10.0.0.1:443":          false,
+		"172.16.5.4:443":        false,
+		"192.168.1.1:443":       false,
+		"169.254.169.254:80":    false,
+		"[::1]:443":             false,
+		"[fd00::1]:443":         false,
+		"[fe80::1]:443":         false,
+		"[::ffff:10.0.0.1]:443": false,
+		"0.0.0.0:80":            false,
+		"8.8.8.8:443":           true,
+		"[2001:4860::8888]:443": true,
+	} {
+		err := refusePrivateAddresses("tcp", address, nil)
+		if allowed {
+			assert.NoError(t, err, address)
+		} else {
+			assert.ErrorIs(t, err, errPrivateAddress, address)
+		}
+	}
+}
+
+func TestCheckOCI(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "registry", r.URL.Query().Get("service"))
+			assert.Equal(t, "repository:org/granite:pull", r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "anonymous"})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer anonymous" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/granite:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/org/granite/manifests/v1":
+			fmt.Fprint(w, `{"config":{"size":10},"layers":[{"size":100},{"size":1000}]}`)
+		case "/v2/org/granite/manifests/sha256:abc":
+			fmt.Fprint(w, `{"manifests":[{"size":500}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	checker := NewChecker(Config{AllowPrivateNetworks: true}).(*schemeChecker)
+	checker.oci.scheme = "http"
+	registry := strings.TrimPrefix(server.URL, "http://")
+
+	result, err := checker.Check(context.Background(), "oci://"+registry+"/org/granite:v1")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable, result.Message)
+	assert.Equal(t, int64(1110), *result.SizeBytes)
+
+	result, err = checker.Check(context.Background(), "oci://"+registry+"/org/granite@sha256:abc")
+	require.NoError(t, err)
+	assert.True(t, result.Reachable)
+	assert.Nil(t, result.SizeBytes, "the size of image indexes is unknown")
+
+	result, err = checker.Check(context.Background(), "oci://"+registry+"/org/granite:v2")
+	require.NoError(t, err)
+	assert.False(t, result.Reachable)
+	assert.Contains(t, result.Message, "404 Not Found for org/granite:v2")
+}
+
+// checkerFunc is a Checker calling the function
+type checkerFunc func(ctx context.Context, uri string) (Result, error)
+
+func (f checkerFunc) Check(ctx context.Context, uri string) (Result, error) {
+	return f(ctx, uri)
+}
+
+func TestVerifier(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		recorded = map[string]Result{}
+	)
+
+	checker := checkerFunc(func(ctx context.Context, uri string) (Result, error) {
+		switch uri {
+		case "https://models/ok":
+			return Result{Reachable: true}, nil
+		case "https://models/broken":
+			return Result{Message: "server responded 404 Not Found"}, nil
+		case "https://models/error":
+			return Result{}, errors.New("unexpected")
+		}
+		return Result{}, ErrUnsupportedScheme
+	})
+
+	verifier := NewVerifier(checker, func(artifactId string, uri string, result Result, verifiedAt time.Time) error {
+		mu.Lock()
+		defer mu.Unlock()
+		recorded[artifactId] = result
+		return nil
+	}, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verifier.Start(ctx)
+
+	verifier.Enqueue("1", "https://models/ok")
+	verifier.Enqueue("2", "https://models/broken")
+	verifier.Enqueue("3", "pvc://models/unsupported")
+	verifier.Enqueue("4", "https://models/error")
+	verifier.Enqueue("5", "https://models/ok")
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(recorded) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, recorded["1"].Reachable)
+	assert.False(t, recorded["2"].Reachable)
+	assert.Equal(t, "server responded 404 Not Found", recorded["2"].Message)
+	assert.NotContains(t, recorded, "3", "unsupported schemes are not recorded")
+	assert.NotContains(t, recorded, "4")
+	assert.True(t, recorded["5"].Reachable)
+}
@@ -0,0 +1,132 @@
+package reachability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// errEndpointNotAllowed is returned for the s3 URIs of another endpoint than the configured one.
+var errEndpointNotAllowed = errors.New("endpoint not allowed")
+
+// s3Checker checks s3://bucket/key URIs of the configured endpoint, the defaultRegion query parameter overrides the
+// configured region. The URIs of other endpoints are refused, the credentials of the registry must not be sent to
+// the endpoints chosen by the users of the api.
+type s3Checker struct {
+	endpoint string
+	region   string
+}
+
+func (c *s3Checker) check(ctx context.Context, uri *url.URL) (Result, error) {
+	bucket := uri.Host
+	key := strings.TrimPrefix(uri.Path, "/")
+	if bucket == "" {
+		return Result{Message: "missing bucket"}, nil
+	}
+
+	client, err := c.client(uri.Query())
+	if errors.Is(err, errEndpointNotAllowed) {
+		return Result{Message: err.Error()}, nil
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	if key != "" {
+		head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err == nil {
+			return Result{Reachable: true, SizeBytes: head.ContentLength}, nil
+		}
+		if !isNotFound(err) {
+			return Result{Message: s3Message(err)}, nil
+		}
+	}
+
+	// Models are often uploaded as a directory, sum the objects below the key
+	prefix := key
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	var (
+		objects int
+		size    int64
+	)
+	err = client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			objects++
+			size += aws.Int64Value(object.Size)
+		}
+		return true
+	})
+	if err != nil {
+		return Result{Message: s3Message(err)}, nil
+	}
+
+	if objects == 0 {
+		return Result{Message: fmt.Sprintf("no object found at s3://%s/%s", bucket, key)}, nil
+	}
+
+	return Result{Reachable: true, SizeBytes: &size}, nil
+}
+
+func (c *s3Checker) client(query url.Values) (*s3.S3, error) {
+	endpoint := c.endpoint
+	if value := query.Get("endpoint"); value != "" && strings.TrimSuffix(value, "/") != strings.TrimSuffix(endpoint, "/") {
+		return nil, fmt.Errorf("%w: %s", errEndpointNotAllowed, value)
+	}
+	region := c.region
+	if value := query.Get("defaultRegion"); value != "" {
+		region = value
+	}
+
+	cfg := aws.NewConfig()
+	if region != "" {
+		cfg = cfg.WithRegion(region)
+	}
+	if endpoint != "" {
+		cfg = cfg.WithEndpoint(endpoint).WithS3ForcePathStyle(true)
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *cfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating S3 session: %w", err)
+	}
+
+	return s3.New(sess), nil
+}
+
+func isNotFound(err error) bool {
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+		return true
+	}
+
+	var aerr awserr.Error
+	return errors.As(err, &aerr) && (aerr.Code() == s3.ErrCodeNoSuchKey || aerr.Code() == "NotFound")
+}
+
+func s3Message(err error) string {
+	var aerr awserr.Error
+	if errors.As(err, &aerr) {
+		return fmt.Sprintf("%s: %s", aerr.Code(), aerr.Message())
+	}
+	return err.Error()
+}
@@ -0,0 +1,113 @@
+package reachability
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// DefaultWorkers is the number of URIs checked concurrently.
+const DefaultWorkers = 2
+
+// queueSize bounds the number of artifacts waiting for verification, artifacts registered once it is reached
+// are not verified.
+const queueSize = 1024
+
+// RecordFunc saves the result of the check of the uri of an artifact, verifiedAt is the time of the check.
+type RecordFunc func(artifactId string, uri string, result Result, verifiedAt time.Time) error
+
+type verification struct {
+	artifactId string
+	uri        string
+}
+
+// Verifier checks the URIs of the registered artifacts in background workers, so that slow storage does not
+// delay the registration.
+type Verifier struct {
+	checker Checker
+	record  RecordFunc
+	workers int
+	queue   chan verification
+	now     func() time.Time
+
+	mu      sync.Mutex
+	pending map[verification]struct{}
+}
+
+// NewVerifier returns a Verifier saving the results of checker with record.
+func NewVerifier(checker Checker, record RecordFunc, workers int) *Verifier {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	return &Verifier{
+		checker: checker,
+		record:  record,
+		workers: workers,
+		queue:   make(chan verification, queueSize),
+		now:     time.Now,
+		pending: map[verification]struct{}{},
+	}
+}
+
+// Start runs the verification workers until the context is canceled.
+func (v *Verifier) Start(ctx context.Context) {
+	for range v.workers {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case next := <-v.queue:
+					v.verify(ctx, next)
+				}
+			}
+		}()
+	}
+}
+
+// Enqueue schedules the check of the uri of an artifact, it never blocks.
+func (v *Verifier) Enqueue(artifactId string, uri string) {
+	next := verification{artifactId: artifactId, uri: uri}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.pending[next]; ok {
+		return
+	}
+
+	select {
+	case v.queue <- next:
+		v.pending[next] = struct{}{}
+	default:
+		glog.Warningf("Artifact verification queue full, skipping verification of artifact %s", artifactId)
+	}
+}
+
+func (v *Verifier) verify(ctx context.Context, next verification) {
+	v.mu.Lock()
+	delete(v.pending, next)
+	v.mu.Unlock()
+
+	result, err := v.checker.Check(ctx, next.uri)
+	if errors.Is(err, ErrUnsupportedScheme) {
+		glog.V(4).Infof("Skipping verification of artifact %s: %v", next.artifactId, err)
+		return
+	}
+	if err != nil {
+		glog.Warningf("Unable to verify artifact %s: %v", next.artifactId, err)
+		return
+	}
+
+	if !result.Reachable {
+		glog.Warningf("Artifact %s uri %s is not reachable: %s", next.artifactId, next.uri, result.Message)
+	}
+
+	if err := v.record(next.artifactId, next.uri, result, v.now()); err != nil {
+		glog.Warningf("Unable to record verification of artifact %s: %v", next.artifactId, err)
+	}
+}
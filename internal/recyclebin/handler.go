@@ -0,0 +1,85 @@
+package recyclebin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BasePath is the path of the recycle bin endpoints.
+const BasePath = "/api/model_registry/v1alpha3/recycle_bin"
+
+// List is the list of archived entities returned by the recycle bin.
+type List struct {
+	Items []Entity `json:"items"`
+	Size  int      `json:"size"`
+}
+
+// NewHandler returns the handler of the recycle bin, passing the other requests to next:
+//
+//	GET    /api/model_registry/v1alpha3/recycle_bin?entityType=             lists the archived registered models and model versions
+//	DELETE /api/model_registry/v1alpha3/recycle_bin/registered_models/{id}  permanently deletes an archived registered model and its versions
+//	DELETE /api/model_registry/v1alpha3/recycle_bin/model_versions/{id}     permanently deletes an archived model version
+//
+// The requests are restricted to the namespace of their tenant, the deletions require the admin permission.
+func NewHandler(bin *Bin, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+BasePath, func(w http.ResponseWriter, r *http.Request) {
+		entities, err := bin.List(r.Context(), r.URL.Query().Get("entityType"))
+		if err != nil {
+			writeBinError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, List{Items: entities, Size: len(entities)})
+	})
+	for collection, entityType := range map[string]string{
+		"registered_models": api.EntityTypeRegisteredModel,
+		"model_versions":    api.EntityTypeModelVersion,
+	} {
+		mux.HandleFunc("DELETE "+BasePath+"/"+collection+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			id, err := strconv.ParseInt(r.PathValue("id"), 10, 32)
+			if err != nil {
+				writeBinError(w, fmt.Errorf("invalid %s id %q: %w", entityType, r.PathValue("id"), api.ErrBadRequest))
+				return
+			}
+			if err := bin.Delete(r.Context(), entityType, int32(id)); err != nil {
+				writeBinError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+	mux.Handle("/", next)
+	return mux
+}
+
+func writeBinError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, api.ErrBadRequest):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, api.ErrNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, api.ErrConflict):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		glog.Errorf("Error serving the recycle bin: %v", err)
+		writeError(w, http.StatusInternalServerError, "error serving the recycle bin")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing recycle bin response: %v", err)
+	}
+}
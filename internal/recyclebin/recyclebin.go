@@ -0,0 +1,409 @@
+// Package recyclebin lists the archived registered models and model versions, the soft deleted entities, and deletes
+// them permanently with their properties and the artifacts attributed to no other entity: on the request of an admin,
+// or once they were archived for longer than the configured TTL.
+//
+// The archive time of an entity is its last update, so an archived entity updated again stays longer in the recycle
+// bin. The entities served by an inference service are never deleted. The deletions are sent to the webhooks and
+// recorded in the audit log, the purges on behalf of the purge job.
+package recyclebin
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/cache"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+const (
+	// JobName is the name of the background job purging the expired entities.
+	JobName = "recycle-bin-purge"
+	// DefaultInterval is how often expired entities are purged.
+	DefaultInterval = time.Hour
+	// archivedState is the value of the state property of the archived entities
+	archivedState = "ARCHIVED"
+)
+
+// Config sets how long the archived entities are kept.
+type Config struct {
+	// TTL is how long an entity stays in the recycle bin before being purged, zero disables the purge.
+	TTL time.Duration
+	// Interval is how often expired entities are purged, defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// Enabled reports whether expired entities are purged.
+func (c *Config) Enabled() bool {
+	return c.TTL > 0
+}
+
+// Entity is an archived registered model or model version.
+type Entity struct {
+	// EntityType is api.EntityTypeRegisteredModel or api.EntityTypeModelVersion.
+	EntityType string `json:"entityType"`
+	Id         int32  `json:"id,string"`
+	Name       string `json:"name"`
+	// RegisteredModelId is the registered model of a model version.
+	RegisteredModelId     *int32 `json:"registeredModelId,omitempty,string"`
+	ArchiveTimeSinceEpoch int64  `json:"archiveTimeSinceEpoch,string"`
+	// PurgeTimeSinceEpoch is when the entity is purged, unset when the purge is disabled.
+	PurgeTimeSinceEpoch *int64 `json:"purgeTimeSinceEpoch,omitempty,string"`
+}
+
+// Bin lists and deletes the archived entities of a registry.
+type Bin struct {
+	db     *gorm.DB
+	config Config
+
+	registeredModelType  int32
+	modelVersionType     int32
+	inferenceServiceType int32
+}
+
+// NewBin returns the recycle bin of the registry in db, typesMap maps type names to ids.
+func NewBin(db *gorm.DB, config Config, typesMap map[string]int32) (*Bin, error) {
+	if config.TTL < 0 {
+		return nil, fmt.Errorf("invalid recycle bin TTL %s: must not be negative", config.TTL)
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	b := &Bin{db: db, config: config}
+	for typeName, typeID := range map[string]*int32{
+		defaults.RegisteredModelTypeName:  &b.registeredModelType,
+		defaults.ModelVersionTypeName:     &b.modelVersionType,
+		defaults.InferenceServiceTypeName: &b.inferenceServiceType,
+	} {
+		id, ok := typesMap[typeName]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in types map", typeName)
+		}
+		*typeID = id
+	}
+
+	return b, nil
+}
+
+// Job returns the background job purging the expired entities every interval.
+func (b *Bin) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Permanently deletes the registered models and model versions archived for longer than the recycle bin TTL",
+		Interval:    b.config.Interval,
+		Run: func(ctx context.Context) error {
+			// the purges are recorded in the audit log on behalf of the job
+			purged, err := b.Purge(api.WithActor(ctx, JobName))
+			if purged > 0 {
+				glog.Infof("Purged %d entities from the recycle bin", purged)
+			}
+			return err
+		},
+	}
+}
+
+// List returns the archived entities of the namespace of ctx, least recently archived first, of entityType if not
+// empty.
+func (b *Bin) List(ctx context.Context, entityType string) ([]Entity, error) {
+	types := []int32{b.registeredModelType, b.modelVersionType}
+	if entityType != "" {
+		typeID, err := b.typeOf(entityType)
+		if err != nil {
+			return nil, err
+		}
+		types = []int32{typeID}
+	}
+
+	db := b.db.WithContext(ctx)
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	propertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+	parentTable := utils.GetTableName(db, &schema.ParentContext{})
+
+	var rows []struct {
+		ID                       int32
+		TypeID                   int32
+		Name                     string
+		LastUpdateTimeSinceEpoch int64
+		ParentContextID          *int32
+	}
+	query := db.Table(contextTable+" c").
+		Select("c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id").
+		Joins("JOIN "+propertyTable+" p ON p.context_id = c.id AND p.name = ? AND p.is_custom_property = ? AND p.string_value = ?", "state", false, archivedState).
+		Joins("LEFT JOIN "+parentTable+" pc ON pc.context_id = c.id").
+		Where("c.type_id IN ?", types)
+	if namespace := api.Namespace(ctx); namespace != "" {
+		query = query.Where("c.namespace = ?", namespace)
+	}
+	if err := query.Order("c.last_update_time_since_epoch, c.id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error reading the recycle bin: %w", err)
+	}
+
+	entities := make([]Entity, 0, len(rows))
+	for _, row := range rows {
+		entity := Entity{
+			EntityType:            api.EntityTypeRegisteredModel,
+			Id:                    row.ID,
+			Name:                  row.Name,
+			ArchiveTimeSinceEpoch: row.LastUpdateTimeSinceEpoch,
+		}
+		if row.TypeID == b.modelVersionType {
+			entity.EntityType = api.EntityTypeModelVersion
+			entity.RegisteredModelId = row.ParentContextID
+			// model version names are prefixed by the id of their registered model
+			if _, name, ok := strings.Cut(row.Name, ":"); ok {
+				entity.Name = name
+			}
+		}
+		if b.config.Enabled() {
+			purgeTime := row.LastUpdateTimeSinceEpoch + b.config.TTL.Milliseconds()
+			entity.PurgeTimeSinceEpoch = &purgeTime
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+// Delete permanently deletes the archived entity of entityType with id in the namespace of ctx, a registered model
+// with its model versions. The live entities, the registered models with live versions and the served entities are
+// not deleted.
+func (b *Bin) Delete(ctx context.Context, entityType string, id int32) error {
+	typeID, err := b.typeOf(entityType)
+	if err != nil {
+		return err
+	}
+
+	db := b.db.WithContext(ctx)
+	query := db.Where("id = ? AND type_id = ?", id, typeID)
+	if namespace := api.Namespace(ctx); namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+	var entity schema.Context
+	if err := query.Take(&entity).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("%s %d not found: %w", entityType, id, api.ErrNotFound)
+	} else if err != nil {
+		return fmt.Errorf("error reading %s %d: %w", entityType, id, err)
+	}
+
+	var deleted []int32
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		deleted, err = b.delete(tx, entity)
+		return err
+	}); err != nil {
+		return err
+	}
+	cache.Invalidate(db, cache.KindRegisteredModels, cache.KindModelVersions, cache.KindArtifacts)
+
+	glog.Infof("Permanently deleted %s %d, %d entities", entityType, id, len(deleted))
+
+	return nil
+}
+
+// Purge permanently deletes the entities archived for longer than the TTL in all the namespaces, returning how many
+// were deleted. The entities which can't be deleted stay in the recycle bin.
+func (b *Bin) Purge(ctx context.Context) (int, error) {
+	if !b.config.Enabled() {
+		return 0, nil
+	}
+
+	cutoff := time.Now().Add(-b.config.TTL).UnixMilli()
+	db := b.db.WithContext(ctx)
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	propertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+
+	// the registered models come first, deleting their versions
+	var expired []schema.Context
+	if err := db.Table(contextTable+" c").
+		Select("c.*").
+		Joins("JOIN "+propertyTable+" p ON p.context_id = c.id AND p.name = ? AND p.is_custom_property = ? AND p.string_value = ?", "state", false, archivedState).
+		Where("c.type_id IN ? AND c.last_update_time_since_epoch < ?", []int32{b.registeredModelType, b.modelVersionType}, cutoff).
+		Order("c.id").
+		Scan(&expired).Error; err != nil {
+		return 0, fmt.Errorf("error finding expired entities: %w", err)
+	}
+	slices.SortStableFunc(expired, func(x, y schema.Context) int {
+		return cmp.Compare(b.typeRank(x.TypeID), b.typeRank(y.TypeID))
+	})
+
+	purged := map[int32]bool{}
+	defer func() {
+		if len(purged) > 0 {
+			cache.Invalidate(db, cache.KindRegisteredModels, cache.KindModelVersions, cache.KindArtifacts)
+		}
+	}()
+	for _, entity := range expired {
+		if purged[entity.ID] {
+			continue
+		}
+		var deleted []int32
+		err := db.Transaction(func(tx *gorm.DB) (err error) {
+			deleted, err = b.delete(tx, entity)
+			return err
+		})
+		if errors.Is(err, api.ErrConflict) {
+			glog.V(2).Infof("Keeping an expired entity in the recycle bin: %v", err)
+			continue
+		}
+		if err != nil {
+			return len(purged), err
+		}
+		for _, id := range deleted {
+			purged[id] = true
+		}
+	}
+
+	return len(purged), nil
+}
+
+// typeOf returns the type id of entityType, api.EntityTypeRegisteredModel or api.EntityTypeModelVersion.
+func (b *Bin) typeOf(entityType string) (int32, error) {
+	switch entityType {
+	case api.EntityTypeRegisteredModel:
+		return b.registeredModelType, nil
+	case api.EntityTypeModelVersion:
+		return b.modelVersionType, nil
+	}
+	return 0, fmt.Errorf("invalid entity type %q, must be %s or %s: %w", entityType,
+		api.EntityTypeRegisteredModel, api.EntityTypeModelVersion, api.ErrBadRequest)
+}
+
+// typeRank orders the registered models before the model versions.
+func (b *Bin) typeRank(typeID int32) int {
+	if typeID == b.registeredModelType {
+		return 0
+	}
+	return 1
+}
+
+// delete deletes the archived entity in tx, a registered model with its versions, returning the ids of the deleted
+// contexts.
+func (b *Bin) delete(tx *gorm.DB, entity schema.Context) ([]int32, error) {
+	name := fmt.Sprintf("model version %d", entity.ID)
+	ids := []int32{entity.ID}
+	if entity.TypeID == b.registeredModelType {
+		name = fmt.Sprintf("registered model %d", entity.ID)
+		var versions []int32
+		if err := tx.Model(&schema.ParentContext{}).Where("parent_context_id = ?", entity.ID).Pluck("context_id", &versions).Error; err != nil {
+			return nil, fmt.Errorf("error finding the model versions of registered model %d: %w", entity.ID, err)
+		}
+		ids = append(ids, versions...)
+	}
+
+	var archived []int32
+	if err := tx.Model(&schema.ContextProperty{}).
+		Where("context_id IN ? AND name = ? AND is_custom_property = ? AND string_value = ?", ids, "state", false, archivedState).
+		Pluck("context_id", &archived).Error; err != nil {
+		return nil, fmt.Errorf("error reading the state of %s: %w", name, err)
+	}
+	if !slices.Contains(archived, entity.ID) {
+		return nil, fmt.Errorf("%s is not archived, archive it first: %w", name, api.ErrConflict)
+	}
+	if len(archived) < len(ids) {
+		return nil, fmt.Errorf("registered model %d has live model versions, archive them first: %w", entity.ID, api.ErrConflict)
+	}
+
+	var served int64
+	if err := tx.Model(&schema.ContextProperty{}).
+		Where("name IN ? AND is_custom_property = ? AND int_value IN ?", []string{"registered_model_id", "model_version_id"}, false, ids).
+		Where("context_id IN (?)", tx.Model(&schema.Context{}).Select("id").Where("type_id = ?", b.inferenceServiceType)).
+		Count(&served).Error; err != nil {
+		return nil, fmt.Errorf("error finding the inference services of %s: %w", name, err)
+	}
+	if served > 0 {
+		return nil, fmt.Errorf("%s is served by %d inference services: %w", name, served, api.ErrConflict)
+	}
+
+	return ids, deleteContexts(tx, ids)
+}
+
+// deleteContexts deletes the contexts with ids, their properties, tags, revisions and links, and the artifacts
+// attributed to no other context with their properties and events. The contexts and artifacts are deleted one by one,
+// so that their deletions are recorded by the webhooks outbox, and in the audit log if the context of tx has an actor.
+func deleteContexts(tx *gorm.DB, ids []int32) error {
+	var artifacts, shared []int32
+	if err := tx.Model(&schema.Attribution{}).Distinct("artifact_id").Where("context_id IN ?", ids).Pluck("artifact_id", &artifacts).Error; err != nil {
+		return fmt.Errorf("error finding the artifacts of the deleted entities: %w", err)
+	}
+	if len(artifacts) > 0 {
+		if err := tx.Model(&schema.Attribution{}).Distinct("artifact_id").Where("artifact_id IN ? AND context_id NOT IN ?", artifacts, ids).Pluck("artifact_id", &shared).Error; err != nil {
+			return fmt.Errorf("error finding the shared artifacts of the deleted entities: %w", err)
+		}
+	}
+	artifacts = slices.DeleteFunc(artifacts, func(id int32) bool { return slices.Contains(shared, id) })
+
+	var contexts []schema.Context
+	if err := tx.Where("id IN ?", ids).Order("id").Find(&contexts).Error; err != nil {
+		return fmt.Errorf("error reading the deleted entities: %w", err)
+	}
+	if err := service.RecordContextDeletions(tx, contexts); err != nil {
+		return err
+	}
+	for _, rows := range []struct {
+		model  any
+		column string
+	}{
+		{&schema.Attribution{}, "context_id"},
+		{&schema.Association{}, "context_id"},
+		{&schema.ParentContext{}, "context_id"},
+		{&schema.ParentContext{}, "parent_context_id"},
+		{&schema.ContextTag{}, "context_id"},
+		{&schema.ContextRevision{}, "context_id"},
+		{&schema.ContextProperty{}, "context_id"},
+	} {
+		if err := tx.Where(rows.column+" IN ?", ids).Delete(rows.model).Error; err != nil {
+			return fmt.Errorf("error deleting entities: %w", err)
+		}
+	}
+	for i := range contexts {
+		if err := tx.Delete(&contexts[i]).Error; err != nil {
+			return fmt.Errorf("error deleting entity %d: %w", contexts[i].ID, err)
+		}
+	}
+
+	if len(artifacts) == 0 {
+		return nil
+	}
+	var deleted []schema.Artifact
+	if err := tx.Where("id IN ?", artifacts).Order("id").Find(&deleted).Error; err != nil {
+		return fmt.Errorf("error reading the deleted artifacts: %w", err)
+	}
+	if err := service.RecordArtifactDeletions(tx, deleted); err != nil {
+		return err
+	}
+	var events []int32
+	if err := tx.Model(&schema.Event{}).Where("artifact_id IN ?", artifacts).Pluck("id", &events).Error; err != nil {
+		return fmt.Errorf("error finding the events of the deleted artifacts: %w", err)
+	}
+	if len(events) > 0 {
+		if err := tx.Where("event_id IN ?", events).Delete(&schema.EventPath{}).Error; err != nil {
+			return fmt.Errorf("error deleting artifact events: %w", err)
+		}
+		if err := tx.Where("id IN ?", events).Delete(&schema.Event{}).Error; err != nil {
+			return fmt.Errorf("error deleting artifact events: %w", err)
+		}
+	}
+	for _, rows := range []any{&schema.ArtifactDigest{}, &schema.ArtifactProperty{}} {
+		if err := tx.Where("artifact_id IN ?", artifacts).Delete(rows).Error; err != nil {
+			return fmt.Errorf("error deleting artifacts: %w", err)
+		}
+	}
+	for i := range deleted {
+		if err := tx.Delete(&deleted[i]).Error; err != nil {
+			return fmt.Errorf("error deleting artifact %d: %w", deleted[i].ID, err)
+		}
+	}
+
+	return nil
+}
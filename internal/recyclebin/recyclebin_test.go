@@ -0,0 +1,255 @@
+package recyclebin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var typesMap = map[string]int32{
+	defaults.RegisteredModelTypeName:  1,
+	defaults.ModelVersionTypeName:     2,
+	defaults.InferenceServiceTypeName: 3,
+}
+
+func newMockBin(t *testing.T, config Config) (*Bin, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	bin, err := NewBin(db, config, typesMap)
+	require.NoError(t, err)
+	return bin, mock
+}
+
+func TestNewBin(t *testing.T) {
+	bin, _ := newMockBin(t, Config{})
+	assert.Equal(t, DefaultInterval, bin.Job().Interval)
+
+	_, err := NewBin(bin.db, Config{TTL: -time.Hour}, typesMap)
+	assert.ErrorContains(t, err, "must not be negative")
+
+	_, err = NewBin(bin.db, Config{}, map[string]int32{defaults.ModelVersionTypeName: 2})
+	assert.ErrorContains(t, err, "not found in types map")
+}
+
+func TestList(t *testing.T) {
+	bin, mock := newMockBin(t, Config{TTL: time.Second})
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id FROM "Context" c JOIN "ContextProperty" p`)).
+		WithArgs("state", false, "ARCHIVED", int32(1), int32(2), "team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "last_update_time_since_epoch", "parent_context_id"}).
+			AddRow(10, 1, "churn", 1000, nil).
+			AddRow(11, 2, "10:v1", 2000, 10))
+
+	entities, err := bin.List(api.WithNamespace(context.Background(), "team-a"), "")
+	require.NoError(t, err)
+	assert.Equal(t, []Entity{
+		{EntityType: api.EntityTypeRegisteredModel, Id: 10, Name: "churn", ArchiveTimeSinceEpoch: 1000, PurgeTimeSinceEpoch: apiutils.Of(int64(2000))},
+		{EntityType: api.EntityTypeModelVersion, Id: 11, Name: "v1", RegisteredModelId: apiutils.Of(int32(10)), ArchiveTimeSinceEpoch: 2000, PurgeTimeSinceEpoch: apiutils.Of(int64(3000))},
+	}, entities)
+
+	_, err = bin.List(context.Background(), "Experiment")
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDelete(t *testing.T) {
+	bin, mock := newMockBin(t, Config{})
+
+	// registered model 10 has the archived version 11, artifact 100 is only attributed to the version, artifact 101 is
+	// shared with an experiment run
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Context" WHERE id = $1 AND type_id = $2`)).
+		WithArgs(int32(10), int32(1), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name"}).AddRow(10, 1, "churn"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "context_id" FROM "ParentContext" WHERE parent_context_id = $1`)).
+		WithArgs(int32(10)).
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(11))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "context_id" FROM "ContextProperty" WHERE context_id IN ($1,$2) AND name = $3`)).
+		WithArgs(int32(10), int32(11), "state", false, "ARCHIVED").
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(10).AddRow(11))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "ContextProperty" WHERE (name IN ($1,$2) AND is_custom_property = $3 AND int_value IN ($4,$5)) AND context_id IN (SELECT "id" FROM "Context" WHERE type_id = $6)`)).
+		WithArgs("registered_model_id", "model_version_id", false, int32(10), int32(11), int32(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT "artifact_id" FROM "Attribution" WHERE context_id IN ($1,$2)`)).
+		WithArgs(int32(10), int32(11)).
+		WillReturnRows(sqlmock.NewRows([]string{"artifact_id"}).AddRow(100).AddRow(101))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT DISTINCT "artifact_id" FROM "Attribution" WHERE artifact_id IN ($1,$2) AND context_id NOT IN ($3,$4)`)).
+		WithArgs(int32(100), int32(101), int32(10), int32(11)).
+		WillReturnRows(sqlmock.NewRows([]string{"artifact_id"}).AddRow(101))
+	// the deletions are audited before the entities are deleted
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Context" WHERE id IN ($1,$2) ORDER BY id`)).
+		WithArgs(int32(10), int32(11)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "namespace"}).AddRow(10, 1, "churn", "team-a").AddRow(11, 2, "10:v1", "team-a"))
+	for _, audited := range []struct{ id, typeID int32 }{{10, 1}, {11, 2}} {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ContextProperty" WHERE context_id = $1`)).
+			WithArgs(audited.id).
+			WillReturnRows(sqlmock.NewRows([]string{"context_id", "name", "is_custom_property", "string_value"}).AddRow(audited.id, "state", false, "ARCHIVED"))
+		mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_events"`)).
+			WithArgs("DELETE", "admin", "Context", audited.typeID, audited.id, "team-a", sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(audited.id))
+	}
+	for _, statement := range []string{
+		`DELETE FROM "Attribution" WHERE context_id IN ($1,$2)`,
+		`DELETE FROM "Association" WHERE context_id IN ($1,$2)`,
+		`DELETE FROM "ParentContext" WHERE context_id IN ($1,$2)`,
+		`DELETE FROM "ParentContext" WHERE parent_context_id IN ($1,$2)`,
+		`DELETE FROM "ContextTag" WHERE context_id IN ($1,$2)`,
+		`DELETE FROM "ContextRevision" WHERE context_id IN ($1,$2)`,
+		`DELETE FROM "ContextProperty" WHERE context_id IN ($1,$2)`,
+	} {
+		mock.ExpectExec(regexp.QuoteMeta(statement)).
+			WithArgs(int32(10), int32(11)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	// the entities are deleted one by one, for the webhooks
+	for _, id := range []int32{10, 11} {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "Context" WHERE "Context"."id" = $1`)).
+			WithArgs(id).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Artifact" WHERE id IN ($1) ORDER BY id`)).
+		WithArgs(int32(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "namespace"}).AddRow(100, 5, "model", "team-a"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ArtifactProperty" WHERE artifact_id = $1`)).
+		WithArgs(int32(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"artifact_id", "name", "is_custom_property"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_events"`)).
+		WithArgs("DELETE", "admin", "Artifact", int32(5), int32(100), "team-a", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "Event" WHERE artifact_id IN ($1)`)).
+		WithArgs(int32(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1000))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "EventPath" WHERE event_id IN ($1)`)).
+		WithArgs(int32(1000)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "Event" WHERE id IN ($1)`)).
+		WithArgs(int32(1000)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	for _, statement := range []string{
+		`DELETE FROM "ArtifactDigest" WHERE artifact_id IN ($1)`,
+		`DELETE FROM "ArtifactProperty" WHERE artifact_id IN ($1)`,
+		`DELETE FROM "Artifact" WHERE "Artifact"."id" = $1`,
+	} {
+		mock.ExpectExec(regexp.QuoteMeta(statement)).
+			WithArgs(int32(100)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	require.NoError(t, bin.Delete(api.WithActor(context.Background(), "admin"), api.EntityTypeRegisteredModel, 10))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteLive(t *testing.T) {
+	bin, mock := newMockBin(t, Config{})
+
+	// registered model 10 is archived but its version 12 is live again
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Context" WHERE id = $1 AND type_id = $2`)).
+		WithArgs(int32(10), int32(1), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name"}).AddRow(10, 1, "churn"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "context_id" FROM "ParentContext"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(11).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "context_id" FROM "ContextProperty"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(10).AddRow(11))
+	mock.ExpectRollback()
+
+	err := bin.Delete(context.Background(), api.EntityTypeRegisteredModel, 10)
+	assert.ErrorIs(t, err, api.ErrConflict)
+	assert.ErrorContains(t, err, "has live model versions")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurge(t *testing.T) {
+	disabled, _ := newMockBin(t, Config{})
+	purged, err := disabled.Purge(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	// the expired model version 21 is served, it stays in the recycle bin
+	bin, mock := newMockBin(t, Config{TTL: 30 * 24 * time.Hour})
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.* FROM "Context" c JOIN "ContextProperty" p`)).
+		WithArgs("state", false, "ARCHIVED", int32(1), int32(2), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name"}).AddRow(21, 2, "20:v1"))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "context_id" FROM "ContextProperty"`)).
+		WithArgs(int32(21), "state", false, "ARCHIVED").
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(21))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "ContextProperty"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectRollback()
+
+	purged, err = bin.Purge(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler(t *testing.T) {
+	bin, mock := newMockBin(t, Config{})
+	handler := NewHandler(bin, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id FROM "Context" c JOIN "ContextProperty" p`)).
+		WithArgs("state", false, "ARCHIVED", int32(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "last_update_time_since_epoch", "parent_context_id"}).
+			AddRow(21, 2, "20:v1", 1000, 20))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"?entityType=ModelVersion", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Equal(t, map[string]any{
+		"items": []any{map[string]any{
+			"entityType":            "ModelVersion",
+			"id":                    "21",
+			"name":                  "v1",
+			"registeredModelId":     "20",
+			"archiveTimeSinceEpoch": "1000",
+		}},
+		"size": float64(1),
+	}, list)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "Context" WHERE id = $1 AND type_id = $2`)).
+		WithArgs(int32(30), int32(2), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, BasePath+"/model_versions/30", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, BasePath+"/model_versions/v1", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/model_registry/v1alpha3/registered_models/10", nil))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,146 @@
+package reporting
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+)
+
+const (
+	// BasePath is the path of the reporting endpoints.
+	BasePath = "/api/model_registry/v1alpha3/stats"
+
+	defaultLeaderboardSize = 10
+	maxLeaderboardSize     = 1000
+)
+
+// List is the list of the rows of a reporting view returned by the reporting endpoints.
+type List[T any] struct {
+	Items []T `json:"items"`
+	Size  int `json:"size"`
+}
+
+// NewHandler returns the handler of the reporting endpoints, passing the other requests to next:
+//
+//	GET /api/model_registry/v1alpha3/stats/models_per_stage                        counts the models of each stage
+//	GET /api/model_registry/v1alpha3/stats/runs_per_week?experimentId=             counts the weekly runs of experiments
+//	GET /api/model_registry/v1alpha3/stats/leaderboard?metric=&registeredModelId=  ranks model versions by a metric
+//
+// The leaderboard also accepts sortOrder, DESC by default, and pageSize, 10 by default.
+// The endpoints read the reporting views, so they lag behind the registry by up to the refresh interval.
+func NewHandler(reporter *Reporter, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/models_per_stage", func(w http.ResponseWriter, r *http.Request) {
+		counts, err := reporter.ModelsPerStage(r.Context())
+		writeList(w, counts, err)
+	})
+
+	mux.HandleFunc("GET "+BasePath+"/runs_per_week", func(w http.ResponseWriter, r *http.Request) {
+		experimentId, err := optionalID(r, "experimentId")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		weeks, err := reporter.RunsPerExperimentWeek(r.Context(), experimentId)
+		writeList(w, weeks, err)
+	})
+
+	mux.HandleFunc("GET "+BasePath+"/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		options, err := leaderboardOptions(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		evaluations, err := reporter.Leaderboard(r.Context(), options)
+		writeList(w, evaluations, err)
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func leaderboardOptions(r *http.Request) (LeaderboardOptions, error) {
+	query := r.URL.Query()
+
+	options := LeaderboardOptions{
+		Metric: query.Get("metric"),
+		Limit:  defaultLeaderboardSize,
+	}
+	if options.Metric == "" {
+		return options, &paramError{param: "metric", message: "is required"}
+	}
+
+	var err error
+	if options.RegisteredModelId, err = optionalID(r, "registeredModelId"); err != nil {
+		return options, err
+	}
+
+	switch strings.ToUpper(query.Get("sortOrder")) {
+	case "", "DESC":
+	case "ASC":
+		options.Ascending = true
+	default:
+		return options, &paramError{param: "sortOrder", message: "must be ASC or DESC"}
+	}
+
+	if pageSize := query.Get("pageSize"); pageSize != "" {
+		size, err := strconv.Atoi(pageSize)
+		if err != nil || size <= 0 || size > maxLeaderboardSize {
+			return options, &paramError{param: "pageSize", message: "must be between 1 and " + strconv.Itoa(maxLeaderboardSize)}
+		}
+		options.Limit = size
+	}
+
+	return options, nil
+}
+
+func optionalID(r *http.Request, param string) (*int32, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, &paramError{param: param, message: "must be an id"}
+	}
+	return apiutils.Of(int32(id)), nil
+}
+
+type paramError struct {
+	param   string
+	message string
+}
+
+func (e *paramError) Error() string {
+	return "invalid parameter " + e.param + ": " + e.message
+}
+
+func writeList[T any](w http.ResponseWriter, items []T, err error) {
+	if err != nil {
+		glog.Errorf("Error reading reporting view: %v", err)
+		writeError(w, http.StatusInternalServerError, "error reading reporting view")
+		return
+	}
+	writeJSON(w, http.StatusOK, List[T]{Items: items, Size: len(items)})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing reporting response: %v", err)
+	}
+}
@@ -0,0 +1,338 @@
+// Package reporting maintains precomputed reporting views of the registry, refreshed by a background job, so that
+// the analytics endpoints read small tables instead of joining the metadata tables on each request.
+//
+// On PostgreSQL the views are materialized views, on MySQL, which has none, they are tables rewritten on refresh. The
+// views are computed per namespace, so that the tenants only read the reports of their namespace.
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+const (
+	// JobName is the name of the background job refreshing the reporting views.
+	JobName = "reporting-refresh"
+	// DefaultInterval is how often the reporting views are refreshed.
+	DefaultInterval = 15 * time.Minute
+
+	// StageProperty is the custom property of the model versions holding their stage, e.g. staging or production.
+	StageProperty = "stage"
+
+	modelsPerStageView    = "mr_report_models_per_stage"
+	latestEvaluationsView = "mr_report_latest_evaluations"
+	runsPerWeekView       = "mr_report_runs_per_experiment_week"
+
+	weekMillis = 7 * 24 * 60 * 60 * 1000
+	// mondayMillis is the offset of the first monday after the epoch, a thursday, so that weeks start on mondays
+	mondayMillis = 4 * 24 * 60 * 60 * 1000
+)
+
+// reportedTypes are the types the view queries filter on.
+var reportedTypes = []string{
+	defaults.ModelVersionTypeName,
+	defaults.ExperimentRunTypeName,
+	defaults.MetricTypeName,
+}
+
+type view struct {
+	name  string
+	query string
+}
+
+// Reporter creates, refreshes and queries the reporting views.
+type Reporter struct {
+	db       *gorm.DB
+	interval time.Duration
+	views    []view
+}
+
+// NewReporter returns a reporter for the registry in db refreshed every interval, typesMap maps type names to ids.
+// Setup must be called before the views are queried.
+func NewReporter(db *gorm.DB, interval time.Duration, typesMap map[string]int32) (*Reporter, error) {
+	switch db.Name() {
+	case "mysql", "postgres":
+	default:
+		return nil, fmt.Errorf("reporting views are not supported on %s databases", db.Name())
+	}
+
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	typeIDs := make(map[string]int32, len(reportedTypes))
+	for _, typeName := range reportedTypes {
+		typeID, ok := typesMap[typeName]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in types map", typeName)
+		}
+		typeIDs[typeName] = typeID
+	}
+
+	return &Reporter{
+		db:       db,
+		interval: interval,
+		views:    buildViews(db, typeIDs),
+	}, nil
+}
+
+// buildViews returns the queries of the views, type ids are inlined as views cannot have parameters.
+func buildViews(db *gorm.DB, typeIDs map[string]int32) []view {
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	contextPropertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+	parentContextTable := utils.GetTableName(db, &schema.ParentContext{})
+	attributionTable := utils.GetTableName(db, &schema.Attribution{})
+	artifactTable := utils.GetTableName(db, &schema.Artifact{})
+	artifactPropertyTable := utils.GetTableName(db, &schema.ArtifactProperty{})
+
+	// integer division truncates on postgres, mysql needs DIV
+	div := "/"
+	if db.Name() == "mysql" {
+		div = "DIV"
+	}
+	weekStart := fmt.Sprintf("((r.create_time_since_epoch - %d) %s %d) * %d + %d", mondayMillis, div, weekMillis, weekMillis, mondayMillis)
+
+	return []view{
+		{
+			name: modelsPerStageView,
+			query: fmt.Sprintf(`SELECT mv.namespace AS namespace,
+	COALESCE(st.string_value, '') AS stage,
+	COUNT(DISTINCT pc.parent_context_id) AS registered_models,
+	COUNT(*) AS model_versions
+FROM %s mv
+JOIN %s pc ON pc.context_id = mv.id
+LEFT JOIN %s st ON st.context_id = mv.id AND st.name = '%s' AND st.is_custom_property = TRUE
+WHERE mv.type_id = %d
+GROUP BY mv.namespace, COALESCE(st.string_value, '')`,
+				contextTable, parentContextTable, contextPropertyTable, StageProperty,
+				typeIDs[defaults.ModelVersionTypeName]),
+		},
+		{
+			// metric artifacts hold the latest value of a metric of their model version, the history is kept apart
+			name: latestEvaluationsView,
+			query: fmt.Sprintf(`SELECT mv.namespace AS namespace,
+	pc.parent_context_id AS registered_model_id,
+	mv.id AS model_version_id,
+	%s AS model_version_name,
+	COALESCE(mvs.string_value, '') AS model_version_state,
+	%s AS metric,
+	v.double_value AS value,
+	a.last_update_time_since_epoch AS last_update_time_since_epoch
+FROM %s mv
+JOIN %s pc ON pc.context_id = mv.id
+LEFT JOIN %s mvs ON mvs.context_id = mv.id AND mvs.name = 'state' AND mvs.is_custom_property = FALSE
+JOIN %s attr ON attr.context_id = mv.id
+JOIN %s a ON a.id = attr.artifact_id
+JOIN %s v ON v.artifact_id = a.id AND v.name = 'value' AND v.is_custom_property = FALSE
+WHERE mv.type_id = %d AND a.type_id = %d`,
+				unprefixed("mv.name"), unprefixed("a.name"),
+				contextTable, parentContextTable, contextPropertyTable, attributionTable, artifactTable, artifactPropertyTable,
+				typeIDs[defaults.ModelVersionTypeName], typeIDs[defaults.MetricTypeName]),
+		},
+		{
+			name: runsPerWeekView,
+			query: fmt.Sprintf(`SELECT r.namespace AS namespace,
+	pc.parent_context_id AS experiment_id,
+	%s AS week_start,
+	COUNT(*) AS runs
+FROM %s r
+JOIN %s pc ON pc.context_id = r.id
+WHERE r.type_id = %d
+GROUP BY r.namespace, pc.parent_context_id, %s`,
+				weekStart, contextTable, parentContextTable,
+				typeIDs[defaults.ExperimentRunTypeName], weekStart),
+		},
+	}
+}
+
+// unprefixed strips the owner id prefix of the stored name of an owned entity.
+func unprefixed(column string) string {
+	return fmt.Sprintf("SUBSTRING(%s FROM POSITION(':' IN %s) + 1)", column, column)
+}
+
+// Setup creates the missing views with their current data, and recreates the ones created before their namespace
+// column.
+func (r *Reporter) Setup(ctx context.Context) error {
+	db := r.db.WithContext(ctx)
+
+	for _, v := range r.views {
+		name := dbutil.QuoteTableName(db, v.name)
+
+		statement := "CREATE MATERIALIZED VIEW IF NOT EXISTS " + name + " AS " + v.query
+		drop := "DROP MATERIALIZED VIEW IF EXISTS " + name
+		if db.Name() == "mysql" {
+			statement = "CREATE TABLE IF NOT EXISTS " + name + " AS " + v.query
+			drop = "DROP TABLE IF EXISTS " + name
+		}
+
+		// the probe fails on the missing views too, dropping them is a no-op
+		if err := db.Exec("SELECT namespace FROM " + name + " WHERE 1 = 0").Error; err != nil {
+			if err := db.Exec(drop).Error; err != nil {
+				return fmt.Errorf("error dropping outdated reporting view %s: %w", v.name, err)
+			}
+		}
+
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("error creating reporting view %s: %w", v.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Refresh recomputes the views, readers see either the previous or the new data of a view.
+func (r *Reporter) Refresh(ctx context.Context) error {
+	db := r.db.WithContext(ctx)
+
+	for _, v := range r.views {
+		name := dbutil.QuoteTableName(db, v.name)
+
+		var err error
+		if db.Name() == "mysql" {
+			err = db.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec("DELETE FROM " + name).Error; err != nil {
+					return err
+				}
+				return tx.Exec("INSERT INTO " + name + " " + v.query).Error
+			})
+		} else {
+			err = db.Exec("REFRESH MATERIALIZED VIEW " + name).Error
+		}
+		if err != nil {
+			return fmt.Errorf("error refreshing reporting view %s: %w", v.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Job returns the background job refreshing the views every interval.
+func (r *Reporter) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Refreshes the reporting views of the stats and leaderboard endpoints",
+		Interval:    r.interval,
+		Run: func(ctx context.Context) error {
+			start := time.Now()
+			if err := r.Refresh(ctx); err != nil {
+				return err
+			}
+			glog.V(2).Infof("Refreshed the reporting views in %s", time.Since(start))
+			return nil
+		},
+	}
+}
+
+// StageCount is the number of registered models with versions in a stage, and of these versions.
+type StageCount struct {
+	// Stage is empty for the versions without a stage
+	Stage            string `json:"stage" gorm:"column:stage"`
+	RegisteredModels int64  `json:"registeredModels" gorm:"column:registered_models"`
+	ModelVersions    int64  `json:"modelVersions" gorm:"column:model_versions"`
+}
+
+// ModelsPerStage returns the model counts of each stage, by stage, of the namespace of the tenant of ctx.
+func (r *Reporter) ModelsPerStage(ctx context.Context) ([]StageCount, error) {
+	db := r.db.WithContext(ctx)
+
+	query := inNamespace(ctx, db.Table(dbutil.QuoteTableName(db, modelsPerStageView))).
+		Select("stage, SUM(registered_models) AS registered_models, SUM(model_versions) AS model_versions").
+		Group("stage")
+	counts := []StageCount{}
+	if err := query.Order("stage").Find(&counts).Error; err != nil {
+		return nil, fmt.Errorf("error reading reporting view %s: %w", modelsPerStageView, err)
+	}
+
+	return counts, nil
+}
+
+// Evaluation is the latest value of a metric of a model version.
+type Evaluation struct {
+	RegisteredModelId int32   `json:"registeredModelId,string" gorm:"column:registered_model_id"`
+	ModelVersionId    int32   `json:"modelVersionId,string" gorm:"column:model_version_id"`
+	ModelVersionName  string  `json:"modelVersionName" gorm:"column:model_version_name"`
+	Metric            string  `json:"metric" gorm:"column:metric"`
+	Value             float64 `json:"value" gorm:"column:value"`
+	// LastUpdateTimeSinceEpoch is when the metric was last set, in milliseconds since epoch
+	LastUpdateTimeSinceEpoch int64 `json:"lastUpdateTimeSinceEpoch,string" gorm:"column:last_update_time_since_epoch"`
+}
+
+// LeaderboardOptions selects the evaluations of a leaderboard.
+type LeaderboardOptions struct {
+	Metric string
+	// RegisteredModelId restricts the leaderboard to the versions of a registered model
+	RegisteredModelId *int32
+	// Ascending ranks the lowest values first, for metrics such as losses
+	Ascending bool
+	Limit     int
+}
+
+// Leaderboard returns the latest evaluations of the model versions that are not archived, by metric value, of the
+// namespace of the tenant of ctx.
+func (r *Reporter) Leaderboard(ctx context.Context, options LeaderboardOptions) ([]Evaluation, error) {
+	db := r.db.WithContext(ctx)
+
+	order := "value DESC, model_version_id"
+	if options.Ascending {
+		order = "value ASC, model_version_id"
+	}
+
+	query := inNamespace(ctx, db.Table(dbutil.QuoteTableName(db, latestEvaluationsView))).
+		Where("metric = ? AND model_version_state <> ?", options.Metric, "ARCHIVED")
+	if options.RegisteredModelId != nil {
+		query = query.Where("registered_model_id = ?", *options.RegisteredModelId)
+	}
+
+	evaluations := []Evaluation{}
+	if err := query.Order(order).Limit(options.Limit).Find(&evaluations).Error; err != nil {
+		return nil, fmt.Errorf("error reading reporting view %s: %w", latestEvaluationsView, err)
+	}
+
+	return evaluations, nil
+}
+
+// ExperimentWeek is the number of runs of an experiment created in a week.
+type ExperimentWeek struct {
+	ExperimentId int32 `json:"experimentId,string" gorm:"column:experiment_id"`
+	// WeekStart is the monday starting the week, in milliseconds since epoch
+	WeekStart int64 `json:"weekStart,string" gorm:"column:week_start"`
+	Runs      int64 `json:"runs" gorm:"column:runs"`
+}
+
+// RunsPerExperimentWeek returns the weekly run counts of an experiment, or of all experiments, by experiment and week,
+// of the runs in the namespace of the tenant of ctx.
+func (r *Reporter) RunsPerExperimentWeek(ctx context.Context, experimentId *int32) ([]ExperimentWeek, error) {
+	db := r.db.WithContext(ctx)
+
+	query := inNamespace(ctx, db.Table(dbutil.QuoteTableName(db, runsPerWeekView)))
+	if experimentId != nil {
+		query = query.Where("experiment_id = ?", *experimentId)
+	}
+
+	weeks := []ExperimentWeek{}
+	if err := query.Select("experiment_id, week_start, SUM(runs) AS runs").Group("experiment_id, week_start").
+		Order("experiment_id, week_start").Find(&weeks).Error; err != nil {
+		return nil, fmt.Errorf("error reading reporting view %s: %w", runsPerWeekView, err)
+	}
+
+	return weeks, nil
+}
+
+// inNamespace restricts the query of a view to the namespace of the tenant of ctx, the requests of no tenant read the
+// reports of all the namespaces.
+func inNamespace(ctx context.Context, query *gorm.DB) *gorm.DB {
+	if namespace := api.Namespace(ctx); namespace != "" {
+		return query.Where("namespace = ?", namespace)
+	}
+	return query
+}
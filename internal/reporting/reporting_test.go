@@ -0,0 +1,192 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var typesMap = map[string]int32{
+	defaults.ModelVersionTypeName:  2,
+	defaults.ExperimentRunTypeName: 4,
+	defaults.MetricTypeName:        6,
+}
+
+func newMockReporter(t *testing.T, dialect string) (*Reporter, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	dialector := postgres.New(postgres.Config{Conn: conn})
+	if dialect == "mysql" {
+		dialector = mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true})
+	}
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	reporter, err := NewReporter(db, 0, typesMap)
+	require.NoError(t, err)
+	return reporter, mock
+}
+
+func TestNewReporter(t *testing.T) {
+	reporter, _ := newMockReporter(t, "postgres")
+	assert.Equal(t, DefaultInterval, reporter.Job().Interval)
+
+	_, err := NewReporter(reporter.db, 0, map[string]int32{defaults.ModelVersionTypeName: 2})
+	assert.ErrorContains(t, err, "not found in types map")
+}
+
+func TestSetupAndRefresh(t *testing.T) {
+	t.Run("postgres", func(t *testing.T) {
+		reporter, mock := newMockReporter(t, "postgres")
+
+		// the first view was created before its namespace column
+		for _, name := range []string{modelsPerStageView, latestEvaluationsView, runsPerWeekView} {
+			probe := mock.ExpectExec(regexp.QuoteMeta(`SELECT namespace FROM "` + name + `" WHERE 1 = 0`))
+			if name == modelsPerStageView {
+				probe.WillReturnError(assert.AnError)
+				mock.ExpectExec(regexp.QuoteMeta(`DROP MATERIALIZED VIEW IF EXISTS "` + name + `"`)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			} else {
+				probe.WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+			mock.ExpectExec(regexp.QuoteMeta(`CREATE MATERIALIZED VIEW IF NOT EXISTS "` + name + `" AS SELECT`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		require.NoError(t, reporter.Setup(context.Background()))
+
+		for _, name := range []string{modelsPerStageView, latestEvaluationsView, runsPerWeekView} {
+			mock.ExpectExec(regexp.QuoteMeta(`REFRESH MATERIALIZED VIEW "` + name + `"`)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		require.NoError(t, reporter.Job().Run(context.Background()))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("mysql", func(t *testing.T) {
+		reporter, mock := newMockReporter(t, "mysql")
+
+		// the views are missing
+		for _, name := range []string{modelsPerStageView, latestEvaluationsView, runsPerWeekView} {
+			mock.ExpectExec(regexp.QuoteMeta("SELECT namespace FROM `" + name + "` WHERE 1 = 0")).
+				WillReturnError(assert.AnError)
+			mock.ExpectExec(regexp.QuoteMeta("DROP TABLE IF EXISTS `" + name + "`")).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE IF NOT EXISTS `" + name + "` AS SELECT")).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		require.NoError(t, reporter.Setup(context.Background()))
+
+		for _, name := range []string{modelsPerStageView, latestEvaluationsView, runsPerWeekView} {
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `" + name + "`")).WillReturnResult(sqlmock.NewResult(0, 3))
+			mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `" + name + "` SELECT")).WillReturnResult(sqlmock.NewResult(0, 3))
+			mock.ExpectCommit()
+		}
+		require.NoError(t, reporter.Refresh(context.Background()))
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRunsPerWeekQuery(t *testing.T) {
+	reporter, _ := newMockReporter(t, "mysql")
+	assert.Contains(t, reporter.views[2].query, "((r.create_time_since_epoch - 345600000) DIV 604800000) * 604800000 + 345600000")
+	assert.Contains(t, reporter.views[2].query, "WHERE r.type_id = 4")
+	assert.Contains(t, reporter.views[2].query, "GROUP BY r.namespace, pc.parent_context_id")
+}
+
+func TestNamespaces(t *testing.T) {
+	reporter, mock := newMockReporter(t, "postgres")
+	teamA := api.WithNamespace(context.Background(), "team-a")
+
+	// the reports of a tenant are the ones of its namespace, the others the sums of all the namespaces
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT stage, SUM(registered_models) AS registered_models, SUM(model_versions) AS model_versions FROM "` + modelsPerStageView + `" WHERE namespace = $1 GROUP BY "stage" ORDER BY stage`)).
+		WithArgs("team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"stage", "registered_models", "model_versions"}).AddRow("production", 1, 2))
+	counts, err := reporter.ModelsPerStage(teamA)
+	require.NoError(t, err)
+	assert.Equal(t, []StageCount{{Stage: "production", RegisteredModels: 1, ModelVersions: 2}}, counts)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT stage, SUM(registered_models) AS registered_models, SUM(model_versions) AS model_versions FROM "` + modelsPerStageView + `" GROUP BY "stage" ORDER BY stage`)).
+		WillReturnRows(sqlmock.NewRows([]string{"stage", "registered_models", "model_versions"}).AddRow("production", 3, 5))
+	_, err = reporter.ModelsPerStage(context.Background())
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "` + latestEvaluationsView + `" WHERE namespace = $1 AND (metric = $2 AND model_version_state <> $3)`)).
+		WithArgs("team-a", "accuracy", "ARCHIVED", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"model_version_id"}))
+	_, err = reporter.Leaderboard(teamA, LeaderboardOptions{Metric: "accuracy", Limit: 10})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT experiment_id, week_start, SUM(runs) AS runs FROM "` + runsPerWeekView + `" WHERE namespace = $1 GROUP BY experiment_id, week_start`)).
+		WithArgs("team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"experiment_id", "week_start", "runs"}))
+	_, err = reporter.RunsPerExperimentWeek(teamA, nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler(t *testing.T) {
+	reporter, mock := newMockReporter(t, "postgres")
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	server := httptest.NewServer(NewHandler(reporter, next))
+	t.Cleanup(server.Close)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "`+latestEvaluationsView+`" WHERE (metric = $1 AND model_version_state <> $2) AND registered_model_id = $3 ORDER BY value ASC, model_version_id LIMIT $4`)).
+		WithArgs("loss", "ARCHIVED", 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"registered_model_id", "model_version_id", "model_version_name", "metric", "value", "last_update_time_since_epoch"}).
+			AddRow(1, 3, "v2", "loss", 0.1, 1700000000000).
+			AddRow(1, 2, "v1", "loss", 0.2, 1600000000000))
+
+	resp, err := http.Get(server.URL + BasePath + "/leaderboard?metric=loss&registeredModelId=1&sortOrder=asc&pageSize=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var leaderboard map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&leaderboard))
+	assert.EqualValues(t, 2, leaderboard["size"])
+	first := leaderboard["items"].([]any)[0].(map[string]any)
+	assert.Equal(t, "3", first["modelVersionId"])
+	assert.Equal(t, "v2", first["modelVersionName"])
+	assert.Equal(t, "1700000000000", first["lastUpdateTimeSinceEpoch"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	for _, query := range []string{
+		"/leaderboard",
+		"/leaderboard?metric=loss&sortOrder=up",
+		"/leaderboard?metric=loss&pageSize=0",
+		"/leaderboard?metric=loss&registeredModelId=abc",
+		"/runs_per_week?experimentId=abc",
+	} {
+		resp, err := http.Get(server.URL + BasePath + query)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/registered_models")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode, "other requests are passed through")
+}
@@ -0,0 +1,210 @@
+package seed
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Profile is a dataset a model registry is seeded with.
+type Profile struct {
+	Models      []Model
+	Experiments []Experiment
+}
+
+// Model is a registered model, labeled with the labels, and its versions.
+type Model struct {
+	Name        string
+	Description string
+	Owner       string
+	Provider    string
+	License     string
+	Tasks       []string
+	Language    []string
+	Labels      []string
+	Versions    []Version
+}
+
+// Version is a model version with its model artifact, at a stage of its lifecycle.
+type Version struct {
+	Name          string
+	Author        string
+	Description   string
+	Stage         string
+	Archived      bool
+	Format        string
+	FormatVersion string
+	URI           string
+}
+
+// Experiment is an experiment and its runs.
+type Experiment struct {
+	Name        string
+	Description string
+	Owner       string
+	Runs        []Run
+}
+
+// Run is an experiment run logging its parameters, and the history of its metrics for each training step.
+type Run struct {
+	Name       string
+	Parameters map[string]string
+	Metrics    []Metric
+	Steps      int
+}
+
+// Metric converges from Start to End over the steps of a run.
+type Metric struct {
+	Name  string
+	Start float64
+	End   float64
+}
+
+var profiles = map[string]Profile{
+	"demo":    demoProfile,
+	"minimal": minimalProfile,
+}
+
+// Profiles returns the names of the available profiles.
+func Profiles() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// GetProfile returns the profile with the name.
+func GetProfile(name string) (Profile, error) {
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown seed profile %q, expected one of %s", name, strings.Join(Profiles(), ", "))
+	}
+	return profile, nil
+}
+
+var minimalProfile = Profile{
+	Models: []Model{{
+		Name:        "hello-model",
+		Description: "A minimal model to get started",
+		Owner:       "demo",
+		Labels:      []string{"example"},
+		Versions: []Version{{
+			Name: "v1", Author: "demo", Stage: "Development",
+			Format: "onnx", FormatVersion: "1", URI: "s3://models/hello-model/v1/model.onnx",
+		}},
+	}},
+	Experiments: []Experiment{{
+		Name:  "hello-experiment",
+		Owner: "demo",
+		Runs: []Run{{
+			Name:       "run-1",
+			Parameters: map[string]string{"learning_rate": "0.01"},
+			Metrics:    []Metric{{Name: "loss", Start: 1, End: 0.2}},
+			Steps:      5,
+		}},
+	}},
+}
+
+var demoProfile = Profile{
+	Models: []Model{
+		{
+			Name:        "fraud-detector",
+			Description: "Gradient boosted trees scoring card transactions for fraud in real time",
+			Owner:       "risk-team",
+			Provider:    "Risk Analytics",
+			License:     "apache-2.0",
+			Tasks:       []string{"tabular-classification"},
+			Labels:      []string{"finance", "real-time", "tabular"},
+			Versions: []Version{
+				{Name: "1.0.0", Author: "alice", Description: "Baseline trained on 2024 transactions", Stage: "Archived", Archived: true, Format: "xgboost", FormatVersion: "1.7", URI: "s3://models/fraud-detector/1.0.0/model.bst"},
+				{Name: "1.1.0", Author: "alice", Description: "Adds merchant category features", Stage: "Production", Format: "xgboost", FormatVersion: "2.0", URI: "s3://models/fraud-detector/1.1.0/model.bst"},
+				{Name: "1.2.0-rc1", Author: "bob", Description: "Retrained with device fingerprints", Stage: "Staging", Format: "xgboost", FormatVersion: "2.0", URI: "s3://models/fraud-detector/1.2.0-rc1/model.bst"},
+			},
+		},
+		{
+			Name:        "sentiment-classifier",
+			Description: "Fine-tuned transformer classifying the sentiment of customer reviews",
+			Owner:       "nlp-team",
+			Provider:    "NLP Platform",
+			License:     "mit",
+			Tasks:       []string{"text-classification"},
+			Language:    []string{"en", "es", "fr"},
+			Labels:      []string{"nlp", "transformers"},
+			Versions: []Version{
+				{Name: "v1", Author: "carol", Description: "DistilBERT fine-tuned on product reviews", Stage: "Production", Format: "onnx", FormatVersion: "1.15", URI: "oci://quay.io/demo/sentiment-classifier:v1"},
+				{Name: "v2", Author: "carol", Description: "Multilingual base model", Stage: "Development", Format: "onnx", FormatVersion: "1.16", URI: "oci://quay.io/demo/sentiment-classifier:v2"},
+			},
+		},
+		{
+			Name:        "product-recommender",
+			Description: "Two-tower retrieval model recommending catalog products",
+			Owner:       "personalization-team",
+			Provider:    "Personalization",
+			License:     "apache-2.0",
+			Tasks:       []string{"recommendation"},
+			Labels:      []string{"recommendation", "embeddings"},
+			Versions: []Version{
+				{Name: "2024.11", Author: "dave", Description: "Weekly retraining", Stage: "Archived", Archived: true, Format: "tensorflow", FormatVersion: "2.15", URI: "s3://models/product-recommender/2024.11/saved_model"},
+				{Name: "2024.12", Author: "dave", Description: "Weekly retraining", Stage: "Production", Format: "tensorflow", FormatVersion: "2.15", URI: "s3://models/product-recommender/2024.12/saved_model"},
+			},
+		},
+		{
+			Name:        "churn-predictor",
+			Description: "Logistic regression predicting subscription churn within 30 days",
+			Owner:       "growth-team",
+			Provider:    "Growth",
+			License:     "apache-2.0",
+			Tasks:       []string{"tabular-classification"},
+			Labels:      []string{"tabular", "batch"},
+			Versions: []Version{
+				{Name: "v1", Author: "erin", Description: "Trained on 12 months of usage", Stage: "Staging", Format: "sklearn", FormatVersion: "1.4", URI: "s3://models/churn-predictor/v1/model.joblib"},
+			},
+		},
+		{
+			Name:        "support-summarizer",
+			Description: "Instruction-tuned language model summarizing support tickets",
+			Owner:       "genai-team",
+			Provider:    "GenAI Platform",
+			License:     "llama3",
+			Tasks:       []string{"summarization", "text-generation"},
+			Language:    []string{"en"},
+			Labels:      []string{"llm", "genai"},
+			Versions: []Version{
+				{Name: "8b-instruct-v1", Author: "frank", Description: "LoRA fine-tuned on resolved tickets", Stage: "Production", Format: "vLLM", FormatVersion: "0.6", URI: "hf://demo/support-summarizer-8b"},
+				{Name: "8b-instruct-v2", Author: "frank", Description: "Longer context window", Stage: "Development", Format: "vLLM", FormatVersion: "0.6", URI: "hf://demo/support-summarizer-8b-v2"},
+			},
+		},
+	},
+	Experiments: []Experiment{
+		{
+			Name:        "fraud-detector-tuning",
+			Description: "Hyperparameter search for the fraud detector",
+			Owner:       "alice",
+			Runs: []Run{
+				{Name: "depth-6", Parameters: map[string]string{"max_depth": "6", "eta": "0.1", "n_estimators": "400"}, Metrics: []Metric{{"logloss", 0.69, 0.12}, {"auc", 0.5, 0.94}}, Steps: 20},
+				{Name: "depth-8", Parameters: map[string]string{"max_depth": "8", "eta": "0.1", "n_estimators": "400"}, Metrics: []Metric{{"logloss", 0.69, 0.10}, {"auc", 0.5, 0.95}}, Steps: 20},
+				{Name: "depth-8-slow", Parameters: map[string]string{"max_depth": "8", "eta": "0.03", "n_estimators": "1200"}, Metrics: []Metric{{"logloss", 0.69, 0.09}, {"auc", 0.5, 0.96}}, Steps: 30},
+			},
+		},
+		{
+			Name:        "sentiment-finetuning",
+			Description: "Fine-tuning runs of the sentiment classifier",
+			Owner:       "carol",
+			Runs: []Run{
+				{Name: "lr-2e-5", Parameters: map[string]string{"learning_rate": "2e-5", "batch_size": "32", "epochs": "3"}, Metrics: []Metric{{"loss", 0.7, 0.21}, {"accuracy", 0.5, 0.91}}, Steps: 25},
+				{Name: "lr-5e-5", Parameters: map[string]string{"learning_rate": "5e-5", "batch_size": "32", "epochs": "3"}, Metrics: []Metric{{"loss", 0.7, 0.25}, {"accuracy", 0.5, 0.89}}, Steps: 25},
+			},
+		},
+		{
+			Name:        "summarizer-lora",
+			Description: "LoRA adapters of the support summarizer",
+			Owner:       "frank",
+			Runs: []Run{
+				{Name: "rank-8", Parameters: map[string]string{"lora_rank": "8", "learning_rate": "1e-4", "max_seq_len": "4096"}, Metrics: []Metric{{"train_loss", 2.1, 0.9}, {"rouge_l", 0.2, 0.41}}, Steps: 20},
+				{Name: "rank-16", Parameters: map[string]string{"lora_rank": "16", "learning_rate": "1e-4", "max_seq_len": "8192"}, Metrics: []Metric{{"train_loss", 2.1, 0.85}, {"rouge_l", 0.2, 0.44}}, Steps: 20},
+			},
+		},
+	},
+}
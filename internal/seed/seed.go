@@ -0,0 +1,254 @@
+// Package seed populates a model registry with a realistic dataset, for demos and the development of clients such
+// as the UI.
+package seed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/clientutil"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// Summary counts the entities seeded.
+type Summary struct {
+	RegisteredModels int
+	ModelVersions    int
+	Artifacts        int
+	Experiments      int
+	ExperimentRuns   int
+	MetricPoints     int
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d registered models, %d model versions, %d artifacts, %d experiments, %d experiment runs and %d metric history points",
+		s.RegisteredModels, s.ModelVersions, s.Artifacts, s.Experiments, s.ExperimentRuns, s.MetricPoints)
+}
+
+// Seeder seeds the model registry server reached with its client.
+type Seeder struct {
+	client *openapi.APIClient
+}
+
+// NewSeeder returns a Seeder of the model registry server at url, authenticated with token if set.
+func NewSeeder(url string, token string) *Seeder {
+	cfg := openapi.NewConfiguration()
+	cfg.Servers = openapi.ServerConfigurations{{URL: strings.TrimSuffix(url, "/")}}
+	if token != "" {
+		cfg.AddDefaultHeader("Authorization", "Bearer "+token)
+	}
+	return &Seeder{client: openapi.NewAPIClient(cfg)}
+}
+
+// Seed creates the entities of the profile, it fails if one of them already exists, e.g. if the profile was already
+// seeded.
+func (s *Seeder) Seed(ctx context.Context, profile Profile) (Summary, error) {
+	var summary Summary
+
+	for _, model := range profile.Models {
+		if err := s.seedModel(ctx, model, &summary); err != nil {
+			return summary, fmt.Errorf("error seeding registered model %s: %w", model.Name, err)
+		}
+	}
+
+	for _, experiment := range profile.Experiments {
+		if err := s.seedExperiment(ctx, experiment, &summary); err != nil {
+			return summary, fmt.Errorf("error seeding experiment %s: %w", experiment.Name, err)
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *Seeder) seedModel(ctx context.Context, model Model, summary *Summary) error {
+	api := s.client.ModelRegistryServiceAPI
+
+	customProperties := map[string]openapi.MetadataValue{}
+	for _, label := range model.Labels {
+		// labels are custom properties with an empty string value
+		customProperties[label] = stringValue("")
+	}
+
+	registeredModel, resp, err := api.CreateRegisteredModel(ctx).RegisteredModelCreate(openapi.RegisteredModelCreate{
+		Name:             model.Name,
+		Description:      optional(model.Description),
+		Owner:            optional(model.Owner),
+		Provider:         optional(model.Provider),
+		License:          optional(model.License),
+		Tasks:            model.Tasks,
+		Language:         model.Language,
+		CustomProperties: customProperties,
+	}).Execute()
+	if err != nil {
+		return restError(resp, err)
+	}
+	summary.RegisteredModels++
+
+	for _, version := range model.Versions {
+		state := openapi.MODELVERSIONSTATE_LIVE
+		if version.Archived {
+			state = openapi.MODELVERSIONSTATE_ARCHIVED
+		}
+
+		modelVersion, resp, err := api.CreateModelVersion(ctx).ModelVersionCreate(openapi.ModelVersionCreate{
+			Name:              version.Name,
+			RegisteredModelId: *registeredModel.Id,
+			Author:            optional(version.Author),
+			Description:       optional(version.Description),
+			State:             &state,
+			CustomProperties: map[string]openapi.MetadataValue{
+				"stage": stringValue(version.Stage),
+			},
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("error creating model version %s: %w", version.Name, restError(resp, err))
+		}
+		summary.ModelVersions++
+
+		artifacts := []openapi.Artifact{{ModelArtifact: &openapi.ModelArtifact{
+			ArtifactType:       apiutils.Of("model-artifact"),
+			Name:               optional(model.Name),
+			Uri:                optional(version.URI),
+			ModelFormatName:    optional(version.Format),
+			ModelFormatVersion: optional(version.FormatVersion),
+		}}}
+		if version.Stage == "Production" {
+			artifacts = append(artifacts, openapi.Artifact{DocArtifact: &openapi.DocArtifact{
+				ArtifactType: apiutils.Of("doc-artifact"),
+				Name:         optional("model-card"),
+				Uri:          optional(fmt.Sprintf("https://docs.example.com/models/%s/%s", model.Name, version.Name)),
+			}})
+		}
+		for _, artifact := range artifacts {
+			if _, resp, err := api.UpsertModelVersionArtifact(ctx, *modelVersion.Id).Artifact(artifact).Execute(); err != nil {
+				return fmt.Errorf("error creating artifact of model version %s: %w", version.Name, restError(resp, err))
+			}
+			summary.Artifacts++
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedExperiment(ctx context.Context, experiment Experiment, summary *Summary) error {
+	api := s.client.ModelRegistryServiceAPI
+
+	created, resp, err := api.CreateExperiment(ctx).ExperimentCreate(openapi.ExperimentCreate{
+		Name:        experiment.Name,
+		Description: optional(experiment.Description),
+		Owner:       optional(experiment.Owner),
+	}).Execute()
+	if err != nil {
+		return restError(resp, err)
+	}
+	summary.Experiments++
+
+	for i, run := range experiment.Runs {
+		status := openapi.EXPERIMENTRUNSTATUS_FINISHED
+		experimentRun, resp, err := api.CreateExperimentRun(ctx).ExperimentRunCreate(openapi.ExperimentRunCreate{
+			Name:         optional(run.Name),
+			ExperimentId: *created.Id,
+			Owner:        optional(experiment.Owner),
+			Status:       &status,
+		}).Execute()
+		if err != nil {
+			return fmt.Errorf("error creating experiment run %s: %w", run.Name, restError(resp, err))
+		}
+		summary.ExperimentRuns++
+
+		names := make([]string, 0, len(run.Parameters))
+		for name := range run.Parameters {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			parameterType := openapi.PARAMETERTYPE_STRING
+			if _, err := strconv.ParseFloat(run.Parameters[name], 64); err == nil {
+				parameterType = openapi.PARAMETERTYPE_NUMBER
+			}
+			_, resp, err := api.UpsertExperimentRunArtifact(ctx, *experimentRun.Id).Artifact(openapi.Artifact{Parameter: &openapi.Parameter{
+				ArtifactType:  apiutils.Of("parameter"),
+				Name:          optional(name),
+				Value:         optional(run.Parameters[name]),
+				ParameterType: &parameterType,
+			}}).Execute()
+			if err != nil {
+				return fmt.Errorf("error creating parameter %s of experiment run %s: %w", name, run.Name, restError(resp, err))
+			}
+			summary.Artifacts++
+		}
+
+		// the noise of the metric curves is seeded so that the profile is the same on every run
+		rng := rand.New(rand.NewPCG(uint64(summary.Experiments), uint64(i)))
+		for _, metric := range run.Metrics {
+			points, err := s.logMetric(ctx, *experimentRun.Id, metric, run.Steps, rng)
+			if err != nil {
+				return fmt.Errorf("error logging metric %s of experiment run %s: %w", metric.Name, run.Name, err)
+			}
+			summary.Artifacts++
+			summary.MetricPoints += points
+		}
+	}
+
+	return nil
+}
+
+// logMetric logs the value of a metric at each step of a run, each update of the metric adds a point to its history.
+func (s *Seeder) logMetric(ctx context.Context, experimentRunID string, metric Metric, steps int, rng *rand.Rand) (int, error) {
+	api := s.client.ModelRegistryServiceAPI
+
+	var id *string
+	for step := range max(steps, 1) {
+		// exponential convergence from Start to End, with 1% noise
+		progress := (1 - math.Exp(-4*float64(step)/float64(max(steps-1, 1)))) / (1 - math.Exp(-4))
+		value := metric.Start + (metric.End-metric.Start)*progress
+		value += (rng.Float64() - 0.5) * 0.02 * math.Abs(metric.End-metric.Start)
+
+		logged, resp, err := api.UpsertExperimentRunArtifact(ctx, experimentRunID).Artifact(openapi.Artifact{Metric: &openapi.Metric{
+			ArtifactType: apiutils.Of("metric"),
+			Id:           id,
+			Name:         optional(metric.Name),
+			Value:        &value,
+			Step:         apiutils.Of(int64(step)),
+			Timestamp:    optional(strconv.FormatInt(time.Now().UnixMilli(), 10)),
+		}}).Execute()
+		if err != nil {
+			return step, restError(resp, err)
+		}
+		id = logged.Metric.Id
+
+		// the history points are named after the update time of the metric, in milliseconds
+		time.Sleep(time.Millisecond)
+	}
+	return max(steps, 1), nil
+}
+
+func stringValue(value string) openapi.MetadataValue {
+	return openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(value, "MetadataStringValue"))
+}
+
+// optional returns a pointer to value, nil for the zero value.
+func optional[T comparable](value T) *T {
+	var zero T
+	if value == zero {
+		return nil
+	}
+	return &value
+}
+
+// restError adds the body of an error response to err.
+func restError(resp *http.Response, err error) error {
+	err = clientutil.RestError(err)
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		return fmt.Errorf("%w (already seeded?)", err)
+	}
+	return err
+}
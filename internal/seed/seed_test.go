@@ -0,0 +1,51 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedDemo(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	profile, err := GetProfile("demo")
+	require.NoError(t, err)
+
+	summary, err := NewSeeder(server.URL, "").Seed(context.Background(), profile)
+	require.NoError(t, err)
+	assert.Equal(t, len(profile.Models), summary.RegisteredModels)
+	assert.Equal(t, len(profile.Experiments), summary.Experiments)
+
+	models, err := service.GetRegisteredModels(api.ListOptions{FilterQuery: apiutils.Of("nlp = ''")})
+	require.NoError(t, err)
+	require.Len(t, models.Items, 1)
+	assert.Equal(t, "sentiment-classifier", models.Items[0].Name)
+
+	versions, err := service.GetModelVersions(api.ListOptions{FilterQuery: apiutils.Of("stage = 'Production'")}, nil)
+	require.NoError(t, err)
+	assert.Len(t, versions.Items, 4)
+
+	runs, err := service.GetExperimentRuns(api.ListOptions{}, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, runs.Items)
+
+	history, err := service.GetExperimentRunMetricHistory(apiutils.Of("logloss"), nil, api.ListOptions{}, runs.Items[0].Id)
+	require.NoError(t, err)
+	assert.Len(t, history.Items, profile.Experiments[0].Runs[0].Steps)
+
+	_, err = NewSeeder(server.URL, "").Seed(context.Background(), profile)
+	assert.ErrorContains(t, err, "already seeded")
+}
+
+func TestGetProfile(t *testing.T) {
+	assert.Equal(t, []string{"demo", "minimal"}, Profiles())
+
+	_, err := GetProfile("unknown")
+	assert.ErrorContains(t, err, "demo, minimal")
+}
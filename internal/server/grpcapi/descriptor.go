@@ -0,0 +1,124 @@
+package grpcapi
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// ServiceName is the full name of the gRPC service of the registry.
+	ServiceName = "modelregistry.v1alpha3.ModelRegistryService"
+
+	protoPackage = "modelregistry.v1alpha3"
+	protoFile    = "model_registry/v1alpha3/model_registry.proto"
+	structType   = ".google.protobuf.Struct"
+)
+
+// rpc is a method of the service.
+type rpc struct {
+	name          string
+	input         string
+	clientStreams bool
+	serverStreams bool
+}
+
+// file is the descriptor of api/grpc/model_registry.proto, built from the rpcs of the resources as there is no
+// generated code, and registered for the reflection service to serve it.
+var file = registerFile()
+
+// The messages of the requests, decoded as dynamic messages.
+var (
+	getRequest   = file.Messages().ByName("GetRequest")
+	listRequest  = file.Messages().ByName("ListRequest")
+	writeRequest = file.Messages().ByName("WriteRequest")
+)
+
+// rpcs returns the methods of the service, in the order of api/grpc/model_registry.proto.
+func rpcs() []rpc {
+	var result []rpc
+	for _, r := range resources {
+		result = append(result,
+			rpc{name: "Get" + r.name, input: "GetRequest"},
+			rpc{name: "List" + r.plural, input: "ListRequest", serverStreams: true},
+			rpc{name: "Create" + r.name, input: "WriteRequest"},
+		)
+		if r.update != nil {
+			result = append(result, rpc{name: "Update" + r.name, input: "WriteRequest"})
+		}
+	}
+	return append(result,
+		rpc{name: "ListExperimentRunArtifacts", input: "ListRequest", serverStreams: true},
+		rpc{name: "LogExperimentRunArtifacts", input: "WriteRequest", clientStreams: true, serverStreams: true},
+	)
+}
+
+func registerFile() protoreflect.FileDescriptor {
+	methods := []*descriptorpb.MethodDescriptorProto{}
+	for _, m := range rpcs() {
+		methods = append(methods, &descriptorpb.MethodDescriptorProto{
+			Name:            proto.String(m.name),
+			InputType:       proto.String("." + protoPackage + "." + m.input),
+			OutputType:      proto.String(structType),
+			ClientStreaming: proto.Bool(m.clientStreams),
+			ServerStreaming: proto.Bool(m.serverStreams),
+		})
+	}
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String(protoFile),
+		Package:    proto.String(protoPackage),
+		Dependency: []string{structpb.File_google_protobuf_struct_proto.Path()},
+		Syntax:     proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("GetRequest", stringField("id", 1)),
+			message("ListRequest",
+				stringField("parent_id", 1),
+				stringField("filter_query", 2),
+				stringField("order_by", 3),
+				stringField("sort_order", 4),
+				field("page_size", 5, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+			),
+			message("WriteRequest",
+				stringField("id", 1),
+				stringField("parent_id", 2),
+				field("resource", 3, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, structType),
+			),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("ModelRegistryService"),
+			Method: methods,
+		}},
+	}, protoregistry.GlobalFiles)
+	if err != nil {
+		panic("invalid model registry service descriptor: " + err.Error())
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic("error registering model registry service descriptor: " + err.Error())
+	}
+	return fd
+}
+
+func message(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+	return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+}
+
+func stringField(name string, number int32) *descriptorpb.FieldDescriptorProto {
+	return field(name, number, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+}
+
+func field(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+	f := &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   kind.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
@@ -0,0 +1,293 @@
+package grpcapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubeflow/model-registry/internal/server/middleware"
+	"github.com/kubeflow/model-registry/internal/server/openapi"
+	"github.com/kubeflow/model-registry/pkg/api"
+	model "github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// resource binds the rpcs of a REST resource: the reads to the core api, and the writes to the REST servicer for
+// them to be validated and reconciled with the existing resources as the REST writes are.
+type resource struct {
+	name   string
+	plural string
+	// scope is the resource of the API token scopes of the rpcs.
+	scope string
+	get   func(registry api.ModelRegistryApi, id string) (any, error)
+	list  func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error)
+	// create and update get the JSON object of the resource, update is nil for the resources the REST api can't
+	// update.
+	create func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error)
+	update func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error)
+}
+
+var resources = []resource{
+	{
+		name:   "RegisteredModel",
+		plural: "RegisteredModels",
+		scope:  middleware.ScopeResourceModels,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetRegisteredModelById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, _ *string) ([]any, string, error) {
+			page, err := registry.GetRegisteredModels(listOptions)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, _ string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.RegisteredModelCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateRegisteredModel(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.RegisteredModelUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateRegisteredModel(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "ModelVersion",
+		plural: "ModelVersions",
+		scope:  middleware.ScopeResourceVersions,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetModelVersionById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+			page, err := registry.GetModelVersions(listOptions, parentId)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+			return write(withField(data, "registeredModelId", parentId), func(entity model.ModelVersionCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateModelVersion(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ModelVersionUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateModelVersion(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "Artifact",
+		plural: "Artifacts",
+		scope:  middleware.ScopeResourceArtifacts,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetArtifactById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+			page, err := registry.GetArtifacts("", listOptions, parentId)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+			if parentId != "" {
+				return write(data, func(entity model.Artifact) (openapi.ImplResponse, error) {
+					return servicer.UpsertModelVersionArtifact(ctx, parentId, entity)
+				})
+			}
+			return write(data, func(entity model.ArtifactCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateArtifact(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ArtifactUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateArtifact(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "ServingEnvironment",
+		plural: "ServingEnvironments",
+		scope:  middleware.ScopeResourceServing,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetServingEnvironmentById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, _ *string) ([]any, string, error) {
+			page, err := registry.GetServingEnvironments(listOptions)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, _ string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ServingEnvironmentCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateServingEnvironment(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ServingEnvironmentUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateServingEnvironment(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "InferenceService",
+		plural: "InferenceServices",
+		scope:  middleware.ScopeResourceServing,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetInferenceServiceById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+			page, err := registry.GetInferenceServices(listOptions, parentId, nil)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+			return write(withField(data, "servingEnvironmentId", parentId), func(entity model.InferenceServiceCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateInferenceService(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.InferenceServiceUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateInferenceService(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "ServeModel",
+		plural: "ServeModels",
+		scope:  middleware.ScopeResourceServing,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetServeModelById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+			page, err := registry.GetServeModels(listOptions, parentId)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+			if parentId == "" {
+				return openapi.ImplResponse{}, fmt.Errorf("parent_id of the inference service is required: %w", api.ErrBadRequest)
+			}
+			return write(data, func(entity model.ServeModelCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateInferenceServiceServe(ctx, parentId, entity)
+			})
+		},
+	},
+	{
+		name:   "Experiment",
+		plural: "Experiments",
+		scope:  middleware.ScopeResourceExperiments,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetExperimentById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, _ *string) ([]any, string, error) {
+			page, err := registry.GetExperiments(listOptions)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, _ string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ExperimentCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateExperiment(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ExperimentUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateExperiment(ctx, id, entity)
+			})
+		},
+	},
+	{
+		name:   "ExperimentRun",
+		plural: "ExperimentRuns",
+		scope:  middleware.ScopeResourceExperiments,
+		get: func(registry api.ModelRegistryApi, id string) (any, error) {
+			return registry.GetExperimentRunById(id)
+		},
+		list: func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+			page, err := registry.GetExperimentRuns(listOptions, parentId)
+			if err != nil {
+				return nil, "", err
+			}
+			return items(page.Items), page.NextPageToken, nil
+		},
+		create: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+			return write(withField(data, "experimentId", parentId), func(entity model.ExperimentRunCreate) (openapi.ImplResponse, error) {
+				return servicer.CreateExperimentRun(ctx, entity)
+			})
+		},
+		update: func(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, id string, data []byte) (openapi.ImplResponse, error) {
+			return write(data, func(entity model.ExperimentRunUpdate) (openapi.ImplResponse, error) {
+				return servicer.UpdateExperimentRun(ctx, id, entity)
+			})
+		},
+	},
+}
+
+// listExperimentRunArtifacts lists the artifacts of the experiment run parentId.
+func listExperimentRunArtifacts(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error) {
+	if parentId == nil {
+		return nil, "", fmt.Errorf("parent_id of the experiment run is required: %w", api.ErrBadRequest)
+	}
+	page, err := registry.GetExperimentRunArtifacts("", listOptions, parentId)
+	if err != nil {
+		return nil, "", err
+	}
+	return items(page.Items), page.NextPageToken, nil
+}
+
+// logExperimentRunArtifact upserts an artifact of the experiment run parentId.
+func logExperimentRunArtifact(ctx context.Context, servicer openapi.ModelRegistryServiceAPIServicer, parentId string, data []byte) (openapi.ImplResponse, error) {
+	if parentId == "" {
+		return openapi.ImplResponse{}, fmt.Errorf("parent_id of the experiment run is required: %w", api.ErrBadRequest)
+	}
+	return write(data, func(entity model.Artifact) (openapi.ImplResponse, error) {
+		return servicer.UpsertExperimentRunArtifact(ctx, parentId, entity)
+	})
+}
+
+// write decodes the JSON object of a resource as the REST api does, rejecting unknown fields, and writes it.
+func write[T any](data []byte, writeEntity func(entity T) (openapi.ImplResponse, error)) (openapi.ImplResponse, error) {
+	var entity T
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&entity); err != nil {
+		return openapi.ImplResponse{}, fmt.Errorf("invalid resource: %v: %w", err, api.ErrBadRequest)
+	}
+	return writeEntity(entity)
+}
+
+// withField sets the field key of the JSON object data to a non empty value.
+func withField(data []byte, key string, value string) []byte {
+	if value == "" {
+		return data
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		// left to the decoding of the resource to reject
+		return data
+	}
+	object[key], _ = json.Marshal(value)
+	result, err := json.Marshal(object)
+	if err != nil {
+		return data
+	}
+	return result
+}
+
+func items[T any](page []T) []any {
+	result := make([]any, len(page))
+	for i := range page {
+		result[i] = &page[i]
+	}
+	return result
+}
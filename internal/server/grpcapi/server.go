@@ -0,0 +1,399 @@
+// Package grpcapi serves the registry api over gRPC next to the REST api, for the clients writing at high rates, e.g.
+// training pipelines streaming their metrics.
+//
+// The service, see api/grpc/model_registry.proto, mirrors the REST resources carried as google.protobuf.Struct: the
+// reads go to the core api and the writes to the REST servicer, so that both apis share the validations and the
+// repositories. The gRPC reflection and health services are registered next to it.
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/server/middleware"
+	"github.com/kubeflow/model-registry/internal/server/openapi"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Server is a gRPC server of the registry api.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+	// registry returns the registry once connected to the datastore, nil until then
+	registry func() api.ModelRegistryApi
+	tokens   *middleware.APITokens
+	// scopes are the scopes the rpcs require, by full method name
+	scopes map[string]string
+}
+
+// NewServer returns a gRPC server of registry, requiring the scopes of the REST api from the bearer tokens of the
+// requests when tokens are enabled. It is NOT_SERVING until SetServing is called.
+func NewServer(registry func() api.ModelRegistryApi, tokens *middleware.APITokens, opts ...grpc.ServerOption) *Server {
+	s := &Server{
+		health:   health.NewServer(),
+		registry: registry,
+		tokens:   tokens,
+		scopes:   map[string]string{},
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := s.authorize(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(s.tenant(ctx), req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := s.authorize(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, &tenantStream{ServerStream: stream, ctx: s.tenant(stream.Context())})
+		}),
+	)
+	s.Server = grpc.NewServer(opts...)
+	s.RegisterService(s.serviceDesc(), s)
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	s.health.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	healthpb.RegisterHealthServer(s.Server, s.health)
+	reflection.Register(s.Server)
+
+	return s
+}
+
+// SetServing reports the server as SERVING to the health checks, once the registry is connected.
+func (s *Server) SetServing() {
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_SERVING)
+}
+
+func (s *Server) serviceDesc() *grpc.ServiceDesc {
+	desc := &grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Metadata:    protoFile,
+	}
+
+	for _, r := range resources {
+		desc.Methods = append(desc.Methods, s.unary("Get"+r.name, r.scope+":"+middleware.ScopeActionRead, getRequest,
+			func(ctx context.Context, req *dynamicpb.Message) (any, error) {
+				registry, err := s.connected(ctx)
+				if err != nil {
+					return nil, err
+				}
+				entity, err := r.get(registry, requestString(req, "id"))
+				if err != nil {
+					return nil, toStatus(err)
+				}
+				return toStruct(entity)
+			}))
+
+		desc.Streams = append(desc.Streams, s.list("List"+r.plural, r.scope+":"+middleware.ScopeActionRead, r.list))
+
+		desc.Methods = append(desc.Methods, s.unary("Create"+r.name, r.scope+":"+middleware.ScopeActionWrite, writeRequest,
+			func(ctx context.Context, req *dynamicpb.Message) (any, error) {
+				if requestString(req, "id") != "" {
+					return nil, status.Errorf(codes.InvalidArgument, "id must be empty to create a %s", r.name)
+				}
+				registry, err := s.connected(ctx)
+				if err != nil {
+					return nil, err
+				}
+				data, err := resourceJSON(req)
+				if err != nil {
+					return nil, err
+				}
+				return reply(r.create(ctx, openapi.NewModelRegistryServiceAPIService(registry), requestString(req, "parent_id"), data))
+			}))
+
+		if r.update != nil {
+			desc.Methods = append(desc.Methods, s.unary("Update"+r.name, r.scope+":"+middleware.ScopeActionWrite, writeRequest,
+				func(ctx context.Context, req *dynamicpb.Message) (any, error) {
+					id := requestString(req, "id")
+					if id == "" {
+						return nil, status.Errorf(codes.InvalidArgument, "id of the %s to update is required", r.name)
+					}
+					registry, err := s.connected(ctx)
+					if err != nil {
+						return nil, err
+					}
+					data, err := resourceJSON(req)
+					if err != nil {
+						return nil, err
+					}
+					return reply(r.update(ctx, openapi.NewModelRegistryServiceAPIService(registry), id, data))
+				}))
+		}
+	}
+
+	desc.Streams = append(desc.Streams,
+		s.list("ListExperimentRunArtifacts", middleware.ScopeResourceArtifacts+":"+middleware.ScopeActionRead, listExperimentRunArtifacts),
+		s.logExperimentRunArtifacts(),
+	)
+
+	return desc
+}
+
+// unary returns an rpc decoding its request as a dynamic message of input.
+func (s *Server) unary(name string, scope string, input protoreflect.MessageDescriptor, call func(ctx context.Context, req *dynamicpb.Message) (any, error)) grpc.MethodDesc {
+	s.scopes["/"+ServiceName+"/"+name] = scope
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(_ any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+			req := dynamicpb.NewMessage(input)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req any) (any, error) {
+				return call(ctx, req.(*dynamicpb.Message))
+			}
+			if interceptor == nil {
+				return handler(ctx, req)
+			}
+			return interceptor(ctx, req, &grpc.UnaryServerInfo{Server: s, FullMethod: "/" + ServiceName + "/" + name}, handler)
+		},
+	}
+}
+
+// list returns an rpc streaming all the resources of a list, reading them by pages.
+func (s *Server) list(name string, scope string, list func(registry api.ModelRegistryApi, listOptions api.ListOptions, parentId *string) ([]any, string, error)) grpc.StreamDesc {
+	s.scopes["/"+ServiceName+"/"+name] = scope
+	return grpc.StreamDesc{
+		StreamName:    name,
+		ServerStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			req := dynamicpb.NewMessage(listRequest)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			registry, err := s.connected(stream.Context())
+			if err != nil {
+				return err
+			}
+
+			listOptions := api.ListOptions{
+				FilterQuery: optionalString(req, "filter_query"),
+				OrderBy:     optionalString(req, "order_by"),
+				SortOrder:   optionalString(req, "sort_order"),
+			}
+			if pageSize := int32(req.Get(req.Descriptor().Fields().ByName("page_size")).Int()); pageSize > 0 {
+				listOptions.PageSize = &pageSize
+			}
+			parentId := optionalString(req, "parent_id")
+
+			for {
+				page, nextPageToken, err := list(registry, listOptions, parentId)
+				if err != nil {
+					return toStatus(err)
+				}
+				for _, item := range page {
+					msg, err := toStruct(item)
+					if err != nil {
+						return err
+					}
+					if err := stream.SendMsg(msg); err != nil {
+						return err
+					}
+				}
+				if nextPageToken == "" {
+					return nil
+				}
+				listOptions.NextPageToken = &nextPageToken
+			}
+		},
+	}
+}
+
+// logExperimentRunArtifacts returns the rpc upserting a stream of experiment run artifacts.
+func (s *Server) logExperimentRunArtifacts() grpc.StreamDesc {
+	name := "LogExperimentRunArtifacts"
+	s.scopes["/"+ServiceName+"/"+name] = middleware.ScopeResourceArtifacts + ":" + middleware.ScopeActionWrite
+	return grpc.StreamDesc{
+		StreamName:    name,
+		ClientStreams: true,
+		ServerStreams: true,
+		Handler: func(_ any, stream grpc.ServerStream) error {
+			registry, err := s.connected(stream.Context())
+			if err != nil {
+				return err
+			}
+			servicer := openapi.NewModelRegistryServiceAPIService(registry)
+
+			for {
+				req := dynamicpb.NewMessage(writeRequest)
+				if err := stream.RecvMsg(req); errors.Is(err, io.EOF) {
+					return nil
+				} else if err != nil {
+					return err
+				}
+
+				data, err := resourceJSON(req)
+				if err != nil {
+					return err
+				}
+				artifact, err := reply(logExperimentRunArtifact(stream.Context(), servicer, requestString(req, "parent_id"), data))
+				if err != nil {
+					return err
+				}
+				if err := stream.SendMsg(artifact); err != nil {
+					return err
+				}
+			}
+		},
+	}
+}
+
+// connected returns the registry bound to ctx, or Unavailable before it is connected to the datastore.
+func (s *Server) connected(ctx context.Context) (api.ModelRegistryApi, error) {
+	registry := s.registry()
+	if registry == nil {
+		return nil, status.Error(codes.Unavailable, "model registry is not connected to its datastore yet")
+	}
+	return api.WithContext(ctx, registry), nil
+}
+
+// authorize checks the scope required by an rpc of the service against the bearer token of the request.
+func (s *Server) authorize(ctx context.Context, fullMethod string) error {
+	required, ok := s.scopes[fullMethod]
+	if !ok || !s.tokens.Enabled() {
+		return nil
+	}
+
+	token := s.tokens.Lookup(bearer(ctx))
+	if token == nil {
+		return status.Error(codes.Unauthenticated, "api token required")
+	}
+	if !token.Grants(required) {
+		glog.V(2).Infof("API token %s denied %s, missing scope %s", token.Name, fullMethod, required)
+		return status.Errorf(codes.PermissionDenied, "api token %s lacks the %s scope", token.Name, required)
+	}
+	return nil
+}
+
+// tenant returns ctx restricted to the namespace of the api token of its request, if it has one.
+func (s *Server) tenant(ctx context.Context) context.Context {
+	if token := s.tokens.Lookup(bearer(ctx)); token != nil && token.Namespace != "" {
+		return api.WithNamespace(ctx, token.Namespace)
+	}
+	return ctx
+}
+
+// tenantStream is a stream running in the context of its tenant.
+type tenantStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tenantStream) Context() context.Context {
+	return s.ctx
+}
+
+// bearer returns the bearer token of the request of ctx, if any.
+func bearer(ctx context.Context) string {
+	var bearer string
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if b, ok := strings.CutPrefix(value, "Bearer "); ok {
+			bearer = b
+		}
+	}
+	return bearer
+}
+
+// reply returns the body of the response of the REST servicer as a Struct, or its error as a status.
+func reply(resp openapi.ImplResponse, err error) (*structpb.Struct, error) {
+	if err != nil || resp.Code >= http.StatusMultipleChoices {
+		code := resp.Code
+		if code == 0 {
+			code = api.ErrToStatus(err)
+		}
+		message := http.StatusText(code)
+		if err != nil {
+			message = err.Error()
+		}
+		return nil, status.Error(grpcCode(code), message)
+	}
+	return toStruct(resp.Body)
+}
+
+// toStruct returns the JSON object of a resource as a Struct.
+func toStruct(v any) (*structpb.Struct, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding resource: %v", err)
+	}
+	result := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, result); err != nil {
+		return nil, status.Errorf(codes.Internal, "error encoding resource: %v", err)
+	}
+	return result, nil
+}
+
+// resourceJSON returns the resource of a write request as a JSON object.
+func resourceJSON(req *dynamicpb.Message) ([]byte, error) {
+	field := req.Descriptor().Fields().ByName("resource")
+	if !req.Has(field) {
+		return []byte("{}"), nil
+	}
+	data, err := protojson.Marshal(req.Get(field).Message().Interface())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid resource: %v", err)
+	}
+	return data, nil
+}
+
+func requestString(req *dynamicpb.Message, name protoreflect.Name) string {
+	return req.Get(req.Descriptor().Fields().ByName(name)).String()
+}
+
+func optionalString(req *dynamicpb.Message, name protoreflect.Name) *string {
+	if value := requestString(req, name); value != "" {
+		return &value
+	}
+	return nil
+}
+
+func toStatus(err error) error {
+	return status.Error(grpcCode(api.ErrToStatus(err)), err.Error())
+}
+
+// grpcCode returns the gRPC code of an http status of the REST api.
+func grpcCode(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	return codes.Internal
+}
@@ -0,0 +1,186 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/server/middleware"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestProtoFile(t *testing.T) {
+	data, err := os.ReadFile("../../../api/grpc/model_registry.proto")
+	require.NoError(t, err)
+
+	var declared []rpc
+	for _, m := range regexp.MustCompile(`rpc (\w+)\((stream )?(\w+)\) returns \((stream )?google\.protobuf\.Struct\);`).FindAllStringSubmatch(string(data), -1) {
+		declared = append(declared, rpc{name: m[1], input: m[3], clientStreams: m[2] != "", serverStreams: m[4] != ""})
+	}
+	assert.Equal(t, declared, rpcs(), "the service must match api/grpc/model_registry.proto")
+
+	_, err = protoregistry.GlobalFiles.FindDescriptorByName(ServiceName)
+	assert.NoError(t, err, "the service is registered for the reflection service")
+}
+
+func TestServer(t *testing.T) {
+	registry := inmemory.NewModelRegistryService(inmemory.NewStore())
+	var connected atomic.Bool
+	tokens, err := middleware.NewAPITokens(&middleware.APITokensConfig{Tokens: []middleware.APIToken{
+		{Name: "pipeline", Token: "pipeline-token", Scopes: []string{"*:*"}},
+		{Name: "reader", Token: "reader-token", Scopes: []string{"*:read"}},
+	}})
+	require.NoError(t, err)
+
+	server := NewServer(func() api.ModelRegistryApi {
+		if !connected.Load() {
+			return nil
+		}
+		return registry
+	}, tokens)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer pipeline-token")
+
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, health.Status)
+
+	call := func(ctx context.Context, method string, req protoreflect.ProtoMessage) (*structpb.Struct, error) {
+		resp := &structpb.Struct{}
+		err := conn.Invoke(ctx, "/"+ServiceName+"/"+method, req, resp)
+		return resp, err
+	}
+	get := func(id string) *dynamicpb.Message {
+		req := dynamicpb.NewMessage(getRequest)
+		req.Set(getRequest.Fields().ByName("id"), protoreflect.ValueOfString(id))
+		return req
+	}
+	write := func(id, parentId string, resource map[string]any) *dynamicpb.Message {
+		req := dynamicpb.NewMessage(writeRequest)
+		fields := writeRequest.Fields()
+		req.Set(fields.ByName("id"), protoreflect.ValueOfString(id))
+		req.Set(fields.ByName("parent_id"), protoreflect.ValueOfString(parentId))
+		value, err := structpb.NewStruct(resource)
+		require.NoError(t, err)
+		req.Set(fields.ByName("resource"), protoreflect.ValueOfMessage(value.ProtoReflect()))
+		return req
+	}
+	list := func(method, parentId string, pageSize int32) ([]*structpb.Struct, error) {
+		stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/"+ServiceName+"/"+method)
+		require.NoError(t, err)
+		req := dynamicpb.NewMessage(listRequest)
+		req.Set(listRequest.Fields().ByName("parent_id"), protoreflect.ValueOfString(parentId))
+		req.Set(listRequest.Fields().ByName("page_size"), protoreflect.ValueOfInt32(pageSize))
+		require.NoError(t, stream.SendMsg(req))
+		require.NoError(t, stream.CloseSend())
+
+		var items []*structpb.Struct
+		for {
+			item := &structpb.Struct{}
+			if err := stream.RecvMsg(item); err == io.EOF {
+				return items, nil
+			} else if err != nil {
+				return items, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	_, err = call(ctx, "GetRegisteredModel", get("1"))
+	assert.Equal(t, codes.Unavailable, status.Code(err), "the registry is not connected yet")
+
+	connected.Store(true)
+	server.SetServing()
+	health, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+
+	// the rpcs require the scopes of their REST requests
+	_, err = call(context.Background(), "CreateRegisteredModel", write("", "", map[string]any{"name": "fraud"}))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	readerCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer reader-token")
+	_, err = call(readerCtx, "CreateRegisteredModel", write("", "", map[string]any{"name": "fraud"}))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	model, err := call(ctx, "CreateRegisteredModel", write("", "", map[string]any{"name": "fraud"}))
+	require.NoError(t, err)
+	modelId := model.Fields["id"].GetStringValue()
+	assert.Equal(t, "fraud", model.Fields["name"].GetStringValue())
+
+	_, err = call(ctx, "CreateRegisteredModel", write("", "", map[string]any{"name": "fraud"}))
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = call(ctx, "CreateRegisteredModel", write("", "", map[string]any{"name": "churn", "color": "red"}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "unknown fields are rejected")
+
+	updated, err := call(ctx, "UpdateRegisteredModel", write(modelId, "", map[string]any{"description": "card fraud"}))
+	require.NoError(t, err)
+	assert.Equal(t, "card fraud", updated.Fields["description"].GetStringValue())
+	assert.Equal(t, "fraud", updated.Fields["name"].GetStringValue(), "updates keep the fields they don't set")
+
+	read, err := call(readerCtx, "GetRegisteredModel", get(modelId))
+	require.NoError(t, err)
+	assert.Equal(t, "card fraud", read.Fields["description"].GetStringValue())
+	_, err = call(ctx, "GetRegisteredModel", get("999"))
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	for i := range 3 {
+		_, err := call(ctx, "CreateModelVersion", write("", modelId, map[string]any{"name": fmt.Sprintf("v%d", i)}))
+		require.NoError(t, err)
+	}
+	versions, err := list("ListModelVersions", modelId, 2)
+	require.NoError(t, err)
+	assert.Len(t, versions, 3, "the lists stream all the pages")
+
+	// training loops stream their metrics
+	experiment, err := call(ctx, "CreateExperiment", write("", "", map[string]any{"name": "sweep"}))
+	require.NoError(t, err)
+	run, err := call(ctx, "CreateExperimentRun", write("", experiment.Fields["id"].GetStringValue(), map[string]any{"name": "lr-0.1"}))
+	require.NoError(t, err)
+	runId := run.Fields["id"].GetStringValue()
+
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ClientStreams: true, ServerStreams: true}, "/"+ServiceName+"/LogExperimentRunArtifacts")
+	require.NoError(t, err)
+	for i, name := range []string{"loss", "accuracy"} {
+		require.NoError(t, stream.SendMsg(write("", runId, map[string]any{"artifactType": "metric", "name": name, "value": 0.5, "step": i})))
+		logged := &structpb.Struct{}
+		require.NoError(t, stream.RecvMsg(logged))
+		assert.Equal(t, name, logged.Fields["name"].GetStringValue())
+	}
+	require.NoError(t, stream.CloseSend())
+	assert.Equal(t, io.EOF, stream.RecvMsg(&structpb.Struct{}))
+
+	artifacts, err := list("ListExperimentRunArtifacts", runId, 0)
+	require.NoError(t, err)
+	assert.Len(t, artifacts, 2)
+
+	_, err = list("ListExperimentRunArtifacts", "", 0)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
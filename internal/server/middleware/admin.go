@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// AdminScope is the scope of the api tokens granted the /admin endpoints, next to the admin token.
+const AdminScope = ScopeResourceRegistry + ":" + ScopeActionAdmin
+
+// IsAdmin returns a function reporting whether a request carries the admin bearer token, always false without a
+// token.
+func IsAdmin(token string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		if token == "" {
+			return false
+		}
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		return ok && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+	}
+}
+
+// RequireAdmin rejects the requests without the admin bearer token or an api token with the AdminScope scope, which
+// the wildcard scopes such as * or registry:* don't grant. The endpoints being global, the tokens of a tenant are
+// rejected with 403. Without an admin token nor api tokens all the requests are served, as the api is not
+// authenticated either.
+func RequireAdmin(token string, tokens *APITokens, next http.Handler) http.Handler {
+	return requireAdmin(token, tokens, false, next)
+}
+
+// RequireTenantAdmin is RequireAdmin also serving the tokens of a tenant, for the endpoints restricted to the
+// namespace of the tenant by Tenants.
+func RequireTenantAdmin(token string, tokens *APITokens, next http.Handler) http.Handler {
+	return requireAdmin(token, tokens, true, next)
+}
+
+func requireAdmin(token string, tokens *APITokens, tenants bool, next http.Handler) http.Handler {
+	if token == "" && !tokens.Enabled() {
+		return next
+	}
+	isAdmin := IsAdmin(token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdmin(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		apiToken := tokens.Lookup(bearer)
+		if apiToken == nil || !apiToken.Grants(AdminScope) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="model-registry-admin"`)
+			http.Error(w, "admin token required", http.StatusUnauthorized)
+			return
+		}
+		if apiToken.Namespace != "" && !tenants {
+			http.Error(w, "api token "+apiToken.Name+" is restricted to namespace "+apiToken.Namespace, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "ci", Token: "ci", Scopes: []string{"*:read", "*:write"}},
+		{Name: "operator", Token: "operator", Scopes: []string{AdminScope}},
+		{Name: "root", Token: "root", Scopes: []string{"*", "registry:*"}},
+		{Name: "team-a", Token: "team-a", Scopes: []string{"*", AdminScope}, Namespace: "team-a"},
+	}})
+	require.NoError(t, err)
+
+	serve := func(handler http.Handler, token string) int {
+		r := httptest.NewRequest(http.MethodGet, "/admin/jobs", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	handler := RequireAdmin("admin", tokens, ok)
+	assert.Equal(t, http.StatusOK, serve(handler, "admin"))
+	assert.Equal(t, http.StatusOK, serve(handler, "operator"))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "ci"), "the api scopes don't grant the admin endpoints")
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "root"), "the wildcards don't grant the admin endpoints")
+	assert.Equal(t, http.StatusForbidden, serve(handler, "team-a"), "the tenants don't reach the global endpoints")
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, ""))
+
+	// with api tokens but no admin token, only the tokens granted the admin scope are served
+	handler = RequireAdmin("", tokens, ok)
+	assert.Equal(t, http.StatusOK, serve(handler, "operator"))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "ci"))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, ""))
+
+	// the tenants reach the endpoints restricted to their namespace
+	handler = RequireTenantAdmin("admin", tokens, ok)
+	assert.Equal(t, http.StatusOK, serve(handler, "team-a"))
+	assert.Equal(t, http.StatusOK, serve(handler, "operator"))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "root"))
+
+	// without any token, as the api, the admin endpoints are not authenticated
+	assert.Equal(t, http.StatusOK, serve(RequireAdmin("", nil, ok), ""))
+}
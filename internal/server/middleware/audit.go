@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// AdminActor is the actor of the writes of the requests with the admin token in the audit log.
+const AdminActor = "admin"
+
+// Callers sets the authenticated caller of the api requests in their context, see api.Caller: the name of their api
+// token, AdminActor for the admin token, else the user of the header set by the authenticating proxy in front of the
+// registry.
+func Callers(tokens *APITokens, userHeader string, isAdmin func(r *http.Request) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(api.WithCaller(r.Context(), callerOf(tokens, userHeader, isAdmin, r))))
+	})
+}
+
+// Audit records the writes of the api requests in the audit log on behalf of their caller, as Callers finds it. The
+// writes of the requests of no caller are recorded with an empty actor.
+func Audit(tokens *APITokens, userHeader string, isAdmin func(r *http.Request) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(api.WithActor(r.Context(), callerOf(tokens, userHeader, isAdmin, r))))
+	})
+}
+
+func callerOf(tokens *APITokens, userHeader string, isAdmin func(r *http.Request) bool, r *http.Request) string {
+	bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch token := tokens.Lookup(bearer); {
+	case token != nil:
+		return token.Name
+	case isAdmin(r):
+		return AdminActor
+	case userHeader != "":
+		return r.Header.Get(userHeader)
+	}
+	return ""
+}
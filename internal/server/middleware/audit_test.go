@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "ci", Token: "ci", Scopes: []string{"*"}},
+	}})
+	require.NoError(t, err)
+
+	var (
+		actor   string
+		audited bool
+		caller  string
+	)
+	handler := Callers(tokens, "X-Remote-User", IsAdmin("admin"), Audit(tokens, "X-Remote-User", IsAdmin("admin"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor, audited = api.Actor(r.Context())
+		caller = api.Caller(r.Context())
+	})))
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		user  string
+		actor string
+	}{
+		{"token", "ci", "mallory", "ci"},
+		{"admin token", "admin", "", AdminActor},
+		{"user header", "", "alice", "alice"},
+		{"unknown token", "unknown", "alice", "alice"},
+		{"anonymous", "", "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			actor, audited = "", false
+			r := httptest.NewRequest(http.MethodPost, "/api/model_registry/v1alpha3/registered_models", nil)
+			if tc.token != "" {
+				r.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			if tc.user != "" {
+				r.Header.Set("X-Remote-User", tc.user)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+			assert.True(t, audited)
+			assert.Equal(t, tc.actor, actor)
+			assert.Equal(t, tc.actor, caller)
+		})
+	}
+}
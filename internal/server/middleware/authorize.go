@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/authz"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// permissionEntityTypes maps the collections of the api paths to the entity types of their permissions.
+var permissionEntityTypes = map[string]string{
+	"registered_models":     authz.EntityTypeRegisteredModels,
+	"registered_model":      authz.EntityTypeRegisteredModels,
+	"model_versions":        authz.EntityTypeModelVersions,
+	"model_version":         authz.EntityTypeModelVersions,
+	"versions":              authz.EntityTypeModelVersions,
+	"promotions":            authz.EntityTypeModelVersions,
+	"promotion_runs":        authz.EntityTypeModelVersions,
+	"ab_tests":              authz.EntityTypeModelVersions,
+	"stage_transitions":     authz.EntityTypeModelVersions,
+	"artifacts":             authz.EntityTypeArtifacts,
+	"artifact":              authz.EntityTypeArtifacts,
+	"model_artifacts":       authz.EntityTypeArtifacts,
+	"model_artifact":        authz.EntityTypeArtifacts,
+	"conversion_jobs":       authz.EntityTypeArtifacts,
+	"metrics_tables":        authz.EntityTypeArtifacts,
+	"unreachable_artifacts": authz.EntityTypeArtifacts,
+	"experiments":           authz.EntityTypeExperiments,
+	"experiment":            authz.EntityTypeExperiments,
+	"experiment_runs":       authz.EntityTypeExperimentRuns,
+	"experiment_run":        authz.EntityTypeExperimentRuns,
+	"metric_history":        authz.EntityTypeExperimentRuns,
+	"serving_environments":  authz.EntityTypeServingEnvironments,
+	"serving_environment":   authz.EntityTypeServingEnvironments,
+	"inference_services":    authz.EntityTypeInferenceServices,
+	"inference_service":     authz.EntityTypeInferenceServices,
+	"deployments":           authz.EntityTypeInferenceServices,
+}
+
+// RequiredPermission returns the verb and entity type of the permission required by an api request: the entity type
+// of the deepest collection of the path, read for GET requests and the reads with POST, admin for DELETE requests and
+// the reviews of promotion runs, write otherwise. The verb is empty outside of the api.
+func RequiredPermission(method string, path string) (verb string, entityType string) {
+	rest, ok := strings.CutPrefix(path, apiBasePath)
+	if !ok {
+		return "", ""
+	}
+
+	entityType, collection, action := authz.EntityTypeRegistry, "", ""
+	for segment := range strings.SplitSeq(rest, "/") {
+		segment, action, _ = strings.Cut(segment, ":")
+		if t, ok := permissionEntityTypes[segment]; ok {
+			entityType, collection = t, segment
+		}
+	}
+
+	switch {
+	case method == http.MethodGet || method == http.MethodHead || slices.Contains(readActions, action) || slices.Contains(readEndpoints, rest):
+		return authz.VerbRead, entityType
+	case method == http.MethodDelete || (collection == "promotion_runs" && slices.Contains(reviewActions, action)):
+		return authz.VerbAdmin, entityType
+	}
+	return authz.VerbWrite, entityType
+}
+
+// Authorization checks the permissions of the callers of the api on the entity types of their namespace.
+type Authorization struct {
+	Checker authz.PermissionChecker
+	// UserHeader and GroupsHeader name the caller of the requests without an api token, set by the authenticating
+	// proxy in front of the registry which must drop them from the requests of the clients. The groups are comma
+	// separated or repeated.
+	UserHeader   string
+	GroupsHeader string
+	// Namespace is the namespace of the permissions of the requests of no tenant.
+	Namespace string
+}
+
+// Authorize rejects the api requests whose caller lacks the permission required by the request in the namespace of
+// the request, with 403, before they reach the repositories. The caller is the api token of the request, by its name,
+// else the user and groups of the headers; the requests of neither are rejected with 401. The admin token is granted
+// all the permissions.
+func Authorize(authorization Authorization, tokens *APITokens, isAdmin func(r *http.Request) bool, next http.Handler) http.Handler {
+	if authorization.Checker == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verb, entityType := RequiredPermission(r.Method, r.URL.Path)
+		// CORS preflight requests don't carry credentials
+		if verb == "" || r.Method == http.MethodOptions || isAdmin(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var subject authz.Subject
+		bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token := tokens.Lookup(bearer); token != nil {
+			subject.User = token.Name
+		} else if authorization.UserHeader != "" {
+			subject.User = r.Header.Get(authorization.UserHeader)
+			if authorization.GroupsHeader != "" {
+				for _, groups := range r.Header.Values(authorization.GroupsHeader) {
+					for group := range strings.SplitSeq(groups, ",") {
+						if group = strings.TrimSpace(group); group != "" {
+							subject.Groups = append(subject.Groups, group)
+						}
+					}
+				}
+			}
+		}
+		if subject.User == "" {
+			writeScopeError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+
+		namespace := api.Namespace(r.Context())
+		if namespace == "" {
+			namespace = authorization.Namespace
+		}
+		permission := authz.Permission{Verb: verb, EntityType: entityType, Namespace: namespace}
+
+		allowed, err := authorization.Checker.Check(r.Context(), subject, permission)
+		if err != nil {
+			glog.Errorf("Error authorizing %s %s of %s: %v", r.Method, r.URL.Path, subject.User, err)
+			writeScopeError(w, http.StatusInternalServerError, "error checking the permissions of the request")
+			return
+		}
+		if !allowed {
+			glog.V(2).Infof("User %s denied %s %s, missing permission %s", subject.User, r.Method, r.URL.Path, permission)
+			writeScopeError(w, http.StatusForbidden, fmt.Sprintf("%s lacks the permission to %s", subject.User, permission))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
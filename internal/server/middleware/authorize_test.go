@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/authz"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredPermission(t *testing.T) {
+	for _, tc := range []struct {
+		method     string
+		path       string
+		verb       string
+		entityType string
+	}{
+		{http.MethodGet, "/api/model_registry/v1alpha3/registered_models", authz.VerbRead, authz.EntityTypeRegisteredModels},
+		{http.MethodPost, "/api/model_registry/v1alpha3/registered_models", authz.VerbWrite, authz.EntityTypeRegisteredModels},
+		{http.MethodDelete, "/api/model_registry/v1alpha3/registered_models/1", authz.VerbAdmin, authz.EntityTypeRegisteredModels},
+		{http.MethodGet, "/api/model_registry/v1alpha3/registered_models/1/versions:byName", authz.VerbRead, authz.EntityTypeModelVersions},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions:batchGet", authz.VerbRead, authz.EntityTypeModelVersions},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions/2/artifacts", authz.VerbWrite, authz.EntityTypeArtifacts},
+		{http.MethodPost, "/api/model_registry/v1alpha3/experiment_runs/3/metric_history", authz.VerbWrite, authz.EntityTypeExperimentRuns},
+		{http.MethodGet, "/api/model_registry/v1alpha3/experiments/3/runs:aggregate", authz.VerbRead, authz.EntityTypeExperiments},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions/2/deployments", authz.VerbWrite, authz.EntityTypeInferenceServices},
+		{http.MethodPost, "/api/model_registry/v1alpha3/promotion_runs/7:approve", authz.VerbAdmin, authz.EntityTypeModelVersions},
+		{http.MethodPost, "/api/model_registry/v1alpha3/resolve", authz.VerbRead, authz.EntityTypeRegistry},
+		{http.MethodPost, "/api/model_registry/v1alpha3/exports", authz.VerbRead, authz.EntityTypeRegistry},
+		{http.MethodPost, "/api/model_registry/v1alpha3/imports", authz.VerbWrite, authz.EntityTypeRegistry},
+		{http.MethodGet, "/api/model_registry/v1alpha3/recycle_bin", authz.VerbRead, authz.EntityTypeRegistry},
+		{http.MethodDelete, "/api/model_registry/v1alpha3/recycle_bin/model_versions/2", authz.VerbAdmin, authz.EntityTypeModelVersions},
+		{http.MethodDelete, "/api/model_registry/v1alpha3/tags/team-nlp", authz.VerbAdmin, authz.EntityTypeRegistry},
+		{http.MethodGet, "/readyz/health", "", ""},
+	} {
+		verb, entityType := RequiredPermission(tc.method, tc.path)
+		assert.Equal(t, tc.verb, verb, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.entityType, entityType, "%s %s", tc.method, tc.path)
+	}
+}
+
+type checkerFunc func(ctx context.Context, subject authz.Subject, permission authz.Permission) (bool, error)
+
+func (f checkerFunc) Check(ctx context.Context, subject authz.Subject, permission authz.Permission) (bool, error) {
+	return f(ctx, subject, permission)
+}
+
+func TestAuthorize(t *testing.T) {
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "ci", Token: "ci", Scopes: []string{"*"}},
+	}})
+	require.NoError(t, err)
+
+	var (
+		subject    authz.Subject
+		permission authz.Permission
+	)
+	checker := checkerFunc(func(_ context.Context, s authz.Subject, p authz.Permission) (bool, error) {
+		subject, permission = s, p
+		if s.User == "broken" {
+			return false, errors.New("unavailable")
+		}
+		return s.User == "ci" || p.Verb == authz.VerbRead || p.Namespace == "team-a", nil
+	})
+	handler := Authorize(Authorization{
+		Checker:      checker,
+		UserHeader:   "X-Remote-User",
+		GroupsHeader: "X-Remote-Group",
+		Namespace:    "kubeflow",
+	}, tokens, IsAdmin("admin"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(r *http.Request, token string, user string, groups ...string) *httptest.ResponseRecorder {
+		subject, permission = authz.Subject{}, authz.Permission{}
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if user != "" {
+			r.Header.Set("X-Remote-User", user)
+		}
+		for _, group := range groups {
+			r.Header.Add("X-Remote-Group", group)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+	const path = "/api/model_registry/v1alpha3/registered_models/1"
+
+	// the users of the headers, in the namespace of the registry without tenant
+	require.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, path, nil), "", "alice", "team-a,ml", "ops").Code)
+	assert.Equal(t, authz.Subject{User: "alice", Groups: []string{"team-a", "ml", "ops"}}, subject)
+	assert.Equal(t, authz.Permission{Verb: authz.VerbRead, EntityType: authz.EntityTypeRegisteredModels, Namespace: "kubeflow"}, permission)
+
+	w := serve(httptest.NewRequest(http.MethodDelete, path, nil), "", "alice")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "alice lacks the permission to admin registeredmodels in namespace kubeflow")
+
+	// the namespace of the tenant
+	r := httptest.NewRequest(http.MethodDelete, path, nil)
+	require.Equal(t, http.StatusOK, serve(r.WithContext(api.WithNamespace(r.Context(), "team-a")), "", "alice").Code)
+	assert.Equal(t, "team-a", permission.Namespace)
+
+	// the api tokens by name, over the headers
+	require.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodPatch, path, nil), "ci", "alice").Code)
+	assert.Equal(t, "ci", subject.User)
+
+	// the admin token is not checked
+	require.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodDelete, path, nil), "admin", "").Code)
+	assert.Empty(t, subject.User)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(httptest.NewRequest(http.MethodGet, path, nil), "unknown", "").Code)
+	assert.Equal(t, http.StatusInternalServerError, serve(httptest.NewRequest(http.MethodGet, path, nil), "", "broken").Code)
+	assert.Equal(t, http.StatusOK, serve(httptest.NewRequest(http.MethodGet, "/readyz/health", nil), "", "").Code)
+
+	// without checker the requests are not authorized
+	w = httptest.NewRecorder()
+	Authorize(Authorization{}, tokens, IsAdmin(""), http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
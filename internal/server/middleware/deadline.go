@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/budget"
+)
+
+// RequestTimeoutHeader is the header of the timeout requested by a client, as a duration, e.g. 2.5s, or seconds.
+const RequestTimeoutHeader = "X-Request-Timeout"
+
+// RequestLimits bounds the time and the queries of each api request.
+type RequestLimits struct {
+	// MaxTimeout caps the timeouts requested by the clients and applies to the requests without one, 0 is unbounded.
+	MaxTimeout time.Duration
+	// Budget bounds the SQL statements run and the rows read by a request.
+	Budget budget.Limits
+}
+
+// Enabled reports whether the server bounds the requests, clients can request timeouts regardless.
+func (l RequestLimits) Enabled() bool {
+	return l.MaxTimeout > 0 || l.Budget.Enabled()
+}
+
+// LimitRequests runs the api requests with the deadline of their requested timeout, capped by the maximum timeout, and
+// with a fresh query budget. The queries of a request fail once its deadline passes, with 504, or once its budget is
+// spent, with 413. Invalid timeouts are rejected with 400.
+func LimitRequests(limits RequestLimits, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		timeout := limits.MaxTimeout
+		if header := r.Header.Get(RequestTimeoutHeader); header != "" {
+			requested, err := parseTimeout(header)
+			if err != nil {
+				returnValidationError(w, fmt.Sprintf("invalid %s header: %v", RequestTimeoutHeader, err))
+				return
+			}
+			if timeout <= 0 || requested < timeout {
+				timeout = requested
+			}
+		}
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		if limits.Budget.Enabled() {
+			ctx = budget.NewContext(ctx, budget.New(limits.Budget))
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func parseTimeout(value string) (time.Duration, error) {
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		// plain seconds, as in the timeoutSeconds parameters
+		seconds, parseErr := strconv.ParseFloat(value, 64)
+		if parseErr != nil {
+			return 0, err
+		}
+		timeout = time.Duration(seconds * float64(time.Second))
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return timeout, nil
+}
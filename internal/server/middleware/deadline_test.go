@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/budget"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitRequests(t *testing.T) {
+	serve := func(limits RequestLimits, timeout string) (*httptest.ResponseRecorder, time.Duration, *budget.Budget) {
+		var remaining time.Duration
+		var requestBudget *budget.Budget
+		handler := LimitRequests(limits, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if deadline, ok := r.Context().Deadline(); ok {
+				remaining = time.Until(deadline)
+			}
+			requestBudget, _ = budget.FromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		r := httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil)
+		if timeout != "" {
+			r.Header.Set(RequestTimeoutHeader, timeout)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w, remaining, requestBudget
+	}
+
+	// requested timeouts are capped by the maximum timeout
+	limits := RequestLimits{MaxTimeout: 10 * time.Second, Budget: budget.Limits{Statements: 100}}
+	w, remaining, requestBudget := serve(limits, "2s")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.InDelta(t, 2*time.Second, remaining, float64(time.Second))
+	assert.NotNil(t, requestBudget)
+
+	_, remaining, _ = serve(limits, "60")
+	assert.InDelta(t, 10*time.Second, remaining, float64(time.Second))
+
+	_, remaining, _ = serve(limits, "")
+	assert.InDelta(t, 10*time.Second, remaining, float64(time.Second))
+
+	// without limits only the requested timeouts apply
+	_, remaining, requestBudget = serve(RequestLimits{}, "")
+	assert.Zero(t, remaining)
+	assert.Nil(t, requestBudget)
+
+	_, remaining, _ = serve(RequestLimits{}, "1.5")
+	assert.InDelta(t, 1500*time.Millisecond, remaining, float64(time.Second))
+
+	for _, timeout := range []string{"soon", "-1s", "0"} {
+		w, _, _ = serve(limits, timeout)
+		assert.Equal(t, http.StatusBadRequest, w.Code, timeout)
+	}
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+)
+
+// versionedCollections are the api collections of the versioned entities, by the table of their entities.
+var versionedCollections = map[string]string{
+	"registered_models":    models.VersionedContext,
+	"model_versions":       models.VersionedContext,
+	"serving_environments": models.VersionedContext,
+	"inference_services":   models.VersionedContext,
+	"experiments":          models.VersionedContext,
+	"experiment_runs":      models.VersionedContext,
+	"artifacts":            models.VersionedArtifact,
+	"model_artifacts":      models.VersionedArtifact,
+}
+
+// ETags sets the ETag header of the responses for a versioned entity to its version, and applies the writes of the
+// requests with an If-Match header only to the version of the entity it names: the entities updated since the editor
+// read them are left unchanged and the request is rejected with 412. The entities served from the cache of the
+// registry have the version cached with them.
+func ETags(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		table, id, ok := versionedEntity(r.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, versions := models.WithEntityVersions(r.Context())
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			version, ok := parseETag(ifMatch)
+			if !ok {
+				writeScopeError(w, http.StatusPreconditionFailed, fmt.Sprintf("the If-Match header %s is not an entity version", ifMatch))
+				return
+			}
+			ctx = models.WithIfMatch(ctx, models.EntityVersion{Table: table, ID: id, Version: version})
+		}
+
+		next.ServeHTTP(&etagWriter{ResponseWriter: w, versions: versions, table: table, id: id}, r.WithContext(ctx))
+	})
+}
+
+// versionedEntity returns the table and id of the versioned entity of an api path, as
+// /api/model_registry/v1alpha3/model_versions/{id}.
+func versionedEntity(path string) (string, int32, bool) {
+	rest, ok := strings.CutPrefix(path, "/api/model_registry/v1alpha3/")
+	if !ok {
+		return "", 0, false
+	}
+	collection, id, ok := strings.Cut(strings.TrimSuffix(rest, "/"), "/")
+	if !ok {
+		return "", 0, false
+	}
+	table, ok := versionedCollections[collection]
+	if !ok {
+		return "", 0, false
+	}
+	parsed, err := strconv.ParseInt(id, 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return table, int32(parsed), true
+}
+
+// formatETag returns the strong ETag of a version.
+func formatETag(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// parseETag returns the version of a strong ETag, weak ETags never match for the If-Match header.
+func parseETag(etag string) (int32, bool) {
+	unquoted, ok := strings.CutPrefix(strings.TrimSpace(etag), `"`)
+	if !ok {
+		return 0, false
+	}
+	unquoted, ok = strings.CutSuffix(unquoted, `"`)
+	if !ok {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(version), true
+}
+
+// etagWriter sets the ETag header of the successful responses to the version of the entity last read or saved by the
+// handler.
+type etagWriter struct {
+	http.ResponseWriter
+	versions    *models.EntityVersions
+	table       string
+	id          int32
+	wroteHeader bool
+}
+
+func (w *etagWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if version, ok := w.versions.Get(w.table, w.id); ok && code >= 200 && code < 300 {
+			w.Header().Set("ETag", formatETag(version))
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// Flush flushes the underlying writer, if it can.
+func (w *etagWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *etagWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
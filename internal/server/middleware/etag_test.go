@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETags(t *testing.T) {
+	// the handler reads the entity at version 3, or saves it at version 4 if its update applies to version 3
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version := int32(3)
+		if expected, ok := models.IfMatch(r.Context()); ok {
+			if expected.Version != version {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			version++
+		}
+		models.RecordEntityVersion(r.Context(), models.EntityVersion{Table: models.VersionedContext, ID: 7, Version: version})
+		models.RecordEntityVersion(r.Context(), models.EntityVersion{Table: models.VersionedArtifact, ID: 7, Version: 42})
+		_, _ = w.Write([]byte(`{"id":"7"}`))
+	})
+
+	serve := func(method string, path string, ifMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/api/model_registry/v1alpha3/"+path, nil)
+		if ifMatch != "" {
+			r.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		ETags(handler).ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(http.MethodGet, "registered_models/7", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	w = serve(http.MethodPatch, "model_versions/7", `"3"`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+
+	w = serve(http.MethodPatch, "model_versions/7", `"2"`)
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+
+	// the ids of the artifacts are those of their own table
+	w = serve(http.MethodGet, "model_artifacts/7", "")
+	assert.Equal(t, `"42"`, w.Header().Get("ETag"))
+
+	// any version, and the versions of reads, are not checked
+	assert.Equal(t, `"3"`, serve(http.MethodPatch, "registered_models/7", "*").Header().Get("ETag"))
+	assert.Equal(t, `"3"`, serve(http.MethodGet, "registered_models/7", `"2"`).Header().Get("ETag"))
+
+	// weak or foreign ETags never match
+	for _, ifMatch := range []string{`W/"3"`, `3`, `"abc"`} {
+		w = serve(http.MethodPatch, "registered_models/7", ifMatch)
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code, ifMatch)
+	}
+
+	// the responses not of a single versioned entity have no ETag
+	for _, path := range []string{"registered_models", "registered_models/7/versions", "serve_models/7", "registered_models/abc"} {
+		assert.Empty(t, serve(http.MethodGet, path, "").Header().Get("ETag"), path)
+	}
+}
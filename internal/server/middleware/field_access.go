@@ -0,0 +1,243 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// FieldAccessRule restricts a field of the entities to the api tokens with one of the roles.
+type FieldAccessRule struct {
+	// Field is the JSON name of a field of the entities, e.g. uri, or customProperties.<name> for a custom property.
+	Field string   `json:"field"`
+	Roles []string `json:"roles"`
+}
+
+// FieldAccessConfig is the content of the field access file, e.g.
+//
+//	fields:
+//	  - field: customProperties.cost
+//	    roles: [finance]
+//	  - field: uri
+//	    roles: [platform, finance]
+type FieldAccessConfig struct {
+	Fields []FieldAccessRule `json:"fields"`
+}
+
+// LoadFieldAccess reads a field access file.
+func LoadFieldAccess(path string) (*FieldAccessConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading field access file: %w", err)
+	}
+	var config FieldAccessConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing field access file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// FieldAccess tells the restricted fields redacted for the roles of a caller.
+type FieldAccess struct {
+	rules []FieldAccessRule
+}
+
+// NewFieldAccess validates the rules of config.
+func NewFieldAccess(config *FieldAccessConfig) (*FieldAccess, error) {
+	fields := map[string]bool{}
+	for i, rule := range config.Fields {
+		name, _ := strings.CutPrefix(rule.Field, converter.CustomPropertyFieldPrefix)
+		switch {
+		case name == "" || strings.ContainsAny(name, " ."):
+			return nil, fmt.Errorf("invalid field access rule %d: field %q must be a field name or customProperties.<name>", i, rule.Field)
+		case rule.Field == "id":
+			return nil, fmt.Errorf("invalid field access rule %d: the ids of the entities can't be restricted", i)
+		case fields[rule.Field]:
+			return nil, fmt.Errorf("invalid field access rule %d: duplicate field %s", i, rule.Field)
+		case len(rule.Roles) == 0 || slices.Contains(rule.Roles, ""):
+			return nil, fmt.Errorf("invalid field access rule %s: at least one role is required", rule.Field)
+		}
+		fields[rule.Field] = true
+	}
+	return &FieldAccess{rules: config.Fields}, nil
+}
+
+// Redacted returns the restricted fields none of the roles grants.
+func (a *FieldAccess) Redacted(roles []string) []string {
+	var redacted []string
+	for _, rule := range a.rules {
+		if !slices.ContainsFunc(rule.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+			redacted = append(redacted, rule.Field)
+		}
+	}
+	return redacted
+}
+
+// RedactFields removes the restricted fields from the api responses of the callers without one of their roles, in
+// single entities, lists, searches and watches alike, and keeps them out of the lineage exports. The roles are those
+// of the api token of the request: the requests without a known token have none, while the admin token sees all the
+// fields. The requests filtering or ordering by a redacted field are rejected with 403 so that its values can't be
+// inferred from the results.
+func RedactFields(access *FieldAccess, tokens *APITokens, isAdmin func(r *http.Request) bool, next http.Handler) http.Handler {
+	if access == nil || len(access.rules) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isAdmin(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var roles []string
+		if tokens != nil {
+			bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if token := tokens.tokens[hashToken(bearer)]; ok && token != nil {
+				roles = token.Roles
+			}
+		}
+
+		redacted := access.Redacted(roles)
+		if len(redacted) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if field, ok := queriedField(r, redacted); ok {
+			writeScopeError(w, http.StatusForbidden, fmt.Sprintf("the %s field is restricted, it can't be filtered or ordered by", field))
+			return
+		}
+
+		rw := &redactingWriter{ResponseWriter: w, code: http.StatusOK, fields: redacted}
+		next.ServeHTTP(rw, r.WithContext(converter.WithRedactedFields(r.Context(), redacted)))
+		if err := rw.finish(); err != nil {
+			glog.Errorf("Error redacting the response of %s: %v", r.URL.Path, err)
+		}
+	})
+}
+
+// queriedField returns the redacted field the filterQuery or orderBy of the request refers to, if any. Custom
+// properties are referred to by their name, possibly with a value type, e.g. cost.double_value.
+func queriedField(r *http.Request, redacted []string) (string, bool) {
+	query := r.URL.Query()
+
+	var properties []string
+	if orderBy := query.Get("orderBy"); orderBy != "" {
+		properties = append(properties, strings.TrimPrefix(orderBy, converter.CustomPropertyFieldPrefix))
+	}
+	if expr, err := filter.Parse(query.Get("filterQuery")); err == nil {
+		properties = append(properties, filterProperties(expr)...)
+	}
+
+	for _, field := range redacted {
+		name := strings.TrimPrefix(field, converter.CustomPropertyFieldPrefix)
+		for _, property := range properties {
+			if slices.Contains(strings.Split(property, "."), name) {
+				return field, true
+			}
+		}
+	}
+	return "", false
+}
+
+func filterProperties(expr *filter.FilterExpression) []string {
+	if expr == nil {
+		return nil
+	}
+	if expr.IsLeaf {
+		return []string{expr.Property}
+	}
+	return append(filterProperties(expr.Left), filterProperties(expr.Right)...)
+}
+
+// redactingWriter holds back a response until the handler returns to redact it, unless the handler flushes it: the
+// writes after a flush, e.g. the changes of a watch each written at once, are then redacted one by one.
+type redactingWriter struct {
+	http.ResponseWriter
+	buf       bytes.Buffer
+	code      int
+	streaming bool
+	fields    []string
+}
+
+func (w *redactingWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.code = code
+}
+
+func (w *redactingWriter) Write(data []byte) (int, error) {
+	if !w.streaming {
+		return w.buf.Write(data)
+	}
+	redacted, err := w.redact(data)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := w.ResponseWriter.Write(redacted); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Flush writes the redacted response held back so far and the next writes redacted.
+func (w *redactingWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		if err := w.writeHeld(); err != nil {
+			glog.Errorf("Error redacting a streamed response: %v", err)
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *redactingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// finish writes the redacted response held back, if the handler didn't stream it.
+func (w *redactingWriter) finish() error {
+	if w.streaming {
+		return nil
+	}
+	return w.writeHeld()
+}
+
+func (w *redactingWriter) writeHeld() error {
+	body, err := w.redact(w.buf.Bytes())
+	w.buf.Reset()
+	if err != nil {
+		// the unredacted body is never sent
+		w.ResponseWriter.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.code)
+	_, err = w.ResponseWriter.Write(body)
+	return err
+}
+
+// redact redacts the JSON documents, the other documents are redacted by their handlers.
+func (w *redactingWriter) redact(data []byte) ([]byte, error) {
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") || len(bytes.TrimSpace(data)) == 0 {
+		return data, nil
+	}
+	redacted, err := converter.RedactJSON(data, w.fields)
+	if err != nil {
+		return nil, errors.Join(errors.New("unable to redact the restricted fields"), err)
+	}
+	return redacted, nil
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFieldAccess(t *testing.T) {
+	for config, message := range map[*FieldAccessConfig]string{
+		{Fields: []FieldAccessRule{{Field: "", Roles: []string{"finance"}}}}:                                                     "must be a field name",
+		{Fields: []FieldAccessRule{{Field: "customProperties.a.b", Roles: []string{"finance"}}}}:                                 "must be a field name",
+		{Fields: []FieldAccessRule{{Field: "id", Roles: []string{"finance"}}}}:                                                   "ids of the entities",
+		{Fields: []FieldAccessRule{{Field: "uri"}}}:                                                                              "at least one role",
+		{Fields: []FieldAccessRule{{Field: "uri", Roles: []string{"a"}}, {Field: "uri", Roles: []string{"b"}}}}:                  "duplicate field uri",
+		{Fields: []FieldAccessRule{{Field: "customProperties.cost", Roles: []string{"a"}}, {Field: "uri", Roles: []string{""}}}}: "at least one role",
+	} {
+		_, err := NewFieldAccess(config)
+		assert.ErrorContains(t, err, message)
+	}
+
+	access, err := NewFieldAccess(&FieldAccessConfig{Fields: []FieldAccessRule{
+		{Field: "customProperties.cost", Roles: []string{"finance"}},
+		{Field: "uri", Roles: []string{"platform", "finance"}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customProperties.cost", "uri"}, access.Redacted(nil))
+	assert.Equal(t, []string{"customProperties.cost"}, access.Redacted([]string{"platform"}))
+	assert.Empty(t, access.Redacted([]string{"finance"}))
+}
+
+func TestRedactFields(t *testing.T) {
+	access, err := NewFieldAccess(&FieldAccessConfig{Fields: []FieldAccessRule{
+		{Field: "customProperties.cost", Roles: []string{"finance"}},
+		{Field: "uri", Roles: []string{"platform"}},
+	}})
+	require.NoError(t, err)
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "finance", Token: "finance", Scopes: []string{"*:read"}, Roles: []string{"finance"}},
+		{Name: "ci", Token: "ci", Scopes: []string{"*:read"}},
+	}})
+	require.NoError(t, err)
+
+	const artifact = `{"id":"1","uri":"s3://internal/model","customProperties":{"cost":{"double_value":12.5},"team":{"string_value":"risk"}}}`
+	var redactedFields []string
+	handler := RedactFields(access, tokens, IsAdmin("admin"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redactedFields = converter.RedactedFields(r.Context())
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if r.URL.Path == "/watch" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"type":"ADDED","entity":` + artifact + "}\n"))
+			require.NoError(t, http.NewResponseController(w).Flush())
+			_, _ = w.Write([]byte(`{"type":"MODIFIED","entity":` + artifact + "}\n"))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"items":[` + artifact + `],"size":1}`))
+	}))
+
+	serve := func(path string, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("without a role", func(t *testing.T) {
+		w := serve("/artifacts", "ci")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, `{"items":[{"id":"1","customProperties":{"team":{"string_value":"risk"}}}],"size":1}`, w.Body.String())
+		assert.Equal(t, []string{"customProperties.cost", "uri"}, redactedFields)
+
+		// anonymous callers have no role
+		assert.JSONEq(t, w.Body.String(), serve("/artifacts", "").Body.String())
+	})
+
+	t.Run("with a role", func(t *testing.T) {
+		w := serve("/artifacts", "finance")
+		assert.JSONEq(t, `{"items":[{"id":"1","customProperties":{"cost":{"double_value":12.5},"team":{"string_value":"risk"}}}],"size":1}`, w.Body.String())
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		redactedFields = nil
+		w := serve("/artifacts", "admin")
+		assert.JSONEq(t, `{"items":[`+artifact+`],"size":1}`, w.Body.String())
+		assert.Empty(t, redactedFields)
+	})
+
+	t.Run("streamed", func(t *testing.T) {
+		w := serve("/watch", "finance")
+		decoder := json.NewDecoder(w.Body)
+		for _, changeType := range []string{"ADDED", "MODIFIED"} {
+			var change map[string]any
+			require.NoError(t, decoder.Decode(&change))
+			assert.Equal(t, changeType, change["type"])
+			assert.NotContains(t, change["entity"], "uri")
+		}
+	})
+
+	t.Run("filtered or ordered by a redacted field", func(t *testing.T) {
+		for _, query := range []string{
+			"filterQuery=cost+%3E+10",
+			"filterQuery=name+%3D+%22a%22+AND+cost.double_value+%3E+10",
+			"orderBy=customProperties.cost.double_value",
+			"orderBy=uri",
+		} {
+			assert.Equal(t, http.StatusForbidden, serve("/artifacts?"+query, "ci").Code, query)
+		}
+		assert.Equal(t, http.StatusCreated, serve("/artifacts?filterQuery=cost+%3E+10", "finance").Code)
+		assert.Equal(t, http.StatusCreated, serve("/artifacts?filterQuery=name+%3D+%22cost%22", "ci").Code)
+	})
+}
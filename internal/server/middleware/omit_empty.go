@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/converter"
+)
+
+// OmitEmptyHeader is the header of the clients asking for responses without null fields and empty properties and
+// customProperties objects, when set to true.
+const OmitEmptyHeader = "X-Omit-Empty"
+
+// OmitEmpty removes the null fields and the empty properties and customProperties objects from the JSON responses of
+// the requests with the OmitEmptyHeader, shrinking the lists of entities with sparse metadata. Streamed responses,
+// which are flushed, are written unchanged.
+func OmitEmpty(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if omit, _ := strconv.ParseBool(r.Header.Get(OmitEmptyHeader)); !omit {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bw := &bufferedWriter{ResponseWriter: w, code: http.StatusOK}
+		next.ServeHTTP(bw, r)
+		if bw.streaming {
+			return
+		}
+
+		body := bw.buf.Bytes()
+		if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") && len(body) > 0 {
+			if compacted, err := converter.OmitEmptyJSON(body); err != nil {
+				glog.Warningf("Error omitting the empty fields of the response of %s: %v", r.URL.Path, err)
+			} else {
+				body = compacted
+			}
+		}
+
+		w.Header().Del("Content-Length")
+		w.WriteHeader(bw.code)
+		_, _ = w.Write(body)
+	})
+}
+
+// bufferedWriter holds back a response until the handler returns, unless the handler flushes it.
+type bufferedWriter struct {
+	http.ResponseWriter
+	buf       bytes.Buffer
+	code      int
+	streaming bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(code)
+		return
+	}
+	w.code = code
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buf.Write(data)
+}
+
+// Flush writes the response held back so far and the next writes unchanged.
+func (w *bufferedWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		w.ResponseWriter.WriteHeader(w.code)
+		_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOmitEmpty(t *testing.T) {
+	serve := func(omit string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil)
+		if omit != "" {
+			r.Header.Set(OmitEmptyHeader, omit)
+		}
+		w := httptest.NewRecorder()
+		OmitEmpty(handler).ServeHTTP(w, r)
+		return w
+	}
+
+	list := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"1","customProperties":{},"description":null}` + "\n"))
+	}
+
+	w := serve("true", list)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+
+	// responses are unchanged without the header
+	w = serve("", list)
+	assert.JSONEq(t, `{"id":"1","customProperties":{},"description":null}`, w.Body.String())
+	w = serve("false", list)
+	assert.JSONEq(t, `{"id":"1","customProperties":{},"description":null}`, w.Body.String())
+
+	// streamed responses are written as they are flushed
+	w = serve("true", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"customProperties":{}}` + "\n"))
+		assert.NoError(t, http.NewResponseController(w).Flush())
+		_, _ = w.Write([]byte(`{"type":"BOOKMARK"}` + "\n"))
+	})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, w.Flushed)
+	assert.Equal(t, `{"customProperties":{}}`+"\n"+`{"type":"BOOKMARK"}`+"\n", w.Body.String())
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/kubeflow/model-registry/internal/server/openapi"
+	"github.com/kubeflow/model-registry/pkg/api"
 )
 
 // WrapWithValidation wraps the auto-generated router with custom validation middleware
@@ -14,3 +15,47 @@ func WrapWithValidation(routers ...openapi.Router) http.Handler {
 	// Wrap it with our custom validation middleware
 	return ValidationMiddleware(baseRouter)
 }
+
+// NewModelRegistryHandler returns the handler of the model registry REST API served by the proxy server, omitting the
+// empty fields of the responses as requested with the OmitEmptyHeader and with the versions of the entities as ETags
+func NewModelRegistryHandler(service api.ModelRegistryApi) http.Handler {
+	return ETags(OmitEmpty(WrapWithValidation(modelRegistryRouters(service)...)))
+}
+
+// modelRegistryRouters returns the routers of the generated and the hand-written controllers of the model registry
+// REST API, all declared in api/openapi/model-registry.yaml.
+func modelRegistryRouters(service api.ModelRegistryApi) []openapi.Router {
+	ModelRegistryServiceAPIService := openapi.NewModelRegistryServiceAPIService(service)
+	ModelRegistryServiceAPIController := openapi.NewModelRegistryServiceAPIController(ModelRegistryServiceAPIService)
+
+	return []openapi.Router{
+		openapi.NewExtendedModelRegistryServiceAPIController(ModelRegistryServiceAPIController),
+		openapi.NewModelVersionPolicyAPIController(service),
+		openapi.NewModelVersionResourcesAPIController(service),
+		openapi.NewBatchGetAPIController(service),
+		openapi.NewBatchCreateAPIController(service),
+		openapi.NewByNameAPIController(service),
+		openapi.NewExternalIdAPIController(service),
+		openapi.NewResolveAPIController(service),
+		openapi.NewPromotionAPIController(service),
+		openapi.NewArtifactVariantAPIController(service),
+		openapi.NewDeployableAPIController(service),
+		openapi.NewConversionJobAPIController(service),
+		openapi.NewDeploymentAPIController(service),
+		openapi.NewStageTransitionAPIController(service),
+		openapi.NewTagAPIController(service),
+		openapi.NewArchiveAPIController(service),
+		openapi.NewABTestAPIController(service),
+		openapi.NewLineageAPIController(service),
+		openapi.NewMetricsTableAPIController(service),
+		openapi.NewMetricAggregateAPIController(service),
+		openapi.NewExperimentMetricSchemaAPIController(service),
+		openapi.NewModelCardAPIController(service),
+		openapi.NewArtifactReachabilityAPIController(service),
+		openapi.NewArtifactReferenceAPIController(service),
+		openapi.NewPropertyValuesAPIController(service),
+		openapi.NewWatchAPIController(service),
+		openapi.NewAuditEventAPIController(service),
+		openapi.NewSnapshotAPIController(service),
+	}
+}
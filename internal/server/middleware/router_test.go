@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	specPathLine   = regexp.MustCompile(`^  "?(/api/[^"]+)"?:$`)
+	specMethodLine = regexp.MustCompile(`^    (get|post|put|patch|delete):$`)
+)
+
+// specOperations returns the operations of the OpenAPI spec, as METHOD path.
+func specOperations(t *testing.T, file string) map[string]bool {
+	f, err := os.Open(file)
+	require.NoError(t, err)
+	defer f.Close()
+
+	operations := map[string]bool{}
+	path := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := specPathLine.FindStringSubmatch(line); match != nil {
+			path = match[1]
+		} else if !strings.HasPrefix(line, "  ") {
+			path = ""
+		} else if match := specMethodLine.FindStringSubmatch(line); match != nil && path != "" {
+			operations[strings.ToUpper(match[1])+" "+path] = true
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return operations
+}
+
+func TestRoutesDeclaredInSpec(t *testing.T) {
+	operations := specOperations(t, "../../../api/openapi/model-registry.yaml")
+	require.NotEmpty(t, operations)
+
+	for _, router := range modelRegistryRouters(nil) {
+		for name, route := range router.Routes() {
+			assert.True(t, operations[route.Method+" "+route.Pattern], "route %s %s %s is not declared in the OpenAPI spec", name, route.Method, route.Pattern)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Resources of the API token scopes, a scope is <resource>:<action>, e.g. models:read.
+const (
+	ScopeResourceModels      = "models"
+	ScopeResourceVersions    = "versions"
+	ScopeResourceArtifacts   = "artifacts"
+	ScopeResourceExperiments = "experiments"
+	ScopeResourceServing     = "serving"
+	// ScopeResourceRegistry covers the endpoints spanning entity types, e.g. watch, stats and reports.
+	ScopeResourceRegistry = "registry"
+)
+
+// Actions of the API token scopes, * matches all the resources or actions, e.g. *:read or versions:*.
+const (
+	ScopeActionRead  = "read"
+	ScopeActionWrite = "write"
+	// ScopeActionPromote creates and runs the promotions of model versions, and moves them between stages.
+	ScopeActionPromote = "promote"
+	// ScopeActionApprove approves and rejects the promotion runs pending approval, separately from promote so that
+	// the runs are not approved by the tokens starting them.
+	ScopeActionApprove = "approve"
+	// ScopeActionAdmin grants the /admin endpoints, as registry:admin only and not through wildcards, see AdminScope.
+	ScopeActionAdmin = "admin"
+)
+
+const apiBasePath = "/api/model_registry/v1alpha3/"
+
+// scopeResources maps the collections of the api paths to the resources of their scopes.
+var scopeResources = map[string]string{
+	"registered_models":     ScopeResourceModels,
+	"registered_model":      ScopeResourceModels,
+	"model_versions":        ScopeResourceVersions,
+	"model_version":         ScopeResourceVersions,
+	"versions":              ScopeResourceVersions,
+	"promotions":            ScopeResourceVersions,
+	"promotion_runs":        ScopeResourceVersions,
+	"ab_tests":              ScopeResourceVersions,
+	"stage_transitions":     ScopeResourceVersions,
+	"artifacts":             ScopeResourceArtifacts,
+	"artifact":              ScopeResourceArtifacts,
+	"model_artifacts":       ScopeResourceArtifacts,
+	"model_artifact":        ScopeResourceArtifacts,
+	"conversion_jobs":       ScopeResourceArtifacts,
+	"metrics_tables":        ScopeResourceArtifacts,
+	"unreachable_artifacts": ScopeResourceArtifacts,
+	"experiments":           ScopeResourceExperiments,
+	"experiment":            ScopeResourceExperiments,
+	"experiment_runs":       ScopeResourceExperiments,
+	"experiment_run":        ScopeResourceExperiments,
+	"metric_history":        ScopeResourceExperiments,
+	"serving_environments":  ScopeResourceServing,
+	"serving_environment":   ScopeResourceServing,
+	"inference_services":    ScopeResourceServing,
+	"inference_service":     ScopeResourceServing,
+	"deployments":           ScopeResourceServing,
+}
+
+// readActions are the custom methods reading entities with POST.
+var readActions = []string{"batchGet"}
+
+// readEndpoints are the endpoints reading entities of any type with POST.
+var readEndpoints = []string{"resolve", "exports"}
+
+// reviewActions are the custom methods reviewing the promotion runs.
+var reviewActions = []string{"approve", "reject"}
+
+// RequiredScope returns the scope required by an api request: the resource of the deepest collection of the path,
+// read for GET requests and the reads with POST, write otherwise, except for promotions and stage transitions which
+// require versions:promote to be written, the reviews of promotion runs which require versions:approve, and the
+// permanent deletions of the recycle bin which require AdminScope.
+func RequiredScope(method string, path string) string {
+	rest, ok := strings.CutPrefix(path, apiBasePath)
+	if !ok {
+		return ""
+	}
+
+	resource, collection, action := ScopeResourceRegistry, "", ""
+	for segment := range strings.SplitSeq(rest, "/") {
+		segment, action, _ = strings.Cut(segment, ":")
+		if r, ok := scopeResources[segment]; ok {
+			resource, collection = r, segment
+		}
+	}
+
+	switch {
+	case method == http.MethodGet || method == http.MethodHead || slices.Contains(readActions, action) || slices.Contains(readEndpoints, rest):
+		return resource + ":" + ScopeActionRead
+	case method == http.MethodDelete && strings.HasPrefix(rest, "recycle_bin/"):
+		return AdminScope
+	case collection == "promotion_runs" && slices.Contains(reviewActions, action):
+		return ScopeResourceVersions + ":" + ScopeActionApprove
+	case collection == "promotions" || collection == "promotion_runs" || action == "transition":
+		return ScopeResourceVersions + ":" + ScopeActionPromote
+	}
+	return resource + ":" + ScopeActionWrite
+}
+
+// APIToken is a bearer token granted scopes on the api.
+type APIToken struct {
+	// Name identifies the token in the logs.
+	Name string `json:"name"`
+	// SHA256 is the hex encoded SHA-256 hash of the token, preferred to Token to keep the token out of the file.
+	SHA256 string   `json:"sha256,omitempty"`
+	Token  string   `json:"token,omitempty"`
+	Scopes []string `json:"scopes"`
+	// Roles grant the fields restricted to them by the field access rules.
+	Roles []string `json:"roles,omitempty"`
+	// Namespace restricts the requests of the token to the entities of the namespace of a tenant, the tokens without
+	// one are not restricted.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// APITokensConfig is the content of the API tokens file, e.g.
+//
+//	tokens:
+//	  - name: ci-metrics
+//	    sha256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+//	    scopes: [experiments:read, experiments:write]
+//	  - name: release
+//	    sha256: 60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752
+//	    scopes: ["*:read", versions:promote]
+//	    roles: [finance]
+//	  - name: release-manager
+//	    sha256: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+//	    scopes: ["*:read", versions:approve]
+//	  - name: team-a
+//	    sha256: 96c2886c51d1dfb4901d9feccff66213ce3e27406282ff6f602a4258a33dacec
+//	    scopes: ["*"]
+//	    namespace: team-a
+type APITokensConfig struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+// LoadAPITokens reads an API tokens file.
+func LoadAPITokens(path string) (*APITokensConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading api tokens file: %w", err)
+	}
+	var config APITokensConfig
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("error parsing api tokens file %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// APITokens authorizes the api requests by the scopes of their bearer token.
+type APITokens struct {
+	// tokens are indexed by their hex encoded SHA-256 hash
+	tokens map[string]*APIToken
+}
+
+// NewAPITokens validates the tokens of config.
+func NewAPITokens(config *APITokensConfig) (*APITokens, error) {
+	tokens := make(map[string]*APIToken, len(config.Tokens))
+	for i := range config.Tokens {
+		token := &config.Tokens[i]
+		if token.Name == "" {
+			return nil, fmt.Errorf("invalid api token %d: name is required", i)
+		}
+
+		hash := strings.ToLower(token.SHA256)
+		switch {
+		case token.Token != "" && hash != "":
+			return nil, fmt.Errorf("invalid api token %s: only one of token and sha256 can be set", token.Name)
+		case token.Token != "":
+			hash = hashToken(token.Token)
+		case len(hash) != sha256.Size*2 || !isHex(hash):
+			return nil, fmt.Errorf("invalid api token %s: sha256 must be a hex encoded SHA-256 hash", token.Name)
+		}
+		if _, ok := tokens[hash]; ok {
+			return nil, fmt.Errorf("invalid api token %s: duplicate token", token.Name)
+		}
+
+		for _, scope := range token.Scopes {
+			if err := validateScope(scope); err != nil {
+				return nil, fmt.Errorf("invalid api token %s: %w", token.Name, err)
+			}
+		}
+		if token.Namespace != "" {
+			if err := validateNamespace(token.Namespace); err != nil {
+				return nil, fmt.Errorf("invalid api token %s: %w", token.Name, err)
+			}
+		}
+
+		tokens[hash] = token
+	}
+
+	return &APITokens{tokens: tokens}, nil
+}
+
+func validateScope(scope string) error {
+	if scope == "*" {
+		return nil
+	}
+	resource, action, ok := strings.Cut(scope, ":")
+	if !ok {
+		return fmt.Errorf("invalid scope %q: must be <resource>:<action>", scope)
+	}
+	switch resource {
+	case "*", ScopeResourceModels, ScopeResourceVersions, ScopeResourceArtifacts, ScopeResourceExperiments,
+		ScopeResourceServing, ScopeResourceRegistry:
+	default:
+		return fmt.Errorf("invalid scope %q: unknown resource %s", scope, resource)
+	}
+	switch action {
+	case "*", ScopeActionRead, ScopeActionWrite, ScopeActionPromote, ScopeActionApprove, ScopeActionAdmin:
+	default:
+		return fmt.Errorf("invalid scope %q: unknown action %s", scope, action)
+	}
+	return nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func isHex(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+// Grants reports whether the token has a scope matching the required scope. AdminScope is granted by itself only, not
+// by the wildcards.
+func (t *APIToken) Grants(required string) bool {
+	if required == AdminScope {
+		return slices.Contains(t.Scopes, AdminScope)
+	}
+	resource, action, _ := strings.Cut(required, ":")
+	for _, scope := range t.Scopes {
+		if scope == "*" {
+			return true
+		}
+		r, a, _ := strings.Cut(scope, ":")
+		if (r == "*" || r == resource) && (a == "*" || a == action) {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether the api requires tokens. APITokens are nil-safe.
+func (t *APITokens) Enabled() bool {
+	return t != nil && len(t.tokens) > 0
+}
+
+// Lookup returns the token of a bearer, or nil for an unknown bearer.
+func (t *APITokens) Lookup(bearer string) *APIToken {
+	if !t.Enabled() || bearer == "" {
+		return nil
+	}
+	return t.tokens[hashToken(bearer)]
+}
+
+// RequireScopes rejects the api requests without a known bearer token, with 401, or whose token lacks the scope
+// required by the request, with 403. Without tokens all the requests are served.
+func RequireScopes(tokens *APITokens, next http.Handler) http.Handler {
+	if !tokens.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		required := RequiredScope(r.Method, r.URL.Path)
+		// CORS preflight requests don't carry credentials
+		if required == "" || r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		token := tokens.tokens[hashToken(bearer)]
+		if !ok || token == nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="model-registry"`)
+			writeScopeError(w, http.StatusUnauthorized, "api token required")
+			return
+		}
+
+		if !token.Grants(required) {
+			glog.V(2).Infof("API token %s denied %s %s, missing scope %s", token.Name, r.Method, r.URL.Path, required)
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="model-registry", error="insufficient_scope", scope=%q`, required))
+			writeScopeError(w, http.StatusForbidden, fmt.Sprintf("api token %s lacks the %s scope", token.Name, required))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeScopeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": http.StatusText(code), "message": message})
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredScope(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/api/model_registry/v1alpha3/registered_models", "models:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/registered_models", "models:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/registered_models/1/versions:byName", "versions:read"},
+		{http.MethodPatch, "/api/model_registry/v1alpha3/model_versions/2", "versions:write"},
+		{http.MethodDelete, "/api/model_registry/v1alpha3/registered_models/1", "models:write"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions:batchGet", "versions:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/registered_models/1/versions:batchCreate", "versions:write"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions/2/artifacts", "artifacts:write"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/experiment_runs/3/metric_history", "experiments:write"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/conversion_jobs/4:complete", "artifacts:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/model_versions/3/metrics_tables", "artifacts:read"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/experiments/3/runs:aggregate", "experiments:read"},
+		{http.MethodPut, "/api/model_registry/v1alpha3/registered_models/1/model_card", "models:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/inference_services/5/model", "serving:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions/2/deployments", "serving:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/promotions", "versions:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/promotions/6/runs", "versions:promote"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/promotion_runs/7:approve", "versions:approve"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/promotion_runs/7:reject", "versions:approve"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/ab_tests/8/results", "versions:write"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/model_versions/2:transition", "versions:promote"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/model_versions/2/stage_transitions", "versions:read"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/watch", "registry:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/resolve", "registry:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/exports", "registry:read"},
+		{http.MethodPost, "/api/model_registry/v1alpha3/imports", "registry:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/recycle_bin", "registry:read"},
+		{http.MethodDelete, "/api/model_registry/v1alpha3/recycle_bin/registered_models/1", "registry:admin"},
+		{http.MethodPut, "/api/model_registry/v1alpha3/tags/team-nlp", "registry:write"},
+		{http.MethodPut, "/api/model_registry/v1alpha3/experiment_runs/3/tags", "experiments:write"},
+		{http.MethodGet, "/api/model_registry/v1alpha3/reports/unreachable_artifacts", "artifacts:read"},
+		{http.MethodGet, "/readyz/health", ""},
+	} {
+		assert.Equal(t, tc.want, RequiredScope(tc.method, tc.path), "%s %s", tc.method, tc.path)
+	}
+}
+
+func TestNewAPITokens(t *testing.T) {
+	for config, message := range map[*APITokensConfig]string{
+		{Tokens: []APIToken{{Token: "secret"}}}:                                                "name is required",
+		{Tokens: []APIToken{{Name: "ci"}}}:                                                     "sha256 must be a hex encoded SHA-256 hash",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret", SHA256: hashToken("a")}}}:            "only one of token and sha256",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret"}, {Name: "cd", Token: "secret"}}}:     "duplicate token",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret", Scopes: []string{"models"}}}}:        "must be <resource>:<action>",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret", Scopes: []string{"runs:read"}}}}:     "unknown resource runs",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret", Scopes: []string{"models:delete"}}}}: "unknown action delete",
+		{Tokens: []APIToken{{Name: "ci", Token: "secret", Namespace: "Team_A"}}}:               "invalid namespace",
+	} {
+		_, err := NewAPITokens(config)
+		assert.ErrorContains(t, err, message)
+	}
+}
+
+func TestRequireScopes(t *testing.T) {
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "ci-metrics", Token: "ci", Scopes: []string{"experiments:*", "*:read"}},
+		{Name: "release", SHA256: hashToken("release"), Scopes: []string{"versions:promote"}},
+		{Name: "root", Token: "root", Scopes: []string{"*"}},
+		{Name: "operator", Token: "operator", Scopes: []string{AdminScope}},
+	}})
+	require.NoError(t, err)
+
+	handler := RequireScopes(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(method string, path string, token string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/model_registry/v1alpha3/experiment_runs/1/metric_history", "ci").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/model_registry/v1alpha3/model_versions/2", "ci").Code)
+
+	w := serve(http.MethodPost, "/api/model_registry/v1alpha3/promotions/3/runs", "ci")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Header().Get("WWW-Authenticate"), `scope="versions:promote"`)
+	assert.Contains(t, w.Body.String(), "api token ci-metrics lacks the versions:promote scope")
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/model_registry/v1alpha3/promotions/3/runs", "release").Code)
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/model_registry/v1alpha3/promotion_runs/4:approve", "release").Code, "promote doesn't grant approve")
+
+	// the permanent deletions of the recycle bin are reserved to the admins
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodDelete, "/api/model_registry/v1alpha3/recycle_bin/registered_models/1", "root").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodDelete, "/api/model_registry/v1alpha3/recycle_bin/registered_models/1", "operator").Code)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", "unknown").Code)
+	assert.Equal(t, http.StatusOK, serve(http.MethodOptions, "/api/model_registry/v1alpha3/registered_models", "").Code)
+
+	// without tokens the api is not authenticated
+	w = httptest.NewRecorder()
+	RequireScopes(nil, http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/watch", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
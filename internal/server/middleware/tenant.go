@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Tenants runs the api requests of a tenant restricted to the entities of its namespace, creating their entities in
+// it: the namespace of their api token if it has one, else the namespace of the header, set by the authenticating
+// proxy in front of the registry which must drop it from the requests of the clients. The requests of no tenant see
+// all the entities. The requests of a token naming another namespace in the header are rejected with 403, the
+// invalid namespaces with 400.
+func Tenants(tokens *APITokens, header string, next http.Handler) http.Handler {
+	if !tokens.Enabled() && header == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace := ""
+		if header != "" {
+			namespace = r.Header.Get(header)
+		}
+
+		bearer, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token := tokens.Lookup(bearer); token != nil && token.Namespace != "" {
+			if namespace != "" && namespace != token.Namespace {
+				glog.V(2).Infof("API token %s denied %s %s in namespace %s", token.Name, r.Method, r.URL.Path, namespace)
+				writeScopeError(w, http.StatusForbidden, fmt.Sprintf("api token %s is restricted to namespace %s", token.Name, token.Namespace))
+				return
+			}
+			namespace = token.Namespace
+		}
+
+		if namespace == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if err := validateNamespace(namespace); err != nil {
+			returnValidationError(w, fmt.Sprintf("invalid %s header: %v", header, err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(api.WithNamespace(r.Context(), namespace)))
+	})
+}
+
+// validateNamespace checks that namespace is a Kubernetes namespace name.
+func validateNamespace(namespace string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenants(t *testing.T) {
+	tokens, err := NewAPITokens(&APITokensConfig{Tokens: []APIToken{
+		{Name: "team-a", Token: "a", Scopes: []string{"*"}, Namespace: "team-a"},
+		{Name: "platform", Token: "platform", Scopes: []string{"*"}},
+	}})
+	require.NoError(t, err)
+
+	var namespace string
+	handler := Tenants(tokens, "X-Forwarded-Namespace", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace = api.Namespace(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(token string, header string) *httptest.ResponseRecorder {
+		namespace = ""
+		r := httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil)
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		if header != "" {
+			r.Header.Set("X-Forwarded-Namespace", header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// the namespace of the token wins, the header can't name another one
+	require.Equal(t, http.StatusOK, serve("a", "").Code)
+	assert.Equal(t, "team-a", namespace)
+	require.Equal(t, http.StatusOK, serve("a", "team-a").Code)
+	assert.Equal(t, "team-a", namespace)
+	w := serve("a", "team-b")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "api token team-a is restricted to namespace team-a")
+
+	// the tokens without namespace are restricted to the namespace of the header, if any
+	require.Equal(t, http.StatusOK, serve("platform", "team-b").Code)
+	assert.Equal(t, "team-b", namespace)
+	require.Equal(t, http.StatusOK, serve("platform", "").Code)
+	assert.Empty(t, namespace)
+	assert.Equal(t, http.StatusBadRequest, serve("platform", "Team B").Code)
+
+	// without tokens nor header the requests are not restricted
+	w = httptest.NewRecorder()
+	Tenants(nil, "", http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -85,59 +85,59 @@ type ModelRegistryServiceAPIRouter interface {
 // and updated with the logic required for the API.
 type ModelRegistryServiceAPIServicer interface {
 	FindArtifact(context.Context, string, string, string) (ImplResponse, error)
-	GetArtifacts(context.Context, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetArtifacts(context.Context, string, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	CreateArtifact(context.Context, model.ArtifactCreate) (ImplResponse, error)
 	GetArtifact(context.Context, string) (ImplResponse, error)
 	UpdateArtifact(context.Context, string, model.ArtifactUpdate) (ImplResponse, error)
 	FindExperiment(context.Context, string, string) (ImplResponse, error)
 	FindExperimentRun(context.Context, string, string, string) (ImplResponse, error)
-	GetExperimentRuns(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperimentRuns(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	CreateExperimentRun(context.Context, model.ExperimentRunCreate) (ImplResponse, error)
-	GetExperimentRunsMetricHistory(context.Context, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperimentRunsMetricHistory(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	GetExperimentRun(context.Context, string) (ImplResponse, error)
 	UpdateExperimentRun(context.Context, string, model.ExperimentRunUpdate) (ImplResponse, error)
-	GetExperimentRunArtifacts(context.Context, string, string, string, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperimentRunArtifacts(context.Context, string, string, string, string, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	UpsertExperimentRunArtifact(context.Context, string, model.Artifact) (ImplResponse, error)
-	GetExperimentRunMetricHistory(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
-	GetExperiments(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperimentRunMetricHistory(context.Context, string, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperiments(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	CreateExperiment(context.Context, model.ExperimentCreate) (ImplResponse, error)
 	GetExperiment(context.Context, string) (ImplResponse, error)
 	UpdateExperiment(context.Context, string, model.ExperimentUpdate) (ImplResponse, error)
-	GetExperimentExperimentRuns(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetExperimentExperimentRuns(context.Context, string, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	CreateExperimentExperimentRun(context.Context, string, model.ExperimentRun) (ImplResponse, error)
 	FindInferenceService(context.Context, string, string, string) (ImplResponse, error)
-	GetInferenceServices(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetInferenceServices(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	CreateInferenceService(context.Context, model.InferenceServiceCreate) (ImplResponse, error)
 	GetInferenceService(context.Context, string) (ImplResponse, error)
 	UpdateInferenceService(context.Context, string, model.InferenceServiceUpdate) (ImplResponse, error)
 	GetInferenceServiceModel(context.Context, string) (ImplResponse, error)
-	GetInferenceServiceServes(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetInferenceServiceServes(context.Context, string, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	CreateInferenceServiceServe(context.Context, string, model.ServeModelCreate) (ImplResponse, error)
 	GetInferenceServiceVersion(context.Context, string) (ImplResponse, error)
 	FindModelArtifact(context.Context, string, string, string) (ImplResponse, error)
-	GetModelArtifacts(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetModelArtifacts(context.Context, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	CreateModelArtifact(context.Context, model.ModelArtifactCreate) (ImplResponse, error)
 	GetModelArtifact(context.Context, string) (ImplResponse, error)
 	UpdateModelArtifact(context.Context, string, model.ModelArtifactUpdate) (ImplResponse, error)
 	FindModelVersion(context.Context, string, string, string) (ImplResponse, error)
-	GetModelVersions(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetModelVersions(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string, string) (ImplResponse, error)
 	CreateModelVersion(context.Context, model.ModelVersionCreate) (ImplResponse, error)
 	GetModelVersion(context.Context, string) (ImplResponse, error)
 	UpdateModelVersion(context.Context, string, model.ModelVersionUpdate) (ImplResponse, error)
-	GetModelVersionArtifacts(context.Context, string, string, string, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetModelVersionArtifacts(context.Context, string, string, string, string, string, model.ArtifactTypeQueryParam, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	UpsertModelVersionArtifact(context.Context, string, model.Artifact) (ImplResponse, error)
 	FindRegisteredModel(context.Context, string, string) (ImplResponse, error)
-	GetRegisteredModels(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetRegisteredModels(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string, string) (ImplResponse, error)
 	CreateRegisteredModel(context.Context, model.RegisteredModelCreate) (ImplResponse, error)
-	GetRegisteredModel(context.Context, string) (ImplResponse, error)
+	GetRegisteredModel(context.Context, string, string) (ImplResponse, error)
 	UpdateRegisteredModel(context.Context, string, model.RegisteredModelUpdate) (ImplResponse, error)
-	GetRegisteredModelVersions(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetRegisteredModelVersions(context.Context, string, string, string, string, string, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	CreateRegisteredModelVersion(context.Context, string, model.ModelVersion) (ImplResponse, error)
 	FindServingEnvironment(context.Context, string, string) (ImplResponse, error)
-	GetServingEnvironments(context.Context, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetServingEnvironments(context.Context, string, string, string, model.OrderByField, model.SortOrder, string, string) (ImplResponse, error)
 	CreateServingEnvironment(context.Context, model.ServingEnvironmentCreate) (ImplResponse, error)
 	GetServingEnvironment(context.Context, string) (ImplResponse, error)
 	UpdateServingEnvironment(context.Context, string, model.ServingEnvironmentUpdate) (ImplResponse, error)
-	GetEnvironmentInferenceServices(context.Context, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
+	GetEnvironmentInferenceServices(context.Context, string, string, string, string, string, string, model.OrderByField, model.SortOrder, string) (ImplResponse, error)
 	CreateEnvironmentInferenceService(context.Context, string, model.InferenceServiceCreate) (ImplResponse, error)
 }
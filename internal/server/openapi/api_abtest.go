@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ABTestAPIController binds http requests for the A/B tests comparing model versions to the core api and writes
+// the results to the http response
+type ABTestAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewABTestAPIController creates a default ab test api controller
+func NewABTestAPIController(coreApi api.ModelRegistryApi) *ABTestAPIController {
+	return &ABTestAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ABTestAPIController
+func (c *ABTestAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ABTestAPIController
+func (c *ABTestAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetABTests",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/ab_tests",
+			c.GetABTests,
+		},
+		{
+			"GetABTest",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/ab_tests/{abtestId}",
+			c.GetABTest,
+		},
+		{
+			"RecordABTestResult",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/ab_tests/{abtestId}/results",
+			c.RecordABTestResult,
+		},
+		{
+			"GetRegisteredModelABTests",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/ab_tests",
+			c.GetRegisteredModelABTests,
+		},
+		{
+			"CreateRegisteredModelABTest",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/ab_tests",
+			c.CreateRegisteredModelABTest,
+		},
+	}
+}
+
+// GetABTests - List all ABTests
+func (c *ABTestAPIController) GetABTests(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetABTests(listOptions, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetABTest - Get an ABTest
+func (c *ABTestAPIController) GetABTest(w http.ResponseWriter, r *http.Request) {
+	abtestIdParam := chi.URLParam(r, "abtestId")
+	if abtestIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"abtestId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetABTestById(abtestIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// RecordABTestResult - Record the outcome metrics of the variants of an ABTest, completing it with a winner
+func (c *ABTestAPIController) RecordABTestResult(w http.ResponseWriter, r *http.Request) {
+	abtestIdParam := chi.URLParam(r, "abtestId")
+	if abtestIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"abtestId"}, nil)
+		return
+	}
+	resultParam := api.ABTestResult{}
+	if err := decodeStrict(r, &resultParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).RecordABTestResult(abtestIdParam, &resultParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetRegisteredModelABTests - List the ABTests comparing versions of a RegisteredModel
+func (c *ABTestAPIController) GetRegisteredModelABTests(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetABTests(listOptions, &registeredmodelIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// CreateRegisteredModelABTest - Create an ABTest comparing versions of a RegisteredModel
+func (c *ABTestAPIController) CreateRegisteredModelABTest(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	abTestParam := api.ABTest{}
+	if err := decodeStrict(r, &abTestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).CreateABTest(registeredmodelIdParam, &abTestParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
@@ -0,0 +1,103 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestABTest(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	v1, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	v2, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+
+	post := func(path string, body any, out any) int {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		resp, err := http.Post(server.URL+"/api/model_registry/v1alpha3/"+path, "application/json", bytes.NewReader(encoded))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var created api.ABTest
+	status := post(fmt.Sprintf("registered_models/%s/ab_tests", *model.Id), api.ABTest{
+		Name: "checkout",
+		Variants: []api.ABTestVariant{
+			{ModelVersionId: *v1.Id, TrafficPercent: 90},
+			{ModelVersionId: *v2.Id, TrafficPercent: 10},
+		},
+		StartTimeSinceEpoch: "1700000000000",
+	}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "checkout", created.Name)
+	assert.Equal(t, *model.Id, created.RegisteredModelId)
+	assert.Equal(t, api.ABTestRunning, created.State)
+	assert.Equal(t, "1700000000000", created.StartTimeSinceEpoch)
+	assert.Empty(t, created.EndTimeSinceEpoch)
+
+	// the variants split the whole traffic between versions of the registered model
+	assert.Equal(t, http.StatusBadRequest, post(fmt.Sprintf("registered_models/%s/ab_tests", *model.Id), api.ABTest{
+		Name:     "uneven",
+		Variants: []api.ABTestVariant{{ModelVersionId: *v1.Id, TrafficPercent: 50}, {ModelVersionId: *v2.Id, TrafficPercent: 40}},
+	}, nil))
+	assert.Equal(t, http.StatusBadRequest, post(fmt.Sprintf("registered_models/%s/ab_tests", *model.Id), api.ABTest{
+		Name:     "single",
+		Variants: []api.ABTestVariant{{ModelVersionId: *v1.Id, TrafficPercent: 100}},
+	}, nil))
+	assert.Equal(t, http.StatusConflict, post(fmt.Sprintf("registered_models/%s/ab_tests", *model.Id), api.ABTest{
+		Name:     "checkout",
+		Variants: []api.ABTestVariant{{ModelVersionId: *v1.Id, TrafficPercent: 50}, {ModelVersionId: *v2.Id, TrafficPercent: 50}},
+	}, nil))
+
+	// results replace the recorded metrics of the variants
+	var recorded api.ABTest
+	require.Equal(t, http.StatusOK, post(fmt.Sprintf("ab_tests/%s/results", created.Id), api.ABTestResult{
+		Variants: []api.ABTestVariantMetrics{
+			{ModelVersionId: *v1.Id, Metrics: map[string]float64{"conversion": 0.031, "latency_ms": 120}},
+			{ModelVersionId: *v2.Id, Metrics: map[string]float64{"conversion": 0.035}},
+		},
+	}, &recorded))
+	require.Equal(t, http.StatusOK, post(fmt.Sprintf("ab_tests/%s/results", created.Id), api.ABTestResult{
+		Variants: []api.ABTestVariantMetrics{{ModelVersionId: *v1.Id, Metrics: map[string]float64{"conversion": 0.032}}},
+	}, &recorded))
+	assert.Equal(t, map[string]float64{"conversion": 0.032, "latency_ms": 120}, recorded.Variants[0].Metrics)
+	assert.Equal(t, map[string]float64{"conversion": 0.035}, recorded.Variants[1].Metrics)
+
+	// a winner completes the test
+	var completed api.ABTest
+	require.Equal(t, http.StatusOK, post(fmt.Sprintf("ab_tests/%s/results", created.Id), api.ABTestResult{WinnerModelVersionId: *v2.Id}, &completed))
+	assert.Equal(t, api.ABTestCompleted, completed.State)
+	assert.Equal(t, *v2.Id, completed.WinnerModelVersionId)
+	assert.NotEmpty(t, completed.EndTimeSinceEpoch)
+
+	assert.Equal(t, http.StatusBadRequest, post(fmt.Sprintf("ab_tests/%s/results", created.Id), api.ABTestResult{
+		Variants: []api.ABTestVariantMetrics{{ModelVersionId: *v1.Id, Metrics: map[string]float64{"conversion": 0.04}}},
+	}, nil))
+
+	resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s/ab_tests", server.URL, *model.Id))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var tests api.ABTestList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&tests))
+	require.Len(t, tests.Items, 1)
+	assert.Equal(t, completed, tests.Items[0])
+}
@@ -0,0 +1,73 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ArchiveAPIController binds http requests deleting registered models and model versions, which archives them,
+// to the core api and writes the results to the http response
+type ArchiveAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewArchiveAPIController creates a default archive api controller
+func NewArchiveAPIController(coreApi api.ModelRegistryApi) *ArchiveAPIController {
+	return &ArchiveAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ArchiveAPIController
+func (c *ArchiveAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ArchiveAPIController
+func (c *ArchiveAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"ArchiveRegisteredModel",
+			strings.ToUpper("Delete"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}",
+			c.ArchiveRegisteredModel,
+		},
+		{
+			"ArchiveModelVersion",
+			strings.ToUpper("Delete"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}",
+			c.ArchiveModelVersion,
+		},
+	}
+}
+
+// ArchiveRegisteredModel - Archive a RegisteredModel and its ModelVersions
+func (c *ArchiveAPIController) ArchiveRegisteredModel(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ArchiveRegisteredModel(registeredmodelIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// ArchiveModelVersion - Archive a ModelVersion
+func (c *ArchiveAPIController) ArchiveModelVersion(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ArchiveModelVersion(modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,89 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveRegisteredModel(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	v1, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	_, err = service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+	other, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+
+	remove := func(path string) *http.Response {
+		req, err := http.NewRequest(http.MethodDelete, server.URL+"/api/model_registry/v1alpha3/"+path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	get := func(path string, list any) {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/" + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(list))
+	}
+
+	// archiving a version leaves the others live
+	resp := remove("model_versions/" + *v1.Id)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var archivedVersion openapi.ModelVersion
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&archivedVersion))
+	assert.Equal(t, openapi.MODELVERSIONSTATE_ARCHIVED, archivedVersion.GetState())
+
+	var versions openapi.ModelVersionList
+	get(fmt.Sprintf("registered_models/%s/versions?state=LIVE", *model.Id), &versions)
+	require.Len(t, versions.Items, 1)
+	assert.Equal(t, "v2", versions.Items[0].Name)
+
+	// archiving a registered model archives its versions
+	resp = remove("registered_models/" + *model.Id)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var archivedModel openapi.RegisteredModel
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&archivedModel))
+	assert.Equal(t, openapi.REGISTEREDMODELSTATE_ARCHIVED, archivedModel.GetState())
+
+	get(fmt.Sprintf("registered_models/%s/versions?state=LIVE", *model.Id), &versions)
+	assert.Empty(t, versions.Items)
+	get("model_versions?state=ARCHIVED", &versions)
+	assert.Len(t, versions.Items, 2)
+
+	var models openapi.RegisteredModelList
+	get("registered_models?state=LIVE", &models)
+	require.Len(t, models.Items, 1)
+	assert.Equal(t, *other.Id, *models.Items[0].Id)
+	get("registered_models?state=ARCHIVED", &models)
+	require.Len(t, models.Items, 1)
+	assert.Equal(t, *model.Id, *models.Items[0].Id)
+	get("registered_models", &models)
+	assert.Len(t, models.Items, 2)
+
+	// archived entities can still be fetched
+	fetched, err := service.GetRegisteredModelById(*model.Id)
+	require.NoError(t, err)
+	assert.Equal(t, openapi.REGISTEREDMODELSTATE_ARCHIVED, fetched.GetState())
+
+	assert.Equal(t, http.StatusNotFound, remove("registered_models/999").StatusCode)
+
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/registered_models?state=DELETED")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,69 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactDeduplication(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	v1, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	v2, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+
+	create := func(versionId string, body string) openapi.Artifact {
+		resp, err := http.Post(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/artifacts", server.URL, versionId), "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var artifact openapi.Artifact
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&artifact))
+		return artifact
+	}
+	modelArtifact := func(name, uri, digest string) string {
+		return fmt.Sprintf(`{"artifactType": "model-artifact", "name": %q, "uri": %q, "customProperties": {"digest": {"metadataType": "MetadataStringValue", "string_value": %q}}}`, name, uri, digest)
+	}
+
+	first := create(*v1.Id, modelArtifact("weights", "s3://models/fraud/v1", "sha256:aaa"))
+	duplicate := create(*v2.Id, modelArtifact("weights", "s3://models/fraud/v2", "sha256:aaa"))
+	assert.NotEqual(t, *first.ModelArtifact.Id, *duplicate.ModelArtifact.Id, "artifacts are duplicated unless deduplication is enabled")
+
+	service.SetArtifactDeduplication(true)
+
+	v3, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v3"}, model.Id)
+	require.NoError(t, err)
+	linked := create(*v3.Id, modelArtifact("weights", "s3://models/fraud/v3", "sha256:aaa"))
+	assert.Equal(t, *first.ModelArtifact.Id, *linked.ModelArtifact.Id, "the canonical artifact is returned")
+	assert.Equal(t, "s3://models/fraud/v1", *linked.ModelArtifact.Uri)
+
+	artifacts, err := service.GetArtifacts(openapi.ARTIFACTTYPEQUERYPARAM_MODEL_ARTIFACT, api.ListOptions{}, v3.Id)
+	require.NoError(t, err)
+	require.Len(t, artifacts.Items, 1)
+	assert.Equal(t, *first.ModelArtifact.Id, *artifacts.Items[0].ModelArtifact.Id, "the canonical artifact is linked to the new parent")
+
+	other := create(*v3.Id, modelArtifact("other-weights", "s3://models/fraud/v3-other", "sha256:bbb"))
+	assert.NotEqual(t, *first.ModelArtifact.Id, *other.ModelArtifact.Id)
+
+	// the digests are deduplicated per artifact type
+	doc := create(*v3.Id, `{"artifactType": "doc-artifact", "name": "readme", "uri": "s3://docs/fraud", "customProperties": {"digest": {"metadataType": "MetadataStringValue", "string_value": "sha256:aaa"}}}`)
+	require.NotNil(t, doc.DocArtifact)
+	linkedDoc := create(*v1.Id, `{"artifactType": "doc-artifact", "name": "readme", "uri": "s3://docs/fraud-copy", "customProperties": {"digest": {"metadataType": "MetadataStringValue", "string_value": "sha256:aaa"}}}`)
+	assert.Equal(t, *doc.DocArtifact.Id, *linkedDoc.DocArtifact.Id)
+
+	// the artifacts without uri are not deduplicated
+	withoutUri := create(*v2.Id, `{"artifactType": "model-artifact", "name": "pending", "customProperties": {"digest": {"metadataType": "MetadataStringValue", "string_value": "sha256:aaa"}}}`)
+	assert.NotEqual(t, *first.ModelArtifact.Id, *withoutUri.ModelArtifact.Id)
+}
@@ -0,0 +1,68 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ArtifactReachabilityAPIController binds http requests for the verification of the model artifact uris
+// to the core api and writes the results to the http response
+type ArtifactReachabilityAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewArtifactReachabilityAPIController creates a default artifact reachability api controller
+func NewArtifactReachabilityAPIController(coreApi api.ModelRegistryApi) *ArtifactReachabilityAPIController {
+	return &ArtifactReachabilityAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ArtifactReachabilityAPIController
+func (c *ArtifactReachabilityAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ArtifactReachabilityAPIController
+func (c *ArtifactReachabilityAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetModelArtifactReachability",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/artifacts/{artifactId}/reachability",
+			c.GetModelArtifactReachability,
+		},
+		{
+			"GetUnreachableModelArtifacts",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/reports/unreachable_artifacts",
+			c.GetUnreachableModelArtifacts,
+		},
+	}
+}
+
+// GetModelArtifactReachability - Get the last verification of the uri of a ModelArtifact
+func (c *ArtifactReachabilityAPIController) GetModelArtifactReachability(w http.ResponseWriter, r *http.Request) {
+	artifactIdParam := chi.URLParam(r, "artifactId")
+	if artifactIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"artifactId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelArtifactReachability(artifactIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetUnreachableModelArtifacts - List the ModelArtifacts whose uri was not reachable when last verified
+func (c *ArtifactReachabilityAPIController) GetUnreachableModelArtifacts(w http.ResponseWriter, r *http.Request) {
+	result, err := api.WithContext(r.Context(), c.coreApi).GetUnreachableModelArtifacts()
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ArtifactReferenceAPIController binds http requests for the references of the model artifacts
+// to the core api and writes the results to the http response
+type ArtifactReferenceAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewArtifactReferenceAPIController creates a default artifact reference api controller
+func NewArtifactReferenceAPIController(coreApi api.ModelRegistryApi) *ArtifactReferenceAPIController {
+	return &ArtifactReferenceAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ArtifactReferenceAPIController
+func (c *ArtifactReferenceAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ArtifactReferenceAPIController
+func (c *ArtifactReferenceAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetModelArtifactReferences",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_artifacts/{modelartifactId}/references",
+			c.GetModelArtifactReferences,
+		},
+	}
+}
+
+// GetModelArtifactReferences - Get the entities referencing the uri of a ModelArtifact
+func (c *ArtifactReferenceAPIController) GetModelArtifactReferences(w http.ResponseWriter, r *http.Request) {
+	modelartifactIdParam := chi.URLParam(r, "modelartifactId")
+	if modelartifactIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelartifactId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelArtifactReferences(modelartifactIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
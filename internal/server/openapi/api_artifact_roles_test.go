@@ -0,0 +1,95 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListArtifactsByRole(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "fraud-training"})
+	require.NoError(t, err)
+	run, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: openapi.PtrString("run-1")}, experiment.Id)
+	require.NoError(t, err)
+
+	for _, artifact := range []openapi.Artifact{
+		{ModelArtifact: &openapi.ModelArtifact{Name: openapi.PtrString("model"), Uri: openapi.PtrString("s3://models/fraud")}},
+		{DocArtifact: &openapi.DocArtifact{Name: openapi.PtrString("readme"), Uri: openapi.PtrString("s3://docs/fraud")}},
+		{Metric: &openapi.Metric{Name: openapi.PtrString("accuracy"), Value: openapi.PtrFloat64(0.97)}},
+	} {
+		_, err := service.UpsertModelVersionArtifact(&artifact, *version.Id)
+		require.NoError(t, err)
+	}
+	_, err = service.UpsertExperimentRunArtifact(&openapi.Artifact{DataSet: &openapi.DataSet{Name: openapi.PtrString("transactions"), Uri: openapi.PtrString("s3://data/transactions")}}, *run.Id)
+	require.NoError(t, err)
+	_, err = service.UpsertExperimentRunArtifact(&openapi.Artifact{Parameter: &openapi.Parameter{Name: openapi.PtrString("epochs"), Value: openapi.PtrString("10")}}, *run.Id)
+	require.NoError(t, err)
+
+	list := func(path string) (int, openapi.ArtifactList) {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/" + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var artifacts openapi.ArtifactList
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&artifacts))
+		}
+		return resp.StatusCode, artifacts
+	}
+	names := func(artifacts openapi.ArtifactList) []string {
+		var names []string
+		for _, artifact := range artifacts.Items {
+			switch {
+			case artifact.ModelArtifact != nil:
+				names = append(names, artifact.ModelArtifact.GetName())
+			case artifact.DocArtifact != nil:
+				names = append(names, artifact.DocArtifact.GetName())
+			case artifact.Metric != nil:
+				names = append(names, artifact.Metric.GetName())
+			case artifact.Parameter != nil:
+				names = append(names, artifact.Parameter.GetName())
+			}
+		}
+		return names
+	}
+
+	status, artifacts := list(fmt.Sprintf("model_versions/%s/artifacts?role=model", *version.Id))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"model"}, names(artifacts))
+
+	status, artifacts = list(fmt.Sprintf("model_versions/%s/artifacts?role=doc,metrics&orderBy=ID&sortOrder=DESC", *version.Id))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"accuracy", "readme"}, names(artifacts))
+
+	// the pages are of the artifacts of the roles
+	status, artifacts = list(fmt.Sprintf("model_versions/%s/artifacts?role=doc,metrics&orderBy=ID&sortOrder=DESC&pageSize=1", *version.Id))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"accuracy"}, names(artifacts))
+	require.NotEmpty(t, artifacts.NextPageToken)
+	status, artifacts = list(fmt.Sprintf("model_versions/%s/artifacts?role=doc,metrics&orderBy=ID&sortOrder=DESC&pageSize=1&nextPageToken=%s", *version.Id, artifacts.NextPageToken))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"readme"}, names(artifacts))
+
+	// the role and the artifact type both restrict the list
+	status, artifacts = list(fmt.Sprintf("model_versions/%s/artifacts?role=model&artifactType=doc-artifact", *version.Id))
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, artifacts.Items)
+
+	status, artifacts = list(fmt.Sprintf("experiment_runs/%s/artifacts?role=parameters", *run.Id))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []string{"epochs"}, names(artifacts))
+
+	status, _ = list(fmt.Sprintf("model_versions/%s/artifacts?role=weights", *version.Id))
+	assert.Equal(t, http.StatusBadRequest, status)
+}
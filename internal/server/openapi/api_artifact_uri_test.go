@@ -0,0 +1,70 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArtifactUriFilters(t *testing.T) {
+	server, _ := inmemory.NewServer(t)
+	base := server.URL + "/api/model_registry/v1alpha3"
+
+	send := func(method, path, body string) *http.Response {
+		req, err := http.NewRequest(method, base+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+	create := func(name, uri string) openapi.ModelArtifact {
+		resp := send(http.MethodPost, "/model_artifacts", fmt.Sprintf(`{"name": %q, "uri": %q}`, name, uri))
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var artifact openapi.ModelArtifact
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&artifact))
+		return artifact
+	}
+	names := func(filterQuery string) []string {
+		resp, err := http.Get(base + "/model_artifacts?orderBy=ID&filterQuery=" + url.QueryEscape(filterQuery))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var list openapi.ModelArtifactList
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		names := []string{}
+		for _, artifact := range list.Items {
+			names = append(names, artifact.GetName())
+		}
+		return names
+	}
+
+	create("granite", "s3://models/granite/v1?defaultRegion=eu-west-1")
+	create("llama", "https://models.s3.us-east-1.amazonaws.com/llama")
+	moved := create("mistral", "s3://archive/mistral")
+	create("phi", "oci://quay.io/org/phi:v1")
+
+	assert.Equal(t, []string{"granite", "llama"}, names("uriBucket = 'models'"), "the https urls of s3 objects are normalized")
+	assert.Equal(t, []string{"granite", "llama", "mistral"}, names("uriScheme = 's3'"))
+	assert.Equal(t, []string{"granite"}, names("uriRegion = 'eu-west-1' AND uriPath LIKE 'granite/%'"))
+	assert.Equal(t, []string{"phi"}, names("uriBucket = 'quay.io'"))
+
+	resp := send(http.MethodPatch, "/model_artifacts/"+*moved.Id, `{"uri": "gs://models/mistral"}`)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"granite", "llama", "mistral"}, names("uriBucket = 'models'"))
+	assert.Equal(t, []string{"mistral"}, names("uriScheme = 'gs'"))
+
+	resp = send(http.MethodPost, "/model_artifacts", `{"name": "broken", "uri": "oci://quay.io"}`)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ArtifactVariantAPIController binds http requests for the variants of model artifacts and their resolution
+// to the core api and writes the results to the http response
+type ArtifactVariantAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewArtifactVariantAPIController creates a default artifact variant api controller
+func NewArtifactVariantAPIController(coreApi api.ModelRegistryApi) *ArtifactVariantAPIController {
+	return &ArtifactVariantAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ArtifactVariantAPIController
+func (c *ArtifactVariantAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ArtifactVariantAPIController
+func (c *ArtifactVariantAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetModelArtifactVariant",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/artifacts/{artifactId}/variant",
+			c.GetModelArtifactVariant,
+		},
+		{
+			"UpdateModelArtifactVariant",
+			strings.ToUpper("Patch"),
+			"/api/model_registry/v1alpha3/artifacts/{artifactId}/variant",
+			c.UpdateModelArtifactVariant,
+		},
+		{
+			"ResolveModelVersionArtifact",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}:resolveArtifact",
+			c.ResolveModelVersionArtifact,
+		},
+	}
+}
+
+// GetModelArtifactVariant - Get the variant of a ModelArtifact
+func (c *ArtifactVariantAPIController) GetModelArtifactVariant(w http.ResponseWriter, r *http.Request) {
+	artifactIdParam := chi.URLParam(r, "artifactId")
+	if artifactIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"artifactId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelArtifactVariant(artifactIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// UpdateModelArtifactVariant - Update the variant of a ModelArtifact
+func (c *ArtifactVariantAPIController) UpdateModelArtifactVariant(w http.ResponseWriter, r *http.Request) {
+	artifactIdParam := chi.URLParam(r, "artifactId")
+	if artifactIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"artifactId"}, nil)
+		return
+	}
+	variantParam := api.ArtifactVariant{}
+	if err := decodeStrict(r, &variantParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).UpdateModelArtifactVariant(artifactIdParam, &variantParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// ResolveModelVersionArtifact - Get the ModelArtifact of a ModelVersion best matching the requested variant
+func (c *ArtifactVariantAPIController) ResolveModelVersionArtifact(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ResolveModelVersionArtifact(modelversionIdParam, api.ArtifactVariantQuery{
+		Architecture: query.Get("architecture"),
+		Accelerator:  query.Get("accelerator"),
+		Precision:    query.Get("precision"),
+		Engine:       query.Get("engine"),
+	})
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
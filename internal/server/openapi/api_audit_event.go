@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// AuditEventAPIController binds http requests for the audit log of the writes of the entities to the core api and
+// writes the results to the http response
+type AuditEventAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewAuditEventAPIController creates a default audit event api controller
+func NewAuditEventAPIController(coreApi api.ModelRegistryApi) *AuditEventAPIController {
+	return &AuditEventAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the AuditEventAPIController
+func (c *AuditEventAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the AuditEventAPIController
+func (c *AuditEventAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetAuditEvents",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/audit_events",
+			c.GetAuditEvents,
+		},
+	}
+}
+
+// GetAuditEvents - List the AuditEvents of the writes of the entities, filtered by actor, action, entityType and
+// entityId, and by time with since and until in milliseconds since epoch
+func (c *AuditEventAPIController) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	filter := api.AuditEventFilter{}
+	for param, value := range map[string]**string{
+		"actor":      &filter.Actor,
+		"action":     &filter.Action,
+		"entityType": &filter.EntityType,
+		"entityId":   &filter.EntityId,
+	} {
+		if query.Has(param) {
+			*value = new(string)
+			**value = query.Get(param)
+		}
+	}
+	for param, value := range map[string]**int64{
+		"since": &filter.Since,
+		"until": &filter.Until,
+	} {
+		if !query.Has(param) {
+			continue
+		}
+		parsed, err := strconv.ParseInt(query.Get(param), 10, 64)
+		if err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: param, Err: err}, nil)
+			return
+		}
+		*value = &parsed
+	}
+
+	result, err := api.WithContext(r.Context(), c.coreApi).GetAuditEvents(listOptions, filter)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,118 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/server/middleware"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditEvents(t *testing.T) {
+	store := inmemory.NewStore()
+	server := httptest.NewServer(middleware.NewModelRegistryHandler(inmemory.NewModelRegistryService(store)))
+	defer server.Close()
+
+	types := store.TypeMap()
+	store.AddAuditEvent(models.AuditEvent{
+		Action:               models.AuditActionCreate,
+		Actor:                "alice",
+		EntityKind:           models.AuditEntityKindContext,
+		TypeID:               types[defaults.RegisteredModelTypeName],
+		EntityID:             1,
+		Changes:              []models.AuditChange{{Field: "name", After: "churn"}},
+		CreateTimeSinceEpoch: apiutils.Of(int64(1000)),
+	})
+	store.AddAuditEvent(models.AuditEvent{
+		Action:               models.AuditActionTransition,
+		Actor:                "bob",
+		EntityKind:           models.AuditEntityKindContext,
+		TypeID:               types[defaults.ModelVersionTypeName],
+		EntityID:             2,
+		Changes:              []models.AuditChange{{Field: "state", Before: "LIVE", After: "ARCHIVED"}},
+		CreateTimeSinceEpoch: apiutils.Of(int64(2000)),
+	})
+	store.AddAuditEvent(models.AuditEvent{
+		Action:               models.AuditActionUpdate,
+		Actor:                "alice",
+		EntityKind:           models.AuditEntityKindArtifact,
+		TypeID:               types[defaults.ModelArtifactTypeName],
+		EntityID:             3,
+		Changes:              []models.AuditChange{{Field: "uri", Before: "s3://a", After: "s3://b"}},
+		CreateTimeSinceEpoch: apiutils.Of(int64(3000)),
+	})
+	store.AddAuditEvent(models.AuditEvent{
+		Action:               models.AuditActionDelete,
+		Actor:                "alice",
+		EntityKind:           models.AuditEntityKindTag,
+		EntityID:             1,
+		Changes:              []models.AuditChange{{Field: "name", Before: "nlp"}},
+		CreateTimeSinceEpoch: apiutils.Of(int64(4000)),
+	})
+
+	get := func(query string) (int, api.AuditEventList) {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/audit_events" + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var list api.AuditEventList
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+		}
+		return resp.StatusCode, list
+	}
+
+	status, list := get("")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, int32(4), list.Size)
+	assert.Equal(t, "RegisteredModel", list.Items[0].EntityType)
+	require.NotNil(t, list.Items[0].Ref)
+	assert.Equal(t, "registered_model/1", list.Items[0].Ref.String())
+	assert.Equal(t, []api.FieldChange{{Field: "name", After: "churn"}}, list.Items[0].Changes)
+	assert.Equal(t, "1000", list.Items[0].CreateTimeSinceEpoch)
+	assert.Equal(t, "ModelArtifact", list.Items[2].EntityType)
+	assert.Equal(t, "artifact/3", list.Items[2].Ref.String(), "the artifacts are referenced as artifacts")
+	assert.Equal(t, api.AuditEntityTypeTag, list.Items[3].EntityType)
+	assert.Nil(t, list.Items[3].Ref)
+
+	for query, ids := range map[string][]string{
+		"?actor=alice":                           {"1", "3", "4"},
+		"?action=TRANSITION":                     {"2"},
+		"?entityType=ModelVersion":               {"2"},
+		"?entityType=Artifact":                   {"3"},
+		"?entityType=Tag":                        {"4"},
+		"?entityType=RegisteredModel&entityId=1": {"1"},
+		"?since=2000&until=3000":                 {"2", "3"},
+		"?actor=alice&sortOrder=DESC":            {"4", "3", "1"},
+	} {
+		status, list := get(query)
+		require.Equal(t, http.StatusOK, status, query)
+		actual := []string{}
+		for _, event := range list.Items {
+			actual = append(actual, event.Id)
+		}
+		assert.Equal(t, ids, actual, query)
+	}
+
+	// the events are paginated
+	status, list = get("?pageSize=3")
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list.Items, 3)
+	require.NotEmpty(t, list.NextPageToken)
+	status, list = get("?pageSize=3&nextPageToken=" + list.NextPageToken)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "4", list.Items[0].Id)
+
+	for _, query := range []string{"?action=PATCH", "?entityType=Unknown", "?entityId=abc", "?since=yesterday", "?since=3000&until=2000"} {
+		status, _ := get(query)
+		assert.Equal(t, http.StatusBadRequest, status, query)
+	}
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BatchCreateAPIController binds http requests creating many model versions with their artifacts in one round trip
+// to the core api and writes the results to the http response
+type BatchCreateAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewBatchCreateAPIController creates a default batch create api controller
+func NewBatchCreateAPIController(coreApi api.ModelRegistryApi) *BatchCreateAPIController {
+	return &BatchCreateAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the BatchCreateAPIController
+func (c *BatchCreateAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the BatchCreateAPIController
+func (c *BatchCreateAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"BatchCreateRegisteredModelVersions",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/versions:batchCreate",
+			c.BatchCreateRegisteredModelVersions,
+		},
+	}
+}
+
+// BatchCreateRegisteredModelVersions - Create many ModelVersion entities with their ModelArtifact entities in a RegisteredModel
+func (c *BatchCreateAPIController) BatchCreateRegisteredModelVersions(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	batchParam := api.ModelVersionBatch{}
+	if err := decodeStrict(r, &batchParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if len(batchParam.Items) == 0 {
+		c.errorHandler(w, r, &RequiredError{"items"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).CreateModelVersions(registeredmodelIdParam, &batchParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
@@ -0,0 +1,71 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchCreateModelVersions(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "sweep"})
+	require.NoError(t, err)
+
+	post := func(batch api.ModelVersionBatch, out any) int {
+		encoded, err := json.Marshal(batch)
+		require.NoError(t, err)
+		resp, err := http.Post(fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s/versions:batchCreate", server.URL, *model.Id), "application/json", bytes.NewReader(encoded))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var created api.ModelVersionBatch
+	require.Equal(t, http.StatusCreated, post(api.ModelVersionBatch{Items: []api.ModelVersionBatchItem{
+		{
+			ModelVersion: openapi.ModelVersion{Name: "lr-0.01"},
+			Artifacts:    []openapi.ModelArtifact{{Name: openapi.PtrString("model"), Uri: openapi.PtrString("s3://sweep/lr-0.01")}},
+		},
+		{ModelVersion: openapi.ModelVersion{Name: "lr-0.1"}},
+	}}, &created))
+	require.Len(t, created.Items, 2)
+	assert.Equal(t, "lr-0.01", created.Items[0].ModelVersion.Name)
+	assert.Equal(t, *model.Id, created.Items[0].ModelVersion.RegisteredModelId)
+	require.Len(t, created.Items[0].Artifacts, 1)
+	assert.Equal(t, "model", created.Items[0].Artifacts[0].GetName())
+	assert.Empty(t, created.Items[1].Artifacts)
+
+	artifacts, err := service.GetArtifacts("", api.ListOptions{}, created.Items[0].ModelVersion.Id)
+	require.NoError(t, err)
+	require.Len(t, artifacts.Items, 1)
+	assert.Equal(t, "s3://sweep/lr-0.01", artifacts.Items[0].ModelArtifact.GetUri())
+
+	// a duplicate name rolls the whole batch back
+	assert.Equal(t, http.StatusConflict, post(api.ModelVersionBatch{Items: []api.ModelVersionBatchItem{
+		{ModelVersion: openapi.ModelVersion{Name: "lr-1"}},
+		{ModelVersion: openapi.ModelVersion{Name: "lr-0.1"}},
+	}}, nil))
+
+	versions, err := service.GetModelVersions(api.ListOptions{}, model.Id)
+	require.NoError(t, err)
+	assert.Len(t, versions.Items, 2)
+
+	tooMany := api.ModelVersionBatch{}
+	for i := range api.MaxBatchCreateModelVersions + 1 {
+		tooMany.Items = append(tooMany.Items, api.ModelVersionBatchItem{ModelVersion: openapi.ModelVersion{Name: fmt.Sprintf("v%d", i)}})
+	}
+	assert.Equal(t, http.StatusBadRequest, post(tooMany, nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, post(api.ModelVersionBatch{}, nil))
+}
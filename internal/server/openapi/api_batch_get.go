@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BatchGetAPIController binds http requests retrieving many entities by id in one round trip
+// to the core api and writes the results to the http response
+type BatchGetAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewBatchGetAPIController creates a default batch get api controller
+func NewBatchGetAPIController(coreApi api.ModelRegistryApi) *BatchGetAPIController {
+	return &BatchGetAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the BatchGetAPIController
+func (c *BatchGetAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the BatchGetAPIController
+func (c *BatchGetAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"BatchGetRegisteredModels",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/registered_models:batchGet",
+			c.BatchGetRegisteredModels,
+		},
+		{
+			"BatchGetModelVersions",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/model_versions:batchGet",
+			c.BatchGetModelVersions,
+		},
+		{
+			"BatchGetArtifacts",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/artifacts:batchGet",
+			c.BatchGetArtifacts,
+		},
+	}
+}
+
+// BatchGetRegisteredModels - Get many RegisteredModel entities by id
+func (c *BatchGetAPIController) BatchGetRegisteredModels(w http.ResponseWriter, r *http.Request) {
+	batchGetParam, ok := c.decodeBatchGetRequest(w, r)
+	if !ok {
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetRegisteredModelsByIds(batchGetParam.Ids)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// BatchGetModelVersions - Get many ModelVersion entities by id
+func (c *BatchGetAPIController) BatchGetModelVersions(w http.ResponseWriter, r *http.Request) {
+	batchGetParam, ok := c.decodeBatchGetRequest(w, r)
+	if !ok {
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionsByIds(batchGetParam.Ids)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// BatchGetArtifacts - Get many Artifact entities by id
+func (c *BatchGetAPIController) BatchGetArtifacts(w http.ResponseWriter, r *http.Request) {
+	batchGetParam, ok := c.decodeBatchGetRequest(w, r)
+	if !ok {
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetArtifactsByIds(batchGetParam.Ids)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+func (c *BatchGetAPIController) decodeBatchGetRequest(w http.ResponseWriter, r *http.Request) (api.BatchGetRequest, bool) {
+	batchGetParam := api.BatchGetRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&batchGetParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return batchGetParam, false
+	}
+	if len(batchGetParam.Ids) == 0 {
+		c.errorHandler(w, r, &RequiredError{"ids"}, nil)
+		return batchGetParam, false
+	}
+	return batchGetParam, true
+}
@@ -0,0 +1,90 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ByNameAPIController binds http requests resolving a single entity by name to the core api
+// and writes the results to the http response
+type ByNameAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewByNameAPIController creates a default by name api controller
+func NewByNameAPIController(coreApi api.ModelRegistryApi) *ByNameAPIController {
+	return &ByNameAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ByNameAPIController
+func (c *ByNameAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ByNameAPIController
+func (c *ByNameAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetRegisteredModelByName",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/registered_models:byName",
+			c.GetRegisteredModelByName,
+		},
+		{
+			"GetRegisteredModelVersionByName",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/versions:byName",
+			c.GetRegisteredModelVersionByName,
+		},
+	}
+}
+
+// GetRegisteredModelByName - Get the RegisteredModel with the given name
+func (c *ByNameAPIController) GetRegisteredModelByName(w http.ResponseWriter, r *http.Request) {
+	nameParam, ok := c.nameParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetRegisteredModelByParams(&nameParam, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetRegisteredModelVersionByName - Get the ModelVersion of a RegisteredModel with the given name
+func (c *ByNameAPIController) GetRegisteredModelVersionByName(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	nameParam, ok := c.nameParam(w, r)
+	if !ok {
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionByParams(&nameParam, &registeredmodelIdParam, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+func (c *ByNameAPIController) nameParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return "", false
+	}
+	nameParam := query.Get("name")
+	if nameParam == "" {
+		c.errorHandler(w, r, &RequiredError{"name"}, nil)
+		return "", false
+	}
+	return nameParam, true
+}
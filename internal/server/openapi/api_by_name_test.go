@@ -0,0 +1,53 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetByName(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	// the names differ only where the others have a LIKE wildcard
+	models := map[string]string{}
+	for _, name := range []string{"churn_v2", "churnXv2", "churn%"} {
+		model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: name})
+		require.NoError(t, err)
+		models[name] = *model.Id
+	}
+	for _, name := range []string{"v_1", "vX1"} {
+		_, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: name}, openapi.PtrString(models["churn_v2"]))
+		require.NoError(t, err)
+	}
+
+	baseURL := server.URL + "/api/model_registry/v1alpha3/"
+	get := func(path string, name string, out any) int {
+		resp, err := http.Get(baseURL + path + "?name=" + url.QueryEscape(name))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	for _, name := range []string{"churn_v2", "churn%"} {
+		var model openapi.RegisteredModel
+		require.Equal(t, http.StatusOK, get("registered_models:byName", name, &model))
+		assert.Equal(t, models[name], *model.Id)
+	}
+	assert.Equal(t, http.StatusNotFound, get("registered_models:byName", "churn_", nil))
+
+	var version openapi.ModelVersion
+	require.Equal(t, http.StatusOK, get("registered_models/"+models["churn_v2"]+"/versions:byName", "v_1", &version))
+	assert.Equal(t, "v_1", version.Name)
+	assert.Equal(t, http.StatusNotFound, get("registered_models/"+models["churn_v2"]+"/versions:byName", "v%", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, get("registered_models:byName", "", nil))
+}
@@ -0,0 +1,139 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ConversionJobAPIController binds http requests for the conversion jobs of model versions
+// to the core api and writes the results to the http response
+type ConversionJobAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewConversionJobAPIController creates a default conversion job api controller
+func NewConversionJobAPIController(coreApi api.ModelRegistryApi) *ConversionJobAPIController {
+	return &ConversionJobAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ConversionJobAPIController
+func (c *ConversionJobAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ConversionJobAPIController
+func (c *ConversionJobAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetConversionJobs",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/conversion_jobs",
+			c.GetConversionJobs,
+		},
+		{
+			"GetConversionJob",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/conversion_jobs/{conversionjobId}",
+			c.GetConversionJob,
+		},
+		{
+			"CompleteConversionJob",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/conversion_jobs/{conversionjobId}:complete",
+			c.CompleteConversionJob,
+		},
+		{
+			"GetModelVersionConversionJobs",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/conversion_jobs",
+			c.GetModelVersionConversionJobs,
+		},
+		{
+			"StartConversionJob",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/conversion_jobs",
+			c.StartConversionJob,
+		},
+	}
+}
+
+// GetConversionJobs - List all ConversionJobs
+func (c *ConversionJobAPIController) GetConversionJobs(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetConversionJobs(listOptions, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetConversionJob - Get a ConversionJob
+func (c *ConversionJobAPIController) GetConversionJob(w http.ResponseWriter, r *http.Request) {
+	conversionjobIdParam := chi.URLParam(r, "conversionjobId")
+	if conversionjobIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"conversionjobId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetConversionJobById(conversionjobIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// CompleteConversionJob - Report the outcome of a running ConversionJob, called by the converter
+func (c *ConversionJobAPIController) CompleteConversionJob(w http.ResponseWriter, r *http.Request) {
+	conversionjobIdParam := chi.URLParam(r, "conversionjobId")
+	if conversionjobIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"conversionjobId"}, nil)
+		return
+	}
+	completionParam := api.ConversionJobCompletion{}
+	if err := decodeStrict(r, &completionParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).CompleteConversionJob(conversionjobIdParam, &completionParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetModelVersionConversionJobs - List all ConversionJobs of a ModelVersion
+func (c *ConversionJobAPIController) GetModelVersionConversionJobs(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetConversionJobs(listOptions, &modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// StartConversionJob - Start a ConversionJob of a ModelVersion, the converter completes it asynchronously
+func (c *ConversionJobAPIController) StartConversionJob(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	jobParam := api.ConversionJob{}
+	if err := decodeStrict(r, &jobParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).StartConversionJob(modelversionIdParam, &jobParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusAccepted, result, err)
+}
@@ -0,0 +1,57 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// DeployableAPIController binds http requests for the deploy gates of the model versions to the core api and writes
+// the results to the http response
+type DeployableAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewDeployableAPIController creates a default deployable api controller
+func NewDeployableAPIController(coreApi api.ModelRegistryApi) *DeployableAPIController {
+	return &DeployableAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the DeployableAPIController
+func (c *DeployableAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the DeployableAPIController
+func (c *DeployableAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"IsModelVersionDeployable",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}:deployable",
+			c.IsModelVersionDeployable,
+		},
+	}
+}
+
+// IsModelVersionDeployable - Evaluate the deploy gates of a ModelVersion, the response is 200 whether it passes them
+// or not
+func (c *DeployableAPIController) IsModelVersionDeployable(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).IsModelVersionDeployable(modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,109 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/deploygate"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelVersionDeployable(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+
+	deployable := func(modelVersionId string) (int, api.Deployability) {
+		resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s:deployable", server.URL, modelVersionId))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var deployability api.Deployability
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&deployability))
+		}
+		return resp.StatusCode, deployability
+	}
+
+	// without gates the model versions are deployable unless archived
+	status, deployability := deployable(*version.Id)
+	require.Equal(t, http.StatusOK, status)
+	assert.True(t, deployability.Deployable)
+	assert.Equal(t, []api.GateResult{{Gate: api.DeployGateState, Passed: true}}, deployability.Gates)
+
+	status, _ = deployable("999")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	gates, err := deploygate.NewGates(&deploygate.Config{Gates: api.DeployGates{
+		Approval:    &api.ApprovalGate{Stages: []api.ModelVersionStage{api.StageProduction}},
+		Signature:   &api.SignatureGate{Property: "signature_verified"},
+		Scans:       []api.ScanGate{{Property: "security_scan"}},
+		Evaluations: &api.EvaluationsGate{},
+	}})
+	require.NoError(t, err)
+	service.SetDeployGates(gates)
+
+	_, err = service.UpsertModelVersionPolicy(*version.Id, &api.ModelVersionPolicy{
+		RequiredEvaluations: []api.EvaluationRequirement{{Suite: "accuracy", MinScore: apiutils.Of(0.9)}},
+	})
+	require.NoError(t, err)
+	artifact, err := service.UpsertModelVersionArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{
+		Name: apiutils.Of("model"),
+		Uri:  apiutils.Of("s3://models/churn/v1"),
+	}}, *version.Id)
+	require.NoError(t, err)
+
+	status, deployability = deployable(*version.Id)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, deployability.Deployable)
+	require.Len(t, deployability.Gates, 5)
+	for _, gate := range deployability.Gates[1:] {
+		assert.False(t, gate.Passed, gate.Gate)
+	}
+	assert.Len(t, deployability.Reasons, 4)
+
+	// the gates pass once the model version is approved, signed, scanned and evaluated
+	_, err = service.TransitionModelVersionStage(*version.Id, &api.StageTransition{ToStage: api.StageStaging})
+	require.NoError(t, err)
+	_, err = service.TransitionModelVersionStage(*version.Id, &api.StageTransition{ToStage: api.StageProduction})
+	require.NoError(t, err)
+
+	artifact.ModelArtifact.CustomProperties = map[string]openapi.MetadataValue{
+		"signature_verified": openapi.MetadataBoolValueAsMetadataValue(openapi.NewMetadataBoolValue(true, "MetadataBoolValue")),
+	}
+	_, err = service.UpsertModelVersionArtifact(artifact, *version.Id)
+	require.NoError(t, err)
+
+	scanned := func(scan string, score float64) {
+		mv, err := service.GetModelVersionById(*version.Id)
+		require.NoError(t, err)
+		mv.CustomProperties["security_scan"] = openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue(scan, "MetadataStringValue"))
+		mv.CustomProperties["eval.accuracy"] = openapi.MetadataDoubleValueAsMetadataValue(openapi.NewMetadataDoubleValue(score, "MetadataDoubleValue"))
+		_, err = service.UpsertModelVersion(mv, model.Id)
+		require.NoError(t, err)
+	}
+
+	scanned("failed", 0.8)
+	status, deployability = deployable(*version.Id)
+	require.Equal(t, http.StatusOK, status)
+	assert.False(t, deployability.Deployable)
+	assert.Equal(t, []string{
+		fmt.Sprintf("model version %s did not pass security_scan: failed", *version.Id),
+		fmt.Sprintf("model version %s scored 0.8 on evaluation accuracy, below the minimum score 0.9", *version.Id),
+	}, deployability.Reasons)
+
+	scanned("Passed", 0.95)
+	status, deployability = deployable(*version.Id)
+	require.Equal(t, http.StatusOK, status)
+	assert.True(t, deployability.Deployable, deployability.Reasons)
+	assert.Empty(t, deployability.Reasons)
+}
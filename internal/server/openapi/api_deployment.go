@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// DeploymentAPIController binds http requests for the deployments of model versions to inference services
+// to the core api and writes the results to the http response
+type DeploymentAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewDeploymentAPIController creates a default deployment api controller
+func NewDeploymentAPIController(coreApi api.ModelRegistryApi) *DeploymentAPIController {
+	return &DeploymentAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the DeploymentAPIController
+func (c *DeploymentAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the DeploymentAPIController
+func (c *DeploymentAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetDeployments",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/deployments",
+			c.GetDeployments,
+		},
+		{
+			"GetDeployment",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/deployments/{deploymentId}",
+			c.GetDeployment,
+		},
+		{
+			"GetInferenceServiceDeployments",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/inference_services/{inferenceserviceId}/deployments",
+			c.GetInferenceServiceDeployments,
+		},
+		{
+			"DeployModelVersion",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/deployments",
+			c.DeployModelVersion,
+		},
+	}
+}
+
+// GetDeployments - List all Deployments
+func (c *DeploymentAPIController) GetDeployments(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetDeployments(listOptions, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetDeployment - Get a Deployment
+func (c *DeploymentAPIController) GetDeployment(w http.ResponseWriter, r *http.Request) {
+	deploymentIdParam := chi.URLParam(r, "deploymentId")
+	if deploymentIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"deploymentId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetDeploymentById(deploymentIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetInferenceServiceDeployments - List the deployment history of an InferenceService
+func (c *DeploymentAPIController) GetInferenceServiceDeployments(w http.ResponseWriter, r *http.Request) {
+	inferenceserviceIdParam := chi.URLParam(r, "inferenceserviceId")
+	if inferenceserviceIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"inferenceserviceId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetDeployments(listOptions, &inferenceserviceIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// DeployModelVersion - Move a ModelVersion to a stage and deploy it to an InferenceService, rolled back on failure
+func (c *DeploymentAPIController) DeployModelVersion(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	deploymentParam := api.Deployment{}
+	if err := decodeStrict(r, &deploymentParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).DeployModelVersion(modelversionIdParam, &deploymentParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusAccepted, result, err)
+}
@@ -0,0 +1,134 @@
+package openapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/deployment"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// deploymentHookFunc is a deployment.Hook calling the function
+type deploymentHookFunc func(ctx context.Context, request deployment.Request) error
+
+func (f deploymentHookFunc) Apply(ctx context.Context, request deployment.Request) error {
+	return f(ctx, request)
+}
+
+func TestDeployModelVersion(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	var hookErr error
+	service.SetDeploymentHook(deploymentHookFunc(func(ctx context.Context, request deployment.Request) error {
+		assert.Equal(t, request.Deployment.ModelVersionId, *request.InferenceService.ModelVersionId)
+		assert.Equal(t, openapi.INFERENCESERVICESTATE_DEPLOYED, *request.InferenceService.DesiredState)
+		return hookErr
+	}))
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	v1, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	v2, err := service.UpsertModelVersion(&openapi.ModelVersion{
+		Name: "v2",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"stage": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("Staging", "MetadataStringValue")),
+		},
+	}, model.Id)
+	require.NoError(t, err)
+	environment, err := service.UpsertServingEnvironment(&openapi.ServingEnvironment{Name: "prod"})
+	require.NoError(t, err)
+	inferenceService, err := service.UpsertInferenceService(&openapi.InferenceService{
+		Name:                 apiutils.Of("fraud"),
+		RegisteredModelId:    *model.Id,
+		ServingEnvironmentId: *environment.Id,
+	})
+	require.NoError(t, err)
+
+	deploy := func(modelVersionId string) api.Deployment {
+		body, err := json.Marshal(api.Deployment{InferenceServiceId: *inferenceService.Id})
+		require.NoError(t, err)
+		resp, err := http.Post(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/deployments", server.URL, modelVersionId), "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		var started api.Deployment
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&started))
+		assert.Equal(t, api.DeploymentRunning, started.State)
+		assert.Equal(t, api.DefaultDeploymentStage, started.Stage)
+
+		var completed *api.Deployment
+		require.Eventually(t, func() bool {
+			completed, err = service.GetDeploymentById(started.Id)
+			require.NoError(t, err)
+			return completed.State != api.DeploymentRunning
+		}, 5*time.Second, 10*time.Millisecond)
+		return *completed
+	}
+
+	stage := func(modelVersionId string) string {
+		modelVersion, err := service.GetModelVersionById(modelVersionId)
+		require.NoError(t, err)
+		if value, ok := modelVersion.CustomProperties["stage"]; ok {
+			return value.MetadataStringValue.StringValue
+		}
+		return ""
+	}
+
+	deployed := deploy(*v1.Id)
+	assert.Equal(t, api.DeploymentSucceeded, deployed.State)
+	assert.Empty(t, deployed.PreviousStage)
+	assert.Empty(t, deployed.PreviousModelVersionId)
+	assert.Equal(t, api.DefaultDeploymentStage, stage(*v1.Id))
+
+	updated, err := service.GetInferenceServiceById(*inferenceService.Id)
+	require.NoError(t, err)
+	assert.Equal(t, *v1.Id, *updated.ModelVersionId)
+	assert.Equal(t, openapi.INFERENCESERVICESTATE_DEPLOYED, *updated.DesiredState)
+
+	// the stage of v2 and the inference service serving v1 are restored
+	hookErr = errors.New("admission webhook denied the request")
+	rolledBack := deploy(*v2.Id)
+	assert.Equal(t, api.DeploymentRolledBack, rolledBack.State)
+	assert.Contains(t, rolledBack.Message, "admission webhook denied the request")
+	assert.Equal(t, "Staging", rolledBack.PreviousStage)
+	assert.Equal(t, *v1.Id, rolledBack.PreviousModelVersionId)
+	assert.Equal(t, "Staging", stage(*v2.Id))
+
+	updated, err = service.GetInferenceServiceById(*inferenceService.Id)
+	require.NoError(t, err)
+	assert.Equal(t, *v1.Id, *updated.ModelVersionId)
+	assert.Equal(t, openapi.INFERENCESERVICESTATE_DEPLOYED, *updated.DesiredState)
+
+	// deployment history of the inference service
+	resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/inference_services/%s/deployments?orderBy=ID", server.URL, *inferenceService.Id))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history api.DeploymentList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Items, 2)
+	assert.Equal(t, deployed.Id, history.Items[0].Id)
+	assert.Equal(t, rolledBack.Id, history.Items[1].Id)
+
+	// inference services serve the model versions of their registered model
+	other, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+	otherVersion, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, other.Id)
+	require.NoError(t, err)
+	_, err = service.DeployModelVersion(*otherVersion.Id, &api.Deployment{InferenceServiceId: *inferenceService.Id})
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+}
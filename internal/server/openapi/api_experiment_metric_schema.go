@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ExperimentMetricSchemaAPIController binds http requests for the metric schemas of experiments to the core api and
+// writes the results to the http response
+type ExperimentMetricSchemaAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewExperimentMetricSchemaAPIController creates a default experiment metric schema api controller
+func NewExperimentMetricSchemaAPIController(coreApi api.ModelRegistryApi) *ExperimentMetricSchemaAPIController {
+	return &ExperimentMetricSchemaAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ExperimentMetricSchemaAPIController
+func (c *ExperimentMetricSchemaAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ExperimentMetricSchemaAPIController
+func (c *ExperimentMetricSchemaAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetExperimentMetricSchema",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/experiments/{experimentId}/metric_schema",
+			c.GetExperimentMetricSchema,
+		},
+		{
+			"UpsertExperimentMetricSchema",
+			strings.ToUpper("Put"),
+			"/api/model_registry/v1alpha3/experiments/{experimentId}/metric_schema",
+			c.UpsertExperimentMetricSchema,
+		},
+	}
+}
+
+// GetExperimentMetricSchema - Get the metrics declared for the runs of an Experiment
+func (c *ExperimentMetricSchemaAPIController) GetExperimentMetricSchema(w http.ResponseWriter, r *http.Request) {
+	experimentIdParam := chi.URLParam(r, "experimentId")
+	if experimentIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"experimentId"}, nil)
+		return
+	}
+	schema, err := api.WithContext(r.Context(), c.coreApi).GetExperimentMetricSchema(experimentIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, schema, err)
+}
+
+// UpsertExperimentMetricSchema - Replace the metrics declared for the runs of an Experiment
+func (c *ExperimentMetricSchemaAPIController) UpsertExperimentMetricSchema(w http.ResponseWriter, r *http.Request) {
+	experimentIdParam := chi.URLParam(r, "experimentId")
+	if experimentIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"experimentId"}, nil)
+		return
+	}
+	schemaParam := api.ExperimentMetricSchema{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&schemaParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	schema, err := api.WithContext(r.Context(), c.coreApi).UpsertExperimentMetricSchema(experimentIdParam, &schemaParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, schema, err)
+}
@@ -0,0 +1,159 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExperimentMetricSchema(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "tuning"})
+	require.NoError(t, err)
+	schemaURL := fmt.Sprintf("%s/api/model_registry/v1alpha3/experiments/%s/metric_schema", server.URL, *experiment.Id)
+
+	put := func(body string) (int, api.ExperimentMetricSchema) {
+		req, err := http.NewRequest(http.MethodPut, schemaURL, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var schema api.ExperimentMetricSchema
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&schema))
+		}
+		return resp.StatusCode, schema
+	}
+
+	resp, err := http.Get(schemaURL)
+	require.NoError(t, err)
+	var schema api.ExperimentMetricSchema
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&schema))
+	resp.Body.Close()
+	assert.Equal(t, api.ExperimentMetricSchema{ExperimentId: *experiment.Id, Metrics: []api.MetricSpec{}}, schema)
+
+	for _, body := range []string{
+		`{"metrics": [{"direction": "HIGHER_IS_BETTER"}]}`,
+		`{"metrics": [{"name": "loss", "direction": "LOWER_IS_BETTER"}, {"name": "loss", "direction": "LOWER_IS_BETTER"}]}`,
+		`{"metrics": [{"name": "loss", "direction": "down"}]}`,
+		`{"metrics": [{"name": "loss", "type": "STRING", "direction": "LOWER_IS_BETTER"}]}`,
+	} {
+		status, _ := put(body)
+		assert.Equal(t, http.StatusBadRequest, status, body)
+	}
+
+	status, schema := put(`{"metrics": [
+		{"name": "accuracy", "direction": "HIGHER_IS_BETTER"},
+		{"name": "loss", "direction": "LOWER_IS_BETTER", "description": "validation loss"},
+		{"name": "epochs", "type": "INT", "direction": "LOWER_IS_BETTER"}
+	]}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []api.MetricSpec{
+		{Name: "accuracy", Type: api.MetricValueDouble, Direction: api.MetricHigherIsBetter},
+		{Name: "loss", Type: api.MetricValueDouble, Direction: api.MetricLowerIsBetter, Description: "validation loss"},
+		{Name: "epochs", Type: api.MetricValueInt, Direction: api.MetricLowerIsBetter},
+	}, schema.Metrics)
+
+	// updating the experiment keeps its schema
+	experiment.Description = openapi.PtrString("hyperparameter search")
+	_, err = service.UpsertExperiment(experiment)
+	require.NoError(t, err)
+	kept, err := service.GetExperimentMetricSchema(*experiment.Id)
+	require.NoError(t, err)
+	assert.Len(t, kept.Metrics, 3)
+
+	logRun := func(name string, metric string, values ...float64) (string, error) {
+		run, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: openapi.PtrString(name)}, experiment.Id)
+		require.NoError(t, err)
+		// the history before the last value is inserted directly, the metric logs the last value
+		last := len(values) - 1
+		for i, value := range values[:last] {
+			require.NoError(t, service.InsertMetricHistory(&openapi.Metric{
+				Name:                     openapi.PtrString(metric),
+				Value:                    openapi.PtrFloat64(value),
+				Step:                     openapi.PtrInt64(int64(i)),
+				LastUpdateTimeSinceEpoch: openapi.PtrString(strconv.Itoa(1000 + i)),
+			}, *run.Id))
+		}
+		_, err = service.UpsertExperimentRunArtifact(&openapi.Artifact{Metric: &openapi.Metric{
+			Name:  openapi.PtrString(metric),
+			Value: openapi.PtrFloat64(values[last]),
+			Step:  openapi.PtrInt64(int64(last)),
+		}}, *run.Id)
+		return *run.Id, err
+	}
+
+	// the runs only log the declared metrics, with values of their type
+	_, err = logRun("typo", "acuracy", 0.9)
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+	assert.ErrorContains(t, err, "declared metrics are accuracy, loss, epochs")
+	_, err = logRun("fractional", "epochs", 2.5)
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+	_, err = logRun("counted", "epochs", 3)
+	assert.NoError(t, err)
+
+	first, err := logRun("first", "loss", 0.9, 0.4, 0.6)
+	require.NoError(t, err)
+	second, err := logRun("second", "loss", 0.3)
+	require.NoError(t, err)
+	third, err := logRun("third", "loss", 0.5, 0.4)
+	require.NoError(t, err)
+	_, err = logRun("fourth", "accuracy", 0.8)
+	require.NoError(t, err)
+
+	leaderboard := func(metric string) (int, api.RunLeaderboard) {
+		resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/experiments/%s/runs:leaderboard?metric=%s", server.URL, *experiment.Id, metric))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var result api.RunLeaderboard
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	// the runs are ranked by their lowest loss, the ties in the order of the runs
+	status, ranked := leaderboard("loss")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, api.RunLeaderboard{
+		Metric:    "loss",
+		Direction: api.MetricLowerIsBetter,
+		Items: []api.RankedRunMetric{
+			{Rank: 1, ExperimentRunId: second, Value: 0.3, Count: 1},
+			{Rank: 2, ExperimentRunId: first, Value: 0.4, Count: 3},
+			{Rank: 3, ExperimentRunId: third, Value: 0.4, Count: 2},
+		},
+		Size: 3,
+	}, ranked)
+
+	status, ranked = leaderboard("accuracy")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, api.MetricHigherIsBetter, ranked.Direction)
+	assert.Equal(t, int32(1), ranked.Size)
+
+	status, _ = leaderboard("f1")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = leaderboard("")
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+
+	// without metrics the runs log any metric again
+	status, _ = put(`{"metrics": []}`)
+	require.Equal(t, http.StatusOK, status)
+	_, err = logRun("free", "acuracy", 0.9)
+	assert.NoError(t, err)
+
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/experiments/999/metric_schema")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ExternalIdAPIController binds http requests looking up entities of any type by external id
+// to the core api and writes the results to the http response
+type ExternalIdAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewExternalIdAPIController creates a default external id api controller
+func NewExternalIdAPIController(coreApi api.ModelRegistryApi) *ExternalIdAPIController {
+	return &ExternalIdAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ExternalIdAPIController
+func (c *ExternalIdAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ExternalIdAPIController
+func (c *ExternalIdAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetEntitiesByExternalId",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/entities:byExternalId",
+			c.GetEntitiesByExternalId,
+		},
+	}
+}
+
+// GetEntitiesByExternalId - Get references to the entities of any type with the given external id
+func (c *ExternalIdAPIController) GetEntitiesByExternalId(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	externalIdParam := query.Get("externalId")
+	if externalIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"externalId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetEntitiesByExternalId(externalIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,156 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/lineage"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// LineageAPIController binds http requests for the lineage of registered models and model versions to the core api
+// and writes the results to the http response
+type LineageAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewLineageAPIController creates a default lineage api controller
+func NewLineageAPIController(coreApi api.ModelRegistryApi) *LineageAPIController {
+	return &LineageAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the LineageAPIController
+func (c *LineageAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the LineageAPIController
+func (c *LineageAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetRegisteredModelLineage",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/lineage",
+			c.GetRegisteredModelLineage,
+		},
+		{
+			"GetModelVersionLineage",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/lineage",
+			c.GetModelVersionLineage,
+		},
+		{
+			"ExportModelVersionLineage",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/lineage:export",
+			c.ExportModelVersionLineage,
+		},
+	}
+}
+
+// GetRegisteredModelLineage - Get the graph of the versions, artifacts, experiment runs and experiments of a
+// RegisteredModel, up to depth links away
+func (c *LineageAPIController) GetRegisteredModelLineage(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	depthParam, err := parseLineageDepth(r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetRegisteredModelLineage(registeredmodelIdParam, depthParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetModelVersionLineage - Get the graph of the registered model, artifacts, experiment runs and experiments of a
+// ModelVersion, up to depth links away
+func (c *LineageAPIController) GetModelVersionLineage(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	depthParam, err := parseLineageDepth(r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionLineage(modelversionIdParam, depthParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// ExportModelVersionLineage - Export the lineage of a ModelVersion as a graph file, in the dot format of Graphviz
+// or in GraphML
+func (c *LineageAPIController) ExportModelVersionLineage(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	depthParam, err := parseLineageDepth(r)
+	if err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	formatParam := r.URL.Query().Get("format")
+	if formatParam == "" {
+		formatParam = lineage.FormatDOT
+	}
+	contentType, ok := lineage.ContentType(formatParam)
+	if !ok {
+		c.errorHandler(w, r, &ParsingError{Param: "format", Err: fmt.Errorf("must be %s or %s", lineage.FormatDOT, lineage.FormatGraphML)}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionLineage(modelversionIdParam, depthParam)
+	if err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+		return
+	}
+
+	// the graph files are not redacted by the middleware as the JSON responses
+	if slices.Contains(converter.RedactedFields(r.Context()), "name") {
+		for i := range result.Nodes {
+			result.Nodes[i].Name = ""
+		}
+	}
+
+	var graph bytes.Buffer
+	if err := lineage.Export(&graph, result, formatParam); err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"model-version-%s-lineage.%s\"", modelversionIdParam, formatParam))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(graph.Bytes())
+}
+
+// parseLineageDepth returns the depth query parameter, api.DefaultLineageDepth if unset.
+func parseLineageDepth(r *http.Request) (int32, error) {
+	param := r.URL.Query().Get("depth")
+	if param == "" {
+		return api.DefaultLineageDepth, nil
+	}
+	depth, err := strconv.Atoi(param)
+	if err != nil || depth < 1 || depth > api.MaxLineageDepth {
+		return 0, &ParsingError{Param: "depth", Err: fmt.Errorf("must be between 1 and %d", api.MaxLineageDepth)}
+	}
+	return int32(depth), nil
+}
@@ -0,0 +1,107 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisteredModelLineage(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "fraud-training"})
+	require.NoError(t, err)
+	run, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: openapi.PtrString("run-1")}, experiment.Id)
+	require.NoError(t, err)
+	otherRun, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: openapi.PtrString("run-2")}, experiment.Id)
+	require.NoError(t, err)
+
+	dataSet, err := service.UpsertExperimentRunArtifact(&openapi.Artifact{DataSet: &openapi.DataSet{Name: openapi.PtrString("transactions"), Uri: openapi.PtrString("s3://data/transactions")}}, *run.Id)
+	require.NoError(t, err)
+	artifact, err := service.UpsertExperimentRunArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{Name: openapi.PtrString("model"), Uri: openapi.PtrString("s3://models/fraud")}}, *run.Id)
+	require.NoError(t, err)
+	// the artifact logged by the run is registered as the version
+	_, err = service.UpsertModelVersionArtifact(artifact, *version.Id)
+	require.NoError(t, err)
+
+	get := func(query string, out any) int {
+		resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s/lineage%s", server.URL, *model.Id, query))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var lineage api.Lineage
+	require.Equal(t, http.StatusOK, get("", &lineage))
+	assert.Equal(t, *model.Id, lineage.RootId)
+	assert.EqualValues(t, api.DefaultLineageDepth, lineage.Depth)
+
+	depths := map[string]int32{}
+	for _, node := range lineage.Nodes {
+		depths[node.Type+"/"+node.Name] = node.Depth
+	}
+	assert.Equal(t, map[string]int32{
+		"kf.RegisteredModel/fraud":     0,
+		"kf.ModelVersion/v1":           1,
+		"kf.ModelArtifact/model":       2,
+		"kf.ExperimentRun/run-1":       3,
+		"kf.Experiment/fraud-training": 4,
+		"kf.DataSet/transactions":      4,
+	}, depths)
+	assert.NotContains(t, depths, "kf.ExperimentRun/"+*otherRun.Name)
+	assert.Contains(t, lineage.Edges, api.LineageEdge{ContextId: *run.Id, Kind: api.LineageArtifact, Id: *dataSet.DataSet.Id})
+	assert.Contains(t, lineage.Edges, api.LineageEdge{ContextId: *experiment.Id, Kind: api.LineageContext, Id: *run.Id})
+
+	var shallow api.Lineage
+	require.Equal(t, http.StatusOK, get("?depth=2", &shallow))
+	assert.Len(t, shallow.Nodes, 3)
+	assert.Len(t, shallow.Edges, 2)
+
+	assert.Equal(t, http.StatusBadRequest, get(fmt.Sprintf("?depth=%d", api.MaxLineageDepth+1), nil))
+	assert.Equal(t, http.StatusBadRequest, get("?depth=0", nil))
+
+	// the lineage of the version has its registered model but not the other versions
+	_, err = service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+	var versionLineage api.Lineage
+	resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/lineage", server.URL, *version.Id))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&versionLineage))
+	names := []string{}
+	for _, node := range versionLineage.Nodes {
+		names = append(names, node.Name)
+	}
+	assert.ElementsMatch(t, []string{"v1", "fraud", "model", "run-1", "fraud-training", "transactions"}, names)
+
+	export, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/lineage:export?format=graphml", server.URL, *version.Id))
+	require.NoError(t, err)
+	defer export.Body.Close()
+	require.Equal(t, http.StatusOK, export.StatusCode)
+	assert.Equal(t, "application/graphml+xml", export.Header.Get("Content-Type"))
+	graph, err := io.ReadAll(export.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(graph), `<edge source="context/`+*run.Id+`" target="artifact/`+*dataSet.DataSet.Id+`">`)
+
+	unsupported, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/lineage:export?format=svg", server.URL, *version.Id))
+	require.NoError(t, err)
+	defer unsupported.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, unsupported.StatusCode)
+}
@@ -0,0 +1,100 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRegisteredModelMergePatch(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name:        "fraud",
+		Description: openapi.PtrString("detects fraud"),
+		Owner:       openapi.PtrString("alice"),
+		CustomProperties: map[string]openapi.MetadataValue{
+			"team":  {MetadataStringValue: openapi.NewMetadataStringValue("risk", "MetadataStringValue")},
+			"stage": {MetadataStringValue: openapi.NewMetadataStringValue("dev", "MetadataStringValue")},
+		},
+	})
+	require.NoError(t, err)
+
+	patch := func(body string) (int, openapi.RegisteredModel) {
+		path := fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s", server.URL, *model.Id)
+		req, err := http.NewRequest(http.MethodPatch, path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var model openapi.RegisteredModel
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&model))
+		}
+		return resp.StatusCode, model
+	}
+
+	// a single custom property is changed, the others are kept
+	status, updated := patch(`{"customProperties": {"stage": {"metadataType": "MetadataStringValue", "string_value": "prod"}}}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "prod", updated.GetCustomProperties()["stage"].MetadataStringValue.StringValue)
+	assert.Equal(t, "risk", updated.GetCustomProperties()["team"].MetadataStringValue.StringValue)
+	assert.Equal(t, "detects fraud", updated.GetDescription())
+
+	// null removes a custom property and a field
+	status, updated = patch(`{"description": null, "customProperties": {"team": null}}`)
+	require.Equal(t, http.StatusOK, status)
+	assert.Nil(t, updated.Description)
+	assert.Equal(t, "alice", updated.GetOwner())
+	assert.NotContains(t, updated.GetCustomProperties(), "team")
+	assert.Contains(t, updated.GetCustomProperties(), "stage")
+
+	stored, err := service.GetRegisteredModelById(*model.Id)
+	require.NoError(t, err)
+	assert.Nil(t, stored.Description)
+	assert.Equal(t, "alice", stored.GetOwner())
+
+	status, _ = patch(`{"externalId": null}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = patch(`{"name": "renamed"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = patch(`["description"]`)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestUpdateArtifactMergePatch(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	artifact, err := service.UpsertArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{
+		Name:        openapi.PtrString("weights"),
+		Uri:         openapi.PtrString("s3://models/fraud"),
+		Description: openapi.PtrString("the weights"),
+	}})
+	require.NoError(t, err)
+
+	path := fmt.Sprintf("%s/api/model_registry/v1alpha3/artifacts/%s", server.URL, *artifact.ModelArtifact.Id)
+	req, err := http.NewRequest(http.MethodPatch, path, strings.NewReader(`{"artifactType": "model-artifact", "description": null, "modelFormatName": "onnx"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated openapi.Artifact
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&updated))
+	require.NotNil(t, updated.ModelArtifact)
+	assert.Nil(t, updated.ModelArtifact.Description)
+	assert.Equal(t, "onnx", updated.ModelArtifact.GetModelFormatName())
+	assert.Equal(t, "s3://models/fraud", updated.ModelArtifact.GetUri())
+}
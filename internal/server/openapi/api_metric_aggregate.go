@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// MetricAggregateAPIController binds http requests for the aggregations of the metric history of the runs of
+// experiments to the core api and writes the results to the http response
+type MetricAggregateAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewMetricAggregateAPIController creates a default metric aggregate api controller
+func NewMetricAggregateAPIController(coreApi api.ModelRegistryApi) *MetricAggregateAPIController {
+	return &MetricAggregateAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the MetricAggregateAPIController
+func (c *MetricAggregateAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the MetricAggregateAPIController
+func (c *MetricAggregateAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetExperimentRunsMetricAggregate",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/experiments/{experimentId}/runs:aggregate",
+			c.GetExperimentRunsMetricAggregate,
+		},
+		{
+			"GetExperimentRunsLeaderboard",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/experiments/{experimentId}/runs:leaderboard",
+			c.GetExperimentRunsLeaderboard,
+		},
+	}
+}
+
+// GetExperimentRunsMetricAggregate - Aggregate the metric history of the metric query parameter of the runs of an
+// Experiment with the agg query parameter, one of max, min or avg
+func (c *MetricAggregateAPIController) GetExperimentRunsMetricAggregate(w http.ResponseWriter, r *http.Request) {
+	experimentIdParam := chi.URLParam(r, "experimentId")
+	if experimentIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"experimentId"}, nil)
+		return
+	}
+	query := r.URL.Query()
+	metricParam := query.Get("metric")
+	if metricParam == "" {
+		c.errorHandler(w, r, &RequiredError{"metric"}, nil)
+		return
+	}
+	aggParam := query.Get("agg")
+	if aggParam == "" {
+		c.errorHandler(w, r, &RequiredError{"agg"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetExperimentRunsMetricAggregate(experimentIdParam, metricParam, aggParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetExperimentRunsLeaderboard - Rank the runs of an Experiment by the best value of the metric query parameter, in the
+// direction declared by the metric schema of the Experiment
+func (c *MetricAggregateAPIController) GetExperimentRunsLeaderboard(w http.ResponseWriter, r *http.Request) {
+	experimentIdParam := chi.URLParam(r, "experimentId")
+	if experimentIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"experimentId"}, nil)
+		return
+	}
+	metricParam := r.URL.Query().Get("metric")
+	if metricParam == "" {
+		c.errorHandler(w, r, &RequiredError{"metric"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetExperimentRunsLeaderboard(experimentIdParam, metricParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,90 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricAggregate(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "tuning"})
+	require.NoError(t, err)
+	other, err := service.UpsertExperiment(&openapi.Experiment{Name: "baseline"})
+	require.NoError(t, err)
+
+	history := map[string][]float64{}
+	logRun := func(experimentId *string, name string, metric string, values ...float64) string {
+		run, err := service.UpsertExperimentRun(&openapi.ExperimentRun{Name: openapi.PtrString(name)}, experimentId)
+		require.NoError(t, err)
+		for i, value := range values {
+			require.NoError(t, service.InsertMetricHistory(&openapi.Metric{
+				Name:                     openapi.PtrString(metric),
+				Value:                    openapi.PtrFloat64(value),
+				Step:                     openapi.PtrInt64(int64(i)),
+				LastUpdateTimeSinceEpoch: openapi.PtrString(strconv.Itoa(1000 + i)),
+			}, *run.Id))
+		}
+		history[*run.Id] = values
+		return *run.Id
+	}
+	first := logRun(experiment.Id, "first", "accuracy", 0.5, 0.7, 0.9)
+	second := logRun(experiment.Id, "second", "accuracy", 0.6, 0.8)
+	// neither a run without history of the metric, nor metrics prefixed with its name, nor other experiments count
+	logRun(experiment.Id, "third", "loss", 0.1)
+	logRun(experiment.Id, "fourth", "accuracy__top5", 0.99)
+	logRun(other.Id, "fifth", "accuracy", 0.1)
+
+	get := func(query string, out any) int {
+		resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/experiments/%s/runs:aggregate?%s", server.URL, *experiment.Id, query))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var result api.RunMetricAggregateList
+	require.Equal(t, http.StatusOK, get("metric=accuracy&agg=max", &result))
+	assert.Equal(t, "accuracy", result.Metric)
+	assert.Equal(t, "max", result.Aggregation)
+	require.Equal(t, int32(2), result.Size)
+	assert.Equal(t, api.RunMetricAggregate{ExperimentRunId: first, Value: 0.9, Count: 3}, result.Items[0])
+	assert.Equal(t, api.RunMetricAggregate{ExperimentRunId: second, Value: 0.8, Count: 2}, result.Items[1])
+	require.NotNil(t, result.Value)
+	assert.Equal(t, 0.9, *result.Value)
+
+	require.Equal(t, http.StatusOK, get("metric=accuracy&agg=min", &result))
+	assert.Equal(t, 0.5, result.Items[0].Value)
+	assert.Equal(t, 0.5, *result.Value)
+
+	// the average over all the runs is the one of all their values, not the average of the averages of the runs
+	require.Equal(t, http.StatusOK, get("metric=accuracy&agg=avg", &result))
+	assert.InDelta(t, 0.7, result.Items[0].Value, 1e-9)
+	assert.InDelta(t, 0.7, result.Items[1].Value, 1e-9)
+	assert.InDelta(t, 0.7, *result.Value, 1e-9)
+
+	var empty api.RunMetricAggregateList
+	require.Equal(t, http.StatusOK, get("metric=f1&agg=max", &empty))
+	assert.Empty(t, empty.Items)
+	assert.Nil(t, empty.Value)
+
+	assert.Equal(t, http.StatusBadRequest, get("metric=accuracy&agg=median", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, get("agg=max", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, get("metric=accuracy", nil))
+
+	resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/experiments/999/runs:aggregate?metric=accuracy&agg=max")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,129 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// MetricsTableAPIController binds http requests for the metrics tables of model versions to the core api and writes
+// the results to the http response
+type MetricsTableAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewMetricsTableAPIController creates a default metrics table api controller
+func NewMetricsTableAPIController(coreApi api.ModelRegistryApi) *MetricsTableAPIController {
+	return &MetricsTableAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the MetricsTableAPIController
+func (c *MetricsTableAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the MetricsTableAPIController
+func (c *MetricsTableAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetMetricsTables",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/metrics_tables",
+			c.GetMetricsTables,
+		},
+		{
+			"GetMetricsTable",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/metrics_tables/{metricstableId}",
+			c.GetMetricsTable,
+		},
+		{
+			"GetModelVersionMetricsTables",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/metrics_tables",
+			c.GetModelVersionMetricsTables,
+		},
+		{
+			"CreateModelVersionMetricsTable",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/metrics_tables",
+			c.CreateModelVersionMetricsTable,
+		},
+	}
+}
+
+// GetMetricsTables - List all MetricsTables, without the values of their columns
+func (c *MetricsTableAPIController) GetMetricsTables(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetMetricsTables(listOptions, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetMetricsTable - Get a MetricsTable with the columns selected by the comma separated columns query parameter,
+// all of them if unset
+func (c *MetricsTableAPIController) GetMetricsTable(w http.ResponseWriter, r *http.Request) {
+	metricstableIdParam := chi.URLParam(r, "metricstableId")
+	if metricstableIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"metricstableId"}, nil)
+		return
+	}
+	var columns []string
+	if param := r.URL.Query().Get("columns"); param != "" {
+		for _, column := range strings.Split(param, ",") {
+			if column = strings.TrimSpace(column); column == "" {
+				c.errorHandler(w, r, &ParsingError{Param: "columns", Err: errors.New("empty column name")}, nil)
+				return
+			}
+			columns = append(columns, column)
+		}
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetMetricsTableById(metricstableIdParam, columns)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetModelVersionMetricsTables - List the MetricsTables of a ModelVersion, without the values of their columns
+func (c *MetricsTableAPIController) GetModelVersionMetricsTables(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetMetricsTables(listOptions, &modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// CreateModelVersionMetricsTable - Create a MetricsTable of a ModelVersion
+func (c *MetricsTableAPIController) CreateModelVersionMetricsTable(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	metricsTableParam := api.MetricsTable{}
+	if err := decodeStrict(r, &metricsTableParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).CreateMetricsTable(modelversionIdParam, &metricsTableParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
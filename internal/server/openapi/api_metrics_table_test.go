@@ -0,0 +1,97 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsTable(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "classifier"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+
+	baseURL := server.URL + "/api/model_registry/v1alpha3/"
+	post := func(path string, body any, out any) int {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		resp, err := http.Post(baseURL+path, "application/json", bytes.NewReader(encoded))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+	get := func(path string, out any) int {
+		resp, err := http.Get(baseURL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	columns := []api.MetricsTableColumn{
+		{Name: "class", Type: api.MetricsTableString, StringValues: []string{"cat", "dog", "bird"}},
+		{Name: "precision", Type: api.MetricsTableDouble, DoubleValues: []float64{0.91, 0.88, 0.5}},
+		{Name: "recall", Type: api.MetricsTableDouble, DoubleValues: []float64{0.87, 0.9, 0.42}},
+	}
+	var created api.MetricsTable
+	require.Equal(t, http.StatusCreated, post(fmt.Sprintf("model_versions/%s/metrics_tables", *version.Id), api.MetricsTable{
+		Name:        "per-class",
+		Description: "per-class metrics on the test split",
+		Columns:     columns,
+	}, &created))
+	assert.Equal(t, "per-class", created.Name)
+	assert.Equal(t, *version.Id, created.ModelVersionId)
+	assert.Equal(t, int32(3), created.RowCount)
+	assert.Equal(t, columns, created.Columns)
+
+	// the columns are selected in the order of the selection
+	var selected api.MetricsTable
+	require.Equal(t, http.StatusOK, get(fmt.Sprintf("metrics_tables/%s?columns=recall,class", created.Id), &selected))
+	assert.Equal(t, []api.MetricsTableColumn{columns[2], columns[0]}, selected.Columns)
+	assert.Equal(t, http.StatusBadRequest, get(fmt.Sprintf("metrics_tables/%s?columns=f1", created.Id), nil))
+
+	// the columns must have a value per row
+	assert.Equal(t, http.StatusBadRequest, post(fmt.Sprintf("model_versions/%s/metrics_tables", *version.Id), api.MetricsTable{
+		Name: "ragged",
+		Columns: []api.MetricsTableColumn{
+			{Name: "class", Type: api.MetricsTableString, StringValues: []string{"cat", "dog"}},
+			{Name: "f1", Type: api.MetricsTableDouble, DoubleValues: []float64{0.9}},
+		},
+	}, nil))
+	assert.Equal(t, http.StatusConflict, post(fmt.Sprintf("model_versions/%s/metrics_tables", *version.Id), api.MetricsTable{
+		Name:    "per-class",
+		Columns: columns,
+	}, nil))
+
+	// the tables listed have the names and types of their columns only
+	var tables api.MetricsTableList
+	require.Equal(t, http.StatusOK, get(fmt.Sprintf("model_versions/%s/metrics_tables", *version.Id), &tables))
+	require.Len(t, tables.Items, 1)
+	assert.Equal(t, []api.MetricsTableColumn{
+		{Name: "class", Type: api.MetricsTableString},
+		{Name: "precision", Type: api.MetricsTableDouble},
+		{Name: "recall", Type: api.MetricsTableDouble},
+	}, tables.Items[0].Columns)
+	assert.Equal(t, int32(3), tables.Items[0].RowCount)
+
+	// metrics tables are not artifacts of the model version
+	artifacts, err := service.GetArtifacts("", api.ListOptions{}, version.Id)
+	require.NoError(t, err)
+	assert.Empty(t, artifacts.Items)
+}
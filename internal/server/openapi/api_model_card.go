@@ -0,0 +1,144 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/internal/modelcard"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelCardAPIController binds http requests for the model cards of registered models to the core api and writes
+// the results to the http response
+type ModelCardAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewModelCardAPIController creates a default model card api controller
+func NewModelCardAPIController(coreApi api.ModelRegistryApi) *ModelCardAPIController {
+	return &ModelCardAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ModelCardAPIController
+func (c *ModelCardAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelCardAPIController
+func (c *ModelCardAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetRegisteredModelCard",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/model_card",
+			c.GetRegisteredModelCard,
+		},
+		{
+			"UpsertRegisteredModelCard",
+			strings.ToUpper("Put"),
+			"/api/model_registry/v1alpha3/registered_models/{registeredmodelId}/model_card",
+			c.UpsertRegisteredModelCard,
+		},
+	}
+}
+
+// GetRegisteredModelCard - Get the ModelCard of a RegisteredModel, as JSON or rendered to Markdown with the format
+// query parameter
+func (c *ModelCardAPIController) GetRegisteredModelCard(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	formatParam := r.URL.Query().Get("format")
+	if formatParam == "" {
+		formatParam = modelcard.FormatJSON
+	}
+	contentType, ok := modelcard.ContentType(formatParam)
+	if !ok {
+		c.errorHandler(w, r, &ParsingError{Param: "format", Err: fmt.Errorf("must be %s or %s", modelcard.FormatJSON, modelcard.FormatMarkdown)}, nil)
+		return
+	}
+	service := api.WithContext(r.Context(), c.coreApi)
+	result, err := service.GetModelCard(registeredmodelIdParam)
+	if err != nil || formatParam == modelcard.FormatJSON {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+		return
+	}
+
+	model, err := service.GetRegisteredModelById(registeredmodelIdParam)
+	if err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+
+	// the rendered cards are not redacted by the middleware as the JSON responses
+	redacted := converter.RedactedFields(r.Context())
+	result, err = redactModelCard(result, redacted)
+	if err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+	modelName := model.Name
+	if slices.Contains(redacted, "name") {
+		modelName = "registered model " + registeredmodelIdParam
+	}
+
+	var document bytes.Buffer
+	if err := modelcard.Render(&document, modelName, result, formatParam); err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(document.Bytes())
+}
+
+// UpsertRegisteredModelCard - Create or replace the ModelCard of a RegisteredModel
+func (c *ModelCardAPIController) UpsertRegisteredModelCard(w http.ResponseWriter, r *http.Request) {
+	registeredmodelIdParam := chi.URLParam(r, "registeredmodelId")
+	if registeredmodelIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
+		return
+	}
+	modelCardParam := api.ModelCard{}
+	if err := decodeStrict(r, &modelCardParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).UpsertModelCard(registeredmodelIdParam, &modelCardParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// redactModelCard removes the redacted fields from the card as from its JSON responses.
+func redactModelCard(card *api.ModelCard, fields []string) (*api.ModelCard, error) {
+	if len(fields) == 0 {
+		return card, nil
+	}
+	data, err := json.Marshal(card)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = converter.RedactJSON(data, fields); err != nil {
+		return nil, err
+	}
+	redacted := &api.ModelCard{}
+	if err := json.Unmarshal(data, redacted); err != nil {
+		return nil, err
+	}
+	return redacted, nil
+}
@@ -0,0 +1,114 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelCard(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud-detector"})
+	require.NoError(t, err)
+
+	cardURL := fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s/model_card", server.URL, *model.Id)
+	put := func(body any, out any) int {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, cardURL, bytes.NewReader(encoded))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+	get := func(query string) (int, string, string) {
+		resp, err := http.Get(cardURL + query)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get("Content-Type"), string(body)
+	}
+
+	code, _, _ := get("")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	var created api.ModelCard
+	require.Equal(t, http.StatusOK, put(api.ModelCard{
+		IntendedUse: "Flagging fraudulent transactions for manual review.",
+		Limitations: []string{"Trained on EU transactions only"},
+		TrainingData: &api.ModelCardTrainingData{
+			Summary:  "12 months of labelled transactions.",
+			Datasets: []string{"s3://datasets/transactions-2025"},
+		},
+	}, &created))
+	assert.Equal(t, *model.Id, created.RegisteredModelId)
+	assert.Equal(t, []string{"Trained on EU transactions only"}, created.Limitations)
+
+	// the sections left out of a replacement are cleared
+	var replaced api.ModelCard
+	require.Equal(t, http.StatusOK, put(api.ModelCard{
+		IntendedUse:           "Flagging fraudulent transactions for manual review.",
+		EthicalConsiderations: []string{"False positives delay legitimate payments"},
+	}, &replaced))
+	assert.Equal(t, created.Id, replaced.Id)
+	assert.Equal(t, created.CreateTimeSinceEpoch, replaced.CreateTimeSinceEpoch)
+	assert.Empty(t, replaced.Limitations)
+	assert.Nil(t, replaced.TrainingData)
+
+	code, contentType, body := get("")
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, contentType, "application/json")
+	var fetched api.ModelCard
+	require.NoError(t, json.Unmarshal([]byte(body), &fetched))
+	assert.Equal(t, replaced, fetched)
+
+	code, contentType, body = get("?format=markdown")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "text/markdown; charset=UTF-8", contentType)
+	assert.Equal(t, `# Model card: fraud-detector
+
+## Intended use
+
+Flagging fraudulent transactions for manual review.
+
+## Ethical considerations
+
+- False positives delay legitimate payments
+`, body)
+
+	code, _, _ = get("?format=pdf")
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// the sections are validated
+	assert.Equal(t, http.StatusBadRequest, put(api.ModelCard{Limitations: []string{"no intended use"}}, nil))
+	assert.Equal(t, http.StatusBadRequest, put(api.ModelCard{IntendedUse: "screening", Limitations: []string{" "}}, nil))
+	assert.Equal(t, http.StatusBadRequest, put(api.ModelCard{IntendedUse: "screening", TrainingData: &api.ModelCardTrainingData{}}, nil))
+	assert.Equal(t, http.StatusBadRequest, put(map[string]any{"intendedUse": "screening", "owner": "risk"}, nil))
+
+	// the card is only attached to registered models
+	resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s/model_card", server.URL, "999"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// model cards are not listed as artifacts
+	artifacts, err := service.GetArtifacts("", api.ListOptions{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, artifacts.Items)
+}
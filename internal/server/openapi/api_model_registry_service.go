@@ -786,6 +786,13 @@ func (c *ModelRegistryServiceAPIController) GetArtifacts(w http.ResponseWriter,
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var artifactTypeParam model.ArtifactTypeQueryParam
 	if query.Has("artifactType") {
 		param := model.ArtifactTypeQueryParam(query.Get("artifactType"))
@@ -821,7 +828,7 @@ func (c *ModelRegistryServiceAPIController) GetArtifacts(w http.ResponseWriter,
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetArtifacts(r.Context(), filterQueryParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetArtifacts(r.Context(), filterQueryParam, qParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -990,6 +997,13 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRuns(w http.ResponseWri
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -1018,7 +1032,14 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRuns(w http.ResponseWri
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperimentRuns(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetExperimentRuns(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1069,6 +1090,13 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunsMetricHistory(w htt
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -1111,7 +1139,7 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunsMetricHistory(w htt
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperimentRunsMetricHistory(r.Context(), filterQueryParam, nameParam, stepIdsParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetExperimentRunsMetricHistory(r.Context(), filterQueryParam, qParam, nameParam, stepIdsParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1189,6 +1217,13 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunArtifacts(w http.Res
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -1238,7 +1273,14 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunArtifacts(w http.Res
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperimentRunArtifacts(r.Context(), experimentrunIdParam, filterQueryParam, nameParam, externalIdParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var roleParam string
+	if query.Has("role") {
+		param := query.Get("role")
+
+		roleParam = param
+	} else {
+	}
+	result, err := c.service.GetExperimentRunArtifacts(r.Context(), experimentrunIdParam, filterQueryParam, qParam, nameParam, externalIdParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, roleParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1299,6 +1341,13 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunMetricHistory(w http
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -1341,7 +1390,7 @@ func (c *ModelRegistryServiceAPIController) GetExperimentRunMetricHistory(w http
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperimentRunMetricHistory(r.Context(), experimentrunIdParam, filterQueryParam, nameParam, stepIdsParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetExperimentRunMetricHistory(r.Context(), experimentrunIdParam, filterQueryParam, qParam, nameParam, stepIdsParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1365,6 +1414,13 @@ func (c *ModelRegistryServiceAPIController) GetExperiments(w http.ResponseWriter
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -1393,7 +1449,14 @@ func (c *ModelRegistryServiceAPIController) GetExperiments(w http.ResponseWriter
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperiments(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetExperiments(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1512,6 +1575,13 @@ func (c *ModelRegistryServiceAPIController) GetExperimentExperimentRuns(w http.R
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -1540,7 +1610,7 @@ func (c *ModelRegistryServiceAPIController) GetExperimentExperimentRuns(w http.R
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetExperimentExperimentRuns(r.Context(), experimentIdParam, nameParam, externalIdParam, filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetExperimentExperimentRuns(r.Context(), experimentIdParam, nameParam, externalIdParam, filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1634,6 +1704,13 @@ func (c *ModelRegistryServiceAPIController) GetInferenceServices(w http.Response
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -1662,7 +1739,14 @@ func (c *ModelRegistryServiceAPIController) GetInferenceServices(w http.Response
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetInferenceServices(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetInferenceServices(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1784,6 +1868,13 @@ func (c *ModelRegistryServiceAPIController) GetInferenceServiceServes(w http.Res
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -1826,7 +1917,7 @@ func (c *ModelRegistryServiceAPIController) GetInferenceServiceServes(w http.Res
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetInferenceServiceServes(r.Context(), inferenceserviceIdParam, filterQueryParam, nameParam, externalIdParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetInferenceServiceServes(r.Context(), inferenceserviceIdParam, filterQueryParam, qParam, nameParam, externalIdParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -1937,6 +2028,13 @@ func (c *ModelRegistryServiceAPIController) GetModelArtifacts(w http.ResponseWri
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -1965,7 +2063,7 @@ func (c *ModelRegistryServiceAPIController) GetModelArtifacts(w http.ResponseWri
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetModelArtifacts(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetModelArtifacts(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2103,6 +2201,13 @@ func (c *ModelRegistryServiceAPIController) GetModelVersions(w http.ResponseWrit
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -2131,7 +2236,21 @@ func (c *ModelRegistryServiceAPIController) GetModelVersions(w http.ResponseWrit
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetModelVersions(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var stateParam string
+	if query.Has("state") {
+		param := query.Get("state")
+
+		stateParam = param
+	} else {
+	}
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetModelVersions(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, stateParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2236,6 +2355,13 @@ func (c *ModelRegistryServiceAPIController) GetModelVersionArtifacts(w http.Resp
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -2285,7 +2411,14 @@ func (c *ModelRegistryServiceAPIController) GetModelVersionArtifacts(w http.Resp
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetModelVersionArtifacts(r.Context(), modelversionIdParam, filterQueryParam, nameParam, externalIdParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var roleParam string
+	if query.Has("role") {
+		param := query.Get("role")
+
+		roleParam = param
+	} else {
+	}
+	result, err := c.service.GetModelVersionArtifacts(r.Context(), modelversionIdParam, filterQueryParam, qParam, nameParam, externalIdParam, artifactTypeParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, roleParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2372,6 +2505,13 @@ func (c *ModelRegistryServiceAPIController) GetRegisteredModels(w http.ResponseW
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -2400,7 +2540,21 @@ func (c *ModelRegistryServiceAPIController) GetRegisteredModels(w http.ResponseW
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetRegisteredModels(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var stateParam string
+	if query.Has("state") {
+		param := query.Get("state")
+
+		stateParam = param
+	} else {
+	}
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetRegisteredModels(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, stateParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2444,7 +2598,19 @@ func (c *ModelRegistryServiceAPIController) GetRegisteredModel(w http.ResponseWr
 		c.errorHandler(w, r, &RequiredError{"registeredmodelId"}, nil)
 		return
 	}
-	result, err := c.service.GetRegisteredModel(r.Context(), registeredmodelIdParam)
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	var asOfParam string
+	if query.Has("asOf") {
+		param := query.Get("asOf")
+
+		asOfParam = param
+	} else {
+	}
+	result, err := c.service.GetRegisteredModel(r.Context(), registeredmodelIdParam, asOfParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2519,6 +2685,13 @@ func (c *ModelRegistryServiceAPIController) GetRegisteredModelVersions(w http.Re
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -2547,7 +2720,14 @@ func (c *ModelRegistryServiceAPIController) GetRegisteredModelVersions(w http.Re
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetRegisteredModelVersions(r.Context(), registeredmodelIdParam, nameParam, externalIdParam, filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var stateParam string
+	if query.Has("state") {
+		param := query.Get("state")
+
+		stateParam = param
+	} else {
+	}
+	result, err := c.service.GetRegisteredModelVersions(r.Context(), registeredmodelIdParam, nameParam, externalIdParam, filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, stateParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2634,6 +2814,13 @@ func (c *ModelRegistryServiceAPIController) GetServingEnvironments(w http.Respon
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var pageSizeParam string
 	if query.Has("pageSize") {
 		param := query.Get("pageSize")
@@ -2662,7 +2849,14 @@ func (c *ModelRegistryServiceAPIController) GetServingEnvironments(w http.Respon
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetServingEnvironments(r.Context(), filterQueryParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	var tagsParam string
+	if query.Has("tags") {
+		param := query.Get("tags")
+
+		tagsParam = param
+	} else {
+	}
+	result, err := c.service.GetServingEnvironments(r.Context(), filterQueryParam, qParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam, tagsParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
@@ -2767,6 +2961,13 @@ func (c *ModelRegistryServiceAPIController) GetEnvironmentInferenceServices(w ht
 		filterQueryParam = param
 	} else {
 	}
+	var qParam string
+	if query.Has("q") {
+		param := query.Get("q")
+
+		qParam = param
+	} else {
+	}
 	var nameParam string
 	if query.Has("name") {
 		param := query.Get("name")
@@ -2809,7 +3010,7 @@ func (c *ModelRegistryServiceAPIController) GetEnvironmentInferenceServices(w ht
 		nextPageTokenParam = param
 	} else {
 	}
-	result, err := c.service.GetEnvironmentInferenceServices(r.Context(), servingenvironmentIdParam, filterQueryParam, nameParam, externalIdParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
+	result, err := c.service.GetEnvironmentInferenceServices(r.Context(), servingenvironmentIdParam, filterQueryParam, qParam, nameParam, externalIdParam, pageSizeParam, orderByParam, sortOrderParam, nextPageTokenParam)
 	// If an error occurred, encode the error with the status code
 	if err != nil {
 		c.errorHandler(w, r, err, &result)
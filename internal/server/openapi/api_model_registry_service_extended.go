@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	model "github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// ExtendedModelRegistryServiceAPIController routes the requests of the generated ModelRegistryServiceAPIController,
+// serving with hand-written handlers those its generated handlers can't: the JSON merge patches of the PATCH endpoints
+// and the lists streamed as server-sent events.
+type ExtendedModelRegistryServiceAPIController struct {
+	*ModelRegistryServiceAPIController
+}
+
+// NewExtendedModelRegistryServiceAPIController returns controller extended with the hand-written handlers.
+func NewExtendedModelRegistryServiceAPIController(controller *ModelRegistryServiceAPIController) *ExtendedModelRegistryServiceAPIController {
+	return &ExtendedModelRegistryServiceAPIController{ModelRegistryServiceAPIController: controller}
+}
+
+// Routes returns all the api routes for the ExtendedModelRegistryServiceAPIController
+func (c *ExtendedModelRegistryServiceAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ExtendedModelRegistryServiceAPIController,
+// the routes of the generated controller with the hand-written handlers in place of theirs.
+func (c *ExtendedModelRegistryServiceAPIController) OrderedRoutes() []Route {
+	handlers := map[string]http.HandlerFunc{
+		"UpdateArtifact": mergePatchHandler(c.errorHandler, "id", c.service.GetArtifact,
+			func() any { return model.NewArtifactUpdateWithDefaults() }, c.UpdateArtifact),
+		"UpdateExperiment": mergePatchHandler(c.errorHandler, "experimentId", c.service.GetExperiment,
+			func() any { return model.NewExperimentUpdateWithDefaults() }, c.UpdateExperiment),
+		"UpdateModelArtifact": mergePatchHandler(c.errorHandler, "modelartifactId", c.service.GetModelArtifact,
+			func() any { return model.NewModelArtifactUpdateWithDefaults() }, c.UpdateModelArtifact),
+		"UpdateModelVersion": mergePatchHandler(c.errorHandler, "modelversionId", c.service.GetModelVersion,
+			func() any { return model.NewModelVersionUpdateWithDefaults() }, c.UpdateModelVersion),
+		"UpdateRegisteredModel": mergePatchHandler(c.errorHandler, "registeredmodelId",
+			func(ctx context.Context, id string) (ImplResponse, error) {
+				return c.service.GetRegisteredModel(ctx, id, "")
+			},
+			func() any { return model.NewRegisteredModelUpdateWithDefaults() }, c.UpdateRegisteredModel),
+	}
+
+	if streamer, ok := c.service.(ListStreamServicer); ok {
+		handlers["GetExperimentRuns"] = listStreamHandler(c.errorHandler, func(r *http.Request, query url.Values, yield func(any) error) (string, error) {
+			return streamer.StreamExperimentRuns(r.Context(), query.Get("filterQuery"), query.Get("q"), query.Get("pageSize"),
+				model.OrderByField(query.Get("orderBy")), model.SortOrder(query.Get("sortOrder")), query.Get("nextPageToken"), query.Get("tags"), yield)
+		}, c.GetExperimentRuns)
+		handlers["GetExperimentExperimentRuns"] = listStreamHandler(c.errorHandler, func(r *http.Request, query url.Values, yield func(any) error) (string, error) {
+			return streamer.StreamExperimentExperimentRuns(r.Context(), chi.URLParam(r, "experimentId"), query.Get("name"), query.Get("externalId"),
+				query.Get("filterQuery"), query.Get("q"), query.Get("pageSize"),
+				model.OrderByField(query.Get("orderBy")), model.SortOrder(query.Get("sortOrder")), query.Get("nextPageToken"), yield)
+		}, c.GetExperimentExperimentRuns)
+		handlers["GetModelVersions"] = listStreamHandler(c.errorHandler, func(r *http.Request, query url.Values, yield func(any) error) (string, error) {
+			return streamer.StreamModelVersions(r.Context(), query.Get("filterQuery"), query.Get("q"), query.Get("pageSize"),
+				model.OrderByField(query.Get("orderBy")), model.SortOrder(query.Get("sortOrder")), query.Get("nextPageToken"), query.Get("state"), query.Get("tags"), yield)
+		}, c.GetModelVersions)
+		handlers["GetRegisteredModels"] = listStreamHandler(c.errorHandler, func(r *http.Request, query url.Values, yield func(any) error) (string, error) {
+			return streamer.StreamRegisteredModels(r.Context(), query.Get("filterQuery"), query.Get("q"), query.Get("pageSize"),
+				model.OrderByField(query.Get("orderBy")), model.SortOrder(query.Get("sortOrder")), query.Get("nextPageToken"), query.Get("state"), query.Get("tags"), yield)
+		}, c.GetRegisteredModels)
+		handlers["GetRegisteredModelVersions"] = listStreamHandler(c.errorHandler, func(r *http.Request, query url.Values, yield func(any) error) (string, error) {
+			return streamer.StreamRegisteredModelVersions(r.Context(), chi.URLParam(r, "registeredmodelId"), query.Get("name"), query.Get("externalId"),
+				query.Get("filterQuery"), query.Get("q"), query.Get("pageSize"),
+				model.OrderByField(query.Get("orderBy")), model.SortOrder(query.Get("sortOrder")), query.Get("nextPageToken"), query.Get("state"), yield)
+		}, c.GetRegisteredModelVersions)
+	}
+
+	routes := c.ModelRegistryServiceAPIController.OrderedRoutes()
+	for i, route := range routes {
+		if handler, ok := handlers[route.Name]; ok {
+			routes[i].HandlerFunc = handler
+		}
+	}
+	return routes
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/kubeflow/model-registry/internal/apiutils"
 	"github.com/kubeflow/model-registry/internal/converter"
@@ -54,11 +55,11 @@ func (s *ModelRegistryServiceAPIService) CreateInferenceService(ctx context.Cont
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertInferenceService(entity)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertInferenceService(entity)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateInferenceServiceServe - Create a ServeModel action in a InferenceService
@@ -68,11 +69,11 @@ func (s *ModelRegistryServiceAPIService) CreateInferenceServiceServe(ctx context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertServeModel(entity, &inferenceserviceId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertServeModel(entity, &inferenceserviceId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateArtifact - Create an Artifact
@@ -82,11 +83,11 @@ func (s *ModelRegistryServiceAPIService) CreateArtifact(ctx context.Context, art
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertArtifact(entity)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertArtifact(entity)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateModelArtifact - Create a ModelArtifact
@@ -96,11 +97,11 @@ func (s *ModelRegistryServiceAPIService) CreateModelArtifact(ctx context.Context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertModelArtifact(entity)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelArtifact(entity)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateModelVersion - Create a ModelVersion
@@ -110,25 +111,25 @@ func (s *ModelRegistryServiceAPIService) CreateModelVersion(ctx context.Context,
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertModelVersion(modelVersion, &modelVersionCreate.RegisteredModelId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelVersion(modelVersion, &modelVersionCreate.RegisteredModelId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateModelVersionArtifact - Create an Artifact in a ModelVersion
 func (s *ModelRegistryServiceAPIService) UpsertModelVersionArtifact(ctx context.Context, modelversionId string, artifact model.Artifact) (ImplResponse, error) {
 	creating := (artifact.DocArtifact != nil && artifact.DocArtifact.Id == nil) || (artifact.ModelArtifact != nil && artifact.ModelArtifact.Id == nil)
 
-	result, err := s.coreApi.UpsertModelVersionArtifact(&artifact, modelversionId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelVersionArtifact(&artifact, modelversionId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
 	if creating {
-		return Response(http.StatusCreated, result), nil
+		return s.writeResponse(http.StatusCreated, result), nil
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 	// return Response(http.StatusNotImplemented, nil), errors.New("unsupported artifactType")
 	// TODO return Response(http.StatusOK, Artifact{}), nil
 }
@@ -140,20 +141,20 @@ func (s *ModelRegistryServiceAPIService) CreateRegisteredModel(ctx context.Conte
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertRegisteredModel(registeredModel)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertRegisteredModel(registeredModel)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateRegisteredModelVersion - Create a ModelVersion in RegisteredModel
 func (s *ModelRegistryServiceAPIService) CreateRegisteredModelVersion(ctx context.Context, registeredmodelId string, modelVersion model.ModelVersion) (ImplResponse, error) {
-	result, err := s.coreApi.UpsertModelVersion(&modelVersion, apiutils.StrPtr(registeredmodelId))
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelVersion(&modelVersion, apiutils.StrPtr(registeredmodelId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateServingEnvironment - Create a ServingEnvironment
@@ -163,16 +164,16 @@ func (s *ModelRegistryServiceAPIService) CreateServingEnvironment(ctx context.Co
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertServingEnvironment(entity)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertServingEnvironment(entity)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // FindInferenceService - Get an InferenceServices that matches search parameters.
 func (s *ModelRegistryServiceAPIService) FindInferenceService(ctx context.Context, name string, externalId string, parentResourceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetInferenceServiceByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetInferenceServiceByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -181,7 +182,7 @@ func (s *ModelRegistryServiceAPIService) FindInferenceService(ctx context.Contex
 
 // FindArtifact - Get an Artifact that matches search parameters.
 func (s *ModelRegistryServiceAPIService) FindArtifact(ctx context.Context, name string, externalId string, parentResourceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetArtifactByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetArtifactByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -191,7 +192,7 @@ func (s *ModelRegistryServiceAPIService) FindArtifact(ctx context.Context, name
 // FindModelArtifact - Get a ModelArtifact that matches search parameters.
 func (s *ModelRegistryServiceAPIService) FindModelArtifact(ctx context.Context, name string, externalId string, parentResourceId string) (ImplResponse, error) {
 
-	result, err := s.coreApi.GetModelArtifactByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetModelArtifactByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -200,7 +201,7 @@ func (s *ModelRegistryServiceAPIService) FindModelArtifact(ctx context.Context,
 
 // FindModelVersion - Get a ModelVersion that matches search parameters.
 func (s *ModelRegistryServiceAPIService) FindModelVersion(ctx context.Context, name string, externalId string, registeredModelId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetModelVersionByParams(apiutils.StrPtr(name), apiutils.StrPtr(registeredModelId), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetModelVersionByParams(apiutils.StrPtr(name), apiutils.StrPtr(registeredModelId), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -209,7 +210,7 @@ func (s *ModelRegistryServiceAPIService) FindModelVersion(ctx context.Context, n
 
 // FindRegisteredModel - Get a RegisteredModel that matches search parameters.
 func (s *ModelRegistryServiceAPIService) FindRegisteredModel(ctx context.Context, name string, externalID string) (ImplResponse, error) {
-	result, err := s.coreApi.GetRegisteredModelByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalID))
+	result, err := api.WithContext(ctx, s.coreApi).GetRegisteredModelByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalID))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -218,7 +219,7 @@ func (s *ModelRegistryServiceAPIService) FindRegisteredModel(ctx context.Context
 
 // FindServingEnvironment - Find ServingEnvironment
 func (s *ModelRegistryServiceAPIService) FindServingEnvironment(ctx context.Context, name string, externalID string) (ImplResponse, error) {
-	result, err := s.coreApi.GetServingEnvironmentByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalID))
+	result, err := api.WithContext(ctx, s.coreApi).GetServingEnvironmentByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalID))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -226,12 +227,12 @@ func (s *ModelRegistryServiceAPIService) FindServingEnvironment(ctx context.Cont
 }
 
 // GetEnvironmentInferenceServices - List All ServingEnvironment&#39;s InferenceServices
-func (s *ModelRegistryServiceAPIService) GetEnvironmentInferenceServices(ctx context.Context, servingenvironmentId string, filterQuery string, name string, externalID string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetEnvironmentInferenceServices(ctx context.Context, servingenvironmentId string, filterQuery string, q string, name string, externalID string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetInferenceServices(listOpts, apiutils.StrPtr(servingenvironmentId), nil)
+	result, err := api.WithContext(ctx, s.coreApi).GetInferenceServices(listOpts, apiutils.StrPtr(servingenvironmentId), nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -240,7 +241,7 @@ func (s *ModelRegistryServiceAPIService) GetEnvironmentInferenceServices(ctx con
 
 // GetInferenceService - Get a InferenceService
 func (s *ModelRegistryServiceAPIService) GetInferenceService(ctx context.Context, inferenceserviceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetInferenceServiceById(inferenceserviceId)
+	result, err := api.WithContext(ctx, s.coreApi).GetInferenceServiceById(inferenceserviceId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -249,7 +250,7 @@ func (s *ModelRegistryServiceAPIService) GetInferenceService(ctx context.Context
 
 // GetInferenceServiceModel - Get InferenceService&#39;s RegisteredModel
 func (s *ModelRegistryServiceAPIService) GetInferenceServiceModel(ctx context.Context, inferenceserviceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetRegisteredModelByInferenceService(inferenceserviceId)
+	result, err := api.WithContext(ctx, s.coreApi).GetRegisteredModelByInferenceService(inferenceserviceId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -257,12 +258,12 @@ func (s *ModelRegistryServiceAPIService) GetInferenceServiceModel(ctx context.Co
 }
 
 // GetInferenceServiceServes - List All InferenceService&#39;s ServeModel actions
-func (s *ModelRegistryServiceAPIService) GetInferenceServiceServes(ctx context.Context, inferenceserviceId string, filterQuery string, name string, externalID string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetInferenceServiceServes(ctx context.Context, inferenceserviceId string, filterQuery string, q string, name string, externalID string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetServeModels(listOpts, apiutils.StrPtr(inferenceserviceId))
+	result, err := api.WithContext(ctx, s.coreApi).GetServeModels(listOpts, apiutils.StrPtr(inferenceserviceId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -271,7 +272,7 @@ func (s *ModelRegistryServiceAPIService) GetInferenceServiceServes(ctx context.C
 
 // GetInferenceServiceVersion - Get InferenceService&#39;s ModelVersion
 func (s *ModelRegistryServiceAPIService) GetInferenceServiceVersion(ctx context.Context, inferenceserviceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetModelVersionByInferenceService(inferenceserviceId)
+	result, err := api.WithContext(ctx, s.coreApi).GetModelVersionByInferenceService(inferenceserviceId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -279,12 +280,15 @@ func (s *ModelRegistryServiceAPIService) GetInferenceServiceVersion(ctx context.
 }
 
 // GetInferenceServices - List All InferenceServices
-func (s *ModelRegistryServiceAPIService) GetInferenceServices(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetInferenceServices(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetInferenceServices(listOpts, nil, nil)
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetInferenceServices(listOpts, nil, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -293,7 +297,7 @@ func (s *ModelRegistryServiceAPIService) GetInferenceServices(ctx context.Contex
 
 // GetArtifact - Get a Artifact
 func (s *ModelRegistryServiceAPIService) GetArtifact(ctx context.Context, artifactId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetArtifactById(artifactId)
+	result, err := api.WithContext(ctx, s.coreApi).GetArtifactById(artifactId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -301,12 +305,12 @@ func (s *ModelRegistryServiceAPIService) GetArtifact(ctx context.Context, artifa
 }
 
 // GetArtifacts - List All Artifacts
-func (s *ModelRegistryServiceAPIService) GetArtifacts(ctx context.Context, filterQuery string, artifactType model.ArtifactTypeQueryParam, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetArtifacts(ctx context.Context, filterQuery string, q string, artifactType model.ArtifactTypeQueryParam, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetArtifacts(artifactType, listOpts, nil)
+	result, err := api.WithContext(ctx, s.coreApi).GetArtifacts(artifactType, listOpts, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -315,7 +319,7 @@ func (s *ModelRegistryServiceAPIService) GetArtifacts(ctx context.Context, filte
 
 // GetModelArtifact - Get a ModelArtifact
 func (s *ModelRegistryServiceAPIService) GetModelArtifact(ctx context.Context, modelartifactId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetModelArtifactById(modelartifactId)
+	result, err := api.WithContext(ctx, s.coreApi).GetModelArtifactById(modelartifactId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -323,12 +327,12 @@ func (s *ModelRegistryServiceAPIService) GetModelArtifact(ctx context.Context, m
 }
 
 // GetModelArtifacts - List All ModelArtifacts
-func (s *ModelRegistryServiceAPIService) GetModelArtifacts(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetModelArtifacts(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetModelArtifacts(listOpts, nil)
+	result, err := api.WithContext(ctx, s.coreApi).GetModelArtifacts(listOpts, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -337,7 +341,7 @@ func (s *ModelRegistryServiceAPIService) GetModelArtifacts(ctx context.Context,
 
 // GetModelVersion - Get a ModelVersion
 func (s *ModelRegistryServiceAPIService) GetModelVersion(ctx context.Context, modelversionId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetModelVersionById(modelversionId)
+	result, err := api.WithContext(ctx, s.coreApi).GetModelVersionById(modelversionId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -346,17 +350,20 @@ func (s *ModelRegistryServiceAPIService) GetModelVersion(ctx context.Context, mo
 
 // GetModelVersionArtifacts - List All ModelVersion&#39;s artifacts
 func (s *ModelRegistryServiceAPIService) GetModelVersionArtifacts(ctx context.Context, modelversionId string,
-	filterQuery string, name string, externalID string, artifactType model.ArtifactTypeQueryParam, pageSize string,
-	orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	filterQuery string, q string, name string, externalID string, artifactType model.ArtifactTypeQueryParam, pageSize string,
+	orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, role string) (ImplResponse, error) {
 
 	// Build combined filter query from filterQuery, name, and externalID parameters
 	combinedFilterQuery := buildCombinedFilterQuery(filterQuery, name, externalID)
 
-	listOpts, err := s.buildListOption(combinedFilterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+	listOpts, err := s.buildListOption(combinedFilterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetArtifacts(artifactType, listOpts, apiutils.StrPtr(modelversionId))
+	if role != "" {
+		listOpts.Roles = strings.Split(role, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetArtifacts(artifactType, listOpts, apiutils.StrPtr(modelversionId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -396,12 +403,18 @@ func buildCombinedFilterQuery(filterQuery, name, externalID string) string {
 }
 
 // GetModelVersions - List All ModelVersions
-func (s *ModelRegistryServiceAPIService) GetModelVersions(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetModelVersions(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetModelVersions(listOpts, nil)
+	if state != "" {
+		listOpts.State = &state
+	}
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetModelVersions(listOpts, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -409,8 +422,21 @@ func (s *ModelRegistryServiceAPIService) GetModelVersions(ctx context.Context, f
 }
 
 // GetRegisteredModel - Get a RegisteredModel
-func (s *ModelRegistryServiceAPIService) GetRegisteredModel(ctx context.Context, registeredmodelId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetRegisteredModelById(registeredmodelId)
+func (s *ModelRegistryServiceAPIService) GetRegisteredModel(ctx context.Context, registeredmodelId string, asOf string) (ImplResponse, error) {
+	service := api.WithContext(ctx, s.coreApi)
+	if asOf != "" {
+		asOfTime, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			err = fmt.Errorf("invalid asOf %q, must be an RFC 3339 time: %w", asOf, api.ErrBadRequest)
+			return ErrorResponse(api.ErrToStatus(err), err), err
+		}
+		result, err := service.GetRegisteredModelAsOf(registeredmodelId, asOfTime)
+		if err != nil {
+			return ErrorResponse(api.ErrToStatus(err), err), err
+		}
+		return Response(http.StatusOK, result), nil
+	}
+	result, err := service.GetRegisteredModelById(registeredmodelId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -418,15 +444,18 @@ func (s *ModelRegistryServiceAPIService) GetRegisteredModel(ctx context.Context,
 }
 
 // GetRegisteredModelVersions - List All RegisteredModel&#39;s ModelVersions
-func (s *ModelRegistryServiceAPIService) GetRegisteredModelVersions(ctx context.Context, registeredmodelId string, name string, externalID string, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+func (s *ModelRegistryServiceAPIService) GetRegisteredModelVersions(ctx context.Context, registeredmodelId string, name string, externalID string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string) (ImplResponse, error) {
 	// Build combined filter query from filterQuery, name, and externalID parameters
 	combinedFilterQuery := buildCombinedFilterQuery(filterQuery, name, externalID)
 
-	listOpts, err := s.buildListOption(combinedFilterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+	listOpts, err := s.buildListOption(combinedFilterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetModelVersions(listOpts, apiutils.StrPtr(registeredmodelId))
+	if state != "" {
+		listOpts.State = &state
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetModelVersions(listOpts, apiutils.StrPtr(registeredmodelId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -434,12 +463,18 @@ func (s *ModelRegistryServiceAPIService) GetRegisteredModelVersions(ctx context.
 }
 
 // GetRegisteredModels - List All RegisteredModels
-func (s *ModelRegistryServiceAPIService) GetRegisteredModels(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetRegisteredModels(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetRegisteredModels(listOpts)
+	if state != "" {
+		listOpts.State = &state
+	}
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetRegisteredModels(listOpts)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -448,7 +483,7 @@ func (s *ModelRegistryServiceAPIService) GetRegisteredModels(ctx context.Context
 
 // GetServingEnvironment - Get a ServingEnvironment
 func (s *ModelRegistryServiceAPIService) GetServingEnvironment(ctx context.Context, servingenvironmentId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetServingEnvironmentById(servingenvironmentId)
+	result, err := api.WithContext(ctx, s.coreApi).GetServingEnvironmentById(servingenvironmentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -456,12 +491,15 @@ func (s *ModelRegistryServiceAPIService) GetServingEnvironment(ctx context.Conte
 }
 
 // GetServingEnvironments - List All ServingEnvironments
-func (s *ModelRegistryServiceAPIService) GetServingEnvironments(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetServingEnvironments(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetServingEnvironments(listOpts)
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetServingEnvironments(listOpts)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -475,7 +513,7 @@ func (s *ModelRegistryServiceAPIService) UpdateInferenceService(ctx context.Cont
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	entity.Id = &inferenceserviceId
-	existing, err := s.coreApi.GetInferenceServiceById(inferenceserviceId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetInferenceServiceById(inferenceserviceId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -483,11 +521,11 @@ func (s *ModelRegistryServiceAPIService) UpdateInferenceService(ctx context.Cont
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertInferenceService(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertInferenceService(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateArtifact - Update a Artifact
@@ -502,7 +540,7 @@ func (s *ModelRegistryServiceAPIService) UpdateArtifact(ctx context.Context, art
 	if artifactUpdate.ModelArtifactUpdate != nil {
 		entity.ModelArtifact.Id = &artifactId
 	}
-	existing, err := s.coreApi.GetArtifactById(artifactId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetArtifactById(artifactId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -510,11 +548,11 @@ func (s *ModelRegistryServiceAPIService) UpdateArtifact(ctx context.Context, art
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertArtifact(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertArtifact(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateModelArtifact - Update a ModelArtifact
@@ -524,7 +562,7 @@ func (s *ModelRegistryServiceAPIService) UpdateModelArtifact(ctx context.Context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	modelArtifact.Id = &modelartifactId
-	existing, err := s.coreApi.GetModelArtifactById(modelartifactId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetModelArtifactById(modelartifactId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -532,11 +570,11 @@ func (s *ModelRegistryServiceAPIService) UpdateModelArtifact(ctx context.Context
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertModelArtifact(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelArtifact(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateModelVersion - Update a ModelVersion
@@ -546,7 +584,7 @@ func (s *ModelRegistryServiceAPIService) UpdateModelVersion(ctx context.Context,
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	modelVersion.Id = &modelversionId
-	existing, err := s.coreApi.GetModelVersionById(modelversionId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetModelVersionById(modelversionId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -554,11 +592,11 @@ func (s *ModelRegistryServiceAPIService) UpdateModelVersion(ctx context.Context,
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertModelVersion(&update, nil)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertModelVersion(&update, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateRegisteredModel - Update a RegisteredModel
@@ -568,7 +606,7 @@ func (s *ModelRegistryServiceAPIService) UpdateRegisteredModel(ctx context.Conte
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	registeredModel.Id = &registeredmodelId
-	existing, err := s.coreApi.GetRegisteredModelById(registeredmodelId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetRegisteredModelById(registeredmodelId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -576,11 +614,11 @@ func (s *ModelRegistryServiceAPIService) UpdateRegisteredModel(ctx context.Conte
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertRegisteredModel(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertRegisteredModel(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateServingEnvironment - Update a ServingEnvironment
@@ -590,7 +628,7 @@ func (s *ModelRegistryServiceAPIService) UpdateServingEnvironment(ctx context.Co
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	entity.Id = &servingenvironmentId
-	existing, err := s.coreApi.GetServingEnvironmentById(servingenvironmentId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetServingEnvironmentById(servingenvironmentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -598,11 +636,11 @@ func (s *ModelRegistryServiceAPIService) UpdateServingEnvironment(ctx context.Co
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertServingEnvironment(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertServingEnvironment(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // CreateExperiment - Create an Experiment
@@ -612,20 +650,20 @@ func (s *ModelRegistryServiceAPIService) CreateExperiment(ctx context.Context, e
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertExperiment(entity)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperiment(entity)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateExperimentExperimentRun - Create an ExperimentRun in Experiment
 func (s *ModelRegistryServiceAPIService) CreateExperimentExperimentRun(ctx context.Context, experimentId string, experimentRun model.ExperimentRun) (ImplResponse, error) {
-	result, err := s.coreApi.UpsertExperimentRun(&experimentRun, apiutils.StrPtr(experimentId))
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperimentRun(&experimentRun, apiutils.StrPtr(experimentId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // CreateExperimentRun - Create an ExperimentRun
@@ -635,16 +673,16 @@ func (s *ModelRegistryServiceAPIService) CreateExperimentRun(ctx context.Context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 
-	result, err := s.coreApi.UpsertExperimentRun(experimentRun, &experimentRunCreate.ExperimentId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperimentRun(experimentRun, &experimentRunCreate.ExperimentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusCreated, result), nil
+	return s.writeResponse(http.StatusCreated, result), nil
 }
 
 // FindExperiment - Get an Experiment that matches search parameters
 func (s *ModelRegistryServiceAPIService) FindExperiment(ctx context.Context, name string, externalId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetExperimentByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentByParams(apiutils.StrPtr(name), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -653,7 +691,7 @@ func (s *ModelRegistryServiceAPIService) FindExperiment(ctx context.Context, nam
 
 // FindExperimentRun - Get an ExperimentRun that matches search parameters
 func (s *ModelRegistryServiceAPIService) FindExperimentRun(ctx context.Context, name string, externalId string, parentResourceId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetExperimentRunByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRunByParams(apiutils.StrPtr(name), apiutils.StrPtr(parentResourceId), apiutils.StrPtr(externalId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -662,7 +700,7 @@ func (s *ModelRegistryServiceAPIService) FindExperimentRun(ctx context.Context,
 
 // GetExperiment - Get an Experiment
 func (s *ModelRegistryServiceAPIService) GetExperiment(ctx context.Context, experimentId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetExperimentById(experimentId)
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentById(experimentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -670,12 +708,12 @@ func (s *ModelRegistryServiceAPIService) GetExperiment(ctx context.Context, expe
 }
 
 // GetExperimentExperimentRuns - List All Experiment's ExperimentRuns
-func (s *ModelRegistryServiceAPIService) GetExperimentExperimentRuns(ctx context.Context, experimentId string, name string, externalId string, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetExperimentExperimentRuns(ctx context.Context, experimentId string, name string, externalId string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetExperimentRuns(listOpts, apiutils.StrPtr(experimentId))
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRuns(listOpts, apiutils.StrPtr(experimentId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -684,7 +722,7 @@ func (s *ModelRegistryServiceAPIService) GetExperimentExperimentRuns(ctx context
 
 // GetExperimentRun - Get an ExperimentRun
 func (s *ModelRegistryServiceAPIService) GetExperimentRun(ctx context.Context, experimentrunId string) (ImplResponse, error) {
-	result, err := s.coreApi.GetExperimentRunById(experimentrunId)
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRunById(experimentrunId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -693,12 +731,15 @@ func (s *ModelRegistryServiceAPIService) GetExperimentRun(ctx context.Context, e
 
 // GetExperimentRunArtifacts - List all artifacts associated with the ExperimentRun
 func (s *ModelRegistryServiceAPIService) GetExperimentRunArtifacts(ctx context.Context, experimentrunId string,
-	filterQuery string, name string, externalId string, artifactType model.ArtifactTypeQueryParam, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+	filterQuery string, q string, name string, externalId string, artifactType model.ArtifactTypeQueryParam, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, role string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetExperimentRunArtifacts(artifactType, listOpts, apiutils.StrPtr(experimentrunId))
+	if role != "" {
+		listOpts.Roles = strings.Split(role, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRunArtifacts(artifactType, listOpts, apiutils.StrPtr(experimentrunId))
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -706,12 +747,15 @@ func (s *ModelRegistryServiceAPIService) GetExperimentRunArtifacts(ctx context.C
 }
 
 // GetExperimentRuns - List All ExperimentRuns
-func (s *ModelRegistryServiceAPIService) GetExperimentRuns(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetExperimentRuns(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetExperimentRuns(listOpts, nil)
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRuns(listOpts, nil)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -719,12 +763,15 @@ func (s *ModelRegistryServiceAPIService) GetExperimentRuns(ctx context.Context,
 }
 
 // GetExperiments - List All Experiments
-func (s *ModelRegistryServiceAPIService) GetExperiments(ctx context.Context, filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+func (s *ModelRegistryServiceAPIService) GetExperiments(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	result, err := s.coreApi.GetExperiments(listOpts)
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	result, err := api.WithContext(ctx, s.coreApi).GetExperiments(listOpts)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -738,7 +785,7 @@ func (s *ModelRegistryServiceAPIService) UpdateExperiment(ctx context.Context, e
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	entity.Id = &experimentId
-	existing, err := s.coreApi.GetExperimentById(experimentId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetExperimentById(experimentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -746,11 +793,11 @@ func (s *ModelRegistryServiceAPIService) UpdateExperiment(ctx context.Context, e
 	if err != nil {
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
-	result, err := s.coreApi.UpsertExperiment(&update)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperiment(&update)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpdateExperimentRun - Update an ExperimentRun
@@ -760,7 +807,7 @@ func (s *ModelRegistryServiceAPIService) UpdateExperimentRun(ctx context.Context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	entity.Id = &experimentrunId
-	existing, err := s.coreApi.GetExperimentRunById(experimentrunId)
+	existing, err := api.WithContext(ctx, s.coreApi).GetExperimentRunById(experimentrunId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -769,44 +816,44 @@ func (s *ModelRegistryServiceAPIService) UpdateExperimentRun(ctx context.Context
 		return ErrorResponse(http.StatusBadRequest, err), err
 	}
 	// Extract experiment ID from existing run for the upsert call
-	result, err := s.coreApi.UpsertExperimentRun(&update, &existing.ExperimentId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperimentRun(&update, &existing.ExperimentId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // UpsertExperimentRunArtifact - Upsert an Artifact in an ExperimentRun
 func (s *ModelRegistryServiceAPIService) UpsertExperimentRunArtifact(ctx context.Context, experimentrunId string, artifact model.Artifact) (ImplResponse, error) {
 	creating := (artifact.DocArtifact != nil && artifact.DocArtifact.Id == nil) || (artifact.ModelArtifact != nil && artifact.ModelArtifact.Id == nil)
 
-	result, err := s.coreApi.UpsertExperimentRunArtifact(&artifact, experimentrunId)
+	result, err := api.WithContext(ctx, s.coreApi).UpsertExperimentRunArtifact(&artifact, experimentrunId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
 	if creating {
-		return Response(http.StatusCreated, result), nil
+		return s.writeResponse(http.StatusCreated, result), nil
 	}
-	return Response(http.StatusOK, result), nil
+	return s.writeResponse(http.StatusOK, result), nil
 }
 
 // GetExperimentRunMetricHistory - Get metric history for an ExperimentRun
 func (s *ModelRegistryServiceAPIService) GetExperimentRunMetricHistory(ctx context.Context, experimentrunId string,
-	filterQuery string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	return s.getMetricHistoryHelper(ctx, apiutils.StrPtr(experimentrunId), filterQuery, name, stepIds, pageSize, orderBy, sortOrder, nextPageToken)
+	filterQuery string, q string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	return s.getMetricHistoryHelper(ctx, apiutils.StrPtr(experimentrunId), filterQuery, q, name, stepIds, pageSize, orderBy, sortOrder, nextPageToken)
 }
 
 // GetExperimentRunsMetricHistory - Get metric history for multiple ExperimentRuns
 func (s *ModelRegistryServiceAPIService) GetExperimentRunsMetricHistory(ctx context.Context,
-	filterQuery string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	filterQuery string, q string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
 	// Pass nil for experimentRunId to get metrics for all experiment runs
-	return s.getMetricHistoryHelper(ctx, nil, filterQuery, name, stepIds, pageSize, orderBy, sortOrder, nextPageToken)
+	return s.getMetricHistoryHelper(ctx, nil, filterQuery, q, name, stepIds, pageSize, orderBy, sortOrder, nextPageToken)
 }
 
 // getMetricHistoryHelper handles the common logic for getting metric history
 func (s *ModelRegistryServiceAPIService) getMetricHistoryHelper(ctx context.Context, experimentRunId *string,
-	filterQuery string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
-	listOpts, err := s.buildListOption(filterQuery, pageSize, orderBy, sortOrder, nextPageToken)
+	filterQuery string, q string, name string, stepIds string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (ImplResponse, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
@@ -825,18 +872,22 @@ func (s *ModelRegistryServiceAPIService) getMetricHistoryHelper(ctx context.Cont
 		stepIdsPtr = &stepIds
 	}
 
-	result, err := s.coreApi.GetExperimentRunMetricHistory(namePtr, stepIdsPtr, listOpts, experimentRunId)
+	result, err := api.WithContext(ctx, s.coreApi).GetExperimentRunMetricHistory(namePtr, stepIdsPtr, listOpts, experimentRunId)
 	if err != nil {
 		return ErrorResponse(api.ErrToStatus(err), err), err
 	}
 	return Response(http.StatusOK, result), nil
 }
 
-func (s *ModelRegistryServiceAPIService) buildListOption(filterQuery string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (api.ListOptions, error) {
+func (s *ModelRegistryServiceAPIService) buildListOption(filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string) (api.ListOptions, error) {
 	var filterQueryPtr *string
 	if filterQuery != "" {
 		filterQueryPtr = &filterQuery
 	}
+	var qPtr *string
+	if q != "" {
+		qPtr = &q
+	}
 	var pageSizeInt32 *int32
 	if pageSize != "" {
 		conv, err := converter.StringToInt32(pageSize)
@@ -859,6 +910,7 @@ func (s *ModelRegistryServiceAPIService) buildListOption(filterQuery string, pag
 	}
 	return api.ListOptions{
 		FilterQuery:   filterQueryPtr,
+		Query:         qPtr,
 		PageSize:      pageSizeInt32,
 		OrderBy:       orderByString,
 		SortOrder:     sortOrderString,
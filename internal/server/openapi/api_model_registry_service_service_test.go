@@ -1,9 +1,14 @@
 package openapi
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	model "github.com/kubeflow/model-registry/pkg/openapi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildCombinedFilterQuery(t *testing.T) {
@@ -86,3 +91,14 @@ func TestBuildCombinedFilterQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestWithWarnings(t *testing.T) {
+	body := withWarnings{
+		result:   &model.RegisteredModel{Id: apiutils.Of("1"), Name: "model"},
+		warnings: []api.Warning{{Rule: api.LintRuleMaxCustomProperties, Message: "too many"}},
+	}
+
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id": "1", "name": "model", "warnings": [{"rule": "max-custom-properties", "message": "too many"}]}`, string(data))
+}
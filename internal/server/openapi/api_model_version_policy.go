@@ -0,0 +1,98 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelVersionPolicyAPIController binds http requests for guardrail and evaluation policies of model versions
+// to the core api and writes the results to the http response
+type ModelVersionPolicyAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewModelVersionPolicyAPIController creates a default model version policy api controller
+func NewModelVersionPolicyAPIController(coreApi api.ModelRegistryApi) *ModelVersionPolicyAPIController {
+	return &ModelVersionPolicyAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ModelVersionPolicyAPIController
+func (c *ModelVersionPolicyAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelVersionPolicyAPIController
+func (c *ModelVersionPolicyAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetInferenceServicePolicy",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/inference_services/{inferenceserviceId}/policy",
+			c.GetInferenceServicePolicy,
+		},
+		{
+			"GetModelVersionPolicy",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/policy",
+			c.GetModelVersionPolicy,
+		},
+		{
+			"UpsertModelVersionPolicy",
+			strings.ToUpper("Put"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/policy",
+			c.UpsertModelVersionPolicy,
+		},
+	}
+}
+
+// GetInferenceServicePolicy - Get the policy of the ModelVersion served by an InferenceService
+func (c *ModelVersionPolicyAPIController) GetInferenceServicePolicy(w http.ResponseWriter, r *http.Request) {
+	inferenceserviceIdParam := chi.URLParam(r, "inferenceserviceId")
+	if inferenceserviceIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"inferenceserviceId"}, nil)
+		return
+	}
+	policy, err := api.WithContext(r.Context(), c.coreApi).GetInferenceServicePolicy(inferenceserviceIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, policy, err)
+}
+
+// GetModelVersionPolicy - Get the policy attached to a ModelVersion
+func (c *ModelVersionPolicyAPIController) GetModelVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	policy, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionPolicy(modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, policy, err)
+}
+
+// UpsertModelVersionPolicy - Replace the policy attached to a ModelVersion
+func (c *ModelVersionPolicyAPIController) UpsertModelVersionPolicy(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	policyParam := api.ModelVersionPolicy{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&policyParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	policy, err := api.WithContext(r.Context(), c.coreApi).UpsertModelVersionPolicy(modelversionIdParam, &policyParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, policy, err)
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ModelVersionResourcesAPIController binds http requests for the resource footprint of model versions
+// to the core api and writes the results to the http response
+type ModelVersionResourcesAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewModelVersionResourcesAPIController creates a default model version resources api controller
+func NewModelVersionResourcesAPIController(coreApi api.ModelRegistryApi) *ModelVersionResourcesAPIController {
+	return &ModelVersionResourcesAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ModelVersionResourcesAPIController
+func (c *ModelVersionResourcesAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ModelVersionResourcesAPIController
+func (c *ModelVersionResourcesAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetModelVersionResourceFootprint",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/resources",
+			c.GetModelVersionResourceFootprint,
+		},
+		{
+			"UpdateModelVersionResourceFootprint",
+			strings.ToUpper("Patch"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/resources",
+			c.UpdateModelVersionResourceFootprint,
+		},
+	}
+}
+
+// GetModelVersionResourceFootprint - Get the resource footprint of a ModelVersion
+func (c *ModelVersionResourcesAPIController) GetModelVersionResourceFootprint(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	footprint, err := api.WithContext(r.Context(), c.coreApi).GetModelVersionResourceFootprint(modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, footprint, err)
+}
+
+// UpdateModelVersionResourceFootprint - Update the resource footprint of a ModelVersion
+func (c *ModelVersionResourcesAPIController) UpdateModelVersionResourceFootprint(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	footprintParam := api.ResourceFootprint{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&footprintParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	footprint, err := api.WithContext(r.Context(), c.coreApi).UpdateModelVersionResourceFootprint(modelversionIdParam, &footprintParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, footprint, err)
+}
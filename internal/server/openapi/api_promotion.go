@@ -0,0 +1,265 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// PromotionAPIController binds http requests for registry-to-registry promotions and their runs
+// to the core api and writes the results to the http response
+type PromotionAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewPromotionAPIController creates a default promotion api controller
+func NewPromotionAPIController(coreApi api.ModelRegistryApi) *PromotionAPIController {
+	return &PromotionAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the PromotionAPIController
+func (c *PromotionAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the PromotionAPIController
+func (c *PromotionAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetPromotions",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/promotions",
+			c.GetPromotions,
+		},
+		{
+			"CreatePromotion",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/promotions",
+			c.CreatePromotion,
+		},
+		{
+			"GetPromotion",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/promotions/{promotionId}",
+			c.GetPromotion,
+		},
+		{
+			"UpdatePromotion",
+			strings.ToUpper("Put"),
+			"/api/model_registry/v1alpha3/promotions/{promotionId}",
+			c.UpdatePromotion,
+		},
+		{
+			"GetPromotionRuns",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/promotions/{promotionId}/runs",
+			c.GetPromotionRuns,
+		},
+		{
+			"StartPromotionRun",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/promotions/{promotionId}/runs",
+			c.StartPromotionRun,
+		},
+		{
+			"GetPromotionRun",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/promotion_runs/{promotionrunId}",
+			c.GetPromotionRun,
+		},
+		{
+			"ApprovePromotionRun",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/promotion_runs/{promotionrunId}:approve",
+			c.ApprovePromotionRun,
+		},
+		{
+			"RejectPromotionRun",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/promotion_runs/{promotionrunId}:reject",
+			c.RejectPromotionRun,
+		},
+	}
+}
+
+// GetPromotions - List all Promotions
+func (c *PromotionAPIController) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetPromotions(listOptions)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// CreatePromotion - Create a Promotion
+func (c *PromotionAPIController) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	promotionParam := api.Promotion{}
+	if err := decodeStrict(r, &promotionParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	promotionParam.Id = ""
+	result, err := api.WithContext(r.Context(), c.coreApi).UpsertPromotion(&promotionParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
+
+// GetPromotion - Get a Promotion
+func (c *PromotionAPIController) GetPromotion(w http.ResponseWriter, r *http.Request) {
+	promotionIdParam := chi.URLParam(r, "promotionId")
+	if promotionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetPromotionById(promotionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// UpdatePromotion - Replace the definition of a Promotion, runs already started are not affected
+func (c *PromotionAPIController) UpdatePromotion(w http.ResponseWriter, r *http.Request) {
+	promotionIdParam := chi.URLParam(r, "promotionId")
+	if promotionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionId"}, nil)
+		return
+	}
+	promotionParam := api.Promotion{}
+	if err := decodeStrict(r, &promotionParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	promotionParam.Id = promotionIdParam
+	result, err := api.WithContext(r.Context(), c.coreApi).UpsertPromotion(&promotionParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetPromotionRuns - List all runs of a Promotion
+func (c *PromotionAPIController) GetPromotionRuns(w http.ResponseWriter, r *http.Request) {
+	promotionIdParam := chi.URLParam(r, "promotionId")
+	if promotionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetPromotionRuns(listOptions, promotionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// StartPromotionRun - Start a run of a Promotion, the model versions are copied in the background, or return the
+// plan of the run with dryRun
+func (c *PromotionAPIController) StartPromotionRun(w http.ResponseWriter, r *http.Request) {
+	promotionIdParam := chi.URLParam(r, "promotionId")
+	if promotionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionId"}, nil)
+		return
+	}
+	dryRunParam, err := parseBool(r.URL.Query().Get("dryRun"))
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "dryRun", Err: err}, nil)
+		return
+	}
+	if dryRunParam {
+		plan, err := api.WithContext(r.Context(), c.coreApi).PlanPromotionRun(promotionIdParam)
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, plan, err)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).StartPromotionRun(promotionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusAccepted, result, err)
+}
+
+// GetPromotionRun - Get a PromotionRun
+func (c *PromotionAPIController) GetPromotionRun(w http.ResponseWriter, r *http.Request) {
+	promotionrunIdParam := chi.URLParam(r, "promotionrunId")
+	if promotionrunIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionrunId"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetPromotionRunById(promotionrunIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// ApprovePromotionRun - Approve a PromotionRun pending approval, the model versions are copied in the background
+func (c *PromotionAPIController) ApprovePromotionRun(w http.ResponseWriter, r *http.Request) {
+	promotionrunIdParam := chi.URLParam(r, "promotionrunId")
+	if promotionrunIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionrunId"}, nil)
+		return
+	}
+	reviewParam := api.PromotionReview{}
+	if err := decodeStrict(r, &reviewParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ApprovePromotionRun(promotionrunIdParam, &reviewParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusAccepted, result, err)
+}
+
+// RejectPromotionRun - Reject a PromotionRun pending approval
+func (c *PromotionAPIController) RejectPromotionRun(w http.ResponseWriter, r *http.Request) {
+	promotionrunIdParam := chi.URLParam(r, "promotionrunId")
+	if promotionrunIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"promotionrunId"}, nil)
+		return
+	}
+	reviewParam := api.PromotionReview{}
+	if err := decodeStrict(r, &reviewParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).RejectPromotionRun(promotionrunIdParam, &reviewParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// parseListOptions parses the pagination query parameters of the list requests.
+func parseListOptions(r *http.Request) (api.ListOptions, error) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		return api.ListOptions{}, err
+	}
+
+	listOptions := api.ListOptions{
+		OrderBy:       queryValue(query, "orderBy"),
+		SortOrder:     queryValue(query, "sortOrder"),
+		NextPageToken: queryValue(query, "nextPageToken"),
+	}
+	if pageSize := query.Get("pageSize"); pageSize != "" {
+		conv, err := converter.StringToInt32(pageSize)
+		if err != nil {
+			return api.ListOptions{}, fmt.Errorf("invalid pageSize: %w", err)
+		}
+		listOptions.PageSize = &conv
+	}
+
+	return listOptions, nil
+}
+
+func queryValue(query url.Values, name string) *string {
+	if value := query.Get(name); value != "" {
+		return &value
+	}
+	return nil
+}
+
+func decodeStrict(r *http.Request, v any) error {
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
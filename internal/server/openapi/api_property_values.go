@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// PropertyValuesAPIController binds http requests retrieving one custom property of many entities
+// to the core api and writes the results to the http response
+type PropertyValuesAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewPropertyValuesAPIController creates a default property values api controller
+func NewPropertyValuesAPIController(coreApi api.ModelRegistryApi) *PropertyValuesAPIController {
+	return &PropertyValuesAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the PropertyValuesAPIController
+func (c *PropertyValuesAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the PropertyValuesAPIController
+func (c *PropertyValuesAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetCustomPropertyValues",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/properties/values",
+			c.GetCustomPropertyValues,
+		},
+	}
+}
+
+// GetCustomPropertyValues - Get one custom property of many entities, ids are comma separated or repeated
+func (c *PropertyValuesAPIController) GetCustomPropertyValues(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	entityTypeParam := query.Get("entityType")
+	if entityTypeParam == "" {
+		c.errorHandler(w, r, &RequiredError{"entityType"}, nil)
+		return
+	}
+	keyParam := query.Get("key")
+	if keyParam == "" {
+		c.errorHandler(w, r, &RequiredError{"key"}, nil)
+		return
+	}
+	idsParam := []string{}
+	for _, ids := range query["ids"] {
+		for id := range strings.SplitSeq(ids, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				idsParam = append(idsParam, id)
+			}
+		}
+	}
+	if len(idsParam) == 0 {
+		c.errorHandler(w, r, &RequiredError{"ids"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetCustomPropertyValues(entityTypeParam, keyParam, idsParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,70 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCustomPropertyValues(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "model"})
+	require.NoError(t, err)
+
+	ids := []string{}
+	for i := range 3 {
+		version := &openapi.ModelVersion{Name: fmt.Sprintf("v%d", i)}
+		if i != 1 {
+			version.CustomProperties = map[string]openapi.MetadataValue{
+				"accuracy": openapi.MetadataDoubleValueAsMetadataValue(openapi.NewMetadataDoubleValue(0.9+float64(i)/100, "MetadataDoubleValue")),
+			}
+		}
+		version, err = service.UpsertModelVersion(version, model.Id)
+		require.NoError(t, err)
+		ids = append(ids, *version.Id)
+	}
+
+	get := func(query string) *http.Response {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/properties/values?" + query)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := get(fmt.Sprintf("entityType=model_version&key=accuracy&ids=%s,%s&ids=%s", ids[2], ids[1], ids[0]))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list api.PropertyValueList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Equal(t, "model_version", list.EntityType)
+	assert.Equal(t, "accuracy", list.Key)
+	require.Equal(t, int32(2), list.Size)
+	assert.Equal(t, ids[2], list.Items[0].Id)
+	assert.InDelta(t, 0.92, list.Items[0].Value.MetadataDoubleValue.DoubleValue, 1e-9)
+	assert.Equal(t, ids[0], list.Items[1].Id)
+	assert.InDelta(t, 0.9, list.Items[1].Value.MetadataDoubleValue.DoubleValue, 1e-9)
+
+	// the model is not a model version
+	resp = get("entityType=model_version&key=accuracy&ids=" + *model.Id)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	assert.Empty(t, list.Items)
+
+	for query, code := range map[string]int{
+		"key=accuracy&ids=1":                            http.StatusUnprocessableEntity,
+		"entityType=model_version&ids=1":                http.StatusUnprocessableEntity,
+		"entityType=model_version&key=accuracy":         http.StatusUnprocessableEntity,
+		"entityType=dataset&key=accuracy&ids=1":         http.StatusBadRequest,
+		"entityType=model_version&key=accuracy&ids=abc": http.StatusBadRequest,
+	} {
+		assert.Equal(t, code, get(query).StatusCode, "query %s", query)
+	}
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// ResolveAPIController binds http requests resolving entity references into the entities of any type to the core
+// api and writes the results to the http response
+type ResolveAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewResolveAPIController creates a default resolve api controller
+func NewResolveAPIController(coreApi api.ModelRegistryApi) *ResolveAPIController {
+	return &ResolveAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the ResolveAPIController
+func (c *ResolveAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the ResolveAPIController
+func (c *ResolveAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"ResolveEntityRefs",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/resolve",
+			c.ResolveEntityRefs,
+		},
+	}
+}
+
+// ResolveEntityRefs - Expand entity references, e.g. registered_model/123 or model_version?externalId=abc, into
+// their entities
+func (c *ResolveAPIController) ResolveEntityRefs(w http.ResponseWriter, r *http.Request) {
+	resolveRequestParam := api.ResolveRequest{}
+	if err := decodeStrict(r, &resolveRequestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ResolveEntityRefs(resolveRequestParam.Refs)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
@@ -0,0 +1,78 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEntityRefs(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1", ExternalId: openapi.PtrString("abc")}, model.Id)
+	require.NoError(t, err)
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "tuning"})
+	require.NoError(t, err)
+
+	baseURL := server.URL + "/api/model_registry/v1alpha3/"
+	resolve := func(body string, out any) int {
+		resp, err := http.Post(baseURL+"resolve", "application/json", bytes.NewReader([]byte(body)))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var resolved api.ResolvedEntityList
+	require.Equal(t, http.StatusOK, resolve(`{"refs": ["model_version?externalId=abc", "registered_model/`+*model.Id+`", "experiment?name=tuning"]}`, &resolved))
+	require.Equal(t, int32(3), resolved.Size)
+	assert.Equal(t, api.EntityRef{EntityType: api.EntityTypeModelVersion, ExternalId: "abc"}, resolved.Items[0].Ref)
+	assert.Equal(t, api.EntityTypeModelVersion, resolved.Items[0].EntityType)
+	assert.Equal(t, *version.Id, resolved.Items[0].Id)
+	assert.Equal(t, "v1", resolved.Items[0].Object.(map[string]any)["name"])
+	assert.Equal(t, *model.Id, resolved.Items[1].Id)
+	assert.Equal(t, "churn", resolved.Items[1].Object.(map[string]any)["name"])
+	assert.Equal(t, *experiment.Id, resolved.Items[2].Id)
+
+	// the references are parsed strictly, and a single missing entity fails the request
+	assert.Equal(t, http.StatusBadRequest, resolve(`{"refs": ["widget/1"]}`, nil))
+	assert.Equal(t, http.StatusBadRequest, resolve(`{"refs": ["model_version?name=v1"]}`, nil))
+	assert.Equal(t, http.StatusBadRequest, resolve(`{"refs": ["registered_model?externalId=a&name=b"]}`, nil))
+	assert.Equal(t, http.StatusNotFound, resolve(`{"refs": ["registered_model/`+*model.Id+`", "registered_model/999"]}`, nil))
+
+	// the other apis return the canonical references to the entities
+	resp, err := http.Get(baseURL + "entities:byExternalId?externalId=abc")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var refs map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&refs))
+	assert.Equal(t, "model_version/"+*version.Id, refs["items"].([]any)[0].(map[string]any)["ref"])
+}
+
+func TestParseEntityRef(t *testing.T) {
+	for _, ref := range []string{"registered_model/1", "artifact?externalId=s3%3A%2F%2Fbucket%2Fmodel", "serving_environment?name=prod+eu"} {
+		parsed, err := api.ParseEntityRef(ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, ref, parsed.String())
+	}
+
+	parsed, err := api.ParseEntityRef("artifact?externalId=s3%3A%2F%2Fbucket%2Fmodel")
+	require.NoError(t, err)
+	assert.Equal(t, "s3://bucket/model", parsed.ExternalId)
+
+	for _, ref := range []string{"", "registered_model", "registered_model/", "registered_model/1/versions", "experiment_run?name=a", "model_version?id=1", "experiment?name="} {
+		_, err := api.ParseEntityRef(ref)
+		assert.ErrorIs(t, err, api.ErrBadRequest, ref)
+	}
+}
@@ -0,0 +1,126 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// SnapshotContentType is the media type of the snapshot archives, a JSON document per line.
+const SnapshotContentType = "application/x-ndjson"
+
+// SnapshotAPIController binds http requests for the snapshots of the registry to the core api and writes the results
+// to the http response
+type SnapshotAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewSnapshotAPIController creates a default snapshot api controller
+func NewSnapshotAPIController(coreApi api.ModelRegistryApi) *SnapshotAPIController {
+	return &SnapshotAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the SnapshotAPIController
+func (c *SnapshotAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the SnapshotAPIController
+func (c *SnapshotAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"ExportSnapshot",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/exports",
+			c.ExportSnapshot,
+		},
+		{
+			"ImportSnapshot",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/imports",
+			c.ImportSnapshot,
+		},
+	}
+}
+
+// ExportSnapshot - Export the registered models of the request with their model versions and artifacts as a
+// JSON-lines archive
+func (c *SnapshotAPIController) ExportSnapshot(w http.ResponseWriter, r *http.Request) {
+	requestParam := api.SnapshotExportRequest{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&requestParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	records, err := api.WithContext(r.Context(), c.coreApi).ExportSnapshot(requestParam)
+	if err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+
+	var archive bytes.Buffer
+	encoder := json.NewEncoder(&archive)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+			return
+		}
+	}
+
+	// the archives are not redacted by the middleware as the JSON responses
+	body, err := converter.RedactJSON(archive.Bytes(), converter.RedactedFields(r.Context()))
+	if err != nil {
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", SnapshotContentType)
+	w.Header().Set("Content-Disposition", `attachment; filename="model-registry-snapshot.jsonl"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// ImportSnapshot - Import the entities of a JSON-lines archive exported by ExportSnapshot with new ids, or return
+// the plan of the import with dryRun
+func (c *SnapshotAPIController) ImportSnapshot(w http.ResponseWriter, r *http.Request) {
+	dryRunParam, err := parseBool(r.URL.Query().Get("dryRun"))
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Param: "dryRun", Err: err}, nil)
+		return
+	}
+	recordsParam := []api.SnapshotRecord{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	for {
+		var record api.SnapshotRecord
+		if err := d.Decode(&record); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		recordsParam = append(recordsParam, record)
+	}
+	if dryRunParam {
+		plan, err := api.WithContext(r.Context(), c.coreApi).PlanSnapshotImport(recordsParam)
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, plan, err)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).ImportSnapshot(recordsParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
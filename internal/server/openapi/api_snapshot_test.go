@@ -0,0 +1,168 @@
+package openapi_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	source, sourceService := inmemory.NewServer(t)
+	target, targetService := inmemory.NewServer(t)
+
+	// the ids of the target registry differ from those of the source
+	_, err := targetService.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "existing"})
+	require.NoError(t, err)
+
+	model, err := sourceService.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name:        "churn",
+		Description: apiutils.Of("Churn classifier"),
+		CustomProperties: map[string]openapi.MetadataValue{
+			"team": openapi.MetadataStringValueAsMetadataValue(openapi.NewMetadataStringValue("growth", "MetadataStringValue")),
+		},
+	})
+	require.NoError(t, err)
+	v1, err := sourceService.UpsertModelVersion(&openapi.ModelVersion{Name: "v1", Author: apiutils.Of("alice")}, model.Id)
+	require.NoError(t, err)
+	v2, err := sourceService.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+	weights, err := sourceService.UpsertModelVersionArtifact(&openapi.Artifact{ModelArtifact: &openapi.ModelArtifact{
+		Name: apiutils.Of("weights"),
+		Uri:  apiutils.Of("s3://models/churn/v1"),
+	}}, *v1.Id)
+	require.NoError(t, err)
+	_, err = sourceService.UpsertModelVersionArtifact(&openapi.Artifact{DocArtifact: &openapi.DocArtifact{
+		Name: apiutils.Of("readme"),
+		Uri:  apiutils.Of("https://docs.example.com/churn"),
+	}}, *v1.Id)
+	require.NoError(t, err)
+	// v2 shares the weights of v1
+	_, err = sourceService.UpsertModelVersionArtifact(weights, *v2.Id)
+	require.NoError(t, err)
+	_, err = sourceService.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "not-exported"})
+	require.NoError(t, err)
+
+	resp, err := http.Post(source.URL+"/api/model_registry/v1alpha3/exports", "application/json",
+		strings.NewReader(`{"registeredModelIds": ["`+*model.Id+`"]}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	archive, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+
+	var kinds []string
+	scanner := bufio.NewScanner(bytes.NewReader(archive))
+	for scanner.Scan() {
+		var record api.SnapshotRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		kinds = append(kinds, record.Kind)
+	}
+	assert.Equal(t, []string{"Header", "RegisteredModel", "ModelVersion", "Artifact", "Artifact", "ModelVersion", "Artifact"}, kinds)
+
+	importSnapshot := func(archive []byte) (int, api.SnapshotImport) {
+		resp, err := http.Post(target.URL+"/api/model_registry/v1alpha3/imports", "application/x-ndjson", bytes.NewReader(archive))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var result api.SnapshotImport
+		if resp.StatusCode == http.StatusCreated {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		}
+		return resp.StatusCode, result
+	}
+
+	planImport := func(archive []byte) api.Plan {
+		resp, err := http.Post(target.URL+"/api/model_registry/v1alpha3/imports?dryRun=true", "application/x-ndjson", bytes.NewReader(archive))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var plan api.Plan
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&plan))
+		return plan
+	}
+
+	// the dry run imports nothing
+	plan := planImport(archive)
+	assert.Equal(t, int32(5), plan.Creates)
+	assert.Equal(t, int32(1), plan.Skips, "the shared artifact is imported once")
+	assert.Zero(t, plan.Conflicts)
+	require.Len(t, plan.Items, 6)
+	assert.Equal(t, api.PlanItem{Action: api.PlanActionCreate, EntityType: api.EntityTypeRegisteredModel, Name: "churn", SourceId: *model.Id}, plan.Items[0])
+	assert.Equal(t, api.PlanItem{Action: api.PlanActionCreate, EntityType: api.EntityTypeModelVersion, Name: "v1", ParentName: "churn", SourceId: *v1.Id}, plan.Items[1])
+	assert.Equal(t, api.PlanActionSkip, plan.Items[5].Action)
+	assert.Equal(t, "weights", plan.Items[5].Name)
+	assert.Equal(t, "v2", plan.Items[5].ParentName)
+	registeredModels, err := targetService.GetRegisteredModels(api.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), registeredModels.Size)
+
+	status, result := importSnapshot(archive)
+	require.Equal(t, http.StatusCreated, status)
+	require.Len(t, result.RegisteredModels, 1)
+	require.Len(t, result.ModelVersions, 2)
+	require.Len(t, result.Artifacts, 2, "the shared artifact is imported once")
+	assert.Equal(t, *model.Id, result.RegisteredModels[0].SourceId)
+	assert.NotEqual(t, *model.Id, result.RegisteredModels[0].Id)
+
+	imported, err := targetService.GetRegisteredModelById(result.RegisteredModels[0].Id)
+	require.NoError(t, err)
+	assert.Equal(t, "churn", imported.Name)
+	assert.Equal(t, "Churn classifier", imported.GetDescription())
+	assert.Equal(t, "growth", imported.CustomProperties["team"].MetadataStringValue.StringValue)
+
+	importedV1, err := targetService.GetModelVersionByParams(apiutils.Of("v1"), imported.Id, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", importedV1.GetAuthor())
+	artifacts, err := targetService.GetArtifacts("", api.ListOptions{}, importedV1.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), artifacts.Size)
+
+	importedV2, err := targetService.GetModelVersionByParams(apiutils.Of("v2"), imported.Id, nil)
+	require.NoError(t, err)
+	artifacts, err = targetService.GetArtifacts("", api.ListOptions{}, importedV2.Id)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), artifacts.Size)
+	assert.Equal(t, result.Artifacts[0].Id, *artifacts.Items[0].ModelArtifact.Id)
+	assert.Equal(t, "s3://models/churn/v1", artifacts.Items[0].ModelArtifact.GetUri())
+
+	// the registered models of a snapshot must not exist yet, nothing is imported otherwise
+	plan = planImport(archive)
+	assert.Equal(t, int32(1), plan.Conflicts)
+	assert.Equal(t, api.PlanActionConflict, plan.Items[0].Action)
+	assert.Equal(t, result.RegisteredModels[0].Id, plan.Items[0].Id)
+	assert.Contains(t, plan.Items[0].Message, "registered model churn of line 2 already exists")
+	status, _ = importSnapshot(archive)
+	assert.Equal(t, http.StatusConflict, status)
+	models, err := targetService.GetRegisteredModels(api.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), models.Size)
+
+	for _, invalid := range []string{
+		``,
+		`{"kind": "Header", "version": 2}`,
+		`{"kind": "Header", "version": 1}` + "\n" + `{"kind": "ModelVersion", "modelVersion": {"id": "1", "name": "v1", "registeredModelId": "1"}}`,
+		`{"kind": "Header", "version": 1}` + "\n" + `{"kind": "Dataset"}`,
+		`{"kind": "Header", "version": 1, "unknown": true}`,
+	} {
+		status, _ := importSnapshot([]byte(invalid))
+		assert.Equal(t, http.StatusBadRequest, status, invalid)
+	}
+
+	for _, body := range []string{`{"registeredModelIds": []}`, `{"registeredModelIds": ["999"]}`} {
+		resp, err := http.Post(source.URL+"/api/model_registry/v1alpha3/exports", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.NotEqual(t, http.StatusOK, resp.StatusCode, body)
+	}
+}
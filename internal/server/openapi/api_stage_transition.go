@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// StageTransitionAPIController binds http requests for the stage lifecycle of model versions to the core api and
+// writes the results to the http response
+type StageTransitionAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewStageTransitionAPIController creates a default stage transition api controller
+func NewStageTransitionAPIController(coreApi api.ModelRegistryApi) *StageTransitionAPIController {
+	return &StageTransitionAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the StageTransitionAPIController
+func (c *StageTransitionAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the StageTransitionAPIController
+func (c *StageTransitionAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"GetStageTransitions",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/stage_transitions",
+			c.GetStageTransitions,
+		},
+		{
+			"GetModelVersionStageTransitions",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}/stage_transitions",
+			c.GetModelVersionStageTransitions,
+		},
+		{
+			"TransitionModelVersionStage",
+			strings.ToUpper("Post"),
+			"/api/model_registry/v1alpha3/model_versions/{modelversionId}:transition",
+			c.TransitionModelVersionStage,
+		},
+	}
+}
+
+// GetStageTransitions - List all StageTransitions
+func (c *StageTransitionAPIController) GetStageTransitions(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetStageTransitions(listOptions, nil)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetModelVersionStageTransitions - List the stage history of a ModelVersion
+func (c *StageTransitionAPIController) GetModelVersionStageTransitions(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetStageTransitions(listOptions, &modelversionIdParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// TransitionModelVersionStage - Move a ModelVersion to another stage of its lifecycle
+func (c *StageTransitionAPIController) TransitionModelVersionStage(w http.ResponseWriter, r *http.Request) {
+	modelversionIdParam := chi.URLParam(r, "modelversionId")
+	if modelversionIdParam == "" {
+		c.errorHandler(w, r, &RequiredError{"modelversionId"}, nil)
+		return
+	}
+	transitionParam := api.StageTransition{}
+	if err := decodeStrict(r, &transitionParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).TransitionModelVersionStage(modelversionIdParam, &transitionParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusCreated, result, err)
+}
@@ -0,0 +1,100 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStageTransition(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+	v1, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	v2, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+
+	transition := func(modelVersionId string, body api.StageTransition, out *api.StageTransition) int {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		resp, err := http.Post(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s:transition", server.URL, modelVersionId), "application/json", bytes.NewReader(encoded))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	stage := func(modelVersionId string) string {
+		mv, err := service.GetModelVersionById(modelVersionId)
+		require.NoError(t, err)
+		value, ok := mv.GetCustomProperties()["stage"]
+		if !ok {
+			return ""
+		}
+		return value.MetadataStringValue.StringValue
+	}
+
+	// versions can't skip Staging on their way to Production
+	assert.Equal(t, http.StatusConflict, transition(*v1.Id, api.StageTransition{ToStage: api.StageProduction}, nil))
+	assert.Equal(t, http.StatusBadRequest, transition(*v1.Id, api.StageTransition{ToStage: "Canary"}, nil))
+	assert.Equal(t, http.StatusConflict, transition(*v1.Id, api.StageTransition{ToStage: api.StageNone}, nil))
+	assert.Equal(t, http.StatusNotFound, transition("999", api.StageTransition{ToStage: api.StageStaging}, nil))
+
+	var staged api.StageTransition
+	require.Equal(t, http.StatusCreated, transition(*v1.Id, api.StageTransition{ToStage: "staging", Comment: "passed offline evaluation"}, &staged))
+	assert.Equal(t, *v1.Id, staged.ModelVersionId)
+	assert.Equal(t, api.StageNone, staged.FromStage)
+	assert.Equal(t, api.StageStaging, staged.ToStage)
+	assert.Equal(t, "passed offline evaluation", staged.Comment)
+	assert.NotEmpty(t, staged.CreateTimeSinceEpoch)
+	assert.Equal(t, "Staging", stage(*v1.Id))
+
+	var promoted api.StageTransition
+	require.Equal(t, http.StatusCreated, transition(*v1.Id, api.StageTransition{ToStage: api.StageProduction}, &promoted))
+	assert.Equal(t, api.StageStaging, promoted.FromStage)
+	assert.Equal(t, "Production", stage(*v1.Id))
+
+	// archiveExisting replaces the Production versions of the registered model
+	require.Equal(t, http.StatusCreated, transition(*v2.Id, api.StageTransition{ToStage: api.StageStaging}, nil))
+	require.Equal(t, http.StatusCreated, transition(*v2.Id, api.StageTransition{ToStage: api.StageProduction, ArchiveExisting: true}, nil))
+	assert.Equal(t, "Production", stage(*v2.Id))
+	assert.Equal(t, "Archived", stage(*v1.Id))
+
+	var none api.StageTransition
+	require.Equal(t, http.StatusCreated, transition(*v1.Id, api.StageTransition{ToStage: api.StageNone}, &none))
+	assert.Equal(t, api.StageArchived, none.FromStage)
+	assert.Equal(t, "", stage(*v1.Id))
+
+	resp, err := http.Get(fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/stage_transitions", server.URL, *v1.Id))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history api.StageTransitionList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&history))
+	require.Len(t, history.Items, 4)
+	for _, item := range history.Items {
+		assert.Equal(t, *v1.Id, item.ModelVersionId)
+	}
+	assert.ElementsMatch(t, []api.ModelVersionStage{api.StageStaging, api.StageProduction, api.StageArchived, api.StageNone},
+		[]api.ModelVersionStage{history.Items[0].ToStage, history.Items[1].ToStage, history.Items[2].ToStage, history.Items[3].ToStage})
+
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/stage_transitions")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var all api.StageTransitionList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&all))
+	assert.Len(t, all.Items, 6)
+}
@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// TagAPIController binds http requests for the tags of registered models, model versions, experiments, experiment
+// runs, inference services and serving environments to the core api and writes the results to the http response
+type TagAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewTagAPIController creates a default tag api controller
+func NewTagAPIController(coreApi api.ModelRegistryApi) *TagAPIController {
+	return &TagAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// taggedCollections are the collections of the entities carrying tags, with the name of their routes, their id
+// parameter and their tag entity type.
+var taggedCollections = []struct {
+	collection string
+	name       string
+	idParam    string
+	entityType string
+}{
+	{"registered_models", "RegisteredModel", "registeredmodelId", api.TagEntityTypeRegisteredModel},
+	{"model_versions", "ModelVersion", "modelversionId", api.TagEntityTypeModelVersion},
+	{"experiments", "Experiment", "experimentId", api.TagEntityTypeExperiment},
+	{"experiment_runs", "ExperimentRun", "experimentrunId", api.TagEntityTypeExperimentRun},
+	{"inference_services", "InferenceService", "inferenceserviceId", api.TagEntityTypeInferenceService},
+	{"serving_environments", "ServingEnvironment", "servingenvironmentId", api.TagEntityTypeServingEnvironment},
+}
+
+// Routes returns all the api routes for the TagAPIController
+func (c *TagAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the TagAPIController
+func (c *TagAPIController) OrderedRoutes() []Route {
+	routes := []Route{
+		{
+			"GetTags",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/tags",
+			c.GetTags,
+		},
+		{
+			"GetTag",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/tags/{tagName}",
+			c.GetTag,
+		},
+		{
+			"UpsertTag",
+			strings.ToUpper("Put"),
+			"/api/model_registry/v1alpha3/tags/{tagName}",
+			c.UpsertTag,
+		},
+		{
+			"DeleteTag",
+			strings.ToUpper("Delete"),
+			"/api/model_registry/v1alpha3/tags/{tagName}",
+			c.DeleteTag,
+		},
+	}
+	for _, tagged := range taggedCollections {
+		pattern := "/api/model_registry/v1alpha3/" + tagged.collection + "/{" + tagged.idParam + "}/tags"
+		routes = append(routes,
+			Route{
+				"Get" + tagged.name + "Tags",
+				strings.ToUpper("Get"),
+				pattern,
+				c.GetEntityTags(tagged.entityType, tagged.idParam),
+			},
+			Route{
+				"Set" + tagged.name + "Tags",
+				strings.ToUpper("Put"),
+				pattern,
+				c.SetEntityTags(tagged.entityType, tagged.idParam),
+			},
+		)
+	}
+	return routes
+}
+
+// GetTags - List all Tags with the number of entities carrying them
+func (c *TagAPIController) GetTags(w http.ResponseWriter, r *http.Request) {
+	listOptions, err := parseListOptions(r)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetTags(listOptions)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// GetTag - Get a Tag
+func (c *TagAPIController) GetTag(w http.ResponseWriter, r *http.Request) {
+	tagNameParam := chi.URLParam(r, "tagName")
+	if tagNameParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tagName"}, nil)
+		return
+	}
+	result, err := api.WithContext(r.Context(), c.coreApi).GetTagByName(tagNameParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// UpsertTag - Create a Tag, or update its color and description
+func (c *TagAPIController) UpsertTag(w http.ResponseWriter, r *http.Request) {
+	tagNameParam := chi.URLParam(r, "tagName")
+	if tagNameParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tagName"}, nil)
+		return
+	}
+	tagParam := api.Tag{}
+	if err := decodeStrict(r, &tagParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if tagParam.Name != "" && tagParam.Name != tagNameParam {
+		c.errorHandler(w, r, &ParsingError{Err: fmt.Errorf("tag name %q does not match the name of the path %q", tagParam.Name, tagNameParam)}, nil)
+		return
+	}
+	tagParam.Name = tagNameParam
+	result, err := api.WithContext(r.Context(), c.coreApi).UpsertTag(&tagParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+}
+
+// DeleteTag - Delete a Tag, removing it from the entities carrying it
+func (c *TagAPIController) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	tagNameParam := chi.URLParam(r, "tagName")
+	if tagNameParam == "" {
+		c.errorHandler(w, r, &RequiredError{"tagName"}, nil)
+		return
+	}
+	err := api.WithContext(r.Context(), c.coreApi).DeleteTag(tagNameParam)
+	encodeCoreResponse(w, r, c.errorHandler, http.StatusNoContent, nil, err)
+}
+
+// GetEntityTags - Get the tags of an entity of entityType, which id is the idParam path parameter
+func (c *TagAPIController) GetEntityTags(entityType string, idParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, idParam)
+		if id == "" {
+			c.errorHandler(w, r, &RequiredError{idParam}, nil)
+			return
+		}
+		result, err := api.WithContext(r.Context(), c.coreApi).GetEntityTags(entityType, id)
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+	}
+}
+
+// SetEntityTags - Replace the tags of an entity of entityType, which id is the idParam path parameter
+func (c *TagAPIController) SetEntityTags(entityType string, idParam string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, idParam)
+		if id == "" {
+			c.errorHandler(w, r, &RequiredError{idParam}, nil)
+			return
+		}
+		tagsParam := api.EntityTags{}
+		if err := decodeStrict(r, &tagsParam); err != nil {
+			c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		result, err := api.WithContext(r.Context(), c.coreApi).SetEntityTags(entityType, id, tagsParam.Tags)
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+	}
+}
@@ -0,0 +1,107 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTags(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	churn, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "churn"})
+	require.NoError(t, err)
+	fraud, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	experiment, err := service.UpsertExperiment(&openapi.Experiment{Name: "tuning"})
+	require.NoError(t, err)
+
+	put := func(path string, body any, out any) int {
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPut, server.URL+"/api/model_registry/v1alpha3/"+path, bytes.NewReader(encoded))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	get := func(path string, out any) int {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/" + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		if out != nil && resp.StatusCode < 300 {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	// the tags are created when first attached, and sorted
+	var tags api.EntityTags
+	require.Equal(t, http.StatusOK, put("registered_models/"+*churn.Id+"/tags", api.EntityTags{Tags: []string{"production", "nlp", "nlp"}}, &tags))
+	assert.Equal(t, []string{"nlp", "production"}, tags.Tags)
+	require.Equal(t, http.StatusOK, put("registered_models/"+*fraud.Id+"/tags", api.EntityTags{Tags: []string{"production"}}, nil))
+	require.Equal(t, http.StatusOK, put("experiments/"+*experiment.Id+"/tags", api.EntityTags{Tags: []string{"nlp"}}, nil))
+
+	assert.Equal(t, http.StatusBadRequest, put("registered_models/"+*churn.Id+"/tags", api.EntityTags{Tags: []string{"a,b"}}, nil))
+	assert.Equal(t, http.StatusNotFound, put("registered_models/999/tags", api.EntityTags{Tags: []string{"nlp"}}, nil))
+
+	require.Equal(t, http.StatusOK, get("registered_models/"+*churn.Id+"/tags", &tags))
+	assert.Equal(t, []string{"nlp", "production"}, tags.Tags)
+	assert.Equal(t, http.StatusNotFound, get("model_versions/"+*churn.Id+"/tags", nil), "registered models are not model versions")
+
+	// the contexts are listed by all their tags
+	var models openapi.RegisteredModelList
+	require.Equal(t, http.StatusOK, get("registered_models?tags=production", &models))
+	assert.Equal(t, int32(2), models.Size)
+	require.Equal(t, http.StatusOK, get("registered_models?tags=production,nlp", &models))
+	require.Equal(t, int32(1), models.Size)
+	assert.Equal(t, "churn", models.Items[0].Name)
+	var experiments openapi.ExperimentList
+	require.Equal(t, http.StatusOK, get("experiments?tags=nlp", &experiments))
+	assert.Equal(t, int32(1), experiments.Size)
+
+	// the tags are discovered with the number of entities carrying them
+	var tag api.Tag
+	require.Equal(t, http.StatusOK, put("tags/production", api.Tag{Color: openapi.PtrString("#00ff00"), Description: openapi.PtrString("Served in production")}, &tag))
+	assert.Equal(t, "production", tag.Name)
+	assert.Equal(t, "#00ff00", *tag.Color)
+	assert.Equal(t, int32(2), tag.Count)
+	assert.Equal(t, http.StatusBadRequest, put("tags/production", api.Tag{Name: "staging"}, nil))
+
+	var list api.TagList
+	require.Equal(t, http.StatusOK, get("tags", &list))
+	require.Equal(t, int32(2), list.Size)
+	assert.Equal(t, "nlp", list.Items[0].Name)
+	assert.Equal(t, int32(2), list.Items[0].Count)
+
+	require.Equal(t, http.StatusOK, get("tags?pageSize=1", &list))
+	require.Equal(t, int32(1), list.Size)
+	require.NotEmpty(t, list.NextPageToken)
+	require.Equal(t, http.StatusOK, get("tags?pageSize=1&nextPageToken="+list.NextPageToken, &list))
+	assert.Equal(t, "production", list.Items[0].Name)
+
+	// deleting a tag removes it from its entities
+	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/api/model_registry/v1alpha3/tags/%s", server.URL, "nlp"), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, http.StatusNotFound, get("tags/nlp", nil))
+	require.Equal(t, http.StatusOK, get("registered_models/"+*churn.Id+"/tags", &tags))
+	assert.Equal(t, []string{"production"}, tags.Tags)
+}
@@ -0,0 +1,66 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRegisteredModelAsOf(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	beforeCreate := time.Now().Add(-time.Second)
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud", Description: openapi.PtrString("first")})
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	beforeUpdate := time.Now()
+	time.Sleep(5 * time.Millisecond)
+
+	model.Description = openapi.PtrString("second")
+	_, err = service.UpsertRegisteredModel(model)
+	require.NoError(t, err)
+
+	get := func(asOf string) (int, openapi.RegisteredModel) {
+		path := fmt.Sprintf("%s/api/model_registry/v1alpha3/registered_models/%s", server.URL, *model.Id)
+		if asOf != "" {
+			path += "?asOf=" + url.QueryEscape(asOf)
+		}
+		resp, err := http.Get(path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var model openapi.RegisteredModel
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&model))
+		}
+		return resp.StatusCode, model
+	}
+
+	status, current := get("")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "second", current.GetDescription())
+
+	status, past := get(beforeUpdate.Format(time.RFC3339Nano))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "first", past.GetDescription())
+	assert.Equal(t, "fraud", past.Name)
+
+	status, now := get(time.Now().Add(time.Second).Format(time.RFC3339))
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "second", now.GetDescription())
+
+	// the model didn't exist yet
+	status, _ = get(beforeCreate.Format(time.RFC3339Nano))
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = get("yesterday")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/budget"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+const (
+	// defaultWatchTimeout and maxWatchTimeout bound how long a watch waits for changes, in seconds.
+	defaultWatchTimeout = 30
+	maxWatchTimeout     = 300
+
+	// watchPollInterval is how often a waiting watch looks for changes.
+	watchPollInterval = 500 * time.Millisecond
+	// watchSettleDelay is how long changes are left to settle before they are returned, so that writes still in flight
+	// with an earlier update time are not skipped by the resource version.
+	watchSettleDelay = time.Second
+)
+
+// WatchAPIController binds http requests watching the changes of entities to the core api and writes the changes to
+// the http response, either once there are some (long polling) or as they happen (streaming)
+type WatchAPIController struct {
+	coreApi      api.ModelRegistryApi
+	errorHandler ErrorHandler
+}
+
+// NewWatchAPIController creates a default watch api controller
+func NewWatchAPIController(coreApi api.ModelRegistryApi) *WatchAPIController {
+	return &WatchAPIController{
+		coreApi:      coreApi,
+		errorHandler: DefaultErrorHandler,
+	}
+}
+
+// Routes returns all the api routes for the WatchAPIController
+func (c *WatchAPIController) Routes() Routes {
+	routes := Routes{}
+	for _, route := range c.OrderedRoutes() {
+		routes[route.Name] = route
+	}
+	return routes
+}
+
+// OrderedRoutes returns all the api routes in a deterministic order for the WatchAPIController
+func (c *WatchAPIController) OrderedRoutes() []Route {
+	return []Route{
+		{
+			"Watch",
+			strings.ToUpper("Get"),
+			"/api/model_registry/v1alpha3/watch",
+			c.Watch,
+		},
+	}
+}
+
+// Watch - Get the changes of entities after a resource version, entity types are comma separated or repeated.
+// Without resourceVersion only the current resource version is returned, to start watching from. Otherwise the request
+// waits up to timeoutSeconds for changes and returns them with the resource version to watch next, or streams them as
+// newline delimited JSON followed by bookmarks to resume from if stream is true.
+func (c *WatchAPIController) Watch(w http.ResponseWriter, r *http.Request) {
+	query, err := parseQuery(r.URL.RawQuery)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	entityTypesParam := []string{}
+	for _, entityTypes := range query["entityTypes"] {
+		for entityType := range strings.SplitSeq(entityTypes, ",") {
+			if entityType = strings.TrimSpace(entityType); entityType != "" && !slices.Contains(entityTypesParam, entityType) {
+				entityTypesParam = append(entityTypesParam, entityType)
+			}
+		}
+	}
+	timeoutParam := defaultWatchTimeout
+	if param := query.Get("timeoutSeconds"); param != "" {
+		if timeoutParam, err = strconv.Atoi(param); err != nil || timeoutParam < 0 || timeoutParam > maxWatchTimeout {
+			c.errorHandler(w, r, &ParsingError{Param: "timeoutSeconds", Err: fmt.Errorf("must be between 0 and %d", maxWatchTimeout)}, nil)
+			return
+		}
+	}
+	streamParam := false
+	if param := query.Get("stream"); param != "" {
+		if streamParam, err = strconv.ParseBool(param); err != nil {
+			c.errorHandler(w, r, &ParsingError{Param: "stream", Err: err}, nil)
+			return
+		}
+	}
+
+	resourceVersionParam := query.Get("resourceVersion")
+	if resourceVersionParam == "" {
+		current := settledResourceVersion()
+		result, err := api.WithContext(r.Context(), c.coreApi).GetChanges(entityTypesParam, current, current)
+		encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+		return
+	}
+	resourceVersion, err := strconv.ParseInt(resourceVersionParam, 10, 64)
+	if err != nil || resourceVersion < 0 {
+		c.errorHandler(w, r, &ParsingError{Param: "resourceVersion", Err: fmt.Errorf("must be a resource version returned by a watch")}, nil)
+		return
+	}
+
+	deadline := time.Now().Add(time.Duration(timeoutParam) * time.Second)
+	if requestDeadline, ok := r.Context().Deadline(); ok && requestDeadline.Add(-watchPollInterval).Before(deadline) {
+		// leave the last poll time to answer before the request deadline
+		deadline = requestDeadline.Add(-watchPollInterval)
+	}
+	var stream *watchStream
+	for {
+		// each poll has its own query budget
+		registry := api.WithContext(budget.Renew(r.Context()), c.coreApi)
+		result, err := registry.GetChanges(entityTypesParam, resourceVersion, settledResourceVersion())
+		if err != nil {
+			if stream == nil {
+				encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, err)
+				return
+			}
+			// the status is already sent, the client resumes from the last bookmark
+			glog.Errorf("Watch failed: %v", err)
+			return
+		}
+		resourceVersion, _ = strconv.ParseInt(result.ResourceVersion, 10, 64)
+
+		timedOut := !time.Now().Before(deadline)
+		if result.Size > 0 || timedOut {
+			if !streamParam {
+				encodeCoreResponse(w, r, c.errorHandler, http.StatusOK, result, nil)
+				return
+			}
+			if stream == nil {
+				stream = newWatchStream(w)
+			}
+			if err := stream.write(result); err != nil || timedOut {
+				return
+			}
+			if result.Size > 0 {
+				// there may be more changes than a list holds
+				continue
+			}
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(min(watchPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// settledResourceVersion returns the resource version of the changes settled so far.
+func settledResourceVersion() int64 {
+	return time.Now().Add(-watchSettleDelay).UnixMilli()
+}
+
+// watchStream writes changes as newline delimited JSON, each list of changes is followed by a bookmark and flushed.
+type watchStream struct {
+	w       http.ResponseWriter
+	encoder *json.Encoder
+}
+
+func newWatchStream(w http.ResponseWriter) *watchStream {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(http.StatusOK)
+	return &watchStream{w: w, encoder: json.NewEncoder(w)}
+}
+
+func (s *watchStream) write(changes *api.ChangeList) error {
+	for _, change := range changes.Items {
+		if err := s.encoder.Encode(change); err != nil {
+			return err
+		}
+	}
+	if err := s.encoder.Encode(api.Change{Type: api.ChangeBookmark, ResourceVersion: changes.ResourceVersion}); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.w).Flush()
+}
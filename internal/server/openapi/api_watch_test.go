@@ -0,0 +1,103 @@
+package openapi_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatch(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	watch := func(query string) (*http.Response, api.ChangeList) {
+		resp, err := http.Get(server.URL + "/api/model_registry/v1alpha3/watch?" + query)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+
+		var changes api.ChangeList
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&changes))
+		}
+		return resp, changes
+	}
+
+	resp, changes := watch("")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, changes.Items)
+	start := changes.ResourceVersion
+	assert.NotEmpty(t, start)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+
+	// waits for the changes to settle
+	resp, changes = watch("resourceVersion=" + start + "&timeoutSeconds=10")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, changes.Items, 2)
+	assert.Equal(t, int32(2), changes.Size)
+	assert.Equal(t, api.ChangeAdded, changes.Items[0].Type)
+	assert.Equal(t, api.EntityTypeRegisteredModel, changes.Items[0].EntityType)
+	assert.Equal(t, *model.Id, changes.Items[0].Id)
+	assert.Equal(t, *model.LastUpdateTimeSinceEpoch, changes.Items[0].ResourceVersion)
+	assert.Equal(t, "fraud", changes.Items[0].Object.(map[string]any)["name"])
+	assert.Equal(t, api.EntityTypeModelVersion, changes.Items[1].EntityType)
+	assert.Equal(t, *version.Id, changes.Items[1].Id)
+	next := changes.ResourceVersion
+
+	// no changes until the timeout
+	resp, changes = watch("resourceVersion=" + next + "&timeoutSeconds=0")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, changes.Items)
+	assert.GreaterOrEqual(t, changes.ResourceVersion, next)
+
+	time.Sleep(5 * time.Millisecond)
+	model.Description = apiutils.Of("fraud detection")
+	_, err = service.UpsertRegisteredModel(model)
+	require.NoError(t, err)
+	_, err = service.UpsertModelVersion(&openapi.ModelVersion{Name: "v2"}, model.Id)
+	require.NoError(t, err)
+
+	resp, changes = watch("resourceVersion=" + next + "&entityTypes=RegisteredModel&timeoutSeconds=10")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, changes.Items, 1)
+	assert.Equal(t, api.ChangeModified, changes.Items[0].Type)
+	assert.Equal(t, "fraud detection", changes.Items[0].Object.(map[string]any)["description"])
+
+	// streams the changes followed by a bookmark until the timeout
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/watch?resourceVersion=0&entityTypes=ModelVersion&stream=true&timeoutSeconds=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	events := []api.Change{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var event api.Change
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	require.GreaterOrEqual(t, len(events), 3)
+	assert.Equal(t, "v1", events[0].Object.(map[string]any)["name"])
+	assert.Equal(t, "v2", events[1].Object.(map[string]any)["name"])
+	assert.Equal(t, api.ChangeBookmark, events[2].Type)
+	assert.Equal(t, api.ChangeBookmark, events[len(events)-1].Type)
+
+	resp, _ = watch("resourceVersion=0&entityTypes=Promotion")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = watch("resourceVersion=now")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = watch("resourceVersion=0&timeoutSeconds=301")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
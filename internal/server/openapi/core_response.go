@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	model "github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// errorBody returns the body of an error response, naming policy violations add the broken rule and a suggestion.
+func errorBody(code int, err error) any {
+	var violation *api.NamingViolation
+	if errors.As(err, &violation) {
+		return namingViolationError{
+			Code:      http.StatusText(code),
+			Message:   err.Error(),
+			Violation: violation,
+		}
+	}
+	return model.Error{
+		Code:    http.StatusText(code),
+		Message: err.Error(),
+	}
+}
+
+type namingViolationError struct {
+	Code      string               `json:"code"`
+	Message   string               `json:"message"`
+	Violation *api.NamingViolation `json:"violation"`
+}
+
+// encodeCoreResponse writes the result of a core api call to the http response, used by the controllers
+// that bind directly to the core api instead of going through a generated servicer.
+func encodeCoreResponse(w http.ResponseWriter, r *http.Request, errorHandler ErrorHandler, code int, body any, err error) {
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		result := ErrorResponse(api.ErrToStatus(err), err)
+		errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	_ = EncodeJSONResponse(body, &code, w)
+}
+
+// writeResponse returns the result of a write, with the warnings of the lint rules it breaks if any.
+func (s *ModelRegistryServiceAPIService) writeResponse(code int, result any) ImplResponse {
+	if warnings := s.coreApi.LintWrite(result); len(warnings) > 0 {
+		return Response(code, withWarnings{result: result, warnings: warnings})
+	}
+	return Response(code, result)
+}
+
+// withWarnings adds a warnings array to the JSON object of the result of a write.
+type withWarnings struct {
+	result   any
+	warnings []api.Warning
+}
+
+func (w withWarnings) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(w.result)
+	if err != nil {
+		return nil, err
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+
+	if object["warnings"], err = json.Marshal(w.warnings); err != nil {
+		return nil, err
+	}
+	return json.Marshal(object)
+}
@@ -22,8 +22,6 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	model "github.com/kubeflow/model-registry/pkg/openapi"
 )
 
 const errMsgRequiredMissing = "required parameter is missing"
@@ -41,10 +39,7 @@ func Response(code int, body interface{}) ImplResponse {
 func ErrorResponse(code int, err error) ImplResponse {
 	return ImplResponse{
 		Code: code,
-		Body: model.Error{
-			Code:    http.StatusText(code),
-			Message: err.Error(),
-		},
+		Body: errorBody(code, err),
 	}
 }
 
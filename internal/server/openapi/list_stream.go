@@ -0,0 +1,216 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/converter"
+	"github.com/kubeflow/model-registry/pkg/api"
+	model "github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+// EventStreamContentType is the media type of the lists streamed as server-sent events, when the request accepts it.
+const EventStreamContentType = "text/event-stream"
+
+// Events of a streamed list.
+const (
+	// listItemEvent carries the JSON of an item of the list.
+	listItemEvent = "item"
+	// listEndEvent ends the list with its listStreamEnd.
+	listEndEvent = "end"
+	// listErrorEvent ends a list failing after its first item with the error body of the failure.
+	listErrorEvent = "error"
+)
+
+// listStreamEnd is the data of the end event of a streamed list.
+type listStreamEnd struct {
+	NextPageToken string `json:"nextPageToken"`
+	Size          int32  `json:"size"`
+}
+
+// ListStreamServicer streams the pages of the list endpoints, each yield is called with an item of the page as it is
+// read from the database and the token of the next page is returned.
+type ListStreamServicer interface {
+	StreamExperimentRuns(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string, yield func(any) error) (string, error)
+	StreamExperimentExperimentRuns(ctx context.Context, experimentId string, name string, externalId string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, yield func(any) error) (string, error)
+	StreamModelVersions(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string, yield func(any) error) (string, error)
+	StreamRegisteredModels(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string, yield func(any) error) (string, error)
+	StreamRegisteredModelVersions(ctx context.Context, registeredmodelId string, name string, externalID string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, yield func(any) error) (string, error)
+}
+
+var _ ListStreamServicer = (*ModelRegistryServiceAPIService)(nil)
+
+// acceptsEventStream reports whether the client of r accepts the lists streamed as server-sent events.
+func acceptsEventStream(r *http.Request) bool {
+	for accepted := range strings.SplitSeq(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err == nil && mediaType == EventStreamContentType {
+			return true
+		}
+	}
+	return false
+}
+
+// listStreamHandler returns next serving the lists streamed as server-sent events to the clients accepting them, list
+// streams the page of the request with the parameters of its query.
+func listStreamHandler(errorHandler ErrorHandler, list func(r *http.Request, query url.Values, yield func(any) error) (string, error), next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !acceptsEventStream(r) {
+			next(w, r)
+			return
+		}
+
+		query, err := parseQuery(r.URL.RawQuery)
+		if err != nil {
+			errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		writeListStream(w, r, errorHandler, func(yield func(any) error) (string, error) {
+			return list(r, query, yield)
+		})
+	}
+}
+
+// writeListStream writes the items of a list as server-sent events, each flushed once written:
+//
+//	event: item
+//	data: <the JSON of an item>
+//
+//	event: end
+//	data: {"nextPageToken": "...", "size": <number of items>}
+//
+// The response starts with the first item, so that a list failing before it gets an error response. A list failing
+// after it ends with an error event instead of the end event.
+func writeListStream(w http.ResponseWriter, r *http.Request, errorHandler ErrorHandler, list func(yield func(any) error) (string, error)) {
+	stream := &eventStream{w: w, redacted: converter.RedactedFields(r.Context())}
+
+	var size int32
+	nextPageToken, err := list(func(item any) error {
+		size++
+		return stream.write(listItemEvent, item)
+	})
+	if err != nil {
+		if !stream.started {
+			result := ErrorResponse(api.ErrToStatus(err), err)
+			errorHandler(w, r, err, &result)
+			return
+		}
+		glog.Errorf("Streaming the list of %s failed: %v", r.URL.Path, err)
+		_ = stream.write(listErrorEvent, errorBody(api.ErrToStatus(err), err))
+		return
+	}
+	if err := stream.write(listEndEvent, listStreamEnd{NextPageToken: nextPageToken, Size: size}); err != nil {
+		glog.Errorf("Streaming the list of %s failed: %v", r.URL.Path, err)
+	}
+}
+
+// eventStream writes server-sent events, the JSON of their data redacted as the field access rules of the request
+// require.
+type eventStream struct {
+	w        http.ResponseWriter
+	redacted []string
+	started  bool
+}
+
+func (s *eventStream) write(event string, data any) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if len(s.redacted) > 0 {
+		if body, err = converter.RedactJSON(body, s.redacted); err != nil {
+			return err
+		}
+	}
+
+	if !s.started {
+		s.started = true
+		s.w.Header().Set("Content-Type", EventStreamContentType)
+		s.w.Header().Set("Cache-Control", "no-cache")
+		s.w.WriteHeader(http.StatusOK)
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", event, body); err != nil {
+		return err
+	}
+	return http.NewResponseController(s.w).Flush()
+}
+
+// StreamExperimentRuns - Stream the ExperimentRuns of a page
+func (s *ModelRegistryServiceAPIService) StreamExperimentRuns(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, tags string, yield func(any) error) (string, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
+	if err != nil {
+		return "", err
+	}
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	return api.WithContext(ctx, s.coreApi).StreamExperimentRuns(listOpts, nil, func(run *model.ExperimentRun) error {
+		return yield(run)
+	})
+}
+
+// StreamExperimentExperimentRuns - Stream the ExperimentRuns of an Experiment of a page
+func (s *ModelRegistryServiceAPIService) StreamExperimentExperimentRuns(ctx context.Context, experimentId string, name string, externalId string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, yield func(any) error) (string, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
+	if err != nil {
+		return "", err
+	}
+	return api.WithContext(ctx, s.coreApi).StreamExperimentRuns(listOpts, apiutils.StrPtr(experimentId), func(run *model.ExperimentRun) error {
+		return yield(run)
+	})
+}
+
+// StreamModelVersions - Stream the ModelVersions of a page
+func (s *ModelRegistryServiceAPIService) StreamModelVersions(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string, yield func(any) error) (string, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
+	if err != nil {
+		return "", err
+	}
+	if state != "" {
+		listOpts.State = &state
+	}
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	return api.WithContext(ctx, s.coreApi).StreamModelVersions(listOpts, nil, func(version *model.ModelVersion) error {
+		return yield(version)
+	})
+}
+
+// StreamRegisteredModels - Stream the RegisteredModels of a page
+func (s *ModelRegistryServiceAPIService) StreamRegisteredModels(ctx context.Context, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, tags string, yield func(any) error) (string, error) {
+	listOpts, err := s.buildListOption(filterQuery, q, pageSize, orderBy, sortOrder, nextPageToken)
+	if err != nil {
+		return "", err
+	}
+	if state != "" {
+		listOpts.State = &state
+	}
+	if tags != "" {
+		listOpts.Tags = strings.Split(tags, ",")
+	}
+	return api.WithContext(ctx, s.coreApi).StreamRegisteredModels(listOpts, func(registeredModel *model.RegisteredModel) error {
+		return yield(registeredModel)
+	})
+}
+
+// StreamRegisteredModelVersions - Stream the ModelVersions of a RegisteredModel of a page
+func (s *ModelRegistryServiceAPIService) StreamRegisteredModelVersions(ctx context.Context, registeredmodelId string, name string, externalID string, filterQuery string, q string, pageSize string, orderBy model.OrderByField, sortOrder model.SortOrder, nextPageToken string, state string, yield func(any) error) (string, error) {
+	listOpts, err := s.buildListOption(buildCombinedFilterQuery(filterQuery, name, externalID), q, pageSize, orderBy, sortOrder, nextPageToken)
+	if err != nil {
+		return "", err
+	}
+	if state != "" {
+		listOpts.State = &state
+	}
+	return api.WithContext(ctx, s.coreApi).StreamModelVersions(listOpts, apiutils.StrPtr(registeredmodelId), func(version *model.ModelVersion) error {
+		return yield(version)
+	})
+}
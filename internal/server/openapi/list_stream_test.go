@@ -0,0 +1,96 @@
+package openapi_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type event struct {
+	name string
+	data string
+}
+
+func TestListStream(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud"})
+	require.NoError(t, err)
+	for i := range 3 {
+		_, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: fmt.Sprintf("v%d", i)}, model.Id)
+		require.NoError(t, err)
+	}
+
+	stream := func(path string) (*http.Response, []event) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+"/api/model_registry/v1alpha3"+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept", "text/event-stream")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var events []event
+		var current event
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				events = append(events, current)
+				current = event{}
+			case strings.HasPrefix(line, "event: "):
+				current.name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				current.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+		require.NoError(t, scanner.Err())
+		return resp, events
+	}
+
+	resp, events := stream(fmt.Sprintf("/registered_models/%s/versions?pageSize=2&orderBy=ID", *model.Id))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	require.Len(t, events, 3)
+	for i, event := range events[:2] {
+		assert.Equal(t, "item", event.name)
+		var version openapi.ModelVersion
+		require.NoError(t, json.Unmarshal([]byte(event.data), &version))
+		assert.Equal(t, fmt.Sprintf("v%d", i), version.Name)
+	}
+	assert.Equal(t, "end", events[2].name)
+	var end struct {
+		NextPageToken string `json:"nextPageToken"`
+		Size          int32  `json:"size"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(events[2].data), &end))
+	assert.Equal(t, int32(2), end.Size)
+	require.NotEmpty(t, end.NextPageToken, "the page is followed by another")
+
+	_, events = stream(fmt.Sprintf("/registered_models/%s/versions?pageSize=2&orderBy=ID&nextPageToken=%s", *model.Id, end.NextPageToken))
+	require.Len(t, events, 2)
+	assert.Contains(t, events[0].data, `"name":"v2"`)
+	assert.Equal(t, `{"nextPageToken":"","size":1}`, events[1].data)
+
+	_, events = stream("/registered_models")
+	require.Len(t, events, 2)
+	assert.Contains(t, events[0].data, `"name":"fraud"`)
+
+	// a list failing before its first item gets an error response
+	resp, _ = stream("/experiments/999/experiment_runs")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// the lists are not streamed to the clients not accepting them
+	resp, err = http.Get(server.URL + "/api/model_registry/v1alpha3/registered_models")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json; charset=UTF-8", resp.Header.Get("Content-Type"))
+}
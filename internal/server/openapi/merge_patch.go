@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// MergePatchContentType is the content type of the JSON merge patches (RFC 7386) of the PATCH requests, which only
+// change the fields they name and remove those set to null.
+const MergePatchContentType = "application/merge-patch+json"
+
+// unclearableFields can't be removed by a merge patch: the columns of the entities, their discriminator, and the values
+// the entities can't be without.
+var unclearableFields = []string{"externalId", "uri", "state", "artifactType", "value"}
+
+// isMergePatch reports whether the body of r is a JSON merge patch.
+func isMergePatch(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == MergePatchContentType
+}
+
+// mergePatchHandler returns next serving the JSON merge patches as the updates they amount to: the patch is applied
+// to the entity get returns for the id of the path parameter param, and the merged update newUpdate returns is passed
+// to next as the body of a JSON request.
+func mergePatchHandler(errorHandler ErrorHandler, param string, get func(context.Context, string) (ImplResponse, error), newUpdate func() any, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !isMergePatch(r) {
+			next(w, r)
+			return
+		}
+
+		id := chi.URLParam(r, param)
+		if id == "" {
+			errorHandler(w, r, &RequiredError{param}, nil)
+			return
+		}
+		current, err := get(r.Context(), id)
+		if err != nil {
+			errorHandler(w, r, err, &current)
+			return
+		}
+		update := newUpdate()
+		ctx, err := decodeMergePatch(r, current.Body, update)
+		if err != nil {
+			errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+		body, err := json.Marshal(update)
+		if err != nil {
+			errorHandler(w, r, &ParsingError{Err: err}, nil)
+			return
+		}
+
+		merged := r.Clone(ctx)
+		merged.Header.Set("Content-Type", "application/json")
+		merged.Body = io.NopCloser(bytes.NewReader(body))
+		merged.ContentLength = int64(len(body))
+		next(w, merged)
+	}
+}
+
+// decodeMergePatch decodes into update the JSON merge patch of r applied to current, the entity as the api returns it.
+// The custom properties set to null are removed from those of the entity, and the other fields set to null are
+// returned as the cleared fields of the context of the request for the update to remove them.
+func decodeMergePatch(r *http.Request, current any, update any) (context.Context, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	var patch map[string]any
+	if err := decodeNumbers(body, &patch); err != nil || patch == nil {
+		return nil, errors.New("the merge patch must be a JSON object")
+	}
+	for field, value := range patch {
+		if value == nil && slices.Contains(unclearableFields, field) {
+			return nil, fmt.Errorf("the field %s can't be removed", field)
+		}
+	}
+
+	data, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	var merged map[string]any
+	if err := decodeNumbers(data, &merged); err != nil {
+		return nil, err
+	}
+	_, hadCustomProperties := merged["customProperties"]
+	merged = mergePatch(merged, patch).(map[string]any)
+	if _, ok := merged["customProperties"]; hadCustomProperties && !ok {
+		// an empty map replaces the custom properties, a missing one keeps them
+		merged["customProperties"] = map[string]any{}
+	}
+
+	// the fields of the patch, as merged, must be those of the update, with its discriminator
+	checked := map[string]any{}
+	for field := range patch {
+		checked[field] = merged[field]
+	}
+	if artifactType, ok := merged["artifactType"]; ok {
+		checked["artifactType"] = artifactType
+	}
+	if data, err = json.Marshal(checked); err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(reflect.New(reflect.TypeOf(update).Elem()).Interface()); err != nil {
+		return nil, err
+	}
+
+	if data, err = json.Marshal(merged); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, update); err != nil {
+		return nil, err
+	}
+
+	var cleared []string
+	for field, value := range patch {
+		if value == nil && field != "customProperties" {
+			cleared = append(cleared, field)
+		}
+	}
+	if len(cleared) == 0 {
+		return r.Context(), nil
+	}
+	slices.Sort(cleared)
+	return api.WithClearedFields(r.Context(), cleared), nil
+}
+
+// mergePatch applies patch to target as RFC 7386 does.
+func mergePatch(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+	for field, value := range patchObject {
+		if value == nil {
+			delete(targetObject, field)
+		} else {
+			targetObject[field] = mergePatch(targetObject[field], value)
+		}
+	}
+	return targetObject
+}
+
+// decodeNumbers decodes data keeping its numbers as they are written.
+func decodeNumbers(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
@@ -0,0 +1,56 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/naming"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingViolationResponse(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	enforcer, err := naming.NewEnforcer(&naming.Config{Policies: map[string]api.NamingPolicy{
+		api.EntityTypeRegisteredModel: {Case: api.NameCaseLower, ReservedPrefixes: []string{"kf-"}},
+	}})
+	require.NoError(t, err)
+	service.SetNamingPolicies(enforcer)
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(server.URL+"/api/model_registry/v1alpha3/registered_models", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := post(`{"name": "KF-Fraud"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var body struct {
+		Code      string              `json:"code"`
+		Message   string              `json:"message"`
+		Violation api.NamingViolation `json:"violation"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Bad Request", body.Code)
+	assert.Contains(t, body.Message, `try "fraud"`)
+	assert.Equal(t, api.NamingViolation{
+		EntityType: api.EntityTypeRegisteredModel,
+		Name:       "kf-fraud",
+		Rule:       api.NamingRuleReservedPrefix,
+		Reason:     `prefix "kf-" is reserved`,
+		Suggestion: "fraud",
+	}, body.Violation)
+
+	resp = post(`{"name": "Fraud"}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var model map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&model))
+	assert.Equal(t, "fraud", model["name"])
+}
@@ -0,0 +1,61 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/propertylimits"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/kubeflow/model-registry/pkg/testing/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyLimits(t *testing.T) {
+	server, service := inmemory.NewServer(t)
+
+	enforcer, err := propertylimits.NewEnforcer(&propertylimits.Config{Limits: map[string]propertylimits.Limit{
+		api.EntityTypeRegisteredModel: {MaxCount: 1},
+		api.EntityTypeExperiment:      {MaxCount: 1, Mode: propertylimits.ModeTruncate},
+	}})
+	require.NoError(t, err)
+	service.SetPropertyLimits(enforcer)
+
+	post := func(path string, body string) *http.Response {
+		resp, err := http.Post(server.URL+"/api/model_registry/v1alpha3/"+path, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	properties := `"customProperties": {
+		"a": {"metadataType": "MetadataStringValue", "string_value": "1"},
+		"b": {"metadataType": "MetadataStringValue", "string_value": "2"}
+	}`
+
+	resp := post("registered_models", `{"name": "fraud", `+properties+`}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp = post("experiments", `{"name": "sweep", `+properties+`}`)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body struct {
+		openapi.Experiment
+		Warnings []api.Warning `json:"warnings"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, []string{"a"}, keys(body.CustomProperties))
+	require.Len(t, body.Warnings, 1)
+	assert.Equal(t, api.LintRuleCustomPropertyLimits, body.Warnings[0].Rule)
+}
+
+func keys(properties map[string]openapi.MetadataValue) []string {
+	result := []string{}
+	for key := range properties {
+		result = append(result, key)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package stale
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// BasePath is the path of the reports endpoints.
+const BasePath = "/api/model_registry/v1alpha3/reports"
+
+// List is the list of stale entities returned by the stale report.
+type List struct {
+	Items []Entity `json:"items"`
+	Size  int      `json:"size"`
+}
+
+// NewHandler returns the handler of the stale report, passing the other requests to next:
+//
+//	GET /api/model_registry/v1alpha3/reports/stale?entityType=  lists the stale registered models and model versions
+//
+// The report lists the entities flagged by the last run of the detection job, least recently updated first.
+func NewHandler(detector *Detector, next http.Handler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/stale", func(w http.ResponseWriter, r *http.Request) {
+		entities, err := detector.Stale(r.Context(), r.URL.Query().Get("entityType"))
+		if errors.Is(err, api.ErrBadRequest) {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err != nil {
+			glog.Errorf("Error reading stale entities: %v", err)
+			writeError(w, http.StatusInternalServerError, "error reading stale entities")
+			return
+		}
+		writeJSON(w, http.StatusOK, List{Items: entities, Size: len(entities)})
+	})
+
+	mux.Handle("/", next)
+
+	return mux
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing stale report response: %v", err)
+	}
+}
@@ -0,0 +1,302 @@
+// Package stale flags the registered models and model versions that were neither updated nor deployed for a while
+// with the stale system property, and reports them so that teams can archive or delete them.
+//
+// A model version is active if it was updated within the window, is served by an inference service deployed or
+// updated within the window, or by a serve model running or updated within the window. A registered model is active
+// if it was updated or served within the window, or has an active version. The registry does not record downloads,
+// so they are not taken into account.
+package stale
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/constants"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/db/utils"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// JobName is the name of the background job flagging the stale entities.
+	JobName = "stale-detection"
+	// DefaultInterval is how often stale entities are looked for.
+	DefaultInterval = time.Hour
+
+	// Property is the system property set to true on the stale entities.
+	Property = "stale"
+
+	// batchSize is the maximum number of flags set or cleared per statement
+	batchSize = 500
+)
+
+// Config enables the detection of stale entities.
+type Config struct {
+	// After is how long an entity must have been inactive to be stale, zero disables the detection.
+	After time.Duration
+	// Interval is how often stale entities are looked for, defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// Enabled reports whether stale entities are detected.
+func (c *Config) Enabled() bool {
+	return c.After > 0
+}
+
+// Entity is a stale registered model or model version.
+type Entity struct {
+	// EntityType is api.EntityTypeRegisteredModel or api.EntityTypeModelVersion.
+	EntityType string `json:"entityType"`
+	Id         int32  `json:"id,string"`
+	Name       string `json:"name"`
+	// RegisteredModelId is the registered model of a model version.
+	RegisteredModelId        *int32 `json:"registeredModelId,omitempty,string"`
+	LastUpdateTimeSinceEpoch int64  `json:"lastUpdateTimeSinceEpoch,string"`
+}
+
+// Detector flags the stale entities of a registry.
+type Detector struct {
+	db     *gorm.DB
+	config Config
+
+	registeredModelType  int32
+	modelVersionType     int32
+	inferenceServiceType int32
+	serveModelType       int32
+}
+
+// NewDetector returns a detector of the stale entities in db, typesMap maps type names to ids.
+func NewDetector(db *gorm.DB, config Config, typesMap map[string]int32) (*Detector, error) {
+	if config.After <= 0 {
+		return nil, fmt.Errorf("invalid stale entity window %s: must be positive", config.After)
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	d := &Detector{db: db, config: config}
+	for typeName, typeID := range map[string]*int32{
+		defaults.RegisteredModelTypeName:  &d.registeredModelType,
+		defaults.ModelVersionTypeName:     &d.modelVersionType,
+		defaults.InferenceServiceTypeName: &d.inferenceServiceType,
+		defaults.ServeModelTypeName:       &d.serveModelType,
+	} {
+		id, ok := typesMap[typeName]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in types map", typeName)
+		}
+		*typeID = id
+	}
+
+	return d, nil
+}
+
+// Job returns the background job flagging the stale entities every interval.
+func (d *Detector) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Flags the registered models and model versions neither updated nor deployed recently as stale",
+		Interval:    d.config.Interval,
+		Run: func(ctx context.Context) error {
+			flagged, cleared, err := d.Detect(ctx)
+			if flagged > 0 || cleared > 0 {
+				glog.Infof("Flagged %d entities as stale, %d are no longer stale", flagged, cleared)
+			}
+			return err
+		},
+	}
+}
+
+// Detect sets the stale property of the entities inactive for the configured window and removes it from the active
+// ones, returning how many entities were flagged and cleared.
+func (d *Detector) Detect(ctx context.Context) (flagged int, cleared int, err error) {
+	cutoff := time.Now().Add(-d.config.After).UnixMilli()
+
+	db := d.db.WithContext(ctx)
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	propertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+	parentTable := utils.GetTableName(db, &schema.ParentContext{})
+	executionTable := utils.GetTableName(db, &schema.Execution{})
+	executionPropertyTable := utils.GetTableName(db, &schema.ExecutionProperty{})
+
+	var inactiveModels, inactiveVersions []int32
+	if err := db.Model(&schema.Context{}).
+		Where("type_id = ? AND last_update_time_since_epoch < ?", d.registeredModelType, cutoff).
+		Pluck("id", &inactiveModels).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding inactive registered models: %w", err)
+	}
+	if err := db.Model(&schema.Context{}).
+		Where("type_id = ? AND last_update_time_since_epoch < ?", d.modelVersionType, cutoff).
+		Pluck("id", &inactiveVersions).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding inactive model versions: %w", err)
+	}
+
+	// registered models and model versions share the ids of contexts
+	var served []int32
+	if err := db.Table(propertyTable+" p").
+		Joins("JOIN "+contextTable+" s ON s.id = p.context_id").
+		Where("s.type_id = ? AND p.name IN ? AND p.is_custom_property = ? AND p.int_value IS NOT NULL",
+			d.inferenceServiceType, []string{"registered_model_id", "model_version_id"}, false).
+		Where("s.last_update_time_since_epoch >= ? OR EXISTS (SELECT 1 FROM "+propertyTable+" d WHERE d.context_id = s.id AND d.name = ? AND d.is_custom_property = ? AND d.string_value = ?)",
+			cutoff, "desired_state", false, "DEPLOYED").
+		Pluck("p.int_value", &served).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding deployed entities: %w", err)
+	}
+	var running []int32
+	if err := db.Table(executionPropertyTable+" p").
+		Joins("JOIN "+executionTable+" e ON e.id = p.execution_id").
+		Where("e.type_id = ? AND p.name = ? AND p.is_custom_property = ? AND p.int_value IS NOT NULL", d.serveModelType, "model_version_id", false).
+		Where("e.last_update_time_since_epoch >= ? OR e.last_known_state = ?", cutoff, constants.ExecutionStateMapping["RUNNING"]).
+		Pluck("p.int_value", &running).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding running serve models: %w", err)
+	}
+	active := toSet(append(served, running...))
+
+	staleVersions := map[int32]bool{}
+	for _, id := range inactiveVersions {
+		if !active[id] {
+			staleVersions[id] = true
+		}
+	}
+
+	var versionParents []schema.ParentContext
+	if err := db.Table(parentTable+" pc").
+		Select("pc.context_id, pc.parent_context_id").
+		Joins("JOIN "+contextTable+" c ON c.id = pc.context_id").
+		Where("c.type_id = ?", d.modelVersionType).
+		Scan(&versionParents).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding the registered models of model versions: %w", err)
+	}
+	for _, parent := range versionParents {
+		if !staleVersions[parent.ContextID] {
+			active[parent.ParentContextID] = true
+		}
+	}
+
+	stale := staleVersions
+	for _, id := range inactiveModels {
+		if !active[id] {
+			stale[id] = true
+		}
+	}
+
+	var flags []int32
+	if err := db.Table(propertyTable+" p").
+		Joins("JOIN "+contextTable+" c ON c.id = p.context_id").
+		Where("c.type_id IN ? AND p.name = ? AND p.is_custom_property = ?", []int32{d.registeredModelType, d.modelVersionType}, Property, false).
+		Pluck("p.context_id", &flags).Error; err != nil {
+		return 0, 0, fmt.Errorf("error finding stale entities: %w", err)
+	}
+	isFlagged := toSet(flags)
+
+	toFlag := []schema.ContextProperty{}
+	for id := range stale {
+		if !isFlagged[id] {
+			toFlag = append(toFlag, schema.ContextProperty{
+				ContextID:        id,
+				Name:             Property,
+				IsCustomProperty: false,
+				BoolValue:        apiutils.Of(true),
+			})
+		}
+	}
+	toClear := []int32{}
+	for _, id := range flags {
+		if !stale[id] {
+			toClear = append(toClear, id)
+		}
+	}
+
+	if len(toFlag) > 0 {
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&toFlag, batchSize).Error; err != nil {
+			return 0, 0, fmt.Errorf("error flagging stale entities: %w", err)
+		}
+	}
+	for batch := range slices.Chunk(toClear, batchSize) {
+		if err := db.Where("context_id IN ? AND name = ? AND is_custom_property = ?", batch, Property, false).
+			Delete(&schema.ContextProperty{}).Error; err != nil {
+			return len(toFlag), cleared, fmt.Errorf("error clearing the stale flag of active entities: %w", err)
+		}
+		cleared += len(batch)
+	}
+
+	return len(toFlag), cleared, nil
+}
+
+// Stale returns the entities flagged as stale by the last detection, least recently updated first, of entityType if
+// not empty. The entities of a tenant are the ones of its namespace.
+func (d *Detector) Stale(ctx context.Context, entityType string) ([]Entity, error) {
+	types := []int32{d.registeredModelType, d.modelVersionType}
+	switch entityType {
+	case "":
+	case api.EntityTypeRegisteredModel:
+		types = []int32{d.registeredModelType}
+	case api.EntityTypeModelVersion:
+		types = []int32{d.modelVersionType}
+	default:
+		return nil, fmt.Errorf("invalid entity type %q, must be %s or %s: %w", entityType,
+			api.EntityTypeRegisteredModel, api.EntityTypeModelVersion, api.ErrBadRequest)
+	}
+
+	db := d.db.WithContext(ctx)
+	contextTable := utils.GetTableName(db, &schema.Context{})
+	propertyTable := utils.GetTableName(db, &schema.ContextProperty{})
+	parentTable := utils.GetTableName(db, &schema.ParentContext{})
+
+	var rows []struct {
+		ID                       int32
+		TypeID                   int32
+		Name                     string
+		LastUpdateTimeSinceEpoch int64
+		ParentContextID          *int32
+	}
+	query := db.Table(contextTable+" c").
+		Select("c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id").
+		Joins("JOIN "+propertyTable+" p ON p.context_id = c.id AND p.name = ? AND p.is_custom_property = ?", Property, false).
+		Joins("LEFT JOIN "+parentTable+" pc ON pc.context_id = c.id").
+		Where("c.type_id IN ?", types)
+	if namespace := api.Namespace(ctx); namespace != "" {
+		query = query.Where("c.namespace = ?", namespace)
+	}
+	if err := query.Order("c.last_update_time_since_epoch, c.id").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("error reading stale entities: %w", err)
+	}
+
+	entities := make([]Entity, 0, len(rows))
+	for _, row := range rows {
+		entity := Entity{
+			EntityType:               api.EntityTypeRegisteredModel,
+			Id:                       row.ID,
+			Name:                     row.Name,
+			LastUpdateTimeSinceEpoch: row.LastUpdateTimeSinceEpoch,
+		}
+		if row.TypeID == d.modelVersionType {
+			entity.EntityType = api.EntityTypeModelVersion
+			entity.RegisteredModelId = row.ParentContextID
+			// model version names are prefixed by the id of their registered model
+			if _, name, ok := strings.Cut(row.Name, ":"); ok {
+				entity.Name = name
+			}
+		}
+		entities = append(entities, entity)
+	}
+
+	return entities, nil
+}
+
+func toSet(ids []int32) map[int32]bool {
+	set := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
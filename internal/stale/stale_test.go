@@ -0,0 +1,144 @@
+package stale
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var typesMap = map[string]int32{
+	defaults.RegisteredModelTypeName:  1,
+	defaults.ModelVersionTypeName:     2,
+	defaults.InferenceServiceTypeName: 3,
+	defaults.ServeModelTypeName:       4,
+}
+
+func newMockDetector(t *testing.T) (*Detector, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	detector, err := NewDetector(db, Config{After: 30 * 24 * time.Hour}, typesMap)
+	require.NoError(t, err)
+	return detector, mock
+}
+
+func TestNewDetector(t *testing.T) {
+	detector, _ := newMockDetector(t)
+	assert.Equal(t, DefaultInterval, detector.Job().Interval)
+
+	_, err := NewDetector(detector.db, Config{}, typesMap)
+	assert.ErrorContains(t, err, "must be positive")
+
+	_, err = NewDetector(detector.db, Config{After: time.Hour}, map[string]int32{defaults.ModelVersionTypeName: 2})
+	assert.ErrorContains(t, err, "not found in types map")
+}
+
+func TestDetect(t *testing.T) {
+	detector, mock := newMockDetector(t)
+
+	// registered model 10 has an inactive version 11 and a deployed version 12, registered model 20 only has the
+	// inactive version 21, registered model 30 was flagged but is active again
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "Context" WHERE type_id = $1 AND last_update_time_since_epoch < $2`)).
+		WithArgs(int32(1), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(20))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "Context" WHERE type_id = $1 AND last_update_time_since_epoch < $2`)).
+		WithArgs(int32(2), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11).AddRow(12).AddRow(21))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "p"."int_value" FROM "ContextProperty" p JOIN "Context" s ON s.id = p.context_id WHERE (s.type_id = $1 AND p.name IN ($2,$3)`)).
+		WithArgs(int32(3), "registered_model_id", "model_version_id", false, sqlmock.AnyArg(), "desired_state", false, "DEPLOYED").
+		WillReturnRows(sqlmock.NewRows([]string{"int_value"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "p"."int_value" FROM "ExecutionProperty" p JOIN "Execution" e ON e.id = p.execution_id`)).
+		WithArgs(int32(4), "model_version_id", false, sqlmock.AnyArg(), int32(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"int_value"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT pc.context_id, pc.parent_context_id FROM "ParentContext" pc JOIN "Context" c ON c.id = pc.context_id WHERE c.type_id = $1`)).
+		WithArgs(int32(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"context_id", "parent_context_id"}).AddRow(11, 10).AddRow(12, 10).AddRow(21, 20))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "p"."context_id" FROM "ContextProperty" p JOIN "Context" c ON c.id = p.context_id WHERE c.type_id IN ($1,$2) AND p.name = $3`)).
+		WithArgs(int32(1), int32(2), Property, false).
+		WillReturnRows(sqlmock.NewRows([]string{"context_id"}).AddRow(11).AddRow(30))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "ContextProperty"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "ContextProperty" WHERE context_id IN ($1) AND name = $2 AND is_custom_property = $3`)).
+		WithArgs(int32(30), Property, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	flagged, cleared, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	// 20 and 21 are flagged, 11 already was
+	assert.Equal(t, 2, flagged)
+	assert.Equal(t, 1, cleared)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler(t *testing.T) {
+	detector, mock := newMockDetector(t)
+
+	handler := NewHandler(detector, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id FROM "Context" c JOIN "ContextProperty" p`)).
+		WithArgs(Property, false, int32(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "last_update_time_since_epoch", "parent_context_id"}).
+			AddRow(21, 2, "20:v1", 1000, 20))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/stale?entityType=ModelVersion", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	assert.Equal(t, map[string]any{
+		"items": []any{map[string]any{
+			"entityType":               "ModelVersion",
+			"id":                       "21",
+			"name":                     "v1",
+			"registeredModelId":        "20",
+			"lastUpdateTimeSinceEpoch": "1000",
+		}},
+		"size": float64(1),
+	}, list)
+
+	// the stale entities of a tenant are the ones of its namespace
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT c.id, c.type_id, c.name, c.last_update_time_since_epoch, pc.parent_context_id FROM "Context" c JOIN "ContextProperty" p`) + `.* WHERE c.type_id IN \(\$3,\$4\) AND c.namespace = \$5`).
+		WithArgs(Property, false, int32(1), int32(2), "team-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type_id", "name", "last_update_time_since_epoch", "parent_context_id"}))
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, BasePath+"/stale", nil)
+	handler.ServeHTTP(w, req.WithContext(api.WithNamespace(req.Context(), "team-a")))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[],"size":0}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/stale?entityType=Experiment", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/registered_models", nil))
+	assert.Equal(t, http.StatusTeapot, w.Code)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+// Package storageuri parses the storage URIs of the artifacts into their components, so that the artifacts can be
+// filtered on the bucket or registry holding them whatever the form of their URI.
+package storageuri
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrInvalid is returned for URIs that can't be parsed, or that miss a component required by their scheme.
+var ErrInvalid = errors.New("invalid storage uri")
+
+// URI is the normalized components of a storage URI.
+type URI struct {
+	// Scheme is the lower case scheme of the URI, s3 for the https URLs of AWS S3 objects.
+	Scheme string
+	// Bucket is the bucket of s3:// and gs:// URIs, the registry of oci:// URIs and the host of the others.
+	Bucket string
+	// Path is the cleaned path of the URI below its bucket, without leading or trailing slash. It holds the repository
+	// and tag or digest of oci:// URIs.
+	Path string
+	// Region is the region of s3 URIs, when it is set by their defaultRegion query parameter or their AWS host.
+	Region string
+}
+
+// Parse returns the components of a storage URI. URIs without a scheme, e.g. local paths, have none.
+func Parse(uri string) (URI, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return URI{}, fmt.Errorf("%w %q: %v", ErrInvalid, uri, err)
+	}
+	if parsed.Scheme == "" {
+		return URI{}, nil
+	}
+
+	result := URI{
+		Scheme: strings.ToLower(parsed.Scheme),
+		Bucket: strings.ToLower(parsed.Host),
+		Path:   cleanPath(parsed.Path),
+	}
+
+	switch result.Scheme {
+	case "s3":
+		result.Region = parsed.Query().Get("defaultRegion")
+	case "http", "https":
+		if bucket, key, region, ok := awsS3Object(result.Bucket, result.Path); ok {
+			result = URI{Scheme: "s3", Bucket: bucket, Path: key, Region: region}
+		}
+	}
+
+	switch result.Scheme {
+	case "s3", "gs":
+		if result.Bucket == "" {
+			return URI{}, fmt.Errorf("%w %q: missing bucket", ErrInvalid, uri)
+		}
+	case "oci":
+		if result.Bucket == "" || result.Path == "" {
+			return URI{}, fmt.Errorf("%w %q: expected oci://<registry>/<repository>[:<tag>]", ErrInvalid, uri)
+		}
+	}
+
+	return result, nil
+}
+
+// awsS3Object returns the bucket, key and region of the virtual hosted (<bucket>.s3.<region>.amazonaws.com/<key>)
+// and path style (s3.<region>.amazonaws.com/<bucket>/<key>) URLs of AWS S3 objects.
+func awsS3Object(host string, urlPath string) (bucket string, key string, region string, ok bool) {
+	service, found := strings.CutSuffix(host, ".amazonaws.com")
+	if !found {
+		return "", "", "", false
+	}
+
+	labels := strings.Split(service, ".")
+	for i, label := range labels {
+		if label != "s3" && !strings.HasPrefix(label, "s3-") {
+			continue
+		}
+
+		region = strings.Join(labels[i+1:], ".")
+		if region == "" && label != "s3" {
+			// legacy s3-<region> hosts
+			region = strings.TrimPrefix(label, "s3-")
+		}
+
+		if i > 0 {
+			return strings.Join(labels[:i], "."), urlPath, region, true
+		}
+		bucket, key, _ = strings.Cut(urlPath, "/")
+		return bucket, key, region, bucket != ""
+	}
+
+	return "", "", "", false
+}
+
+func cleanPath(p string) string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
@@ -0,0 +1,36 @@
+package storageuri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]URI{
+		"s3://models/granite/v1/":                           {Scheme: "s3", Bucket: "models", Path: "granite/v1"},
+		"s3://models//granite/./v1?defaultRegion=eu-west-1": {Scheme: "s3", Bucket: "models", Path: "granite/v1", Region: "eu-west-1"},
+		"S3://Models/granite":                               {Scheme: "s3", Bucket: "models", Path: "granite"},
+		"https://models.s3.eu-west-1.amazonaws.com/granite": {Scheme: "s3", Bucket: "models", Path: "granite", Region: "eu-west-1"},
+		"https://my.models.s3.amazonaws.com/granite/v1":     {Scheme: "s3", Bucket: "my.models", Path: "granite/v1"},
+		"https://s3.us-east-2.amazonaws.com/models/granite": {Scheme: "s3", Bucket: "models", Path: "granite", Region: "us-east-2"},
+		"https://s3-us-west-1.amazonaws.com/models/granite": {Scheme: "s3", Bucket: "models", Path: "granite", Region: "us-west-1"},
+		"gs://models/granite":                               {Scheme: "gs", Bucket: "models", Path: "granite"},
+		"oci://quay.io/org/granite:v1":                      {Scheme: "oci", Bucket: "quay.io", Path: "org/granite:v1"},
+		"https://huggingface.co/ibm-granite/granite-3.0-8b": {Scheme: "https", Bucket: "huggingface.co", Path: "ibm-granite/granite-3.0-8b"},
+		"https://ec2.us-east-1.amazonaws.com/models":        {Scheme: "https", Bucket: "ec2.us-east-1.amazonaws.com", Path: "models"},
+		"pvc://models-pvc/granite":                          {Scheme: "pvc", Bucket: "models-pvc", Path: "granite"},
+		"/mnt/models/granite":                               {},
+	}
+	for uri, expected := range tests {
+		parsed, err := Parse(uri)
+		require.NoError(t, err, uri)
+		assert.Equal(t, expected, parsed, uri)
+	}
+
+	for _, uri := range []string{"s3:///granite", "gs://", "oci://quay.io", "oci:///org/granite", "https://host:port/model"} {
+		_, err := Parse(uri)
+		assert.ErrorIs(t, err, ErrInvalid, uri)
+	}
+}
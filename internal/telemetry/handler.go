@@ -0,0 +1,51 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// BasePath is the path of the telemetry endpoints.
+	BasePath = "/admin/telemetry"
+	// EnabledHeader tells whether the previewed report is sent, true or false.
+	EnabledHeader = "X-Model-Registry-Telemetry-Enabled"
+)
+
+// NewHandler returns the handler of the telemetry endpoints:
+//
+//	GET /admin/telemetry/preview    returns the report sent next, as sent, even when telemetry is disabled
+func NewHandler(reporter *Reporter) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath+"/preview", func(w http.ResponseWriter, r *http.Request) {
+		report, err := reporter.Report(r.Context())
+		if err != nil {
+			glog.Errorf("Error building the telemetry report: %v", err)
+			writeJSON(w, http.StatusInternalServerError, map[string]string{
+				"code":    http.StatusText(http.StatusInternalServerError),
+				"message": "error building the telemetry report",
+			})
+			return
+		}
+
+		if reporter.Enabled() {
+			w.Header().Set(EnabledHeader, "true")
+		} else {
+			w.Header().Set(EnabledHeader, "false")
+		}
+		writeJSON(w, http.StatusOK, report)
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing telemetry response: %v", err)
+	}
+}
@@ -0,0 +1,216 @@
+// Package telemetry reports anonymized, aggregate usage metrics of the registry to its maintainers, when operators
+// opt in. Reports hold entity counts by type, the features in use and the build, never names, ids, uris or hosts.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/jobs"
+	"gorm.io/gorm"
+)
+
+const (
+	// JobName is the name of the background job sending the telemetry reports.
+	JobName = "telemetry-report"
+	// DefaultInterval is how often telemetry reports are sent.
+	DefaultInterval = 24 * time.Hour
+
+	// reportVersion is the version of the report schema
+	reportVersion = 1
+	// registryTypePrefix is the prefix of the types of the registry, other types are not counted as their names are
+	// chosen by the users
+	registryTypePrefix = "kf."
+
+	sendTimeout = 30 * time.Second
+)
+
+// Config opts in to telemetry.
+type Config struct {
+	// Enabled sends the reports, telemetry is off by default.
+	Enabled bool
+	// Endpoint is the url the reports are posted to.
+	Endpoint string
+	// Interval is how often reports are sent, defaults to DefaultInterval.
+	Interval time.Duration
+}
+
+// Validate checks that reports have an endpoint when telemetry is enabled.
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid telemetry endpoint %q: must be an http or https url", c.Endpoint)
+	}
+	return nil
+}
+
+// Report is the content of a telemetry report.
+type Report struct {
+	ReportVersion int    `json:"reportVersion"`
+	Version       string `json:"version"`
+	GoVersion     string `json:"goVersion"`
+	Platform      string `json:"platform"`
+	Database      string `json:"database"`
+	// Entities counts the entities of each registry type
+	Entities map[string]int64 `json:"entities"`
+	// Features are the features enabled on the server, sorted
+	Features []string `json:"features"`
+}
+
+// Reporter builds the telemetry reports and sends them if telemetry is enabled.
+type Reporter struct {
+	db        *gorm.DB
+	config    Config
+	typeNames map[int32]string
+	features  func(ctx context.Context) []string
+	client    *http.Client
+}
+
+// NewReporter returns a reporter of the registry in db, typesMap maps type names to ids and features returns the
+// names of the features in use.
+func NewReporter(db *gorm.DB, config Config, typesMap map[string]int32, features func(ctx context.Context) []string) (*Reporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	typeNames := map[int32]string{}
+	for name, id := range typesMap {
+		if strings.HasPrefix(name, registryTypePrefix) {
+			typeNames[id] = name
+		}
+	}
+
+	return &Reporter{
+		db:        db,
+		config:    config,
+		typeNames: typeNames,
+		features:  features,
+		client:    &http.Client{Timeout: sendTimeout},
+	}, nil
+}
+
+// Enabled reports whether the reports are sent.
+func (r *Reporter) Enabled() bool {
+	return r.config.Enabled
+}
+
+// Report returns the report sent next.
+func (r *Reporter) Report(ctx context.Context) (*Report, error) {
+	entities := map[string]int64{}
+	for _, name := range r.typeNames {
+		entities[name] = 0
+	}
+
+	for _, model := range []any{&schema.Context{}, &schema.Artifact{}, &schema.Execution{}} {
+		var counts []struct {
+			TypeID int32
+			Count  int64
+		}
+		if err := r.db.WithContext(ctx).Model(model).
+			Select("type_id, COUNT(*) AS count").
+			Group("type_id").
+			Scan(&counts).Error; err != nil {
+			return nil, fmt.Errorf("error counting entities: %w", err)
+		}
+
+		for _, count := range counts {
+			if name, ok := r.typeNames[count.TypeID]; ok {
+				entities[name] += count.Count
+			}
+		}
+	}
+
+	features := []string{}
+	if r.features != nil {
+		features = append(features, r.features(ctx)...)
+	}
+	slices.Sort(features)
+
+	return &Report{
+		ReportVersion: reportVersion,
+		Version:       version(),
+		GoVersion:     runtime.Version(),
+		Platform:      runtime.GOOS + "/" + runtime.GOARCH,
+		Database:      r.db.Name(),
+		Entities:      entities,
+		Features:      features,
+	}, nil
+}
+
+// version is the version of the server module, from the build information.
+func version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "" {
+		return "unknown"
+	}
+	return info.Main.Version
+}
+
+// Send posts a report to the endpoint.
+func (r *Reporter) Send(ctx context.Context) error {
+	if !r.config.Enabled {
+		return fmt.Errorf("telemetry is disabled")
+	}
+
+	report, err := r.Report(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("error encoding telemetry report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.config.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending telemetry report: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("error sending telemetry report: endpoint returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Job returns the background job sending a report every interval.
+func (r *Reporter) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Sends the anonymized usage telemetry report",
+		Interval:    r.config.Interval,
+		Run: func(ctx context.Context) error {
+			if err := r.Send(ctx); err != nil {
+				return err
+			}
+			glog.V(2).Infof("Sent the telemetry report to %s", r.config.Endpoint)
+			return nil
+		},
+	}
+}
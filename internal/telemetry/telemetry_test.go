@@ -0,0 +1,100 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newMockReporter(t *testing.T, config Config) (*Reporter, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	typesMap := map[string]int32{
+		defaults.RegisteredModelTypeName: 1,
+		defaults.ModelVersionTypeName:    2,
+		defaults.ModelArtifactTypeName:   3,
+		"acme.CustomType":                9,
+	}
+	reporter, err := NewReporter(db, config, typesMap, func(ctx context.Context) []string {
+		return []string{"reporting-views", "archive"}
+	})
+	require.NoError(t, err)
+	return reporter, mock
+}
+
+func expectCounts(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT type_id, COUNT(*) AS count FROM "Context" GROUP BY "type_id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "count"}).AddRow(1, 2).AddRow(2, 5).AddRow(9, 7))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT type_id, COUNT(*) AS count FROM "Artifact" GROUP BY "type_id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "count"}).AddRow(3, 4))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT type_id, COUNT(*) AS count FROM "Execution" GROUP BY "type_id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"type_id", "count"}))
+}
+
+func TestConfig(t *testing.T) {
+	assert.NoError(t, (&Config{}).Validate(), "telemetry is off by default")
+	assert.Error(t, (&Config{Enabled: true}).Validate())
+	assert.Error(t, (&Config{Enabled: true, Endpoint: "telemetry.example.com"}).Validate())
+	assert.NoError(t, (&Config{Enabled: true, Endpoint: "https://telemetry.example.com/v1/reports"}).Validate())
+}
+
+func TestPreview(t *testing.T) {
+	reporter, mock := newMockReporter(t, Config{})
+	assert.Equal(t, DefaultInterval, reporter.Job().Interval)
+	assert.ErrorContains(t, reporter.Send(context.Background()), "disabled")
+
+	expectCounts(mock)
+	w := httptest.NewRecorder()
+	NewHandler(reporter).ServeHTTP(w, httptest.NewRequest(http.MethodGet, BasePath+"/preview", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "false", w.Header().Get(EnabledHeader))
+
+	var report Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	assert.Equal(t, map[string]int64{
+		defaults.RegisteredModelTypeName: 2,
+		defaults.ModelVersionTypeName:    5,
+		defaults.ModelArtifactTypeName:   4,
+	}, report.Entities, "types not defined by the registry are not reported")
+	assert.Equal(t, []string{"archive", "reporting-views"}, report.Features)
+	assert.Equal(t, "postgres", report.Database)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSend(t *testing.T) {
+	var received map[string]any
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(endpoint.Close)
+
+	reporter, mock := newMockReporter(t, Config{Enabled: true, Endpoint: endpoint.URL})
+
+	expectCounts(mock)
+	require.NoError(t, reporter.Job().Run(context.Background()))
+	assert.EqualValues(t, 1, received["reportVersion"])
+	assert.Equal(t, map[string]any{
+		defaults.RegisteredModelTypeName: 2.0,
+		defaults.ModelVersionTypeName:    5.0,
+		defaults.ModelArtifactTypeName:   4.0,
+	}, received["entities"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
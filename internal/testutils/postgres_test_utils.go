@@ -127,7 +127,11 @@ func CleanupPostgresTestData(t *testing.T, db *gorm.DB) {
 	// essential system data that should not be cleaned up between tests
 	tables := []string{
 		"ArtifactProperty",
+		"ArtifactDigest",
 		"ContextProperty",
+		"ContextTag",
+		"Tag",
+		"audit_events",
 		"ExecutionProperty",
 		"ParentContext",
 		"Attribution",
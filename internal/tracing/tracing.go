@@ -0,0 +1,120 @@
+// Package tracing exports OpenTelemetry traces of the REST api requests, with the spans of the repository operations
+// they run and of the SQL statements of these operations as their children.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/metrics"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	gormtracing "gorm.io/plugin/opentelemetry/tracing"
+)
+
+// instrumentationName is the name of the tracer of the registry spans.
+const instrumentationName = "github.com/kubeflow/model-registry"
+
+// Config configures the export of the traces.
+type Config struct {
+	// Endpoint is the OTLP/HTTP traces endpoint, e.g. http://collector:4318/v1/traces, tracing is off when empty.
+	Endpoint string
+	// Headers are added to the export requests, e.g. for authentication, as <name>=<value>.
+	Headers []string
+	// SampleRatio is the ratio of the traces started by the registry that are sampled, the requests of the traces
+	// sampled by the clients are always sampled.
+	SampleRatio float64
+	// Tenant is recorded in the resource of the spans, the namespace the registry serves.
+	Tenant string
+}
+
+// Enabled reports whether traces are exported.
+func (c *Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Setup exports the spans of the registry to the endpoint of config, the returned function flushes the spans not
+// exported yet and stops the export.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if u, err := url.Parse(config.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP traces endpoint %q: must be an http or https url", config.Endpoint)
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid trace sample ratio %v: must be between 0 and 1", config.SampleRatio)
+	}
+
+	headers := map[string]string{}
+	for _, header := range config.Headers {
+		name, value, ok := strings.Cut(header, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q: must be <name>=<value>", header)
+		}
+		headers[strings.TrimSpace(name)] = value
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(config.Endpoint), otlptracehttp.WithHeaders(headers))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP traces exporter: %w", err)
+	}
+
+	attributes := []attribute.KeyValue{attribute.String("service.name", "model-registry")}
+	if config.Tenant != "" {
+		attributes = append(attributes, attribute.String("k8s.namespace.name", config.Tenant))
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attributes...)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// Middleware starts the span of the requests served by next, named after their method and route, continuing the
+// traces propagated by the clients.
+func Middleware(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "model-registry", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+		return r.Method + " " + metrics.Route(r.URL.Path)
+	}))
+}
+
+// RegisterGorm records the SQL statements run by db as spans, children of the span of the context of their query.
+// The values of the statements are not recorded.
+func RegisterGorm(db *gorm.DB) error {
+	if err := db.Use(gormtracing.NewPlugin(gormtracing.WithoutMetrics(), gormtracing.WithoutQueryVariables())); err != nil {
+		return fmt.Errorf("error registering the SQL tracing: %w", err)
+	}
+	return nil
+}
+
+// Start starts a span of the registry, a child of the span of ctx if any. The spans are not recorded when tracing
+// is off.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends a span started with Start, recording err if the operation failed.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,60 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "model version get_by_id")
+		End(span, nil)
+		_, span = Start(r.Context(), "model version list")
+		End(span, errors.New("boom"))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/model_registry/v1alpha3/model_versions/3", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	request := spans[2]
+	assert.Equal(t, "GET /api/model_registry/v1alpha3/model_versions/{id}", request.Name())
+	for _, span := range spans[:2] {
+		assert.Equal(t, request.SpanContext().SpanID(), span.Parent().SpanID(), "the spans of the operations are children of the request span")
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "boom", spans[1].Status().Description)
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	_, err := Setup(context.Background(), Config{Endpoint: "collector:4318"})
+	assert.ErrorContains(t, err, "invalid OTLP traces endpoint")
+
+	_, err = Setup(context.Background(), Config{Endpoint: "http://collector:4318/v1/traces", SampleRatio: 2})
+	assert.ErrorContains(t, err, "invalid trace sample ratio")
+
+	_, err = Setup(context.Background(), Config{Endpoint: "http://collector:4318/v1/traces", SampleRatio: 1, Headers: []string{"token"}})
+	assert.ErrorContains(t, err, "invalid OTLP header")
+
+	shutdown, err := Setup(context.Background(), Config{Endpoint: "http://collector:4318/v1/traces", SampleRatio: 1, Headers: []string{"Authorization=Bearer token"}})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()), "nothing is exported without spans")
+}
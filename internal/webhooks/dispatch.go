@@ -0,0 +1,175 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/jobs"
+)
+
+const (
+	// EventHeader is the event of a notification.
+	EventHeader = "X-Model-Registry-Event"
+	// DeliveryHeader is the id of the event of a notification, the same for all its delivery attempts.
+	DeliveryHeader = "X-Model-Registry-Delivery"
+	// SignatureHeader is the HMAC-SHA256 of the body of a notification keyed by the secret of the subscription, as
+	// sha256=<hex digest>.
+	SignatureHeader = "X-Model-Registry-Signature"
+)
+
+// Notification is the body posted to the subscriptions, they get the entity from the API if needed. It carries no
+// field of the entity, so that none is sent to the receivers without the scopes or the access to read it.
+type Notification struct {
+	// Id of the event, receivers dedupe the notifications by id as they are delivered at least once.
+	Id         string `json:"id"`
+	Event      Event  `json:"event"`
+	EntityType string `json:"entityType"`
+	EntityId   string `json:"entityId"`
+	// TimeSinceEpoch is when the entity was written, in milliseconds since epoch.
+	TimeSinceEpoch string `json:"timeSinceEpoch"`
+}
+
+// Job returns the background job delivering the events of the outbox every interval.
+func (n *Notifier) Job() jobs.Job {
+	return jobs.Job{
+		Name:        JobName,
+		Description: "Delivers the entity events recorded in the outbox to the webhook subscriptions",
+		Interval:    n.config.Interval,
+		Run:         n.Dispatch,
+	}
+}
+
+// Dispatch delivers the events of the outbox due for delivery, by id. Delivered events are removed from the outbox,
+// the others are retried with an exponential backoff until they are dropped after the maximum number of attempts.
+func (n *Notifier) Dispatch(ctx context.Context) error {
+	db := n.db.WithContext(ctx)
+	outbox := dbutil.QuoteTableName(db, outboxTable)
+
+	subscriptions, err := n.subscriptions(db)
+	if err != nil {
+		return err
+	}
+
+	var records []outboxRecord
+	if err := db.Table(outbox).Where("next_attempt_time_since_epoch <= ?", time.Now().UnixMilli()).
+		Order("id").Limit(batchSize).Find(&records).Error; err != nil {
+		return fmt.Errorf("error reading webhooks outbox: %w", err)
+	}
+
+	delivered := 0
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		deliveryErr := n.deliver(ctx, record, subscriptions)
+		if deliveryErr == nil || record.Attempts+1 >= n.config.MaxAttempts {
+			if deliveryErr != nil {
+				glog.Warningf("Dropping webhook event %d after %d attempts: %v", record.ID, record.Attempts+1, deliveryErr)
+			} else {
+				delivered++
+			}
+			if err := db.Table(outbox).Where("id = ?", record.ID).Delete(&outboxRecord{}).Error; err != nil {
+				return fmt.Errorf("error removing webhook event %d from the outbox: %w", record.ID, err)
+			}
+			continue
+		}
+
+		attempts := record.Attempts + 1
+		next := time.Now().Add(n.backoff(attempts)).UnixMilli()
+		if err := db.Table(outbox).Where("id = ?", record.ID).Updates(map[string]any{
+			"attempts":                      attempts,
+			"next_attempt_time_since_epoch": next,
+			"last_error":                    deliveryErr.Error(),
+		}).Error; err != nil {
+			return fmt.Errorf("error rescheduling webhook event %d: %w", record.ID, err)
+		}
+		glog.V(2).Infof("Retrying webhook event %d, attempt %d failed: %v", record.ID, attempts, deliveryErr)
+	}
+
+	if delivered > 0 {
+		glog.V(2).Infof("Delivered %d webhook events", delivered)
+	}
+	return nil
+}
+
+// backoff returns the delay before the next delivery attempt of an event which failed attempts times.
+func (n *Notifier) backoff(attempts int) time.Duration {
+	delay := n.config.Interval
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// deliver posts the event to the matching subscriptions, the ones of the namespace of its entity or of all the
+// namespaces. The errors of the failed deliveries are joined.
+func (n *Notifier) deliver(ctx context.Context, record outboxRecord, subscriptions []Subscription) error {
+	body, err := json.Marshal(Notification{
+		Id:             strconv.FormatInt(record.ID, 10),
+		Event:          Event(record.Event),
+		EntityType:     record.EntityType,
+		EntityId:       strconv.FormatInt(int64(record.EntityID), 10),
+		TimeSinceEpoch: strconv.FormatInt(record.CreateTimeSinceEpoch, 10),
+	})
+	if err != nil {
+		return fmt.Errorf("unable to encode webhook notification: %w", err)
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		if !subscription.matches(Event(record.Event), record.EntityType, record.Namespace) {
+			continue
+		}
+		if err := n.post(ctx, subscription, record, body); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", subscription.Id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) post(ctx context.Context, subscription Subscription, record outboxRecord, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, record.Event)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(record.ID, 10))
+	if subscription.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(subscription.Secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook responded %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+// Sign returns the SignatureHeader of a notification body, for the receivers to verify it.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
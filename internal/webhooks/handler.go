@@ -0,0 +1,90 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// BasePath is the path of the webhook endpoints.
+	BasePath = "/admin/webhooks"
+
+	// maxBodySize bounds the size of the subscriptions created
+	maxBodySize = 64 << 10
+)
+
+// NewHandler returns the handler of the webhook endpoints:
+//
+//	GET    /admin/webhooks         lists the subscriptions
+//	POST   /admin/webhooks         registers a subscription
+//	GET    /admin/webhooks/{id}    returns a subscription
+//	DELETE /admin/webhooks/{id}    deletes a subscription
+func NewHandler(notifier *Notifier) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+BasePath, func(w http.ResponseWriter, r *http.Request) {
+		subscriptions, err := notifier.ListSubscriptions(r.Context())
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, SubscriptionList{Items: subscriptions, Size: len(subscriptions)})
+	})
+	mux.HandleFunc("POST "+BasePath, func(w http.ResponseWriter, r *http.Request) {
+		var subscription Subscription
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&subscription); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid webhook subscription: "+err.Error())
+			return
+		}
+		created, err := notifier.CreateSubscription(r.Context(), subscription)
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+	})
+	mux.HandleFunc("GET "+BasePath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		subscription, err := notifier.GetSubscription(r.Context(), r.PathValue("id"))
+		if err != nil {
+			writeFailure(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, subscription)
+	})
+	mux.HandleFunc("DELETE "+BasePath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := notifier.DeleteSubscription(r.Context(), r.PathValue("id")); err != nil {
+			writeFailure(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	return mux
+}
+
+func writeFailure(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrSubscriptionNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, ErrInvalidSubscription):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		glog.Errorf("Error handling webhook subscription request: %v", err)
+		writeError(w, http.StatusInternalServerError, "error handling webhook subscription request")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"code": http.StatusText(code), "message": message})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		glog.Errorf("Error writing webhook response: %v", err)
+	}
+}
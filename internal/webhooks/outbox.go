@@ -0,0 +1,153 @@
+package webhooks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"gorm.io/gorm"
+)
+
+// deletedEntitySetting passes the type id and namespace of a deleted entity, read before the delete, to the callback
+// recording its event
+const deletedEntitySetting = "webhooks:deleted_entity"
+
+// outboxRecord is a row of the outbox table, an event pending delivery.
+type outboxRecord struct {
+	ID                        int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	Event                     string `gorm:"column:event"`
+	EntityType                string `gorm:"column:entity_type"`
+	EntityID                  int32  `gorm:"column:entity_id"`
+	Namespace                 string `gorm:"column:namespace"`
+	CreateTimeSinceEpoch      int64  `gorm:"column:create_time_since_epoch"`
+	Attempts                  int    `gorm:"column:attempts"`
+	NextAttemptTimeSinceEpoch int64  `gorm:"column:next_attempt_time_since_epoch"`
+	LastError                 string `gorm:"column:last_error"`
+}
+
+// Register installs the callbacks recording the events of the entities written with db in the outbox, in the
+// transaction of the write. Only the writes of a single context, artifact or execution, by primary key, are recorded:
+// the repositories don't write the entities in bulk. Registering them again is a no-op.
+func (n *Notifier) Register() error {
+	callbacks := n.db.Callback()
+	if callbacks.Create().Get("webhooks:after_create") != nil {
+		return nil
+	}
+	for name, err := range map[string]error{
+		"create": callbacks.Create().After("gorm:create").Before("gorm:commit_or_rollback_transaction").
+			Register("webhooks:after_create", n.recordEvent(EventCreate)),
+		"update": callbacks.Update().After("gorm:update").Before("gorm:commit_or_rollback_transaction").
+			Register("webhooks:after_update", n.recordEvent(EventUpdate)),
+		"before delete": callbacks.Delete().After("gorm:begin_transaction").Before("gorm:delete").
+			Register("webhooks:before_delete", n.readDeletedEntity),
+		"delete": callbacks.Delete().After("gorm:delete").Before("gorm:commit_or_rollback_transaction").
+			Register("webhooks:after_delete", n.recordEvent(EventDelete)),
+	} {
+		if err != nil {
+			return fmt.Errorf("error registering webhooks %s callback: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writtenEntity is the entity written by a statement.
+type writtenEntity struct {
+	table     string
+	id        int32
+	typeID    int32
+	namespace string
+}
+
+// hasNamespace reports whether the entity has a namespace, the executions have none.
+func (e *writtenEntity) hasNamespace() bool {
+	return e.table != schema.TableNameExecution
+}
+
+// writtenEntityOf returns the entity written by the statement, false if it doesn't write a single entity.
+func writtenEntityOf(db *gorm.DB) (writtenEntity, bool) {
+	switch entity := db.Statement.Model.(type) {
+	case *schema.Context:
+		return writtenEntity{schema.TableNameContext, entity.ID, entity.TypeID, entity.Namespace}, entity.ID != 0
+	case *schema.Artifact:
+		return writtenEntity{schema.TableNameArtifact, entity.ID, entity.TypeID, entity.Namespace}, entity.ID != 0
+	case *schema.Execution:
+		return writtenEntity{schema.TableNameExecution, entity.ID, entity.TypeID, ""}, entity.ID != 0
+	}
+	return writtenEntity{}, false
+}
+
+// readEntity reads the type id and namespace of the entity the statement doesn't set, with the connection of the
+// statement.
+func readEntity(db *gorm.DB, entity *writtenEntity) error {
+	if entity.typeID != 0 && (entity.namespace != "" || !entity.hasNamespace()) {
+		return nil
+	}
+
+	columns := []string{"type_id"}
+	if entity.hasNamespace() {
+		columns = append(columns, "namespace")
+	}
+	var row struct {
+		TypeID    int32
+		Namespace string
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Table(dbutil.QuoteTableName(db, entity.table)).
+		Select(columns).Where("id = ?", entity.id).Scan(&row).Error; err != nil {
+		return err
+	}
+	entity.typeID, entity.namespace = row.TypeID, row.Namespace
+	return nil
+}
+
+// readDeletedEntity reads the type id and namespace of the deleted entity, as deletes only set its id.
+func (n *Notifier) readDeletedEntity(db *gorm.DB) {
+	entity, ok := writtenEntityOf(db)
+	if !ok || db.Error != nil {
+		return
+	}
+
+	if err := readEntity(db, &entity); err != nil {
+		_ = db.AddError(fmt.Errorf("error reading the deleted entity: %w", err))
+		return
+	}
+	db.InstanceSet(deletedEntitySetting, entity)
+}
+
+// recordEvent returns the callback inserting the event of the written entity in the outbox, with the connection of
+// the statement so that it is committed or rolled back with the write.
+func (n *Notifier) recordEvent(event Event) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		entity, ok := writtenEntityOf(db)
+		if !ok || db.Error != nil || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		if event == EventDelete {
+			value, _ := db.InstanceGet(deletedEntitySetting)
+			entity, _ = value.(writtenEntity)
+		} else if event == EventUpdate {
+			// the updates set the changed fields only, the creates all of them
+			if err := readEntity(db, &entity); err != nil {
+				_ = db.AddError(fmt.Errorf("error reading the written entity: %w", err))
+				return
+			}
+		}
+		entityType, ok := n.entityTypes[entity.typeID]
+		if !ok {
+			return
+		}
+
+		record := outboxRecord{
+			Event:                string(event),
+			EntityType:           entityType,
+			EntityID:             entity.id,
+			Namespace:            entity.namespace,
+			CreateTimeSinceEpoch: time.Now().UnixMilli(),
+		}
+		tx := db.Session(&gorm.Session{NewDB: true})
+		if err := tx.Table(dbutil.QuoteTableName(tx, outboxTable)).Create(&record).Error; err != nil {
+			_ = db.AddError(fmt.Errorf("error recording webhook event: %w", err))
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// Package webhooks notifies the urls registered by the admins of the creations, updates and deletions of the
+// registry entities, so that e.g. CI/CD pipelines react to new model versions without polling.
+//
+// The subscriptions of a tenant are notified of the events of the entities of its namespace only. The notifications
+// carry the ids of the entities but none of their fields: the receivers get them from the api, with the scopes,
+// namespace and field redaction of their own credentials.
+//
+// The writes of the entities record their events in an outbox table, in the transaction of the write, so that the
+// events of rolled back writes are never sent. A background job on the leader replica then posts the events of the
+// outbox to the urls of the matching subscriptions, retrying the failed deliveries with a backoff: events are
+// delivered at least once, in order unless retried, and receivers dedupe them by id.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/types"
+	"github.com/kubeflow/model-registry/internal/reachability"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// JobName is the name of the background job delivering the events.
+	JobName = "webhooks-dispatch"
+	// DefaultInterval is how often the outbox is polled for events to deliver.
+	DefaultInterval = 10 * time.Second
+	// DefaultMaxAttempts is how many times the delivery of an event is attempted before it is dropped.
+	DefaultMaxAttempts = 10
+	// DefaultTimeout bounds the time a subscription takes to respond to a notification.
+	DefaultTimeout = 10 * time.Second
+
+	// maxBackoff bounds the delay between the delivery attempts of an event
+	maxBackoff = time.Hour
+	// batchSize is the maximum number of events delivered per run
+	batchSize = 100
+	// typeNamePrefix prefixes the names of the registry types, stripped from the entity types of the events
+	typeNamePrefix = "kf."
+
+	subscriptionsTable = "mr_webhook_subscriptions"
+	outboxTable        = "mr_webhook_outbox"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrInvalidSubscription  = errors.New("invalid webhook subscription")
+)
+
+// Event is the kind of write of an entity.
+type Event string
+
+const (
+	EventCreate Event = "CREATE"
+	EventUpdate Event = "UPDATE"
+	EventDelete Event = "DELETE"
+)
+
+var events = []Event{EventCreate, EventUpdate, EventDelete}
+
+// Config enables the webhooks.
+type Config struct {
+	Enabled bool
+	// Interval is how often the outbox is polled, defaults to DefaultInterval.
+	Interval time.Duration
+	// MaxAttempts is how many times an event is delivered before it is dropped, defaults to DefaultMaxAttempts.
+	MaxAttempts int
+	// Timeout bounds the response time of the subscriptions, defaults to DefaultTimeout.
+	Timeout time.Duration
+	// AllowPrivateNetworks delivers the notifications to the loopback, private and link-local addresses, e.g. the
+	// services of the cluster, which are refused otherwise.
+	AllowPrivateNetworks bool
+}
+
+// Subscription registers a url notified of the events of the entities of some types.
+type Subscription struct {
+	// Id of the subscription. Output only.
+	Id string `json:"id,omitempty"`
+	// URL is the http or https url the notifications are posted to.
+	URL string `json:"url"`
+	// EntityTypes are the types of the entities notified, e.g. ModelVersion, all types when empty.
+	EntityTypes []string `json:"entityTypes,omitempty"`
+	// Events are the events notified, all events when empty.
+	Events []Event `json:"events,omitempty"`
+	// Secret, if set, signs the notifications in the SignatureHeader. Write only.
+	Secret string `json:"secret,omitempty"`
+	// Namespace restricts the notifications to the entities of a namespace, all the entities when empty. The
+	// subscriptions created by a tenant are in its namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+}
+
+// matches reports whether the subscription is notified of the event of an entity of entityType in namespace.
+func (s *Subscription) matches(event Event, entityType string, namespace string) bool {
+	return (len(s.EntityTypes) == 0 || slices.Contains(s.EntityTypes, entityType)) &&
+		(len(s.Events) == 0 || slices.Contains(s.Events, event)) &&
+		(s.Namespace == "" || s.Namespace == namespace)
+}
+
+// SubscriptionList is the list of the subscriptions returned by GET /admin/webhooks.
+type SubscriptionList struct {
+	Items []Subscription `json:"items"`
+	Size  int            `json:"size"`
+}
+
+// subscriptionRecord is a row of the subscriptions table, the entity types and events are comma separated.
+type subscriptionRecord struct {
+	ID                   int64  `gorm:"column:id;primaryKey;autoIncrement"`
+	URL                  string `gorm:"column:url"`
+	EntityTypes          string `gorm:"column:entity_types"`
+	Events               string `gorm:"column:events"`
+	Secret               string `gorm:"column:secret"`
+	Namespace            string `gorm:"column:namespace"`
+	CreateTimeSinceEpoch int64  `gorm:"column:create_time_since_epoch"`
+}
+
+func (r *subscriptionRecord) toSubscription() Subscription {
+	subscription := Subscription{
+		Id:                   strconv.FormatInt(r.ID, 10),
+		URL:                  r.URL,
+		Secret:               r.Secret,
+		Namespace:            r.Namespace,
+		CreateTimeSinceEpoch: strconv.FormatInt(r.CreateTimeSinceEpoch, 10),
+	}
+	if r.EntityTypes != "" {
+		subscription.EntityTypes = strings.Split(r.EntityTypes, ",")
+	}
+	if r.Events != "" {
+		for _, event := range strings.Split(r.Events, ",") {
+			subscription.Events = append(subscription.Events, Event(event))
+		}
+	}
+	return subscription
+}
+
+// Notifier records the events of the entities in the outbox, delivers them and manages the subscriptions.
+type Notifier struct {
+	db     *gorm.DB
+	config Config
+	// entityTypes are the entity types of the events by type id
+	entityTypes map[int32]string
+	client      *http.Client
+}
+
+// NewNotifier returns the notifier of the registry in db, typesMap maps type names to ids. The subscriptions and outbox
+// tables are created by the migrations of the database.
+func NewNotifier(db *gorm.DB, config Config, typesMap map[string]int32) (*Notifier, error) {
+	switch db.Name() {
+	case types.DatabaseTypeMySQL, types.DatabaseTypePostgres:
+	default:
+		return nil, fmt.Errorf("webhooks are not supported on %s databases", db.Name())
+	}
+
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+
+	entityTypes := make(map[int32]string, len(typesMap))
+	for typeName, typeID := range typesMap {
+		if entityType, ok := strings.CutPrefix(typeName, typeNamePrefix); ok {
+			entityTypes[typeID] = entityType
+		}
+	}
+
+	return &Notifier{
+		db:          db,
+		config:      config,
+		entityTypes: entityTypes,
+		client:      reachability.NewHTTPClient(config.Timeout, config.AllowPrivateNetworks),
+	}, nil
+}
+
+// validate checks the subscription, its entity types must be entity types of the registry.
+func (n *Notifier) validate(subscription *Subscription) error {
+	if u, err := url.Parse(subscription.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url %q must be an http or https url", ErrInvalidSubscription, subscription.URL)
+	}
+	if len(subscription.URL) > 2048 {
+		return fmt.Errorf("%w: url can't be longer than 2048 bytes", ErrInvalidSubscription)
+	}
+	if len(subscription.Secret) > 255 {
+		return fmt.Errorf("%w: secret can't be longer than 255 bytes", ErrInvalidSubscription)
+	}
+
+	for _, entityType := range subscription.EntityTypes {
+		if !slices.Contains(slices.Collect(maps.Values(n.entityTypes)), entityType) {
+			return fmt.Errorf("%w: unknown entity type %q", ErrInvalidSubscription, entityType)
+		}
+	}
+	if len(strings.Join(subscription.EntityTypes, ",")) > 1024 {
+		return fmt.Errorf("%w: too many entity types", ErrInvalidSubscription)
+	}
+
+	for _, event := range subscription.Events {
+		if !slices.Contains(events, event) {
+			return fmt.Errorf("%w: invalid event %q, must be one of %s, %s or %s", ErrInvalidSubscription, event, EventCreate, EventUpdate, EventDelete)
+		}
+	}
+
+	if subscription.Namespace != "" {
+		if errs := validation.IsDNS1123Label(subscription.Namespace); len(errs) > 0 {
+			return fmt.Errorf("%w: invalid namespace %q: %s", ErrInvalidSubscription, subscription.Namespace, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// CreateSubscription registers a subscription, which is notified of the events recorded from then on. The
+// subscriptions of a tenant are created in its namespace.
+func (n *Notifier) CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error) {
+	if namespace := api.Namespace(ctx); namespace != "" {
+		if subscription.Namespace != "" && subscription.Namespace != namespace {
+			return Subscription{}, fmt.Errorf("%w: namespace %s is not the namespace %s of the tenant", ErrInvalidSubscription, subscription.Namespace, namespace)
+		}
+		subscription.Namespace = namespace
+	}
+	if err := n.validate(&subscription); err != nil {
+		return Subscription{}, err
+	}
+
+	record := subscriptionRecord{
+		URL:                  subscription.URL,
+		EntityTypes:          strings.Join(subscription.EntityTypes, ","),
+		Secret:               subscription.Secret,
+		Namespace:            subscription.Namespace,
+		CreateTimeSinceEpoch: time.Now().UnixMilli(),
+	}
+	eventNames := make([]string, 0, len(subscription.Events))
+	for _, event := range subscription.Events {
+		eventNames = append(eventNames, string(event))
+	}
+	record.Events = strings.Join(eventNames, ",")
+
+	if err := n.subscriptionsTable(n.db.WithContext(ctx)).Create(&record).Error; err != nil {
+		return Subscription{}, fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+
+	return withoutSecret(record.toSubscription()), nil
+}
+
+// ListSubscriptions returns the subscriptions by id, without their secrets, the ones of its namespace for a tenant.
+func (n *Notifier) ListSubscriptions(ctx context.Context) ([]Subscription, error) {
+	subscriptions, err := n.subscriptions(n.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i] = withoutSecret(subscriptions[i])
+	}
+	return subscriptions, nil
+}
+
+// GetSubscription returns a subscription, without its secret. The subscriptions of other namespaces are not found for
+// a tenant.
+func (n *Notifier) GetSubscription(ctx context.Context, id string) (Subscription, error) {
+	subscriptionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("%w: %s", ErrSubscriptionNotFound, id)
+	}
+
+	var record subscriptionRecord
+	if err := n.subscriptionsTable(n.db.WithContext(ctx)).Where("id = ?", subscriptionID).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return Subscription{}, fmt.Errorf("%w: %s", ErrSubscriptionNotFound, id)
+		}
+		return Subscription{}, fmt.Errorf("error getting webhook subscription: %w", err)
+	}
+
+	return withoutSecret(record.toSubscription()), nil
+}
+
+// DeleteSubscription deletes a subscription, its pending notifications are not sent. The subscriptions of other
+// namespaces are not found for a tenant.
+func (n *Notifier) DeleteSubscription(ctx context.Context, id string) error {
+	subscriptionID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrSubscriptionNotFound, id)
+	}
+
+	result := n.subscriptionsTable(n.db.WithContext(ctx)).Where("id = ?", subscriptionID).Delete(&subscriptionRecord{})
+	if result.Error != nil {
+		return fmt.Errorf("error deleting webhook subscription: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", ErrSubscriptionNotFound, id)
+	}
+
+	return nil
+}
+
+// subscriptionsTable returns the query of the subscriptions table, restricted to the namespace of the tenant of the
+// context of db.
+func (n *Notifier) subscriptionsTable(db *gorm.DB) *gorm.DB {
+	query := db.Table(dbutil.QuoteTableName(db, subscriptionsTable))
+	if namespace := api.Namespace(db.Statement.Context); namespace != "" {
+		query = query.Where("namespace = ?", namespace)
+	}
+	return query
+}
+
+// subscriptions returns the subscriptions by id, with their secrets.
+func (n *Notifier) subscriptions(db *gorm.DB) ([]Subscription, error) {
+	var records []subscriptionRecord
+	if err := n.subscriptionsTable(db).Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("error listing webhook subscriptions: %w", err)
+	}
+
+	subscriptions := make([]Subscription, 0, len(records))
+	for _, record := range records {
+		subscriptions = append(subscriptions, record.toSubscription())
+	}
+	return subscriptions, nil
+}
+
+func withoutSecret(subscription Subscription) Subscription {
+	subscription.Secret = ""
+	return subscription
+}
@@ -0,0 +1,352 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kubeflow/model-registry/internal/db/schema"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var typesMap = map[string]int32{
+	defaults.RegisteredModelTypeName: 1,
+	defaults.ModelVersionTypeName:    2,
+	defaults.ExperimentTypeName:      3,
+}
+
+func newMockNotifier(t *testing.T) (*Notifier, sqlmock.Sqlmock) {
+	return newMockNotifierWithConfig(t, Config{Enabled: true, AllowPrivateNetworks: true})
+}
+
+func newMockNotifierWithConfig(t *testing.T, config Config) (*Notifier, sqlmock.Sqlmock) {
+	conn, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: conn, SkipInitializeWithVersion: true}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	notifier, err := NewNotifier(db, config, typesMap)
+	require.NoError(t, err)
+	return notifier, mock
+}
+
+func TestRecordEvents(t *testing.T) {
+	notifier, mock := newMockNotifier(t)
+	require.NoError(t, notifier.Register())
+	require.NoError(t, notifier.Register(), "registering again is a no-op")
+
+	insertEvent := regexp.QuoteMeta("INSERT INTO `mr_webhook_outbox` (`event`,`entity_type`,`entity_id`,`namespace`,`create_time_since_epoch`,`attempts`,`next_attempt_time_since_epoch`,`last_error`)")
+
+	t.Run("create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `Context`")).WillReturnResult(sqlmock.NewResult(5, 1))
+		mock.ExpectExec(insertEvent).
+			WithArgs(string(EventCreate), "ModelVersion", 5, "team-a", sqlmock.AnyArg(), 0, 0, "").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, notifier.db.Create(&schema.Context{TypeID: 2, Name: "1:v1", Namespace: "team-a"}).Error)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE `Context`")).WillReturnResult(sqlmock.NewResult(0, 1))
+		// the namespace isn't updated, it is read
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT type_id,namespace FROM `Context` WHERE id = ?")).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"type_id", "namespace"}).AddRow(1, "team-a"))
+		mock.ExpectExec(insertEvent).
+			WithArgs(string(EventUpdate), "RegisteredModel", 3, "team-a", sqlmock.AnyArg(), 0, 0, "").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectCommit()
+
+		model := &schema.Context{ID: 3, TypeID: 1, Name: "model"}
+		require.NoError(t, notifier.db.Model(model).Updates(model).Error)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT type_id,namespace FROM `Context` WHERE id = ?")).
+			WithArgs(4).
+			WillReturnRows(sqlmock.NewRows([]string{"type_id", "namespace"}).AddRow(3, "team-b"))
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `Context` WHERE `Context`.`id` = ?")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(insertEvent).
+			WithArgs(string(EventDelete), "Experiment", 4, "team-b", sqlmock.AnyArg(), 0, 0, "").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, notifier.db.Delete(&schema.Context{ID: 4}).Error)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolled back with the write", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `Context`")).WillReturnResult(sqlmock.NewResult(6, 1))
+		mock.ExpectExec(insertEvent).WillReturnError(assert.AnError)
+		mock.ExpectRollback()
+
+		err := notifier.db.Create(&schema.Context{TypeID: 2, Name: "1:v2"}).Error
+		assert.ErrorContains(t, err, "error recording webhook event")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not an entity of the registry", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `Context`")).WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+		require.NoError(t, notifier.db.Create(&schema.Context{TypeID: 42, Name: "other"}).Error)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `ContextProperty`")).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		require.NoError(t, notifier.db.Create(&schema.ContextProperty{ContextID: 5, Name: "p"}).Error)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// receiver records the notifications it receives, responding with status.
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func TestDispatch(t *testing.T) {
+	notifier, mock := newMockNotifier(t)
+
+	ok := &receiver{status: http.StatusOK}
+	okServer := httptest.NewServer(ok)
+	defer okServer.Close()
+	failing := &receiver{status: http.StatusInternalServerError}
+	failingServer := httptest.NewServer(failing)
+	defer failingServer.Close()
+
+	otherTenant := &receiver{status: http.StatusOK}
+	otherTenantServer := httptest.NewServer(otherTenant)
+	defer otherTenantServer.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_subscriptions` ORDER BY id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "entity_types", "events", "secret", "namespace", "create_time_since_epoch"}).
+			AddRow(1, okServer.URL, "ModelVersion", "CREATE", "s3cret", "team-a", 1000).
+			AddRow(2, failingServer.URL, "", "", "", "", 2000).
+			AddRow(3, otherTenantServer.URL, "", "", "", "team-b", 3000))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_outbox` WHERE next_attempt_time_since_epoch <= ? ORDER BY id LIMIT ?")).
+		WithArgs(sqlmock.AnyArg(), batchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event", "entity_type", "entity_id", "namespace", "create_time_since_epoch", "attempts", "next_attempt_time_since_epoch", "last_error"}).
+			AddRow(10, "CREATE", "ModelVersion", 5, "team-a", 3000, 0, 0, "").
+			AddRow(11, "UPDATE", "ModelVersion", 5, "team-a", 4000, DefaultMaxAttempts-1, 0, "previous error"))
+
+	// the first event is retried as the second subscription failed, the second is dropped after its last attempt
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `mr_webhook_outbox` SET `attempts`=?,`last_error`=?,`next_attempt_time_since_epoch`=? WHERE id = ?")).
+		WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg(), 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mr_webhook_outbox` WHERE id = ?")).
+		WithArgs(11).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, notifier.Dispatch(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// the first subscription is only notified of the creations of model versions
+	require.Len(t, ok.requests, 1)
+	assert.Equal(t, "CREATE", ok.requests[0].Header.Get(EventHeader))
+	assert.Equal(t, "10", ok.requests[0].Header.Get(DeliveryHeader))
+	assert.Equal(t, Sign("s3cret", ok.bodies[0]), ok.requests[0].Header.Get(SignatureHeader))
+
+	var notification Notification
+	require.NoError(t, json.Unmarshal(ok.bodies[0], &notification))
+	assert.Equal(t, Notification{Id: "10", Event: EventCreate, EntityType: "ModelVersion", EntityId: "5", TimeSinceEpoch: "3000"}, notification)
+
+	// the second subscription is notified of all the events, unsigned
+	require.Len(t, failing.requests, 2)
+	assert.Empty(t, failing.requests[0].Header.Get(SignatureHeader))
+
+	// the third subscription isn't notified of the events of the other namespaces
+	assert.Empty(t, otherTenant.requests)
+}
+
+func TestDispatchRefusesPrivateAddresses(t *testing.T) {
+	notifier, mock := newMockNotifierWithConfig(t, Config{Enabled: true})
+
+	loopback := &receiver{status: http.StatusOK}
+	loopbackServer := httptest.NewServer(loopback)
+	defer loopbackServer.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_subscriptions` ORDER BY id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "entity_types", "events", "secret", "namespace", "create_time_since_epoch"}).
+			AddRow(1, loopbackServer.URL, "", "", "", "", 1000))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_outbox` WHERE next_attempt_time_since_epoch <= ? ORDER BY id LIMIT ?")).
+		WithArgs(sqlmock.AnyArg(), batchSize).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event", "entity_type", "entity_id", "namespace", "create_time_since_epoch", "attempts", "next_attempt_time_since_epoch", "last_error"}).
+			AddRow(10, "CREATE", "ModelVersion", 5, "", 3000, 0, 0, ""))
+
+	// the delivery to the loopback address is refused and retried
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `mr_webhook_outbox` SET `attempts`=?,`last_error`=?,`next_attempt_time_since_epoch`=? WHERE id = ?")).
+		WithArgs(1, sqlmock.AnyArg(), sqlmock.AnyArg(), 10).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, notifier.Dispatch(context.Background()))
+	require.NoError(t, mock.ExpectationsWereMet())
+	assert.Empty(t, loopback.requests)
+}
+
+func TestBackoff(t *testing.T) {
+	notifier, _ := newMockNotifier(t)
+
+	assert.Equal(t, DefaultInterval, notifier.backoff(1))
+	assert.Equal(t, 2*DefaultInterval, notifier.backoff(2))
+	assert.Equal(t, 8*DefaultInterval, notifier.backoff(4))
+	assert.Equal(t, time.Hour, notifier.backoff(100))
+}
+
+func TestHandler(t *testing.T) {
+	notifier, mock := newMockNotifier(t)
+	handler := NewHandler(notifier)
+
+	serveIn := func(namespace string, method string, path string, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if namespace != "" {
+			req = req.WithContext(api.WithNamespace(req.Context(), namespace))
+		}
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	serve := func(method string, path string, body string) *httptest.ResponseRecorder {
+		return serveIn("", method, path, body)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `mr_webhook_subscriptions` (`url`,`entity_types`,`events`,`secret`,`namespace`,`create_time_since_epoch`)")).
+			WithArgs("https://ci.example.com/hook", "ModelVersion", "CREATE", "s3cret", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(7, 1))
+		mock.ExpectCommit()
+
+		rec := serve(http.MethodPost, BasePath, `{"url":"https://ci.example.com/hook","entityTypes":["ModelVersion"],"events":["CREATE"],"secret":"s3cret"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		var created Subscription
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "7", created.Id)
+		assert.Equal(t, []string{"ModelVersion"}, created.EntityTypes)
+		assert.Equal(t, []Event{EventCreate}, created.Events)
+		assert.Empty(t, created.Secret, "secrets are write only")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, body := range map[string]string{
+			"url":         `{"url":"ftp://ci.example.com/hook"}`,
+			"entity type": `{"url":"https://ci.example.com/hook","entityTypes":["Unknown"]}`,
+			"event":       `{"url":"https://ci.example.com/hook","events":["RENAME"]}`,
+			"namespace":   `{"url":"https://ci.example.com/hook","namespace":"Team A"}`,
+			"json":        `{"url":`,
+		} {
+			rec := serve(http.MethodPost, BasePath, body)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+		}
+	})
+
+	t.Run("create by a tenant", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `mr_webhook_subscriptions` (`url`,`entity_types`,`events`,`secret`,`namespace`,`create_time_since_epoch`)")).
+			WithArgs("https://ci.example.com/hook", "", "", "", "team-a", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(8, 1))
+		mock.ExpectCommit()
+
+		rec := serveIn("team-a", http.MethodPost, BasePath, `{"url":"https://ci.example.com/hook"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		var created Subscription
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "team-a", created.Namespace)
+
+		rec = serveIn("team-a", http.MethodPost, BasePath, `{"url":"https://ci.example.com/hook","namespace":"team-b"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, "tenants can't subscribe to the events of other namespaces")
+	})
+
+	t.Run("list by a tenant", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_subscriptions` WHERE namespace = ? ORDER BY id")).
+			WithArgs("team-a").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "entity_types", "events", "secret", "namespace", "create_time_since_epoch"}).
+				AddRow(8, "https://ci.example.com/hook", "", "", "", "team-a", 1000))
+
+		rec := serveIn("team-a", http.MethodGet, BasePath, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other tenant not found", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_subscriptions` WHERE namespace = ? AND id = ? LIMIT ?")).
+			WithArgs("team-b", 8, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		assert.Equal(t, http.StatusNotFound, serveIn("team-b", http.MethodGet, BasePath+"/8", "").Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mr_webhook_subscriptions` ORDER BY id")).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "url", "entity_types", "events", "secret", "namespace", "create_time_since_epoch"}).
+				AddRow(7, "https://ci.example.com/hook", "", "", "s3cret", "", 1000))
+
+		rec := serve(http.MethodGet, BasePath, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		var list SubscriptionList
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+		require.Equal(t, 1, list.Size)
+		assert.Equal(t, Subscription{Id: "7", URL: "https://ci.example.com/hook", CreateTimeSinceEpoch: "1000"}, list.Items[0])
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, BasePath+"/abc", "").Code)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mr_webhook_subscriptions` WHERE id = ?")).
+			WithArgs(8).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, BasePath+"/8", "").Code)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
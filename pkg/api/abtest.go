@@ -0,0 +1,74 @@
+package api
+
+// ABTestState is the state of an A/B test.
+type ABTestState string
+
+const (
+	// ABTestRunning tests split the traffic between their variants and record their results.
+	ABTestRunning ABTestState = "RUNNING"
+	// ABTestCompleted tests have a winner, see WinnerModelVersionId, and no longer record results.
+	ABTestCompleted ABTestState = "COMPLETED"
+)
+
+// ABTestVariant is a model version taking part in an A/B test.
+type ABTestVariant struct {
+	// ModelVersionId is the ID of the model version, a version of the registered model of the test.
+	ModelVersionId string `json:"modelVersionId"`
+	// TrafficPercent is the percentage of the traffic served by the model version, the variants of a test sum to 100.
+	TrafficPercent int32 `json:"trafficPercent"`
+	// Metrics are the outcome metrics of the model version, the latest recorded value of each. Output only.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+// ABTest compares two or more versions of a registered model serving a share of the traffic each, capturing the
+// outcome of the experiments driving the promotions in the registry.
+type ABTest struct {
+	// Id of the test. Output only.
+	Id string `json:"id,omitempty"`
+	// Name uniquely identifies the test among those of the registered model.
+	Name string `json:"name"`
+	// Description of the test.
+	Description string `json:"description,omitempty"`
+	// RegisteredModelId is the ID of the registered model whose versions are compared. Output only.
+	RegisteredModelId string `json:"registeredModelId,omitempty"`
+	// Variants are the compared model versions and their traffic, at least two.
+	Variants []ABTestVariant `json:"variants"`
+	// StartTimeSinceEpoch is the start time in milliseconds since epoch, defaults to the creation time.
+	StartTimeSinceEpoch string `json:"startTimeSinceEpoch,omitempty"`
+	// EndTimeSinceEpoch is the planned end time in milliseconds since epoch, set to the completion time when
+	// the test completes without.
+	EndTimeSinceEpoch string `json:"endTimeSinceEpoch,omitempty"`
+	// State of the test. Output only.
+	State ABTestState `json:"state,omitempty"`
+	// WinnerModelVersionId is the ID of the model version which won the completed test. Output only.
+	WinnerModelVersionId string `json:"winnerModelVersionId,omitempty"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// ABTestList is a page of A/B tests.
+type ABTestList struct {
+	Items         []ABTest `json:"items"`
+	NextPageToken string   `json:"nextPageToken"`
+	PageSize      int32    `json:"pageSize"`
+	Size          int32    `json:"size"`
+}
+
+// ABTestVariantMetrics are outcome metrics of a variant of an A/B test.
+type ABTestVariantMetrics struct {
+	// ModelVersionId is the ID of the model version of the variant.
+	ModelVersionId string `json:"modelVersionId"`
+	// Metrics are the values of the outcome metrics, replacing the values recorded before.
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+// ABTestResult records the outcome metrics of the variants of a running A/B test, and completes it when it
+// has a winner.
+type ABTestResult struct {
+	// Variants are the metrics of the variants.
+	Variants []ABTestVariantMetrics `json:"variants,omitempty"`
+	// WinnerModelVersionId completes the test with the variant of the model version as winner.
+	WinnerModelVersionId string `json:"winnerModelVersionId,omitempty"`
+}
@@ -1,17 +1,48 @@
 package api
 
-import "github.com/kubeflow/model-registry/pkg/openapi"
+import (
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
 
 // ListOptions provides options for listing entities with pagination and sorting.
 // It includes parameters such as PageSize, OrderBy, SortOrder, and NextPageToken.
 type ListOptions struct {
-	PageSize      *int32  // The maximum number of entities to be returned per page.
-	OrderBy       *string // The field by which entities are ordered.
-	SortOrder     *string // The sorting order, which can be "ASC" (ascending) or "DESC" (descending).
-	NextPageToken *string // A token to retrieve the next page of entities in a paginated result set.
-	FilterQuery   *string // A filter query to restrict results based on entity properties.
+	PageSize      *int32   // The maximum number of entities to be returned per page.
+	OrderBy       *string  // The field by which entities are ordered.
+	SortOrder     *string  // The sorting order, which can be "ASC" (ascending) or "DESC" (descending).
+	NextPageToken *string  // A token to retrieve the next page of entities in a paginated result set.
+	FilterQuery   *string  // A filter query to restrict results based on entity properties.
+	Query         *string  // A free-text search over the name, description and string custom properties of the entities.
+	State         *string  // Restricts registered models and model versions to a state: LIVE, ARCHIVED or ALL, the default.
+	Roles         []string // Restricts artifacts to the roles of their types: model, data, metrics, parameters or doc.
+	Tags          []string // Restricts registered models, model versions, experiments, experiment runs, inference services and serving environments to the ones with all these tags.
 }
 
+// States of the ListOptions, LIVE and ARCHIVED are the states of the registered models and model versions.
+const (
+	ListStateLive     = "LIVE"
+	ListStateArchived = "ARCHIVED"
+	ListStateAll      = "ALL"
+)
+
+// Roles of the ListOptions, the roles the artifacts of each type play for their model version or experiment run.
+const (
+	ArtifactRoleModel      = "model"
+	ArtifactRoleData       = "data"
+	ArtifactRoleMetrics    = "metrics"
+	ArtifactRoleParameters = "parameters"
+	ArtifactRoleDoc        = "doc"
+)
+
+// MaxBatchGetIds is the maximum number of ids that can be retrieved at once by the batch get methods.
+const MaxBatchGetIds = 100
+
+// MaxBatchCreateModelVersions is the maximum number of model versions that can be created at once by
+// CreateModelVersions.
+const MaxBatchCreateModelVersions = 100
+
 // ModelRegistryApi defines the external API for the Model Registry library
 type ModelRegistryApi interface {
 	// REGISTERED MODEL
@@ -23,6 +54,14 @@ type ModelRegistryApi interface {
 	// GetRegisteredModelById retrieve RegisteredModel by id
 	GetRegisteredModelById(id string) (*openapi.RegisteredModel, error)
 
+	// GetRegisteredModelAsOf retrieve RegisteredModel by id as it was at asOf, from the revisions recorded by its
+	// updates
+	GetRegisteredModelAsOf(id string, asOf time.Time) (*openapi.RegisteredModel, error)
+
+	// GetRegisteredModelsByIds retrieve the RegisteredModel instances with the given ids in a single round trip,
+	// in the same order as ids, ids not found are skipped
+	GetRegisteredModelsByIds(ids []string) (*openapi.RegisteredModelList, error)
+
 	// GetRegisteredModelByInferenceService retrieve a RegisteredModel by inference service id
 	GetRegisteredModelByInferenceService(inferenceServiceId string) (*openapi.RegisteredModel, error)
 
@@ -32,6 +71,18 @@ type ModelRegistryApi interface {
 	// GetRegisteredModels return all ModelArtifact properly ordered and sized based on listOptions param.
 	GetRegisteredModels(listOptions ListOptions) (*openapi.RegisteredModelList, error)
 
+	// StreamRegisteredModels calls yield with the RegisteredModel instances of the page of listOptions as they are
+	// read from the database, stopping at the first error of yield, and returns the token of the next page.
+	StreamRegisteredModels(listOptions ListOptions, yield func(*openapi.RegisteredModel) error) (string, error)
+
+	// ArchiveRegisteredModel soft delete a RegisteredModel and its ModelVersions by moving them to the ARCHIVED
+	// state, they are kept with their lineage and can be restored by updating their state
+	ArchiveRegisteredModel(id string) (*openapi.RegisteredModel, error)
+
+	// GetRegisteredModelLineage retrieve the Lineage of a RegisteredModel, the entities up to depth links away:
+	// its ModelVersion instances, their artifacts and the ExperimentRun and Experiment instances which produced them
+	GetRegisteredModelLineage(id string, depth int32) (*Lineage, error)
+
 	// MODEL VERSION
 
 	// UpsertModelVersion create a new Model Version or update a Model Version associated to a
@@ -41,6 +92,18 @@ type ModelRegistryApi interface {
 	// GetModelVersionById retrieve ModelVersion by id
 	GetModelVersionById(id string) (*openapi.ModelVersion, error)
 
+	// GetModelVersionsByIds retrieve the ModelVersion instances with the given ids in a single round trip,
+	// in the same order as ids, ids not found are skipped
+	GetModelVersionsByIds(ids []string) (*openapi.ModelVersionList, error)
+
+	// GetModelVersionLineage retrieve the Lineage of a ModelVersion, the entities up to depth links away: its
+	// RegisteredModel, its artifacts and the ExperimentRun and Experiment instances which produced them
+	GetModelVersionLineage(id string, depth int32) (*Lineage, error)
+
+	// CreateModelVersions create the model versions of a batch with their model artifacts in the RegisteredModel
+	// identified by registeredModelId in a single transaction, none of them is created if one fails
+	CreateModelVersions(registeredModelId string, batch *ModelVersionBatch) (*ModelVersionBatch, error)
+
 	// GetModelVersionByInferenceService retrieve a ModelVersion by inference service id
 	GetModelVersionByInferenceService(inferenceServiceId string) (*openapi.ModelVersion, error)
 
@@ -51,6 +114,191 @@ type ModelRegistryApi interface {
 	// if registeredModelId is provided, return all ModelVersion instances belonging to a specific RegisteredModel
 	GetModelVersions(listOptions ListOptions, registeredModelId *string) (*openapi.ModelVersionList, error)
 
+	// StreamModelVersions calls yield with the ModelVersion instances of the page of listOptions as they are read from
+	// the database, stopping at the first error of yield, and returns the token of the next page.
+	StreamModelVersions(listOptions ListOptions, registeredModelId *string, yield func(*openapi.ModelVersion) error) (string, error)
+
+	// ArchiveModelVersion soft delete a ModelVersion by moving it to the ARCHIVED state
+	ArchiveModelVersion(id string) (*openapi.ModelVersion, error)
+
+	// MODEL VERSION POLICY
+
+	// GetModelVersionPolicy retrieve the guardrail and evaluation policy attached to a ModelVersion,
+	// an empty policy is returned if none was attached yet
+	GetModelVersionPolicy(modelVersionId string) (*ModelVersionPolicy, error)
+
+	// UpsertModelVersionPolicy replace the guardrail and evaluation policy attached to a ModelVersion
+	UpsertModelVersionPolicy(modelVersionId string, policy *ModelVersionPolicy) (*ModelVersionPolicy, error)
+
+	// GetInferenceServicePolicy retrieve the policy of the ModelVersion currently served by an InferenceService
+	GetInferenceServicePolicy(inferenceServiceId string) (*ModelVersionPolicy, error)
+
+	// MODEL VERSION DEPLOYABILITY
+
+	// IsModelVersionDeployable evaluate the configured deploy gates for a ModelVersion, with the reasons of the failed ones
+	IsModelVersionDeployable(modelVersionId string) (*Deployability, error)
+
+	// MODEL VERSION RESOURCE FOOTPRINT
+
+	// GetModelVersionResourceFootprint retrieve the serving resource requirements and cost of a ModelVersion
+	GetModelVersionResourceFootprint(modelVersionId string) (*ResourceFootprint, error)
+
+	// UpdateModelVersionResourceFootprint update the serving resource requirements and cost of a ModelVersion,
+	// only the fields set in footprint are updated
+	UpdateModelVersionResourceFootprint(modelVersionId string, footprint *ResourceFootprint) (*ResourceFootprint, error)
+
+	// ARTIFACT VARIANT
+
+	// GetModelArtifactVariant retrieve the platform, precision and engine a ModelArtifact is built for
+	GetModelArtifactVariant(artifactId string) (*ArtifactVariant, error)
+
+	// UpdateModelArtifactVariant update the platform, precision and engine a ModelArtifact is built for,
+	// only the fields set in variant are updated
+	UpdateModelArtifactVariant(artifactId string, variant *ArtifactVariant) (*ArtifactVariant, error)
+
+	// ResolveModelVersionArtifact return the ModelArtifact of a ModelVersion best matching query
+	ResolveModelVersionArtifact(modelVersionId string, query ArtifactVariantQuery) (*openapi.Artifact, error)
+
+	// ARTIFACT REACHABILITY
+
+	// GetModelArtifactReachability retrieve the outcome of the last verification of the uri of a ModelArtifact
+	GetModelArtifactReachability(artifactId string) (*ArtifactReachability, error)
+
+	// GetUnreachableModelArtifacts return all the ModelArtifact whose uri was not reachable when last verified
+	GetUnreachableModelArtifacts() (*ArtifactReachabilityList, error)
+
+	// ARTIFACT REFERENCES
+
+	// GetModelArtifactReferences return the model versions, experiment runs and inference services referencing the uri of a ModelArtifact
+	GetModelArtifactReferences(artifactId string) (*ArtifactReferences, error)
+
+	// PROMOTION
+
+	// UpsertPromotion create or update a Promotion copying model versions from a source to a target registry.
+	// If Id is provided update the entity otherwise create a new one.
+	UpsertPromotion(promotion *Promotion) (*Promotion, error)
+
+	// GetPromotionById retrieve Promotion by id
+	GetPromotionById(id string) (*Promotion, error)
+
+	// GetPromotions return all Promotion properly ordered and sized based on listOptions param.
+	GetPromotions(listOptions ListOptions) (*PromotionList, error)
+
+	// StartPromotionRun start a run of a Promotion, the run waits for approval if the promotion requires it,
+	// otherwise it copies the matching model versions in the background
+	StartPromotionRun(promotionId string) (*PromotionRun, error)
+
+	// PlanPromotionRun return the changes a run of a Promotion would make if approved now, without starting it
+	PlanPromotionRun(promotionId string) (*Plan, error)
+
+	// GetPromotionRunById retrieve PromotionRun by id
+	GetPromotionRunById(id string) (*PromotionRun, error)
+
+	// GetPromotionRuns return all PromotionRun of a Promotion properly ordered and sized based on listOptions param.
+	GetPromotionRuns(listOptions ListOptions, promotionId string) (*PromotionRunList, error)
+
+	// ApprovePromotionRun approve a PromotionRun pending approval and start copying the matching model versions
+	ApprovePromotionRun(id string, review *PromotionReview) (*PromotionRun, error)
+
+	// RejectPromotionRun reject a PromotionRun pending approval
+	RejectPromotionRun(id string, review *PromotionReview) (*PromotionRun, error)
+
+	// CONVERSION JOB
+
+	// StartConversionJob trigger the external converter of job for a model artifact of a ModelVersion,
+	// the converted artifact is registered as a variant of the ModelVersion once the job completes
+	StartConversionJob(modelVersionId string, job *ConversionJob) (*ConversionJob, error)
+
+	// GetConversionJobById retrieve ConversionJob by id
+	GetConversionJobById(id string) (*ConversionJob, error)
+
+	// GetConversionJobs return all ConversionJob properly ordered and sized based on listOptions param.
+	// if modelVersionId is provided, return all ConversionJob of the ModelVersion
+	GetConversionJobs(listOptions ListOptions, modelVersionId *string) (*ConversionJobList, error)
+
+	// CompleteConversionJob record the outcome of a running ConversionJob reported by its converter
+	CompleteConversionJob(id string, completion *ConversionJobCompletion) (*ConversionJob, error)
+
+	// DEPLOYMENT
+
+	// DeployModelVersion move a ModelVersion to the stage of deployment and deploy it to its InferenceService in
+	// the background, the stage change is rolled back if the InferenceService can not be deployed
+	DeployModelVersion(modelVersionId string, deployment *Deployment) (*Deployment, error)
+
+	// GetDeploymentById retrieve Deployment by id
+	GetDeploymentById(id string) (*Deployment, error)
+
+	// GetDeployments return all Deployment properly ordered and sized based on listOptions param.
+	// if inferenceServiceId is provided, return the deployment history of the InferenceService
+	GetDeployments(listOptions ListOptions, inferenceServiceId *string) (*DeploymentList, error)
+
+	// STAGE TRANSITION
+
+	// TransitionModelVersionStage move a ModelVersion to another stage of its lifecycle and record the transition
+	TransitionModelVersionStage(modelVersionId string, transition *StageTransition) (*StageTransition, error)
+
+	// GetStageTransitions return all StageTransition properly ordered and sized based on listOptions param.
+	// if modelVersionId is provided, return the stage history of the ModelVersion
+	GetStageTransitions(listOptions ListOptions, modelVersionId *string) (*StageTransitionList, error)
+
+	// TAG
+
+	// UpsertTag create a Tag, or update the color and description of the Tag with its name
+	UpsertTag(tag *Tag) (*Tag, error)
+
+	// GetTagByName retrieve Tag by name
+	GetTagByName(name string) (*Tag, error)
+
+	// GetTags return all Tag properly ordered and sized based on listOptions param, with the number of entities
+	// carrying each of them
+	GetTags(listOptions ListOptions) (*TagList, error)
+
+	// DeleteTag delete the Tag with the name, removing it from the entities carrying it
+	DeleteTag(name string) error
+
+	// GetEntityTags return the tags of the entity of entityType with the id, entityType is one of the
+	// TagEntityType constants
+	GetEntityTags(entityType string, id string) (*EntityTags, error)
+
+	// SetEntityTags replace the tags of the entity of entityType with the id, creating the tags that don't exist
+	SetEntityTags(entityType string, id string, tags []string) (*EntityTags, error)
+
+	// AB TEST
+
+	// CreateABTest create an ABTest comparing versions of the RegisteredModel
+	CreateABTest(registeredModelId string, abTest *ABTest) (*ABTest, error)
+
+	// GetABTestById retrieve ABTest by id
+	GetABTestById(id string) (*ABTest, error)
+
+	// GetABTests return all ABTest properly ordered and sized based on listOptions param.
+	// if registeredModelId is provided, return the ABTest instances comparing versions of the RegisteredModel
+	GetABTests(listOptions ListOptions, registeredModelId *string) (*ABTestList, error)
+
+	// RecordABTestResult record the outcome metrics of the variants of a running ABTest, completing it if the
+	// result has a winner
+	RecordABTestResult(id string, result *ABTestResult) (*ABTest, error)
+
+	// METRICS TABLE
+
+	// CreateMetricsTable create a MetricsTable of the evaluation outputs of the ModelVersion
+	CreateMetricsTable(modelVersionId string, metricsTable *MetricsTable) (*MetricsTable, error)
+
+	// GetMetricsTableById retrieve MetricsTable by id with the selected columns, all of them if columns is nil
+	GetMetricsTableById(id string, columns []string) (*MetricsTable, error)
+
+	// GetMetricsTables return all MetricsTable properly ordered and sized based on listOptions param, without the
+	// values of their columns. if modelVersionId is provided, return the MetricsTable instances of the ModelVersion
+	GetMetricsTables(listOptions ListOptions, modelVersionId *string) (*MetricsTableList, error)
+
+	// MODEL CARD
+
+	// UpsertModelCard create or replace the ModelCard of the RegisteredModel
+	UpsertModelCard(registeredModelId string, modelCard *ModelCard) (*ModelCard, error)
+
+	// GetModelCard retrieve the ModelCard of the RegisteredModel
+	GetModelCard(registeredModelId string) (*ModelCard, error)
+
 	// ARTIFACT
 
 	// UpsertModelVersionArtifact create or update an Artifact for a specific ModelVersion, the behavior follows the same
@@ -64,6 +312,10 @@ type ModelRegistryApi interface {
 	// GetArtifactById retrieve Artifact by id
 	GetArtifactById(id string) (*openapi.Artifact, error)
 
+	// GetArtifactsByIds retrieve the Artifact instances with the given ids in a single round trip,
+	// in the same order as ids, ids not found are skipped
+	GetArtifactsByIds(ids []string) (*openapi.ArtifactList, error)
+
 	// GetArtifactByParams find Artifact instances that match the provided optional params
 	GetArtifactByParams(artifactName *string, parentResourceId *string, externalId *string) (*openapi.Artifact, error)
 
@@ -160,6 +412,9 @@ type ModelRegistryApi interface {
 	// GetExperimentRuns return all ExperimentRun properly ordered and sized based on listOptions param.
 	// if experimentId is provided, return all ExperimentRun instances belonging to a specific Experiment
 	GetExperimentRuns(listOptions ListOptions, experimentId *string) (*openapi.ExperimentRunList, error)
+	// StreamExperimentRuns calls yield with the ExperimentRun instances of the page of listOptions as they are read from
+	// the database, stopping at the first error of yield, and returns the token of the next page.
+	StreamExperimentRuns(listOptions ListOptions, experimentId *string, yield func(*openapi.ExperimentRun) error) (string, error)
 
 	// EXPERIMENT RUN ARTIFACTS
 	// UpsertExperimentRunArtifact create or update an Artifact for a specific ExperimentRun, the behavior follows the same
@@ -169,8 +424,73 @@ type ModelRegistryApi interface {
 	// if experimentRunId is provided, return all Artifact instances belonging to a specific ExperimentRun
 	GetExperimentRunArtifacts(artifactType openapi.ArtifactTypeQueryParam, listOptions ListOptions, experimentRunId *string) (*openapi.ArtifactList, error)
 
+	// EXTERNAL ID
+
+	// GetEntitiesByExternalId return the references to the entities of any type with the given external id,
+	// more than one entity is returned only if the external id policy allows it
+	GetEntitiesByExternalId(externalId string) (*EntityReferenceList, error)
+
+	// ENTITY REFERENCE
+
+	// ResolveEntityRefs expand the entity references into their entities, in the same order. The references of
+	// missing entities fail the whole call.
+	ResolveEntityRefs(refs []EntityRef) (*ResolvedEntityList, error)
+
 	// EXPERIMENT RUN METRIC HISTORY
 	// GetExperimentRunMetricHistory return metric history for a specific ExperimentRun properly ordered and sized based on listOptions param.
 	// if name is provided, filter metrics by name. if stepIds is provided, filter metrics by step ids
 	GetExperimentRunMetricHistory(name *string, stepIds *string, listOptions ListOptions, experimentRunId *string) (*openapi.MetricList, error)
+
+	// GetExperimentRunsMetricAggregate return the aggregation of the metric history of the metric of the runs of the
+	// Experiment, for each run and over all of them. aggregation is one of max, min or avg.
+	GetExperimentRunsMetricAggregate(experimentId string, metric string, aggregation string) (*RunMetricAggregateList, error)
+
+	// GetExperimentRunsLeaderboard return the runs of the Experiment ranked by the best value of the metric history of
+	// the metric, which must be declared in the metric schema of the Experiment
+	GetExperimentRunsLeaderboard(experimentId string, metric string) (*RunLeaderboard, error)
+
+	// EXPERIMENT METRIC SCHEMA
+
+	// GetExperimentMetricSchema retrieve the metrics declared for the runs of an Experiment
+	GetExperimentMetricSchema(experimentId string) (*ExperimentMetricSchema, error)
+
+	// UpsertExperimentMetricSchema replace the metrics declared for the runs of an Experiment, the metrics logged
+	// before are not validated again
+	UpsertExperimentMetricSchema(experimentId string, schema *ExperimentMetricSchema) (*ExperimentMetricSchema, error)
+
+	// PROPERTY VALUES
+
+	// GetCustomPropertyValues return the custom property key of the entities of entityType with the given ids in a
+	// single query, entityType is one of the PropertyEntityType constants
+	GetCustomPropertyValues(entityType string, key string, ids []string) (*PropertyValueList, error)
+
+	// WATCH
+
+	// GetChanges return the creations and updates of the entities of entityTypes, or of all the WatchEntityTypes, after
+	// resourceVersion and at most at until, both in milliseconds since epoch
+	GetChanges(entityTypes []string, resourceVersion int64, until int64) (*ChangeList, error)
+
+	// AUDIT
+
+	// GetAuditEvents return the audit events matching filter properly ordered and sized based on listOptions param
+	GetAuditEvents(listOptions ListOptions, filter AuditEventFilter) (*AuditEventList, error)
+
+	// SNAPSHOT
+
+	// ExportSnapshot return the records of a snapshot of the registered models of the request, with their model
+	// versions and artifacts, starting with its header
+	ExportSnapshot(request SnapshotExportRequest) ([]SnapshotRecord, error)
+
+	// ImportSnapshot create the entities of the records of a snapshot with new ids, failing before creating any if the
+	// snapshot is invalid or its registered models already exist, and creating none if any fails on a database
+	ImportSnapshot(records []SnapshotRecord) (*SnapshotImport, error)
+
+	// PlanSnapshotImport return the changes the import of the records of a snapshot would make, including its
+	// conflicts, without importing them
+	PlanSnapshotImport(records []SnapshotRecord) (*Plan, error)
+
+	// LINT
+
+	// LintWrite returns the warnings of the lint rules broken by a written entity, the result of an upsert
+	LintWrite(entity any) []Warning
 }
@@ -0,0 +1,66 @@
+package api
+
+// Actions of the audit events.
+const (
+	AuditActionCreate = "CREATE"
+	AuditActionUpdate = "UPDATE"
+	AuditActionDelete = "DELETE"
+	// AuditActionTransition is an update changing the state or the stage of the entity.
+	AuditActionTransition = "TRANSITION"
+)
+
+// AuditActions are the actions of the audit events.
+var AuditActions = []string{AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionTransition}
+
+// AuditEntityTypeTag is the entity type of the audit events of the tags.
+const AuditEntityTypeTag = "Tag"
+
+// AuditEvent is a write of an entity by the actor of an api request, recorded in the audit log.
+type AuditEvent struct {
+	// Id of the event. Output only.
+	Id string `json:"id"`
+	// Action is one of the AuditActions.
+	Action string `json:"action"`
+	// Actor is the caller of the request, the name of its token or its user.
+	Actor string `json:"actor"`
+	// EntityType is the type of the entity, e.g. RegisteredModel, ModelArtifact, Metric or Tag.
+	EntityType string `json:"entityType"`
+	EntityId   string `json:"entityId"`
+	// Ref is the canonical reference to the entity, unset for the entity types with no reference.
+	Ref       *EntityRef `json:"ref,omitempty"`
+	Namespace string     `json:"namespace,omitempty"`
+	// Changes are the fields of the entity changed by the write, sorted by field. The custom properties are the
+	// customProperties.<name> fields.
+	Changes []FieldChange `json:"changes"`
+	// CreateTimeSinceEpoch is the time of the write in milliseconds since epoch.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch"`
+}
+
+// FieldChange is the value of a field before and after a write, Before is unset for the fields set by the write and
+// After for the fields it removed.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// AuditEventList is a page of audit events.
+type AuditEventList struct {
+	Items         []AuditEvent `json:"items"`
+	NextPageToken string       `json:"nextPageToken"`
+	PageSize      int32        `json:"pageSize"`
+	Size          int32        `json:"size"`
+}
+
+// AuditEventFilter selects the audit events of GetAuditEvents, the unset fields match all the events.
+type AuditEventFilter struct {
+	Actor *string
+	// Action is one of the AuditActions.
+	Action *string
+	// EntityType is the type of the entities, Artifact matches the artifacts of all types.
+	EntityType *string
+	EntityId   *string
+	// Since and Until bound the time of the events in milliseconds since epoch, inclusively.
+	Since *int64
+	Until *int64
+}
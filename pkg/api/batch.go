@@ -0,0 +1,21 @@
+package api
+
+import "github.com/kubeflow/model-registry/pkg/openapi"
+
+// BatchGetRequest is the body of the batch get endpoints.
+type BatchGetRequest struct {
+	// Ids are the ids of the entities to retrieve, at most MaxBatchGetIds.
+	Ids []string `json:"ids"`
+}
+
+// ModelVersionBatch is the body and the result of the batch create endpoint of the model versions.
+type ModelVersionBatch struct {
+	// Items are the model versions to create with their model artifacts, at most MaxBatchCreateModelVersions.
+	Items []ModelVersionBatchItem `json:"items"`
+}
+
+// ModelVersionBatchItem is a model version of a ModelVersionBatch with its model artifacts.
+type ModelVersionBatchItem struct {
+	ModelVersion openapi.ModelVersion    `json:"modelVersion"`
+	Artifacts    []openapi.ModelArtifact `json:"artifacts,omitempty"`
+}
@@ -0,0 +1,82 @@
+package api
+
+import "context"
+
+// ContextBinder is implemented by the registries able to run the queries of a request with its context, to stop them
+// at its deadline and account them to its query budget.
+type ContextBinder interface {
+	WithContext(ctx context.Context) ModelRegistryApi
+}
+
+// WithContext returns registry bound to ctx, or registry itself if it can't be bound.
+func WithContext(ctx context.Context, registry ModelRegistryApi) ModelRegistryApi {
+	if binder, ok := registry.(ContextBinder); ok {
+		return binder.WithContext(ctx)
+	}
+	return registry
+}
+
+type clearedFieldsKey struct{}
+
+// WithClearedFields returns ctx for the updates removing the fields of their entity set to null by a JSON merge patch,
+// as description. The fields left unset by the other updates are unchanged.
+func WithClearedFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, clearedFieldsKey{}, fields)
+}
+
+// ClearedFields returns the fields the updates of ctx remove, if any.
+func ClearedFields(ctx context.Context) []string {
+	fields, _ := ctx.Value(clearedFieldsKey{}).([]string)
+	return fields
+}
+
+type namespaceKey struct{}
+
+// WithNamespace returns ctx for the requests of a tenant: the entities they read, list and update are restricted to
+// namespace, and the entities they create are created in it.
+func WithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceKey{}, namespace)
+}
+
+// Namespace returns the namespace of the tenant of ctx, empty for the requests of no tenant which see all the entities.
+func Namespace(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	namespace, _ := ctx.Value(namespaceKey{}).(string)
+	return namespace
+}
+
+type actorKey struct{}
+
+// WithActor returns ctx for the requests whose writes are recorded in the audit log, on behalf of actor: the name of
+// their api token or their user, empty for the anonymous requests.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor of the writes of ctx, false if they are not audited.
+func Actor(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	actor, ok := ctx.Value(actorKey{}).(string)
+	return actor, ok
+}
+
+type callerKey struct{}
+
+// WithCaller returns ctx for the requests of an authenticated caller: the name of their api token, the admin, or their
+// user set by the authenticating proxy in front of the registry.
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// Caller returns the authenticated caller of ctx, empty for the anonymous requests.
+func Caller(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
@@ -0,0 +1,61 @@
+package api
+
+import "github.com/kubeflow/model-registry/pkg/openapi"
+
+// ConversionJobState is the state of a conversion job.
+type ConversionJobState string
+
+const (
+	// ConversionJobRunning jobs were handed to the external converter, which has not reported completion yet.
+	ConversionJobRunning ConversionJobState = "RUNNING"
+	// ConversionJobSucceeded jobs registered the converted artifact as a variant of the model version.
+	ConversionJobSucceeded ConversionJobState = "SUCCEEDED"
+	// ConversionJobFailed jobs could not be triggered or were reported failed by the converter, see the message.
+	ConversionJobFailed ConversionJobState = "FAILED"
+)
+
+// ConversionJob converts a model artifact of a model version (e.g. ONNX export, INT8 quantization) with an
+// external converter, the resulting artifact is registered as a variant of the model version on completion.
+type ConversionJob struct {
+	// Id of the job. Output only.
+	Id string `json:"id,omitempty"`
+	// ModelVersionId is the ID of the model version owning the source and converted artifacts. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// Converter names the conversion hook configured on the server running the job, e.g. "onnx-export".
+	Converter string `json:"converter"`
+	// SourceArtifactId is the ID of the model artifact of the model version to convert.
+	SourceArtifactId string `json:"sourceArtifactId"`
+	// Parameters are passed as is to the converter.
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// Variant is recorded on the converted artifact, it describes what the converter produces.
+	Variant ArtifactVariant `json:"variant"`
+	// State of the job. Output only.
+	State ConversionJobState `json:"state,omitempty"`
+	// Message explains failed jobs. Output only.
+	Message string `json:"message,omitempty"`
+	// ArtifactId is the ID of the converted artifact, once the job succeeded. Output only.
+	ArtifactId string `json:"artifactId,omitempty"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// ConversionJobList is a page of conversion jobs.
+type ConversionJobList struct {
+	Items         []ConversionJob `json:"items"`
+	NextPageToken string          `json:"nextPageToken"`
+	PageSize      int32           `json:"pageSize"`
+	Size          int32           `json:"size"`
+}
+
+// ConversionJobCompletion is reported by the converter when a conversion job completes.
+type ConversionJobCompletion struct {
+	// State is either ConversionJobSucceeded or ConversionJobFailed.
+	State ConversionJobState `json:"state"`
+	// Message explains failures.
+	Message string `json:"message,omitempty"`
+	// Artifact is the converted artifact, required on success. Its uri must be set, the name defaults to
+	// "<source artifact name>-<converter>".
+	Artifact *openapi.ModelArtifact `json:"artifact,omitempty"`
+}
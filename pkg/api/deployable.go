@@ -0,0 +1,72 @@
+package api
+
+// Gates of the deployability of the model versions, DeployGateState is always evaluated.
+const (
+	// DeployGateState requires the model version not to be archived.
+	DeployGateState       = "state"
+	DeployGateApproval    = "approval"
+	DeployGateSignature   = "signature"
+	DeployGateScans       = "scans"
+	DeployGateEvaluations = "evaluations"
+)
+
+// DefaultScanPassValues are the values of the scan properties of the passed scans unless configured otherwise.
+var DefaultScanPassValues = []string{"passed"}
+
+// DefaultEvaluationPropertyPrefix is the prefix of the custom properties of the model versions holding the scores of
+// their evaluation suites unless configured otherwise.
+const DefaultEvaluationPropertyPrefix = "eval."
+
+// DeployGates configures the gates the model versions must pass to be deployed, the unset gates are not evaluated.
+type DeployGates struct {
+	Approval    *ApprovalGate    `json:"approval,omitempty"`
+	Signature   *SignatureGate   `json:"signature,omitempty"`
+	Scans       []ScanGate       `json:"scans,omitempty"`
+	Evaluations *EvaluationsGate `json:"evaluations,omitempty"`
+}
+
+// ApprovalGate requires the model version to be in one of the stages, which it is approved into with the stage
+// transitions.
+type ApprovalGate struct {
+	Stages []ModelVersionStage `json:"stages"`
+}
+
+// SignatureGate requires the live model artifacts of the model version to have their signature verified, recorded
+// as true in the boolean custom property by the verifier.
+type SignatureGate struct {
+	Property string `json:"property"`
+}
+
+// ScanGate requires the custom property of the model version, recorded by a scanner, to be one of the pass values,
+// DefaultScanPassValues by default, ignoring case.
+type ScanGate struct {
+	Property   string   `json:"property"`
+	PassValues []string `json:"passValues,omitempty"`
+}
+
+// EvaluationsGate requires the model version to have the score of each required evaluation of its policy in the
+// custom property of the suite prefixed by PropertyPrefix, DefaultEvaluationPropertyPrefix by default, at least the
+// minimum score of the evaluation.
+type EvaluationsGate struct {
+	PropertyPrefix string `json:"propertyPrefix,omitempty"`
+}
+
+// GateResult is the outcome of a gate for a model version.
+type GateResult struct {
+	// Gate is one of the DeployGate constants.
+	Gate   string `json:"gate"`
+	Passed bool   `json:"passed"`
+	// Reasons explain why the gate failed.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Deployability tells whether a model version passes all the configured gates to be deployed.
+type Deployability struct {
+	ModelVersionId string `json:"modelVersionId"`
+	// Deployable is true if the model version passed all the gates.
+	Deployable bool `json:"deployable"`
+	// Gates are the outcomes of the gates, in the order of the DeployGate constants.
+	Gates []GateResult `json:"gates"`
+	// Reasons are the reasons of all the failed gates.
+	Reasons []string `json:"reasons"`
+}
@@ -0,0 +1,53 @@
+package api
+
+// DefaultDeploymentStage is the stage model versions are moved to when deployed, unless the deployment sets one.
+const DefaultDeploymentStage = "Production"
+
+// DeploymentState is the state of a deployment.
+type DeploymentState string
+
+const (
+	// DeploymentRunning deployments are being applied to the model version and the inference service.
+	DeploymentRunning DeploymentState = "RUNNING"
+	// DeploymentSucceeded deployments moved the model version to the stage and the inference service serves it.
+	DeploymentSucceeded DeploymentState = "SUCCEEDED"
+	// DeploymentRolledBack deployments failed, the stage of the model version and the inference service were
+	// restored, see the message.
+	DeploymentRolledBack DeploymentState = "ROLLED_BACK"
+	// DeploymentFailed deployments failed and could not be rolled back, see the message.
+	DeploymentFailed DeploymentState = "FAILED"
+)
+
+// Deployment moves a model version to a stage, Production by default, and deploys it to an inference service as
+// a single operation: the stage change is rolled back if the inference service can not be deployed. Deployments
+// are the deployment history of the inference services.
+type Deployment struct {
+	// Id of the deployment. Output only.
+	Id string `json:"id,omitempty"`
+	// ModelVersionId is the ID of the deployed model version. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// InferenceServiceId is the ID of the inference service serving the model version.
+	InferenceServiceId string `json:"inferenceServiceId"`
+	// Stage the model version is moved to, defaults to DefaultDeploymentStage.
+	Stage string `json:"stage,omitempty"`
+	// PreviousStage is the stage of the model version before the deployment, empty if it had none. Output only.
+	PreviousStage string `json:"previousStage,omitempty"`
+	// PreviousModelVersionId is the ID of the model version the inference service served before. Output only.
+	PreviousModelVersionId string `json:"previousModelVersionId,omitempty"`
+	// State of the deployment. Output only.
+	State DeploymentState `json:"state,omitempty"`
+	// Message explains rolled back and failed deployments. Output only.
+	Message string `json:"message,omitempty"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// DeploymentList is a page of deployments.
+type DeploymentList struct {
+	Items         []Deployment `json:"items"`
+	NextPageToken string       `json:"nextPageToken"`
+	PageSize      int32        `json:"pageSize"`
+	Size          int32        `json:"size"`
+}
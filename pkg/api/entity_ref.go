@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MaxResolveRefs is the maximum number of entity references that can be resolved at once by ResolveEntityRefs.
+const MaxResolveRefs = 100
+
+// entityRefTypes are the type names of the entity references by entity type.
+var entityRefTypes = map[string]string{
+	EntityTypeRegisteredModel:    "registered_model",
+	EntityTypeModelVersion:       "model_version",
+	EntityTypeArtifact:           "artifact",
+	EntityTypeServingEnvironment: "serving_environment",
+	EntityTypeInferenceService:   "inference_service",
+	EntityTypeServeModel:         "serve_model",
+	EntityTypeExperiment:         "experiment",
+	EntityTypeExperimentRun:      "experiment_run",
+}
+
+// entityRefNamedTypes are the entity types that can be referenced by name, the ones which names are unique.
+var entityRefNamedTypes = []string{EntityTypeRegisteredModel, EntityTypeServingEnvironment, EntityTypeExperiment}
+
+// EntityRef is the canonical reference to an entity of any type, written as a string in one of the forms:
+//
+//	registered_model/123                referencing the entity by id
+//	model_version?externalId=abc        referencing the entity by external id
+//	experiment?name=tuning              referencing the entity by name
+//
+// The type is one of registered_model, model_version, artifact, serving_environment, inference_service, serve_model,
+// experiment and experiment_run, only registered models, serving environments and experiments are referenced by name.
+// The external ids and names are query escaped.
+type EntityRef struct {
+	// EntityType is the type of the entity, one of the EntityType constants.
+	EntityType string
+	// Id, ExternalId or Name identifies the entity, only one of them is set.
+	Id         string
+	ExternalId string
+	Name       string
+}
+
+// NewEntityRef returns the reference to the entity of entityType, one of the EntityType constants, with the id.
+func NewEntityRef(entityType string, id string) EntityRef {
+	return EntityRef{EntityType: entityType, Id: id}
+}
+
+// ParseEntityRef parses an entity reference written in one of the forms of EntityRef.
+func ParseEntityRef(ref string) (EntityRef, error) {
+	typeName, id, byID := strings.Cut(ref, "/")
+	query := ""
+	if !byID {
+		typeName, query, _ = strings.Cut(ref, "?")
+	}
+
+	parsed := EntityRef{}
+	for entityType, name := range entityRefTypes {
+		if name == typeName {
+			parsed.EntityType = entityType
+		}
+	}
+	if parsed.EntityType == "" {
+		return EntityRef{}, fmt.Errorf("invalid entity reference %q, unknown type %q: %w", ref, typeName, ErrBadRequest)
+	}
+
+	if byID {
+		if id == "" || strings.ContainsAny(id, "/?") {
+			return EntityRef{}, fmt.Errorf("invalid entity reference %q, invalid id %q: %w", ref, id, ErrBadRequest)
+		}
+		parsed.Id = id
+		return parsed, nil
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return EntityRef{}, fmt.Errorf("invalid entity reference %q: %v: %w", ref, err, ErrBadRequest)
+	}
+	if len(values) != 1 || len(values["externalId"]) > 1 || len(values["name"]) > 1 {
+		return EntityRef{}, fmt.Errorf("invalid entity reference %q, must have an id, an externalId or a name: %w", ref, ErrBadRequest)
+	}
+	parsed.ExternalId = values.Get("externalId")
+	parsed.Name = values.Get("name")
+	switch {
+	case parsed.ExternalId == "" && parsed.Name == "":
+		return EntityRef{}, fmt.Errorf("invalid entity reference %q, must have an id, an externalId or a name: %w", ref, ErrBadRequest)
+	case parsed.Name != "" && !slices.Contains(entityRefNamedTypes, parsed.EntityType):
+		return EntityRef{}, fmt.Errorf("invalid entity reference %q, %s entities are not referenced by name: %w", ref, typeName, ErrBadRequest)
+	}
+	return parsed, nil
+}
+
+// String returns the canonical form of the reference.
+func (r EntityRef) String() string {
+	typeName := entityRefTypes[r.EntityType]
+	switch {
+	case r.Id != "":
+		return typeName + "/" + r.Id
+	case r.ExternalId != "":
+		return typeName + "?externalId=" + url.QueryEscape(r.ExternalId)
+	default:
+		return typeName + "?name=" + url.QueryEscape(r.Name)
+	}
+}
+
+// MarshalText writes the reference as a string in JSON.
+func (r EntityRef) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText parses the reference from a string in JSON.
+func (r *EntityRef) UnmarshalText(text []byte) error {
+	parsed, err := ParseEntityRef(string(text))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// ResolveRequest is the body of the resolve endpoint.
+type ResolveRequest struct {
+	// Refs are the references to resolve, at most MaxResolveRefs.
+	Refs []EntityRef `json:"refs"`
+}
+
+// ResolvedEntity is an entity expanded from its reference.
+type ResolvedEntity struct {
+	// Ref is the resolved reference, as requested.
+	Ref EntityRef `json:"ref"`
+	// EntityType is the type of the entity, one of the EntityType constants.
+	EntityType string `json:"entityType"`
+	Id         string `json:"id"`
+	// Object is the entity as returned by its get endpoint.
+	Object any `json:"object"`
+}
+
+// ResolvedEntityList are the entities of the references of a ResolveRequest, in the order of the references.
+type ResolvedEntityList struct {
+	Items []ResolvedEntity `json:"items"`
+	Size  int32            `json:"size"`
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net/http"
 )
@@ -9,6 +10,10 @@ var (
 	ErrBadRequest = errors.New("bad request")
 	ErrNotFound   = errors.New("not found")
 	ErrConflict   = errors.New("conflict")
+	// ErrPreconditionFailed is returned when an entity was updated since the version its update was based on.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrQueryBudgetExceeded is returned when a request runs more SQL statements or reads more rows than allowed.
+	ErrQueryBudgetExceeded = errors.New("query budget exceeded")
 )
 
 func ErrToStatus(err error) int {
@@ -23,6 +28,18 @@ func ErrToStatus(err error) int {
 		return http.StatusConflict
 	}
 
+	if errors.Is(err, ErrPreconditionFailed) {
+		return http.StatusPreconditionFailed
+	}
+
+	if errors.Is(err, ErrQueryBudgetExceeded) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return http.StatusGatewayTimeout
+	}
+
 	// Default error to return
 	return http.StatusInternalServerError
 }
@@ -0,0 +1,42 @@
+package api
+
+// ExternalIdPolicy defines the scope in which the external ids of the entities must be unique.
+type ExternalIdPolicy string
+
+const (
+	// ExternalIdUniquePerType requires external ids to be unique among the entities stored in the same table,
+	// as enforced by the database: an artifact and a registered model can share an external id.
+	ExternalIdUniquePerType ExternalIdPolicy = "per-type"
+	// ExternalIdUniqueGlobal requires external ids to be unique across all entity types.
+	ExternalIdUniqueGlobal ExternalIdPolicy = "global"
+)
+
+// Entity types of the EntityReference.
+const (
+	EntityTypeRegisteredModel    = "RegisteredModel"
+	EntityTypeModelVersion       = "ModelVersion"
+	EntityTypeArtifact           = "Artifact"
+	EntityTypeServingEnvironment = "ServingEnvironment"
+	EntityTypeInferenceService   = "InferenceService"
+	EntityTypeServeModel         = "ServeModel"
+	EntityTypeExperiment         = "Experiment"
+	EntityTypeExperimentRun      = "ExperimentRun"
+)
+
+// EntityReference identifies an entity of any type.
+type EntityReference struct {
+	// EntityType is the type of the entity, one of the EntityType constants.
+	EntityType string `json:"entityType"`
+	// ArtifactType is the type of the artifact (e.g. "model-artifact"), only set for artifacts.
+	ArtifactType string `json:"artifactType,omitempty"`
+	// Id is the ID of the entity, unique per EntityType.
+	Id string `json:"id"`
+	// Ref is the canonical reference to the entity.
+	Ref EntityRef `json:"ref"`
+}
+
+// EntityReferenceList is a list of references to entities of any type.
+type EntityReferenceList struct {
+	Items []EntityReference `json:"items"`
+	Size  int32             `json:"size"`
+}
@@ -0,0 +1,53 @@
+package api
+
+// Kinds of the nodes of a Lineage, the MLMD kinds of the entities.
+const (
+	LineageContext   = "CONTEXT"
+	LineageArtifact  = "ARTIFACT"
+	LineageExecution = "EXECUTION"
+)
+
+// DefaultLineageDepth reaches the datasets, metrics and parameters of the experiment runs which logged the artifacts
+// of the versions of a registered model, MaxLineageDepth bounds the depth of a Lineage.
+const (
+	DefaultLineageDepth = 4
+	MaxLineageDepth     = 10
+)
+
+// LineageNode is an entity of a Lineage.
+type LineageNode struct {
+	// Id of the entity, unique among the entities of its kind.
+	Id string `json:"id"`
+	// Kind of the entity: CONTEXT, ARTIFACT or EXECUTION.
+	Kind string `json:"kind"`
+	// Type of the entity, e.g. kf.ModelVersion, kf.ExperimentRun or kf.DataSet.
+	Type string `json:"type"`
+	// Name of the entity.
+	Name string `json:"name,omitempty"`
+	// Ref is the canonical reference to the entity, unset for the types without get endpoint, e.g. metric history.
+	Ref *EntityRef `json:"ref,omitempty"`
+	// Depth is the number of links between the entity and the root of the lineage.
+	Depth int32 `json:"depth"`
+}
+
+// LineageEdge links a context to a child context, an artifact attributed to it or an execution associated to it.
+type LineageEdge struct {
+	// ContextId is the ID of the context.
+	ContextId string `json:"contextId"`
+	// Kind of the linked entity: CONTEXT, ARTIFACT or EXECUTION.
+	Kind string `json:"kind"`
+	// Id of the linked entity.
+	Id string `json:"id"`
+}
+
+// Lineage is the graph of the entities related to a registered model or a model version: the versions and their
+// artifacts, the experiment runs which logged them, their experiments and the other artifacts logged by the runs,
+// e.g. datasets.
+type Lineage struct {
+	// RootId is the ID of the registered model or model version.
+	RootId string `json:"rootId"`
+	// Depth is the maximum number of links walked from the root.
+	Depth int32         `json:"depth"`
+	Nodes []LineageNode `json:"nodes"`
+	Edges []LineageEdge `json:"edges"`
+}
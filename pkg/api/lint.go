@@ -0,0 +1,35 @@
+package api
+
+// Lint rules reported in the warnings of the writes.
+const (
+	LintRuleMaxModelVersions    = "max-model-versions"
+	LintRuleMaxCustomProperties = "max-custom-properties"
+	LintRuleDeprecatedURIScheme = "deprecated-uri-scheme"
+	// LintRuleCustomPropertyLimits reports the entities at the custom property limits of a type truncating writes.
+	LintRuleCustomPropertyLimits = "custom-property-limits"
+)
+
+// LintRules configures the soft limits checked by the writes, which are applied anyway and report the limits they
+// break as warnings. A zero limit disables its rule.
+type LintRules struct {
+	// MaxModelVersions is the number of versions of a registered model from which archiving the old ones is suggested.
+	MaxModelVersions int32
+	// MaxCustomProperties is the number of custom properties of an entity above which it is reported.
+	MaxCustomProperties int
+	// DeprecatedURISchemes are the schemes of artifact uris being phased out, e.g. "http".
+	DeprecatedURISchemes []string
+}
+
+// DefaultLintRules are the lint rules of the registry unless configured otherwise.
+var DefaultLintRules = LintRules{
+	MaxModelVersions:    500,
+	MaxCustomProperties: 100,
+}
+
+// Warning is actionable feedback on a write that succeeded.
+type Warning struct {
+	// Rule is the lint rule that reported the warning, one of the LintRule constants.
+	Rule string `json:"rule"`
+	// Message describes the issue and how to address it.
+	Message string `json:"message"`
+}
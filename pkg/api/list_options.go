@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+//go:generate go run ../../internal/db/filter/listoptionsgen -o list_options.gen.go
+
+// listOptions is the builder of the ListOptions shared by the entity builders of list_options.gen.go, B is the
+// builder of the entity its methods return for chaining. The filters of the builder are combined with AND.
+type listOptions[B any] struct {
+	self    *B
+	options ListOptions
+	filters []string
+}
+
+// WithPageSize sets the maximum number of entities per page.
+func (l *listOptions[B]) WithPageSize(pageSize int32) *B {
+	l.options.PageSize = &pageSize
+	return l.self
+}
+
+// WithNextPageToken sets the token of the page to list, returned with the previous page.
+func (l *listOptions[B]) WithNextPageToken(nextPageToken string) *B {
+	l.options.NextPageToken = &nextPageToken
+	return l.self
+}
+
+// WithFilter restricts the entities to the ones matching the filter query.
+func (l *listOptions[B]) WithFilter(filterQuery string) *B {
+	l.filters = append(l.filters, filterQuery)
+	return l.self
+}
+
+// WithQuery restricts the entities to the ones matching the free-text search.
+func (l *listOptions[B]) WithQuery(query string) *B {
+	l.options.Query = &query
+	return l.self
+}
+
+// CreatedSince restricts the entities to the ones created after t.
+func (l *listOptions[B]) CreatedSince(t time.Time) *B {
+	return l.where("createTimeSinceEpoch", ">", strconv.FormatInt(t.UnixMilli(), 10))
+}
+
+// UpdatedSince restricts the entities to the ones updated after t.
+func (l *listOptions[B]) UpdatedSince(t time.Time) *B {
+	return l.where("lastUpdateTimeSinceEpoch", ">", strconv.FormatInt(t.UnixMilli(), 10))
+}
+
+// OrderByCreateTime orders the entities by creation time.
+func (l *listOptions[B]) OrderByCreateTime(order openapi.SortOrder) *B {
+	return l.orderBy(openapi.ORDERBYFIELD_CREATE_TIME, order)
+}
+
+// OrderByLastUpdateTime orders the entities by last update time.
+func (l *listOptions[B]) OrderByLastUpdateTime(order openapi.SortOrder) *B {
+	return l.orderBy(openapi.ORDERBYFIELD_LAST_UPDATE_TIME, order)
+}
+
+// OrderById orders the entities by id.
+func (l *listOptions[B]) OrderById(order openapi.SortOrder) *B {
+	return l.orderBy(openapi.ORDERBYFIELD_ID, order)
+}
+
+// Build returns the ListOptions, the builder can be reused to build others.
+func (l *listOptions[B]) Build() ListOptions {
+	options := l.options
+	options.Tags = append([]string(nil), l.options.Tags...)
+	switch len(l.filters) {
+	case 0:
+	case 1:
+		filterQuery := l.filters[0]
+		options.FilterQuery = &filterQuery
+	default:
+		filterQuery := "(" + strings.Join(l.filters, ") AND (") + ")"
+		options.FilterQuery = &filterQuery
+	}
+	return options
+}
+
+func (l *listOptions[B]) orderBy(field openapi.OrderByField, order openapi.SortOrder) *B {
+	orderBy, sortOrder := string(field), string(order)
+	l.options.OrderBy, l.options.SortOrder = &orderBy, &sortOrder
+	return l.self
+}
+
+func (l *listOptions[B]) where(property string, operator string, value string) *B {
+	return l.WithFilter(fmt.Sprintf("%s %s %s", property, operator, value))
+}
+
+func (l *listOptions[B]) withState(state string) *B {
+	l.options.State = &state
+	return l.self
+}
+
+func (l *listOptions[B]) withTags(tags []string) *B {
+	l.options.Tags = append(l.options.Tags, tags...)
+	return l.self
+}
+
+// quoteFilterString returns the string literal of a filter query with the value.
+func quoteFilterString(value string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
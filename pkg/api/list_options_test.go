@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOptionsBuilder(t *testing.T) {
+	builder := NewModelVersionListOptions().
+		WithName("it's").
+		WithRegisteredModelId(12).
+		WithState(ListStateArchived).
+		WithTags("prod", "gpu").
+		WithPageSize(10).
+		OrderByCreateTime(openapi.SORTORDER_DESC)
+
+	options := builder.Build()
+	require.NotNil(t, options.FilterQuery)
+	assert.Equal(t, `(name = 'it\'s') AND (registeredModelId = 12)`, *options.FilterQuery)
+	assert.Equal(t, ListStateArchived, *options.State)
+	assert.Equal(t, []string{"prod", "gpu"}, options.Tags)
+	assert.Equal(t, int32(10), *options.PageSize)
+	assert.Equal(t, "CREATE_TIME", *options.OrderBy)
+	assert.Equal(t, "DESC", *options.SortOrder)
+	assert.Nil(t, options.NextPageToken)
+
+	// the built options are not changed by the next calls of the builder
+	next := builder.WithNextPageToken("token").WithCostCurrency("EUR").Build()
+	assert.Equal(t, "token", *next.NextPageToken)
+	assert.Equal(t, `(name = 'it\'s') AND (registeredModelId = 12) AND (costCurrency = 'EUR')`, *next.FilterQuery)
+	assert.Nil(t, options.NextPageToken)
+	assert.Equal(t, `(name = 'it\'s') AND (registeredModelId = 12)`, *options.FilterQuery)
+}
+
+func TestListOptionsBuilderFilters(t *testing.T) {
+	assert.Equal(t, ListOptions{}, NewRegisteredModelListOptions().Build())
+
+	options := NewMetricListOptions().WithValue(0.5).Build()
+	assert.Equal(t, "value = 0.5", *options.FilterQuery)
+
+	options = NewDataSetListOptions().WithSource(`C:\data`).OrderById(openapi.SORTORDER_ASC).Build()
+	assert.Equal(t, `source = 'C:\\data'`, *options.FilterQuery)
+	assert.Equal(t, "ID", *options.OrderBy)
+
+	since := time.UnixMilli(1700000000000)
+	options = NewExperimentRunListOptions().UpdatedSince(since).WithFilter("owner = 'a' OR owner = 'b'").Build()
+	assert.Equal(t, "(lastUpdateTimeSinceEpoch > 1700000000000) AND (owner = 'a' OR owner = 'b')", *options.FilterQuery)
+}
@@ -0,0 +1,33 @@
+package api
+
+// Aggregations of GetExperimentRunsMetricAggregate.
+const (
+	MetricAggregationMax = "max"
+	MetricAggregationMin = "min"
+	MetricAggregationAvg = "avg"
+)
+
+// RunMetricAggregate is the aggregation of the metric history of a metric of an experiment run.
+type RunMetricAggregate struct {
+	// ExperimentRunId is the id of the experiment run.
+	ExperimentRunId string `json:"experimentRunId"`
+	// Value is the aggregation of the values of the metric history of the run.
+	Value float64 `json:"value"`
+	// Count is the number of values of the metric history of the run.
+	Count int32 `json:"count"`
+}
+
+// RunMetricAggregateList is the aggregation of the metric history of a metric of the runs of an experiment, computed
+// by the database instead of the clients paging through the metric history of every run.
+type RunMetricAggregateList struct {
+	// Metric is the name of the aggregated metric.
+	Metric string `json:"metric"`
+	// Aggregation is one of max, min or avg.
+	Aggregation string `json:"aggregation"`
+	// Value is the aggregation of the values of the metric history of all the runs, unset if none has history of
+	// the metric.
+	Value *float64 `json:"value,omitempty"`
+	// Items are the aggregations of each run with history of the metric, ordered by run id.
+	Items []RunMetricAggregate `json:"items"`
+	Size  int32                `json:"size"`
+}
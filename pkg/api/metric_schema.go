@@ -0,0 +1,64 @@
+package api
+
+// MetricDirection tells which values of a metric are the best.
+type MetricDirection string
+
+const (
+	MetricHigherIsBetter MetricDirection = "HIGHER_IS_BETTER"
+	MetricLowerIsBetter  MetricDirection = "LOWER_IS_BETTER"
+)
+
+// MetricValueType is the type of the values logged for a metric.
+type MetricValueType string
+
+const (
+	// MetricValueDouble metrics have any number as values, the default.
+	MetricValueDouble MetricValueType = "DOUBLE"
+	// MetricValueInt metrics have integers as values, e.g. counts.
+	MetricValueInt MetricValueType = "INT"
+)
+
+// MetricSpec declares a metric logged by the runs of an experiment.
+type MetricSpec struct {
+	// Name uniquely identifies the metric in the schema.
+	Name string `json:"name"`
+	// Type of the values of the metric, MetricValueDouble by default.
+	Type MetricValueType `json:"type,omitempty"`
+	// Direction tells which runs are the best for the metric.
+	Direction MetricDirection `json:"direction"`
+	// Description of the metric.
+	Description string `json:"description,omitempty"`
+}
+
+// ExperimentMetricSchema declares the metrics logged by the runs of an experiment. Once an experiment declares
+// metrics, the runs can only log those, with values of their type, so that a misspelled metric is rejected instead of
+// being recorded as another metric.
+type ExperimentMetricSchema struct {
+	// ExperimentId is the ID of the Experiment the schema is attached to. Output only.
+	ExperimentId string `json:"experimentId,omitempty"`
+	// Metrics declared, the runs of an experiment without metrics can log any metric.
+	Metrics []MetricSpec `json:"metrics"`
+}
+
+// RankedRunMetric is the best value of a metric logged by an experiment run, and its rank among the runs.
+type RankedRunMetric struct {
+	// Rank of the run, from 1 for the best run.
+	Rank int32 `json:"rank"`
+	// ExperimentRunId is the id of the experiment run.
+	ExperimentRunId string `json:"experimentRunId"`
+	// Value is the best value of the metric history of the run.
+	Value float64 `json:"value"`
+	// Count is the number of values of the metric history of the run.
+	Count int32 `json:"count"`
+}
+
+// RunLeaderboard ranks the runs of an experiment by the best value of a metric of its schema, in its direction.
+type RunLeaderboard struct {
+	// Metric is the name of the ranking metric.
+	Metric string `json:"metric"`
+	// Direction of the metric in the schema of the experiment.
+	Direction MetricDirection `json:"direction"`
+	// Items are the runs with history of the metric, from the best, the ties ordered by run id.
+	Items []RankedRunMetric `json:"items"`
+	Size  int32             `json:"size"`
+}
@@ -0,0 +1,52 @@
+package api
+
+// MetricsTableColumnType is the type of the values of a column of a metrics table.
+type MetricsTableColumnType string
+
+const (
+	// MetricsTableDouble columns have DoubleValues.
+	MetricsTableDouble MetricsTableColumnType = "DOUBLE"
+	// MetricsTableString columns have StringValues.
+	MetricsTableString MetricsTableColumnType = "STRING"
+)
+
+// MetricsTableColumn is a column of a metrics table, with a value per row.
+type MetricsTableColumn struct {
+	// Name uniquely identifies the column in its table.
+	Name string `json:"name"`
+	// Type of the values of the column.
+	Type MetricsTableColumnType `json:"type"`
+	// DoubleValues are the values of a DOUBLE column.
+	DoubleValues []float64 `json:"doubleValues,omitempty"`
+	// StringValues are the values of a STRING column.
+	StringValues []string `json:"stringValues,omitempty"`
+}
+
+// MetricsTable is a tabular evaluation output of a model version, e.g. per-class metrics or slice analyses, stored
+// in a compact columnar encoding and retrieved with a selection of its columns.
+type MetricsTable struct {
+	// Id of the table. Output only.
+	Id string `json:"id,omitempty"`
+	// Name uniquely identifies the table among those of the model version.
+	Name string `json:"name"`
+	// Description of the table.
+	Description string `json:"description,omitempty"`
+	// ModelVersionId is the ID of the evaluated model version. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// Columns of the table, with the same number of values each. The columns of the tables listed have no values.
+	Columns []MetricsTableColumn `json:"columns"`
+	// RowCount is the number of rows of the table. Output only.
+	RowCount int32 `json:"rowCount"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// MetricsTableList is a page of metrics tables.
+type MetricsTableList struct {
+	Items         []MetricsTable `json:"items"`
+	NextPageToken string         `json:"nextPageToken"`
+	PageSize      int32          `json:"pageSize"`
+	Size          int32          `json:"size"`
+}
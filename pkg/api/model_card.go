@@ -0,0 +1,32 @@
+package api
+
+// ModelCardTrainingData summarizes the data a model was trained on.
+type ModelCardTrainingData struct {
+	// Summary of the training data, e.g. its sources, size and preprocessing.
+	Summary string `json:"summary"`
+	// Datasets are the names or URIs of the datasets of the training data.
+	Datasets []string `json:"datasets,omitempty"`
+}
+
+// ModelCard documents the intended use, limitations and ethical considerations of a registered model, with a
+// structure validated on write rather than free-form custom properties. A registered model has at most one card.
+type ModelCard struct {
+	// Id of the card. Output only.
+	Id string `json:"id,omitempty"`
+	// RegisteredModelId is the ID of the documented registered model. Output only.
+	RegisteredModelId string `json:"registeredModelId,omitempty"`
+	// IntendedUse describes the primary uses and users the model is meant for.
+	IntendedUse string `json:"intendedUse"`
+	// OutOfScopeUses are the uses the model is not meant for.
+	OutOfScopeUses []string `json:"outOfScopeUses,omitempty"`
+	// Limitations are the known limitations of the model, e.g. the inputs it performs poorly on.
+	Limitations []string `json:"limitations,omitempty"`
+	// EthicalConsiderations are the risks and mitigations to consider when using the model.
+	EthicalConsiderations []string `json:"ethicalConsiderations,omitempty"`
+	// TrainingData summarizes the data the model was trained on.
+	TrainingData *ModelCardTrainingData `json:"trainingData,omitempty"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
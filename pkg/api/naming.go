@@ -0,0 +1,56 @@
+package api
+
+import "fmt"
+
+// Rules of the naming policies.
+const (
+	NamingRulePattern        = "pattern"
+	NamingRuleReservedPrefix = "reserved-prefix"
+	NamingRuleMaxLength      = "max-length"
+)
+
+// NameCase is the case the names of a naming policy are normalized to.
+type NameCase string
+
+const (
+	// NameCasePreserve keeps names as they are.
+	NameCasePreserve NameCase = ""
+	NameCaseLower    NameCase = "lower"
+	NameCaseUpper    NameCase = "upper"
+)
+
+// NamingPolicy constrains the names of the new entities of a type, names are normalized to Case before they are
+// checked against the rules.
+type NamingPolicy struct {
+	// Pattern is a regular expression the whole name must match.
+	Pattern string   `json:"pattern,omitempty"`
+	Case    NameCase `json:"case,omitempty"`
+	// ReservedPrefixes are prefixes names cannot start with, e.g. the ones of system entities.
+	ReservedPrefixes []string `json:"reservedPrefixes,omitempty"`
+	// MaxLength is the maximum number of characters of a name, 0 is unlimited.
+	MaxLength int `json:"maxLength,omitempty"`
+}
+
+// NamingViolation is the error of the creation of an entity with a name breaking the naming policy of its type.
+type NamingViolation struct {
+	// EntityType is one of the EntityType constants.
+	EntityType string `json:"entityType"`
+	Name       string `json:"name"`
+	// Rule is the NamingRule constant of the broken rule.
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+	// Suggestion is a similar name following the policy, if one is found.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+func (v *NamingViolation) Error() string {
+	message := fmt.Sprintf("%s name %q breaks the naming policy: %s", v.EntityType, v.Name, v.Reason)
+	if v.Suggestion != "" {
+		message += fmt.Sprintf(", try %q", v.Suggestion)
+	}
+	return message
+}
+
+func (v *NamingViolation) Unwrap() error {
+	return ErrBadRequest
+}
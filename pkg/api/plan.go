@@ -0,0 +1,75 @@
+package api
+
+// PlanAction is the change the import of a snapshot or a promotion run would make to an entity.
+type PlanAction string
+
+const (
+	// PlanActionCreate entities do not exist yet and would be created.
+	PlanActionCreate PlanAction = "CREATE"
+	// PlanActionUpdate entities exist and would be updated, with the changes of the item.
+	PlanActionUpdate PlanAction = "UPDATE"
+	// PlanActionConflict entities clash with an existing entity, see the message.
+	PlanActionConflict PlanAction = "CONFLICT"
+	// PlanActionSkip entities would be left as they are, see the message.
+	PlanActionSkip PlanAction = "SKIP"
+)
+
+// PlanFieldChange is the change of a field of an entity updated by a plan, the custom properties are named
+// "customProperties.<name>".
+type PlanFieldChange struct {
+	Field string `json:"field"`
+	// From is the current value of the field, empty if it is not set.
+	From string `json:"from,omitempty"`
+	// To is the value of the field once the plan is applied, empty if it is only known then, e.g. the id of an
+	// entity to create.
+	To string `json:"to,omitempty"`
+}
+
+// PlanItem is the change a plan would make to an entity.
+type PlanItem struct {
+	Action PlanAction `json:"action"`
+	// EntityType is the type of the entity, one of the EntityType constants.
+	EntityType string `json:"entityType"`
+	// Name of the entity.
+	Name string `json:"name"`
+	// ParentName is the name of the registered model of a model version, or of the model version of an artifact.
+	ParentName string `json:"parentName,omitempty"`
+	// SourceId is the id of the entity in the snapshot or the source registry of the promotion.
+	SourceId string `json:"sourceId,omitempty"`
+	// Id is the id of the existing entity updated, conflicting or skipped.
+	Id string `json:"id,omitempty"`
+	// Changes are the changes of the fields of the updated entities.
+	Changes []PlanFieldChange `json:"changes,omitempty"`
+	// Message explains the conflicts and the skips.
+	Message string `json:"message,omitempty"`
+}
+
+// Plan is the machine-readable diff returned by the dry runs of the imports of snapshots and of the promotion runs,
+// the changes they would make without making any. Applying a plan with conflicts fails.
+type Plan struct {
+	Items     []PlanItem `json:"items"`
+	Creates   int32      `json:"creates"`
+	Updates   int32      `json:"updates"`
+	Conflicts int32      `json:"conflicts"`
+	Skips     int32      `json:"skips"`
+}
+
+// NewPlan returns an empty plan.
+func NewPlan() *Plan {
+	return &Plan{Items: []PlanItem{}}
+}
+
+// Add appends item to the plan and counts its action.
+func (p *Plan) Add(item PlanItem) {
+	p.Items = append(p.Items, item)
+	switch item.Action {
+	case PlanActionCreate:
+		p.Creates++
+	case PlanActionUpdate:
+		p.Updates++
+	case PlanActionConflict:
+		p.Conflicts++
+	case PlanActionSkip:
+		p.Skips++
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+// GuardrailConfig describes a guardrail that a serving gateway must enforce in front of a model version.
+type GuardrailConfig struct {
+	// Name uniquely identifies the guardrail within a policy.
+	Name string `json:"name"`
+	// Provider is the guardrail implementation, e.g. "trustyai" or "nemo-guardrails".
+	Provider string `json:"provider,omitempty"`
+	// Config holds provider specific settings passed through to the gateway as-is.
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// EvaluationRequirement describes an evaluation suite a model version is required to pass.
+type EvaluationRequirement struct {
+	// Suite is the name of the evaluation suite, unique within a policy.
+	Suite string `json:"suite"`
+	// MinScore is the optional minimum score required to pass the suite.
+	MinScore *float64 `json:"minScore,omitempty"`
+}
+
+// ModelVersionPolicy groups the guardrails and required evaluations attached to a model version.
+type ModelVersionPolicy struct {
+	// ModelVersionId is the ID of the ModelVersion the policy is attached to. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// Guardrails to enforce at serving time.
+	Guardrails []GuardrailConfig `json:"guardrails"`
+	// RequiredEvaluations the model version must pass.
+	RequiredEvaluations []EvaluationRequirement `json:"requiredEvaluations"`
+}
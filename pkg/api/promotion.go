@@ -0,0 +1,127 @@
+package api
+
+// PromotionRunState is the state of a promotion run.
+type PromotionRunState string
+
+const (
+	// PromotionRunPendingApproval runs wait for a reviewer to approve or reject them before copying anything.
+	PromotionRunPendingApproval PromotionRunState = "PENDING_APPROVAL"
+	// PromotionRunRunning runs are copying the matching model versions to the target registry.
+	PromotionRunRunning PromotionRunState = "RUNNING"
+	// PromotionRunSucceeded runs promoted or skipped all the matching model versions.
+	PromotionRunSucceeded PromotionRunState = "SUCCEEDED"
+	// PromotionRunFailed runs could not promote at least one of the matching model versions.
+	PromotionRunFailed PromotionRunState = "FAILED"
+	// PromotionRunRejected runs were rejected by a reviewer and did not copy anything.
+	PromotionRunRejected PromotionRunState = "REJECTED"
+)
+
+// PromotionResult is the outcome of the promotion of a single model version.
+type PromotionResult string
+
+const (
+	// PromotionResultPromoted model versions were copied to the target registry by the run.
+	PromotionResultPromoted PromotionResult = "PROMOTED"
+	// PromotionResultSkipped model versions had already been promoted to the target registry.
+	PromotionResultSkipped PromotionResult = "SKIPPED"
+	// PromotionResultFailed model versions could not be copied, see the message.
+	PromotionResultFailed PromotionResult = "FAILED"
+)
+
+// PromotionRegistry identifies a model registry taking part in a promotion.
+type PromotionRegistry struct {
+	// Name labels the registry in the provenance recorded on the promoted model versions, e.g. "dev" or "prod". The
+	// names of the remote registries configured on the server select them, the other names are this registry.
+	Name string `json:"name"`
+}
+
+// PromotionSource selects the model versions copied by a promotion.
+type PromotionSource struct {
+	// Registry the model versions are read from.
+	Registry PromotionRegistry `json:"registry"`
+	// RegisteredModelName restricts the promotion to the versions of a registered model.
+	RegisteredModelName string `json:"registeredModelName,omitempty"`
+	// FilterQuery restricts the promotion to the model versions matching the filter, e.g. "state='LIVE'".
+	FilterQuery string `json:"filterQuery,omitempty"`
+}
+
+// Promotion copies the model versions matching a filter from a source registry to a target registry.
+type Promotion struct {
+	// Id of the promotion. Output only.
+	Id string `json:"id,omitempty"`
+	// Name uniquely identifies the promotion.
+	Name string `json:"name"`
+	// Description of the promotion.
+	Description string `json:"description,omitempty"`
+	// Source selects the model versions to promote.
+	Source PromotionSource `json:"source"`
+	// Target is the registry the model versions are copied to, it must be a remote registry.
+	Target PromotionRegistry `json:"target"`
+	// RequireApproval holds the runs until a reviewer approves them.
+	RequireApproval bool `json:"requireApproval"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// PromotionList is a page of promotions.
+type PromotionList struct {
+	Items         []Promotion `json:"items"`
+	NextPageToken string      `json:"nextPageToken"`
+	PageSize      int32       `json:"pageSize"`
+	Size          int32       `json:"size"`
+}
+
+// PromotedModelVersion is the outcome of the promotion of a model version by a run.
+type PromotedModelVersion struct {
+	// RegisteredModelName is the name of the registered model of the version, in both registries.
+	RegisteredModelName string `json:"registeredModelName"`
+	// VersionName is the name of the model version, in both registries.
+	VersionName string `json:"versionName"`
+	// SourceModelVersionId is the ID of the model version in the source registry.
+	SourceModelVersionId string `json:"sourceModelVersionId"`
+	// TargetModelVersionId is the ID of the model version in the target registry, unset if it was not copied.
+	TargetModelVersionId string `json:"targetModelVersionId,omitempty"`
+	// Result of the promotion of the model version.
+	Result PromotionResult `json:"result"`
+	// Message explains skipped and failed promotions.
+	Message string `json:"message,omitempty"`
+}
+
+// PromotionRun is an execution of a promotion, copying the model versions matching the promotion source
+// at the time the run is approved.
+type PromotionRun struct {
+	// Id of the run. Output only.
+	Id string `json:"id"`
+	// PromotionId is the ID of the promotion the run executes.
+	PromotionId string `json:"promotionId"`
+	// State of the run.
+	State PromotionRunState `json:"state"`
+	// Reviewer who approved or rejected the run.
+	Reviewer string `json:"reviewer,omitempty"`
+	// ReviewComment left by the reviewer.
+	ReviewComment string `json:"reviewComment,omitempty"`
+	// Message explains failed runs.
+	Message string `json:"message,omitempty"`
+	// ModelVersions is the outcome of the promotion of each matching model version, once the run completed.
+	ModelVersions []PromotedModelVersion `json:"modelVersions"`
+	// CreateTimeSinceEpoch is the creation time in milliseconds since epoch.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time in milliseconds since epoch.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// PromotionRunList is a page of promotion runs.
+type PromotionRunList struct {
+	Items         []PromotionRun `json:"items"`
+	NextPageToken string         `json:"nextPageToken"`
+	PageSize      int32          `json:"pageSize"`
+	Size          int32          `json:"size"`
+}
+
+// PromotionReview approves or rejects a promotion run pending approval, the reviewer is the authenticated caller.
+type PromotionReview struct {
+	// Comment of the reviewer.
+	Comment string `json:"comment,omitempty"`
+}
@@ -0,0 +1,31 @@
+package api
+
+import "github.com/kubeflow/model-registry/pkg/openapi"
+
+// MaxPropertyValuesIds is the maximum number of entities which property can be retrieved at once.
+const MaxPropertyValuesIds = 1000
+
+// Entity types of GetCustomPropertyValues.
+const (
+	PropertyEntityTypeRegisteredModel = "registered_model"
+	PropertyEntityTypeModelVersion    = "model_version"
+	PropertyEntityTypeModelArtifact   = "model_artifact"
+	PropertyEntityTypeExperiment      = "experiment"
+	PropertyEntityTypeExperimentRun   = "experiment_run"
+)
+
+// PropertyValue is the value of a custom property of an entity.
+type PropertyValue struct {
+	// Id is the id of the entity.
+	Id    string                `json:"id"`
+	Value openapi.MetadataValue `json:"value"`
+}
+
+// PropertyValueList is the value of one custom property of many entities.
+type PropertyValueList struct {
+	EntityType string `json:"entityType"`
+	Key        string `json:"key"`
+	// Items are in the order of the requested ids, the entities without the property are skipped.
+	Items []PropertyValue `json:"items"`
+	Size  int32           `json:"size"`
+}
@@ -0,0 +1,25 @@
+package api
+
+// ArtifactReachability is the outcome of the last verification of the uri of a model artifact.
+type ArtifactReachability struct {
+	// ArtifactId is the ID of the ModelArtifact.
+	ArtifactId string `json:"artifactId"`
+	// Name of the ModelArtifact.
+	Name string `json:"name,omitempty"`
+	// Uri is the verified uri.
+	Uri string `json:"uri,omitempty"`
+	// Reachable is unset until the uri was verified.
+	Reachable *bool `json:"reachable,omitempty"`
+	// SizeBytes is the size of the blob at the uri, unset if it is unknown.
+	SizeBytes string `json:"sizeBytes,omitempty"`
+	// LastVerifiedTimeSinceEpoch is the time of the last verification in milliseconds since epoch.
+	LastVerifiedTimeSinceEpoch string `json:"lastVerifiedTimeSinceEpoch,omitempty"`
+	// Message explains why the uri is not reachable.
+	Message string `json:"message,omitempty"`
+}
+
+// ArtifactReachabilityList lists the verifications of model artifacts.
+type ArtifactReachabilityList struct {
+	Items []ArtifactReachability `json:"items"`
+	Size  int32                  `json:"size"`
+}
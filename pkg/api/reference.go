@@ -0,0 +1,42 @@
+package api
+
+// Kinds of the entities referencing an artifact
+const (
+	ArtifactReferenceModelVersion     = "ModelVersion"
+	ArtifactReferenceExperimentRun    = "ExperimentRun"
+	ArtifactReferenceInferenceService = "InferenceService"
+)
+
+// ArtifactReference is an entity keeping the blob of an artifact in use.
+type ArtifactReference struct {
+	// Kind of the referencing entity, ModelVersion, ExperimentRun or InferenceService.
+	Kind string `json:"kind"`
+	// Id of the referencing entity.
+	Id string `json:"id"`
+	// Ref is the canonical reference to the referencing entity.
+	Ref EntityRef `json:"ref"`
+	// Name of the referencing entity.
+	Name string `json:"name,omitempty"`
+	// ArtifactId is the ID of the ModelArtifact with the same uri through which the entity references the blob.
+	ArtifactId string `json:"artifactId"`
+	// State of the referencing entity.
+	State string `json:"state,omitempty"`
+	// Active is false for archived versions and runs and for undeployed inference services.
+	Active bool `json:"active"`
+}
+
+// ArtifactReferences are the entities referencing the blob at the uri of a model artifact.
+type ArtifactReferences struct {
+	// ArtifactId is the ID of the ModelArtifact.
+	ArtifactId string `json:"artifactId"`
+	// Uri of the ModelArtifact.
+	Uri string `json:"uri,omitempty"`
+	// ArtifactIds are the IDs of all the ModelArtifacts registered with the uri, including ArtifactId.
+	ArtifactIds []string `json:"artifactIds"`
+	// References lists the model versions, experiment runs and inference services using the uri.
+	References []ArtifactReference `json:"references"`
+	// ActiveReferences is the number of active references.
+	ActiveReferences int32 `json:"activeReferences"`
+	// Deletable is true when no active entity references the uri.
+	Deletable bool `json:"deletable"`
+}
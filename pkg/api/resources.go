@@ -0,0 +1,20 @@
+package api
+
+// ResourceFootprint describes the serving resource requirements and estimated cost of a model version.
+// All fields are optional, unset fields are left untouched on update.
+type ResourceFootprint struct {
+	// ModelVersionId is the ID of the ModelVersion the footprint belongs to. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// CpuRequest is the CPU required to serve the model, as a Kubernetes quantity (e.g. "500m", "2").
+	CpuRequest *string `json:"cpuRequest,omitempty"`
+	// MemoryRequest is the memory required to serve the model, as a Kubernetes quantity (e.g. "16Gi").
+	MemoryRequest *string `json:"memoryRequest,omitempty"`
+	// AcceleratorType is the extended resource name of the accelerator (e.g. "nvidia.com/gpu").
+	AcceleratorType *string `json:"acceleratorType,omitempty"`
+	// AcceleratorCount is the number of accelerators required to serve the model.
+	AcceleratorCount *int32 `json:"acceleratorCount,omitempty"`
+	// CostPer1kInferences is the estimated cost of serving one thousand inference requests.
+	CostPer1kInferences *float64 `json:"costPer1kInferences,omitempty"`
+	// CostCurrency is the ISO 4217 currency code of CostPer1kInferences (e.g. "USD").
+	CostCurrency *string `json:"costCurrency,omitempty"`
+}
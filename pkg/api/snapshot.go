@@ -0,0 +1,52 @@
+package api
+
+import "github.com/kubeflow/model-registry/pkg/openapi"
+
+// SnapshotVersion is the version of the format of the snapshots exported, the snapshots of other versions are not
+// imported.
+const SnapshotVersion = 1
+
+// Kinds of the records of a snapshot.
+const (
+	// SnapshotRecordHeader is the first record of a snapshot, with its version.
+	SnapshotRecordHeader          = "Header"
+	SnapshotRecordRegisteredModel = "RegisteredModel"
+	SnapshotRecordModelVersion    = "ModelVersion"
+	SnapshotRecordArtifact        = "Artifact"
+)
+
+// SnapshotExportRequest is the body of the export endpoint.
+type SnapshotExportRequest struct {
+	// RegisteredModelIds are the ids of the registered models exported with all their model versions and artifacts.
+	RegisteredModelIds []string `json:"registeredModelIds"`
+}
+
+// SnapshotRecord is a record of a snapshot, a line of its JSON-lines archive. The entities keep the ids of the
+// registry they were exported from, the model versions follow their registered model and the artifacts their model
+// version.
+type SnapshotRecord struct {
+	// Kind is one of the SnapshotRecord constants, telling which field of the record is set.
+	Kind string `json:"kind"`
+	// Version of the snapshot format, set in the header.
+	Version int `json:"version,omitempty"`
+	// ExportTimeSinceEpoch is the time of the export in milliseconds since epoch, set in the header.
+	ExportTimeSinceEpoch string                   `json:"exportTimeSinceEpoch,omitempty"`
+	RegisteredModel      *openapi.RegisteredModel `json:"registeredModel,omitempty"`
+	ModelVersion         *openapi.ModelVersion    `json:"modelVersion,omitempty"`
+	// ModelVersionId is the id of the model version of an artifact, set with the artifact.
+	ModelVersionId string            `json:"modelVersionId,omitempty"`
+	Artifact       *openapi.Artifact `json:"artifact,omitempty"`
+}
+
+// SnapshotIdMapping maps the id of an entity in the registry of a snapshot to the id of the entity imported.
+type SnapshotIdMapping struct {
+	SourceId string `json:"sourceId"`
+	Id       string `json:"id"`
+}
+
+// SnapshotImport is the result of the import of a snapshot, the ids of the entities imported.
+type SnapshotImport struct {
+	RegisteredModels []SnapshotIdMapping `json:"registeredModels"`
+	ModelVersions    []SnapshotIdMapping `json:"modelVersions"`
+	Artifacts        []SnapshotIdMapping `json:"artifacts"`
+}
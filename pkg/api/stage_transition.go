@@ -0,0 +1,48 @@
+package api
+
+// ModelVersionStage is a stage of the lifecycle of a model version, held by its stage custom property.
+type ModelVersionStage string
+
+const (
+	// StageNone model versions have no stage custom property, or a stage outside of the lifecycle.
+	StageNone       ModelVersionStage = "None"
+	StageStaging    ModelVersionStage = "Staging"
+	StageProduction ModelVersionStage = "Production"
+	StageArchived   ModelVersionStage = "Archived"
+)
+
+// StageTransitions are the stages each stage of the lifecycle can move to.
+var StageTransitions = map[ModelVersionStage][]ModelVersionStage{
+	StageNone:       {StageStaging, StageArchived},
+	StageStaging:    {StageProduction, StageNone, StageArchived},
+	StageProduction: {StageStaging, StageArchived},
+	StageArchived:   {StageNone, StageStaging},
+}
+
+// StageTransition moves a model version from its stage to another, allowed by StageTransitions. Stage transitions
+// are the stage history of the model versions.
+type StageTransition struct {
+	// Id of the transition. Output only.
+	Id string `json:"id,omitempty"`
+	// ModelVersionId is the ID of the model version. Output only.
+	ModelVersionId string `json:"modelVersionId,omitempty"`
+	// FromStage is the stage of the model version before the transition. Output only.
+	FromStage ModelVersionStage `json:"fromStage,omitempty"`
+	// ToStage is the stage the model version is moved to.
+	ToStage ModelVersionStage `json:"toStage"`
+	// Comment explains the transition.
+	Comment string `json:"comment,omitempty"`
+	// ArchiveExisting moves the other Production versions of the registered model to Archived when moving the
+	// model version to Production, recording their transitions. Input only.
+	ArchiveExisting bool `json:"archiveExisting,omitempty"`
+	// CreateTimeSinceEpoch is the time of the transition in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+}
+
+// StageTransitionList is a page of stage transitions.
+type StageTransitionList struct {
+	Items         []StageTransition `json:"items"`
+	NextPageToken string            `json:"nextPageToken"`
+	PageSize      int32             `json:"pageSize"`
+	Size          int32             `json:"size"`
+}
@@ -0,0 +1,47 @@
+package api
+
+// MaxTagNameLength is the maximum length of the name of a tag.
+const MaxTagNameLength = 255
+
+// Entity types of GetEntityTags and SetEntityTags, the contexts tags can be attached to.
+const (
+	TagEntityTypeRegisteredModel    = "registered_model"
+	TagEntityTypeModelVersion       = "model_version"
+	TagEntityTypeExperiment         = "experiment"
+	TagEntityTypeExperimentRun      = "experiment_run"
+	TagEntityTypeInferenceService   = "inference_service"
+	TagEntityTypeServingEnvironment = "serving_environment"
+)
+
+// Tag is a key-only label of registered models, model versions, experiments, experiment runs, inference services
+// and serving environments. Unlike custom properties tags have no value, they are shared by the entities carrying
+// them, and the entities are listed by their tags with the tags list option.
+type Tag struct {
+	// Id of the tag. Output only.
+	Id string `json:"id,omitempty"`
+	// Name of the tag, letters, digits and the characters '-', '_', '.' and ':'.
+	Name string `json:"name"`
+	// Color of the tag in the UI, e.g. #ff0000.
+	Color *string `json:"color,omitempty"`
+	// Description of the tag.
+	Description *string `json:"description,omitempty"`
+	// Count is the number of entities with the tag. Output only.
+	Count int32 `json:"count"`
+	// CreateTimeSinceEpoch is the creation time of the tag in milliseconds since epoch. Output only.
+	CreateTimeSinceEpoch string `json:"createTimeSinceEpoch,omitempty"`
+	// LastUpdateTimeSinceEpoch is the last update time of the tag in milliseconds since epoch. Output only.
+	LastUpdateTimeSinceEpoch string `json:"lastUpdateTimeSinceEpoch,omitempty"`
+}
+
+// TagList is a page of tags.
+type TagList struct {
+	Items         []Tag  `json:"items"`
+	NextPageToken string `json:"nextPageToken"`
+	PageSize      int32  `json:"pageSize"`
+	Size          int32  `json:"size"`
+}
+
+// EntityTags are the names of the tags of an entity, sorted.
+type EntityTags struct {
+	Tags []string `json:"tags"`
+}
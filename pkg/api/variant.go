@@ -0,0 +1,25 @@
+package api
+
+// ArtifactVariant describes the build of a model artifact, so that a model version can own one artifact per
+// platform, precision or engine. Unset fields match any value on resolution.
+// All fields are optional, unset fields are left untouched on update and empty strings clear the field.
+type ArtifactVariant struct {
+	// ArtifactId is the ID of the ModelArtifact the variant belongs to. Output only.
+	ArtifactId string `json:"artifactId,omitempty"`
+	// Architecture is the CPU architecture the artifact is built for, using GOARCH names (e.g. "amd64", "arm64").
+	Architecture *string `json:"architecture,omitempty"`
+	// Accelerator is the accelerator the artifact is built for (e.g. "a100", "h100", "inferentia2").
+	Accelerator *string `json:"accelerator,omitempty"`
+	// Precision is the numeric precision of the weights (e.g. "fp32", "fp16", "bf16", "int8").
+	Precision *string `json:"precision,omitempty"`
+	// Engine is the serving engine format of the artifact (e.g. "tensorrt", "onnx", "openvino").
+	Engine *string `json:"engine,omitempty"`
+}
+
+// ArtifactVariantQuery selects the artifact variant of a model version to serve, empty fields match any variant.
+type ArtifactVariantQuery struct {
+	Architecture string
+	Accelerator  string
+	Precision    string
+	Engine       string
+}
@@ -0,0 +1,48 @@
+package api
+
+// Types of the changes of a watch.
+const (
+	ChangeAdded    = "ADDED"
+	ChangeModified = "MODIFIED"
+	// ChangeBookmark only carries the resource version to resume a streamed watch from.
+	ChangeBookmark = "BOOKMARK"
+)
+
+// MaxChanges is the maximum number of changes returned at once.
+const MaxChanges = 500
+
+// WatchEntityTypes are the entity types that can be watched.
+var WatchEntityTypes = []string{
+	EntityTypeRegisteredModel,
+	EntityTypeModelVersion,
+	EntityTypeArtifact,
+	EntityTypeServingEnvironment,
+	EntityTypeInferenceService,
+	EntityTypeServeModel,
+	EntityTypeExperiment,
+	EntityTypeExperimentRun,
+}
+
+// Change is the creation or the update of an entity.
+type Change struct {
+	// Type is ChangeAdded, ChangeModified or ChangeBookmark.
+	Type string `json:"type"`
+	// EntityType is one of the WatchEntityTypes.
+	EntityType string `json:"entityType,omitempty"`
+	Id         string `json:"id,omitempty"`
+	// Ref is the canonical reference to the entity, unset for bookmarks.
+	Ref *EntityRef `json:"ref,omitempty"`
+	// ResourceVersion is the last update time of the entity in milliseconds since epoch, or the resource version of a
+	// bookmark.
+	ResourceVersion string `json:"resourceVersion"`
+	// Object is the entity as returned by its get endpoint.
+	Object any `json:"object,omitempty"`
+}
+
+// ChangeList is a list of changes ordered by resource version.
+type ChangeList struct {
+	Items []Change `json:"items"`
+	Size  int32    `json:"size"`
+	// ResourceVersion is the resource version to get the next changes from.
+	ResourceVersion string `json:"resourceVersion"`
+}
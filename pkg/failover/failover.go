@@ -0,0 +1,282 @@
+// Package failover spreads the requests of the model registry clients over several registries, for the highly
+// available consumers like serving gateways: a primary registry receiving the writes, and its read replicas or
+// federated peers serving the reads too. The reads go to the nearest healthy registry, the one answering its health
+// checks the fastest, and fail over to the next ones when a registry can't be reached or is unavailable. The writes
+// always go to the primary registry and are never retried, as they are not idempotent.
+//
+// The Client is an http.RoundTripper, the clients of the REST api send their requests through it:
+//
+//	registries, err := failover.NewClient(failover.Config{Primary: "http://model-registry:8080", Replicas: []string{"http://model-registry-replica:8080"}})
+//	go registries.Run(ctx)
+//	client := registries.APIClient()
+package failover
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+const (
+	// HealthPath is the path of the health endpoint of the registries.
+	HealthPath = "/readyz/health"
+
+	// DefaultHealthInterval is how often the registries are checked.
+	DefaultHealthInterval = 10 * time.Second
+	// DefaultHealthTimeout bounds the health checks, a registry not answering in time is unhealthy.
+	DefaultHealthTimeout = 2 * time.Second
+)
+
+// Config configures a Client.
+type Config struct {
+	// Primary is the url of the registry receiving the writes, e.g. http://model-registry:8080.
+	Primary string
+	// Replicas are the urls of the registries serving the reads next to the primary registry.
+	Replicas []string
+	// HealthInterval is how often the registries are checked, DefaultHealthInterval if 0.
+	HealthInterval time.Duration
+	// HealthTimeout bounds the health checks, DefaultHealthTimeout if 0.
+	HealthTimeout time.Duration
+	// Transport sends the requests to the registries, http.DefaultTransport if nil.
+	Transport http.RoundTripper
+}
+
+// Endpoint is the state of a registry, as of its last health check or request.
+type Endpoint struct {
+	URL     string
+	Primary bool
+	Healthy bool
+	// Latency is the duration of the last successful health check, zero before the first one.
+	Latency time.Duration
+	// LastError is why the registry is unhealthy.
+	LastError string
+}
+
+// Client routes the requests to the registries, it is safe for concurrent use.
+type Client struct {
+	config Config
+	// endpoints are the registries in the order of the config, the primary first
+	endpoints []*endpoint
+}
+
+// endpoint is a registry, healthy until a health check or a request fails and again once a health check succeeds.
+type endpoint struct {
+	url     *url.URL
+	primary bool
+
+	mu        sync.Mutex
+	healthy   bool
+	latency   time.Duration
+	lastError string
+}
+
+// NewClient returns the Client of the registries of config, the primary registry is required.
+func NewClient(config Config) (*Client, error) {
+	if config.Primary == "" {
+		return nil, errors.New("a primary registry is required")
+	}
+	if config.HealthInterval == 0 {
+		config.HealthInterval = DefaultHealthInterval
+	}
+	if config.HealthTimeout == 0 {
+		config.HealthTimeout = DefaultHealthTimeout
+	}
+	if config.Transport == nil {
+		config.Transport = http.DefaultTransport
+	}
+
+	c := &Client{config: config}
+	for i, raw := range append([]string{config.Primary}, config.Replicas...) {
+		u, err := url.Parse(strings.TrimSuffix(raw, "/"))
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("unsupported url %q, the scheme must be http or https", raw)
+		}
+		c.endpoints = append(c.endpoints, &endpoint{url: u, primary: i == 0, healthy: true})
+	}
+
+	return c, nil
+}
+
+// HTTPClient returns an http.Client sending its requests through c.
+func (c *Client) HTTPClient() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// APIClient returns a client of the REST api sending its requests through c.
+func (c *Client) APIClient() *openapi.APIClient {
+	cfg := openapi.NewConfiguration()
+	cfg.Servers = openapi.ServerConfigurations{{URL: c.endpoints[0].url.String()}}
+	cfg.HTTPClient = c.HTTPClient()
+	return openapi.NewAPIClient(cfg)
+}
+
+// RoundTrip sends a read to the nearest healthy registry, failing over to the others, and a write to the primary
+// registry. The scheme, host and base path of the url of req are replaced by the ones of the registry, the requests
+// are expected to target the primary registry as the clients of HTTPClient and APIClient do.
+func (c *Client) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRead(req) {
+		return c.send(c.endpoints[0], req)
+	}
+
+	candidates := c.readEndpoints()
+	for _, e := range candidates[:len(candidates)-1] {
+		resp, err := c.send(e, req)
+		if req.Context().Err() != nil || (err == nil && !isUnavailable(resp.StatusCode)) {
+			return resp, err
+		}
+		if err == nil {
+			resp.Body.Close()
+			e.setUnhealthy(fmt.Errorf("responded %s", resp.Status))
+		}
+	}
+	return c.send(candidates[len(candidates)-1], req)
+}
+
+// Run checks the registries every HealthInterval until ctx is done, returning its error.
+func (c *Client) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		c.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check checks the health of the registries concurrently, measuring their latency.
+func (c *Client) Check(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, e := range c.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.check(ctx, e)
+		}()
+	}
+	wg.Wait()
+}
+
+// Endpoints returns the state of the registries, the primary first.
+func (c *Client) Endpoints() []Endpoint {
+	endpoints := make([]Endpoint, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		e.mu.Lock()
+		endpoints = append(endpoints, Endpoint{
+			URL:       e.url.String(),
+			Primary:   e.primary,
+			Healthy:   e.healthy,
+			Latency:   e.latency,
+			LastError: e.lastError,
+		})
+		e.mu.Unlock()
+	}
+	return endpoints
+}
+
+func (c *Client) check(ctx context.Context, e *endpoint) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.HealthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.url.JoinPath(HealthPath).String(), nil)
+	if err != nil {
+		e.setUnhealthy(err)
+		return
+	}
+
+	start := time.Now()
+	resp, err := c.config.Transport.RoundTrip(req)
+	if err != nil {
+		e.setUnhealthy(err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		e.setUnhealthy(fmt.Errorf("health check responded %s", resp.Status))
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy, e.latency, e.lastError = true, time.Since(start), ""
+}
+
+// readEndpoints returns the registries in the order the reads try them: the healthy ones by latency, then the
+// unhealthy ones as a last resort, the primary first between equals.
+func (c *Client) readEndpoints() []*endpoint {
+	type candidate struct {
+		endpoint *endpoint
+		healthy  bool
+		latency  time.Duration
+	}
+	candidates := make([]candidate, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		e.mu.Lock()
+		candidates = append(candidates, candidate{endpoint: e, healthy: e.healthy, latency: e.latency})
+		e.mu.Unlock()
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		if a.healthy != b.healthy {
+			if a.healthy {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(a.latency, b.latency)
+	})
+
+	endpoints := make([]*endpoint, 0, len(candidates))
+	for _, candidate := range candidates {
+		endpoints = append(endpoints, candidate.endpoint)
+	}
+	return endpoints
+}
+
+// send sends a copy of req to the registry, marking it unhealthy when it can't be reached.
+func (c *Client) send(e *endpoint, req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	target := *e.url
+	target.Path = e.url.Path + strings.TrimPrefix(req.URL.Path, c.endpoints[0].url.Path)
+	target.RawPath = ""
+	target.RawQuery = req.URL.RawQuery
+	out.URL, out.Host = &target, target.Host
+
+	resp, err := c.config.Transport.RoundTrip(out)
+	if err != nil && req.Context().Err() == nil {
+		e.setUnhealthy(err)
+	}
+	return resp, err
+}
+
+func (e *endpoint) setUnhealthy(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthy, e.lastError = false, err.Error()
+}
+
+// isRead reports whether the request reads, the reads are idempotent and can be sent to any registry.
+func isRead(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || req.Method == http.MethodOptions
+}
+
+// isUnavailable reports whether a response status is the failure of the registry rather than of the request.
+func isUnavailable(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
@@ -0,0 +1,178 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry answers the health checks after a delay and the api requests with its name, or with the status of
+// unavailable when set.
+type fakeRegistry struct {
+	name  string
+	delay time.Duration
+
+	mu          sync.Mutex
+	unavailable int
+	requests    []string
+}
+
+func newFakeRegistry(t *testing.T, name string, delay time.Duration) (*fakeRegistry, *httptest.Server) {
+	f := &fakeRegistry{name: name, delay: delay}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == HealthPath {
+		time.Sleep(f.delay)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+	if f.unavailable != 0 {
+		w.WriteHeader(f.unavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"id": "1", "name": %q}`, f.name)
+}
+
+func (f *fakeRegistry) setUnavailable(status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.unavailable = status
+}
+
+func (f *fakeRegistry) served() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// name returns the registry which served a request of the client.
+func name(t *testing.T, client *http.Client, method string, url string) string {
+	req, err := http.NewRequest(method, url, strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body := map[string]string{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body["name"]
+}
+
+func TestClientRoutesReadsToNearestRegistry(t *testing.T) {
+	_, primary := newFakeRegistry(t, "primary", 50*time.Millisecond)
+	_, near := newFakeRegistry(t, "near", 0)
+	_, far := newFakeRegistry(t, "far", 20*time.Millisecond)
+
+	registries, err := NewClient(Config{Primary: primary.URL, Replicas: []string{far.URL, near.URL}})
+	require.NoError(t, err)
+	client := registries.HTTPClient()
+	modelsURL := primary.URL + "/api/model_registry/v1alpha3/registered_models/1?x=1"
+
+	// the reads go to the primary registry until the latency of the registries is known
+	assert.Equal(t, "primary", name(t, client, http.MethodGet, modelsURL))
+
+	registries.Check(context.Background())
+	for _, endpoint := range registries.Endpoints() {
+		assert.True(t, endpoint.Healthy, endpoint.URL)
+		assert.NotZero(t, endpoint.Latency, endpoint.URL)
+	}
+	assert.Equal(t, "near", name(t, client, http.MethodGet, modelsURL))
+	assert.Equal(t, "primary", name(t, client, http.MethodPatch, modelsURL), "the writes go to the primary registry")
+	assert.Equal(t, "primary", name(t, client, http.MethodPost, modelsURL))
+
+	near.Close()
+	registries.Check(context.Background())
+	assert.Equal(t, "far", name(t, client, http.MethodGet, modelsURL))
+	assert.False(t, registries.Endpoints()[2].Healthy)
+	assert.NotEmpty(t, registries.Endpoints()[2].LastError)
+}
+
+func TestClientFailsOverReads(t *testing.T) {
+	primaryRegistry, primary := newFakeRegistry(t, "primary", 20*time.Millisecond)
+	replicaRegistry, replica := newFakeRegistry(t, "replica", 0)
+
+	registries, err := NewClient(Config{Primary: primary.URL, Replicas: []string{replica.URL}})
+	require.NoError(t, err)
+	registries.Check(context.Background())
+	client := registries.HTTPClient()
+	modelsURL := primary.URL + "/api/model_registry/v1alpha3/registered_models?pageSize=1"
+
+	// an unavailable registry is skipped until its next successful health check
+	replicaRegistry.setUnavailable(http.StatusServiceUnavailable)
+	assert.Equal(t, "primary", name(t, client, http.MethodGet, modelsURL))
+	assert.Equal(t, []string{"GET /api/model_registry/v1alpha3/registered_models?pageSize=1"}, replicaRegistry.served())
+	assert.Equal(t, "primary", name(t, client, http.MethodGet, modelsURL))
+	assert.Len(t, replicaRegistry.served(), 1)
+
+	replicaRegistry.setUnavailable(0)
+	registries.Check(context.Background())
+	assert.Equal(t, "replica", name(t, client, http.MethodGet, modelsURL))
+
+	// the client errors are not failed over, nor the writes
+	replicaRegistry.setUnavailable(http.StatusNotFound)
+	resp, err := client.Get(modelsURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	primaryRegistry.setUnavailable(http.StatusServiceUnavailable)
+	resp, err = client.Post(modelsURL, "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Len(t, replicaRegistry.served(), 3)
+
+	// the last registry answers the reads when all of them are unavailable
+	replicaRegistry.setUnavailable(http.StatusBadGateway)
+	resp, err = client.Get(modelsURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestAPIClient(t *testing.T) {
+	_, primary := newFakeRegistry(t, "primary", 0)
+	replicaRegistry, replica := newFakeRegistry(t, "replica", 0)
+
+	registries, err := NewClient(Config{Primary: primary.URL + "/", Replicas: []string{replica.URL + "/registry"}})
+	require.NoError(t, err)
+	// the primary registry is unreachable, the api reads fail over to the replica
+	primary.Close()
+
+	model, _, err := registries.APIClient().ModelRegistryServiceAPI.GetRegisteredModel(context.Background(), "1").Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "replica", model.Name)
+	assert.Equal(t, []string{"GET /registry/api/model_registry/v1alpha3/registered_models/1"}, replicaRegistry.served())
+	assert.False(t, registries.Endpoints()[0].Healthy)
+}
+
+func TestNewClientValidatesConfig(t *testing.T) {
+	_, err := NewClient(Config{})
+	assert.ErrorContains(t, err, "primary registry is required")
+
+	_, err = NewClient(Config{Primary: "http://model-registry:8080", Replicas: []string{"model-registry-replica"}})
+	assert.ErrorContains(t, err, "the scheme must be http or https")
+
+	registries, err := NewClient(Config{Primary: "http://model-registry:8080"})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultHealthInterval, registries.config.HealthInterval)
+	assert.Equal(t, []Endpoint{{URL: "http://model-registry:8080", Primary: true, Healthy: true}}, registries.Endpoints())
+}
@@ -1,17 +1,25 @@
 package inferenceservicecontroller
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	neturl "net/url"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kubeflow/model-registry/pkg/api"
 	"github.com/kubeflow/model-registry/pkg/openapi"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -20,6 +28,7 @@ import (
 type InferenceServiceController struct {
 	client                        client.Client
 	httpClient                    *http.Client
+	recorder                      record.EventRecorder
 	log                           logr.Logger
 	bearerToken                   string
 	inferenceServiceIDLabel       string
@@ -77,6 +86,11 @@ func (r *InferenceServiceController) OverrideHTTPClient(client *http.Client) {
 	r.httpClient = client
 }
 
+// SetEventRecorder sets the recorder used to emit Kubernetes events on reconciled InferenceServices
+func (r *InferenceServiceController) SetEventRecorder(recorder record.EventRecorder) {
+	r.recorder = recorder
+}
+
 // Reconcile performs the reconciliation of the model registry based on Kubeflow InferenceService CRs
 func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	mrNamespace := r.defaultModelRegistryNamespace
@@ -128,7 +142,7 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 	mrApi, err := r.initModelRegistryService(ctx, log, mrName, mrNamespace, mrUrl)
 	if err != nil {
 		log.Error(err, "Unable to initialize Model Registry service")
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.recordSyncFailure(ctx, log, isvc, ReasonRegistryUnreachable, err)
 	}
 
 	// Check if the InferenceService instance is marked to be deleted, which is
@@ -157,7 +171,7 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 	// Retrieve or create the ServingEnvironment associated to the current namespace
 	servingEnvironment, err := r.getOrCreateServingEnvironment(mrApiCtx, log, mrApi, req.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err
+		return ctrl.Result{}, r.recordSyncFailure(ctx, log, isvc, syncFailureReason(err), err)
 	}
 
 	if okMrIsvcId {
@@ -165,7 +179,8 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 		log.Info("Retrieving model registry InferenceService by id", "mrIsvcId", mrIsvcId)
 		mrIs, _, err = mrApi.ModelRegistryServiceAPI.GetInferenceService(mrApiCtx, mrIsvcId).Execute()
 		if err != nil {
-			return ctrl.Result{}, fmt.Errorf("unable to find InferenceService with id %s in model registry: %w", mrIsvcId, err)
+			err = fmt.Errorf("unable to find InferenceService with id %s in model registry: %w", mrIsvcId, err)
+			return ctrl.Result{}, r.recordSyncFailure(ctx, log, isvc, syncFailureReason(err), err)
 		}
 
 		mrCurrentIvcUrl := mrIs.CustomProperties["url"].MetadataStringValue.GetStringValue()
@@ -180,7 +195,7 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 				mrIs,
 			)
 			if err != nil {
-				return ctrl.Result{}, err
+				return ctrl.Result{}, r.recordSyncFailure(ctx, log, isvc, syncFailureReason(err), err)
 			}
 		}
 
@@ -188,7 +203,14 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 		// No corresponding InferenceService in model registry, create new one
 		mrIs, err = r.createMRInferenceService(mrApiCtx, log, mrApi, isvc, *servingEnvironment.Id, registeredModelId, modelVersionId)
 		if err != nil {
-			return ctrl.Result{}, err
+			return ctrl.Result{}, r.recordSyncFailure(ctx, log, isvc, syncFailureReason(err), err)
+		}
+
+		if modelVersionId != "" {
+			// The resource footprint is informational only, do not fail the reconciliation on errors
+			if err := r.updateMRModelVersionResources(mrApiCtx, log, mrApi, isvc, modelVersionId); err != nil {
+				log.Error(err, "Unable to update the resource footprint of the model version", "modelVersionId", modelVersionId)
+			}
 		}
 	}
 
@@ -216,6 +238,8 @@ func (r *InferenceServiceController) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, nil
 	}
 
+	r.recordSyncSuccess(ctx, log, isvc, *mrIs.Id)
+
 	// No need to update the ISVC, the IS id is already set
 	if isvc.Labels[r.inferenceServiceIDLabel] == *mrIs.Id {
 		return ctrl.Result{}, nil
@@ -360,6 +384,16 @@ func (r *InferenceServiceController) createMRInferenceService(
 	is, _, err := mr.ModelRegistryServiceAPI.FindInferenceService(ctx).
 		Name(isName).ParentResourceId(servingEnvironmentId).Execute()
 	if err != nil {
+		if modelVersionIdPtr != nil {
+			_, resp, err := mr.ModelRegistryServiceAPI.GetModelVersion(ctx, modelVersionId).Execute()
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return nil, fmt.Errorf("%w: no model version with id %s in model registry", errModelVersionNotFound, modelVersionId)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		log.Info("Creating new model registry InferenceService", "name", isName, "registeredModelId", registeredModelId, "modelVersionId", modelVersionId)
 
 		isCreate := openapi.InferenceServiceCreate{
@@ -415,6 +449,57 @@ func (r *InferenceServiceController) updateMRInferenceService(
 	return err
 }
 
+// updateMRModelVersionResources records the resource requests of the ISVC predictor on the served model registry ModelVersion
+func (r *InferenceServiceController) updateMRModelVersionResources(
+	ctx context.Context,
+	log logr.Logger,
+	mr *openapi.APIClient,
+	isvc *kservev1beta1.InferenceService,
+	modelVersionId string,
+) error {
+	footprint := resourceFootprintFromISVC(isvc)
+	if footprint == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(footprint)
+	if err != nil {
+		return err
+	}
+
+	servers := mr.GetConfig().Servers
+	if len(servers) == 0 {
+		return fmt.Errorf("missing model registry server url")
+	}
+
+	url := fmt.Sprintf("%s/api/model_registry/v1alpha3/model_versions/%s/resources", strings.TrimSuffix(servers[0].URL, "/"), neturl.PathEscape(modelVersionId))
+
+	log.Info("Updating model registry ModelVersion resource footprint", "modelVersionId", modelVersionId)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if token, ok := ctx.Value(openapi.ContextAccessToken).(string); ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d updating resource footprint of model version %s", resp.StatusCode, modelVersionId)
+	}
+
+	return nil
+}
+
 func (r *InferenceServiceController) getOrCreateServingEnvironment(ctx context.Context, log logr.Logger, mr *openapi.APIClient, namespace string) (*openapi.ServingEnvironment, error) {
 	servingEnvironment, _, err := mr.ModelRegistryServiceAPI.FindServingEnvironment(ctx).Name(namespace).Execute()
 	if err != nil {
@@ -453,3 +538,55 @@ func IgnoreDeletingErrors(err error) error {
 	}
 	return err
 }
+
+// resourceFootprintFromISVC extracts the resource requests of the predictor model container,
+// falling back to the limits when no request is set. It returns nil if no resources are defined.
+func resourceFootprintFromISVC(isvc *kservev1beta1.InferenceService) *api.ResourceFootprint {
+	if isvc.Spec.Predictor.Model == nil {
+		return nil
+	}
+
+	resources := isvc.Spec.Predictor.Model.Resources
+	quantity := func(name corev1.ResourceName) (resource.Quantity, bool) {
+		if q, ok := resources.Requests[name]; ok {
+			return q, true
+		}
+		q, ok := resources.Limits[name]
+		return q, ok
+	}
+
+	footprint := &api.ResourceFootprint{}
+	found := false
+
+	if q, ok := quantity(corev1.ResourceCPU); ok {
+		footprint.CpuRequest = openapi.PtrString(q.String())
+		found = true
+	}
+
+	if q, ok := quantity(corev1.ResourceMemory); ok {
+		footprint.MemoryRequest = openapi.PtrString(q.String())
+		found = true
+	}
+
+	// Accelerators are exposed as extended resources (e.g. nvidia.com/gpu), which must be set as limits
+	names := make([]string, 0, len(resources.Limits))
+	for name := range resources.Limits {
+		if strings.Contains(string(name), "/") && !strings.HasPrefix(string(name), corev1.ResourceHugePagesPrefix) {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) > 0 {
+		q := resources.Limits[corev1.ResourceName(names[0])]
+		footprint.AcceleratorType = openapi.PtrString(names[0])
+		footprint.AcceleratorCount = openapi.PtrInt32(int32(q.Value()))
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	return footprint
+}
@@ -0,0 +1,96 @@
+package inferenceservicecontroller
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/go-logr/logr"
+	kservev1beta1 "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ModelRegistrySynced is the ISVC status condition reporting whether the ISVC is in sync with the model registry
+const ModelRegistrySynced apis.ConditionType = "ModelRegistrySynced"
+
+// Reasons used for both the ModelRegistrySynced condition and the emitted events
+const (
+	ReasonSyncSucceeded        = "SyncSucceeded"
+	ReasonSyncFailed           = "SyncFailed"
+	ReasonRegistryUnreachable  = "RegistryUnreachable"
+	ReasonModelVersionNotFound = "ModelVersionNotFound"
+)
+
+// errModelVersionNotFound is returned when the ISVC references a model version missing from the model registry
+var errModelVersionNotFound = errors.New("model version not found")
+
+// syncFailureReason classifies a model registry client error, transport errors mean the registry is unreachable
+func syncFailureReason(err error) string {
+	if errors.Is(err, errModelVersionNotFound) {
+		return ReasonModelVersionNotFound
+	}
+
+	var openapiErr *openapi.GenericOpenAPIError
+	if errors.As(err, &openapiErr) {
+		return ReasonSyncFailed
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ReasonRegistryUnreachable
+	}
+
+	return ReasonSyncFailed
+}
+
+// recordSyncFailure emits a warning event and marks the ModelRegistrySynced condition as false, returning err unchanged
+func (r *InferenceServiceController) recordSyncFailure(ctx context.Context, log logr.Logger, isvc *kservev1beta1.InferenceService, reason string, err error) error {
+	if r.recorder != nil {
+		r.recorder.Event(isvc, corev1.EventTypeWarning, reason, err.Error())
+	}
+
+	r.setSyncedCondition(ctx, log, isvc, &apis.Condition{
+		Type:    ModelRegistrySynced,
+		Status:  corev1.ConditionFalse,
+		Reason:  reason,
+		Message: err.Error(),
+	})
+
+	return err
+}
+
+// recordSyncSuccess marks the ModelRegistrySynced condition as true, emitting an event only on transitions
+func (r *InferenceServiceController) recordSyncSuccess(ctx context.Context, log logr.Logger, isvc *kservev1beta1.InferenceService, mrIsvcId string) {
+	if isvc.Status.IsConditionReady(ModelRegistrySynced) {
+		return
+	}
+
+	if r.recorder != nil {
+		r.recorder.Eventf(isvc, corev1.EventTypeNormal, ReasonSyncSucceeded, "InferenceService synced with model registry InferenceService %s", mrIsvcId)
+	}
+
+	r.setSyncedCondition(ctx, log, isvc, &apis.Condition{
+		Type:   ModelRegistrySynced,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonSyncSucceeded,
+	})
+}
+
+// setSyncedCondition patches the ISVC status, failures are only logged as the condition is informational
+func (r *InferenceServiceController) setSyncedCondition(ctx context.Context, log logr.Logger, isvc *kservev1beta1.InferenceService, condition *apis.Condition) {
+	current := isvc.Status.GetCondition(ModelRegistrySynced)
+	if current != nil && current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message {
+		return
+	}
+
+	patch := client.MergeFrom(isvc.DeepCopy())
+
+	isvc.Status.SetCondition(ModelRegistrySynced, condition)
+
+	if err := r.client.Status().Patch(ctx, isvc, patch); err != nil {
+		log.Error(err, "Unable to update the InferenceService status condition", "condition", ModelRegistrySynced)
+	}
+}
@@ -150,6 +150,7 @@ var _ = BeforeSuite(func() {
 	}
 
 	inferenceServiceController.OverrideHTTPClient(mrMockServer.Client())
+	inferenceServiceController.SetEventRecorder(mgr.GetEventRecorderFor("model-registry-inferenceservice-controller"))
 
 	err = inferenceServiceController.SetupWithManager(mgr)
 	Expect(err).ToNot(HaveOccurred())
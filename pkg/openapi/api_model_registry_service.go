@@ -17,6 +17,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // ModelRegistryServiceAPIService ModelRegistryServiceAPI service
@@ -3667,6 +3668,7 @@ type ApiGetArtifactsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	artifactType  *ArtifactTypeQueryParam
 	pageSize      *string
 	orderBy       *OrderByField
@@ -3680,6 +3682,12 @@ func (r ApiGetArtifactsRequest) FilterQuery(filterQuery string) ApiGetArtifactsR
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetArtifactsRequest) Q(q string) ApiGetArtifactsRequest {
+	r.q = &q
+	return r
+}
+
 // Specifies the artifact type for listing artifacts.
 func (r ApiGetArtifactsRequest) ArtifactType(artifactType ArtifactTypeQueryParam) ApiGetArtifactsRequest {
 	r.artifactType = &artifactType
@@ -3754,6 +3762,9 @@ func (a *ModelRegistryServiceAPIService) GetArtifactsExecute(r ApiGetArtifactsRe
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.artifactType != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "artifactType", r.artifactType, "form", "")
 	}
@@ -3882,6 +3893,7 @@ type ApiGetEnvironmentInferenceServicesRequest struct {
 	ApiService           *ModelRegistryServiceAPIService
 	servingenvironmentId string
 	filterQuery          *string
+	q                    *string
 	name                 *string
 	externalId           *string
 	pageSize             *string
@@ -3896,6 +3908,12 @@ func (r ApiGetEnvironmentInferenceServicesRequest) FilterQuery(filterQuery strin
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetEnvironmentInferenceServicesRequest) Q(q string) ApiGetEnvironmentInferenceServicesRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetEnvironmentInferenceServicesRequest) Name(name string) ApiGetEnvironmentInferenceServicesRequest {
 	r.name = &name
@@ -3979,6 +3997,9 @@ func (a *ModelRegistryServiceAPIService) GetEnvironmentInferenceServicesExecute(
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -4248,6 +4269,7 @@ type ApiGetExperimentExperimentRunsRequest struct {
 	name          *string
 	externalId    *string
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
@@ -4272,6 +4294,12 @@ func (r ApiGetExperimentExperimentRunsRequest) FilterQuery(filterQuery string) A
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentExperimentRunsRequest) Q(q string) ApiGetExperimentExperimentRunsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetExperimentExperimentRunsRequest) PageSize(pageSize string) ApiGetExperimentExperimentRunsRequest {
 	r.pageSize = &pageSize
@@ -4349,6 +4377,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentExperimentRunsExecute(r Ap
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -4610,6 +4641,7 @@ type ApiGetExperimentRunArtifactsRequest struct {
 	ApiService      *ModelRegistryServiceAPIService
 	experimentrunId string
 	filterQuery     *string
+	q               *string
 	name            *string
 	externalId      *string
 	artifactType    *ArtifactTypeQueryParam
@@ -4617,6 +4649,7 @@ type ApiGetExperimentRunArtifactsRequest struct {
 	orderBy         *OrderByField
 	sortOrder       *SortOrder
 	nextPageToken   *string
+	role            *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -4625,6 +4658,12 @@ func (r ApiGetExperimentRunArtifactsRequest) FilterQuery(filterQuery string) Api
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentRunArtifactsRequest) Q(q string) ApiGetExperimentRunArtifactsRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetExperimentRunArtifactsRequest) Name(name string) ApiGetExperimentRunArtifactsRequest {
 	r.name = &name
@@ -4667,6 +4706,12 @@ func (r ApiGetExperimentRunArtifactsRequest) NextPageToken(nextPageToken string)
 	return r
 }
 
+// Restricts the list to the artifacts of comma separated roles: &#x60;model&#x60;, &#x60;data&#x60;, &#x60;metrics&#x60;, &#x60;parameters&#x60; or &#x60;doc&#x60;.
+func (r ApiGetExperimentRunArtifactsRequest) Role(role string) ApiGetExperimentRunArtifactsRequest {
+	r.role = &role
+	return r
+}
+
 func (r ApiGetExperimentRunArtifactsRequest) Execute() (*ArtifactList, *http.Response, error) {
 	return r.ApiService.GetExperimentRunArtifactsExecute(r)
 }
@@ -4712,6 +4757,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunArtifactsExecute(r ApiG
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -4733,6 +4781,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunArtifactsExecute(r ApiG
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.role != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "role", r.role, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -4835,6 +4886,7 @@ type ApiGetExperimentRunMetricHistoryRequest struct {
 	ApiService      *ModelRegistryServiceAPIService
 	experimentrunId string
 	filterQuery     *string
+	q               *string
 	name            *string
 	stepIds         *string
 	pageSize        *string
@@ -4849,6 +4901,12 @@ func (r ApiGetExperimentRunMetricHistoryRequest) FilterQuery(filterQuery string)
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentRunMetricHistoryRequest) Q(q string) ApiGetExperimentRunMetricHistoryRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetExperimentRunMetricHistoryRequest) Name(name string) ApiGetExperimentRunMetricHistoryRequest {
 	r.name = &name
@@ -4932,6 +4990,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunMetricHistoryExecute(r
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -5051,10 +5112,12 @@ type ApiGetExperimentRunsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -5063,6 +5126,12 @@ func (r ApiGetExperimentRunsRequest) FilterQuery(filterQuery string) ApiGetExper
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentRunsRequest) Q(q string) ApiGetExperimentRunsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetExperimentRunsRequest) PageSize(pageSize string) ApiGetExperimentRunsRequest {
 	r.pageSize = &pageSize
@@ -5087,6 +5156,12 @@ func (r ApiGetExperimentRunsRequest) NextPageToken(nextPageToken string) ApiGetE
 	return r
 }
 
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetExperimentRunsRequest) Tags(tags string) ApiGetExperimentRunsRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetExperimentRunsRequest) Execute() (*ExperimentRunList, *http.Response, error) {
 	return r.ApiService.GetExperimentRunsExecute(r)
 }
@@ -5131,6 +5206,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunsExecute(r ApiGetExperi
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -5143,6 +5221,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunsExecute(r ApiGetExperi
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -5233,6 +5314,7 @@ type ApiGetExperimentRunsMetricHistoryRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	name          *string
 	stepIds       *string
 	pageSize      *string
@@ -5247,6 +5329,12 @@ func (r ApiGetExperimentRunsMetricHistoryRequest) FilterQuery(filterQuery string
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentRunsMetricHistoryRequest) Q(q string) ApiGetExperimentRunsMetricHistoryRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetExperimentRunsMetricHistoryRequest) Name(name string) ApiGetExperimentRunsMetricHistoryRequest {
 	r.name = &name
@@ -5327,6 +5415,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentRunsMetricHistoryExecute(r
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -5446,10 +5537,12 @@ type ApiGetExperimentsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -5458,6 +5551,12 @@ func (r ApiGetExperimentsRequest) FilterQuery(filterQuery string) ApiGetExperime
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetExperimentsRequest) Q(q string) ApiGetExperimentsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetExperimentsRequest) PageSize(pageSize string) ApiGetExperimentsRequest {
 	r.pageSize = &pageSize
@@ -5482,6 +5581,12 @@ func (r ApiGetExperimentsRequest) NextPageToken(nextPageToken string) ApiGetExpe
 	return r
 }
 
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetExperimentsRequest) Tags(tags string) ApiGetExperimentsRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetExperimentsRequest) Execute() (*ExperimentList, *http.Response, error) {
 	return r.ApiService.GetExperimentsExecute(r)
 }
@@ -5526,6 +5631,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentsExecute(r ApiGetExperimen
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -5538,6 +5646,9 @@ func (a *ModelRegistryServiceAPIService) GetExperimentsExecute(r ApiGetExperimen
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -5923,6 +6034,7 @@ type ApiGetInferenceServiceServesRequest struct {
 	ApiService         *ModelRegistryServiceAPIService
 	inferenceserviceId string
 	filterQuery        *string
+	q                  *string
 	name               *string
 	externalId         *string
 	pageSize           *string
@@ -5937,6 +6049,12 @@ func (r ApiGetInferenceServiceServesRequest) FilterQuery(filterQuery string) Api
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetInferenceServiceServesRequest) Q(q string) ApiGetInferenceServiceServesRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetInferenceServiceServesRequest) Name(name string) ApiGetInferenceServiceServesRequest {
 	r.name = &name
@@ -6020,6 +6138,9 @@ func (a *ModelRegistryServiceAPIService) GetInferenceServiceServesExecute(r ApiG
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -6286,10 +6407,12 @@ type ApiGetInferenceServicesRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -6298,6 +6421,12 @@ func (r ApiGetInferenceServicesRequest) FilterQuery(filterQuery string) ApiGetIn
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetInferenceServicesRequest) Q(q string) ApiGetInferenceServicesRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetInferenceServicesRequest) PageSize(pageSize string) ApiGetInferenceServicesRequest {
 	r.pageSize = &pageSize
@@ -6322,6 +6451,12 @@ func (r ApiGetInferenceServicesRequest) NextPageToken(nextPageToken string) ApiG
 	return r
 }
 
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetInferenceServicesRequest) Tags(tags string) ApiGetInferenceServicesRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetInferenceServicesRequest) Execute() (*InferenceServiceList, *http.Response, error) {
 	return r.ApiService.GetInferenceServicesExecute(r)
 }
@@ -6366,6 +6501,9 @@ func (a *ModelRegistryServiceAPIService) GetInferenceServicesExecute(r ApiGetInf
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -6378,6 +6516,9 @@ func (a *ModelRegistryServiceAPIService) GetInferenceServicesExecute(r ApiGetInf
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -6637,6 +6778,7 @@ type ApiGetModelArtifactsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
@@ -6649,6 +6791,12 @@ func (r ApiGetModelArtifactsRequest) FilterQuery(filterQuery string) ApiGetModel
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetModelArtifactsRequest) Q(q string) ApiGetModelArtifactsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetModelArtifactsRequest) PageSize(pageSize string) ApiGetModelArtifactsRequest {
 	r.pageSize = &pageSize
@@ -6717,6 +6865,9 @@ func (a *ModelRegistryServiceAPIService) GetModelArtifactsExecute(r ApiGetModelA
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -6989,6 +7140,7 @@ type ApiGetModelVersionArtifactsRequest struct {
 	ApiService     *ModelRegistryServiceAPIService
 	modelversionId string
 	filterQuery    *string
+	q              *string
 	name           *string
 	externalId     *string
 	artifactType   *ArtifactTypeQueryParam
@@ -6996,6 +7148,7 @@ type ApiGetModelVersionArtifactsRequest struct {
 	orderBy        *OrderByField
 	sortOrder      *SortOrder
 	nextPageToken  *string
+	role           *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -7004,6 +7157,12 @@ func (r ApiGetModelVersionArtifactsRequest) FilterQuery(filterQuery string) ApiG
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetModelVersionArtifactsRequest) Q(q string) ApiGetModelVersionArtifactsRequest {
+	r.q = &q
+	return r
+}
+
 // Name of entity to search.
 func (r ApiGetModelVersionArtifactsRequest) Name(name string) ApiGetModelVersionArtifactsRequest {
 	r.name = &name
@@ -7046,6 +7205,12 @@ func (r ApiGetModelVersionArtifactsRequest) NextPageToken(nextPageToken string)
 	return r
 }
 
+// Restricts the list to the artifacts of comma separated roles: &#x60;model&#x60;, &#x60;data&#x60;, &#x60;metrics&#x60;, &#x60;parameters&#x60; or &#x60;doc&#x60;.
+func (r ApiGetModelVersionArtifactsRequest) Role(role string) ApiGetModelVersionArtifactsRequest {
+	r.role = &role
+	return r
+}
+
 func (r ApiGetModelVersionArtifactsRequest) Execute() (*ArtifactList, *http.Response, error) {
 	return r.ApiService.GetModelVersionArtifactsExecute(r)
 }
@@ -7091,6 +7256,9 @@ func (a *ModelRegistryServiceAPIService) GetModelVersionArtifactsExecute(r ApiGe
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.name != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "name", r.name, "form", "")
 	}
@@ -7112,6 +7280,9 @@ func (a *ModelRegistryServiceAPIService) GetModelVersionArtifactsExecute(r ApiGe
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.role != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "role", r.role, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -7213,10 +7384,13 @@ type ApiGetModelVersionsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	state         *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -7225,6 +7399,12 @@ func (r ApiGetModelVersionsRequest) FilterQuery(filterQuery string) ApiGetModelV
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetModelVersionsRequest) Q(q string) ApiGetModelVersionsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetModelVersionsRequest) PageSize(pageSize string) ApiGetModelVersionsRequest {
 	r.pageSize = &pageSize
@@ -7249,6 +7429,18 @@ func (r ApiGetModelVersionsRequest) NextPageToken(nextPageToken string) ApiGetMo
 	return r
 }
 
+// Restricts the list to the &#x60;LIVE&#x60; or &#x60;ARCHIVED&#x60; entities, defaults to &#x60;ALL&#x60;.
+func (r ApiGetModelVersionsRequest) State(state string) ApiGetModelVersionsRequest {
+	r.state = &state
+	return r
+}
+
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetModelVersionsRequest) Tags(tags string) ApiGetModelVersionsRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetModelVersionsRequest) Execute() (*ModelVersionList, *http.Response, error) {
 	return r.ApiService.GetModelVersionsExecute(r)
 }
@@ -7293,6 +7485,9 @@ func (a *ModelRegistryServiceAPIService) GetModelVersionsExecute(r ApiGetModelVe
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -7305,6 +7500,12 @@ func (a *ModelRegistryServiceAPIService) GetModelVersionsExecute(r ApiGetModelVe
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.state != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "state", r.state, "form", "")
+	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -7395,6 +7596,13 @@ type ApiGetRegisteredModelRequest struct {
 	ctx               context.Context
 	ApiService        *ModelRegistryServiceAPIService
 	registeredmodelId string
+	asOf              *time.Time
+}
+
+// Reads the entity as it was at an RFC 3339 time, from the revisions recorded by its updates.
+func (r ApiGetRegisteredModelRequest) AsOf(asOf time.Time) ApiGetRegisteredModelRequest {
+	r.asOf = &asOf
+	return r
 }
 
 func (r ApiGetRegisteredModelRequest) Execute() (*RegisteredModel, *http.Response, error) {
@@ -7404,7 +7612,7 @@ func (r ApiGetRegisteredModelRequest) Execute() (*RegisteredModel, *http.Respons
 /*
 GetRegisteredModel Get a RegisteredModel
 
-Gets the details of a single instance of a `RegisteredModel`.
+Gets the details of a single instance of a `RegisteredModel`, or of its state at a past time with `asOf`.
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param registeredmodelId A unique identifier for a `RegisteredModel`.
@@ -7441,6 +7649,9 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelExecute(r ApiGetRegis
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.asOf != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "asOf", r.asOf, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -7480,6 +7691,17 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelExecute(r ApiGetRegis
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 401 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -7545,10 +7767,12 @@ type ApiGetRegisteredModelVersionsRequest struct {
 	name              *string
 	externalId        *string
 	filterQuery       *string
+	q                 *string
 	pageSize          *string
 	orderBy           *OrderByField
 	sortOrder         *SortOrder
 	nextPageToken     *string
+	state             *string
 }
 
 // Name of entity to search.
@@ -7569,6 +7793,12 @@ func (r ApiGetRegisteredModelVersionsRequest) FilterQuery(filterQuery string) Ap
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetRegisteredModelVersionsRequest) Q(q string) ApiGetRegisteredModelVersionsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetRegisteredModelVersionsRequest) PageSize(pageSize string) ApiGetRegisteredModelVersionsRequest {
 	r.pageSize = &pageSize
@@ -7593,6 +7823,12 @@ func (r ApiGetRegisteredModelVersionsRequest) NextPageToken(nextPageToken string
 	return r
 }
 
+// Restricts the list to the &#x60;LIVE&#x60; or &#x60;ARCHIVED&#x60; entities, defaults to &#x60;ALL&#x60;.
+func (r ApiGetRegisteredModelVersionsRequest) State(state string) ApiGetRegisteredModelVersionsRequest {
+	r.state = &state
+	return r
+}
+
 func (r ApiGetRegisteredModelVersionsRequest) Execute() (*ModelVersionList, *http.Response, error) {
 	return r.ApiService.GetRegisteredModelVersionsExecute(r)
 }
@@ -7646,6 +7882,9 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelVersionsExecute(r Api
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -7658,6 +7897,9 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelVersionsExecute(r Api
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.state != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "state", r.state, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -7759,10 +8001,13 @@ type ApiGetRegisteredModelsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	state         *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -7771,6 +8016,12 @@ func (r ApiGetRegisteredModelsRequest) FilterQuery(filterQuery string) ApiGetReg
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetRegisteredModelsRequest) Q(q string) ApiGetRegisteredModelsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetRegisteredModelsRequest) PageSize(pageSize string) ApiGetRegisteredModelsRequest {
 	r.pageSize = &pageSize
@@ -7795,6 +8046,18 @@ func (r ApiGetRegisteredModelsRequest) NextPageToken(nextPageToken string) ApiGe
 	return r
 }
 
+// Restricts the list to the &#x60;LIVE&#x60; or &#x60;ARCHIVED&#x60; entities, defaults to &#x60;ALL&#x60;.
+func (r ApiGetRegisteredModelsRequest) State(state string) ApiGetRegisteredModelsRequest {
+	r.state = &state
+	return r
+}
+
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetRegisteredModelsRequest) Tags(tags string) ApiGetRegisteredModelsRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetRegisteredModelsRequest) Execute() (*RegisteredModelList, *http.Response, error) {
 	return r.ApiService.GetRegisteredModelsExecute(r)
 }
@@ -7839,6 +8102,9 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelsExecute(r ApiGetRegi
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -7851,6 +8117,12 @@ func (a *ModelRegistryServiceAPIService) GetRegisteredModelsExecute(r ApiGetRegi
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.state != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "state", r.state, "form", "")
+	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -8088,10 +8360,12 @@ type ApiGetServingEnvironmentsRequest struct {
 	ctx           context.Context
 	ApiService    *ModelRegistryServiceAPIService
 	filterQuery   *string
+	q             *string
 	pageSize      *string
 	orderBy       *OrderByField
 	sortOrder     *SortOrder
 	nextPageToken *string
+	tags          *string
 }
 
 // A SQL-like query string to filter the list of entities. The query supports rich filtering capabilities with automatic type inference.  **Supported Operators:** - Comparison: &#x60;&#x3D;&#x60;, &#x60;!&#x3D;&#x60;, &#x60;&lt;&gt;&#x60;, &#x60;&gt;&#x60;, &#x60;&lt;&#x60;, &#x60;&gt;&#x3D;&#x60;, &#x60;&lt;&#x3D;&#x60; - Pattern matching: &#x60;LIKE&#x60;, &#x60;ILIKE&#x60; (case-insensitive) - Set membership: &#x60;IN&#x60; - Logical: &#x60;AND&#x60;, &#x60;OR&#x60; - Grouping: &#x60;()&#x60; for complex expressions  **Data Types:** - Strings: &#x60;\&quot;value\&quot;&#x60; or &#x60;&#39;value&#39;&#x60; - Numbers: &#x60;42&#x60;, &#x60;3.14&#x60;, &#x60;1e-5&#x60; - Booleans: &#x60;true&#x60;, &#x60;false&#x60; (case-insensitive)  **Property Access:** - Standard properties: &#x60;name&#x60;, &#x60;id&#x60;, &#x60;state&#x60;, &#x60;createTimeSinceEpoch&#x60; - Custom properties: Any user-defined property name - Escaped properties: Use backticks for special characters: &#x60;&#x60; &#x60;custom-property&#x60; &#x60;&#x60; - Type-specific access: &#x60;property.string_value&#x60;, &#x60;property.double_value&#x60;, &#x60;property.int_value&#x60;, &#x60;property.bool_value&#x60;  **Examples:** - Basic: &#x60;name &#x3D; \&quot;my-model\&quot;&#x60; - Comparison: &#x60;accuracy &gt; 0.95&#x60; - Pattern: &#x60;name LIKE \&quot;%tensorflow%\&quot;&#x60; - Complex: &#x60;(name &#x3D; \&quot;model-a\&quot; OR name &#x3D; \&quot;model-b\&quot;) AND state &#x3D; \&quot;LIVE\&quot;&#x60; - Custom property: &#x60;framework.string_value &#x3D; \&quot;pytorch\&quot;&#x60; - Escaped property: &#x60;&#x60; &#x60;mlflow.source.type&#x60; &#x3D; \&quot;notebook\&quot; &#x60;&#x60;
@@ -8100,6 +8374,12 @@ func (r ApiGetServingEnvironmentsRequest) FilterQuery(filterQuery string) ApiGet
 	return r
 }
 
+// A free-text search over the name, description and string custom properties of the entities.
+func (r ApiGetServingEnvironmentsRequest) Q(q string) ApiGetServingEnvironmentsRequest {
+	r.q = &q
+	return r
+}
+
 // Number of entities in each page.
 func (r ApiGetServingEnvironmentsRequest) PageSize(pageSize string) ApiGetServingEnvironmentsRequest {
 	r.pageSize = &pageSize
@@ -8124,6 +8404,12 @@ func (r ApiGetServingEnvironmentsRequest) NextPageToken(nextPageToken string) Ap
 	return r
 }
 
+// Restricts the list to the entities with all the comma separated tags.
+func (r ApiGetServingEnvironmentsRequest) Tags(tags string) ApiGetServingEnvironmentsRequest {
+	r.tags = &tags
+	return r
+}
+
 func (r ApiGetServingEnvironmentsRequest) Execute() (*ServingEnvironmentList, *http.Response, error) {
 	return r.ApiService.GetServingEnvironmentsExecute(r)
 }
@@ -8168,6 +8454,9 @@ func (a *ModelRegistryServiceAPIService) GetServingEnvironmentsExecute(r ApiGetS
 	if r.filterQuery != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "filterQuery", r.filterQuery, "form", "")
 	}
+	if r.q != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "q", r.q, "form", "")
+	}
 	if r.pageSize != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "pageSize", r.pageSize, "form", "")
 	}
@@ -8180,6 +8469,9 @@ func (a *ModelRegistryServiceAPIService) GetServingEnvironmentsExecute(r ApiGetS
 	if r.nextPageToken != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "nextPageToken", r.nextPageToken, "form", "")
 	}
+	if r.tags != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "tags", r.tags, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -0,0 +1,640 @@
+package inmemory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+)
+
+func artifactFields(name, uri, state, artifactType, externalID **string, createTime, updateTime **int64) attributeFields {
+	return attributeFields{
+		Name:         name,
+		ExternalID:   externalID,
+		URI:          uri,
+		State:        state,
+		ArtifactType: artifactType,
+		CreateTime:   createTime,
+		UpdateTime:   updateTime,
+	}
+}
+
+// matchesParent checks if an artifact is attributed to the parent context, when one is set.
+func (r *repository[E, A]) matchesParent(id int32, parentResourceID *int32) bool {
+	return parentResourceID == nil || r.store.links[artifactKind].has(*parentResourceID, id)
+}
+
+// FindByDigest returns the artifact of the type with the lowest id among those with the digest custom property, the
+// first one saved with it as the canonical artifact of the database repositories.
+func (r *repository[E, A]) FindByDigest(digest string) (E, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range slices.Sorted(maps.Keys(r.table().records)) {
+		entity, ok := r.get(id)
+		if !ok || entity.CustomProperties == nil {
+			continue
+		}
+		for _, property := range *entity.CustomProperties {
+			if property.Name == models.DigestCustomProperty && property.StringValue != nil && *property.StringValue == digest {
+				return r.output(entity), nil
+			}
+		}
+	}
+
+	var zero E
+	return zero, fmt.Errorf("%w: no %s with digest %s: %w", r.notFoundError, r.entityName, digest, api.ErrNotFound)
+}
+
+type modelArtifactRepository struct {
+	*repository[models.ModelArtifact, models.ModelArtifactAttributes]
+}
+
+func NewModelArtifactRepository(store *Store) models.ModelArtifactRepository {
+	return &modelArtifactRepository{newModelArtifactRepository(store)}
+}
+
+func newModelArtifactRepository(store *Store) *repository[models.ModelArtifact, models.ModelArtifactAttributes] {
+	return newRepository[models.ModelArtifact](repositoryConfig[models.ModelArtifactAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.ModelArtifactTypeName,
+		entityName:    "model artifact",
+		notFoundError: service.ErrModelArtifactNotFound,
+		childEntity:   true,
+		artifactType:  models.ModelArtifactType,
+		fields: func(a *models.ModelArtifactAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})
+}
+
+func (r *modelArtifactRepository) Save(modelArtifact models.ModelArtifact, parentResourceID *int32) (models.ModelArtifact, error) {
+	return r.save(modelArtifact, parentResourceID)
+}
+
+func (r *modelArtifactRepository) List(listOptions models.ModelArtifactListOptions) (*models.ListWrapper[models.ModelArtifact], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ModelArtifactImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ParentResourceID)
+	})
+}
+
+type docArtifactRepository struct {
+	*repository[models.DocArtifact, models.DocArtifactAttributes]
+}
+
+func NewDocArtifactRepository(store *Store) models.DocArtifactRepository {
+	return &docArtifactRepository{newDocArtifactRepository(store)}
+}
+
+func newDocArtifactRepository(store *Store) *repository[models.DocArtifact, models.DocArtifactAttributes] {
+	return newRepository[models.DocArtifact](repositoryConfig[models.DocArtifactAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.DocArtifactTypeName,
+		entityName:    "doc artifact",
+		notFoundError: service.ErrDocArtifactNotFound,
+		childEntity:   true,
+		artifactType:  models.DocArtifactType,
+		fields: func(a *models.DocArtifactAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})
+}
+
+func (r *docArtifactRepository) Save(docArtifact models.DocArtifact, parentResourceID *int32) (models.DocArtifact, error) {
+	return r.save(docArtifact, parentResourceID)
+}
+
+func (r *docArtifactRepository) List(listOptions models.DocArtifactListOptions) (*models.ListWrapper[models.DocArtifact], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.DocArtifactImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ParentResourceID)
+	})
+}
+
+type dataSetRepository struct {
+	*repository[models.DataSet, models.DataSetAttributes]
+}
+
+func NewDataSetRepository(store *Store) models.DataSetRepository {
+	return &dataSetRepository{newDataSetRepository(store)}
+}
+
+func newDataSetRepository(store *Store) *repository[models.DataSet, models.DataSetAttributes] {
+	return newRepository[models.DataSet](repositoryConfig[models.DataSetAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.DataSetTypeName,
+		entityName:    "dataset",
+		notFoundError: service.ErrDataSetNotFound,
+		childEntity:   true,
+		artifactType:  models.DataSetType,
+		fields: func(a *models.DataSetAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})
+}
+
+func (r *dataSetRepository) Save(dataSet models.DataSet, parentResourceID *int32) (models.DataSet, error) {
+	return r.save(dataSet, parentResourceID)
+}
+
+func (r *dataSetRepository) List(listOptions models.DataSetListOptions) (*models.ListWrapper[models.DataSet], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.DataSetImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ParentResourceID)
+	})
+}
+
+type metricRepository struct {
+	*repository[models.Metric, models.MetricAttributes]
+}
+
+func NewMetricRepository(store *Store) models.MetricRepository {
+	return &metricRepository{newMetricRepository(store)}
+}
+
+func newMetricRepository(store *Store) *repository[models.Metric, models.MetricAttributes] {
+	return newRepository[models.Metric](repositoryConfig[models.MetricAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.MetricTypeName,
+		entityName:    "metric",
+		notFoundError: service.ErrMetricNotFound,
+		childEntity:   true,
+		artifactType:  models.MetricType,
+		fields: func(a *models.MetricAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})
+}
+
+func (r *metricRepository) Save(metric models.Metric, parentResourceID *int32) (models.Metric, error) {
+	return r.save(metric, parentResourceID)
+}
+
+func (r *metricRepository) List(listOptions models.MetricListOptions) (*models.ListWrapper[models.Metric], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.MetricImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ParentResourceID)
+	})
+}
+
+type parameterRepository struct {
+	*repository[models.Parameter, models.ParameterAttributes]
+}
+
+func NewParameterRepository(store *Store) models.ParameterRepository {
+	return &parameterRepository{newParameterRepository(store)}
+}
+
+func newParameterRepository(store *Store) *repository[models.Parameter, models.ParameterAttributes] {
+	return newRepository[models.Parameter](repositoryConfig[models.ParameterAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.ParameterTypeName,
+		entityName:    "parameter",
+		notFoundError: service.ErrParameterNotFound,
+		childEntity:   true,
+		artifactType:  models.ParameterType,
+		fields: func(a *models.ParameterAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})
+}
+
+func (r *parameterRepository) Save(parameter models.Parameter, parentResourceID *int32) (models.Parameter, error) {
+	return r.save(parameter, parentResourceID)
+}
+
+func (r *parameterRepository) List(listOptions models.ParameterListOptions) (*models.ListWrapper[models.Parameter], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.ParameterImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ParentResourceID)
+	})
+}
+
+type metricsTableRepository struct {
+	*repository[models.MetricsTable, models.MetricsTableAttributes]
+}
+
+func NewMetricsTableRepository(store *Store) models.MetricsTableRepository {
+	return &metricsTableRepository{newRepository[models.MetricsTable](repositoryConfig[models.MetricsTableAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.MetricsTableTypeName,
+		entityName:    "metrics table",
+		notFoundError: service.ErrMetricsTableNotFound,
+		fields: func(a *models.MetricsTableAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *metricsTableRepository) Save(metricsTable models.MetricsTable, modelVersionID *int32) (models.MetricsTable, error) {
+	return r.save(metricsTable, modelVersionID)
+}
+
+func (r *metricsTableRepository) List(listOptions models.MetricsTableListOptions) (*models.ListWrapper[models.MetricsTable], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.MetricsTableImpl) bool {
+		return r.matchesParent(id, listOptions.ModelVersionID)
+	})
+}
+
+type modelCardRepository struct {
+	*repository[models.ModelCard, models.ModelCardAttributes]
+}
+
+func NewModelCardRepository(store *Store) models.ModelCardRepository {
+	return &modelCardRepository{newRepository[models.ModelCard](repositoryConfig[models.ModelCardAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.ModelCardTypeName,
+		entityName:    "model card",
+		notFoundError: service.ErrModelCardNotFound,
+		fields: func(a *models.ModelCardAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *modelCardRepository) Save(modelCard models.ModelCard, registeredModelID *int32) (models.ModelCard, error) {
+	return r.save(modelCard, registeredModelID)
+}
+
+func (r *modelCardRepository) List(listOptions models.ModelCardListOptions) (*models.ListWrapper[models.ModelCard], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.ModelCardImpl) bool {
+		return r.matchesParent(id, listOptions.RegisteredModelID)
+	})
+}
+
+type metricHistoryRepository struct {
+	*repository[models.MetricHistory, models.MetricHistoryAttributes]
+}
+
+func NewMetricHistoryRepository(store *Store) models.MetricHistoryRepository {
+	return &metricHistoryRepository{newRepository[models.MetricHistory](repositoryConfig[models.MetricHistoryAttributes]{
+		store:         store,
+		kind:          artifactKind,
+		typeName:      defaults.MetricHistoryTypeName,
+		entityName:    "metric history",
+		notFoundError: service.ErrMetricHistoryNotFound,
+		childEntity:   true,
+		artifactType:  models.MetricHistoryType,
+		fields: func(a *models.MetricHistoryAttributes) attributeFields {
+			return artifactFields(&a.Name, &a.URI, &a.State, &a.ArtifactType, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *metricHistoryRepository) Save(metricHistory models.MetricHistory, experimentRunID *int32) (models.MetricHistory, error) {
+	return r.save(metricHistory, experimentRunID)
+}
+
+func (r *metricHistoryRepository) List(listOptions models.MetricHistoryListOptions) (*models.ListWrapper[models.MetricHistory], error) {
+	var namePattern *string
+	if listOptions.Name != nil {
+		namePattern = apiutils.Of("%" + likeEscaper.Replace(*listOptions.Name) + "%")
+	}
+
+	var steps []int32
+	if listOptions.StepIds != nil {
+		for part := range strings.SplitSeq(*listOptions.StepIds, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			step, err := strconv.ParseInt(part, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid step ID '%s': %w", part, api.ErrBadRequest)
+			}
+			steps = append(steps, int32(step))
+		}
+	}
+
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.MetricHistoryImpl) bool {
+		if len(steps) > 0 {
+			step := intProperty(entity, "step")
+			if step == nil || !slices.Contains(steps, *step) {
+				return false
+			}
+		}
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesParent(id, listOptions.ExperimentRunID)
+	})
+}
+
+func (r *metricHistoryRepository) Aggregate(options models.MetricHistoryAggregateOptions) ([]models.MetricHistoryAggregate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	aggregates := []models.MetricHistoryAggregate{}
+	for _, runID := range slices.Sorted(maps.Keys(r.store.links[contextKind][options.ExperimentID])) {
+		prefix := fmt.Sprintf("%d:%s__", runID, options.Name)
+		aggregate := models.MetricHistoryAggregate{ExperimentRunID: runID}
+		for id := range r.store.links[artifactKind][runID] {
+			entity, ok := r.get(id)
+			if !ok || entity.Attributes.Name == nil {
+				continue
+			}
+			suffix, ok := strings.CutPrefix(*entity.Attributes.Name, prefix)
+			if !ok || strings.Contains(suffix, "__") {
+				continue
+			}
+			value := doubleProperty(entity, "value")
+			if value == nil {
+				continue
+			}
+
+			switch options.Aggregation {
+			case models.MetricAggregationMax:
+				if aggregate.Count == 0 || *value > aggregate.Value {
+					aggregate.Value = *value
+				}
+			case models.MetricAggregationMin:
+				if aggregate.Count == 0 || *value < aggregate.Value {
+					aggregate.Value = *value
+				}
+			case models.MetricAggregationAvg:
+				aggregate.Value += *value
+			default:
+				return nil, fmt.Errorf("unsupported metric aggregation %q", options.Aggregation)
+			}
+			aggregate.Count++
+		}
+		if aggregate.Count == 0 {
+			continue
+		}
+		if options.Aggregation == models.MetricAggregationAvg {
+			aggregate.Value /= float64(aggregate.Count)
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+	return aggregates, nil
+}
+
+// artifactRepository lists the artifacts of all the types but metric history records, in a single sequence.
+type artifactRepository struct {
+	store          *Store
+	modelArtifacts *repository[models.ModelArtifact, models.ModelArtifactAttributes]
+	docArtifacts   *repository[models.DocArtifact, models.DocArtifactAttributes]
+	dataSets       *repository[models.DataSet, models.DataSetAttributes]
+	metrics        *repository[models.Metric, models.MetricAttributes]
+	parameters     *repository[models.Parameter, models.ParameterAttributes]
+}
+
+func NewArtifactRepository(store *Store) models.ArtifactRepository {
+	return &artifactRepository{
+		store:          store,
+		modelArtifacts: newModelArtifactRepository(store),
+		docArtifacts:   newDocArtifactRepository(store),
+		dataSets:       newDataSetRepository(store),
+		metrics:        newMetricRepository(store),
+		parameters:     newParameterRepository(store),
+	}
+}
+
+// get returns the artifact with the id, false for unknown ids and metric history records.
+func (r *artifactRepository) get(id int32) (models.Artifact, bool) {
+	if entity, ok := r.modelArtifacts.get(id); ok {
+		return models.Artifact{ModelArtifact: apiutils.Of(r.modelArtifacts.output(entity))}, true
+	}
+	if entity, ok := r.docArtifacts.get(id); ok {
+		return models.Artifact{DocArtifact: apiutils.Of(r.docArtifacts.output(entity))}, true
+	}
+	if entity, ok := r.dataSets.get(id); ok {
+		return models.Artifact{DataSet: apiutils.Of(r.dataSets.output(entity))}, true
+	}
+	if entity, ok := r.metrics.get(id); ok {
+		return models.Artifact{Metric: apiutils.Of(r.metrics.output(entity))}, true
+	}
+	if entity, ok := r.parameters.get(id); ok {
+		return models.Artifact{Parameter: apiutils.Of(r.parameters.output(entity))}, true
+	}
+	return models.Artifact{}, false
+}
+
+func (r *artifactRepository) GetByID(id int32) (models.Artifact, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	artifact, ok := r.get(id)
+	if !ok {
+		return models.Artifact{}, fmt.Errorf("%w: artifact %d", service.ErrArtifactNotFound, id)
+	}
+	return artifact, nil
+}
+
+// GetByIDs returns the artifacts with the given ids ordered by id, ids not found and metric history records are skipped.
+func (r *artifactRepository) GetByIDs(ids []int32) ([]models.Artifact, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	artifacts := []models.Artifact{}
+	for _, id := range slices.Compact(ids) {
+		if artifact, ok := r.get(id); ok {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+// artifactTypeName returns the type name of an artifact type query parameter.
+func artifactTypeName(artifactType string) (string, error) {
+	switch openapi.ArtifactTypeQueryParam(artifactType) {
+	case openapi.ARTIFACTTYPEQUERYPARAM_MODEL_ARTIFACT:
+		return defaults.ModelArtifactTypeName, nil
+	case openapi.ARTIFACTTYPEQUERYPARAM_DOC_ARTIFACT:
+		return defaults.DocArtifactTypeName, nil
+	case openapi.ARTIFACTTYPEQUERYPARAM_DATASET_ARTIFACT:
+		return defaults.DataSetTypeName, nil
+	case openapi.ARTIFACTTYPEQUERYPARAM_METRIC:
+		return defaults.MetricTypeName, nil
+	case openapi.ARTIFACTTYPEQUERYPARAM_PARAMETER:
+		return defaults.ParameterTypeName, nil
+	}
+	return "", fmt.Errorf("invalid artifact type %s: %w", artifactType, api.ErrBadRequest)
+}
+
+func (r *artifactRepository) List(listOptions models.ArtifactListOptions) (*models.ListWrapper[models.Artifact], error) {
+	var types []string
+	if listOptions.ArtifactType != nil {
+		typeName, err := artifactTypeName(*listOptions.ArtifactType)
+		if err != nil {
+			return nil, err
+		}
+		types = []string{typeName}
+	}
+	if len(listOptions.ArtifactTypes) > 0 {
+		var allowed []string
+		for _, artifactType := range listOptions.ArtifactTypes {
+			typeName, err := artifactTypeName(artifactType)
+			if err != nil {
+				return nil, err
+			}
+			allowed = append(allowed, typeName)
+		}
+		if types == nil {
+			types = allowed
+		} else {
+			// an empty, non nil, intersection matches no artifacts
+			types = slices.DeleteFunc(types, func(typeName string) bool { return !slices.Contains(allowed, typeName) })
+		}
+	}
+
+	namePattern := childNamePattern(listOptions.Name, nil)
+	restEntityType := listOptions.GetRestEntityType()
+	all := listOptions.Pagination
+	all.PageSize, all.NextPageToken = nil, nil
+
+	artifacts := []models.Artifact{}
+	if types == nil || slices.Contains(types, defaults.ModelArtifactTypeName) {
+		items, err := r.modelArtifacts.matching(all, restEntityType, func(id int32, entity *models.ModelArtifactImpl) bool {
+			return r.modelArtifacts.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.modelArtifacts.matchesParent(id, listOptions.ParentResourceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			artifacts = append(artifacts, models.Artifact{ModelArtifact: &item})
+		}
+	}
+	if types == nil || slices.Contains(types, defaults.DocArtifactTypeName) {
+		items, err := r.docArtifacts.matching(all, restEntityType, func(id int32, entity *models.DocArtifactImpl) bool {
+			return r.docArtifacts.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.docArtifacts.matchesParent(id, listOptions.ParentResourceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			artifacts = append(artifacts, models.Artifact{DocArtifact: &item})
+		}
+	}
+	if types == nil || slices.Contains(types, defaults.DataSetTypeName) {
+		items, err := r.dataSets.matching(all, restEntityType, func(id int32, entity *models.DataSetImpl) bool {
+			return r.dataSets.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.dataSets.matchesParent(id, listOptions.ParentResourceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			artifacts = append(artifacts, models.Artifact{DataSet: &item})
+		}
+	}
+	if types == nil || slices.Contains(types, defaults.MetricTypeName) {
+		items, err := r.metrics.matching(all, restEntityType, func(id int32, entity *models.MetricImpl) bool {
+			return r.metrics.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.metrics.matchesParent(id, listOptions.ParentResourceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			artifacts = append(artifacts, models.Artifact{Metric: &item})
+		}
+	}
+	if types == nil || slices.Contains(types, defaults.ParameterTypeName) {
+		items, err := r.parameters.matching(all, restEntityType, func(id int32, entity *models.ParameterImpl) bool {
+			return r.parameters.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.parameters.matchesParent(id, listOptions.ParentResourceID)
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			artifacts = append(artifacts, models.Artifact{Parameter: &item})
+		}
+	}
+
+	var page []models.Artifact
+	var nextPageToken string
+	var err error
+	orderBy := listOptions.GetOrderBy()
+	if propertyOrder, ok := scopes.ParseCustomPropertyOrder(orderBy); ok {
+		page, nextPageToken, err = paginateByCustomProperty(artifacts, listOptions.Pagination, propertyOrder, artifactCustomProperties)
+	} else {
+		page, nextPageToken, err = paginate(artifacts, listOptions.Pagination, func(artifact models.Artifact) (int64, int32) {
+			return artifactOrderValue(artifact, orderBy)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListWrapper[models.Artifact]{
+		Items:         page,
+		NextPageToken: nextPageToken,
+		PageSize:      listOptions.GetPageSize(),
+		Size:          int32(len(page)),
+	}, nil
+}
+
+// artifactOrderValue returns the (orderBy value, id) key of an artifact of any type.
+// artifactCustomProperties returns the custom properties and id of an artifact of any type.
+func artifactCustomProperties(artifact models.Artifact) ([]models.Properties, int32) {
+	var id int32
+	var customProperties *[]models.Properties
+	switch {
+	case artifact.ModelArtifact != nil:
+		a := *artifact.ModelArtifact
+		id, customProperties = *a.GetID(), a.GetCustomProperties()
+	case artifact.DocArtifact != nil:
+		a := *artifact.DocArtifact
+		id, customProperties = *a.GetID(), a.GetCustomProperties()
+	case artifact.DataSet != nil:
+		a := *artifact.DataSet
+		id, customProperties = *a.GetID(), a.GetCustomProperties()
+	case artifact.Metric != nil:
+		a := *artifact.Metric
+		id, customProperties = *a.GetID(), a.GetCustomProperties()
+	case artifact.Parameter != nil:
+		a := *artifact.Parameter
+		id, customProperties = *a.GetID(), a.GetCustomProperties()
+	}
+
+	if customProperties == nil {
+		return nil, id
+	}
+	return *customProperties, id
+}
+
+func artifactOrderValue(artifact models.Artifact, orderBy string) (int64, int32) {
+	var id int32
+	var createTime, updateTime *int64
+	switch {
+	case artifact.ModelArtifact != nil:
+		a := *artifact.ModelArtifact
+		id, createTime, updateTime = *a.GetID(), a.GetAttributes().CreateTimeSinceEpoch, a.GetAttributes().LastUpdateTimeSinceEpoch
+	case artifact.DocArtifact != nil:
+		a := *artifact.DocArtifact
+		id, createTime, updateTime = *a.GetID(), a.GetAttributes().CreateTimeSinceEpoch, a.GetAttributes().LastUpdateTimeSinceEpoch
+	case artifact.DataSet != nil:
+		a := *artifact.DataSet
+		id, createTime, updateTime = *a.GetID(), a.GetAttributes().CreateTimeSinceEpoch, a.GetAttributes().LastUpdateTimeSinceEpoch
+	case artifact.Metric != nil:
+		a := *artifact.Metric
+		id, createTime, updateTime = *a.GetID(), a.GetAttributes().CreateTimeSinceEpoch, a.GetAttributes().LastUpdateTimeSinceEpoch
+	case artifact.Parameter != nil:
+		a := *artifact.Parameter
+		id, createTime, updateTime = *a.GetID(), a.GetAttributes().CreateTimeSinceEpoch, a.GetAttributes().LastUpdateTimeSinceEpoch
+	}
+
+	switch orderBy {
+	case "CREATE_TIME":
+		return *createTime, id
+	case "LAST_UPDATE_TIME":
+		return *updateTime, id
+	default:
+		return int64(id), id
+	}
+}
@@ -0,0 +1,82 @@
+package inmemory
+
+import (
+	"slices"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+)
+
+// auditEvents are the audit events of the store, the in-memory repositories do not record their writes so the
+// events are only the ones added with Store.AddAuditEvent.
+type auditEvents struct {
+	lastID int32
+	events []models.AuditEvent
+}
+
+// AddAuditEvent stores an audit event with the next id, and the current time if it has none, and returns it.
+func (s *Store) AddAuditEvent(event models.AuditEvent) models.AuditEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.auditEvents.lastID++
+	id := s.auditEvents.lastID
+	event.ID = &id
+	if event.CreateTimeSinceEpoch == nil {
+		now := s.now()
+		event.CreateTimeSinceEpoch = &now
+	}
+	event.Changes = slices.Clone(event.Changes)
+	s.auditEvents.events = append(s.auditEvents.events, event)
+	return event
+}
+
+type auditEventRepository struct {
+	store *Store
+}
+
+func NewAuditEventRepository(store *Store) models.AuditEventRepository {
+	return &auditEventRepository{store: store}
+}
+
+func (r *auditEventRepository) List(listOptions models.AuditEventListOptions) (*models.ListWrapper[models.AuditEvent], error) {
+	r.store.mu.RLock()
+	items := []models.AuditEvent{}
+	for _, event := range r.store.auditEvents.events {
+		if matchesAuditEvent(event, listOptions) {
+			items = append(items, event)
+		}
+	}
+	r.store.mu.RUnlock()
+
+	items, nextPageToken, err := paginate(items, listOptions.Pagination, func(event models.AuditEvent) (int64, int32) {
+		if listOptions.GetOrderBy() == "CREATE_TIME" {
+			return *event.CreateTimeSinceEpoch, *event.ID
+		}
+		return int64(*event.ID), *event.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListWrapper[models.AuditEvent]{
+		Items:         items,
+		NextPageToken: nextPageToken,
+		PageSize:      listOptions.GetPageSize(),
+		Size:          int32(len(items)),
+	}, nil
+}
+
+// matchesAuditEvent checks if the event matches the filters of the list options.
+func matchesAuditEvent(event models.AuditEvent, listOptions models.AuditEventListOptions) bool {
+	switch {
+	case listOptions.Actor != nil && event.Actor != *listOptions.Actor,
+		listOptions.Action != nil && event.Action != *listOptions.Action,
+		listOptions.EntityKind != nil && event.EntityKind != *listOptions.EntityKind,
+		listOptions.TypeID != nil && event.TypeID != *listOptions.TypeID,
+		listOptions.EntityID != nil && event.EntityID != *listOptions.EntityID,
+		listOptions.Since != nil && *event.CreateTimeSinceEpoch < *listOptions.Since,
+		listOptions.Until != nil && *event.CreateTimeSinceEpoch > *listOptions.Until:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,395 @@
+package inmemory
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/defaults"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// matchesNameOrExternalID checks the name pattern, or the external id when there is no name pattern, of an entity
+// as the list filters of the database repositories do.
+func (r *repository[E, A]) matchesNameOrExternalID(entity *models.BaseEntity[A], namePattern *string, externalID *string) bool {
+	fields := r.fields(entity.Attributes)
+	if namePattern != nil {
+		return *fields.Name != nil && matchLike(*namePattern, **fields.Name, false, likeEscape)
+	}
+	if externalID != nil {
+		return *fields.ExternalID != nil && **fields.ExternalID == *externalID
+	}
+	return true
+}
+
+// likeEscape escapes the wildcards of the name patterns, as in the database repositories.
+const likeEscape = '!'
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// namePattern returns the pattern matching the name literally.
+func namePattern(name *string) *string {
+	if name == nil {
+		return nil
+	}
+	return apiutils.Of(likeEscaper.Replace(*name))
+}
+
+// childNamePattern returns the pattern of the prefixed names of child entities, for the parent if it is set.
+func childNamePattern(name *string, parentID *int32) *string {
+	if name == nil {
+		return nil
+	}
+	if parentID != nil {
+		return namePattern(apiutils.Of(fmt.Sprintf("%d:%s", *parentID, *name)))
+	}
+	return apiutils.Of("%:" + likeEscaper.Replace(*name))
+}
+
+// intProperty returns the integer property named name, e.g. the id of the parent of an entity.
+func intProperty(entity interface{ GetProperties() *[]models.Properties }, name string) *int32 {
+	if entity.GetProperties() == nil {
+		return nil
+	}
+	for _, property := range *entity.GetProperties() {
+		if property.Name == name && property.IntValue != nil {
+			return property.IntValue
+		}
+	}
+	return nil
+}
+
+// doubleProperty returns the double property named name, e.g. the value of a metric.
+func doubleProperty(entity interface{ GetProperties() *[]models.Properties }, name string) *float64 {
+	if entity.GetProperties() == nil {
+		return nil
+	}
+	for _, property := range *entity.GetProperties() {
+		if property.Name == name && property.DoubleValue != nil {
+			return property.DoubleValue
+		}
+	}
+	return nil
+}
+
+// matchesState checks the state property of an entity against the state the list is restricted to, entities
+// without a state are live as for the database repositories.
+func matchesState(properties *[]models.Properties, state *string) bool {
+	if state == nil {
+		return true
+	}
+	archived := false
+	if properties != nil {
+		for _, property := range *properties {
+			if property.Name == "state" && !property.IsCustomProperty && property.StringValue != nil {
+				archived = *property.StringValue == api.ListStateArchived
+			}
+		}
+	}
+	return archived == (*state == api.ListStateArchived)
+}
+
+func basicFields(name **string, externalID **string, createTime **int64, updateTime **int64) attributeFields {
+	return attributeFields{Name: name, ExternalID: externalID, CreateTime: createTime, UpdateTime: updateTime}
+}
+
+type registeredModelRepository struct {
+	*repository[models.RegisteredModel, models.RegisteredModelAttributes]
+
+	// revisions are the saved versions of each model, as the revisions recorded by the database repository
+	revisionsMu sync.Mutex
+	revisions   map[int32][]models.RegisteredModel
+}
+
+func NewRegisteredModelRepository(store *Store) models.RegisteredModelRepository {
+	return &registeredModelRepository{repository: newRepository[models.RegisteredModel](repositoryConfig[models.RegisteredModelAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.RegisteredModelTypeName,
+		entityName:    "registered model",
+		notFoundError: service.ErrRegisteredModelNotFound,
+		fields: func(a *models.RegisteredModelAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	}), revisions: map[int32][]models.RegisteredModel{}}
+}
+
+func (r *registeredModelRepository) Save(model models.RegisteredModel) (models.RegisteredModel, error) {
+	saved, err := r.save(model, nil)
+	if err != nil {
+		return saved, err
+	}
+	// the revision is a copy of its own, the caller may change the saved model
+	revision, err := r.GetByID(*saved.GetID())
+	if err != nil {
+		return saved, err
+	}
+	r.revisionsMu.Lock()
+	defer r.revisionsMu.Unlock()
+	r.revisions[*saved.GetID()] = append(r.revisions[*saved.GetID()], revision)
+	return saved, nil
+}
+
+func (r *registeredModelRepository) GetByIDAsOf(id int32, asOf int64) (models.RegisteredModel, error) {
+	current, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if updated := current.GetAttributes().LastUpdateTimeSinceEpoch; updated == nil || *updated <= asOf {
+		return current, nil
+	}
+
+	r.revisionsMu.Lock()
+	defer r.revisionsMu.Unlock()
+	revisions := r.revisions[id]
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if updated := revisions[i].GetAttributes().LastUpdateTimeSinceEpoch; updated != nil && *updated <= asOf {
+			return revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no revision of %s %d at %d", r.notFoundError, r.entityName, id, asOf)
+}
+
+func (r *registeredModelRepository) List(listOptions models.RegisteredModelListOptions) (*models.ListWrapper[models.RegisteredModel], error) {
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.RegisteredModelImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern(listOptions.Name), listOptions.ExternalID) &&
+			matchesState(entity.Properties, listOptions.State)
+	})
+}
+
+func (r *registeredModelRepository) ListStream(listOptions models.RegisteredModelListOptions, yield func(models.RegisteredModel) error) (string, error) {
+	page, err := r.List(listOptions)
+	return yieldPage(page, err, yield)
+}
+
+type modelVersionRepository struct {
+	*repository[models.ModelVersion, models.ModelVersionAttributes]
+}
+
+func NewModelVersionRepository(store *Store) models.ModelVersionRepository {
+	return &modelVersionRepository{newRepository[models.ModelVersion](repositoryConfig[models.ModelVersionAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.ModelVersionTypeName,
+		entityName:    "model version",
+		notFoundError: service.ErrModelVersionNotFound,
+		childEntity:   true,
+		fields: func(a *models.ModelVersionAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *modelVersionRepository) Save(modelVersion models.ModelVersion) (models.ModelVersion, error) {
+	return r.save(modelVersion, intProperty(modelVersion, "registered_model_id"))
+}
+
+func (r *modelVersionRepository) SaveBatch(modelVersions []models.ModelVersion, modelArtifacts models.ModelArtifactsFunc) ([]models.ModelVersion, [][]models.ModelArtifact, error) {
+	artifactRepository := newModelArtifactRepository(r.store)
+
+	var saved []models.ModelVersion
+	var savedArtifacts [][]models.ModelArtifact
+
+	err := r.store.transaction(func() error {
+		saved = make([]models.ModelVersion, 0, len(modelVersions))
+		savedArtifacts = make([][]models.ModelArtifact, len(modelVersions))
+
+		for i, modelVersion := range modelVersions {
+			savedVersion, err := r.saveLocked(modelVersion, intProperty(modelVersion, "registered_model_id"))
+			if err != nil {
+				return err
+			}
+			saved = append(saved, savedVersion)
+
+			if modelArtifacts == nil {
+				continue
+			}
+			artifacts, err := modelArtifacts(i, *savedVersion.GetID())
+			if err != nil {
+				return err
+			}
+			for _, artifact := range artifacts {
+				savedArtifact, err := artifactRepository.saveLocked(artifact, savedVersion.GetID())
+				if err != nil {
+					return err
+				}
+				savedArtifacts[i] = append(savedArtifacts[i], savedArtifact)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return saved, savedArtifacts, nil
+}
+
+func (r *modelVersionRepository) List(listOptions models.ModelVersionListOptions) (*models.ListWrapper[models.ModelVersion], error) {
+	namePattern := childNamePattern(listOptions.Name, listOptions.ParentResourceID)
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ModelVersionImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) &&
+			(listOptions.ParentResourceID == nil || r.store.links[contextKind].has(*listOptions.ParentResourceID, id)) &&
+			(listOptions.ArtifactID == nil || r.store.links[artifactKind].has(id, *listOptions.ArtifactID)) &&
+			matchesState(entity.Properties, listOptions.State)
+	})
+}
+
+func (r *modelVersionRepository) ListStream(listOptions models.ModelVersionListOptions, yield func(models.ModelVersion) error) (string, error) {
+	page, err := r.List(listOptions)
+	return yieldPage(page, err, yield)
+}
+
+type servingEnvironmentRepository struct {
+	*repository[models.ServingEnvironment, models.ServingEnvironmentAttributes]
+}
+
+func NewServingEnvironmentRepository(store *Store) models.ServingEnvironmentRepository {
+	return &servingEnvironmentRepository{newRepository[models.ServingEnvironment](repositoryConfig[models.ServingEnvironmentAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.ServingEnvironmentTypeName,
+		entityName:    "serving environment",
+		notFoundError: service.ErrServingEnvironmentNotFound,
+		fields: func(a *models.ServingEnvironmentAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *servingEnvironmentRepository) Save(servingEnvironment models.ServingEnvironment) (models.ServingEnvironment, error) {
+	return r.save(servingEnvironment, nil)
+}
+
+func (r *servingEnvironmentRepository) List(listOptions models.ServingEnvironmentListOptions) (*models.ListWrapper[models.ServingEnvironment], error) {
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ServingEnvironmentImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern(listOptions.Name), listOptions.ExternalID)
+	})
+}
+
+type inferenceServiceRepository struct {
+	*repository[models.InferenceService, models.InferenceServiceAttributes]
+}
+
+func NewInferenceServiceRepository(store *Store) models.InferenceServiceRepository {
+	return &inferenceServiceRepository{newRepository[models.InferenceService](repositoryConfig[models.InferenceServiceAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.InferenceServiceTypeName,
+		entityName:    "inference service",
+		notFoundError: service.ErrInferenceServiceNotFound,
+		childEntity:   true,
+		fields: func(a *models.InferenceServiceAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *inferenceServiceRepository) Save(inferenceService models.InferenceService) (models.InferenceService, error) {
+	return r.save(inferenceService, intProperty(inferenceService, "serving_environment_id"))
+}
+
+func (r *inferenceServiceRepository) List(listOptions models.InferenceServiceListOptions) (*models.ListWrapper[models.InferenceService], error) {
+	namePattern := childNamePattern(listOptions.Name, listOptions.ParentResourceID)
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.InferenceServiceImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) &&
+			(listOptions.ParentResourceID == nil || r.store.links[contextKind].has(*listOptions.ParentResourceID, id)) &&
+			(listOptions.Runtime == nil || propertyValue(entity.Properties, "runtime", filter.StringValueType, nil) == *listOptions.Runtime)
+	})
+}
+
+type experimentRepository struct {
+	*repository[models.Experiment, models.ExperimentAttributes]
+}
+
+func NewExperimentRepository(store *Store) models.ExperimentRepository {
+	return &experimentRepository{newRepository[models.Experiment](repositoryConfig[models.ExperimentAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.ExperimentTypeName,
+		entityName:    "experiment",
+		notFoundError: service.ErrExperimentNotFound,
+		fields: func(a *models.ExperimentAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *experimentRepository) Save(experiment models.Experiment) (models.Experiment, error) {
+	return r.save(experiment, nil)
+}
+
+func (r *experimentRepository) List(listOptions models.ExperimentListOptions) (*models.ListWrapper[models.Experiment], error) {
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ExperimentImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern(listOptions.Name), listOptions.ExternalID)
+	})
+}
+
+type experimentRunRepository struct {
+	*repository[models.ExperimentRun, models.ExperimentRunAttributes]
+}
+
+func NewExperimentRunRepository(store *Store) models.ExperimentRunRepository {
+	return &experimentRunRepository{newRepository[models.ExperimentRun](repositoryConfig[models.ExperimentRunAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.ExperimentRunTypeName,
+		entityName:    "experiment run",
+		notFoundError: service.ErrExperimentRunNotFound,
+		childEntity:   true,
+		fields: func(a *models.ExperimentRunAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *experimentRunRepository) Save(experimentRun models.ExperimentRun, experimentID *int32) (models.ExperimentRun, error) {
+	return r.save(experimentRun, experimentID)
+}
+
+func (r *experimentRunRepository) List(listOptions models.ExperimentRunListOptions) (*models.ListWrapper[models.ExperimentRun], error) {
+	namePattern := childNamePattern(listOptions.Name, listOptions.ExperimentID)
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ExperimentRunImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) &&
+			(listOptions.ExperimentID == nil || r.store.links[contextKind].has(*listOptions.ExperimentID, id)) &&
+			(listOptions.ArtifactID == nil || r.store.links[artifactKind].has(id, *listOptions.ArtifactID))
+	})
+}
+
+func (r *experimentRunRepository) ListStream(listOptions models.ExperimentRunListOptions, yield func(models.ExperimentRun) error) (string, error) {
+	page, err := r.List(listOptions)
+	return yieldPage(page, err, yield)
+}
+
+type promotionRepository struct {
+	*repository[models.Promotion, models.PromotionAttributes]
+}
+
+func NewPromotionRepository(store *Store) models.PromotionRepository {
+	return &promotionRepository{newRepository[models.Promotion](repositoryConfig[models.PromotionAttributes]{
+		store:         store,
+		kind:          contextKind,
+		typeName:      defaults.PromotionTypeName,
+		entityName:    "promotion",
+		notFoundError: service.ErrPromotionNotFound,
+		fields: func(a *models.PromotionAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *promotionRepository) Save(promotion models.Promotion) (models.Promotion, error) {
+	return r.save(promotion, nil)
+}
+
+func (r *promotionRepository) List(listOptions models.PromotionListOptions) (*models.ListWrapper[models.Promotion], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.PromotionImpl) bool {
+		name := entity.Attributes.Name
+		return listOptions.Name == nil || name != nil && *name == *listOptions.Name
+	})
+}
@@ -0,0 +1,182 @@
+package inmemory
+
+import (
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/internal/defaults"
+)
+
+// matchesContext checks if an execution is associated with the context, when one is set.
+func (r *repository[E, A]) matchesContext(id int32, contextID *int32) bool {
+	return contextID == nil || r.store.links[executionKind].has(*contextID, id)
+}
+
+type serveModelRepository struct {
+	*repository[models.ServeModel, models.ServeModelAttributes]
+}
+
+func NewServeModelRepository(store *Store) models.ServeModelRepository {
+	return &serveModelRepository{newRepository[models.ServeModel](repositoryConfig[models.ServeModelAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.ServeModelTypeName,
+		entityName:    "serve model",
+		notFoundError: service.ErrServeModelNotFound,
+		childEntity:   true,
+		fields: func(a *models.ServeModelAttributes) attributeFields {
+			return attributeFields{
+				Name:       &a.Name,
+				ExternalID: &a.ExternalID,
+				State:      &a.LastKnownState,
+				CreateTime: &a.CreateTimeSinceEpoch,
+				UpdateTime: &a.LastUpdateTimeSinceEpoch,
+			}
+		},
+	})}
+}
+
+func (r *serveModelRepository) Save(serveModel models.ServeModel, inferenceServiceID *int32) (models.ServeModel, error) {
+	return r.save(serveModel, inferenceServiceID)
+}
+
+func (r *serveModelRepository) List(listOptions models.ServeModelListOptions) (*models.ListWrapper[models.ServeModel], error) {
+	namePattern := childNamePattern(listOptions.Name, nil)
+	return r.list(listOptions.Pagination, listOptions.GetRestEntityType(), func(id int32, entity *models.ServeModelImpl) bool {
+		return r.matchesNameOrExternalID(entity, namePattern, listOptions.ExternalID) && r.matchesContext(id, listOptions.InferenceServiceID)
+	})
+}
+
+type conversionJobRepository struct {
+	*repository[models.ConversionJob, models.ConversionJobAttributes]
+}
+
+func NewConversionJobRepository(store *Store) models.ConversionJobRepository {
+	return &conversionJobRepository{newRepository[models.ConversionJob](repositoryConfig[models.ConversionJobAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.ConversionJobTypeName,
+		entityName:    "conversion job",
+		notFoundError: service.ErrConversionJobNotFound,
+		fields: func(a *models.ConversionJobAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *conversionJobRepository) Save(conversionJob models.ConversionJob, modelVersionID *int32) (models.ConversionJob, error) {
+	return r.save(conversionJob, modelVersionID)
+}
+
+func (r *conversionJobRepository) List(listOptions models.ConversionJobListOptions) (*models.ListWrapper[models.ConversionJob], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.ConversionJobImpl) bool {
+		return r.matchesContext(id, listOptions.ModelVersionID)
+	})
+}
+
+type deploymentRepository struct {
+	*repository[models.Deployment, models.DeploymentAttributes]
+}
+
+func NewDeploymentRepository(store *Store) models.DeploymentRepository {
+	return &deploymentRepository{newRepository[models.Deployment](repositoryConfig[models.DeploymentAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.DeploymentTypeName,
+		entityName:    "deployment",
+		notFoundError: service.ErrDeploymentNotFound,
+		fields: func(a *models.DeploymentAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *deploymentRepository) Save(deployment models.Deployment, inferenceServiceID *int32) (models.Deployment, error) {
+	return r.save(deployment, inferenceServiceID)
+}
+
+func (r *deploymentRepository) List(listOptions models.DeploymentListOptions) (*models.ListWrapper[models.Deployment], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.DeploymentImpl) bool {
+		return r.matchesContext(id, listOptions.InferenceServiceID)
+	})
+}
+
+type abTestRepository struct {
+	*repository[models.ABTest, models.ABTestAttributes]
+}
+
+func NewABTestRepository(store *Store) models.ABTestRepository {
+	return &abTestRepository{newRepository[models.ABTest](repositoryConfig[models.ABTestAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.ABTestTypeName,
+		entityName:    "ab test",
+		notFoundError: service.ErrABTestNotFound,
+		fields: func(a *models.ABTestAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *abTestRepository) Save(abTest models.ABTest, registeredModelID *int32) (models.ABTest, error) {
+	return r.save(abTest, registeredModelID)
+}
+
+func (r *abTestRepository) List(listOptions models.ABTestListOptions) (*models.ListWrapper[models.ABTest], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.ABTestImpl) bool {
+		return r.matchesContext(id, listOptions.RegisteredModelID)
+	})
+}
+
+type stageTransitionRepository struct {
+	*repository[models.StageTransition, models.StageTransitionAttributes]
+}
+
+func NewStageTransitionRepository(store *Store) models.StageTransitionRepository {
+	return &stageTransitionRepository{newRepository[models.StageTransition](repositoryConfig[models.StageTransitionAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.StageTransitionTypeName,
+		entityName:    "stage transition",
+		notFoundError: service.ErrStageTransitionNotFound,
+		fields: func(a *models.StageTransitionAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *stageTransitionRepository) Save(stageTransition models.StageTransition, modelVersionID *int32) (models.StageTransition, error) {
+	return r.save(stageTransition, modelVersionID)
+}
+
+func (r *stageTransitionRepository) List(listOptions models.StageTransitionListOptions) (*models.ListWrapper[models.StageTransition], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.StageTransitionImpl) bool {
+		return r.matchesContext(id, listOptions.ModelVersionID)
+	})
+}
+
+type promotionRunRepository struct {
+	*repository[models.PromotionRun, models.PromotionRunAttributes]
+}
+
+func NewPromotionRunRepository(store *Store) models.PromotionRunRepository {
+	return &promotionRunRepository{newRepository[models.PromotionRun](repositoryConfig[models.PromotionRunAttributes]{
+		store:         store,
+		kind:          executionKind,
+		typeName:      defaults.PromotionRunTypeName,
+		entityName:    "promotion run",
+		notFoundError: service.ErrPromotionRunNotFound,
+		fields: func(a *models.PromotionRunAttributes) attributeFields {
+			return basicFields(&a.Name, &a.ExternalID, &a.CreateTimeSinceEpoch, &a.LastUpdateTimeSinceEpoch)
+		},
+	})}
+}
+
+func (r *promotionRunRepository) Save(promotionRun models.PromotionRun, promotionID *int32) (models.PromotionRun, error) {
+	return r.save(promotionRun, promotionID)
+}
+
+func (r *promotionRunRepository) List(listOptions models.PromotionRunListOptions) (*models.ListWrapper[models.PromotionRun], error) {
+	return r.list(listOptions.Pagination, "", func(id int32, entity *models.PromotionRunImpl) bool {
+		return r.matchesContext(id, listOptions.PromotionID)
+	})
+}
@@ -0,0 +1,298 @@
+package inmemory
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// typeSuffixes are the explicit value types a filter query property can end with, e.g. "budget.double_value"
+var typeSuffixes = []string{filter.StringValueType, filter.IntValueType, filter.DoubleValueType, filter.BoolValueType}
+
+// matchesFilter evaluates a parsed filter query on an entity, selecting what the query built by filter.QueryBuilder
+// selects in the database.
+func (r *repository[E, A]) matchesFilter(expr *filter.FilterExpression, restEntityType filter.RestEntityType, entity *models.BaseEntity[A]) (bool, error) {
+	if !expr.IsLeaf {
+		left, err := r.matchesFilter(expr.Left, restEntityType, entity)
+		if err != nil {
+			return false, err
+		}
+		// no short circuit, so that unsupported conditions are always reported
+		right, err := r.matchesFilter(expr.Right, restEntityType, entity)
+		if err != nil {
+			return false, err
+		}
+		if expr.Operator == "OR" {
+			return left || right, nil
+		}
+		return left && right, nil
+	}
+
+	propertyName, explicitType := expr.Property, ""
+	for _, suffix := range typeSuffixes {
+		if name, ok := strings.CutSuffix(expr.Property, "."+suffix); ok {
+			propertyName, explicitType = name, suffix
+			break
+		}
+	}
+
+	operator, value := expr.Operator, expr.Value
+	definition := filter.GetPropertyDefinitionForRestEntity(restEntityType, propertyName)
+
+	var actual any
+	switch definition.Location {
+	case filter.EntityTable:
+		actual = r.columnValue(entity, definition.Column)
+		if definition.Column == "name" && r.childEntity {
+			// names are stored as parentId:name, as in filter.QueryBuilder
+			if s, ok := value.(string); ok {
+				if operator == "=" {
+					operator, value = "LIKE", "%:"+s
+				} else if operator == "LIKE" && !strings.Contains(s, ":") && !strings.HasPrefix(s, "%") {
+					value = "%:" + s
+				}
+			}
+		}
+	case filter.PropertyTable:
+		actual = propertyValue(entity.Properties, definition.Column, explicitType, value)
+	case filter.Custom:
+		actual = propertyValue(entity.CustomProperties, definition.Column, explicitType, value)
+	default:
+		return false, fmt.Errorf("filter on %s is not supported by the in-memory repositories: %w", propertyName, api.ErrBadRequest)
+	}
+
+	return compare(operator, actual, value), nil
+}
+
+// columnValue returns the value of an entity table column, nil for NULL.
+func (r *repository[E, A]) columnValue(entity *models.BaseEntity[A], column string) any {
+	fields := r.fields(entity.Attributes)
+	var field **string
+	switch column {
+	case "id":
+		return int64(*entity.ID)
+	case "create_time_since_epoch":
+		return **fields.CreateTime
+	case "last_update_time_since_epoch":
+		return **fields.UpdateTime
+	case "name":
+		field = fields.Name
+	case "external_id":
+		field = fields.ExternalID
+	case "uri":
+		field = fields.URI
+	case "state", "last_known_state":
+		field = fields.State
+	}
+	if field == nil || *field == nil {
+		return nil
+	}
+	return **field
+}
+
+// propertyValue returns the value of a property compared with value, nil if it is not set. Without an explicit type,
+// integers are compared with both integer and double values.
+func propertyValue(properties *[]models.Properties, name string, explicitType string, value any) any {
+	if properties == nil {
+		return nil
+	}
+	for _, property := range *properties {
+		if property.Name != name {
+			continue
+		}
+
+		valueType := explicitType
+		if valueType == "" {
+			valueType = inferValueType(value)
+		}
+		switch {
+		case valueType == filter.IntValueType && property.IntValue != nil:
+			return int64(*property.IntValue)
+		case (valueType == filter.DoubleValueType || valueType == filter.IntValueType && explicitType == "") && property.DoubleValue != nil:
+			return *property.DoubleValue
+		case valueType == filter.BoolValueType && property.BoolValue != nil:
+			return *property.BoolValue
+		case valueType == filter.StringValueType && property.StringValue != nil:
+			return *property.StringValue
+		}
+		return nil
+	}
+	return nil
+}
+
+func inferValueType(value any) string {
+	switch v := value.(type) {
+	case int64:
+		return filter.IntValueType
+	case float64:
+		return filter.DoubleValueType
+	case bool:
+		return filter.BoolValueType
+	case []any:
+		if len(v) > 0 {
+			return inferValueType(v[0])
+		}
+	}
+	return filter.StringValueType
+}
+
+// compare evaluates actual operator value, comparisons with NULL are false.
+func compare(operator string, actual any, value any) bool {
+	if actual == nil {
+		return false
+	}
+
+	if operator == "IN" {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+		for _, v := range values {
+			if compare("=", actual, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch a := actual.(type) {
+	case string:
+		s := fmt.Sprint(value)
+		switch operator {
+		case "LIKE":
+			return like(s, a, false)
+		case "ILIKE":
+			return like(s, a, true)
+		}
+		return ordered(operator, strings.Compare(a, s))
+	case bool:
+		b, ok := value.(bool)
+		if !ok {
+			return false
+		}
+		switch operator {
+		case "=":
+			return a == b
+		case "!=":
+			return a != b
+		}
+		return false
+	default:
+		x, ok := number(actual)
+		y, ok2 := number(value)
+		if !ok || !ok2 {
+			return false
+		}
+		switch {
+		case x < y:
+			return ordered(operator, -1)
+		case x > y:
+			return ordered(operator, 1)
+		}
+		return ordered(operator, 0)
+	}
+}
+
+// ordered returns if a comparison result satisfies operator.
+func ordered(operator string, comparison int) bool {
+	switch operator {
+	case "=":
+		return comparison == 0
+	case "!=":
+		return comparison != 0
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	}
+	return false
+}
+
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// like matches s against a SQL LIKE pattern.
+func like(pattern string, s string, insensitive bool) bool {
+	return matchLike(pattern, s, insensitive, 0)
+}
+
+// matchLike matches s against a SQL LIKE pattern with an ESCAPE character, the characters following it are matched
+// literally.
+func matchLike(pattern string, s string, insensitive bool, escape rune) bool {
+	var expr strings.Builder
+	if insensitive {
+		expr.WriteString("(?is)")
+	} else {
+		expr.WriteString("(?s)")
+	}
+	expr.WriteString("^")
+	escaped := false
+	for _, c := range pattern {
+		switch {
+		case escaped:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+			escaped = false
+		case escape != 0 && c == escape:
+			escaped = true
+		case c == '%':
+			expr.WriteString(".*")
+		case c == '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String()).MatchString(s)
+}
+
+// matchesSearch checks if each term of a free-text search is a word of the name, description or a string custom
+// property of an entity, the terms matching different fields, as filter.FullTextScope does with the indexes of MySQL
+// and Postgres.
+func (r *repository[E, A]) matchesSearch(terms []string, entity *models.BaseEntity[A]) bool {
+	var values []string
+	if name := r.fields(entity.Attributes).Name; name != nil && *name != nil {
+		values = append(values, **name)
+	}
+	if entity.Properties != nil {
+		for _, property := range *entity.Properties {
+			if property.Name == filter.DescriptionProperty && property.StringValue != nil {
+				values = append(values, *property.StringValue)
+			}
+		}
+	}
+	if entity.CustomProperties != nil {
+		for _, property := range *entity.CustomProperties {
+			if property.StringValue != nil {
+				values = append(values, *property.StringValue)
+			}
+		}
+	}
+
+	var words []string
+	for _, value := range values {
+		words = append(words, filter.FullTextTerms(value)...)
+	}
+	for _, term := range terms {
+		if !slices.Contains(words, term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package inmemory
+
+import (
+	"slices"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/models"
+)
+
+// lineageKinds are the lineage kinds of the kinds of the store.
+var lineageKinds = [3]models.LineageKind{
+	contextKind:   models.LineageContext,
+	artifactKind:  models.LineageArtifact,
+	executionKind: models.LineageExecution,
+}
+
+type lineageRepository struct {
+	store *Store
+}
+
+func NewLineageRepository(store *Store) models.LineageRepository {
+	return &lineageRepository{store: store}
+}
+
+func (r *lineageRepository) GetLinks(ids models.LineageIDs) ([]models.LineageLink, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	contextIDs := ids[models.LineageContext]
+
+	links := []models.LineageLink{}
+	for k, kindLinks := range r.store.links {
+		kind := lineageKinds[k]
+		for contextID, linked := range kindLinks {
+			for id := range linked {
+				// the links of a context, and the parent contexts of the contexts and the contexts of the
+				// other entities
+				if slices.Contains(contextIDs, contextID) || slices.Contains(ids[kind], id) {
+					links = append(links, models.LineageLink{ContextID: contextID, Kind: kind, ID: id})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(links, func(a, b models.LineageLink) int {
+		if a.ContextID != b.ContextID {
+			return int(a.ContextID - b.ContextID)
+		}
+		return int(a.ID - b.ID)
+	})
+	return links, nil
+}
+
+func (r *lineageRepository) GetNodes(ids models.LineageIDs) ([]models.LineageNode, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	nodes := []models.LineageNode{}
+	for k, table := range r.store.tables {
+		kind := lineageKinds[k]
+		kindIDs := slices.Sorted(slices.Values(ids[kind]))
+		for _, id := range slices.Compact(kindIDs) {
+			if record, ok := table.records[id]; ok {
+				node := models.LineageNode{Kind: kind, ID: id, TypeID: r.store.types[record.typeName]}
+				if record.name != nil {
+					node.Name = apiutils.Of(*record.name)
+				}
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	return nodes, nil
+}
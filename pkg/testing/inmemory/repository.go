@@ -0,0 +1,487 @@
+package inmemory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/internal/db/dbutil"
+	"github.com/kubeflow/model-registry/internal/db/filter"
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/scopes"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"gorm.io/gorm"
+)
+
+// attributeFields points to the attributes of an entity the repositories manage, fields an entity type doesn't
+// have are nil.
+type attributeFields struct {
+	Name         **string
+	ExternalID   **string
+	URI          **string
+	State        **string
+	ArtifactType **string
+	CreateTime   **int64
+	UpdateTime   **int64
+}
+
+// repositoryConfig configures the in-memory repository of an entity type.
+type repositoryConfig[A any] struct {
+	store         *Store
+	kind          kind
+	typeName      string
+	entityName    string
+	notFoundError error
+	// childEntity is true for the entities which names are prefixed with the id of their parent
+	childEntity bool
+	// artifactType is the artifact type set in the attributes of artifacts
+	artifactType string
+	fields       func(attributes *A) attributeFields
+}
+
+// repository is the in-memory repository of the entities E with the attributes A, E is the entity interface
+// implemented by *models.BaseEntity[A].
+type repository[E any, A any] struct {
+	repositoryConfig[A]
+}
+
+func newRepository[E any, A any](config repositoryConfig[A]) *repository[E, A] {
+	return &repository[E, A]{repositoryConfig: config}
+}
+
+func (r *repository[E, A]) typeID() int32 {
+	return r.store.types[r.typeName]
+}
+
+func (r *repository[E, A]) table() *table {
+	return r.store.tables[r.kind]
+}
+
+// get returns the stored entity with the id, the store must be locked.
+func (r *repository[E, A]) get(id int32) (*models.BaseEntity[A], bool) {
+	rec, ok := r.table().records[id]
+	if !ok || rec.typeName != r.typeName {
+		return nil, false
+	}
+	return rec.entity.(*models.BaseEntity[A]), true
+}
+
+func (r *repository[E, A]) GetByID(id int32) (E, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entity, ok := r.get(id)
+	if !ok {
+		var zero E
+		return zero, fmt.Errorf("%w: %s %d", r.notFoundError, r.entityName, id)
+	}
+	return r.output(entity), nil
+}
+
+// GetByIDs returns the entities with the given ids ordered by id, ids not found are skipped.
+func (r *repository[E, A]) GetByIDs(ids []int32) ([]E, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+
+	entities := []E{}
+	for _, id := range slices.Compact(ids) {
+		if entity, ok := r.get(id); ok {
+			entities = append(entities, r.output(entity))
+		}
+	}
+	return entities, nil
+}
+
+// GetCustomPropertyByIDs returns the custom property named name of the entities with the given ids, ids not found or
+// without the property are skipped.
+func (r *repository[E, A]) GetCustomPropertyByIDs(ids []int32, name string) (map[int32]models.Properties, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	properties := map[int32]models.Properties{}
+	for _, id := range ids {
+		entity, ok := r.get(id)
+		if !ok {
+			continue
+		}
+		for _, property := range cloneProperties(entity.CustomProperties) {
+			if property.Name == name {
+				properties[id] = property
+			}
+		}
+	}
+	return properties, nil
+}
+
+// save stores an entity, new if it has no id, and links it to the parent context.
+func (r *repository[E, A]) save(entity models.Entity[A], parentResourceID *int32) (E, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.saveLocked(entity, parentResourceID)
+}
+
+// saveLocked is save for the callers holding the lock of the store.
+func (r *repository[E, A]) saveLocked(entity models.Entity[A], parentResourceID *int32) (E, error) {
+	var zero E
+	now := r.store.now()
+
+	var attributes A
+	if entity.GetAttributes() != nil {
+		attributes = *entity.GetAttributes()
+	}
+	fields := r.fields(&attributes)
+
+	saved := &models.BaseEntity[A]{
+		TypeID:     apiutils.Of(r.typeID()),
+		Attributes: &attributes,
+	}
+	properties := customized(cloneProperties(entity.GetProperties()), false)
+	customProperties := customized(cloneProperties(entity.GetCustomProperties()), true)
+
+	var id int32
+	if entity.GetID() != nil {
+		id = *entity.GetID()
+		stored, ok := r.get(id)
+		if !ok {
+			return zero, fmt.Errorf("%w: %s %d", r.notFoundError, r.entityName, id)
+		}
+
+		// attributes not set are left unchanged, as with an update of the non zero columns
+		merge(fields, r.fields(stored.Attributes))
+		*fields.CreateTime = *r.fields(stored.Attributes).CreateTime
+
+		properties = mergeProperties(*stored.Properties, properties)
+		if entity.GetCustomProperties() == nil {
+			customProperties = cloneProperties(stored.CustomProperties)
+		}
+	} else {
+		id = r.table().lastID + 1
+		*fields.CreateTime = apiutils.Of(now)
+	}
+	*fields.UpdateTime = apiutils.Of(now)
+
+	if r.table().conflicts(id, r.typeName, *fields.Name, *fields.ExternalID) {
+		return zero, fmt.Errorf("error saving %s: %w", r.entityName, gorm.ErrDuplicatedKey)
+	}
+
+	if entity.GetID() == nil {
+		r.table().lastID = id
+	}
+	saved.ID = &id
+	saved.Properties = &properties
+	saved.CustomProperties = &customProperties
+
+	r.table().records[id] = &record{
+		typeName:   r.typeName,
+		name:       *fields.Name,
+		externalID: *fields.ExternalID,
+		entity:     saved,
+		touched:    r.touched,
+	}
+	if parentResourceID != nil && r.store.links[r.kind].add(*parentResourceID, id) {
+		r.store.touchContext(*parentResourceID, now)
+	}
+
+	return r.output(saved), nil
+}
+
+// touched returns a copy of a stored entity with its last update time set to now.
+func (r *repository[E, A]) touched(entity any, now int64) any {
+	touched := *entity.(*models.BaseEntity[A])
+	attributes := *touched.Attributes
+	*r.fields(&attributes).UpdateTime = apiutils.Of(now)
+	touched.Attributes = &attributes
+	return &touched
+}
+
+// list returns a page of the entities matching match and the filter query of the pagination, evaluated for
+// restEntityType. Filter queries are ignored without an entity type, as for the list options of the database
+// repositories not implementing FilterApplier.
+func (r *repository[E, A]) list(pagination models.Pagination, restEntityType filter.RestEntityType, match func(id int32, entity *models.BaseEntity[A]) bool) (*models.ListWrapper[E], error) {
+	entities, err := r.matching(pagination, restEntityType, match)
+	if err != nil {
+		return nil, err
+	}
+
+	var page []E
+	var nextPageToken string
+	orderBy := pagination.GetOrderBy()
+	if propertyOrder, ok := scopes.ParseCustomPropertyOrder(orderBy); ok {
+		page, nextPageToken, err = paginateByCustomProperty(entities, pagination, propertyOrder, func(entity E) ([]models.Properties, int32) {
+			base := any(entity).(*models.BaseEntity[A])
+			return *base.CustomProperties, *base.ID
+		})
+	} else {
+		page, nextPageToken, err = paginate(entities, pagination, func(entity E) (int64, int32) {
+			return r.orderValue(any(entity).(*models.BaseEntity[A]), orderBy), *any(entity).(*models.BaseEntity[A]).ID
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListWrapper[E]{
+		Items:         page,
+		NextPageToken: nextPageToken,
+		PageSize:      pagination.GetPageSize(),
+		Size:          int32(len(page)),
+	}, nil
+}
+
+// yieldPage calls yield with the entities of a listed page, the in-memory lists are not read from a cursor.
+func yieldPage[E any](page *models.ListWrapper[E], err error, yield func(E) error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	for _, entity := range page.Items {
+		if err := yield(entity); err != nil {
+			return "", err
+		}
+	}
+	return page.NextPageToken, nil
+}
+
+// matching returns all the entities matching match, the filter query, the free-text search and the tags of the
+// pagination, in no order.
+func (r *repository[E, A]) matching(pagination models.Pagination, restEntityType filter.RestEntityType, match func(id int32, entity *models.BaseEntity[A]) bool) ([]E, error) {
+	var expr *filter.FilterExpression
+	if filterQuery := pagination.GetFilterQuery(); filterQuery != "" && restEntityType != "" {
+		var err error
+		expr, err = filter.Parse(filterQuery)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", dbutil.EnhanceFilterQueryError(err, filterQuery), api.ErrBadRequest)
+		}
+	}
+
+	terms := filter.FullTextTerms(pagination.GetQuery())
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entities := []E{}
+	for id, rec := range r.table().records {
+		if rec.typeName != r.typeName {
+			continue
+		}
+		entity := rec.entity.(*models.BaseEntity[A])
+		if match != nil && !match(id, entity) {
+			continue
+		}
+		if expr != nil {
+			matches, err := r.matchesFilter(expr, restEntityType, entity)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+		}
+		if len(terms) != 0 && !r.matchesSearch(terms, entity) {
+			continue
+		}
+		if r.kind == contextKind && !r.store.tags.hasAll(id, pagination.GetTags()) {
+			continue
+		}
+		entities = append(entities, r.output(entity))
+	}
+	return entities, nil
+}
+
+// orderValue returns the value of the orderBy column of an entity, the id for unknown columns.
+func (r *repository[E, A]) orderValue(entity *models.BaseEntity[A], orderBy string) int64 {
+	fields := r.fields(entity.Attributes)
+	switch orderBy {
+	case "CREATE_TIME":
+		return **fields.CreateTime
+	case "LAST_UPDATE_TIME":
+		return **fields.UpdateTime
+	default:
+		return int64(*entity.ID)
+	}
+}
+
+// paginate sorts items on their (orderBy value, id) key and returns the page after the next page token of the
+// pagination, with the token of the following page if any.
+func paginate[T any](items []T, pagination models.Pagination, key func(T) (int64, int32)) ([]T, string, error) {
+	compare := func(value int64, id int32, otherValue int64, otherID int32) int {
+		order := cmp.Or(cmp.Compare(value, otherValue), cmp.Compare(id, otherID))
+		if pagination.GetSortOrder() == models.SortOrderDesc {
+			return -order
+		}
+		return order
+	}
+
+	if token := pagination.GetNextPageToken(); token != "" {
+		cursor, err := scopes.DecodeCursor(token)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid next page token: %v: %w", err, api.ErrBadRequest)
+		}
+		cursorValue, err := strconv.ParseInt(cursor.Value, 10, 64)
+		if err != nil {
+			cursorValue = int64(cursor.ID)
+		}
+		items = slices.DeleteFunc(items, func(item T) bool {
+			value, id := key(item)
+			return compare(value, id, cursorValue, cursor.ID) <= 0
+		})
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		aValue, aID := key(a)
+		bValue, bID := key(b)
+		return compare(aValue, aID, bValue, bID)
+	})
+
+	pageSize := int(pagination.GetPageSize())
+	if pageSize <= 0 || len(items) <= pageSize {
+		return items, "", nil
+	}
+
+	items = items[:pageSize]
+	value, id := key(items[pageSize-1])
+	return items, scopes.CreateNextPageToken(id, strconv.FormatInt(value, 10)), nil
+}
+
+// paginateByCustomProperty is paginate for the orderings by a custom property, key returning the custom properties
+// and id of an item. The items without the property are listed last, in both sort orders, as by the database.
+func paginateByCustomProperty[T any](items []T, pagination models.Pagination, order scopes.CustomPropertyOrder, key func(T) ([]models.Properties, int32)) ([]T, string, error) {
+	sortValue := func(item T) (string, int32) {
+		customProperties, id := key(item)
+		return order.SortValue(customProperties), id
+	}
+	compare := func(value string, id int32, otherValue string, otherID int32) int {
+		if (value == "") != (otherValue == "") {
+			return order.Compare(value, otherValue)
+		}
+		result := cmp.Or(order.Compare(value, otherValue), cmp.Compare(id, otherID))
+		if pagination.GetSortOrder() == models.SortOrderDesc {
+			return -result
+		}
+		return result
+	}
+
+	if token := pagination.GetNextPageToken(); token != "" {
+		cursor, err := scopes.DecodeCursor(token)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid next page token: %v: %w", err, api.ErrBadRequest)
+		}
+		items = slices.DeleteFunc(items, func(item T) bool {
+			value, id := sortValue(item)
+			return compare(value, id, cursor.Value, cursor.ID) <= 0
+		})
+	}
+
+	slices.SortFunc(items, func(a, b T) int {
+		aValue, aID := sortValue(a)
+		bValue, bID := sortValue(b)
+		return compare(aValue, aID, bValue, bID)
+	})
+
+	pageSize := int(pagination.GetPageSize())
+	if pageSize <= 0 || len(items) <= pageSize {
+		return items, "", nil
+	}
+
+	items = items[:pageSize]
+	value, id := sortValue(items[pageSize-1])
+	return items, scopes.CreateNextPageToken(id, value), nil
+}
+
+// output returns a copy of a stored entity, so that callers changing it don't change the store.
+func (r *repository[E, A]) output(entity *models.BaseEntity[A]) E {
+	attributes := *entity.Attributes
+	if r.artifactType != "" {
+		if fields := r.fields(&attributes); fields.ArtifactType != nil {
+			*fields.ArtifactType = &r.artifactType
+		}
+	}
+
+	properties := cloneProperties(entity.Properties)
+	customProperties := cloneProperties(entity.CustomProperties)
+
+	// E is the entity interface of the type, implemented by *models.BaseEntity[A]
+	return any(&models.BaseEntity[A]{
+		ID:               apiutils.Of(*entity.ID),
+		TypeID:           apiutils.Of(*entity.TypeID),
+		Attributes:       &attributes,
+		Properties:       &properties,
+		CustomProperties: &customProperties,
+	}).(E)
+}
+
+// merge sets the fields left nil to the stored ones.
+func merge(fields attributeFields, stored attributeFields) {
+	for _, pair := range [][2]**string{
+		{fields.Name, stored.Name},
+		{fields.ExternalID, stored.ExternalID},
+		{fields.URI, stored.URI},
+		{fields.State, stored.State},
+	} {
+		if pair[0] != nil && *pair[0] == nil {
+			*pair[0] = *pair[1]
+		}
+	}
+}
+
+// mergeProperties returns the stored properties updated with the saved ones, only the saved properties without a
+// value are removed.
+func mergeProperties(stored []models.Properties, saved []models.Properties) []models.Properties {
+	merged := cloneProperties(&stored)
+	for _, property := range saved {
+		i := slices.IndexFunc(merged, func(p models.Properties) bool { return p.Name == property.Name })
+		switch {
+		case !property.HasValue():
+			if i >= 0 {
+				merged = slices.Delete(merged, i, i+1)
+			}
+		case i < 0:
+			merged = append(merged, property)
+		default:
+			merged[i] = property
+		}
+	}
+	return merged
+}
+
+// customized sets if properties are custom properties, as they are listed apart from the other properties.
+func customized(properties []models.Properties, isCustom bool) []models.Properties {
+	for i := range properties {
+		properties[i].IsCustomProperty = isCustom
+	}
+	return properties
+}
+
+// cloneProperties returns a deep copy of properties, an empty slice for nil.
+func cloneProperties(properties *[]models.Properties) []models.Properties {
+	cloned := []models.Properties{}
+	if properties == nil {
+		return cloned
+	}
+	for _, property := range *properties {
+		cloned = append(cloned, models.Properties{
+			Name:             property.Name,
+			IsCustomProperty: property.IsCustomProperty,
+			IntValue:         clonePointer(property.IntValue),
+			DoubleValue:      clonePointer(property.DoubleValue),
+			StringValue:      clonePointer(property.StringValue),
+			BoolValue:        clonePointer(property.BoolValue),
+			ByteValue:        clonePointer(property.ByteValue),
+			ProtoValue:       clonePointer(property.ProtoValue),
+		})
+	}
+	return cloned
+}
+
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	cloned := *value
+	return &cloned
+}
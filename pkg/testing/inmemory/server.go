@@ -0,0 +1,55 @@
+package inmemory
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/core"
+	"github.com/kubeflow/model-registry/internal/server/middleware"
+)
+
+// NewModelRegistryService returns the core service over the in-memory repositories of the store.
+func NewModelRegistryService(store *Store) *core.ModelRegistryService {
+	return core.NewModelRegistryService(
+		NewArtifactRepository(store),
+		NewModelArtifactRepository(store),
+		NewDocArtifactRepository(store),
+		NewRegisteredModelRepository(store),
+		NewModelVersionRepository(store),
+		NewServingEnvironmentRepository(store),
+		NewInferenceServiceRepository(store),
+		NewServeModelRepository(store),
+		NewExperimentRepository(store),
+		NewExperimentRunRepository(store),
+		NewDataSetRepository(store),
+		NewMetricRepository(store),
+		NewParameterRepository(store),
+		NewMetricHistoryRepository(store),
+		NewPromotionRepository(store),
+		NewPromotionRunRepository(store),
+		NewConversionJobRepository(store),
+		NewDeploymentRepository(store),
+		NewABTestRepository(store),
+		NewLineageRepository(store),
+		NewMetricsTableRepository(store),
+		NewModelCardRepository(store),
+		NewStageTransitionRepository(store),
+		NewTagRepository(store),
+		NewAuditEventRepository(store),
+		store.TypeMap(),
+	)
+}
+
+// NewServer starts the model registry REST API, with the handler of the proxy server, over an empty in-memory
+// store. The server is closed when the test ends, the service is returned to seed or inspect the store directly.
+//
+// The server runs over the in-memory repositories rather than SQLite, which has no datastore connector in this tree.
+func NewServer(tb testing.TB) (*httptest.Server, *core.ModelRegistryService) {
+	tb.Helper()
+
+	service := NewModelRegistryService(NewStore())
+	server := httptest.NewServer(middleware.NewModelRegistryHandler(service))
+	tb.Cleanup(server.Close)
+
+	return server, service
+}
@@ -0,0 +1,243 @@
+package inmemory
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/kubeflow/model-registry/internal/apiutils"
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/kubeflow/model-registry/pkg/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registeredModelsPath = "/api/model_registry/v1alpha3/registered_models"
+
+func TestServerRegisteredModels(t *testing.T) {
+	server, _ := NewServer(t)
+
+	post := func(body string) *http.Response {
+		resp, err := http.Post(server.URL+registeredModelsPath, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	for i := range 3 {
+		resp := post(fmt.Sprintf(`{"name": "model-%d", "customProperties": {"team": {"metadataType": "MetadataStringValue", "string_value": "team-%d"}}}`, i, i%2))
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+
+	resp := post(`{"name": "model-0"}`)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, err := http.Get(server.URL + registeredModelsPath + "/999")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + registeredModelsPath + "?pageSize=2&filterQuery=team%3D%27team-0%27")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list openapi.RegisteredModelList
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, "model-0", list.Items[0].Name)
+	assert.Equal(t, "model-2", list.Items[1].Name)
+}
+
+func TestServicePagination(t *testing.T) {
+	service := NewModelRegistryService(NewStore())
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "model"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+
+	for i := range 5 {
+		_, err := service.UpsertModelVersionArtifact(&openapi.Artifact{
+			ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of(fmt.Sprintf("artifact-%d", i)), Uri: apiutils.Of("s3://bucket/model")},
+		}, *version.Id)
+		require.NoError(t, err)
+	}
+	_, err = service.UpsertModelVersionArtifact(&openapi.Artifact{
+		DocArtifact: &openapi.DocArtifact{Name: apiutils.Of("readme")},
+	}, *version.Id)
+	require.NoError(t, err)
+
+	var names []string
+	listOptions := api.ListOptions{PageSize: apiutils.Of(int32(2)), SortOrder: apiutils.Of("DESC")}
+	for {
+		page, err := service.GetArtifacts("", listOptions, version.Id)
+		require.NoError(t, err)
+		for _, artifact := range page.Items {
+			if artifact.ModelArtifact != nil {
+				names = append(names, *artifact.ModelArtifact.Name)
+			} else {
+				names = append(names, *artifact.DocArtifact.Name)
+			}
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+	assert.Equal(t, []string{"readme", "artifact-4", "artifact-3", "artifact-2", "artifact-1", "artifact-0"}, names)
+
+	filtered, err := service.GetArtifacts("", api.ListOptions{FilterQuery: apiutils.Of("name = 'artifact-2' OR name = 'readme'")}, version.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), filtered.Size)
+
+	_, err = service.GetRegisteredModels(api.ListOptions{FilterQuery: apiutils.Of("name =")})
+	assert.ErrorIs(t, err, api.ErrBadRequest)
+
+	built, err := service.GetArtifacts("", api.NewModelArtifactListOptions().WithName("artifact-3").WithUri("s3://bucket/model").Build(), version.Id)
+	require.NoError(t, err)
+	require.Len(t, built.Items, 1)
+	assert.Equal(t, "artifact-3", *built.Items[0].ModelArtifact.Name)
+}
+
+func TestServiceCustomPropertyOrdering(t *testing.T) {
+	service := NewModelRegistryService(NewStore())
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "model"})
+	require.NoError(t, err)
+	accuracies := map[string]openapi.MetadataValue{
+		"v1": openapi.MetadataDoubleValueAsMetadataValue(openapi.NewMetadataDoubleValue(0.8, "MetadataDoubleValue")),
+		"v2": openapi.MetadataIntValueAsMetadataValue(openapi.NewMetadataIntValue("1", "MetadataIntValue")),
+		"v3": openapi.MetadataDoubleValueAsMetadataValue(openapi.NewMetadataDoubleValue(0.95, "MetadataDoubleValue")),
+	}
+	for _, name := range []string{"v1", "v2", "v3", "v4"} {
+		customProperties := map[string]openapi.MetadataValue{}
+		if accuracy, ok := accuracies[name]; ok {
+			customProperties["accuracy"] = accuracy
+		}
+		_, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: name, CustomProperties: customProperties}, model.Id)
+		require.NoError(t, err)
+	}
+
+	var names []string
+	listOptions := api.ListOptions{PageSize: apiutils.Of(int32(1)), OrderBy: apiutils.Of("customProperties.accuracy"), SortOrder: apiutils.Of("DESC")}
+	for {
+		page, err := service.GetModelVersions(listOptions, model.Id)
+		require.NoError(t, err)
+		for _, version := range page.Items {
+			names = append(names, version.Name)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		listOptions.NextPageToken = &page.NextPageToken
+	}
+	// the versions without accuracy are listed last
+	assert.Equal(t, []string{"v2", "v3", "v1", "v4"}, names)
+}
+
+func TestServerFullTextSearch(t *testing.T) {
+	server, service := NewServer(t)
+
+	_, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "fraud-detector", Description: apiutils.Of("Scores card payments")})
+	require.NoError(t, err)
+	_, err = service.UpsertRegisteredModel(&openapi.RegisteredModel{
+		Name: "churn",
+		CustomProperties: map[string]openapi.MetadataValue{
+			"team": {MetadataStringValue: &openapi.MetadataStringValue{StringValue: "Payments risk", MetadataType: "MetadataStringValue"}},
+		},
+	})
+	require.NoError(t, err)
+	_, err = service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "forecast"})
+	require.NoError(t, err)
+
+	search := func(q string) []string {
+		resp, err := http.Get(server.URL + registeredModelsPath + "?q=" + url.QueryEscape(q))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var models openapi.RegisteredModelList
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&models))
+		names := []string{}
+		for _, model := range models.Items {
+			names = append(names, model.Name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	assert.Equal(t, []string{"churn", "fraud-detector"}, search("payments"))
+	assert.Equal(t, []string{"fraud-detector"}, search("FRAUD"))
+	assert.Equal(t, []string{"churn"}, search("payments risk"))
+	assert.Empty(t, search("payments forecast"))
+	// the terms can match different fields, and match whole words as MySQL and Postgres do
+	assert.Equal(t, []string{"fraud-detector"}, search("fraud payments"))
+	assert.Equal(t, []string{"churn"}, search("churn risk"))
+	assert.Empty(t, search("payment"))
+	assert.Len(t, search(""), 3)
+}
+
+func TestServiceUpdatesParentContexts(t *testing.T) {
+	store := NewStore()
+	var clock int64
+	store.now = func() int64 {
+		clock++
+		return clock
+	}
+	service := NewModelRegistryService(store)
+
+	model, err := service.UpsertRegisteredModel(&openapi.RegisteredModel{Name: "model"})
+	require.NoError(t, err)
+	version, err := service.UpsertModelVersion(&openapi.ModelVersion{Name: "v1"}, model.Id)
+	require.NoError(t, err)
+	lastUpdate := func(get func(string) (string, error), id string) int64 {
+		updated, err := get(id)
+		require.NoError(t, err)
+		parsed, err := strconv.ParseInt(updated, 10, 64)
+		require.NoError(t, err)
+		return parsed
+	}
+	modelUpdate := func(id string) (string, error) {
+		m, err := service.GetRegisteredModelById(id)
+		return m.GetLastUpdateTimeSinceEpoch(), err
+	}
+	versionUpdate := func(id string) (string, error) {
+		v, err := service.GetModelVersionById(id)
+		return v.GetLastUpdateTimeSinceEpoch(), err
+	}
+
+	// a new model version updates its registered model, a new artifact its model version
+	assert.Equal(t, version.GetLastUpdateTimeSinceEpoch(), strconv.FormatInt(lastUpdate(modelUpdate, *model.Id), 10))
+
+	artifact, err := service.UpsertModelVersionArtifact(&openapi.Artifact{
+		ModelArtifact: &openapi.ModelArtifact{Name: apiutils.Of("model"), Uri: apiutils.Of("s3://bucket/model")},
+	}, *version.Id)
+	require.NoError(t, err)
+	assert.Equal(t, artifact.ModelArtifact.GetLastUpdateTimeSinceEpoch(), strconv.FormatInt(lastUpdate(versionUpdate, *version.Id), 10))
+
+	// updating the artifact doesn't change the relationship
+	before := lastUpdate(versionUpdate, *version.Id)
+	artifact.ModelArtifact.Description = apiutils.Of("updated")
+	_, err = service.UpsertModelVersionArtifact(artifact, *version.Id)
+	require.NoError(t, err)
+	assert.Equal(t, before, lastUpdate(versionUpdate, *version.Id))
+
+	// changing the tags of an entity updates it, setting the same ones doesn't
+	_, err = service.SetEntityTags(api.TagEntityTypeModelVersion, *version.Id, []string{"prod"})
+	require.NoError(t, err)
+	tagged := lastUpdate(versionUpdate, *version.Id)
+	assert.Greater(t, tagged, before)
+
+	_, err = service.SetEntityTags(api.TagEntityTypeModelVersion, *version.Id, []string{"prod"})
+	require.NoError(t, err)
+	assert.Equal(t, tagged, lastUpdate(versionUpdate, *version.Id))
+
+	require.NoError(t, service.DeleteTag("prod"))
+	assert.Greater(t, lastUpdate(versionUpdate, *version.Id), tagged)
+}
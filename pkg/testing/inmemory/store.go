@@ -0,0 +1,166 @@
+// Package inmemory implements the model registry repositories in memory, to run the core service and the REST server
+// in tests without a database: the tests of the registry, and the integration tests of the downstream controllers and
+// SDK clients, which start the REST server with NewServer.
+//
+// The repositories behave like the database ones for what the core service relies on: ids are shared by all the
+// contexts, artifacts and executions, names are unique per type and external ids per entity kind, duplicates are
+// reported with gorm.ErrDuplicatedKey, and entities are listed with the same list options, filter queries and page
+// tokens. Filter queries on the properties of related entities are not supported, and LIKE is case sensitive.
+package inmemory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+)
+
+// kind is the MLMD kind of an entity, each kind has its own id sequence and relationship with contexts.
+type kind int
+
+const (
+	contextKind kind = iota
+	artifactKind
+	executionKind
+)
+
+// Store holds the entities of a set of in-memory repositories, it is safe for concurrent use.
+type Store struct {
+	mu    sync.RWMutex
+	types map[string]int32
+
+	tables [3]*table
+	// links of each kind map a context id to the ids of its child contexts, attributed artifacts and associated
+	// executions
+	links [3]links
+	tags  tags
+
+	auditEvents auditEvents
+
+	now func() int64
+}
+
+// NewStore returns an empty store with the types of the model registry datastore spec.
+func NewStore() *Store {
+	names := service.DatastoreSpec().AllNames()
+	slices.Sort(names)
+
+	types := make(map[string]int32, len(names))
+	for i, name := range names {
+		types[name] = int32(i + 1)
+	}
+
+	s := &Store{
+		types: types,
+		tags:  tags{byName: map[string]*models.Tag{}, contexts: map[int32]map[string]bool{}},
+		now:   func() int64 { return time.Now().UnixMilli() },
+	}
+	for k := range s.tables {
+		s.tables[k] = &table{records: map[int32]*record{}}
+		s.links[k] = links{}
+	}
+	return s
+}
+
+// TypeMap returns the type ids by type name, as the datastore connector does.
+func (s *Store) TypeMap() map[string]int32 {
+	types := make(map[string]int32, len(s.types))
+	for name, id := range s.types {
+		types[name] = id
+	}
+	return types
+}
+
+// transaction runs fn holding the lock of the store, restoring the entities and links it saved if it fails as
+// the database repositories roll their transactions back.
+func (s *Store) transaction(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tables [3]table
+	var saved [3]links
+	for k := range s.tables {
+		tables[k] = table{lastID: s.tables[k].lastID, records: maps.Clone(s.tables[k].records)}
+		saved[k] = links{}
+		for contextID, ids := range s.links[k] {
+			saved[k][contextID] = maps.Clone(ids)
+		}
+	}
+
+	if err := fn(); err != nil {
+		for k := range s.tables {
+			*s.tables[k] = tables[k]
+			s.links[k] = saved[k]
+		}
+		return err
+	}
+
+	return nil
+}
+
+// table holds the entities of a kind.
+type table struct {
+	lastID  int32
+	records map[int32]*record
+}
+
+// record is a stored entity, with the columns the unique keys are checked on.
+type record struct {
+	typeName   string
+	name       *string
+	externalID *string
+	// entity is the *models.BaseEntity of the type, never shared with the callers
+	entity any
+	// touched returns a copy of the entity with its last update time set to now
+	touched func(entity any, now int64) any
+}
+
+// touchContext sets the last update time of a context to now, as the database repositories do for the changes of its
+// relationships and tags, the caller holds the lock of the store.
+func (s *Store) touchContext(id int32, now int64) {
+	stored, ok := s.tables[contextKind].records[id]
+	if !ok {
+		return
+	}
+	touched := *stored
+	touched.entity = stored.touched(stored.entity, now)
+	s.tables[contextKind].records[id] = &touched
+}
+
+// conflicts checks if a record other than id has the same name for the type, or the same external id.
+func (t *table) conflicts(id int32, typeName string, name *string, externalID *string) bool {
+	for otherID, other := range t.records {
+		if otherID == id {
+			continue
+		}
+		if name != nil && other.name != nil && other.typeName == typeName && *other.name == *name {
+			return true
+		}
+		if externalID != nil && other.externalID != nil && *other.externalID == *externalID {
+			return true
+		}
+	}
+	return false
+}
+
+// links are the relationships between a context and the ids of other entities.
+type links map[int32]map[int32]bool
+
+// add links the id to the context, reporting whether the link is new.
+func (l links) add(contextID int32, id int32) bool {
+	if l[contextID] == nil {
+		l[contextID] = map[int32]bool{}
+	}
+	if l[contextID][id] {
+		return false
+	}
+	l[contextID][id] = true
+	return true
+}
+
+func (l links) has(contextID int32, id int32) bool {
+	return l[contextID][id]
+}
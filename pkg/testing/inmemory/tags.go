@@ -0,0 +1,174 @@
+package inmemory
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kubeflow/model-registry/internal/db/models"
+	"github.com/kubeflow/model-registry/internal/db/service"
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+// tags are the tags of the store, with the tags of each context.
+type tags struct {
+	lastID int32
+	byName map[string]*models.Tag
+	// contexts maps a context id to the names of its tags
+	contexts map[int32]map[string]bool
+}
+
+// hasAll checks if the context has all the tags with the names.
+func (t *tags) hasAll(contextID int32, names []string) bool {
+	for _, name := range names {
+		if !t.contexts[contextID][name] {
+			return false
+		}
+	}
+	return true
+}
+
+// count returns the number of contexts with the tag with the name.
+func (t *tags) count(name string) int32 {
+	count := int32(0)
+	for _, names := range t.contexts {
+		if names[name] {
+			count++
+		}
+	}
+	return count
+}
+
+type tagRepository struct {
+	store *Store
+}
+
+func NewTagRepository(store *Store) models.TagRepository {
+	return &tagRepository{store: store}
+}
+
+func (r *tagRepository) GetByName(name string) (models.Tag, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tag, ok := r.store.tags.byName[name]
+	if !ok {
+		return models.Tag{}, fmt.Errorf("%w: %s: %w", service.ErrTagNotFound, name, api.ErrNotFound)
+	}
+	return r.output(tag), nil
+}
+
+func (r *tagRepository) List(listOptions models.TagListOptions) (*models.ListWrapper[models.Tag], error) {
+	r.store.mu.RLock()
+	items := []models.Tag{}
+	for _, tag := range r.store.tags.byName {
+		items = append(items, r.output(tag))
+	}
+	r.store.mu.RUnlock()
+
+	items, nextPageToken, err := paginate(items, listOptions.Pagination, func(tag models.Tag) (int64, int32) {
+		switch listOptions.GetOrderBy() {
+		case "CREATE_TIME":
+			return *tag.CreateTimeSinceEpoch, *tag.ID
+		case "LAST_UPDATE_TIME":
+			return *tag.LastUpdateTimeSinceEpoch, *tag.ID
+		default:
+			return int64(*tag.ID), *tag.ID
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ListWrapper[models.Tag]{
+		Items:         items,
+		NextPageToken: nextPageToken,
+		PageSize:      listOptions.GetPageSize(),
+		Size:          int32(len(items)),
+	}, nil
+}
+
+func (r *tagRepository) Save(tag models.Tag) (models.Tag, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := r.store.now()
+	saved, ok := r.store.tags.byName[tag.Name]
+	if !ok {
+		saved = r.store.newTag(tag.Name, now)
+	}
+	saved.Color = tag.Color
+	saved.Description = tag.Description
+	saved.LastUpdateTimeSinceEpoch = &now
+
+	return r.output(saved), nil
+}
+
+func (r *tagRepository) Delete(name string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.tags.byName[name]; !ok {
+		return fmt.Errorf("%w: %s: %w", service.ErrTagNotFound, name, api.ErrNotFound)
+	}
+	delete(r.store.tags.byName, name)
+	now := r.store.now()
+	for contextID, names := range r.store.tags.contexts {
+		if names[name] {
+			delete(names, name)
+			r.store.touchContext(contextID, now)
+		}
+	}
+	return nil
+}
+
+func (r *tagRepository) GetContextTags(contextIDs []int32) (map[int32][]string, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	tagsByContext := make(map[int32][]string, len(contextIDs))
+	for _, contextID := range contextIDs {
+		if names := r.store.tags.contexts[contextID]; len(names) > 0 {
+			tagsByContext[contextID] = slices.Sorted(maps.Keys(names))
+		}
+	}
+	return tagsByContext, nil
+}
+
+func (r *tagRepository) SetContextTags(contextID int32, names []string) ([]string, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	names = slices.Compact(slices.Sorted(slices.Values(names)))
+
+	now := r.store.now()
+	contextTags := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := r.store.tags.byName[name]; !ok {
+			r.store.newTag(name, now)
+		}
+		contextTags[name] = true
+	}
+	if !maps.Equal(r.store.tags.contexts[contextID], contextTags) {
+		r.store.touchContext(contextID, now)
+	}
+	r.store.tags.contexts[contextID] = contextTags
+
+	return names, nil
+}
+
+// output returns a copy of a stored tag with its count.
+func (r *tagRepository) output(tag *models.Tag) models.Tag {
+	output := *tag
+	output.Count = r.store.tags.count(tag.Name)
+	return output
+}
+
+// newTag stores a new tag with the name, the caller holds the lock of the store.
+func (s *Store) newTag(name string, now int64) *models.Tag {
+	s.tags.lastID++
+	id := s.tags.lastID
+	tag := &models.Tag{ID: &id, Name: name, CreateTimeSinceEpoch: &now, LastUpdateTimeSinceEpoch: &now}
+	s.tags.byName[name] = tag
+	return tag
+}
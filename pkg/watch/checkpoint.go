@@ -0,0 +1,73 @@
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Checkpointer persists the resource version a Consumer reached, to resume from it after a restart.
+type Checkpointer interface {
+	// Load returns the resource version saved, or an empty string without checkpoint.
+	Load(ctx context.Context) (string, error)
+	Save(ctx context.Context, resourceVersion string) error
+}
+
+// FileCheckpointer saves the checkpoints in a file, replaced atomically so that a crash never leaves it partial.
+type FileCheckpointer struct {
+	Path string
+}
+
+func (f *FileCheckpointer) Load(_ context.Context) (string, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (f *FileCheckpointer) Save(_ context.Context, resourceVersion string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(resourceVersion + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.Path)
+}
+
+// MemoryCheckpointer keeps the checkpoints in memory, for consumers which rebuild their state on restart.
+type MemoryCheckpointer struct {
+	mu              sync.Mutex
+	resourceVersion string
+}
+
+func (m *MemoryCheckpointer) Load(_ context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resourceVersion, nil
+}
+
+func (m *MemoryCheckpointer) Save(_ context.Context, resourceVersion string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resourceVersion = resourceVersion
+	return nil
+}
@@ -0,0 +1,262 @@
+// Package watch consumes the changes of the entities of a model registry, as returned by its watch endpoint, for
+// indexers and replicators. The consumer persists the resource version it reached with a Checkpointer and delivers
+// each change at least once to a Handler: a change is delivered again after a restart or a failure until all the
+// changes of its list are handled and the resource version after them is saved.
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+)
+
+const (
+	// WatchPath is the path of the watch endpoint of the model registry REST API.
+	WatchPath = "/api/model_registry/v1alpha3/watch"
+
+	// DefaultTimeoutSeconds is how long each watch request waits for changes.
+	DefaultTimeoutSeconds = 30
+	// DefaultMinBackoff and DefaultMaxBackoff bound the delays between the retries of failed requests and handlers.
+	DefaultMinBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff = 30 * time.Second
+)
+
+// Handler handles the changes consumed, a change it fails to handle is retried until it succeeds. Changes may be
+// handled more than once, handlers must be idempotent, e.g. upserting the object of the change by id.
+type Handler interface {
+	Handle(ctx context.Context, change api.Change) error
+}
+
+// HandlerFunc is a Handler function.
+type HandlerFunc func(ctx context.Context, change api.Change) error
+
+func (f HandlerFunc) Handle(ctx context.Context, change api.Change) error {
+	return f(ctx, change)
+}
+
+// Config configures a Consumer.
+type Config struct {
+	// BaseURL is the url of the model registry, e.g. http://model-registry:8080.
+	BaseURL string
+	// Token is the bearer token of the requests, if the model registry requires one.
+	Token string
+	// EntityTypes are the entity types to consume the changes of, all the api.WatchEntityTypes if empty.
+	EntityTypes []string
+	Handler     Handler
+	Checkpoints Checkpointer
+	// FromBeginning consumes all the entities when there is no checkpoint yet, instead of the changes from now on.
+	FromBeginning bool
+	// TimeoutSeconds is how long each watch request waits for changes, DefaultTimeoutSeconds if 0.
+	TimeoutSeconds int
+	// MinBackoff and MaxBackoff bound the delays between retries, DefaultMinBackoff and DefaultMaxBackoff if 0.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Client sends the requests, a client without timeout if nil: the requests are bounded by TimeoutSeconds.
+	Client *http.Client
+}
+
+// Consumer delivers the changes of a model registry to a handler at least once, resuming from its checkpoints.
+type Consumer struct {
+	config  Config
+	watch   *url.URL
+	backoff backoff
+}
+
+// requestError is the error of a watch request the registry answered with a client error, which is not retried.
+type requestError struct {
+	status  string
+	message string
+}
+
+func (e *requestError) Error() string {
+	return fmt.Sprintf("watch responded %s: %s", e.status, e.message)
+}
+
+// NewConsumer returns the Consumer of config, the handler and checkpointer are required.
+func NewConsumer(config Config) (*Consumer, error) {
+	if config.Handler == nil {
+		return nil, errors.New("a handler is required to consume changes")
+	}
+	if config.Checkpoints == nil {
+		return nil, errors.New("a checkpointer is required to consume changes")
+	}
+
+	base, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url %q, the scheme must be http or https", config.BaseURL)
+	}
+
+	if config.TimeoutSeconds == 0 {
+		config.TimeoutSeconds = DefaultTimeoutSeconds
+	}
+	if config.MinBackoff == 0 {
+		config.MinBackoff = DefaultMinBackoff
+	}
+	if config.MaxBackoff == 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{}
+	}
+
+	return &Consumer{
+		config:  config,
+		watch:   base.JoinPath(WatchPath),
+		backoff: backoff{min: config.MinBackoff, max: config.MaxBackoff},
+	}, nil
+}
+
+// Run consumes the changes until ctx is done, returning its error, or until the registry rejects the watch, e.g.
+// for an unknown entity type or a missing scope. Failed requests, handlers and checkpoints are retried with an
+// exponential backoff.
+func (c *Consumer) Run(ctx context.Context) error {
+	resourceVersion, err := retry(ctx, &c.backoff, func() (string, error) {
+		return c.start(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		changes, err := retry(ctx, &c.backoff, func() (*api.ChangeList, error) {
+			return c.get(ctx, resourceVersion)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, change := range changes.Items {
+			if _, err := retry(ctx, &c.backoff, func() (struct{}, error) {
+				return struct{}{}, c.config.Handler.Handle(ctx, change)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if changes.ResourceVersion == resourceVersion {
+			continue
+		}
+		if _, err := retry(ctx, &c.backoff, func() (struct{}, error) {
+			return struct{}{}, c.config.Checkpoints.Save(ctx, changes.ResourceVersion)
+		}); err != nil {
+			return err
+		}
+		resourceVersion = changes.ResourceVersion
+	}
+}
+
+// start returns the resource version to consume from: the checkpoint, or 0 for FromBeginning without a checkpoint.
+// Otherwise the current resource version of the registry is saved as the first checkpoint, so that a restart before
+// the first changes doesn't skip them.
+func (c *Consumer) start(ctx context.Context) (string, error) {
+	resourceVersion, err := c.config.Checkpoints.Load(ctx)
+	if err != nil || resourceVersion != "" {
+		return resourceVersion, err
+	}
+
+	if c.config.FromBeginning {
+		return "0", nil
+	}
+
+	current, err := c.get(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	return current.ResourceVersion, c.config.Checkpoints.Save(ctx, current.ResourceVersion)
+}
+
+// get returns the changes after resourceVersion, or the current resource version without one.
+func (c *Consumer) get(ctx context.Context, resourceVersion string) (*api.ChangeList, error) {
+	query := url.Values{}
+	if len(c.config.EntityTypes) > 0 {
+		query.Set("entityTypes", strings.Join(c.config.EntityTypes, ","))
+	}
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+		query.Set("timeoutSeconds", strconv.Itoa(c.config.TimeoutSeconds))
+	}
+	watch := *c.watch
+	watch.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, watch.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		err := &requestError{status: resp.Status, message: strings.TrimSpace(string(message))}
+		// timeouts, rate limits and server errors are transient, other client errors are not
+		if resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, errors.New(err.Error())
+		}
+		return nil, err
+	}
+
+	var changes api.ChangeList
+	if err := json.NewDecoder(resp.Body).Decode(&changes); err != nil {
+		return nil, fmt.Errorf("error decoding changes: %w", err)
+	}
+	return &changes, nil
+}
+
+// retry calls fn until it succeeds, fails with a request error or ctx is done, waiting with the backoff between
+// attempts.
+func retry[T any](ctx context.Context, b *backoff, fn func() (T, error)) (T, error) {
+	for {
+		result, err := fn()
+		var rejected *requestError
+		if err == nil || errors.As(err, &rejected) {
+			if err == nil {
+				b.reset()
+			}
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		case <-time.After(b.next()):
+		}
+	}
+}
+
+// backoff is an exponential backoff with jitter, doubled after each failure and reset after a success.
+type backoff struct {
+	min, max time.Duration
+	delay    time.Duration
+}
+
+func (b *backoff) next() time.Duration {
+	b.delay = min(max(b.delay*2, b.min), b.max)
+	// jitter the delay by ±50% so that the consumers which failed together don't retry in lockstep
+	return rand.N(b.delay) + b.delay/2
+}
+
+func (b *backoff) reset() {
+	b.delay = 0
+}
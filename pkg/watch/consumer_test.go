@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kubeflow/model-registry/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWatch serves the changes after the resource version of each request, failing the first request of each
+// resource version with a server error.
+type fakeWatch struct {
+	mu       sync.Mutex
+	changes  []api.Change
+	failed   map[string]bool
+	requests []string
+}
+
+func (f *fakeWatch) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resourceVersion := r.URL.Query().Get("resourceVersion")
+	f.requests = append(f.requests, resourceVersion)
+	if !f.failed[resourceVersion] {
+		f.failed[resourceVersion] = true
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	list := api.ChangeList{Items: []api.Change{}, ResourceVersion: resourceVersion}
+	if resourceVersion == "" {
+		list.ResourceVersion = "1"
+	}
+	for _, change := range f.changes {
+		if resourceVersion != "" && change.ResourceVersion > resourceVersion {
+			list.Items = append(list.Items, change)
+			list.ResourceVersion = change.ResourceVersion
+		}
+	}
+	list.Size = int32(len(list.Items))
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func newTestConsumer(t *testing.T, url string, handler Handler, checkpoints Checkpointer) *Consumer {
+	consumer, err := NewConsumer(Config{
+		BaseURL:     url,
+		Handler:     handler,
+		Checkpoints: checkpoints,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	return consumer
+}
+
+func TestConsumerDeliversAtLeastOnce(t *testing.T) {
+	fake := &fakeWatch{
+		failed: map[string]bool{},
+		changes: []api.Change{
+			{Type: api.ChangeAdded, EntityType: "RegisteredModel", Id: "1", ResourceVersion: "2"},
+			{Type: api.ChangeAdded, EntityType: "ModelVersion", Id: "2", ResourceVersion: "3"},
+		},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	checkpoints := &FileCheckpointer{Path: filepath.Join(t.TempDir(), "checkpoint")}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var handled []string
+	failures := 1
+	consumer := newTestConsumer(t, server.URL, HandlerFunc(func(_ context.Context, change api.Change) error {
+		handled = append(handled, change.Id)
+		if change.Id == "2" && failures > 0 {
+			failures--
+			return errors.New("index unavailable")
+		}
+		if change.Id == "2" {
+			// stopping before the list is handled
+			cancel()
+			return errors.New("stopped")
+		}
+		return nil
+	}), checkpoints)
+
+	// no checkpoint yet, consuming from the current resource version
+	err := consumer.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the failed change is delivered again, and the checkpoint is only saved after the whole list is handled
+	assert.Equal(t, []string{"1", "2", "2"}, handled)
+	saved, err := checkpoints.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1", saved)
+
+	// resuming from the checkpoint delivers the changes of the unsaved list again
+	handled = nil
+	fake.changes = append(fake.changes, api.Change{Type: api.ChangeModified, EntityType: "ModelVersion", Id: "3", ResourceVersion: "4"})
+	ctx, cancel = context.WithCancel(context.Background())
+	consumer = newTestConsumer(t, server.URL, HandlerFunc(func(_ context.Context, change api.Change) error {
+		handled = append(handled, change.Id)
+		return nil
+	}), checkpoints)
+	go func() {
+		for {
+			if saved, _ := checkpoints.Load(context.Background()); saved == "4" {
+				cancel()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	require.ErrorIs(t, consumer.Run(ctx), context.Canceled)
+	assert.Equal(t, []string{"1", "2", "3"}, handled)
+}
+
+func TestConsumerStopsOnRejectedWatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unsupported entity type", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	consumer := newTestConsumer(t, server.URL, HandlerFunc(func(context.Context, api.Change) error { return nil }), &MemoryCheckpointer{})
+
+	err := consumer.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400 Bad Request")
+}
+
+func TestNewConsumerValidatesConfig(t *testing.T) {
+	handler := HandlerFunc(func(context.Context, api.Change) error { return nil })
+
+	_, err := NewConsumer(Config{BaseURL: "http://localhost:8080", Checkpoints: &MemoryCheckpointer{}})
+	assert.Error(t, err)
+	_, err = NewConsumer(Config{BaseURL: "http://localhost:8080", Handler: handler})
+	assert.Error(t, err)
+	_, err = NewConsumer(Config{BaseURL: "localhost:8080", Handler: handler, Checkpoints: &MemoryCheckpointer{}})
+	assert.Error(t, err)
+}